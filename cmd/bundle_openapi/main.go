@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/openapi"
+)
+
+func main() {
+	source := flag.String("source", filepath.Join("docs", "src", "openapi.yaml"), "Root of the OpenAPI sources")
+	outDir := flag.String("out-dir", "docs", "Directory of the bundled specifications")
+	flag.Parse()
+
+	files, err := openapi.Files(*source)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to bundle the OpenAPI sources: %v\n", err)
+		os.Exit(1)
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(*outDir, name)
+		if err := os.WriteFile(path, files[name], 0o644); err != nil { // #nosec G306 -- public documentation
+			fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", path, err)
+			os.Exit(1)
+		}
+		fmt.Println("Wrote", path)
+	}
+}
@@ -4,23 +4,41 @@ import (
 	"context"
 	"errors"
 	"flag"
+	"fmt"
 	"log"
 	"log/slog"
+	"maps"
 
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/admission"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/configcheck"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/demodata"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/events"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/imagewarmup"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/jobstate"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/jobwatch"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/leader"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/localmode"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/metrics"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/mlflow"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/notifications"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/providercache"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/providerhealth"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/server"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/storage"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/validation"
 	"github.com/eval-hub/eval-hub/internal/logging"
 	"github.com/eval-hub/eval-hub/internal/otel"
+	"github.com/eval-hub/eval-hub/internal/postprocess"
+	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
 var (
@@ -34,15 +52,31 @@ var (
 	GitHash string
 )
 
+const (
+	echoAdapterFlag = "echo-adapter"
+	// permissionCheckTimeout bounds the start up check of the permissions of the runtime.
+	permissionCheckTimeout = 30 * time.Second
+)
+
 type Args struct {
-	ConfigDir string
-	LocalMode bool
+	ConfigDir            string
+	LocalMode            bool
+	DataDir              string
+	EchoAdapter          bool
+	ValidateConfig       bool
+	ValidateConfigFormat string
+	SeedDemoData         bool
 }
 
 func args() Args {
 	configDir := ""
 	dir := flag.String("configdir", configDir, "Directory to search for configuration files.")
 	local := flag.Bool("local", false, "Server operates in local mode or not.")
+	dataDir := flag.String("datadir", "", "Directory for local mode state such as the SQLite database (default ~/.evalhub).")
+	echoAdapter := flag.Bool(echoAdapterFlag, false, "Run the echo demo adapter for the job spec in $EVALHUB_JOB_SPEC_PATH and exit.")
+	validateConfig := flag.Bool("validate-config", false, "Validate the configuration and the database connectivity, print a summary and exit non-zero on errors.")
+	validateConfigFormat := flag.String("validate-config-format", "text", "Format of the -validate-config summary: text or json.")
+	seedDemoData := flag.Bool("seed-demo-data", false, "Seed the database with demo tenants, providers, collections and completed jobs.")
 	flag.Parse()
 	configDir = *dir
 	if configDir == "" {
@@ -50,14 +84,32 @@ func args() Args {
 	}
 
 	return Args{
-		ConfigDir: configDir,
-		LocalMode: *local,
+		ConfigDir:            configDir,
+		LocalMode:            *local,
+		DataDir:              *dataDir,
+		EchoAdapter:          *echoAdapter,
+		ValidateConfig:       *validateConfig,
+		ValidateConfigFormat: *validateConfigFormat,
+		SeedDemoData:         *seedDemoData,
 	}
 }
 
 func main() {
 	args := args()
 
+	// the echo demo provider runs this binary as its local adapter
+	if args.EchoAdapter {
+		if err := localmode.RunEchoAdapter(os.Getenv(localmode.EnvJobSpecPath), os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	// the operator runs this binary as an init container to check a configuration before rolling it out
+	if args.ValidateConfig {
+		os.Exit(validateConfig(args))
+	}
+
 	startUpFailed := func(conf *config.Config, err error, msg string, logger *slog.Logger) {
 		server.HandleStartupFailure(conf, args.LocalMode, err, msg, logger)
 		log.Fatal(err)
@@ -77,6 +129,20 @@ func main() {
 
 	serviceConfig.Service.LocalMode = args.LocalMode
 
+	// local mode keeps its state in an on-disk SQLite database unless a database is configured
+	localDatabasePath := ""
+	if args.LocalMode {
+		dataDir := args.DataDir
+		if dataDir == "" {
+			if dataDir, err = localmode.DefaultDataDir(); err != nil {
+				startUpFailed(serviceConfig, err, "Failed to resolve the local mode data directory", logger)
+			}
+		}
+		if localDatabasePath, err = localmode.ProvisionDatabase(logger, serviceConfig, dataDir); err != nil {
+			startUpFailed(serviceConfig, err, "Failed to provision the local mode database", logger)
+		}
+	}
+
 	// set up the validator
 	validate, err := validation.NewValidator()
 	if err != nil {
@@ -90,6 +156,19 @@ func main() {
 		startUpFailed(serviceConfig, err, "Failed to create provider configs", logger)
 	}
 
+	// register the bundled echo demo provider in local mode
+	builtinProviders := map[string]api.ProviderResource{}
+	if args.LocalMode {
+		executable, err := os.Executable()
+		if err != nil {
+			startUpFailed(serviceConfig, err, "Failed to resolve the service executable", logger)
+		}
+		if _, exists := providerConfigs[localmode.EchoProviderID]; !exists {
+			builtinProviders[localmode.EchoProviderID] = localmode.EchoProvider(localmode.EchoCommand(executable, echoAdapterFlag))
+			maps.Copy(providerConfigs, builtinProviders)
+		}
+	}
+
 	// set up the collection configs
 	collectionConfigs, err := config.LoadCollectionConfigs(logger, validate, args.ConfigDir)
 	if err != nil {
@@ -97,6 +176,12 @@ func main() {
 		startUpFailed(serviceConfig, err, "Failed to create collection configs", logger)
 	}
 
+	// set up the message catalogs used to localize messages per Accept-Language
+	messageCatalogs, err := config.LoadMessageCatalogs(logger, args.ConfigDir)
+	if err != nil {
+		startUpFailed(serviceConfig, err, "Failed to load message catalogs", logger)
+	}
+
 	// setup OTEL before storage so DB metrics can register against the MeterProvider
 	var otelShutdown func(context.Context) error
 	if serviceConfig.IsOTELEnabled() {
@@ -117,6 +202,9 @@ func main() {
 		if err := metrics.Init(); err != nil {
 			startUpFailed(serviceConfig, err, "Failed to initialize OTEL metrics", logger)
 		}
+		jobstate.OnTransition(func(ctx context.Context, _ string, from, to api.OverallState) {
+			metrics.RecordEvaluationJobTransition(ctx, from, to)
+		})
 	}
 
 	// set up the storage
@@ -132,8 +220,55 @@ func main() {
 		// we do this as no point trying to continue
 		startUpFailed(serviceConfig, err, "Failed to create storage", logger)
 	}
+	// the demo jobs are seeded before the event consumers are attached, they are history
+	if args.SeedDemoData {
+		if err := demodata.Seed(logger, storage, time.Now()); err != nil {
+			startUpFailed(serviceConfig, err, "Failed to seed the demo data", logger)
+		}
+	}
+	// normalize and post-process the metrics of completed benchmarks before they are stored
+	postProcessors, err := postprocess.NewChain(logger, serviceConfig.PostProcessing)
+	if err != nil {
+		startUpFailed(serviceConfig, err, "Failed to configure results post-processors", logger)
+	}
+	storage = postprocess.NewStorage(storage, postProcessors, logger)
+	// publish job events from the storage layer to the side-effect consumers
+	eventBus, err := events.NewBus(logger, serviceConfig.Events)
+	if err != nil {
+		startUpFailed(serviceConfig, err, "Failed to create event bus", logger)
+	}
+	storage = events.NewStorage(storage, eventBus, logger)
+	// serve the provider listings from memory, the writes of this replica clear the cache
+	if serviceConfig.ProviderCache.IsEnabled() {
+		storage = providercache.NewStorage(storage, serviceConfig.ProviderCache.EffectiveTTL())
+	}
+
+	// stream job updates to clients of the watch API
+	jobUpdates := jobwatch.NewHub()
+	eventBus.Subscribe(jobUpdates.HandleEvent)
+
+	// send a summary of the jobs that reach a terminal state to their notifiers
+	notifier, err := notifications.NewDispatcher(logger, serviceConfig.Notifications, serviceConfig.Proxy)
+	if err != nil {
+		startUpFailed(serviceConfig, err, "Failed to configure notifications", logger)
+	}
+	if notifier != nil {
+		eventBus.Subscribe(notifier.HandleEvent)
+	}
+
+	// the callback tokens are signed with a secret shared by the replicas, without one only
+	// this replica can verify the tokens of the jobs it starts
+	if serviceConfig.CallbackAuth.IsEnabled() && serviceConfig.CallbackAuth.Secret == "" {
+		secret, err := callbackauth.GenerateSecret()
+		if err != nil {
+			startUpFailed(serviceConfig, err, "Failed to generate the callback token secret", logger)
+		}
+		serviceConfig.CallbackAuth.Secret = secret
+		logger.Warn("callback_auth.secret is not set; generated a secret that is not shared with other replicas and changes on restart")
+	}
+
 	// setup runtime
-	runtime, err := runtimes.NewRuntime(logger, serviceConfig)
+	runtime, err := runtimes.NewRuntime(logger, serviceConfig, storage)
 	if err != nil {
 		// we do this as no point trying to continue
 		startUpFailed(serviceConfig, err, "Failed to create runtime", logger)
@@ -159,6 +294,39 @@ func main() {
 		startUpFailed(serviceConfig, err, "Failed to create API server", logger)
 	}
 
+	// Run provider canary evaluations for providers that declare a health_check
+	providerHealth := providerhealth.NewMonitor(logger, storage, runtime)
+	srv.SetProviderHealth(providerHealth)
+	srv.SetProviderHealthScheduler(providerHealth)
+	srv.SetJobWatcher(jobUpdates)
+	srv.SetMessageCatalogs(messageCatalogs)
+
+	// Report the permissions that the runtime lacks now rather than as Forbidden errors of the
+	// benchmarks; the service still starts, as the jobs that do not need them can run
+	permissionCtx, permissionCancel := context.WithTimeout(context.Background(), permissionCheckTimeout)
+	srv.CheckRuntimePermissions(permissionCtx)
+	permissionCancel()
+
+	// Pre-pull the adapter images of the system providers when the runtime supports it
+	var imageWarmup *imagewarmup.Manager
+	if serviceConfig.ImageWarmup.IsEnabled() {
+		if warmer, ok := runtime.(abstractions.ImageWarmer); ok {
+			imageWarmup = imagewarmup.NewManager(logger, storage, warmer, serviceConfig.ImageWarmup)
+			srv.SetImageWarmup(imageWarmup)
+		} else {
+			logger.Warn("image_warmup is enabled but the runtime cannot pre-pull images", "runtime", runtime.Name())
+		}
+	}
+
+	// Call the operator's admission webhooks before evaluation jobs are stored
+	jobAdmission, err := admission.NewController(logger, serviceConfig.Admission, serviceConfig.Proxy)
+	if err != nil {
+		startUpFailed(serviceConfig, err, "Failed to configure admission webhooks", logger)
+	}
+	if jobAdmission != nil {
+		srv.SetJobAdmission(jobAdmission)
+	}
+
 	// Create the metrics server if Prometheus is enabled
 	var metricsSrv *server.MetricsServer
 	if serviceConfig.IsPrometheusEnabled() {
@@ -188,8 +356,42 @@ func main() {
 		"prometheus", serviceConfig.IsPrometheusEnabled(),
 	)
 
-	// Start config watcher to reload system providers and collections on file changes
-	watcherDone, watcherCancel := config.SetupWatcher(logger, validate, storage, args.ConfigDir)
+	// The config watcher, the provider health checks, the failure diagnostics and the experiment
+	// link retries write to the shared storage, so when several replicas share a database they
+	// only run on the elected leader
+	leaderLock, err := leader.NewLock(logger, serviceConfig.Database)
+	if err != nil {
+		startUpFailed(serviceConfig, err, "Failed to create leader lock", logger)
+	}
+	backgroundCtx, backgroundCancel := context.WithCancel(context.Background())
+	backgroundDone := make(chan struct{})
+	if imageWarmup != nil {
+		// every replica reports the pull status, only the leader manages the warm-up
+		go imageWarmup.RunStatus(backgroundCtx)
+	}
+	// the local jobs directory is on the disk of each replica
+	go srv.RunJobFilesCleanup(backgroundCtx)
+	// every replica reports the provider health stored by the leader
+	go providerHealth.RunHealthSync(backgroundCtx)
+	if serviceConfig.Service.EnableAdminAPI {
+		// every replica applies the settings changed on the admin API of any of them, the
+		// intervals of the background checks taking effect on the leader
+		go srv.RunAdminConfigSync(backgroundCtx)
+	}
+	go func() {
+		defer close(backgroundDone)
+		leader.NewElector(logger, leaderLock, leader.DefaultRetryInterval).Run(backgroundCtx, func(ctx context.Context) {
+			// Start config watcher to reload system providers and collections on file changes
+			watcherDone, _ := config.SetupWatcher(ctx, logger, validate, storage, args.ConfigDir, builtinProviders)
+			if imageWarmup != nil {
+				go imageWarmup.RunWarmup(ctx)
+			}
+			go srv.RunFailureDiagnostics(ctx)
+			go srv.RunExperimentLinking(ctx)
+			providerHealth.Run(ctx, providerhealth.DefaultPollInterval)
+			<-watcherDone
+		})
+	}()
 
 	// Start metrics server in a goroutine
 	if metricsSrv != nil {
@@ -200,6 +402,10 @@ func main() {
 		}()
 	}
 
+	if serviceConfig.Service.LocalMode {
+		fmt.Print(localmode.Quickstart(srv.BaseURL(), localDatabasePath))
+	}
+
 	// Start server in a goroutine
 	go func() {
 		if err := srv.Start(); err != nil {
@@ -217,10 +423,13 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
-	// Stop config watcher
-	logger.Info("Shutting down API config watcher...")
-	watcherCancel()
-	<-watcherDone // Wait for Watch() to fully complete
+	// Stop the config watcher and provider health checks, and give up leadership
+	logger.Info("Shutting down API background subsystems...")
+	backgroundCancel()
+	<-backgroundDone // Wait for Watch() to fully complete
+	if err := leaderLock.Close(); err != nil {
+		logger.Error("Failed to close leader lock", "error", err.Error())
+	}
 
 	// Create a context with timeout for graceful shutdown
 	waitForShutdown := 30 * time.Second
@@ -234,12 +443,23 @@ func main() {
 		}
 	}
 
+	// no other replica resumes the jobs waiting for their model here
+	srv.StopModelWaits()
+
 	// shutdown the storage
 	logger.Info("Shutting down API storage...")
 	if err := storage.Close(); err != nil {
 		logger.Error("Failed to close API storage", "error", err.Error())
 	}
 
+	// shutdown the event bus
+	if err := eventBus.Close(); err != nil {
+		logger.Error("Failed to close event bus", "error", err.Error())
+	}
+	if notifier != nil {
+		notifier.Wait()
+	}
+
 	// shutdown the otel tracing
 	if otelShutdown != nil {
 		logger.Info("Shutting down API OTEL...")
@@ -258,3 +478,44 @@ func main() {
 		_ = logShutdown() // ignore the error
 	}
 }
+
+// validateConfig validates the configuration without starting the service, writes the summary
+// to stdout and returns the exit code: 0 when the configuration is valid, 1 when it has errors
+// and 2 when the summary cannot be written.
+func validateConfig(args Args) int {
+	// only the problems are logged unless a log level is set, the summary reports the rest
+	if os.Getenv("LOG_LEVEL") == "" {
+		_ = logging.SetLevel("warn")
+	}
+	logger, logShutdown, err := logging.NewLogger()
+	if err != nil {
+		logger = logging.FallbackLogger()
+	} else {
+		defer func() { _ = logShutdown() }()
+	}
+
+	report := configcheck.Run(logger, configcheck.Options{
+		ConfigDir: args.ConfigDir,
+		Version:   Version,
+		Build:     Build,
+		BuildDate: BuildDate,
+		GitHash:   GitHash,
+	})
+
+	switch args.ValidateConfigFormat {
+	case "json":
+		err = report.WriteJSON(os.Stdout)
+	case "text", "":
+		err = report.WriteText(os.Stdout)
+	default:
+		err = fmt.Errorf("unsupported -validate-config-format %q: must be text or json", args.ValidateConfigFormat)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		return 2
+	}
+	if !report.Valid {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,121 @@
+// evalctl is the command line tool of eval-hub for the authors of providers.
+//
+//	evalctl adapter verify --command "python -m my_adapter" --provider-id my-provider --benchmark-id my-benchmark
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/eval-hub/eval-hub/internal/adapterverify"
+)
+
+const usage = `Usage: evalctl <command> [flags]
+
+Commands:
+  adapter verify   run the adapter of a provider against a mock eval-hub and report its
+                   compliance with the callback protocol
+`
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) >= 2 && args[0] == "adapter" && args[1] == "verify" {
+		return adapterVerify(ctx, args[2:], stdout, stderr)
+	}
+	fmt.Fprint(stderr, usage)
+	return 2
+}
+
+// envFlags are the repeated --env NAME=VALUE flags.
+type envFlags []string
+
+func (e *envFlags) String() string { return strings.Join(*e, ",") }
+
+func (e *envFlags) Set(value string) error {
+	if !strings.Contains(value, "=") {
+		return fmt.Errorf("%q is not NAME=VALUE", value)
+	}
+	*e = append(*e, value)
+	return nil
+}
+
+// adapterVerify runs evalctl adapter verify, it exits with 1 when the adapter does not comply.
+func adapterVerify(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("evalctl adapter verify", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var env envFlags
+	command := flags.String("command", "", "Shell command that starts the adapter, as runtime.local.command of the provider (required)")
+	providerID := flags.String("provider-id", "", "ID of the provider (required)")
+	benchmarkID := flags.String("benchmark-id", "", "ID of the benchmark that the adapter runs (required)")
+	parameters := flags.String("parameters", "", "JSON object of the parameters of the benchmark")
+	numExamples := flags.Int("num-examples", adapterverify.DefaultNumExamples, "num_examples of the benchmark")
+	timeout := flags.Duration("timeout", adapterverify.DefaultTimeout, "How long the adapter may run in each scenario")
+	scenarios := flags.String("scenarios", "", "Comma-separated scenarios to run, all by default: "+strings.Join(adapterverify.ScenarioNames(), ", "))
+	workDir := flags.String("work-dir", "", "Directory of the job specs and outputs of the adapter, a temporary directory by default")
+	reportPath := flags.String("report", "", "File to write the JSON compliance report to")
+	verbose := flags.Bool("v", false, "Log the progress of the verification")
+	flags.Var(&env, "env", "NAME=VALUE variable of the adapter, may be repeated")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	opts := adapterverify.Options{
+		Command:     *command,
+		Env:         env,
+		ProviderID:  *providerID,
+		BenchmarkID: *benchmarkID,
+		NumExamples: *numExamples,
+		Timeout:     *timeout,
+		WorkDir:     *workDir,
+	}
+	if *parameters != "" {
+		if err := json.Unmarshal([]byte(*parameters), &opts.Parameters); err != nil {
+			fmt.Fprintf(stderr, "invalid --parameters: %v\n", err)
+			return 2
+		}
+	}
+	if *scenarios != "" {
+		opts.Scenarios = strings.Split(*scenarios, ",")
+	}
+	if *verbose {
+		opts.Logger = slog.New(slog.NewTextHandler(stderr, nil))
+	}
+
+	report, err := adapterverify.Verify(ctx, opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "adapter verify: %v\n", err)
+		return 2
+	}
+	report.WriteText(stdout)
+	if *reportPath != "" {
+		reportJSON, err := json.MarshalIndent(report, "", "  ")
+		if err == nil {
+			err = os.WriteFile(*reportPath, reportJSON, 0o600)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "write report: %v\n", err)
+			return 2
+		}
+	}
+	if !report.Passed {
+		return 1
+	}
+	return 0
+}
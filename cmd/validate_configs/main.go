@@ -12,7 +12,7 @@ import (
 )
 
 func main() {
-	configDir := flag.String("config-dir", "config", "Directory containing providers/ and collections/ subdirectories")
+	configDir := flag.String("config-dir", "config", "Directory containing providers/, collections/ and optional messages/ subdirectories")
 	flag.Parse()
 
 	logger := logging.FallbackLogger()
@@ -42,11 +42,18 @@ func main() {
 		os.Exit(1)
 	}
 
+	catalogs, err := config.LoadMessageCatalogs(logger, *configDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "message catalogs: %v\n", err)
+		os.Exit(1)
+	}
+
 	providerIDs := sortedKeys(providers)
 	collectionIDs := sortedKeys(collections)
 
 	fmt.Printf("validated %d providers: %v\n", len(providers), providerIDs)
 	fmt.Printf("validated %d collections: %v\n", len(collections), collectionIDs)
+	fmt.Printf("validated %d message catalogs: %v\n", len(catalogs), sortedKeys(catalogs))
 }
 
 func sortedKeys[K comparable, V any](m map[K]V) []K {
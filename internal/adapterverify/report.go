@@ -0,0 +1,74 @@
+package adapterverify
+
+import (
+	"fmt"
+	"io"
+)
+
+// Report is the compliance report of an adapter: the checks of each scenario that it ran.
+type Report struct {
+	ProviderID  string           `json:"provider_id"`
+	BenchmarkID string           `json:"benchmark_id"`
+	Passed      bool             `json:"passed"`
+	Scenarios   []ScenarioReport `json:"scenarios"`
+}
+
+// ScenarioReport is how the adapter behaved in a scenario.
+type ScenarioReport struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// ExitCode is the exit code of the adapter, nil when it did not exit in time.
+	ExitCode *int `json:"exit_code,omitempty"`
+	// LogPath is the file of the output of the adapter.
+	LogPath string  `json:"log_path"`
+	Checks  []Check `json:"checks"`
+}
+
+// Check is a requirement of the callback protocol. An adapter that fails a required check
+// does not work with eval-hub; a recommended check covers behaviour that eval-hub relies on
+// for retries and error reporting.
+type Check struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required"`
+	Passed   bool   `json:"passed"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// passed returns false when a required check of the scenario failed.
+func (s *ScenarioReport) passed() bool {
+	for _, check := range s.Checks {
+		if check.Required && !check.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteText writes the report for a terminal.
+func (r *Report) WriteText(w io.Writer) {
+	fmt.Fprintf(w, "Adapter compliance of benchmark %s of provider %s\n", r.BenchmarkID, r.ProviderID)
+	for _, scenario := range r.Scenarios {
+		fmt.Fprintf(w, "\n%s: %s\n", scenario.Name, scenario.Description)
+		for _, check := range scenario.Checks {
+			status := "PASS"
+			switch {
+			case check.Passed:
+			case check.Required:
+				status = "FAIL"
+			default:
+				status = "WARN"
+			}
+			fmt.Fprintf(w, "  [%s] %s", status, check.Name)
+			if check.Detail != "" {
+				fmt.Fprintf(w, ": %s", check.Detail)
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "  adapter output: %s\n", scenario.LogPath)
+	}
+	if r.Passed {
+		fmt.Fprintln(w, "\nThe adapter complies with the callback protocol.")
+	} else {
+		fmt.Fprintln(w, "\nThe adapter does not comply with the callback protocol.")
+	}
+}
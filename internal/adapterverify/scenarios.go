@@ -0,0 +1,194 @@
+package adapterverify
+
+import (
+	"fmt"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// scenario is a situation that the adapter is run in, with the checks of how it behaved.
+type scenario struct {
+	name        string
+	description string
+	// modelUnavailable makes the mock model answer every request with 503
+	modelUnavailable bool
+	// rejectFirstEvent makes the mock server answer the first status event with 503
+	rejectFirstEvent bool
+	checks           func(run *scenarioRun) []Check
+}
+
+// scenarioRun is what the adapter did in a scenario.
+type scenarioRun struct {
+	spec     expectedIdentity
+	token    string
+	exitCode *int
+	record   jobRecord
+}
+
+// expectedIdentity is the benchmark that the events of the adapter must be about.
+type expectedIdentity struct {
+	providerID     string
+	benchmarkID    string
+	benchmarkIndex int
+}
+
+// scenarios are the scenarios that the adapter is run in, in order.
+var scenarios = []*scenario{
+	{
+		name:        "success",
+		description: "the model answers, the adapter reports its progress and results",
+		checks: func(run *scenarioRun) []Check {
+			checks := run.protocolChecks()
+			terminal := run.terminalEvent()
+			checks = append(checks,
+				check("exits with code 0", true, run.exitCode != nil && *run.exitCode == 0, exitDetail(run.exitCode)),
+				check("queries the model", true, run.record.modelRequests > 0, "the adapter sent no request to the model URL of the job spec"),
+				check("reports running before the results", false, run.reportedRunning(), "no running event before the terminal event"),
+				check("completes", true, terminal != nil && terminal.Status == api.StateCompleted, terminalDetail(terminal)),
+				check("reports metrics", true, terminal != nil && len(terminal.Metrics) > 0, "the completed event has no metrics"),
+			)
+			return checks
+		},
+	},
+	{
+		name:             "model_unavailable",
+		description:      "the model answers every request with 503, the adapter reports the failure",
+		modelUnavailable: true,
+		checks: func(run *scenarioRun) []Check {
+			checks := run.protocolChecks()
+			terminal := run.terminalEvent()
+			failed := terminal != nil && terminal.Status == api.StateFailed
+			checks = append(checks,
+				check("fails", true, failed, terminalDetail(terminal)),
+				check("reports an error message", true, failed && terminal.ErrorMessage != nil && terminal.ErrorMessage.Message != "", "the failed event has no error_message.message"),
+				check("reports the model_unavailable message code", false, failed && terminal.ErrorMessage != nil && terminal.ErrorMessage.MessageCode == constants.MESSAGE_CODE_MODEL_UNAVAILABLE, "without it the failure is not retried by the retry policy of the benchmark"),
+			)
+			return checks
+		},
+	},
+	{
+		name:             "callback_retry",
+		description:      "eval-hub answers the first status event with 503, the adapter sends it again",
+		rejectFirstEvent: true,
+		checks: func(run *scenarioRun) []Check {
+			return []Check{
+				check("exits", true, run.exitCode != nil, "the adapter did not exit in time"),
+				check("retries rejected events", false, run.retriedRejectedEvent(), "the event answered with 503 was not sent again"),
+				check("reports a terminal event", true, run.terminalEvent() != nil, "no completed or failed event was accepted"),
+			}
+		},
+	},
+}
+
+// protocolChecks are the checks of the events of every scenario.
+func (run *scenarioRun) protocolChecks() []Check {
+	checks := []Check{
+		check("exits", true, run.exitCode != nil, "the adapter did not exit in time"),
+		check("reports status events", true, len(run.record.events) > 0, "the adapter posted no event to the callback URL of the job spec"),
+	}
+	invalid, missingToken, wrongBenchmark := "", "", ""
+	for i, received := range run.record.events {
+		if received.err != nil && invalid == "" {
+			invalid = fmt.Sprintf("event %d: %v", i+1, received.err)
+		}
+		if received.token != run.token && missingToken == "" {
+			missingToken = fmt.Sprintf("event %d has no %s header with the callback_token of the job spec", i+1, callbackauth.TokenHeader)
+		}
+		if event := received.benchmarkEvent(); event != nil && wrongBenchmark == "" &&
+			(event.ProviderID != run.spec.providerID || event.ID != run.spec.benchmarkID || event.BenchmarkIndex != run.spec.benchmarkIndex) {
+			wrongBenchmark = fmt.Sprintf("event %d is about benchmark %s of provider %s at index %d", i+1, event.ID, event.ProviderID, event.BenchmarkIndex)
+		}
+	}
+	terminals, lastIsTerminal := 0, false
+	for _, received := range run.accepted() {
+		event := received.benchmarkEvent()
+		lastIsTerminal = event.Status == api.StateCompleted || event.Status == api.StateFailed
+		if lastIsTerminal {
+			terminals++
+		}
+	}
+	checks = append(checks,
+		check("sends valid status events", true, invalid == "", invalid),
+		check("sends the callback token", true, missingToken == "", missingToken),
+		check("reports the benchmark of the job spec", true, wrongBenchmark == "", wrongBenchmark),
+		check("reports one terminal event, last", true, terminals == 1 && lastIsTerminal, fmt.Sprintf("%d completed or failed events, the last event is terminal: %t", terminals, lastIsTerminal)),
+	)
+	return checks
+}
+
+// accepted returns the events that the mock server accepted.
+func (run *scenarioRun) accepted() []receivedEvent {
+	var accepted []receivedEvent
+	for _, received := range run.record.events {
+		if !received.rejected && received.benchmarkEvent() != nil {
+			accepted = append(accepted, received)
+		}
+	}
+	return accepted
+}
+
+// terminalEvent returns the last completed or failed event that the mock server accepted.
+func (run *scenarioRun) terminalEvent() *api.BenchmarkStatusEvent {
+	var terminal *api.BenchmarkStatusEvent
+	for _, received := range run.accepted() {
+		if event := received.benchmarkEvent(); event.Status == api.StateCompleted || event.Status == api.StateFailed {
+			terminal = event
+		}
+	}
+	return terminal
+}
+
+func (run *scenarioRun) reportedRunning() bool {
+	for _, received := range run.accepted() {
+		switch received.benchmarkEvent().Status {
+		case api.StateRunning:
+			return true
+		case api.StateCompleted, api.StateFailed:
+			return false
+		}
+	}
+	return false
+}
+
+// retriedRejectedEvent tells whether the adapter sent the rejected event again.
+func (run *scenarioRun) retriedRejectedEvent() bool {
+	events := run.record.events
+	if len(events) < 2 || !events[0].rejected {
+		return false
+	}
+	rejected := events[0].benchmarkEvent()
+	retried := events[1].benchmarkEvent()
+	return rejected != nil && retried != nil && retried.Status == rejected.Status
+}
+
+func (received receivedEvent) benchmarkEvent() *api.BenchmarkStatusEvent {
+	if received.event == nil {
+		return nil
+	}
+	return received.event.BenchmarkStatusEvent
+}
+
+// check returns a check, with the detail of its failure.
+func check(name string, required, passed bool, failureDetail string) Check {
+	c := Check{Name: name, Required: required, Passed: passed}
+	if !passed {
+		c.Detail = failureDetail
+	}
+	return c
+}
+
+func exitDetail(exitCode *int) string {
+	if exitCode == nil {
+		return "the adapter did not exit in time"
+	}
+	return fmt.Sprintf("the adapter exited with code %d", *exitCode)
+}
+
+func terminalDetail(terminal *api.BenchmarkStatusEvent) string {
+	if terminal == nil {
+		return "no completed or failed event was accepted"
+	}
+	return fmt.Sprintf("the terminal event is %s", terminal.Status)
+}
@@ -0,0 +1,197 @@
+package adapterverify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/go-playground/validator/v10"
+)
+
+// mockModelName is the name of the model that the mock server serves.
+const mockModelName = "mock-model"
+
+// receivedEvent is a status event that the adapter posted.
+type receivedEvent struct {
+	event *api.StatusEvent
+	// err is why the event is not a valid status event
+	err error
+	// token is the callback token header of the request
+	token string
+	// rejected tells that the mock server answered the event with an error on purpose
+	rejected bool
+}
+
+// jobRecord is what the mock server saw of the adapter of a job.
+type jobRecord struct {
+	scenario      *scenario
+	events        []receivedEvent
+	modelRequests int
+}
+
+// mockServer is the eval-hub that the adapters report their status events to, it also
+// serves an OpenAI-compatible model for the adapters to evaluate. It records what each job
+// sent, by job ID.
+type mockServer struct {
+	logger   *slog.Logger
+	validate *validator.Validate
+	server   *http.Server
+	baseURL  string
+
+	mu   sync.Mutex
+	jobs map[string]*jobRecord
+}
+
+func newMockServer(logger *slog.Logger, validate *validator.Validate) (*mockServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("listen: %w", err)
+	}
+	s := &mockServer{
+		logger:   logger,
+		validate: validate,
+		baseURL:  "http://" + listener.Addr().String(),
+		jobs:     map[string]*jobRecord{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/evaluations/jobs/{id}/events", s.handleEvent)
+	mux.HandleFunc("/model/{id}/", s.handleModel)
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: defaultReadHeaderTimeout}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Mock eval-hub server failed", "error", err)
+		}
+	}()
+	return s, nil
+}
+
+func (s *mockServer) close(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// register starts recording the job of a scenario.
+func (s *mockServer) register(jobID string, scenario *scenario) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[jobID] = &jobRecord{scenario: scenario}
+}
+
+// record returns a copy of what the server saw of a job.
+func (s *mockServer) record(jobID string) jobRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record := *s.jobs[jobID]
+	record.events = append([]receivedEvent(nil), record.events...)
+	return record
+}
+
+// modelURL is the URL of the model that the adapter of a job evaluates.
+func (s *mockServer) modelURL(jobID string) string {
+	return s.baseURL + "/model/" + jobID + "/v1"
+}
+
+func (s *mockServer) handleEvent(w http.ResponseWriter, r *http.Request) {
+	jobID := r.PathValue("id")
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	received := receivedEvent{token: r.Header.Get(callbackauth.TokenHeader)}
+	event := &api.StatusEvent{}
+	if err := json.Unmarshal(body, event); err != nil {
+		received.err = fmt.Errorf("invalid JSON: %w", err)
+	} else if err := s.validate.Struct(event); err != nil {
+		received.err = err
+	} else {
+		received.event = event
+	}
+
+	s.mu.Lock()
+	record, ok := s.jobs[jobID]
+	if ok {
+		// the first event of a job whose callbacks fail is answered as by an unavailable server
+		received.rejected = record.scenario.rejectFirstEvent && len(record.events) == 0
+		record.events = append(record.events, received)
+	}
+	s.mu.Unlock()
+
+	switch {
+	case !ok:
+		http.Error(w, fmt.Sprintf("unknown evaluation job %q", jobID), http.StatusNotFound)
+	case received.rejected:
+		http.Error(w, "the mock server rejects the first event", http.StatusServiceUnavailable)
+	case received.err != nil:
+		s.logger.Info("Adapter sent an invalid status event", "job_id", jobID, "error", received.err)
+		http.Error(w, received.err.Error(), http.StatusBadRequest)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// handleModel answers the OpenAI-compatible requests of the adapter with a fixed answer, or
+// as an unavailable model.
+func (s *mockServer) handleModel(w http.ResponseWriter, r *http.Request) {
+	jobID := r.PathValue("id")
+	s.mu.Lock()
+	record, ok := s.jobs[jobID]
+	if ok {
+		record.modelRequests++
+	}
+	s.mu.Unlock()
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if record.scenario.modelUnavailable {
+		http.Error(w, "the mock model is unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	var response any
+	switch path := r.URL.Path; {
+	case strings.HasSuffix(path, "/models"):
+		response = map[string]any{
+			"object": "list",
+			"data":   []any{map[string]any{"id": mockModelName, "object": "model"}},
+		}
+	case strings.HasSuffix(path, "/chat/completions"):
+		response = map[string]any{
+			"id":     "mock-completion",
+			"object": "chat.completion",
+			"model":  mockModelName,
+			"choices": []any{map[string]any{
+				"index":         0,
+				"message":       map[string]any{"role": "assistant", "content": "A"},
+				"finish_reason": "stop",
+			}},
+			"usage": map[string]any{"prompt_tokens": 1, "completion_tokens": 1, "total_tokens": 2},
+		}
+	case strings.HasSuffix(path, "/completions"):
+		response = map[string]any{
+			"id":     "mock-completion",
+			"object": "text_completion",
+			"model":  mockModelName,
+			"choices": []any{map[string]any{
+				"index":         0,
+				"text":          "A",
+				"finish_reason": "stop",
+			}},
+			"usage": map[string]any{"prompt_tokens": 1, "completion_tokens": 1, "total_tokens": 2},
+		}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,247 @@
+// Package adapterverify runs the adapter of a provider against a mock eval-hub, through the
+// scenarios of the callback protocol, and reports whether it complies: that it reads its job
+// spec, reports valid status events with the callback token of the job, finishes with one
+// completed or failed event, and reports the failures of the model.
+package adapterverify
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/validation"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const (
+	// DefaultTimeout is how long the adapter may run in a scenario by default.
+	DefaultTimeout = 5 * time.Minute
+	// DefaultNumExamples is the num_examples of the benchmark by default, the model of the
+	// mock server gives the same answer to every example.
+	DefaultNumExamples = 2
+
+	defaultReadHeaderTimeout = 10 * time.Second
+	shutdownTimeout          = 5 * time.Second
+)
+
+// Options is the adapter to verify and the benchmark that it runs.
+type Options struct {
+	// Command is the shell command that starts the adapter, as runtime.local.command of a
+	// provider. It gets the path of its job spec in EVALHUB_JOB_SPEC_PATH.
+	Command string
+	// Env are extra NAME=VALUE variables of the adapter.
+	Env         []string
+	ProviderID  string
+	BenchmarkID string
+	Parameters  map[string]any
+	NumExamples int
+	// Timeout is how long the adapter may run in each scenario.
+	Timeout time.Duration
+	// Scenarios are the names of the scenarios to run, all when empty.
+	Scenarios []string
+	// WorkDir is where the job spec and output of the adapter of each scenario are kept, a
+	// temporary directory when empty.
+	WorkDir string
+	Logger  *slog.Logger
+}
+
+// ScenarioNames returns the names of the scenarios, in the order that they run in.
+func ScenarioNames() []string {
+	names := make([]string, len(scenarios))
+	for i, scenario := range scenarios {
+		names[i] = scenario.name
+	}
+	return names
+}
+
+// Verify runs the adapter in each scenario and returns the compliance report. The error is
+// about the harness itself, e.g. an unknown scenario, not about the adapter.
+func Verify(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Command == "" {
+		return nil, errors.New("the command of the adapter is required")
+	}
+	if opts.ProviderID == "" || opts.BenchmarkID == "" {
+		return nil, errors.New("the provider and benchmark IDs are required")
+	}
+	selected, err := selectScenarios(opts.Scenarios)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.NumExamples <= 0 {
+		opts.NumExamples = DefaultNumExamples
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.New(slog.DiscardHandler)
+	}
+	if opts.WorkDir == "" {
+		opts.WorkDir, err = os.MkdirTemp("", "evalhub-adapter-verify-")
+		if err != nil {
+			return nil, fmt.Errorf("create work directory: %w", err)
+		}
+	}
+
+	validate, err := validation.NewValidator()
+	if err != nil {
+		return nil, fmt.Errorf("create validator: %w", err)
+	}
+	server, err := newMockServer(opts.Logger, validate)
+	if err != nil {
+		return nil, fmt.Errorf("start mock eval-hub: %w", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = server.close(shutdownCtx)
+	}()
+
+	report := &Report{ProviderID: opts.ProviderID, BenchmarkID: opts.BenchmarkID, Passed: true}
+	for _, scenario := range selected {
+		scenarioReport, err := runScenario(ctx, server, scenario, &opts)
+		if err != nil {
+			return nil, fmt.Errorf("scenario %s: %w", scenario.name, err)
+		}
+		report.Passed = report.Passed && scenarioReport.passed()
+		report.Scenarios = append(report.Scenarios, *scenarioReport)
+	}
+	return report, nil
+}
+
+func selectScenarios(names []string) ([]*scenario, error) {
+	if len(names) == 0 {
+		return scenarios, nil
+	}
+	var selected []*scenario
+	for _, name := range names {
+		found := false
+		for _, scenario := range scenarios {
+			if scenario.name == name {
+				selected = append(selected, scenario)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown scenario %q, the scenarios are %v", name, ScenarioNames())
+		}
+	}
+	return selected, nil
+}
+
+// runScenario writes the job spec of the scenario, runs the adapter with it until it exits
+// or times out, and checks what it reported.
+func runScenario(ctx context.Context, server *mockServer, scenario *scenario, opts *Options) (*ScenarioReport, error) {
+	jobID := "adapter-verify-" + scenario.name
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	server.register(jobID, scenario)
+
+	jobDir := filepath.Join(opts.WorkDir, scenario.name)
+	metaDir := filepath.Join(jobDir, "meta")
+	if err := os.MkdirAll(metaDir, 0o750); err != nil {
+		return nil, fmt.Errorf("create job directory: %w", err)
+	}
+	specPath := filepath.Join(metaDir, "job.json")
+	specJSON, err := buildJobSpec(server, jobID, token, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(specPath, specJSON, 0o600); err != nil {
+		return nil, fmt.Errorf("write job spec: %w", err)
+	}
+	absSpecPath, err := filepath.Abs(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve job spec path: %w", err)
+	}
+	logPath := filepath.Join(jobDir, "jobrun.log")
+	logFile, err := os.Create(logPath) // #nosec G304 -- the log path is under the work directory of the harness
+	if err != nil {
+		return nil, fmt.Errorf("create log file: %w", err)
+	}
+	defer func() { _ = logFile.Close() }()
+
+	runCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	cmd := exec.CommandContext(runCtx, "sh", "-c", opts.Command) // #nosec G204 -- the harness runs the adapter command that its user gives
+	cmd.Dir = jobDir
+	cmd.Env = append(os.Environ(), opts.Env...)
+	cmd.Env = append(cmd.Env, "EVALHUB_JOB_SPEC_PATH="+absSpecPath)
+	cmd.Stdout = logFile
+	cmd.Stderr = logFile
+	cmd.WaitDelay = shutdownTimeout
+
+	opts.Logger.Info("Running adapter", "scenario", scenario.name, "job_spec_path", absSpecPath)
+	run := &scenarioRun{
+		spec:  expectedIdentity{providerID: opts.ProviderID, benchmarkID: opts.BenchmarkID},
+		token: token,
+	}
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	switch {
+	case runCtx.Err() != nil:
+		// killed on timeout, or the verification was cancelled
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	case err == nil:
+		exitCode := 0
+		run.exitCode = &exitCode
+	case errors.As(err, &exitErr):
+		exitCode := exitErr.ExitCode()
+		run.exitCode = &exitCode
+	default:
+		return nil, fmt.Errorf("run adapter: %w", err)
+	}
+	run.record = server.record(jobID)
+
+	return &ScenarioReport{
+		Name:        scenario.name,
+		Description: scenario.description,
+		ExitCode:    run.exitCode,
+		LogPath:     logPath,
+		Checks:      scenario.checks(run),
+	}, nil
+}
+
+// buildJobSpec returns the job.json of the scenario, pointing the adapter at the model and
+// the callback URL of the mock server.
+func buildJobSpec(server *mockServer, jobID, token string, opts *Options) ([]byte, error) {
+	parameters := shared.CopyParams(opts.Parameters)
+	parameters["num_examples"] = opts.NumExamples
+	evaluation := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model: api.ModelRef{URL: server.modelURL(jobID), Name: mockModelName},
+		},
+	}
+	benchmark := &api.EvaluationBenchmarkConfig{
+		Ref:        api.Ref{ID: opts.BenchmarkID},
+		ProviderID: opts.ProviderID,
+		Parameters: parameters,
+	}
+	spec, err := shared.BuildJobSpec(evaluation, opts.ProviderID, benchmark, 0, &server.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	spec.CallbackToken = token
+	return shared.MarshalJobSpec(spec)
+}
+
+func randomToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("generate callback token: %w", err)
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,142 @@
+package adapterverify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// fakeAdapterEnv makes the test binary act as an adapter, in the mode of its value.
+const fakeAdapterEnv = "ADAPTERVERIFY_FAKE_ADAPTER"
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(fakeAdapterEnv); mode != "" {
+		if err := runFakeAdapter(mode); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runFakeAdapter follows the callback protocol in the compliant mode, and reports nothing in
+// the silent mode.
+func runFakeAdapter(mode string) error {
+	if mode == "silent" {
+		return nil
+	}
+	specJSON, err := os.ReadFile(os.Getenv("EVALHUB_JOB_SPEC_PATH"))
+	if err != nil {
+		return err
+	}
+	var spec shared.JobSpec
+	if err := json.Unmarshal(specJSON, &spec); err != nil {
+		return err
+	}
+	event := &api.BenchmarkStatusEvent{ProviderID: spec.ProviderID, ID: spec.BenchmarkID, BenchmarkIndex: spec.BenchmarkIndex, Status: api.StateRunning}
+	if err := postEvent(&spec, event); err != nil {
+		return err
+	}
+	response, err := http.Post(spec.Model.URL+"/chat/completions", "application/json", strings.NewReader(`{"messages":[]}`))
+	if err != nil {
+		return err
+	}
+	_ = response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		event.Status = api.StateFailed
+		event.ErrorMessage = &api.MessageInfo{Message: "the model is unavailable", MessageCode: "model_unavailable"}
+	} else {
+		event.Status = api.StateCompleted
+		event.Metrics = map[string]any{"acc": 1.0}
+	}
+	return postEvent(&spec, event)
+}
+
+// postEvent posts the event, once more when it is rejected.
+func postEvent(spec *shared.JobSpec, event *api.BenchmarkStatusEvent) error {
+	body, err := json.Marshal(&api.StatusEvent{BenchmarkStatusEvent: event})
+	if err != nil {
+		return err
+	}
+	for attempt := 0; attempt < 2; attempt++ {
+		request, err := http.NewRequest(http.MethodPost, *spec.CallbackURL+"/api/v1/evaluations/jobs/"+spec.JobID+"/events", bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		request.Header.Set(callbackauth.TokenHeader, spec.CallbackToken)
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			return err
+		}
+		_ = response.Body.Close()
+		if response.StatusCode < 300 {
+			return nil
+		}
+	}
+	return fmt.Errorf("the event was rejected")
+}
+
+func verifyFakeAdapter(t *testing.T, mode string) *Report {
+	t.Helper()
+	report, err := Verify(context.Background(), Options{
+		Command:     os.Args[0],
+		Env:         []string{fakeAdapterEnv + "=" + mode},
+		ProviderID:  "provider-1",
+		BenchmarkID: "bench-1",
+		Timeout:     30 * time.Second,
+		WorkDir:     t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	return report
+}
+
+func TestVerifyCompliantAdapter(t *testing.T) {
+	report := verifyFakeAdapter(t, "compliant")
+
+	if len(report.Scenarios) != len(scenarios) {
+		t.Fatalf("expected a report of every scenario, got %+v", report.Scenarios)
+	}
+	for _, scenario := range report.Scenarios {
+		for _, check := range scenario.Checks {
+			if !check.Passed {
+				t.Errorf("scenario %s: expected check %q to pass, got %s", scenario.Name, check.Name, check.Detail)
+			}
+		}
+	}
+	if !report.Passed {
+		t.Error("expected the compliant adapter to pass")
+	}
+}
+
+func TestVerifySilentAdapter(t *testing.T) {
+	report := verifyFakeAdapter(t, "silent")
+
+	if report.Passed {
+		t.Fatal("expected an adapter that reports nothing to fail")
+	}
+	var text strings.Builder
+	report.WriteText(&text)
+	if !strings.Contains(text.String(), "[FAIL] reports status events: the adapter posted no event") {
+		t.Errorf("expected the report to name the missing events, got\n%s", text.String())
+	}
+}
+
+func TestVerifyUnknownScenario(t *testing.T) {
+	_, err := Verify(context.Background(), Options{Command: "true", ProviderID: "provider-1", BenchmarkID: "bench-1", Scenarios: []string{"unknown"}})
+	if err == nil || !strings.Contains(err.Error(), "unknown scenario") {
+		t.Fatalf("expected an unknown scenario error, got %v", err)
+	}
+}
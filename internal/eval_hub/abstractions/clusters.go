@@ -0,0 +1,14 @@
+package abstractions
+
+import (
+	"context"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// ClusterReporter is implemented by runtimes that dispatch evaluation jobs to several
+// clusters, e.g. remote Kubernetes clusters.
+type ClusterReporter interface {
+	// ClusterStatus returns the status of each cluster that the runtime dispatches to.
+	ClusterStatus(ctx context.Context) ([]api.ClusterStatus, error)
+}
@@ -0,0 +1,44 @@
+package abstractions
+
+import (
+	"context"
+	"time"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// BenchmarkFailure is a benchmark workload that the runtime found failed.
+type BenchmarkFailure struct {
+	BenchmarkIndex int
+	// ShardIndex is the shard of a sharded benchmark whose workload failed.
+	ShardIndex *int
+	// CreatedAt is when the workload was created, to tell the workload of a retry from the
+	// workloads of the runs before it.
+	CreatedAt    time.Time
+	ErrorMessage *api.MessageInfo
+}
+
+// FailureDiagnoser is implemented by runtimes that can tell that the workload of a benchmark
+// failed, and why, when its adapter could not report it, e.g. as its pod ran out of memory.
+type FailureDiagnoser interface {
+	// DiagnoseBenchmarkFailures returns the benchmarks of the job whose latest workload
+	// failed, with an error message classifying the failure. The failures it could diagnose
+	// are returned with the error when some workloads could not be read.
+	DiagnoseBenchmarkFailures(ctx context.Context, evaluation *api.EvaluationJobResource) ([]BenchmarkFailure, error)
+}
+
+// PlacementReporter is implemented by runtimes that read where the benchmarks of a job run
+// from their workloads, e.g. the pods and nodes of the Kubernetes Jobs.
+type PlacementReporter interface {
+	// BenchmarkPlacements returns the placement of the latest workload of each benchmark of
+	// the job that has one, by benchmark index. The placements it could read are returned
+	// with the error when some workloads could not be read.
+	BenchmarkPlacements(ctx context.Context, evaluation *api.EvaluationJobResource) (map[int]*api.BenchmarkPlacement, error)
+}
+
+// ReconcileScheduler changes how often the workloads of the unfinished jobs are reconciled
+// with the jobs, for their failures and placements, while the service runs.
+type ReconcileScheduler interface {
+	ReconcileInterval() time.Duration
+	SetReconcileInterval(reconcileInterval time.Duration)
+}
@@ -0,0 +1,23 @@
+package abstractions
+
+import (
+	"context"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// ImageWarmupReporter exposes the pre-pull status of provider adapter images.
+type ImageWarmupReporter interface {
+	// ProviderImageWarmup returns nil when the image of the provider is not pre-pulled.
+	ProviderImageWarmup(providerID string) *api.ProviderImageWarmup
+}
+
+// ImageWarmer is implemented by runtimes that can pre-pull adapter images on the nodes
+// that run evaluation jobs.
+type ImageWarmer interface {
+	// WarmUpImages pre-pulls the images, keyed by provider ID, and stops pre-pulling the
+	// images of any other provider.
+	WarmUpImages(ctx context.Context, images map[string]string) error
+	// ImageWarmupStatus returns the pre-pull status of the images, keyed by provider ID.
+	ImageWarmupStatus(ctx context.Context) (map[string]api.ProviderImageWarmup, error)
+}
@@ -0,0 +1,24 @@
+package abstractions
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// JobAdmissionRequest is the evaluation job under review, before it is stored.
+type JobAdmissionRequest struct {
+	JobID  string
+	Tenant api.Tenant
+	User   api.User
+	Job    *api.EvaluationJobConfig
+}
+
+// JobAdmission lets external webhooks validate and mutate evaluation jobs on creation.
+type JobAdmission interface {
+	// Admit returns a service error when the job is rejected. When the job was mutated
+	// it returns the resulting job as JSON, to be decoded and validated like a request
+	// body; otherwise it returns nil.
+	Admit(ctx context.Context, logger *slog.Logger, request *JobAdmissionRequest) ([]byte, error)
+}
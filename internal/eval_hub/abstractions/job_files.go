@@ -0,0 +1,10 @@
+package abstractions
+
+import "context"
+
+// JobFilesCleaner is implemented by runtimes that keep the files of the jobs on the disk of
+// the server, which each replica must clean up on its own.
+type JobFilesCleaner interface {
+	// CleanupJobFiles removes the files of the jobs that are past their retention.
+	CleanupJobFiles(ctx context.Context) error
+}
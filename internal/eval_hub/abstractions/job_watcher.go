@@ -0,0 +1,10 @@
+package abstractions
+
+import "github.com/eval-hub/eval-hub/pkg/api"
+
+// JobWatcher delivers evaluation job snapshots as they are persisted.
+type JobWatcher interface {
+	// Subscribe returns the channel snapshots are delivered on and a function that
+	// releases the subscription. The channel is never closed.
+	Subscribe(jobID string) (<-chan *api.EvaluationJobResource, func())
+}
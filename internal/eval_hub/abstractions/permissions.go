@@ -0,0 +1,16 @@
+package abstractions
+
+import (
+	"context"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// PermissionChecker is implemented by runtimes that can check, before running any job, that
+// the service may do everything that running the jobs needs, e.g. the Kubernetes RBAC of its
+// service account.
+type PermissionChecker interface {
+	// CheckPermissions returns the permissions that are missing, nil when the runtime has
+	// nothing to check.
+	CheckPermissions(ctx context.Context) (*api.PermissionCheck, error)
+}
@@ -0,0 +1,33 @@
+package abstractions
+
+import (
+	"time"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// ProviderHealthReporter exposes the outcome of provider canary evaluations.
+type ProviderHealthReporter interface {
+	// ProviderHealth returns nil when the provider has no health check configured.
+	ProviderHealth(providerID string) *api.ProviderHealth
+}
+
+// ProviderHealthRecord is the stored health of a system provider with the state of its health
+// check, so that every replica reports the health and a new leader resumes the checks.
+type ProviderHealthRecord struct {
+	ProviderID string             `json:"provider_id"`
+	Health     api.ProviderHealth `json:"health"`
+	// NextRunAt is when the next canary job of the provider is due.
+	NextRunAt time.Time `json:"next_run_at,omitzero"`
+	// CanaryJobID, CanaryTenant and CanaryStartedAt are of the canary job in flight, if any.
+	CanaryJobID     string     `json:"canary_job_id,omitempty"`
+	CanaryTenant    api.Tenant `json:"canary_tenant,omitempty"`
+	CanaryStartedAt time.Time  `json:"canary_started_at,omitzero"`
+}
+
+// ProviderHealthScheduler changes how often provider canary evaluations are polled while
+// the service runs.
+type ProviderHealthScheduler interface {
+	PollInterval() time.Duration
+	SetPollInterval(pollInterval time.Duration)
+}
@@ -18,6 +18,15 @@ import (
 type RuntimeStorage interface {
 	GetProvider(id string) (*api.ProviderResource, error)
 	UpdateEvaluationJob(id string, runStatus *api.StatusEvent) error
+	// UpdateEvaluationJobModelMetadata stores the metadata of the model that the runtime read
+	// from the deployment of the model.
+	UpdateEvaluationJobModelMetadata(id string, metadata *api.ModelMetadata) error
+	// UpdateEvaluationJobBenchmarkPlacement stores where the runtime runs the benchmark, over
+	// the placement that it stored before.
+	UpdateEvaluationJobBenchmarkPlacement(id string, benchmarkIndex int, placement *api.BenchmarkPlacement) error
+	// PinEvaluationJobAdapterImage records the adapter image pinned to its digest for the job
+	// and returns the image pinned first, that every benchmark of the job runs.
+	PinEvaluationJobAdapterImage(id string, image string, pinned string) (string, error)
 }
 
 type Runtime interface {
@@ -25,6 +34,9 @@ type Runtime interface {
 	WithContext(ctx context.Context) Runtime
 	Name() string
 	RunEvaluationJob(evaluation *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig, storage RuntimeStorage) error
+	// RunEvaluationBenchmarks starts the benchmarks at benchmarkIndices of a job that is already
+	// running, e.g. once the benchmarks they depend on have completed.
+	RunEvaluationBenchmarks(evaluation *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig, benchmarkIndices []int, storage RuntimeStorage) error
 	DeleteEvaluationJobResources(evaluation *api.EvaluationJobResource) error
 	// GetEvaluationLogs returns plain-text workload logs. When benchmarkIndex is nil, logs
 	// for all benchmarks are concatenated with section headers; otherwise only that benchmark.
@@ -34,6 +46,25 @@ type Runtime interface {
 		benchmarkIndex *int,
 		opts api.EvaluationLogOptions,
 	) (string, error)
+	// GetEvaluationJobSpec returns the job spec JSON that the runtime hands to the adapter of
+	// the benchmark at benchmarkIndex, or of its shard at shardIndex for sharded benchmarks,
+	// as built from the current state of the job. The callback token is left out.
+	GetEvaluationJobSpec(
+		evaluation *api.EvaluationJobResource,
+		benchmarks []api.EvaluationBenchmarkConfig,
+		benchmarkIndex int,
+		shardIndex int,
+		storage RuntimeStorage,
+	) ([]byte, error)
+}
+
+// BenchmarkCanceller is implemented by runtimes that can stop the workloads of some of the
+// benchmarks of a job and keep the others, e.g. the logs of the benchmark that failed a
+// job that fails fast.
+type BenchmarkCanceller interface {
+	// CancelEvaluationBenchmarks stops the workloads of the benchmarks of the job at
+	// benchmarkIndices.
+	CancelEvaluationBenchmarks(evaluation *api.EvaluationJobResource, benchmarkIndices []int) error
 }
 
 // This interface must be decoupled from the service HTTP layer
@@ -29,6 +29,14 @@ type QueryFilter struct {
 	Params map[string]any
 }
 
+// JobVisibility is the value of the "visible_to" evaluation jobs filter, set when jobs are
+// scoped to their owner: it matches the jobs owned by User or shared with User or with one
+// of Groups.
+type JobVisibility struct {
+	User   api.User
+	Groups []string
+}
+
 // ExtractQueryParams returns the limit, offset, and filtered params
 func (filter *QueryFilter) ExtractQueryParams() *QueryFilter {
 	params := maps.Clone(filter.Params)
@@ -58,6 +66,39 @@ func (filter *QueryFilter) String() string {
 	return fmt.Sprintf(`{"limit":%d,"offset":%d,"params":%v}`, filter.Limit, filter.Offset, filter.Params)
 }
 
+// CachedBenchmarkResult is the latest completed result of a benchmark, keyed by the
+// model, benchmark and parameters it was evaluated with.
+type CachedBenchmarkResult struct {
+	Key         string
+	JobID       string
+	CompletedAt time.Time
+	Result      api.BenchmarkResult
+}
+
+// BenchmarkDurationKey identifies the runs of a benchmark that take about as long: the
+// benchmark of a provider with a number of examples in the same bucket.
+type BenchmarkDurationKey struct {
+	ProviderID     string
+	BenchmarkID    string
+	ExamplesBucket int
+}
+
+// EvaluationJobUpdate is the outcome of the status event that UpdateEvaluationJob stored.
+type EvaluationJobUpdate struct {
+	// PreviousState and State are the states of the job before and after the event.
+	PreviousState api.OverallState
+	State         api.OverallState
+	// ReadyBenchmarks are the indices of the benchmarks whose dependencies all completed
+	// with this event. The caller starts them.
+	ReadyBenchmarks []int
+	// RetryBenchmarks are the benchmarks that failed with this event and are to be retried.
+	// The caller starts them again after their backoff.
+	RetryBenchmarks []api.BenchmarkRetry
+	// Ignored is api.StatusEventDuplicate or api.StatusEventOutOfOrder when the event was
+	// not applied. The job is left unchanged.
+	Ignored string
+}
+
 type Storage interface {
 	WithLogger(logger *slog.Logger) Storage
 	WithContext(ctx context.Context) Storage
@@ -71,9 +112,74 @@ type Storage interface {
 	GetEvaluationJob(id string) (*api.EvaluationJobResource, error)
 	GetEvaluationJobs(filter *QueryFilter) (*QueryResults[api.EvaluationJobResource], error)
 	DeleteEvaluationJob(id string) error
-	UpdateEvaluationJob(id string, runStatus *api.StatusEvent) error
+	// PatchEvaluationJob applies the patches to the job config, whatever the job state.
+	PatchEvaluationJob(id string, patches *api.Patch) (*api.EvaluationJobResource, error)
+	// UpdateEvaluationJobOwner hands the job over to owner.
+	UpdateEvaluationJobOwner(id string, owner api.User) (*api.EvaluationJobResource, error)
+	UpdateEvaluationJob(id string, runStatus *api.StatusEvent) (*EvaluationJobUpdate, error)
 	// UpdateEvaluationJobStatus is used to update the status of an evaluation job and is internal - do we need it here?
-	UpdateEvaluationJobStatus(id string, state api.OverallState, message *api.MessageInfo) error
+	// It returns the state of the job before the update.
+	UpdateEvaluationJobStatus(id string, state api.OverallState, message *api.MessageInfo) (api.OverallState, error)
+	// GetEvaluationJobFindings returns the safety findings of the benchmarks of the job, the
+	// most severe first, filtered by the params benchmark_index (int), severity
+	// ([]api.Severity), min_severity (the api.Severity rank), probe and detector.
+	GetEvaluationJobFindings(id string, filter *QueryFilter) (*QueryResults[api.FindingResource], error)
+	// GetEvaluationMetricsHistory returns the metrics of the completed benchmarks of the jobs,
+	// the oldest first, filtered by the params model, benchmark, provider_id, metric,
+	// recorded_after and recorded_before (time.Time) and visible_to (JobVisibility).
+	GetEvaluationMetricsHistory(filter *QueryFilter) (*QueryResults[api.MetricPoint], error)
+	// GetEvaluationMetricNames returns the sorted names of the metrics of the history,
+	// filtered by the params of GetEvaluationMetricsHistory.
+	GetEvaluationMetricNames(filter *QueryFilter) ([]string, error)
+	// PutEvaluationJobArtifact stores the metadata of an artifact uploaded for a benchmark of
+	// the job, replacing the metadata of a previous upload with the same name.
+	PutEvaluationJobArtifact(id string, artifact *api.ArtifactResource) error
+	// GetEvaluationJobArtifacts returns the artifacts of a benchmark of the job, by name.
+	GetEvaluationJobArtifacts(id string, benchmarkIndex int, filter *QueryFilter) (*QueryResults[api.ArtifactResource], error)
+	// PutEvaluationJobGeneration stores the metadata of the raw generations uploaded for a
+	// benchmark of the job, replacing the metadata of a previous upload with the same name.
+	PutEvaluationJobGeneration(id string, generation *api.ArtifactResource) error
+	// GetEvaluationJobGeneration returns the metadata of the generations of a benchmark of the
+	// job with the name.
+	GetEvaluationJobGeneration(id string, benchmarkIndex int, name string) (*api.ArtifactResource, error)
+	// GetEvaluationJobGenerations returns the generations of a benchmark of the job, by name.
+	GetEvaluationJobGenerations(id string, benchmarkIndex int, filter *QueryFilter) (*QueryResults[api.ArtifactResource], error)
+	// AddEvaluationJobRedaction records the redactions applied to an artifact or a message of
+	// a benchmark of the job.
+	AddEvaluationJobRedaction(id string, record *api.RedactionRecord) error
+	// GetEvaluationJobRedactions returns the redactions applied to the job, oldest first.
+	GetEvaluationJobRedactions(id string, filter *QueryFilter) (*QueryResults[api.RedactionRecord], error)
+	// ReviewEvaluationJob decides the pending review of the job, the pass of the job becomes
+	// the decision of the reviewer.
+	ReviewEvaluationJob(id string, reviewer api.User, decision *api.ReviewDecision) (*api.EvaluationJobResource, error)
+	// UpdateEvaluationJobModelMetadata stores the metadata of the model that the job evaluated
+	// in its results, whatever the job state.
+	UpdateEvaluationJobModelMetadata(id string, metadata *api.ModelMetadata) error
+	// UpdateEvaluationJobBenchmarkPlacement merges the placement reported by the runtime into
+	// the status of the benchmark, whatever the job state.
+	UpdateEvaluationJobBenchmarkPlacement(id string, benchmarkIndex int, placement *api.BenchmarkPlacement) error
+	// PinEvaluationJobAdapterImage records the digest that the adapter image of the job
+	// resolved to, unless the job recorded one for the image before, and returns the
+	// recorded image, whatever the job state.
+	PinEvaluationJobAdapterImage(id string, image string, pinned string) (string, error)
+	// LinkEvaluationJobExperiment links the job to its MLflow experiment, when the experiment
+	// could not be created with the job, whatever the job state.
+	LinkEvaluationJobExperiment(id string, experimentID string, experimentURL string) error
+	// GetEvaluationJobsWithoutExperiment returns at most limit jobs of any tenant, the newest
+	// first, that have an MLflow experiment name but are not linked to their experiment yet.
+	GetEvaluationJobsWithoutExperiment(limit int) ([]api.Resource, error)
+	// AddEvaluationJobBenchmarkMetrics adds the metrics, by benchmark index, that the results
+	// of the benchmarks do not have yet, e.g. the ones only logged to MLflow, whatever the job
+	// state, and returns the updated job.
+	AddEvaluationJobBenchmarkMetrics(id string, metrics map[int]map[string]any) (*api.EvaluationJobResource, error)
+
+	// Status dead letter operations, the status events that were rejected, of any tenant
+	AddStatusDeadLetter(letter *api.StatusDeadLetter) error
+	GetStatusDeadLetter(id string) (*api.StatusDeadLetter, error)
+	// GetStatusDeadLetters returns the dead letters, the newest first, filtered by the
+	// "tenant" and "job_id" params.
+	GetStatusDeadLetters(filter *QueryFilter) (*QueryResults[api.StatusDeadLetter], error)
+	DeleteStatusDeadLetter(id string) error
 
 	// Collection operations
 	CreateCollection(collection *api.CollectionResource) error
@@ -91,6 +197,52 @@ type Storage interface {
 	PatchProvider(id string, patches *api.Patch) (*api.ProviderResource, error)
 	DeleteProvider(id string) error
 
+	// Baseline operations, baselines are identified by their name
+	CreateBaseline(baseline *api.BaselineResource) error
+	GetBaseline(name string) (*api.BaselineResource, error)
+	GetBaselines(filter *QueryFilter) (*QueryResults[api.BaselineResource], error)
+	DeleteBaseline(name string) error
+
+	// Evaluation view operations, the saved filters of the evaluation jobs list
+	CreateEvaluationView(view *api.EvaluationViewResource) error
+	GetEvaluationView(id string) (*api.EvaluationViewResource, error)
+	GetEvaluationViews(filter *QueryFilter) (*QueryResults[api.EvaluationViewResource], error)
+	UpdateEvaluationView(id string, config *api.EvaluationViewConfig) (*api.EvaluationViewResource, error)
+	DeleteEvaluationView(id string) error
+
+	// Result cache operations
+	PutCachedBenchmarkResult(entry *CachedBenchmarkResult) error
+	// GetCachedBenchmarkResult returns the entry for the key if it completed at or after
+	// since, or nil when there is none.
+	GetCachedBenchmarkResult(key string, since time.Time) (*CachedBenchmarkResult, error)
+
+	// Tag policy operations, the tag policy of the tenant
+	PutTagPolicy(policy *api.TagPolicyResource) error
+	// GetTagPolicy returns the tag policy of the tenant, or nil when it has none.
+	GetTagPolicy() (*api.TagPolicyResource, error)
+
+	// Redaction policy operations, the redaction policy of the tenant
+	PutRedactionPolicy(policy *api.RedactionPolicyResource) error
+	// GetRedactionPolicy returns the redaction policy of the tenant, or nil when it has none.
+	GetRedactionPolicy() (*api.RedactionPolicyResource, error)
+
+	// Admin configuration operations, the live settings of the service, shared by all the
+	// replicas and not scoped to a tenant
+	PutAdminConfig(config *api.AdminConfig) error
+	// GetAdminConfig returns the stored admin configuration, or nil when it was never changed.
+	GetAdminConfig() (*api.AdminConfig, error)
+
+	// Provider health operations, the outcome of the canary evaluations of the system
+	// providers, shared by all the replicas and not scoped to a tenant
+	PutProviderHealth(record *ProviderHealthRecord) error
+	GetProviderHealthRecords() ([]ProviderHealthRecord, error)
+	DeleteProviderHealth(providerID string) error
+
+	// Benchmark duration operations
+	// GetBenchmarkDurations returns the mean duration of the completed runs of the benchmarks
+	// of the tenant, for the keys that have one.
+	GetBenchmarkDurations(keys []BenchmarkDurationKey) (map[BenchmarkDurationKey]time.Duration, error)
+
 	// LoadSystemResources reloads system-owned providers and collections into
 	// the database. Existing system resources are deleted and replaced.
 	LoadSystemResources(systemCollections map[string]api.CollectionResource, systemProviders map[string]api.ProviderResource) error
@@ -0,0 +1,245 @@
+// Package admission calls the operator's admission webhooks for evaluation jobs before
+// they are stored. Webhooks can reject a job, e.g. to enforce an approved benchmark list,
+// and mutating webhooks can change it with a JSON patch, e.g. to add mandatory tags.
+package admission
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"slices"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/metrics"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+)
+
+const (
+	OperationCreate = "CREATE"
+
+	// maxResponseBytes bounds the webhook response read into memory.
+	maxResponseBytes = 1 << 20
+)
+
+// Request is the body POSTed to every webhook.
+type Request struct {
+	// UID identifies this review and must be echoed in the response.
+	UID       string     `json:"uid"`
+	Operation string     `json:"operation"`
+	JobID     string     `json:"job_id"`
+	Tenant    api.Tenant `json:"tenant,omitempty"`
+	User      api.User   `json:"user,omitempty"`
+	// Job is the evaluation job configuration, including the changes of the mutating
+	// webhooks called before this one.
+	Job json.RawMessage `json:"job"`
+}
+
+// Response is the body a webhook answers with.
+type Response struct {
+	UID     string `json:"uid"`
+	Allowed bool   `json:"allowed"`
+	// Message is the reason reported to the user when the job is rejected.
+	Message string `json:"message,omitempty"`
+	// Patch is an RFC 6902 JSON patch to the job, applied for mutating webhooks only.
+	Patch json.RawMessage `json:"patch,omitempty"`
+}
+
+type Controller struct {
+	webhooks []*webhook
+}
+
+type webhook struct {
+	config.AdmissionWebhookConfig
+	client *http.Client
+}
+
+// NewController returns the controller for the configured webhooks, or nil when there are none.
+// The webhooks are called through the admission proxy of the proxy config.
+func NewController(logger *slog.Logger, cfg *config.AdmissionConfig, proxyConfig *config.ProxyConfig) (*Controller, error) {
+	if !cfg.IsEnabled() {
+		return nil, nil
+	}
+
+	c := &Controller{}
+	names := map[string]bool{}
+	for _, hookConfig := range cfg.Webhooks {
+		if hookConfig.Name == "" || hookConfig.URL == "" {
+			return nil, fmt.Errorf("admission webhooks require a name and a url")
+		}
+		if names[hookConfig.Name] {
+			return nil, fmt.Errorf("admission webhook %s is configured more than once", hookConfig.Name)
+		}
+		names[hookConfig.Name] = true
+		switch hookConfig.EffectiveFailurePolicy() {
+		case config.AdmissionFailurePolicyFail, config.AdmissionFailurePolicyIgnore:
+		default:
+			return nil, fmt.Errorf("admission webhook %s has an unknown failure policy %q, expected %s or %s",
+				hookConfig.Name, hookConfig.FailurePolicy, config.AdmissionFailurePolicyFail, config.AdmissionFailurePolicyIgnore)
+		}
+
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if hookConfig.CACertPath != "" {
+			caCert, err := os.ReadFile(hookConfig.CACertPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate for admission webhook %s: %w", hookConfig.Name, err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(caCert) {
+				return nil, fmt.Errorf("failed to parse CA certificate for admission webhook %s: no valid PEM certificates in %s", hookConfig.Name, hookConfig.CACertPath)
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		c.webhooks = append(c.webhooks, &webhook{
+			AdmissionWebhookConfig: hookConfig,
+			client: &http.Client{
+				Timeout:   hookConfig.EffectiveTimeout(),
+				Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: proxyConfig.ProxyFunc(config.ProxyDestinationAdmission)},
+			},
+		})
+		logger.Info("Configured admission webhook", "name", hookConfig.Name, "url", hookConfig.URL,
+			"mutating", hookConfig.Mutating, "failure_policy", hookConfig.EffectiveFailurePolicy())
+	}
+
+	// mutating webhooks first, so that the validating webhooks review the final job
+	slices.SortStableFunc(c.webhooks, func(a, b *webhook) int {
+		switch {
+		case a.Mutating == b.Mutating:
+			return 0
+		case a.Mutating:
+			return -1
+		default:
+			return 1
+		}
+	})
+	return c, nil
+}
+
+func (c *Controller) Admit(ctx context.Context, logger *slog.Logger, request *abstractions.JobAdmissionRequest) ([]byte, error) {
+	job, err := json.Marshal(request.Job)
+	if err != nil {
+		return nil, serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+
+	mutated := false
+	for _, hook := range c.webhooks {
+		logger := logger.With("webhook", hook.Name, "job_id", request.JobID, "tenant", request.Tenant, "user", request.User)
+
+		response, err := hook.review(ctx, &Request{
+			UID:       common.GUID(),
+			Operation: OperationCreate,
+			JobID:     request.JobID,
+			Tenant:    request.Tenant,
+			User:      request.User,
+			Job:       job,
+		})
+		var patched []byte
+		if err == nil && response.Allowed && hook.Mutating && hasPatch(response.Patch) {
+			patched, err = applyPatch(job, response.Patch)
+		}
+		if err != nil {
+			if hook.EffectiveFailurePolicy() == config.AdmissionFailurePolicyIgnore {
+				logger.Warn("Admission webhook failed, admitting the job as the failure policy is ignore", "error", err)
+				metrics.RecordAdmissionWebhookCall(ctx, hook.Name, metrics.AdmissionOutcomeIgnored)
+				continue
+			}
+			logger.Error("Admission webhook failed", "error", err)
+			metrics.RecordAdmissionWebhookCall(ctx, hook.Name, metrics.AdmissionOutcomeError)
+			return nil, serviceerrors.NewServiceError(messages.AdmissionWebhookFailed, "Webhook", hook.Name, "Error", err.Error())
+		}
+
+		if !response.Allowed {
+			reason := response.Message
+			if reason == "" {
+				reason = "no reason given"
+			}
+			logger.Info("Admission webhook rejected the evaluation job", "reason", reason)
+			metrics.RecordAdmissionWebhookCall(ctx, hook.Name, metrics.AdmissionOutcomeDenied)
+			return nil, serviceerrors.NewServiceError(messages.AdmissionDenied, "Webhook", hook.Name, "Reason", reason)
+		}
+
+		if patched == nil {
+			if hasPatch(response.Patch) {
+				logger.Warn("Ignoring the patch returned by a validating admission webhook")
+			}
+			metrics.RecordAdmissionWebhookCall(ctx, hook.Name, metrics.AdmissionOutcomeAllowed)
+			continue
+		}
+
+		// audit record of the change made to the user's job
+		logger.Info("Admission webhook mutated the evaluation job", "patch", string(response.Patch))
+		metrics.RecordAdmissionWebhookCall(ctx, hook.Name, metrics.AdmissionOutcomeMutated)
+		job = patched
+		mutated = true
+	}
+
+	if !mutated {
+		return nil, nil
+	}
+	return job, nil
+}
+
+func (w *webhook) review(ctx context.Context, request *Request) (*Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.Header.Set("Accept", "application/json")
+
+	httpResponse, err := w.client.Do(httpRequest)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = httpResponse.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(httpResponse.Body, maxResponseBytes))
+	if err != nil {
+		return nil, err
+	}
+	if httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299 {
+		return nil, fmt.Errorf("unexpected status %d", httpResponse.StatusCode)
+	}
+
+	response := &Response{}
+	if err := json.Unmarshal(data, response); err != nil {
+		return nil, fmt.Errorf("invalid response: %w", err)
+	}
+	if response.UID != request.UID {
+		return nil, errors.New("invalid response: the uid does not match the request")
+	}
+	return response, nil
+}
+
+func hasPatch(patch json.RawMessage) bool {
+	return len(patch) > 0 && string(patch) != "null"
+}
+
+func applyPatch(job []byte, patchJSON json.RawMessage) ([]byte, error) {
+	patch, err := jsonpatch.DecodePatch(patchJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch: %w", err)
+	}
+	patched, err := patch.Apply(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply patch: %w", err)
+	}
+	return patched, nil
+}
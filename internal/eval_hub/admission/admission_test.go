@@ -0,0 +1,217 @@
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// newWebhook serves a webhook that answers every review with respond.
+func newWebhook(t *testing.T, respond func(request *Request) *Response) (*httptest.Server, *[]*Request) {
+	t.Helper()
+	var received []*Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &Request{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received = append(received, request)
+		response := respond(request)
+		if response.UID == "" {
+			response.UID = request.UID
+		}
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return server, &received
+}
+
+func allow(_ *Request) *Response {
+	return &Response{Allowed: true}
+}
+
+func newController(t *testing.T, webhooks ...config.AdmissionWebhookConfig) *Controller {
+	t.Helper()
+	controller, err := NewController(logging.FallbackLogger(), &config.AdmissionConfig{Webhooks: webhooks}, nil)
+	if err != nil {
+		t.Fatalf("NewController: %v", err)
+	}
+	return controller
+}
+
+func admissionRequest() *abstractions.JobAdmissionRequest {
+	return &abstractions.JobAdmissionRequest{
+		JobID:  "job-1",
+		Tenant: "team-a",
+		User:   "alice",
+		Job: &api.EvaluationJobConfig{
+			Name:       "nightly",
+			Model:      api.ModelRef{URL: "http://model", Name: "model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness"}},
+		},
+	}
+}
+
+func messageCode(err error) string {
+	var serviceErr *serviceerrors.ServiceError
+	if !errors.As(err, &serviceErr) {
+		return ""
+	}
+	return serviceErr.MessageCode().GetCode()
+}
+
+func TestAdmit(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.FallbackLogger()
+
+	t.Run("an allowed job is unchanged", func(t *testing.T) {
+		server, received := newWebhook(t, allow)
+		controller := newController(t, config.AdmissionWebhookConfig{Name: "audit", URL: server.URL})
+
+		patched, err := controller.Admit(ctx, logger, admissionRequest())
+		if err != nil {
+			t.Fatalf("Admit: %v", err)
+		}
+		if patched != nil {
+			t.Fatalf("expected no mutation, got %s", patched)
+		}
+		request := (*received)[0]
+		if request.Operation != OperationCreate || request.JobID != "job-1" || request.Tenant != "team-a" || request.User != "alice" {
+			t.Fatalf("unexpected review request %+v", request)
+		}
+	})
+
+	t.Run("a denied job is rejected with the webhook's reason", func(t *testing.T) {
+		server, _ := newWebhook(t, func(_ *Request) *Response {
+			return &Response{Allowed: false, Message: "mmlu is not an approved benchmark"}
+		})
+		controller := newController(t, config.AdmissionWebhookConfig{Name: "approved-benchmarks", URL: server.URL, FailurePolicy: config.AdmissionFailurePolicyIgnore})
+
+		_, err := controller.Admit(ctx, logger, admissionRequest())
+		if messageCode(err) != messages.AdmissionDenied.GetCode() {
+			t.Fatalf("expected %s, got %v", messages.AdmissionDenied.GetCode(), err)
+		}
+	})
+
+	t.Run("mutations are applied in order before validation", func(t *testing.T) {
+		addTag := func(tag string) func(*Request) *Response {
+			return func(request *Request) *Response {
+				job := api.EvaluationJobConfig{}
+				_ = json.Unmarshal(request.Job, &job)
+				op := `[{"op":"add","path":"/tags/-","value":"` + tag + `"}]`
+				if job.Tags == nil {
+					op = `[{"op":"add","path":"/tags","value":["` + tag + `"]}]`
+				}
+				return &Response{Allowed: true, Patch: json.RawMessage(op)}
+			}
+		}
+		first, _ := newWebhook(t, addTag("cost-center-42"))
+		second, _ := newWebhook(t, addTag("reviewed"))
+		validating, validated := newWebhook(t, func(_ *Request) *Response {
+			// patches from validating webhooks are ignored
+			return &Response{Allowed: true, Patch: json.RawMessage(`[{"op":"remove","path":"/tags"}]`)}
+		})
+		// the validating webhook is listed first but called last
+		controller := newController(t,
+			config.AdmissionWebhookConfig{Name: "policy", URL: validating.URL},
+			config.AdmissionWebhookConfig{Name: "cost-center", URL: first.URL, Mutating: true},
+			config.AdmissionWebhookConfig{Name: "review", URL: second.URL, Mutating: true},
+		)
+
+		patched, err := controller.Admit(ctx, logger, admissionRequest())
+		if err != nil {
+			t.Fatalf("Admit: %v", err)
+		}
+		job := api.EvaluationJobConfig{}
+		if err := json.Unmarshal(patched, &job); err != nil {
+			t.Fatalf("decode patched job: %v", err)
+		}
+		if len(job.Tags) != 2 || job.Tags[0] != "cost-center-42" || job.Tags[1] != "reviewed" {
+			t.Fatalf("expected the tags of both mutating webhooks, got %v", job.Tags)
+		}
+		seen := api.EvaluationJobConfig{}
+		_ = json.Unmarshal((*validated)[0].Job, &seen)
+		if len(seen.Tags) != 2 {
+			t.Fatalf("expected the validating webhook to review the mutated job, got tags %v", seen.Tags)
+		}
+	})
+
+	t.Run("failure policy", func(t *testing.T) {
+		slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(500 * time.Millisecond):
+			}
+		}))
+		t.Cleanup(slow.Close)
+		broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}))
+		t.Cleanup(broken.Close)
+		badPatch, _ := newWebhook(t, func(_ *Request) *Response {
+			return &Response{Allowed: true, Patch: json.RawMessage(`[{"op":"replace","path":"/missing/field","value":1}]`)}
+		})
+		wrongUID, _ := newWebhook(t, func(_ *Request) *Response {
+			return &Response{UID: "another-review", Allowed: true}
+		})
+
+		cases := []struct {
+			name    string
+			webhook config.AdmissionWebhookConfig
+		}{
+			{"timeout", config.AdmissionWebhookConfig{URL: slow.URL, Timeout: 50 * time.Millisecond}},
+			{"error status", config.AdmissionWebhookConfig{URL: broken.URL}},
+			{"invalid patch", config.AdmissionWebhookConfig{URL: badPatch.URL, Mutating: true}},
+			{"uid mismatch", config.AdmissionWebhookConfig{URL: wrongUID.URL}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				tc.webhook.Name = "flaky"
+				controller := newController(t, tc.webhook)
+				if _, err := controller.Admit(ctx, logger, admissionRequest()); messageCode(err) != messages.AdmissionWebhookFailed.GetCode() {
+					t.Fatalf("expected %s with the fail policy, got %v", messages.AdmissionWebhookFailed.GetCode(), err)
+				}
+
+				tc.webhook.FailurePolicy = config.AdmissionFailurePolicyIgnore
+				controller = newController(t, tc.webhook)
+				patched, err := controller.Admit(ctx, logger, admissionRequest())
+				if err != nil || patched != nil {
+					t.Fatalf("expected the job to be admitted unchanged with the ignore policy, got %s, %v", patched, err)
+				}
+			})
+		}
+	})
+}
+
+func TestNewController(t *testing.T) {
+	logger := logging.FallbackLogger()
+
+	controller, err := NewController(logger, nil, nil)
+	if err != nil || controller != nil {
+		t.Fatalf("expected no controller without webhooks, got %v, %v", controller, err)
+	}
+
+	invalid := map[string][]config.AdmissionWebhookConfig{
+		"missing url":    {{Name: "policy"}},
+		"duplicate name": {{Name: "policy", URL: "http://a"}, {Name: "policy", URL: "http://b"}},
+		"unknown policy": {{Name: "policy", URL: "http://a", FailurePolicy: "retry"}},
+		"missing CA":     {{Name: "policy", URL: "https://a", CACertPath: "/does/not/exist.pem"}},
+	}
+	for name, webhooks := range invalid {
+		if _, err := NewController(logger, &config.AdmissionConfig{Webhooks: webhooks}, nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package callbackauth
+
+import (
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+)
+
+func TestToken(t *testing.T) {
+	cfg := &config.CallbackAuthConfig{Enabled: true, Secret: "s3cr3t"}
+
+	token := Token(cfg, "job-1")
+	if token == "" {
+		t.Fatal("expected a token when callback authentication is enabled")
+	}
+	if !Verify(cfg, "job-1", token) {
+		t.Fatal("expected the token of the job to be valid")
+	}
+	if Verify(cfg, "job-2", token) {
+		t.Fatal("expected the token of another job to be rejected")
+	}
+	if Verify(cfg, "job-1", "") {
+		t.Fatal("expected a missing token to be rejected")
+	}
+	if Verify(&config.CallbackAuthConfig{Enabled: true, Secret: "other"}, "job-1", token) {
+		t.Fatal("expected a token signed with another secret to be rejected")
+	}
+
+	if Token(nil, "job-1") != "" || !Verify(nil, "job-1", "") {
+		t.Fatal("expected no token to be needed when callback authentication is disabled")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	first, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret: %v", err)
+	}
+	second, _ := GenerateSecret()
+	if first == "" || first == second {
+		t.Fatalf("expected distinct random secrets, got %q and %q", first, second)
+	}
+}
@@ -0,0 +1,184 @@
+// Package circuitbreaker fails the calls to an outbound dependency fast while it is down, so
+// that an outage of the dependency does not hold every request that needs it for the length
+// of its timeouts.
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/metrics"
+)
+
+// ErrOpen is the error of the calls that the breaker rejects while it is open.
+var ErrOpen = errors.New("circuit breaker is open")
+
+type State string
+
+const (
+	// StateClosed lets the calls through and counts their consecutive failures.
+	StateClosed State = "closed"
+	// StateOpen rejects the calls until the open duration has passed.
+	StateOpen State = "open"
+	// StateHalfOpen lets a single trial call through, which closes the breaker when it
+	// succeeds and opens it again when it fails.
+	StateHalfOpen State = "half_open"
+)
+
+// Breaker is a circuit breaker, safe for concurrent use. A nil Breaker lets every call
+// through.
+type Breaker struct {
+	name             string
+	logger           *slog.Logger
+	failureThreshold int
+	openDuration     time.Duration
+	now              func() time.Time
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// New returns the breaker of the dependency name, or nil when the config disables it.
+func New(name string, breakerConfig *config.CircuitBreakerConfig, logger *slog.Logger) *Breaker {
+	if !breakerConfig.IsEnabled() {
+		return nil
+	}
+	return &Breaker{
+		name:             name,
+		logger:           logger,
+		failureThreshold: breakerConfig.EffectiveFailureThreshold(),
+		openDuration:     breakerConfig.EffectiveOpenDuration(),
+		now:              time.Now,
+		state:            StateClosed,
+	}
+}
+
+// State returns the state of the breaker, an open breaker whose open duration has passed is
+// half open.
+func (b *Breaker) State() State {
+	if b == nil {
+		return StateClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateOpen && b.now().Sub(b.openedAt) >= b.openDuration {
+		return StateHalfOpen
+	}
+	return b.state
+}
+
+// Allow returns ErrOpen when the call must not be made. Every allowed call must be followed
+// by a call to Record with its outcome.
+func (b *Breaker) Allow() error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.openDuration {
+			return fmt.Errorf("%s: %w", b.name, ErrOpen)
+		}
+		b.transition(StateHalfOpen)
+		b.trial = true
+		return nil
+	case StateHalfOpen:
+		if b.trial {
+			return fmt.Errorf("%s: %w", b.name, ErrOpen)
+		}
+		b.trial = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record records the outcome of an allowed call.
+func (b *Breaker) Record(failed bool) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == StateHalfOpen {
+		b.trial = false
+		if failed {
+			b.open()
+		} else {
+			b.failures = 0
+			b.transition(StateClosed)
+		}
+		return
+	}
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == StateClosed && b.failures >= b.failureThreshold {
+		b.open()
+	}
+}
+
+// abandon ends an allowed call without an outcome, so that a half open breaker lets another
+// trial call through.
+func (b *Breaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
+
+func (b *Breaker) open() {
+	b.openedAt = b.now()
+	b.transition(StateOpen)
+}
+
+func (b *Breaker) transition(state State) {
+	if b.state == state {
+		return
+	}
+	b.logger.Warn("Circuit breaker changed state", "dependency", b.name, "from", b.state, "to", state, "failures", b.failures)
+	metrics.RecordCircuitBreakerTransition(context.Background(), b.name, string(state))
+	b.state = state
+}
+
+// Transport returns a round tripper that makes the requests through next while the breaker
+// allows them. Transport errors and the 5xx and 429 responses count as failures.
+func (b *Breaker) Transport(next http.RoundTripper) http.RoundTripper {
+	if b == nil {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{breaker: b, next: next}
+}
+
+type transport struct {
+	breaker *Breaker
+	next    http.RoundTripper
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	resp, err := t.next.RoundTrip(req)
+	// a request cancelled by its caller says nothing about the dependency
+	if err != nil && req.Context().Err() != nil {
+		t.breaker.abandon()
+		return resp, err
+	}
+	t.breaker.Record(err != nil || resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests)
+	return resp, err
+}
@@ -0,0 +1,126 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+)
+
+func newTestBreaker(t *testing.T, threshold int, openDuration time.Duration) (*Breaker, *time.Time) {
+	t.Helper()
+	now := time.Now()
+	breaker := New("test", &config.CircuitBreakerConfig{FailureThreshold: threshold, OpenDuration: openDuration}, slog.New(slog.DiscardHandler))
+	breaker.now = func() time.Time { return now }
+	return breaker, &now
+}
+
+func TestBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	breaker, now := newTestBreaker(t, 3, time.Minute)
+
+	for range 2 {
+		if err := breaker.Allow(); err != nil {
+			t.Fatalf("Allow() err = %v", err)
+		}
+		breaker.Record(true)
+	}
+	// a success resets the count of consecutive failures
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("Allow() err = %v", err)
+	}
+	breaker.Record(false)
+	for range 3 {
+		if err := breaker.Allow(); err != nil {
+			t.Fatalf("Allow() err = %v", err)
+		}
+		breaker.Record(true)
+	}
+	if breaker.State() != StateOpen {
+		t.Fatalf("state = %s, want open", breaker.State())
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Allow() err = %v, want ErrOpen", err)
+	}
+
+	*now = now.Add(time.Minute)
+	if breaker.State() != StateHalfOpen {
+		t.Fatalf("state = %s, want half_open", breaker.State())
+	}
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("expected a trial call, got %v", err)
+	}
+	if err := breaker.Allow(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("expected a single trial call, got %v", err)
+	}
+	breaker.Record(true)
+	if breaker.State() != StateOpen {
+		t.Fatalf("state = %s, a failed trial must open the breaker again", breaker.State())
+	}
+
+	*now = now.Add(time.Minute)
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("expected a trial call, got %v", err)
+	}
+	breaker.Record(false)
+	if breaker.State() != StateClosed {
+		t.Fatalf("state = %s, a successful trial must close the breaker", breaker.State())
+	}
+}
+
+func TestDisabledBreakerAllowsEveryCall(t *testing.T) {
+	breaker := New("test", &config.CircuitBreakerConfig{Disabled: true}, slog.New(slog.DiscardHandler))
+	if breaker != nil {
+		t.Fatal("expected no breaker when it is disabled")
+	}
+	for range 10 {
+		breaker.Record(true)
+	}
+	if err := breaker.Allow(); err != nil {
+		t.Fatalf("Allow() err = %v", err)
+	}
+	if next := http.DefaultTransport; breaker.Transport(next) != next {
+		t.Fatal("expected the transport to be unchanged")
+	}
+}
+
+func TestTransportCountsServerErrors(t *testing.T) {
+	status := http.StatusNotFound
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	breaker, _ := newTestBreaker(t, 2, time.Hour)
+	client := &http.Client{Transport: breaker.Transport(http.DefaultTransport)}
+	get := func() error {
+		resp, err := client.Get(srv.URL)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		return err
+	}
+
+	// client errors say nothing about the health of the dependency
+	for range 3 {
+		if err := get(); err != nil {
+			t.Fatalf("Get() err = %v", err)
+		}
+	}
+	if breaker.State() != StateClosed {
+		t.Fatalf("state = %s, want closed", breaker.State())
+	}
+
+	status = http.StatusServiceUnavailable
+	for range 2 {
+		if err := get(); err != nil {
+			t.Fatalf("Get() err = %v", err)
+		}
+	}
+	if err := get(); !errors.Is(err, ErrOpen) {
+		t.Fatalf("Get() err = %v, want ErrOpen", err)
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+import "time"
+
+const (
+	// AdmissionFailurePolicyFail rejects the job when the webhook cannot be reached or
+	// returns an invalid response.
+	AdmissionFailurePolicyFail = "fail"
+	// AdmissionFailurePolicyIgnore admits the job as if the webhook had allowed it.
+	AdmissionFailurePolicyIgnore = "ignore"
+
+	DefaultAdmissionWebhookTimeout = 10 * time.Second
+)
+
+// AdmissionConfig lists the webhooks that review an evaluation job before it is stored.
+// Mutating webhooks are called first, in order, each seeing the previous one's changes;
+// the validating webhooks then see the final job.
+type AdmissionConfig struct {
+	Webhooks []AdmissionWebhookConfig `mapstructure:"webhooks,omitempty"`
+}
+
+type AdmissionWebhookConfig struct {
+	Name string `mapstructure:"name"`
+	URL  string `mapstructure:"url"`
+	// Mutating webhooks may return a JSON patch to apply to the job.
+	Mutating      bool          `mapstructure:"mutating,omitempty"`
+	Timeout       time.Duration `mapstructure:"timeout,omitempty"`
+	FailurePolicy string        `mapstructure:"failure_policy,omitempty"`
+	// CACertPath is a PEM CA bundle for webhooks served with a private CA.
+	CACertPath string `mapstructure:"ca_cert_path,omitempty"`
+}
+
+func (c *AdmissionConfig) IsEnabled() bool {
+	return c != nil && len(c.Webhooks) > 0
+}
+
+func (c *AdmissionWebhookConfig) EffectiveTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return DefaultAdmissionWebhookTimeout
+	}
+	return c.Timeout
+}
+
+func (c *AdmissionWebhookConfig) EffectiveFailurePolicy() string {
+	if c.FailurePolicy == "" {
+		return AdmissionFailurePolicyFail
+	}
+	return c.FailurePolicy
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+// DefaultArgoExitHandlerImage is the image of the exit handler of the workflows, which only
+// needs a shell and curl.
+const DefaultArgoExitHandlerImage = "curlimages/curl:8.10.1"
+
+// ArgoConfig is how the argo runtime submits the evaluation jobs as Argo Workflows. The pods
+// of a workflow have no sidecar, the adapters post their status events straight to eval-hub
+// at EvalHubURL.
+type ArgoConfig struct {
+	// Namespace is where the workflows are submitted, the namespace of the service by default.
+	Namespace string `mapstructure:"namespace,omitempty"`
+	// ServiceAccount runs the pods of the workflows, e.g. with the permissions of the Argo
+	// executor. The default service account of the namespace when omitted.
+	ServiceAccount string `mapstructure:"service_account,omitempty"`
+	// EvalHubURL is the URL at which the pods of the workflows reach eval-hub.
+	EvalHubURL string `mapstructure:"eval_hub_url"`
+	// ExitHandlerImage runs the exit handler that reports the failed benchmarks of a workflow.
+	ExitHandlerImage string `mapstructure:"exit_handler_image,omitempty"`
+	// ArchiveData saves the /data directory of each benchmark as an output artifact, in the
+	// artifact repository configured for Argo in the namespace.
+	ArchiveData bool `mapstructure:"archive_data,omitempty"`
+	// TTL is how long the finished workflows are kept, forever when omitted.
+	TTL time.Duration `mapstructure:"ttl,omitempty"`
+}
+
+func (c *ArgoConfig) EffectiveExitHandlerImage() string {
+	if c == nil || c.ExitHandlerImage == "" {
+		return DefaultArgoExitHandlerImage
+	}
+	return c.ExitHandlerImage
+}
+
+// Validate checks that eval-hub can be reached from the workflows.
+func (c *ArgoConfig) Validate() error {
+	if c == nil || c.EvalHubURL == "" {
+		return errors.New("argo.eval_hub_url is required by the argo runtime")
+	}
+	u, err := url.Parse(c.EvalHubURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("argo.eval_hub_url must be an http or https URL")
+	}
+	if c.TTL < 0 {
+		return errors.New("argo.ttl must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package config
+
+// DefaultMaxArtifactBytes is applied when artifacts.max_size_bytes is omitted or zero.
+const DefaultMaxArtifactBytes int64 = 1 << 30 // 1 GiB
+
+// ArtifactsConfig bounds the intermediate artifacts that adapters upload for the benchmarks
+// of a job. The artifacts are streamed to the MLflow artifact store of the job experiment,
+// so they are not held by the service, and are not subject to service.max_request_body_bytes.
+type ArtifactsConfig struct {
+	// MaxSizeBytes is the largest artifact accepted, -1 for no limit.
+	MaxSizeBytes int64 `mapstructure:"max_size_bytes,omitempty"`
+	// GenerationsKey is the secret from which the keys that encrypt the raw generations of
+	// each tenant are derived. Generations are rejected without it. All the replicas must
+	// share it, and it can not be changed without losing the generations stored before.
+	GenerationsKey string `mapstructure:"generations_key,omitempty" json:"-"`
+}
+
+func (c *ArtifactsConfig) EffectiveMaxSizeBytes() int64 {
+	if c == nil || c.MaxSizeBytes == 0 {
+		return DefaultMaxArtifactBytes
+	}
+	return c.MaxSizeBytes
+}
+
+// HasGenerationsKey returns true when the raw generations can be encrypted.
+func (c *ArtifactsConfig) HasGenerationsKey() bool {
+	return c != nil && c.GenerationsKey != ""
+}
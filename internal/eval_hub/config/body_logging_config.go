@@ -0,0 +1,140 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// DefaultBodyLoggingMaxBytes is applied when body_logging.max_bytes is omitted or zero.
+const DefaultBodyLoggingMaxBytes = 64 << 10 // 64 KiB
+
+// DefaultBodyLoggingRedactedFields are always redacted from logged bodies, in addition to
+// body_logging.redacted_fields.
+var DefaultBodyLoggingRedactedFields = []string{
+	"model.auth",
+	"callback_token",
+	"token",
+	"password",
+	"secret",
+	"api_key",
+}
+
+// BodyLoggingConfig turns on the logging of request and response bodies, to diagnose
+// malformed payloads sent by SDKs. It is meant to be enabled for a while for the routes or
+// tenants under investigation, since bodies can be large and hold user data: JSON bodies
+// are logged with the redacted fields replaced, other and larger bodies only by their size.
+type BodyLoggingConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Routes are the path prefixes whose bodies are logged, e.g. /api/v1/evaluations/jobs.
+	// Empty logs every route.
+	Routes []string `mapstructure:"routes,omitempty"`
+	// Tenants are the tenants (X-Tenant) whose bodies are logged. Empty logs every tenant.
+	Tenants []string `mapstructure:"tenants,omitempty"`
+	// MaxBytes is the size of the largest body that is logged; larger ones are logged by
+	// their size only. Zero or unset uses DefaultBodyLoggingMaxBytes.
+	MaxBytes int `mapstructure:"max_bytes,omitempty"`
+	// RedactedFields are the dotted paths of the JSON fields to redact, e.g. model.auth,
+	// on top of DefaultBodyLoggingRedactedFields. A path matches at any depth, so token
+	// also redacts model.auth.token.
+	RedactedFields []string `mapstructure:"redacted_fields,omitempty"`
+}
+
+func (c *BodyLoggingConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// Matches returns true when the bodies of a request to path from tenant are logged.
+func (c *BodyLoggingConfig) Matches(path string, tenant string) bool {
+	if !c.IsEnabled() {
+		return false
+	}
+	if len(c.Routes) > 0 && !slices.ContainsFunc(c.Routes, func(route string) bool {
+		route = strings.TrimSuffix(route, "/")
+		return path == route || strings.HasPrefix(path, route+"/")
+	}) {
+		return false
+	}
+	return len(c.Tenants) == 0 || slices.Contains(c.Tenants, tenant)
+}
+
+func (c *BodyLoggingConfig) EffectiveMaxBytes() int {
+	if c == nil || c.MaxBytes <= 0 {
+		return DefaultBodyLoggingMaxBytes
+	}
+	return c.MaxBytes
+}
+
+func (c *BodyLoggingConfig) EffectiveRedactedFields() []string {
+	if c == nil {
+		return DefaultBodyLoggingRedactedFields
+	}
+	return append(slices.Clone(DefaultBodyLoggingRedactedFields), c.RedactedFields...)
+}
+
+// RedactBody returns the decoded JSON body v with the values of the redacted fields
+// replaced. A field matches the keys whose path ends with its dotted path, at any depth
+// and in the objects of arrays, so that the secrets nested in a request are redacted as
+// well. The value of a JSON patch operation is redacted when its path ends with a
+// redacted field, e.g. {"op":"replace","path":"/model/auth","value":...}.
+func RedactBody(v any, fields []string) any {
+	paths := make([][]string, 0, len(fields))
+	for _, field := range fields {
+		if field != "" {
+			paths = append(paths, strings.Split(field, "."))
+		}
+	}
+	return redactBodyValue(v, nil, paths)
+}
+
+func redactBodyValue(v any, parents []string, paths [][]string) any {
+	switch value := v.(type) {
+	case map[string]any:
+		patchPath, isPatch := jsonPatchPath(value)
+		for key, item := range value {
+			keyPath := append(slices.Clip(parents), key)
+			if isPatch && key == "value" {
+				keyPath = patchPath
+			}
+			if isRedactedPath(keyPath, paths) {
+				value[key] = sanitiseValue(item)
+				continue
+			}
+			value[key] = redactBodyValue(item, keyPath, paths)
+		}
+		return value
+	case []any:
+		for i, item := range value {
+			value[i] = redactBodyValue(item, parents, paths)
+		}
+		return value
+	default:
+		return v
+	}
+}
+
+var jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// jsonPatchPath returns the keys of the path of a JSON patch operation (RFC 6902).
+func jsonPatchPath(operation map[string]any) ([]string, bool) {
+	op, _ := operation["op"].(string)
+	path, ok := operation["path"].(string)
+	if op == "" || !ok || !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+	keys := strings.Split(path[1:], "/")
+	for i, key := range keys {
+		keys[i] = jsonPointerUnescaper.Replace(key)
+	}
+	return keys, true
+}
+
+// isRedactedPath returns true when keyPath ends with one of the redacted paths, ignoring
+// case.
+func isRedactedPath(keyPath []string, paths [][]string) bool {
+	return slices.ContainsFunc(paths, func(path []string) bool {
+		if len(path) > len(keyPath) {
+			return false
+		}
+		return slices.EqualFunc(keyPath[len(keyPath)-len(path):], path, strings.EqualFold)
+	})
+}
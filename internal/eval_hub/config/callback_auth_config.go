@@ -0,0 +1,17 @@
+package config
+
+// CallbackAuthConfig requires adapters to authenticate the status events they post for a job.
+// Every job is given a token signed with Secret, in its job spec, and events for the job are
+// rejected unless they carry it. All the replicas of the service must share the secret; it is
+// best mapped from a secret file (see secrets.mappings).
+//
+// It is disabled by default: the adapters that read job spec version 1, and those that post
+// events without the sidecar and do not send the token, would have their jobs rejected.
+type CallbackAuthConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	Secret  string `mapstructure:"secret,omitempty" json:"-"`
+}
+
+func (c *CallbackAuthConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
@@ -0,0 +1,18 @@
+package config
+
+// CapacityCheckConfig makes the Kubernetes runtime check, before it creates the Job of a
+// benchmark, whether a node of the cluster has the CPU, memory and GPUs that the pod requests
+// free. A benchmark that does not fit is still dispatched, but its Job is annotated and the
+// benchmark reports why it is likely to stay pending, rather than sit in Pending without an
+// explanation. The service account of the service needs to list the nodes and the pods of
+// the cluster.
+type CapacityCheckConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Autoscaling is set when the cluster autoscaler can add nodes, the benchmarks that do
+	// not fit then report that they wait for a new node rather than for free resources.
+	Autoscaling bool `mapstructure:"autoscaling,omitempty"`
+}
+
+func (c *CapacityCheckConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultCircuitBreakerFailureThreshold = 5
+	DefaultCircuitBreakerOpenDuration     = 30 * time.Second
+)
+
+// CircuitBreakerConfig is how a circuit breaker guards the calls to an outbound dependency:
+// after FailureThreshold consecutive failures the calls fail fast for OpenDuration, then a
+// single trial call decides whether the dependency is back.
+type CircuitBreakerConfig struct {
+	Disabled         bool          `mapstructure:"disabled,omitempty"`
+	FailureThreshold int           `mapstructure:"failure_threshold,omitempty"`
+	OpenDuration     time.Duration `mapstructure:"open_duration,omitempty"`
+}
+
+func (c *CircuitBreakerConfig) Validate(name string) error {
+	if c == nil {
+		return nil
+	}
+	if c.FailureThreshold < 0 {
+		return errors.New(name + ".failure_threshold must not be negative")
+	}
+	if c.OpenDuration < 0 {
+		return errors.New(name + ".open_duration must not be negative")
+	}
+	return nil
+}
+
+// IsEnabled is true unless the breaker is disabled, the breaker is on by default.
+func (c *CircuitBreakerConfig) IsEnabled() bool {
+	return c == nil || !c.Disabled
+}
+
+func (c *CircuitBreakerConfig) EffectiveFailureThreshold() int {
+	if c == nil || c.FailureThreshold == 0 {
+		return DefaultCircuitBreakerFailureThreshold
+	}
+	return c.FailureThreshold
+}
+
+func (c *CircuitBreakerConfig) EffectiveOpenDuration() time.Duration {
+	if c == nil || c.OpenDuration == 0 {
+		return DefaultCircuitBreakerOpenDuration
+	}
+	return c.OpenDuration
+}
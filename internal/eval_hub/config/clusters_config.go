@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+)
+
+// LocalClusterName names the cluster of the service, a job that names it is not dispatched
+// to a remote cluster whatever the cluster of its tenant or provider.
+const LocalClusterName = "local"
+
+// ClustersConfig registers remote Kubernetes clusters that the Kubernetes runtime dispatches
+// evaluation jobs to, e.g. GPU clusters apart from the control-plane cluster that hosts the
+// service. A benchmark runs on the cluster that its job names, else on the cluster of its
+// provider, else on the cluster of its tenant, else on the cluster of the service.
+type ClustersConfig struct {
+	Remote []ClusterConfig `mapstructure:"remote"`
+	// Tenants and Providers map tenant and provider IDs to the name of their cluster.
+	Tenants   map[string]string `mapstructure:"tenants,omitempty"`
+	Providers map[string]string `mapstructure:"providers,omitempty"`
+}
+
+// ClusterConfig is a remote cluster and the credentials the service uses to reach it.
+type ClusterConfig struct {
+	Name string `mapstructure:"name"`
+	// Kubeconfig is the path of the kubeconfig file of the cluster, e.g. mounted from a secret.
+	Kubeconfig string `mapstructure:"kubeconfig"`
+	// Context is the context of the kubeconfig to use, its current context when empty.
+	Context string `mapstructure:"context,omitempty"`
+	// EvalHubURL is the URL of the service that the sidecars of the jobs on the cluster send
+	// their status events to, as the in-cluster URL of the service does not resolve there.
+	EvalHubURL string `mapstructure:"eval_hub_url"`
+}
+
+// Validate checks that the clusters have unique names, a kubeconfig and a URL of the service,
+// and that the tenants and providers are mapped to registered clusters.
+func (c *ClustersConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	var names []string
+	for i, cluster := range c.Remote {
+		if cluster.Name == "" {
+			return fmt.Errorf("clusters.remote[%d].name is required", i)
+		}
+		if cluster.Name == LocalClusterName {
+			return fmt.Errorf("clusters.remote[%d].name %q is reserved for the cluster of the service", i, cluster.Name)
+		}
+		if slices.Contains(names, cluster.Name) {
+			return fmt.Errorf("clusters.remote[%d].name %q is not unique", i, cluster.Name)
+		}
+		if cluster.Kubeconfig == "" {
+			return fmt.Errorf("clusters.remote[%d].kubeconfig is required", i)
+		}
+		if cluster.EvalHubURL == "" {
+			return fmt.Errorf("clusters.remote[%d].eval_hub_url is required", i)
+		}
+		names = append(names, cluster.Name)
+	}
+	for _, mapping := range []struct {
+		name     string
+		clusters map[string]string
+	}{{"tenants", c.Tenants}, {"providers", c.Providers}} {
+		for id, name := range mapping.clusters {
+			if !slices.Contains(names, name) {
+				return fmt.Errorf("clusters.%s.%s: the cluster %q is not registered", mapping.name, id, name)
+			}
+		}
+	}
+	return nil
+}
+
+// Names returns the names of the registered clusters.
+func (c *ClustersConfig) Names() []string {
+	if c == nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.Remote))
+	for _, cluster := range c.Remote {
+		names = append(names, cluster.Name)
+	}
+	return names
+}
+
+// Cluster returns the registered cluster with the name, nil when there is none.
+func (c *ClustersConfig) Cluster(name string) *ClusterConfig {
+	if c == nil || name == "" {
+		return nil
+	}
+	for i := range c.Remote {
+		if c.Remote[i].Name == name {
+			return &c.Remote[i]
+		}
+	}
+	return nil
+}
+
+// ClusterFor returns the name of the cluster that a benchmark of the provider runs on for a
+// job of the tenant that names jobCluster, empty for the cluster of the service.
+func (c *ClustersConfig) ClusterFor(jobCluster, providerID, tenant string) string {
+	if c == nil {
+		return ""
+	}
+	if jobCluster == LocalClusterName {
+		return ""
+	}
+	if jobCluster != "" {
+		return jobCluster
+	}
+	if name := c.Providers[providerID]; name != "" {
+		return name
+	}
+	return c.Tenants[tenant]
+}
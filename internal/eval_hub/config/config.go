@@ -6,12 +6,36 @@ const (
 )
 
 type Config struct {
-	Service    *ServiceConfig    `mapstructure:"service"`
-	Database   *map[string]any   `mapstructure:"database"`
-	MLFlow     *MLFlowConfig     `mapstructure:"mlflow,omitempty"`
-	OTEL       *OTELConfig       `mapstructure:"otel,omitempty"`
-	Prometheus *PrometheusConfig `mapstructure:"prometheus,omitempty"`
-	Sidecar    *SidecarConfig    `mapstructure:"sidecar,omitempty"`
+	Service          *ServiceConfig          `mapstructure:"service"`
+	Database         *map[string]any         `mapstructure:"database"`
+	MLFlow           *MLFlowConfig           `mapstructure:"mlflow,omitempty"`
+	OTEL             *OTELConfig             `mapstructure:"otel,omitempty"`
+	Prometheus       *PrometheusConfig       `mapstructure:"prometheus,omitempty"`
+	Sidecar          *SidecarConfig          `mapstructure:"sidecar,omitempty"`
+	Events           *EventsConfig           `mapstructure:"events,omitempty"`
+	Admission        *AdmissionConfig        `mapstructure:"admission,omitempty"`
+	ResultCache      *ResultCacheConfig      `mapstructure:"result_cache,omitempty"`
+	Sampling         *SamplingConfig         `mapstructure:"sampling,omitempty"`
+	JobEnv           *JobEnvConfig           `mapstructure:"job_env,omitempty"`
+	ProviderCache    *ProviderCacheConfig    `mapstructure:"provider_cache,omitempty"`
+	PostProcessing   *PostProcessingConfig   `mapstructure:"post_processing,omitempty"`
+	CallbackAuth     *CallbackAuthConfig     `mapstructure:"callback_auth,omitempty"`
+	BodyLogging      *BodyLoggingConfig      `mapstructure:"body_logging,omitempty"`
+	JobAccess        *JobAccessConfig        `mapstructure:"job_access,omitempty"`
+	Proxy            *ProxyConfig            `mapstructure:"proxy,omitempty"`
+	ImageWarmup      *ImageWarmupConfig      `mapstructure:"image_warmup,omitempty"`
+	CapacityCheck    *CapacityCheckConfig    `mapstructure:"capacity_check,omitempty"`
+	Clusters         *ClustersConfig         `mapstructure:"clusters,omitempty"`
+	ImagePolicy      *ImagePolicyConfig      `mapstructure:"image_policy,omitempty"`
+	Artifacts        *ArtifactsConfig        `mapstructure:"artifacts,omitempty"`
+	Notifications    *NotificationsConfig    `mapstructure:"notifications,omitempty"`
+	ParameterSecrets *ParameterSecretsConfig `mapstructure:"parameter_secrets,omitempty"`
+	LocalJobs        *LocalJobsConfig        `mapstructure:"local_jobs,omitempty"`
+	LocalContainers  *LocalContainersConfig  `mapstructure:"local_containers,omitempty"`
+	LocalSandbox     *LocalSandboxConfig     `mapstructure:"local_sandbox,omitempty"`
+	MockRuntime      *MockRuntimeConfig      `mapstructure:"mock_runtime,omitempty"`
+	Argo             *ArgoConfig             `mapstructure:"argo,omitempty"`
+	LMEvalJob        *LMEvalJobConfig        `mapstructure:"lmevaljob,omitempty"`
 }
 
 // IsOTELEnabled reports whether OpenTelemetry export is turned on in config.
@@ -208,6 +208,29 @@ func TestServiceConfig_TLS(t *testing.T) {
 			t.Error("key only: want error")
 		}
 	})
+	t.Run("ValidateTLSConfig client auth", func(t *testing.T) {
+		valid := []*config.ServiceConfig{
+			{TLSCertFile: "/c", TLSKeyFile: "/k", TLSClientAuth: config.TLSClientAuthNone},
+			{TLSCertFile: "/c", TLSKeyFile: "/k", TLSClientAuth: config.TLSClientAuthOptional, TLSClientCAFile: "/ca"},
+			{TLSCertFile: "/c", TLSKeyFile: "/k", TLSClientAuth: config.TLSClientAuthRequire, TLSClientCAFile: "/ca"},
+		}
+		for _, c := range valid {
+			if err := c.ValidateTLSConfig(); err != nil {
+				t.Errorf("%#v: %v", c, err)
+			}
+		}
+		invalid := []*config.ServiceConfig{
+			{TLSCertFile: "/c", TLSKeyFile: "/k", TLSClientAuth: config.TLSClientAuthRequire},
+			{TLSClientAuth: config.TLSClientAuthOptional, TLSClientCAFile: "/ca"},
+			{TLSCertFile: "/c", TLSKeyFile: "/k", TLSClientAuth: "always", TLSClientCAFile: "/ca"},
+			{TLSCertFile: "/c", TLSKeyFile: "/k", TLSReloadInterval: -1},
+		}
+		for _, c := range invalid {
+			if err := c.ValidateTLSConfig(); err == nil {
+				t.Errorf("%#v: want error", c)
+			}
+		}
+	})
 }
 
 func TestServiceConfig_HTTP(t *testing.T) {
@@ -285,6 +308,15 @@ func TestServiceConfig_HTTP(t *testing.T) {
 			t.Errorf("explicit: got %d", got)
 		}
 	})
+	t.Run("EffectiveCompressionMinBytes", func(t *testing.T) {
+		var c *config.ServiceConfig
+		if got := c.EffectiveCompressionMinBytes(); got != config.DefaultCompressionMinBytes {
+			t.Errorf("nil: got %d", got)
+		}
+		if got := (&config.ServiceConfig{CompressionMinBytes: 4096}).EffectiveCompressionMinBytes(); got != 4096 {
+			t.Errorf("explicit: got %d", got)
+		}
+	})
 	t.Run("ValidateHTTPConfig", func(t *testing.T) {
 		if err := (&config.ServiceConfig{}).ValidateHTTPConfig(); err != nil {
 			t.Errorf("empty: %v", err)
@@ -307,5 +339,249 @@ func TestServiceConfig_HTTP(t *testing.T) {
 		if err := (&config.ServiceConfig{MaxRequestBodyBytes: -2}).ValidateHTTPConfig(); err == nil {
 			t.Error("max body < -1: want error")
 		}
+		if err := (&config.ServiceConfig{CompressionMinBytes: -1}).ValidateHTTPConfig(); err == nil {
+			t.Error("negative compression_min_bytes: want error")
+		}
+	})
+}
+
+func TestBodyLoggingConfig(t *testing.T) {
+	t.Run("Matches", func(t *testing.T) {
+		var off *config.BodyLoggingConfig
+		if off.Matches("/api/v1/evaluations/jobs", "team-a") {
+			t.Error("nil config: want no match")
+		}
+		all := &config.BodyLoggingConfig{Enabled: true}
+		if !all.Matches("/api/v1/evaluations/providers", "") {
+			t.Error("no routes or tenants: want every request to match")
+		}
+		c := &config.BodyLoggingConfig{Enabled: true, Routes: []string{"/api/v1/evaluations/jobs/"}, Tenants: []string{"team-a"}}
+		tests := []struct {
+			path   string
+			tenant string
+			want   bool
+		}{
+			{"/api/v1/evaluations/jobs", "team-a", true},
+			{"/api/v1/evaluations/jobs/j1/events", "team-a", true},
+			{"/api/v1/evaluations/jobsx", "team-a", false},
+			{"/api/v1/evaluations/jobs", "team-b", false},
+		}
+		for _, tt := range tests {
+			if got := c.Matches(tt.path, tt.tenant); got != tt.want {
+				t.Errorf("Matches(%q, %q) = %v, want %v", tt.path, tt.tenant, got, tt.want)
+			}
+		}
+	})
+	t.Run("EffectiveMaxBytes", func(t *testing.T) {
+		if got := (&config.BodyLoggingConfig{}).EffectiveMaxBytes(); got != config.DefaultBodyLoggingMaxBytes {
+			t.Errorf("default: got %d", got)
+		}
+		if got := (&config.BodyLoggingConfig{MaxBytes: 10}).EffectiveMaxBytes(); got != 10 {
+			t.Errorf("explicit: got %d", got)
+		}
+	})
+	t.Run("EffectiveRedactedFields", func(t *testing.T) {
+		got := (&config.BodyLoggingConfig{RedactedFields: []string{"custom.api_token"}}).EffectiveRedactedFields()
+		if len(got) != len(config.DefaultBodyLoggingRedactedFields)+1 || got[len(got)-1] != "custom.api_token" {
+			t.Errorf("got %v", got)
+		}
+	})
+}
+
+func TestProxyConfig(t *testing.T) {
+	for _, name := range []string{"HTTP_PROXY", "http_proxy", "HTTPS_PROXY", "https_proxy", "NO_PROXY", "no_proxy"} {
+		t.Setenv(name, "")
+	}
+	proxyURL := func(t *testing.T, c *config.ProxyConfig, destination string, target string) string {
+		t.Helper()
+		proxy := c.ProxyFunc(destination)
+		if proxy == nil {
+			return ""
+		}
+		req, err := http.NewRequest(http.MethodGet, target, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		u, err := proxy(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u == nil {
+			return ""
+		}
+		return u.String()
+	}
+
+	t.Run("direct without environment or config", func(t *testing.T) {
+		var c *config.ProxyConfig
+		if got := proxyURL(t, c, config.ProxyDestinationMLFlow, "https://mlflow.example.com"); got != "" {
+			t.Errorf("got proxy %q, want a direct connection", got)
+		}
+	})
+
+	t.Run("honors the environment", func(t *testing.T) {
+		t.Setenv("HTTPS_PROXY", "http://env-proxy:3128")
+		t.Setenv("NO_PROXY", ".svc")
+		var c *config.ProxyConfig
+		if got := proxyURL(t, c, config.ProxyDestinationMLFlow, "https://mlflow.example.com"); got != "http://env-proxy:3128" {
+			t.Errorf("got proxy %q, want the proxy of HTTPS_PROXY", got)
+		}
+		if got := proxyURL(t, c, config.ProxyDestinationMLFlow, "https://mlflow.team.svc"); got != "" {
+			t.Errorf("got proxy %q for a NO_PROXY host, want a direct connection", got)
+		}
+	})
+
+	t.Run("config overrides the environment per destination", func(t *testing.T) {
+		t.Setenv("HTTPS_PROXY", "http://env-proxy:3128")
+		c := &config.ProxyConfig{
+			ProxySettings: config.ProxySettings{HTTPSProxy: "http://egress:8080", NoProxy: "internal.example.com"},
+			Destinations: map[string]config.ProxySettings{
+				config.ProxyDestinationAdmission: {Direct: true},
+				config.ProxyDestinationOCI:       {HTTPSProxy: "http://registry-proxy:8080"},
+			},
+		}
+		if got := proxyURL(t, c, config.ProxyDestinationMLFlow, "https://mlflow.example.com"); got != "http://egress:8080" {
+			t.Errorf("mlflow: got proxy %q, want the default proxy of the config", got)
+		}
+		if got := proxyURL(t, c, config.ProxyDestinationMLFlow, "https://internal.example.com"); got != "" {
+			t.Errorf("mlflow: got proxy %q for a no_proxy host, want a direct connection", got)
+		}
+		if got := proxyURL(t, c, config.ProxyDestinationAdmission, "https://webhook.example.com"); got != "" {
+			t.Errorf("admission: got proxy %q, want a direct connection", got)
+		}
+		if got := proxyURL(t, c, config.ProxyDestinationOCI, "https://quay.io"); got != "http://registry-proxy:8080" {
+			t.Errorf("oci: got proxy %q, want the proxy of the destination", got)
+		}
+		if settings := c.Settings(config.ProxyDestinationOCI); settings.NoProxy != "internal.example.com" {
+			t.Errorf("oci: got no_proxy %q, want the default no_proxy of the config", settings.NoProxy)
+		}
+	})
+
+	t.Run("a destination proxy overrides a direct default", func(t *testing.T) {
+		t.Setenv("HTTPS_PROXY", "http://env-proxy:3128")
+		c := &config.ProxyConfig{
+			ProxySettings: config.ProxySettings{Direct: true},
+			Destinations:  map[string]config.ProxySettings{config.ProxyDestinationModel: {HTTPSProxy: "http://model-proxy:8080"}},
+		}
+		if got := proxyURL(t, c, config.ProxyDestinationMLFlow, "https://mlflow.example.com"); got != "" {
+			t.Errorf("mlflow: got proxy %q, want a direct connection", got)
+		}
+		if got := proxyURL(t, c, config.ProxyDestinationModel, "https://model.example.com"); got != "http://model-proxy:8080" {
+			t.Errorf("model: got proxy %q, want the proxy of the destination", got)
+		}
+	})
+}
+
+func TestRequestTimeouts(t *testing.T) {
+	t.Run("defaults by method and route", func(t *testing.T) {
+		var c *config.RequestTimeoutsConfig
+		if got := c.Timeout(http.MethodGet, "/api/v1/evaluations/jobs/{job_id}"); got != config.DefaultReadRequestTimeout {
+			t.Errorf("GET: got %s want %s", got, config.DefaultReadRequestTimeout)
+		}
+		if got := c.Timeout(http.MethodDelete, "/api/v1/evaluations/jobs/{job_id}"); got != config.DefaultRequestTimeout {
+			t.Errorf("DELETE: got %s want %s", got, config.DefaultRequestTimeout)
+		}
+		if got := c.Timeout(http.MethodGet, "/api/v1/admin/export"); got != 10*time.Minute {
+			t.Errorf("export: got %s want 10m", got)
+		}
+	})
+
+	t.Run("configured deadlines", func(t *testing.T) {
+		c := &config.RequestTimeoutsConfig{
+			Read: 2 * time.Second,
+			Routes: map[string]time.Duration{
+				"POST /api/v1/evaluations/jobs":     5 * time.Minute,
+				"/api/v1/evaluations/jobs/{job_id}": 3 * time.Second,
+				"GET /api/v1/admin/export":          -1,
+			},
+		}
+		if got := c.Timeout(http.MethodGet, "/api/v1/evaluations/providers"); got != 2*time.Second {
+			t.Errorf("GET: got %s want 2s", got)
+		}
+		if got := c.Timeout(http.MethodPost, "/api/v1/evaluations/jobs"); got != 5*time.Minute {
+			t.Errorf("POST jobs: got %s want 5m", got)
+		}
+		if got := c.Timeout(http.MethodDelete, "/api/v1/evaluations/jobs/{job_id}"); got != 3*time.Second {
+			t.Errorf("a route without method: got %s want 3s", got)
+		}
+		if got := c.Timeout(http.MethodGet, "/api/v1/admin/export"); got != 0 {
+			t.Errorf("a negative deadline: got %s want none", got)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c := &config.RequestTimeoutsConfig{Disabled: true, Read: time.Second}
+		if got := c.Timeout(http.MethodGet, "/api/v1/evaluations/jobs"); got != 0 {
+			t.Errorf("got %s want none", got)
+		}
 	})
 }
+
+func TestClustersConfig(t *testing.T) {
+	clusters := &config.ClustersConfig{
+		Remote: []config.ClusterConfig{
+			{Name: "gpu-east", Kubeconfig: "/etc/evalhub/clusters/gpu-east", EvalHubURL: "https://eval-hub.example.com"},
+			{Name: "gpu-west", Kubeconfig: "/etc/evalhub/clusters/gpu-west", EvalHubURL: "https://eval-hub.example.com"},
+		},
+		Providers: map[string]string{"lm_evaluation_harness": "gpu-east"},
+		Tenants:   map[string]string{"team-vision": "gpu-west"},
+	}
+	if err := clusters.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+
+	t.Run("cluster is chosen by job, provider then tenant", func(t *testing.T) {
+		cases := []struct {
+			jobCluster, providerID, tenant, want string
+		}{
+			{"gpu-west", "lm_evaluation_harness", "", "gpu-west"},
+			{"", "lm_evaluation_harness", "team-vision", "gpu-east"},
+			{"", "garak", "team-vision", "gpu-west"},
+			{"", "garak", "team-nlp", ""},
+			{config.LocalClusterName, "lm_evaluation_harness", "team-vision", ""},
+		}
+		for _, tc := range cases {
+			if got := clusters.ClusterFor(tc.jobCluster, tc.providerID, tc.tenant); got != tc.want {
+				t.Errorf("ClusterFor(%q, %q, %q) = %q, want %q", tc.jobCluster, tc.providerID, tc.tenant, got, tc.want)
+			}
+		}
+		var none *config.ClustersConfig
+		if got := none.ClusterFor("", "lm_evaluation_harness", "team-vision"); got != "" {
+			t.Errorf("ClusterFor() without clusters = %q", got)
+		}
+	})
+
+	t.Run("invalid clusters are rejected", func(t *testing.T) {
+		cases := map[string]*config.ClustersConfig{
+			"reserved name": {Remote: []config.ClusterConfig{{Name: config.LocalClusterName, Kubeconfig: "k", EvalHubURL: "u"}}},
+			"duplicate name": {Remote: []config.ClusterConfig{
+				{Name: "gpu", Kubeconfig: "k", EvalHubURL: "u"},
+				{Name: "gpu", Kubeconfig: "k", EvalHubURL: "u"},
+			}},
+			"missing kubeconfig":   {Remote: []config.ClusterConfig{{Name: "gpu", EvalHubURL: "u"}}},
+			"missing eval_hub_url": {Remote: []config.ClusterConfig{{Name: "gpu", Kubeconfig: "k"}}},
+			"unknown cluster":      {Tenants: map[string]string{"team-vision": "gpu"}},
+		}
+		for name, c := range cases {
+			if err := c.Validate(); err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+	})
+}
+
+func TestImagePolicyConfig_ValidateRuntimes(t *testing.T) {
+	policy := &config.ImagePolicyConfig{PinDigests: true}
+	if err := policy.ValidateRuntimes([]string{"kubernetes", "local"}); err != nil {
+		t.Errorf("ValidateRuntimes() = %v", err)
+	}
+	for _, name := range []string{"argo", "lmevaljob"} {
+		if err := policy.ValidateRuntimes([]string{"kubernetes", name}); err == nil {
+			t.Errorf("expected an error with the %s runtime", name)
+		}
+	}
+	var disabled *config.ImagePolicyConfig
+	if err := disabled.ValidateRuntimes([]string{"argo"}); err != nil {
+		t.Errorf("ValidateRuntimes() without a policy = %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"regexp"
+	"slices"
+
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v4"
+)
+
+// envReference matches ${VAR} and ${VAR:-fallback}; $${VAR} escapes a literal ${VAR}.
+var envReference = regexp.MustCompile(`\$(\$?)\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// expandEnv replaces the environment variable references of a value with their values. The
+// fallback of ${VAR:-fallback} is used when VAR is unset or empty, an unset variable without
+// a fallback expands to "" and is returned in unset.
+func expandEnv(value string) (expanded string, unset []string) {
+	expanded = envReference.ReplaceAllStringFunc(value, func(reference string) string {
+		match := envReference.FindStringSubmatch(reference)
+		if match[1] != "" {
+			return reference[1:]
+		}
+		if env := os.Getenv(match[2]); env != "" {
+			return env
+		}
+		if match[3] != "" {
+			return match[4]
+		}
+		if _, found := os.LookupEnv(match[2]); !found {
+			unset = append(unset, match[2])
+		}
+		return ""
+	})
+	return expanded, unset
+}
+
+// expandConfigEnv expands the environment variable references in the values of the config
+// file read by configValues, so that image tags, URLs and namespaces can vary per
+// environment. The values are expanded after the YAML is parsed, so that a variable cannot
+// change the structure of the file. An unquoted value is typed after its expansion, e.g.
+// port: ${PORT:-8080} is a number; a quoted value stays a string.
+func expandConfigEnv(logger *slog.Logger, configValues *viper.Viper) error {
+	configFile := configValues.ConfigFileUsed()
+	content, err := os.ReadFile(configFile)
+	if err != nil {
+		return err
+	}
+	if !bytes.Contains(content, []byte("${")) {
+		return nil
+	}
+
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal(content, document); err != nil {
+		return err
+	}
+	var unset []string
+	expandNode(document, &unset)
+	if len(unset) > 0 {
+		slices.Sort(unset)
+		logger.Warn("Environment variables referenced by the configuration are not set", "file", configFile, "variables", slices.Compact(unset))
+	}
+
+	expanded, err := yaml.Marshal(document)
+	if err != nil {
+		return err
+	}
+	return configValues.ReadConfig(bytes.NewReader(expanded))
+}
+
+// expandNode expands the string values under node; the keys of mappings are left as is.
+func expandNode(node *yaml.Node, unset *[]string) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			expandNode(child, unset)
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			expandNode(node.Content[i], unset)
+		}
+	case yaml.ScalarNode:
+		if node.Tag != "!!str" || !envReference.MatchString(node.Value) {
+			return
+		}
+		value, missing := expandEnv(node.Value)
+		*unset = append(*unset, missing...)
+		node.Value = value
+		if node.Style == 0 && value != "" {
+			// resolve the type of the expanded plain value
+			node.Tag = ""
+		}
+	}
+}
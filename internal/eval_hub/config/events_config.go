@@ -0,0 +1,36 @@
+package config
+
+const (
+	EventsBackendMemory = "memory"
+	EventsBackendNATS   = "nats"
+
+	DefaultEventsNATSSubject = "evalhub.events"
+)
+
+// EventsConfig selects the bus that carries job events to side-effect consumers.
+// The in-memory bus only reaches consumers in the same process; NATS also reaches
+// the other replicas of the service.
+type EventsConfig struct {
+	Backend string            `mapstructure:"backend,omitempty"`
+	NATS    *NATSEventsConfig `mapstructure:"nats,omitempty"`
+}
+
+type NATSEventsConfig struct {
+	URL string `mapstructure:"url"`
+	// Subject is the prefix events are published under as <subject>.<event type>.
+	Subject string `mapstructure:"subject,omitempty"`
+}
+
+func (c *EventsConfig) EffectiveBackend() string {
+	if c == nil || c.Backend == "" {
+		return EventsBackendMemory
+	}
+	return c.Backend
+}
+
+func (c *NATSEventsConfig) EffectiveSubject() string {
+	if c == nil || c.Subject == "" {
+		return DefaultEventsNATSSubject
+	}
+	return c.Subject
+}
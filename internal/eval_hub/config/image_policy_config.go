@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const DefaultImagePolicyTimeout = 30 * time.Second
+
+// ImagePolicyConfig makes the Kubernetes runtime run the adapter images of the providers by
+// digest rather than by mutable tag. The tag of the image is resolved to a digest in its
+// registry when the Job of the first benchmark of a job is created, and the benchmarks of the
+// job run the image by that digest, which the job records.
+// With public keys, the image must also carry a cosign signature made with one of the keys,
+// and the benchmarks whose image cannot be resolved or verified fail without a Job.
+type ImagePolicyConfig struct {
+	// PinDigests resolves the tags of the adapter images to digests.
+	PinDigests bool `mapstructure:"pin_digests"`
+	// PublicKeys are the paths of the PEM public keys that sign the adapter images. Setting
+	// them turns signature verification on, which implies pin_digests.
+	PublicKeys []string `mapstructure:"public_keys,omitempty"`
+	// DockerConfig is the path of a Docker config.json with the credentials of the private
+	// registries, e.g. mounted from a kubernetes.io/dockerconfigjson secret. Registries without
+	// credentials are read anonymously.
+	DockerConfig string `mapstructure:"docker_config,omitempty"`
+	// InsecureRegistries are the registry hosts reached over plain HTTP.
+	InsecureRegistries []string `mapstructure:"insecure_registries,omitempty"`
+	// Timeout bounds the registry requests made for an image.
+	Timeout time.Duration `mapstructure:"timeout,omitempty"`
+}
+
+func (c *ImagePolicyConfig) IsEnabled() bool {
+	return c != nil && (c.PinDigests || len(c.PublicKeys) > 0)
+}
+
+// VerifiesSignatures returns true when the images must carry a signature of a public key.
+func (c *ImagePolicyConfig) VerifiesSignatures() bool {
+	return c != nil && len(c.PublicKeys) > 0
+}
+
+// IsInsecureRegistry returns true when the registry is reached over plain HTTP.
+func (c *ImagePolicyConfig) IsInsecureRegistry(registry string) bool {
+	return c != nil && slices.Contains(c.InsecureRegistries, registry)
+}
+
+func (c *ImagePolicyConfig) EffectiveTimeout() time.Duration {
+	if c == nil || c.Timeout <= 0 {
+		return DefaultImagePolicyTimeout
+	}
+	return c.Timeout
+}
+
+// Validate checks that the paths of the public keys are set.
+func (c *ImagePolicyConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for i, path := range c.PublicKeys {
+		if path == "" {
+			return fmt.Errorf("image_policy.public_keys[%d] is empty", i)
+		}
+	}
+	return nil
+}
+
+// ValidateRuntimes checks that the enabled runtimes apply the policy. Only the kubernetes
+// runtime pins and verifies the adapter images, the argo and lmevaljob runtimes would run
+// the images by their mutable tags.
+func (c *ImagePolicyConfig) ValidateRuntimes(runtimes []string) error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	for _, name := range runtimes {
+		if name == api.RuntimeArgo || name == api.RuntimeLMEvalJob {
+			return fmt.Errorf("image_policy is not applied by the %s runtime, remove it from service.runtimes or disable image_policy", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	DefaultImageWarmupInterval   = time.Minute
+	DefaultImageWarmupPauseImage = "registry.k8s.io/pause:3.10"
+)
+
+// ImageWarmupConfig makes the Kubernetes runtime pre-pull the adapter images of the system
+// providers on the nodes that run evaluation jobs, so that the first job of a provider does
+// not wait for a cold pull of a large image. The images are pulled by a DaemonSet per
+// provider, and the pull status of each provider is reported on the providers API.
+type ImageWarmupConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// Providers are the IDs of the system providers whose images are pre-pulled, all the
+	// system providers with a Kubernetes image when empty.
+	Providers []string `mapstructure:"providers,omitempty"`
+	// Namespace is the namespace of the DaemonSets, the namespace of the service when empty.
+	Namespace string `mapstructure:"namespace,omitempty"`
+	// NodeSelector and Tolerations select the node pools the images are pulled on.
+	NodeSelector map[string]string `mapstructure:"node_selector,omitempty"`
+	Tolerations  []Toleration      `mapstructure:"tolerations,omitempty"`
+	// PauseImage is the image the DaemonSet pods run once the adapter image is pulled.
+	PauseImage string `mapstructure:"pause_image,omitempty"`
+	// Interval is how often the DaemonSets are reconciled and their pull status refreshed.
+	Interval time.Duration `mapstructure:"interval,omitempty"`
+}
+
+// Toleration lets the warm-up pods run on tainted nodes, e.g. GPU node pools.
+type Toleration struct {
+	Key      string `mapstructure:"key,omitempty"`
+	Operator string `mapstructure:"operator,omitempty"`
+	Value    string `mapstructure:"value,omitempty"`
+	Effect   string `mapstructure:"effect,omitempty"`
+}
+
+func (c *ImageWarmupConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// IncludesProvider returns true when the image of the provider is pre-pulled.
+func (c *ImageWarmupConfig) IncludesProvider(providerID string) bool {
+	return c.IsEnabled() && (len(c.Providers) == 0 || slices.Contains(c.Providers, providerID))
+}
+
+func (c *ImageWarmupConfig) EffectiveInterval() time.Duration {
+	if c == nil || c.Interval <= 0 {
+		return DefaultImageWarmupInterval
+	}
+	return c.Interval
+}
+
+func (c *ImageWarmupConfig) EffectivePauseImage() string {
+	if c == nil || c.PauseImage == "" {
+		return DefaultImageWarmupPauseImage
+	}
+	return c.PauseImage
+}
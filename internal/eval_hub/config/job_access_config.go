@@ -0,0 +1,67 @@
+package config
+
+import "slices"
+
+// JobAccessConfig scopes the evaluation jobs of a tenant to their owner. By default every
+// user of a tenant can read and manage all its jobs; with OwnerScoped a job is visible only
+// to its owner and to the users and groups it is shared with, and only its owner can
+// change, cancel, share or hand it over.
+type JobAccessConfig struct {
+	OwnerScoped bool `mapstructure:"owner_scoped"`
+	// AdminGroups are the groups (X-Groups) whose members can read and manage every job of
+	// their tenant, e.g. to hand over the jobs of someone who left the team.
+	AdminGroups []string `mapstructure:"admin_groups,omitempty"`
+	// ReviewerGroups are the groups whose members review the jobs of their tenant whose
+	// scores are borderline. When set, only their members and the admins can review jobs;
+	// otherwise any user who can read a job, other than its owner, can review it.
+	ReviewerGroups []string `mapstructure:"reviewer_groups,omitempty"`
+	// GenerationReaderGroups are the groups whose members, with the admins, can list and
+	// download the raw generations of the jobs of their tenant that they can read, e.g. the
+	// red-team reviewers. Nobody else can, whether or not the groups are set.
+	GenerationReaderGroups []string `mapstructure:"generation_reader_groups,omitempty"`
+}
+
+func (c *JobAccessConfig) IsOwnerScoped() bool {
+	return c != nil && c.OwnerScoped
+}
+
+// IsAdmin returns true when one of groups is an admin group.
+func (c *JobAccessConfig) IsAdmin(groups []string) bool {
+	if c == nil {
+		return false
+	}
+	return slices.ContainsFunc(groups, func(group string) bool {
+		return slices.Contains(c.AdminGroups, group)
+	})
+}
+
+// HasAdminGroups returns true when admin groups are configured.
+func (c *JobAccessConfig) HasAdminGroups() bool {
+	return c != nil && len(c.AdminGroups) > 0
+}
+
+// HasReviewerGroups returns true when the reviews are restricted to the reviewer groups.
+func (c *JobAccessConfig) HasReviewerGroups() bool {
+	return c != nil && len(c.ReviewerGroups) > 0
+}
+
+// IsReviewer returns true when one of groups is a reviewer group or an admin group.
+func (c *JobAccessConfig) IsReviewer(groups []string) bool {
+	if !c.HasReviewerGroups() {
+		return false
+	}
+	return c.IsAdmin(groups) || slices.ContainsFunc(groups, func(group string) bool {
+		return slices.Contains(c.ReviewerGroups, group)
+	})
+}
+
+// IsGenerationReader returns true when one of groups is a generation reader group or an
+// admin group.
+func (c *JobAccessConfig) IsGenerationReader(groups []string) bool {
+	if c == nil {
+		return false
+	}
+	return c.IsAdmin(groups) || slices.ContainsFunc(groups, func(group string) bool {
+		return slices.Contains(c.GenerationReaderGroups, group)
+	})
+}
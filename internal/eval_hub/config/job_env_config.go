@@ -0,0 +1,48 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// JobEnvConfig is the allowlist of the environment variables that jobs may set on their
+// adapters, with the env of the job config. Jobs may set none without it. The allowlist of a
+// tenant replaces the global allowlist.
+type JobEnvConfig struct {
+	JobEnvAllowlist `mapstructure:",squash"`
+	Tenants         map[string]JobEnvAllowlist `mapstructure:"tenants,omitempty"`
+}
+
+// JobEnvAllowlist are the variables and the secrets that the env of a job may use.
+type JobEnvAllowlist struct {
+	// Names are the names of the variables, or prefixes of them ending with *, e.g. HF_*.
+	Names []string `mapstructure:"names,omitempty"`
+	// Secrets are the secrets that the secretRef:// values of the variables may read.
+	Secrets []string `mapstructure:"secrets,omitempty"`
+}
+
+// Allowlist returns the allowlist of the jobs of a tenant.
+func (c *JobEnvConfig) Allowlist(tenant string) JobEnvAllowlist {
+	if c == nil {
+		return JobEnvAllowlist{}
+	}
+	if allowlist, ok := c.Tenants[tenant]; ok {
+		return allowlist
+	}
+	return c.JobEnvAllowlist
+}
+
+// AllowsName returns true when a job may set the variable.
+func (a JobEnvAllowlist) AllowsName(name string) bool {
+	return slices.ContainsFunc(a.Names, func(pattern string) bool {
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			return strings.HasPrefix(name, prefix)
+		}
+		return name == pattern
+	})
+}
+
+// AllowsSecret returns true when the variables of a job may read the secret.
+func (a JobEnvAllowlist) AllowsSecret(name string) bool {
+	return slices.Contains(a.Secrets, name)
+}
@@ -0,0 +1,33 @@
+package config
+
+import "errors"
+
+// LMEvalJobConfig is where the lmevaljob runtime creates the LMEvalJob custom resources that the
+// TrustyAI operator runs, for the providers with a runtime.lmevaljob block.
+type LMEvalJobConfig struct {
+	// Namespace is where the resources are created, the namespace of the tenant of the job by
+	// default, like the Jobs of the kubernetes runtime.
+	Namespace string `mapstructure:"namespace,omitempty"`
+	// CompletionsPath is appended to the URL of the model of the job for the base_url model
+	// argument, unless the URL already ends with it.
+	CompletionsPath string `mapstructure:"completions_path,omitempty"`
+}
+
+const DefaultLMEvalJobCompletionsPath = "/v1/completions"
+
+func (c *LMEvalJobConfig) EffectiveCompletionsPath() string {
+	if c == nil || c.CompletionsPath == "" {
+		return DefaultLMEvalJobCompletionsPath
+	}
+	return c.CompletionsPath
+}
+
+func (c *LMEvalJobConfig) Validate() error {
+	if c == nil || c.CompletionsPath == "" {
+		return nil
+	}
+	if c.CompletionsPath[0] != '/' {
+		return errors.New("lmevaljob.completions_path must start with /")
+	}
+	return nil
+}
@@ -126,7 +126,7 @@ func applyGPUNodeSelector(cfg *api.ProviderConfig, nodeSelector map[string]strin
 	cfg.Runtime.K8s.GPU.NodeSelector = nodeSelector
 }
 
-func loadProvider(logger *slog.Logger, validate *validator.Validate, file string, dirs ...string) (*api.ProviderResource, string, error) {
+func loadProvider(logger *slog.Logger, validate *validator.Validate, overrides map[string][]providerOverride, file string, dirs ...string) (*api.ProviderResource, string, error) {
 	type providerConfigInternal struct {
 		ID                 string `mapstructure:"id" yaml:"id" json:"id"`
 		api.ProviderConfig `mapstructure:",squash"`
@@ -136,15 +136,25 @@ func loadProvider(logger *slog.Logger, validate *validator.Validate, file string
 	if err != nil {
 		return nil, "", err
 	}
+	if err := expandConfigEnv(logger, configValues); err != nil {
+		return nil, configValues.ConfigFileUsed(), err
+	}
+	if err := applyProviderOverrides(logger, configValues, configValues.GetString("id"), overrides[configValues.GetString("id")]); err != nil {
+		return nil, configValues.ConfigFileUsed(), err
+	}
 
 	// node_selector is stripped before Unmarshal because struct decode uses "." paths and
 	// cannot fill map[string]string; parseGPUNodeSelector re-decodes the Get() value with "::".
 	configPath := configValues.ConfigFileUsed()
-	var rawNodeSelector any
+	var rawNodeSelector, rawSpotNodeSelector any
 	if configValues.IsSet("runtime.k8s.gpu.node_selector") {
 		rawNodeSelector = configValues.Get("runtime.k8s.gpu.node_selector")
 		configValues.Set("runtime.k8s.gpu.node_selector", nil)
 	}
+	if configValues.IsSet("runtime.k8s.spot.node_selector") {
+		rawSpotNodeSelector = configValues.Get("runtime.k8s.spot.node_selector")
+		configValues.Set("runtime.k8s.spot.node_selector", nil)
+	}
 	if err := configValues.Unmarshal(&providerConfig); err != nil {
 		return nil, configPath, err
 	}
@@ -155,6 +165,15 @@ func loadProvider(logger *slog.Logger, validate *validator.Validate, file string
 		}
 		applyGPUNodeSelector(&providerConfig.ProviderConfig, nodeSelector)
 	}
+	if rawSpotNodeSelector != nil {
+		nodeSelector, err := parseGPUNodeSelector(rawSpotNodeSelector)
+		if err != nil {
+			return nil, configPath, err
+		}
+		if runtime := providerConfig.Runtime; runtime != nil && runtime.K8s != nil && runtime.K8s.Spot != nil {
+			runtime.K8s.Spot.NodeSelector = nodeSelector
+		}
+	}
 	res := &api.ProviderResource{
 		Resource: api.Resource{
 			ID:    providerConfig.ID,
@@ -240,12 +259,16 @@ func LoadProviderConfigs(logger *slog.Logger, validate *validator.Validate, dirs
 	if err != nil {
 		return providerConfigs, err
 	}
+	overrides, err := loadProviderOverrides(logger, dir)
+	if err != nil {
+		return nil, err
+	}
 	for _, file := range files {
 		if file.IsDir() || !strings.HasSuffix(file.Name(), ".yaml") {
 			continue
 		}
 		name := strings.TrimSuffix(file.Name(), ".yaml")
-		providerConfig, fileUsed, err := loadProvider(logger, validate, name, dir)
+		providerConfig, fileUsed, err := loadProvider(logger, validate, overrides, name, dir)
 		if err != nil {
 			return nil, err
 		}
@@ -263,6 +286,13 @@ func LoadProviderConfigs(logger *slog.Logger, validate *validator.Validate, dirs
 		logger.Info("Provider loaded", "provider_id", providerConfig.Resource.ID, "file", fileName)
 	}
 
+	// an override of a provider that does not exist, e.g. a misspelt id, is not ignored
+	for id, providerOverrides := range overrides {
+		if _, ok := providerConfigs[id]; !ok {
+			return nil, fmt.Errorf("provider override %s: there is no system provider %q", providerOverrides[0].file, id)
+		}
+	}
+
 	return providerConfigs, nil
 }
 
@@ -318,6 +348,9 @@ func LoadCollectionConfigs(logger *slog.Logger, validate *validator.Validate, di
 //
 // Configuration supports:
 //   - Environment variable mapping: Define in env_mappings (e.g., PORT → service.port)
+//   - Environment variable references: ${VAR} and ${VAR:-fallback} in the values of
+//     config.yaml and of the CONFIG_PATH config are expanded when they are read, $${VAR}
+//     is kept as a literal ${VAR}.
 //   - Secrets from files: Define in secrets.mappings with secrets.dir (e.g., /tmp/db_password → database.password)
 //   - Optional secrets: Append :optional to the secret file name to mark it as optional.
 //     If an optional secret file doesn't exist, no error is logged and the configuration
@@ -347,48 +380,22 @@ func LoadConfig(logger *slog.Logger, version string, build string, buildDate str
 		dirs = configLookup
 	}
 
-	configValues, err := readConfig(logger, "config", "yaml", dirs...)
+	configValues, err := readServiceConfig(logger, dirs...)
 	if err != nil {
-		logger.Error("Failed to read configuration file config.yaml", "error", err.Error(), "dirs", dirs)
 		return nil, err
 	}
 
-	// If CONFIG_PATH is set, load the operator-mounted config and apply its
-	// top-level keys over the bundled defaults. This replaces (not deep-merges)
-	// sections like secrets, so bundled secret mappings don't leak through.
-	// Values not present in the operator config (e.g. service) are preserved.
-	if configPath := os.Getenv("CONFIG_PATH"); configPath != "" {
-		logger.Info("CONFIG_PATH set, applying operator config", "config_path", configPath)
-		operatorConfig := viper.New()
-		operatorConfig.SetConfigFile(configPath)
-		if err := operatorConfig.ReadInConfig(); err != nil {
-			logger.Error("Failed to read CONFIG_PATH config", "config_path", configPath, "error", err.Error())
-			return nil, err
-		}
-		for key, value := range operatorConfig.AllSettings() {
-			configValues.Set(key, value)
-		}
-		logger.Info("Applied operator config", "config_path", configPath)
-	}
-
 	// set up the secrets from the secrets directory
 	var redactedFields []string
-	secrets := SecretMap{}
-	if secretsSub := configValues.Sub("secrets"); secretsSub != nil {
-		if err := secretsSub.Unmarshal(&secrets); err != nil {
-			logger.Error("Failed to unmarshal secret mappings", "error", err.Error())
-			return nil, err
-		}
+	secrets, err := readSecretMap(logger, configValues)
+	if err != nil {
+		return nil, err
 	}
 	if secrets.Dir != "" {
 		// check that the secrets directory exists
 		if _, err := os.Stat(secrets.Dir); !os.IsNotExist(err) {
 			for fileName, fieldName := range secrets.Mappings {
-				// the secret file name can be optional by appending :optional to the file name
-				optional := strings.HasSuffix(fileName, ":optional")
-				if optional {
-					fileName = strings.TrimSuffix(fileName, ":optional")
-				}
+				fileName, optional := SecretFileName(fileName)
 				secret, err := getSecret(secrets.Dir, fileName, optional)
 				if err != nil {
 					// log the error and fail the startup (by returning the error)
@@ -404,11 +411,27 @@ func LoadConfig(logger *slog.Logger, version string, build string, buildDate str
 		}
 	}
 
+	// like the GPU node selector of providers, the label keys of the warm-up node selector
+	// can contain dots and are decoded apart from the rest of the configuration
+	var rawWarmupNodeSelector any
+	if configValues.IsSet("image_warmup.node_selector") {
+		rawWarmupNodeSelector = configValues.Get("image_warmup.node_selector")
+		configValues.Set("image_warmup.node_selector", nil)
+	}
+
 	conf := Config{}
 	if err := configValues.Unmarshal(&conf); err != nil {
 		logger.Error("Failed to unmarshal configuration", "error", err.Error())
 		return nil, err
 	}
+	if rawWarmupNodeSelector != nil && conf.ImageWarmup != nil {
+		nodeSelector, err := parseGPUNodeSelector(rawWarmupNodeSelector)
+		if err != nil {
+			logger.Error("Failed to unmarshal image_warmup.node_selector", "error", err.Error())
+			return nil, err
+		}
+		conf.ImageWarmup.NodeSelector = nodeSelector
+	}
 
 	// set the version, build, and build date
 	conf.Service.Version = version
@@ -419,6 +442,76 @@ func LoadConfig(logger *slog.Logger, version string, build string, buildDate str
 	return &conf, nil
 }
 
+// readServiceConfig reads config.yaml from the first of the dirs that has it, with the
+// top-level keys of the operator config of CONFIG_PATH applied over it.
+func readServiceConfig(logger *slog.Logger, dirs ...string) (*viper.Viper, error) {
+	configValues, err := readConfig(logger, "config", "yaml", dirs...)
+	if err != nil {
+		logger.Error("Failed to read configuration file config.yaml", "error", err.Error(), "dirs", dirs)
+		return nil, err
+	}
+	if err := expandConfigEnv(logger, configValues); err != nil {
+		logger.Error("Failed to expand the environment variables of config.yaml", "error", err.Error())
+		return nil, err
+	}
+
+	// If CONFIG_PATH is set, load the operator-mounted config and apply its
+	// top-level keys over the bundled defaults. This replaces (not deep-merges)
+	// sections like secrets, so bundled secret mappings don't leak through.
+	// Values not present in the operator config (e.g. service) are preserved.
+	if configPath := os.Getenv("CONFIG_PATH"); configPath != "" {
+		logger.Info("CONFIG_PATH set, applying operator config", "config_path", configPath)
+		operatorConfig := viper.New()
+		operatorConfig.SetConfigFile(configPath)
+		if err := operatorConfig.ReadInConfig(); err != nil {
+			logger.Error("Failed to read CONFIG_PATH config", "config_path", configPath, "error", err.Error())
+			return nil, err
+		}
+		if err := expandConfigEnv(logger, operatorConfig); err != nil {
+			logger.Error("Failed to expand the environment variables of CONFIG_PATH config", "config_path", configPath, "error", err.Error())
+			return nil, err
+		}
+		for key, value := range operatorConfig.AllSettings() {
+			configValues.Set(key, value)
+		}
+		logger.Info("Applied operator config", "config_path", configPath)
+	}
+	return configValues, nil
+}
+
+func readSecretMap(logger *slog.Logger, configValues *viper.Viper) (*SecretMap, error) {
+	secrets := &SecretMap{}
+	if secretsSub := configValues.Sub("secrets"); secretsSub != nil {
+		if err := secretsSub.Unmarshal(secrets); err != nil {
+			logger.Error("Failed to unmarshal secret mappings", "error", err.Error())
+			return nil, err
+		}
+	}
+	return secrets, nil
+}
+
+// LoadSecretMap returns the secrets section of the service configuration, as LoadConfig
+// applies it, so that the secret files can be checked before the service starts.
+func LoadSecretMap(logger *slog.Logger, dirs ...string) (*SecretMap, error) {
+	if !hasExplicitConfigDir(dirs) {
+		dirs = configLookup
+	}
+	configValues, err := readServiceConfig(logger, dirs...)
+	if err != nil {
+		return nil, err
+	}
+	return readSecretMap(logger, configValues)
+}
+
+// SecretFileName returns the name of the secret file of a secret mapping and whether it is
+// optional. The secret file name can be optional by appending :optional to the file name.
+func SecretFileName(mapping string) (string, bool) {
+	if name, found := strings.CutSuffix(mapping, ":optional"); found {
+		return name, true
+	}
+	return mapping, false
+}
+
 // getSecret reads a secret from a file and returns the value as a string.
 // If the file does not exist and optional is false, it logs an error and returns an empty string.
 // If the file does not exist and optional is true, it silently returns an empty string.
@@ -127,6 +127,62 @@ benchmarks:
 	}
 }
 
+func TestLoadProviderConfigs_ParsesSpotNodeSelectorFromInlineYAML(t *testing.T) {
+	logger := logging.FallbackLogger()
+	configRoot := t.TempDir()
+	provDir := filepath.Join(configRoot, "providers")
+	if err := os.MkdirAll(provDir, 0o755); err != nil {
+		t.Fatalf("mkdir providers: %v", err)
+	}
+	content := `id: spot_selector_test
+name: Spot Selector Test
+description: provider with a dotted spot node selector label
+runtime:
+  k8s:
+    image: quay.io/example/adapter:latest
+    entrypoint:
+      - /bin/true
+    data_volume:
+      size: 50Gi
+    spot:
+      node_selector:
+        cloud.google.com/gke-spot: "true"
+      tolerations:
+        - key: cloud.google.com/gke-spot
+          operator: Equal
+          value: "true"
+          effect: NoSchedule
+      checkpoint: true
+benchmarks:
+  - id: arc_easy
+    name: arc_easy
+    description: test benchmark
+    category: reasoning
+`
+	if err := os.WriteFile(filepath.Join(provDir, "spot_selector_test.yaml"), []byte(content), 0o600); err != nil {
+		t.Fatalf("write provider yaml: %v", err)
+	}
+
+	providers, err := config.LoadProviderConfigs(logger, testhelpers.NewValidator(t), configRoot)
+	if err != nil {
+		t.Fatalf("LoadProviderConfigs failed: %v", err)
+	}
+	p, ok := providers["spot_selector_test"]
+	if !ok || p.Runtime == nil || p.Runtime.K8s == nil || p.Runtime.K8s.Spot == nil {
+		t.Fatalf("expected spot_selector_test with runtime.k8s.spot, got keys: %v", providerIDs(providers))
+	}
+	spot := p.Runtime.K8s.Spot
+	if got := spot.NodeSelector["cloud.google.com/gke-spot"]; got != "true" || len(spot.NodeSelector) != 1 {
+		t.Fatalf("expected cloud.google.com/gke-spot in node_selector, got %v", spot.NodeSelector)
+	}
+	if len(spot.Tolerations) != 1 || spot.Tolerations[0].Key != "cloud.google.com/gke-spot" || spot.Tolerations[0].Effect != "NoSchedule" {
+		t.Fatalf("expected the spot toleration, got %+v", spot.Tolerations)
+	}
+	if !p.Runtime.K8s.Checkpoints() {
+		t.Fatalf("expected the spot node pool to checkpoint")
+	}
+}
+
 func copyProviderFixture(t *testing.T, configRoot, fixtureName string) {
 	t.Helper()
 	src := filepath.Join("..", "..", "..", "tests", "features", "test_data", fixtureName)
@@ -135,6 +135,51 @@ secrets:
 		}
 	})
 
+	t.Run("CONFIG_PATH configures admission webhooks", func(t *testing.T) {
+		baseDir := t.TempDir()
+		baseContent := `
+service:
+  port: 8080
+database:
+  driver: sqlite
+`
+		if err := os.WriteFile(filepath.Join(baseDir, "config.yaml"), []byte(baseContent), 0600); err != nil {
+			t.Fatalf("Failed to write base config: %v", err)
+		}
+		operatorDir := t.TempDir()
+		operatorContent := `
+admission:
+  webhooks:
+    - name: mandatory-tags
+      url: https://policy.example.svc/mutate
+      mutating: true
+      timeout: 5s
+      failure_policy: ignore
+    - name: approved-benchmarks
+      url: https://policy.example.svc/validate
+`
+		if err := os.WriteFile(filepath.Join(operatorDir, "config.yaml"), []byte(operatorContent), 0600); err != nil {
+			t.Fatalf("Failed to write operator config: %v", err)
+		}
+		t.Setenv("CONFIG_PATH", filepath.Join(operatorDir, "config.yaml"))
+
+		serviceConfig, err := config.LoadConfig(logger, version, "local", time.Now().Format(time.RFC3339), baseDir)
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		if !serviceConfig.Admission.IsEnabled() || len(serviceConfig.Admission.Webhooks) != 2 {
+			t.Fatalf("Expected two admission webhooks, got %+v", serviceConfig.Admission)
+		}
+		mutating := serviceConfig.Admission.Webhooks[0]
+		if !mutating.Mutating || mutating.EffectiveTimeout() != 5*time.Second || mutating.EffectiveFailurePolicy() != config.AdmissionFailurePolicyIgnore {
+			t.Fatalf("Unexpected mutating webhook config %+v", mutating)
+		}
+		validating := serviceConfig.Admission.Webhooks[1]
+		if validating.Mutating || validating.EffectiveTimeout() != config.DefaultAdmissionWebhookTimeout || validating.EffectiveFailurePolicy() != config.AdmissionFailurePolicyFail {
+			t.Fatalf("Unexpected validating webhook config %+v", validating)
+		}
+	})
+
 	t.Run("CONFIG_PATH replaces bundled secret mappings", func(t *testing.T) {
 		// Bundled config has a non-optional secret mapping (db_password).
 		// Operator config has a different mapping (db-url).
@@ -215,6 +260,84 @@ secrets:
 		}
 	})
 
+	t.Run("expanding environment variables in values", func(t *testing.T) {
+		dir := t.TempDir()
+		content := `
+service:
+  port: ${EVAL_HUB_TEST_PORT:-8080}
+  termination_file: "${EVAL_HUB_TEST_DIR}/termination-log"
+database:
+  driver: sqlite
+  url: ${EVAL_HUB_TEST_DB_URL:-file::memory:?mode=memory&cache=shared}
+mlflow:
+  tracking_uri: "$${EVAL_HUB_TEST_DIR}"
+`
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		t.Setenv("EVAL_HUB_TEST_PORT", "9090")
+		t.Setenv("EVAL_HUB_TEST_DIR", "/var/run/eval-hub")
+
+		serviceConfig, err := config.LoadConfig(logger, version, "local", time.Now().Format(time.RFC3339), "", dir)
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		if serviceConfig.Service.Port != 9090 {
+			t.Fatalf("Expected port 9090 from EVAL_HUB_TEST_PORT, got %d", serviceConfig.Service.Port)
+		}
+		if serviceConfig.Service.TerminationFile != "/var/run/eval-hub/termination-log" {
+			t.Fatalf("Expected the termination file under EVAL_HUB_TEST_DIR, got %s", serviceConfig.Service.TerminationFile)
+		}
+		if url := (*serviceConfig.Database)["url"]; url != "file::memory:?mode=memory&cache=shared" {
+			t.Fatalf("Expected the fallback database URL, got %v", url)
+		}
+		if serviceConfig.MLFlow.TrackingURI != "${EVAL_HUB_TEST_DIR}" {
+			t.Fatalf("Expected the escaped reference to be kept, got %s", serviceConfig.MLFlow.TrackingURI)
+		}
+	})
+
+	t.Run("image warm-up node selector keeps dotted label keys", func(t *testing.T) {
+		dir := t.TempDir()
+		content := `
+service:
+  port: 8080
+database:
+  driver: sqlite
+  url: file::memory:?mode=memory&cache=shared
+image_warmup:
+  enabled: true
+  providers: [lm_evaluation_harness]
+  node_selector:
+    nvidia.com/gpu.present: "true"
+  tolerations:
+    - key: nvidia.com/gpu
+      operator: Exists
+      effect: NoSchedule
+  interval: 30s
+`
+		if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+
+		serviceConfig, err := config.LoadConfig(logger, version, "local", time.Now().Format(time.RFC3339), "", dir)
+		if err != nil {
+			t.Fatalf("Failed to load config: %v", err)
+		}
+		warmup := serviceConfig.ImageWarmup
+		if !warmup.IsEnabled() || !warmup.IncludesProvider("lm_evaluation_harness") || warmup.IncludesProvider("garak") {
+			t.Fatalf("Unexpected image warm-up providers: %+v", warmup)
+		}
+		if warmup.NodeSelector["nvidia.com/gpu.present"] != "true" || len(warmup.NodeSelector) != 1 {
+			t.Fatalf("Expected the dotted node selector key, got %v", warmup.NodeSelector)
+		}
+		if len(warmup.Tolerations) != 1 || warmup.Tolerations[0].Key != "nvidia.com/gpu" {
+			t.Fatalf("Unexpected tolerations: %+v", warmup.Tolerations)
+		}
+		if warmup.EffectiveInterval() != 30*time.Second || warmup.EffectivePauseImage() != config.DefaultImageWarmupPauseImage {
+			t.Fatalf("Unexpected interval or pause image: %s %s", warmup.EffectiveInterval(), warmup.EffectivePauseImage())
+		}
+	})
+
 	t.Run("loads providers from config dir", func(t *testing.T) {
 		_, err := config.LoadProviderConfigs(logger, testhelpers.NewValidator(t))
 		if err != nil {
@@ -342,6 +465,40 @@ func TestLoadProviderConfigs(t *testing.T) {
 		}
 	})
 
+	t.Run("expands environment variables in provider values", func(t *testing.T) {
+		dir := t.TempDir()
+		provDir := filepath.Join(dir, "providers")
+		if err := os.MkdirAll(provDir, 0755); err != nil {
+			t.Fatalf("MkdirAll providers: %v", err)
+		}
+		content := "id: alpha\nname: Alpha\nruntime:\n  k8s:\n    image: quay.io/evalhub/adapter:${EVAL_HUB_TEST_TAG:-latest}\n    cpu_request: ${EVAL_HUB_TEST_CPU}\n"
+		if err := os.WriteFile(filepath.Join(provDir, "alpha.yaml"), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write alpha.yaml: %v", err)
+		}
+		t.Setenv("EVAL_HUB_TEST_CPU", "250m")
+
+		providers, err := config.LoadProviderConfigs(logger, testhelpers.NewValidator(t), dir)
+		if err != nil {
+			t.Fatalf("LoadProviderConfigs failed: %v", err)
+		}
+		k8s := providers["alpha"].Runtime.K8s
+		if k8s.Image != "quay.io/evalhub/adapter:latest" {
+			t.Fatalf("Expected the fallback image tag, got %s", k8s.Image)
+		}
+		if k8s.CPURequest != "250m" {
+			t.Fatalf("Expected the CPU request from EVAL_HUB_TEST_CPU, got %s", k8s.CPURequest)
+		}
+
+		t.Setenv("EVAL_HUB_TEST_TAG", "v1.2.3")
+		providers, err = config.LoadProviderConfigs(logger, testhelpers.NewValidator(t), dir)
+		if err != nil {
+			t.Fatalf("LoadProviderConfigs failed: %v", err)
+		}
+		if image := providers["alpha"].Runtime.K8s.Image; image != "quay.io/evalhub/adapter:v1.2.3" {
+			t.Fatalf("Expected the image tag from EVAL_HUB_TEST_TAG, got %s", image)
+		}
+	})
+
 	t.Run("fail providers with missing id", func(t *testing.T) {
 		dir := t.TempDir()
 		provDir := filepath.Join(dir, "providers")
@@ -470,3 +627,63 @@ func providerIDs(providers map[string]api.ProviderResource) []string {
 func testPassword() string {
 	return fmt.Sprintf("pw-%d", time.Now().UnixNano())
 }
+
+func TestLoadMessageCatalogs(t *testing.T) {
+	logger := logging.FallbackLogger()
+
+	t.Run("loads catalogs keyed by locale", func(t *testing.T) {
+		dir := t.TempDir()
+		msgDir := filepath.Join(dir, "messages")
+		if err := os.MkdirAll(msgDir, 0755); err != nil {
+			t.Fatalf("MkdirAll messages: %v", err)
+		}
+		content := `errors:
+  resource_not_found: "Die Ressource {{.Type}} '{{.ResourceId}}' wurde nicht gefunden."
+status:
+  "Evaluation job created. Waiting for a runtime": "Evaluierungsjob erstellt. Warte auf eine Laufzeit"
+`
+		if err := os.WriteFile(filepath.Join(msgDir, "de_DE.yaml"), []byte(content), 0600); err != nil {
+			t.Fatalf("Failed to write de_DE.yaml: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(msgDir, "readme.txt"), []byte("ignore me"), 0600); err != nil {
+			t.Fatalf("Failed to write readme.txt: %v", err)
+		}
+
+		catalogs, err := config.LoadMessageCatalogs(logger, dir)
+		if err != nil {
+			t.Fatalf("LoadMessageCatalogs failed: %v", err)
+		}
+		catalog, ok := catalogs["de-de"]
+		if !ok || len(catalogs) != 1 {
+			t.Fatalf("Expected the catalog 'de-de', got %v", catalogs)
+		}
+		// status keys keep their case and dots
+		if got := catalog.StatusMessage("Evaluation job created. Waiting for a runtime"); got != "Evaluierungsjob erstellt. Warte auf eine Laufzeit" {
+			t.Errorf("StatusMessage = %q", got)
+		}
+	})
+
+	t.Run("missing messages dir serves English only", func(t *testing.T) {
+		catalogs, err := config.LoadMessageCatalogs(logger, t.TempDir())
+		if err != nil {
+			t.Fatalf("LoadMessageCatalogs failed: %v", err)
+		}
+		if len(catalogs) != 0 {
+			t.Fatalf("Expected no catalogs, got %d", len(catalogs))
+		}
+	})
+
+	t.Run("fails on an unknown message code", func(t *testing.T) {
+		dir := t.TempDir()
+		msgDir := filepath.Join(dir, "messages")
+		if err := os.MkdirAll(msgDir, 0755); err != nil {
+			t.Fatalf("MkdirAll messages: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(msgDir, "fr.yaml"), []byte("errors:\n  no_such_code: oops\n"), 0600); err != nil {
+			t.Fatalf("Failed to write fr.yaml: %v", err)
+		}
+		if _, err := config.LoadMessageCatalogs(logger, dir); err == nil {
+			t.Fatal("LoadMessageCatalogs did not fail for an unknown message code")
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package config
+
+const (
+	DefaultLocalContainerEngine  = "podman"
+	DefaultLocalContainerNetwork = "host"
+)
+
+// LocalContainersConfig is how the local runtime runs the commands of the providers that set
+// an image.
+type LocalContainersConfig struct {
+	// Engine is the container CLI, podman or docker.
+	Engine string `mapstructure:"engine,omitempty"`
+	// Network is the network of the containers. The default host network lets the adapters
+	// reach the callback URL of the server on localhost.
+	Network string `mapstructure:"network,omitempty"`
+	// Args are extra arguments of the run command, e.g. --gpus=all.
+	Args []string `mapstructure:"args,omitempty"`
+}
+
+func (c *LocalContainersConfig) EffectiveEngine() string {
+	if c == nil || c.Engine == "" {
+		return DefaultLocalContainerEngine
+	}
+	return c.Engine
+}
+
+func (c *LocalContainersConfig) EffectiveNetwork() string {
+	if c == nil || c.Network == "" {
+		return DefaultLocalContainerNetwork
+	}
+	return c.Network
+}
@@ -0,0 +1,40 @@
+package config
+
+import "time"
+
+const (
+	// DefaultLocalJobsDir is where the local runtime keeps the files of the jobs by default.
+	DefaultLocalJobsDir = "/tmp/evalhub-jobs"
+
+	DefaultLocalJobsCleanupInterval = 10 * time.Minute
+)
+
+// LocalJobsConfig is where the local runtime keeps the job spec, logs and outputs of the
+// benchmarks of each job, and how much disk they may use. Without quotas and a retention the
+// directory grows until the disk is full.
+type LocalJobsConfig struct {
+	Dir string `mapstructure:"dir,omitempty"`
+	// MaxJobSizeMB is the disk quota of a job, its running benchmarks are killed and failed
+	// when its directory grows past it.
+	MaxJobSizeMB int `mapstructure:"max_job_size_mb,omitempty"`
+	// MaxTotalSizeMB is the disk quota of the directory, no benchmark is started while it
+	// is exceeded.
+	MaxTotalSizeMB int `mapstructure:"max_total_size_mb,omitempty"`
+	// Retention removes the directories of the jobs that were not written to for this long.
+	Retention       time.Duration `mapstructure:"retention,omitempty"`
+	CleanupInterval time.Duration `mapstructure:"cleanup_interval,omitempty"`
+}
+
+func (c *LocalJobsConfig) JobsDir() string {
+	if c == nil || c.Dir == "" {
+		return DefaultLocalJobsDir
+	}
+	return c.Dir
+}
+
+func (c *LocalJobsConfig) EffectiveCleanupInterval() time.Duration {
+	if c == nil || c.CleanupInterval <= 0 {
+		return DefaultLocalJobsCleanupInterval
+	}
+	return c.CleanupInterval
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// LocalSandboxConfig isolates the benchmark processes of the local runtime from the server.
+// Without it the processes run as the server, in its working directory and with its whole
+// environment, secrets included.
+type LocalSandboxConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+	// EnvPassthrough are the variables of the server environment that the processes get,
+	// besides PATH, LANG and TZ. HOME and TMPDIR are the working directory of the process.
+	EnvPassthrough []string `mapstructure:"env_passthrough,omitempty"`
+	// User is the uid[:gid], or the name, of the user that the processes run as, the
+	// server must run as root to switch to it.
+	User   string             `mapstructure:"user,omitempty"`
+	Limits LocalSandboxLimits `mapstructure:"limits,omitempty"`
+	// CgroupParent is a cgroup v2 directory that the server may create cgroups in, e.g.
+	// /sys/fs/cgroup/eval-hub. Each process runs in a cgroup of its own under it, with the
+	// memory and CPU limits. Linux only.
+	CgroupParent string `mapstructure:"cgroup_parent,omitempty"`
+	// AllowedCommands are the commands that the providers may run, by provider ID. When set,
+	// the benchmarks of a provider whose command is not listed are not started.
+	AllowedCommands map[string][]string `mapstructure:"allowed_commands,omitempty"`
+}
+
+// LocalSandboxLimits are the resource limits of a benchmark process, zero is no limit. The
+// rlimits apply to each process of the benchmark, the cgroup limits to all of them.
+type LocalSandboxLimits struct {
+	// CPUSeconds is the CPU time rlimit.
+	CPUSeconds int `mapstructure:"cpu_seconds,omitempty"`
+	// OpenFiles is the open files rlimit.
+	OpenFiles int `mapstructure:"open_files,omitempty"`
+	// VirtualMemoryMB is the address space rlimit.
+	VirtualMemoryMB int `mapstructure:"virtual_memory_mb,omitempty"`
+	// MemoryMB is the memory.max of the cgroup.
+	MemoryMB int `mapstructure:"memory_mb,omitempty"`
+	// CPUs is the cpu.max of the cgroup, in CPUs, e.g. 1.5.
+	CPUs float64 `mapstructure:"cpus,omitempty"`
+}
+
+func (c *LocalSandboxConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+// Validate reports the limits that need a cgroup_parent.
+func (c *LocalSandboxConfig) Validate() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+	if c.Limits.CPUSeconds < 0 || c.Limits.OpenFiles < 0 || c.Limits.VirtualMemoryMB < 0 || c.Limits.MemoryMB < 0 || c.Limits.CPUs < 0 {
+		return errors.New("local_sandbox.limits must not be negative")
+	}
+	if c.CgroupParent == "" && (c.Limits.MemoryMB > 0 || c.Limits.CPUs > 0) {
+		return errors.New("local_sandbox.limits.memory_mb and cpus need local_sandbox.cgroup_parent")
+	}
+	return nil
+}
+
+// CheckCommand returns an error when the commands of the provider are restricted and the
+// command is not one of them.
+func (c *LocalSandboxConfig) CheckCommand(providerID, command string) error {
+	if !c.IsEnabled() || c.AllowedCommands == nil {
+		return nil
+	}
+	if !slices.Contains(c.AllowedCommands[providerID], command) {
+		return fmt.Errorf("the command of provider %s is not in local_sandbox.allowed_commands", providerID)
+	}
+	return nil
+}
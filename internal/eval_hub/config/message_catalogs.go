@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"go.yaml.in/yaml/v4"
+)
+
+// messageCatalogFile is the layout of a message catalog file, messages/<locale>.yaml
+// in the config dir. The file name is the locale, e.g. de.yaml or pt-BR.yaml.
+//
+//	errors:
+//	  resource_not_found: "Die Ressource {{.Type}} '{{.ResourceId}}' wurde nicht gefunden."
+//	status:
+//	  "Evaluation job created": "Evaluierungsjob erstellt"
+//
+// The catalogs are decoded without Viper because Viper lower-cases keys and splits them
+// on dots, which would break the status keys.
+type messageCatalogFile struct {
+	Errors map[string]string `yaml:"errors"`
+	Status map[string]string `yaml:"status"`
+}
+
+// LoadMessageCatalogs loads the message catalogs from the messages directory of the
+// config dir. A missing directory is not an error: the messages are then served in
+// English only.
+func LoadMessageCatalogs(logger *slog.Logger, dirs ...string) (messages.Catalogs, error) {
+	if !hasExplicitConfigDir(dirs) {
+		dirs = []string{}
+		for _, dir := range configLookup {
+			dirs = append(dirs, dir+"/messages")
+		}
+	} else {
+		dirs = []string{dirs[0] + "/messages"}
+	}
+
+	catalogs := messages.Catalogs{}
+
+	files, dir, err := scanFolders(logger, dirs...)
+	if err != nil {
+		return catalogs, err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".yaml") {
+			continue
+		}
+		fileName := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, err
+		}
+		var catalogFile messageCatalogFile
+		if err := yaml.Unmarshal(data, &catalogFile); err != nil {
+			return nil, fmt.Errorf("message catalog %s: %w", fileName, err)
+		}
+		catalog, err := messages.NewCatalog(strings.TrimSuffix(file.Name(), ".yaml"), catalogFile.Errors, catalogFile.Status)
+		if err != nil {
+			return nil, err
+		}
+		catalogs[catalog.GetLocale()] = catalog
+		logger.Info("Message catalog loaded", "locale", catalog.GetLocale(), "file", fileName)
+	}
+
+	return catalogs, nil
+}
@@ -2,9 +2,16 @@ package config
 
 import (
 	"crypto/tls"
+	"strings"
 	"time"
 )
 
+const (
+	DefaultExperimentLinkRetryInterval    = 30 * time.Second
+	DefaultExperimentLinkMaxRetryInterval = 10 * time.Minute
+	DefaultExperimentLinkMaxAge           = 24 * time.Hour
+)
+
 type MLFlowConfig struct {
 	TrackingURI        string        `mapstructure:"tracking_uri"`
 	HTTPTimeout        time.Duration `mapstructure:"http_timeout"`
@@ -13,5 +20,79 @@ type MLFlowConfig struct {
 	Token              string        `mapstructure:"token"`
 	TokenPath          string        `mapstructure:"token_path"`
 	Workspace          string        `mapstructure:"workspace"`
-	TLSConfig          *tls.Config   // not serialized
+	// CircuitBreaker fails the MLflow calls fast while MLflow is down.
+	CircuitBreaker *CircuitBreakerConfig `mapstructure:"circuit_breaker,omitempty"`
+	// ExperimentLinking is how the jobs are linked to their experiment, in the background.
+	ExperimentLinking *ExperimentLinkingConfig `mapstructure:"experiment_linking,omitempty"`
+	// Tenants maps the tenants to their MLflow workspaces and experiments, by tenant. The jobs
+	// of a tenant that is not mapped write to the workspace named after the tenant.
+	Tenants   map[string]MLFlowTenantConfig `mapstructure:"tenants,omitempty"`
+	TLSConfig *tls.Config                   // not serialized
+}
+
+// MLFlowTenantConfig is where the jobs of a tenant write to a shared MLflow server.
+type MLFlowTenantConfig struct {
+	// Workspaces are the MLflow workspaces that the jobs of the tenant may write to; the
+	// jobs that do not select one write to the first.
+	Workspaces []string `mapstructure:"workspaces,omitempty"`
+	// ExperimentPrefix is prepended to the experiment names of the jobs of the tenant, e.g.
+	// team-a/, so that the teams do not share the names on a server without workspaces.
+	ExperimentPrefix string `mapstructure:"experiment_prefix,omitempty"`
+	// DefaultExperiment is the experiment of the jobs of the tenant that do not set one.
+	DefaultExperiment string `mapstructure:"default_experiment,omitempty"`
+}
+
+// Tenant returns the MLflow mapping of a tenant, and whether the tenant is mapped.
+func (c *MLFlowConfig) Tenant(tenant string) (MLFlowTenantConfig, bool) {
+	if c == nil {
+		return MLFlowTenantConfig{}, false
+	}
+	mapping, ok := c.Tenants[tenant]
+	return mapping, ok
+}
+
+// DefaultWorkspace returns the workspace of the jobs of the tenant that do not select one.
+func (c MLFlowTenantConfig) DefaultWorkspace() string {
+	if len(c.Workspaces) == 0 {
+		return ""
+	}
+	return c.Workspaces[0]
+}
+
+// ExperimentName returns the name of an experiment of the tenant, with its prefix.
+func (c MLFlowTenantConfig) ExperimentName(name string) string {
+	if c.ExperimentPrefix == "" || strings.HasPrefix(name, c.ExperimentPrefix) {
+		return name
+	}
+	return c.ExperimentPrefix + name
+}
+
+// ExperimentLinkingConfig is how the jobs are linked to their MLflow experiment. The
+// experiment is created in the background once the job is stored, and when that fails the
+// elected leader retries it, backing off from RetryInterval to MaxRetryInterval, for the jobs
+// created within MaxAge.
+type ExperimentLinkingConfig struct {
+	RetryInterval    time.Duration `mapstructure:"retry_interval,omitempty"`
+	MaxRetryInterval time.Duration `mapstructure:"max_retry_interval,omitempty"`
+	MaxAge           time.Duration `mapstructure:"max_age,omitempty"`
+}
+
+// RetryIntervals returns the first and the longest interval between two link attempts.
+func (c *ExperimentLinkingConfig) RetryIntervals() (time.Duration, time.Duration) {
+	retryInterval, maxRetryInterval := DefaultExperimentLinkRetryInterval, DefaultExperimentLinkMaxRetryInterval
+	if c != nil && c.RetryInterval > 0 {
+		retryInterval = c.RetryInterval
+	}
+	if c != nil && c.MaxRetryInterval > 0 {
+		maxRetryInterval = c.MaxRetryInterval
+	}
+	return retryInterval, max(retryInterval, maxRetryInterval)
+}
+
+// EffectiveMaxAge returns how long after their creation the links of the jobs are retried.
+func (c *ExperimentLinkingConfig) EffectiveMaxAge() time.Duration {
+	if c == nil || c.MaxAge <= 0 {
+		return DefaultExperimentLinkMaxAge
+	}
+	return c.MaxAge
 }
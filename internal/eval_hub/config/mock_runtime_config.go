@@ -0,0 +1,81 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultMockRuntimeMinDuration = 5 * time.Second
+	DefaultMockRuntimeMaxDuration = 30 * time.Second
+	// DefaultMockRuntimeMetric is the metric that the mock runtime reports when none are
+	// configured.
+	DefaultMockRuntimeMetric = "score"
+)
+
+// MockRuntimeConfig is how the mock runtime simulates the benchmarks: how long they run, how
+// many fail, and the metrics that the completed ones report. The mock runtime starts no
+// adapter, it is for load and integration tests of the storage, webhooks and dashboards.
+type MockRuntimeConfig struct {
+	// MinDuration and MaxDuration bound the uniformly distributed duration of a benchmark.
+	MinDuration time.Duration `mapstructure:"min_duration,omitempty"`
+	MaxDuration time.Duration `mapstructure:"max_duration,omitempty"`
+	// FailureRate is the probability, from 0 to 1, that a benchmark fails.
+	FailureRate float64 `mapstructure:"failure_rate,omitempty"`
+	// Metrics are the metrics of the completed benchmarks, by name.
+	Metrics map[string]MockMetricConfig `mapstructure:"metrics,omitempty"`
+	// Seed makes the simulation reproducible, a random seed is used when it is 0.
+	Seed uint64 `mapstructure:"seed,omitempty"`
+}
+
+// MockMetricConfig is the normal distribution of a metric, clamped to [Min, Max] when Max is
+// greater than Min.
+type MockMetricConfig struct {
+	Mean   float64 `mapstructure:"mean"`
+	StdDev float64 `mapstructure:"stddev,omitempty"`
+	Min    float64 `mapstructure:"min,omitempty"`
+	Max    float64 `mapstructure:"max,omitempty"`
+}
+
+func (c *MockRuntimeConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if c.MinDuration < 0 || c.MaxDuration < 0 {
+		return errors.New("mock_runtime.min_duration and max_duration must not be negative")
+	}
+	if c.MaxDuration > 0 && c.MaxDuration < c.MinDuration {
+		return errors.New("mock_runtime.max_duration must not be less than min_duration")
+	}
+	if c.FailureRate < 0 || c.FailureRate > 1 {
+		return errors.New("mock_runtime.failure_rate must be between 0 and 1")
+	}
+	for name, metric := range c.Metrics {
+		if metric.StdDev < 0 {
+			return fmt.Errorf("mock_runtime.metrics.%s.stddev must not be negative", name)
+		}
+	}
+	return nil
+}
+
+// DurationRange returns the bounds of the duration of a benchmark.
+func (c *MockRuntimeConfig) DurationRange() (time.Duration, time.Duration) {
+	if c == nil || (c.MinDuration == 0 && c.MaxDuration == 0) {
+		return DefaultMockRuntimeMinDuration, DefaultMockRuntimeMaxDuration
+	}
+	if c.MaxDuration < c.MinDuration {
+		return c.MinDuration, c.MinDuration
+	}
+	return c.MinDuration, c.MaxDuration
+}
+
+// EffectiveMetrics returns the configured metrics, or a score between 0 and 1.
+func (c *MockRuntimeConfig) EffectiveMetrics() map[string]MockMetricConfig {
+	if c == nil || len(c.Metrics) == 0 {
+		return map[string]MockMetricConfig{
+			DefaultMockRuntimeMetric: {Mean: 0.7, StdDev: 0.1, Min: 0, Max: 1},
+		}
+	}
+	return c.Metrics
+}
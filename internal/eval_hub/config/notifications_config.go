@@ -0,0 +1,95 @@
+package config
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// NotifierTypeSlack posts the summaries to a Slack incoming webhook.
+	NotifierTypeSlack = "slack"
+	// NotifierTypeEmail sends the summaries by email through an SMTP server.
+	NotifierTypeEmail = "email"
+
+	DefaultNotifierTimeout = 10 * time.Second
+	DefaultSMTPPort        = 587
+)
+
+// NotificationsConfig sends a summary of an evaluation job (state, pass/fail and links)
+// to the notifiers the job names in notify, and to those of its tenant, when the job
+// reaches a terminal state.
+type NotificationsConfig struct {
+	Notifiers []NotifierConfig `mapstructure:"notifiers,omitempty"`
+	// Tenants are the notify targets of every job of a tenant, in addition to the targets
+	// of the job.
+	Tenants map[string][]string `mapstructure:"tenants,omitempty"`
+	// BaseURL is the external URL of the service, the summaries link to the job in the UI.
+	BaseURL string `mapstructure:"base_url,omitempty"`
+}
+
+// NotifierConfig is a named notifier. Jobs name it in notify, or name the channel of a
+// Slack notifier, e.g. notify: ["#ml-evals"].
+type NotifierConfig struct {
+	Name    string               `mapstructure:"name"`
+	Type    string               `mapstructure:"type"`
+	Timeout time.Duration        `mapstructure:"timeout,omitempty"`
+	Slack   *SlackNotifierConfig `mapstructure:"slack,omitempty"`
+	Email   *EmailNotifierConfig `mapstructure:"email,omitempty"`
+}
+
+type SlackNotifierConfig struct {
+	// WebhookURL is the incoming webhook, which posts to the channel it was created for. It
+	// holds the credentials of the webhook and is best given as ${ENV} or a secret.
+	WebhookURL string `mapstructure:"webhook_url" json:"-"`
+	// Channel is the channel of the webhook, e.g. #ml-evals, that jobs can name in notify.
+	Channel string `mapstructure:"channel,omitempty"`
+}
+
+type EmailNotifierConfig struct {
+	Host string `mapstructure:"host"`
+	Port int    `mapstructure:"port,omitempty"`
+	// Username and Password authenticate to the server, which must then offer STARTTLS.
+	Username string   `mapstructure:"username,omitempty"`
+	Password string   `mapstructure:"password,omitempty" json:"-"`
+	From     string   `mapstructure:"from"`
+	To       []string `mapstructure:"to"`
+}
+
+func (c *NotificationsConfig) IsEnabled() bool {
+	return c != nil && len(c.Notifiers) > 0
+}
+
+// Notifier returns the notifier a notify target names, by name or by Slack channel, or nil
+// when there is none.
+func (c *NotificationsConfig) Notifier(target string) *NotifierConfig {
+	if c == nil || target == "" {
+		return nil
+	}
+	for i := range c.Notifiers {
+		if c.Notifiers[i].Name == target {
+			return &c.Notifiers[i]
+		}
+	}
+	if strings.HasPrefix(target, "#") {
+		for i := range c.Notifiers {
+			if slack := c.Notifiers[i].Slack; c.Notifiers[i].Type == NotifierTypeSlack && slack != nil && slack.Channel == target {
+				return &c.Notifiers[i]
+			}
+		}
+	}
+	return nil
+}
+
+func (c *NotifierConfig) EffectiveTimeout() time.Duration {
+	if c.Timeout <= 0 {
+		return DefaultNotifierTimeout
+	}
+	return c.Timeout
+}
+
+func (c *EmailNotifierConfig) EffectivePort() int {
+	if c.Port <= 0 {
+		return DefaultSMTPPort
+	}
+	return c.Port
+}
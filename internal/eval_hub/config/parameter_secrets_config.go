@@ -0,0 +1,17 @@
+package config
+
+// ParameterSecretsConfig is where the local runtime reads the secretRef://name/key parameters
+// of the benchmarks from: the value of a reference is the content of the file <Dir>/name/key,
+// the layout of a Kubernetes secret mounted as a volume. The kubernetes runtime reads the
+// references from the secrets in the namespace of the job instead.
+type ParameterSecretsConfig struct {
+	Dir string `mapstructure:"dir"`
+}
+
+// SecretsDir returns the directory of the parameter secrets, empty when none is configured.
+func (c *ParameterSecretsConfig) SecretsDir() string {
+	if c == nil {
+		return ""
+	}
+	return c.Dir
+}
@@ -0,0 +1,22 @@
+package config
+
+// PostProcessingConfig lists the post-processors that run on the metrics of a benchmark when
+// it completes. They run in order, each on the metrics of the previous one, and the result
+// is stored next to the metrics the runtime reported.
+type PostProcessingConfig struct {
+	Processors []PostProcessorConfig `mapstructure:"processors,omitempty"`
+}
+
+type PostProcessorConfig struct {
+	// Type is the name the post-processor is registered with, e.g. rename.
+	Type string `mapstructure:"type"`
+	// Providers and Tenants restrict the post-processor to the benchmarks of these
+	// providers and the jobs of these tenants; it runs for all of them when they are empty.
+	Providers []string       `mapstructure:"providers,omitempty"`
+	Tenants   []string       `mapstructure:"tenants,omitempty"`
+	Options   map[string]any `mapstructure:"options,omitempty"`
+}
+
+func (c *PostProcessingConfig) IsEnabled() bool {
+	return c != nil && len(c.Processors) > 0
+}
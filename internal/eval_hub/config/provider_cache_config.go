@@ -0,0 +1,24 @@
+package config
+
+import "time"
+
+const DefaultProviderCacheTTL = 30 * time.Second
+
+// ProviderCacheConfig enables the in-memory cache of the provider listings of this replica.
+// The cache is cleared when this replica writes a provider or reloads the system providers;
+// the writes of other replicas are seen after the TTL.
+type ProviderCacheConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	TTL     time.Duration `mapstructure:"ttl,omitempty"`
+}
+
+func (c *ProviderCacheConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *ProviderCacheConfig) EffectiveTTL() time.Duration {
+	if c == nil || c.TTL <= 0 {
+		return DefaultProviderCacheTTL
+	}
+	return c.TTL
+}
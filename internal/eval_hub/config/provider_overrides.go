@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+	"go.yaml.in/yaml/v4"
+)
+
+// providerOverridesDir is the directory, next to the providers directory, of the files that
+// patch the system providers for an environment.
+const providerOverridesDir = "provider_overrides"
+
+// providerOverride is an override file: the id of the system provider it patches and the
+// fields it sets. Mappings are merged into the provider, other values, lists included,
+// replace the values of the provider.
+type providerOverride struct {
+	file   string
+	patch  map[string]any
+	fields []string
+}
+
+// loadProviderOverrides reads the override files of the providers directory, by provider
+// id, in the order of their file names. The environment variable references of their values
+// are expanded as in the provider files.
+func loadProviderOverrides(logger *slog.Logger, providersDir string) (map[string][]providerOverride, error) {
+	overrides := map[string][]providerOverride{}
+	if providersDir == "" {
+		return overrides, nil
+	}
+	dir := filepath.Join(filepath.Dir(providersDir), providerOverridesDir)
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return overrides, nil
+		}
+		return nil, err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".yaml") {
+			continue
+		}
+		path := filepath.Join(dir, file.Name())
+		id, override, err := readProviderOverride(logger, path)
+		if err != nil {
+			return nil, fmt.Errorf("provider override %s: %w", path, err)
+		}
+		overrides[id] = append(overrides[id], override)
+	}
+	return overrides, nil
+}
+
+func readProviderOverride(logger *slog.Logger, path string) (string, providerOverride, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", providerOverride{}, err
+	}
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal(content, document); err != nil {
+		return "", providerOverride{}, err
+	}
+	var unset []string
+	expandNode(document, &unset)
+	if len(unset) > 0 {
+		slices.Sort(unset)
+		logger.Warn("Environment variables referenced by the configuration are not set", "file", path, "variables", slices.Compact(unset))
+	}
+	var patch map[string]any
+	if err := document.Decode(&patch); err != nil {
+		return "", providerOverride{}, err
+	}
+	id, _ := patch["id"].(string)
+	if strings.TrimSpace(id) == "" {
+		return "", providerOverride{}, fmt.Errorf("the id of the provider to override is required")
+	}
+	delete(patch, "id")
+	return id, providerOverride{file: path, patch: patch, fields: overriddenFields("", patch)}, nil
+}
+
+// overriddenFields returns the paths of the values set by a patch, e.g. runtime.k8s.image.
+func overriddenFields(prefix string, patch map[string]any) []string {
+	var fields []string
+	for key, value := range patch {
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			fields = append(fields, overriddenFields(prefix+key+".", nested)...)
+			continue
+		}
+		fields = append(fields, prefix+key)
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// applyProviderOverrides merges the overrides of a provider into the values read from its
+// file, and logs the fields that each override sets.
+func applyProviderOverrides(logger *slog.Logger, configValues *viper.Viper, id string, overrides []providerOverride) error {
+	for _, override := range overrides {
+		if err := configValues.MergeConfigMap(override.patch); err != nil {
+			return fmt.Errorf("provider override %s: %w", override.file, err)
+		}
+		logger.Info("Provider overridden", "provider_id", id, "file", override.file, "fields", override.fields)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package config_test
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+)
+
+const overriddenProvider = `id: lm_evaluation_harness
+name: LM Evaluation Harness
+runtime:
+  k8s:
+    image: quay.io/opendatahub/ta-lmes-job:odh-3.4-ea2
+    entrypoint:
+    - /opt/app-root/bin/python
+    cpu_limit: 500m
+    memory_limit: 4Gi
+benchmarks:
+- id: arc_easy
+  name: ARC Easy
+`
+
+func writeConfigFile(t *testing.T, configRoot string, dir string, name string, content string) {
+	t.Helper()
+	path := filepath.Join(configRoot, dir)
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		t.Fatalf("mkdir %s: %v", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(path, name), []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+func TestLoadProviderConfigs_AppliesOverrides(t *testing.T) {
+	configRoot := t.TempDir()
+	writeConfigFile(t, configRoot, "providers", "lm_evaluation_harness.yaml", overriddenProvider)
+	writeConfigFile(t, configRoot, "provider_overrides", "10-image.yaml", `id: lm_evaluation_harness
+runtime:
+  k8s:
+    image: registry.internal/lmes-job:${LMES_TAG:-v2}
+    cpu_limit: "2"
+`)
+	writeConfigFile(t, configRoot, "provider_overrides", "20-gpu.yaml", `id: lm_evaluation_harness
+runtime:
+  k8s:
+    cpu_limit: "4"
+    gpu:
+      resource: nvidia.com/gpu
+      count: 1
+      node_selector:
+        nvidia.com/gpu.product: A100-SXM4-40GB
+`)
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, nil))
+	providers, err := config.LoadProviderConfigs(logger, testhelpers.NewValidator(t), configRoot)
+	if err != nil {
+		t.Fatalf("LoadProviderConfigs failed: %v", err)
+	}
+	k8s := providers["lm_evaluation_harness"].Runtime.K8s
+	if k8s.Image != "registry.internal/lmes-job:v2" {
+		t.Errorf("image = %q, want the overridden image", k8s.Image)
+	}
+	if k8s.CPULimit != "4" {
+		t.Errorf("cpu_limit = %q, want the value of the last override", k8s.CPULimit)
+	}
+	if k8s.MemoryLimit != "4Gi" || len(k8s.Entrypoint) != 1 {
+		t.Errorf("expected the fields that are not overridden to be kept, got %+v", k8s)
+	}
+	if k8s.GPU == nil || k8s.GPU.NodeSelector["nvidia.com/gpu.product"] != "A100-SXM4-40GB" {
+		t.Errorf("expected the overridden GPU config, got %+v", k8s.GPU)
+	}
+	if len(providers["lm_evaluation_harness"].Benchmarks) != 1 {
+		t.Errorf("expected the benchmarks to be kept")
+	}
+	for _, want := range []string{
+		"fields=\"[runtime.k8s.cpu_limit runtime.k8s.image]\"",
+		"fields=\"[runtime.k8s.cpu_limit runtime.k8s.gpu.count runtime.k8s.gpu.node_selector.nvidia.com/gpu.product runtime.k8s.gpu.resource]\"",
+	} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("expected the overridden fields to be logged as %s, got:\n%s", want, logs.String())
+		}
+	}
+}
+
+func TestLoadProviderConfigs_RejectsInvalidOverrides(t *testing.T) {
+	tests := map[string]struct {
+		override string
+		want     string
+	}{
+		"unknown provider": {override: "id: lm_eval\nname: typo\n", want: `there is no system provider "lm_eval"`},
+		"no id":            {override: "name: no id\n", want: "the id of the provider to override is required"},
+		"invalid value":    {override: "id: lm_evaluation_harness\nruntime:\n  k8s:\n    image_pull_policy: Sometimes\n", want: "image_pull_policy"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			configRoot := t.TempDir()
+			writeConfigFile(t, configRoot, "providers", "lm_evaluation_harness.yaml", overriddenProvider)
+			writeConfigFile(t, configRoot, "provider_overrides", "override.yaml", tt.override)
+
+			_, err := config.LoadProviderConfigs(slog.New(slog.DiscardHandler), testhelpers.NewValidator(t), configRoot)
+			if err == nil || !strings.Contains(strings.ToLower(err.Error()), strings.ToLower(tt.want)) {
+				t.Fatalf("expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"net/http"
+	"net/url"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// The destinations of outbound connections that can have their own proxy settings.
+const (
+	ProxyDestinationMLFlow    = "mlflow"
+	ProxyDestinationAdmission = "admission"
+	ProxyDestinationOCI       = "oci"
+	ProxyDestinationModel     = "model"
+	// ProxyDestinationNotifications is the destination of the Slack notifiers.
+	ProxyDestinationNotifications = "notifications"
+)
+
+// ProxySettings route outbound connections through an HTTP proxy. Empty fields fall back to
+// the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables (or their lowercase forms).
+type ProxySettings struct {
+	HTTPProxy  string `mapstructure:"http_proxy,omitempty" json:"http_proxy,omitempty"`
+	HTTPSProxy string `mapstructure:"https_proxy,omitempty" json:"https_proxy,omitempty"`
+	// NoProxy is a comma-separated list of hosts, domains and CIDRs that are reached directly.
+	NoProxy string `mapstructure:"no_proxy,omitempty" json:"no_proxy,omitempty"`
+	// Direct connects without a proxy, whatever the environment variables say.
+	Direct bool `mapstructure:"direct,omitempty" json:"direct,omitempty"`
+}
+
+// ProxyConfig holds the proxy settings of the outbound connections of the service: the
+// settings of all destinations, overridden per destination (mlflow, admission, oci, model, notifications)
+// under destinations.
+type ProxyConfig struct {
+	ProxySettings `mapstructure:",squash"`
+	Destinations  map[string]ProxySettings `mapstructure:"destinations,omitempty" json:"destinations,omitempty"`
+}
+
+// override returns the settings with the fields set in o applied over them.
+func (s ProxySettings) override(o ProxySettings) ProxySettings {
+	if o.Direct {
+		return ProxySettings{Direct: true}
+	}
+	if o.HTTPProxy != "" || o.HTTPSProxy != "" {
+		s.Direct = false
+	}
+	if o.HTTPProxy != "" {
+		s.HTTPProxy = o.HTTPProxy
+	}
+	if o.HTTPSProxy != "" {
+		s.HTTPSProxy = o.HTTPSProxy
+	}
+	if o.NoProxy != "" {
+		s.NoProxy = o.NoProxy
+	}
+	return s
+}
+
+// IsDirect returns true when the connections are not routed through a proxy.
+func (s ProxySettings) IsDirect() bool {
+	return s.Direct || (s.HTTPProxy == "" && s.HTTPSProxy == "")
+}
+
+// ProxyFunc returns the Proxy function of an http.Transport for the settings, nil when the
+// connections are direct.
+func (s ProxySettings) ProxyFunc() func(*http.Request) (*url.URL, error) {
+	if s.IsDirect() {
+		return nil
+	}
+	proxyURL := (&httpproxy.Config{HTTPProxy: s.HTTPProxy, HTTPSProxy: s.HTTPSProxy, NoProxy: s.NoProxy}).ProxyFunc()
+	return func(r *http.Request) (*url.URL, error) {
+		return proxyURL(r.URL)
+	}
+}
+
+// Settings returns the effective proxy settings of the destination: the settings of the
+// destination over the settings of all destinations over the environment variables.
+func (c *ProxyConfig) Settings(destination string) ProxySettings {
+	env := httpproxy.FromEnvironment()
+	settings := ProxySettings{HTTPProxy: env.HTTPProxy, HTTPSProxy: env.HTTPSProxy, NoProxy: env.NoProxy}
+	if c == nil {
+		return settings
+	}
+	settings = settings.override(c.ProxySettings)
+	if destinationSettings, found := c.Destinations[destination]; found {
+		settings = settings.override(destinationSettings)
+	}
+	return settings
+}
+
+// ProxyFunc returns the Proxy function of an http.Transport for the destination, nil when
+// its connections are direct.
+func (c *ProxyConfig) ProxyFunc(destination string) func(*http.Request) (*url.URL, error) {
+	return c.Settings(destination).ProxyFunc()
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultRequestTimeout is the deadline of the requests that change resources.
+	DefaultRequestTimeout = 30 * time.Second
+	// DefaultReadRequestTimeout is the deadline of the GET requests.
+	DefaultReadRequestTimeout = 10 * time.Second
+)
+
+// defaultRouteTimeouts are the deadlines of the routes that take longer than the others by
+// default: job creation, which may create the jobs of a sweep, and the exports and imports.
+var defaultRouteTimeouts = map[string]time.Duration{
+	"POST /api/v1/evaluations/jobs":                          2 * time.Minute,
+	"GET /api/v1/evaluations/providers/{provider_id}/export": 2 * time.Minute,
+	"POST /api/v1/evaluations/providers/import":              2 * time.Minute,
+	"GET /api/v1/admin/export":                               10 * time.Minute,
+	"POST /api/v1/admin/import":                              10 * time.Minute,
+}
+
+// RequestTimeoutsConfig is the deadline of the requests of each route. A request that
+// exceeds it is answered with 504 and the steps that it completed; the storage and runtime
+// calls that it makes get the deadline too.
+type RequestTimeoutsConfig struct {
+	// Disabled turns the deadlines off, the requests are only bound by the timeouts of the
+	// HTTP server.
+	Disabled bool `mapstructure:"disabled,omitempty"`
+	// Default is the deadline of the requests that are not GET requests.
+	Default time.Duration `mapstructure:"default,omitempty"`
+	// Read is the deadline of the GET requests.
+	Read time.Duration `mapstructure:"read,omitempty"`
+	// Routes are the deadlines of routes, by "METHOD pattern" or pattern as the routes are
+	// registered, e.g. "GET /api/v1/evaluations/jobs/{job_id}". A negative deadline turns the
+	// deadline of the route off.
+	Routes map[string]time.Duration `mapstructure:"routes,omitempty"`
+}
+
+// Timeout returns the deadline of the requests with the method to the route of the pattern,
+// 0 when they have none.
+func (c *RequestTimeoutsConfig) Timeout(method, pattern string) time.Duration {
+	if c != nil && c.Disabled {
+		return 0
+	}
+	timeout, ok := c.routeTimeout(method, pattern)
+	if !ok {
+		timeout, ok = defaultRouteTimeouts[method+" "+pattern]
+	}
+	if !ok {
+		timeout = c.methodTimeout(method)
+	}
+	return max(timeout, 0)
+}
+
+func (c *RequestTimeoutsConfig) routeTimeout(method, pattern string) (time.Duration, bool) {
+	if c == nil {
+		return 0, false
+	}
+	if timeout, ok := c.Routes[method+" "+pattern]; ok {
+		return timeout, true
+	}
+	timeout, ok := c.Routes[pattern]
+	return timeout, ok
+}
+
+func (c *RequestTimeoutsConfig) methodTimeout(method string) time.Duration {
+	if method == http.MethodGet || method == http.MethodHead {
+		if c == nil || c.Read == 0 {
+			return DefaultReadRequestTimeout
+		}
+		return c.Read
+	}
+	if c == nil || c.Default == 0 {
+		return DefaultRequestTimeout
+	}
+	return c.Default
+}
@@ -0,0 +1,25 @@
+package config
+
+import "time"
+
+const DefaultResultCacheTTL = 24 * time.Hour
+
+// ResultCacheConfig enables the benchmark result cache. Completed benchmark results are
+// recorded per model, benchmark and parameters, and jobs created with
+// reuse_cached_results reuse a recorded result that is younger than the TTL instead of
+// running the benchmark again.
+type ResultCacheConfig struct {
+	Enabled bool          `mapstructure:"enabled"`
+	TTL     time.Duration `mapstructure:"ttl,omitempty"`
+}
+
+func (c *ResultCacheConfig) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *ResultCacheConfig) EffectiveTTL() time.Duration {
+	if c == nil || c.TTL <= 0 {
+		return DefaultResultCacheTTL
+	}
+	return c.TTL
+}
@@ -0,0 +1,63 @@
+package config
+
+// SmokeTestTag is the job tag that runs the benchmarks of a job on the smoke sample size.
+const SmokeTestTag = "smoke_test"
+
+// SamplingConfig caps the examples and benchmarks that a job may run, so that a
+// misconfigured CI pipeline does not start full-dataset runs. The caps of a tenant replace
+// the global caps that they set.
+type SamplingConfig struct {
+	SamplingLimits `mapstructure:",squash"`
+	// SmokeTestNumExamples is the num_examples of the benchmarks of the jobs tagged
+	// smoke_test, a lower num_examples of the benchmark is kept.
+	SmokeTestNumExamples int                       `mapstructure:"smoke_test_num_examples,omitempty"`
+	Tenants              map[string]SamplingLimits `mapstructure:"tenants,omitempty"`
+}
+
+// SamplingLimits are the caps of a job, zero is no cap.
+type SamplingLimits struct {
+	// MaxNumExamples caps the num_examples of each benchmark, benchmarks that do not set
+	// num_examples run this many examples instead of the full dataset.
+	MaxNumExamples int `mapstructure:"max_num_examples,omitempty"`
+	// MaxBenchmarks caps the benchmarks of a job, larger jobs are rejected on creation.
+	MaxBenchmarks int `mapstructure:"max_benchmarks,omitempty"`
+}
+
+// Limits returns the caps of the jobs of a tenant.
+func (c *SamplingConfig) Limits(tenant string) SamplingLimits {
+	if c == nil {
+		return SamplingLimits{}
+	}
+	limits := c.SamplingLimits
+	if tenantLimits, ok := c.Tenants[tenant]; ok {
+		if tenantLimits.MaxNumExamples > 0 {
+			limits.MaxNumExamples = tenantLimits.MaxNumExamples
+		}
+		if tenantLimits.MaxBenchmarks > 0 {
+			limits.MaxBenchmarks = tenantLimits.MaxBenchmarks
+		}
+	}
+	return limits
+}
+
+// NumExamples returns the num_examples that a benchmark of a job of the tenant runs with,
+// given the num_examples of the benchmark, nil for the full dataset.
+func (c *SamplingConfig) NumExamples(tenant string, smokeTest bool, numExamples *int) *int {
+	if c == nil {
+		return numExamples
+	}
+	if smokeTest && c.SmokeTestNumExamples > 0 {
+		numExamples = capNumExamples(numExamples, c.SmokeTestNumExamples)
+	}
+	if limit := c.Limits(tenant).MaxNumExamples; limit > 0 {
+		numExamples = capNumExamples(numExamples, limit)
+	}
+	return numExamples
+}
+
+func capNumExamples(numExamples *int, limit int) *int {
+	if numExamples != nil && *numExamples <= limit {
+		return numExamples
+	}
+	return &limit
+}
@@ -3,12 +3,18 @@ package config
 import (
 	"fmt"
 	"net/http"
+	"slices"
 	"time"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
 // DefaultMaxRequestBodyBytes is applied when service.max_request_body_bytes is omitted or zero.
 const DefaultMaxRequestBodyBytes int64 = 10 << 20 // 10 MiB
 
+// DefaultCompressionMinBytes is applied when service.compression_min_bytes is omitted or zero.
+const DefaultCompressionMinBytes = 1 << 10 // 1 KiB
+
 type ServiceConfig struct {
 	Version         string `mapstructure:"version,omitempty"`
 	Build           string `mapstructure:"build,omitempty"`
@@ -21,6 +27,14 @@ type ServiceConfig struct {
 	LocalMode       bool   `mapstructure:"local_mode,omitempty"`
 	TLSCertFile     string `mapstructure:"tls_cert_file,omitempty"`
 	TLSKeyFile      string `mapstructure:"tls_key_file,omitempty"`
+	// TLSClientCAFile is the CA bundle client certificates are verified against, see TLSClientAuth.
+	TLSClientCAFile string `mapstructure:"tls_client_ca_file,omitempty"`
+	// TLSClientAuth is the client certificate policy: none (default), optional (verified when
+	// presented) or require.
+	TLSClientAuth string `mapstructure:"tls_client_auth,omitempty"`
+	// TLSReloadInterval is how often the TLS files are checked for changes, so that rotated
+	// certificates are served without a restart. Zero uses the default (30s).
+	TLSReloadInterval time.Duration `mapstructure:"tls_reload_interval,omitempty"`
 	// ReadTimeout is http.Server ReadTimeout (entire request read). Zero uses default (15s).
 	ReadTimeout time.Duration `mapstructure:"read_timeout,omitempty"`
 	// WriteTimeout is http.Server WriteTimeout. Zero uses default (15s).
@@ -36,6 +50,51 @@ type ServiceConfig struct {
 	// MaxRequestBodyBytes limits incoming request bodies via http.MaxBytesReader.
 	// Zero or unset uses DefaultMaxRequestBodyBytes. -1 disables the limit.
 	MaxRequestBodyBytes int64 `mapstructure:"max_request_body_bytes,omitempty"`
+	// DisableSwaggerUI turns off the interactive documentation at /docs. The OpenAPI
+	// specification itself is always served.
+	DisableSwaggerUI bool `mapstructure:"disable_swagger_ui,omitempty"`
+	// DisableCompression turns off gzip/deflate compression of responses.
+	DisableCompression bool `mapstructure:"disable_compression,omitempty"`
+	// CompressionMinBytes is the size from which responses are compressed. Zero or unset uses
+	// DefaultCompressionMinBytes.
+	CompressionMinBytes int `mapstructure:"compression_min_bytes,omitempty"`
+	// EnableAdminAPI serves /api/v1/admin/config, which changes settings of the running
+	// service. It is off by default since the settings are not scoped to a tenant.
+	EnableAdminAPI bool `mapstructure:"enable_admin_api,omitempty"`
+	// Runtimes are the other runtimes of the deployment, besides the default runtime, local
+	// in local mode and kubernetes otherwise. Benchmarks run on the runtime that their
+	// provider declares, or that their job selects with its runtime field.
+	Runtimes []string `mapstructure:"runtimes,omitempty"`
+	// Runtime replaces the default runtime of the deployment, e.g. with mock to load test the
+	// service without running the adapters.
+	Runtime string `mapstructure:"runtime,omitempty"`
+	// RequestTimeouts are the deadlines of the requests of each route.
+	RequestTimeouts *RequestTimeoutsConfig `mapstructure:"request_timeouts,omitempty"`
+}
+
+// DefaultRuntime returns the runtime of the jobs that do not select one.
+func (c *ServiceConfig) DefaultRuntime() string {
+	if c != nil && c.Runtime != "" {
+		return c.Runtime
+	}
+	if c != nil && c.LocalMode {
+		return api.RuntimeLocal
+	}
+	return api.RuntimeKubernetes
+}
+
+// EnabledRuntimes returns the default runtime followed by the other configured runtimes.
+func (c *ServiceConfig) EnabledRuntimes() []string {
+	enabled := []string{c.DefaultRuntime()}
+	if c == nil {
+		return enabled
+	}
+	for _, name := range c.Runtimes {
+		if !slices.Contains(enabled, name) {
+			enabled = append(enabled, name)
+		}
+	}
+	return enabled
 }
 
 // TLSEnabled returns true when both TLS cert and key paths are configured.
@@ -43,13 +102,41 @@ func (c *ServiceConfig) TLSEnabled() bool {
 	return c.TLSCertFile != "" && c.TLSKeyFile != ""
 }
 
+const (
+	TLSClientAuthNone     = "none"
+	TLSClientAuthOptional = "optional"
+	TLSClientAuthRequire  = "require"
+)
+
 // ValidateTLSConfig returns an error when exactly one of TLSCertFile or
-// TLSKeyFile is set, which would cause a silent fallback to plain HTTP.
+// TLSKeyFile is set, which would cause a silent fallback to plain HTTP,
+// or when client certificate verification is misconfigured.
 func (c *ServiceConfig) ValidateTLSConfig() error {
 	if (c.TLSCertFile != "") != (c.TLSKeyFile != "") {
 		return fmt.Errorf("partial TLS config: both TLSCertFile and TLSKeyFile must be provided")
 	}
-	return nil
+	if c.TLSReloadInterval < 0 {
+		return fmt.Errorf("service.tls_reload_interval must not be negative")
+	}
+	switch c.TLSClientAuth {
+	case "", TLSClientAuthNone:
+		return nil
+	case TLSClientAuthOptional, TLSClientAuthRequire:
+		if !c.TLSEnabled() {
+			return fmt.Errorf("service.tls_client_auth %q requires tls_cert_file and tls_key_file", c.TLSClientAuth)
+		}
+		if c.TLSClientCAFile == "" {
+			return fmt.Errorf("service.tls_client_auth %q requires tls_client_ca_file", c.TLSClientAuth)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid service.tls_client_auth %q: must be %s, %s or %s", c.TLSClientAuth, TLSClientAuthNone, TLSClientAuthOptional, TLSClientAuthRequire)
+	}
+}
+
+// ClientCertificatesEnabled returns true when client certificates are verified.
+func (c *ServiceConfig) ClientCertificatesEnabled() bool {
+	return c.TLSClientAuth == TLSClientAuthOptional || c.TLSClientAuth == TLSClientAuthRequire
 }
 
 const (
@@ -57,8 +144,18 @@ const (
 	defaultWriteTimeout      = 15 * time.Second
 	defaultIdleTimeout       = 60 * time.Second
 	defaultReadHeaderTimeout = 15 * time.Second
+	defaultTLSReloadInterval = 30 * time.Second
 )
 
+// EffectiveTLSReloadInterval returns how often the TLS files are checked for changes. When unset
+// or non-positive, returns 30s.
+func (c *ServiceConfig) EffectiveTLSReloadInterval() time.Duration {
+	if c == nil || c.TLSReloadInterval <= 0 {
+		return defaultTLSReloadInterval
+	}
+	return c.TLSReloadInterval
+}
+
 // EffectiveReadTimeout returns http.Server ReadTimeout. When unset or non-positive, returns 15s.
 func (c *ServiceConfig) EffectiveReadTimeout() time.Duration {
 	if c == nil || c.ReadTimeout <= 0 {
@@ -115,6 +212,15 @@ func (c *ServiceConfig) EffectiveMaxRequestBodyBytes() int64 {
 	return c.MaxRequestBodyBytes
 }
 
+// EffectiveCompressionMinBytes returns the size from which responses are compressed. When unset
+// or non-positive, returns DefaultCompressionMinBytes.
+func (c *ServiceConfig) EffectiveCompressionMinBytes() int {
+	if c == nil || c.CompressionMinBytes <= 0 {
+		return DefaultCompressionMinBytes
+	}
+	return c.CompressionMinBytes
+}
+
 // ValidateHTTPConfig returns an error when HTTP-related settings are invalid.
 func (c *ServiceConfig) ValidateHTTPConfig() error {
 	if c == nil {
@@ -135,6 +241,9 @@ func (c *ServiceConfig) ValidateHTTPConfig() error {
 	if c.MaxHeaderBytes < 0 {
 		return fmt.Errorf("service.max_header_bytes must not be negative")
 	}
+	if c.CompressionMinBytes < 0 {
+		return fmt.Errorf("service.compression_min_bytes must not be negative")
+	}
 	if c.MaxRequestBodyBytes < -1 {
 		return fmt.Errorf("service.max_request_body_bytes must be -1 (unlimited) or >= 0")
 	}
@@ -14,6 +14,20 @@ type SidecarConfig struct {
 	Model            *SidecarModelConfig     `mapstructure:"model,omitempty" json:"model,omitempty"`
 	SidecarContainer *SidecarContainerConfig `mapstructure:"sidecar_container,omitempty" json:"sidecar_container,omitempty"`
 	OTEL             *OTELConfig             `mapstructure:"otel,omitempty" json:"otel,omitempty"`
+	// Proxy is set from the proxy config of the service when writing sidecar_config.json, so
+	// that the sidecar reaches MLflow, the OCI registry and the model through the same proxies.
+	Proxy *ProxyConfig `mapstructure:"-" json:"proxy,omitempty"`
+	// ResultRelay is set per job pod when the adapter writes its status events to a results
+	// file instead of sending them to the sidecar.
+	ResultRelay *SidecarResultRelayConfig `mapstructure:"-" json:"result_relay,omitempty"`
+}
+
+// SidecarResultRelayConfig makes the sidecar tail the results file of the adapter and post
+// each status event in it to the events endpoint of the job, through its eval-hub proxy.
+type SidecarResultRelayConfig struct {
+	Path         string        `json:"path"`
+	JobID        string        `json:"job_id"`
+	PollInterval time.Duration `json:"poll_interval,omitempty"`
 }
 
 // SidecarModelConfig holds the model credential-injection proxy settings written into
@@ -26,6 +40,9 @@ type SidecarModelConfig struct {
 	AuthSecretMountPath string        `mapstructure:"auth_secret_mount_path,omitempty" json:"auth_secret_mount_path,omitempty"`
 	HTTPTimeout         time.Duration `mapstructure:"http_timeout,omitempty" json:"http_timeout,omitempty"`
 	InsecureSkipVerify  bool          `mapstructure:"insecure_skip_verify,omitempty" json:"insecure_skip_verify,omitempty"`
+	// AuthTokenPath is the file of the bearer token sent to the model when the adapter sends
+	// none, instead of the service account token of the pod.
+	AuthTokenPath string `mapstructure:"auth_token_path,omitempty" json:"auth_token_path,omitempty"`
 }
 
 // SidecarOCIConfig holds optional TLS/timeout overrides for the OCI registry HTTP client.
@@ -44,6 +61,13 @@ type EvalHubClientConfig struct {
 	Token              string        `mapstructure:"token,omitempty" json:"-"`
 	TokenCacheTimeout  time.Duration `mapstructure:"token_cache_timeout" json:"token_cache_timeout,omitempty"`
 	TLSConfig          *tls.Config   `json:"-"` // set at runtime, not from config file
+	// ClientCertPath and ClientKeyPath are the client certificate the sidecar presents to eval-hub
+	// when it verifies client certificates (service.tls_client_auth).
+	ClientCertPath string `mapstructure:"client_cert_path,omitempty" json:"client_cert_path,omitempty"`
+	ClientKeyPath  string `mapstructure:"client_key_path,omitempty" json:"client_key_path,omitempty"`
+	// ClientCertSecret is a kubernetes.io/tls secret in the job namespace; the k8s runtime mounts it
+	// in the sidecar of job pods and sets ClientCertPath and ClientKeyPath to its files.
+	ClientCertSecret string `mapstructure:"client_cert_secret,omitempty" json:"-"`
 }
 
 // SidecarMLFlowConfig holds sidecar-specific MLflow settings (e.g. token cache TTL).
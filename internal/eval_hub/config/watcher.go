@@ -9,11 +9,12 @@ import (
 	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
 	"github.com/fsnotify/fsnotify"
 	"github.com/go-playground/validator/v10"
 )
 
-// Watcher monitors provider and collection config directories for changes
+// Watcher monitors provider, provider override and collection config directories for changes
 // and reloads system resources into storage when files are modified.
 type Watcher struct {
 	logger    *slog.Logger
@@ -21,6 +22,9 @@ type Watcher struct {
 	storage   abstractions.Storage
 	configDir string
 	debounce  time.Duration
+	// builtinProviders are registered by the service itself (e.g. the local mode demo
+	// provider) and are kept on every reload.
+	builtinProviders map[string]api.ProviderResource
 }
 
 // NewWatcher creates a config watcher that monitors the given config directory
@@ -47,9 +51,10 @@ func (w *Watcher) Watch(ctx context.Context) error {
 	defer func() { _ = watcher.Close() }()
 
 	providerDir := w.resolveDir("providers")
+	providerOverridesDir := w.resolveDir(providerOverridesDir)
 	collectionDir := w.resolveDir("collections")
 
-	dirs := []string{providerDir, collectionDir}
+	dirs := []string{providerDir, providerOverridesDir, collectionDir}
 	watched := 0
 	for _, dir := range dirs {
 		if _, err := os.Stat(dir); err != nil {
@@ -160,6 +165,12 @@ func (w *Watcher) reload() {
 		return
 	}
 
+	for id, provider := range w.builtinProviders {
+		if _, exists := providerConfigs[id]; !exists {
+			providerConfigs[id] = provider
+		}
+	}
+
 	if err := w.storage.LoadSystemResources(collectionConfigs, providerConfigs); err != nil {
 		w.logger.Error("Failed to update system resources in storage", "error", err.Error())
 		return
@@ -201,12 +212,13 @@ func (w *Watcher) resolveParentDir() string {
 	return ""
 }
 
-func SetupWatcher(logger *slog.Logger, validate *validator.Validate, storage abstractions.Storage, configDir string) (chan struct{}, context.CancelFunc) {
+func SetupWatcher(ctx context.Context, logger *slog.Logger, validate *validator.Validate, storage abstractions.Storage, configDir string, builtinProviders map[string]api.ProviderResource) (chan struct{}, context.CancelFunc) {
 	// Start config watcher to reload system providers and collections on file changes
-	watcherCtx, watcherCancel := context.WithCancel(context.Background())
+	watcherCtx, watcherCancel := context.WithCancel(ctx)
 	doneCh := make(chan struct{})
 
 	configWatcher := NewWatcher(logger, validate, storage, configDir)
+	configWatcher.builtinProviders = builtinProviders
 	go func() {
 		defer close(doneCh)
 		if err := configWatcher.Watch(watcherCtx); err != nil {
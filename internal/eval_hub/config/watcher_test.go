@@ -205,3 +205,32 @@ func writeTestProvider(t *testing.T, dir, id, name string) {
 		t.Fatalf("Failed to write test provider: %v", err)
 	}
 }
+
+func TestWatcher_ReloadKeepsBuiltinProviders(t *testing.T) {
+	dir := t.TempDir()
+	provDir := filepath.Join(dir, "providers")
+	if err := os.MkdirAll(provDir, 0755); err != nil {
+		t.Fatalf("MkdirAll providers: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "collections"), 0755); err != nil {
+		t.Fatalf("MkdirAll collections: %v", err)
+	}
+	writeTestProvider(t, provDir, "alpha", "Alpha Provider")
+
+	store := &mockStorage{}
+	w := NewWatcher(logging.FallbackLogger(), testhelpers.NewValidator(t), store, dir)
+	w.builtinProviders = map[string]api.ProviderResource{
+		"echo":  {Resource: api.Resource{ID: "echo"}, ProviderConfig: api.ProviderConfig{Name: "Echo"}},
+		"alpha": {Resource: api.Resource{ID: "alpha"}, ProviderConfig: api.ProviderConfig{Name: "Builtin Alpha"}},
+	}
+
+	w.reload()
+
+	providers := store.getLastProviders()
+	if _, ok := providers["echo"]; !ok {
+		t.Fatal("Expected builtin provider 'echo' after reload")
+	}
+	if providers["alpha"].Name != "Alpha Provider" {
+		t.Fatalf("Expected configured provider to take precedence, got %q", providers["alpha"].Name)
+	}
+}
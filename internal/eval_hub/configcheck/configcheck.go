@@ -0,0 +1,336 @@
+// Package configcheck validates the configuration of the service without starting it: the
+// service config, the secret mappings, the provider, collection and message catalog configs
+// and the connectivity of the database. It backs the -validate-config mode of the service,
+// which the operator runs as an init container before rolling out a new configuration.
+package configcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/admission"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/notifications"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/validation"
+	"github.com/eval-hub/eval-hub/internal/postprocess"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	StatusOK      Status = "ok"
+	StatusWarning Status = "warning"
+	StatusError   Status = "error"
+	// StatusSkipped is the status of a check that depends on a check that failed.
+	StatusSkipped Status = "skipped"
+)
+
+// defaultDatabaseTimeout is how long the database has to answer the ping of the check.
+const defaultDatabaseTimeout = 5 * time.Second
+
+// Options are the options of a validation run.
+type Options struct {
+	// ConfigDir is the directory of the configuration files, the default lookup when empty.
+	ConfigDir string
+	Version   string
+	Build     string
+	BuildDate string
+	GitHash   string
+	// DatabaseTimeout is how long the database has to answer, 5s when unset.
+	DatabaseTimeout time.Duration
+}
+
+// Result is the outcome of a check with the problems that it found.
+type Result struct {
+	Check    string   `json:"check"`
+	Status   Status   `json:"status"`
+	Summary  string   `json:"summary,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+func (r *Result) errorf(format string, args ...any) {
+	r.Errors = append(r.Errors, fmt.Sprintf(format, args...))
+}
+
+func (r *Result) warnf(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Report is the summary of a validation run.
+type Report struct {
+	Valid    bool     `json:"valid"`
+	Errors   int      `json:"errors"`
+	Warnings int      `json:"warnings"`
+	Results  []Result `json:"results"`
+}
+
+func (r *Report) add(result Result) {
+	switch {
+	case result.Status == StatusSkipped:
+	case len(result.Errors) > 0:
+		result.Status = StatusError
+	case len(result.Warnings) > 0:
+		result.Status = StatusWarning
+	default:
+		result.Status = StatusOK
+	}
+	r.Errors += len(result.Errors)
+	r.Warnings += len(result.Warnings)
+	r.Valid = r.Errors == 0
+	r.Results = append(r.Results, result)
+}
+
+func (r *Report) skip(check string, reason string) {
+	r.add(Result{Check: check, Status: StatusSkipped, Summary: reason})
+}
+
+// WriteText writes the report in a form for humans, one line per check followed by its
+// problems.
+func (r *Report) WriteText(w io.Writer) error {
+	var b strings.Builder
+	for _, result := range r.Results {
+		fmt.Fprintf(&b, "[%s] %s", strings.ToUpper(string(result.Status)), result.Check)
+		if result.Summary != "" {
+			fmt.Fprintf(&b, ": %s", result.Summary)
+		}
+		b.WriteString("\n")
+		for _, problem := range result.Errors {
+			fmt.Fprintf(&b, "  error: %s\n", problem)
+		}
+		for _, problem := range result.Warnings {
+			fmt.Fprintf(&b, "  warning: %s\n", problem)
+		}
+	}
+	outcome := "valid"
+	if !r.Valid {
+		outcome = "invalid"
+	}
+	fmt.Fprintf(&b, "configuration is %s: %d error(s), %d warning(s)\n", outcome, r.Errors, r.Warnings)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteJSON writes the report as JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// Run validates the configuration of the service and reports every problem that it finds
+// rather than stopping at the first one. The checks that depend on the service config are
+// skipped when it cannot be loaded. Run has no side effects: the database is pinged but its
+// schemas and system resources are left untouched.
+func Run(logger *slog.Logger, options Options) *Report {
+	report := &Report{Valid: true}
+
+	report.add(checkSecrets(logger, options.ConfigDir))
+
+	serviceConfig, result := checkServiceConfig(logger, options)
+	report.add(result)
+
+	providers, result := checkProviders(logger, options.ConfigDir)
+	report.add(result)
+	if providers != nil {
+		report.add(checkCollections(logger, options.ConfigDir, providers))
+	} else {
+		report.skip("collections", "the provider configs could not be loaded")
+	}
+
+	report.add(checkMessageCatalogs(logger, options.ConfigDir))
+
+	if serviceConfig != nil {
+		timeout := options.DatabaseTimeout
+		if timeout <= 0 {
+			timeout = defaultDatabaseTimeout
+		}
+		report.add(checkDatabase(serviceConfig, timeout))
+	} else {
+		report.skip("database", "the service config could not be loaded")
+	}
+
+	return report
+}
+
+// checkSecrets checks every secret mapping, where loading the service config stops at the
+// first secret file that cannot be read.
+func checkSecrets(logger *slog.Logger, configDir string) Result {
+	result := Result{Check: "secrets"}
+	secrets, err := config.LoadSecretMap(logger, configDir)
+	if err != nil {
+		result.errorf("failed to read the secret mappings: %s", err.Error())
+		return result
+	}
+	if len(secrets.Mappings) == 0 {
+		result.Summary = "no secret mappings"
+		return result
+	}
+	if secrets.Dir == "" {
+		result.errorf("secrets.dir is required for the %d secret mapping(s)", len(secrets.Mappings))
+		return result
+	}
+	if info, err := os.Stat(secrets.Dir); err != nil || !info.IsDir() {
+		// the service starts without the secrets when the directory is missing
+		result.warnf("secrets directory %s does not exist, none of the %d secret mapping(s) are applied", secrets.Dir, len(secrets.Mappings))
+		return result
+	}
+
+	for _, mapping := range slices.Sorted(maps.Keys(secrets.Mappings)) {
+		field := secrets.Mappings[mapping]
+		fileName, optional := config.SecretFileName(mapping)
+		file := filepath.Join(secrets.Dir, fileName)
+		content, err := os.ReadFile(file)
+		switch {
+		case err != nil && os.IsNotExist(err) && optional:
+		case err != nil:
+			result.errorf("secret file %s for %s cannot be read: %s", file, field, err.Error())
+		case len(content) == 0:
+			result.warnf("secret file %s for %s is empty, %s is not set", file, field, field)
+		}
+	}
+	result.Summary = fmt.Sprintf("%d secret mapping(s) in %s", len(secrets.Mappings), secrets.Dir)
+	return result
+}
+
+// checkServiceConfig loads the service config and checks the sections that the service
+// validates at startup.
+func checkServiceConfig(logger *slog.Logger, options Options) (*config.Config, Result) {
+	result := Result{Check: "service config"}
+	serviceConfig, err := config.LoadConfig(logger, options.Version, options.Build, options.BuildDate, options.GitHash, options.ConfigDir)
+	if err != nil {
+		result.errorf("failed to load the service config: %s", err.Error())
+		return nil, result
+	}
+
+	if serviceConfig.Service == nil {
+		result.errorf("the service section is missing")
+	} else {
+		if err := serviceConfig.Service.ValidateTLSConfig(); err != nil {
+			result.errorf("%s", err.Error())
+		}
+		for _, file := range []string{serviceConfig.Service.TLSCertFile, serviceConfig.Service.TLSKeyFile, serviceConfig.Service.TLSClientCAFile} {
+			if file == "" {
+				continue
+			}
+			if _, err := os.Stat(file); err != nil {
+				result.errorf("TLS file %s cannot be read: %s", file, err.Error())
+			}
+		}
+	}
+	if serviceConfig.Database == nil {
+		result.errorf("the database section is missing")
+	}
+
+	switch backend := serviceConfig.Events.EffectiveBackend(); backend {
+	case config.EventsBackendMemory:
+	case config.EventsBackendNATS:
+		if serviceConfig.Events.NATS == nil || serviceConfig.Events.NATS.URL == "" {
+			result.errorf("events.nats.url is required for the %s events backend", backend)
+		}
+	default:
+		result.errorf("unsupported events backend: %s", backend)
+	}
+	if _, err := postprocess.NewChain(logger, serviceConfig.PostProcessing); err != nil {
+		result.errorf("invalid post_processing: %s", err.Error())
+	}
+	if _, err := admission.NewController(logger, serviceConfig.Admission, serviceConfig.Proxy); err != nil {
+		result.errorf("invalid admission: %s", err.Error())
+	}
+	if _, err := notifications.NewDispatcher(logger, serviceConfig.Notifications, serviceConfig.Proxy); err != nil {
+		result.errorf("invalid notifications: %s", err.Error())
+	}
+	if serviceConfig.CallbackAuth.IsEnabled() && serviceConfig.CallbackAuth.Secret == "" {
+		result.warnf("callback_auth.secret is not set; each replica generates its own secret")
+	}
+	return serviceConfig, result
+}
+
+func checkProviders(logger *slog.Logger, configDir string) (map[string]api.ProviderResource, Result) {
+	result := Result{Check: "providers"}
+	validate, err := validation.NewValidator()
+	if err != nil {
+		result.errorf("failed to create the validator: %s", err.Error())
+		return nil, result
+	}
+	providers, err := config.LoadProviderConfigs(logger, validate, configDir)
+	if err != nil {
+		result.errorf("failed to load the provider configs: %s", err.Error())
+		return nil, result
+	}
+	if len(providers) == 0 {
+		result.warnf("no provider configs found, no evaluation can run until providers are created")
+	}
+	result.Summary = fmt.Sprintf("%d provider(s)", len(providers))
+	return providers, result
+}
+
+// checkCollections loads the collection configs and checks that their benchmarks are the
+// benchmarks of the provider configs. A benchmark of another provider is a warning: the
+// service starts, but the jobs of the collection fail in the tenants without that provider.
+func checkCollections(logger *slog.Logger, configDir string, providers map[string]api.ProviderResource) Result {
+	result := Result{Check: "collections"}
+	validate, err := validation.NewValidator()
+	if err != nil {
+		result.errorf("failed to create the validator: %s", err.Error())
+		return result
+	}
+	collections, err := config.LoadCollectionConfigs(logger, validate, configDir)
+	if err != nil {
+		result.errorf("failed to load the collection configs: %s", err.Error())
+		return result
+	}
+
+	for _, id := range slices.Sorted(maps.Keys(collections)) {
+		for _, benchmark := range collections[id].Benchmarks {
+			provider, found := providers[benchmark.ProviderID]
+			if !found {
+				result.warnf("collection %s: benchmark %s references unknown provider %s", id, benchmark.ID, benchmark.ProviderID)
+				continue
+			}
+			if !slices.ContainsFunc(provider.Benchmarks, func(b api.BenchmarkResource) bool { return b.ID == benchmark.ID }) {
+				result.warnf("collection %s: benchmark %s is not a benchmark of provider %s", id, benchmark.ID, benchmark.ProviderID)
+			}
+		}
+	}
+	result.Summary = fmt.Sprintf("%d collection(s)", len(collections))
+	return result
+}
+
+func checkMessageCatalogs(logger *slog.Logger, configDir string) Result {
+	result := Result{Check: "message catalogs"}
+	catalogs, err := config.LoadMessageCatalogs(logger, configDir)
+	if err != nil {
+		result.errorf("failed to load the message catalogs: %s", err.Error())
+		return result
+	}
+	result.Summary = fmt.Sprintf("%d catalog(s)", len(catalogs))
+	return result
+}
+
+func checkDatabase(serviceConfig *config.Config, timeout time.Duration) Result {
+	result := Result{Check: "database"}
+	if serviceConfig.Database == nil {
+		result.Status = StatusSkipped
+		result.Summary = "no database section"
+		return result
+	}
+	if driver, ok := (*serviceConfig.Database)["driver"].(string); ok {
+		result.Summary = fmt.Sprintf("driver %s", driver)
+	}
+	if err := storage.CheckConnection(serviceConfig.Database, timeout); err != nil {
+		result.errorf("failed to connect to the database: %s", err.Error())
+	}
+	return result
+}
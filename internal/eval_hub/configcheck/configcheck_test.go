@@ -0,0 +1,146 @@
+package configcheck
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/logging"
+)
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func getResult(t *testing.T, report *Report, check string) Result {
+	t.Helper()
+	for _, result := range report.Results {
+		if result.Check == check {
+			return result
+		}
+	}
+	t.Fatalf("no result for check %q in %+v", check, report.Results)
+	return Result{}
+}
+
+func TestRun(t *testing.T) {
+	logger := logging.FallbackLogger()
+
+	t.Run("the bundled configuration is valid", func(t *testing.T) {
+		report := Run(logger, Options{ConfigDir: "../../../config"})
+		if !report.Valid || report.Errors != 0 {
+			t.Fatalf("expected the bundled configuration to be valid, got %+v", report.Results)
+		}
+		for _, check := range []string{"secrets", "service config", "providers", "collections", "message catalogs", "database"} {
+			if result := getResult(t, report, check); result.Status != StatusOK {
+				t.Errorf("expected check %q to be ok, got %+v", check, result)
+			}
+		}
+	})
+
+	t.Run("every problem is reported", func(t *testing.T) {
+		dir := t.TempDir()
+		secretsDir := filepath.Join(dir, "secrets")
+		writeFile(t, filepath.Join(secretsDir, "empty"), "")
+		writeFile(t, filepath.Join(dir, "config.yaml"), `
+service:
+  port: 8080
+database:
+  driver: oracle
+secrets:
+  dir: `+secretsDir+`
+  mappings:
+    db_password: database.password
+    empty: mlflow.token
+    missing:optional: callback_auth.secret
+`)
+		writeFile(t, filepath.Join(dir, "collections", "broken.yaml"), `
+id: broken
+name: Broken
+category: test
+benchmarks:
+  - id: arc
+    provider_id: unknown
+`)
+
+		report := Run(logger, Options{ConfigDir: dir, DatabaseTimeout: time.Second})
+		if report.Valid {
+			t.Fatalf("expected the configuration to be invalid, got %+v", report.Results)
+		}
+
+		secrets := getResult(t, report, "secrets")
+		if secrets.Status != StatusError || len(secrets.Errors) != 1 || !strings.Contains(secrets.Errors[0], "db_password") {
+			t.Errorf("expected the missing secret file to be an error, got %+v", secrets)
+		}
+		if len(secrets.Warnings) != 1 || !strings.Contains(secrets.Warnings[0], "empty") {
+			t.Errorf("expected the empty secret file to be a warning, got %+v", secrets)
+		}
+		if result := getResult(t, report, "service config"); result.Status != StatusError {
+			t.Errorf("expected the service config to fail on the missing secret, got %+v", result)
+		}
+		if result := getResult(t, report, "providers"); result.Status != StatusWarning {
+			t.Errorf("expected a warning without provider configs, got %+v", result)
+		}
+		if result := getResult(t, report, "collections"); result.Status != StatusWarning || !strings.Contains(strings.Join(result.Warnings, " "), "unknown provider unknown") {
+			t.Errorf("expected a warning for the unknown provider, got %+v", result)
+		}
+		if result := getResult(t, report, "database"); result.Status != StatusSkipped {
+			t.Errorf("expected the database check to be skipped, got %+v", result)
+		}
+		if report.Errors != 2 {
+			t.Errorf("expected 2 errors, got %d", report.Errors)
+		}
+
+		// with the secret in place the database driver is checked
+		writeFile(t, filepath.Join(secretsDir, "db_password"), "s3cr3t")
+		report = Run(logger, Options{ConfigDir: dir, DatabaseTimeout: time.Second})
+		if result := getResult(t, report, "database"); result.Status != StatusError || !strings.Contains(result.Errors[0], "unsupported driver: oracle") {
+			t.Errorf("expected the unsupported driver to be an error, got %+v", result)
+		}
+	})
+}
+
+func TestReport_Write(t *testing.T) {
+	report := &Report{Valid: true}
+	report.add(Result{Check: "secrets", Summary: "no secret mappings"})
+	report.add(Result{Check: "database", Errors: []string{"failed to connect"}})
+	report.skip("collections", "the provider configs could not be loaded")
+
+	var text bytes.Buffer
+	if err := report.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range []string{
+		"[OK] secrets: no secret mappings",
+		"[ERROR] database",
+		"  error: failed to connect",
+		"[SKIPPED] collections: the provider configs could not be loaded",
+		"configuration is invalid: 1 error(s), 0 warning(s)",
+	} {
+		if !strings.Contains(text.String(), line) {
+			t.Errorf("expected %q in the summary:\n%s", line, text.String())
+		}
+	}
+
+	var out bytes.Buffer
+	if err := report.WriteJSON(&out); err != nil {
+		t.Fatal(err)
+	}
+	decoded := Report{}
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Valid || decoded.Errors != 1 || len(decoded.Results) != 3 || decoded.Results[1].Status != StatusError {
+		t.Errorf("unexpected JSON summary: %s", out.String())
+	}
+}
@@ -14,4 +14,6 @@ const (
 	HTTPCodePayloadTooLarge     = 413
 	HTTPCodeInternalServerError = 500
 	HTTPCodeNotImplemented      = 501
+	HTTPCodeServiceUnavailable  = 503
+	HTTPCodeGatewayTimeout      = 504
 )
@@ -10,4 +10,51 @@ const (
 	// MESSAGE_CODE_GPU_UNAVAILABLE is set when an evaluation job's Kueue workload is inadmissible
 	// because the requested queue does not have sufficient GPU capacity.
 	MESSAGE_CODE_GPU_UNAVAILABLE = "gpu_unavailable"
+
+	// MESSAGE_CODE_BENCHMARK_DEPENDENCY_FAILED is set on a benchmark that is not run because
+	// a benchmark it depends on failed or was cancelled.
+	MESSAGE_CODE_BENCHMARK_DEPENDENCY_FAILED = "benchmark_dependency_failed"
+
+	// MESSAGE_CODE_BENCHMARK_FAIL_FAST is set on a benchmark that is cancelled because another
+	// benchmark of its job failed and the job fails fast.
+	MESSAGE_CODE_BENCHMARK_FAIL_FAST = "benchmark_fail_fast"
+
+	// MESSAGE_CODE_WAITING_FOR_MODEL is set while a job waits for its model endpoint to be ready.
+	MESSAGE_CODE_WAITING_FOR_MODEL = "waiting_for_model"
+
+	// MESSAGE_CODE_MODEL_NOT_READY is set on a job that failed because its model endpoint was
+	// not ready within the timeout of wait_for_model.
+	MESSAGE_CODE_MODEL_NOT_READY = "model_not_ready"
+
+	// MESSAGE_CODE_MODEL_UNAVAILABLE is reported by an adapter whose benchmark failed because
+	// the model endpoint was unavailable, e.g. answered 503, which is retried by a retry policy.
+	MESSAGE_CODE_MODEL_UNAVAILABLE = "model_unavailable"
+
+	// MESSAGE_CODE_OOM_KILLED is reported for a benchmark whose pod was killed for running out
+	// of memory, which is retried by a retry policy.
+	MESSAGE_CODE_OOM_KILLED = "oom_killed"
+
+	// MESSAGE_CODE_POD_EVICTED is set on a benchmark whose pod was evicted from its node, which
+	// is retried by a retry policy.
+	MESSAGE_CODE_POD_EVICTED = "pod_evicted"
+
+	// MESSAGE_CODE_BENCHMARK_WORKLOAD_FAILED is set on a benchmark whose workload the runtime
+	// found failed without the adapter reporting it, e.g. as its container crashed.
+	MESSAGE_CODE_BENCHMARK_WORKLOAD_FAILED = "benchmark_workload_failed"
+
+	// MESSAGE_CODE_BENCHMARK_RETRYING is set on a benchmark that failed with a transient error
+	// while it waits to be retried.
+	MESSAGE_CODE_BENCHMARK_RETRYING = "benchmark_retrying"
+
+	// MESSAGE_CODE_MLFLOW_RUN_NOT_FOUND is set on a benchmark whose adapter reported an MLflow
+	// run that does not exist in the MLflow workspace of the tenant.
+	MESSAGE_CODE_MLFLOW_RUN_NOT_FOUND = "mlflow_run_not_found"
+
+	// MESSAGE_CODE_INSUFFICIENT_CAPACITY is set on a pending benchmark whose pod no node of the
+	// cluster has the free resources for when its workload is created.
+	MESSAGE_CODE_INSUFFICIENT_CAPACITY = "insufficient_capacity"
 )
+
+// TransientMessageCodes are the message codes of the failures that a retry policy retries
+// when it does not list its own.
+var TransientMessageCodes = []string{MESSAGE_CODE_MODEL_UNAVAILABLE, MESSAGE_CODE_OOM_KILLED, MESSAGE_CODE_POD_EVICTED, MESSAGE_CODE_GPU_UNAVAILABLE}
@@ -1,8 +1,14 @@
 package constants
 
 const (
-	PATH_PARAMETER_JOB_ID          = "job_id"
-	PATH_PARAMETER_BENCHMARK_INDEX = "benchmark_index"
-	PATH_PARAMETER_COLLECTION_ID   = "collection_id"
-	PATH_PARAMETER_PROVIDER_ID     = "provider_id"
+	PATH_PARAMETER_JOB_ID            = "job_id"
+	PATH_PARAMETER_BENCHMARK_INDEX   = "benchmark_index"
+	PATH_PARAMETER_COLLECTION_ID     = "collection_id"
+	PATH_PARAMETER_PROVIDER_ID       = "provider_id"
+	PATH_PARAMETER_SWEEP_ID          = "sweep_id"
+	PATH_PARAMETER_BASELINE_NAME     = "baseline_name"
+	PATH_PARAMETER_ARTIFACT_NAME     = "artifact_name"
+	PATH_PARAMETER_VIEW_ID           = "view_id"
+	PATH_PARAMETER_OTHER_PROVIDER_ID = "other_provider_id"
+	PATH_PARAMETER_DEAD_LETTER_ID    = "dead_letter_id"
 )
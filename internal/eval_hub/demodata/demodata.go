@@ -0,0 +1,246 @@
+// Package demodata seeds a database with demo tenants, providers, collections and finished
+// evaluation jobs with realistic metrics, to demo the API and the UI, and to develop
+// dashboards, without running evaluations.
+package demodata
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/google/uuid"
+)
+
+const (
+	// Owner owns the demo resources.
+	Owner api.User = "demo-user"
+	// Tag tags the demo resources.
+	Tag = "demo"
+
+	// runs is the number of weekly runs of each model.
+	runs = 6
+)
+
+// Tenants are the demo tenants.
+var Tenants = []api.Tenant{"demo-research", "demo-platform"}
+
+type demoBenchmark struct {
+	id       string
+	name     string
+	category string
+	metric   string
+	// score is the score of the first run of the reference model.
+	score float64
+}
+
+// providerID is the ID of the demo provider of a tenant, the IDs are unique across tenants.
+func providerID(tenant api.Tenant) string {
+	return tenant.String() + "-harness"
+}
+
+// collectionID is the ID of the demo collection of a tenant.
+func collectionID(tenant api.Tenant) string {
+	return tenant.String() + "-release-gate"
+}
+
+var benchmarks = []demoBenchmark{
+	{id: "mmlu", name: "MMLU", category: "knowledge", metric: "acc", score: 0.62},
+	{id: "hellaswag", name: "HellaSwag", category: "reasoning", metric: "acc_norm", score: 0.78},
+	{id: "arc_challenge", name: "ARC Challenge", category: "reasoning", metric: "acc_norm", score: 0.53},
+	{id: "gsm8k", name: "GSM8K", category: "math", metric: "exact_match", score: 0.57},
+	{id: "truthfulqa_mc2", name: "TruthfulQA MC2", category: "safety", metric: "acc", score: 0.48},
+}
+
+type demoModel struct {
+	name string
+	// offset is added to the scores of the reference model.
+	offset float64
+}
+
+var models = []demoModel{
+	{name: "granite-3.1-8b-instruct", offset: 0},
+	{name: "llama-3.1-8b-instruct", offset: 0.03},
+	{name: "mistral-7b-instruct-v0.3", offset: -0.04},
+}
+
+// Seed stores the demo provider and collection of each demo tenant, and the weekly runs of
+// the demo models up to now, a few of them partially failed. The resources are stored with
+// fixed IDs and the ones that exist are kept, so that Seed can run at every start.
+func Seed(logger *slog.Logger, storage abstractions.Storage, now time.Time) error {
+	for _, tenant := range Tenants {
+		scoped := storage.WithLogger(logger).WithTenant(tenant).WithOwner(Owner)
+		if err := seedProvider(scoped, tenant, now); err != nil {
+			return fmt.Errorf("seed the demo provider of tenant %s: %w", tenant, err)
+		}
+		if err := seedCollection(scoped, tenant, now); err != nil {
+			return fmt.Errorf("seed the demo collection of tenant %s: %w", tenant, err)
+		}
+		seeded := 0
+		for _, model := range models {
+			for run := range runs {
+				created, err := seedJob(scoped, tenant, model, run, now)
+				if err != nil {
+					return fmt.Errorf("seed the demo job of model %s of tenant %s: %w", model.name, tenant, err)
+				}
+				if created {
+					seeded++
+				}
+			}
+		}
+		logger.Info("Seeded the demo data", "tenant", tenant, "jobs", seeded)
+	}
+	return nil
+}
+
+func seedProvider(storage abstractions.Storage, tenant api.Tenant, now time.Time) error {
+	if _, err := storage.GetProvider(providerID(tenant)); !isNotFound(err) {
+		return err
+	}
+	provider := &api.ProviderResource{
+		Resource: api.Resource{ID: providerID(tenant), Tenant: tenant, Owner: Owner, CreatedAt: now},
+		ProviderConfig: api.ProviderConfig{
+			Name:        "Demo harness",
+			Title:       "Demo harness",
+			Description: "Demo provider of the seeded jobs. Its jobs run on the mock runtime only.",
+			Tags:        []string{Tag},
+		},
+	}
+	for _, benchmark := range benchmarks {
+		provider.Benchmarks = append(provider.Benchmarks, api.BenchmarkResource{
+			ID:           benchmark.id,
+			Name:         benchmark.name,
+			Category:     benchmark.category,
+			Metrics:      []string{benchmark.metric},
+			NumFewShot:   5,
+			DatasetSize:  1000,
+			PrimaryScore: &api.PrimaryScore{Metric: benchmark.metric},
+		})
+	}
+	return storage.CreateProvider(provider)
+}
+
+func seedCollection(storage abstractions.Storage, tenant api.Tenant, now time.Time) error {
+	if _, err := storage.GetCollection(collectionID(tenant)); !isNotFound(err) {
+		return err
+	}
+	threshold := float32(0.55)
+	collection := &api.CollectionResource{
+		Resource: api.Resource{ID: collectionID(tenant), Tenant: tenant, Owner: Owner, CreatedAt: now},
+		CollectionConfig: api.CollectionConfig{
+			Name:         "Demo release gate",
+			Description:  "Demo collection of the benchmarks that a release candidate must pass.",
+			Category:     "release",
+			Tags:         []string{Tag},
+			PassCriteria: &api.PassCriteria{Threshold: &threshold},
+		},
+	}
+	for _, benchmark := range benchmarks {
+		collection.Benchmarks = append(collection.Benchmarks, api.CollectionBenchmarkConfig{
+			Ref:          api.Ref{ID: benchmark.id},
+			ProviderID:   providerID(tenant),
+			Weight:       1,
+			PrimaryScore: &api.PrimaryScore{Metric: benchmark.metric},
+		})
+	}
+	return storage.CreateCollection(collection)
+}
+
+// seedJob stores the job of a weekly run of a model, unless it exists, and reports its
+// benchmarks to it as an adapter would. The last run of the weakest model runs out of
+// memory on a benchmark.
+func seedJob(storage abstractions.Storage, tenant api.Tenant, model demoModel, run int, now time.Time) (bool, error) {
+	id := uuid.NewSHA1(uuid.NameSpaceURL, fmt.Appendf(nil, "eval-hub-demo/%s/%s/%d", tenant, model.name, run)).String()
+	if _, err := storage.GetEvaluationJob(id); !isNotFound(err) {
+		return false, err
+	}
+	// the storage stamps the job when it is stored, the run date is in its name and in the
+	// timestamps of its benchmarks
+	runAt := now.Add(-time.Duration(runs-run) * 7 * 24 * time.Hour).Truncate(time.Hour)
+	job := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{
+			Resource: api.Resource{ID: id, Tenant: tenant, Owner: Owner},
+		},
+		Status: &api.EvaluationJobStatus{
+			EvaluationJobState: api.EvaluationJobState{
+				State: api.OverallStatePending,
+				Message: api.WithMessageOrigin(&api.MessageInfo{
+					Message:     "Evaluation job created",
+					MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_CREATED,
+				}, api.MessageOriginServer),
+			},
+		},
+		Results: &api.EvaluationJobResults{},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Name:    fmt.Sprintf("%s weekly %s", model.name, runAt.Format(time.DateOnly)),
+			Model:   api.ModelRef{URL: fmt.Sprintf("http://%s.demo.svc.cluster.local:8000/v1", model.name), Name: model.name},
+			Tags:    []string{Tag, "weekly"},
+			Runtime: api.RuntimeMock,
+		},
+	}
+	for _, benchmark := range benchmarks {
+		job.Benchmarks = append(job.Benchmarks, api.EvaluationBenchmarkConfig{
+			Ref:          api.Ref{ID: benchmark.id},
+			ProviderID:   providerID(tenant),
+			PrimaryScore: &api.PrimaryScore{Metric: benchmark.metric},
+		})
+	}
+	if err := storage.CreateEvaluationJob(job); err != nil {
+		return false, err
+	}
+
+	// the scores are reproducible: the noise of a job is drawn from its ID
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(id))
+	noise := rand.New(rand.NewPCG(hash.Sum64(), uint64(run)))
+	startedAt := runAt.Add(time.Minute)
+	for index, benchmark := range benchmarks {
+		completedAt := startedAt.Add(time.Duration(15+noise.IntN(45)) * time.Minute)
+		event := &api.BenchmarkStatusEvent{
+			ProviderID:     providerID(tenant),
+			ID:             benchmark.id,
+			BenchmarkIndex: index,
+			Status:         api.StateRunning,
+			StartedAt:      api.DateTimeToString(startedAt),
+		}
+		if _, err := storage.UpdateEvaluationJob(id, &api.StatusEvent{BenchmarkStatusEvent: event}); err != nil {
+			return false, err
+		}
+		finished := *event
+		finished.CompletedAt = api.DateTimeToString(completedAt)
+		if run == runs-1 && model.offset < 0 && benchmark.id == "gsm8k" {
+			finished.Status = api.StateFailed
+			finished.ErrorMessage = api.WithMessageOrigin(&api.MessageInfo{
+				Message:     "The adapter ran out of memory while generating the answers",
+				MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_FAILED,
+			}, api.MessageOriginAdapter)
+		} else {
+			// a steady improvement over the runs, with some noise
+			score := benchmark.score + model.offset + 0.006*float64(run) + 0.02*(noise.Float64()-0.5)
+			score = math.Round(min(max(score, 0), 1)*10000) / 10000
+			finished.Status = api.StateCompleted
+			finished.Metrics = map[string]any{
+				benchmark.metric:             score,
+				benchmark.metric + "_stderr": math.Round(math.Sqrt(score*(1-score)/1000)*10000) / 10000,
+			}
+		}
+		if _, err := storage.UpdateEvaluationJob(id, &api.StatusEvent{BenchmarkStatusEvent: &finished}); err != nil {
+			return false, err
+		}
+		startedAt = completedAt
+	}
+	return true, nil
+}
+
+func isNotFound(err error) bool {
+	var se *serviceerrors.ServiceError
+	return errors.As(err, &se) && se.MessageCode() == messages.ResourceNotFound
+}
@@ -0,0 +1,75 @@
+package demodata
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestSeed(t *testing.T) {
+	logger := logging.FallbackLogger()
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           "file:eval_hub_demo_data_test?mode=memory&cache=shared",
+		"database_name": "eval_hub_demo_data_test",
+	}
+	store, err := storage.NewStorage(&databaseConfig, nil, nil, false, false, logger)
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	for range 2 {
+		// seeding again keeps the seeded resources
+		if err := Seed(logger, store, now); err != nil {
+			t.Fatalf("Seed: %v", err)
+		}
+	}
+
+	for _, tenant := range Tenants {
+		scoped := store.WithTenant(tenant)
+		if _, err := scoped.GetProvider(providerID(tenant)); err != nil {
+			t.Errorf("expected the demo provider of tenant %s, got %v", tenant, err)
+		}
+		if _, err := scoped.GetCollection(collectionID(tenant)); err != nil {
+			t.Errorf("expected the demo collection of tenant %s, got %v", tenant, err)
+		}
+		jobs, err := scoped.GetEvaluationJobs(&abstractions.QueryFilter{Limit: 100})
+		if err != nil {
+			t.Fatalf("GetEvaluationJobs: %v", err)
+		}
+		if len(jobs.Items) != len(models)*runs {
+			t.Fatalf("expected %d demo jobs of tenant %s, got %d", len(models)*runs, tenant, len(jobs.Items))
+		}
+		states := map[api.OverallState]int{}
+		for _, job := range jobs.Items {
+			states[job.Status.State]++
+			for _, benchmark := range job.Status.Benchmarks {
+				completedAt, err := api.DateTimeFromString(benchmark.CompletedAt)
+				if err != nil || !completedAt.Before(now) {
+					t.Errorf("expected benchmark %s of job %s to complete before now, got %q", benchmark.ID, job.Resource.ID, benchmark.CompletedAt)
+				}
+			}
+			if job.Status.State != api.OverallStateCompleted {
+				continue
+			}
+			if job.Results == nil || len(job.Results.Benchmarks) != len(benchmarks) {
+				t.Fatalf("expected the results of every benchmark of job %s, got %+v", job.Resource.ID, job.Results)
+			}
+			for _, result := range job.Results.Benchmarks {
+				score, ok := result.Metrics[benchmarks[result.BenchmarkIndex].metric].(float64)
+				if !ok || score <= 0 || score >= 1 {
+					t.Errorf("expected a score in (0, 1) for benchmark %s of job %s, got %v", result.ID, job.Resource.ID, result.Metrics)
+				}
+			}
+		}
+		if states[api.OverallStateCompleted] != len(models)*runs-1 || states[api.OverallStatePartiallyFailed] != 1 {
+			t.Errorf("expected one partially failed job of tenant %s and the others completed, got %v", tenant, states)
+		}
+	}
+}
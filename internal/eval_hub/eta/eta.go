@@ -0,0 +1,108 @@
+// Package eta estimates when an evaluation job completes from the mean durations of the
+// previous runs of its benchmarks, which are kept for each benchmark of a provider and bucket
+// of the number of examples.
+package eta
+
+import (
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// ExamplesBucket returns the bucket of the number of examples of a run of a benchmark, the
+// power of ten at or above it, e.g. 1000 for 250 examples, and 0 for a run of all the
+// examples.
+func ExamplesBucket(numExamples *int) int {
+	if numExamples == nil || *numExamples <= 0 {
+		return 0
+	}
+	bucket := 1
+	for bucket < *numExamples {
+		bucket *= 10
+	}
+	return bucket
+}
+
+// Key returns the key of the durations of the runs of the benchmark.
+func Key(benchmark *api.EvaluationBenchmarkConfig) abstractions.BenchmarkDurationKey {
+	return abstractions.BenchmarkDurationKey{
+		ProviderID:     benchmark.ProviderID,
+		BenchmarkID:    benchmark.ID,
+		ExamplesBucket: ExamplesBucket(shared.NumExamplesFromParameters(benchmark.Parameters)),
+	}
+}
+
+// Keys returns the keys of the durations of the benchmarks.
+func Keys(benchmarks []api.EvaluationBenchmarkConfig) []abstractions.BenchmarkDurationKey {
+	keys := make([]abstractions.BenchmarkDurationKey, 0, len(benchmarks))
+	for i := range benchmarks {
+		keys = append(keys, Key(&benchmarks[i]))
+	}
+	return keys
+}
+
+// Completion returns when the job is estimated to complete, and false when it can not be
+// estimated because a benchmark that has not finished has no previous run. The benchmarks
+// run at the same time, except that a benchmark starts when the benchmarks it depends on
+// have completed; a running benchmark is estimated to take its mean duration from its start,
+// and no less than until now.
+func Completion(job *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig, durations map[abstractions.BenchmarkDurationKey]time.Duration, now time.Time) (time.Time, bool) {
+	statuses := map[int]*api.BenchmarkStatus{}
+	if job.Status != nil {
+		for i := range job.Status.Benchmarks {
+			statuses[job.Status.Benchmarks[i].BenchmarkIndex] = &job.Status.Benchmarks[i]
+		}
+	}
+
+	// remaining returns the time from now until the benchmark completes
+	remaining := map[int]time.Duration{}
+	var remainingOf func(index int, visiting map[int]bool) (time.Duration, bool)
+	remainingOf = func(index int, visiting map[int]bool) (time.Duration, bool) {
+		if value, ok := remaining[index]; ok {
+			return value, true
+		}
+		if index < 0 || index >= len(benchmarks) || visiting[index] {
+			return 0, false
+		}
+		status := statuses[index]
+		if status != nil && api.IsBenchmarkTerminalState(status.Status) {
+			remaining[index] = 0
+			return 0, true
+		}
+		mean, ok := durations[Key(&benchmarks[index])]
+		if !ok {
+			return 0, false
+		}
+		if status != nil && status.Status == api.StateRunning {
+			if startedAt, err := api.DateTimeFromString(status.StartedAt); err == nil {
+				value := max(mean-now.Sub(startedAt), 0)
+				remaining[index] = value
+				return value, true
+			}
+		}
+		visiting[index] = true
+		var dependencies time.Duration
+		for _, dependency := range benchmarks[index].DependsOn {
+			value, ok := remainingOf(dependency, visiting)
+			if !ok {
+				return 0, false
+			}
+			dependencies = max(dependencies, value)
+		}
+		delete(visiting, index)
+		remaining[index] = dependencies + mean
+		return dependencies + mean, true
+	}
+
+	var longest time.Duration
+	for index := range benchmarks {
+		value, ok := remainingOf(index, map[int]bool{})
+		if !ok {
+			return time.Time{}, false
+		}
+		longest = max(longest, value)
+	}
+	return now.Add(longest), true
+}
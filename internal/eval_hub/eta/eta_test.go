@@ -0,0 +1,99 @@
+package eta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestExamplesBucket(t *testing.T) {
+	for numExamples, want := range map[int]int{0: 0, 1: 1, 7: 10, 10: 10, 250: 1000, 1000: 1000, 1001: 10000} {
+		if got := ExamplesBucket(&numExamples); got != want {
+			t.Errorf("ExamplesBucket(%d) = %d, want %d", numExamples, got, want)
+		}
+	}
+	if got := ExamplesBucket(nil); got != 0 {
+		t.Errorf("expected the bucket of all the examples to be 0, got %d", got)
+	}
+	benchmark := api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness", Parameters: map[string]any{"num_examples": float64(250)}}
+	if key := Key(&benchmark); key != (abstractions.BenchmarkDurationKey{ProviderID: "lm_evaluation_harness", BenchmarkID: "mmlu", ExamplesBucket: 1000}) {
+		t.Errorf("unexpected key %+v", key)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	benchmark := func(id string, dependsOn ...int) api.EvaluationBenchmarkConfig {
+		return api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: id}, ProviderID: "p", DependsOn: dependsOn}
+	}
+	key := func(id string) abstractions.BenchmarkDurationKey {
+		return abstractions.BenchmarkDurationKey{ProviderID: "p", BenchmarkID: id}
+	}
+	durations := map[abstractions.BenchmarkDurationKey]time.Duration{
+		key("mmlu"):      2 * time.Hour,
+		key("arc"):       30 * time.Minute,
+		key("hellaswag"): time.Hour,
+	}
+	job := func(statuses ...api.BenchmarkStatus) *api.EvaluationJobResource {
+		return &api.EvaluationJobResource{Status: &api.EvaluationJobStatus{Benchmarks: statuses}}
+	}
+	running := func(index int, startedAt time.Time) api.BenchmarkStatus {
+		return api.BenchmarkStatus{BenchmarkIndex: index, Status: api.StateRunning, StartedAt: api.DateTimeToString(startedAt)}
+	}
+
+	tests := map[string]struct {
+		job        *api.EvaluationJobResource
+		benchmarks []api.EvaluationBenchmarkConfig
+		want       time.Duration
+		unknown    bool
+	}{
+		"in parallel": {
+			job:        job(),
+			benchmarks: []api.EvaluationBenchmarkConfig{benchmark("mmlu"), benchmark("arc")},
+			want:       2 * time.Hour,
+		},
+		"pipeline": {
+			job:        job(),
+			benchmarks: []api.EvaluationBenchmarkConfig{benchmark("mmlu"), benchmark("arc", 0), benchmark("hellaswag")},
+			want:       2*time.Hour + 30*time.Minute,
+		},
+		"running": {
+			job:        job(running(0, now.Add(-90*time.Minute))),
+			benchmarks: []api.EvaluationBenchmarkConfig{benchmark("mmlu"), benchmark("arc")},
+			want:       30 * time.Minute,
+		},
+		"overrunning": {
+			job:        job(running(0, now.Add(-3*time.Hour))),
+			benchmarks: []api.EvaluationBenchmarkConfig{benchmark("mmlu")},
+			want:       0,
+		},
+		"finished benchmark": {
+			job:        job(api.BenchmarkStatus{BenchmarkIndex: 0, Status: api.StateCompleted}),
+			benchmarks: []api.EvaluationBenchmarkConfig{benchmark("mmlu"), benchmark("arc", 0)},
+			want:       30 * time.Minute,
+		},
+		"never run": {
+			job:        job(),
+			benchmarks: []api.EvaluationBenchmarkConfig{benchmark("mmlu"), benchmark("truthfulqa")},
+			unknown:    true,
+		},
+		"dependency cycle": {
+			job:        job(),
+			benchmarks: []api.EvaluationBenchmarkConfig{benchmark("mmlu", 1), benchmark("arc", 0)},
+			unknown:    true,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			completion, ok := Completion(tt.job, tt.benchmarks, durations, now)
+			if ok == tt.unknown {
+				t.Fatalf("expected an estimate %v, got %v", !tt.unknown, ok)
+			}
+			if ok && !completion.Equal(now.Add(tt.want)) {
+				t.Errorf("expected the job to complete in %v, got %v", tt.want, completion.Sub(now))
+			}
+		})
+	}
+}
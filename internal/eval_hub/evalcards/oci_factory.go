@@ -45,10 +45,14 @@ func NewOCIHTTPClient(serviceConfig *config.Config, isOTELEnabled bool, logger *
 	if err != nil {
 		return nil, err
 	}
-	transport := http.RoundTripper(&http.Transport{})
-	if tlsConfig != nil {
-		transport = &http.Transport{TLSClientConfig: tlsConfig}
-	}
+	var proxyConfig *config.ProxyConfig
+	if serviceConfig != nil {
+		proxyConfig = serviceConfig.Proxy
+	}
+	transport := http.RoundTripper(&http.Transport{
+		TLSClientConfig: tlsConfig,
+		Proxy:           proxyConfig.ProxyFunc(config.ProxyDestinationOCI),
+	})
 	if isOTELEnabled {
 		transport = otelhttp.NewTransport(transport)
 	}
@@ -133,8 +133,8 @@ func (t *mlflowTarget) Export(ctx context.Context, job *api.EvaluationJobResourc
 	if t.logger != nil {
 		client = client.WithLogger(t.logger)
 	}
-	if !job.Resource.Tenant.IsEmpty() {
-		client = client.WithWorkspace(job.Resource.Tenant.String())
+	if workspace := job.MLFlowWorkspace(); workspace != "" {
+		client = client.WithWorkspace(workspace)
 	}
 
 	artifactLocation := ""
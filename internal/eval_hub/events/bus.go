@@ -0,0 +1,23 @@
+package events
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+)
+
+// NewBus creates the bus selected by the events configuration.
+func NewBus(logger *slog.Logger, conf *config.EventsConfig) (Bus, error) {
+	switch backend := conf.EffectiveBackend(); backend {
+	case config.EventsBackendMemory:
+		return NewMemoryBus(logger), nil
+	case config.EventsBackendNATS:
+		if conf.NATS == nil || conf.NATS.URL == "" {
+			return nil, fmt.Errorf("events.nats.url is required for the %s events backend", backend)
+		}
+		return NewNATSBus(logger, conf.NATS.URL, conf.NATS.EffectiveSubject())
+	default:
+		return nil, fmt.Errorf("unsupported events backend: %s", backend)
+	}
+}
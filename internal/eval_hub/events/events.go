@@ -0,0 +1,128 @@
+// Package events carries evaluation job events from the storage layer to side-effect
+// consumers (the watch API, and in future webhooks, metrics and exporters), so that a new
+// side effect is a new subscriber rather than another edit to the job update path.
+package events
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type Type string
+
+const (
+	// JobCreated is emitted once the job has been stored.
+	JobCreated Type = "job.created"
+	// BenchmarkUpdated is emitted for every benchmark status event applied to the job.
+	BenchmarkUpdated Type = "job.benchmark_updated"
+	// JobStatusChanged is emitted when the overall job status is set directly, e.g. when
+	// the runtime fails to start the job.
+	JobStatusChanged Type = "job.status_changed"
+	// JobCompleted is emitted when the job reaches a terminal state, whether completed,
+	// failed, partially failed or cancelled. It follows the event that caused it.
+	JobCompleted Type = "job.completed"
+	// JobReviewed is emitted when a reviewer approves or rejects a job whose score was
+	// borderline.
+	JobReviewed Type = "job.reviewed"
+)
+
+// Event describes a persisted change to an evaluation job.
+type Event struct {
+	Type   Type       `json:"type"`
+	Time   time.Time  `json:"time"`
+	JobID  string     `json:"job_id"`
+	Tenant api.Tenant `json:"tenant,omitempty"`
+	// Job is the job as stored after the change.
+	Job *api.EvaluationJobResource `json:"job"`
+	// Benchmark is the status event that was applied, for BenchmarkUpdated only.
+	Benchmark *api.BenchmarkStatusEvent `json:"benchmark,omitempty"`
+	// Origin identifies the replica that published the event.
+	Origin string `json:"origin,omitempty"`
+}
+
+// replicaID is the Origin of the events published by this process.
+var replicaID = common.GUID()
+
+// ReplicaID returns the Origin of the events published by this process.
+func ReplicaID() string {
+	return replicaID
+}
+
+// IsLocal returns true when the event was published by this process. With the NATS backend
+// every replica receives every event, side effects that must happen once per event, such as
+// notifications, only handle the local events.
+func (e Event) IsLocal() bool {
+	return e.Origin == replicaID
+}
+
+// Handler consumes events. Handlers run on the publisher's goroutine, which is usually
+// serving an API request, so they must not block: hand slow work off to a goroutine.
+type Handler func(event Event)
+
+type Bus interface {
+	Publish(event Event)
+	// Subscribe registers handler for every event and returns a function that removes it.
+	Subscribe(handler Handler) func()
+	Close() error
+}
+
+// memoryBus delivers events to the subscribers of this process.
+type memoryBus struct {
+	logger *slog.Logger
+
+	mu       sync.RWMutex
+	nextID   int
+	handlers map[int]Handler
+}
+
+func NewMemoryBus(logger *slog.Logger) Bus {
+	return &memoryBus{
+		logger:   logger,
+		handlers: map[int]Handler{},
+	}
+}
+
+func (b *memoryBus) Publish(event Event) {
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers))
+	for _, handler := range b.handlers {
+		handlers = append(handlers, handler)
+	}
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		b.deliver(handler, event)
+	}
+}
+
+// deliver isolates the publisher from a failing subscriber.
+func (b *memoryBus) deliver(handler Handler, event Event) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error("Event handler panicked", "type", event.Type, "job_id", event.JobID, "panic", r)
+		}
+	}()
+	handler(event)
+}
+
+func (b *memoryBus) Subscribe(handler Handler) func() {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		delete(b.handlers, id)
+		b.mu.Unlock()
+	}
+}
+
+func (b *memoryBus) Close() error {
+	return nil
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/nats-io/nats.go"
+)
+
+func TestMemoryBus(t *testing.T) {
+	bus := NewMemoryBus(logging.FallbackLogger())
+
+	var first, second int
+	unsubscribe := bus.Subscribe(func(Event) { first++ })
+	bus.Subscribe(func(Event) { second++ })
+	bus.Subscribe(func(Event) { panic("broken subscriber") })
+
+	bus.Publish(Event{Type: JobCreated, JobID: "job-1"})
+	unsubscribe()
+	bus.Publish(Event{Type: JobCompleted, JobID: "job-1"})
+
+	if first != 1 || second != 2 {
+		t.Fatalf("unexpected deliveries: first=%d second=%d", first, second)
+	}
+}
+
+func TestNewBus(t *testing.T) {
+	logger := logging.FallbackLogger()
+
+	bus, err := NewBus(logger, nil)
+	if err != nil {
+		t.Fatalf("NewBus with no configuration: %v", err)
+	}
+	if _, ok := bus.(*memoryBus); !ok {
+		t.Fatalf("expected the memory bus by default, got %T", bus)
+	}
+
+	if _, err := NewBus(logger, &config.EventsConfig{Backend: config.EventsBackendNATS}); err == nil {
+		t.Fatal("expected an error for nats without a URL")
+	}
+	if _, err := NewBus(logger, &config.EventsConfig{Backend: "kafka"}); err == nil {
+		t.Fatal("expected an error for an unsupported backend")
+	}
+}
+
+func TestNATSBusReceive(t *testing.T) {
+	logger := logging.FallbackLogger()
+	b := &natsBus{Bus: NewMemoryBus(logger), logger: logger, subject: config.DefaultEventsNATSSubject}
+
+	var received []Event
+	b.Subscribe(func(event Event) { received = append(received, event) })
+
+	b.receive(&nats.Msg{Subject: "evalhub.events.job.completed", Data: []byte(`{"type":"job.completed","job_id":"job-1","tenant":"tenant-a","job":{"resource":{"id":"job-1"}}}`)})
+	b.receive(&nats.Msg{Subject: "evalhub.events.job.completed", Data: []byte(`not json`)})
+
+	if len(received) != 1 {
+		t.Fatalf("expected one event, got %d", len(received))
+	}
+	if event := received[0]; event.Type != JobCompleted || event.Tenant != "tenant-a" || event.Job.Resource.ID != "job-1" {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+}
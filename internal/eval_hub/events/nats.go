@@ -0,0 +1,67 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsBus publishes events to NATS and delivers every event received on the subject,
+// including this process' own, to the local subscribers. Each replica therefore sees
+// the updates made by the others, e.g. for jobs watched through another replica.
+type natsBus struct {
+	Bus
+	logger  *slog.Logger
+	conn    *nats.Conn
+	sub     *nats.Subscription
+	subject string
+}
+
+// NewNATSBus connects to the NATS server at url. Events are published as JSON under
+// <subject>.<event type>.
+func NewNATSBus(logger *slog.Logger, url string, subject string) (Bus, error) {
+	conn, err := nats.Connect(url, nats.Name("eval-hub"))
+	if err != nil {
+		return nil, fmt.Errorf("connect to NATS at %s: %w", url, err)
+	}
+	b := &natsBus{
+		Bus:     NewMemoryBus(logger),
+		logger:  logger,
+		conn:    conn,
+		subject: subject,
+	}
+	b.sub, err = conn.Subscribe(subject+".>", b.receive)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("subscribe to NATS subject %s: %w", subject, err)
+	}
+	logger.Info("Connected to NATS event bus", "url", url, "subject", subject)
+	return b, nil
+}
+
+func (b *natsBus) Publish(event Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		b.logger.Error("Failed to encode event", "type", event.Type, "job_id", event.JobID, "error", err)
+		return
+	}
+	if err := b.conn.Publish(b.subject+"."+string(event.Type), data); err != nil {
+		b.logger.Error("Failed to publish event to NATS", "type", event.Type, "job_id", event.JobID, "error", err)
+	}
+}
+
+func (b *natsBus) receive(msg *nats.Msg) {
+	var event Event
+	if err := json.Unmarshal(msg.Data, &event); err != nil {
+		b.logger.Warn("Ignoring malformed event from NATS", "subject", msg.Subject, "error", err)
+		return
+	}
+	b.Bus.Publish(event)
+}
+
+func (b *natsBus) Close() error {
+	// drain delivers the events already received before closing the connection
+	return b.conn.Drain()
+}
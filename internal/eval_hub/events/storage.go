@@ -0,0 +1,132 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// publishingStorage emits job events after every successful job write, whichever code
+// path (jobs API, events API, runtime, cancellation) made it.
+type publishingStorage struct {
+	abstractions.Storage
+	bus    Bus
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// NewStorage wraps storage so that evaluation job writes are published to bus.
+func NewStorage(storage abstractions.Storage, bus Bus, logger *slog.Logger) abstractions.Storage {
+	return &publishingStorage{Storage: storage, bus: bus, logger: logger, now: time.Now}
+}
+
+func (s *publishingStorage) with(storage abstractions.Storage) *publishingStorage {
+	return &publishingStorage{Storage: storage, bus: s.bus, logger: s.logger, now: s.now}
+}
+
+func (s *publishingStorage) WithLogger(logger *slog.Logger) abstractions.Storage {
+	scoped := s.with(s.Storage.WithLogger(logger))
+	scoped.logger = logger
+	return scoped
+}
+
+func (s *publishingStorage) WithContext(ctx context.Context) abstractions.Storage {
+	return s.with(s.Storage.WithContext(ctx))
+}
+
+func (s *publishingStorage) WithTenant(tenant api.Tenant) abstractions.Storage {
+	return s.with(s.Storage.WithTenant(tenant))
+}
+
+func (s *publishingStorage) WithOwner(owner api.User) abstractions.Storage {
+	return s.with(s.Storage.WithOwner(owner))
+}
+
+func (s *publishingStorage) CreateEvaluationJob(evaluation *api.EvaluationJobResource) error {
+	if err := s.Storage.CreateEvaluationJob(evaluation); err != nil {
+		return err
+	}
+	s.publish(JobCreated, evaluation, nil)
+	return nil
+}
+
+func (s *publishingStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent) (*abstractions.EvaluationJobUpdate, error) {
+	update, err := s.Storage.UpdateEvaluationJob(id, runStatus)
+	if err != nil {
+		return nil, err
+	}
+	// a retried or late event leaves the job as it is
+	if update.Ignored != "" {
+		return update, nil
+	}
+	job := s.reload(id)
+	if job == nil {
+		return update, nil
+	}
+	s.publish(BenchmarkUpdated, job, runStatus.BenchmarkStatusEvent)
+	// the job completes with the update that moves it to a terminal state, only once
+	if update.State != update.PreviousState && update.State.IsTerminalState() {
+		s.publish(JobCompleted, job, nil)
+	}
+	return update, nil
+}
+
+func (s *publishingStorage) UpdateEvaluationJobStatus(id string, state api.OverallState, message *api.MessageInfo) (api.OverallState, error) {
+	previousState, err := s.Storage.UpdateEvaluationJobStatus(id, state, message)
+	if err != nil {
+		return "", err
+	}
+	// a job that is already terminal, e.g. cancelled twice, has completed before
+	if previousState.IsTerminalState() {
+		return previousState, nil
+	}
+	job := s.reload(id)
+	if job == nil {
+		return previousState, nil
+	}
+	// a terminal status set directly (failed to start, cancelled) is reported as JobCompleted only
+	if isTerminal(job) {
+		s.publish(JobCompleted, job, nil)
+	} else {
+		s.publish(JobStatusChanged, job, nil)
+	}
+	return previousState, nil
+}
+
+func (s *publishingStorage) ReviewEvaluationJob(id string, reviewer api.User, decision *api.ReviewDecision) (*api.EvaluationJobResource, error) {
+	job, err := s.Storage.ReviewEvaluationJob(id, reviewer, decision)
+	if err != nil {
+		return nil, err
+	}
+	s.publish(JobReviewed, job, nil)
+	return job, nil
+}
+
+// reload reads back the committed job so that consumers see exactly what GET returns.
+func (s *publishingStorage) reload(id string) *api.EvaluationJobResource {
+	job, err := s.Storage.GetEvaluationJob(id)
+	if err != nil {
+		s.logger.Warn("Failed to read evaluation job for events", "id", id, "error", err)
+		return nil
+	}
+	return job
+}
+
+func (s *publishingStorage) publish(eventType Type, job *api.EvaluationJobResource, benchmark *api.BenchmarkStatusEvent) {
+	s.bus.Publish(Event{
+		Type:      eventType,
+		Time:      s.now(),
+		JobID:     job.Resource.ID,
+		Tenant:    job.Resource.Tenant,
+		Job:       job,
+		Benchmark: benchmark,
+		Origin:    replicaID,
+	})
+}
+
+func isTerminal(job *api.EvaluationJobResource) bool {
+	return job.Status != nil && job.Status.State.IsTerminalState()
+}
@@ -0,0 +1,185 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type recorder struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (r *recorder) handle(event Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) types() []Type {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var types []Type
+	for _, event := range r.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func (r *recorder) last() Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.events[len(r.events)-1]
+}
+
+func TestStoragePublishesJobEvents(t *testing.T) {
+	logger := logging.FallbackLogger()
+	databaseConfig := map[string]any{
+		"driver": "sqlite",
+		"url":    fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name()),
+	}
+	store, err := storage.NewStorage(&databaseConfig, nil, nil, false, false, logger)
+	if err != nil {
+		t.Fatalf("NewStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	bus := NewMemoryBus(logger)
+	events := &recorder{}
+	bus.Subscribe(events.handle)
+	scoped := NewStorage(store, bus, logger).WithTenant("tenant-a").WithOwner("alice")
+
+	newJob := func(id string) *api.EvaluationJobResource {
+		return &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: id, CreatedAt: time.Now(), Tenant: "tenant-a", Owner: "alice"}},
+			Status: &api.EvaluationJobStatus{
+				EvaluationJobState: api.EvaluationJobState{
+					State:   api.OverallStatePending,
+					Message: &api.MessageInfo{Message: "created", MessageCode: "test"},
+				},
+			},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Name:       "events",
+				Model:      api.ModelRef{URL: "http://model", Name: "model"},
+				Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "b"}, ProviderID: "p"}},
+			},
+		}
+	}
+
+	t.Run("benchmark updates through to completion", func(t *testing.T) {
+		events.events = nil
+		if err := scoped.CreateEvaluationJob(newJob("job-1")); err != nil {
+			t.Fatalf("CreateEvaluationJob: %v", err)
+		}
+		for _, status := range []api.State{api.StateRunning, api.StateCompleted} {
+			_, err := scoped.UpdateEvaluationJob("job-1", &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+				ProviderID: "p",
+				ID:         "b",
+				Status:     status,
+				Metrics:    map[string]any{"score": 1.0},
+			}})
+			if err != nil {
+				t.Fatalf("UpdateEvaluationJob(%s): %v", status, err)
+			}
+		}
+
+		want := []Type{JobCreated, BenchmarkUpdated, BenchmarkUpdated, JobCompleted}
+		if got := events.types(); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("expected events %v, got %v", want, got)
+		}
+		last := events.last()
+		if last.JobID != "job-1" || last.Tenant != "tenant-a" || last.Job.Status.State != api.OverallStateCompleted {
+			t.Fatalf("unexpected completion event: %+v", last)
+		}
+		if !last.IsLocal() {
+			t.Fatalf("expected the event to be published by this replica, got origin %q", last.Origin)
+		}
+		if events.events[1].Benchmark == nil || events.events[1].Benchmark.Status != api.StateRunning {
+			t.Fatalf("expected the applied benchmark status, got %+v", events.events[1].Benchmark)
+		}
+	})
+
+	t.Run("a job completes only once", func(t *testing.T) {
+		events.events = nil
+		job := newJob("job-3")
+		job.Benchmarks = append(job.Benchmarks, api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: "c"}, ProviderID: "p"})
+		if err := scoped.CreateEvaluationJob(job); err != nil {
+			t.Fatalf("CreateEvaluationJob: %v", err)
+		}
+		update := func(index int, id string, eventID string) {
+			t.Helper()
+			_, err := scoped.UpdateEvaluationJob("job-3", &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+				ProviderID:     "p",
+				ID:             id,
+				BenchmarkIndex: index,
+				Status:         api.StateCompleted,
+				EventID:        eventID,
+				Sequence:       1,
+			}})
+			if err != nil {
+				t.Fatalf("UpdateEvaluationJob(%s): %v", eventID, err)
+			}
+		}
+		update(0, "b", "b-1")
+		update(1, "c", "c-1")
+		// the adapter retries the event that completed the job
+		update(1, "c", "c-1")
+
+		want := []Type{JobCreated, BenchmarkUpdated, BenchmarkUpdated, JobCompleted}
+		if got := events.types(); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("expected events %v, got %v", want, got)
+		}
+	})
+
+	t.Run("direct status changes", func(t *testing.T) {
+		events.events = nil
+		if err := scoped.CreateEvaluationJob(newJob("job-2")); err != nil {
+			t.Fatalf("CreateEvaluationJob: %v", err)
+		}
+		message := &api.MessageInfo{Message: "status", MessageCode: "test"}
+		if _, err := scoped.UpdateEvaluationJobStatus("job-2", api.OverallStatePending, message); err != nil {
+			t.Fatalf("UpdateEvaluationJobStatus: %v", err)
+		}
+		if _, err := scoped.UpdateEvaluationJobStatus("job-2", api.OverallStateCancelled, message); err != nil {
+			t.Fatalf("UpdateEvaluationJobStatus: %v", err)
+		}
+		want := []Type{JobCreated, JobStatusChanged, JobCompleted}
+		if got := events.types(); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("expected events %v, got %v", want, got)
+		}
+	})
+
+	t.Run("a job cancelled twice completes once", func(t *testing.T) {
+		events.events = nil
+		if err := scoped.CreateEvaluationJob(newJob("job-4")); err != nil {
+			t.Fatalf("CreateEvaluationJob: %v", err)
+		}
+		message := &api.MessageInfo{Message: "cancelled", MessageCode: "test"}
+		for range 2 {
+			if _, err := scoped.UpdateEvaluationJobStatus("job-4", api.OverallStateCancelled, message); err != nil {
+				t.Fatalf("UpdateEvaluationJobStatus: %v", err)
+			}
+		}
+		want := []Type{JobCreated, JobCompleted}
+		if got := events.types(); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("expected events %v, got %v", want, got)
+		}
+	})
+
+	t.Run("failed writes publish nothing", func(t *testing.T) {
+		events.events = nil
+		_, err := scoped.UpdateEvaluationJobStatus("missing", api.OverallStateFailed, &api.MessageInfo{Message: "x", MessageCode: "test"})
+		if err == nil {
+			t.Fatal("expected an error for a missing job")
+		}
+		if got := events.types(); len(got) != 0 {
+			t.Fatalf("expected no events, got %v", got)
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
@@ -14,7 +15,11 @@ import (
 //
 // The ExecutionContext contains:
 //   - Logger: A request-scoped logger with enriched fields (request_id, method, uri, etc.)
-//   - User and tenant from the request when present
+//   - User, tenant and the groups of the user from the request when present
+//   - Messages: the message catalog of the locale negotiated from Accept-Language,
+//     nil when the messages are served in English
+//   - Timeout: the deadline of the request set on Ctx, 0 when it has none, with the Trace
+//     of the steps that the request completed
 type ExecutionContext struct {
 	Ctx       context.Context
 	RequestID string
@@ -22,6 +27,10 @@ type ExecutionContext struct {
 	StartedAt time.Time
 	User      api.User
 	Tenant    api.Tenant
+	Groups    []string
+	Messages  *messages.Catalog
+	Timeout   time.Duration
+	Trace     *Trace
 }
 
 // This struct contains per request context information
@@ -50,5 +59,9 @@ func (e *ExecutionContext) WithContext(ctx context.Context) *ExecutionContext {
 		StartedAt: e.StartedAt,
 		User:      e.User,
 		Tenant:    e.Tenant,
+		Groups:    e.Groups,
+		Messages:  e.Messages,
+		Timeout:   e.Timeout,
+		Trace:     e.Trace,
 	}
 }
@@ -3,7 +3,9 @@ package executioncontext_test
 import (
 	"context"
 	"log/slog"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
 	"github.com/eval-hub/eval-hub/pkg/api"
@@ -40,3 +42,37 @@ func TestExecutionContext_WithContext(t *testing.T) {
 		t.Error("WithContext should preserve other fields")
 	}
 }
+
+func TestTrace(t *testing.T) {
+	ctx, cancel := executioncontext.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, ok := ctx.Deadline(); !ok {
+		t.Fatal("expected the context to have a deadline")
+	}
+	trace := executioncontext.TraceFrom(ctx)
+	if trace.Timeout() != time.Minute {
+		t.Fatalf("got timeout %s want 1m", trace.Timeout())
+	}
+	if got := trace.String(); got != "no step completed" {
+		t.Errorf("empty trace: got %q", got)
+	}
+
+	executioncontext.RecordStep(ctx, "storage select evaluations", 1500*time.Millisecond, false)
+	executioncontext.RecordStep(ctx, "runtime get-evaluation-job-logs", 2*time.Second, true)
+	if got, want := trace.String(), "storage select evaluations 1.5s, runtime get-evaluation-job-logs 2s (failed)"; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+
+	for range 30 {
+		executioncontext.RecordStep(ctx, "storage select providers", time.Millisecond, false)
+	}
+	if steps := trace.Steps(); len(steps) != 20 {
+		t.Errorf("expected the trace to keep the latest 20 steps, got %d", len(steps))
+	}
+	if got := trace.String(); !strings.HasPrefix(got, "12 earlier steps, ") {
+		t.Errorf("expected the dropped steps to be counted, got %q", got)
+	}
+
+	// a context without trace records nothing
+	executioncontext.RecordStep(context.Background(), "storage select providers", time.Millisecond, false)
+}
@@ -0,0 +1,104 @@
+package executioncontext
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxTraceSteps bounds the steps that a trace keeps, the latest ones are the most telling
+// when a request exceeds its deadline.
+const maxTraceSteps = 20
+
+type traceKey struct{}
+
+// Trace records the steps of a request, e.g. its storage statements and handler operations,
+// so that a request that exceeds its deadline can report where its time went.
+type Trace struct {
+	timeout time.Duration
+	mu      sync.Mutex
+	steps   []TraceStep
+	dropped int
+}
+
+// TraceStep is a completed step of a request.
+type TraceStep struct {
+	Name     string
+	Duration time.Duration
+	Failed   bool
+}
+
+// WithTimeout returns a context with the deadline of a request of the timeout, that records
+// the steps of the request in a trace.
+func WithTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx = context.WithValue(ctx, traceKey{}, &Trace{timeout: timeout})
+	return context.WithTimeout(ctx, timeout)
+}
+
+// TraceFrom returns the trace of the context, nil when it has none.
+func TraceFrom(ctx context.Context) *Trace {
+	if ctx == nil {
+		return nil
+	}
+	trace, _ := ctx.Value(traceKey{}).(*Trace)
+	return trace
+}
+
+// Timeout returns the timeout of the request of the trace.
+func (t *Trace) Timeout() time.Duration {
+	if t == nil {
+		return 0
+	}
+	return t.timeout
+}
+
+// RecordStep records a completed step in the trace of the context, if it has one.
+func RecordStep(ctx context.Context, name string, duration time.Duration, failed bool) {
+	trace := TraceFrom(ctx)
+	if trace == nil {
+		return
+	}
+	trace.mu.Lock()
+	defer trace.mu.Unlock()
+	if len(trace.steps) == maxTraceSteps {
+		trace.steps = trace.steps[1:]
+		trace.dropped++
+	}
+	trace.steps = append(trace.steps, TraceStep{Name: name, Duration: duration, Failed: failed})
+}
+
+// Steps returns the steps that the trace kept, in the order that they completed.
+func (t *Trace) Steps() []TraceStep {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TraceStep(nil), t.steps...)
+}
+
+// String summarizes the steps of the trace, e.g. "storage select evaluations 4.9s (failed)".
+func (t *Trace) String() string {
+	if t == nil {
+		return "no step completed"
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.steps) == 0 {
+		return "no step completed"
+	}
+	parts := make([]string, 0, len(t.steps)+1)
+	if t.dropped > 0 {
+		parts = append(parts, fmt.Sprintf("%d earlier steps", t.dropped))
+	}
+	for _, step := range t.steps {
+		part := fmt.Sprintf("%s %s", step.Name, step.Duration.Round(time.Millisecond))
+		if step.Failed {
+			part += " (failed)"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
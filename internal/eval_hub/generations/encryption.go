@@ -0,0 +1,195 @@
+// Package generations encrypts the raw generations of the benchmarks of a job, the prompts and
+// responses of each sample, before they are stored in the artifact store, with a key of the
+// tenant of the job. They can then only be read through the API, by the users allowed to.
+package generations
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The content is encrypted with AES-256-GCM in chunks of chunkSize bytes, so that it can be
+// streamed. The encrypted content starts with the format and a random nonce prefix; the nonce
+// of each chunk is the prefix, the index of the chunk and whether it is the last chunk, so that
+// chunks can be neither reordered nor dropped.
+const (
+	format      = "EHG1"
+	prefixSize  = 7
+	chunkSize   = 64 * 1024
+	headerSize  = len(format) + prefixSize
+	counterSize = 4
+)
+
+// keyPrefix keeps the keys of the generations apart from anything else derived from the secret.
+const keyPrefix = "evalhub-generations:"
+
+// ErrInvalidContent is returned when the content can not be decrypted, because it was not
+// encrypted with the key of the tenant or it was changed.
+var ErrInvalidContent = errors.New("the generations can not be decrypted")
+
+// TenantKey returns the key that encrypts the generations of the tenant.
+func TenantKey(secret string, tenant api.Tenant) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(keyPrefix + tenant.String()))
+	return mac.Sum(nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func chunkNonce(prefix []byte, index uint32, last bool) []byte {
+	nonce := make([]byte, 0, prefixSize+counterSize+1)
+	nonce = append(nonce, prefix...)
+	nonce = binary.BigEndian.AppendUint32(nonce, index)
+	if last {
+		return append(nonce, 1)
+	}
+	return append(nonce, 0)
+}
+
+// chunkReader reads the chunks of a stream, and tells whether a chunk is the last one.
+type chunkReader struct {
+	source *bufio.Reader
+	buffer []byte
+}
+
+func (r *chunkReader) next() ([]byte, bool, error) {
+	n, err := io.ReadFull(r.source, r.buffer)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, false, err
+	}
+	if n < len(r.buffer) {
+		return r.buffer[:n], true, nil
+	}
+	if _, err := r.source.Peek(1); err != nil {
+		if err == io.EOF {
+			return r.buffer, true, nil
+		}
+		return nil, false, err
+	}
+	return r.buffer, false, nil
+}
+
+// encryptingReader reads the encrypted content of a plaintext stream.
+type encryptingReader struct {
+	aead    cipher.AEAD
+	prefix  []byte
+	chunks  *chunkReader
+	index   uint32
+	pending []byte
+	done    bool
+}
+
+// NewEncryptingReader returns a reader of the content of plaintext encrypted with key.
+func NewEncryptingReader(key []byte, plaintext io.Reader) (io.Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, prefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+	return &encryptingReader{
+		aead:    aead,
+		prefix:  prefix,
+		chunks:  &chunkReader{source: bufio.NewReader(plaintext), buffer: make([]byte, chunkSize)},
+		pending: append([]byte(format), prefix...),
+	}, nil
+}
+
+func (r *encryptingReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		chunk, last, err := r.chunks.next()
+		if err != nil {
+			return 0, err
+		}
+		r.pending = r.aead.Seal(nil, chunkNonce(r.prefix, r.index, last), chunk, nil)
+		r.index++
+		r.done = last
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// decryptingReader reads the plaintext of an encrypted stream.
+type decryptingReader struct {
+	aead    cipher.AEAD
+	prefix  []byte
+	chunks  *chunkReader
+	index   uint32
+	pending []byte
+	done    bool
+}
+
+// NewDecryptingReader returns a reader of the plaintext of content encrypted with key. The
+// first chunk is decrypted at once, so that content encrypted with another key is reported
+// before anything is read.
+func NewDecryptingReader(key []byte, content io.Reader) (io.Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	source := bufio.NewReader(content)
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(source, header); err != nil || string(header[:len(format)]) != format {
+		return nil, ErrInvalidContent
+	}
+	r := &decryptingReader{
+		aead:   aead,
+		prefix: header[len(format):],
+		chunks: &chunkReader{source: source, buffer: make([]byte, chunkSize+aead.Overhead())},
+	}
+	if err := r.nextChunk(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *decryptingReader) nextChunk() error {
+	chunk, last, err := r.chunks.next()
+	if err != nil {
+		return err
+	}
+	plaintext, err := r.aead.Open(nil, chunkNonce(r.prefix, r.index, last), chunk, nil)
+	if err != nil {
+		return fmt.Errorf("%w: chunk %d", ErrInvalidContent, r.index)
+	}
+	r.pending = plaintext
+	r.index++
+	r.done = last
+	return nil
+}
+
+func (r *decryptingReader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.nextChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
@@ -0,0 +1,78 @@
+package generations
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func encrypt(t *testing.T, key []byte, plaintext []byte) []byte {
+	t.Helper()
+	reader, err := NewEncryptingReader(key, bytes.NewReader(plaintext))
+	if err != nil {
+		t.Fatalf("NewEncryptingReader: %v", err)
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	return content
+}
+
+func decrypt(key []byte, content []byte) ([]byte, error) {
+	reader, err := NewDecryptingReader(key, bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	return io.ReadAll(reader)
+}
+
+func TestEncryption(t *testing.T) {
+	key := TenantKey("s3cr3t", "team-a")
+
+	for _, size := range []int{0, 10, chunkSize, chunkSize + 1, 3*chunkSize + 17} {
+		plaintext := bytes.Repeat([]byte("a"), size)
+		content := encrypt(t, key, plaintext)
+		if size > 0 && bytes.Contains(content, plaintext[:min(size, 64)]) {
+			t.Errorf("size %d: expected the content to be encrypted", size)
+		}
+		decrypted, err := decrypt(key, content)
+		if err != nil {
+			t.Fatalf("size %d: decrypt: %v", size, err)
+		}
+		if !bytes.Equal(decrypted, plaintext) {
+			t.Fatalf("size %d: decrypted %d bytes, want %d", size, len(decrypted), size)
+		}
+	}
+}
+
+func TestEncryptionTampering(t *testing.T) {
+	key := TenantKey("s3cr3t", "team-a")
+	plaintext := []byte(strings.Repeat(`{"prompt":"p","response":"r"}`+"\n", 5000))
+	content := encrypt(t, key, plaintext)
+
+	if _, err := decrypt(TenantKey("s3cr3t", "team-b"), content); !errors.Is(err, ErrInvalidContent) {
+		t.Fatalf("expected the key of another tenant to be rejected, got %v", err)
+	}
+	if _, err := decrypt(TenantKey("other", "team-a"), content); !errors.Is(err, ErrInvalidContent) {
+		t.Fatalf("expected the key of another secret to be rejected, got %v", err)
+	}
+
+	changed := bytes.Clone(content)
+	changed[len(changed)-20] ^= 1
+	if _, err := decrypt(key, changed); !errors.Is(err, ErrInvalidContent) {
+		t.Fatalf("expected changed content to be rejected, got %v", err)
+	}
+
+	// dropping the last chunk leaves a chunk that was not sealed as the last one
+	truncated := content[:headerSize+chunkSize+16]
+	if _, err := decrypt(key, truncated); !errors.Is(err, ErrInvalidContent) {
+		t.Fatalf("expected truncated content to be rejected, got %v", err)
+	}
+
+	if _, err := decrypt(key, plaintext); !errors.Is(err, ErrInvalidContent) {
+		t.Fatalf("expected content that is not encrypted to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,356 @@
+package handlers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// archivePageSize is the number of resources read from the storage at a time on export.
+const archivePageSize = 100
+
+// HandleExportArchive handles GET /api/v1/admin/export. The archive holds the providers,
+// collections, baselines and evaluation jobs of the tenant of the request as JSON lines,
+// after a manifest line; system providers and collections are left out.
+func (h *Handlers) HandleExportArchive(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	var archive []byte
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			var err error
+			archive, err = exportArchive(ctx, storage.WithContext(runtimeCtx))
+			return err
+		},
+		"storage",
+		"export-archive",
+		"tenant", ctx.Tenant.String(),
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	filename := fmt.Sprintf("eval-hub-%s-%s.jsonl", archiveTenantName(ctx.Tenant), time.Now().UTC().Format("20060102T150405Z"))
+	w.SetHeader("Content-Type", "application/x-ndjson")
+	w.SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if ctx.RequestID != "" {
+		w.SetHeader("X-Global-Transaction-Id", ctx.RequestID)
+	}
+	w.SetStatusCode(200)
+	_, _ = w.Write(archive)
+	ctx.Logger.Warn("Tenant archive exported", "bytes", len(archive))
+	logging.LogRequestSuccess(ctx, 200, nil)
+}
+
+func archiveTenantName(tenant api.Tenant) string {
+	if tenant.IsEmpty() {
+		return "all"
+	}
+	return tenant.String()
+}
+
+func exportArchive(ctx *executioncontext.ExecutionContext, storage abstractions.Storage) ([]byte, error) {
+	manifest := api.ArchiveManifest{
+		Format:     api.ArchiveFormat,
+		Version:    api.ArchiveVersion,
+		Tenant:     ctx.Tenant,
+		ExportedAt: time.Now().UTC(),
+		ExportedBy: ctx.User,
+		Counts:     map[api.ArchiveKind]int{},
+	}
+	var records []api.ArchiveRecord
+	add := func(kind api.ArchiveKind, resource any) error {
+		resourceJSON, err := json.Marshal(resource)
+		if err != nil {
+			return serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+		}
+		records = append(records, api.ArchiveRecord{Kind: kind, Resource: resourceJSON})
+		manifest.Counts[kind]++
+		return nil
+	}
+
+	tenantResources := map[string]any{"scope": abstractions.ScopeTenant}
+	providers, err := listAllPages(ctx, storage.GetProviders, tenantResources)
+	if err != nil {
+		return nil, err
+	}
+	for _, provider := range providers {
+		if err := add(api.ArchiveKindProvider, provider); err != nil {
+			return nil, err
+		}
+	}
+	collections, err := listAllPages(ctx, storage.GetCollections, tenantResources)
+	if err != nil {
+		return nil, err
+	}
+	for _, collection := range collections {
+		if err := add(api.ArchiveKindCollection, collection); err != nil {
+			return nil, err
+		}
+	}
+	baselines, err := listAllPages(ctx, storage.GetBaselines, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, baseline := range baselines {
+		if err := add(api.ArchiveKindBaseline, baseline); err != nil {
+			return nil, err
+		}
+	}
+	jobs, err := listAllPages(ctx, storage.GetEvaluationJobs, nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		if err := add(api.ArchiveKindEvaluationJob, job); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	manifestJSON, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	if err := encoder.Encode(api.ArchiveRecord{Kind: api.ArchiveKindManifest, Resource: manifestJSON}); err != nil {
+		return nil, serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	for _, record := range records {
+		if err := encoder.Encode(record); err != nil {
+			return nil, serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// listAllPages reads every page of a storage list.
+func listAllPages[T any](ctx *executioncontext.ExecutionContext, list func(filter *abstractions.QueryFilter) (*abstractions.QueryResults[T], error), params map[string]any) ([]T, error) {
+	var items []T
+	for offset := 0; ; {
+		filter := &abstractions.QueryFilter{Limit: archivePageSize, Offset: offset, Params: map[string]any{}}
+		for key, value := range params {
+			filter.Params[key] = value
+		}
+		res, err := list(filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range res.Errors {
+			// the resources that can not be read are left out of the archive
+			ctx.Logger.Warn("Resource left out of the archive", "error", message)
+		}
+		items = append(items, res.Items...)
+		offset += len(res.Items)
+		if len(res.Items) == 0 || offset >= res.TotalCount {
+			return items, nil
+		}
+	}
+}
+
+// HandleImportArchive handles POST /api/v1/admin/import. The resources of an archive made
+// by HandleExportArchive are created in the tenant of the request with their IDs and
+// owners; resources whose ID already exists are skipped, so an import can be retried.
+func (h *Handlers) HandleImportArchive(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	var records []archiveLine
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			bodyBytes, err := req.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			records, err = parseArchive(bodyBytes)
+			return err
+		},
+		"validation",
+		"validate-archive",
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			result := importArchive(ctx, storage.WithContext(runtimeCtx), records)
+			ctx.Logger.Warn("Tenant archive imported", "imported", result.Imported, "skipped", result.Skipped, "errors", len(result.Errors))
+			w.WriteJSON(result, 200)
+			return nil
+		},
+		"storage",
+		"import-archive",
+		"tenant", ctx.Tenant.String(),
+	)
+}
+
+// archiveLine is a resource record of an archive with its line number, to report errors.
+type archiveLine struct {
+	line   int
+	record api.ArchiveRecord
+}
+
+// parseArchive returns the resource records of an archive after checking its manifest.
+func parseArchive(archive []byte) ([]archiveLine, error) {
+	invalid := func(reason string) error {
+		return serviceerrors.NewServiceError(messages.InvalidArchive, "Reason", reason)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(archive))
+	scanner.Buffer(make([]byte, 0, 64<<10), len(archive)+1)
+	var records []archiveLine
+	var manifest *api.ArchiveManifest
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var record api.ArchiveRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, invalid(fmt.Sprintf("line %d: %s", line, err.Error()))
+		}
+		if manifest == nil {
+			manifest = &api.ArchiveManifest{}
+			if record.Kind != api.ArchiveKindManifest || json.Unmarshal(record.Resource, manifest) != nil {
+				return nil, invalid("the first line must be the manifest")
+			}
+			if manifest.Format != api.ArchiveFormat || manifest.Version != api.ArchiveVersion {
+				return nil, invalid(fmt.Sprintf("unsupported format %s version %d", manifest.Format, manifest.Version))
+			}
+			continue
+		}
+		records = append(records, archiveLine{line: line, record: record})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, invalid(err.Error())
+	}
+	if manifest == nil {
+		return nil, invalid("the archive is empty")
+	}
+	return records, nil
+}
+
+func importArchive(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, records []archiveLine) *api.ArchiveImportResult {
+	result := &api.ArchiveImportResult{
+		Imported: map[api.ArchiveKind]int{},
+		Skipped:  map[api.ArchiveKind]int{},
+	}
+	for _, line := range records {
+		kind := line.record.Kind
+		imported, err := importArchiveRecord(ctx, storage, line.record)
+		switch {
+		case err != nil:
+			result.Errors = append(result.Errors, fmt.Sprintf("line %d (%s): %s", line.line, kind, err.Error()))
+		case imported:
+			result.Imported[kind]++
+		default:
+			result.Skipped[kind]++
+		}
+	}
+	return result
+}
+
+// importArchiveRecord creates the resource of a record in the tenant of the request and
+// returns false when a resource with its ID already exists.
+func importArchiveRecord(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, record api.ArchiveRecord) (bool, error) {
+	switch record.Kind {
+	case api.ArchiveKindProvider:
+		provider := &api.ProviderResource{}
+		if err := unmarshalArchiveResource(record, provider, &provider.Resource); err != nil {
+			return false, err
+		}
+		provider.Resource.Tenant = ctx.Tenant
+		return createUnlessExists(provider.Resource.ID, storage.GetProvider, func() error { return storage.CreateProvider(provider) })
+	case api.ArchiveKindCollection:
+		collection := &api.CollectionResource{}
+		if err := unmarshalArchiveResource(record, collection, &collection.Resource); err != nil {
+			return false, err
+		}
+		collection.Resource.Tenant = ctx.Tenant
+		return createUnlessExists(collection.Resource.ID, storage.GetCollection, func() error { return storage.CreateCollection(collection) })
+	case api.ArchiveKindBaseline:
+		baseline := &api.BaselineResource{}
+		if err := unmarshalArchiveResource(record, baseline, &baseline.Resource); err != nil {
+			return false, err
+		}
+		baseline.Resource.Tenant = ctx.Tenant
+		return createUnlessExists(baseline.Resource.ID, storage.GetBaseline, func() error { return storage.CreateBaseline(baseline) })
+	case api.ArchiveKindEvaluationJob:
+		job := &api.EvaluationJobResource{}
+		if err := unmarshalArchiveResource(record, job, &job.Resource.Resource); err != nil {
+			return false, err
+		}
+		job.Resource.Tenant = ctx.Tenant
+		cancelImportedActiveJob(job)
+		return createUnlessExists(job.Resource.ID, storage.GetEvaluationJob, func() error { return storage.CreateEvaluationJob(job) })
+	default:
+		return false, fmt.Errorf("unknown kind %q", record.Kind)
+	}
+}
+
+func unmarshalArchiveResource(record api.ArchiveRecord, resource any, base *api.Resource) error {
+	if err := json.Unmarshal(record.Resource, resource); err != nil {
+		return err
+	}
+	if base.ID == "" {
+		return fmt.Errorf("the resource has no id")
+	}
+	if base.IsSystemResource() {
+		return fmt.Errorf("system resources can not be imported")
+	}
+	return nil
+}
+
+// cancelImportedActiveJob cancels a job that was not finished when it was exported, since
+// nothing runs it in this instance.
+func cancelImportedActiveJob(job *api.EvaluationJobResource) {
+	if job.Status == nil {
+		job.Status = &api.EvaluationJobStatus{}
+	}
+	exportedState := job.Status.State
+	if exportedState.IsTerminalState() {
+		return
+	}
+	job.Status.State = api.OverallStateCancelled
+	job.Status.Message = api.WithMessageOrigin(&api.MessageInfo{
+		Message:     fmt.Sprintf("Evaluation job cancelled on import, it was %s when exported", exportedState),
+		MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_CANCELLED,
+	}, api.MessageOriginServer)
+}
+
+func createUnlessExists[T any](id string, get func(id string) (*T, error), create func() error) (bool, error) {
+	_, err := get(id)
+	if err == nil {
+		return false, nil
+	}
+	var se *serviceerrors.ServiceError
+	if !errors.As(err, &se) || se.MessageCode() != messages.ResourceNotFound {
+		return false, err
+	}
+	if err := create(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,232 @@
+package handlers_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// archiveTestStorage keeps the resources of a tenant in memory.
+type archiveTestStorage struct {
+	abstractions.Storage
+	providers   map[string]api.ProviderResource
+	collections map[string]api.CollectionResource
+	baselines   map[string]api.BaselineResource
+	jobs        map[string]api.EvaluationJobResource
+}
+
+func newArchiveTestStorage() *archiveTestStorage {
+	return &archiveTestStorage{
+		providers:   map[string]api.ProviderResource{},
+		collections: map[string]api.CollectionResource{},
+		baselines:   map[string]api.BaselineResource{},
+		jobs:        map[string]api.EvaluationJobResource{},
+	}
+}
+
+func (s *archiveTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *archiveTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *archiveTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *archiveTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func archiveNotFound(id string) error {
+	return serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "resource", "ResourceId", id)
+}
+
+// archivePage returns a page of items, two at a time so that the export reads several pages.
+func archivePage[T any](items map[string]T, filter *abstractions.QueryFilter) *abstractions.QueryResults[T] {
+	all := make([]T, 0, len(items))
+	for _, id := range slices.Sorted(maps.Keys(items)) {
+		all = append(all, items[id])
+	}
+	end := min(filter.Offset+2, len(all))
+	return &abstractions.QueryResults[T]{Items: all[min(filter.Offset, end):end], TotalCount: len(all)}
+}
+
+func (s *archiveTestStorage) GetProviders(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.ProviderResource], error) {
+	return archivePage(s.providers, filter), nil
+}
+
+func (s *archiveTestStorage) GetProvider(id string) (*api.ProviderResource, error) {
+	if provider, ok := s.providers[id]; ok {
+		return &provider, nil
+	}
+	return nil, archiveNotFound(id)
+}
+
+func (s *archiveTestStorage) CreateProvider(provider *api.ProviderResource) error {
+	s.providers[provider.Resource.ID] = *provider
+	return nil
+}
+
+func (s *archiveTestStorage) GetCollections(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.CollectionResource], error) {
+	return archivePage(s.collections, filter), nil
+}
+
+func (s *archiveTestStorage) GetCollection(id string) (*api.CollectionResource, error) {
+	if collection, ok := s.collections[id]; ok {
+		return &collection, nil
+	}
+	return nil, archiveNotFound(id)
+}
+
+func (s *archiveTestStorage) CreateCollection(collection *api.CollectionResource) error {
+	s.collections[collection.Resource.ID] = *collection
+	return nil
+}
+
+func (s *archiveTestStorage) GetBaselines(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.BaselineResource], error) {
+	return archivePage(s.baselines, filter), nil
+}
+
+func (s *archiveTestStorage) GetBaseline(name string) (*api.BaselineResource, error) {
+	if baseline, ok := s.baselines[name]; ok {
+		return &baseline, nil
+	}
+	return nil, archiveNotFound(name)
+}
+
+func (s *archiveTestStorage) CreateBaseline(baseline *api.BaselineResource) error {
+	s.baselines[baseline.Resource.ID] = *baseline
+	return nil
+}
+
+func (s *archiveTestStorage) GetEvaluationJobs(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.EvaluationJobResource], error) {
+	return archivePage(s.jobs, filter), nil
+}
+
+func (s *archiveTestStorage) GetEvaluationJob(id string) (*api.EvaluationJobResource, error) {
+	if job, ok := s.jobs[id]; ok {
+		return &job, nil
+	}
+	return nil, archiveNotFound(id)
+}
+
+func (s *archiveTestStorage) CreateEvaluationJob(job *api.EvaluationJobResource) error {
+	s.jobs[job.Resource.ID] = *job
+	return nil
+}
+
+func TestHandleExportImportArchive(t *testing.T) {
+	source := newArchiveTestStorage()
+	source.providers["my-harness"] = api.ProviderResource{Resource: api.Resource{ID: "my-harness", Tenant: "team-a", Owner: "alice"}}
+	source.collections["safety"] = api.CollectionResource{Resource: api.Resource{ID: "safety", Tenant: "team-a", Owner: "alice"}}
+	source.baselines["prod-v3"] = api.BaselineResource{Resource: api.Resource{ID: "prod-v3", Tenant: "team-a"}, Score: 0.7}
+	for id, state := range map[string]api.OverallState{"job-done": api.OverallStateCompleted, "job-running": api.OverallStateRunning, "job-failed": api.OverallStateFailed} {
+		source.jobs[id] = api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: id, Tenant: "team-a", Owner: "bob"}},
+			Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: state}},
+		}
+	}
+
+	exportCtx := executioncontext.NewExecutionContext(context.Background(), "req-export", logging.FallbackLogger(), "admin", "team-a")
+	recorder := httptest.NewRecorder()
+	handlers.New(source, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil).
+		HandleExportArchive(exportCtx, createMockRequest("GET", "/api/v1/admin/export"), MockResponseWrapper{recorder: recorder})
+	if recorder.Code != 200 {
+		t.Fatalf("export: expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if contentType := recorder.Header().Get("Content-Type"); contentType != "application/x-ndjson" {
+		t.Errorf("export: Content-Type = %q", contentType)
+	}
+	archive := recorder.Body.Bytes()
+
+	var kinds []api.ArchiveKind
+	var manifest api.ArchiveManifest
+	scanner := bufio.NewScanner(bytes.NewReader(archive))
+	for scanner.Scan() {
+		var record api.ArchiveRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("decode archive line %q: %v", scanner.Text(), err)
+		}
+		if record.Kind == api.ArchiveKindManifest {
+			if err := json.Unmarshal(record.Resource, &manifest); err != nil {
+				t.Fatalf("decode manifest: %v", err)
+			}
+		}
+		kinds = append(kinds, record.Kind)
+	}
+	if len(kinds) != 7 || kinds[0] != api.ArchiveKindManifest || kinds[1] != api.ArchiveKindProvider || kinds[6] != api.ArchiveKindEvaluationJob {
+		t.Fatalf("unexpected archive records %v", kinds)
+	}
+	if manifest.Tenant != "team-a" || manifest.ExportedBy != "admin" || manifest.Counts[api.ArchiveKindEvaluationJob] != 3 {
+		t.Fatalf("unexpected manifest %+v", manifest)
+	}
+
+	target := newArchiveTestStorage()
+	h := handlers.New(target, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+	importArchive := func(body []byte) (*httptest.ResponseRecorder, api.ArchiveImportResult) {
+		t.Helper()
+		importCtx := executioncontext.NewExecutionContext(context.Background(), "req-import", logging.FallbackLogger(), "admin", "team-b")
+		recorder := httptest.NewRecorder()
+		h.HandleImportArchive(importCtx, &bodyRequest{
+			MockRequest: createMockRequest("POST", "/api/v1/admin/import"),
+			body:        body,
+		}, MockResponseWrapper{recorder: recorder})
+		var result api.ArchiveImportResult
+		if recorder.Code == 200 {
+			if err := json.Unmarshal(recorder.Body.Bytes(), &result); err != nil {
+				t.Fatalf("decode import result: %v", err)
+			}
+		}
+		return recorder, result
+	}
+
+	t.Run("the resources are created in the tenant of the request", func(t *testing.T) {
+		recorder, result := importArchive(archive)
+		if recorder.Code != 200 {
+			t.Fatalf("import: expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if result.Imported[api.ArchiveKindEvaluationJob] != 3 || result.Imported[api.ArchiveKindProvider] != 1 || len(result.Errors) != 0 {
+			t.Fatalf("unexpected import result %+v", result)
+		}
+		if job := target.jobs["job-done"]; job.Resource.Tenant != "team-b" || job.Resource.Owner != "bob" || job.Status.State != api.OverallStateCompleted {
+			t.Errorf("unexpected imported job %+v", job.Resource)
+		}
+		if job := target.jobs["job-running"]; job.Status.State != api.OverallStateCancelled || !strings.Contains(job.Status.Message.Message, "running") {
+			t.Errorf("expected the running job to be cancelled, got %+v", job.Status)
+		}
+		if baseline := target.baselines["prod-v3"]; baseline.Resource.Tenant != "team-b" || baseline.Score != 0.7 {
+			t.Errorf("unexpected imported baseline %+v", baseline)
+		}
+	})
+
+	t.Run("existing resources are skipped", func(t *testing.T) {
+		_, result := importArchive(archive)
+		if len(result.Imported) != 0 || result.Skipped[api.ArchiveKindEvaluationJob] != 3 || result.Skipped[api.ArchiveKindCollection] != 1 {
+			t.Fatalf("unexpected import result %+v", result)
+		}
+	})
+
+	t.Run("an archive without a manifest is rejected", func(t *testing.T) {
+		lines := bytes.SplitN(archive, []byte("\n"), 2)
+		recorder, _ := importArchive(lines[1])
+		if recorder.Code != 400 || !strings.Contains(recorder.Body.String(), "invalid_archive") {
+			t.Fatalf("expected invalid_archive, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("records that cannot be imported are reported", func(t *testing.T) {
+		body := append(bytes.SplitN(archive, []byte("\n"), 2)[0], []byte("\n{\"kind\": \"widget\", \"resource\": {}}\n")...)
+		_, result := importArchive(body)
+		if len(result.Errors) != 1 || !strings.HasPrefix(result.Errors[0], "line 2 (widget)") {
+			t.Fatalf("expected the unknown kind to be reported, got %+v", result)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// HandleGetAdminClusters handles GET /api/v1/admin/clusters. It reports the clusters that the
+// runtime dispatches evaluation jobs to, with whether they are reachable and their active
+// jobs; there are none when the runtime does not dispatch to clusters.
+func (h *Handlers) HandleGetAdminClusters(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	logging.LogRequestStarted(ctx)
+
+	list := api.ClusterStatusList{Clusters: []api.ClusterStatus{}}
+	if reporter, ok := h.runtime.(abstractions.ClusterReporter); ok {
+		statuses, err := reporter.ClusterStatus(ctx.Ctx)
+		if err != nil {
+			w.Error(err, ctx.RequestID)
+			return
+		}
+		list.Clusters = append(list.Clusters, statuses...)
+	}
+	w.WriteJSON(list, 200)
+}
@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serialization"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const (
+	// AdminConfigSyncInterval is how often each replica applies the admin configuration
+	// changed on another replica.
+	AdminConfigSyncInterval = 15 * time.Second
+
+	// minProviderHealthPollInterval and minReconcileInterval keep the background checks from
+	// polling the storage and the runtime in a tight loop.
+	minProviderHealthPollInterval = time.Second
+	minReconcileInterval          = time.Second
+)
+
+var (
+	// these are the settings that can be changed while the service runs
+	allowedAdminConfigPatches = []allowedPatch{
+		{Path: "/log_level", Op: api.PatchOpReplace, Prefix: false},
+		{Path: "/provider_health_poll_interval", Op: api.PatchOpReplace, Prefix: false},
+		{Path: "/reconcile_interval", Op: api.PatchOpReplace, Prefix: false},
+	}
+)
+
+// HandleGetAdminConfig handles GET /api/v1/admin/config
+func (h *Handlers) HandleGetAdminConfig(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	logging.LogRequestStarted(ctx)
+
+	w.WriteJSON(h.adminConfig(), 200)
+}
+
+// HandlePatchAdminConfig handles PATCH /api/v1/admin/config. The changes are stored, so that
+// every replica applies them within AdminConfigSyncInterval, including the leader that runs
+// the background checks, and the replicas started later. This replica applies them at once;
+// each one is logged with the user who made it.
+func (h *Handlers) HandlePatchAdminConfig(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	logging.LogRequestStarted(ctx)
+
+	current := h.adminConfig()
+	var updated api.AdminConfig
+	var pollInterval, reconcileInterval time.Duration
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			bodyBytes, err := req.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			var patches api.Patch
+			if err = json.Unmarshal(bodyBytes, &patches); err != nil {
+				return serviceerrors.NewServiceError(messages.InvalidJSONRequest, "Error", err.Error())
+			}
+			if err := h.verifyPatches(runtimeCtx, patches, allowedAdminConfigPatches); err != nil {
+				return err
+			}
+			currentJSON, err := json.Marshal(current)
+			if err != nil {
+				return serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+			}
+			patchedJSON, err := applyJSONPatches(currentJSON, &patches)
+			if err != nil {
+				return serviceerrors.NewServiceError(messages.InvalidJSONRequest, "Error", err.Error())
+			}
+			if err := serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), patchedJSON, &updated); err != nil {
+				return err
+			}
+			pollInterval, reconcileInterval, err = h.parseAdminIntervals(updated)
+			if err != nil {
+				return serviceerrors.NewServiceError(messages.RequestValidationFailed, "Error", err.Error())
+			}
+			return nil
+		},
+		"validation",
+		"validate-admin-config-patch",
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	updated.UpdatedAt = time.Now().UTC()
+	updated.UpdatedBy = ctx.User
+	err = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			return h.storage.WithLogger(ctx.Logger).WithContext(runtimeCtx).PutAdminConfig(&updated)
+		},
+		"storage",
+		"put-admin-config",
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	record := func(setting string, oldValue string, newValue string) {
+		auditAdminConfigChange(ctx, setting, oldValue, newValue)
+	}
+	if err := h.applyAdminConfig(current, &updated, pollInterval, reconcileInterval, record); err != nil {
+		w.Error(serviceerrors.NewServiceError(messages.RequestValidationFailed, "Error", err.Error()), ctx.RequestID)
+		return
+	}
+
+	w.WriteJSON(h.adminConfig(), 200)
+}
+
+// SyncAdminConfig applies the admin configuration stored by the latest PATCH on any replica,
+// when it is not the one this replica applied last.
+func (h *Handlers) SyncAdminConfig(ctx context.Context, logger *slog.Logger) {
+	stored, err := h.storage.WithLogger(logger).WithContext(ctx).GetAdminConfig()
+	if err != nil {
+		logger.Warn("Failed to read the admin configuration", "error", err)
+		return
+	}
+	if stored == nil {
+		return
+	}
+	if applied := h.appliedAdminConfig.Load(); applied != nil && applied.UpdatedAt.Equal(stored.UpdatedAt) {
+		return
+	}
+	current := h.adminConfig()
+	pollInterval, reconcileInterval, err := h.parseAdminIntervals(*stored)
+	if err != nil {
+		logger.Warn("Ignored the stored admin configuration", "error", err, "updated_by", stored.UpdatedBy)
+		return
+	}
+	record := func(setting string, oldValue string, newValue string) {
+		logger.Warn("Admin configuration changed", "setting", setting, "old_value", oldValue, "new_value", newValue,
+			"updated_by", stored.UpdatedBy, "updated_at", stored.UpdatedAt)
+	}
+	if err := h.applyAdminConfig(current, stored, pollInterval, reconcileInterval, record); err != nil {
+		logger.Warn("Failed to apply the stored admin configuration", "error", err)
+	}
+}
+
+// applyAdminConfig applies to this replica the settings of updated that differ from current,
+// with the intervals parsed by parseAdminIntervals, and records each change.
+func (h *Handlers) applyAdminConfig(current api.AdminConfig, updated *api.AdminConfig, pollInterval time.Duration, reconcileInterval time.Duration, record func(setting string, oldValue string, newValue string)) error {
+	if pollInterval > 0 {
+		h.providerHealthScheduler.SetPollInterval(pollInterval)
+		record("provider_health_poll_interval", current.ProviderHealthPollInterval, pollInterval.String())
+	}
+	if reconcileInterval > 0 {
+		h.reconcileScheduler.SetReconcileInterval(reconcileInterval)
+		record("reconcile_interval", current.ReconcileInterval, reconcileInterval.String())
+	}
+	if updated.LogLevel != "" && updated.LogLevel != current.LogLevel {
+		// the audit record is logged at the more verbose of the two levels so that it is
+		// never filtered out by the change it records
+		if updated.LogLevel == "error" {
+			record("log_level", current.LogLevel, updated.LogLevel)
+		}
+		if err := logging.SetLevel(updated.LogLevel); err != nil {
+			return err
+		}
+		if updated.LogLevel != "error" {
+			record("log_level", current.LogLevel, updated.LogLevel)
+		}
+	}
+	h.appliedAdminConfig.Store(updated)
+	return nil
+}
+
+func (h *Handlers) adminConfig() api.AdminConfig {
+	config := api.AdminConfig{
+		LogLevel: logging.Level(),
+	}
+	if h.providerHealthScheduler != nil {
+		config.ProviderHealthPollInterval = h.providerHealthScheduler.PollInterval().String()
+	}
+	if h.reconcileScheduler != nil {
+		config.ReconcileInterval = h.reconcileScheduler.ReconcileInterval().String()
+	}
+	if applied := h.appliedAdminConfig.Load(); applied != nil {
+		config.UpdatedAt = applied.UpdatedAt
+		config.UpdatedBy = applied.UpdatedBy
+	}
+	return config
+}
+
+// parseAdminIntervals returns the intervals of updated that differ from the ones of this
+// replica, zero for the ones that are unchanged or empty.
+func (h *Handlers) parseAdminIntervals(updated api.AdminConfig) (time.Duration, time.Duration, error) {
+	var pollInterval, reconcileInterval time.Duration
+	var err error
+	if updated.ProviderHealthPollInterval != "" {
+		if h.providerHealthScheduler == nil {
+			return 0, 0, fmt.Errorf("the provider health checks are not running")
+		}
+		pollInterval, err = parseAdminInterval("provider_health_poll_interval", updated.ProviderHealthPollInterval, minProviderHealthPollInterval)
+		if err != nil {
+			return 0, 0, err
+		}
+		if pollInterval == h.providerHealthScheduler.PollInterval() {
+			pollInterval = 0
+		}
+	}
+	if updated.ReconcileInterval != "" {
+		if h.reconcileScheduler == nil {
+			return 0, 0, fmt.Errorf("the job workloads are not reconciled")
+		}
+		reconcileInterval, err = parseAdminInterval("reconcile_interval", updated.ReconcileInterval, minReconcileInterval)
+		if err != nil {
+			return 0, 0, err
+		}
+		if reconcileInterval == h.reconcileScheduler.ReconcileInterval() {
+			reconcileInterval = 0
+		}
+	}
+	return pollInterval, reconcileInterval, nil
+}
+
+func parseAdminInterval(setting string, value string, minInterval time.Duration) (time.Duration, error) {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", setting, err)
+	}
+	if interval < minInterval {
+		return 0, fmt.Errorf("%s must be at least %s", setting, minInterval)
+	}
+	return interval, nil
+}
+
+// auditAdminConfigChange records a change of a live setting; the request logger carries
+// the user and tenant who made it.
+func auditAdminConfigChange(ctx *executioncontext.ExecutionContext, setting string, oldValue string, newValue string) {
+	ctx.Logger.Warn("Admin configuration changed", "setting", setting, "old_value", oldValue, "new_value", newValue)
+}
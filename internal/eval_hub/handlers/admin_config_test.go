@@ -0,0 +1,197 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type fakeProviderHealthScheduler struct {
+	pollInterval time.Duration
+}
+
+func (f *fakeProviderHealthScheduler) PollInterval() time.Duration {
+	return f.pollInterval
+}
+
+func (f *fakeProviderHealthScheduler) SetPollInterval(pollInterval time.Duration) {
+	f.pollInterval = pollInterval
+}
+
+type fakeReconcileScheduler struct {
+	reconcileInterval time.Duration
+}
+
+func (f *fakeReconcileScheduler) ReconcileInterval() time.Duration {
+	return f.reconcileInterval
+}
+
+func (f *fakeReconcileScheduler) SetReconcileInterval(reconcileInterval time.Duration) {
+	f.reconcileInterval = reconcileInterval
+}
+
+// adminConfigTestStorage keeps the admin configuration in memory, shared by the replicas
+// built on it.
+type adminConfigTestStorage struct {
+	abstractions.Storage
+	config *api.AdminConfig
+}
+
+func (s *adminConfigTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *adminConfigTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *adminConfigTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *adminConfigTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *adminConfigTestStorage) PutAdminConfig(config *api.AdminConfig) error {
+	stored := *config
+	s.config = &stored
+	return nil
+}
+
+func (s *adminConfigTestStorage) GetAdminConfig() (*api.AdminConfig, error) {
+	if s.config == nil {
+		return nil, nil
+	}
+	stored := *s.config
+	return &stored, nil
+}
+
+func patchAdminConfig(t *testing.T, h *handlers.Handlers, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := &bodyRequest{MockRequest: createMockRequest("PATCH", "/api/v1/admin/config"), body: []byte(body)}
+	recorder := httptest.NewRecorder()
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", logging.FallbackLogger(), "admin", "ops")
+	h.HandlePatchAdminConfig(ctx, req, MockResponseWrapper{recorder: recorder})
+	return recorder
+}
+
+func TestHandleAdminConfig(t *testing.T) {
+	previousLevel := logging.Level()
+	t.Cleanup(func() { _ = logging.SetLevel(previousLevel) })
+	if err := logging.SetLevel("info"); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+
+	storage := &adminConfigTestStorage{}
+	scheduler := &fakeProviderHealthScheduler{pollInterval: 30 * time.Second}
+	reconciler := &fakeReconcileScheduler{reconcileInterval: 30 * time.Second}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil).
+		WithProviderHealthScheduler(scheduler).WithReconcileScheduler(reconciler)
+
+	t.Run("GET returns the live settings", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", logging.FallbackLogger(), "admin", "ops")
+		h.HandleGetAdminConfig(ctx, createMockRequest("GET", "/api/v1/admin/config"), MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d body %s", recorder.Code, recorder.Body.String())
+		}
+		var got api.AdminConfig
+		if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if got.LogLevel != "info" || got.ProviderHealthPollInterval != "30s" || got.ReconcileInterval != "30s" {
+			t.Errorf("unexpected config %+v", got)
+		}
+	})
+
+	t.Run("PATCH applies the changes", func(t *testing.T) {
+		recorder := patchAdminConfig(t, h, `[
+			{"op": "replace", "path": "/log_level", "value": "debug"},
+			{"op": "replace", "path": "/provider_health_poll_interval", "value": "2m"},
+			{"op": "replace", "path": "/reconcile_interval", "value": "1m"}
+		]`)
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d body %s", recorder.Code, recorder.Body.String())
+		}
+		if logging.Level() != "debug" {
+			t.Errorf("log level: got %q want debug", logging.Level())
+		}
+		if scheduler.pollInterval != 2*time.Minute {
+			t.Errorf("poll interval: got %s want 2m", scheduler.pollInterval)
+		}
+		if reconciler.reconcileInterval != time.Minute {
+			t.Errorf("reconcile interval: got %s want 1m", reconciler.reconcileInterval)
+		}
+		var got api.AdminConfig
+		if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if got.LogLevel != "debug" || got.ProviderHealthPollInterval != "2m0s" || got.ReconcileInterval != "1m0s" {
+			t.Errorf("unexpected config %+v", got)
+		}
+		if got.UpdatedBy != "admin" || got.UpdatedAt.IsZero() {
+			t.Errorf("expected who changed the settings and when, got %+v", got)
+		}
+		if storage.config == nil || storage.config.LogLevel != "debug" || storage.config.UpdatedBy != "admin" {
+			t.Errorf("expected the settings to be stored for the other replicas, got %+v", storage.config)
+		}
+	})
+
+	t.Run("other replicas apply the stored changes", func(t *testing.T) {
+		// the leader runs the background checks that the settings tune
+		leaderScheduler := &fakeProviderHealthScheduler{pollInterval: 30 * time.Second}
+		leaderReconciler := &fakeReconcileScheduler{reconcileInterval: 30 * time.Second}
+		leader := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil).
+			WithProviderHealthScheduler(leaderScheduler).WithReconcileScheduler(leaderReconciler)
+		if err := logging.SetLevel("info"); err != nil {
+			t.Fatalf("SetLevel: %v", err)
+		}
+
+		leader.SyncAdminConfig(context.Background(), logging.FallbackLogger())
+		if leaderScheduler.pollInterval != 2*time.Minute || leaderReconciler.reconcileInterval != time.Minute {
+			t.Errorf("expected the stored intervals on the leader, got %s and %s", leaderScheduler.pollInterval, leaderReconciler.reconcileInterval)
+		}
+		if logging.Level() != "debug" {
+			t.Errorf("log level: got %q want debug", logging.Level())
+		}
+
+		// a configuration is applied once, the local changes made since are kept
+		leaderReconciler.reconcileInterval = 45 * time.Second
+		leader.SyncAdminConfig(context.Background(), logging.FallbackLogger())
+		if leaderReconciler.reconcileInterval != 45*time.Second {
+			t.Errorf("expected the stored configuration to be applied once, got %s", leaderReconciler.reconcileInterval)
+		}
+		leaderReconciler.reconcileInterval = time.Minute
+	})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid log level", `[{"op": "replace", "path": "/log_level", "value": "verbose"}]`},
+		{"invalid duration", `[{"op": "replace", "path": "/provider_health_poll_interval", "value": "soon"}]`},
+		{"poll interval too short", `[{"op": "replace", "path": "/provider_health_poll_interval", "value": "10ms"}]`},
+		{"reconcile interval too short", `[{"op": "replace", "path": "/reconcile_interval", "value": "10ms"}]`},
+		{"setting that cannot be changed", `[{"op": "replace", "path": "/database", "value": "x"}]`},
+		{"not a patch", `{"log_level": "warn"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := patchAdminConfig(t, h, tt.body)
+			if recorder.Code != 400 {
+				t.Fatalf("expected status 400, got %d body %s", recorder.Code, recorder.Body.String())
+			}
+			if logging.Level() != "debug" || scheduler.pollInterval != 2*time.Minute || reconciler.reconcileInterval != time.Minute {
+				t.Errorf("a rejected patch must not change the settings")
+			}
+		})
+	}
+
+	t.Run("poll interval needs the provider health checks", func(t *testing.T) {
+		h := handlers.New(&adminConfigTestStorage{}, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+		recorder := patchAdminConfig(t, h, `[{"op": "replace", "path": "/provider_health_poll_interval", "value": "1m"}]`)
+		if recorder.Code != 400 {
+			t.Fatalf("expected status 400, got %d body %s", recorder.Code, recorder.Body.String())
+		}
+	})
+}
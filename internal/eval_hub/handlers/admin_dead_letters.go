@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// deadLetterStatusEvent keeps a status event that was rejected as a dead letter, for the
+// operators to find out why and requeue it. Only the events rejected as invalid for their job
+// are kept: the events of a job that no longer exists and the failures of the service, which
+// the sidecar retries, are not. The body is redacted with the redaction policy of the tenant,
+// and is not kept when the policy can not be applied.
+func (h *Handlers) deadLetterStatusEvent(ctx *executioncontext.ExecutionContext, evaluationJobID string, body []byte, rejection error) {
+	var se *serviceerrors.ServiceError
+	if len(body) == 0 || !errors.As(rejection, &se) {
+		return
+	}
+	status := se.MessageCode().GetStatusCode()
+	if status < http.StatusBadRequest || status >= http.StatusInternalServerError || se.MessageCode() == messages.ResourceNotFound {
+		return
+	}
+	storage := h.getStorage(ctx).WithContext(context.WithoutCancel(ctx.Ctx))
+	text := string(body)
+	redactor, err := loadRedactor(storage)
+	if err != nil {
+		ctx.Logger.Warn("Failed to redact the rejected status event, it is not kept", "job_id", evaluationJobID, "error", err)
+		return
+	}
+	if redactor != nil {
+		text, _ = redactor.Redact(text)
+	}
+	letter := &api.StatusDeadLetter{
+		ID:         common.GUID(),
+		Tenant:     ctx.Tenant,
+		Owner:      ctx.User,
+		JobID:      evaluationJobID,
+		ReceivedAt: time.Now().UTC(),
+		ErrorCode:  se.MessageCode().GetErrorCode(),
+		Reason:     messages.GetErrorMessage(se.MessageCode(), se.MessageParams()...),
+		Body:       text,
+	}
+	if err := storage.AddStatusDeadLetter(letter); err != nil {
+		ctx.Logger.Error("Failed to keep the rejected status event", "job_id", evaluationJobID, "error", err)
+		return
+	}
+	ctx.Logger.Info("Rejected status event kept as a dead letter", "job_id", evaluationJobID, "dead_letter_id", letter.ID, "error_code", letter.ErrorCode)
+}
+
+// HandleListDeadLetters handles GET /api/v1/admin/dead-letters, the status events that were
+// rejected, of all tenants, the newest first. They can be filtered by tenant and job_id.
+func (h *Handlers) HandleListDeadLetters(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	filter, err := CommonListFilters(req, "tenant", "job_id")
+	logging.LogRequestStarted(ctx, "filter", filter)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	allowedParams := []string{"limit", "offset", "tenant", "job_id"}
+	if badParams := getAllParams(req, allowedParams...); len(badParams) > 0 {
+		// just report the first bad parameter
+		w.Error(serviceerrors.NewServiceError(messages.QueryBadParameter, "ParameterName", badParams[0], "AllowedParameters", strings.Join(allowedParams, ", ")), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			letters, err := h.storage.WithLogger(ctx.Logger).WithContext(runtimeCtx).GetStatusDeadLetters(filter)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			page, err := CreatePage(ctx, letters.TotalCount, filter.Offset, filter.Limit, req)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			result := api.StatusDeadLetterList{
+				Page:  *page,
+				Items: letters.Items,
+			}
+			w.WriteJSON(result, 200, "count", strconv.Itoa(len(letters.Items)), "total_count", strconv.Itoa(letters.TotalCount))
+			return nil
+		},
+		"storage",
+		"list-dead-letters",
+	)
+}
+
+// HandleRequeueDeadLetter handles POST /api/v1/admin/dead-letters/{id}/requeue. It applies the
+// status event again, as sent by the user of the tenant that sent it, e.g. once the cause of
+// the rejection is fixed. The dead letter is deleted when the event is applied, and kept when
+// it is rejected again, with the error of the new rejection returned.
+func (h *Handlers) HandleRequeueDeadLetter(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	logging.LogRequestStarted(ctx)
+
+	id := req.PathValue(constants.PATH_PARAMETER_DEAD_LETTER_ID)
+	if id == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_DEAD_LETTER_ID), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			storage := h.storage.WithLogger(ctx.Logger).WithContext(runtimeCtx)
+			letter, err := storage.GetStatusDeadLetter(id)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			senderCtx := ctx.WithContext(runtimeCtx)
+			senderCtx.Tenant = letter.Tenant
+			senderCtx.User = letter.Owner
+			status, err := h.parseStatusEvent(senderCtx, []byte(letter.Body))
+			if err == nil {
+				err = h.applyStatusEvent(senderCtx, runtimeCtx, h.getStorage(senderCtx), letter.JobID, status)
+			}
+			if err != nil {
+				ctx.Logger.Info("Requeued status event rejected again", "dead_letter_id", id, "job_id", letter.JobID, "error", err)
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if err := storage.DeleteStatusDeadLetter(id); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			ctx.Logger.Info("Requeued status event applied", "dead_letter_id", id, "job_id", letter.JobID)
+			w.WriteJSON(nil, 204)
+			return nil
+		},
+		"storage",
+		"requeue-dead-letter",
+		"dead_letter.id", id,
+	)
+}
+
+// HandleDeleteDeadLetter handles DELETE /api/v1/admin/dead-letters/{id}, it discards a status
+// event that was rejected.
+func (h *Handlers) HandleDeleteDeadLetter(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	logging.LogRequestStarted(ctx)
+
+	id := req.PathValue(constants.PATH_PARAMETER_DEAD_LETTER_ID)
+	if id == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_DEAD_LETTER_ID), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			if err := h.storage.WithLogger(ctx.Logger).WithContext(runtimeCtx).DeleteStatusDeadLetter(id); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(nil, 204)
+			return nil
+		},
+		"storage",
+		"delete-dead-letter",
+		"dead_letter.id", id,
+	)
+}
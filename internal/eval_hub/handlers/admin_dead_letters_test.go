@@ -0,0 +1,123 @@
+package handlers_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// deadLetterStorage rejects the status events until accept is set, and keeps the dead letters.
+type deadLetterStorage struct {
+	*fakeStorage
+	accept  bool
+	updated int
+	letters map[string]*api.StatusDeadLetter
+}
+
+func (s *deadLetterStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *deadLetterStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *deadLetterStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *deadLetterStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *deadLetterStorage) UpdateEvaluationJob(id string, _ *api.StatusEvent) (*abstractions.EvaluationJobUpdate, error) {
+	if !s.accept {
+		return nil, serviceerrors.NewServiceError(messages.JobCanNotBeUpdated, "Id", id, "NewStatus", "updated", "Status", "cancelled")
+	}
+	s.updated++
+	return &abstractions.EvaluationJobUpdate{}, nil
+}
+
+func (s *deadLetterStorage) AddStatusDeadLetter(letter *api.StatusDeadLetter) error {
+	s.letters[letter.ID] = letter
+	return nil
+}
+
+func (s *deadLetterStorage) GetStatusDeadLetter(id string) (*api.StatusDeadLetter, error) {
+	letter, ok := s.letters[id]
+	if !ok {
+		return nil, serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "status dead letter", "ResourceId", id)
+	}
+	return letter, nil
+}
+
+func (s *deadLetterStorage) DeleteStatusDeadLetter(id string) error {
+	if _, ok := s.letters[id]; !ok {
+		return serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "status dead letter", "ResourceId", id)
+	}
+	delete(s.letters, id)
+	return nil
+}
+
+func TestStatusDeadLetters(t *testing.T) {
+	storage := &deadLetterStorage{
+		fakeStorage: &fakeStorage{job: &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1"}},
+			Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		}},
+		letters: map[string]*api.StatusDeadLetter{},
+	}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	newRequest := func(method, path, body string, pathValues map[string]string) *updateEvaluationRequest {
+		return &updateEvaluationRequest{
+			bodyRequest: &bodyRequest{MockRequest: createMockRequest(method, path), body: []byte(body)},
+			pathValues:  pathValues,
+		}
+	}
+
+	body := `{"benchmark_status_event":{"provider_id":"p1","id":"b1","status":"completed"}}`
+	recorder := httptest.NewRecorder()
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-event", logger, "adapter", "team-a")
+	h.HandleUpdateEvaluation(ctx, newRequest("POST", "/api/v1/evaluations/jobs/job-1/events", body, map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-1"}), MockResponseWrapper{recorder: recorder})
+	if recorder.Code != 409 {
+		t.Fatalf("expected status 409, got %d body %s", recorder.Code, recorder.Body.String())
+	}
+	if len(storage.letters) != 1 {
+		t.Fatalf("expected the rejected event to be kept, got %d dead letters", len(storage.letters))
+	}
+	var letter *api.StatusDeadLetter
+	for _, kept := range storage.letters {
+		letter = kept
+	}
+	if letter.Tenant != "team-a" || letter.Owner != "adapter" || letter.JobID != "job-1" || letter.ErrorCode != messages.JobCanNotBeUpdated.GetErrorCode() || letter.Body != body {
+		t.Errorf("unexpected dead letter %+v", letter)
+	}
+
+	requeue := func() *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		ctx := executioncontext.NewExecutionContext(context.Background(), "req-requeue", logger, "operator", "ops")
+		path := "/api/v1/admin/dead-letters/" + letter.ID + "/requeue"
+		h.HandleRequeueDeadLetter(ctx, newRequest("POST", path, "", map[string]string{constants.PATH_PARAMETER_DEAD_LETTER_ID: letter.ID}), MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	// rejected again, the dead letter is kept
+	if recorder := requeue(); recorder.Code != 409 {
+		t.Fatalf("expected status 409, got %d body %s", recorder.Code, recorder.Body.String())
+	}
+	if len(storage.letters) != 1 {
+		t.Fatalf("expected the dead letter to be kept, got %d dead letters", len(storage.letters))
+	}
+
+	storage.accept = true
+	if recorder := requeue(); recorder.Code != 204 {
+		t.Fatalf("expected status 204, got %d body %s", recorder.Code, recorder.Body.String())
+	}
+	if storage.updated != 1 || len(storage.letters) != 0 {
+		t.Errorf("expected the event to be applied and the dead letter deleted, got %d updates and %d dead letters", storage.updated, len(storage.letters))
+	}
+	if recorder := requeue(); recorder.Code != 404 {
+		t.Errorf("expected status 404 for a requeued dead letter, got %d", recorder.Code)
+	}
+}
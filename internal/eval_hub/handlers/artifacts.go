@@ -0,0 +1,285 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/mlflow"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/redaction"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// artifactNamePattern is the pattern of the names of the artifacts, which are file names in
+// the artifact store: no path separators, and no name made of dots only.
+var artifactNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-][A-Za-z0-9._-]{0,254}$`)
+
+// HandleUploadEvaluationBenchmarkArtifact handles PUT
+// /api/v1/evaluations/jobs/{id}/benchmarks/{benchmark_index}/artifacts/{name}. Adapters
+// upload the intermediate artifacts of a benchmark while it runs; the body is streamed to
+// the artifact store of the job experiment and the metadata of the artifact is stored at
+// once, so that the artifacts can be listed before the job completes. Uploading an artifact
+// again replaces it.
+func (h *Handlers) HandleUploadEvaluationBenchmarkArtifact(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID, benchmarkIndex, ok := benchmarkPathParameters(ctx, req, w)
+	if !ok {
+		return
+	}
+	name := req.PathValue(constants.PATH_PARAMETER_ARTIFACT_NAME)
+	if name == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_ARTIFACT_NAME), ctx.RequestID)
+		return
+	}
+	if !artifactNamePattern.MatchString(name) {
+		w.Error(serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", constants.PATH_PARAMETER_ARTIFACT_NAME, "Type", "file name of letters, digits, '.', '_' and '-'", "Value", name), ctx.RequestID)
+		return
+	}
+
+	// only the pods of the job hold its callback token
+	if h.serviceConfig != nil && !callbackauth.Verify(h.serviceConfig.CallbackAuth, evaluationJobID, req.Header(callbackauth.TokenHeader)) {
+		ctx.Logger.Warn("Rejected evaluation job artifact without a valid callback token", "job_id", evaluationJobID)
+		w.Error(serviceerrors.NewServiceError(messages.CallbackTokenInvalid, "EvaluationJobID", evaluationJobID), ctx.RequestID)
+		return
+	}
+
+	stream, ok := req.(http_wrappers.StreamingRequestWrapper)
+	if !ok {
+		w.ErrorWithMessageCode(ctx.RequestID, messages.NotImplemented, "Api", req.URI())
+		return
+	}
+
+	contentType := req.Header("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			job, err := scoped.GetEvaluationJob(evaluationJobID)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if err := h.checkBenchmarkIndex(scoped, job, benchmarkIndex); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if h.mlflowClient == nil || job.Resource.MLFlowExperimentID == "" {
+				reason := "the job has no MLflow experiment"
+				if h.mlflowClient == nil {
+					reason = "MLflow is not configured"
+				}
+				err := serviceerrors.NewServiceError(messages.ArtifactStoreUnavailable, "EvaluationJobID", evaluationJobID, "Reason", reason)
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			client := h.mlflowClient.WithContext(runtimeCtx).WithLogger(ctx.Logger)
+			if workspace := job.MLFlowWorkspace(); workspace != "" {
+				client = client.WithWorkspace(workspace)
+			}
+			artifactLocation := ""
+			if job.Experiment != nil {
+				artifactLocation = job.Experiment.ArtifactLocation
+			}
+
+			// text artifacts, e.g. the prompts and responses of the samples, are redacted as
+			// they are streamed, so the size and digest are those of the redacted content
+			var content io.Reader = stream.Body()
+			var redacted *redaction.Reader
+			if redaction.IsText(contentType) {
+				redactor, err := loadRedactor(scoped.WithTenant(job.Resource.Tenant))
+				if err != nil {
+					w.Error(err, ctx.RequestID)
+					return err
+				}
+				if redactor != nil {
+					redacted = redactor.NewReader(content)
+					content = redacted
+				}
+			}
+
+			body := &artifactReader{body: content, hash: sha256.New()}
+			uri, err := mlflow.UploadJobArtifact(client, job.Resource.MLFlowExperimentID, evaluationJobID, benchmarkIndex, name, artifactLocation, body, contentType)
+			if body.err != nil {
+				// the upload failed because the body could not be read, e.g. it is too large
+				w.Error(body.err, ctx.RequestID)
+				return body.err
+			}
+			if err != nil {
+				ctx.Logger.Error("Failed to upload evaluation job artifact", "error", err, "job_id", evaluationJobID, "benchmark_index", benchmarkIndex, "name", name)
+				err := serviceerrors.NewServiceError(messages.MLFlowRequestFailed, "Error", err.Error())
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			artifact := &api.ArtifactResource{
+				JobID:          evaluationJobID,
+				BenchmarkIndex: benchmarkIndex,
+				Name:           name,
+				ContentType:    contentType,
+				Size:           body.size,
+				Digest:         "sha256:" + hex.EncodeToString(body.hash.Sum(nil)),
+				URI:            uri,
+				UploadedAt:     time.Now().UTC(),
+			}
+			if err := scoped.PutEvaluationJobArtifact(evaluationJobID, artifact); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if redacted != nil && redacted.Counts.Total() > 0 {
+				record := &api.RedactionRecord{
+					JobID:          evaluationJobID,
+					BenchmarkIndex: benchmarkIndex,
+					Target:         api.RedactionTargetArtifact,
+					Artifact:       name,
+					Redactions:     redacted.Counts,
+					RedactedAt:     artifact.UploadedAt,
+				}
+				if err := scoped.AddEvaluationJobRedaction(evaluationJobID, record); err != nil {
+					w.Error(err, ctx.RequestID)
+					return err
+				}
+			}
+			ctx.Logger.Info("Uploaded evaluation job artifact", "job_id", evaluationJobID, "benchmark_index", benchmarkIndex, "name", name, "size", body.size)
+			w.WriteJSON(artifact, 201)
+			return nil
+		},
+		"mlflow",
+		"upload-evaluation-artifact",
+		"job.id", evaluationJobID,
+		"benchmark.index", strconv.Itoa(benchmarkIndex),
+	)
+}
+
+// HandleListEvaluationBenchmarkArtifacts handles GET
+// /api/v1/evaluations/jobs/{id}/benchmarks/{benchmark_index}/artifacts, the artifacts that
+// the adapter of the benchmark uploaded so far.
+func (h *Handlers) HandleListEvaluationBenchmarkArtifacts(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	evaluationJobID, benchmarkIndex, ok := benchmarkPathParameters(ctx, req, w)
+	if !ok {
+		return
+	}
+
+	filter, err := CommonListFilters(req)
+	logging.LogRequestStarted(ctx, "filter", filter)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	allowedParams := []string{"limit", "offset"}
+	if badParams := getAllParams(req, allowedParams...); len(badParams) > 0 {
+		// just report the first bad parameter
+		w.Error(serviceerrors.NewServiceError(messages.QueryBadParameter, "ParameterName", badParams[0], "AllowedParameters", strings.Join(allowedParams, ", ")), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			if _, err := h.getAccessibleEvaluationJob(ctx, scoped, evaluationJobID, jobAccessRead); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			artifacts, err := scoped.GetEvaluationJobArtifacts(evaluationJobID, benchmarkIndex, filter)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			page, err := CreatePage(ctx, artifacts.TotalCount, filter.Offset, filter.Limit, req)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			result := api.ArtifactResourceList{
+				Page:  *page,
+				Items: artifacts.Items,
+			}
+			w.WriteJSON(result, 200, "count", strconv.Itoa(len(artifacts.Items)), "total_count", strconv.Itoa(artifacts.TotalCount))
+			return nil
+		},
+		"storage",
+		"list-evaluation-artifacts",
+		"job.id", evaluationJobID,
+		"benchmark.index", strconv.Itoa(benchmarkIndex),
+	)
+}
+
+// benchmarkPathParameters returns the job ID and the benchmark index of the path, or writes
+// the error and returns false when they are missing or invalid.
+func benchmarkPathParameters(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) (string, int, bool) {
+	evaluationJobID := req.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return "", 0, false
+	}
+	rawIndex := req.PathValue(constants.PATH_PARAMETER_BENCHMARK_INDEX)
+	if rawIndex == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_BENCHMARK_INDEX), ctx.RequestID)
+		return "", 0, false
+	}
+	benchmarkIndex, err := strconv.Atoi(rawIndex)
+	if err != nil || benchmarkIndex < 0 {
+		w.Error(serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", constants.PATH_PARAMETER_BENCHMARK_INDEX, "Type", "non-negative integer", "Value", rawIndex), ctx.RequestID)
+		return "", 0, false
+	}
+	return evaluationJobID, benchmarkIndex, true
+}
+
+// checkBenchmarkIndex returns an error when the job has no benchmark at the index.
+func (h *Handlers) checkBenchmarkIndex(storage interface {
+	GetCollection(id string) (*api.CollectionResource, error)
+}, job *api.EvaluationJobResource, benchmarkIndex int) error {
+	benchmarks, err := h.resolveJobBenchmarks(storage, job)
+	if err != nil {
+		return err
+	}
+	if benchmarkIndex >= len(benchmarks) {
+		return serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", constants.PATH_PARAMETER_BENCHMARK_INDEX, "Type", fmt.Sprintf("integer between 0 and %d", len(benchmarks)-1), "Value", strconv.Itoa(benchmarkIndex))
+	}
+	return nil
+}
+
+// artifactReader hashes and counts the content of an artifact as it is streamed to the
+// artifact store, and keeps the error of the request body apart from the errors of the
+// store.
+type artifactReader struct {
+	body io.Reader
+	hash hash.Hash
+	size int64
+	err  error
+}
+
+func (r *artifactReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	r.hash.Write(p[:n])
+	r.size += int64(n)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
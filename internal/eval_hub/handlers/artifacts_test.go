@@ -0,0 +1,199 @@
+package handlers_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/eval-hub/eval-hub/pkg/mlflowclient"
+)
+
+// artifactsTestStorage keeps the artifacts it is given in memory.
+type artifactsTestStorage struct {
+	*baselineTestStorage
+	artifacts []api.ArtifactResource
+}
+
+func (s *artifactsTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *artifactsTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *artifactsTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *artifactsTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *artifactsTestStorage) PutEvaluationJobArtifact(_ string, artifact *api.ArtifactResource) error {
+	s.artifacts = append(s.artifacts, *artifact)
+	return nil
+}
+
+func (s *artifactsTestStorage) GetEvaluationJobArtifacts(id string, benchmarkIndex int, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.ArtifactResource], error) {
+	items := []api.ArtifactResource{}
+	for _, artifact := range s.artifacts {
+		if artifact.JobID == id && artifact.BenchmarkIndex == benchmarkIndex {
+			items = append(items, artifact)
+		}
+	}
+	return &abstractions.QueryResults[api.ArtifactResource]{Items: items, TotalCount: len(items)}, nil
+}
+
+// artifactRequest is a request whose body is streamed.
+type artifactRequest struct {
+	*baselineRequest
+	content io.Reader
+}
+
+func (r *artifactRequest) Body() io.Reader {
+	return r.content
+}
+
+// tooLargeReader fails as the body of a request larger than its limit.
+type tooLargeReader struct{}
+
+func (tooLargeReader) Read(_ []byte) (int, error) {
+	return 0, serviceerrors.NewServiceError(messages.RequestBodyTooLarge, "Limit", 16)
+}
+
+func TestHandleUploadEvaluationBenchmarkArtifact(t *testing.T) {
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-artifacts", logging.FallbackLogger(), "test-user", "test-tenant")
+
+	var uploadedPath, uploadedContent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/mlflow-artifacts/artifacts/") {
+			content, _ := io.ReadAll(r.Body)
+			uploadedPath, uploadedContent = r.URL.Path, string(content)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	storage := &artifactsTestStorage{baselineTestStorage: newBaselineTestStorage()}
+	storage.jobs["job-artifacts"] = &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-artifacts"}, MLFlowExperimentID: "8"},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+		},
+	}
+	storage.jobs["job-no-experiment"] = &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-no-experiment"}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+		},
+	}
+	callbackAuth := &config.CallbackAuthConfig{Enabled: true, Secret: "test-secret"}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, mlflowclient.NewClient(srv.URL), &config.Config{CallbackAuth: callbackAuth}, nil)
+
+	upload := func(jobID string, index string, name string, content io.Reader, token string) *httptest.ResponseRecorder {
+		request := &artifactRequest{
+			baselineRequest: &baselineRequest{
+				MockRequest: createMockRequest("PUT", "/api/v1/evaluations/jobs/"+jobID+"/benchmarks/"+index+"/artifacts/"+name),
+				path: map[string]string{
+					constants.PATH_PARAMETER_JOB_ID:          jobID,
+					constants.PATH_PARAMETER_BENCHMARK_INDEX: index,
+					constants.PATH_PARAMETER_ARTIFACT_NAME:   name,
+				},
+			},
+			content: content,
+		}
+		request.SetHeader("Content-Type", "application/jsonl")
+		if token != "" {
+			request.SetHeader(callbackauth.TokenHeader, token)
+		}
+		recorder := httptest.NewRecorder()
+		h.HandleUploadEvaluationBenchmarkArtifact(ctx, request, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	t.Run("the artifact is streamed to the experiment and its metadata stored", func(t *testing.T) {
+		content := `{"doc_id":0,"pred":"B"}` + "\n"
+		recorder := upload("job-artifacts", "0", "predictions.jsonl", strings.NewReader(content), callbackauth.Token(callbackAuth, "job-artifacts"))
+		if recorder.Code != 201 {
+			t.Fatalf("expected status 201, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if want := "8/eval-hub/jobs/job-artifacts/benchmarks/0/predictions.jsonl"; !strings.HasSuffix(uploadedPath, want) || uploadedContent != content {
+			t.Errorf("expected the content uploaded to %s, got %q at %s", want, uploadedContent, uploadedPath)
+		}
+		artifact := api.ArtifactResource{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &artifact); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		digest := sha256.Sum256([]byte(content))
+		if artifact.Size != int64(len(content)) || artifact.Digest != "sha256:"+hex.EncodeToString(digest[:]) || artifact.ContentType != "application/jsonl" || artifact.URI == "" {
+			t.Errorf("unexpected artifact %+v", artifact)
+		}
+		if len(storage.artifacts) != 1 || storage.artifacts[0].Name != "predictions.jsonl" {
+			t.Errorf("expected the artifact to be stored, got %+v", storage.artifacts)
+		}
+	})
+
+	t.Run("rejected uploads", func(t *testing.T) {
+		token := callbackauth.Token(callbackAuth, "job-artifacts")
+		for _, tc := range []struct {
+			name    string
+			jobID   string
+			index   string
+			file    string
+			content io.Reader
+			token   string
+			code    int
+		}{
+			{name: "no callback token", jobID: "job-artifacts", index: "0", file: "a.json", token: "", code: 401},
+			{name: "name with dots only", jobID: "job-artifacts", index: "0", file: "..", token: token, code: 400},
+			{name: "benchmark out of range", jobID: "job-artifacts", index: "1", file: "a.json", token: token, code: 400},
+			{name: "job without experiment", jobID: "job-no-experiment", index: "0", file: "a.json", token: callbackauth.Token(callbackAuth, "job-no-experiment"), code: 400},
+			{name: "body too large", jobID: "job-artifacts", index: "0", file: "a.json", content: tooLargeReader{}, token: token, code: 413},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				content := tc.content
+				if content == nil {
+					content = strings.NewReader("{}")
+				}
+				if recorder := upload(tc.jobID, tc.index, tc.file, content, tc.token); recorder.Code != tc.code {
+					t.Errorf("expected status %d, got %d: %s", tc.code, recorder.Code, recorder.Body.String())
+				}
+			})
+		}
+		if len(storage.artifacts) != 1 {
+			t.Errorf("expected no artifact to be stored for the rejected uploads, got %+v", storage.artifacts)
+		}
+	})
+
+	t.Run("the artifacts of a benchmark are listed", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		h.HandleListEvaluationBenchmarkArtifacts(ctx, &baselineRequest{
+			MockRequest: createMockRequest("GET", "/api/v1/evaluations/jobs/job-artifacts/benchmarks/0/artifacts"),
+			path: map[string]string{
+				constants.PATH_PARAMETER_JOB_ID:          "job-artifacts",
+				constants.PATH_PARAMETER_BENCHMARK_INDEX: "0",
+			},
+		}, MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		artifacts := api.ArtifactResourceList{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &artifacts); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if artifacts.TotalCount != 1 || len(artifacts.Items) != 1 || artifacts.Items[0].Name != "predictions.jsonl" {
+			t.Errorf("expected the uploaded artifact, got %+v", artifacts)
+		}
+	})
+}
@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serialization"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// HandleListBaselines handles GET /api/v1/evaluations/baselines
+func (h *Handlers) HandleListBaselines(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	var ofilter *abstractions.QueryFilter
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			filter, err := CommonListFilters(req)
+
+			logging.LogRequestStarted(ctx, "filter", filter)
+
+			if err != nil {
+				return err
+			}
+
+			allowedParams := []string{"limit", "offset", "name", "owner"}
+			badParams := getAllParams(req, allowedParams...)
+			if len(badParams) > 0 {
+				// just report the first bad parameter
+				return serviceerrors.NewServiceError(messages.QueryBadParameter, "ParameterName", badParams[0], "AllowedParameters", strings.Join(allowedParams, ", "))
+			}
+
+			ofilter = filter
+			return nil
+		},
+		"validation",
+		"validate-baselines-filter",
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			baselines, err := storage.WithContext(runtimeCtx).GetBaselines(ofilter)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			page, err := CreatePage(ctx, baselines.TotalCount, ofilter.Offset, ofilter.Limit, req)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			result := api.BaselineResourceList{
+				Page:  *page,
+				Items: baselines.Items,
+			}
+			w.WriteJSON(result, 200, "count", strconv.Itoa(len(baselines.Items)), "total_count", strconv.Itoa(baselines.TotalCount))
+			return nil
+		},
+		"storage",
+		"list-baselines",
+	)
+}
+
+// HandleCreateBaseline handles POST /api/v1/evaluations/baselines
+func (h *Handlers) HandleCreateBaseline(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	config := &api.BaselineConfig{}
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			// get the body bytes from the context
+			bodyBytes, err := req.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			return serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, config)
+		},
+		"validation",
+		"validate-baseline",
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			job, err := h.getAccessibleEvaluationJob(ctx, scoped, config.JobID, jobAccessRead)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if err := validateBaselineJob(job); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			baseline := api.NewBaselineResource(api.Resource{
+				ID:        config.Name,
+				CreatedAt: time.Now(),
+				Owner:     ctx.User,
+				Tenant:    ctx.Tenant,
+			}, *config, job)
+			if err := scoped.CreateBaseline(baseline); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(baseline, 201)
+			return nil
+		},
+		"storage",
+		"create-baseline",
+		"baseline.name", config.Name,
+		"job.id", config.JobID,
+	)
+}
+
+// validateBaselineJob checks that a job can be registered as a baseline: it must have
+// completed with a test score to compare later jobs with.
+func validateBaselineJob(job *api.EvaluationJobResource) error {
+	if job.Status == nil || job.Status.State != api.OverallStateCompleted {
+		return serviceerrors.NewServiceError(messages.InvalidBaselineJob, "JobID", job.Resource.ID, "Reason", "it is not completed")
+	}
+	if job.Results == nil || job.Results.Test == nil {
+		return serviceerrors.NewServiceError(messages.InvalidBaselineJob, "JobID", job.Resource.ID, "Reason", "it has no test score")
+	}
+	return nil
+}
+
+// HandleGetBaseline handles GET /api/v1/evaluations/baselines/{baseline_name}
+func (h *Handlers) HandleGetBaseline(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	name := req.PathValue(constants.PATH_PARAMETER_BASELINE_NAME)
+	if name == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_BASELINE_NAME), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			baseline, err := storage.WithContext(runtimeCtx).GetBaseline(name)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(baseline, 200)
+			return nil
+		},
+		"storage",
+		"get-baseline",
+		"baseline.name", name,
+	)
+}
+
+// HandleDeleteBaseline handles DELETE /api/v1/evaluations/baselines/{baseline_name}
+func (h *Handlers) HandleDeleteBaseline(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	name := req.PathValue(constants.PATH_PARAMETER_BASELINE_NAME)
+	if name == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_BASELINE_NAME), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			if err := storage.WithContext(runtimeCtx).DeleteBaseline(name); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(nil, 204)
+			return nil
+		},
+		"storage",
+		"delete-baseline",
+		"baseline.name", name,
+	)
+}
+
+// HandleGetEvaluationComparison handles GET /api/v1/evaluations/jobs/{job_id}/comparison?baseline={name}
+func (h *Handlers) HandleGetEvaluationComparison(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID := req.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+	name, err := GetParam(req, "baseline", false, "")
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			job, err := h.getAccessibleEvaluationJob(ctx, scoped, evaluationJobID, jobAccessRead)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			baseline, err := scoped.GetBaseline(name)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(api.NewBaselineComparison(job, baseline), 200)
+			return nil
+		},
+		"storage",
+		"get-evaluation-comparison",
+		"job.id", evaluationJobID,
+		"baseline.name", name,
+	)
+}
+
+// checkPassCriteriaBaselines returns an error when a baseline that the pass criteria of a
+// job, its collection or its benchmarks must not regress from does not exist.
+func checkPassCriteriaBaselines(storage abstractions.Storage, evaluation *api.EvaluationJobConfig, collection *api.CollectionResource, benchmarks []api.EvaluationBenchmarkConfig) error {
+	criteria := []*api.PassCriteria{evaluation.PassCriteria}
+	if collection != nil {
+		criteria = append(criteria, collection.PassCriteria)
+	}
+	for _, benchmark := range benchmarks {
+		criteria = append(criteria, benchmark.PassCriteria)
+	}
+	checked := make(map[string]bool)
+	for _, c := range criteria {
+		if c == nil || c.MustNotRegress == "" || checked[c.MustNotRegress] {
+			continue
+		}
+		checked[c.MustNotRegress] = true
+		if _, err := storage.GetBaseline(c.MustNotRegress); err != nil {
+			var se *serviceerrors.ServiceError
+			if errors.As(err, &se) && se.MessageCode() == messages.ResourceNotFound {
+				return serviceerrors.NewServiceError(messages.ResourceDoesNotExist, "Type", "baseline", "ResourceID", c.MustNotRegress)
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,221 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// baselineTestStorage keeps the jobs and baselines in memory.
+type baselineTestStorage struct {
+	abstractions.Storage
+	jobs      map[string]*api.EvaluationJobResource
+	baselines map[string]*api.BaselineResource
+}
+
+func (s *baselineTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *baselineTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *baselineTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *baselineTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *baselineTestStorage) GetTagPolicy() (*api.TagPolicyResource, error) {
+	return nil, nil
+}
+
+func (s *baselineTestStorage) GetRedactionPolicy() (*api.RedactionPolicyResource, error) {
+	return nil, nil
+}
+
+func (s *baselineTestStorage) GetProvider(id string) (*api.ProviderResource, error) {
+	return &api.ProviderResource{
+		Resource:       api.Resource{ID: id},
+		ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "arc_easy"}}},
+	}, nil
+}
+
+func (s *baselineTestStorage) GetEvaluationJob(id string) (*api.EvaluationJobResource, error) {
+	if job, ok := s.jobs[id]; ok {
+		return job, nil
+	}
+	return nil, serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "evaluation job", "ResourceId", id)
+}
+
+func (s *baselineTestStorage) CreateBaseline(baseline *api.BaselineResource) error {
+	s.baselines[baseline.Resource.ID] = baseline
+	return nil
+}
+
+func (s *baselineTestStorage) GetBaseline(name string) (*api.BaselineResource, error) {
+	if baseline, ok := s.baselines[name]; ok {
+		return baseline, nil
+	}
+	return nil, serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "baseline", "ResourceId", name)
+}
+
+type baselineRequest struct {
+	*MockRequest
+	body  []byte
+	path  map[string]string
+	query map[string][]string
+}
+
+func (r *baselineRequest) BodyAsBytes() ([]byte, error) {
+	return r.body, nil
+}
+
+func (r *baselineRequest) PathValue(name string) string {
+	return r.path[name]
+}
+
+func (r *baselineRequest) Query(key string) []string {
+	return r.query[key]
+}
+
+func newBaselineTestStorage() *baselineTestStorage {
+	score := float32(0.75)
+	return &baselineTestStorage{
+		jobs: map[string]*api.EvaluationJobResource{
+			"job-completed": {
+				Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-completed"}},
+				Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateCompleted}},
+				Results: &api.EvaluationJobResults{
+					Test: &api.EvaluationTest{Score: score},
+					Benchmarks: []api.BenchmarkResult{
+						{ID: "arc_easy", ProviderID: "lm_evaluation_harness", Test: &api.BenchmarkTest{PrimaryScoreMetric: "acc", PrimaryScore: 0.75}},
+					},
+				},
+				EvaluationJobConfig: api.EvaluationJobConfig{
+					Model: api.ModelRef{Name: "granite", URL: "http://granite:8000", Auth: &api.ModelAuth{SecretRef: "granite-token"}},
+				},
+			},
+			"job-running": {
+				Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-running"}},
+				Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+			},
+		},
+		baselines: map[string]*api.BaselineResource{},
+	}
+}
+
+func TestHandleCreateBaseline(t *testing.T) {
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-baseline", logging.FallbackLogger(), "test-user", "test-tenant")
+
+	create := func(t *testing.T, storage *baselineTestStorage, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+		recorder := httptest.NewRecorder()
+		h.HandleCreateBaseline(ctx, &baselineRequest{
+			MockRequest: createMockRequest("POST", "/api/v1/evaluations/baselines"),
+			body:        []byte(body),
+		}, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	t.Run("a completed job is registered with its model and scores", func(t *testing.T) {
+		storage := newBaselineTestStorage()
+		recorder := create(t, storage, `{"name": "prod-v3", "job_id": "job-completed"}`)
+		if recorder.Code != 201 {
+			t.Fatalf("expected status 201, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		baseline := storage.baselines["prod-v3"]
+		if baseline == nil || baseline.Resource.Owner != "test-user" || baseline.Model.Name != "granite" || baseline.Score != 0.75 {
+			t.Fatalf("unexpected baseline %+v", baseline)
+		}
+		if baseline.Model.Auth != nil {
+			t.Errorf("expected the model auth not to be copied, got %+v", baseline.Model.Auth)
+		}
+		if benchmark := baseline.GetBenchmark("lm_evaluation_harness", "arc_easy"); benchmark == nil || benchmark.PrimaryScore != 0.75 {
+			t.Errorf("unexpected benchmark baseline %+v", baseline.Benchmarks)
+		}
+	})
+
+	t.Run("a job that is not completed is rejected", func(t *testing.T) {
+		recorder := create(t, newBaselineTestStorage(), `{"name": "prod-v3", "job_id": "job-running"}`)
+		if recorder.Code != 400 || !strings.Contains(recorder.Body.String(), "invalid_baseline_job") {
+			t.Fatalf("expected invalid_baseline_job, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("a name that is not a DNS label is rejected", func(t *testing.T) {
+		recorder := create(t, newBaselineTestStorage(), `{"name": "Prod V3", "job_id": "job-completed"}`)
+		if recorder.Code != 400 {
+			t.Fatalf("expected status 400, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+}
+
+func TestHandleGetEvaluationComparison(t *testing.T) {
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-comparison", logging.FallbackLogger(), "test-user", "test-tenant")
+	storage := newBaselineTestStorage()
+	storage.baselines["prod-v3"] = &api.BaselineResource{
+		BaselineConfig: api.BaselineConfig{Name: "prod-v3", JobID: "job-baseline"},
+		Score:          0.8,
+		Benchmarks: []api.BaselineBenchmark{
+			{ID: "arc_easy", ProviderID: "lm_evaluation_harness", PrimaryScoreMetric: "acc", PrimaryScore: 0.7},
+		},
+	}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+
+	compare := func(baseline string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		h.HandleGetEvaluationComparison(ctx, &baselineRequest{
+			MockRequest: createMockRequest("GET", "/api/v1/evaluations/jobs/job-completed/comparison"),
+			path:        map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-completed"},
+			query:       map[string][]string{"baseline": {baseline}},
+		}, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	recorder := compare("prod-v3")
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	comparison := api.BaselineComparison{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &comparison); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if comparison.BaselineJobID != "job-baseline" || comparison.Delta == nil || !comparison.Regressed {
+		t.Fatalf("expected the job to regress from the baseline, got %+v", comparison)
+	}
+	if len(comparison.Benchmarks) != 1 || comparison.Benchmarks[0].Delta == nil || *comparison.Benchmarks[0].Delta <= 0 {
+		t.Fatalf("expected the benchmark to improve on the baseline, got %+v", comparison.Benchmarks)
+	}
+
+	if recorder := compare("prod-v4"); recorder.Code != 404 {
+		t.Fatalf("expected status 404 for an unknown baseline, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleCreateEvaluation_MustNotRegress(t *testing.T) {
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-must-not-regress", logging.FallbackLogger(), "test-user", "test-tenant")
+	storage := newBaselineTestStorage()
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+
+	recorder := httptest.NewRecorder()
+	h.HandleCreateEvaluation(ctx, &bodyRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+		body: []byte(`{
+			"name": "candidate",
+			"model": {"url": "http://test.com", "name": "test"},
+			"pass_criteria": {"must_not_regress": "prod-v3"},
+			"benchmarks": [{"id": "arc_easy", "provider_id": "lm_evaluation_harness"}]
+		}`),
+	}, MockResponseWrapper{recorder: recorder})
+
+	if recorder.Code != 400 || !strings.Contains(recorder.Body.String(), "resource_does_not_exist") {
+		t.Fatalf("expected an unknown baseline to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
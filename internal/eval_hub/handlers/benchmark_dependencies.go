@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// startReadyBenchmarks starts the benchmarks at ready, whose dependencies all completed with
+// a status update, as reported by the storage. The job is reloaded first so that
+// the job specs of the benchmarks carry the artifacts of their dependencies. A benchmark
+// that cannot be started is marked failed, which in turn cancels its own dependents.
+func (h *Handlers) startReadyBenchmarks(
+	ctx context.Context,
+	storage abstractions.Storage,
+	runtimeStorage abstractions.RuntimeStorage,
+	jobID string,
+	ready []int,
+	logger *slog.Logger,
+) {
+	if len(ready) == 0 || h.runtime == nil {
+		return
+	}
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	job, err := storage.GetEvaluationJob(jobID)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to load the evaluation job to start dependent benchmarks", "job_id", jobID, "error", err)
+		return
+	}
+	benchmarks, err := h.resolveJobBenchmarksForStorage(storage, job)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to resolve benchmarks to start dependent benchmarks", "job_id", jobID, "error", err)
+		return
+	}
+
+	logger.InfoContext(ctx, "starting benchmarks whose dependencies completed", "job_id", jobID, "benchmark_indices", ready)
+	// like the job itself, the benchmarks run detached from the request that completed their dependencies
+	err = h.runtime.WithLogger(logger).WithContext(context.Background()).RunEvaluationBenchmarks(job, benchmarks, ready, runtimeStorage)
+	if err == nil {
+		return
+	}
+	logger.ErrorContext(ctx, "failed to start dependent benchmarks", "job_id", jobID, "benchmark_indices", ready, "error", err)
+	failBenchmarks(ctx, runtimeStorage, jobID, benchmarks, ready, err, logger)
+}
+
+// failBenchmarks marks the benchmarks at indices failed with the error that kept them from
+// being started.
+func failBenchmarks(
+	ctx context.Context,
+	runtimeStorage abstractions.RuntimeStorage,
+	jobID string,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	indices []int,
+	err error,
+	logger *slog.Logger,
+) {
+	for _, index := range indices {
+		if index < 0 || index >= len(benchmarks) {
+			continue
+		}
+		failure := &api.StatusEvent{
+			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+				ProviderID:     benchmarks[index].ProviderID,
+				ID:             benchmarks[index].ID,
+				BenchmarkIndex: index,
+				Status:         api.StateFailed,
+				ErrorMessage: api.WithMessageOrigin(&api.MessageInfo{
+					Message:     err.Error(),
+					MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_FAILED,
+				}, api.MessageOriginServer),
+			},
+		}
+		if updateErr := runtimeStorage.UpdateEvaluationJob(jobID, failure); updateErr != nil {
+			logger.ErrorContext(ctx, "failed to update benchmark status", "job_id", jobID, "benchmark_index", index, "error", updateErr)
+		}
+	}
+}
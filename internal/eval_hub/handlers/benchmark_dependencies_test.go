@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type dependencyTestRuntime struct {
+	abstractions.Runtime
+	err     error
+	job     *api.EvaluationJobResource
+	started []int
+}
+
+func (r *dependencyTestRuntime) WithLogger(_ *slog.Logger) abstractions.Runtime { return r }
+
+func (r *dependencyTestRuntime) WithContext(_ context.Context) abstractions.Runtime { return r }
+
+func (r *dependencyTestRuntime) RunEvaluationBenchmarks(job *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, benchmarkIndices []int, _ abstractions.RuntimeStorage) error {
+	r.job = job
+	r.started = append(r.started, benchmarkIndices...)
+	return r.err
+}
+
+func TestStartReadyBenchmarks(t *testing.T) {
+	job := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1"}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Benchmarks: []api.EvaluationBenchmarkConfig{
+				{Ref: api.Ref{ID: "generate"}, ProviderID: "garak"},
+				{Ref: api.Ref{ID: "judge"}, ProviderID: "garak", DependsOn: []int{0}},
+			},
+		},
+	}
+
+	t.Run("ready benchmarks are started with the stored job", func(t *testing.T) {
+		storage := &resultCacheTestStorage{job: job}
+		runtime := &dependencyTestRuntime{}
+		h := &Handlers{runtime: runtime}
+
+		h.startReadyBenchmarks(context.Background(), storage, storageRuntimeStorage{storage}, "job-1", []int{1}, nil)
+		if runtime.job != job || !slices.Equal(runtime.started, []int{1}) {
+			t.Fatalf("expected judge to be started, got %v", runtime.started)
+		}
+
+		runtime.started = nil
+		h.startReadyBenchmarks(context.Background(), storage, storageRuntimeStorage{storage}, "job-1", nil, nil)
+		if len(runtime.started) != 0 {
+			t.Fatalf("expected nothing to be started, got %v", runtime.started)
+		}
+	})
+
+	t.Run("benchmarks that cannot be started are failed", func(t *testing.T) {
+		storage := &resultCacheTestStorage{job: job}
+		h := &Handlers{runtime: &dependencyTestRuntime{err: errors.New("no capacity")}}
+
+		h.startReadyBenchmarks(context.Background(), storage, storageRuntimeStorage{storage}, "job-1", []int{1}, nil)
+		if len(storage.updates) != 1 {
+			t.Fatalf("expected one status update, got %d", len(storage.updates))
+		}
+		update := storage.updates[0]
+		if update.ID != "judge" || update.BenchmarkIndex != 1 || update.Status != api.StateFailed || update.ErrorMessage.Message != "no capacity" {
+			t.Fatalf("unexpected status update %+v", update)
+		}
+	})
+}
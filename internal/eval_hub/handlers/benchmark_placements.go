@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// finalPlacementDelay is how long after a job ended its placements are read a last time, for
+// the workloads that were still stopping when their adapter reported the end of the job.
+const finalPlacementDelay = 15 * time.Second
+
+// RefreshBenchmarkPlacements stores the placements that the runtime reads from the workloads
+// of the benchmarks of the unfinished jobs, e.g. the pod of a Kubernetes Job once it is
+// scheduled, so that the status of a job tells where each benchmark runs.
+func (h *Handlers) RefreshBenchmarkPlacements(ctx context.Context, logger *slog.Logger) {
+	reporter, ok := h.runtime.(abstractions.PlacementReporter)
+	if !ok {
+		return
+	}
+	storage := h.storage.WithLogger(logger).WithContext(ctx)
+	for _, state := range []api.OverallState{api.OverallStatePending, api.OverallStateRunning} {
+		for offset := 0; ; offset += failureDiagnosticsPageSize {
+			res, err := storage.GetEvaluationJobs(&abstractions.QueryFilter{
+				Limit:  failureDiagnosticsPageSize,
+				Offset: offset,
+				Params: map[string]any{"status": string(state)},
+			})
+			if err != nil {
+				logger.Warn("Failed to list evaluation jobs to refresh the benchmark placements", "status", state, "error", err)
+				return
+			}
+			for i := range res.Items {
+				h.refreshJobPlacements(ctx, reporter, &res.Items[i], logger)
+			}
+			if len(res.Items) < failureDiagnosticsPageSize {
+				break
+			}
+		}
+	}
+}
+
+// refreshFinalPlacements reads the placements of a job that ended once its workloads had the
+// time to stop, for their finish times.
+func (h *Handlers) refreshFinalPlacements(job *api.EvaluationJobResource, logger *slog.Logger) {
+	reporter, ok := h.runtime.(abstractions.PlacementReporter)
+	if !ok {
+		return
+	}
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	go func() {
+		time.Sleep(finalPlacementDelay)
+		h.refreshJobPlacements(context.Background(), reporter, job, logger)
+	}()
+}
+
+func (h *Handlers) refreshJobPlacements(ctx context.Context, reporter abstractions.PlacementReporter, job *api.EvaluationJobResource, logger *slog.Logger) {
+	placements, err := reporter.BenchmarkPlacements(ctx, job)
+	if err != nil {
+		// the placements read on the clusters that could be reached are still stored
+		logger.Warn("Failed to read the benchmark placements of evaluation job", "job_id", job.Resource.ID, "error", err)
+	}
+	if len(placements) == 0 {
+		return
+	}
+	storage := h.storage.WithLogger(logger).WithContext(context.Background()).WithTenant(job.Resource.Tenant).WithOwner(job.Resource.Owner)
+	// the listed job may lack the status of its benchmarks
+	jobID := job.Resource.ID
+	job, err = storage.GetEvaluationJob(jobID)
+	if err != nil {
+		logger.Warn("Failed to load evaluation job to refresh the benchmark placements", "job_id", jobID, "error", err)
+		return
+	}
+	for index, placement := range placements {
+		current := benchmarkPlacement(job, index)
+		if merged := current.Merge(placement); current != nil && *merged == *current {
+			continue
+		}
+		if err := storage.UpdateEvaluationJobBenchmarkPlacement(job.Resource.ID, index, placement); err != nil {
+			logger.Error("Failed to store the benchmark placement of evaluation job", "job_id", job.Resource.ID, "benchmark_index", index, "error", err)
+		}
+	}
+}
+
+func benchmarkPlacement(job *api.EvaluationJobResource, benchmarkIndex int) *api.BenchmarkPlacement {
+	if job.Status == nil {
+		return nil
+	}
+	for _, benchmark := range job.Status.Benchmarks {
+		if benchmark.BenchmarkIndex == benchmarkIndex {
+			return benchmark.Placement
+		}
+	}
+	return nil
+}
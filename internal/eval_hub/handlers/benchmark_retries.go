@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/jobstate"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// retryBenchmarks starts the benchmarks of retries, that failed with a transient error with
+// a status update, again, each after its backoff, as reported by the storage. The runtime
+// creates new resources for each run, so that nothing of the failed run is reused. A
+// benchmark is not started when it is no longer pending by then, e.g. as its job was
+// cancelled, and is marked failed when it cannot be started.
+func (h *Handlers) retryBenchmarks(
+	ctx context.Context,
+	storage abstractions.Storage,
+	runtimeStorage abstractions.RuntimeStorage,
+	jobID string,
+	retries []api.BenchmarkRetry,
+	logger *slog.Logger,
+) {
+	if len(retries) == 0 || h.runtime == nil {
+		return
+	}
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	// the retries run detached from the request that reported the failure
+	storage = storage.WithContext(context.Background())
+	for _, retry := range retries {
+		logger.InfoContext(ctx, "retrying benchmark after a transient failure", "job_id", jobID, "benchmark_index", retry.BenchmarkIndex, "backoff", retry.Backoff)
+		time.AfterFunc(retry.Backoff, func() {
+			h.retryBenchmark(storage, runtimeStorage, jobID, retry.BenchmarkIndex, logger)
+		})
+	}
+}
+
+func (h *Handlers) retryBenchmark(
+	storage abstractions.Storage,
+	runtimeStorage abstractions.RuntimeStorage,
+	jobID string,
+	index int,
+	logger *slog.Logger,
+) {
+	ctx := context.Background()
+	job, err := storage.GetEvaluationJob(jobID)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to load the evaluation job to retry a benchmark", "job_id", jobID, "benchmark_index", index, "error", err)
+		return
+	}
+	// a finished job does not run its benchmarks any more
+	if job.Status == nil {
+		return
+	}
+	if _, err := jobstate.Check(jobID, job.Status.State, api.OverallStateRunning); err != nil {
+		logger.InfoContext(ctx, "evaluation job finished, not retrying the benchmark", "job_id", jobID, "benchmark_index", index, "state", job.Status.State)
+		return
+	}
+	pending := false
+	for _, benchmark := range job.Status.Benchmarks {
+		if benchmark.BenchmarkIndex == index {
+			pending = benchmark.Status == api.StatePending
+			break
+		}
+	}
+	if !pending {
+		logger.InfoContext(ctx, "benchmark is no longer pending, not retrying it", "job_id", jobID, "benchmark_index", index)
+		return
+	}
+	benchmarks, err := h.resolveJobBenchmarksForStorage(storage, job)
+	if err != nil {
+		logger.WarnContext(ctx, "failed to resolve benchmarks to retry a benchmark", "job_id", jobID, "benchmark_index", index, "error", err)
+		return
+	}
+
+	err = h.runtime.WithLogger(logger).WithContext(ctx).RunEvaluationBenchmarks(job, benchmarks, []int{index}, runtimeStorage)
+	if err == nil {
+		return
+	}
+	logger.ErrorContext(ctx, "failed to retry benchmark", "job_id", jobID, "benchmark_index", index, "error", err)
+	failBenchmarks(ctx, runtimeStorage, jobID, benchmarks, []int{index}, err, logger)
+}
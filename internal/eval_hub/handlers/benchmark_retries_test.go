@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type retryTestStorage struct {
+	noopStorage
+	job     *api.EvaluationJobResource
+	updates chan *api.BenchmarkStatusEvent
+}
+
+func (s *retryTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+
+func (s *retryTestStorage) GetEvaluationJob(_ string) (*api.EvaluationJobResource, error) {
+	return s.job, nil
+}
+
+func (s *retryTestStorage) UpdateEvaluationJob(_ string, runStatus *api.StatusEvent) (*abstractions.EvaluationJobUpdate, error) {
+	s.updates <- runStatus.BenchmarkStatusEvent
+	return &abstractions.EvaluationJobUpdate{}, nil
+}
+
+type retryTestRuntime struct {
+	abstractions.Runtime
+	err     error
+	started chan []int
+}
+
+func (r *retryTestRuntime) WithLogger(_ *slog.Logger) abstractions.Runtime { return r }
+
+func (r *retryTestRuntime) WithContext(_ context.Context) abstractions.Runtime { return r }
+
+func (r *retryTestRuntime) RunEvaluationBenchmarks(_ *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, benchmarkIndices []int, _ abstractions.RuntimeStorage) error {
+	r.started <- benchmarkIndices
+	return r.err
+}
+
+func TestRetryBenchmarks(t *testing.T) {
+	retryJob := func(jobState api.OverallState, benchmarkState api.State) *api.EvaluationJobResource {
+		return &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1"}},
+			Status: &api.EvaluationJobStatus{
+				EvaluationJobState: api.EvaluationJobState{State: jobState},
+				Benchmarks: []api.BenchmarkStatus{
+					{ProviderID: "lm_evaluation_harness", ID: "mmlu", BenchmarkIndex: 0, Status: benchmarkState},
+				},
+			},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Benchmarks: []api.EvaluationBenchmarkConfig{
+					{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness", Retry: &api.RetryPolicy{MaxRetries: 2}},
+				},
+			},
+		}
+	}
+	retry := []api.BenchmarkRetry{{BenchmarkIndex: 0}}
+
+	t.Run("a pending benchmark is started again", func(t *testing.T) {
+		storage := &retryTestStorage{job: retryJob(api.OverallStateRunning, api.StatePending), updates: make(chan *api.BenchmarkStatusEvent, 1)}
+		runtime := &retryTestRuntime{started: make(chan []int, 1)}
+		h := &Handlers{runtime: runtime}
+
+		h.retryBenchmarks(context.Background(), storage, storageRuntimeStorage{storage}, "job-1", retry, nil)
+		select {
+		case started := <-runtime.started:
+			if len(started) != 1 || started[0] != 0 {
+				t.Fatalf("expected the benchmark at index 0 to be started, got %v", started)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the benchmark to be retried")
+		}
+	})
+
+	t.Run("a benchmark that cannot be started is failed", func(t *testing.T) {
+		storage := &retryTestStorage{job: retryJob(api.OverallStateRunning, api.StatePending), updates: make(chan *api.BenchmarkStatusEvent, 1)}
+		runtime := &retryTestRuntime{err: errors.New("no capacity"), started: make(chan []int, 1)}
+		h := &Handlers{runtime: runtime}
+
+		h.retryBenchmarks(context.Background(), storage, storageRuntimeStorage{storage}, "job-1", retry, nil)
+		select {
+		case update := <-storage.updates:
+			if update.BenchmarkIndex != 0 || update.Status != api.StateFailed || update.ErrorMessage.Message != "no capacity" {
+				t.Fatalf("unexpected status update %+v", update)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the benchmark to be failed")
+		}
+	})
+
+	t.Run("a cancelled job is not retried", func(t *testing.T) {
+		storage := &retryTestStorage{job: retryJob(api.OverallStateCancelled, api.StateCancelled), updates: make(chan *api.BenchmarkStatusEvent, 1)}
+		runtime := &retryTestRuntime{started: make(chan []int, 1)}
+		h := &Handlers{runtime: runtime}
+
+		h.retryBenchmark(storage, storageRuntimeStorage{storage}, "job-1", 0, slog.New(slog.DiscardHandler))
+		if len(runtime.started) != 0 {
+			t.Fatalf("expected the benchmark of a cancelled job not to be started, got %v", <-runtime.started)
+		}
+	})
+}
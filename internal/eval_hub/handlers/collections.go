@@ -245,7 +245,10 @@ func (h *Handlers) HandleCreateCollection(ctx *executioncontext.ExecutionContext
 			if err != nil {
 				return err
 			}
-			return serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, collection)
+			if err := serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, collection); err != nil {
+				return err
+			}
+			return checkTagPolicy(storage.WithContext(runtimeCtx), "collection", collection.Tags)
 		},
 		"validation",
 		"validate-collection",
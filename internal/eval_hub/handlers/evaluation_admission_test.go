@@ -0,0 +1,114 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type fakeJobAdmission struct {
+	request *abstractions.JobAdmissionRequest
+	patched []byte
+	err     error
+}
+
+func (f *fakeJobAdmission) Admit(_ context.Context, _ *slog.Logger, request *abstractions.JobAdmissionRequest) ([]byte, error) {
+	f.request = request
+	return f.patched, f.err
+}
+
+func TestHandleCreateEvaluationAdmission(t *testing.T) {
+	providerConfigs := map[string]api.ProviderResource{
+		"garak": {
+			Resource: api.Resource{ID: "garak"},
+			ProviderConfig: api.ProviderConfig{
+				Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}},
+			},
+		},
+	}
+	body := []byte(`{"name": "test-evaluation-job", "model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}]}`)
+
+	create := func(t *testing.T, jobAdmission abstractions.JobAdmission) (*fakeRuntime, *httptest.ResponseRecorder) {
+		t.Helper()
+		runtime := &fakeRuntime{}
+		h := handlers.New(&fakeStorage{providerConfigs: providerConfigs}, testhelpers.NewValidator(t), runtime, nil, nil, nil).
+			WithJobAdmission(jobAdmission)
+		ctx := executioncontext.NewExecutionContext(context.Background(), "req-admission", logging.FallbackLogger(), "test-user", "test-tenant")
+		req := &bodyRequest{
+			MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+			body:        body,
+		}
+		recorder := httptest.NewRecorder()
+		h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+		return runtime, recorder
+	}
+
+	t.Run("the job is reviewed before it is stored", func(t *testing.T) {
+		jobAdmission := &fakeJobAdmission{}
+		runtime, recorder := create(t, jobAdmission)
+
+		if recorder.Code != 202 {
+			t.Fatalf("expected status 202, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if !runtime.called {
+			t.Fatalf("expected runtime to be invoked")
+		}
+		request := jobAdmission.request
+		if request == nil || request.Job.Name != "test-evaluation-job" || request.Tenant != "test-tenant" || request.User != "test-user" || request.JobID == "" {
+			t.Fatalf("unexpected admission request %+v", request)
+		}
+	})
+
+	t.Run("a rejected job is not created", func(t *testing.T) {
+		runtime, recorder := create(t, &fakeJobAdmission{
+			err: serviceerrors.NewServiceError(messages.AdmissionDenied, "Webhook", "approved-benchmarks", "Reason", "bench-1 is not approved"),
+		})
+
+		if recorder.Code != 403 {
+			t.Fatalf("expected status 403, got %d", recorder.Code)
+		}
+		if runtime.called {
+			t.Fatalf("did not expect runtime to be invoked")
+		}
+	})
+
+	t.Run("a mutated job is created as mutated", func(t *testing.T) {
+		patched := []byte(`{"name": "test-evaluation-job", "tags": ["cost-center-42"], "model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}]}`)
+		_, recorder := create(t, &fakeJobAdmission{patched: patched})
+
+		if recorder.Code != 202 {
+			t.Fatalf("expected status 202, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		job := api.EvaluationJobResource{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &job); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if !slices.Equal(job.Tags, []string{"cost-center-42"}) {
+			t.Fatalf("expected the tag added by the webhook, got %v", job.Tags)
+		}
+	})
+
+	t.Run("a mutated job is validated", func(t *testing.T) {
+		patched := []byte(`{"model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}]}`)
+		runtime, recorder := create(t, &fakeJobAdmission{patched: patched})
+
+		if recorder.Code != 400 {
+			t.Fatalf("expected status 400, got %d", recorder.Code)
+		}
+		if runtime.called {
+			t.Fatalf("did not expect runtime to be invoked")
+		}
+	})
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const (
+	// InlineEvaluationMaxBenchmarks is the most benchmarks an inline job may have.
+	InlineEvaluationMaxBenchmarks = 5
+	// InlineEvaluationMaxNumExamples is the most num_examples of a benchmark of an inline job.
+	InlineEvaluationMaxNumExamples = 100
+	// InlineEvaluationTimeout is how long an inline evaluation waits for its job to finish.
+	InlineEvaluationTimeout = 5 * time.Minute
+)
+
+// HandleEvaluateInline handles POST /api/v1/evaluations/jobs:evaluate. It creates a small
+// evaluation job on the local runtime and answers once the job finished, with its results,
+// for notebook-style use where polling the job is awkward. The job is stored as any other
+// job; when it does not finish within InlineEvaluationTimeout it is answered with 202, as on
+// creation, and keeps running.
+func (h *Handlers) HandleEvaluateInline(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	if h.rejectInMaintenance(ctx, w) {
+		return
+	}
+
+	stream, ok := w.(http_wrappers.StreamingResponseWrapper)
+	if h.jobWatcher == nil || !ok {
+		w.ErrorWithMessageCode(ctx.RequestID, messages.NotImplemented, "Api", req.URI())
+		return
+	}
+
+	id := common.GUID()
+
+	evaluation, collection, err := h.readEvaluationJob(ctx, storage, id, req)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	if err := h.checkInlineEvaluation(ctx, evaluation, collection); err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	ApplyEvaluationJobQueueDefaults(evaluation)
+
+	// subscribe before creating the job so no update is lost
+	updates, unsubscribe := h.jobWatcher.Subscribe(id)
+	defer unsubscribe()
+
+	// the answer outlives the server write timeout
+	if err := stream.SetWriteDeadline(time.Now().Add(InlineEvaluationTimeout + watchKeepAliveInterval)); err != nil {
+		ctx.Logger.Debug("Unable to extend the write deadline of the inline evaluation", "error", err)
+	}
+
+	job, err := h.createEvaluationJob(ctx, storage, id, evaluation, collection)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	timeout := time.NewTimer(InlineEvaluationTimeout)
+	defer timeout.Stop()
+	for !isJobFinished(job) {
+		select {
+		case <-ctx.Ctx.Done():
+			return
+		case <-timeout.C:
+			ctx.Logger.Info("Inline evaluation job did not finish in time", "id", id, "timeout", InlineEvaluationTimeout)
+			w.WriteJSON(localizeJobMessages(ctx, job), 202)
+			return
+		case job = <-updates:
+		}
+	}
+	w.WriteJSON(localizeJobMessages(ctx, job), 200)
+}
+
+// checkInlineEvaluation rejects a job that is too large to run inline, and selects the local
+// runtime for it.
+func (h *Handlers) checkInlineEvaluation(ctx *executioncontext.ExecutionContext, evaluation *api.EvaluationJobConfig, collection *api.CollectionResource) error {
+	if evaluation.Sweep != nil {
+		return serviceerrors.NewServiceError(messages.InlineEvaluationNotSupported, "Reason", "a sweep runs a job per configuration")
+	}
+	if evaluation.Runtime != "" && evaluation.Runtime != api.RuntimeLocal {
+		return serviceerrors.NewServiceError(messages.InlineEvaluationNotSupported, "Reason", "inline jobs run on the local runtime")
+	}
+	benchmarks, err := GetJobBenchmarks(&api.EvaluationJobResource{EvaluationJobConfig: *evaluation}, collection)
+	if err != nil {
+		return err
+	}
+	if len(benchmarks) > InlineEvaluationMaxBenchmarks {
+		return serviceerrors.NewServiceError(messages.InlineEvaluationNotSupported, "Reason", fmt.Sprintf("the job has more than %d benchmarks", InlineEvaluationMaxBenchmarks))
+	}
+	for _, benchmark := range benchmarks {
+		numExamples := shared.NumExamplesFromParameters(benchmark.Parameters)
+		if numExamples == nil || *numExamples > InlineEvaluationMaxNumExamples {
+			return serviceerrors.NewServiceError(messages.InlineEvaluationNotSupported, "Reason", fmt.Sprintf("the benchmark '%s' must set num_examples to at most %d", benchmark.ID, InlineEvaluationMaxNumExamples))
+		}
+	}
+	evaluation.Runtime = api.RuntimeLocal
+	return h.validateJobRuntime(ctx, evaluation, benchmarks)
+}
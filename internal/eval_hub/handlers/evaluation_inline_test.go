@@ -0,0 +1,95 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/jobwatch"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// inlineTestRuntime completes each job it starts at once.
+type inlineTestRuntime struct {
+	fakeRuntime
+	hub *jobwatch.Hub
+}
+
+func (r *inlineTestRuntime) WithLogger(_ *slog.Logger) abstractions.Runtime { return r }
+
+func (r *inlineTestRuntime) WithContext(_ context.Context) abstractions.Runtime { return r }
+
+func (r *inlineTestRuntime) RunEvaluationJob(job *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, _ abstractions.RuntimeStorage) error {
+	completed := *job
+	completed.Status = &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateCompleted}}
+	completed.Results = &api.EvaluationJobResults{Benchmarks: []api.BenchmarkResult{
+		{ID: "bench-1", ProviderID: "garak", Metrics: map[string]any{"acc": 0.5}},
+	}}
+	r.hub.Publish(&completed)
+	return nil
+}
+
+func TestHandleEvaluateInline(t *testing.T) {
+	providerConfigs := map[string]api.ProviderResource{
+		"garak": {
+			Resource: api.Resource{ID: "garak"},
+			ProviderConfig: api.ProviderConfig{
+				Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}},
+				Runtime:    &api.Runtime{K8s: &api.K8sRuntime{Image: "quay.io/garak:latest"}, Local: &api.LocalRuntime{Command: "garak-adapter"}},
+			},
+		},
+	}
+	serviceConfig := &config.Config{Service: &config.ServiceConfig{Runtimes: []string{api.RuntimeLocal}}}
+	evaluate := func(t *testing.T, body string) *httptest.ResponseRecorder {
+		hub := jobwatch.NewHub()
+		runtime := &inlineTestRuntime{hub: hub}
+		h := handlers.New(&fakeStorage{providerConfigs: providerConfigs}, testhelpers.NewValidator(t), runtime, nil, serviceConfig, nil).WithJobWatcher(hub)
+		req := &bodyRequest{
+			MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs:evaluate"),
+			body:        []byte(body),
+		}
+		ctx := executioncontext.NewExecutionContext(context.Background(), "req-inline", logging.FallbackLogger(), "test-user", "test-tenant")
+		w := &streamingResponseWrapper{MockResponseWrapper: MockResponseWrapper{httptest.NewRecorder()}}
+		h.HandleEvaluateInline(ctx, req, w)
+		return w.recorder
+	}
+
+	t.Run("returns the results of the finished job", func(t *testing.T) {
+		recorder := evaluate(t, `{"name":"inline","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak","parameters":{"num_examples":10}}]}`)
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var job api.EvaluationJobResource
+		if err := json.Unmarshal(recorder.Body.Bytes(), &job); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if job.Status.State != api.OverallStateCompleted || job.Results == nil || len(job.Results.Benchmarks) != 1 {
+			t.Fatalf("expected the completed job with its results, got %s", recorder.Body.String())
+		}
+		if job.Runtime != api.RuntimeLocal {
+			t.Errorf("expected the job to run on the local runtime, got %q", job.Runtime)
+		}
+	})
+
+	for name, body := range map[string]string{
+		"without num_examples":      `{"name":"inline","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}]}`,
+		"too many examples":         `{"name":"inline","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak","parameters":{"num_examples":1000}}]}`,
+		"on the kubernetes runtime": `{"name":"inline","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak","parameters":{"num_examples":10}}],"runtime":"kubernetes"}`,
+	} {
+		t.Run("rejects a job "+name, func(t *testing.T) {
+			recorder := evaluate(t, body)
+			if recorder.Code != 400 || !strings.Contains(recorder.Body.String(), "inline_evaluation_not_supported") {
+				t.Fatalf("expected the inline_evaluation_not_supported error, got %d: %s", recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
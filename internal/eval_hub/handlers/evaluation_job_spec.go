@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+)
+
+// HandleGetEvaluationBenchmarkJobSpec handles GET /api/v1/evaluations/jobs/{id}/benchmarks/{benchmark_index}/spec
+func (h *Handlers) HandleGetEvaluationBenchmarkJobSpec(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID := req.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+	rawIndex := req.PathValue(constants.PATH_PARAMETER_BENCHMARK_INDEX)
+	if rawIndex == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_BENCHMARK_INDEX), ctx.RequestID)
+		return
+	}
+	benchmarkIndex, err := strconv.Atoi(rawIndex)
+	if err != nil || benchmarkIndex < 0 {
+		w.Error(serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", constants.PATH_PARAMETER_BENCHMARK_INDEX, "Type", "non-negative integer", "Value", rawIndex), ctx.RequestID)
+		return
+	}
+	shardIndex, err := GetParam(req, "shard_index", true, 0)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	if shardIndex < 0 {
+		w.Error(serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", "shard_index", "Type", "non-negative integer", "Value", strconv.Itoa(shardIndex)), ctx.RequestID)
+		return
+	}
+
+	if h.runtime == nil {
+		w.Error(serviceerrors.NewServiceError(messages.InternalServerError, "Error", "no runtime configured"), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			job, err := h.getAccessibleEvaluationJob(ctx, storage.WithContext(runtimeCtx), evaluationJobID, jobAccessRead)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			benchmarks, err := h.resolveJobBenchmarks(storage.WithContext(runtimeCtx), job)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			spec, err := h.runtime.WithLogger(ctx.Logger).WithContext(runtimeCtx).GetEvaluationJobSpec(
+				job, benchmarks, benchmarkIndex, shardIndex, h.createRuntimeStorage(ctx, runtimeCtx))
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			w.WriteJSON(json.RawMessage(spec), 200)
+			return nil
+		},
+		"runtime",
+		"get-evaluation-job-spec",
+		"job.id", evaluationJobID,
+		"benchmark.index", rawIndex,
+	)
+}
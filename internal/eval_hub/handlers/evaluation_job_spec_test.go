@@ -0,0 +1,82 @@
+package handlers_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestHandleGetEvaluationBenchmarkJobSpec(t *testing.T) {
+	jobID := "job-spec"
+	storage := &fakeStorage{
+		job: &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{
+				Resource: api.Resource{ID: jobID},
+			},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Benchmarks: []api.EvaluationBenchmarkConfig{
+					{Ref: api.Ref{ID: "bench-1"}, ProviderID: "provider-1", Shards: 4},
+				},
+			},
+		},
+	}
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name        string
+		index       string
+		query       map[string][]string
+		wantStatus  int
+		wantIndices []int
+	}{
+		{name: "benchmark", index: "0", wantStatus: http.StatusOK, wantIndices: []int{0, 0}},
+		{name: "shard", index: "0", query: map[string][]string{"shard_index": {"3"}}, wantStatus: http.StatusOK, wantIndices: []int{0, 3}},
+		{name: "invalid benchmark index", index: "abc", wantStatus: http.StatusBadRequest},
+		{name: "negative shard index", index: "0", query: map[string][]string{"shard_index": {"-1"}}, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runtime := &fakeRuntime{spec: []byte(`{"id": "job-spec", "benchmark_id": "bench-1"}`)}
+			h := handlers.New(storage, testhelpers.NewValidator(t), runtime, nil, nil, nil)
+			rec := httptest.NewRecorder()
+			ctx := executioncontext.NewExecutionContext(context.Background(), "req-spec", logger, "test-user", "test-tenant")
+			req := &logsRequest{
+				MockRequest: createMockRequest(http.MethodGet, "/api/v1/evaluations/jobs/"+jobID+"/benchmarks/"+tt.index+"/spec"),
+				pathValues: map[string]string{
+					constants.PATH_PARAMETER_JOB_ID:          jobID,
+					constants.PATH_PARAMETER_BENCHMARK_INDEX: tt.index,
+				},
+				queryValues: tt.query,
+			}
+
+			h.HandleGetEvaluationBenchmarkJobSpec(ctx, req, MockResponseWrapper{recorder: rec})
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if runtime.called {
+					t.Fatal("expected the runtime not to be called")
+				}
+				return
+			}
+			if !slices.Equal(runtime.specIndices, tt.wantIndices) {
+				t.Fatalf("benchmark and shard index = %v, want %v", runtime.specIndices, tt.wantIndices)
+			}
+			if body := strings.TrimSpace(rec.Body.String()); body != `{"id":"job-spec","benchmark_id":"bench-1"}` {
+				t.Fatalf("body = %s, want the job spec of the runtime", body)
+			}
+		})
+	}
+}
@@ -63,7 +63,7 @@ func (h *Handlers) handleGetEvaluationLogs(
 	_ = h.withSpan(
 		ctx,
 		func(runtimeCtx context.Context) error {
-			job, err := storage.WithContext(runtimeCtx).GetEvaluationJob(evaluationJobID)
+			job, err := h.getAccessibleEvaluationJob(ctx, storage.WithContext(runtimeCtx), evaluationJobID, jobAccessRead)
 			if err != nil {
 				w.Error(err, ctx.RequestID)
 				return err
@@ -41,6 +41,14 @@ func (r *logsRuntime) RunEvaluationJob(
 ) error {
 	return nil
 }
+func (r *logsRuntime) RunEvaluationBenchmarks(
+	_ *api.EvaluationJobResource,
+	_ []api.EvaluationBenchmarkConfig,
+	_ []int,
+	_ abstractions.RuntimeStorage,
+) error {
+	return nil
+}
 func (r *logsRuntime) DeleteEvaluationJobResources(_ *api.EvaluationJobResource) error { return nil }
 func (r *logsRuntime) GetEvaluationLogs(
 	_ *api.EvaluationJobResource,
@@ -56,6 +64,15 @@ func (r *logsRuntime) GetEvaluationLogs(
 	}
 	return r.logs, nil
 }
+func (r *logsRuntime) GetEvaluationJobSpec(
+	_ *api.EvaluationJobResource,
+	_ []api.EvaluationBenchmarkConfig,
+	_ int,
+	_ int,
+	_ abstractions.RuntimeStorage,
+) ([]byte, error) {
+	return nil, nil
+}
 
 type logsRequest struct {
 	*MockRequest
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
 	"github.com/eval-hub/eval-hub/internal/otel"
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
@@ -26,7 +27,10 @@ func (h *Handlers) onEvaluationJobUpdated(
 		return
 	}
 
+	h.stopFailedFastBenchmarks(ctx, job, logger)
 	h.exportEvaluationResults(ctx, job, logger)
+	h.cacheBenchmarkResults(ctx, storage, job, logger)
+	h.refreshFinalPlacements(job, logger)
 
 	if h.serviceConfig == nil || !h.serviceConfig.IsOTELJobContainerLogsEnabled() || h.runtime == nil {
 		return
@@ -46,6 +50,37 @@ func (h *Handlers) onEvaluationJobUpdated(
 	otel.ExportJobContainerLogsAsync(ctx, h.runtime, job, benchmarks, logger)
 }
 
+// stopFailedFastBenchmarks stops the workloads of the benchmarks that the storage cancelled
+// when a job that fails fast failed. Runtimes that cannot stop some of the benchmarks of a
+// job delete all its resources, as when the job is cancelled.
+func (h *Handlers) stopFailedFastBenchmarks(ctx context.Context, job *api.EvaluationJobResource, logger *slog.Logger) {
+	if h.runtime == nil || job.Status.State != api.OverallStateFailed || !job.Aggregation().FailsFast() {
+		return
+	}
+	var cancelled []int
+	for _, benchmark := range job.Status.Benchmarks {
+		if benchmark.ErrorMessage != nil && benchmark.ErrorMessage.MessageCode == constants.MESSAGE_CODE_BENCHMARK_FAIL_FAST {
+			cancelled = append(cancelled, benchmark.BenchmarkIndex)
+		}
+	}
+	if len(cancelled) == 0 {
+		return
+	}
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	runtime := h.runtime.WithLogger(logger).WithContext(ctx)
+	if canceller, ok := runtime.(abstractions.BenchmarkCanceller); ok {
+		if err := canceller.CancelEvaluationBenchmarks(job, cancelled); err != nil {
+			logger.Error("Failed to stop the benchmarks of the evaluation job that failed fast", "error", err, "id", job.Resource.ID, "benchmark_indices", cancelled)
+		}
+		return
+	}
+	if err := runtime.DeleteEvaluationJobResources(job); err != nil {
+		logger.Error("Failed to delete the runtime resources of the evaluation job that failed fast", "error", err, "id", job.Resource.ID)
+	}
+}
+
 func (h *Handlers) resolveJobBenchmarksForStorage(storage abstractions.Storage, job *api.EvaluationJobResource) ([]api.EvaluationBenchmarkConfig, error) {
 	var collection *api.CollectionResource
 	if job.Collection != nil && job.Collection.ID != "" {
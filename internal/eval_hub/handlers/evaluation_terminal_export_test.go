@@ -36,11 +36,14 @@ func (s *terminalExportStorage) WithContext(_ context.Context) abstractions.Stor
 func (s *terminalExportStorage) WithTenant(_ api.Tenant) abstractions.Storage { return s }
 func (s *terminalExportStorage) WithOwner(_ api.User) abstractions.Storage    { return s }
 
-func (s *terminalExportStorage) UpdateEvaluationJob(_ string, status *api.StatusEvent) error {
+func (s *terminalExportStorage) UpdateEvaluationJob(_ string, status *api.StatusEvent) (*abstractions.EvaluationJobUpdate, error) {
+	update := &abstractions.EvaluationJobUpdate{}
 	if s.job != nil && s.job.Status != nil && status != nil && status.BenchmarkStatusEvent != nil {
+		update.PreviousState = s.job.Status.State
 		s.job.Status.State = api.OverallState(status.BenchmarkStatusEvent.Status)
+		update.State = s.job.Status.State
 	}
-	return nil
+	return update, nil
 }
 
 func TestHandleUpdateEvaluationSkipsCardExportWhenNotTerminal(t *testing.T) {
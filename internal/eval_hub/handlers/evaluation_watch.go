@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const (
+	// WatchEventStatus is the server-sent event name used for job snapshots.
+	WatchEventStatus = "status"
+
+	// watchKeepAliveInterval keeps proxies from closing an idle stream. Each keep-alive
+	// also pushes the write deadline forward, so the stream outlives the server write timeout.
+	watchKeepAliveInterval = 15 * time.Second
+)
+
+// HandleWatchEvaluation handles GET /api/v1/evaluations/jobs/{id}/watch. It streams the
+// job as server-sent events: the current state first, then every persisted update, and
+// ends the stream once the job reaches a terminal state.
+func (h *Handlers) HandleWatchEvaluation(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID := r.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+
+	stream, ok := w.(http_wrappers.StreamingResponseWrapper)
+	if h.jobWatcher == nil || !ok {
+		w.ErrorWithMessageCode(ctx.RequestID, messages.NotImplemented, "Api", r.URI())
+		return
+	}
+
+	// subscribe before reading the job so no update between the two is lost
+	updates, unsubscribe := h.jobWatcher.Subscribe(evaluationJobID)
+	defer unsubscribe()
+
+	// reading the job through the scoped storage also checks the caller may see it
+	job, err := h.getAccessibleEvaluationJob(ctx, storage, evaluationJobID, jobAccessRead)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	stream.SetHeader("Content-Type", "text/event-stream")
+	stream.SetHeader("Cache-Control", "no-cache")
+	stream.SetHeader("X-Accel-Buffering", "no")
+	if ctx.RequestID != "" {
+		stream.SetHeader("X-Global-Transaction-Id", ctx.RequestID)
+	}
+	extendWriteDeadline(ctx, stream)
+	stream.SetStatusCode(200)
+	logging.LogRequestSuccess(ctx, 200, nil)
+
+	events := 0
+	send := func(job *api.EvaluationJobResource) bool {
+		events++
+		if err := writeWatchEvent(stream, events, localizeJobMessages(ctx, job)); err != nil {
+			ctx.Logger.Debug("Stopped watching evaluation job", "id", evaluationJobID, "error", err)
+			return false
+		}
+		return !isJobFinished(job)
+	}
+
+	if !send(job) {
+		return
+	}
+
+	keepAlive := time.NewTicker(watchKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Ctx.Done():
+			return
+		case <-keepAlive.C:
+			extendWriteDeadline(ctx, stream)
+			if _, err := stream.Write([]byte(": keep-alive\n\n")); err != nil {
+				return
+			}
+			if err := stream.Flush(); err != nil {
+				return
+			}
+		case job := <-updates:
+			if !send(job) {
+				return
+			}
+		}
+	}
+}
+
+func writeWatchEvent(stream http_wrappers.StreamingResponseWrapper, id int, job *api.EvaluationJobResource) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(stream, "id: %d\nevent: %s\ndata: %s\n\n", id, WatchEventStatus, data); err != nil {
+		return err
+	}
+	return stream.Flush()
+}
+
+func extendWriteDeadline(ctx *executioncontext.ExecutionContext, stream http_wrappers.StreamingResponseWrapper) {
+	if err := stream.SetWriteDeadline(time.Now().Add(2 * watchKeepAliveInterval)); err != nil {
+		ctx.Logger.Debug("Unable to extend the write deadline of the watch stream", "error", err)
+	}
+}
+
+func isJobFinished(job *api.EvaluationJobResource) bool {
+	return job.Status != nil && job.Status.State.IsTerminalState()
+}
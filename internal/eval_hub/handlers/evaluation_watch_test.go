@@ -0,0 +1,122 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/jobwatch"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type streamingResponseWrapper struct {
+	MockResponseWrapper
+	flushes int
+}
+
+func (w *streamingResponseWrapper) Flush() error {
+	w.flushes++
+	return nil
+}
+
+func (w *streamingResponseWrapper) SetWriteDeadline(_ time.Time) error {
+	return nil
+}
+
+func watchJob(id string, state api.OverallState) *api.EvaluationJobResource {
+	return &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: id}},
+		Status: &api.EvaluationJobStatus{
+			EvaluationJobState: api.EvaluationJobState{State: state},
+		},
+	}
+}
+
+func watchRequest(id string) *listEvaluationsRequest {
+	return &listEvaluationsRequest{
+		MockRequest: createMockRequest("GET", "/api/v1/evaluations/jobs/"+id+"/watch"),
+		pathValues:  map[string]string{constants.PATH_PARAMETER_JOB_ID: id},
+	}
+}
+
+func TestHandleWatchEvaluation(t *testing.T) {
+	t.Run("streams updates until the job finishes", func(t *testing.T) {
+		hub := jobwatch.NewHub()
+		storage := &fakeStorage{job: watchJob("job-1", api.OverallStateRunning)}
+		h := handlers.New(storage, nil, nil, nil, nil, nil).WithJobWatcher(hub)
+
+		ctx := &executioncontext.ExecutionContext{Ctx: context.Background(), Logger: logging.FallbackLogger()}
+		w := &streamingResponseWrapper{MockResponseWrapper: MockResponseWrapper{httptest.NewRecorder()}}
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			h.HandleWatchEvaluation(ctx, watchRequest("job-1"), w)
+		}()
+
+		deadline := time.Now().Add(5 * time.Second)
+		for !hub.HasSubscribers("job-1") {
+			if time.Now().After(deadline) {
+				t.Fatal("handler did not subscribe to the job")
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		hub.Publish(watchJob("job-1", api.OverallStateCompleted))
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("watch did not end after the job completed")
+		}
+
+		if ct := w.recorder.Header().Get("Content-Type"); ct != "text/event-stream" {
+			t.Fatalf("expected text/event-stream, got %q", ct)
+		}
+		body := w.recorder.Body.String()
+		if n := strings.Count(body, "event: "+handlers.WatchEventStatus+"\n"); n != 2 {
+			t.Fatalf("expected 2 status events, got %d:\n%s", n, body)
+		}
+		if !strings.Contains(body, `"state":"running"`) || !strings.Contains(body, `"state":"completed"`) {
+			t.Fatalf("expected running and completed snapshots:\n%s", body)
+		}
+		if w.flushes < 2 {
+			t.Fatalf("expected each event to be flushed, got %d flushes", w.flushes)
+		}
+		if hub.HasSubscribers("job-1") {
+			t.Fatal("expected the subscription to be released")
+		}
+	})
+
+	t.Run("finished job returns a single event", func(t *testing.T) {
+		hub := jobwatch.NewHub()
+		storage := &fakeStorage{job: watchJob("job-2", api.OverallStateFailed)}
+		h := handlers.New(storage, nil, nil, nil, nil, nil).WithJobWatcher(hub)
+
+		ctx := &executioncontext.ExecutionContext{Ctx: context.Background(), Logger: logging.FallbackLogger()}
+		w := &streamingResponseWrapper{MockResponseWrapper: MockResponseWrapper{httptest.NewRecorder()}}
+		h.HandleWatchEvaluation(ctx, watchRequest("job-2"), w)
+
+		if n := strings.Count(w.recorder.Body.String(), "event: "); n != 1 {
+			t.Fatalf("expected 1 event, got %d", n)
+		}
+	})
+
+	t.Run("not implemented without a job watcher", func(t *testing.T) {
+		storage := &fakeStorage{job: watchJob("job-3", api.OverallStateRunning)}
+		h := handlers.New(storage, nil, nil, nil, nil, nil)
+
+		ctx := &executioncontext.ExecutionContext{Ctx: context.Background(), Logger: logging.FallbackLogger()}
+		w := &streamingResponseWrapper{MockResponseWrapper: MockResponseWrapper{httptest.NewRecorder()}}
+		h.HandleWatchEvaluation(ctx, watchRequest("job-3"), w)
+
+		if w.recorder.Code != 501 {
+			t.Fatalf("expected 501, got %d", w.recorder.Code)
+		}
+	})
+}
@@ -2,21 +2,28 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log/slog"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/eta"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/jobstate"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/metrics"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/mlflow"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/serialization"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/validation"
@@ -25,6 +32,23 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
+var (
+	// these are the allowed patches for an evaluation job, the rest of the job config
+	// can not change once the job is created
+	allowedEvaluationJobPatches = []allowedPatch{
+		{Path: "/annotations", Op: api.PatchOpAdd, Prefix: true},
+		{Path: "/annotations", Op: api.PatchOpRemove, Prefix: true},
+		{Path: "/annotations", Op: api.PatchOpReplace, Prefix: true},
+
+		{Path: "/links", Op: api.PatchOpAdd, Prefix: true},
+		{Path: "/links", Op: api.PatchOpRemove, Prefix: true},
+		{Path: "/links", Op: api.PatchOpReplace, Prefix: true},
+	}
+
+	// envNamePattern are the names of the variables that the env of a job may set.
+	envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
 // BackendSpec represents the backend specification
 type BackendSpec struct {
 	URL  string `json:"url"`
@@ -71,29 +95,41 @@ func (h *Handlers) runtimeName() string {
 }
 
 func (s *runtimeStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent) error {
-	var previousState api.OverallState
-	job, jobErr := s.scopedStorage().GetEvaluationJob(id)
-	if jobErr == nil && job != nil && job.Status != nil {
-		previousState = job.Status.State
-	}
-
 	err := s.validate.Struct(runStatus)
 	if err != nil {
 		s.logger.Info("Failed to validate evaluation job status from the runtime", "job_id", id, "error", err)
 		return err
 	}
-	err = s.scopedStorage().UpdateEvaluationJob(id, runStatus)
+	if err := redactStatusEvent(s.scopedStorage(), id, runStatus.BenchmarkStatusEvent); err != nil {
+		s.logger.Info("Failed to redact evaluation job status from the runtime", "job_id", id, "error", err)
+		return err
+	}
+	update, err := s.scopedStorage().UpdateEvaluationJob(id, runStatus)
 	if err != nil {
 		s.logger.Info("Failed to update evaluation job in storage", "job_id", id, "error", err)
 		return err
 	}
+	s.handlers.startReadyBenchmarks(s.ctx, s.scopedStorage(), s, id, update.ReadyBenchmarks, s.logger)
+	s.handlers.retryBenchmarks(s.ctx, s.scopedStorage(), s, id, update.RetryBenchmarks, s.logger)
 
 	s.handlers.onEvaluationJobUpdated(s.ctx, s.scopedStorage(), func() (*api.EvaluationJobResource, error) {
 		return s.scopedStorage().GetEvaluationJob(id)
-	}, previousState, s.logger)
+	}, update.PreviousState, s.logger)
 	return nil
 }
 
+func (s *runtimeStorage) UpdateEvaluationJobModelMetadata(id string, metadata *api.ModelMetadata) error {
+	return s.scopedStorage().UpdateEvaluationJobModelMetadata(id, metadata)
+}
+
+func (s *runtimeStorage) UpdateEvaluationJobBenchmarkPlacement(id string, benchmarkIndex int, placement *api.BenchmarkPlacement) error {
+	return s.scopedStorage().UpdateEvaluationJobBenchmarkPlacement(id, benchmarkIndex, placement)
+}
+
+func (s *runtimeStorage) PinEvaluationJobAdapterImage(id string, image string, pinned string) (string, error) {
+	return s.scopedStorage().PinEvaluationJobAdapterImage(id, image, pinned)
+}
+
 func (h *Handlers) getStorage(ctx *executioncontext.ExecutionContext) abstractions.Storage {
 	return h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx).WithTenant(ctx.Tenant).WithOwner(ctx.User)
 }
@@ -117,8 +153,41 @@ func (h *Handlers) HandleCreateEvaluation(ctx *executioncontext.ExecutionContext
 
 	logging.LogRequestStarted(ctx)
 
+	if h.rejectInMaintenance(ctx, w) {
+		return
+	}
+
 	id := common.GUID()
 
+	evaluation, collection, err := h.readEvaluationJob(ctx, storage, id, req)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	ApplyEvaluationJobQueueDefaults(evaluation)
+
+	if evaluation.Sweep != nil {
+		h.createEvaluationSweep(ctx, w, storage, id, evaluation, collection)
+		return
+	}
+
+	job, err := h.createEvaluationJob(ctx, storage, id, evaluation, collection)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	w.WriteJSON(localizeJobMessages(ctx, job), 202)
+}
+
+// readEvaluationJob reads the evaluation job of a create request and validates it, returning
+// the job and its collection.
+func (h *Handlers) readEvaluationJob(
+	ctx *executioncontext.ExecutionContext,
+	storage abstractions.Storage,
+	id string,
+	req http_wrappers.RequestWrapper,
+) (*api.EvaluationJobConfig, *api.CollectionResource, error) {
 	evaluation := &api.EvaluationJobConfig{}
 	var collection *api.CollectionResource
 
@@ -134,6 +203,31 @@ func (h *Handlers) HandleCreateEvaluation(ctx *executioncontext.ExecutionContext
 			if err != nil {
 				return err
 			}
+			evaluation, err = h.admitEvaluationJob(ctx.WithContext(runtimeCtx), id, evaluation)
+			if err != nil {
+				return err
+			}
+			// after the admission webhooks, which may add the mandatory tags
+			if err := checkTagPolicy(storage.WithContext(runtimeCtx), "evaluation job", evaluation.Tags); err != nil {
+				return err
+			}
+			// only the server links jobs to a sweep
+			evaluation.SweepRun = nil
+			if err := validation.ValidateSweep(evaluation); err != nil {
+				return err
+			}
+			if err := validation.ValidateWaitForModel(evaluation); err != nil {
+				return err
+			}
+			if err := h.checkNotifyTargets(evaluation); err != nil {
+				return err
+			}
+			if err := h.checkJobEnv(ctx, evaluation); err != nil {
+				return err
+			}
+			if err := h.applyTenantExperiment(ctx, evaluation); err != nil {
+				return err
+			}
 			if evaluation.Collection != nil && evaluation.Collection.ID != "" {
 				collection, err = storage.WithContext(runtimeCtx).GetCollection(evaluation.Collection.ID)
 				if err != nil {
@@ -143,57 +237,74 @@ func (h *Handlers) HandleCreateEvaluation(ctx *executioncontext.ExecutionContext
 					return err
 				}
 			}
+			if err := validation.ValidateBenchmarkDependencies(evaluation.Benchmarks); err != nil {
+				return err
+			}
 			jobForResolve := &api.EvaluationJobResource{EvaluationJobConfig: *evaluation}
 			benchmarks, err := GetJobBenchmarks(jobForResolve, collection)
 			if err != nil {
 				return err
 			}
-			return h.validateBenchmarkReferences(ctx, benchmarks)
+			if err := h.checkMaxBenchmarks(ctx, benchmarks); err != nil {
+				return err
+			}
+			if err := validation.ValidateStateAggregation(evaluation, benchmarks); err != nil {
+				return err
+			}
+			passCriteria := evaluation.PassCriteria
+			if (passCriteria == nil || passCriteria.Threshold == nil) && collection != nil {
+				passCriteria = collection.PassCriteria
+			}
+			if err := h.validateBenchmarkReferences(ctx, benchmarks, passCriteria); err != nil {
+				return err
+			}
+			if err := validation.ValidateSecretRefs(benchmarks); err != nil {
+				return err
+			}
+			if err := h.validateJobRuntime(ctx, evaluation, benchmarks); err != nil {
+				return err
+			}
+			if err := h.validateSecretRefRuntimes(ctx, evaluation, benchmarks); err != nil {
+				return err
+			}
+			if err := h.validateJobCluster(evaluation); err != nil {
+				return err
+			}
+			if err := h.validateJobSpecVersions(ctx, evaluation, benchmarks); err != nil {
+				return err
+			}
+			if err := checkPassCriteriaBaselines(storage.WithContext(runtimeCtx), evaluation, collection, benchmarks); err != nil {
+				return err
+			}
+			if collection == nil {
+				// store the effective parameters so that they are visible on the job; the
+				// benchmarks of a collection job get them when they are resolved, see
+				// applyCollectionDefaultParameters
+				return applyDefaultParameters(h.getStorage(ctx), evaluation.Benchmarks)
+			}
+			return nil
 		},
 		"validation",
 		"validate-evaluation-job",
 		"job.id", id,
 	)
-
 	if err != nil {
-		w.Error(err, ctx.RequestID)
-		return
+		return nil, nil, err
 	}
+	return evaluation, collection, nil
+}
 
-	ApplyEvaluationJobQueueDefaults(evaluation)
-
-	mlflowExperimentID := ""
-	mlflowExperimentURL := ""
-	if h.mlflowClient != nil {
-		err = h.withSpan(
-			ctx,
-			func(runtimeCtx context.Context) error {
-				client := h.mlflowClient.WithContext(runtimeCtx).WithLogger(ctx.Logger)
-				// Experiments must be scoped to the tenant namespace so job pods running
-				// in that namespace can reach them with their own X-MLFLOW-WORKSPACE header.
-				if !ctx.Tenant.IsEmpty() {
-					client = client.WithWorkspace(ctx.Tenant.String())
-				}
-				mlflowExperimentID, mlflowExperimentURL, err = mlflow.GetOrCreateExperimentID(client, evaluation, id)
-				return err
-			},
-			"mlflow",
-			"get-or-create-experiment",
-			"job.id", id,
-		)
-		if err != nil {
-			w.Error(err, ctx.RequestID)
-			return
-		}
-	} else if mlflow.HasExperimentName(evaluation) {
+// createEvaluationJob stores a validated evaluation job and starts it on the runtime. When
+// the runtime fails to start the job, the job is returned marked as failed, with the error.
+func (h *Handlers) createEvaluationJob(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, id string, evaluation *api.EvaluationJobConfig, collection *api.CollectionResource) (*api.EvaluationJobResource, error) {
+	if h.mlflowClient == nil && mlflow.HasExperimentName(evaluation) {
 		// MLflow not configured but experiment name provided in the input
-		w.Error(serviceerrors.NewServiceError(messages.MLFlowRequiredForExperiment), ctx.RequestID)
-		return
+		return nil, serviceerrors.NewServiceError(messages.MLFlowRequiredForExperiment)
 	}
 
 	var job *api.EvaluationJobResource
 
-	err = h.withSpan(
+	err := h.withSpan(
 		ctx,
 		func(runtimeCtx context.Context) error {
 			job = &api.EvaluationJobResource{
@@ -204,7 +315,6 @@ func (h *Handlers) HandleCreateEvaluation(ctx *executioncontext.ExecutionContext
 						Owner:     ctx.User,
 						Tenant:    ctx.Tenant,
 					},
-					MLFlowExperimentID: mlflowExperimentID,
 				},
 				Status: &api.EvaluationJobStatus{
 					EvaluationJobState: api.EvaluationJobState{
@@ -215,9 +325,7 @@ func (h *Handlers) HandleCreateEvaluation(ctx *executioncontext.ExecutionContext
 						}, api.MessageOriginServer),
 					},
 				},
-				Results: &api.EvaluationJobResults{
-					MLFlowExperimentURL: mlflowExperimentURL,
-				},
+				Results:             &api.EvaluationJobResults{},
 				EvaluationJobConfig: *evaluation,
 			}
 			return storage.WithContext(runtimeCtx).CreateEvaluationJob(job)
@@ -225,18 +333,45 @@ func (h *Handlers) HandleCreateEvaluation(ctx *executioncontext.ExecutionContext
 		"storage",
 		"store-evaluation-job",
 		"job.id", id,
-		"job.experiment_id", mlflowExperimentID,
-		"job.experiment_url", mlflowExperimentURL,
 	)
 
 	if err != nil {
-		w.Error(err, ctx.RequestID)
-		return
+		return nil, err
 	}
 
 	metrics.RecordEvaluationJobCreated(ctx.Ctx, h.runtimeName())
+	if h.mlflowClient != nil && mlflow.HasExperimentName(evaluation) {
+		// the experiment is linked once the job is stored, so that the job creation does not
+		// wait for MLflow, nor fail when it is down
+		h.linkExperimentInBackground(ctx, storage, job)
+	}
 
-	_ = h.withSpan(
+	job = h.reuseCachedResults(ctx, storage, job, collection)
+	if job.Status != nil && job.Status.State.IsTerminalState() {
+		// every benchmark was completed from the result cache, there is nothing to run
+		h.onEvaluationJobUpdated(ctx.Ctx, storage, func() (*api.EvaluationJobResource, error) {
+			return job, nil
+		}, api.OverallStatePending, ctx.Logger)
+		return job, nil
+	}
+
+	if job.WaitForModel != nil && h.runtime != nil {
+		if err := h.waitForModel(ctx, storage, job, collection); err != nil {
+			return nil, err
+		}
+		return job, nil
+	}
+	return job, h.startEvaluationJob(ctx, storage, job, collection)
+}
+
+// startEvaluationJob starts the stored job on the runtime. When the runtime fails to start
+// the job, the job is marked as failed, with the error.
+func (h *Handlers) startEvaluationJob(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, job *api.EvaluationJobResource, collection *api.CollectionResource) error {
+	experimentURL := ""
+	if job.Results != nil {
+		experimentURL = job.Results.MLFlowExperimentURL
+	}
+	return h.withSpan(
 		ctx,
 		func(runtimeCtx context.Context) error {
 			if h.runtime != nil {
@@ -249,34 +384,155 @@ func (h *Handlers) HandleCreateEvaluation(ctx *executioncontext.ExecutionContext
 					}, api.MessageOriginServer)
 					metrics.RecordEvaluationJobRuntimeStartFailed(ctx.Ctx, h.runtimeName())
 					metrics.RecordEvaluationJobTerminalState(ctx.Ctx, api.OverallStatePending, state)
-					if err := storage.WithContext(runtimeCtx).UpdateEvaluationJobStatus(job.Resource.ID, state, message); err != nil {
+					if _, err := storage.WithContext(runtimeCtx).UpdateEvaluationJobStatus(job.Resource.ID, state, message); err != nil {
 						ctx.Logger.Error("Failed to update evaluation status", "error", err, "job_id", job.Resource.ID)
 					}
+					job.Status.State = state
+					job.Status.Message = message
 					// return the first error encountered
-					w.Error(runErr, ctx.RequestID)
 					return runErr
 				}
+				h.captureModelMetadata(ctx, storage, job)
 			} else {
 				message := api.WithMessageOrigin(&api.MessageInfo{
 					Message:     "Evaluation job created but no runtime configured",
 					MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_UPDATED,
 				}, api.MessageOriginServer)
-				if err := storage.WithContext(runtimeCtx).UpdateEvaluationJobStatus(job.Resource.ID, job.Status.State, message); err != nil {
+				if _, err := storage.WithContext(runtimeCtx).UpdateEvaluationJobStatus(job.Resource.ID, job.Status.State, message); err != nil {
 					ctx.Logger.Error("Failed to update evaluation status", "error", err, "job_id", job.Resource.ID)
 				}
 				job.Status.Message = message
 			}
-			w.WriteJSON(job, 202)
 			return nil
 		},
 		"runtime",
 		"start-evaluation-job",
-		"job.id", id,
-		"job.experiment_id", mlflowExperimentID,
-		"job.experiment_url", mlflowExperimentURL,
+		"job.id", job.Resource.ID,
+		"job.experiment_id", job.Resource.MLFlowExperimentID,
+		"job.experiment_url", experimentURL,
 	)
 }
 
+// admitEvaluationJob passes the job through the admission webhooks, if any, and returns
+// the job to store.
+func (h *Handlers) admitEvaluationJob(ctx *executioncontext.ExecutionContext, id string, evaluation *api.EvaluationJobConfig) (*api.EvaluationJobConfig, error) {
+	if h.jobAdmission == nil {
+		return evaluation, nil
+	}
+	patched, err := h.jobAdmission.Admit(ctx.Ctx, ctx.Logger, &abstractions.JobAdmissionRequest{
+		JobID:  id,
+		Tenant: ctx.Tenant,
+		User:   ctx.User,
+		Job:    evaluation,
+	})
+	if err != nil || patched == nil {
+		return evaluation, err
+	}
+	// a mutated job must be as valid as one sent by the user
+	mutated := &api.EvaluationJobConfig{}
+	if err := serialization.Unmarshal(h.validate, ctx, patched, mutated); err != nil {
+		return nil, err
+	}
+	return mutated, nil
+}
+
+// checkNotifyTargets returns an error when a notify target of the job is not a configured
+// notifier, so that a typo is reported when the job is created rather than lost when it ends.
+func (h *Handlers) checkNotifyTargets(evaluation *api.EvaluationJobConfig) error {
+	var notifications *config.NotificationsConfig
+	if h.serviceConfig != nil {
+		notifications = h.serviceConfig.Notifications
+	}
+	for _, target := range evaluation.Notify {
+		if notifications.Notifier(target) == nil {
+			return serviceerrors.NewServiceError(messages.NotifyTargetUnknown, "Target", target)
+		}
+	}
+	return nil
+}
+
+// checkJobEnv rejects a job whose env sets a variable, or reads a secret, that is not in the
+// job env allowlist of the tenant.
+func (h *Handlers) checkJobEnv(ctx *executioncontext.ExecutionContext, evaluation *api.EvaluationJobConfig) error {
+	if len(evaluation.Env) == 0 {
+		return nil
+	}
+	var jobEnv *config.JobEnvConfig
+	if h.serviceConfig != nil {
+		jobEnv = h.serviceConfig.JobEnv
+	}
+	allowlist := jobEnv.Allowlist(ctx.Tenant.String())
+	seen := map[string]bool{}
+	for _, item := range evaluation.Env {
+		if !envNamePattern.MatchString(item.Name) {
+			return serviceerrors.NewServiceError(messages.JobEnvNotAllowed, "Name", item.Name, "Reason", "it is not a valid variable name")
+		}
+		if seen[item.Name] {
+			return serviceerrors.NewServiceError(messages.JobEnvNotAllowed, "Name", item.Name, "Reason", "it is set more than once")
+		}
+		seen[item.Name] = true
+		if !allowlist.AllowsName(item.Name) {
+			return serviceerrors.NewServiceError(messages.JobEnvNotAllowed, "Name", item.Name, "Reason", "it is not in the job env allowlist of the tenant")
+		}
+		ref, isRef, err := item.SecretRef()
+		if err != nil {
+			return serviceerrors.NewServiceError(messages.JobEnvNotAllowed, "Name", item.Name, "Reason", err.Error())
+		}
+		if isRef && !allowlist.AllowsSecret(ref.Name) {
+			return serviceerrors.NewServiceError(messages.JobEnvNotAllowed, "Name", item.Name, "Reason", fmt.Sprintf("the secret %s is not in the job env allowlist of the tenant", ref.Name))
+		}
+	}
+	return nil
+}
+
+// applyTenantExperiment sets the MLflow experiment of a job from the mapping of its tenant
+// in the MLflow config: the default experiment when the job sets none, the prefix of the
+// experiment name, and the default workspace. A job may only select a workspace that its
+// tenant is mapped to, or the workspace named after its tenant when it is not mapped.
+func (h *Handlers) applyTenantExperiment(ctx *executioncontext.ExecutionContext, evaluation *api.EvaluationJobConfig) error {
+	var mlflowConfig *config.MLFlowConfig
+	if h.serviceConfig != nil {
+		mlflowConfig = h.serviceConfig.MLFlow
+	}
+	tenant := ctx.Tenant.String()
+	mapping, mapped := mlflowConfig.Tenant(tenant)
+	if evaluation.Experiment == nil && mapping.DefaultExperiment != "" && h.mlflowClient != nil {
+		evaluation.Experiment = &api.ExperimentConfig{Name: mapping.DefaultExperiment}
+	}
+	if evaluation.Experiment == nil {
+		return nil
+	}
+	workspaces := mapping.Workspaces
+	if len(workspaces) == 0 {
+		workspaces = []string{tenant}
+	}
+	if workspace := evaluation.Experiment.Workspace; workspace != "" && !slices.Contains(workspaces, workspace) {
+		return serviceerrors.NewServiceError(messages.MLFlowWorkspaceNotAllowed, "Tenant", tenant, "Workspace", workspace, "Workspaces", strings.Join(workspaces, ", "))
+	}
+	if !mapped {
+		return nil
+	}
+	evaluation.Experiment.Name = mapping.ExperimentName(evaluation.Experiment.Name)
+	if evaluation.Experiment.Workspace == "" {
+		evaluation.Experiment.Workspace = mapping.DefaultWorkspace()
+	}
+	return nil
+}
+
+// checkMaxBenchmarks rejects a job with more benchmarks than the sampling config allows
+// the tenant.
+func (h *Handlers) checkMaxBenchmarks(ctx *executioncontext.ExecutionContext, benchmarks []api.EvaluationBenchmarkConfig) error {
+	var sampling *config.SamplingConfig
+	if h.serviceConfig != nil {
+		sampling = h.serviceConfig.Sampling
+	}
+	limit := sampling.Limits(ctx.Tenant.String()).MaxBenchmarks
+	if limit > 0 && len(benchmarks) > limit {
+		return serviceerrors.NewServiceError(messages.TooManyBenchmarks, "Count", len(benchmarks), "MaxBenchmarks", limit)
+	}
+	return nil
+}
+
 func (h *Handlers) createRuntimeStorage(ctx *executioncontext.ExecutionContext, jobContext context.Context) *runtimeStorage {
 	return &runtimeStorage{
 		ctx:      jobContext,
@@ -295,6 +551,9 @@ func (h *Handlers) executeEvaluationJob(ctx *executioncontext.ExecutionContext,
 	if err != nil {
 		return err
 	}
+	if err := applyCollectionDefaultParameters(h.getStorage(ctx), job, benchmarks); err != nil {
+		return err
+	}
 
 	// Detach storage from the HTTP request context so that background
 	// goroutines inside the runtime can update job status after the
@@ -306,9 +565,14 @@ func (h *Handlers) executeEvaluationJob(ctx *executioncontext.ExecutionContext,
 	return h.runtime.WithLogger(ctx.Logger).WithContext(jobContext).RunEvaluationJob(job, benchmarks, h.createRuntimeStorage(ctx, jobContext))
 }
 
-func (h *Handlers) validateBenchmarkReferences(ctx *executioncontext.ExecutionContext, benchmarks []api.EvaluationBenchmarkConfig) error {
+// validateBenchmarkReferences checks that the benchmarks exist, that their primary scores
+// refer to the metrics of their providers, and that the thresholds of the pass criteria of
+// the benchmarks and of the job are within the range of the scores they apply to.
+func (h *Handlers) validateBenchmarkReferences(ctx *executioncontext.ExecutionContext, benchmarks []api.EvaluationBenchmarkConfig, passCriteria *api.PassCriteria) error {
 	storage := h.getStorage(ctx)
 
+	bounds := make([]validation.ScoreBounds, 0, len(benchmarks))
+	lowerIsBetter := make([]bool, 0, len(benchmarks))
 	for _, benchmark := range benchmarks {
 		provider, err := storage.GetProvider(benchmark.ProviderID)
 		if err != nil {
@@ -323,7 +587,8 @@ func (h *Handlers) validateBenchmarkReferences(ctx *executioncontext.ExecutionCo
 				"ResourceID", benchmark.ProviderID,
 			)
 		}
-		if !slices.ContainsFunc(provider.Benchmarks, func(b api.BenchmarkResource) bool { return b.ID == benchmark.ID }) {
+		providerBenchmark := provider.GetBenchmark(benchmark.ID)
+		if providerBenchmark == nil {
 			ctx.Logger.Debug("Benchmark does not exist in provider", "benchmark_id", benchmark.ID, "provider_id", benchmark.ProviderID)
 			return serviceerrors.NewServiceError(
 				messages.ResourceDoesNotExist,
@@ -331,6 +596,153 @@ func (h *Handlers) validateBenchmarkReferences(ctx *executioncontext.ExecutionCo
 				"ResourceID", benchmark.ID,
 			)
 		}
+		// the primary score and pass criteria of the provider apply when the job sets none
+		primaryScore, benchmarkPassCriteria := benchmark.PrimaryScore, benchmark.PassCriteria
+		if !primaryScore.IsSet() {
+			primaryScore = providerBenchmark.PrimaryScore
+		}
+		if benchmarkPassCriteria == nil {
+			benchmarkPassCriteria = providerBenchmark.PassCriteria
+		}
+		if err := validation.ValidateMetricReference(provider, benchmark.ID, primaryScore, benchmarkPassCriteria); err != nil {
+			return err
+		}
+		bounds = append(bounds, validation.PrimaryScoreBounds(&provider.ProviderConfig, primaryScore))
+		lowerIsBetter = append(lowerIsBetter, primaryScore.IsSet() && (primaryScore.LowerIsBetter || provider.GetMetric(primaryScore.Metric).LowerIsBetter()))
+	}
+	return validation.ValidateJobThreshold(passCriteria, validation.JobScoreBounds(bounds, lowerIsBetter))
+}
+
+// validateJobRuntime checks that the runtime selected by a job is enabled and that the
+// providers of its benchmarks can run on it.
+func (h *Handlers) validateJobRuntime(ctx *executioncontext.ExecutionContext, evaluation *api.EvaluationJobConfig, benchmarks []api.EvaluationBenchmarkConfig) error {
+	if evaluation.Runtime == "" {
+		return nil
+	}
+	enabled := h.enabledRuntimes()
+	if !slices.Contains(enabled, evaluation.Runtime) {
+		return serviceerrors.NewServiceError(messages.RuntimeNotEnabled, "Runtime", evaluation.Runtime, "EnabledRuntimes", strings.Join(enabled, ", "))
+	}
+	storage := h.getStorage(ctx)
+	for _, benchmark := range benchmarks {
+		provider, err := storage.GetProvider(benchmark.ProviderID)
+		if err != nil {
+			return err
+		}
+		if !provider.SupportsRuntime(evaluation.Runtime) {
+			return serviceerrors.NewServiceError(messages.RuntimeNotSupported, "ProviderID", benchmark.ProviderID, "Runtime", evaluation.Runtime)
+		}
+	}
+	return nil
+}
+
+// validateSecretRefRuntimes rejects the benchmarks with secretRef:// parameters that would
+// run on a runtime that cannot resolve them: the argo and lmevaljob runtimes hand the
+// parameters to the adapter as they are, so it would get the reference for the credential.
+func (h *Handlers) validateSecretRefRuntimes(ctx *executioncontext.ExecutionContext, evaluation *api.EvaluationJobConfig, benchmarks []api.EvaluationBenchmarkConfig) error {
+	enabled := h.enabledRuntimes()
+	if len(enabled) == 0 {
+		return nil
+	}
+	storage := h.getStorage(ctx)
+	for _, benchmark := range benchmarks {
+		provider, err := storage.GetProvider(benchmark.ProviderID)
+		if err != nil {
+			return err
+		}
+		if provider == nil {
+			continue
+		}
+		// the references are checked by validation.ValidateSecretRefs
+		refs, _ := api.FindSecretRefs(provider.WithDefaultParameters(benchmark).Parameters)
+		if len(refs) == 0 {
+			continue
+		}
+		runtime := benchmarkRuntime(evaluation, provider, enabled)
+		if runtime == api.RuntimeArgo || runtime == api.RuntimeLMEvalJob {
+			return serviceerrors.NewServiceError(messages.SecretRefsNotSupported, "ProviderID", benchmark.ProviderID, "BenchmarkID", benchmark.ID, "Runtime", runtime)
+		}
+	}
+	return nil
+}
+
+// benchmarkRuntime returns the runtime that a benchmark of the provider runs on, as the
+// runtime router selects it: the runtime of the job, or else the first enabled runtime that
+// the provider supports, starting with the default runtime.
+func benchmarkRuntime(evaluation *api.EvaluationJobConfig, provider *api.ProviderResource, enabled []string) string {
+	if evaluation.Runtime != "" {
+		return evaluation.Runtime
+	}
+	for _, name := range enabled {
+		if provider.SupportsRuntime(name) {
+			return name
+		}
+	}
+	return enabled[0]
+}
+
+// validateJobCluster checks that the cluster selected by a job is the cluster of the service
+// or a registered remote cluster.
+func (h *Handlers) validateJobCluster(evaluation *api.EvaluationJobConfig) error {
+	if evaluation.Cluster == "" || evaluation.Cluster == config.LocalClusterName {
+		return nil
+	}
+	var clusters *config.ClustersConfig
+	if h.serviceConfig != nil {
+		clusters = h.serviceConfig.Clusters
+	}
+	if clusters.Cluster(evaluation.Cluster) == nil {
+		registered := append([]string{config.LocalClusterName}, clusters.Names()...)
+		return serviceerrors.NewServiceError(messages.ClusterNotRegistered, "Cluster", evaluation.Cluster, "Clusters", strings.Join(registered, ", "))
+	}
+	return nil
+}
+
+// validateJobSpecVersions rejects the benchmarks whose adapter reads none of the job spec
+// versions that the server writes, or whose job spec needs fields that the version that the
+// adapter reads does not have, e.g. shard for a sharded benchmark.
+func (h *Handlers) validateJobSpecVersions(ctx *executioncontext.ExecutionContext, evaluation *api.EvaluationJobConfig, benchmarks []api.EvaluationBenchmarkConfig) error {
+	storage := h.getStorage(ctx)
+	callbackAuth := h.serviceConfig != nil && h.serviceConfig.CallbackAuth.IsEnabled()
+	for i := range benchmarks {
+		benchmark := &benchmarks[i]
+		provider, err := storage.GetProvider(benchmark.ProviderID)
+		if err != nil {
+			return err
+		}
+		version, err := shared.NegotiateJobSpecVersion(provider.JobSpecVersions)
+		if err == nil {
+			err = shared.CheckJobSpecVersion(evaluation, benchmark, callbackAuth, version)
+		}
+		if err != nil {
+			return serviceerrors.NewServiceError(messages.JobSpecVersionNotSupported, "ProviderID", benchmark.ProviderID, "BenchmarkID", benchmark.ID, "Reason", err.Error())
+		}
+	}
+	return nil
+}
+
+func (h *Handlers) enabledRuntimes() []string {
+	if h.serviceConfig != nil {
+		return h.serviceConfig.Service.EnabledRuntimes()
+	}
+	if h.runtime != nil {
+		return []string{h.runtime.Name()}
+	}
+	return nil
+}
+
+// applyDefaultParameters merges the default parameters that the providers define for the
+// benchmarks under the parameters given in the job.
+func applyDefaultParameters(storage providerGetter, benchmarks []api.EvaluationBenchmarkConfig) error {
+	for i, benchmark := range benchmarks {
+		provider, err := storage.GetProvider(benchmark.ProviderID)
+		if err != nil {
+			return err
+		}
+		if provider == nil {
+			continue
+		}
+		benchmarks[i] = provider.WithDefaultParameters(benchmark)
 	}
 	return nil
 }
@@ -351,7 +763,7 @@ func (h *Handlers) HandleListEvaluations(ctx *executioncontext.ExecutionContext,
 
 			logging.LogRequestStarted(ctx, "filter", filter)
 
-			allowedParams := []string{"limit", "offset", "status", "name", "tags", "owner", "experiment_id"}
+			allowedParams := []string{"limit", "offset", "status", "name", "tags", "owner", "experiment_id", "sweep_id", "annotation", "link", "model", "created_after", "created_before", "view"}
 			badParams := getAllParams(req, allowedParams...)
 			if len(badParams) > 0 {
 				// just report the first bad parameter
@@ -372,6 +784,61 @@ func (h *Handlers) HandleListEvaluations(ctx *executioncontext.ExecutionContext,
 			if experimentID != "" {
 				filter.Params["experiment_id"] = experimentID
 			}
+			sweepID, err := GetParam(req, "sweep_id", true, "")
+			if err != nil {
+				return err
+			}
+			if sweepID != "" {
+				filter.Params["sweep_id"] = sweepID
+			}
+			annotation, err := GetParam(req, "annotation", true, "")
+			if err != nil {
+				return err
+			}
+			if annotation != "" {
+				filter.Params["annotation"] = annotation
+			}
+			link, err := GetParam(req, "link", true, "")
+			if err != nil {
+				return err
+			}
+			if link != "" {
+				filter.Params["link"] = link
+			}
+			model, err := GetParam(req, "model", true, "")
+			if err != nil {
+				return err
+			}
+			if model != "" {
+				filter.Params["model"] = model
+			}
+			for _, param := range []string{"created_after", "created_before"} {
+				createdAt, err := GetTimeParam(req, param)
+				if err != nil {
+					return err
+				}
+				if createdAt != nil {
+					filter.Params[param] = *createdAt
+				}
+			}
+			viewID, err := GetParam(req, "view", true, "")
+			if err != nil {
+				return err
+			}
+			if viewID != "" {
+				view, err := storage.WithContext(runtimeCtx).GetEvaluationView(viewID)
+				if err != nil {
+					return err
+				}
+				// the query parameters refine the saved filter, e.g. to page through it
+				for name, value := range view.Filter.QueryParams() {
+					if current, ok := filter.Params[name]; !ok || current == "" {
+						filter.Params[name] = value
+					}
+				}
+			}
+
+			h.addJobVisibilityFilter(ctx, filter.Params)
 
 			ofilter = filter
 			return nil
@@ -400,9 +867,16 @@ func (h *Handlers) HandleListEvaluations(ctx *executioncontext.ExecutionContext,
 				w.Error(err, ctx.RequestID)
 				return err
 			}
+			items := res.Items
+			if ctx.Messages != nil {
+				items = make([]api.EvaluationJobResource, 0, len(res.Items))
+				for i := range res.Items {
+					items = append(items, *localizeJobMessages(ctx, &res.Items[i]))
+				}
+			}
 			result := api.EvaluationJobResourceList{
 				Page:   *page,
-				Items:  res.Items,
+				Items:  items,
 				Errors: res.Errors,
 			}
 			count = len(res.Items)
@@ -433,12 +907,13 @@ func (h *Handlers) HandleGetEvaluation(ctx *executioncontext.ExecutionContext, r
 	_ = h.withSpan(
 		ctx,
 		func(runtimeCtx context.Context) error {
-			response, err := storage.WithContext(runtimeCtx).GetEvaluationJob(evaluationJobID)
+			scoped := storage.WithContext(runtimeCtx)
+			response, err := h.getAccessibleEvaluationJob(ctx, scoped, evaluationJobID, jobAccessRead)
 			if err != nil {
 				w.Error(err, ctx.RequestID)
 				return err
 			}
-			w.WriteJSON(response, 200)
+			w.WriteJSON(withEstimatedCompletion(ctx, scoped, withAllowedNextStates(localizeJobMessages(ctx, response))), 200)
 			return nil
 		},
 		"storage",
@@ -447,7 +922,57 @@ func (h *Handlers) HandleGetEvaluation(ctx *executioncontext.ExecutionContext, r
 	)
 }
 
-func (h *Handlers) HandleUpdateEvaluation(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+// withAllowedNextStates returns the job with the states it can move to, so that clients
+// know which operations are valid, e.g. that a finished job can no longer be cancelled.
+func withAllowedNextStates(job *api.EvaluationJobResource) *api.EvaluationJobResource {
+	if job == nil || job.Status == nil {
+		return job
+	}
+	withStates := *job
+	status := *job.Status
+	status.AllowedNextStates = jobstate.NextStates(status.State)
+	withStates.Status = &status
+	return &withStates
+}
+
+// withEstimatedCompletion returns the job with when it is estimated to complete, if it is not
+// finished. The job is returned as it is when the estimate can not be made, which does not
+// fail the request.
+func withEstimatedCompletion(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, job *api.EvaluationJobResource) *api.EvaluationJobResource {
+	if job == nil || job.Status == nil || len(jobstate.NextStates(job.Status.State)) == 0 {
+		return job
+	}
+	var collection *api.CollectionResource
+	if job.Collection != nil && job.Collection.ID != "" {
+		var err error
+		if collection, err = storage.GetCollection(job.Collection.ID); err != nil {
+			ctx.Logger.Debug("Failed to get the collection of the job to estimate its completion", "id", job.Resource.ID, "error", err)
+			return job
+		}
+	}
+	benchmarks, err := GetJobBenchmarks(job, collection)
+	if err != nil {
+		return job
+	}
+	durations, err := storage.GetBenchmarkDurations(eta.Keys(benchmarks))
+	if err != nil {
+		ctx.Logger.Debug("Failed to get the durations of the benchmarks of the job", "id", job.Resource.ID, "error", err)
+		return job
+	}
+	completion, ok := eta.Completion(job, benchmarks, durations, time.Now())
+	if !ok {
+		return job
+	}
+	withEstimate := *job
+	status := *job.Status
+	status.EstimatedCompletionAt = api.DateTimeToString(completion.UTC())
+	withEstimate.Status = &status
+	return &withEstimate
+}
+
+// HandlePatchEvaluation handles PATCH /api/v1/evaluations/jobs/{id}, only the annotations
+// and links of a job can be patched.
+func (h *Handlers) HandlePatchEvaluation(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
 	storage := h.getStorage(ctx)
 
 	logging.LogRequestStarted(ctx)
@@ -459,20 +984,25 @@ func (h *Handlers) HandleUpdateEvaluation(ctx *executioncontext.ExecutionContext
 		return
 	}
 
-	var status = &api.StatusEvent{}
+	var patches api.Patch
 
 	err := h.withSpan(
 		ctx,
 		func(runtimeCtx context.Context) error {
-			// get the body bytes from the context
 			bodyBytes, err := r.BodyAsBytes()
 			if err != nil {
 				return err
 			}
-			return serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, status)
+			if err = json.Unmarshal(bodyBytes, &patches); err != nil {
+				return serviceerrors.NewServiceError(messages.InvalidJSONRequest, "Error", err.Error())
+			}
+			if err := h.verifyPatches(runtimeCtx, patches, allowedEvaluationJobPatches); err != nil {
+				return err
+			}
+			return h.validatePatchedEvaluationJobMetadata(ctx.WithContext(runtimeCtx), storage, evaluationJobID, patches)
 		},
 		"validation",
-		"validate-evaluation-job",
+		"validate-evaluation-job-patch",
 		"job.id", evaluationJobID,
 	)
 	if err != nil {
@@ -480,35 +1010,93 @@ func (h *Handlers) HandleUpdateEvaluation(ctx *executioncontext.ExecutionContext
 		return
 	}
 
-	if status.BenchmarkStatusEvent != nil {
-		status.BenchmarkStatusEvent.StampRuntimeMessageOrigins()
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			job, err := storage.WithContext(runtimeCtx).PatchEvaluationJob(evaluationJobID, &patches)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(localizeJobMessages(ctx, job), 200)
+			return nil
+		},
+		"storage",
+		"patch-evaluation-job",
+		"job.id", evaluationJobID,
+	)
+}
+
+func (h *Handlers) validatePatchedEvaluationJobMetadata(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, evaluationJobID string, patches api.Patch) error {
+	existing, err := h.getAccessibleEvaluationJob(ctx, storage, evaluationJobID, jobAccessChange)
+	if err != nil {
+		return err
+	}
+	metadataJSON, err := json.Marshal(existing.EvaluationJobMetadata)
+	if err != nil {
+		return serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	patchedJSON, err := applyJSONPatches(metadataJSON, &patches)
+	if err != nil {
+		return serviceerrors.NewServiceError(messages.InvalidJSONRequest, "Error", err.Error())
 	}
+	return serialization.Unmarshal(h.validate, ctx, patchedJSON, &api.EvaluationJobMetadata{})
+}
 
-	ctx.Logger.Debug("Updating evaluation job", "id", evaluationJobID, "state", status.BenchmarkStatusEvent.Status, "status", status)
+func (h *Handlers) HandleUpdateEvaluation(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	// Extract ID from path
+	evaluationJobID := r.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+
+	// only the pods of the job hold its callback token
+	if h.serviceConfig != nil && !callbackauth.Verify(h.serviceConfig.CallbackAuth, evaluationJobID, r.Header(callbackauth.TokenHeader)) {
+		ctx.Logger.Warn("Rejected evaluation job update without a valid callback token", "job_id", evaluationJobID)
+		w.Error(serviceerrors.NewServiceError(messages.CallbackTokenInvalid, "EvaluationJobID", evaluationJobID), ctx.RequestID)
+		return
+	}
 
-	var previousState api.OverallState
+	var body []byte
+	var status *api.StatusEvent
 
-	_ = h.withSpan(
+	err := h.withSpan(
 		ctx,
 		func(runtimeCtx context.Context) error {
-			scoped := storage.WithContext(runtimeCtx)
-			job, jobErr := scoped.GetEvaluationJob(evaluationJobID)
-			if jobErr == nil && job != nil && job.Status != nil {
-				previousState = job.Status.State
-			}
-			if status.BenchmarkStatusEvent != nil {
-				h.rewriteSidecarURLsInBenchmarkStatus(status.BenchmarkStatusEvent, job, ctx.Logger)
+			// get the body bytes from the context
+			bodyBytes, err := r.BodyAsBytes()
+			if err != nil {
+				return err
 			}
+			body = bodyBytes
+			status, err = h.parseStatusEvent(ctx.WithContext(runtimeCtx), body)
+			return err
+		},
+		"validation",
+		"validate-evaluation-job",
+		"job.id", evaluationJobID,
+	)
+	if err != nil {
+		h.deadLetterStatusEvent(ctx, evaluationJobID, body, err)
+		w.Error(err, ctx.RequestID)
+		return
+	}
 
-			err = scoped.UpdateEvaluationJob(evaluationJobID, status)
-			if err != nil {
+	ctx.Logger.Debug("Updating evaluation job", "id", evaluationJobID, "state", status.BenchmarkStatusEvent.Status, "status", status)
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			if err := h.applyStatusEvent(ctx, runtimeCtx, storage, evaluationJobID, status); err != nil {
+				h.deadLetterStatusEvent(ctx, evaluationJobID, body, err)
 				w.Error(err, ctx.RequestID)
 				return err
 			}
-
-			h.onEvaluationJobUpdated(runtimeCtx, scoped, func() (*api.EvaluationJobResource, error) {
-				return scoped.GetEvaluationJob(evaluationJobID)
-			}, previousState, ctx.Logger)
 			w.WriteJSON(nil, 204)
 			return nil
 		},
@@ -518,6 +1106,52 @@ func (h *Handlers) HandleUpdateEvaluation(ctx *executioncontext.ExecutionContext
 	)
 }
 
+// parseStatusEvent validates the body of a status event sent for a job.
+func (h *Handlers) parseStatusEvent(ctx *executioncontext.ExecutionContext, body []byte) (*api.StatusEvent, error) {
+	status := &api.StatusEvent{}
+	if err := serialization.Unmarshal(h.validate, ctx, body, status); err != nil {
+		return nil, err
+	}
+	if status.BenchmarkStatusEvent != nil {
+		status.BenchmarkStatusEvent.StampRuntimeMessageOrigins()
+	}
+	return status, nil
+}
+
+// applyStatusEvent stores a status event sent for the job, then starts the benchmarks that
+// it made ready and the retries that it scheduled.
+func (h *Handlers) applyStatusEvent(ctx *executioncontext.ExecutionContext, runtimeCtx context.Context, storage abstractions.Storage, evaluationJobID string, status *api.StatusEvent) error {
+	scoped := storage.WithContext(runtimeCtx)
+	job, _ := scoped.GetEvaluationJob(evaluationJobID)
+	if status.BenchmarkStatusEvent != nil {
+		h.rewriteSidecarURLsInBenchmarkStatus(status.BenchmarkStatusEvent, job, ctx.Logger)
+		h.linkMLFlowRun(runtimeCtx, ctx.Logger, status.BenchmarkStatusEvent, job)
+		if job != nil {
+			if err := redactStatusEvent(scoped.WithTenant(job.Resource.Tenant), evaluationJobID, status.BenchmarkStatusEvent); err != nil {
+				return err
+			}
+		}
+	}
+
+	update, err := scoped.UpdateEvaluationJob(evaluationJobID, status)
+	if err != nil {
+		return err
+	}
+	if update.Ignored != "" {
+		// a retried or late event is accepted so that the adapter does not send it again
+		metrics.RecordStatusEventIgnored(runtimeCtx, status.BenchmarkStatusEvent.ProviderID, update.Ignored)
+		return nil
+	}
+	runtimeStorage := h.createRuntimeStorage(ctx, context.Background())
+	h.startReadyBenchmarks(runtimeCtx, scoped, runtimeStorage, evaluationJobID, update.ReadyBenchmarks, ctx.Logger)
+	h.retryBenchmarks(runtimeCtx, scoped, runtimeStorage, evaluationJobID, update.RetryBenchmarks, ctx.Logger)
+
+	h.onEvaluationJobUpdated(runtimeCtx, scoped, func() (*api.EvaluationJobResource, error) {
+		return scoped.GetEvaluationJob(evaluationJobID)
+	}, update.PreviousState, ctx.Logger)
+	return nil
+}
+
 // HandleCancelEvaluation handles DELETE /api/v1/evaluations/jobs/{id}
 func (h *Handlers) HandleCancelEvaluation(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
 	storage := h.getStorage(ctx)
@@ -531,6 +1165,23 @@ func (h *Handlers) HandleCancelEvaluation(ctx *executioncontext.ExecutionContext
 		return
 	}
 
+	if h.isJobAccessScoped(ctx) {
+		err := h.withSpan(
+			ctx,
+			func(runtimeCtx context.Context) error {
+				_, err := h.getAccessibleEvaluationJob(ctx, storage.WithContext(runtimeCtx), evaluationJobID, jobAccessCancel)
+				return err
+			},
+			"storage",
+			"check-evaluation-job-access",
+			"job.id", evaluationJobID,
+		)
+		if err != nil {
+			w.Error(err, ctx.RequestID)
+			return
+		}
+	}
+
 	err := h.withSpan(
 		ctx,
 		func(runtimeCtx context.Context) error {
@@ -589,7 +1240,7 @@ func (h *Handlers) HandleCancelEvaluation(ctx *executioncontext.ExecutionContext
 				if jobErr == nil && job != nil && job.Status != nil {
 					previousState = job.Status.State
 				}
-				err = storage.WithContext(runtimeCtx).UpdateEvaluationJobStatus(evaluationJobID, api.OverallStateCancelled, api.WithMessageOrigin(&api.MessageInfo{
+				_, err = storage.WithContext(runtimeCtx).UpdateEvaluationJobStatus(evaluationJobID, api.OverallStateCancelled, api.WithMessageOrigin(&api.MessageInfo{
 					Message:     "Evaluation job cancelled",
 					MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_CANCELLED,
 				}, api.MessageOriginServer))
@@ -9,16 +9,22 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/server"
+	"github.com/eval-hub/eval-hub/internal/logging"
 	"github.com/eval-hub/eval-hub/internal/testhelpers"
 	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/eval-hub/eval-hub/pkg/mlflowclient"
 )
 
 type bodyRequest struct {
@@ -93,10 +99,22 @@ func (f *fakeStorage) CreateEvaluationJob(_ *api.EvaluationJobResource) error {
 	return nil
 }
 
-func (f *fakeStorage) UpdateEvaluationJobStatus(id string, state api.OverallState, message *api.MessageInfo) error {
+func (f *fakeStorage) GetTagPolicy() (*api.TagPolicyResource, error) {
+	return nil, nil
+}
+
+func (f *fakeStorage) GetRedactionPolicy() (*api.RedactionPolicyResource, error) {
+	return nil, nil
+}
+
+func (f *fakeStorage) AddStatusDeadLetter(_ *api.StatusDeadLetter) error {
+	return nil
+}
+
+func (f *fakeStorage) UpdateEvaluationJobStatus(id string, state api.OverallState, message *api.MessageInfo) (api.OverallState, error) {
 	f.lastStatusID = id
 	f.lastStatus = state
-	return nil
+	return "", nil
 }
 
 func (f *fakeStorage) GetEvaluationJob(_ string) (*api.EvaluationJobResource, error) {
@@ -107,8 +125,8 @@ func (f *fakeStorage) GetEvaluationJobs(_ *abstractions.QueryFilter) (*abstracti
 	return &abstractions.QueryResults[api.EvaluationJobResource]{Items: []api.EvaluationJobResource{}, TotalCount: 0}, nil
 }
 
-func (f *fakeStorage) UpdateEvaluationJob(_ string, _ *api.StatusEvent) error {
-	return nil
+func (f *fakeStorage) UpdateEvaluationJob(_ string, _ *api.StatusEvent) (*abstractions.EvaluationJobUpdate, error) {
+	return &abstractions.EvaluationJobUpdate{}, nil
 }
 
 func (f *fakeStorage) DeleteEvaluationJob(id string) error {
@@ -117,8 +135,12 @@ func (f *fakeStorage) DeleteEvaluationJob(id string) error {
 }
 
 type fakeRuntime struct {
-	err    error
-	called bool
+	err               error
+	called            bool
+	benchmarks        []api.EvaluationBenchmarkConfig
+	startedBenchmarks []int
+	spec              []byte
+	specIndices       []int
 }
 
 func (r *fakeRuntime) WithLogger(_ *slog.Logger) abstractions.Runtime { return r }
@@ -127,11 +149,22 @@ func (r *fakeRuntime) WithContext(_ context.Context) abstractions.Runtime {
 }
 func (r *fakeRuntime) Name() string { return "fake" }
 func (r *fakeRuntime) RunEvaluationJob(
+	_ *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	_ abstractions.RuntimeStorage,
+) error {
+	r.called = true
+	r.benchmarks = benchmarks
+	return r.err
+}
+func (r *fakeRuntime) RunEvaluationBenchmarks(
 	_ *api.EvaluationJobResource,
 	_ []api.EvaluationBenchmarkConfig,
+	benchmarkIndices []int,
 	_ abstractions.RuntimeStorage,
 ) error {
 	r.called = true
+	r.startedBenchmarks = append(r.startedBenchmarks, benchmarkIndices...)
 	return r.err
 }
 func (r *fakeRuntime) DeleteEvaluationJobResources(_ *api.EvaluationJobResource) error {
@@ -150,6 +183,20 @@ func (r *fakeRuntime) GetEvaluationLogs(
 	}
 	return "", nil
 }
+func (r *fakeRuntime) GetEvaluationJobSpec(
+	_ *api.EvaluationJobResource,
+	_ []api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	shardIndex int,
+	_ abstractions.RuntimeStorage,
+) ([]byte, error) {
+	r.called = true
+	r.specIndices = []int{benchmarkIndex, shardIndex}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.spec, nil
+}
 
 type listEvaluationsRequest struct {
 	*MockRequest
@@ -204,9 +251,12 @@ func (s *updateEvaluationStorage) WithContext(_ context.Context) abstractions.St
 func (s *updateEvaluationStorage) WithTenant(_ api.Tenant) abstractions.Storage { return s }
 func (s *updateEvaluationStorage) WithOwner(_ api.User) abstractions.Storage    { return s }
 
-func (s *updateEvaluationStorage) UpdateEvaluationJob(_ string, status *api.StatusEvent) error {
+func (s *updateEvaluationStorage) UpdateEvaluationJob(_ string, status *api.StatusEvent) (*abstractions.EvaluationJobUpdate, error) {
 	s.lastStatusEvent = status
-	return s.updateErr
+	if s.updateErr != nil {
+		return nil, s.updateErr
+	}
+	return &abstractions.EvaluationJobUpdate{}, nil
 }
 
 func TestResolveProvider_FromMap(t *testing.T) {
@@ -795,6 +845,59 @@ func TestHandleUpdateEvaluationAcceptsValidPhase(t *testing.T) {
 	}
 }
 
+func TestHandleUpdateEvaluationRequiresCallbackToken(t *testing.T) {
+	t.Parallel()
+	callbackAuth := &config.CallbackAuthConfig{Enabled: true, Secret: "test-secret"}
+	validate := testhelpers.NewValidator(t)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name     string
+		token    string
+		wantCode int
+	}{
+		{name: "missing token", token: "", wantCode: 401},
+		{name: "token of another job", token: callbackauth.Token(callbackAuth, "job-other"), wantCode: 401},
+		{name: "token of the job", token: callbackauth.Token(callbackAuth, "job-callback"), wantCode: 204},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &updateEvaluationStorage{fakeStorage: &fakeStorage{}}
+			h := handlers.New(storage, validate, &fakeRuntime{}, nil, &config.Config{CallbackAuth: callbackAuth}, nil)
+
+			body := `{"benchmark_status_event":{"provider_id":"p1","id":"b1","status":"running"}}`
+			req := &bodyRequest{
+				MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs/job-callback/events"),
+				body:        []byte(body),
+			}
+			if tt.token != "" {
+				req.SetHeader(callbackauth.TokenHeader, tt.token)
+			}
+			reqWithPath := &updateEvaluationRequest{
+				bodyRequest: req,
+				pathValues:  map[string]string{"job_id": "job-callback"},
+			}
+			recorder := httptest.NewRecorder()
+			resp := MockResponseWrapper{recorder: recorder}
+			ctx := executioncontext.NewExecutionContext(context.Background(), "req-callback", logger, "test-user", "test-tenant")
+
+			h.HandleUpdateEvaluation(ctx, reqWithPath, resp)
+
+			if recorder.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d body %s", tt.wantCode, recorder.Code, recorder.Body.String())
+			}
+			if tt.wantCode == 401 {
+				if !strings.Contains(recorder.Body.String(), "callback_token_invalid") {
+					t.Fatalf("expected callback_token_invalid in body, got %q", recorder.Body.String())
+				}
+				if storage.lastStatusEvent != nil {
+					t.Fatal("expected the rejected status event not to be stored")
+				}
+			}
+		})
+	}
+}
+
 func TestHandleUpdateEvaluationStampsRuntimeMessageOrigins(t *testing.T) {
 	t.Parallel()
 	storage := &updateEvaluationStorage{fakeStorage: &fakeStorage{}}
@@ -1132,6 +1235,243 @@ func TestHandleCreateEvaluationRejectsInvalidQueueName(t *testing.T) {
 	}
 }
 
+func TestHandleCreateEvaluationRejectsUnknownNotifyTarget(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	providerConfigs := map[string]api.ProviderResource{
+		"garak": {
+			Resource:       api.Resource{ID: "garak"},
+			ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}}},
+		},
+	}
+	serviceConfig := &config.Config{Notifications: &config.NotificationsConfig{Notifiers: []config.NotifierConfig{
+		{Name: "ml-evals", Type: config.NotifierTypeSlack, Slack: &config.SlackNotifierConfig{WebhookURL: "https://hooks.example.com/x", Channel: "#ml-evals"}},
+	}}}
+
+	for target, code := range map[string]int{"ml-evals": 202, "#ml-evals": 202, "#other": 400} {
+		t.Run(target, func(t *testing.T) {
+			h := handlers.New(&fakeStorage{providerConfigs: providerConfigs}, testhelpers.NewValidator(t), &fakeRuntime{}, nil, serviceConfig, nil)
+			body := fmt.Sprintf(`{"name":"test-job","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}],"notify":[%q]}`, target)
+			req := &bodyRequest{
+				MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+				body:        []byte(body),
+			}
+			ctx := executioncontext.NewExecutionContext(context.Background(), "req-notify", logger, "test-user", "test-tenant")
+			recorder := httptest.NewRecorder()
+
+			h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+			if recorder.Code != code {
+				t.Fatalf("expected status %d for notify target %q, got %d: %s", code, target, recorder.Code, recorder.Body.String())
+			}
+			if code == 400 && !strings.Contains(recorder.Body.String(), "notify_target_unknown") {
+				t.Errorf("expected the notify_target_unknown error, got %s", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleCreateEvaluationRejectsTooManyBenchmarks(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	providerConfigs := map[string]api.ProviderResource{
+		"garak": {
+			Resource:       api.Resource{ID: "garak"},
+			ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}, {ID: "bench-2"}}},
+		},
+	}
+	serviceConfig := &config.Config{Sampling: &config.SamplingConfig{
+		SamplingLimits: config.SamplingLimits{MaxBenchmarks: 2},
+		Tenants:        map[string]config.SamplingLimits{"small-tenant": {MaxBenchmarks: 1}},
+	}}
+	body := `{"name":"test-job","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"},{"id":"bench-2","provider_id":"garak"}]}`
+
+	for tenant, code := range map[string]int{"test-tenant": 202, "small-tenant": 400} {
+		t.Run(tenant, func(t *testing.T) {
+			h := handlers.New(&fakeStorage{providerConfigs: providerConfigs}, testhelpers.NewValidator(t), &fakeRuntime{}, nil, serviceConfig, nil)
+			req := &bodyRequest{
+				MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+				body:        []byte(body),
+			}
+			ctx := executioncontext.NewExecutionContext(context.Background(), "req-max-benchmarks", logger, "test-user", api.Tenant(tenant))
+			recorder := httptest.NewRecorder()
+
+			h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+			if recorder.Code != code {
+				t.Fatalf("expected status %d for tenant %q, got %d: %s", code, tenant, recorder.Code, recorder.Body.String())
+			}
+			if code == 400 && !strings.Contains(recorder.Body.String(), "too_many_benchmarks") {
+				t.Errorf("expected the too_many_benchmarks error, got %s", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleCreateEvaluationChecksTheJobEnvAllowlist(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	providerConfigs := map[string]api.ProviderResource{
+		"garak": {
+			Resource:       api.Resource{ID: "garak"},
+			ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}}},
+		},
+	}
+	serviceConfig := &config.Config{JobEnv: &config.JobEnvConfig{
+		JobEnvAllowlist: config.JobEnvAllowlist{Names: []string{"HF_*", "DATASET_MIRROR"}, Secrets: []string{"hf-credentials"}},
+		Tenants:         map[string]config.JobEnvAllowlist{"locked-tenant": {}},
+	}}
+
+	for name, tc := range map[string]struct {
+		tenant string
+		env    string
+		code   int
+	}{
+		"allowed":            {tenant: "test-tenant", env: `{"name":"HF_TOKEN","value":"secretRef://hf-credentials/token"},{"name":"DATASET_MIRROR","value":"https://mirror.example"}`, code: 202},
+		"name not allowed":   {tenant: "test-tenant", env: `{"name":"LD_PRELOAD","value":"/tmp/x.so"}`, code: 400},
+		"secret not allowed": {tenant: "test-tenant", env: `{"name":"HF_TOKEN","value":"secretRef://db-credentials/password"}`, code: 400},
+		"malformed secret":   {tenant: "test-tenant", env: `{"name":"HF_TOKEN","value":"secretRef://hf-credentials"}`, code: 400},
+		"invalid name":       {tenant: "test-tenant", env: `{"name":"HF-TOKEN","value":"x"}`, code: 400},
+		"tenant allowlist":   {tenant: "locked-tenant", env: `{"name":"HF_HOME","value":"/data/hf"}`, code: 400},
+	} {
+		t.Run(name, func(t *testing.T) {
+			h := handlers.New(&fakeStorage{providerConfigs: providerConfigs}, testhelpers.NewValidator(t), &fakeRuntime{}, nil, serviceConfig, nil)
+			body := fmt.Sprintf(`{"name":"test-job","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}],"env":[%s]}`, tc.env)
+			req := &bodyRequest{
+				MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+				body:        []byte(body),
+			}
+			ctx := executioncontext.NewExecutionContext(context.Background(), "req-job-env", logger, "test-user", api.Tenant(tc.tenant))
+			recorder := httptest.NewRecorder()
+
+			h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+			if recorder.Code != tc.code {
+				t.Fatalf("expected status %d, got %d: %s", tc.code, recorder.Code, recorder.Body.String())
+			}
+			if tc.code == 400 && !strings.Contains(recorder.Body.String(), "job_env_not_allowed") {
+				t.Errorf("expected the job_env_not_allowed error, got %s", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleCreateEvaluationAppliesTheMLFlowTenantMapping(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	// the experiments are linked in the background, MLflow failing leaves them to the retries
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+	providerConfigs := map[string]api.ProviderResource{
+		"garak": {
+			Resource:       api.Resource{ID: "garak"},
+			ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}}},
+		},
+	}
+	serviceConfig := &config.Config{MLFlow: &config.MLFlowConfig{Tenants: map[string]config.MLFlowTenantConfig{
+		"team-a": {Workspaces: []string{"team-a-evals", "team-a-release"}, ExperimentPrefix: "team-a/", DefaultExperiment: "nightly"},
+	}}}
+
+	for name, tc := range map[string]struct {
+		tenant     string
+		experiment string
+		code       int
+		want       *api.ExperimentConfig
+	}{
+		"default experiment":          {tenant: "team-a", code: 202, want: &api.ExperimentConfig{Name: "team-a/nightly", Workspace: "team-a-evals"}},
+		"prefixed experiment":         {tenant: "team-a", experiment: `{"name":"release"}`, code: 202, want: &api.ExperimentConfig{Name: "team-a/release", Workspace: "team-a-evals"}},
+		"already prefixed":            {tenant: "team-a", experiment: `{"name":"team-a/release","workspace":"team-a-release"}`, code: 202, want: &api.ExperimentConfig{Name: "team-a/release", Workspace: "team-a-release"}},
+		"workspace of another team":   {tenant: "team-a", experiment: `{"name":"release","workspace":"team-b"}`, code: 403},
+		"tenant not mapped":           {tenant: "team-b", code: 202},
+		"own workspace of the tenant": {tenant: "team-b", experiment: `{"name":"release","workspace":"team-b"}`, code: 202, want: &api.ExperimentConfig{Name: "release", Workspace: "team-b"}},
+		"mapped workspace of a team":  {tenant: "team-b", experiment: `{"name":"release","workspace":"team-a-evals"}`, code: 403},
+	} {
+		t.Run(name, func(t *testing.T) {
+			h := handlers.New(&fakeStorage{providerConfigs: providerConfigs}, testhelpers.NewValidator(t), &fakeRuntime{}, mlflowclient.NewClient(srv.URL), serviceConfig, nil)
+			body := `{"name":"test-job","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}]}`
+			if tc.experiment != "" {
+				body = strings.Replace(body, `"name":"test-job",`, `"name":"test-job","experiment":`+tc.experiment+`,`, 1)
+			}
+			req := &bodyRequest{
+				MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+				body:        []byte(body),
+			}
+			ctx := executioncontext.NewExecutionContext(context.Background(), "req-mlflow-tenant", logger, "test-user", api.Tenant(tc.tenant))
+			recorder := httptest.NewRecorder()
+
+			h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+			if recorder.Code != tc.code {
+				t.Fatalf("expected status %d, got %d: %s", tc.code, recorder.Code, recorder.Body.String())
+			}
+			if tc.code == 403 {
+				if !strings.Contains(recorder.Body.String(), "mlflow_workspace_not_allowed") {
+					t.Errorf("expected the mlflow_workspace_not_allowed error, got %s", recorder.Body.String())
+				}
+				return
+			}
+			var job api.EvaluationJobResource
+			if err := json.Unmarshal(recorder.Body.Bytes(), &job); err != nil {
+				t.Fatalf("failed to decode the job: %v", err)
+			}
+			if tc.want == nil {
+				if job.Experiment != nil {
+					t.Errorf("expected no experiment, got %+v", job.Experiment)
+				}
+				return
+			}
+			if job.Experiment == nil || job.Experiment.Name != tc.want.Name || job.Experiment.Workspace != tc.want.Workspace {
+				t.Errorf("expected the experiment %+v, got %+v", tc.want, job.Experiment)
+			}
+		})
+	}
+}
+
+func TestHandleCreateEvaluationRejectsIncompatibleJobSpecVersion(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	providerConfigs := map[string]api.ProviderResource{
+		"legacy": {
+			Resource:       api.Resource{ID: "legacy"},
+			ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}}, JobSpecVersions: []int{1}},
+		},
+		"future": {
+			Resource:       api.Resource{ID: "future"},
+			ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}}, JobSpecVersions: []int{99}},
+		},
+	}
+
+	for name, tc := range map[string]struct {
+		benchmark string
+		code      int
+	}{
+		"version 1 adapter":             {`{"id":"bench-1","provider_id":"legacy"}`, 202},
+		"version 1 adapter with shards": {`{"id":"bench-1","provider_id":"legacy","shards":2}`, 400},
+		"adapter of an unknown version": {`{"id":"bench-1","provider_id":"future"}`, 400},
+	} {
+		t.Run(name, func(t *testing.T) {
+			h := handlers.New(&fakeStorage{providerConfigs: providerConfigs}, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+			req := &bodyRequest{
+				MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+				body:        []byte(`{"name":"test-job","model":{"url":"http://test.com","name":"test"},"benchmarks":[` + tc.benchmark + `]}`),
+			}
+			ctx := executioncontext.NewExecutionContext(context.Background(), "req-job-spec-version", logger, "test-user", "test-tenant")
+			recorder := httptest.NewRecorder()
+
+			h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+			if recorder.Code != tc.code {
+				t.Fatalf("expected status %d, got %d: %s", tc.code, recorder.Code, recorder.Body.String())
+			}
+			if tc.code == 400 && !strings.Contains(recorder.Body.String(), "job_spec_version_not_supported") {
+				t.Errorf("expected the job_spec_version_not_supported error, got %s", recorder.Body.String())
+			}
+		})
+	}
+}
+
 func TestHandleCreateEvaluationRejectsInvalidHardwareProfileRef(t *testing.T) {
 	t.Parallel()
 	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
@@ -1168,3 +1508,548 @@ func TestHandleCreateEvaluationRejectsInvalidHardwareProfileRef(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleCreateEvaluationAppliesProviderDefaultParameters(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	providerConfigs := map[string]api.ProviderResource{
+		"lm_evaluation_harness": {
+			Resource: api.Resource{ID: "lm_evaluation_harness"},
+			ProviderConfig: api.ProviderConfig{
+				Benchmarks: []api.BenchmarkResource{
+					{
+						ID: "gsm8k",
+						Parameters: map[string]any{
+							"num_fewshot": 5,
+							"batch_size":  8,
+							"gen_kwargs":  map[string]any{"temperature": 0, "max_gen_toks": 256},
+						},
+					},
+					{ID: "arc_easy"},
+				},
+			},
+		},
+	}
+	storage := &fakeStorage{providerConfigs: providerConfigs}
+	runtime := &fakeRuntime{}
+	h := handlers.New(storage, testhelpers.NewValidator(t), runtime, nil, nil, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-defaults", logger, "test-user", "test-tenant")
+
+	req := &bodyRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+		body: []byte(`{"name": "test-evaluation-job", "model":{"url":"http://test.com","name":"test"},"benchmarks":[
+			{"id":"gsm8k","provider_id":"lm_evaluation_harness","parameters":{"batch_size":16,"gen_kwargs":{"temperature":0.7}}},
+			{"id":"arc_easy","provider_id":"lm_evaluation_harness"}]}`),
+	}
+	recorder := httptest.NewRecorder()
+	h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+	if recorder.Code != 202 {
+		t.Fatalf("expected status 202, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if len(runtime.benchmarks) != 2 {
+		t.Fatalf("expected 2 benchmarks passed to the runtime, got %d", len(runtime.benchmarks))
+	}
+	got, err := json.Marshal(runtime.benchmarks[0].Parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"batch_size":16,"gen_kwargs":{"max_gen_toks":256,"temperature":0.7},"num_fewshot":5}`
+	if string(got) != want {
+		t.Fatalf("expected parameters %s, got %s", want, got)
+	}
+	if len(runtime.benchmarks[1].Parameters) != 0 {
+		t.Fatalf("expected no parameters for a benchmark without defaults, got %v", runtime.benchmarks[1].Parameters)
+	}
+
+	var job api.EvaluationJobResource
+	if err := json.Unmarshal(recorder.Body.Bytes(), &job); err != nil {
+		t.Fatalf("failed to decode the response: %v", err)
+	}
+	if job.Benchmarks[0].Parameters["num_fewshot"] != float64(5) {
+		t.Fatalf("expected the response to show the default num_fewshot, got %v", job.Benchmarks[0].Parameters)
+	}
+}
+
+func TestHandleCreateEvaluationAppliesProviderDefaultParametersToCollectionBenchmarks(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	storage := &logsCollectionStorage{
+		fakeStorage: fakeStorage{
+			providerConfigs: map[string]api.ProviderResource{
+				"lm_evaluation_harness": {
+					Resource: api.Resource{ID: "lm_evaluation_harness"},
+					ProviderConfig: api.ProviderConfig{
+						Benchmarks: []api.BenchmarkResource{
+							{ID: "gsm8k", Parameters: map[string]any{"num_fewshot": 5, "batch_size": 8}},
+							{ID: "arc_easy"},
+						},
+					},
+				},
+			},
+			collectionConfigs: map[string]api.CollectionResource{
+				"coll-1": {
+					Resource: api.Resource{ID: "coll-1"},
+					CollectionConfig: api.CollectionConfig{
+						Name: "reasoning",
+						Benchmarks: []api.CollectionBenchmarkConfig{
+							{Ref: api.Ref{ID: "gsm8k"}, ProviderID: "lm_evaluation_harness", Parameters: map[string]any{"batch_size": 16}},
+							{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"},
+						},
+					},
+				},
+			},
+		},
+	}
+	runtime := &fakeRuntime{}
+	h := handlers.New(storage, testhelpers.NewValidator(t), runtime, nil, nil, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-collection-defaults", logger, "test-user", "test-tenant")
+
+	req := &bodyRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+		body:        []byte(`{"name": "test-evaluation-job", "model":{"url":"http://test.com","name":"test"},"collection":{"id":"coll-1"}}`),
+	}
+	recorder := httptest.NewRecorder()
+	h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+	if recorder.Code != 202 {
+		t.Fatalf("expected status 202, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if len(runtime.benchmarks) != 2 {
+		t.Fatalf("expected 2 benchmarks passed to the runtime, got %d", len(runtime.benchmarks))
+	}
+	got, err := json.Marshal(runtime.benchmarks[0].Parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"batch_size":16,"num_fewshot":5}`; string(got) != want {
+		t.Fatalf("expected parameters %s, got %s", want, got)
+	}
+	if len(runtime.benchmarks[1].Parameters) != 0 {
+		t.Fatalf("expected no parameters for a benchmark without defaults, got %v", runtime.benchmarks[1].Parameters)
+	}
+	if storage.collectionConfigs["coll-1"].Benchmarks[0].Parameters["num_fewshot"] != nil {
+		t.Fatalf("expected the collection to be left unchanged")
+	}
+}
+
+// patchEvaluationStorage applies the job patches to an in-memory job.
+type patchEvaluationStorage struct {
+	abstractions.Storage
+	job     *api.EvaluationJobResource
+	patched bool
+}
+
+func (s *patchEvaluationStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *patchEvaluationStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *patchEvaluationStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *patchEvaluationStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *patchEvaluationStorage) GetEvaluationJob(_ string) (*api.EvaluationJobResource, error) {
+	return s.job, nil
+}
+
+func (s *patchEvaluationStorage) PatchEvaluationJob(_ string, patches *api.Patch) (*api.EvaluationJobResource, error) {
+	s.patched = true
+	for _, patch := range *patches {
+		if patch.Path == "/annotations/ticket" {
+			s.job.Annotations["ticket"] = patch.Value.(string)
+		}
+	}
+	return s.job, nil
+}
+
+func TestHandlePatchEvaluation(t *testing.T) {
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-patch-job", logging.FallbackLogger(), "test-user", "test-tenant")
+
+	patch := func(t *testing.T, body string) (*patchEvaluationStorage, *httptest.ResponseRecorder) {
+		t.Helper()
+		storage := &patchEvaluationStorage{job: &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1"}},
+			Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateCompleted}},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Name:                  "nightly",
+				EvaluationJobMetadata: api.EvaluationJobMetadata{Annotations: map[string]string{"team": "ml"}},
+			},
+		}}
+		h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+		req := &providersRequest{
+			MockRequest: createMockRequest("PATCH", "/api/v1/evaluations/jobs/job-1"),
+			pathValues:  map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-1"},
+		}
+		req.SetBody([]byte(body))
+		recorder := httptest.NewRecorder()
+		h.HandlePatchEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+		return storage, recorder
+	}
+
+	t.Run("annotations of a completed job can be patched", func(t *testing.T) {
+		storage, recorder := patch(t, `[{"op": "add", "path": "/annotations/ticket", "value": "INC-42"}]`)
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if storage.job.Annotations["ticket"] != "INC-42" {
+			t.Fatalf("unexpected annotations %v", storage.job.Annotations)
+		}
+	})
+
+	t.Run("the rest of the job config can not be patched", func(t *testing.T) {
+		storage, recorder := patch(t, `[{"op": "replace", "path": "/name", "value": "hacked"}]`)
+		if recorder.Code != 400 || storage.patched {
+			t.Fatalf("expected the patch to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("links are validated", func(t *testing.T) {
+		storage, recorder := patch(t, `[{"op": "add", "path": "/links", "value": [{"type": "wiki", "url": "https://wiki.example.com"}]}]`)
+		if recorder.Code != 400 || storage.patched {
+			t.Fatalf("expected an unknown link type to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		storage, recorder = patch(t, `[{"op": "add", "path": "/links", "value": [{"type": "pull_request", "url": "not a url"}]}]`)
+		if recorder.Code != 400 || storage.patched {
+			t.Fatalf("expected an invalid link URL to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("annotation keys can not hold the filter separators", func(t *testing.T) {
+		storage, recorder := patch(t, `[{"op": "add", "path": "/annotations/a:b", "value": "c"}]`)
+		if recorder.Code != 400 || storage.patched {
+			t.Fatalf("expected the annotation key to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+}
+
+func TestHandleCreateEvaluationValidatesRuntime(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	providerConfigs := map[string]api.ProviderResource{
+		"garak": {
+			Resource: api.Resource{ID: "garak"},
+			ProviderConfig: api.ProviderConfig{
+				Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}},
+				Runtime:    &api.Runtime{K8s: &api.K8sRuntime{Image: "quay.io/garak:latest"}},
+			},
+		},
+	}
+	serviceConfig := &config.Config{Service: &config.ServiceConfig{Runtimes: []string{api.RuntimeLocal}}}
+
+	tests := map[string]struct {
+		runtime string
+		code    int
+		want    string
+	}{
+		"default runtime":             {runtime: "", code: 202},
+		"enabled runtime":             {runtime: api.RuntimeKubernetes, code: 202},
+		"not enabled":                 {runtime: api.RuntimeKFP, code: 400, want: "runtime_not_enabled"},
+		"unsupported by the provider": {runtime: api.RuntimeLocal, code: 400, want: "runtime_not_supported"},
+		"unknown":                     {runtime: "slurm", code: 400, want: "runtime"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := handlers.New(&fakeStorage{providerConfigs: providerConfigs}, testhelpers.NewValidator(t), &fakeRuntime{}, nil, serviceConfig, nil)
+			body := fmt.Sprintf(`{"name":"test-job","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}],"runtime":%q}`, tt.runtime)
+			req := &bodyRequest{
+				MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+				body:        []byte(body),
+			}
+			ctx := executioncontext.NewExecutionContext(context.Background(), "req-runtime", logger, "test-user", "test-tenant")
+			recorder := httptest.NewRecorder()
+
+			h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+			if recorder.Code != tt.code {
+				t.Fatalf("expected status %d for runtime %q, got %d: %s", tt.code, tt.runtime, recorder.Code, recorder.Body.String())
+			}
+			if !strings.Contains(recorder.Body.String(), tt.want) {
+				t.Errorf("expected %q in the response, got %s", tt.want, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleCreateEvaluationRejectsSecretRefsTheRuntimeCannotResolve(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	providerConfigs := map[string]api.ProviderResource{
+		"garak": {
+			Resource: api.Resource{ID: "garak"},
+			ProviderConfig: api.ProviderConfig{
+				Benchmarks: []api.BenchmarkResource{
+					{ID: "bench-1"},
+					{ID: "bench-2", Parameters: map[string]any{"api_key": "secretRef://judge-credentials/api-key"}},
+				},
+				Runtime: &api.Runtime{
+					K8s:       &api.K8sRuntime{Image: "quay.io/garak:latest"},
+					LMEvalJob: &api.LMEvalJobRuntime{},
+				},
+			},
+		},
+	}
+	serviceConfig := &config.Config{Service: &config.ServiceConfig{Runtimes: []string{api.RuntimeArgo, api.RuntimeLMEvalJob}}}
+
+	tests := map[string]struct {
+		runtime    string
+		benchmark  string
+		parameters string
+		code       int
+	}{
+		"kubernetes resolves the references":  {benchmark: "bench-1", parameters: `{"api_key":"secretRef://judge-credentials/api-key"}`, code: 202},
+		"argo cannot resolve the references":  {runtime: api.RuntimeArgo, benchmark: "bench-1", parameters: `{"api_key":"secretRef://judge-credentials/api-key"}`, code: 400},
+		"argo without references":             {runtime: api.RuntimeArgo, benchmark: "bench-1", parameters: `{"limit":10}`, code: 202},
+		"a reference in the provider default": {runtime: api.RuntimeLMEvalJob, benchmark: "bench-2", parameters: `{}`, code: 400},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := handlers.New(&fakeStorage{providerConfigs: providerConfigs}, testhelpers.NewValidator(t), &fakeRuntime{}, nil, serviceConfig, nil)
+			body := fmt.Sprintf(`{"name":"test-job","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":%q,"provider_id":"garak","parameters":%s}],"runtime":%q}`, tt.benchmark, tt.parameters, tt.runtime)
+			req := &bodyRequest{
+				MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+				body:        []byte(body),
+			}
+			ctx := executioncontext.NewExecutionContext(context.Background(), "req-secret-refs", logger, "test-user", "test-tenant")
+			recorder := httptest.NewRecorder()
+
+			h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+			if recorder.Code != tt.code {
+				t.Fatalf("expected status %d, got %d: %s", tt.code, recorder.Code, recorder.Body.String())
+			}
+			if tt.code == 400 && !strings.Contains(recorder.Body.String(), "secret_refs_not_supported") {
+				t.Errorf("expected secret_refs_not_supported in the response, got %s", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleCreateEvaluationValidatesCluster(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	providerConfigs := map[string]api.ProviderResource{
+		"garak": {
+			Resource:       api.Resource{ID: "garak"},
+			ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}}},
+		},
+	}
+	serviceConfig := &config.Config{Clusters: &config.ClustersConfig{
+		Remote: []config.ClusterConfig{{Name: "gpu-east", Kubeconfig: "/etc/evalhub/clusters/gpu-east", EvalHubURL: "https://eval-hub.example.com"}},
+	}}
+
+	tests := map[string]struct {
+		cluster string
+		code    int
+		want    string
+	}{
+		"default cluster":        {cluster: "", code: 202},
+		"cluster of the service": {cluster: config.LocalClusterName, code: 202},
+		"registered cluster":     {cluster: "gpu-east", code: 202},
+		"unregistered cluster":   {cluster: "gpu-west", code: 400, want: "local, gpu-east"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := handlers.New(&fakeStorage{providerConfigs: providerConfigs}, testhelpers.NewValidator(t), &fakeRuntime{}, nil, serviceConfig, nil)
+			body := fmt.Sprintf(`{"name":"test-job","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}],"cluster":%q}`, tt.cluster)
+			req := &bodyRequest{
+				MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+				body:        []byte(body),
+			}
+			ctx := executioncontext.NewExecutionContext(context.Background(), "req-cluster", logger, "test-user", "test-tenant")
+			recorder := httptest.NewRecorder()
+
+			h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+			if recorder.Code != tt.code {
+				t.Fatalf("expected status %d for cluster %q, got %d: %s", tt.code, tt.cluster, recorder.Code, recorder.Body.String())
+			}
+			if !strings.Contains(recorder.Body.String(), tt.want) {
+				t.Errorf("expected %q in the response, got %s", tt.want, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleCreateEvaluationValidatesSecretRefs(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	providerConfigs := map[string]api.ProviderResource{
+		"garak": {
+			Resource:       api.Resource{ID: "garak"},
+			ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}}},
+		},
+	}
+
+	tests := map[string]struct {
+		value string
+		code  int
+	}{
+		"reference":           {value: "secretRef://judge-credentials/api-key", code: 202},
+		"malformed reference": {value: "secretRef://judge-credentials", code: 400},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := handlers.New(&fakeStorage{providerConfigs: providerConfigs}, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+			body := fmt.Sprintf(`{"name":"test-job","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak","parameters":{"judge":{"api_key":%q}}}]}`, tt.value)
+			req := &bodyRequest{
+				MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+				body:        []byte(body),
+			}
+			ctx := executioncontext.NewExecutionContext(context.Background(), "req-secret-ref", logger, "test-user", "test-tenant")
+			recorder := httptest.NewRecorder()
+
+			h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+			if recorder.Code != tt.code {
+				t.Fatalf("expected status %d, got %d: %s", tt.code, recorder.Code, recorder.Body.String())
+			}
+			if tt.code == 400 && !strings.Contains(recorder.Body.String(), "judge.api_key") {
+				t.Errorf("expected the parameter to be named in the response, got %s", recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleGetEvaluationAllowedNextStates(t *testing.T) {
+	running := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-running"}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+	}
+	completed := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-completed"}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateCompleted}},
+	}
+	storage := &jobAccessTestStorage{jobs: map[string]*api.EvaluationJobResource{"job-running": running, "job-completed": completed}}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+
+	get := func(id string) api.EvaluationJobResource {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		h.HandleGetEvaluation(jobAccessContext("alice"), &baselineRequest{
+			MockRequest: createMockRequest("GET", "/api/v1/evaluations/jobs/"+id),
+			path:        map[string]string{constants.PATH_PARAMETER_JOB_ID: id},
+		}, MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var got api.EvaluationJobResource
+		if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return got
+	}
+
+	got := get("job-running")
+	if got.Status == nil || !slices.Contains(got.Status.AllowedNextStates, api.OverallStateCancelled) || slices.Contains(got.Status.AllowedNextStates, api.OverallStateWaitingForModel) {
+		t.Errorf("expected the states a running job can move to, got %+v", got.Status)
+	}
+	if running.Status.AllowedNextStates != nil {
+		t.Errorf("expected the stored job to be left unchanged, got %v", running.Status.AllowedNextStates)
+	}
+	if got := get("job-completed"); got.Status == nil || len(got.Status.AllowedNextStates) != 0 {
+		t.Errorf("expected no next state for a completed job, got %+v", got.Status)
+	}
+}
+
+func TestHandleGetEvaluationEstimatedCompletion(t *testing.T) {
+	started := time.Now().Add(-time.Hour)
+	running := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-running"}},
+		Status: &api.EvaluationJobStatus{
+			EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning},
+			Benchmarks:         []api.BenchmarkStatus{{ProviderID: "p", ID: "mmlu", Status: api.StateRunning, StartedAt: api.DateTimeToString(started)}},
+		},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "mmlu"}, ProviderID: "p"}, {Ref: api.Ref{ID: "arc"}, ProviderID: "p"}},
+		},
+	}
+	storage := &jobAccessTestStorage{
+		jobs: map[string]*api.EvaluationJobResource{"job-running": running},
+		durations: map[abstractions.BenchmarkDurationKey]time.Duration{
+			{ProviderID: "p", BenchmarkID: "mmlu"}: 3 * time.Hour,
+			{ProviderID: "p", BenchmarkID: "arc"}:  time.Hour,
+		},
+	}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+
+	get := func() *api.EvaluationJobStatus {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		h.HandleGetEvaluation(jobAccessContext("alice"), &baselineRequest{
+			MockRequest: createMockRequest("GET", "/api/v1/evaluations/jobs/job-running"),
+			path:        map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-running"},
+		}, MockResponseWrapper{recorder: recorder})
+		var got api.EvaluationJobResource
+		if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil || got.Status == nil {
+			t.Fatalf("decode response %s: %v", recorder.Body.String(), err)
+		}
+		return got.Status
+	}
+
+	estimate, err := api.DateTimeFromString(get().EstimatedCompletionAt)
+	if err != nil {
+		t.Fatalf("expected an estimated completion, got %v", err)
+	}
+	if want := started.Add(3 * time.Hour); estimate.Sub(want).Abs() > time.Minute {
+		t.Errorf("expected the job to complete when mmlu does at %v, got %v", want, estimate)
+	}
+	if running.Status.EstimatedCompletionAt != "" {
+		t.Errorf("expected the stored job to be left unchanged, got %v", running.Status.EstimatedCompletionAt)
+	}
+
+	delete(storage.durations, abstractions.BenchmarkDurationKey{ProviderID: "p", BenchmarkID: "arc"})
+	if got := get(); got.EstimatedCompletionAt != "" {
+		t.Errorf("expected no estimate with a benchmark that never ran, got %v", got.EstimatedCompletionAt)
+	}
+}
+
+func TestHandleCreateEvaluationValidatesThresholds(t *testing.T) {
+	t.Parallel()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	zero, one, hundred := 0.0, 1.0, 100.0
+	providerConfigs := map[string]api.ProviderResource{
+		"lm_evaluation_harness": {
+			Resource: api.Resource{ID: "lm_evaluation_harness"},
+			ProviderConfig: api.ProviderConfig{
+				Metrics: []api.MetricDefinition{
+					{Name: "acc", Min: &zero, Max: &one},
+					{Name: "f1", Min: &zero, Max: &one},
+					{Name: "perplexity", Min: &zero, Max: &hundred, Direction: api.MetricDirectionLowerIsBetter},
+				},
+				Benchmarks: []api.BenchmarkResource{{ID: "mmlu"}, {ID: "wikitext"}},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		benchmarks   string
+		passCriteria string
+		code         int
+	}{
+		"benchmark threshold in range":      {benchmarks: `{"id":"mmlu","provider_id":"lm_evaluation_harness","primary_score":{"metric":"acc"},"pass_criteria":{"threshold":0.7}}`, code: 202},
+		"benchmark threshold out of range":  {benchmarks: `{"id":"mmlu","provider_id":"lm_evaluation_harness","primary_score":{"metric":"acc"},"pass_criteria":{"threshold":70}}`, code: 400},
+		"weighted threshold out of range":   {benchmarks: `{"id":"mmlu","provider_id":"lm_evaluation_harness","primary_score":{"metrics":[{"metric":"acc"},{"metric":"f1","weight":2}]},"pass_criteria":{"threshold":1.5}}`, code: 400},
+		"undeclared metric":                 {benchmarks: `{"id":"mmlu","provider_id":"lm_evaluation_harness","primary_score":{"metric":"bleu"}}`, code: 400},
+		"negative weight":                   {benchmarks: `{"id":"mmlu","provider_id":"lm_evaluation_harness","weight":-1,"primary_score":{"metric":"acc"}}`, code: 400},
+		"job threshold in range":            {benchmarks: `{"id":"mmlu","provider_id":"lm_evaluation_harness","primary_score":{"metric":"acc"}}`, passCriteria: `{"threshold":0.6}`, code: 202},
+		"job threshold out of range":        {benchmarks: `{"id":"mmlu","provider_id":"lm_evaluation_harness","primary_score":{"metric":"acc"}}`, passCriteria: `{"threshold":60}`, code: 400},
+		"job threshold of unbounded scores": {benchmarks: `{"id":"mmlu","provider_id":"lm_evaluation_harness","primary_score":{"expression":"100 * acc"}}`, passCriteria: `{"threshold":60}`, code: 202},
+		"job threshold of lower is better":  {benchmarks: `{"id":"wikitext","provider_id":"lm_evaluation_harness","primary_score":{"metric":"perplexity"}}`, passCriteria: `{"threshold":-50}`, code: 202},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := handlers.New(&fakeStorage{providerConfigs: providerConfigs}, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+			body := `{"name":"test-job","model":{"url":"http://test.com","name":"test"},"benchmarks":[` + tt.benchmarks + `]`
+			if tt.passCriteria != "" {
+				body += `,"pass_criteria":` + tt.passCriteria
+			}
+			req := &bodyRequest{
+				MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+				body:        []byte(body + "}"),
+			}
+			ctx := executioncontext.NewExecutionContext(context.Background(), "req-thresholds", logger, "test-user", "test-tenant")
+			recorder := httptest.NewRecorder()
+
+			h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+
+			if recorder.Code != tt.code {
+				t.Fatalf("expected status %d, got %d: %s", tt.code, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
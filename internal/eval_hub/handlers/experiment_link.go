@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/circuitbreaker"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/mlflow"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// experimentLinkPageSize bounds the jobs without experiment that a pass of the retries reads.
+const experimentLinkPageSize = 100
+
+// experimentLinks holds the backoff of the jobs whose experiment could not be linked yet, by
+// job id. It is only used by the elected leader, which retries the links.
+type experimentLinks struct {
+	mu      sync.Mutex
+	retries map[string]*experimentLinkRetry
+}
+
+type experimentLinkRetry struct {
+	interval time.Duration
+	next     time.Time
+}
+
+func newExperimentLinks() *experimentLinks {
+	return &experimentLinks{retries: make(map[string]*experimentLinkRetry)}
+}
+
+func (h *Handlers) experimentLinkingConfig() *config.ExperimentLinkingConfig {
+	if h.serviceConfig == nil || h.serviceConfig.MLFlow == nil {
+		return nil
+	}
+	return h.serviceConfig.MLFlow.ExperimentLinking
+}
+
+// ExperimentLinkRetryInterval returns how often the leader retries the experiment links.
+func (h *Handlers) ExperimentLinkRetryInterval() time.Duration {
+	retryInterval, _ := h.experimentLinkingConfig().RetryIntervals()
+	return retryInterval
+}
+
+// linkExperimentInBackground links the stored job to its MLflow experiment in the background,
+// so that the job creation does not wait for MLflow. When the link fails it is retried by
+// the elected leader, see LinkPendingExperiments. The attempt is stopped, with the model
+// waits, at shutdown.
+func (h *Handlers) linkExperimentInBackground(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, job *api.EvaluationJobResource) {
+	logger := ctx.Logger.With("job_id", job.Resource.ID, "experiment_name", job.Experiment.Name)
+	// the request goes on with the job, e.g. to start it
+	linked := *job
+	h.modelWaits.wg.Add(1)
+	go func() {
+		defer h.modelWaits.wg.Done()
+		if err := h.linkExperiment(h.modelWaits.ctx, logger, storage, &linked); err != nil {
+			logger.Warn("Failed to link evaluation job to its MLflow experiment, it will be retried", "error", err.Error())
+		}
+	}()
+}
+
+// LinkPendingExperiments retries the links of the jobs created within the max age of
+// experiment_linking that are not linked to their experiment yet, each with its own backoff.
+// The pass stops early while the circuit breaker of MLflow is open.
+func (h *Handlers) LinkPendingExperiments(ctx context.Context, logger *slog.Logger) {
+	if h.mlflowClient == nil {
+		return
+	}
+	linkingConfig := h.experimentLinkingConfig()
+	retryInterval, maxRetryInterval := linkingConfig.RetryIntervals()
+	maxAge := linkingConfig.EffectiveMaxAge()
+
+	jobs, err := h.storage.WithLogger(logger).WithContext(ctx).GetEvaluationJobsWithoutExperiment(experimentLinkPageSize)
+	if err != nil {
+		logger.Warn("Failed to list the evaluation jobs without MLflow experiment", "error", err)
+		return
+	}
+
+	links := h.experimentLinks
+	now := time.Now()
+	pending := make(map[string]bool, len(jobs))
+	for _, pendingJob := range jobs {
+		// the newest first, the older ones are given up
+		if now.Sub(pendingJob.CreatedAt) > maxAge {
+			break
+		}
+		pending[pendingJob.ID] = true
+		links.mu.Lock()
+		retry, ok := links.retries[pendingJob.ID]
+		links.mu.Unlock()
+		if ok && now.Before(retry.next) {
+			continue
+		}
+
+		jobLogger := logger.With("job_id", pendingJob.ID, "tenant", pendingJob.Tenant)
+		storage := h.storage.WithLogger(jobLogger).WithContext(ctx).WithTenant(pendingJob.Tenant)
+		job, err := storage.GetEvaluationJob(pendingJob.ID)
+		if err == nil {
+			err = h.linkExperiment(ctx, jobLogger, storage, job)
+		}
+		if err == nil || isResourceNotFound(err) {
+			links.mu.Lock()
+			delete(links.retries, pendingJob.ID)
+			links.mu.Unlock()
+			continue
+		}
+
+		interval := retryInterval
+		if ok {
+			interval = min(2*retry.interval, maxRetryInterval)
+		}
+		links.mu.Lock()
+		links.retries[pendingJob.ID] = &experimentLinkRetry{interval: interval, next: now.Add(interval)}
+		links.mu.Unlock()
+		jobLogger.Warn("Failed to link evaluation job to its MLflow experiment", "error", err.Error(), "retry_in", interval)
+		if errors.Is(err, circuitbreaker.ErrOpen) {
+			// the other jobs would fail the same way until the breaker lets a trial through
+			return
+		}
+	}
+
+	// forget the backoff of the jobs that were linked, deleted or given up meanwhile
+	links.mu.Lock()
+	for id := range links.retries {
+		if !pending[id] {
+			delete(links.retries, id)
+		}
+	}
+	links.mu.Unlock()
+}
+
+// linkExperiment gets or creates the MLflow experiment of the job, in the MLflow workspace of
+// the job, and links the job to it.
+func (h *Handlers) linkExperiment(ctx context.Context, logger *slog.Logger, storage abstractions.Storage, job *api.EvaluationJobResource) error {
+	if job.Resource.MLFlowExperimentID != "" {
+		return nil
+	}
+	client := h.mlflowClient.WithContext(ctx).WithLogger(logger)
+	// Experiments must be scoped to the workspace of the job, the tenant namespace unless
+	// the tenant is mapped to other workspaces, so job pods can reach them with their own
+	// X-MLFLOW-WORKSPACE header.
+	if workspace := job.MLFlowWorkspace(); workspace != "" {
+		client = client.WithWorkspace(workspace)
+	}
+	experimentID, experimentURL, err := mlflow.GetOrCreateExperimentID(client, &job.EvaluationJobConfig, job.Resource.ID)
+	if err != nil {
+		return err
+	}
+	if err := storage.WithContext(context.Background()).LinkEvaluationJobExperiment(job.Resource.ID, experimentID, experimentURL); err != nil {
+		return err
+	}
+	logger.Info("Linked evaluation job to its MLflow experiment", "experiment_id", experimentID)
+	return nil
+}
@@ -0,0 +1,150 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/mlflow"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/eval-hub/eval-hub/pkg/mlflowclient"
+)
+
+// experimentLinkTestStorage reports the experiments that the jobs are linked to, and lists
+// the job as without experiment until it is linked.
+type experimentLinkTestStorage struct {
+	*modelWaitTestStorage
+	linked chan string
+}
+
+func (s *experimentLinkTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *experimentLinkTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *experimentLinkTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *experimentLinkTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *experimentLinkTestStorage) GetEvaluationJobsWithoutExperiment(_ int) ([]api.Resource, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.job == nil || s.job.Resource.MLFlowExperimentID != "" {
+		return nil, nil
+	}
+	return []api.Resource{s.job.Resource.Resource}, nil
+}
+
+func (s *experimentLinkTestStorage) LinkEvaluationJobExperiment(_ string, experimentID string, _ string) error {
+	s.mu.Lock()
+	s.job.Resource.MLFlowExperimentID = experimentID
+	s.mu.Unlock()
+	s.linked <- experimentID
+	return nil
+}
+
+func TestHandleCreateEvaluationLinksTheExperimentInTheBackground(t *testing.T) {
+	logger := logging.FallbackLogger()
+	providerConfigs := map[string]api.ProviderResource{
+		"garak": {
+			Resource:       api.Resource{ID: "garak"},
+			ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}}},
+		},
+	}
+
+	var up atomic.Bool
+	var lookups atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/2.0/mlflow/experiments/get-by-name" {
+			lookups.Add(1)
+		}
+		if !up.Load() {
+			http.Error(w, `{"error_code":"INTERNAL_ERROR","message":"down"}`, http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path == "/api/2.0/mlflow/experiments/get-by-name" {
+			_ = json.NewEncoder(w).Encode(mlflowclient.GetExperimentResponse{
+				Experiment: mlflowclient.Experiment{ExperimentID: "exp-1", Name: "demo", LifecycleStage: "active"},
+			})
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	serviceConfig := &config.Config{MLFlow: &config.MLFlowConfig{
+		TrackingURI:       server.URL,
+		TokenPath:         filepath.Join(t.TempDir(), "no-such-token"),
+		ExperimentLinking: &config.ExperimentLinkingConfig{RetryInterval: 200 * time.Millisecond},
+	}}
+	client, err := mlflow.NewMLFlowClient(serviceConfig, logger)
+	if err != nil {
+		t.Fatalf("NewMLFlowClient: %v", err)
+	}
+	storage := &experimentLinkTestStorage{
+		modelWaitTestStorage: &modelWaitTestStorage{fakeStorage: &fakeStorage{providerConfigs: providerConfigs}},
+		linked:               make(chan string, 1),
+	}
+	h := handlers.New(storage, testhelpers.NewValidator(t), nil, client, serviceConfig, nil)
+	t.Cleanup(h.StopModelWaits)
+
+	// the job is created while MLflow is down, without its experiment
+	req := &bodyRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+		body:        []byte(`{"name":"test-job","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}],"experiment":{"name":"demo"}}`),
+	}
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-experiment-link", logger, "test-user", "")
+	recorder := httptest.NewRecorder()
+	h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+	if recorder.Code != 202 {
+		t.Fatalf("expected status 202, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if strings.Contains(recorder.Body.String(), `"mlflow_experiment_id"`) {
+		t.Fatalf("expected the job to be created without its experiment, got %s", recorder.Body.String())
+	}
+
+	// the background attempt fails, then the first retry
+	waitFor(t, func() bool { return lookups.Load() >= 1 })
+	h.LinkPendingExperiments(context.Background(), logger)
+	if lookups.Load() != 2 {
+		t.Fatalf("expected the link to be retried, got %d lookups", lookups.Load())
+	}
+
+	// the next retry waits for its backoff, even though MLflow is back
+	up.Store(true)
+	h.LinkPendingExperiments(context.Background(), logger)
+	if lookups.Load() != 2 {
+		t.Fatalf("expected the link not to be retried before its backoff, got %d lookups", lookups.Load())
+	}
+
+	time.Sleep(250 * time.Millisecond)
+	h.LinkPendingExperiments(context.Background(), logger)
+	select {
+	case experimentID := <-storage.linked:
+		if experimentID != "exp-1" {
+			t.Errorf("expected the job to be linked to exp-1, got %q", experimentID)
+		}
+	default:
+		t.Fatal("expected the job to be linked to its experiment once MLflow is back")
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
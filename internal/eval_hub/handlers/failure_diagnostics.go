@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const (
+	// FailureDiagnosticsInterval is how often the workloads of the unfinished jobs are checked
+	// for failures that their adapters did not report.
+	FailureDiagnosticsInterval = 30 * time.Second
+
+	failureDiagnosticsPageSize = 100
+)
+
+// DiagnoseBenchmarkFailures marks failed the benchmarks of the unfinished jobs whose workload
+// the runtime found failed, e.g. as the pod ran out of memory before its adapter could
+// report anything, with the error message classifying the failure. The failures go through
+// the runtime storage of the job, so that the benchmarks are retried by their retry policy
+// and the benchmarks that depend on them are cancelled, as for a failure of the adapter.
+func (h *Handlers) DiagnoseBenchmarkFailures(ctx context.Context, logger *slog.Logger) {
+	diagnoser, ok := h.runtime.(abstractions.FailureDiagnoser)
+	if !ok {
+		return
+	}
+	storage := h.storage.WithLogger(logger).WithContext(ctx)
+	// a job stays pending until one of its benchmarks reports that it is running
+	for _, state := range []api.OverallState{api.OverallStatePending, api.OverallStateRunning} {
+		for offset := 0; ; offset += failureDiagnosticsPageSize {
+			res, err := storage.GetEvaluationJobs(&abstractions.QueryFilter{
+				Limit:  failureDiagnosticsPageSize,
+				Offset: offset,
+				Params: map[string]any{"status": string(state)},
+			})
+			if err != nil {
+				logger.Warn("Failed to list evaluation jobs to diagnose failures", "status", state, "error", err)
+				return
+			}
+			for i := range res.Items {
+				h.reportBenchmarkFailures(ctx, diagnoser, &res.Items[i], logger)
+			}
+			if len(res.Items) < failureDiagnosticsPageSize {
+				break
+			}
+		}
+	}
+}
+
+func (h *Handlers) reportBenchmarkFailures(ctx context.Context, diagnoser abstractions.FailureDiagnoser, job *api.EvaluationJobResource, logger *slog.Logger) {
+	failures, err := diagnoser.DiagnoseBenchmarkFailures(ctx, job)
+	if err != nil {
+		// the failures found on the clusters that could be read are still reported
+		logger.Warn("Failed to diagnose the workloads of evaluation job", "job_id", job.Resource.ID, "error", err)
+	}
+	if len(failures) == 0 {
+		return
+	}
+	runtimeStorage := &runtimeStorage{
+		ctx:      context.Background(),
+		logger:   logger,
+		handlers: h,
+		tenant:   job.Resource.Tenant,
+		owner:    job.Resource.Owner,
+		validate: h.validate,
+	}
+	// the listed job may lack the status of its benchmarks
+	jobID := job.Resource.ID
+	job, err = runtimeStorage.scopedStorage().GetEvaluationJob(jobID)
+	if err != nil {
+		logger.Warn("Failed to load evaluation job to report failed workloads", "job_id", jobID, "error", err)
+		return
+	}
+	benchmarks, err := h.resolveJobBenchmarksForStorage(runtimeStorage.scopedStorage(), job)
+	if err != nil {
+		logger.Warn("Failed to resolve benchmarks to report failed workloads", "job_id", job.Resource.ID, "error", err)
+		return
+	}
+	for _, failure := range failures {
+		if failure.BenchmarkIndex < 0 || failure.BenchmarkIndex >= len(benchmarks) || !failureUnreported(job, failure) {
+			continue
+		}
+		benchmark := benchmarks[failure.BenchmarkIndex]
+		logger.Info("Benchmark workload of evaluation job failed", "job_id", job.Resource.ID, "benchmark_index", failure.BenchmarkIndex, "failure_class", failure.ErrorMessage.FailureClass)
+		event := &api.StatusEvent{
+			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+				ProviderID:     benchmark.ProviderID,
+				ID:             benchmark.ID,
+				BenchmarkIndex: failure.BenchmarkIndex,
+				ShardIndex:     failure.ShardIndex,
+				Status:         api.StateFailed,
+				ErrorMessage:   failure.ErrorMessage,
+				CompletedAt:    api.DateTimeToString(time.Now()),
+			},
+		}
+		if err := runtimeStorage.UpdateEvaluationJob(job.Resource.ID, event); err != nil {
+			logger.Error("Failed to report the failed workload of evaluation job", "job_id", job.Resource.ID, "benchmark_index", failure.BenchmarkIndex, "error", err)
+		}
+	}
+}
+
+// failureUnreported reports whether the failure of a workload is not reported yet: its
+// benchmark, or shard, has not finished, and the workload is not of a run of the benchmark
+// that failed before it was retried.
+func failureUnreported(job *api.EvaluationJobResource, failure abstractions.BenchmarkFailure) bool {
+	if job.Status == nil {
+		return true
+	}
+	for _, benchmark := range job.Status.Benchmarks {
+		if benchmark.BenchmarkIndex != failure.BenchmarkIndex {
+			continue
+		}
+		if api.IsBenchmarkTerminalState(benchmark.Status) {
+			return false
+		}
+		if failure.ShardIndex != nil {
+			for _, shard := range benchmark.Shards {
+				if shard.ShardIndex == *failure.ShardIndex && api.IsBenchmarkTerminalState(shard.Status) {
+					return false
+				}
+			}
+		}
+		if len(benchmark.Attempts) > 0 {
+			failedAt, err := api.DateTimeFromString(benchmark.Attempts[len(benchmark.Attempts)-1].FailedAt)
+			if err == nil && failure.CreatedAt.Before(failedAt) {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
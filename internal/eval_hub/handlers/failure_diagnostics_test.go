@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestFailureUnreported(t *testing.T) {
+	retriedAt := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	shard := 1
+	job := &api.EvaluationJobResource{
+		Status: &api.EvaluationJobStatus{
+			Benchmarks: []api.BenchmarkStatus{
+				{BenchmarkIndex: 0, Status: api.StateFailed},
+				{BenchmarkIndex: 1, Status: api.StateRunning, Attempts: []api.BenchmarkAttempt{{Attempt: 1, FailedAt: api.DateTimeToString(retriedAt)}}},
+				{BenchmarkIndex: 2, Status: api.StateRunning, Shards: []api.BenchmarkShardStatus{{ShardIndex: 1, Status: api.StateFailed}}},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		failure abstractions.BenchmarkFailure
+		want    bool
+	}{
+		"finished benchmark":       {failure: abstractions.BenchmarkFailure{BenchmarkIndex: 0}, want: false},
+		"run before the retry":     {failure: abstractions.BenchmarkFailure{BenchmarkIndex: 1, CreatedAt: retriedAt.Add(-time.Hour)}, want: false},
+		"retry":                    {failure: abstractions.BenchmarkFailure{BenchmarkIndex: 1, CreatedAt: retriedAt.Add(time.Minute)}, want: true},
+		"finished shard":           {failure: abstractions.BenchmarkFailure{BenchmarkIndex: 2, ShardIndex: &shard}, want: false},
+		"benchmark without status": {failure: abstractions.BenchmarkFailure{BenchmarkIndex: 3}, want: true},
+	}
+	for name, tt := range tests {
+		if got := failureUnreported(job, tt.failure); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", name, tt.want, got)
+		}
+	}
+}
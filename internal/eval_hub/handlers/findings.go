@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// HandleListEvaluationFindings handles GET /api/v1/evaluations/jobs/{id}/findings, the safety
+// findings of the benchmarks of a job, filtered by severity (a comma separated list),
+// min_severity, benchmark_index, probe and detector.
+func (h *Handlers) HandleListEvaluationFindings(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	evaluationJobID := req.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+
+	filter, err := findingsFilter(req)
+	logging.LogRequestStarted(ctx, "filter", filter)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			if _, err := h.getAccessibleEvaluationJob(ctx, scoped, evaluationJobID, jobAccessRead); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			findings, err := scoped.GetEvaluationJobFindings(evaluationJobID, filter)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			page, err := CreatePage(ctx, findings.TotalCount, filter.Offset, filter.Limit, req)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			result := api.FindingResourceList{
+				Page:  *page,
+				Items: findings.Items,
+			}
+			w.WriteJSON(result, 200, "count", strconv.Itoa(len(findings.Items)), "total_count", strconv.Itoa(findings.TotalCount))
+			return nil
+		},
+		"storage",
+		"list-evaluation-findings",
+		"job.id", evaluationJobID,
+	)
+}
+
+// findingsFilter returns the filter of the findings of a job from the query parameters.
+func findingsFilter(req http_wrappers.RequestWrapper) (*abstractions.QueryFilter, error) {
+	allowedParams := []string{"limit", "offset", "severity", "min_severity", "benchmark_index", "probe", "detector"}
+	if badParams := getAllParams(req, allowedParams...); len(badParams) > 0 {
+		// just report the first bad parameter
+		return nil, serviceerrors.NewServiceError(messages.QueryBadParameter, "ParameterName", badParams[0], "AllowedParameters", strings.Join(allowedParams, ", "))
+	}
+	common, err := CommonListFilters(req)
+	if err != nil {
+		return nil, err
+	}
+	filter := &abstractions.QueryFilter{Limit: common.Limit, Offset: common.Offset, Params: map[string]any{}}
+
+	severities, err := GetParam(req, "severity", true, "")
+	if err != nil {
+		return nil, err
+	}
+	if severities != "" {
+		var parsed []api.Severity
+		for value := range strings.SplitSeq(severities, ",") {
+			severity, err := parseSeverity("severity", value)
+			if err != nil {
+				return nil, err
+			}
+			parsed = append(parsed, severity)
+		}
+		filter.Params["severity"] = parsed
+	}
+
+	minSeverity, err := GetParam(req, "min_severity", true, "")
+	if err != nil {
+		return nil, err
+	}
+	if minSeverity != "" {
+		severity, err := parseSeverity("min_severity", minSeverity)
+		if err != nil {
+			return nil, err
+		}
+		filter.Params["min_severity"] = severity.Rank()
+	}
+
+	if slices.ContainsFunc(req.Query("benchmark_index"), func(value string) bool { return value != "" }) {
+		benchmarkIndex, err := GetParam(req, "benchmark_index", true, 0)
+		if err != nil {
+			return nil, err
+		}
+		if benchmarkIndex < 0 {
+			return nil, serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", "benchmark_index", "Type", "positive integer", "Value", strconv.Itoa(benchmarkIndex))
+		}
+		filter.Params["benchmark_index"] = benchmarkIndex
+	}
+
+	for _, param := range []string{"probe", "detector"} {
+		value, err := GetParam(req, param, true, "")
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			filter.Params[param] = value
+		}
+	}
+	return filter, nil
+}
+
+func parseSeverity(param string, value string) (api.Severity, error) {
+	severity := api.Severity(strings.TrimSpace(value))
+	if !slices.Contains(api.Severities, severity) {
+		return "", serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", param, "Type", "severity (info, low, medium, high or critical)", "Value", value)
+	}
+	return severity, nil
+}
@@ -0,0 +1,85 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// findingsTestStorage records the filter of the findings it lists.
+type findingsTestStorage struct {
+	*baselineTestStorage
+	filter *abstractions.QueryFilter
+}
+
+func (s *findingsTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *findingsTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *findingsTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *findingsTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *findingsTestStorage) GetEvaluationJobFindings(id string, filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.FindingResource], error) {
+	s.filter = filter
+	return &abstractions.QueryResults[api.FindingResource]{
+		Items:      []api.FindingResource{{JobID: id, BenchmarkID: "garak", Finding: api.Finding{Probe: "dan.Dan_11_0", Severity: api.SeverityCritical}}},
+		TotalCount: 1,
+	}, nil
+}
+
+func TestHandleListEvaluationFindings(t *testing.T) {
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-findings", logging.FallbackLogger(), "test-user", "test-tenant")
+	storage := &findingsTestStorage{baselineTestStorage: newBaselineTestStorage()}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+
+	list := func(jobID string, query map[string][]string) *httptest.ResponseRecorder {
+		storage.filter = nil
+		recorder := httptest.NewRecorder()
+		h.HandleListEvaluationFindings(ctx, &baselineRequest{
+			MockRequest: createMockRequest("GET", "/api/v1/evaluations/jobs/"+jobID+"/findings"),
+			path:        map[string]string{constants.PATH_PARAMETER_JOB_ID: jobID},
+			query:       query,
+		}, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	recorder := list("job-completed", map[string][]string{
+		"severity":        {"high,critical"},
+		"min_severity":    {"medium"},
+		"benchmark_index": {"0"},
+		"probe":           {"dan.Dan_11_0"},
+	})
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	findings := api.FindingResourceList{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &findings); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if findings.TotalCount != 1 || len(findings.Items) != 1 || findings.Items[0].Severity != api.SeverityCritical {
+		t.Fatalf("expected the finding of the storage, got %+v", findings)
+	}
+	params := storage.filter.Params
+	if severities, _ := params["severity"].([]api.Severity); !slices.Equal(severities, []api.Severity{api.SeverityHigh, api.SeverityCritical}) {
+		t.Errorf("severity = %v, want high and critical", params["severity"])
+	}
+	if params["min_severity"] != api.SeverityMedium.Rank() || params["benchmark_index"] != 0 || params["probe"] != "dan.Dan_11_0" {
+		t.Errorf("unexpected filter params %v", params)
+	}
+
+	if recorder := list("job-completed", map[string][]string{"severity": {"high,urgent"}}); recorder.Code != 400 {
+		t.Errorf("expected status 400 for an unknown severity, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := list("job-unknown", nil); recorder.Code != 404 {
+		t.Errorf("expected status 404 for an unknown job, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,363 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/generations"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/mlflow"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/redaction"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The raw generations of a benchmark are the prompts and responses of its samples that the
+// adapter captured. They can hold harmful or personal content, so they are stored encrypted
+// with a key of the tenant, apart from the other artifacts, and only the generation readers
+// can list and download them. Every access is logged.
+
+// HandleUploadEvaluationBenchmarkGenerations handles PUT
+// /api/v1/evaluations/jobs/{id}/benchmarks/{benchmark_index}/generations/{name}. Adapters
+// upload the raw generations of a benchmark as they upload its artifacts; the body is
+// redacted, encrypted and streamed to the artifact store of the job experiment. Uploading
+// generations again replaces them.
+func (h *Handlers) HandleUploadEvaluationBenchmarkGenerations(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID, benchmarkIndex, ok := benchmarkPathParameters(ctx, req, w)
+	if !ok {
+		return
+	}
+	name, ok := generationsNamePathParameter(ctx, req, w)
+	if !ok {
+		return
+	}
+
+	// only the pods of the job hold its callback token
+	if h.serviceConfig != nil && !callbackauth.Verify(h.serviceConfig.CallbackAuth, evaluationJobID, req.Header(callbackauth.TokenHeader)) {
+		ctx.Logger.Warn("Rejected evaluation job generations without a valid callback token", "job_id", evaluationJobID)
+		w.Error(serviceerrors.NewServiceError(messages.CallbackTokenInvalid, "EvaluationJobID", evaluationJobID), ctx.RequestID)
+		return
+	}
+
+	// the generations are never stored unencrypted
+	if h.serviceConfig == nil || !h.serviceConfig.Artifacts.HasGenerationsKey() {
+		w.Error(serviceerrors.NewServiceError(messages.GenerationsNotEnabled), ctx.RequestID)
+		return
+	}
+
+	stream, ok := req.(http_wrappers.StreamingRequestWrapper)
+	if !ok {
+		w.ErrorWithMessageCode(ctx.RequestID, messages.NotImplemented, "Api", req.URI())
+		return
+	}
+
+	contentType := req.Header("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			job, err := scoped.GetEvaluationJob(evaluationJobID)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if err := h.checkBenchmarkIndex(scoped, job, benchmarkIndex); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if h.mlflowClient == nil || job.Resource.MLFlowExperimentID == "" {
+				reason := "the job has no MLflow experiment"
+				if h.mlflowClient == nil {
+					reason = "MLflow is not configured"
+				}
+				err := serviceerrors.NewServiceError(messages.ArtifactStoreUnavailable, "EvaluationJobID", evaluationJobID, "Reason", reason)
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			client := h.mlflowClient.WithContext(runtimeCtx).WithLogger(ctx.Logger)
+			if workspace := job.MLFlowWorkspace(); workspace != "" {
+				client = client.WithWorkspace(workspace)
+			}
+			artifactLocation := ""
+			if job.Experiment != nil {
+				artifactLocation = job.Experiment.ArtifactLocation
+			}
+
+			// the size and digest are those of the redacted content, which is what the
+			// generation readers download
+			var content io.Reader = stream.Body()
+			var redacted *redaction.Reader
+			if redaction.IsText(contentType) {
+				redactor, err := loadRedactor(scoped.WithTenant(job.Resource.Tenant))
+				if err != nil {
+					w.Error(err, ctx.RequestID)
+					return err
+				}
+				if redactor != nil {
+					redacted = redactor.NewReader(content)
+					content = redacted
+				}
+			}
+			body := &artifactReader{body: content, hash: sha256.New()}
+			encrypted, err := generations.NewEncryptingReader(generations.TenantKey(h.serviceConfig.Artifacts.GenerationsKey, job.Resource.Tenant), body)
+			if err != nil {
+				err := serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			uri, err := mlflow.UploadJobArtifact(client, job.Resource.MLFlowExperimentID, evaluationJobID, benchmarkIndex, mlflow.JobGenerationsArtifactName(name), artifactLocation, encrypted, "application/octet-stream")
+			if body.err != nil {
+				// the upload failed because the body could not be read, e.g. it is too large
+				w.Error(body.err, ctx.RequestID)
+				return body.err
+			}
+			if err != nil {
+				ctx.Logger.Error("Failed to upload evaluation job generations", "error", err, "job_id", evaluationJobID, "benchmark_index", benchmarkIndex, "name", name)
+				err := serviceerrors.NewServiceError(messages.MLFlowRequestFailed, "Error", err.Error())
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			generation := &api.ArtifactResource{
+				JobID:          evaluationJobID,
+				BenchmarkIndex: benchmarkIndex,
+				Name:           name,
+				ContentType:    contentType,
+				Size:           body.size,
+				Digest:         "sha256:" + hex.EncodeToString(body.hash.Sum(nil)),
+				URI:            uri,
+				UploadedAt:     time.Now().UTC(),
+			}
+			if err := scoped.PutEvaluationJobGeneration(evaluationJobID, generation); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if redacted != nil && redacted.Counts.Total() > 0 {
+				record := &api.RedactionRecord{
+					JobID:          evaluationJobID,
+					BenchmarkIndex: benchmarkIndex,
+					Target:         api.RedactionTargetArtifact,
+					Artifact:       mlflow.JobGenerationsArtifactName(name),
+					Redactions:     redacted.Counts,
+					RedactedAt:     generation.UploadedAt,
+				}
+				if err := scoped.AddEvaluationJobRedaction(evaluationJobID, record); err != nil {
+					w.Error(err, ctx.RequestID)
+					return err
+				}
+			}
+			ctx.Logger.Info("Uploaded evaluation job generations", "job_id", evaluationJobID, "benchmark_index", benchmarkIndex, "name", name, "size", body.size)
+			w.WriteJSON(generation, 201)
+			return nil
+		},
+		"mlflow",
+		"upload-evaluation-generations",
+		"job.id", evaluationJobID,
+		"benchmark.index", strconv.Itoa(benchmarkIndex),
+	)
+}
+
+// HandleListEvaluationBenchmarkGenerations handles GET
+// /api/v1/evaluations/jobs/{id}/benchmarks/{benchmark_index}/generations, the raw
+// generations that the adapter of the benchmark uploaded so far.
+func (h *Handlers) HandleListEvaluationBenchmarkGenerations(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	evaluationJobID, benchmarkIndex, ok := benchmarkPathParameters(ctx, req, w)
+	if !ok {
+		return
+	}
+
+	filter, err := CommonListFilters(req)
+	logging.LogRequestStarted(ctx, "filter", filter)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	allowedParams := []string{"limit", "offset"}
+	if badParams := getAllParams(req, allowedParams...); len(badParams) > 0 {
+		// just report the first bad parameter
+		w.Error(serviceerrors.NewServiceError(messages.QueryBadParameter, "ParameterName", badParams[0], "AllowedParameters", strings.Join(allowedParams, ", ")), ctx.RequestID)
+		return
+	}
+	if !h.isGenerationReader(ctx) {
+		ctx.Logger.Warn("Rejected access to raw generations", "job_id", evaluationJobID, "benchmark_index", benchmarkIndex)
+		w.Error(serviceerrors.NewServiceError(messages.GenerationsAccessDenied, "User", ctx.User), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			if _, err := h.getAccessibleEvaluationJob(ctx, scoped, evaluationJobID, jobAccessRead); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			generations, err := scoped.GetEvaluationJobGenerations(evaluationJobID, benchmarkIndex, filter)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			page, err := CreatePage(ctx, generations.TotalCount, filter.Offset, filter.Limit, req)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			ctx.Logger.Warn("Raw generations listed", "job_id", evaluationJobID, "benchmark_index", benchmarkIndex)
+			result := api.ArtifactResourceList{
+				Page:  *page,
+				Items: generations.Items,
+			}
+			w.WriteJSON(result, 200, "count", strconv.Itoa(len(generations.Items)), "total_count", strconv.Itoa(generations.TotalCount))
+			return nil
+		},
+		"storage",
+		"list-evaluation-generations",
+		"job.id", evaluationJobID,
+		"benchmark.index", strconv.Itoa(benchmarkIndex),
+	)
+}
+
+// HandleDownloadEvaluationBenchmarkGenerations handles GET
+// /api/v1/evaluations/jobs/{id}/benchmarks/{benchmark_index}/generations/{name}, the
+// decrypted content of raw generations of the benchmark.
+func (h *Handlers) HandleDownloadEvaluationBenchmarkGenerations(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID, benchmarkIndex, ok := benchmarkPathParameters(ctx, req, w)
+	if !ok {
+		return
+	}
+	name, ok := generationsNamePathParameter(ctx, req, w)
+	if !ok {
+		return
+	}
+	if !h.isGenerationReader(ctx) {
+		ctx.Logger.Warn("Rejected access to raw generations", "job_id", evaluationJobID, "benchmark_index", benchmarkIndex, "name", name)
+		w.Error(serviceerrors.NewServiceError(messages.GenerationsAccessDenied, "User", ctx.User), ctx.RequestID)
+		return
+	}
+	if !h.serviceConfig.Artifacts.HasGenerationsKey() {
+		w.Error(serviceerrors.NewServiceError(messages.GenerationsNotEnabled), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			job, err := h.getAccessibleEvaluationJob(ctx, scoped, evaluationJobID, jobAccessRead)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			generation, err := scoped.GetEvaluationJobGeneration(evaluationJobID, benchmarkIndex, name)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if h.mlflowClient == nil || job.Resource.MLFlowExperimentID == "" {
+				err := serviceerrors.NewServiceError(messages.MLFlowRequiredForExperiment)
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			client := h.mlflowClient.WithContext(runtimeCtx).WithLogger(ctx.Logger)
+			if workspace := job.MLFlowWorkspace(); workspace != "" {
+				client = client.WithWorkspace(workspace)
+			}
+			artifactLocation := ""
+			if job.Experiment != nil {
+				artifactLocation = job.Experiment.ArtifactLocation
+			}
+			content, err := mlflow.DownloadJobArtifact(client, job.Resource.MLFlowExperimentID, evaluationJobID, benchmarkIndex, mlflow.JobGenerationsArtifactName(name), artifactLocation)
+			if err != nil {
+				ctx.Logger.Error("Failed to download evaluation job generations", "error", err, "job_id", evaluationJobID, "benchmark_index", benchmarkIndex, "name", name)
+				err := serviceerrors.NewServiceError(messages.MLFlowRequestFailed, "Error", err.Error())
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			defer func() { _ = content.Close() }()
+
+			decrypted, err := generations.NewDecryptingReader(generations.TenantKey(h.serviceConfig.Artifacts.GenerationsKey, job.Resource.Tenant), content)
+			plaintext := bufio.NewReader(decrypted)
+			if err == nil {
+				// decrypt the first chunk before the response is started, so that content
+				// encrypted with another key is reported as an error
+				if _, peekErr := plaintext.Peek(1); peekErr != nil && peekErr != io.EOF {
+					err = peekErr
+				}
+			}
+			if err != nil {
+				ctx.Logger.Error("Failed to decrypt evaluation job generations", "error", err, "job_id", evaluationJobID, "benchmark_index", benchmarkIndex, "name", name)
+				err := serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			ctx.Logger.Warn("Raw generations downloaded", "job_id", evaluationJobID, "benchmark_index", benchmarkIndex, "name", name, "size", generation.Size)
+			w.SetHeader("Content-Type", generation.ContentType)
+			w.SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+			if ctx.RequestID != "" {
+				w.SetHeader("X-Global-Transaction-Id", ctx.RequestID)
+			}
+			w.SetStatusCode(200)
+			if _, err := io.Copy(w, plaintext); err != nil {
+				// the response has started, the client sees a truncated download
+				ctx.Logger.Error("Failed to stream evaluation job generations", "error", err, "job_id", evaluationJobID, "benchmark_index", benchmarkIndex, "name", name)
+				return err
+			}
+			logging.LogRequestSuccess(ctx, 200, nil)
+			return nil
+		},
+		"mlflow",
+		"download-evaluation-generations",
+		"job.id", evaluationJobID,
+		"benchmark.index", strconv.Itoa(benchmarkIndex),
+	)
+}
+
+// isGenerationReader returns true when the user can read the raw generations of the jobs.
+func (h *Handlers) isGenerationReader(ctx *executioncontext.ExecutionContext) bool {
+	return h.serviceConfig != nil && h.serviceConfig.JobAccess.IsGenerationReader(ctx.Groups)
+}
+
+// generationsNamePathParameter returns the name of the generations of the path, or writes
+// the error and returns false when it is missing or invalid.
+func generationsNamePathParameter(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) (string, bool) {
+	name := req.PathValue(constants.PATH_PARAMETER_ARTIFACT_NAME)
+	if name == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_ARTIFACT_NAME), ctx.RequestID)
+		return "", false
+	}
+	if !artifactNamePattern.MatchString(name) {
+		w.Error(serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", constants.PATH_PARAMETER_ARTIFACT_NAME, "Type", "file name of letters, digits, '.', '_' and '-'", "Value", name), ctx.RequestID)
+		return "", false
+	}
+	return name, true
+}
@@ -0,0 +1,211 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/eval-hub/eval-hub/pkg/mlflowclient"
+)
+
+// generationsTestStorage keeps the generations it is given in memory.
+type generationsTestStorage struct {
+	*artifactsTestStorage
+	generations []api.ArtifactResource
+}
+
+func (s *generationsTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *generationsTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *generationsTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *generationsTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *generationsTestStorage) PutEvaluationJobGeneration(_ string, generation *api.ArtifactResource) error {
+	s.generations = append(s.generations, *generation)
+	return nil
+}
+
+func (s *generationsTestStorage) GetEvaluationJobGeneration(id string, benchmarkIndex int, name string) (*api.ArtifactResource, error) {
+	for _, generation := range s.generations {
+		if generation.JobID == id && generation.BenchmarkIndex == benchmarkIndex && generation.Name == name {
+			return &generation, nil
+		}
+	}
+	return nil, serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "evaluation job generations", "ResourceId", name)
+}
+
+func (s *generationsTestStorage) GetEvaluationJobGenerations(id string, benchmarkIndex int, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.ArtifactResource], error) {
+	items := []api.ArtifactResource{}
+	for _, generation := range s.generations {
+		if generation.JobID == id && generation.BenchmarkIndex == benchmarkIndex {
+			items = append(items, generation)
+		}
+	}
+	return &abstractions.QueryResults[api.ArtifactResource]{Items: items, TotalCount: len(items)}, nil
+}
+
+func TestEvaluationBenchmarkGenerations(t *testing.T) {
+	stored := map[string][]byte{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/mlflow-artifacts/artifacts/") {
+			http.NotFound(w, r)
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			stored[r.URL.Path], _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusOK)
+		case http.MethodGet:
+			content, ok := stored[r.URL.Path]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(content)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	storage := &generationsTestStorage{artifactsTestStorage: &artifactsTestStorage{baselineTestStorage: newBaselineTestStorage()}}
+	storage.jobs["job-generations"] = &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-generations", Tenant: "test-tenant"}, MLFlowExperimentID: "8"},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+		},
+	}
+	callbackAuth := &config.CallbackAuthConfig{Enabled: true, Secret: "test-secret"}
+	serviceConfig := &config.Config{
+		CallbackAuth: callbackAuth,
+		Artifacts:    &config.ArtifactsConfig{GenerationsKey: "generations-secret"},
+		JobAccess:    &config.JobAccessConfig{AdminGroups: []string{"admins"}, GenerationReaderGroups: []string{"red-team"}},
+	}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, mlflowclient.NewClient(srv.URL), serviceConfig, nil)
+
+	newContext := func(groups ...string) *executioncontext.ExecutionContext {
+		ctx := executioncontext.NewExecutionContext(context.Background(), "req-generations", logging.FallbackLogger(), "test-user", "test-tenant")
+		ctx.Groups = groups
+		return ctx
+	}
+	request := func(method, name string) *baselineRequest {
+		path := map[string]string{
+			constants.PATH_PARAMETER_JOB_ID:          "job-generations",
+			constants.PATH_PARAMETER_BENCHMARK_INDEX: "0",
+		}
+		uri := "/api/v1/evaluations/jobs/job-generations/benchmarks/0/generations"
+		if name != "" {
+			path[constants.PATH_PARAMETER_ARTIFACT_NAME] = name
+			uri += "/" + name
+		}
+		return &baselineRequest{MockRequest: createMockRequest(method, uri), path: path}
+	}
+	upload := func(h *handlers.Handlers, name, content string) *httptest.ResponseRecorder {
+		upload := &artifactRequest{baselineRequest: request("PUT", name), content: strings.NewReader(content)}
+		upload.SetHeader("Content-Type", "application/jsonl")
+		upload.SetHeader(callbackauth.TokenHeader, callbackauth.Token(callbackAuth, "job-generations"))
+		recorder := httptest.NewRecorder()
+		h.HandleUploadEvaluationBenchmarkGenerations(newContext(), upload, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	content := `{"doc_id":0,"prompt":"Which is heavier?","response":"B"}` + "\n"
+
+	t.Run("the generations are stored encrypted", func(t *testing.T) {
+		recorder := upload(h, "samples.jsonl", content)
+		if recorder.Code != 201 {
+			t.Fatalf("expected status 201, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if len(stored) != 1 {
+			t.Fatalf("expected one upload, got %d", len(stored))
+		}
+		for path, encrypted := range stored {
+			if !strings.HasSuffix(path, "8/eval-hub/jobs/job-generations/benchmarks/0/generations/samples.jsonl") {
+				t.Errorf("unexpected path %s", path)
+			}
+			if strings.Contains(string(encrypted), "heavier") {
+				t.Errorf("expected the generations to be encrypted, got %q", encrypted)
+			}
+		}
+		if len(storage.generations) != 1 || storage.generations[0].Size != int64(len(content)) || len(storage.artifacts) != 0 {
+			t.Errorf("expected the generations apart from the artifacts, got %+v and %+v", storage.generations, storage.artifacts)
+		}
+	})
+
+	t.Run("the generations are not stored without a key", func(t *testing.T) {
+		withoutKey := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, mlflowclient.NewClient(srv.URL), &config.Config{CallbackAuth: callbackAuth}, nil)
+		if recorder := upload(withoutKey, "other.jsonl", content); recorder.Code != 400 || !strings.Contains(recorder.Body.String(), "generations_key") {
+			t.Errorf("expected status 400, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("only the generation readers can list and download the generations", func(t *testing.T) {
+		for _, groups := range [][]string{nil, {"eval-users"}} {
+			recorder := httptest.NewRecorder()
+			h.HandleListEvaluationBenchmarkGenerations(newContext(groups...), request("GET", ""), MockResponseWrapper{recorder: recorder})
+			if recorder.Code != 403 {
+				t.Errorf("expected the list to be denied to %v, got %d: %s", groups, recorder.Code, recorder.Body.String())
+			}
+			recorder = httptest.NewRecorder()
+			h.HandleDownloadEvaluationBenchmarkGenerations(newContext(groups...), request("GET", "samples.jsonl"), MockResponseWrapper{recorder: recorder})
+			if recorder.Code != 403 {
+				t.Errorf("expected the download to be denied to %v, got %d: %s", groups, recorder.Code, recorder.Body.String())
+			}
+		}
+
+		for _, group := range []string{"red-team", "admins"} {
+			recorder := httptest.NewRecorder()
+			h.HandleListEvaluationBenchmarkGenerations(newContext(group), request("GET", ""), MockResponseWrapper{recorder: recorder})
+			if recorder.Code != 200 {
+				t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+			}
+			list := api.ArtifactResourceList{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &list); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if list.TotalCount != 1 || list.Items[0].Name != "samples.jsonl" {
+				t.Errorf("expected the uploaded generations, got %+v", list)
+			}
+
+			recorder = httptest.NewRecorder()
+			h.HandleDownloadEvaluationBenchmarkGenerations(newContext(group), request("GET", "samples.jsonl"), MockResponseWrapper{recorder: recorder})
+			if recorder.Code != 200 {
+				t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+			}
+			if recorder.Body.String() != content || recorder.Header().Get("Content-Type") != "application/jsonl" {
+				t.Errorf("expected the decrypted generations, got %q (%s)", recorder.Body.String(), recorder.Header().Get("Content-Type"))
+			}
+		}
+
+		recorder := httptest.NewRecorder()
+		h.HandleDownloadEvaluationBenchmarkGenerations(newContext("red-team"), request("GET", "missing.jsonl"), MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 404 {
+			t.Errorf("expected status 404, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("the generations can not be decrypted with another key", func(t *testing.T) {
+		rotated := *serviceConfig
+		rotated.Artifacts = &config.ArtifactsConfig{GenerationsKey: "another-secret"}
+		other := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, mlflowclient.NewClient(srv.URL), &rotated, nil)
+		recorder := httptest.NewRecorder()
+		other.HandleDownloadEvaluationBenchmarkGenerations(newContext("red-team"), request("GET", "samples.jsonl"), MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 500 || strings.Contains(recorder.Body.String(), "heavier") {
+			t.Errorf("expected status 500, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serialization"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The Grafana handlers serve the history of the metrics as a datasource of the Grafana JSON
+// datasource plugin, whose URL is /api/v1/evaluations/metrics/grafana. The datasource sends
+// the X-Tenant and X-User headers of the other requests, and sees the metrics of the jobs of
+// the tenant that the user can read.
+
+// HandleGrafanaTest handles GET /api/v1/evaluations/metrics/grafana, the test of the datasource.
+func (h *Handlers) HandleGrafanaTest(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	logging.LogRequestStarted(ctx)
+	w.WriteJSON(map[string]string{"status": "ok"}, 200)
+}
+
+// HandleGrafanaSearch handles POST /api/v1/evaluations/metrics/grafana/search, the names of the
+// metrics that contain the target.
+func (h *Handlers) HandleGrafanaSearch(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	search := &api.GrafanaSearchRequest{}
+	if err := h.decodeGrafanaRequest(ctx, r, search); err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			filter := &abstractions.QueryFilter{Params: map[string]any{}}
+			h.addJobVisibilityFilter(ctx, filter.Params)
+			names, err := storage.WithContext(runtimeCtx).GetEvaluationMetricNames(filter)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			matching := make([]string, 0, len(names))
+			for _, name := range names {
+				if strings.Contains(name, search.Target) {
+					matching = append(matching, name)
+				}
+			}
+			w.WriteJSON(matching, 200)
+			return nil
+		},
+		"storage",
+		"grafana-search",
+	)
+}
+
+// HandleGrafanaQuery handles POST /api/v1/evaluations/metrics/grafana/query, a time series of
+// the values of the metric of each target in the time range for each model and benchmark.
+func (h *Handlers) HandleGrafanaQuery(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	query := &api.GrafanaQueryRequest{}
+	if err := h.decodeGrafanaRequest(ctx, r, query); err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			series := make([]api.GrafanaTimeSeries, 0)
+			for _, target := range query.Targets {
+				if target.Hide {
+					continue
+				}
+				filter := &abstractions.QueryFilter{Params: map[string]any{
+					"metric":          target.Target,
+					"recorded_after":  query.Range.From,
+					"recorded_before": query.Range.To,
+				}}
+				if payload := target.Payload; payload != nil {
+					for param, value := range map[string]string{"model": payload.Model, "benchmark": payload.Benchmark, "provider_id": payload.ProviderID} {
+						if value != "" {
+							filter.Params[param] = value
+						}
+					}
+				}
+				h.addJobVisibilityFilter(ctx, filter.Params)
+				history, err := scoped.GetEvaluationMetricsHistory(filter)
+				if err != nil {
+					w.Error(err, ctx.RequestID)
+					return err
+				}
+				series = append(series, grafanaTimeSeries(target, history.Items)...)
+			}
+			w.WriteJSON(series, 200)
+			return nil
+		},
+		"storage",
+		"grafana-query",
+	)
+}
+
+func (h *Handlers) decodeGrafanaRequest(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, request any) error {
+	return h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			bodyBytes, err := r.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			return serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, request)
+		},
+		"validation",
+		"validate-grafana-request",
+	)
+}
+
+// grafanaTimeSeries returns a series of the points, which are the oldest first, for each model
+// and benchmark in the order of their first point. A series is named after its model,
+// benchmark and metric.
+func grafanaTimeSeries(target api.GrafanaTarget, points []api.MetricPoint) []api.GrafanaTimeSeries {
+	var series []api.GrafanaTimeSeries
+	indexes := map[string]int{}
+	for _, point := range points {
+		name := point.ModelName + " " + point.ProviderID + "/" + point.BenchmarkID + " " + point.Metric
+		index, ok := indexes[name]
+		if !ok {
+			index = len(series)
+			indexes[name] = index
+			series = append(series, api.GrafanaTimeSeries{Target: name, RefID: target.RefID, Datapoints: [][2]float64{}})
+		}
+		series[index].Datapoints = append(series[index].Datapoints, [2]float64{point.Value, float64(point.RecordedAt.UnixMilli())})
+	}
+	return series
+}
@@ -0,0 +1,116 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// grafanaTestStorage returns the metric points it holds and records the filters of the queries.
+type grafanaTestStorage struct {
+	abstractions.Storage
+	points  []api.MetricPoint
+	filters []*abstractions.QueryFilter
+}
+
+func (s *grafanaTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *grafanaTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *grafanaTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *grafanaTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *grafanaTestStorage) GetEvaluationMetricsHistory(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.MetricPoint], error) {
+	s.filters = append(s.filters, filter)
+	return &abstractions.QueryResults[api.MetricPoint]{Items: s.points, TotalCount: len(s.points)}, nil
+}
+
+func (s *grafanaTestStorage) GetEvaluationMetricNames(filter *abstractions.QueryFilter) ([]string, error) {
+	s.filters = append(s.filters, filter)
+	return []string{"acc", "acc_norm", "bleu"}, nil
+}
+
+func TestHandleGrafanaQuery(t *testing.T) {
+	released := time.Date(2026, 9, 1, 12, 0, 0, 0, time.UTC)
+	storage := &grafanaTestStorage{points: []api.MetricPoint{
+		{ModelName: "granite", ProviderID: "lm_evaluation_harness", BenchmarkID: "mmlu", Metric: "acc", Value: 0.6, RecordedAt: released},
+		{ModelName: "granite", ProviderID: "lm_evaluation_harness", BenchmarkID: "arc_easy", Metric: "acc", Value: 0.8, RecordedAt: released},
+		{ModelName: "granite", ProviderID: "lm_evaluation_harness", BenchmarkID: "mmlu", Metric: "acc", Value: 0.7, RecordedAt: released.Add(time.Hour)},
+	}}
+	serviceConfig := &config.Config{JobAccess: &config.JobAccessConfig{OwnerScoped: true}}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, serviceConfig, nil)
+
+	query := func(body string) *httptest.ResponseRecorder {
+		storage.filters = nil
+		recorder := httptest.NewRecorder()
+		h.HandleGrafanaQuery(jobAccessContext("alice"), &bodyRequest{
+			MockRequest: createMockRequest("POST", "/api/v1/evaluations/metrics/grafana/query"),
+			body:        []byte(body),
+		}, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	recorder := query(`{
+		"range": {"from": "2026-09-01T00:00:00Z", "to": "2026-09-02T00:00:00Z"},
+		"targets": [
+			{"refId": "A", "target": "acc", "payload": {"model": "granite"}},
+			{"refId": "B", "target": "bleu", "hide": true}
+		]
+	}`)
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var series []api.GrafanaTimeSeries
+	if err := json.Unmarshal(recorder.Body.Bytes(), &series); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(series) != 2 || series[0].Target != "granite lm_evaluation_harness/mmlu acc" || series[0].RefID != "A" {
+		t.Fatalf("expected a series for each benchmark, got %+v", series)
+	}
+	if want := [][2]float64{{0.6, float64(released.UnixMilli())}, {0.7, float64(released.Add(time.Hour).UnixMilli())}}; !slices.Equal(series[0].Datapoints, want) {
+		t.Errorf("datapoints = %v, want %v", series[0].Datapoints, want)
+	}
+	if len(storage.filters) != 1 {
+		t.Fatalf("expected the hidden target not to be queried, got %d queries", len(storage.filters))
+	}
+	params := storage.filters[0].Params
+	if params["metric"] != "acc" || params["model"] != "granite" || params["benchmark"] != nil || params["recorded_after"] != time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("unexpected filter params %v", params)
+	}
+	if visibility, _ := params["visible_to"].(abstractions.JobVisibility); visibility.User != "alice" {
+		t.Errorf("expected the metrics of the jobs visible to alice, got %v", params["visible_to"])
+	}
+
+	if recorder := query(`{"range": {"from": "2026-09-02T00:00:00Z", "to": "2026-09-01T00:00:00Z"}, "targets": [{"target": "acc"}]}`); recorder.Code != 400 {
+		t.Errorf("expected status 400 for an inverted range, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleGrafanaSearch(t *testing.T) {
+	storage := &grafanaTestStorage{}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+
+	recorder := httptest.NewRecorder()
+	h.HandleGrafanaSearch(jobAccessContext("alice"), &bodyRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/metrics/grafana/search"),
+		body:        []byte(`{"target": "acc"}`),
+	}, MockResponseWrapper{recorder: recorder})
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var names []string
+	if err := json.Unmarshal(recorder.Body.Bytes(), &names); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !slices.Equal(names, []string{"acc", "acc_norm"}) {
+		t.Errorf("expected the metrics that contain the target, got %v", names)
+	}
+}
@@ -1,9 +1,12 @@
 package handlers
 
 import (
+	"sync/atomic"
+
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/evalcards"
+	"github.com/eval-hub/eval-hub/pkg/api"
 	"github.com/eval-hub/eval-hub/pkg/mlflowclient"
 	"github.com/go-playground/validator/v10"
 )
@@ -16,6 +19,19 @@ type Handlers struct {
 	mlflowClient    *mlflowclient.Client
 	resultsExporter evalcards.ResultsExporter
 	serviceConfig   *config.Config
+	providerHealth  abstractions.ProviderHealthReporter
+	imageWarmup     abstractions.ImageWarmupReporter
+	jobWatcher      abstractions.JobWatcher
+	jobAdmission    abstractions.JobAdmission
+	modelWaits      *modelWaits
+	experimentLinks *experimentLinks
+	maintenance     *maintenance
+
+	providerHealthScheduler abstractions.ProviderHealthScheduler
+	reconcileScheduler      abstractions.ReconcileScheduler
+	// appliedAdminConfig is the admin configuration this replica applied last
+	appliedAdminConfig atomic.Pointer[api.AdminConfig]
+	permissionCheck    *api.PermissionCheck
 }
 
 func New(
@@ -33,5 +49,52 @@ func New(
 		mlflowClient:    mlflowClient,
 		resultsExporter: resultsExporter,
 		serviceConfig:   serviceConfig,
+		modelWaits:      newModelWaits(),
+		experimentLinks: newExperimentLinks(),
+		maintenance:     &maintenance{},
 	}
 }
+
+// WithProviderHealth sets the source of provider health reported on the providers API.
+func (h *Handlers) WithProviderHealth(providerHealth abstractions.ProviderHealthReporter) *Handlers {
+	h.providerHealth = providerHealth
+	return h
+}
+
+// WithImageWarmup sets the source of the image pre-pull status reported on the providers API.
+func (h *Handlers) WithImageWarmup(imageWarmup abstractions.ImageWarmupReporter) *Handlers {
+	h.imageWarmup = imageWarmup
+	return h
+}
+
+// WithJobWatcher sets the source of job updates streamed by the watch API.
+func (h *Handlers) WithJobWatcher(jobWatcher abstractions.JobWatcher) *Handlers {
+	h.jobWatcher = jobWatcher
+	return h
+}
+
+// WithJobAdmission sets the admission webhooks that review evaluation jobs on creation.
+func (h *Handlers) WithJobAdmission(jobAdmission abstractions.JobAdmission) *Handlers {
+	h.jobAdmission = jobAdmission
+	return h
+}
+
+// WithProviderHealthScheduler sets the provider health checks whose poll interval can be
+// changed on the admin API.
+func (h *Handlers) WithProviderHealthScheduler(providerHealthScheduler abstractions.ProviderHealthScheduler) *Handlers {
+	h.providerHealthScheduler = providerHealthScheduler
+	return h
+}
+
+// WithReconcileScheduler sets the reconciliation of the job workloads whose interval can be
+// changed on the admin API.
+func (h *Handlers) WithReconcileScheduler(reconcileScheduler abstractions.ReconcileScheduler) *Handlers {
+	h.reconcileScheduler = reconcileScheduler
+	return h
+}
+
+// WithPermissionCheck sets the check of the permissions of the runtime reported on /readyz.
+func (h *Handlers) WithPermissionCheck(permissionCheck *api.PermissionCheck) *Handlers {
+	h.permissionCheck = permissionCheck
+	return h
+}
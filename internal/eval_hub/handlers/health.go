@@ -8,10 +8,13 @@ import (
 	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
 	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
 const (
-	STATUS_HEALTHY = "healthy"
+	STATUS_HEALTHY     = "healthy"
+	STATUS_READY       = "ready"
+	STATUS_MAINTENANCE = "maintenance"
 )
 
 type HealthResponse struct {
@@ -33,3 +36,31 @@ func (h *Handlers) HandleHealth(ctx *executioncontext.ExecutionContext, r http_w
 	}
 	w.WriteJSON(healthInfo, 200)
 }
+
+// ReadinessResponse is the response of /readyz.
+type ReadinessResponse struct {
+	Status      string               `json:"status"`
+	Timestamp   time.Time            `json:"timestamp"`
+	Maintenance *api.MaintenanceMode `json:"maintenance,omitempty"`
+	// Permissions is the check of the Kubernetes permissions of the runtime made when the
+	// replica started, with the permissions that are missing.
+	Permissions *api.PermissionCheck `json:"permissions,omitempty"`
+}
+
+// HandleReadiness reports whether the replica accepts new evaluation jobs. In maintenance
+// mode the status is maintenance but the response is still a 200: the replica keeps serving
+// the reads and the status updates of the running jobs while it drains.
+func (h *Handlers) HandleReadiness(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	// probes must not flood the logs, see HandleHealth
+	ctx.Ctx = context.WithValue(ctx.Ctx, logging.LogLevelKey, slog.LevelDebug)
+	readiness := ReadinessResponse{
+		Status:    STATUS_READY,
+		Timestamp: time.Now().UTC(),
+	}
+	readiness.Permissions = h.permissionCheck
+	if mode := h.maintenance.get(); mode.Enabled {
+		readiness.Status = STATUS_MAINTENANCE
+		readiness.Maintenance = &mode
+	}
+	w.WriteJSON(readiness, 200)
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
 func TestHandleHealth(t *testing.T) {
@@ -55,3 +56,34 @@ func TestHandleHealth(t *testing.T) {
 		}
 	})
 }
+
+func TestHandleReadinessReportsMissingPermissions(t *testing.T) {
+	check := &api.PermissionCheck{
+		CheckedAt: time.Now().UTC(),
+		Missing: []api.MissingPermission{{
+			Cluster:     "local",
+			Namespace:   "eval-hub",
+			Verb:        "get",
+			Resource:    "pods",
+			Subresource: "log",
+			NeededFor:   "the logs and the failure diagnostics of the benchmarks",
+		}},
+	}
+	h := handlers.New(nil, nil, nil, nil, nil, nil).WithPermissionCheck(check)
+
+	w := httptest.NewRecorder()
+	h.HandleReadiness(createExecutionContext(), createMockRequest("GET", "/readyz"), &MockResponseWrapper{w})
+	if w.Code != 200 {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var got handlers.ReadinessResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Status != handlers.STATUS_READY {
+		t.Errorf("expected the replica to stay ready, got %s", got.Status)
+	}
+	if got.Permissions == nil || len(got.Permissions.Missing) != 1 || got.Permissions.Missing[0].Subresource != "log" {
+		t.Errorf("expected the missing permission on /readyz, got %+v", got.Permissions)
+	}
+}
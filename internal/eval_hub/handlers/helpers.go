@@ -8,6 +8,7 @@ import (
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
@@ -103,6 +104,23 @@ func GetParam[T string | int | bool](r http_wrappers.RequestWrapper, name string
 	}
 }
 
+// GetTimeParam returns the RFC 3339 date-time of an optional query parameter, nil when it is
+// not set. The value is not decoded again, which would turn the + of a time zone offset into
+// a space.
+func GetTimeParam(r http_wrappers.RequestWrapper, name string) (*time.Time, error) {
+	for _, value := range r.Query(name) {
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", name, "Type", "RFC 3339 date-time", "Value", value)
+		}
+		return &t, nil
+	}
+	return nil, nil
+}
+
 func CheckScope(filter *abstractions.QueryFilter) error {
 	// owner and scope are mutually exclusive
 	mismatchedParams := []string{"owner", "scope"}
@@ -259,6 +277,21 @@ func GetJobBenchmarks(job *api.EvaluationJobResource, collection *api.Collection
 	return job.Benchmarks, nil
 }
 
+// providerGetter reads the providers of the benchmarks.
+type providerGetter interface {
+	GetProvider(id string) (*api.ProviderResource, error)
+}
+
+// applyCollectionDefaultParameters merges the default parameters of the providers under
+// the parameters of the benchmarks of a collection job, as resolved by GetJobBenchmarks.
+// The benchmarks of a job without a collection are stored with them when it is created.
+func applyCollectionDefaultParameters(storage providerGetter, job *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig) error {
+	if job.Collection == nil || job.Collection.ID == "" {
+		return nil
+	}
+	return applyDefaultParameters(storage, benchmarks)
+}
+
 func mergeBenchmarkParameters(benchmark api.CollectionBenchmarkConfig, jobBenchmarks []api.EvaluationBenchmarkConfig) api.EvaluationBenchmarkConfig {
 	parameters := map[string]any{}
 	for _, jobBenchmark := range jobBenchmarks {
@@ -273,18 +306,24 @@ func mergeBenchmarkParameters(benchmark api.CollectionBenchmarkConfig, jobBenchm
 			parameters[key] = value
 		}
 	}
-	// pick up TestDataRef and HardwareConfig from the job override if provided
+	// pick up TestDataRef, Conversation, HardwareConfig and Shards from the job override if provided
 	testDataRef := benchmark.TestDataRef
+	conversation := benchmark.Conversation
 	var hardwareConfig *api.BenchmarkHardwareConfig
+	shards := 0
 
 	for _, jobBenchmark := range jobBenchmarks {
 		if jobBenchmark.ID == benchmark.ID && jobBenchmark.ProviderID == benchmark.ProviderID {
 			if jobBenchmark.TestDataRef != nil {
 				testDataRef = jobBenchmark.TestDataRef
 			}
+			if jobBenchmark.Conversation != nil {
+				conversation = jobBenchmark.Conversation
+			}
 			if jobBenchmark.HardwareConfig != nil {
 				hardwareConfig = jobBenchmark.HardwareConfig
 			}
+			shards = jobBenchmark.Shards
 			break
 		}
 	}
@@ -302,9 +341,13 @@ func mergeBenchmarkParameters(benchmark api.CollectionBenchmarkConfig, jobBenchm
 		Weight:         benchmark.Weight,
 		PrimaryScore:   benchmark.PrimaryScore,
 		PassCriteria:   benchmark.PassCriteria,
+		Required:       benchmark.Required,
+		Retry:          benchmark.Retry,
 		HardwareConfig: hardwareConfig,
 		TestDataRef:    testDataRef,
+		Conversation:   conversation,
 		Parameters:     parameters,
+		Shards:         shards,
 	}
 }
 
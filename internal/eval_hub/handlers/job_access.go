@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serialization"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// jobAccess is what a request does with an evaluation job, the action of JobAccessDenied.
+type jobAccess string
+
+const (
+	jobAccessRead     jobAccess = "read"
+	jobAccessChange   jobAccess = "change"
+	jobAccessCancel   jobAccess = "cancel"
+	jobAccessShare    jobAccess = "share"
+	jobAccessTransfer jobAccess = "hand over"
+)
+
+// isJobAccessScoped returns true when the jobs are scoped to their owner for the user of the
+// request. Requests without a user, i.e. in local mode, and the admin groups are not scoped.
+func (h *Handlers) isJobAccessScoped(ctx *executioncontext.ExecutionContext) bool {
+	if h.serviceConfig == nil || !h.serviceConfig.JobAccess.IsOwnerScoped() || ctx.User == "" {
+		return false
+	}
+	return !h.serviceConfig.JobAccess.IsAdmin(ctx.Groups)
+}
+
+// checkJobAccess returns an error when the user of the request cannot access the job.
+// A job that is not visible to the user is reported as not found, so that its existence
+// is not disclosed.
+func (h *Handlers) checkJobAccess(ctx *executioncontext.ExecutionContext, job *api.EvaluationJobResource, access jobAccess) error {
+	if !h.isJobAccessScoped(ctx) || job.Resource.Owner == ctx.User {
+		return nil
+	}
+	// the members of the reviewer groups read the jobs they review
+	if access == jobAccessRead && h.isReviewer(ctx) && api.NewReviewResource(job) != nil {
+		return nil
+	}
+	if !isJobSharedWith(job, ctx.User, ctx.Groups) {
+		return serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "evaluation job", "ResourceId", job.Resource.ID)
+	}
+	if access != jobAccessRead {
+		return serviceerrors.NewServiceError(messages.JobAccessDenied, "User", ctx.User, "Action", string(access), "EvaluationJobID", job.Resource.ID)
+	}
+	return nil
+}
+
+// getAccessibleEvaluationJob returns the job when the user of the request can access it.
+func (h *Handlers) getAccessibleEvaluationJob(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, evaluationJobID string, access jobAccess) (*api.EvaluationJobResource, error) {
+	job, err := storage.GetEvaluationJob(evaluationJobID)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.checkJobAccess(ctx, job, access); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// addJobVisibilityFilter restricts a list of jobs to the ones the user of the request can
+// read when the jobs are scoped to their owner.
+func (h *Handlers) addJobVisibilityFilter(ctx *executioncontext.ExecutionContext, params map[string]any) {
+	if h.isJobAccessScoped(ctx) {
+		params["visible_to"] = abstractions.JobVisibility{User: ctx.User, Groups: ctx.Groups}
+	}
+}
+
+func isJobSharedWith(job *api.EvaluationJobResource, user api.User, groups []string) bool {
+	sharing := job.SharedWith
+	if sharing.IsEmpty() {
+		return false
+	}
+	return slices.Contains(sharing.Users, user) || slices.ContainsFunc(groups, func(group string) bool {
+		return slices.Contains(sharing.Groups, group)
+	})
+}
+
+// HandleUpdateEvaluationOwner handles PUT /api/v1/evaluations/jobs/{id}/owner
+func (h *Handlers) HandleUpdateEvaluationOwner(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID := r.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+
+	ownership := &api.JobOwnership{}
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			bodyBytes, err := r.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			return serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, ownership)
+		},
+		"validation",
+		"validate-evaluation-job-owner",
+		"job.id", evaluationJobID,
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			if _, err := h.getAccessibleEvaluationJob(ctx, scoped, evaluationJobID, jobAccessTransfer); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			job, err := scoped.UpdateEvaluationJobOwner(evaluationJobID, ownership.Owner)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			ctx.Logger.Info("Evaluation job handed over", "id", evaluationJobID, "owner", ownership.Owner)
+			w.WriteJSON(localizeJobMessages(ctx, job), 200)
+			return nil
+		},
+		"storage",
+		"update-evaluation-job-owner",
+		"job.id", evaluationJobID,
+	)
+}
+
+// HandleUpdateEvaluationSharing handles PUT /api/v1/evaluations/jobs/{id}/sharing, the
+// users and groups in the body replace the ones the job was shared with.
+func (h *Handlers) HandleUpdateEvaluationSharing(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID := r.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+
+	sharing := &api.JobSharing{}
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			bodyBytes, err := r.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			return serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, sharing)
+		},
+		"validation",
+		"validate-evaluation-job-sharing",
+		"job.id", evaluationJobID,
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			if _, err := h.getAccessibleEvaluationJob(ctx, scoped, evaluationJobID, jobAccessShare); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			// "add" replaces the sharing when the job is already shared
+			job, err := scoped.PatchEvaluationJob(evaluationJobID, &api.Patch{
+				{Op: api.PatchOpAdd, Path: "/shared_with", Value: sharing},
+			})
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(localizeJobMessages(ctx, job), 200)
+			return nil
+		},
+		"storage",
+		"update-evaluation-job-sharing",
+		"job.id", evaluationJobID,
+	)
+}
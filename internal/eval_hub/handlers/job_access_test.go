@@ -0,0 +1,232 @@
+package handlers_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// jobAccessTestStorage keeps the jobs in memory and records the list filter.
+type jobAccessTestStorage struct {
+	abstractions.Storage
+	jobs      map[string]*api.EvaluationJobResource
+	filter    *abstractions.QueryFilter
+	durations map[abstractions.BenchmarkDurationKey]time.Duration
+}
+
+func (s *jobAccessTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *jobAccessTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *jobAccessTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *jobAccessTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *jobAccessTestStorage) GetEvaluationJob(id string) (*api.EvaluationJobResource, error) {
+	if job, ok := s.jobs[id]; ok {
+		return job, nil
+	}
+	return nil, serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "evaluation job", "ResourceId", id)
+}
+
+func (s *jobAccessTestStorage) GetEvaluationJobs(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.EvaluationJobResource], error) {
+	s.filter = filter
+	return &abstractions.QueryResults[api.EvaluationJobResource]{}, nil
+}
+
+func (s *jobAccessTestStorage) GetBenchmarkDurations(_ []abstractions.BenchmarkDurationKey) (map[abstractions.BenchmarkDurationKey]time.Duration, error) {
+	return s.durations, nil
+}
+
+func (s *jobAccessTestStorage) UpdateEvaluationJobOwner(id string, owner api.User) (*api.EvaluationJobResource, error) {
+	job := s.jobs[id]
+	job.Resource.Owner = owner
+	return job, nil
+}
+
+func (s *jobAccessTestStorage) PatchEvaluationJob(id string, patches *api.Patch) (*api.EvaluationJobResource, error) {
+	job := s.jobs[id]
+	job.SharedWith = (*patches)[0].Value.(*api.JobSharing)
+	return job, nil
+}
+
+func (s *jobAccessTestStorage) UpdateEvaluationJobStatus(_ string, _ api.OverallState, _ *api.MessageInfo) (api.OverallState, error) {
+	return "", nil
+}
+
+func newJobAccessTestHandlers(t *testing.T) (*handlers.Handlers, *jobAccessTestStorage) {
+	t.Helper()
+	storage := &jobAccessTestStorage{
+		jobs: map[string]*api.EvaluationJobResource{
+			"job-1": {
+				Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1", Owner: "alice"}},
+				Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+				EvaluationJobConfig: api.EvaluationJobConfig{
+					SharedWith: &api.JobSharing{Users: []api.User{"bob"}, Groups: []string{"ml-team"}},
+				},
+			},
+		},
+	}
+	serviceConfig := &config.Config{JobAccess: &config.JobAccessConfig{OwnerScoped: true, AdminGroups: []string{"eval-admins"}}}
+	return handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, serviceConfig, nil), storage
+}
+
+func jobAccessContext(user api.User, groups ...string) *executioncontext.ExecutionContext {
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-job-access", logging.FallbackLogger(), user, "test-tenant")
+	ctx.Groups = groups
+	return ctx
+}
+
+func TestHandleGetEvaluation_OwnerScoped(t *testing.T) {
+	h, _ := newJobAccessTestHandlers(t)
+
+	for _, tc := range []struct {
+		name string
+		ctx  *executioncontext.ExecutionContext
+		want int
+	}{
+		{"the owner can read the job", jobAccessContext("alice"), 200},
+		{"a user the job is shared with can read it", jobAccessContext("bob"), 200},
+		{"a member of a group the job is shared with can read it", jobAccessContext("carol", "dev", "ml-team"), 200},
+		{"an admin can read the job", jobAccessContext("erin", "eval-admins"), 200},
+		{"another user of the tenant cannot see the job", jobAccessContext("dave", "dev"), 404},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			h.HandleGetEvaluation(tc.ctx, &baselineRequest{
+				MockRequest: createMockRequest("GET", "/api/v1/evaluations/jobs/job-1"),
+				path:        map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-1"},
+			}, MockResponseWrapper{recorder: recorder})
+			if recorder.Code != tc.want {
+				t.Fatalf("expected status %d, got %d: %s", tc.want, recorder.Code, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleCancelEvaluation_OwnerScoped(t *testing.T) {
+	h, _ := newJobAccessTestHandlers(t)
+
+	cancel := func(ctx *executioncontext.ExecutionContext) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		h.HandleCancelEvaluation(ctx, &baselineRequest{
+			MockRequest: createMockRequest("DELETE", "/api/v1/evaluations/jobs/job-1"),
+			path:        map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-1"},
+		}, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	if recorder := cancel(jobAccessContext("bob")); recorder.Code != 403 || !strings.Contains(recorder.Body.String(), "job_access_denied") {
+		t.Fatalf("expected job_access_denied for a user the job is shared with, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := cancel(jobAccessContext("alice")); recorder.Code != 204 {
+		t.Fatalf("expected the owner to cancel the job, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleUpdateEvaluationOwner(t *testing.T) {
+	transfer := func(h *handlers.Handlers, ctx *executioncontext.ExecutionContext, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		h.HandleUpdateEvaluationOwner(ctx, &baselineRequest{
+			MockRequest: createMockRequest("PUT", "/api/v1/evaluations/jobs/job-1/owner"),
+			path:        map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-1"},
+			body:        []byte(body),
+		}, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	t.Run("the owner hands the job over", func(t *testing.T) {
+		h, storage := newJobAccessTestHandlers(t)
+		if recorder := transfer(h, jobAccessContext("alice"), `{"owner": "dave"}`); recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if owner := storage.jobs["job-1"].Resource.Owner; owner != "dave" {
+			t.Fatalf("expected dave to own the job, got %s", owner)
+		}
+	})
+
+	t.Run("a user the job is shared with cannot take it over", func(t *testing.T) {
+		h, storage := newJobAccessTestHandlers(t)
+		if recorder := transfer(h, jobAccessContext("bob"), `{"owner": "bob"}`); recorder.Code != 403 {
+			t.Fatalf("expected status 403, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if owner := storage.jobs["job-1"].Resource.Owner; owner != "alice" {
+			t.Fatalf("expected alice to still own the job, got %s", owner)
+		}
+	})
+
+	t.Run("an admin hands the job over", func(t *testing.T) {
+		h, _ := newJobAccessTestHandlers(t)
+		if recorder := transfer(h, jobAccessContext("erin", "eval-admins"), `{"owner": "dave"}`); recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("an owner is required", func(t *testing.T) {
+		h, _ := newJobAccessTestHandlers(t)
+		if recorder := transfer(h, jobAccessContext("alice"), `{}`); recorder.Code != 400 {
+			t.Fatalf("expected status 400, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+}
+
+func TestHandleUpdateEvaluationSharing(t *testing.T) {
+	share := func(h *handlers.Handlers, ctx *executioncontext.ExecutionContext, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		h.HandleUpdateEvaluationSharing(ctx, &baselineRequest{
+			MockRequest: createMockRequest("PUT", "/api/v1/evaluations/jobs/job-1/sharing"),
+			path:        map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-1"},
+			body:        []byte(body),
+		}, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	h, storage := newJobAccessTestHandlers(t)
+	if recorder := share(h, jobAccessContext("bob"), `{"users": ["dave"]}`); recorder.Code != 403 {
+		t.Fatalf("expected a user the job is shared with not to share it, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := share(h, jobAccessContext("alice"), `{"users": ["dave"], "groups": ["qa"]}`); recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	sharing := storage.jobs["job-1"].SharedWith
+	if len(sharing.Users) != 1 || sharing.Users[0] != "dave" || len(sharing.Groups) != 1 || sharing.Groups[0] != "qa" {
+		t.Fatalf("expected the sharing to be replaced, got %+v", sharing)
+	}
+}
+
+func TestHandleListEvaluations_OwnerScoped(t *testing.T) {
+	h, storage := newJobAccessTestHandlers(t)
+
+	list := func(ctx *executioncontext.ExecutionContext) {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		h.HandleListEvaluations(ctx, &baselineRequest{
+			MockRequest: createMockRequest("GET", "/api/v1/evaluations/jobs"),
+		}, MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	}
+
+	list(jobAccessContext("bob", "ml-team"))
+	visibility, ok := storage.filter.Params["visible_to"].(abstractions.JobVisibility)
+	if !ok || visibility.User != "bob" || len(visibility.Groups) != 1 || visibility.Groups[0] != "ml-team" {
+		t.Fatalf("expected the jobs to be restricted to the ones visible to bob, got %v", storage.filter.Params)
+	}
+
+	list(jobAccessContext("erin", "eval-admins"))
+	if _, ok := storage.filter.Params["visible_to"]; ok {
+		t.Fatalf("expected an admin to list every job, got %v", storage.filter.Params)
+	}
+}
@@ -138,6 +138,28 @@ func TestMergeBenchmarkParameters(t *testing.T) {
 		}
 	})
 
+	t.Run("job override conversation replaces the collection conversation", func(t *testing.T) {
+		t.Parallel()
+		benchmark := api.CollectionBenchmarkConfig{
+			Ref:          api.Ref{ID: "bench-1"},
+			ProviderID:   "prov-a",
+			Conversation: &api.ConversationConfig{SystemPrompt: "from collection"},
+		}
+		got := mergeBenchmarkParameters(benchmark, nil)
+		if got.Conversation == nil || got.Conversation.SystemPrompt != "from collection" {
+			t.Fatalf("conversation = %+v, want the collection conversation", got.Conversation)
+		}
+		job := []api.EvaluationBenchmarkConfig{{
+			Ref:          api.Ref{ID: "bench-1"},
+			ProviderID:   "prov-a",
+			Conversation: &api.ConversationConfig{MultiTurn: true},
+		}}
+		got = mergeBenchmarkParameters(benchmark, job)
+		if got.Conversation == nil || !got.Conversation.MultiTurn || got.Conversation.SystemPrompt != "" {
+			t.Fatalf("conversation = %+v, want the job override", got.Conversation)
+		}
+	})
+
 	t.Run("provider-level hardware_config when benchmark id omitted", func(t *testing.T) {
 		t.Parallel()
 		benchmark := api.CollectionBenchmarkConfig{
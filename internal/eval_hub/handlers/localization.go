@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"slices"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// localizeJobMessages returns the job with the status messages set by the server
+// translated to the locale of the request. Messages reported by runtimes, adapters
+// and the SDK are passed through as they are. The job is copied before it is changed
+// since it may be shared, e.g. by the watchers of a job.
+func localizeJobMessages(ctx *executioncontext.ExecutionContext, job *api.EvaluationJobResource) *api.EvaluationJobResource {
+	if ctx.Messages == nil || job == nil || job.Status == nil {
+		return job
+	}
+	localized := *job
+	status := *job.Status
+	status.Message = localizeMessage(ctx, status.Message)
+	status.Benchmarks = slices.Clone(status.Benchmarks)
+	for i := range status.Benchmarks {
+		status.Benchmarks[i].ErrorMessage = localizeMessage(ctx, status.Benchmarks[i].ErrorMessage)
+		status.Benchmarks[i].WarningMessage = localizeMessage(ctx, status.Benchmarks[i].WarningMessage)
+	}
+	localized.Status = &status
+	return &localized
+}
+
+func localizeMessage(ctx *executioncontext.ExecutionContext, message *api.MessageInfo) *api.MessageInfo {
+	if message == nil || message.MessageOrigin != api.MessageOriginServer {
+		return message
+	}
+	localized := *message
+	localized.Message = ctx.Messages.StatusMessage(message.Message)
+	return &localized
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestLocalizeJobMessages(t *testing.T) {
+	catalog, err := messages.NewCatalog("de", nil, map[string]string{
+		"Evaluation job created": "Evaluierungsjob erstellt",
+		"Benchmark failed":       "Benchmark fehlgeschlagen",
+	})
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+	job := &api.EvaluationJobResource{
+		Status: &api.EvaluationJobStatus{
+			EvaluationJobState: api.EvaluationJobState{
+				State:   api.OverallStatePending,
+				Message: &api.MessageInfo{Message: "Evaluation job created", MessageCode: "evaluation_job_created", MessageOrigin: api.MessageOriginServer},
+			},
+			Benchmarks: []api.BenchmarkStatus{
+				{ID: "a", ErrorMessage: &api.MessageInfo{Message: "Benchmark failed", MessageOrigin: api.MessageOriginServer}},
+				{ID: "b", ErrorMessage: &api.MessageInfo{Message: "Benchmark failed", MessageOrigin: api.MessageOriginAdapter}},
+			},
+		},
+	}
+
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", slog.Default(), "test-user", "test-tenant")
+	if got := localizeJobMessages(ctx, job); got != job {
+		t.Fatal("without a catalog the job must be returned as it is")
+	}
+
+	ctx.Messages = catalog
+	got := localizeJobMessages(ctx, job)
+	if got.Status.Message.Message != "Evaluierungsjob erstellt" {
+		t.Errorf("job message: got %q", got.Status.Message.Message)
+	}
+	if got.Status.Benchmarks[0].ErrorMessage.Message != "Benchmark fehlgeschlagen" {
+		t.Errorf("server benchmark message: got %q", got.Status.Benchmarks[0].ErrorMessage.Message)
+	}
+	if got.Status.Benchmarks[1].ErrorMessage.Message != "Benchmark failed" {
+		t.Errorf("adapter messages must not be translated, got %q", got.Status.Benchmarks[1].ErrorMessage.Message)
+	}
+	if job.Status.Message.Message != "Evaluation job created" || job.Status.Benchmarks[0].ErrorMessage.Message != "Benchmark failed" {
+		t.Error("the original job must not be changed")
+	}
+}
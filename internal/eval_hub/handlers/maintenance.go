@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serialization"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const (
+	// defaultMaintenanceRetryAfter is the Retry-After of the rejected jobs when the operator
+	// does not set one.
+	defaultMaintenanceRetryAfter = 5 * time.Minute
+	// defaultMaintenanceMessage is returned to the users when the operator gives no reason.
+	defaultMaintenanceMessage = "new evaluation jobs are paused, retry later"
+)
+
+// maintenance is the maintenance state of this replica.
+type maintenance struct {
+	lock sync.RWMutex
+	mode api.MaintenanceMode
+}
+
+func (m *maintenance) get() api.MaintenanceMode {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.mode
+}
+
+func (m *maintenance) set(mode api.MaintenanceMode) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.mode = mode
+}
+
+// HandleGetMaintenance handles GET /api/v1/admin/maintenance
+func (h *Handlers) HandleGetMaintenance(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	logging.LogRequestStarted(ctx)
+
+	w.WriteJSON(h.maintenance.get(), 200)
+}
+
+// HandleSetMaintenance handles PUT /api/v1/admin/maintenance. While the maintenance mode is
+// enabled new evaluation jobs are rejected with a 503 and a Retry-After, and the jobs that
+// were already submitted keep running, so that the replica can be drained before an upgrade.
+// Like the admin settings, the mode applies to this replica only and lasts until it restarts.
+func (h *Handlers) HandleSetMaintenance(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	logging.LogRequestStarted(ctx)
+
+	var mode api.MaintenanceMode
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			bodyBytes, err := req.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			return serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, &mode)
+		},
+		"validation",
+		"validate-maintenance-mode",
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	current := h.maintenance.get()
+	if mode.Enabled {
+		if mode.RetryAfterSeconds == 0 {
+			mode.RetryAfterSeconds = int(defaultMaintenanceRetryAfter.Seconds())
+		}
+		// changing the message of an ongoing maintenance keeps when and by whom it started
+		mode.Since, mode.EnabledBy = current.Since, current.EnabledBy
+		if !current.Enabled {
+			now := time.Now().UTC()
+			mode.Since, mode.EnabledBy = &now, ctx.User
+		}
+	} else {
+		mode = api.MaintenanceMode{}
+	}
+	h.maintenance.set(mode)
+	auditAdminConfigChange(ctx, "maintenance", maintenanceSetting(current), maintenanceSetting(mode))
+
+	w.WriteJSON(mode, 200)
+}
+
+func maintenanceSetting(mode api.MaintenanceMode) string {
+	if !mode.Enabled {
+		return "disabled"
+	}
+	return "enabled: " + mode.Message
+}
+
+// rejectInMaintenance answers a request that submits a new job with a 503 when the replica
+// is in maintenance mode, and returns true when it did.
+func (h *Handlers) rejectInMaintenance(ctx *executioncontext.ExecutionContext, w http_wrappers.ResponseWrapper) bool {
+	mode := h.maintenance.get()
+	if !mode.Enabled {
+		return false
+	}
+	message := mode.Message
+	if message == "" {
+		message = defaultMaintenanceMessage
+	}
+	w.SetHeader("Retry-After", strconv.Itoa(mode.RetryAfterSeconds))
+	w.Error(serviceerrors.NewServiceError(messages.ServiceInMaintenance, "Message", message), ctx.RequestID)
+	return true
+}
@@ -0,0 +1,89 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestMaintenanceMode(t *testing.T) {
+	h := handlers.New(&fakeStorage{}, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", logging.FallbackLogger(), "operator", "tenant")
+
+	setMaintenance := func(t *testing.T, body string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := &providersRequest{MockRequest: createMockRequest("PUT", "/api/v1/admin/maintenance"), body: []byte(body)}
+		recorder := httptest.NewRecorder()
+		h.HandleSetMaintenance(ctx, req, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+	readiness := func(t *testing.T) handlers.ReadinessResponse {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		h.HandleReadiness(ctx, createMockRequest("GET", "/readyz"), MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d", recorder.Code)
+		}
+		var got handlers.ReadinessResponse
+		if err := json.NewDecoder(recorder.Body).Decode(&got); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		return got
+	}
+	createJob := func(t *testing.T) *httptest.ResponseRecorder {
+		t.Helper()
+		req := &providersRequest{MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"), body: []byte(`{}`)}
+		recorder := httptest.NewRecorder()
+		h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	if got := readiness(t); got.Status != handlers.STATUS_READY || got.Maintenance != nil {
+		t.Fatalf("expected the replica to be ready, got %+v", got)
+	}
+
+	recorder := setMaintenance(t, `{"enabled": true, "message": "upgrading the database"}`)
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var mode api.MaintenanceMode
+	if err := json.NewDecoder(recorder.Body).Decode(&mode); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !mode.Enabled || mode.Since == nil || mode.EnabledBy != "operator" || mode.RetryAfterSeconds != 300 {
+		t.Errorf("expected the maintenance mode with the default retry after, got %+v", mode)
+	}
+
+	rejected := createJob(t)
+	if rejected.Code != 503 || rejected.Header().Get("Retry-After") != "300" {
+		t.Errorf("expected status 503 with a Retry-After, got %d %q", rejected.Code, rejected.Header().Get("Retry-After"))
+	}
+	if !strings.Contains(rejected.Body.String(), "upgrading the database") || !strings.Contains(rejected.Body.String(), "service_in_maintenance") {
+		t.Errorf("expected the maintenance error with the message of the operator, got %s", rejected.Body.String())
+	}
+	if got := readiness(t); got.Status != handlers.STATUS_MAINTENANCE || got.Maintenance == nil || got.Maintenance.Message != "upgrading the database" {
+		t.Errorf("expected the maintenance mode on readiness, got %+v", got)
+	}
+
+	if recorder := setMaintenance(t, `{"enabled": true, "retry_after_seconds": -1}`); recorder.Code != 400 {
+		t.Errorf("expected status 400 for a negative retry after, got %d", recorder.Code)
+	}
+
+	if recorder := setMaintenance(t, `{"enabled": false}`); recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if got := readiness(t); got.Status != handlers.STATUS_READY {
+		t.Errorf("expected the replica to be ready again, got %+v", got)
+	}
+	if recorder := createJob(t); recorder.Code == 503 {
+		t.Errorf("expected new jobs to be accepted again, got %s", recorder.Body.String())
+	}
+}
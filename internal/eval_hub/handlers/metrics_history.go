@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// HandleListMetricsHistory handles GET /api/v1/evaluations/metrics/history, the metrics of the
+// completed benchmarks of the jobs the user can read, the oldest first, filtered by model,
+// benchmark, provider_id and metric.
+func (h *Handlers) HandleListMetricsHistory(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	filter, err := h.metricsHistoryFilter(ctx, req)
+	logging.LogRequestStarted(ctx, "filter", filter)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			res, err := storage.WithContext(runtimeCtx).GetEvaluationMetricsHistory(filter)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			page, err := CreatePage(ctx, res.TotalCount, filter.Offset, filter.Limit, req)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			result := api.MetricPointList{
+				Page:  *page,
+				Items: res.Items,
+			}
+			w.WriteJSON(result, 200, "count", strconv.Itoa(len(res.Items)), "total_count", strconv.Itoa(res.TotalCount))
+			return nil
+		},
+		"storage",
+		"list-metrics-history",
+	)
+}
+
+// metricsHistoryFilter returns the filter of the metrics history from the query parameters.
+func (h *Handlers) metricsHistoryFilter(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper) (*abstractions.QueryFilter, error) {
+	allowedParams := []string{"limit", "offset", "model", "benchmark", "provider_id", "metric"}
+	if badParams := getAllParams(req, allowedParams...); len(badParams) > 0 {
+		// just report the first bad parameter
+		return nil, serviceerrors.NewServiceError(messages.QueryBadParameter, "ParameterName", badParams[0], "AllowedParameters", strings.Join(allowedParams, ", "))
+	}
+	common, err := CommonListFilters(req)
+	if err != nil {
+		return nil, err
+	}
+	filter := &abstractions.QueryFilter{Limit: common.Limit, Offset: common.Offset, Params: map[string]any{}}
+
+	for _, param := range []string{"model", "benchmark", "provider_id", "metric"} {
+		value, err := GetParam(req, param, true, "")
+		if err != nil {
+			return nil, err
+		}
+		if value != "" {
+			filter.Params[param] = value
+		}
+	}
+
+	h.addJobVisibilityFilter(ctx, filter.Params)
+	return filter, nil
+}
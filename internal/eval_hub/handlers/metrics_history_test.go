@@ -0,0 +1,82 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// metricsHistoryTestStorage records the filter of the metrics it lists.
+type metricsHistoryTestStorage struct {
+	abstractions.Storage
+	filter *abstractions.QueryFilter
+}
+
+func (s *metricsHistoryTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *metricsHistoryTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *metricsHistoryTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *metricsHistoryTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *metricsHistoryTestStorage) GetEvaluationMetricsHistory(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.MetricPoint], error) {
+	s.filter = filter
+	return &abstractions.QueryResults[api.MetricPoint]{
+		Items:      []api.MetricPoint{{JobID: "job-1", ModelName: "granite", BenchmarkID: "mmlu", Metric: "acc", Value: 0.7, RecordedAt: time.Now()}},
+		TotalCount: 1,
+	}, nil
+}
+
+func TestHandleListMetricsHistory(t *testing.T) {
+	storage := &metricsHistoryTestStorage{}
+	serviceConfig := &config.Config{JobAccess: &config.JobAccessConfig{OwnerScoped: true, AdminGroups: []string{"eval-admins"}}}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, serviceConfig, nil)
+
+	list := func(user api.User, query map[string][]string, groups ...string) *httptest.ResponseRecorder {
+		storage.filter = nil
+		uri := "/api/v1/evaluations/metrics/history"
+		if values := url.Values(query).Encode(); values != "" {
+			uri += "?" + values
+		}
+		recorder := httptest.NewRecorder()
+		h.HandleListMetricsHistory(jobAccessContext(user, groups...), &baselineRequest{
+			MockRequest: createMockRequest("GET", uri),
+			query:       query,
+		}, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	recorder := list("alice", map[string][]string{"model": {"granite"}, "benchmark": {"mmlu"}, "metric": {"acc"}})
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	history := api.MetricPointList{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &history); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if history.TotalCount != 1 || len(history.Items) != 1 || history.Items[0].Value != 0.7 {
+		t.Fatalf("expected the metric of the storage, got %+v", history)
+	}
+	params := storage.filter.Params
+	if params["model"] != "granite" || params["benchmark"] != "mmlu" || params["metric"] != "acc" || params["provider_id"] != nil {
+		t.Errorf("unexpected filter params %v", params)
+	}
+	if visibility, _ := params["visible_to"].(abstractions.JobVisibility); visibility.User != "alice" {
+		t.Errorf("expected the metrics of the jobs visible to alice, got %v", params["visible_to"])
+	}
+
+	if recorder := list("admin", nil, "eval-admins"); recorder.Code != 200 || storage.filter.Params["visible_to"] != nil {
+		t.Errorf("expected the metrics of all the jobs for an admin, got %d with %v", recorder.Code, storage.filter)
+	}
+	if recorder := list("alice", map[string][]string{"job_id": {"job-1"}}); recorder.Code != 400 {
+		t.Errorf("expected status 400 for an unknown parameter, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/mlflow"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/eval-hub/eval-hub/pkg/mlflowclient"
+)
+
+// mlflowRunClient returns the MLflow client in the MLflow workspace of the job, where its
+// adapters log their runs.
+func (h *Handlers) mlflowRunClient(ctx context.Context, logger *slog.Logger, job *api.EvaluationJobResource) *mlflowclient.Client {
+	client := h.mlflowClient.WithContext(ctx).WithLogger(logger)
+	if job == nil {
+		return client
+	}
+	if workspace := job.MLFlowWorkspace(); workspace != "" {
+		client = client.WithWorkspace(workspace)
+	}
+	return client
+}
+
+// linkMLFlowRun verifies that the MLflow run of a terminal status event exists and sets the
+// URL of the run in the MLflow UI. A run that does not exist gets a warning on the benchmark
+// instead. When MLflow cannot be asked, the URL is built from the experiment of the job.
+func (h *Handlers) linkMLFlowRun(ctx context.Context, logger *slog.Logger, event *api.BenchmarkStatusEvent, job *api.EvaluationJobResource) {
+	if h.mlflowClient == nil || event == nil || event.MLFlowRunID == "" || !api.IsBenchmarkTerminalState(event.Status) {
+		return
+	}
+	logger = logger.With("mlflow_run_id", event.MLFlowRunID, "benchmark_index", event.BenchmarkIndex)
+	client := h.mlflowRunClient(ctx, logger, job)
+	run, err := mlflow.GetRun(client, event.MLFlowRunID)
+	switch {
+	case err == nil:
+		event.MLFlowRunURL = client.GetRunURL(run.Info.ExperimentID, run.Info.RunID)
+	case isResourceNotFound(err):
+		logger.Warn("The MLflow run reported for the benchmark does not exist")
+		if event.WarningMessage == nil {
+			event.WarningMessage = api.WithMessageOrigin(&api.MessageInfo{
+				Message:     fmt.Sprintf("The MLflow run %s reported for the benchmark does not exist", event.MLFlowRunID),
+				MessageCode: constants.MESSAGE_CODE_MLFLOW_RUN_NOT_FOUND,
+			}, api.MessageOriginServer)
+		}
+	default:
+		logger.Warn("Failed to verify the MLflow run of the benchmark", "error", err.Error())
+		if job != nil && job.Resource.MLFlowExperimentID != "" {
+			event.MLFlowRunURL = client.GetRunURL(job.Resource.MLFlowExperimentID, event.MLFlowRunID)
+		}
+	}
+}
+
+// HandleSyncEvaluationMLFlowMetrics handles POST /api/v1/evaluations/jobs/{id}/mlflow/sync, it
+// adds to the results of the benchmarks the metrics that their adapters only logged to their
+// MLflow run.
+func (h *Handlers) HandleSyncEvaluationMLFlowMetrics(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID := r.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+	if h.mlflowClient == nil {
+		w.Error(serviceerrors.NewServiceError(messages.MLFlowRequiredForExperiment), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			job, err := h.getAccessibleEvaluationJob(ctx, scoped, evaluationJobID, jobAccessChange)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			metrics, err := h.mlflowRunMetrics(runtimeCtx, ctx.Logger, job)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if len(metrics) > 0 {
+				job, err = scoped.AddEvaluationJobBenchmarkMetrics(evaluationJobID, metrics)
+				if err != nil {
+					w.Error(err, ctx.RequestID)
+					return err
+				}
+			}
+			ctx.Logger.Info("Synced the MLflow metrics of the evaluation job", "id", evaluationJobID, "benchmarks", len(metrics))
+			w.WriteJSON(localizeJobMessages(ctx, job), 200)
+			return nil
+		},
+		"mlflow",
+		"sync-evaluation-job-mlflow-metrics",
+		"job.id", evaluationJobID,
+	)
+}
+
+// mlflowRunMetrics returns, by benchmark index, the metrics of the MLflow runs of the results
+// that the results do not have. The runs that do not exist are skipped.
+func (h *Handlers) mlflowRunMetrics(ctx context.Context, logger *slog.Logger, job *api.EvaluationJobResource) (map[int]map[string]any, error) {
+	metrics := map[int]map[string]any{}
+	if job.Results == nil {
+		return metrics, nil
+	}
+	client := h.mlflowRunClient(ctx, logger, job)
+	for _, result := range job.Results.Benchmarks {
+		if result.MLFlowRunID == "" {
+			continue
+		}
+		run, err := mlflow.GetRun(client, result.MLFlowRunID)
+		if err != nil {
+			if isResourceNotFound(err) {
+				logger.Warn("The MLflow run of the benchmark does not exist", "mlflow_run_id", result.MLFlowRunID, "benchmark_index", result.BenchmarkIndex)
+				continue
+			}
+			return nil, err
+		}
+		for _, metric := range run.Data.Metrics {
+			if _, ok := result.Metrics[metric.Key]; ok {
+				continue
+			}
+			if metrics[result.BenchmarkIndex] == nil {
+				metrics[result.BenchmarkIndex] = map[string]any{}
+			}
+			metrics[result.BenchmarkIndex][metric.Key] = metric.Value
+		}
+	}
+	return metrics, nil
+}
@@ -0,0 +1,146 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/mlflow"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/eval-hub/eval-hub/pkg/mlflowclient"
+)
+
+// mlflowRunTestStorage records the status events and the metrics added to the results.
+type mlflowRunTestStorage struct {
+	*fakeStorage
+	event   *api.BenchmarkStatusEvent
+	metrics map[int]map[string]any
+}
+
+func (s *mlflowRunTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *mlflowRunTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *mlflowRunTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *mlflowRunTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *mlflowRunTestStorage) UpdateEvaluationJob(_ string, status *api.StatusEvent) (*abstractions.EvaluationJobUpdate, error) {
+	s.event = status.BenchmarkStatusEvent
+	return &abstractions.EvaluationJobUpdate{}, nil
+}
+
+func (s *mlflowRunTestStorage) AddEvaluationJobBenchmarkMetrics(_ string, metrics map[int]map[string]any) (*api.EvaluationJobResource, error) {
+	s.metrics = metrics
+	return s.job, nil
+}
+
+// newMLFlowRunTestHandlers serves run-1 of experiment exp-1, with its accuracy and f1, and
+// no other run.
+func newMLFlowRunTestHandlers(t *testing.T) (*handlers.Handlers, *mlflowRunTestStorage) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/2.0/mlflow/runs/get" {
+			http.NotFound(w, r)
+			return
+		}
+		var req mlflowclient.GetRunRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if req.RunID == "run-1" {
+			_, _ = w.Write([]byte(`{"run": {"info": {"run_id": "run-1", "experiment_id": "exp-1"}, "data": {"metrics": [{"key": "accuracy", "value": 0.5}, {"key": "f1", "value": 0.7}]}}}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error_code": "RESOURCE_DOES_NOT_EXIST", "message": "Run not found"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	serviceConfig := &config.Config{MLFlow: &config.MLFlowConfig{
+		TrackingURI: server.URL,
+		TokenPath:   filepath.Join(t.TempDir(), "no-such-token"),
+	}}
+	client, err := mlflow.NewMLFlowClient(serviceConfig, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("NewMLFlowClient: %v", err)
+	}
+	storage := &mlflowRunTestStorage{fakeStorage: &fakeStorage{job: &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1"}, MLFlowExperimentID: "exp-1"},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+	}}}
+	return handlers.New(storage, testhelpers.NewValidator(t), nil, client, serviceConfig, nil), storage
+}
+
+func TestHandleUpdateEvaluationLinksTheMLFlowRun(t *testing.T) {
+	update := func(h *handlers.Handlers, body string) {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		h.HandleUpdateEvaluation(jobAccessContext(""), &updateEvaluationRequest{
+			bodyRequest: &bodyRequest{
+				MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs/job-1/events"),
+				body:        []byte(body),
+			},
+			pathValues: map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-1"},
+		}, MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 204 {
+			t.Fatalf("expected status 204, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	}
+
+	t.Run("a run that exists gets its URL", func(t *testing.T) {
+		h, storage := newMLFlowRunTestHandlers(t)
+		update(h, `{"benchmark_status_event":{"provider_id":"p1","id":"b1","status":"completed","mlflow_run_id":"run-1"}}`)
+		if storage.event.MLFlowRunURL == "" || storage.event.WarningMessage != nil {
+			t.Fatalf("expected the URL of the run and no warning, got %+v", storage.event)
+		}
+	})
+
+	t.Run("a run that does not exist gets a warning", func(t *testing.T) {
+		h, storage := newMLFlowRunTestHandlers(t)
+		update(h, `{"benchmark_status_event":{"provider_id":"p1","id":"b1","status":"completed","mlflow_run_id":"run-2"}}`)
+		if storage.event.MLFlowRunURL != "" {
+			t.Errorf("expected no URL for a missing run, got %q", storage.event.MLFlowRunURL)
+		}
+		if storage.event.WarningMessage == nil || storage.event.WarningMessage.MessageCode != constants.MESSAGE_CODE_MLFLOW_RUN_NOT_FOUND {
+			t.Errorf("expected the mlflow_run_not_found warning, got %+v", storage.event.WarningMessage)
+		}
+	})
+
+	t.Run("a running benchmark is not verified", func(t *testing.T) {
+		h, storage := newMLFlowRunTestHandlers(t)
+		update(h, `{"benchmark_status_event":{"provider_id":"p1","id":"b1","status":"running","mlflow_run_id":"run-2"}}`)
+		if storage.event.MLFlowRunURL != "" || storage.event.WarningMessage != nil {
+			t.Errorf("expected the event unchanged, got %+v", storage.event)
+		}
+	})
+}
+
+func TestHandleSyncEvaluationMLFlowMetrics(t *testing.T) {
+	h, storage := newMLFlowRunTestHandlers(t)
+	storage.job.Results = &api.EvaluationJobResults{Benchmarks: []api.BenchmarkResult{
+		{ID: "b1", ProviderID: "p1", BenchmarkIndex: 0, MLFlowRunID: "run-1", Metrics: map[string]any{"accuracy": 0.9}},
+		// the run of this benchmark does not exist, it is skipped
+		{ID: "b2", ProviderID: "p1", BenchmarkIndex: 1, MLFlowRunID: "run-2"},
+		{ID: "b3", ProviderID: "p1", BenchmarkIndex: 2},
+	}}
+
+	recorder := httptest.NewRecorder()
+	h.HandleSyncEvaluationMLFlowMetrics(executioncontext.NewExecutionContext(context.Background(), "req-mlflow-sync", logging.FallbackLogger(), "", ""), &baselineRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs/job-1/mlflow/sync"),
+		path:        map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-1"},
+	}, MockResponseWrapper{recorder: recorder})
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	// the accuracy that the hub has is kept
+	if len(storage.metrics) != 1 || len(storage.metrics[0]) != 1 || storage.metrics[0]["f1"] != 0.7 {
+		t.Fatalf("expected only the f1 of the first benchmark to be added, got %v", storage.metrics)
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/modelinfo"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// captureModelMetadata reads the metadata of the model of a started job from its endpoint and
+// stores it in the results of the job, in the background. A model that does not serve the
+// metadata does not fail the job. The model of an InferenceService is captured by the runtime,
+// which resolves its URL.
+func (h *Handlers) captureModelMetadata(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, job *api.EvaluationJobResource) {
+	if job.Model.URL == "" {
+		return
+	}
+	var proxyConfig *config.ProxyConfig
+	if h.serviceConfig != nil {
+		proxyConfig = h.serviceConfig.Proxy
+	}
+	id, modelURL, modelName := job.Resource.ID, job.Model.URL, job.Model.Name
+	logger := ctx.Logger.With("job_id", id, "model_url", modelURL)
+
+	// the capture is stopped with the model waits, at shutdown
+	h.modelWaits.wg.Add(1)
+	go func() {
+		defer h.modelWaits.wg.Done()
+		captureCtx, cancel := context.WithTimeout(h.modelWaits.ctx, modelinfo.Timeout)
+		defer cancel()
+
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = proxyConfig.ProxyFunc(config.ProxyDestinationModel)
+		client := &http.Client{Transport: transport, Timeout: modelinfo.Timeout}
+		defer client.CloseIdleConnections()
+
+		metadata, err := modelinfo.Fetch(captureCtx, client, modelURL, modelName, "")
+		if err != nil {
+			logger.Info("Model metadata not captured for evaluation job", "reason", err.Error())
+			return
+		}
+		if err := storage.WithContext(context.Background()).UpdateEvaluationJobModelMetadata(id, metadata); err != nil {
+			logger.Warn("Failed to store the model metadata of evaluation job", "error", err)
+		}
+	}()
+}
@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// modelWaits are the jobs of this replica that wait for their model endpoint. The waits are
+// not resumed by another replica, so the jobs are failed when the service stops. The captures
+// of the model metadata of started jobs are stopped with them.
+type modelWaits struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newModelWaits() *modelWaits {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &modelWaits{ctx: ctx, cancel: cancel}
+}
+
+// StopModelWaits fails the jobs that are still waiting for their model endpoint and returns
+// once they are, at shutdown.
+func (h *Handlers) StopModelWaits() {
+	h.modelWaits.cancel()
+	h.modelWaits.wg.Wait()
+}
+
+// waitForModel marks the job waiting_for_model and launches it on the runtime in the
+// background once its model endpoint is ready, or fails it when the endpoint is not ready
+// within the timeout of wait_for_model.
+func (h *Handlers) waitForModel(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, job *api.EvaluationJobResource, collection *api.CollectionResource) error {
+	wait := job.WaitForModel
+	readinessURL := wait.ReadinessURL(job.Model.URL)
+	message := api.WithMessageOrigin(&api.MessageInfo{
+		Message:     fmt.Sprintf("Waiting for the model endpoint %s to be ready", readinessURL),
+		MessageCode: constants.MESSAGE_CODE_WAITING_FOR_MODEL,
+	}, api.MessageOriginServer)
+	if _, err := storage.WithContext(ctx.Ctx).UpdateEvaluationJobStatus(job.Resource.ID, api.OverallStateWaitingForModel, message); err != nil {
+		return err
+	}
+	job.Status.State = api.OverallStateWaitingForModel
+	job.Status.Message = message
+
+	// the wait outlives the request that created the job
+	waitCtx, cancel := context.WithTimeout(h.modelWaits.ctx, wait.EffectiveTimeout())
+	background := ctx.WithContext(waitCtx)
+	logger := ctx.Logger.With("job_id", job.Resource.ID, "readiness_url", readinessURL)
+
+	h.modelWaits.wg.Add(1)
+	go func() {
+		defer h.modelWaits.wg.Done()
+		defer cancel()
+
+		logger.Info("Waiting for the model endpoint of evaluation job")
+		ready, err := h.pollModelReadiness(background, storage, job.Resource.ID, readinessURL, wait.EffectivePollInterval())
+		scoped := storage.WithContext(context.Background())
+		if err != nil {
+			// the job was cancelled or deleted
+			logger.Info("Stopped waiting for the model endpoint of evaluation job", "reason", err.Error())
+			return
+		}
+		if !ready {
+			reason := fmt.Sprintf("The model endpoint %s was not ready within %s", readinessURL, wait.EffectiveTimeout())
+			if h.modelWaits.ctx.Err() != nil {
+				reason = fmt.Sprintf("The service stopped while waiting for the model endpoint %s to be ready", readinessURL)
+			}
+			logger.Warn("Evaluation job failed waiting for the model endpoint", "reason", reason)
+			failure := api.WithMessageOrigin(&api.MessageInfo{
+				Message:     reason,
+				MessageCode: constants.MESSAGE_CODE_MODEL_NOT_READY,
+			}, api.MessageOriginServer)
+			if _, err := scoped.UpdateEvaluationJobStatus(job.Resource.ID, api.OverallStateFailed, failure); err != nil {
+				logger.Error("Failed to update evaluation status", "error", err)
+			}
+			return
+		}
+
+		// the job leaves waiting_for_model before it is launched, this fails if it was
+		// cancelled since the last poll
+		pending := api.WithMessageOrigin(&api.MessageInfo{
+			Message:     "The model endpoint is ready, the evaluation job is starting",
+			MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_UPDATED,
+		}, api.MessageOriginServer)
+		if _, err := scoped.UpdateEvaluationJobStatus(job.Resource.ID, api.OverallStatePending, pending); err != nil {
+			logger.Info("Evaluation job not launched after waiting for the model endpoint", "error", err)
+			return
+		}
+		logger.Info("Model endpoint of evaluation job is ready, launching the job")
+		// the job returned to the client is not shared with the runtime
+		waited, err := scoped.GetEvaluationJob(job.Resource.ID)
+		if err != nil {
+			logger.Error("Failed to read evaluation job after waiting for the model endpoint", "error", err)
+			return
+		}
+		if err := h.startEvaluationJob(background.WithContext(context.Background()), scoped, waited, collection); err != nil {
+			logger.Error("Failed to start evaluation job after waiting for the model endpoint", "error", err)
+		}
+	}()
+	return nil
+}
+
+// pollModelReadiness polls the readiness URL until the endpoint is ready or the context is
+// done. It returns an error when the job no longer waits, e.g. because it was cancelled.
+func (h *Handlers) pollModelReadiness(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, id string, readinessURL string, interval time.Duration) (bool, error) {
+	var proxyConfig *config.ProxyConfig
+	if h.serviceConfig != nil {
+		proxyConfig = h.serviceConfig.Proxy
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxyConfig.ProxyFunc(config.ProxyDestinationModel)
+	client := &http.Client{Transport: transport, Timeout: interval}
+	defer client.CloseIdleConnections()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		job, err := storage.WithContext(context.Background()).GetEvaluationJob(id)
+		if err != nil {
+			return false, err
+		}
+		if job.Status != nil && job.Status.State != api.OverallStateWaitingForModel {
+			return false, fmt.Errorf("the evaluation job is %s", job.Status.State)
+		}
+		if modelReady(ctx.Ctx, client, readinessURL) {
+			return true, nil
+		}
+		select {
+		case <-ctx.Ctx.Done():
+			return false, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// modelReady returns true when a GET of the readiness URL answers with a 2xx status.
+func modelReady(ctx context.Context, client *http.Client, readinessURL string) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, readinessURL, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode/100 == 2
+}
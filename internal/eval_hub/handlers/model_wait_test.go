@@ -0,0 +1,216 @@
+package handlers_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// modelWaitTestStorage keeps the job and the history of its states, for the background
+// wait.
+type modelWaitTestStorage struct {
+	*fakeStorage
+	mu     sync.Mutex
+	job    *api.EvaluationJobResource
+	states []api.OverallState
+}
+
+func (s *modelWaitTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *modelWaitTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *modelWaitTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *modelWaitTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *modelWaitTestStorage) CreateEvaluationJob(job *api.EvaluationJobResource) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored := *job
+	status := *job.Status
+	stored.Status = &status
+	s.job = &stored
+	s.states = append(s.states, status.State)
+	return nil
+}
+
+func (s *modelWaitTestStorage) UpdateEvaluationJobStatus(id string, state api.OverallState, message *api.MessageInfo) (api.OverallState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.job.Status.State.IsTerminalState() {
+		return "", serviceerrors.NewServiceError(messages.JobCanNotBeUpdated, "Id", id, "NewStatus", state, "Status", s.job.Status.State)
+	}
+	previousState := s.job.Status.State
+	s.job.Status.State = state
+	s.job.Status.Message = message
+	s.states = append(s.states, state)
+	return previousState, nil
+}
+
+func (s *modelWaitTestStorage) GetEvaluationJob(_ string) (*api.EvaluationJobResource, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job := *s.job
+	status := *s.job.Status
+	job.Status = &status
+	return &job, nil
+}
+
+func (s *modelWaitTestStorage) history() ([]api.OverallState, *api.MessageInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]api.OverallState(nil), s.states...), s.job.Status.Message
+}
+
+// launchRuntime reports the jobs it runs.
+type launchRuntime struct {
+	fakeRuntime
+	launched chan string
+}
+
+func (r *launchRuntime) WithLogger(_ *slog.Logger) abstractions.Runtime     { return r }
+func (r *launchRuntime) WithContext(_ context.Context) abstractions.Runtime { return r }
+func (r *launchRuntime) RunEvaluationJob(job *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, _ abstractions.RuntimeStorage) error {
+	r.launched <- job.Resource.ID
+	return nil
+}
+
+func TestHandleCreateEvaluationWaitsForModel(t *testing.T) {
+	logger := logging.FallbackLogger()
+	providerConfigs := map[string]api.ProviderResource{
+		"garak": {
+			Resource:       api.Resource{ID: "garak"},
+			ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "bench-1"}}},
+		},
+	}
+
+	create := func(t *testing.T, h *handlers.Handlers, waitForModel string) *httptest.ResponseRecorder {
+		t.Helper()
+		body := fmt.Sprintf(`{"name":"test-job","model":{"url":"http://test.com","name":"test"},"benchmarks":[{"id":"bench-1","provider_id":"garak"}],"wait_for_model":%s}`, waitForModel)
+		req := &bodyRequest{
+			MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+			body:        []byte(body),
+		}
+		ctx := executioncontext.NewExecutionContext(context.Background(), "req-wait", logger, "test-user", "test-tenant")
+		recorder := httptest.NewRecorder()
+		h.HandleCreateEvaluation(ctx, req, MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 202 {
+			t.Fatalf("expected status 202, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		return recorder
+	}
+
+	t.Run("the job is launched once the model is ready", func(t *testing.T) {
+		var polls atomic.Int32
+		model := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/health" || polls.Add(1) < 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(model.Close)
+
+		storage := &modelWaitTestStorage{fakeStorage: &fakeStorage{providerConfigs: providerConfigs}}
+		runtime := &launchRuntime{launched: make(chan string, 1)}
+		h := handlers.New(storage, testhelpers.NewValidator(t), runtime, nil, nil, nil)
+		t.Cleanup(h.StopModelWaits)
+
+		recorder := create(t, h, fmt.Sprintf(`{"url":%q,"readiness_path":"/health","poll_interval_seconds":1}`, model.URL))
+		if body := recorder.Body.String(); !strings.Contains(body, `"state":"waiting_for_model"`) {
+			t.Errorf("expected the job to be waiting for the model, got %s", body)
+		}
+
+		select {
+		case <-runtime.launched:
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the job to be launched once the model is ready")
+		}
+		states, _ := storage.history()
+		want := []api.OverallState{api.OverallStatePending, api.OverallStateWaitingForModel, api.OverallStatePending}
+		if fmt.Sprint(states) != fmt.Sprint(want) {
+			t.Errorf("expected the states %v, got %v", want, states)
+		}
+		if polls.Load() < 2 {
+			t.Errorf("expected the model to be polled until ready, got %d polls", polls.Load())
+		}
+	})
+
+	t.Run("the job fails when the model is not ready in time", func(t *testing.T) {
+		model := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(model.Close)
+
+		storage := &modelWaitTestStorage{fakeStorage: &fakeStorage{providerConfigs: providerConfigs}}
+		runtime := &launchRuntime{launched: make(chan string, 1)}
+		h := handlers.New(storage, testhelpers.NewValidator(t), runtime, nil, nil, nil)
+
+		create(t, h, fmt.Sprintf(`{"url":%q,"timeout_seconds":1,"poll_interval_seconds":1}`, model.URL))
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			states, message := storage.history()
+			if states[len(states)-1] == api.OverallStateFailed {
+				if message.MessageCode != constants.MESSAGE_CODE_MODEL_NOT_READY {
+					t.Errorf("expected the %s message code, got %+v", constants.MESSAGE_CODE_MODEL_NOT_READY, message)
+				}
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected the job to fail, got the states %v", states)
+			}
+			time.Sleep(50 * time.Millisecond)
+		}
+		h.StopModelWaits()
+		if len(runtime.launched) != 0 {
+			t.Error("did not expect the job to be launched")
+		}
+	})
+
+	t.Run("a cancelled job is not launched", func(t *testing.T) {
+		model := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		t.Cleanup(model.Close)
+
+		storage := &modelWaitTestStorage{fakeStorage: &fakeStorage{providerConfigs: providerConfigs}}
+		runtime := &launchRuntime{launched: make(chan string, 1)}
+		h := handlers.New(storage, testhelpers.NewValidator(t), runtime, nil, nil, nil)
+
+		create(t, h, fmt.Sprintf(`{"url":%q,"poll_interval_seconds":1}`, model.URL))
+		if _, err := storage.UpdateEvaluationJobStatus("job", api.OverallStateCancelled, &api.MessageInfo{Message: "cancelled", MessageCode: "test"}); err != nil {
+			t.Fatalf("UpdateEvaluationJobStatus: %v", err)
+		}
+		h.StopModelWaits()
+		states, _ := storage.history()
+		if last := states[len(states)-1]; last != api.OverallStateCancelled || len(runtime.launched) != 0 {
+			t.Errorf("expected the job to stay cancelled and not be launched, got the states %v", states)
+		}
+	})
+
+	t.Run("the jobs still waiting fail when the service stops", func(t *testing.T) {
+		storage := &modelWaitTestStorage{fakeStorage: &fakeStorage{providerConfigs: providerConfigs}}
+		h := handlers.New(storage, testhelpers.NewValidator(t), &launchRuntime{launched: make(chan string, 1)}, nil, nil, nil)
+
+		create(t, h, `{"url":"http://127.0.0.1:1","poll_interval_seconds":60}`)
+		h.StopModelWaits()
+		states, message := storage.history()
+		if states[len(states)-1] != api.OverallStateFailed || message.MessageCode != constants.MESSAGE_CODE_MODEL_NOT_READY {
+			t.Errorf("expected the job to fail, got the states %v and %+v", states, message)
+		}
+	})
+}
@@ -19,7 +19,23 @@ var (
 	}
 )
 
+// HandleOpenAPI handles GET /openapi.yaml, returning the JSON document instead when the
+// client accepts application/json.
 func (h *Handlers) HandleOpenAPI(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper, dirs ...string) {
+	if strings.Contains(r.Header("Accept"), "application/json") {
+		h.serveOpenAPI(ctx, w, "openapi.json", "application/json", dirs...)
+		return
+	}
+	h.serveOpenAPI(ctx, w, "openapi.yaml", "application/yaml", dirs...)
+}
+
+// HandleOpenAPIJSON handles GET /api/v1/openapi.json, the versioned location of the
+// specification for SDK generators.
+func (h *Handlers) HandleOpenAPIJSON(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper, dirs ...string) {
+	h.serveOpenAPI(ctx, w, "openapi.json", "application/json", dirs...)
+}
+
+func (h *Handlers) serveOpenAPI(ctx *executioncontext.ExecutionContext, w http_wrappers.ResponseWrapper, file string, contentType string, dirs ...string) {
 	found := func(contents []byte, contentType string) {
 		w.SetHeader("Content-Type", contentType)
 		for key, value := range noCacheHeaders {
@@ -28,14 +44,6 @@ func (h *Handlers) HandleOpenAPI(ctx *executioncontext.ExecutionContext, r http_
 		_, _ = w.Write(contents)
 	}
 
-	// Determine content type based on Accept header
-	file := "openapi.yaml"
-	contentType := "application/yaml"
-	if strings.Contains(r.Header("Accept"), "application/json") {
-		file = "openapi.json"
-		contentType = "application/json"
-	}
-
 	// start by trying to find it relative to the executable (when running in a cluster)
 	exePath, _ := os.Executable()
 	if exePath != "" {
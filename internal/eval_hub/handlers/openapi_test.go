@@ -2,6 +2,7 @@ package handlers_test
 
 import (
 	"context"
+	"encoding/json"
 	"io"
 	"log/slog"
 	"net/http"
@@ -59,6 +60,34 @@ func TestHandleOpenAPI(t *testing.T) {
 	})
 }
 
+func TestHandleOpenAPIJSON(t *testing.T) {
+	h := handlers.New(nil, nil, nil, nil, nil, nil)
+	ctx := createExecutionContext()
+	w := httptest.NewRecorder()
+
+	// the versioned endpoint always returns JSON, whatever the Accept header says
+	req := createMockRequest("GET", "/api/v1/openapi.json")
+	req.SetHeader("Accept", "application/yaml")
+	h.HandleOpenAPIJSON(ctx, req, &MockResponseWrapper{w})
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("expected Content-Type application/json, got %q", ct)
+	}
+	var spec map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if _, ok := spec["openapi"]; !ok {
+		t.Fatal("response does not appear to be an OpenAPI specification")
+	}
+	if _, ok := spec["paths"].(map[string]any)["/api/v1/evaluations/jobs"]; !ok {
+		t.Fatal("specification does not document the evaluation jobs API")
+	}
+}
+
 func TestHandleOpenAPI_SpecNotFound(t *testing.T) {
 	h := handlers.New(nil, nil, nil, nil, nil, nil)
 
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
 	"github.com/eval-hub/eval-hub/internal/otel"
 )
@@ -13,7 +15,8 @@ func (h *Handlers) withSpan(ctx *executioncontext.ExecutionContext, fn otel.Span
 	if len(atts)%2 == 1 {
 		attributes[atts[len(atts)-1]] = ""
 	}
-	return otel.WithSpan(
+	started := time.Now()
+	err := otel.WithSpan(
 		ctx.Ctx,
 		h.serviceConfig,
 		ctx.Logger,
@@ -22,4 +25,6 @@ func (h *Handlers) withSpan(ctx *executioncontext.ExecutionContext, fn otel.Span
 		attributes,
 		fn,
 	)
+	executioncontext.RecordStep(ctx.Ctx, component+" "+operation, time.Since(started), err != nil)
+	return err
 }
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// benchmarksFilter selects the benchmarks of a provider. The tags are a comma separated list
+// of tags that must all match, or a | separated list of tags of which one must match, as in
+// the filters of the other listings.
+type benchmarksFilter struct {
+	limit    int
+	offset   int
+	name     string
+	category string
+	tags     string
+}
+
+// HandleListProviderBenchmarks handles GET /api/v1/evaluations/providers/{id}/benchmarks, a
+// page of the benchmarks of a provider filtered by category, tags and a search of their id
+// and name, for providers such as lm_evaluation_harness whose benchmarks are too many to list
+// with the provider.
+func (h *Handlers) HandleListProviderBenchmarks(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	providerId := req.PathValue(constants.PATH_PARAMETER_PROVIDER_ID)
+	if providerId == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_PROVIDER_ID), ctx.RequestID)
+		return
+	}
+
+	filter, err := providerBenchmarksFilter(req)
+	logging.LogRequestStarted(ctx, "filter", filter)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			provider, err := storage.WithContext(runtimeCtx).GetProvider(providerId)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			benchmarks := filterBenchmarks(provider.Benchmarks, filter)
+			totalCount := len(benchmarks)
+			items := benchmarks[min(filter.offset, totalCount):min(filter.offset+filter.limit, totalCount)]
+
+			page, err := CreatePage(ctx, totalCount, filter.offset, filter.limit, req)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			result := api.BenchmarkResourceList{
+				Page:  *page,
+				Items: items,
+			}
+			if err := writeJSONWithETag(req, w, result, "count", strconv.Itoa(len(items)), "total_count", strconv.Itoa(totalCount)); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			return nil
+		},
+		"storage",
+		"list-provider-benchmarks",
+		"provider.id", providerId,
+	)
+}
+
+// providerBenchmarksFilter returns the filter of the benchmarks of a provider from the query
+// parameters.
+func providerBenchmarksFilter(req http_wrappers.RequestWrapper) (*benchmarksFilter, error) {
+	allowedParams := []string{"limit", "offset", "name", "category", "tags"}
+	if badParams := getAllParams(req, allowedParams...); len(badParams) > 0 {
+		// just report the first bad parameter
+		return nil, serviceerrors.NewServiceError(messages.QueryBadParameter, "ParameterName", badParams[0], "AllowedParameters", strings.Join(allowedParams, ", "))
+	}
+	common, err := CommonListFilters(req, "category")
+	if err != nil {
+		return nil, err
+	}
+	filter := &benchmarksFilter{limit: common.Limit, offset: common.Offset}
+	filter.name, _ = common.Params["name"].(string)
+	filter.category, _ = common.Params["category"].(string)
+	filter.tags, _ = common.Params["tags"].(string)
+	return filter, nil
+}
+
+// filterBenchmarks returns the benchmarks that match the filter, in the order of the provider.
+// The name is searched case-insensitively in the id and the name of the benchmarks.
+func filterBenchmarks(benchmarks []api.BenchmarkResource, filter *benchmarksFilter) []api.BenchmarkResource {
+	name := strings.ToLower(strings.TrimSpace(filter.name))
+	category := strings.TrimSpace(filter.category)
+	matched := make([]api.BenchmarkResource, 0, len(benchmarks))
+	for _, benchmark := range benchmarks {
+		if name != "" && !strings.Contains(strings.ToLower(benchmark.ID), name) && !strings.Contains(strings.ToLower(benchmark.Name), name) {
+			continue
+		}
+		if category != "" && !strings.EqualFold(benchmark.Category, category) {
+			continue
+		}
+		if !matchesTags(benchmark.Tags, filter.tags) {
+			continue
+		}
+		matched = append(matched, benchmark)
+	}
+	return matched
+}
+
+func matchesTags(tags []string, filter string) bool {
+	filter = strings.TrimSpace(filter)
+	if filter == "" {
+		return true
+	}
+	if strings.Contains(filter, ",") {
+		for tag := range strings.SplitSeq(filter, ",") {
+			if !slices.Contains(tags, strings.TrimSpace(tag)) {
+				return false
+			}
+		}
+		return true
+	}
+	for tag := range strings.SplitSeq(filter, "|") {
+		if slices.Contains(tags, strings.TrimSpace(tag)) {
+			return true
+		}
+	}
+	return false
+}
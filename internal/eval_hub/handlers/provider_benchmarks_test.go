@@ -0,0 +1,121 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestHandleListProviderBenchmarks(t *testing.T) {
+	provider := api.ProviderResource{
+		Resource: api.Resource{ID: "lm_evaluation_harness"},
+		ProviderConfig: api.ProviderConfig{
+			Name: "lm_evaluation_harness",
+			Benchmarks: []api.BenchmarkResource{
+				{ID: "arc_easy", Name: "ARC Easy", Category: "reasoning", Tags: []string{"reasoning", "science"}},
+				{ID: "arc_challenge", Name: "ARC Challenge", Category: "reasoning", Tags: []string{"reasoning", "science", "hard"}},
+				{ID: "gsm8k", Name: "Grade School Math", Category: "math", Tags: []string{"math"}},
+				{ID: "mmlu_physics", Name: "MMLU Physics", Category: "knowledge", Tags: []string{"science"}},
+			},
+		},
+	}
+	storage := &fakeStorage{providerConfigs: map[string]api.ProviderResource{provider.Resource.ID: provider}}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", logging.FallbackLogger(), "test-user", "test-tenant")
+
+	list := func(t *testing.T, providerID string, query url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		uri := "/api/v1/evaluations/providers/" + providerID + "/benchmarks"
+		if len(query) > 0 {
+			uri += "?" + query.Encode()
+		}
+		req := &providersRequest{
+			MockRequest: createMockRequest("GET", uri),
+			queryValues: query,
+			pathValues:  map[string]string{constants.PATH_PARAMETER_PROVIDER_ID: providerID},
+		}
+		recorder := httptest.NewRecorder()
+		h.HandleListProviderBenchmarks(ctx, req, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+	ids := func(t *testing.T, recorder *httptest.ResponseRecorder) (api.BenchmarkResourceList, []string) {
+		t.Helper()
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var got api.BenchmarkResourceList
+		if err := json.NewDecoder(recorder.Body).Decode(&got); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		var ids []string
+		for _, benchmark := range got.Items {
+			ids = append(ids, benchmark.ID)
+		}
+		return got, ids
+	}
+
+	tests := map[string]struct {
+		query url.Values
+		want  []string
+	}{
+		"all":                 {query: url.Values{}, want: []string{"arc_easy", "arc_challenge", "gsm8k", "mmlu_physics"}},
+		"category":            {query: url.Values{"category": {"reasoning"}}, want: []string{"arc_easy", "arc_challenge"}},
+		"all of the tags":     {query: url.Values{"tags": {"science,hard"}}, want: []string{"arc_challenge"}},
+		"any of the tags":     {query: url.Values{"tags": {"math|hard"}}, want: []string{"arc_challenge", "gsm8k"}},
+		"name search":         {query: url.Values{"name": {"PHYS"}}, want: []string{"mmlu_physics"}},
+		"id search":           {query: url.Values{"name": {"arc_"}}, want: []string{"arc_easy", "arc_challenge"}},
+		"offset past the end": {query: url.Values{"offset": {"10"}}, want: nil},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, gotIDs := ids(t, list(t, provider.Resource.ID, tt.query))
+			if len(gotIDs) != len(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, gotIDs)
+			}
+			for i := range tt.want {
+				if gotIDs[i] != tt.want[i] {
+					t.Fatalf("expected %v, got %v", tt.want, gotIDs)
+				}
+			}
+			if tt.query.Has("offset") && got.TotalCount != 4 {
+				t.Errorf("expected the total count of the filtered benchmarks, got %d", got.TotalCount)
+			}
+		})
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		got, gotIDs := ids(t, list(t, provider.Resource.ID, url.Values{"tags": {"science"}, "limit": {"2"}, "offset": {"1"}}))
+		if len(gotIDs) != 2 || gotIDs[0] != "arc_challenge" || gotIDs[1] != "mmlu_physics" {
+			t.Errorf("expected the second page, got %v", gotIDs)
+		}
+		if got.TotalCount != 3 || got.Next != nil {
+			t.Errorf("expected 3 benchmarks and no next page, got %d and %+v", got.TotalCount, got.Next)
+		}
+
+		got, _ = ids(t, list(t, provider.Resource.ID, url.Values{"limit": {"1"}}))
+		if got.Next == nil {
+			t.Error("expected a next page")
+		}
+	})
+
+	t.Run("unknown parameter", func(t *testing.T) {
+		if recorder := list(t, provider.Resource.ID, url.Values{"owner": {"alice"}}); recorder.Code != 400 {
+			t.Errorf("expected status 400, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		if recorder := list(t, "missing", nil); recorder.Code != 404 {
+			t.Errorf("expected status 404, got %d", recorder.Code)
+		}
+	})
+}
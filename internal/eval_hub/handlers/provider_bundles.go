@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serialization"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"go.yaml.in/yaml/v4"
+)
+
+const (
+	bundleFormatJSON = "json"
+	bundleFormatYAML = "yaml"
+	// maxProviderIDLength bounds the ids given to imported providers.
+	maxProviderIDLength = 128
+	// importProviderPath is the last segment of the import endpoint, which a provider can not
+	// have as its id.
+	importProviderPath = "import"
+)
+
+// HandleExportProvider handles GET /api/v1/evaluations/providers/{id}/export. The bundle
+// holds the config of the provider with its benchmarks, as JSON or as YAML with
+// format=yaml, to be imported in another eval-hub instance.
+func (h *Handlers) HandleExportProvider(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	providerId := req.PathValue(constants.PATH_PARAMETER_PROVIDER_ID)
+	if providerId == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_PROVIDER_ID), ctx.RequestID)
+		return
+	}
+
+	allowedParams := []string{"format"}
+	if badParams := getAllParams(req, allowedParams...); len(badParams) > 0 {
+		// just report the first bad parameter
+		w.Error(serviceerrors.NewServiceError(messages.QueryBadParameter, "ParameterName", badParams[0], "AllowedParameters", strings.Join(allowedParams, ", ")), ctx.RequestID)
+		return
+	}
+	format, err := GetParam(req, "format", true, bundleFormatJSON)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	if format != bundleFormatJSON && format != bundleFormatYAML {
+		w.Error(serviceerrors.NewServiceError(messages.QueryParameterValueInvalid, "ParameterName", "format", "AllowedValues", bundleFormatJSON+"|"+bundleFormatYAML), ctx.RequestID)
+		return
+	}
+
+	var bundle []byte
+
+	err = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			provider, err := storage.WithContext(runtimeCtx).GetProvider(providerId)
+			if err != nil {
+				return err
+			}
+			bundle, err = marshalProviderBundle(&api.ProviderBundle{
+				Format:     api.ProviderBundleFormat,
+				Version:    api.ProviderBundleVersion,
+				ID:         provider.Resource.ID,
+				ExportedAt: time.Now().UTC(),
+				Provider:   provider.ProviderConfig,
+			}, format)
+			return err
+		},
+		"storage",
+		"export-provider",
+		"provider.id", providerId,
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	contentType := "application/json"
+	if format == bundleFormatYAML {
+		contentType = "application/yaml"
+	}
+	w.SetHeader("Content-Type", contentType)
+	w.SetHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("%s.provider.%s", providerId, format)))
+	if ctx.RequestID != "" {
+		w.SetHeader("X-Global-Transaction-Id", ctx.RequestID)
+	}
+	w.SetStatusCode(200)
+	_, _ = w.Write(bundle)
+	logging.LogRequestSuccess(ctx, 200, nil, "bytes", len(bundle))
+}
+
+// marshalProviderBundle returns the bundle as JSON, or as YAML with the JSON field names and
+// in the same order.
+func marshalProviderBundle(bundle *api.ProviderBundle, format string) ([]byte, error) {
+	body, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return nil, serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	if format == bundleFormatJSON {
+		return append(body, '\n'), nil
+	}
+	// JSON is YAML, the node keeps the order of the fields; it is written in block style
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal(body, document); err != nil {
+		return nil, serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	clearNodeStyle(document)
+	body, err = yaml.Marshal(document)
+	if err != nil {
+		return nil, serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	return body, nil
+}
+
+func clearNodeStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearNodeStyle(child)
+	}
+}
+
+// HandleImportProvider handles POST /api/v1/evaluations/providers/import. The provider of a
+// bundle made by HandleExportProvider, as JSON or YAML, is created in the tenant of the
+// request with the id of the bundle, or the id given with the id parameter. The import
+// fails with a conflict when a provider already has the id, so that a vetted provider is
+// never replaced silently.
+func (h *Handlers) HandleImportProvider(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	var provider *api.ProviderResource
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			allowedParams := []string{"id"}
+			if badParams := getAllParams(req, allowedParams...); len(badParams) > 0 {
+				// just report the first bad parameter
+				return serviceerrors.NewServiceError(messages.QueryBadParameter, "ParameterName", badParams[0], "AllowedParameters", strings.Join(allowedParams, ", "))
+			}
+			id, err := GetParam(req, "id", true, "")
+			if err != nil {
+				return err
+			}
+			bodyBytes, err := req.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			bundle, err := h.unmarshalProviderBundle(ctx.WithContext(runtimeCtx), bodyBytes)
+			if err != nil {
+				return err
+			}
+			if id == "" {
+				id = bundle.ID
+			}
+			if id == "" {
+				id = common.GUID()
+			}
+			if err := validateImportedProviderID(id); err != nil {
+				return err
+			}
+			provider = &api.ProviderResource{
+				Resource: api.Resource{
+					ID:        id,
+					CreatedAt: time.Now(),
+					Owner:     ctx.User,
+					Tenant:    ctx.Tenant,
+				},
+				ProviderConfig: bundle.Provider,
+			}
+			return nil
+		},
+		"validation",
+		"validate-provider-bundle",
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			// the system providers are found too, an import can not shadow them
+			if _, err := scoped.GetProvider(provider.Resource.ID); err == nil {
+				err = serviceerrors.NewServiceError(messages.ProviderAlreadyExists, "ProviderID", provider.Resource.ID)
+				w.Error(err, ctx.RequestID)
+				return err
+			} else if !isResourceNotFound(err) {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if err := scoped.CreateProvider(provider); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(provider, 201)
+			return nil
+		},
+		"storage",
+		"import-provider",
+		"provider.id", provider.Resource.ID,
+	)
+}
+
+// unmarshalProviderBundle reads and validates a bundle given as JSON or YAML.
+func (h *Handlers) unmarshalProviderBundle(ctx *executioncontext.ExecutionContext, body []byte) (*api.ProviderBundle, error) {
+	var document any
+	if err := yaml.Unmarshal(body, &document); err != nil {
+		return nil, serviceerrors.NewServiceError(messages.InvalidProviderBundle, "Reason", err.Error())
+	}
+	if _, ok := document.(map[string]any); !ok {
+		return nil, serviceerrors.NewServiceError(messages.InvalidProviderBundle, "Reason", "the bundle must be an object")
+	}
+	jsonBytes, err := json.Marshal(document)
+	if err != nil {
+		return nil, serviceerrors.NewServiceError(messages.InvalidProviderBundle, "Reason", err.Error())
+	}
+	bundle := &api.ProviderBundle{}
+	if err := serialization.Unmarshal(h.validate, ctx, jsonBytes, bundle); err != nil {
+		return nil, err
+	}
+	if bundle.Format != api.ProviderBundleFormat || bundle.Version != api.ProviderBundleVersion {
+		return nil, serviceerrors.NewServiceError(messages.InvalidProviderBundle, "Reason", fmt.Sprintf("unsupported format %q version %d", bundle.Format, bundle.Version))
+	}
+	return bundle, nil
+}
+
+// validateImportedProviderID checks an id that is used in the paths of the providers API.
+func validateImportedProviderID(id string) error {
+	invalid := func(reason string) error {
+		return serviceerrors.NewServiceError(messages.InvalidProviderBundle, "Reason", fmt.Sprintf("the provider id %q %s", id, reason))
+	}
+	switch {
+	case len(id) > maxProviderIDLength:
+		return invalid(fmt.Sprintf("is longer than %d characters", maxProviderIDLength))
+	case strings.ContainsAny(id, "/?#% \t\r\n"):
+		return invalid("can not contain /, ?, #, % or whitespace")
+	case id == importProviderPath:
+		return invalid("is reserved")
+	}
+	return nil
+}
+
+func isResourceNotFound(err error) bool {
+	var se *serviceerrors.ServiceError
+	return errors.As(err, &se) && se.MessageCode() == messages.ResourceNotFound
+}
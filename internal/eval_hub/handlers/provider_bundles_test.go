@@ -0,0 +1,143 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// bundleStorage keeps the providers it creates.
+type bundleStorage struct {
+	*fakeStorage
+}
+
+func (s *bundleStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *bundleStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *bundleStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *bundleStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *bundleStorage) CreateProvider(provider *api.ProviderResource) error {
+	s.providerConfigs[provider.Resource.ID] = *provider
+	return nil
+}
+
+func TestProviderBundles(t *testing.T) {
+	provider := gpuTestProvider()
+	provider.Resource.Tenant = "team-a"
+	provider.Resource.Owner = "alice"
+	provider.Benchmarks = append(provider.Benchmarks, api.BenchmarkResource{ID: "dated", Name: "2024-01-01", Tags: []string{"true"}})
+	storage := &bundleStorage{fakeStorage: &fakeStorage{providerConfigs: map[string]api.ProviderResource{provider.Resource.ID: provider}}}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", logging.FallbackLogger(), "bob", "team-b")
+
+	export := func(t *testing.T, query url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		uri := "/api/v1/evaluations/providers/" + provider.Resource.ID + "/export"
+		if len(query) > 0 {
+			uri += "?" + query.Encode()
+		}
+		req := &providersRequest{
+			MockRequest: createMockRequest("GET", uri),
+			queryValues: query,
+			pathValues:  map[string]string{constants.PATH_PARAMETER_PROVIDER_ID: provider.Resource.ID},
+		}
+		recorder := httptest.NewRecorder()
+		h.HandleExportProvider(ctx, req, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+	importBundle := func(t *testing.T, bundle []byte, query url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		uri := "/api/v1/evaluations/providers/import"
+		if len(query) > 0 {
+			uri += "?" + query.Encode()
+		}
+		req := &providersRequest{
+			MockRequest: createMockRequest("POST", uri),
+			queryValues: query,
+			body:        bundle,
+		}
+		recorder := httptest.NewRecorder()
+		h.HandleImportProvider(ctx, req, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		t.Run("round trip as "+format, func(t *testing.T) {
+			exported := export(t, url.Values{"format": {format}})
+			if exported.Code != 200 {
+				t.Fatalf("expected status 200, got %d: %s", exported.Code, exported.Body.String())
+			}
+			if got := exported.Header().Get("Content-Disposition"); !strings.Contains(got, provider.Resource.ID+".provider."+format) {
+				t.Errorf("unexpected Content-Disposition %q", got)
+			}
+			bundle := exported.Body.Bytes()
+			for _, leaked := range []string{"team-a", "alice"} {
+				if strings.Contains(string(bundle), leaked) {
+					t.Errorf("the bundle holds %q of the exporting instance:\n%s", leaked, bundle)
+				}
+			}
+
+			conflict := importBundle(t, bundle, nil)
+			if conflict.Code != 409 || !strings.Contains(conflict.Body.String(), "provider_already_exists") {
+				t.Fatalf("expected a conflict on the id of the bundle, got %d: %s", conflict.Code, conflict.Body.String())
+			}
+
+			imported := importBundle(t, bundle, url.Values{"id": {"vetted-" + format}})
+			if imported.Code != 201 {
+				t.Fatalf("expected status 201, got %d: %s", imported.Code, imported.Body.String())
+			}
+			var got api.ProviderResource
+			if err := json.NewDecoder(imported.Body).Decode(&got); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if got.Resource.ID != "vetted-"+format || got.Resource.Tenant != "team-b" || got.Resource.Owner != "bob" {
+				t.Errorf("expected the provider in the tenant of the request with the given id, got %+v", got.Resource)
+			}
+			wantConfig, _ := json.Marshal(provider.ProviderConfig)
+			gotConfig, _ := json.Marshal(got.ProviderConfig)
+			if string(gotConfig) != string(wantConfig) {
+				t.Errorf("the config changed on the round trip:\n got %s\nwant %s", gotConfig, wantConfig)
+			}
+		})
+	}
+
+	t.Run("invalid bundles", func(t *testing.T) {
+		tests := map[string]struct {
+			bundle string
+			query  url.Values
+			want   string
+		}{
+			"not yaml":       {bundle: "format: [", want: "invalid_provider_bundle"},
+			"not an object":  {bundle: "- a", want: "invalid_provider_bundle"},
+			"unknown format": {bundle: `{"format":"other","version":1,"provider":{"name":"p","benchmarks":[]}}`, want: "invalid_provider_bundle"},
+			"reserved id":    {bundle: `{"format":"eval-hub-provider","version":1,"id":"import","provider":{"name":"p","benchmarks":[]}}`, want: "is reserved"},
+			"path in the id": {bundle: `{"format":"eval-hub-provider","version":1,"provider":{"name":"p","benchmarks":[]}}`, query: url.Values{"id": {"a/b"}}, want: "can not contain"},
+		}
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				recorder := importBundle(t, []byte(tt.bundle), tt.query)
+				if recorder.Code != 400 || !strings.Contains(recorder.Body.String(), tt.want) {
+					t.Errorf("expected status 400 with %q, got %d: %s", tt.want, recorder.Code, recorder.Body.String())
+				}
+			})
+		}
+	})
+
+	t.Run("unknown export format", func(t *testing.T) {
+		if recorder := export(t, url.Values{"format": {"xml"}}); recorder.Code != 400 {
+			t.Errorf("expected status 400, got %d", recorder.Code)
+		}
+	})
+}
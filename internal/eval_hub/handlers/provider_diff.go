@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// HandleDiffProviders handles GET /api/v1/evaluations/providers/{id}/diff/{other_id}. It
+// lists the benchmarks the other provider adds, removes and changes, so that an operator
+// can review the import of a new version of a framework before it replaces the system
+// provider.
+func (h *Handlers) HandleDiffProviders(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	providerId := req.PathValue(constants.PATH_PARAMETER_PROVIDER_ID)
+	if providerId == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_PROVIDER_ID), ctx.RequestID)
+		return
+	}
+	otherProviderId := req.PathValue(constants.PATH_PARAMETER_OTHER_PROVIDER_ID)
+	if otherProviderId == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_OTHER_PROVIDER_ID), ctx.RequestID)
+		return
+	}
+
+	var diff *api.ProviderDiff
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			provider, err := storage.WithContext(runtimeCtx).GetProvider(providerId)
+			if err != nil {
+				return err
+			}
+			otherProvider, err := storage.WithContext(runtimeCtx).GetProvider(otherProviderId)
+			if err != nil {
+				return err
+			}
+			diff = api.DiffProviders(provider, otherProvider)
+			return nil
+		},
+		"storage",
+		"diff-providers",
+		"provider.id", providerId,
+		"other_provider.id", otherProviderId,
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	ctx.Logger.Info("Compared providers", "provider_id", providerId, "other_provider_id", otherProviderId,
+		"added", len(diff.Added), "removed", len(diff.Removed), "changed", len(diff.Changed))
+	w.WriteJSON(diff, 200)
+}
@@ -0,0 +1,80 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestHandleDiffProviders(t *testing.T) {
+	provider := api.ProviderResource{
+		Resource: api.Resource{ID: "harness"},
+		ProviderConfig: api.ProviderConfig{Name: "Harness", Benchmarks: []api.BenchmarkResource{
+			{ID: "arc_easy", Name: "ARC Easy", Category: "reasoning"},
+			{ID: "mmlu", Name: "MMLU", Category: "knowledge"},
+		}},
+	}
+	upgrade := api.ProviderResource{
+		Resource: api.Resource{ID: "harness-v2"},
+		ProviderConfig: api.ProviderConfig{Name: "Harness", Benchmarks: []api.BenchmarkResource{
+			{ID: "arc_easy", Name: "ARC Easy", Category: "reasoning", Parameters: map[string]any{"num_fewshot": 5}},
+			{ID: "ifeval", Name: "IFEval", Category: "instruction_following"},
+		}},
+	}
+	storage := &fakeStorage{providerConfigs: map[string]api.ProviderResource{"harness": provider, "harness-v2": upgrade}}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", logging.FallbackLogger(), "alice", "test-tenant")
+
+	diff := func(id, otherId string) *httptest.ResponseRecorder {
+		req := &providersRequest{
+			MockRequest: createMockRequest("GET", "/api/v1/evaluations/providers/"+id+"/diff/"+otherId),
+			pathValues: map[string]string{
+				constants.PATH_PARAMETER_PROVIDER_ID:       id,
+				constants.PATH_PARAMETER_OTHER_PROVIDER_ID: otherId,
+			},
+		}
+		recorder := httptest.NewRecorder()
+		h.HandleDiffProviders(ctx, req, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	t.Run("lists the added, removed and changed benchmarks", func(t *testing.T) {
+		recorder := diff("harness", "harness-v2")
+		if recorder.Code != 200 {
+			t.Fatalf("expected 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		var got api.ProviderDiff
+		if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+			t.Fatalf("failed to decode the diff: %v", err)
+		}
+		if got.From != "harness" || got.To != "harness-v2" || got.Unchanged != 0 {
+			t.Fatalf("unexpected diff %+v", got)
+		}
+		if len(got.Added) != 1 || got.Added[0].ID != "ifeval" || len(got.Removed) != 1 || got.Removed[0].ID != "mmlu" {
+			t.Fatalf("unexpected added %+v and removed %+v", got.Added, got.Removed)
+		}
+		if len(got.Changed) != 1 || got.Changed[0].ID != "arc_easy" || len(got.Changed[0].Fields) != 1 || got.Changed[0].Fields[0].Field != "parameters.num_fewshot" {
+			t.Fatalf("unexpected changes %+v", got.Changed)
+		}
+	})
+
+	t.Run("an unknown provider is not found", func(t *testing.T) {
+		if recorder := diff("harness", "missing"); recorder.Code != 404 {
+			t.Fatalf("expected 404, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("a missing path parameter is rejected", func(t *testing.T) {
+		if recorder := diff("harness", ""); recorder.Code != 400 {
+			t.Fatalf("expected 400, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+}
@@ -2,7 +2,10 @@ package handlers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -48,9 +51,60 @@ var (
 		{Path: "/agent", Op: api.PatchOpAdd, Prefix: true},
 		{Path: "/agent", Op: api.PatchOpRemove, Prefix: true},
 		{Path: "/agent", Op: api.PatchOpReplace, Prefix: true},
+
+		{Path: "/health_check", Op: api.PatchOpAdd, Prefix: true},
+		{Path: "/health_check", Op: api.PatchOpRemove, Prefix: true},
+		{Path: "/health_check", Op: api.PatchOpReplace, Prefix: true},
 	}
 )
 
+// attachProviderStatus fills in the latest canary outcome and image pre-pull status for the
+// given providers.
+func (h *Handlers) attachProviderStatus(providers ...*api.ProviderResource) {
+	for _, provider := range providers {
+		if provider == nil {
+			continue
+		}
+		if h.providerHealth != nil {
+			provider.Health = h.providerHealth.ProviderHealth(provider.Resource.ID)
+		}
+		if h.imageWarmup != nil {
+			provider.ImageWarmup = h.imageWarmup.ProviderImageWarmup(provider.Resource.ID)
+		}
+	}
+}
+
+// writeJSONWithETag writes v with a weak ETag of its JSON, or answers 304 Not Modified when
+// the If-None-Match header of the request matches it, so that clients polling the providers
+// are not sent the same listing again. The ETag is weak as the response may be compressed.
+func writeJSONWithETag(req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper, v any, arguments ...any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	sum := sha256.Sum256(body)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+	w.SetHeader("ETag", etag)
+	w.SetHeader("Cache-Control", "no-cache")
+	if etagMatches(req.Header("If-None-Match"), etag) {
+		w.WriteJSON(nil, http.StatusNotModified, arguments...)
+		return nil
+	}
+	w.WriteJSON(json.RawMessage(body), http.StatusOK, arguments...)
+	return nil
+}
+
+// etagMatches returns true when the If-None-Match header lists etag, compared weakly.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
 func (h *Handlers) HandleCreateProvider(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
 	storage := h.storage.WithLogger(ctx.Logger).WithContext(ctx.Ctx).WithTenant(ctx.Tenant).WithOwner(ctx.User)
 
@@ -167,10 +221,11 @@ func (h *Handlers) HandleListProviders(ctx *executioncontext.ExecutionContext, r
 				return err
 			}
 
-			if !benchmarks {
-				for i := range providers.Items {
+			for i := range providers.Items {
+				if !benchmarks {
 					providers.Items[i].Benchmarks = []api.BenchmarkResource{}
 				}
+				h.attachProviderStatus(&providers.Items[i])
 			}
 
 			page, err := CreatePage(ctx, providers.TotalCount, ofilter.Offset, ofilter.Limit, req)
@@ -186,7 +241,10 @@ func (h *Handlers) HandleListProviders(ctx *executioncontext.ExecutionContext, r
 
 			count = len(providers.Items)
 			totalCount = providers.TotalCount
-			w.WriteJSON(result, 200, "count", strconv.Itoa(count), "total_count", strconv.Itoa(totalCount))
+			if err := writeJSONWithETag(req, w, result, "count", strconv.Itoa(count), "total_count", strconv.Itoa(totalCount)); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
 			return nil
 		},
 		"storage",
@@ -215,7 +273,11 @@ func (h *Handlers) HandleGetProvider(ctx *executioncontext.ExecutionContext, req
 				w.Error(err, ctx.RequestID)
 				return err
 			}
-			w.WriteJSON(provider, 200)
+			h.attachProviderStatus(provider)
+			if err := writeJSONWithETag(req, w, provider); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
 			return nil
 		},
 		"storage",
@@ -261,3 +261,41 @@ func assertProviderGPUConfig(t *testing.T, got api.ProviderResource) {
 		t.Errorf("node_selector = %v", got.Runtime.K8s.GPU.NodeSelector)
 	}
 }
+
+func TestHandleListProviders_AnswersIfNoneMatch(t *testing.T) {
+	storage := &listProvidersStorage{
+		fakeStorage: &fakeStorage{},
+		providers:   []api.ProviderResource{gpuTestProvider()},
+	}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", logging.FallbackLogger(), "test-user", "test-tenant")
+
+	list := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := &providersRequest{
+			MockRequest: createMockRequest("GET", "/api/v1/evaluations/providers"),
+			queryValues: map[string][]string{},
+			pathValues:  map[string]string{},
+		}
+		if ifNoneMatch != "" {
+			req.SetHeader("If-None-Match", ifNoneMatch)
+		}
+		recorder := httptest.NewRecorder()
+		h.HandleListProviders(ctx, req, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	first := list("")
+	etag := first.Header().Get("ETag")
+	if first.Code != 200 || !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("expected status 200 with a weak ETag, got %d and %q", first.Code, etag)
+	}
+	if got := list(etag); got.Code != 304 {
+		t.Errorf("expected status 304 for the current ETag, got %d", got.Code)
+	}
+	if got := list(`"other", ` + strings.TrimPrefix(etag, "W/")); got.Code != 304 {
+		t.Errorf("expected the ETag to match weakly in a list, got %d", got.Code)
+	}
+	if got := list(`W/"stale"`); got.Code != 200 || got.Header().Get("ETag") != etag {
+		t.Errorf("expected status 200 for a stale ETag, got %d", got.Code)
+	}
+}
@@ -0,0 +1,160 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type fakeProviderHealth map[string]api.ProviderHealth
+
+func (f fakeProviderHealth) ProviderHealth(providerID string) *api.ProviderHealth {
+	if h, ok := f[providerID]; ok {
+		return &h
+	}
+	return nil
+}
+
+func healthTestProviders() []api.ProviderResource {
+	return []api.ProviderResource{
+		{
+			Resource:       api.Resource{ID: "checked"},
+			ProviderConfig: api.ProviderConfig{Name: "Checked", Benchmarks: []api.BenchmarkResource{{ID: "smoke"}}},
+		},
+		{
+			Resource:       api.Resource{ID: "unchecked"},
+			ProviderConfig: api.ProviderConfig{Name: "Unchecked", Benchmarks: []api.BenchmarkResource{{ID: "smoke"}}},
+		},
+	}
+}
+
+func healthTestReporter() fakeProviderHealth {
+	lastSuccess := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	return fakeProviderHealth{
+		"checked": {
+			Status:        api.ProviderHealthStatusUnhealthy,
+			LastSuccessAt: &lastSuccess,
+			FailureStreak: 3,
+			LastError:     "image pull failed",
+		},
+	}
+}
+
+func TestHandleListProviders_ReturnsProviderHealth(t *testing.T) {
+	storage := &listProvidersStorage{
+		fakeStorage: &fakeStorage{},
+		providers:   healthTestProviders(),
+	}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil).WithProviderHealth(healthTestReporter())
+
+	req := &providersRequest{
+		MockRequest: createMockRequest("GET", "/api/v1/evaluations/providers"),
+		queryValues: map[string][]string{},
+		pathValues:  map[string]string{},
+	}
+	recorder := httptest.NewRecorder()
+	resp := MockResponseWrapper{recorder: recorder}
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", logging.FallbackLogger(), "test-user", "test-tenant")
+
+	h.HandleListProviders(ctx, req, resp)
+
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d body %s", recorder.Code, recorder.Body.String())
+	}
+	var got api.ProviderResourceList
+	if err := json.NewDecoder(recorder.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if len(got.Items) != 2 {
+		t.Fatalf("expected 2 providers, got %d", len(got.Items))
+	}
+	health := got.Items[0].Health
+	if health == nil || health.Status != api.ProviderHealthStatusUnhealthy || health.FailureStreak != 3 || health.LastSuccessAt == nil {
+		t.Fatalf("unexpected health for checked provider: %+v", health)
+	}
+	if got.Items[1].Health != nil {
+		t.Fatalf("expected no health for unchecked provider, got %+v", got.Items[1].Health)
+	}
+}
+
+func TestHandleGetProvider_ReturnsProviderHealth(t *testing.T) {
+	providers := healthTestProviders()
+	storage := &fakeStorage{providerConfigs: map[string]api.ProviderResource{
+		providers[0].Resource.ID: providers[0],
+	}}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil).WithProviderHealth(healthTestReporter())
+
+	req := &providersRequest{
+		MockRequest: createMockRequest("GET", "/api/v1/evaluations/providers/checked"),
+		pathValues:  map[string]string{constants.PATH_PARAMETER_PROVIDER_ID: "checked"},
+	}
+	recorder := httptest.NewRecorder()
+	resp := MockResponseWrapper{recorder: recorder}
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", logging.FallbackLogger(), "test-user", "test-tenant")
+
+	h.HandleGetProvider(ctx, req, resp)
+
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d body %s", recorder.Code, recorder.Body.String())
+	}
+	var got api.ProviderResource
+	if err := json.NewDecoder(recorder.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.Health == nil || got.Health.LastError != "image pull failed" {
+		t.Fatalf("unexpected health: %+v", got.Health)
+	}
+}
+
+type fakeImageWarmup map[string]api.ProviderImageWarmup
+
+func (f fakeImageWarmup) ProviderImageWarmup(providerID string) *api.ProviderImageWarmup {
+	if w, ok := f[providerID]; ok {
+		return &w
+	}
+	return nil
+}
+
+func TestHandleGetProvider_ReturnsImageWarmup(t *testing.T) {
+	providers := healthTestProviders()
+	storage := &fakeStorage{providerConfigs: map[string]api.ProviderResource{
+		providers[0].Resource.ID: providers[0],
+	}}
+	imageWarmup := fakeImageWarmup{
+		"checked": {Image: "quay.io/eval-hub/checked:1.0", State: api.ImageWarmupStatePulling, Nodes: 4, Pulled: 3},
+	}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil).WithImageWarmup(imageWarmup)
+
+	req := &providersRequest{
+		MockRequest: createMockRequest("GET", "/api/v1/evaluations/providers/checked"),
+		pathValues:  map[string]string{constants.PATH_PARAMETER_PROVIDER_ID: "checked"},
+	}
+	recorder := httptest.NewRecorder()
+	resp := MockResponseWrapper{recorder: recorder}
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-1", logging.FallbackLogger(), "test-user", "test-tenant")
+
+	h.HandleGetProvider(ctx, req, resp)
+
+	if recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d body %s", recorder.Code, recorder.Body.String())
+	}
+	var got api.ProviderResource
+	if err := json.NewDecoder(recorder.Body).Decode(&got); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if got.ImageWarmup == nil || got.ImageWarmup.State != api.ImageWarmupStatePulling || got.ImageWarmup.Pulled != 3 {
+		t.Fatalf("unexpected image warm-up: %+v", got.ImageWarmup)
+	}
+	if got.Health != nil {
+		t.Fatalf("expected no health without a health reporter, got %+v", got.Health)
+	}
+}
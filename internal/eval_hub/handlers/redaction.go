@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/redaction"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serialization"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// loadRedactor returns the redactor of the redaction policy of the tenant of the storage, nil
+// when the tenant has none.
+func loadRedactor(storage abstractions.Storage) (*redaction.Redactor, error) {
+	policy, err := storage.GetRedactionPolicy()
+	if err != nil || policy == nil {
+		return nil, err
+	}
+	redactor, err := redaction.Compile(&policy.RedactionPolicy)
+	if err != nil {
+		return nil, serviceerrors.NewServiceError(messages.RedactionPolicyInvalid, "Error", err.Error())
+	}
+	return redactor, nil
+}
+
+// redactStatusEvent redacts the error and warning messages of a benchmark status event before
+// it is stored, and records the redactions applied. The event is not stored when the policy
+// can not be applied, rather than stored unredacted.
+func redactStatusEvent(storage abstractions.Storage, jobID string, event *api.BenchmarkStatusEvent) error {
+	if event == nil || (event.ErrorMessage == nil && event.WarningMessage == nil) {
+		return nil
+	}
+	redactor, err := loadRedactor(storage)
+	if err != nil || redactor == nil {
+		return err
+	}
+	for _, message := range []struct {
+		target api.RedactionTarget
+		info   *api.MessageInfo
+	}{
+		{api.RedactionTargetErrorMessage, event.ErrorMessage},
+		{api.RedactionTargetWarningMessage, event.WarningMessage},
+	} {
+		counts := redactor.RedactMessage(message.info)
+		if counts.Total() == 0 {
+			continue
+		}
+		record := &api.RedactionRecord{
+			JobID:          jobID,
+			BenchmarkIndex: event.BenchmarkIndex,
+			Target:         message.target,
+			Redactions:     counts,
+			RedactedAt:     time.Now().UTC(),
+		}
+		if err := storage.AddEvaluationJobRedaction(jobID, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HandleGetRedactionPolicy handles GET /api/v1/evaluations/redaction-policy, the redaction
+// policy of the tenant, empty when it has none.
+func (h *Handlers) HandleGetRedactionPolicy(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			policy, err := storage.WithContext(runtimeCtx).GetRedactionPolicy()
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if policy == nil {
+				policy = &api.RedactionPolicyResource{Tenant: ctx.Tenant}
+			}
+			w.WriteJSON(policy, 200)
+			return nil
+		},
+		"storage",
+		"get-redaction-policy",
+	)
+}
+
+// HandlePutRedactionPolicy handles PUT /api/v1/evaluations/redaction-policy, it replaces the
+// redaction policy of the tenant. When admin groups are configured, only their members can
+// change it. The policy applies to the artifacts and messages stored from then on.
+func (h *Handlers) HandlePutRedactionPolicy(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	if h.serviceConfig != nil && h.serviceConfig.JobAccess.HasAdminGroups() && !h.serviceConfig.JobAccess.IsAdmin(ctx.Groups) {
+		w.Error(serviceerrors.NewServiceError(messages.RedactionPolicyAccessDenied, "User", ctx.User), ctx.RequestID)
+		return
+	}
+
+	policy := &api.RedactionPolicy{}
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			bodyBytes, err := r.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			if err := serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, policy); err != nil {
+				return err
+			}
+			if _, err := redaction.Compile(policy); err != nil {
+				return serviceerrors.NewServiceError(messages.RedactionPolicyInvalid, "Error", err.Error())
+			}
+			return nil
+		},
+		"validation",
+		"validate-redaction-policy",
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			resource := &api.RedactionPolicyResource{
+				Tenant:          ctx.Tenant,
+				UpdatedAt:       time.Now(),
+				UpdatedBy:       ctx.User,
+				RedactionPolicy: *policy,
+			}
+			if err := storage.WithContext(runtimeCtx).PutRedactionPolicy(resource); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			ctx.Logger.Info("Redaction policy updated", "rules", len(policy.Rules))
+			w.WriteJSON(resource, 200)
+			return nil
+		},
+		"storage",
+		"put-redaction-policy",
+	)
+}
+
+// HandleListEvaluationRedactions handles GET /api/v1/evaluations/jobs/{id}/redactions, the
+// audit of the redactions applied to the artifacts and messages of the benchmarks of the job.
+func (h *Handlers) HandleListEvaluationRedactions(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	evaluationJobID := req.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+
+	filter, err := CommonListFilters(req)
+	logging.LogRequestStarted(ctx, "filter", filter)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+	allowedParams := []string{"limit", "offset"}
+	if badParams := getAllParams(req, allowedParams...); len(badParams) > 0 {
+		// just report the first bad parameter
+		w.Error(serviceerrors.NewServiceError(messages.QueryBadParameter, "ParameterName", badParams[0], "AllowedParameters", strings.Join(allowedParams, ", ")), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			if _, err := h.getAccessibleEvaluationJob(ctx, scoped, evaluationJobID, jobAccessRead); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			redactions, err := scoped.GetEvaluationJobRedactions(evaluationJobID, filter)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			page, err := CreatePage(ctx, redactions.TotalCount, filter.Offset, filter.Limit, req)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			result := api.RedactionRecordList{
+				Page:  *page,
+				Items: redactions.Items,
+			}
+			w.WriteJSON(result, 200, "count", strconv.Itoa(len(redactions.Items)), "total_count", strconv.Itoa(redactions.TotalCount))
+			return nil
+		},
+		"storage",
+		"list-evaluation-redactions",
+		"job.id", evaluationJobID,
+	)
+}
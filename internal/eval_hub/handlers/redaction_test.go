@@ -0,0 +1,211 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/eval-hub/eval-hub/pkg/mlflowclient"
+)
+
+// redactionTestStorage keeps the redaction policy and the redactions it is given in memory.
+type redactionTestStorage struct {
+	*artifactsTestStorage
+	policy     *api.RedactionPolicyResource
+	redactions []api.RedactionRecord
+	lastStatus *api.StatusEvent
+}
+
+func (s *redactionTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *redactionTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *redactionTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *redactionTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *redactionTestStorage) PutRedactionPolicy(policy *api.RedactionPolicyResource) error {
+	s.policy = policy
+	return nil
+}
+
+func (s *redactionTestStorage) GetRedactionPolicy() (*api.RedactionPolicyResource, error) {
+	return s.policy, nil
+}
+
+func (s *redactionTestStorage) AddEvaluationJobRedaction(_ string, record *api.RedactionRecord) error {
+	s.redactions = append(s.redactions, *record)
+	return nil
+}
+
+func (s *redactionTestStorage) GetEvaluationJobRedactions(id string, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.RedactionRecord], error) {
+	items := []api.RedactionRecord{}
+	for _, record := range s.redactions {
+		if record.JobID == id {
+			items = append(items, record)
+		}
+	}
+	return &abstractions.QueryResults[api.RedactionRecord]{Items: items, TotalCount: len(items)}, nil
+}
+
+func (s *redactionTestStorage) UpdateEvaluationJob(_ string, status *api.StatusEvent) (*abstractions.EvaluationJobUpdate, error) {
+	s.lastStatus = status
+	return &abstractions.EvaluationJobUpdate{}, nil
+}
+
+func (s *redactionTestStorage) GetEvaluationJobs(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.EvaluationJobResource], error) {
+	return &abstractions.QueryResults[api.EvaluationJobResource]{Items: []api.EvaluationJobResource{}}, nil
+}
+
+func TestRedactionPolicy(t *testing.T) {
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-redaction", logging.FallbackLogger(), "test-user", "test-tenant")
+
+	var uploadedContent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && strings.Contains(r.URL.Path, "/mlflow-artifacts/artifacts/") {
+			content, _ := io.ReadAll(r.Body)
+			uploadedContent = string(content)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	storage := &redactionTestStorage{artifactsTestStorage: &artifactsTestStorage{baselineTestStorage: newBaselineTestStorage()}}
+	storage.jobs["job-redaction"] = &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-redaction", Tenant: "test-tenant", Owner: "test-user"}, MLFlowExperimentID: "8"},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "toxigen"}, ProviderID: "lm_evaluation_harness"}},
+		},
+	}
+	h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, mlflowclient.NewClient(srv.URL), nil, nil)
+
+	putPolicy := func(body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		h.HandlePutRedactionPolicy(ctx, &baselineRequest{
+			MockRequest: createMockRequest("PUT", "/api/v1/evaluations/redaction-policy"),
+			body:        []byte(body),
+		}, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+	upload := func(name string, contentType string, content string) *httptest.ResponseRecorder {
+		request := &artifactRequest{
+			baselineRequest: &baselineRequest{
+				MockRequest: createMockRequest("PUT", "/api/v1/evaluations/jobs/job-redaction/benchmarks/0/artifacts/"+name),
+				path: map[string]string{
+					constants.PATH_PARAMETER_JOB_ID:          "job-redaction",
+					constants.PATH_PARAMETER_BENCHMARK_INDEX: "0",
+					constants.PATH_PARAMETER_ARTIFACT_NAME:   name,
+				},
+			},
+			content: strings.NewReader(content),
+		}
+		request.SetHeader("Content-Type", contentType)
+		recorder := httptest.NewRecorder()
+		h.HandleUploadEvaluationBenchmarkArtifact(ctx, request, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	t.Run("invalid patterns are rejected", func(t *testing.T) {
+		for _, body := range []string{
+			`{"rules":[{"name":"bad","pattern":"("}]}`,
+			`{"rules":[{"name":"empty","pattern":"x*"}]}`,
+			`{"rules":[{"pattern":"x"}]}`,
+		} {
+			if recorder := putPolicy(body); recorder.Code != 400 {
+				t.Errorf("expected status 400 for %s, got %d: %s", body, recorder.Code, recorder.Body.String())
+			}
+		}
+		if storage.policy != nil {
+			t.Fatalf("expected no policy to be stored, got %+v", storage.policy)
+		}
+	})
+
+	t.Run("the policy is stored", func(t *testing.T) {
+		recorder := putPolicy(`{"rules":[{"name":"email","pattern":"[a-z.]+@[a-z.]+"}]}`)
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if storage.policy == nil || len(storage.policy.Rules) != 1 || storage.policy.UpdatedBy != "test-user" {
+			t.Fatalf("unexpected policy %+v", storage.policy)
+		}
+	})
+
+	t.Run("text artifacts are redacted and audited", func(t *testing.T) {
+		recorder := upload("samples.jsonl", "application/jsonl", `{"prompt":"I am jane@example.com, and you?"}`+"\n"+`{"prompt":"hi"}`+"\n")
+		if recorder.Code != 201 {
+			t.Fatalf("expected status 201, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if want := `{"prompt":"I am [REDACTED:email], and you?"}` + "\n" + `{"prompt":"hi"}` + "\n"; uploadedContent != want {
+			t.Fatalf("uploaded %q, want %q", uploadedContent, want)
+		}
+		artifact := api.ArtifactResource{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &artifact); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if artifact.Size != int64(len(uploadedContent)) {
+			t.Errorf("expected the size of the redacted content, got %d", artifact.Size)
+		}
+		if len(storage.redactions) != 1 || storage.redactions[0].Target != api.RedactionTargetArtifact || storage.redactions[0].Artifact != "samples.jsonl" || storage.redactions[0].Redactions["email"] != 1 {
+			t.Fatalf("unexpected redactions %+v", storage.redactions)
+		}
+	})
+
+	t.Run("binary artifacts are stored as they are", func(t *testing.T) {
+		content := "jane@example.com"
+		if recorder := upload("checkpoint.bin", "application/octet-stream", content); recorder.Code != 201 {
+			t.Fatalf("expected status 201, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if uploadedContent != content || len(storage.redactions) != 1 {
+			t.Fatalf("expected the binary artifact not to be redacted, got %q and %+v", uploadedContent, storage.redactions)
+		}
+	})
+
+	t.Run("the messages of status events are redacted and audited", func(t *testing.T) {
+		body := `{"benchmark_status_event":{"provider_id":"lm_evaluation_harness","id":"toxigen","benchmark_index":0,"status":"failed","error_message":{"message":"failed on the prompt of bob@example.com","message_code":"ADAPTER_FAIL"}}}`
+		recorder := httptest.NewRecorder()
+		h.HandleUpdateEvaluation(ctx, &baselineRequest{
+			MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs/job-redaction/events"),
+			body:        []byte(body),
+			path:        map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-redaction"},
+		}, MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 204 {
+			t.Fatalf("expected status 204, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if storage.lastStatus == nil || storage.lastStatus.BenchmarkStatusEvent.ErrorMessage.Message != "failed on the prompt of [REDACTED:email]" {
+			t.Fatalf("expected the stored message to be redacted, got %+v", storage.lastStatus)
+		}
+		if len(storage.redactions) != 2 || storage.redactions[1].Target != api.RedactionTargetErrorMessage {
+			t.Fatalf("unexpected redactions %+v", storage.redactions)
+		}
+	})
+
+	t.Run("the redactions of a job are listed", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		h.HandleListEvaluationRedactions(ctx, &baselineRequest{
+			MockRequest: createMockRequest("GET", "/api/v1/evaluations/jobs/job-redaction/redactions"),
+			path:        map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-redaction"},
+		}, MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		list := api.RedactionRecordList{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &list); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if list.TotalCount != 2 || len(list.Items) != 2 {
+			t.Fatalf("expected the two redactions, got %+v", list)
+		}
+	})
+}
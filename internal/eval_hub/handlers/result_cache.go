@@ -0,0 +1,225 @@
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/metrics"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// resultCacheKey identifies the evaluation of a benchmark against a model. Anything that
+// can change the metrics is part of the key, including the adapter images of the provider
+// so that a new adapter version runs the benchmarks again; weights and pass criteria are
+// not, as the test result is computed again for every job.
+func resultCacheKey(model api.ModelRef, rag *api.RAGConfig, benchmark api.EvaluationBenchmarkConfig, adapterImages map[string]string) (string, error) {
+	key, err := json.Marshal(struct {
+		ModelURL        string                  `json:"model_url"`
+		ModelName       string                  `json:"model_name"`
+		ModelParameters map[string]any          `json:"model_parameters,omitempty"`
+		ProviderID      string                  `json:"provider_id"`
+		BenchmarkID     string                  `json:"benchmark_id"`
+		Parameters      map[string]any          `json:"parameters,omitempty"`
+		TestDataRef     *api.TestDataRef        `json:"test_data_ref,omitempty"`
+		Conversation    *api.ConversationConfig `json:"conversation,omitempty"`
+		RAG             *api.RAGConfig          `json:"rag,omitempty"`
+		AdapterImages   map[string]string       `json:"adapter_images,omitempty"`
+	}{
+		ModelURL:        model.URL,
+		ModelName:       model.Name,
+		ModelParameters: model.Parameters,
+		ProviderID:      benchmark.ProviderID,
+		BenchmarkID:     benchmark.ID,
+		Parameters:      benchmark.Parameters,
+		TestDataRef:     benchmark.TestDataRef,
+		Conversation:    benchmark.Conversation,
+		RAG:             rag,
+		AdapterImages:   adapterImages,
+	})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// adapterImageResolver returns the adapter images of the provider of a benchmark, by
+// runtime, reading each provider once.
+func adapterImageResolver(storage abstractions.Storage) func(providerID string) (map[string]string, error) {
+	resolved := map[string]map[string]string{}
+	return func(providerID string) (map[string]string, error) {
+		if images, ok := resolved[providerID]; ok {
+			return images, nil
+		}
+		provider, err := storage.GetProvider(providerID)
+		if err != nil {
+			return nil, err
+		}
+		var images map[string]string
+		if provider != nil && provider.Runtime != nil {
+			images = map[string]string{}
+			if provider.Runtime.K8s != nil && provider.Runtime.K8s.Image != "" {
+				images[api.RuntimeKubernetes] = provider.Runtime.K8s.Image
+			}
+			if provider.Runtime.Local != nil && provider.Runtime.Local.Image != "" {
+				images[api.RuntimeLocal] = provider.Runtime.Local.Image
+			}
+		}
+		resolved[providerID] = images
+		return images, nil
+	}
+}
+
+// reuseCachedResults completes the benchmarks of a new job that opted in with
+// reuse_cached_results from the cached results of earlier jobs, and returns the job
+// as stored afterwards. Cache failures are logged and the benchmark is run as usual.
+func (h *Handlers) reuseCachedResults(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, job *api.EvaluationJobResource, collection *api.CollectionResource) *api.EvaluationJobResource {
+	if !job.ReuseCachedResults || h.serviceConfig == nil || !h.serviceConfig.ResultCache.IsEnabled() {
+		return job
+	}
+	benchmarks, err := GetJobBenchmarks(job, collection)
+	if err != nil {
+		return job
+	}
+	if err := applyCollectionDefaultParameters(storage, job, benchmarks); err != nil {
+		ctx.Logger.Warn("Failed to apply the default parameters for the result cache", "error", err, "job_id", job.Resource.ID)
+		return job
+	}
+
+	since := time.Now().Add(-h.serviceConfig.ResultCache.EffectiveTTL())
+	adapterImages := adapterImageResolver(storage)
+	reused := 0
+	for i, benchmark := range benchmarks {
+		logger := ctx.Logger.With("job_id", job.Resource.ID, "benchmark_id", benchmark.ID, "benchmark_index", i, "provider_id", benchmark.ProviderID)
+
+		images, err := adapterImages(benchmark.ProviderID)
+		if err != nil {
+			logger.Warn("Failed to resolve the adapter images for the result cache", "error", err)
+			continue
+		}
+		key, err := resultCacheKey(job.Model, job.RAG, benchmark, images)
+		if err != nil {
+			logger.Warn("Failed to compute the result cache key", "error", err)
+			continue
+		}
+		entry, err := storage.GetCachedBenchmarkResult(key, since)
+		if err != nil {
+			logger.Warn("Failed to look up the result cache", "error", err)
+			continue
+		}
+		metrics.RecordResultCacheLookup(ctx.Ctx, benchmark.ProviderID, entry != nil)
+		if entry == nil {
+			continue
+		}
+
+		now := api.DateTimeToString(time.Now())
+		_, err = storage.UpdateEvaluationJob(job.Resource.ID, &api.StatusEvent{
+			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+				ProviderID:     benchmark.ProviderID,
+				ID:             benchmark.ID,
+				BenchmarkIndex: i,
+				Status:         api.StateCompleted,
+				Phase:          api.JobPhaseCompleted,
+				Metrics:        entry.Result.Metrics,
+				AdditionalInfo: entry.Result.AdditionalInfo,
+				Artifacts:      entry.Result.Artifacts,
+				MLFlowRunID:    entry.Result.MLFlowRunID,
+				MLFlowRunURL:   entry.Result.MLFlowRunURL,
+				LogsPath:       entry.Result.LogsPath,
+				StartedAt:      now,
+				CompletedAt:    now,
+				CachedFrom:     entry.JobID,
+			},
+		})
+		if err != nil {
+			logger.Warn("Failed to reuse the cached benchmark result", "error", err, "cached_from", entry.JobID)
+			continue
+		}
+		logger.Info("Reused cached benchmark result", "cached_from", entry.JobID, "cached_at", entry.CompletedAt)
+		reused++
+	}
+
+	if reused == 0 {
+		return job
+	}
+	stored, err := storage.GetEvaluationJob(job.Resource.ID)
+	if err != nil {
+		ctx.Logger.Warn("Failed to reload the evaluation job after reusing cached results", "error", err, "job_id", job.Resource.ID)
+		return job
+	}
+	return stored
+}
+
+// cacheBenchmarkResults records the completed results of a finished job in the result
+// cache. Results that were themselves reused are not recorded again, so that a cache
+// entry never outlives the TTL of the run that produced it.
+func (h *Handlers) cacheBenchmarkResults(ctx context.Context, storage abstractions.Storage, job *api.EvaluationJobResource, logger *slog.Logger) {
+	if h.serviceConfig == nil || !h.serviceConfig.ResultCache.IsEnabled() || job.Results == nil || len(job.Results.Benchmarks) == 0 {
+		return
+	}
+	if logger == nil {
+		logger = slog.New(slog.DiscardHandler)
+	}
+	benchmarks, err := h.resolveJobBenchmarksForStorage(storage, job)
+	if err == nil {
+		err = applyCollectionDefaultParameters(storage, job, benchmarks)
+	}
+	if err != nil {
+		logger.WarnContext(ctx, "failed to resolve benchmarks for the result cache", "job_id", job.Resource.ID, "error", err)
+		return
+	}
+
+	adapterImages := adapterImageResolver(storage)
+	for _, result := range job.Results.Benchmarks {
+		if result.Cached || result.BenchmarkIndex < 0 || result.BenchmarkIndex >= len(benchmarks) {
+			continue
+		}
+		completedAt, ok := completedBenchmarkTime(job, result.BenchmarkIndex)
+		if !ok {
+			continue
+		}
+		benchmark := benchmarks[result.BenchmarkIndex]
+		images, err := adapterImages(benchmark.ProviderID)
+		if err != nil {
+			logger.WarnContext(ctx, "failed to resolve the adapter images for the result cache", "job_id", job.Resource.ID, "benchmark_id", result.ID, "error", err)
+			continue
+		}
+		key, err := resultCacheKey(job.Model, job.RAG, benchmark, images)
+		if err != nil {
+			logger.WarnContext(ctx, "failed to compute the result cache key", "job_id", job.Resource.ID, "benchmark_id", result.ID, "error", err)
+			continue
+		}
+		if err := storage.PutCachedBenchmarkResult(&abstractions.CachedBenchmarkResult{
+			Key:         key,
+			JobID:       job.Resource.ID,
+			CompletedAt: completedAt,
+			Result:      result,
+		}); err != nil {
+			logger.WarnContext(ctx, "failed to cache the benchmark result", "job_id", job.Resource.ID, "benchmark_id", result.ID, "error", err)
+		}
+	}
+}
+
+// completedBenchmarkTime returns when the benchmark at benchmarkIndex completed, if it did.
+func completedBenchmarkTime(job *api.EvaluationJobResource, benchmarkIndex int) (time.Time, bool) {
+	if job.Status == nil {
+		return time.Time{}, false
+	}
+	for _, benchmark := range job.Status.Benchmarks {
+		if benchmark.BenchmarkIndex != benchmarkIndex || benchmark.Status != api.StateCompleted {
+			continue
+		}
+		completedAt, err := api.DateTimeFromString(benchmark.CompletedAt)
+		if err != nil {
+			return time.Now(), true
+		}
+		return completedAt, true
+	}
+	return time.Time{}, false
+}
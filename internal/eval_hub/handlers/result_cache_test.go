@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type resultCacheTestStorage struct {
+	noopStorage
+	job     *api.EvaluationJobResource
+	cached  map[string]*abstractions.CachedBenchmarkResult
+	updates []*api.BenchmarkStatusEvent
+	put     []*abstractions.CachedBenchmarkResult
+	image   string
+}
+
+func (s *resultCacheTestStorage) GetProvider(id string) (*api.ProviderResource, error) {
+	return &api.ProviderResource{
+		Resource:       api.Resource{ID: id},
+		ProviderConfig: api.ProviderConfig{Runtime: &api.Runtime{K8s: &api.K8sRuntime{Image: s.image}}},
+	}, nil
+}
+
+func (s *resultCacheTestStorage) GetEvaluationJob(_ string) (*api.EvaluationJobResource, error) {
+	return s.job, nil
+}
+
+func (s *resultCacheTestStorage) UpdateEvaluationJob(_ string, runStatus *api.StatusEvent) (*abstractions.EvaluationJobUpdate, error) {
+	s.updates = append(s.updates, runStatus.BenchmarkStatusEvent)
+	return &abstractions.EvaluationJobUpdate{}, nil
+}
+
+func (s *resultCacheTestStorage) GetCachedBenchmarkResult(key string, _ time.Time) (*abstractions.CachedBenchmarkResult, error) {
+	return s.cached[key], nil
+}
+
+func (s *resultCacheTestStorage) PutCachedBenchmarkResult(entry *abstractions.CachedBenchmarkResult) error {
+	s.put = append(s.put, entry)
+	return nil
+}
+
+func resultCacheTestJob() *api.EvaluationJobResource {
+	return &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-2"}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePending}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Name:  "nightly",
+			Model: api.ModelRef{URL: "http://model", Name: "model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{
+				{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness", Parameters: map[string]any{"num_fewshot": 5}},
+				{Ref: api.Ref{ID: "hellaswag"}, ProviderID: "lm_evaluation_harness"},
+			},
+			ReuseCachedResults: true,
+		},
+	}
+}
+
+func TestResultCacheKey(t *testing.T) {
+	model := api.ModelRef{URL: "http://model", Name: "model"}
+	benchmark := api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness", Parameters: map[string]any{"num_fewshot": 5, "limit": 100}}
+	key, err := resultCacheKey(model, nil, benchmark, nil)
+	if err != nil {
+		t.Fatalf("resultCacheKey: %v", err)
+	}
+
+	reweighted := benchmark
+	reweighted.Weight = 2
+	if other, _ := resultCacheKey(model, nil, reweighted, nil); other != key {
+		t.Errorf("expected the weight not to change the key")
+	}
+	reparameterized := benchmark
+	reparameterized.Parameters = map[string]any{"num_fewshot": 0, "limit": 100}
+	if other, _ := resultCacheKey(model, nil, reparameterized, nil); other == key {
+		t.Errorf("expected the parameters to change the key")
+	}
+	if other, _ := resultCacheKey(api.ModelRef{URL: "http://model", Name: "model-v2"}, nil, benchmark, nil); other == key {
+		t.Errorf("expected the model to change the key")
+	}
+	rag := &api.RAGConfig{Retrieval: api.RetrievalConfig{URL: "http://retriever", TopK: 5}}
+	if other, _ := resultCacheKey(model, rag, benchmark, nil); other == key {
+		t.Errorf("expected the rag config to change the key")
+	}
+	if other, _ := resultCacheKey(model, nil, benchmark, map[string]string{api.RuntimeKubernetes: "quay.io/eval-hub/lm-eval:v2"}); other == key {
+		t.Errorf("expected the adapter image to change the key")
+	}
+}
+
+func TestReuseCachedResults(t *testing.T) {
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-cache", logging.FallbackLogger(), "test-user", "test-tenant")
+	enabled := &config.Config{ResultCache: &config.ResultCacheConfig{Enabled: true}}
+
+	newStorage := func(t *testing.T, job *api.EvaluationJobResource) *resultCacheTestStorage {
+		t.Helper()
+		key, err := resultCacheKey(job.Model, job.RAG, job.Benchmarks[0], map[string]string{api.RuntimeKubernetes: "quay.io/eval-hub/lm-eval:v1"})
+		if err != nil {
+			t.Fatalf("resultCacheKey: %v", err)
+		}
+		return &resultCacheTestStorage{
+			image: "quay.io/eval-hub/lm-eval:v1",
+			job:   &api.EvaluationJobResource{Resource: job.Resource},
+			cached: map[string]*abstractions.CachedBenchmarkResult{
+				key: {Key: key, JobID: "job-1", Result: api.BenchmarkResult{ID: "mmlu", Metrics: map[string]any{"accuracy": 0.7}}},
+			},
+		}
+	}
+
+	t.Run("a cached result completes the benchmark", func(t *testing.T) {
+		job := resultCacheTestJob()
+		storage := newStorage(t, job)
+		h := &Handlers{serviceConfig: enabled}
+
+		stored := h.reuseCachedResults(ctx, storage, job, nil)
+		if stored != storage.job {
+			t.Fatalf("expected the job to be reloaded after reusing a result")
+		}
+		if len(storage.updates) != 1 {
+			t.Fatalf("expected one benchmark to be completed from the cache, got %d", len(storage.updates))
+		}
+		update := storage.updates[0]
+		if update.ID != "mmlu" || update.BenchmarkIndex != 0 || update.Status != api.StateCompleted ||
+			update.CachedFrom != "job-1" || update.Metrics["accuracy"] != 0.7 {
+			t.Fatalf("unexpected status update %+v", update)
+		}
+	})
+
+	t.Run("results of another adapter image are not reused", func(t *testing.T) {
+		job := resultCacheTestJob()
+		storage := newStorage(t, job)
+		storage.image = "quay.io/eval-hub/lm-eval:v2"
+		h := &Handlers{serviceConfig: enabled}
+
+		if stored := h.reuseCachedResults(ctx, storage, job, nil); stored != job || len(storage.updates) != 0 {
+			t.Fatalf("expected the job to run without cached results")
+		}
+	})
+
+	t.Run("jobs reuse results only when they opt in", func(t *testing.T) {
+		job := resultCacheTestJob()
+		job.ReuseCachedResults = false
+		storage := newStorage(t, job)
+		h := &Handlers{serviceConfig: enabled}
+
+		if stored := h.reuseCachedResults(ctx, storage, job, nil); stored != job || len(storage.updates) != 0 {
+			t.Fatalf("expected the job to run without cached results")
+		}
+	})
+
+	t.Run("nothing is reused when the cache is disabled", func(t *testing.T) {
+		job := resultCacheTestJob()
+		storage := newStorage(t, job)
+		h := &Handlers{serviceConfig: &config.Config{}}
+
+		if stored := h.reuseCachedResults(ctx, storage, job, nil); stored != job || len(storage.updates) != 0 {
+			t.Fatalf("expected the job to run without cached results")
+		}
+	})
+}
+
+func TestCacheBenchmarkResults(t *testing.T) {
+	job := resultCacheTestJob()
+	job.Status = &api.EvaluationJobStatus{
+		EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePartiallyFailed},
+		Benchmarks: []api.BenchmarkStatus{
+			{ID: "mmlu", BenchmarkIndex: 0, Status: api.StateCompleted, CompletedAt: "2026-01-02T03:04:05Z"},
+			{ID: "hellaswag", BenchmarkIndex: 1, Status: api.StateFailed},
+		},
+	}
+	job.Results = &api.EvaluationJobResults{Benchmarks: []api.BenchmarkResult{
+		{ID: "mmlu", BenchmarkIndex: 0, Metrics: map[string]any{"accuracy": 0.7}},
+		{ID: "hellaswag", BenchmarkIndex: 1},
+	}}
+	storage := &resultCacheTestStorage{image: "quay.io/eval-hub/lm-eval:v1"}
+	h := &Handlers{serviceConfig: &config.Config{ResultCache: &config.ResultCacheConfig{Enabled: true}}}
+
+	h.cacheBenchmarkResults(context.Background(), storage, job, nil)
+	if len(storage.put) != 1 {
+		t.Fatalf("expected only the completed benchmark to be cached, got %d entries", len(storage.put))
+	}
+	entry := storage.put[0]
+	key, _ := resultCacheKey(job.Model, job.RAG, job.Benchmarks[0], map[string]string{api.RuntimeKubernetes: "quay.io/eval-hub/lm-eval:v1"})
+	if entry.Key != key || entry.JobID != "job-2" || entry.Result.ID != "mmlu" ||
+		!entry.CompletedAt.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)) {
+		t.Fatalf("unexpected cache entry %+v", entry)
+	}
+
+	storage.put = nil
+	job.Results.Benchmarks[0].Cached = true
+	h.cacheBenchmarkResults(context.Background(), storage, job, nil)
+	if len(storage.put) != 0 {
+		t.Fatalf("expected a reused result not to be cached again")
+	}
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serialization"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// isReviewer returns true when the user of the request is a member of a reviewer group, who
+// reviews every job of the tenant.
+func (h *Handlers) isReviewer(ctx *executioncontext.ExecutionContext) bool {
+	return h.serviceConfig != nil && h.serviceConfig.JobAccess.IsReviewer(ctx.Groups)
+}
+
+// checkReviewAccess returns an error when the user of the request cannot review the job.
+func (h *Handlers) checkReviewAccess(ctx *executioncontext.ExecutionContext, job *api.EvaluationJobResource) error {
+	if !h.isReviewer(ctx) {
+		if err := h.checkJobAccess(ctx, job, jobAccessRead); err != nil {
+			return err
+		}
+		if h.serviceConfig != nil && h.serviceConfig.JobAccess.HasReviewerGroups() {
+			return serviceerrors.NewServiceError(messages.ReviewAccessDenied, "User", ctx.User, "EvaluationJobID", job.Resource.ID, "Reason", "only the members of the reviewer groups can review jobs")
+		}
+	}
+	if ctx.User != "" && job.Resource.Owner == ctx.User {
+		return serviceerrors.NewServiceError(messages.ReviewAccessDenied, "User", ctx.User, "EvaluationJobID", job.Resource.ID, "Reason", "the owner of a job cannot review it")
+	}
+	return nil
+}
+
+// HandleListReviews handles GET /api/v1/evaluations/reviews, the reviews of the jobs of the
+// tenant in the state of the state parameter, pending by default.
+func (h *Handlers) HandleListReviews(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	filter, err := h.reviewsFilter(ctx, req)
+	logging.LogRequestStarted(ctx, "filter", filter)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			res, err := storage.WithContext(runtimeCtx).GetEvaluationJobs(filter)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			page, err := CreatePage(ctx, res.TotalCount, filter.Offset, filter.Limit, req)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			items := make([]api.ReviewResource, 0, len(res.Items))
+			for i := range res.Items {
+				if review := api.NewReviewResource(&res.Items[i]); review != nil {
+					items = append(items, *review)
+				}
+			}
+			result := api.ReviewResourceList{
+				Page:  *page,
+				Items: items,
+			}
+			w.WriteJSON(result, 200, "count", strconv.Itoa(len(items)), "total_count", strconv.Itoa(res.TotalCount))
+			return nil
+		},
+		"storage",
+		"list-reviews",
+	)
+}
+
+// reviewsFilter returns the filter of the jobs with a review from the query parameters. The
+// members of the reviewer groups see the reviews of every job of the tenant, other users the
+// reviews of the jobs they can read.
+func (h *Handlers) reviewsFilter(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper) (*abstractions.QueryFilter, error) {
+	allowedParams := []string{"limit", "offset", "state"}
+	if badParams := getAllParams(req, allowedParams...); len(badParams) > 0 {
+		// just report the first bad parameter
+		return nil, serviceerrors.NewServiceError(messages.QueryBadParameter, "ParameterName", badParams[0], "AllowedParameters", strings.Join(allowedParams, ", "))
+	}
+	common, err := CommonListFilters(req)
+	if err != nil {
+		return nil, err
+	}
+	filter := &abstractions.QueryFilter{Limit: common.Limit, Offset: common.Offset, Params: map[string]any{}}
+
+	state, err := GetParam(req, "state", true, string(api.ReviewStatePending))
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains([]api.ReviewState{api.ReviewStatePending, api.ReviewStateApproved, api.ReviewStateRejected}, api.ReviewState(state)) {
+		return nil, serviceerrors.NewServiceError(messages.QueryParameterInvalid, "ParameterName", "state", "Type", "review state (pending, approved or rejected)", "Value", state)
+	}
+	filter.Params["review"] = state
+
+	if !h.isReviewer(ctx) {
+		h.addJobVisibilityFilter(ctx, filter.Params)
+	}
+	return filter, nil
+}
+
+// HandleReviewEvaluationJob handles POST /api/v1/evaluations/jobs/{id}/review, the decision
+// of a reviewer on the pending review of a job.
+func (h *Handlers) HandleReviewEvaluationJob(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	evaluationJobID := r.PathValue(constants.PATH_PARAMETER_JOB_ID)
+	if evaluationJobID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_JOB_ID), ctx.RequestID)
+		return
+	}
+
+	decision := &api.ReviewDecision{}
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			bodyBytes, err := r.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			return serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, decision)
+		},
+		"validation",
+		"validate-evaluation-job-review",
+		"job.id", evaluationJobID,
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			scoped := storage.WithContext(runtimeCtx)
+			job, err := scoped.GetEvaluationJob(evaluationJobID)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if err := h.checkReviewAccess(ctx, job); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			job, err = scoped.ReviewEvaluationJob(evaluationJobID, ctx.User, decision)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			ctx.Logger.Info("Evaluation job reviewed", "id", evaluationJobID, "decision", decision.Decision)
+			w.WriteJSON(localizeJobMessages(ctx, job), 200)
+			return nil
+		},
+		"storage",
+		"review-evaluation-job",
+		"job.id", evaluationJobID,
+	)
+}
@@ -0,0 +1,134 @@
+package handlers_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// reviewTestStorage records the decisions of the reviews.
+type reviewTestStorage struct {
+	*jobAccessTestStorage
+	reviewer api.User
+	decision *api.ReviewDecision
+}
+
+func (s *reviewTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *reviewTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *reviewTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *reviewTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *reviewTestStorage) ReviewEvaluationJob(id string, reviewer api.User, decision *api.ReviewDecision) (*api.EvaluationJobResource, error) {
+	s.reviewer, s.decision = reviewer, decision
+	return s.jobs[id], nil
+}
+
+func newReviewTestHandlers(t *testing.T, reviewerGroups ...string) (*handlers.Handlers, *reviewTestStorage) {
+	t.Helper()
+	storage := &reviewTestStorage{jobAccessTestStorage: &jobAccessTestStorage{
+		jobs: map[string]*api.EvaluationJobResource{
+			"job-1": {
+				Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1", Owner: "alice"}},
+				Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateCompleted}},
+				Results: &api.EvaluationJobResults{Test: &api.EvaluationTest{
+					Score:     0.72,
+					Threshold: 0.7,
+					Review:    &api.Review{State: api.ReviewStatePending, Band: 0.05, ScorePass: true},
+				}},
+				EvaluationJobConfig: api.EvaluationJobConfig{
+					SharedWith: &api.JobSharing{Users: []api.User{"bob"}},
+				},
+			},
+		},
+	}}
+	serviceConfig := &config.Config{JobAccess: &config.JobAccessConfig{OwnerScoped: true, AdminGroups: []string{"eval-admins"}, ReviewerGroups: reviewerGroups}}
+	return handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, serviceConfig, nil), storage
+}
+
+func TestHandleReviewEvaluationJob(t *testing.T) {
+	review := func(h *handlers.Handlers, ctx *executioncontext.ExecutionContext, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		h.HandleReviewEvaluationJob(ctx, &baselineRequest{
+			MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs/job-1/review"),
+			path:        map[string]string{constants.PATH_PARAMETER_JOB_ID: "job-1"},
+			body:        []byte(body),
+		}, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	t.Run("a user the job is shared with reviews it", func(t *testing.T) {
+		h, storage := newReviewTestHandlers(t)
+		if recorder := review(h, jobAccessContext("bob"), `{"decision": "approved"}`); recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if storage.reviewer != "bob" || storage.decision.Decision != api.ReviewStateApproved {
+			t.Fatalf("expected bob to approve the job, got %s %+v", storage.reviewer, storage.decision)
+		}
+	})
+
+	t.Run("the owner cannot review the job", func(t *testing.T) {
+		h, storage := newReviewTestHandlers(t)
+		if recorder := review(h, jobAccessContext("alice"), `{"decision": "approved"}`); recorder.Code != 403 || !strings.Contains(recorder.Body.String(), "review_access_denied") {
+			t.Fatalf("expected review_access_denied, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if storage.decision != nil {
+			t.Fatalf("expected no decision, got %+v", storage.decision)
+		}
+	})
+
+	t.Run("only the reviewer groups review jobs when they are set", func(t *testing.T) {
+		h, _ := newReviewTestHandlers(t, "eval-reviewers")
+		if recorder := review(h, jobAccessContext("bob"), `{"decision": "approved"}`); recorder.Code != 403 {
+			t.Fatalf("expected status 403 for a user outside the reviewer groups, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		// the job is not shared with carol, a reviewer reviews every job of the tenant
+		if recorder := review(h, jobAccessContext("carol", "eval-reviewers"), `{"decision": "rejected", "comment": "noisy"}`); recorder.Code != 200 {
+			t.Fatalf("expected status 200 for a reviewer, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("a rejection needs a comment", func(t *testing.T) {
+		h, _ := newReviewTestHandlers(t)
+		if recorder := review(h, jobAccessContext("bob"), `{"decision": "rejected"}`); recorder.Code != 400 {
+			t.Fatalf("expected status 400, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+}
+
+func TestHandleListReviews(t *testing.T) {
+	list := func(h *handlers.Handlers, ctx *executioncontext.ExecutionContext, query map[string][]string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		h.HandleListReviews(ctx, &baselineRequest{
+			MockRequest: createMockRequest("GET", "/api/v1/evaluations/reviews"),
+			query:       query,
+		}, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	h, storage := newReviewTestHandlers(t, "eval-reviewers")
+	if recorder := list(h, jobAccessContext("bob"), nil); recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if storage.filter.Params["review"] != "pending" || storage.filter.Params["visible_to"] == nil {
+		t.Errorf("expected the pending reviews of the jobs bob can read, got %v", storage.filter.Params)
+	}
+	if recorder := list(h, jobAccessContext("carol", "eval-reviewers"), map[string][]string{"state": {"approved"}}); recorder.Code != 200 {
+		t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if _, scoped := storage.filter.Params["visible_to"]; storage.filter.Params["review"] != "approved" || scoped {
+		t.Errorf("expected the approved reviews of every job for a reviewer, got %v", storage.filter.Params)
+	}
+	if recorder := list(h, jobAccessContext("carol", "eval-reviewers"), map[string][]string{"state": {"done"}}); recorder.Code != 400 {
+		t.Errorf("expected status 400 for an unknown state, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -9,6 +9,16 @@ import (
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
+// storageRuntimeStorage passes the status updates of a runtime to a test storage.
+type storageRuntimeStorage struct {
+	abstractions.Storage
+}
+
+func (s storageRuntimeStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent) error {
+	_, err := s.Storage.UpdateEvaluationJob(id, runStatus)
+	return err
+}
+
 // noopStorage is a minimal abstractions.Storage for handler unit tests.
 type noopStorage struct{}
 
@@ -29,11 +39,17 @@ func (noopStorage) GetEvaluationJobs(_ *abstractions.QueryFilter) (*abstractions
 	return &abstractions.QueryResults[api.EvaluationJobResource]{}, nil
 }
 func (noopStorage) DeleteEvaluationJob(_ string) error { return nil }
-func (noopStorage) UpdateEvaluationJob(_ string, _ *api.StatusEvent) error {
-	return nil
+func (noopStorage) UpdateEvaluationJob(_ string, _ *api.StatusEvent) (*abstractions.EvaluationJobUpdate, error) {
+	return &abstractions.EvaluationJobUpdate{}, nil
 }
-func (noopStorage) UpdateEvaluationJobStatus(_ string, _ api.OverallState, _ *api.MessageInfo) error {
-	return nil
+func (noopStorage) PatchEvaluationJob(_ string, _ *api.Patch) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (noopStorage) UpdateEvaluationJobOwner(_ string, _ api.User) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (noopStorage) UpdateEvaluationJobStatus(_ string, _ api.OverallState, _ *api.MessageInfo) (api.OverallState, error) {
+	return "", nil
 }
 func (noopStorage) CreateCollection(_ *api.CollectionResource) error { return nil }
 func (noopStorage) GetCollection(_ string) (*api.CollectionResource, error) {
@@ -63,6 +79,98 @@ func (noopStorage) PatchProvider(_ string, _ *api.Patch) (*api.ProviderResource,
 	return nil, nil
 }
 func (noopStorage) DeleteProvider(_ string) error { return nil }
+func (noopStorage) CreateBaseline(_ *api.BaselineResource) error {
+	return nil
+}
+func (noopStorage) GetBaseline(_ string) (*api.BaselineResource, error) {
+	return nil, nil
+}
+func (noopStorage) GetBaselines(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.BaselineResource], error) {
+	return nil, nil
+}
+func (noopStorage) DeleteBaseline(_ string) error { return nil }
+func (noopStorage) GetEvaluationJobFindings(_ string, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.FindingResource], error) {
+	return &abstractions.QueryResults[api.FindingResource]{}, nil
+}
+func (noopStorage) GetEvaluationMetricsHistory(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.MetricPoint], error) {
+	return &abstractions.QueryResults[api.MetricPoint]{}, nil
+}
+func (noopStorage) GetEvaluationMetricNames(_ *abstractions.QueryFilter) ([]string, error) {
+	return nil, nil
+}
+func (noopStorage) PutEvaluationJobArtifact(_ string, _ *api.ArtifactResource) error { return nil }
+func (noopStorage) GetEvaluationJobArtifacts(_ string, _ int, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.ArtifactResource], error) {
+	return &abstractions.QueryResults[api.ArtifactResource]{}, nil
+}
+func (noopStorage) PutEvaluationJobGeneration(_ string, _ *api.ArtifactResource) error {
+	return nil
+}
+func (noopStorage) GetEvaluationJobGeneration(_ string, _ int, _ string) (*api.ArtifactResource, error) {
+	return nil, nil
+}
+func (noopStorage) GetEvaluationJobGenerations(_ string, _ int, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.ArtifactResource], error) {
+	return &abstractions.QueryResults[api.ArtifactResource]{}, nil
+}
+func (noopStorage) ReviewEvaluationJob(_ string, _ api.User, _ *api.ReviewDecision) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (noopStorage) UpdateEvaluationJobModelMetadata(_ string, _ *api.ModelMetadata) error { return nil }
+func (noopStorage) LinkEvaluationJobExperiment(_ string, _ string, _ string) error        { return nil }
+func (noopStorage) GetEvaluationJobsWithoutExperiment(_ int) ([]api.Resource, error)      { return nil, nil }
+func (noopStorage) UpdateEvaluationJobBenchmarkPlacement(_ string, _ int, _ *api.BenchmarkPlacement) error {
+	return nil
+}
+func (noopStorage) PinEvaluationJobAdapterImage(_ string, _ string, pinned string) (string, error) {
+	return pinned, nil
+}
+func (noopStorage) AddEvaluationJobBenchmarkMetrics(_ string, _ map[int]map[string]any) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (noopStorage) PutTagPolicy(_ *api.TagPolicyResource) error             { return nil }
+func (noopStorage) GetTagPolicy() (*api.TagPolicyResource, error)           { return nil, nil }
+func (noopStorage) PutRedactionPolicy(_ *api.RedactionPolicyResource) error { return nil }
+func (noopStorage) GetRedactionPolicy() (*api.RedactionPolicyResource, error) {
+	return nil, nil
+}
+func (noopStorage) PutAdminConfig(_ *api.AdminConfig) error                      { return nil }
+func (noopStorage) GetAdminConfig() (*api.AdminConfig, error)                    { return nil, nil }
+func (noopStorage) PutProviderHealth(_ *abstractions.ProviderHealthRecord) error { return nil }
+func (noopStorage) GetProviderHealthRecords() ([]abstractions.ProviderHealthRecord, error) {
+	return nil, nil
+}
+func (noopStorage) DeleteProviderHealth(_ string) error                              { return nil }
+func (noopStorage) AddEvaluationJobRedaction(_ string, _ *api.RedactionRecord) error { return nil }
+func (noopStorage) GetEvaluationJobRedactions(_ string, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.RedactionRecord], error) {
+	return &abstractions.QueryResults[api.RedactionRecord]{}, nil
+}
+func (noopStorage) AddStatusDeadLetter(_ *api.StatusDeadLetter) error { return nil }
+func (noopStorage) GetStatusDeadLetter(_ string) (*api.StatusDeadLetter, error) {
+	return nil, nil
+}
+func (noopStorage) GetStatusDeadLetters(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.StatusDeadLetter], error) {
+	return &abstractions.QueryResults[api.StatusDeadLetter]{}, nil
+}
+func (noopStorage) DeleteStatusDeadLetter(_ string) error                    { return nil }
+func (noopStorage) CreateEvaluationView(_ *api.EvaluationViewResource) error { return nil }
+func (noopStorage) GetEvaluationView(_ string) (*api.EvaluationViewResource, error) {
+	return nil, nil
+}
+func (noopStorage) GetEvaluationViews(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.EvaluationViewResource], error) {
+	return nil, nil
+}
+func (noopStorage) UpdateEvaluationView(_ string, _ *api.EvaluationViewConfig) (*api.EvaluationViewResource, error) {
+	return nil, nil
+}
+func (noopStorage) DeleteEvaluationView(_ string) error { return nil }
+func (noopStorage) PutCachedBenchmarkResult(_ *abstractions.CachedBenchmarkResult) error {
+	return nil
+}
+func (noopStorage) GetCachedBenchmarkResult(_ string, _ time.Time) (*abstractions.CachedBenchmarkResult, error) {
+	return nil, nil
+}
+func (noopStorage) GetBenchmarkDurations(_ []abstractions.BenchmarkDurationKey) (map[abstractions.BenchmarkDurationKey]time.Duration, error) {
+	return nil, nil
+}
 func (noopStorage) LoadSystemResources(_ map[string]api.CollectionResource, _ map[string]api.ProviderResource) error {
 	return nil
 }
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// createEvaluationSweep creates and starts a child job for each configuration of the sweep
+// of a validated job, and responds with the sweep. The sweep has no record of its own: it
+// is the set of jobs that carry its ID in their sweep_run.
+func (h *Handlers) createEvaluationSweep(ctx *executioncontext.ExecutionContext, w http_wrappers.ResponseWrapper, storage abstractions.Storage, sweepID string, evaluation *api.EvaluationJobConfig, collection *api.CollectionResource) {
+	configurations := evaluation.Sweep.Configurations()
+	ctx.Logger.Info("Creating evaluation sweep", "sweep_id", sweepID, "strategy", evaluation.Sweep.Strategy, "count", len(configurations))
+
+	jobs := make([]api.EvaluationJobResource, 0, len(configurations))
+	for i, values := range configurations {
+		config := sweepJobConfig(evaluation, sweepID, i, len(configurations), values)
+		job, err := h.createEvaluationJob(ctx, storage, common.GUID(), config, collection)
+		if job == nil {
+			// a sweep is created whole or not at all, so the jobs created so far are deleted
+			h.deleteSweepJobs(ctx, storage, sweepID, jobs)
+			w.Error(err, ctx.RequestID)
+			return
+		}
+		if err != nil {
+			// the job is stored as failed and reported by the sweep
+			ctx.Logger.Warn("Failed to start evaluation sweep job", "sweep_id", sweepID, "job_id", job.Resource.ID, "error", err)
+		}
+		jobs = append(jobs, *job)
+	}
+
+	w.WriteJSON(api.NewEvaluationSweepResource(sweepID, jobs), 202)
+}
+
+// deleteSweepJobs deletes the runtime resources and the records of the jobs of a sweep
+// whose creation failed partway. Failures are logged so that every job gets a chance to
+// be deleted, and the deletion outlives a request that has timed out.
+func (h *Handlers) deleteSweepJobs(ctx *executioncontext.ExecutionContext, storage abstractions.Storage, sweepID string, jobs []api.EvaluationJobResource) {
+	cleanupCtx := context.WithoutCancel(ctx.Ctx)
+	for i := range jobs {
+		job := &jobs[i]
+		if h.runtime != nil && job.Status != nil && !job.Status.State.IsTerminalState() {
+			if err := h.runtime.WithLogger(ctx.Logger).WithContext(cleanupCtx).DeleteEvaluationJobResources(job); err != nil {
+				ctx.Logger.Error("Failed to delete evaluation sweep job runtime resources", "sweep_id", sweepID, "job_id", job.Resource.ID, "error", err)
+			}
+		}
+		if err := storage.WithContext(cleanupCtx).DeleteEvaluationJob(job.Resource.ID); err != nil {
+			ctx.Logger.Error("Failed to delete evaluation sweep job", "sweep_id", sweepID, "job_id", job.Resource.ID, "error", err)
+		}
+	}
+}
+
+// sweepJobConfig returns the config of the index-th child job of a sweep, which has the
+// given values of the sweep parameters.
+func sweepJobConfig(evaluation *api.EvaluationJobConfig, sweepID string, index int, count int, values []any) *api.EvaluationJobConfig {
+	config := *evaluation
+	config.Sweep = nil
+	config.SweepRun = &api.SweepRun{
+		SweepID:    sweepID,
+		Strategy:   evaluation.Sweep.Strategy,
+		Index:      index,
+		Count:      count,
+		Parameters: make(map[string]any, len(values)),
+	}
+	config.Model.Parameters = maps.Clone(evaluation.Model.Parameters)
+	config.Benchmarks = slices.Clone(evaluation.Benchmarks)
+	for i := range config.Benchmarks {
+		config.Benchmarks[i].Parameters = maps.Clone(config.Benchmarks[i].Parameters)
+	}
+
+	for i, parameter := range evaluation.Sweep.Parameters {
+		value := values[i]
+		config.SweepRun.Parameters[parameter.Key()] = value
+		switch parameter.Target {
+		case api.SweepTargetModel:
+			if config.Model.Parameters == nil {
+				config.Model.Parameters = make(map[string]any)
+			}
+			config.Model.Parameters[parameter.Name] = value
+		case api.SweepTargetBenchmark:
+			for j := range config.Benchmarks {
+				if config.Benchmarks[j].Parameters == nil {
+					config.Benchmarks[j].Parameters = make(map[string]any)
+				}
+				config.Benchmarks[j].Parameters[parameter.Name] = value
+			}
+		}
+	}
+	return &config
+}
+
+// HandleGetEvaluationSweep handles GET /api/v1/evaluations/sweeps/{sweep_id}
+func (h *Handlers) HandleGetEvaluationSweep(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	sweepID := r.PathValue(constants.PATH_PARAMETER_SWEEP_ID)
+	if sweepID == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_SWEEP_ID), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			params := map[string]any{"sweep_id": sweepID}
+			h.addJobVisibilityFilter(ctx, params)
+			res, err := storage.WithContext(runtimeCtx).GetEvaluationJobs(&abstractions.QueryFilter{
+				Limit:  api.MaxSweepJobs,
+				Params: params,
+			})
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if len(res.Items) == 0 {
+				err := serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "evaluation sweep", "ResourceId", sweepID)
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(api.NewEvaluationSweepResource(sweepID, res.Items), 200)
+			return nil
+		},
+		"storage",
+		"get-evaluation-sweep",
+		"sweep.id", sweepID,
+	)
+}
@@ -0,0 +1,237 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// sweepTestStorage keeps the jobs created by the handler.
+type sweepTestStorage struct {
+	abstractions.Storage
+	jobs []*api.EvaluationJobResource
+	// createLimit fails the creation of jobs once that many are stored, when set
+	createLimit int
+	deleted     []string
+}
+
+func (s *sweepTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *sweepTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *sweepTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *sweepTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *sweepTestStorage) GetTagPolicy() (*api.TagPolicyResource, error) {
+	return nil, nil
+}
+
+func (s *sweepTestStorage) GetRedactionPolicy() (*api.RedactionPolicyResource, error) {
+	return nil, nil
+}
+
+func (s *sweepTestStorage) GetProvider(id string) (*api.ProviderResource, error) {
+	return &api.ProviderResource{
+		Resource:       api.Resource{ID: id},
+		ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "arc_easy"}}},
+	}, nil
+}
+
+func (s *sweepTestStorage) CreateEvaluationJob(job *api.EvaluationJobResource) error {
+	if s.createLimit > 0 && len(s.jobs) >= s.createLimit {
+		return errors.New("database is unavailable")
+	}
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+func (s *sweepTestStorage) DeleteEvaluationJob(id string) error {
+	s.deleted = append(s.deleted, id)
+	return nil
+}
+
+func (s *sweepTestStorage) UpdateEvaluationJobStatus(_ string, _ api.OverallState, _ *api.MessageInfo) (api.OverallState, error) {
+	return "", nil
+}
+
+func (s *sweepTestStorage) GetEvaluationJobs(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.EvaluationJobResource], error) {
+	items := []api.EvaluationJobResource{}
+	for _, job := range s.jobs {
+		if job.SweepRun != nil && job.SweepRun.SweepID == filter.Params["sweep_id"] {
+			items = append(items, *job)
+		}
+	}
+	return &abstractions.QueryResults[api.EvaluationJobResource]{Items: items, TotalCount: len(items)}, nil
+}
+
+type sweepRequest struct {
+	*MockRequest
+	sweepID string
+}
+
+func (r *sweepRequest) PathValue(name string) string {
+	if name == constants.PATH_PARAMETER_SWEEP_ID {
+		return r.sweepID
+	}
+	return ""
+}
+
+func TestHandleCreateEvaluationSweep(t *testing.T) {
+	ctx := executioncontext.NewExecutionContext(context.Background(), "req-sweep", logging.FallbackLogger(), "test-user", "test-tenant")
+
+	create := func(t *testing.T, body string) (*sweepTestStorage, *handlers.Handlers, *httptest.ResponseRecorder) {
+		t.Helper()
+		storage := &sweepTestStorage{}
+		h := handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, nil, nil)
+		recorder := httptest.NewRecorder()
+		h.HandleCreateEvaluation(ctx, &bodyRequest{
+			MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+			body:        []byte(body),
+		}, MockResponseWrapper{recorder: recorder})
+		return storage, h, recorder
+	}
+
+	t.Run("a grid sweep creates a job per combination", func(t *testing.T) {
+		storage, h, recorder := create(t, `{
+			"name": "prompt-sweep",
+			"model": {"url": "http://test.com", "name": "test", "parameters": {"max_tokens": 256}},
+			"benchmarks": [{"id": "arc_easy", "provider_id": "lm_evaluation_harness", "parameters": {"limit": 10}}],
+			"sweep": {"strategy": "grid", "parameters": [
+				{"target": "model", "name": "temperature", "values": [0, 0.7]},
+				{"target": "benchmark", "name": "prompt_template", "values": ["plain", "cot", "few_shot"]}
+			]}
+		}`)
+
+		if recorder.Code != 202 {
+			t.Fatalf("expected status 202, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		sweep := api.EvaluationSweepResource{}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &sweep); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if sweep.ID == "" || sweep.Strategy != api.SweepStrategyGrid || len(sweep.Jobs) != 6 {
+			t.Fatalf("unexpected sweep %+v", sweep)
+		}
+		if len(storage.jobs) != 6 {
+			t.Fatalf("expected 6 jobs to be stored, got %d", len(storage.jobs))
+		}
+
+		last := storage.jobs[5]
+		if last.Sweep != nil || last.SweepRun == nil || last.SweepRun.SweepID != sweep.ID || last.SweepRun.Index != 5 || last.SweepRun.Count != 6 {
+			t.Fatalf("unexpected sweep run %+v", last.SweepRun)
+		}
+		if last.Model.Parameters["temperature"] != 0.7 || last.Model.Parameters["max_tokens"] != float64(256) {
+			t.Errorf("unexpected model parameters %v", last.Model.Parameters)
+		}
+		if last.Benchmarks[0].Parameters["prompt_template"] != "few_shot" || last.Benchmarks[0].Parameters["limit"] != float64(10) {
+			t.Errorf("unexpected benchmark parameters %v", last.Benchmarks[0].Parameters)
+		}
+		if storage.jobs[0].Model.Parameters["temperature"] != float64(0) || storage.jobs[0].Benchmarks[0].Parameters["prompt_template"] != "plain" {
+			t.Errorf("expected the jobs not to share their parameters, got %v and %v", storage.jobs[0].Model.Parameters, storage.jobs[0].Benchmarks[0].Parameters)
+		}
+
+		t.Run("the sweep reports its jobs and the best configuration", func(t *testing.T) {
+			storage.jobs[1].Status.State = api.OverallStateCompleted
+			storage.jobs[1].Results.Test = &api.EvaluationTest{Score: 0.6}
+			storage.jobs[4].Status.State = api.OverallStateCompleted
+			storage.jobs[4].Results.Test = &api.EvaluationTest{Score: 0.8}
+
+			recorder := httptest.NewRecorder()
+			h.HandleGetEvaluationSweep(ctx, &sweepRequest{MockRequest: createMockRequest("GET", "/api/v1/evaluations/sweeps/"+sweep.ID), sweepID: sweep.ID}, MockResponseWrapper{recorder: recorder})
+			if recorder.Code != 200 {
+				t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+			}
+			got := api.EvaluationSweepResource{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if got.State != api.OverallStateRunning || len(got.Jobs) != 6 {
+				t.Fatalf("unexpected sweep %+v", got)
+			}
+			if got.Best == nil || got.Best.JobID != storage.jobs[4].Resource.ID ||
+				got.Best.Parameters["model.temperature"] != 0.7 || got.Best.Parameters["benchmark.prompt_template"] != "cot" {
+				t.Fatalf("unexpected best configuration %+v", got.Best)
+			}
+		})
+	})
+
+	t.Run("an unknown sweep is not found", func(t *testing.T) {
+		h := handlers.New(&sweepTestStorage{}, testhelpers.NewValidator(t), nil, nil, nil, nil)
+		recorder := httptest.NewRecorder()
+		h.HandleGetEvaluationSweep(ctx, &sweepRequest{MockRequest: createMockRequest("GET", "/api/v1/evaluations/sweeps/unknown"), sweepID: "unknown"}, MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 404 {
+			t.Fatalf("expected status 404, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("a sweep that fails partway deletes the jobs already created", func(t *testing.T) {
+		storage := &sweepTestStorage{createLimit: 2}
+		runtime := &fakeRuntime{}
+		h := handlers.New(storage, testhelpers.NewValidator(t), runtime, nil, nil, nil)
+		recorder := httptest.NewRecorder()
+		h.HandleCreateEvaluation(ctx, &bodyRequest{
+			MockRequest: createMockRequest("POST", "/api/v1/evaluations/jobs"),
+			body: []byte(`{
+				"name": "prompt-sweep",
+				"model": {"url": "http://test.com", "name": "test"},
+				"benchmarks": [{"id": "arc_easy", "provider_id": "lm_evaluation_harness"}],
+				"sweep": {"strategy": "grid", "parameters": [
+					{"target": "model", "name": "temperature", "values": [0, 0.3, 0.7]}
+				]}
+			}`),
+		}, MockResponseWrapper{recorder: recorder})
+
+		if recorder.Code < 400 {
+			t.Fatalf("expected an error status, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if len(storage.jobs) != 2 {
+			t.Fatalf("expected 2 jobs to be created, got %d", len(storage.jobs))
+		}
+		expected := []string{storage.jobs[0].Resource.ID, storage.jobs[1].Resource.ID}
+		if !slices.Equal(storage.deleted, expected) {
+			t.Fatalf("expected jobs %v to be deleted, got %v", expected, storage.deleted)
+		}
+	})
+
+	t.Run("an invalid sweep creates no job", func(t *testing.T) {
+		storage, _, recorder := create(t, `{
+			"name": "prompt-sweep",
+			"model": {"url": "http://test.com", "name": "test"},
+			"benchmarks": [{"id": "arc_easy", "provider_id": "lm_evaluation_harness"}],
+			"sweep": {"strategy": "random", "samples": 5, "parameters": [
+				{"target": "model", "name": "temperature", "values": [0, 0.7]}
+			]}
+		}`)
+		if recorder.Code != 400 {
+			t.Fatalf("expected status 400, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if len(storage.jobs) != 0 {
+			t.Fatalf("expected no job to be stored, got %d", len(storage.jobs))
+		}
+	})
+
+	t.Run("a sweep run sent by the client is ignored", func(t *testing.T) {
+		storage, _, recorder := create(t, `{
+			"name": "not-a-sweep",
+			"model": {"url": "http://test.com", "name": "test"},
+			"benchmarks": [{"id": "arc_easy", "provider_id": "lm_evaluation_harness"}],
+			"sweep_run": {"sweep_id": "someone-elses-sweep"}
+		}`)
+		if recorder.Code != 202 {
+			t.Fatalf("expected status 202, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if len(storage.jobs) != 1 || storage.jobs[0].SweepRun != nil {
+			t.Fatalf("expected one job without a sweep run, got %+v", storage.jobs)
+		}
+	})
+}
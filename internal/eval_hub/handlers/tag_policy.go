@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serialization"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/validation"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// checkTagPolicy returns an error when the tags of a job or collection to create do not follow
+// the tag policy of the tenant.
+func checkTagPolicy(storage abstractions.Storage, resourceType string, tags []string) error {
+	policy, err := storage.GetTagPolicy()
+	if err != nil || policy == nil {
+		return err
+	}
+	return validation.ValidateTags(&policy.TagPolicy, resourceType, tags)
+}
+
+// HandleGetTagPolicy handles GET /api/v1/evaluations/tag-policy, the tag policy of the tenant,
+// empty when it has none.
+func (h *Handlers) HandleGetTagPolicy(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			policy, err := storage.WithContext(runtimeCtx).GetTagPolicy()
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			if policy == nil {
+				policy = &api.TagPolicyResource{Tenant: ctx.Tenant}
+			}
+			w.WriteJSON(policy, 200)
+			return nil
+		},
+		"storage",
+		"get-tag-policy",
+	)
+}
+
+// HandlePutTagPolicy handles PUT /api/v1/evaluations/tag-policy, it replaces the tag policy of
+// the tenant. When admin groups are configured, only their members can change it. The policy
+// applies to the jobs and collections created from then on.
+func (h *Handlers) HandlePutTagPolicy(ctx *executioncontext.ExecutionContext, r http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	if h.serviceConfig != nil && h.serviceConfig.JobAccess.HasAdminGroups() && !h.serviceConfig.JobAccess.IsAdmin(ctx.Groups) {
+		w.Error(serviceerrors.NewServiceError(messages.TagPolicyAccessDenied, "User", ctx.User), ctx.RequestID)
+		return
+	}
+
+	policy := &api.TagPolicy{}
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			bodyBytes, err := r.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			return serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, policy)
+		},
+		"validation",
+		"validate-tag-policy",
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			resource := &api.TagPolicyResource{
+				Tenant:    ctx.Tenant,
+				UpdatedAt: time.Now(),
+				UpdatedBy: ctx.User,
+				TagPolicy: *policy,
+			}
+			if err := storage.WithContext(runtimeCtx).PutTagPolicy(resource); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			ctx.Logger.Info("Tag policy updated", "keys", len(policy.Keys), "required", len(policy.Required))
+			w.WriteJSON(resource, 200)
+			return nil
+		},
+		"storage",
+		"put-tag-policy",
+	)
+}
@@ -0,0 +1,139 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// tagPolicyTestStorage keeps the tag policy in memory.
+type tagPolicyTestStorage struct {
+	*fakeStorage
+	policy *api.TagPolicyResource
+}
+
+func (s *tagPolicyTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *tagPolicyTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *tagPolicyTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *tagPolicyTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *tagPolicyTestStorage) PutTagPolicy(policy *api.TagPolicyResource) error {
+	s.policy = policy
+	return nil
+}
+
+func (s *tagPolicyTestStorage) GetTagPolicy() (*api.TagPolicyResource, error) {
+	return s.policy, nil
+}
+
+func newTagPolicyTestHandlers(t *testing.T) (*handlers.Handlers, *tagPolicyTestStorage) {
+	t.Helper()
+	storage := &tagPolicyTestStorage{fakeStorage: &fakeStorage{
+		providerConfigs: map[string]api.ProviderResource{
+			"p1": {
+				Resource:       api.Resource{ID: "p1"},
+				ProviderConfig: api.ProviderConfig{Benchmarks: []api.BenchmarkResource{{ID: "b1"}}},
+			},
+		},
+	}}
+	serviceConfig := &config.Config{JobAccess: &config.JobAccessConfig{AdminGroups: []string{"eval-admins"}}}
+	return handlers.New(storage, testhelpers.NewValidator(t), &fakeRuntime{}, nil, serviceConfig, nil), storage
+}
+
+func tagPolicyRequest(method string, path string, body string) *providersRequest {
+	req := &providersRequest{
+		MockRequest: createMockRequest(method, path),
+		queryValues: map[string][]string{},
+		pathValues:  map[string]string{},
+	}
+	req.SetBody([]byte(body))
+	return req
+}
+
+func TestHandlePutTagPolicy(t *testing.T) {
+	body := `{"keys": [{"key": "cost-center"}, {"key": "env", "values": ["dev", "production"]}], "required": ["cost-center"]}`
+
+	t.Run("a user outside the admin groups cannot update it", func(t *testing.T) {
+		h, storage := newTagPolicyTestHandlers(t)
+		recorder := httptest.NewRecorder()
+		h.HandlePutTagPolicy(jobAccessContext("alice"), tagPolicyRequest("PUT", "/api/v1/evaluations/tag-policy", body), MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 403 || !strings.Contains(recorder.Body.String(), "tag_policy_access_denied") {
+			t.Fatalf("expected a 403 tag_policy_access_denied, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if storage.policy != nil {
+			t.Errorf("expected the policy not to be stored, got %+v", storage.policy)
+		}
+	})
+
+	t.Run("an invalid policy is rejected", func(t *testing.T) {
+		h, _ := newTagPolicyTestHandlers(t)
+		recorder := httptest.NewRecorder()
+		h.HandlePutTagPolicy(jobAccessContext("admin", "eval-admins"), tagPolicyRequest("PUT", "/api/v1/evaluations/tag-policy", `{"keys": [{"key": "env"}], "required": ["cost-center"]}`), MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 400 {
+			t.Fatalf("expected status 400, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("an admin updates it", func(t *testing.T) {
+		h, storage := newTagPolicyTestHandlers(t)
+		recorder := httptest.NewRecorder()
+		h.HandlePutTagPolicy(jobAccessContext("admin", "eval-admins"), tagPolicyRequest("PUT", "/api/v1/evaluations/tag-policy", body), MockResponseWrapper{recorder: recorder})
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		if storage.policy == nil || storage.policy.UpdatedBy != "admin" || storage.policy.Tenant != "test-tenant" || len(storage.policy.Keys) != 2 {
+			t.Fatalf("expected the policy of the tenant to be stored, got %+v", storage.policy)
+		}
+
+		recorder = httptest.NewRecorder()
+		h.HandleGetTagPolicy(jobAccessContext("alice"), tagPolicyRequest("GET", "/api/v1/evaluations/tag-policy", ""), MockResponseWrapper{recorder: recorder})
+		var got api.TagPolicyResource
+		if err := json.Unmarshal(recorder.Body.Bytes(), &got); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if recorder.Code != 200 || len(got.Required) != 1 || got.Required[0] != "cost-center" {
+			t.Fatalf("expected the stored policy, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+}
+
+func TestHandleGetTagPolicyWithoutPolicy(t *testing.T) {
+	h, _ := newTagPolicyTestHandlers(t)
+	recorder := httptest.NewRecorder()
+	h.HandleGetTagPolicy(jobAccessContext("alice"), tagPolicyRequest("GET", "/api/v1/evaluations/tag-policy", ""), MockResponseWrapper{recorder: recorder})
+	if recorder.Code != 200 || strings.TrimSpace(recorder.Body.String()) != `{"tenant":"test-tenant"}` {
+		t.Fatalf("expected an empty policy, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleCreateCollectionEnforcesTheTagPolicy(t *testing.T) {
+	h, storage := newTagPolicyTestHandlers(t)
+	storage.policy = &api.TagPolicyResource{TagPolicy: api.TagPolicy{
+		Keys:     []api.TagPolicyKey{{Key: "cost-center"}, {Key: "env", Values: []string{"dev", "production"}}},
+		Required: []string{"cost-center"},
+	}}
+	create := func(tags string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		body := `{"name": "tagged", "category": "test", "tags": ` + tags + `, "benchmarks": [{"id": "b1", "provider_id": "p1"}]}`
+		h.HandleCreateCollection(executioncontext.NewExecutionContext(context.Background(), "req-tags", slog.New(slog.DiscardHandler), "alice", "test-tenant"),
+			tagPolicyRequest("POST", "/api/v1/evaluations/collections", body), MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	if recorder := create(`["env:prod"]`); recorder.Code != 400 || !strings.Contains(recorder.Body.String(), "tag_policy_violation") {
+		t.Fatalf("expected a 400 tag_policy_violation, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if recorder := create(`["cost-center:ml", "env:production"]`); recorder.Code != 201 {
+		t.Fatalf("expected status 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serialization"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// HandleListEvaluationViews handles GET /api/v1/evaluations/views
+func (h *Handlers) HandleListEvaluationViews(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	var ofilter *abstractions.QueryFilter
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			filter, err := CommonListFilters(req)
+
+			logging.LogRequestStarted(ctx, "filter", filter)
+
+			if err != nil {
+				return err
+			}
+
+			allowedParams := []string{"limit", "offset", "name", "owner"}
+			badParams := getAllParams(req, allowedParams...)
+			if len(badParams) > 0 {
+				// just report the first bad parameter
+				return serviceerrors.NewServiceError(messages.QueryBadParameter, "ParameterName", badParams[0], "AllowedParameters", strings.Join(allowedParams, ", "))
+			}
+
+			ofilter = filter
+			return nil
+		},
+		"validation",
+		"validate-evaluation-views-filter",
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			views, err := storage.WithContext(runtimeCtx).GetEvaluationViews(ofilter)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			page, err := CreatePage(ctx, views.TotalCount, ofilter.Offset, ofilter.Limit, req)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+
+			result := api.EvaluationViewResourceList{
+				Page:  *page,
+				Items: views.Items,
+			}
+			w.WriteJSON(result, 200, "count", strconv.Itoa(len(views.Items)), "total_count", strconv.Itoa(views.TotalCount))
+			return nil
+		},
+		"storage",
+		"list-evaluation-views",
+	)
+}
+
+// HandleCreateEvaluationView handles POST /api/v1/evaluations/views
+func (h *Handlers) HandleCreateEvaluationView(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	config := &api.EvaluationViewConfig{}
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			// get the body bytes from the context
+			bodyBytes, err := req.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			return serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, config)
+		},
+		"validation",
+		"validate-evaluation-view",
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	view := &api.EvaluationViewResource{
+		Resource: api.Resource{
+			ID:        common.GUID(),
+			CreatedAt: time.Now(),
+			Owner:     ctx.User,
+			Tenant:    ctx.Tenant,
+		},
+		EvaluationViewConfig: *config,
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			if err := storage.WithContext(runtimeCtx).CreateEvaluationView(view); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(view, 201)
+			return nil
+		},
+		"storage",
+		"create-evaluation-view",
+		"view.id", view.Resource.ID,
+	)
+}
+
+// HandleGetEvaluationView handles GET /api/v1/evaluations/views/{view_id}
+func (h *Handlers) HandleGetEvaluationView(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	id := req.PathValue(constants.PATH_PARAMETER_VIEW_ID)
+	if id == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_VIEW_ID), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			view, err := storage.WithContext(runtimeCtx).GetEvaluationView(id)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(view, 200)
+			return nil
+		},
+		"storage",
+		"get-evaluation-view",
+		"view.id", id,
+	)
+}
+
+// HandleUpdateEvaluationView handles PUT /api/v1/evaluations/views/{view_id}
+func (h *Handlers) HandleUpdateEvaluationView(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	id := req.PathValue(constants.PATH_PARAMETER_VIEW_ID)
+	if id == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_VIEW_ID), ctx.RequestID)
+		return
+	}
+
+	config := &api.EvaluationViewConfig{}
+
+	err := h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			// get the body bytes from the context
+			bodyBytes, err := req.BodyAsBytes()
+			if err != nil {
+				return err
+			}
+			return serialization.Unmarshal(h.validate, ctx.WithContext(runtimeCtx), bodyBytes, config)
+		},
+		"validation",
+		"validate-evaluation-view-update",
+		"view.id", id,
+	)
+	if err != nil {
+		w.Error(err, ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			view, err := storage.WithContext(runtimeCtx).UpdateEvaluationView(id, config)
+			if err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(view, 200)
+			return nil
+		},
+		"storage",
+		"update-evaluation-view",
+		"view.id", id,
+	)
+}
+
+// HandleDeleteEvaluationView handles DELETE /api/v1/evaluations/views/{view_id}
+func (h *Handlers) HandleDeleteEvaluationView(ctx *executioncontext.ExecutionContext, req http_wrappers.RequestWrapper, w http_wrappers.ResponseWrapper) {
+	storage := h.getStorage(ctx)
+
+	logging.LogRequestStarted(ctx)
+
+	id := req.PathValue(constants.PATH_PARAMETER_VIEW_ID)
+	if id == "" {
+		w.Error(serviceerrors.NewServiceError(messages.MissingPathParameter, "ParameterName", constants.PATH_PARAMETER_VIEW_ID), ctx.RequestID)
+		return
+	}
+
+	_ = h.withSpan(
+		ctx,
+		func(runtimeCtx context.Context) error {
+			if err := storage.WithContext(runtimeCtx).DeleteEvaluationView(id); err != nil {
+				w.Error(err, ctx.RequestID)
+				return err
+			}
+			w.WriteJSON(nil, 204)
+			return nil
+		},
+		"storage",
+		"delete-evaluation-view",
+		"view.id", id,
+	)
+}
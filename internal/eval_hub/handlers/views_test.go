@@ -0,0 +1,155 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/testhelpers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// viewTestStorage keeps the views in memory and records the filter of the jobs list.
+type viewTestStorage struct {
+	abstractions.Storage
+	views  map[string]*api.EvaluationViewResource
+	filter *abstractions.QueryFilter
+}
+
+func (s *viewTestStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *viewTestStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *viewTestStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *viewTestStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *viewTestStorage) CreateEvaluationView(view *api.EvaluationViewResource) error {
+	s.views[view.Resource.ID] = view
+	return nil
+}
+
+func (s *viewTestStorage) GetEvaluationView(id string) (*api.EvaluationViewResource, error) {
+	if view, ok := s.views[id]; ok {
+		return view, nil
+	}
+	return nil, serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "evaluation view", "ResourceId", id)
+}
+
+func (s *viewTestStorage) GetEvaluationJobs(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.EvaluationJobResource], error) {
+	s.filter = filter
+	return &abstractions.QueryResults[api.EvaluationJobResource]{}, nil
+}
+
+func TestHandleCreateEvaluationView(t *testing.T) {
+	storage := &viewTestStorage{views: map[string]*api.EvaluationViewResource{}}
+	h := handlers.New(storage, testhelpers.NewValidator(t), nil, nil, nil, nil)
+
+	recorder := httptest.NewRecorder()
+	h.HandleCreateEvaluationView(jobAccessContext("alice"), &baselineRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/views"),
+		body:        []byte(`{"name": "nightly failures", "filter": {"status": "failed", "tags": "nightly", "created_after": "2026-01-01T00:00:00Z"}}`),
+	}, MockResponseWrapper{recorder: recorder})
+	if recorder.Code != 201 {
+		t.Fatalf("expected status 201, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	var view api.EvaluationViewResource
+	if err := json.Unmarshal(recorder.Body.Bytes(), &view); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if view.Resource.ID == "" || view.Resource.Owner != "alice" || view.Resource.Tenant != "test-tenant" || view.Filter.Status != "failed" {
+		t.Fatalf("unexpected view %+v", view)
+	}
+	if storage.views[view.Resource.ID] == nil {
+		t.Fatalf("expected the view to be stored")
+	}
+
+	recorder = httptest.NewRecorder()
+	h.HandleCreateEvaluationView(jobAccessContext("alice"), &baselineRequest{
+		MockRequest: createMockRequest("POST", "/api/v1/evaluations/views"),
+		body:        []byte(`{"filter": {"status": "failed"}}`),
+	}, MockResponseWrapper{recorder: recorder})
+	if recorder.Code != 400 {
+		t.Fatalf("expected a view without name to be rejected, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestHandleListEvaluationsByView(t *testing.T) {
+	createdAfter := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	storage := &viewTestStorage{views: map[string]*api.EvaluationViewResource{
+		"view-1": {
+			Resource: api.Resource{ID: "view-1"},
+			EvaluationViewConfig: api.EvaluationViewConfig{
+				Name:   "granite",
+				Filter: api.EvaluationViewFilter{Status: "failed", Model: "granite", CreatedAfter: &createdAfter},
+			},
+		},
+	}}
+	h := handlers.New(storage, testhelpers.NewValidator(t), nil, nil, nil, nil)
+
+	list := func(uri string, query map[string][]string) *httptest.ResponseRecorder {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		h.HandleListEvaluations(jobAccessContext(""), &baselineRequest{
+			MockRequest: createMockRequest("GET", uri),
+			query:       query,
+		}, MockResponseWrapper{recorder: recorder})
+		return recorder
+	}
+
+	t.Run("the view filter is applied and refined by the query", func(t *testing.T) {
+		recorder := list("/api/v1/evaluations/jobs?view=view-1&status=completed", map[string][]string{"view": {"view-1"}, "status": {"completed"}})
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		params := storage.filter.Params
+		if params["status"] != "completed" || params["model"] != "granite" || params["created_after"] != createdAfter {
+			t.Fatalf("expected the filter of the view with the status of the query, got %v", params)
+		}
+		if _, ok := params["view"]; ok {
+			t.Fatalf("expected the view not to be a filter, got %v", params)
+		}
+	})
+
+	t.Run("an unknown view is not found", func(t *testing.T) {
+		if recorder := list("/api/v1/evaluations/jobs?view=view-2", map[string][]string{"view": {"view-2"}}); recorder.Code != 404 {
+			t.Fatalf("expected status 404, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("a creation time must be a date-time", func(t *testing.T) {
+		recorder := list("/api/v1/evaluations/jobs?created_before=yesterday", map[string][]string{"created_before": {"yesterday"}})
+		if recorder.Code != 400 {
+			t.Fatalf("expected status 400, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+	})
+
+	t.Run("a creation time keeps its offset", func(t *testing.T) {
+		recorder := list("/api/v1/evaluations/jobs?created_before=2026-02-01T10:00:00%2B02:00", map[string][]string{"created_before": {"2026-02-01T10:00:00+02:00"}})
+		if recorder.Code != 200 {
+			t.Fatalf("expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		createdBefore, ok := storage.filter.Params["created_before"].(time.Time)
+		if !ok || !createdBefore.Equal(time.Date(2026, 2, 1, 8, 0, 0, 0, time.UTC)) {
+			t.Fatalf("expected created_before at 08:00 UTC, got %v", storage.filter.Params["created_before"])
+		}
+	})
+}
+
+func TestHandleGetEvaluationViewMissingID(t *testing.T) {
+	storage := &viewTestStorage{views: map[string]*api.EvaluationViewResource{}}
+	h := handlers.New(storage, testhelpers.NewValidator(t), nil, nil, nil, nil)
+	recorder := httptest.NewRecorder()
+	h.HandleGetEvaluationView(jobAccessContext("alice"), &baselineRequest{
+		MockRequest: createMockRequest("GET", "/api/v1/evaluations/views/"),
+		path:        map[string]string{constants.PATH_PARAMETER_VIEW_ID: ""},
+	}, MockResponseWrapper{recorder: recorder})
+	if recorder.Code != 400 {
+		t.Fatalf("expected status 400, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+}
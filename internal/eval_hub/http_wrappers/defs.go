@@ -1,6 +1,11 @@
 package http_wrappers
 
-import "github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+import (
+	"io"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+)
 
 // RequestWrapper abstracts the underlying HTTP request.
 type RequestWrapper interface {
@@ -14,6 +19,15 @@ type RequestWrapper interface {
 	PathValue(name string) string
 }
 
+// StreamingRequestWrapper is implemented by requests whose body can be read as it arrives,
+// such as artifact uploads that are too large to be held in memory.
+type StreamingRequestWrapper interface {
+	RequestWrapper
+	// Body returns the request body. Reading past the body size limit of the request fails
+	// with a messages.RequestBodyTooLarge service error.
+	Body() io.Reader
+}
+
 // Response abstraction of underlying HTTP library
 type ResponseWrapper interface {
 	Error(err error, requestId string)
@@ -24,3 +38,13 @@ type ResponseWrapper interface {
 	Write(buf []byte) (n int, err error)
 	WriteJSON(v any, code int, arguments ...any)
 }
+
+// StreamingResponseWrapper is implemented by responses that can deliver data before the
+// handler returns, such as server-sent events.
+type StreamingResponseWrapper interface {
+	ResponseWrapper
+	// Flush sends any buffered data to the client.
+	Flush() error
+	// SetWriteDeadline overrides the server write timeout for this response.
+	SetWriteDeadline(deadline time.Time) error
+}
@@ -0,0 +1,137 @@
+// Package imagewarmup pre-pulls the adapter images of the system providers on the nodes that
+// run evaluation jobs, so that the first job of a provider does not wait minutes for a cold
+// pull of a large image, and reports the pull status of each provider.
+package imagewarmup
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const providersPageSize = 100
+
+// Manager makes the runtime pre-pull the images of the system providers selected by the
+// warm-up config and keeps their latest pull status in memory.
+//
+// The images are only warmed up by the elected leader, with RunWarmup, since the warm-up
+// resources are shared by the replicas; every replica refreshes the pull status with
+// RunStatus to report it on the providers API.
+type Manager struct {
+	logger  *slog.Logger
+	storage abstractions.Storage
+	warmer  abstractions.ImageWarmer
+	config  *config.ImageWarmupConfig
+	now     func() time.Time
+
+	mu     sync.RWMutex
+	status map[string]api.ProviderImageWarmup
+}
+
+func NewManager(logger *slog.Logger, storage abstractions.Storage, warmer abstractions.ImageWarmer, config *config.ImageWarmupConfig) *Manager {
+	return &Manager{
+		logger:  logger,
+		storage: storage,
+		warmer:  warmer,
+		config:  config,
+		now:     time.Now,
+		status:  map[string]api.ProviderImageWarmup{},
+	}
+}
+
+// RunWarmup warms up the images until ctx is cancelled.
+func (m *Manager) RunWarmup(ctx context.Context) {
+	m.run(ctx, m.WarmupOnce)
+}
+
+// RunStatus refreshes the pull status of the images until ctx is cancelled.
+func (m *Manager) RunStatus(ctx context.Context) {
+	m.run(ctx, m.RefreshOnce)
+}
+
+func (m *Manager) run(ctx context.Context, once func(ctx context.Context)) {
+	ticker := time.NewTicker(m.config.EffectiveInterval())
+	defer ticker.Stop()
+	for {
+		once(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// WarmupOnce makes the runtime pre-pull the images of the selected system providers, and
+// stop pre-pulling the images of the providers that are no longer selected or were deleted.
+func (m *Manager) WarmupOnce(ctx context.Context) {
+	images, err := m.providerImages(ctx)
+	if err != nil {
+		m.logger.Warn("Failed to list providers for image warm-up", "error", err)
+		return
+	}
+	if err := m.warmer.WarmUpImages(ctx, images); err != nil {
+		m.logger.Warn("Failed to warm up provider images", "error", err)
+	}
+}
+
+// RefreshOnce refreshes the pull status of the images.
+func (m *Manager) RefreshOnce(ctx context.Context) {
+	status, err := m.warmer.ImageWarmupStatus(ctx)
+	if err != nil {
+		m.logger.Warn("Failed to get the image warm-up status", "error", err)
+		return
+	}
+	now := m.now()
+	for id, warmup := range status {
+		warmup.UpdatedAt = now
+		status[id] = warmup
+	}
+	m.mu.Lock()
+	m.status = status
+	m.mu.Unlock()
+}
+
+// ProviderImageWarmup returns the latest pull status of the image of the provider, or nil
+// when its image is not pre-pulled.
+func (m *Manager) ProviderImageWarmup(providerID string) *api.ProviderImageWarmup {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	warmup, ok := m.status[providerID]
+	if !ok {
+		return nil
+	}
+	return &warmup
+}
+
+// providerImages returns the Kubernetes images of the system providers selected by the
+// config, keyed by provider ID.
+func (m *Manager) providerImages(ctx context.Context) (map[string]string, error) {
+	storage := m.storage.WithLogger(m.logger).WithContext(ctx)
+	images := map[string]string{}
+	for offset := 0; ; offset += providersPageSize {
+		res, err := storage.GetProviders(&abstractions.QueryFilter{
+			Limit:  providersPageSize,
+			Offset: offset,
+			Params: map[string]any{"scope": abstractions.ScopeSystem},
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, provider := range res.Items {
+			id := provider.Resource.ID
+			if !m.config.IncludesProvider(id) || provider.Runtime == nil || provider.Runtime.K8s == nil || provider.Runtime.K8s.Image == "" {
+				continue
+			}
+			images[id] = provider.Runtime.K8s.Image
+		}
+		if len(res.Items) < providersPageSize || offset+len(res.Items) >= res.TotalCount {
+			return images, nil
+		}
+	}
+}
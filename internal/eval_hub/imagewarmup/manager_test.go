@@ -0,0 +1,118 @@
+package imagewarmup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type fakeWarmer struct {
+	images    map[string]string
+	status    map[string]api.ProviderImageWarmup
+	statusErr error
+}
+
+func (w *fakeWarmer) WarmUpImages(_ context.Context, images map[string]string) error {
+	w.images = images
+	return nil
+}
+
+func (w *fakeWarmer) ImageWarmupStatus(_ context.Context) (map[string]api.ProviderImageWarmup, error) {
+	if w.statusErr != nil {
+		return nil, w.statusErr
+	}
+	status := map[string]api.ProviderImageWarmup{}
+	for id, warmup := range w.status {
+		status[id] = warmup
+	}
+	return status, nil
+}
+
+func k8sProvider(id string, image string) api.ProviderResource {
+	provider := api.ProviderResource{
+		Resource:       api.Resource{ID: id, Owner: abstractions.OwnerSystem},
+		ProviderConfig: api.ProviderConfig{Name: id, Benchmarks: []api.BenchmarkResource{{ID: "smoke", Name: "Smoke"}}},
+	}
+	if image != "" {
+		provider.Runtime = &api.Runtime{K8s: &api.K8sRuntime{Image: image}}
+	}
+	return provider
+}
+
+func newTestManager(t *testing.T, warmer *fakeWarmer, cfg *config.ImageWarmupConfig) *Manager {
+	t.Helper()
+	logger := logging.FallbackLogger()
+	providers := map[string]api.ProviderResource{
+		"lm_eval":  k8sProvider("lm_eval", "quay.io/eval-hub/lm-eval:1.0"),
+		"garak":    k8sProvider("garak", "quay.io/eval-hub/garak:1.0"),
+		"no_image": k8sProvider("no_image", ""),
+	}
+	databaseConfig := map[string]any{
+		"driver": "sqlite",
+		"url":    fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name()),
+	}
+	store, err := storage.NewStorage(&databaseConfig, nil, providers, false, false, logger)
+	if err != nil {
+		t.Fatalf("NewStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	m := NewManager(logger, store, warmer, cfg)
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+	return m
+}
+
+func TestManagerWarmupOnce(t *testing.T) {
+	t.Run("warms up the images of every system provider with an image", func(t *testing.T) {
+		warmer := &fakeWarmer{}
+		m := newTestManager(t, warmer, &config.ImageWarmupConfig{Enabled: true})
+		m.WarmupOnce(context.Background())
+		if len(warmer.images) != 2 || warmer.images["lm_eval"] != "quay.io/eval-hub/lm-eval:1.0" || warmer.images["garak"] != "quay.io/eval-hub/garak:1.0" {
+			t.Fatalf("unexpected images: %v", warmer.images)
+		}
+	})
+
+	t.Run("warms up the images of the configured providers only", func(t *testing.T) {
+		warmer := &fakeWarmer{}
+		m := newTestManager(t, warmer, &config.ImageWarmupConfig{Enabled: true, Providers: []string{"garak", "unknown"}})
+		m.WarmupOnce(context.Background())
+		if len(warmer.images) != 1 || warmer.images["garak"] == "" {
+			t.Fatalf("unexpected images: %v", warmer.images)
+		}
+	})
+}
+
+func TestManagerRefreshOnce(t *testing.T) {
+	warmer := &fakeWarmer{status: map[string]api.ProviderImageWarmup{
+		"lm_eval": {Image: "quay.io/eval-hub/lm-eval:1.0", State: api.ImageWarmupStatePulling, Nodes: 3, Pulled: 1},
+	}}
+	m := newTestManager(t, warmer, &config.ImageWarmupConfig{Enabled: true})
+
+	if warmup := m.ProviderImageWarmup("lm_eval"); warmup != nil {
+		t.Fatalf("expected no status before the first refresh, got %+v", warmup)
+	}
+	m.RefreshOnce(context.Background())
+	warmup := m.ProviderImageWarmup("lm_eval")
+	if warmup == nil || warmup.State != api.ImageWarmupStatePulling || warmup.Pulled != 1 || warmup.UpdatedAt.IsZero() {
+		t.Fatalf("unexpected status: %+v", warmup)
+	}
+	if warmup := m.ProviderImageWarmup("garak"); warmup != nil {
+		t.Fatalf("expected no status for a provider without warm-up, got %+v", warmup)
+	}
+
+	// a failed refresh keeps the last known status
+	warmer.statusErr = errors.New("forbidden")
+	m.RefreshOnce(context.Background())
+	if warmup := m.ProviderImageWarmup("lm_eval"); warmup == nil || warmup.State != api.ImageWarmupStatePulling {
+		t.Fatalf("expected the last status to be kept, got %+v", warmup)
+	}
+}
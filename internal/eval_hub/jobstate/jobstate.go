@@ -0,0 +1,173 @@
+// Package jobstate is the state machine of evaluation jobs: the states a job can move to
+// from each state, the state a job is in given the states of its benchmarks, and the hooks
+// that are called when a job changes state.
+package jobstate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// transitions are the states that a job can move to from each state. A job that is not
+// terminal can always be failed, e.g. when its runtime can not start it, or cancelled.
+// The terminal states have no transition: the status of a finished job is never changed.
+var transitions = map[api.OverallState][]api.OverallState{
+	api.OverallStatePending: {
+		api.OverallStateWaitingForModel,
+		api.OverallStateRunning,
+		// the benchmarks of a job can all report before the job is seen running
+		api.OverallStateCompleted,
+		api.OverallStateFailed,
+		api.OverallStatePartiallyFailed,
+		api.OverallStateCancelled,
+	},
+	api.OverallStateWaitingForModel: {
+		// the model is ready and the benchmarks are launched
+		api.OverallStatePending,
+		api.OverallStateRunning,
+		api.OverallStateFailed,
+		api.OverallStateCancelled,
+	},
+	api.OverallStateRunning: {
+		// the benchmarks of the job are queued again, e.g. after a preemption
+		api.OverallStatePending,
+		api.OverallStateCompleted,
+		api.OverallStateFailed,
+		api.OverallStatePartiallyFailed,
+		api.OverallStateCancelled,
+	},
+	api.OverallStateCompleted:       nil,
+	api.OverallStateFailed:          nil,
+	api.OverallStatePartiallyFailed: nil,
+	api.OverallStateCancelled:       nil,
+}
+
+// States returns all the states of a job.
+func States() []api.OverallState {
+	return []api.OverallState{
+		api.OverallStatePending,
+		api.OverallStateWaitingForModel,
+		api.OverallStateRunning,
+		api.OverallStateCompleted,
+		api.OverallStateFailed,
+		api.OverallStatePartiallyFailed,
+		api.OverallStateCancelled,
+	}
+}
+
+// NextStates returns the states that a job can move to from the state, none for a terminal
+// or unknown state.
+func NextStates(from api.OverallState) []api.OverallState {
+	return slices.Clone(transitions[from])
+}
+
+// CanTransition reports whether a job can move from one state to the other. Staying in the
+// same state is not a transition.
+func CanTransition(from, to api.OverallState) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// Check validates that the job can be set to the state. It returns true when the job is
+// already in the state, which is not an error but leaves nothing to change, and an error
+// when the job can not move to the state, such as any change of a finished job.
+func Check(jobID string, from, to api.OverallState) (bool, error) {
+	if from == to {
+		return true, nil
+	}
+	if !CanTransition(from, to) {
+		return false, serviceerrors.NewServiceError(messages.JobCanNotBeUpdated, "Id", jobID, "NewStatus", to, "Status", from)
+	}
+	return false, nil
+}
+
+// BenchmarkCounts are the number of benchmarks of a job in each state.
+type BenchmarkCounts struct {
+	Total     int
+	Running   int
+	Completed int
+	Failed    int
+	Cancelled int
+}
+
+// Derive returns the state of a job given the states of its benchmarks, and the message of
+// the state. failures lists the failed benchmarks and is appended to the message of a job
+// that failed.
+func Derive(counts BenchmarkCounts, failures string) (api.OverallState, string) {
+	return DeriveWith(counts, nil, failures)
+}
+
+// DeriveWith returns the state of a job given the states of its benchmarks and the
+// aggregation policy of the job, best_effort when nil, and the message of the state.
+func DeriveWith(counts BenchmarkCounts, aggregation *api.StateAggregation, failures string) (api.OverallState, string) {
+	completed, failed, cancelled := counts.Completed, counts.Failed, counts.Cancelled
+	finished := completed+failed+cancelled == counts.Total
+	switch {
+	case aggregation.FailsFast() && failed > 0:
+		return api.OverallStateFailed, "A benchmark failed and the job fails fast. \n" + failures
+	case aggregation != nil && aggregation.Policy == api.AggregationQuorum && finished && cancelled < counts.Total:
+		// a quorum larger than the benchmarks of a collection that shrank needs them all
+		quorum := min(max(aggregation.Quorum, 1), counts.Total)
+		if completed >= quorum {
+			message := fmt.Sprintf("Evaluation job is completed, %d of %d benchmarks completed with a quorum of %d", completed, counts.Total, quorum)
+			if completed < counts.Total {
+				message += ". \n" + failures
+			}
+			return api.OverallStateCompleted, message
+		}
+		return api.OverallStateFailed, fmt.Sprintf("Only %d of %d benchmarks completed, below the quorum of %d. \n", completed, counts.Total, quorum) + failures
+	}
+	switch {
+	case completed == counts.Total:
+		return api.OverallStateCompleted, "Evaluation job is completed"
+	case failed == counts.Total:
+		return api.OverallStateFailed, "Evaluation job is failed. \n" + failures
+	case completed+failed == counts.Total:
+		return api.OverallStatePartiallyFailed, "Some of the benchmarks failed. \n" + failures
+	case cancelled == counts.Total:
+		return api.OverallStateCancelled, "Evaluation job is cancelled"
+	case completed+failed+cancelled == counts.Total:
+		return api.OverallStatePartiallyFailed, "Some of the benchmarks failed or cancelled. \n" + failures
+	case counts.Running > 0, completed > 0, failed > 0, cancelled > 0:
+		// if at least one benchmark has reported a state then the job is running
+		return api.OverallStateRunning, "Evaluation job is running"
+	default:
+		return api.OverallStatePending, "Evaluation job is pending"
+	}
+}
+
+// Hook is called after a job has moved from one state to another and the change has been
+// stored.
+type Hook func(ctx context.Context, jobID string, from, to api.OverallState)
+
+var (
+	hooksLock sync.RWMutex
+	hooks     []Hook
+)
+
+// OnTransition registers a hook that is called on every change of the state of a job,
+// whichever code path made it. Hooks are registered at startup and run synchronously, so
+// they must be quick.
+func OnTransition(hook Hook) {
+	hooksLock.Lock()
+	defer hooksLock.Unlock()
+	hooks = append(hooks, hook)
+}
+
+// Transitioned calls the hooks when the job has changed state.
+func Transitioned(ctx context.Context, jobID string, from, to api.OverallState) {
+	if from == to {
+		return
+	}
+	hooksLock.RLock()
+	registered := hooks
+	hooksLock.RUnlock()
+	for _, hook := range registered {
+		hook(ctx, jobID, from, to)
+	}
+}
@@ -0,0 +1,146 @@
+package jobstate
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestTransitions(t *testing.T) {
+	terminal := []api.OverallState{
+		api.OverallStateCompleted,
+		api.OverallStateFailed,
+		api.OverallStatePartiallyFailed,
+		api.OverallStateCancelled,
+	}
+	allowed := map[api.OverallState][]api.OverallState{
+		api.OverallStatePending:         {api.OverallStateWaitingForModel, api.OverallStateRunning, api.OverallStateCompleted, api.OverallStateFailed, api.OverallStatePartiallyFailed, api.OverallStateCancelled},
+		api.OverallStateWaitingForModel: {api.OverallStatePending, api.OverallStateRunning, api.OverallStateFailed, api.OverallStateCancelled},
+		api.OverallStateRunning:         {api.OverallStatePending, api.OverallStateCompleted, api.OverallStateFailed, api.OverallStatePartiallyFailed, api.OverallStateCancelled},
+	}
+
+	if len(transitions) != len(States()) {
+		t.Fatalf("expected a transition entry for each of the %d states, got %d", len(States()), len(transitions))
+	}
+	for _, from := range States() {
+		if _, ok := transitions[from]; !ok {
+			t.Errorf("state %s has no transition entry", from)
+		}
+		for _, to := range States() {
+			want := slices.Contains(allowed[from], to)
+			if got := CanTransition(from, to); got != want {
+				t.Errorf("CanTransition(%s, %s) = %v, want %v", from, to, got, want)
+			}
+			if slices.Contains(terminal, from) && CanTransition(from, to) {
+				t.Errorf("terminal state %s can move to %s", from, to)
+			}
+		}
+		if from != api.OverallStateCancelled && !slices.Contains(terminal, from) && !CanTransition(from, api.OverallStateCancelled) {
+			t.Errorf("state %s can not be cancelled", from)
+		}
+	}
+	if next := NextStates("unknown"); len(next) != 0 {
+		t.Errorf("expected no next state for an unknown state, got %v", next)
+	}
+
+	next := NextStates(api.OverallStateRunning)
+	next[0] = api.OverallStateCompleted
+	if transitions[api.OverallStateRunning][0] != api.OverallStatePending {
+		t.Error("expected NextStates to return a copy of the transition table")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	same, err := Check("job-1", api.OverallStateRunning, api.OverallStateRunning)
+	if err != nil || !same {
+		t.Errorf("expected the same state to be reported without error, got %v, %v", same, err)
+	}
+	same, err = Check("job-1", api.OverallStateRunning, api.OverallStateCompleted)
+	if err != nil || same {
+		t.Errorf("expected an allowed transition, got %v, %v", same, err)
+	}
+	_, err = Check("job-1", api.OverallStateCompleted, api.OverallStateRunning)
+	if err == nil || !strings.Contains(err.Error(), "job-1") {
+		t.Errorf("expected an error naming the job for a change of a finished job, got %v", err)
+	}
+}
+
+func TestDerive(t *testing.T) {
+	tests := map[string]struct {
+		counts  BenchmarkCounts
+		want    api.OverallState
+		message string
+	}{
+		"nothing reported":  {counts: BenchmarkCounts{Total: 3}, want: api.OverallStatePending, message: "pending"},
+		"one running":       {counts: BenchmarkCounts{Total: 3, Running: 1}, want: api.OverallStateRunning, message: "running"},
+		"one completed":     {counts: BenchmarkCounts{Total: 3, Completed: 1}, want: api.OverallStateRunning, message: "running"},
+		"all completed":     {counts: BenchmarkCounts{Total: 3, Completed: 3}, want: api.OverallStateCompleted, message: "completed"},
+		"all failed":        {counts: BenchmarkCounts{Total: 2, Failed: 2}, want: api.OverallStateFailed, message: "bench-1 failed"},
+		"some failed":       {counts: BenchmarkCounts{Total: 2, Completed: 1, Failed: 1}, want: api.OverallStatePartiallyFailed, message: "bench-1 failed"},
+		"all cancelled":     {counts: BenchmarkCounts{Total: 2, Cancelled: 2}, want: api.OverallStateCancelled, message: "cancelled"},
+		"some cancelled":    {counts: BenchmarkCounts{Total: 3, Completed: 1, Failed: 1, Cancelled: 1}, want: api.OverallStatePartiallyFailed, message: "failed or cancelled"},
+		"cancelled running": {counts: BenchmarkCounts{Total: 3, Running: 1, Cancelled: 1}, want: api.OverallStateRunning, message: "running"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			state, message := Derive(tt.counts, "bench-1 failed")
+			if state != tt.want {
+				t.Errorf("expected state %s, got %s", tt.want, state)
+			}
+			if !strings.Contains(message, tt.message) {
+				t.Errorf("expected the message to contain %q, got %q", tt.message, message)
+			}
+		})
+	}
+}
+
+func TestDeriveWith(t *testing.T) {
+	failFast := &api.StateAggregation{Policy: api.AggregationFailFast}
+	quorum := &api.StateAggregation{Policy: api.AggregationQuorum, Quorum: 2}
+	tests := map[string]struct {
+		counts      BenchmarkCounts
+		aggregation *api.StateAggregation
+		want        api.OverallState
+		message     string
+	}{
+		"fail fast on a failure":      {counts: BenchmarkCounts{Total: 3, Running: 1, Failed: 1}, aggregation: failFast, want: api.OverallStateFailed, message: "fails fast"},
+		"fail fast without failures":  {counts: BenchmarkCounts{Total: 3, Running: 1, Completed: 1}, aggregation: failFast, want: api.OverallStateRunning, message: "running"},
+		"fail fast all completed":     {counts: BenchmarkCounts{Total: 2, Completed: 2}, aggregation: failFast, want: api.OverallStateCompleted, message: "completed"},
+		"quorum waits for all":        {counts: BenchmarkCounts{Total: 3, Running: 1, Completed: 2}, aggregation: quorum, want: api.OverallStateRunning, message: "running"},
+		"quorum met":                  {counts: BenchmarkCounts{Total: 3, Completed: 2, Failed: 1}, aggregation: quorum, want: api.OverallStateCompleted, message: "2 of 3 benchmarks completed"},
+		"quorum missed":               {counts: BenchmarkCounts{Total: 3, Completed: 1, Failed: 2}, aggregation: quorum, want: api.OverallStateFailed, message: "below the quorum of 2"},
+		"quorum above the benchmarks": {counts: BenchmarkCounts{Total: 1, Completed: 1}, aggregation: &api.StateAggregation{Policy: api.AggregationQuorum, Quorum: 3}, want: api.OverallStateCompleted, message: "quorum of 1"},
+		"quorum all cancelled":        {counts: BenchmarkCounts{Total: 2, Cancelled: 2}, aggregation: quorum, want: api.OverallStateCancelled, message: "cancelled"},
+		"best effort":                 {counts: BenchmarkCounts{Total: 2, Completed: 1, Failed: 1}, aggregation: &api.StateAggregation{Policy: api.AggregationBestEffort}, want: api.OverallStatePartiallyFailed, message: "bench-1 failed"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			state, message := DeriveWith(tt.counts, tt.aggregation, "bench-1 failed")
+			if state != tt.want {
+				t.Errorf("expected state %s, got %s", tt.want, state)
+			}
+			if !strings.Contains(message, tt.message) {
+				t.Errorf("expected the message to contain %q, got %q", tt.message, message)
+			}
+		})
+	}
+}
+
+func TestTransitioned(t *testing.T) {
+	saved := hooks
+	t.Cleanup(func() { hooks = saved })
+	hooks = nil
+
+	var calls []string
+	OnTransition(func(_ context.Context, jobID string, from, to api.OverallState) {
+		calls = append(calls, jobID+":"+string(from)+"->"+string(to))
+	})
+	Transitioned(context.Background(), "job-1", api.OverallStateRunning, api.OverallStateRunning)
+	Transitioned(context.Background(), "job-1", api.OverallStatePending, api.OverallStateRunning)
+	if strings.Join(calls, " ") != "job-1:pending->running" {
+		t.Errorf("expected the hook to be called on a change of state only, got %v", calls)
+	}
+}
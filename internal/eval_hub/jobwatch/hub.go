@@ -0,0 +1,82 @@
+// Package jobwatch fans out evaluation job updates to clients watching a job, so that
+// dashboards can follow a job without polling the GET endpoint.
+package jobwatch
+
+import (
+	"sync"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/events"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// Hub is an in-process pub/sub of evaluation job snapshots keyed by job ID.
+//
+// Each subscriber holds at most one pending snapshot: a slow reader skips intermediate
+// updates and always receives the latest state, so publishers never block.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan *api.EvaluationJobResource]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: map[string]map[chan *api.EvaluationJobResource]struct{}{},
+	}
+}
+
+// Subscribe registers interest in the job. The returned function must be called to
+// release the subscription; the channel is never closed.
+func (h *Hub) Subscribe(jobID string) (<-chan *api.EvaluationJobResource, func()) {
+	ch := make(chan *api.EvaluationJobResource, 1)
+
+	h.mu.Lock()
+	subs, ok := h.subscribers[jobID]
+	if !ok {
+		subs = map[chan *api.EvaluationJobResource]struct{}{}
+		h.subscribers[jobID] = subs
+	}
+	subs[ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			subs := h.subscribers[jobID]
+			delete(subs, ch)
+			if len(subs) == 0 {
+				delete(h.subscribers, jobID)
+			}
+		})
+	}
+}
+
+// HasSubscribers reports whether anyone is watching the job.
+func (h *Hub) HasSubscribers(jobID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers[jobID]) > 0
+}
+
+// Publish delivers the job snapshot to every subscriber of the job.
+func (h *Hub) Publish(job *api.EvaluationJobResource) {
+	if job == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers[job.Resource.ID] {
+		// replace a snapshot the subscriber has not read yet
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- job
+	}
+}
+
+// HandleEvent is the events.Handler that feeds the hub from the event bus.
+func (h *Hub) HandleEvent(event events.Event) {
+	h.Publish(event.Job)
+}
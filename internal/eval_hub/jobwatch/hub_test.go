@@ -0,0 +1,54 @@
+package jobwatch
+
+import (
+	"testing"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func job(id string, state api.OverallState) *api.EvaluationJobResource {
+	return &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: id}},
+		Status: &api.EvaluationJobStatus{
+			EvaluationJobState: api.EvaluationJobState{State: state},
+		},
+	}
+}
+
+func TestHub(t *testing.T) {
+	t.Run("delivers the latest snapshot to a slow subscriber", func(t *testing.T) {
+		hub := NewHub()
+		updates, unsubscribe := hub.Subscribe("job-1")
+		defer unsubscribe()
+
+		hub.Publish(job("job-1", api.OverallStateRunning))
+		hub.Publish(job("job-1", api.OverallStateCompleted))
+		hub.Publish(job("job-2", api.OverallStateFailed))
+
+		got := <-updates
+		if got.Status.State != api.OverallStateCompleted {
+			t.Fatalf("expected the latest snapshot, got %s", got.Status.State)
+		}
+		select {
+		case extra := <-updates:
+			t.Fatalf("unexpected snapshot %+v", extra)
+		default:
+		}
+	})
+
+	t.Run("unsubscribe releases the job", func(t *testing.T) {
+		hub := NewHub()
+		_, first := hub.Subscribe("job-1")
+		_, second := hub.Subscribe("job-1")
+
+		first()
+		first()
+		if !hub.HasSubscribers("job-1") {
+			t.Fatal("expected the second subscriber to remain")
+		}
+		second()
+		if hub.HasSubscribers("job-1") {
+			t.Fatal("expected no subscribers")
+		}
+	})
+}
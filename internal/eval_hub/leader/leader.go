@@ -0,0 +1,105 @@
+// Package leader elects one replica of the service to run the background subsystems
+// (config reloads, provider health checks) while every replica serves the API.
+package leader
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// DefaultRetryInterval is how often a replica tries to take the lock, and how often the
+// leader checks that it still holds it.
+const DefaultRetryInterval = 15 * time.Second
+
+// Lock is a mutual-exclusion lock shared by all replicas.
+type Lock interface {
+	// TryAcquire takes the lock if it is free and reports whether this replica holds it.
+	TryAcquire(ctx context.Context) (bool, error)
+	// Check returns an error once the lock may have been lost, e.g. because the database
+	// session holding it ended.
+	Check(ctx context.Context) error
+	// Release gives the lock up.
+	Release(ctx context.Context) error
+	Close() error
+}
+
+type Elector struct {
+	logger        *slog.Logger
+	lock          Lock
+	retryInterval time.Duration
+}
+
+func NewElector(logger *slog.Logger, lock Lock, retryInterval time.Duration) *Elector {
+	if retryInterval <= 0 {
+		retryInterval = DefaultRetryInterval
+	}
+	return &Elector{
+		logger:        logger,
+		lock:          lock,
+		retryInterval: retryInterval,
+	}
+}
+
+// Run competes for the lock until ctx is done. Each time this replica becomes the
+// leader, lead is called with a context that is cancelled when leadership is lost;
+// Run waits for lead to return before competing again, so lead never runs twice at once.
+func (e *Elector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	ticker := time.NewTicker(e.retryInterval)
+	defer ticker.Stop()
+	for {
+		acquired, err := e.lock.TryAcquire(ctx)
+		if err != nil {
+			e.logger.Warn("Failed to acquire the leader lock", "error", err)
+		}
+		if acquired {
+			e.logger.Info("Acquired the leader lock, starting background subsystems")
+			e.leadUntilLost(ctx, ticker, lead)
+			e.release()
+			e.logger.Info("Stopped background subsystems")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) leadUntilLost(ctx context.Context, ticker *time.Ticker, lead func(ctx context.Context)) {
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		lead(leaderCtx)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			cancel()
+			<-done
+			return
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := e.lock.Check(ctx); err != nil {
+				e.logger.Warn("Lost the leader lock", "error", err)
+				cancel()
+				<-done
+				return
+			}
+		}
+	}
+}
+
+func (e *Elector) release() {
+	// the run context may already be cancelled at shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := e.lock.Release(ctx); err != nil {
+		e.logger.Warn("Failed to release the leader lock", "error", err)
+	}
+}
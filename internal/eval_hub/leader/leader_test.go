@@ -0,0 +1,200 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/logging"
+)
+
+// fakeLock is shared by several electors to stand in for the database.
+type fakeLock struct {
+	mu       sync.Mutex
+	holder   *fakeSession
+	released int
+}
+
+type fakeSession struct {
+	lock *fakeLock
+	lost bool
+}
+
+func (l *fakeLock) session() *fakeSession {
+	return &fakeSession{lock: l}
+}
+
+func (s *fakeSession) TryAcquire(_ context.Context) (bool, error) {
+	s.lock.mu.Lock()
+	defer s.lock.mu.Unlock()
+	if s.lock.holder == nil {
+		s.lock.holder = s
+	}
+	return s.lock.holder == s, nil
+}
+
+func (s *fakeSession) Check(_ context.Context) error {
+	s.lock.mu.Lock()
+	defer s.lock.mu.Unlock()
+	if s.lost {
+		s.lock.holder = nil
+		s.lost = false
+		return errors.New("session ended")
+	}
+	if s.lock.holder != s {
+		return errors.New("not held")
+	}
+	return nil
+}
+
+func (s *fakeSession) Release(_ context.Context) error {
+	s.lock.mu.Lock()
+	defer s.lock.mu.Unlock()
+	if s.lock.holder == s {
+		s.lock.holder = nil
+		s.lock.released++
+	}
+	return nil
+}
+
+func (s *fakeSession) Close() error { return nil }
+
+func (s *fakeSession) loseSession() {
+	s.lock.mu.Lock()
+	defer s.lock.mu.Unlock()
+	s.lost = true
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestElector(t *testing.T) {
+	logger := logging.FallbackLogger()
+	interval := 5 * time.Millisecond
+
+	t.Run("only one replica leads at a time", func(t *testing.T) {
+		lock := &fakeLock{}
+		ctx, cancel := context.WithCancel(context.Background())
+
+		var mu sync.Mutex
+		leading, maxLeading, terms := 0, 0, 0
+		lead := func(ctx context.Context) {
+			mu.Lock()
+			leading++
+			terms++
+			maxLeading = max(maxLeading, leading)
+			mu.Unlock()
+			<-ctx.Done()
+			mu.Lock()
+			leading--
+			mu.Unlock()
+		}
+
+		sessions := []*fakeSession{lock.session(), lock.session(), lock.session()}
+		var wg sync.WaitGroup
+		for _, session := range sessions {
+			wg.Go(func() { NewElector(logger, session, interval).Run(ctx, lead) })
+		}
+
+		waitFor(t, "a leader", func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return terms == 1
+		})
+
+		// the leader's session ends: another replica takes over
+		lock.mu.Lock()
+		holder := lock.holder
+		lock.mu.Unlock()
+		holder.loseSession()
+		waitFor(t, "a new leader", func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return terms == 2
+		})
+
+		cancel()
+		wg.Wait()
+		if maxLeading != 1 {
+			t.Errorf("expected a single leader at any time, got %d", maxLeading)
+		}
+		if leading != 0 {
+			t.Errorf("expected lead to have returned on shutdown, %d still running", leading)
+		}
+		if lock.holder != nil {
+			t.Errorf("expected the lock to be released on shutdown")
+		}
+	})
+
+	t.Run("lock errors are retried", func(t *testing.T) {
+		lock := &erroringLock{failures: 2}
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		led := make(chan struct{})
+		go func() {
+			defer close(done)
+			NewElector(logger, lock, interval).Run(ctx, func(ctx context.Context) {
+				close(led)
+				<-ctx.Done()
+			})
+		}()
+
+		select {
+		case <-led:
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected to lead once the lock is available")
+		}
+		cancel()
+		<-done
+	})
+}
+
+type erroringLock struct {
+	mu       sync.Mutex
+	failures int
+}
+
+func (l *erroringLock) TryAcquire(_ context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.failures > 0 {
+		l.failures--
+		return false, errors.New("connection refused")
+	}
+	return true, nil
+}
+
+func (l *erroringLock) Check(_ context.Context) error   { return nil }
+func (l *erroringLock) Release(_ context.Context) error { return nil }
+func (l *erroringLock) Close() error                    { return nil }
+
+func TestNewLock(t *testing.T) {
+	logger := logging.FallbackLogger()
+
+	lock, err := NewLock(logger, &map[string]any{"driver": "sqlite", "url": "file::memory:"})
+	if err != nil {
+		t.Fatalf("NewLock: %v", err)
+	}
+	if _, ok := lock.(*localLock); !ok {
+		t.Errorf("expected a local lock for sqlite, got %T", lock)
+	}
+
+	lock, err = NewLock(logger, &map[string]any{"driver": "pgx", "url": "postgres://localhost:1/evalhub"})
+	if err != nil {
+		t.Fatalf("NewLock: %v", err)
+	}
+	defer func() { _ = lock.Close() }()
+	if _, ok := lock.(*postgresLock); !ok {
+		t.Errorf("expected a Postgres advisory lock for pgx, got %T", lock)
+	}
+}
@@ -0,0 +1,121 @@
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	// import the postgres driver - "pgx"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+const (
+	postgresDriver = "pgx"
+
+	// AdvisoryLockKey identifies the leader lock among the Postgres advisory locks of
+	// the database. Replicas sharing a database share the lock.
+	AdvisoryLockKey int64 = 0x6576616c687562 // "evalhub"
+)
+
+// NewLock returns the lock suited to the database configuration: a Postgres advisory
+// lock when the replicas share a Postgres database, otherwise a lock local to the
+// process, since an SQLite database is never shared between replicas.
+func NewLock(logger *slog.Logger, database *map[string]any) (Lock, error) {
+	if database != nil {
+		driver, _ := (*database)["driver"].(string)
+		url, _ := (*database)["url"].(string)
+		if driver == postgresDriver {
+			logger.Info("Using a Postgres advisory lock for leader election")
+			return NewPostgresLock(url, AdvisoryLockKey)
+		}
+	}
+	return &localLock{}, nil
+}
+
+// localLock is always held: there is a single replica.
+type localLock struct{}
+
+func (l *localLock) TryAcquire(_ context.Context) (bool, error) { return true, nil }
+func (l *localLock) Check(_ context.Context) error              { return nil }
+func (l *localLock) Release(_ context.Context) error            { return nil }
+func (l *localLock) Close() error                               { return nil }
+
+// postgresLock holds a session-level advisory lock on a dedicated connection. Postgres
+// releases the lock when the session ends, so a replica that dies or loses the
+// database gives up leadership without any lease to expire.
+type postgresLock struct {
+	db  *sql.DB
+	key int64
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+func NewPostgresLock(url string, key int64) (Lock, error) {
+	db, err := sql.Open(postgresDriver, url)
+	if err != nil {
+		return nil, fmt.Errorf("open leader lock database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	return &postgresLock{db: db, key: key}, nil
+}
+
+func (l *postgresLock) TryAcquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn != nil {
+		return true, nil
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&acquired); err != nil {
+		_ = conn.Close()
+		return false, err
+	}
+	if !acquired {
+		_ = conn.Close()
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+func (l *postgresLock) Check(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn == nil {
+		return fmt.Errorf("leader lock is not held")
+	}
+	if err := l.conn.PingContext(ctx); err != nil {
+		// the session, and with it the lock, is gone
+		_ = l.conn.Close()
+		l.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (l *postgresLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.conn == nil {
+		return nil
+	}
+	_, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key)
+	// closing the session releases the lock even if the unlock failed
+	if cerr := l.conn.Close(); err == nil {
+		err = cerr
+	}
+	l.conn = nil
+	return err
+}
+
+func (l *postgresLock) Close() error {
+	return l.db.Close()
+}
@@ -0,0 +1,107 @@
+package localmode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// EnvJobSpecPath is set by the local runtime to the job spec written for the benchmark.
+const EnvJobSpecPath = "EVALHUB_JOB_SPEC_PATH"
+
+// RunEchoAdapter is the adapter behind the echo provider. It reads the job spec written by
+// the local runtime and reports a running and then a completed status event to the
+// callback URL, with the callback token of the job, echoing the benchmark parameters in
+// the results.
+func RunEchoAdapter(specPath string, out io.Writer) error {
+	if specPath == "" {
+		return fmt.Errorf("%s is not set", EnvJobSpecPath)
+	}
+	data, err := os.ReadFile(specPath) // #nosec G304 -- path provided by the local runtime
+	if err != nil {
+		return fmt.Errorf("read job spec: %w", err)
+	}
+	var spec shared.JobSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return fmt.Errorf("parse job spec: %w", err)
+	}
+	if spec.CallbackURL == nil || *spec.CallbackURL == "" {
+		return fmt.Errorf("job spec %s has no callback_url", specPath)
+	}
+
+	fmt.Fprintf(out, "echo adapter: job %s benchmark %s[%d] model %s\n", spec.JobID, spec.BenchmarkID, spec.BenchmarkIndex, spec.Model.Name)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	startedAt := api.DateTimeToString(time.Now())
+
+	running := &api.BenchmarkStatusEvent{
+		ProviderID:     spec.ProviderID,
+		ID:             spec.BenchmarkID,
+		BenchmarkIndex: spec.BenchmarkIndex,
+		Status:         api.StateRunning,
+		Phase:          api.JobPhaseRunningEvaluation,
+		StartedAt:      startedAt,
+	}
+	if err := postStatusEvent(client, &spec, running); err != nil {
+		return err
+	}
+
+	completed := &api.BenchmarkStatusEvent{
+		ProviderID:     spec.ProviderID,
+		ID:             spec.BenchmarkID,
+		BenchmarkIndex: spec.BenchmarkIndex,
+		Status:         api.StateCompleted,
+		Phase:          api.JobPhaseCompleted,
+		Metrics:        map[string]any{"score": 1.0},
+		AdditionalInfo: map[string]any{
+			"model":      spec.Model.Name,
+			"parameters": spec.Parameters,
+		},
+		StartedAt:   startedAt,
+		CompletedAt: api.DateTimeToString(time.Now()),
+	}
+	if err := postStatusEvent(client, &spec, completed); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, "echo adapter: completed")
+	return nil
+}
+
+func postStatusEvent(client *http.Client, spec *shared.JobSpec, event *api.BenchmarkStatusEvent) error {
+	body, err := json.Marshal(api.StatusEvent{BenchmarkStatusEvent: event})
+	if err != nil {
+		return fmt.Errorf("marshal status event: %w", err)
+	}
+	endpoint, err := url.JoinPath(*spec.CallbackURL, "api/v1/evaluations/jobs", spec.JobID, "events")
+	if err != nil {
+		return fmt.Errorf("build events URL: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build %s status event request: %w", event.Status, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if spec.CallbackToken != "" {
+		req.Header.Set(callbackauth.TokenHeader, spec.CallbackToken)
+	}
+	resp, err := client.Do(req) // #nosec G107 -- callback URL comes from the job spec
+	if err != nil {
+		return fmt.Errorf("post %s status event: %w", event.Status, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode >= http.StatusBadRequest {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("post %s status event: HTTP %d: %s", event.Status, resp.StatusCode, string(msg))
+	}
+	return nil
+}
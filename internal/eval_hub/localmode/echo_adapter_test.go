@@ -0,0 +1,117 @@
+package localmode
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func writeJobSpec(t *testing.T, spec shared.JobSpec) string {
+	t.Helper()
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("marshal job spec: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "job.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write job spec: %v", err)
+	}
+	return path
+}
+
+func TestRunEchoAdapter(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	var tokens []string
+	var events []api.StatusEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var event api.StatusEvent
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		tokens = append(tokens, r.Header.Get(callbackauth.TokenHeader))
+		events = append(events, event)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(srv.Close)
+
+	callbackURL := srv.URL
+	path := writeJobSpec(t, shared.JobSpec{
+		JobID:          "job-1",
+		ProviderID:     EchoProviderID,
+		BenchmarkID:    EchoBenchmarkID,
+		BenchmarkIndex: 2,
+		Model:          api.ModelRef{Name: "demo"},
+		Parameters:     map[string]any{"limit": float64(3)},
+		CallbackURL:    &callbackURL,
+		CallbackToken:  "job-1-token",
+	})
+
+	if err := RunEchoAdapter(path, io.Discard); err != nil {
+		t.Fatalf("RunEchoAdapter: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+	for _, p := range paths {
+		if p != "/api/v1/evaluations/jobs/job-1/events" {
+			t.Errorf("unexpected path %s", p)
+		}
+	}
+	for _, token := range tokens {
+		if token != "job-1-token" {
+			t.Errorf("expected the callback token of the job on every event, got %q", token)
+		}
+	}
+	running, completed := events[0].BenchmarkStatusEvent, events[1].BenchmarkStatusEvent
+	if running.Status != api.StateRunning || completed.Status != api.StateCompleted {
+		t.Errorf("unexpected states %s, %s", running.Status, completed.Status)
+	}
+	if completed.BenchmarkIndex != 2 || completed.Metrics["score"] != 1.0 {
+		t.Errorf("unexpected completed event: %+v", completed)
+	}
+	params, _ := completed.AdditionalInfo["parameters"].(map[string]any)
+	if params["limit"] != float64(3) {
+		t.Errorf("expected parameters to be echoed, got %v", completed.AdditionalInfo)
+	}
+}
+
+func TestRunEchoAdapterErrors(t *testing.T) {
+	t.Run("missing spec path", func(t *testing.T) {
+		if err := RunEchoAdapter("", io.Discard); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("missing callback URL", func(t *testing.T) {
+		path := writeJobSpec(t, shared.JobSpec{JobID: "job-1"})
+		if err := RunEchoAdapter(path, io.Discard); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("rejected event", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusConflict)
+		}))
+		t.Cleanup(srv.Close)
+		callbackURL := srv.URL
+		path := writeJobSpec(t, shared.JobSpec{JobID: "job-1", CallbackURL: &callbackURL})
+		if err := RunEchoAdapter(path, io.Discard); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
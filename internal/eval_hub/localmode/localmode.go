@@ -0,0 +1,137 @@
+package localmode
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const (
+	// EchoProviderID is the ID of the bundled demo provider registered in local mode.
+	EchoProviderID = "echo"
+	// EchoBenchmarkID is the single benchmark offered by the echo provider.
+	EchoBenchmarkID = "echo"
+
+	// DatabaseFileName is the SQLite database created in the data directory.
+	DatabaseFileName = "evalhub.db"
+
+	// envDatabaseURL overrides the database URL (see env_mappings in config.yaml).
+	envDatabaseURL = "DB_URL"
+)
+
+// DefaultDataDir returns ~/.evalhub, where local mode keeps its state.
+func DefaultDataDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".evalhub"), nil
+}
+
+// ProvisionDatabase points the service at an on-disk SQLite database in dataDir when the
+// configuration still uses the bundled in-memory database, so that local mode keeps jobs,
+// providers and collections across restarts. An explicit DB_URL or a non in-memory
+// database configuration is left untouched. Returns the path of the database file, or ""
+// when the configuration was not changed.
+func ProvisionDatabase(logger *slog.Logger, conf *config.Config, dataDir string) (string, error) {
+	if dataDir == "" || os.Getenv(envDatabaseURL) != "" {
+		return "", nil
+	}
+	if conf.Database != nil {
+		database := *conf.Database
+		driver, _ := database["driver"].(string)
+		url, _ := database["url"].(string)
+		if driver != "sqlite" || !strings.Contains(url, "mode=memory") {
+			return "", nil
+		}
+	}
+
+	if err := os.MkdirAll(dataDir, 0o700); err != nil {
+		return "", fmt.Errorf("create data directory %s: %w", dataDir, err)
+	}
+	path, err := filepath.Abs(filepath.Join(dataDir, DatabaseFileName))
+	if err != nil {
+		return "", fmt.Errorf("resolve database path: %w", err)
+	}
+
+	database := map[string]any{}
+	if conf.Database != nil {
+		for key, value := range *conf.Database {
+			database[key] = value
+		}
+	}
+	database["driver"] = "sqlite"
+	database["url"] = "file:" + filepath.ToSlash(path)
+	conf.Database = &database
+
+	logger.Info("Using on-disk SQLite database for local mode", "path", path)
+	return path, nil
+}
+
+// EchoProvider returns the bundled demo provider. Its single benchmark runs command, which
+// is expected to start the echo adapter (see RunEchoAdapter), so a job completes without
+// any model server or evaluation framework installed.
+func EchoProvider(command string) api.ProviderResource {
+	return api.ProviderResource{
+		Resource: api.Resource{
+			ID:    EchoProviderID,
+			Owner: abstractions.OwnerSystem,
+		},
+		ProviderConfig: api.ProviderConfig{
+			Name:        "Echo",
+			Title:       "Echo demo provider",
+			Description: "Bundled local mode demo provider that completes immediately and echoes the job parameters",
+			Tags:        []string{"demo", "local"},
+			Benchmarks: []api.BenchmarkResource{
+				{
+					ID:          EchoBenchmarkID,
+					Name:        "Echo",
+					Description: "Reports a fixed score of 1.0 and echoes the benchmark parameters",
+					Category:    "demo",
+					Metrics:     []string{"score"},
+					PrimaryScore: &api.PrimaryScore{
+						Metric: "score",
+					},
+				},
+			},
+			Runtime: &api.Runtime{
+				Local: &api.LocalRuntime{
+					Command: command,
+				},
+			},
+		},
+	}
+}
+
+// EchoCommand returns the shell command that runs the echo adapter with the given executable.
+func EchoCommand(executable string, flag string) string {
+	return fmt.Sprintf("%s -%s", shellQuote(executable), flag)
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
+// Quickstart returns the message printed once the local mode server is listening.
+func Quickstart(baseURL string, databasePath string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\neval-hub is running in local mode at %s\n", baseURL)
+	fmt.Fprintf(&sb, "  API docs:  %s/docs\n", baseURL)
+	fmt.Fprintf(&sb, "  Results:   %s/ui/\n", baseURL)
+	if databasePath != "" {
+		fmt.Fprintf(&sb, "  Database:  %s\n", databasePath)
+	}
+	sb.WriteString("\nRun the bundled echo demo provider:\n\n")
+	fmt.Fprintf(&sb, `  curl -s -X POST %s/api/v1/evaluations/jobs \
+    -H 'Content-Type: application/json' \
+    -d '{"name":"quickstart","model":{"url":"http://localhost:8000/v1","name":"demo"},"benchmarks":[{"id":"%s","provider_id":"%s"}]}'
+`, baseURL, EchoBenchmarkID, EchoProviderID)
+	fmt.Fprintf(&sb, "\n  curl -s %s/api/v1/evaluations/jobs\n\n", baseURL)
+	return sb.String()
+}
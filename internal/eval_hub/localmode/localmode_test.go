@@ -0,0 +1,111 @@
+package localmode
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/logging"
+)
+
+func TestProvisionDatabase(t *testing.T) {
+	logger := logging.FallbackLogger()
+
+	t.Run("replaces the in-memory sqlite database", func(t *testing.T) {
+		t.Setenv(envDatabaseURL, "")
+		dataDir := filepath.Join(t.TempDir(), "evalhub")
+		conf := &config.Config{Database: &map[string]any{
+			"driver":         "sqlite",
+			"url":            "file::eval_hub:?mode=memory&cache=shared",
+			"max_open_conns": 1,
+		}}
+
+		path, err := ProvisionDatabase(logger, conf, dataDir)
+		if err != nil {
+			t.Fatalf("ProvisionDatabase: %v", err)
+		}
+		if path != filepath.Join(dataDir, DatabaseFileName) {
+			t.Errorf("path = %q, want %q", path, filepath.Join(dataDir, DatabaseFileName))
+		}
+		database := *conf.Database
+		if database["url"] != "file:"+filepath.ToSlash(path) {
+			t.Errorf("url = %v", database["url"])
+		}
+		if database["max_open_conns"] != 1 {
+			t.Errorf("expected other database settings to be kept, got %v", database)
+		}
+	})
+
+	t.Run("keeps a configured database", func(t *testing.T) {
+		t.Setenv(envDatabaseURL, "")
+		conf := &config.Config{Database: &map[string]any{
+			"driver": "pgx",
+			"url":    "postgres://user@localhost:5432/eval_hub",
+		}}
+
+		path, err := ProvisionDatabase(logger, conf, t.TempDir())
+		if err != nil {
+			t.Fatalf("ProvisionDatabase: %v", err)
+		}
+		if path != "" || (*conf.Database)["driver"] != "pgx" {
+			t.Errorf("expected database to be left untouched, got path %q config %v", path, *conf.Database)
+		}
+	})
+
+	t.Run("keeps the database when DB_URL is set", func(t *testing.T) {
+		t.Setenv(envDatabaseURL, "file::other:?mode=memory&cache=shared")
+		conf := &config.Config{Database: &map[string]any{
+			"driver": "sqlite",
+			"url":    "file::other:?mode=memory&cache=shared",
+		}}
+
+		path, err := ProvisionDatabase(logger, conf, t.TempDir())
+		if err != nil {
+			t.Fatalf("ProvisionDatabase: %v", err)
+		}
+		if path != "" {
+			t.Errorf("expected no provisioning, got %q", path)
+		}
+	})
+}
+
+func TestEchoProvider(t *testing.T) {
+	provider := EchoProvider("echo-cmd")
+	if !provider.Resource.IsSystemResource() {
+		t.Error("expected the echo provider to be a system provider")
+	}
+	if provider.Runtime == nil || provider.Runtime.Local == nil || provider.Runtime.Local.Command != "echo-cmd" {
+		t.Errorf("unexpected runtime: %+v", provider.Runtime)
+	}
+	if len(provider.Benchmarks) != 1 || provider.Benchmarks[0].ID != EchoBenchmarkID {
+		t.Errorf("unexpected benchmarks: %+v", provider.Benchmarks)
+	}
+}
+
+func TestEchoCommandQuotesExecutable(t *testing.T) {
+	command := EchoCommand("/opt/eval hub/it's/eval-hub", "echo-adapter")
+	out, err := exec.Command("sh", "-c", "printf '%s\\n' "+command).Output()
+	if err != nil {
+		t.Fatalf("sh: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 || lines[0] != "/opt/eval hub/it's/eval-hub" || lines[1] != "-echo-adapter" {
+		t.Errorf("unexpected shell words: %q", lines)
+	}
+}
+
+func TestQuickstart(t *testing.T) {
+	msg := Quickstart("http://127.0.0.1:8080", "/home/user/.evalhub/evalhub.db")
+	for _, want := range []string{
+		"http://127.0.0.1:8080/docs",
+		"http://127.0.0.1:8080/ui/",
+		"/home/user/.evalhub/evalhub.db",
+		`"provider_id":"echo"`,
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("quickstart missing %q:\n%s", want, msg)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package messages
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// DefaultLocale is the locale of the messages declared in this package. It is served
+// when the client does not ask for a locale that has a catalog.
+const DefaultLocale = "en"
+
+// Catalog holds the translations of the user-facing messages for one locale.
+//
+// Errors is keyed by message code (e.g. resource_not_found) and holds templates with
+// the same parameters as the English message. Status is keyed by the English text of
+// the status messages set by the server (e.g. "Evaluation job created"). Messages
+// without a translation are served in English.
+type Catalog struct {
+	locale string
+	errors map[string]*template.Template
+	status map[string]string
+}
+
+// NewCatalog creates the catalog of a locale, checking that every error translation
+// belongs to a known message code and is a valid template.
+func NewCatalog(locale string, errors map[string]string, status map[string]string) (*Catalog, error) {
+	locale = normalizeLocale(locale)
+	if locale == "" {
+		return nil, fmt.Errorf("the message catalog locale is required")
+	}
+	c := &Catalog{
+		locale: locale,
+		errors: make(map[string]*template.Template, len(errors)),
+		status: make(map[string]string, len(status)),
+	}
+	for code, text := range errors {
+		if !slices.ContainsFunc(registry, func(m *MessageCode) bool { return m.code == code }) {
+			return nil, fmt.Errorf("message catalog %s: unknown message code %q", locale, code)
+		}
+		tmpl, err := template.New(code).Parse(text)
+		if err != nil {
+			return nil, fmt.Errorf("message catalog %s: invalid message %q: %w", locale, code, err)
+		}
+		c.errors[code] = tmpl
+	}
+	for message, translation := range status {
+		if translation != "" {
+			c.status[message] = translation
+		}
+	}
+	return c, nil
+}
+
+// GetLocale returns the locale of the catalog, DefaultLocale for a nil catalog.
+func (c *Catalog) GetLocale() string {
+	if c == nil {
+		return DefaultLocale
+	}
+	return c.locale
+}
+
+// ErrorMessage renders the message in the locale of the catalog and returns it with
+// its locale. A nil catalog, or one without a translation for the message, renders the
+// English message.
+func (c *Catalog) ErrorMessage(messageCode *MessageCode, messageParams ...any) (string, string) {
+	if c != nil {
+		if tmpl, ok := c.errors[messageCode.code]; ok {
+			if msg, err := render(tmpl, messageParams...); err == nil {
+				return msg, c.locale
+			}
+		}
+	}
+	return GetErrorMessage(messageCode, messageParams...), DefaultLocale
+}
+
+// StatusMessage returns the translation of a status message set by the server, or the
+// message itself when the catalog has none.
+func (c *Catalog) StatusMessage(message string) string {
+	if c != nil {
+		if translation, ok := c.status[message]; ok {
+			return translation
+		}
+	}
+	return message
+}
+
+// Catalogs are the message catalogs of the service, keyed by locale.
+type Catalogs map[string]*Catalog
+
+// Negotiate returns the catalog to serve for an Accept-Language header value, or nil
+// when the client prefers English or no locale of the header has a catalog. A language
+// tag also matches the catalog of its base language, e.g. de-AT is served from de.
+func (c Catalogs) Negotiate(acceptLanguage string) *Catalog {
+	if len(c) == 0 || acceptLanguage == "" {
+		return nil
+	}
+	for _, tag := range parseAcceptLanguage(acceptLanguage) {
+		if tag == "*" {
+			return nil
+		}
+		base, _, _ := strings.Cut(tag, "-")
+		if catalog, ok := c[tag]; ok {
+			return catalog
+		}
+		if base == DefaultLocale {
+			return nil
+		}
+		if catalog, ok := c[base]; ok {
+			return catalog
+		}
+	}
+	return nil
+}
+
+// parseAcceptLanguage returns the language tags of an Accept-Language header value
+// ordered by preference, leaving out the ones with a zero quality.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = normalizeLocale(tag)
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+	result := make([]string, 0, len(tags))
+	for _, t := range tags {
+		result = append(result, t.tag)
+	}
+	return result
+}
+
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
@@ -0,0 +1,93 @@
+package messages_test
+
+import (
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+)
+
+func newTestCatalog(t *testing.T, locale string) *messages.Catalog {
+	t.Helper()
+	catalog, err := messages.NewCatalog(locale,
+		map[string]string{
+			"resource_not_found": "Die Ressource {{.Type}} '{{.ResourceId}}' wurde nicht gefunden.",
+		},
+		map[string]string{
+			"Evaluation job created": "Evaluierungsjob erstellt",
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+	return catalog
+}
+
+func TestCatalogErrorMessage(t *testing.T) {
+	catalog := newTestCatalog(t, "de")
+
+	msg, locale := catalog.ErrorMessage(messages.ResourceNotFound, "Type", "job", "ResourceId", "j1")
+	if msg != "Die Ressource job 'j1' wurde nicht gefunden." || locale != "de" {
+		t.Errorf("got %q (%s)", msg, locale)
+	}
+
+	// messages without a translation fall back to English
+	msg, locale = catalog.ErrorMessage(messages.MissingPathParameter, "ParameterName", "id")
+	if msg != messages.GetErrorMessage(messages.MissingPathParameter, "ParameterName", "id") || locale != messages.DefaultLocale {
+		t.Errorf("got %q (%s)", msg, locale)
+	}
+
+	var none *messages.Catalog
+	msg, locale = none.ErrorMessage(messages.ResourceNotFound, "Type", "job", "ResourceId", "j1")
+	if msg != "The job resource 'j1' was not found." || locale != messages.DefaultLocale {
+		t.Errorf("nil catalog: got %q (%s)", msg, locale)
+	}
+}
+
+func TestCatalogStatusMessage(t *testing.T) {
+	catalog := newTestCatalog(t, "de")
+	if got := catalog.StatusMessage("Evaluation job created"); got != "Evaluierungsjob erstellt" {
+		t.Errorf("got %q", got)
+	}
+	if got := catalog.StatusMessage("Evaluation job is pending"); got != "Evaluation job is pending" {
+		t.Errorf("untranslated message: got %q", got)
+	}
+}
+
+func TestNewCatalogRejectsInvalidTranslations(t *testing.T) {
+	if _, err := messages.NewCatalog("de", map[string]string{"no_such_code": "x"}, nil); err == nil {
+		t.Error("expected an error for an unknown message code")
+	}
+	if _, err := messages.NewCatalog("de", map[string]string{"resource_not_found": "{{.Type"}, nil); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+	if _, err := messages.NewCatalog(" ", nil, nil); err == nil {
+		t.Error("expected an error for an empty locale")
+	}
+}
+
+func TestCatalogsNegotiate(t *testing.T) {
+	catalogs := messages.Catalogs{
+		"de":    newTestCatalog(t, "de"),
+		"pt-br": newTestCatalog(t, "pt_BR"),
+	}
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", messages.DefaultLocale},
+		{"de", "de"},
+		{"de-AT", "de"},
+		{"pt-BR,pt;q=0.9", "pt-br"},
+		{"pt", messages.DefaultLocale},
+		{"fr, de;q=0.5", "de"},
+		{"en-US, de;q=0.8", messages.DefaultLocale},
+		{"de;q=0.3, en;q=0.7", messages.DefaultLocale},
+		{"de;q=0, fr", messages.DefaultLocale},
+		{"*", messages.DefaultLocale},
+	}
+	for _, tt := range tests {
+		if got := catalogs.Negotiate(tt.header).GetLocale(); got != tt.want {
+			t.Errorf("Negotiate(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
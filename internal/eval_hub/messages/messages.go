@@ -2,6 +2,8 @@ package messages
 
 import (
 	"bytes"
+	"slices"
+	"strings"
 	"text/template"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
@@ -10,6 +12,12 @@ import (
 // This package provides all the error messages that should be reported to the user.
 // Note that we add a comment with the message parameters so that it is possible
 // to see the parameters in the IDE when creating an error message.
+//
+// The messages form the error registry of the API: each error response carries the
+// stable error code of its message (EVAL_ followed by the upper-cased message code, e.g.
+// EVAL_RESOURCE_NOT_FOUND), a link to the description of the code in docs/errors.md and
+// whether the request may succeed when retried unchanged. Error codes must never be
+// renamed once released, clients branch on them; docs/errors.md must list every code.
 var (
 	// API errors that are not storage specific
 
@@ -107,6 +115,55 @@ var (
 		"resource_does_not_exist",
 	)
 
+	// InvalidMetricReference The primary score of benchmark '{{.BenchmarkID}}' of provider '{{.ProviderID}}' is not valid: {{.Reason}}.
+	InvalidMetricReference = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The primary score of benchmark '{{.BenchmarkID}}' of provider '{{.ProviderID}}' is not valid: {{.Reason}}.",
+		"invalid_metric_reference",
+	)
+
+	// InvalidShardIndex The shard index {{.ShardIndex}} is out of range for benchmark '{{.BenchmarkID}}', which has {{.Shards}} shards.
+	InvalidShardIndex = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The shard index {{.ShardIndex}} is out of range for benchmark '{{.BenchmarkID}}', which has {{.Shards}} shards.",
+		"invalid_shard_index",
+	)
+
+	// InvalidBenchmarkDependency The benchmark '{{.BenchmarkID}}' at index {{.BenchmarkIndex}} cannot depend on benchmark index {{.DependsOn}}: {{.Reason}}.
+	InvalidBenchmarkDependency = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The benchmark '{{.BenchmarkID}}' at index {{.BenchmarkIndex}} cannot depend on benchmark index {{.DependsOn}}: {{.Reason}}.",
+		"invalid_benchmark_dependency",
+	)
+
+	// InvalidSweep The sweep is not valid: {{.Reason}}.
+	InvalidSweep = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The sweep is not valid: {{.Reason}}.",
+		"invalid_sweep",
+	)
+
+	// BaselineAlreadyExists The baseline '{{.Name}}' already exists.
+	BaselineAlreadyExists = createMessage(
+		constants.HTTPCodeConflict,
+		"The baseline '{{.Name}}' already exists.",
+		"baseline_already_exists",
+	)
+
+	// InvalidBaselineJob The job '{{.JobID}}' cannot be a baseline: {{.Reason}}.
+	InvalidBaselineJob = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The job '{{.JobID}}' cannot be a baseline: {{.Reason}}.",
+		"invalid_baseline_job",
+	)
+
+	// ReviewNotPending The evaluation job '{{.EvaluationJobID}}' has no pending review, its review is '{{.State}}'.
+	ReviewNotPending = createMessage(
+		constants.HTTPCodeConflict,
+		"The evaluation job '{{.EvaluationJobID}}' has no pending review, its review is '{{.State}}'.",
+		"review_not_pending",
+	)
+
 	// LocalRuntimeNotEnabled Local runtime is not enabled for provider '{{.ProviderID}}'. Please configure a local runtime command for this provider and try again.
 	LocalRuntimeNotEnabled = createMessage(
 		constants.HTTPCodeBadRequest,
@@ -114,6 +171,13 @@ var (
 		"local_runtime_not_enabled",
 	)
 
+	// InferenceServiceNotSupported The model of the evaluation job is the InferenceService '{{.Name}}', which only the Kubernetes runtime resolves. Please give the model url instead.
+	InferenceServiceNotSupported = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The model of the evaluation job is the InferenceService '{{.Name}}', which only the Kubernetes runtime resolves. Please give the model url instead.",
+		"inference_service_not_supported",
+	)
+
 	// ProviderIDNotUnique The provider ID '{{.ProviderID}}' is not unique.
 	ProviderIDNotUnique = createMessage(
 		constants.HTTPCodeBadRequest,
@@ -149,6 +213,188 @@ var (
 		"mlflow_request_failed",
 	)
 
+	// ArtifactStoreUnavailable The artifacts of the evaluation job '{{.EvaluationJobID}}' cannot be stored: {{.Reason}}.
+	ArtifactStoreUnavailable = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The artifacts of the evaluation job '{{.EvaluationJobID}}' cannot be stored: {{.Reason}}.",
+		"artifact_store_unavailable",
+	)
+
+	// GenerationsNotEnabled The raw generations of the evaluation jobs cannot be stored, the artifacts.generations_key is not configured.
+	GenerationsNotEnabled = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The raw generations of the evaluation jobs cannot be stored, the artifacts.generations_key is not configured.",
+		"generations_not_enabled",
+	)
+
+	// NotifyTargetUnknown The notify target '{{.Target}}' is not a configured notifier or the channel of one.
+	NotifyTargetUnknown = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The notify target '{{.Target}}' is not a configured notifier or the channel of one.",
+		"notify_target_unknown",
+	)
+
+	// JobEnvNotAllowed The environment variable '{{.Name}}' of the evaluation job is not allowed: {{.Reason}}.
+	JobEnvNotAllowed = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The environment variable '{{.Name}}' of the evaluation job is not allowed: {{.Reason}}.",
+		"job_env_not_allowed",
+	)
+
+	// MLFlowWorkspaceNotAllowed The jobs of the tenant '{{.Tenant}}' cannot write to the MLflow workspace '{{.Workspace}}', only to {{.Workspaces}}.
+	MLFlowWorkspaceNotAllowed = createMessage(
+		constants.HTTPCodeForbidden,
+		"The jobs of the tenant '{{.Tenant}}' cannot write to the MLflow workspace '{{.Workspace}}', only to {{.Workspaces}}.",
+		"mlflow_workspace_not_allowed",
+	)
+
+	// TooManyBenchmarks The evaluation job has {{.Count}} benchmarks, more than the maximum of {{.MaxBenchmarks}}.
+	TooManyBenchmarks = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The evaluation job has {{.Count}} benchmarks, more than the maximum of {{.MaxBenchmarks}}.",
+		"too_many_benchmarks",
+	)
+
+	// InlineEvaluationNotSupported The evaluation job cannot be run inline: {{.Reason}}.
+	InlineEvaluationNotSupported = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The evaluation job cannot be run inline: {{.Reason}}.",
+		"inline_evaluation_not_supported",
+	)
+
+	// JobSpecVersionNotSupported The adapter of provider '{{.ProviderID}}' cannot run the benchmark '{{.BenchmarkID}}': {{.Reason}}.
+	JobSpecVersionNotSupported = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The adapter of provider '{{.ProviderID}}' cannot run the benchmark '{{.BenchmarkID}}': {{.Reason}}.",
+		"job_spec_version_not_supported",
+	)
+
+	// RuntimeNotEnabled The runtime '{{.Runtime}}' is not enabled, the enabled runtimes are: {{.EnabledRuntimes}}.
+	RuntimeNotEnabled = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The runtime '{{.Runtime}}' is not enabled, the enabled runtimes are: {{.EnabledRuntimes}}.",
+		"runtime_not_enabled",
+	)
+
+	// ClusterNotRegistered The cluster '{{.Cluster}}' is not registered, the registered clusters are: {{.Clusters}}.
+	ClusterNotRegistered = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The cluster '{{.Cluster}}' is not registered, the registered clusters are: {{.Clusters}}.",
+		"cluster_not_registered",
+	)
+
+	// RuntimeNotSupported The provider '{{.ProviderID}}' can not run on the runtime '{{.Runtime}}'.
+	RuntimeNotSupported = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The provider '{{.ProviderID}}' can not run on the runtime '{{.Runtime}}'.",
+		"runtime_not_supported",
+	)
+
+	// SecretRefsNotSupported The benchmark '{{.BenchmarkID}}' of provider '{{.ProviderID}}' has secretRef:// parameters, which the runtime '{{.Runtime}}' can not resolve.
+	SecretRefsNotSupported = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The benchmark '{{.BenchmarkID}}' of provider '{{.ProviderID}}' has secretRef:// parameters, which the runtime '{{.Runtime}}' can not resolve.",
+		"secret_refs_not_supported",
+	)
+
+	// CallbackTokenInvalid The callback token for evaluation job '{{.EvaluationJobID}}' is missing or invalid.
+	CallbackTokenInvalid = createMessage(
+		constants.HTTPCodeUnauthorized,
+		"The callback token for evaluation job '{{.EvaluationJobID}}' is missing or invalid.",
+		"callback_token_invalid",
+	)
+
+	// InvalidArchive The archive is not valid: {{.Reason}}.
+	InvalidArchive = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The archive is not valid: {{.Reason}}.",
+		"invalid_archive",
+	)
+
+	// InvalidProviderBundle The provider bundle is not valid: {{.Reason}}.
+	InvalidProviderBundle = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The provider bundle is not valid: {{.Reason}}.",
+		"invalid_provider_bundle",
+	)
+
+	// ProviderAlreadyExists The provider '{{.ProviderID}}' already exists, import the bundle with another id.
+	ProviderAlreadyExists = createMessage(
+		constants.HTTPCodeConflict,
+		"The provider '{{.ProviderID}}' already exists, import the bundle with another id.",
+		"provider_already_exists",
+	)
+
+	// JobAccessDenied The user '{{.User}}' cannot {{.Action}} the evaluation job '{{.EvaluationJobID}}', only its owner can.
+	JobAccessDenied = createMessage(
+		constants.HTTPCodeForbidden,
+		"The user '{{.User}}' cannot {{.Action}} the evaluation job '{{.EvaluationJobID}}', only its owner can.",
+		"job_access_denied",
+	)
+
+	// ReviewAccessDenied The user '{{.User}}' cannot review the evaluation job '{{.EvaluationJobID}}': {{.Reason}}.
+	ReviewAccessDenied = createMessage(
+		constants.HTTPCodeForbidden,
+		"The user '{{.User}}' cannot review the evaluation job '{{.EvaluationJobID}}': {{.Reason}}.",
+		"review_access_denied",
+	)
+
+	// TagPolicyViolation The tags of the {{.Type}} do not follow the tag policy of the tenant: {{.Reason}}.
+	TagPolicyViolation = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The tags of the {{.Type}} do not follow the tag policy of the tenant: {{.Reason}}.",
+		"tag_policy_violation",
+	)
+
+	// TagPolicyAccessDenied The user '{{.User}}' cannot change the tag policy of the tenant, only the members of the admin groups can.
+	TagPolicyAccessDenied = createMessage(
+		constants.HTTPCodeForbidden,
+		"The user '{{.User}}' cannot change the tag policy of the tenant, only the members of the admin groups can.",
+		"tag_policy_access_denied",
+	)
+
+	// RedactionPolicyInvalid The redaction policy is invalid: {{.Error}}.
+	RedactionPolicyInvalid = createMessage(
+		constants.HTTPCodeBadRequest,
+		"The redaction policy is invalid: {{.Error}}.",
+		"redaction_policy_invalid",
+	)
+
+	// RedactionPolicyAccessDenied The user '{{.User}}' cannot change the redaction policy of the tenant, only the members of the admin groups can.
+	RedactionPolicyAccessDenied = createMessage(
+		constants.HTTPCodeForbidden,
+		"The user '{{.User}}' cannot change the redaction policy of the tenant, only the members of the admin groups can.",
+		"redaction_policy_access_denied",
+	)
+
+	// GenerationsAccessDenied The user '{{.User}}' cannot read the raw generations of the evaluation jobs, only the members of the generation reader groups can.
+	GenerationsAccessDenied = createMessage(
+		constants.HTTPCodeForbidden,
+		"The user '{{.User}}' cannot read the raw generations of the evaluation jobs, only the members of the generation reader groups can.",
+		"generations_access_denied",
+	)
+
+	// AdmissionDenied The evaluation job was rejected by the admission webhook '{{.Webhook}}': '{{.Reason}}'.
+	AdmissionDenied = createMessage(
+		constants.HTTPCodeForbidden,
+		"The evaluation job was rejected by the admission webhook '{{.Webhook}}': '{{.Reason}}'.",
+		"admission_denied",
+	)
+
+	// AdmissionWebhookFailed The admission webhook '{{.Webhook}}' failed: '{{.Error}}'.
+	AdmissionWebhookFailed = createRetriableMessage(
+		constants.HTTPCodeInternalServerError,
+		"The admission webhook '{{.Webhook}}' failed: '{{.Error}}'.",
+		"admission_webhook_failed",
+	)
+
+	// ServiceInMaintenance The service is in maintenance and does not accept new evaluation jobs: '{{.Message}}'.
+	ServiceInMaintenance = createRetriableMessage(
+		constants.HTTPCodeServiceUnavailable,
+		"The service is in maintenance and does not accept new evaluation jobs: '{{.Message}}'.",
+		"service_in_maintenance",
+	)
+
 	// Configuration related errors
 
 	// ConfigurationFailed The service startup failed: '{{.Error}}'.
@@ -167,17 +413,24 @@ var (
 		"json_unmarshalling_failed",
 	)
 
+	// RequestTimeout The request did not complete within its deadline of {{.Timeout}}, it ran for {{.Elapsed}}. Completed steps: {{.Diagnostics}}.
+	RequestTimeout = createRetriableMessage(
+		constants.HTTPCodeGatewayTimeout,
+		"The request did not complete within its deadline of {{.Timeout}}, it ran for {{.Elapsed}}. Completed steps: {{.Diagnostics}}.",
+		"request_timeout",
+	)
+
 	// Storage related errors
 
 	// DatabaseOperationFailed The request for the {{.Type}} resource {{.ResourceId}} failed: '{{.Error}}'.
-	DatabaseOperationFailed = createMessage(
+	DatabaseOperationFailed = createRetriableMessage(
 		constants.HTTPCodeInternalServerError,
 		"The request for the {{.Type}} resource {{.ResourceId}} failed: '{{.Error}}'.",
 		"database_operation_failed",
 	)
 
 	// QueryFailed The request for the {{.Type}} failed: '{{.Error}}'.
-	QueryFailed = createMessage(
+	QueryFailed = createRetriableMessage(
 		constants.HTTPCodeInternalServerError,
 		"The request for the {{.Type}} failed: '{{.Error}}'.",
 		"query_failed",
@@ -240,12 +493,22 @@ var (
 	)
 )
 
+// ErrorDocsURL is the page that describes the error codes, with one section per code.
+const ErrorDocsURL = "https://github.com/eval-hub/eval-hub/blob/main/docs/errors.md"
+
+// errorCodePrefix is prepended to the upper-cased message codes to form the error codes.
+const errorCodePrefix = "EVAL_"
+
 type MessageCode struct {
-	status int
-	one    string
-	code   string
+	status    int
+	one       string
+	code      string
+	retriable bool
 }
 
+// registry holds all the messages, in the order they are declared.
+var registry []*MessageCode
+
 func (m *MessageCode) GetStatusCode() int {
 	return m.status
 }
@@ -258,16 +521,53 @@ func (m *MessageCode) GetMessage() string {
 	return m.one
 }
 
+// GetErrorCode returns the stable, machine-readable error code of the message.
+func (m *MessageCode) GetErrorCode() string {
+	return errorCodePrefix + strings.ToUpper(m.code)
+}
+
+// GetDocsURL returns the link to the description of the error code.
+func (m *MessageCode) GetDocsURL() string {
+	return ErrorDocsURL + "#" + strings.ToLower(m.GetErrorCode())
+}
+
+// IsRetriable returns true when the request may succeed if it is retried unchanged, i.e.
+// the error comes from a transient failure of the service or one of its dependencies.
+func (m *MessageCode) IsRetriable() bool {
+	return m.retriable
+}
+
+// Registry returns all the messages, in the order they are declared.
+func Registry() []*MessageCode {
+	return slices.Clone(registry)
+}
+
 func createMessage(status int, one string, code string) *MessageCode {
-	return &MessageCode{
-		status,
-		one,
-		code,
+	m := &MessageCode{
+		status: status,
+		one:    one,
+		code:   code,
 	}
+	registry = append(registry, m)
+	return m
+}
+
+func createRetriableMessage(status int, one string, code string) *MessageCode {
+	m := createMessage(status, one, code)
+	m.retriable = true
+	return m
 }
 
 func GetErrorMessage(messageCode *MessageCode, messageParams ...any) string {
-	msg := messageCode.GetMessage()
+	tmpl, _ := template.New("errmfs").Parse(messageCode.GetMessage())
+	msg, err := render(tmpl, messageParams...)
+	if err != nil {
+		return "INVALID TEMPLATE"
+	}
+	return msg
+}
+
+func render(tmpl *template.Template, messageParams ...any) (string, error) {
 	params := make(map[string]any)
 	for i := 0; i < len(messageParams); i += 2 {
 		param := messageParams[i]
@@ -280,11 +580,9 @@ func GetErrorMessage(messageCode *MessageCode, messageParams ...any) string {
 		params[param.(string)] = paramValue
 	}
 
-	tmpl, _ := template.New("errmfs").Parse(msg)
 	out := bytes.NewBuffer(nil)
-	err := tmpl.Execute(out, params)
-	if err != nil {
-		return "INVALID TEMPLATE"
+	if err := tmpl.Execute(out, params); err != nil {
+		return "", err
 	}
-	return out.String()
+	return out.String(), nil
 }
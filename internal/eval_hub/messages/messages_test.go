@@ -0,0 +1,54 @@
+package messages_test
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+)
+
+var errorCodePattern = regexp.MustCompile(`^EVAL_[A-Z0-9_]+$`)
+
+func TestRegistryErrorCodes(t *testing.T) {
+	registry := messages.Registry()
+	if len(registry) == 0 {
+		t.Fatal("expected a non-empty message registry")
+	}
+	seen := make(map[string]bool, len(registry))
+	for _, m := range registry {
+		code := m.GetErrorCode()
+		if !errorCodePattern.MatchString(code) {
+			t.Errorf("error code %q of message %q does not match %s", code, m.GetCode(), errorCodePattern)
+		}
+		if seen[code] {
+			t.Errorf("error code %q is declared more than once", code)
+		}
+		seen[code] = true
+		if want := messages.ErrorDocsURL + "#" + strings.ToLower(code); m.GetDocsURL() != want {
+			t.Errorf("docs URL of %q: got %q want %q", code, m.GetDocsURL(), want)
+		}
+	}
+}
+
+func TestRegistryIsDocumented(t *testing.T) {
+	doc, err := os.ReadFile("../../../docs/errors.md")
+	if err != nil {
+		t.Fatalf("read docs/errors.md: %v", err)
+	}
+	for _, m := range messages.Registry() {
+		if !strings.Contains(string(doc), "\n### "+m.GetErrorCode()+"\n") {
+			t.Errorf("docs/errors.md has no section for %s", m.GetErrorCode())
+		}
+	}
+}
+
+func TestRetriableMessages(t *testing.T) {
+	if !messages.DatabaseOperationFailed.IsRetriable() {
+		t.Error("DatabaseOperationFailed should be retriable")
+	}
+	if messages.RequestValidationFailed.IsRetriable() {
+		t.Error("RequestValidationFailed should not be retriable")
+	}
+}
@@ -0,0 +1,39 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Admission webhook outcomes recorded by RecordAdmissionWebhookCall.
+const (
+	AdmissionOutcomeAllowed = "allowed"
+	AdmissionOutcomeMutated = "mutated"
+	AdmissionOutcomeDenied  = "denied"
+	AdmissionOutcomeError   = "error"
+	AdmissionOutcomeIgnored = "ignored"
+)
+
+var admissionWebhookCallsTotal metric.Int64Counter
+
+func initAdmissionMetrics(meter metric.Meter) error {
+	var err error
+	admissionWebhookCallsTotal, err = meter.Int64Counter(
+		"evalhub.admission_webhook_calls",
+		metric.WithDescription("Evaluation job admission webhook calls by outcome"),
+	)
+	return err
+}
+
+// RecordAdmissionWebhookCall records the outcome of a call to an admission webhook.
+func RecordAdmissionWebhookCall(ctx context.Context, webhook string, outcome string) {
+	if admissionWebhookCallsTotal == nil {
+		return
+	}
+	admissionWebhookCallsTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("webhook", webhook),
+		attribute.String("outcome", outcome),
+	))
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var circuitBreakerTransitionsTotal metric.Int64Counter
+
+func initCircuitBreakerMetrics(meter metric.Meter) error {
+	var err error
+	circuitBreakerTransitionsTotal, err = meter.Int64Counter(
+		"evalhub.circuit_breaker_transitions",
+		metric.WithDescription("Circuit breaker state transitions by outbound dependency"),
+	)
+	return err
+}
+
+// RecordCircuitBreakerTransition records that the circuit breaker of an outbound dependency
+// entered state.
+func RecordCircuitBreakerTransition(ctx context.Context, dependency string, state string) {
+	if circuitBreakerTransitionsTotal == nil {
+		return
+	}
+	circuitBreakerTransitionsTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("dependency", dependency),
+		attribute.String("state", state),
+	))
+}
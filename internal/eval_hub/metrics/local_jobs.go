@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	localJobsDiskUsage     metric.Int64Gauge
+	localJobsRemovedTotal  metric.Int64Counter
+	localJobsQuotaExceeded metric.Int64Counter
+)
+
+func initLocalJobsMetrics(meter metric.Meter) error {
+	var err error
+	localJobsDiskUsage, err = meter.Int64Gauge(
+		"evalhub.local_jobs_disk_usage",
+		metric.WithDescription("Disk used by the files of the jobs of the local runtime"),
+		metric.WithUnit("By"),
+	)
+	if err != nil {
+		return err
+	}
+
+	localJobsRemovedTotal, err = meter.Int64Counter(
+		"evalhub.local_jobs_removed",
+		metric.WithDescription("Job directories of the local runtime removed past their retention"),
+	)
+	if err != nil {
+		return err
+	}
+
+	localJobsQuotaExceeded, err = meter.Int64Counter(
+		"evalhub.local_jobs_quota_exceeded",
+		metric.WithDescription("Benchmarks of the local runtime failed on a disk quota, by quota"),
+	)
+	return err
+}
+
+// RecordLocalJobsDiskUsage records the disk used by the job directories of the local runtime.
+func RecordLocalJobsDiskUsage(ctx context.Context, bytes int64) {
+	if localJobsDiskUsage == nil {
+		return
+	}
+	localJobsDiskUsage.Record(ctx, bytes)
+}
+
+// RecordLocalJobsRemoved counts the job directories removed by the retention of the local
+// runtime.
+func RecordLocalJobsRemoved(ctx context.Context, count int) {
+	if localJobsRemovedTotal == nil || count == 0 {
+		return
+	}
+	localJobsRemovedTotal.Add(ctx, int64(count))
+}
+
+// RecordLocalJobsQuotaExceeded counts a benchmark failed on the job or total disk quota.
+func RecordLocalJobsQuotaExceeded(ctx context.Context, quota string) {
+	if localJobsQuotaExceeded == nil {
+		return
+	}
+	localJobsQuotaExceeded.Add(ctx, 1, metric.WithAttributes(attribute.String("quota", quota)))
+}
@@ -14,6 +14,7 @@ const instrumentationScope = "github.com/eval-hub/eval-hub/internal/eval_hub/met
 var (
 	evaluationJobsTotal         metric.Int64Counter
 	evaluationJobCompletions    metric.Int64Counter
+	evaluationJobTransitions    metric.Int64Counter
 	benchmarkRuntimeErrorsTotal metric.Int64Counter
 )
 
@@ -38,6 +39,14 @@ func Init() error {
 		return err
 	}
 
+	evaluationJobTransitions, err = meter.Int64Counter(
+		"evalhub.evaluation_job_transitions",
+		metric.WithDescription("Evaluation job state transitions"),
+	)
+	if err != nil {
+		return err
+	}
+
 	benchmarkRuntimeErrorsTotal, err = meter.Int64Counter(
 		"evalhub.benchmark_runtime_errors",
 		metric.WithDescription("Benchmark scheduling or start errors by runtime"),
@@ -46,6 +55,34 @@ func Init() error {
 		return err
 	}
 
+	if err := initProviderHealthMetrics(meter); err != nil {
+		return err
+	}
+
+	if err := initAdmissionMetrics(meter); err != nil {
+		return err
+	}
+
+	if err := initCircuitBreakerMetrics(meter); err != nil {
+		return err
+	}
+
+	if err := initResultCacheMetrics(meter); err != nil {
+		return err
+	}
+
+	if err := initStatusEventMetrics(meter); err != nil {
+		return err
+	}
+
+	if err := initStorageMetrics(meter); err != nil {
+		return err
+	}
+
+	if err := initLocalJobsMetrics(meter); err != nil {
+		return err
+	}
+
 	return initHTTPMetrics(meter)
 }
 
@@ -94,6 +131,17 @@ func RecordEvaluationJobTerminalState(ctx context.Context, previous, newState ap
 	))
 }
 
+// RecordEvaluationJobTransition records a change of the state of a job.
+func RecordEvaluationJobTransition(ctx context.Context, from, to api.OverallState) {
+	if evaluationJobTransitions == nil {
+		return
+	}
+	evaluationJobTransitions.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("from", string(from)),
+		attribute.String("to", string(to)),
+	))
+}
+
 // RecordBenchmarkRuntimeError increments the counter when a runtime fails to schedule or start a benchmark.
 func RecordBenchmarkRuntimeError(ctx context.Context, runtime string) {
 	if benchmarkRuntimeErrorsTotal == nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/metrics"
 	"github.com/eval-hub/eval-hub/pkg/api"
@@ -28,8 +29,19 @@ func TestInitCreatesEvaluationJobInstruments(t *testing.T) {
 	metrics.RecordEvaluationJobCancelled(ctx)
 	metrics.RecordEvaluationJobRuntimeStartFailed(ctx, "local")
 	metrics.RecordEvaluationJobTerminalState(ctx, api.OverallStateRunning, api.OverallStateCompleted)
+	metrics.RecordEvaluationJobTransition(ctx, api.OverallStatePending, api.OverallStateRunning)
 	metrics.RecordBenchmarkRuntimeError(ctx, "kubernetes")
+	lastSuccess := time.Now()
+	metrics.RecordProviderHealthCheck(ctx, "lm_evaluation_harness", &api.ProviderHealth{
+		Status:        api.ProviderHealthStatusHealthy,
+		LastSuccessAt: &lastSuccess,
+	})
 	metrics.RecordHTTPServerRequest(ctx, http.MethodGet, "/api/v1/health", http.StatusOK)
+	metrics.RecordStorageStatement(ctx, "sqlite", "select", "evaluations", time.Millisecond, true)
+	metrics.RecordStorageTransaction(ctx, "sqlite", "update evaluation job", time.Millisecond, false)
+	metrics.RecordLocalJobsDiskUsage(ctx, 1024)
+	metrics.RecordLocalJobsRemoved(ctx, 1)
+	metrics.RecordLocalJobsQuotaExceeded(ctx, "total")
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/api/v1/health", nil)
 	metrics.IncHTTPServerActiveRequests(ctx, req)
 	metrics.DecHTTPServerActiveRequests(ctx, req)
@@ -49,9 +61,19 @@ func TestInitCreatesEvaluationJobInstruments(t *testing.T) {
 	for _, want := range []string{
 		"evalhub.evaluation_jobs",
 		"evalhub.evaluation_job_completions",
+		"evalhub.evaluation_job_transitions",
 		"evalhub.benchmark_runtime_errors",
+		"evalhub.provider_health_checks",
+		"evalhub.provider_health_failure_streak",
+		"evalhub.provider_health_last_success",
 		"http.server.request.count",
 		"http.server.active_requests",
+		"evalhub.storage_statement_duration",
+		"evalhub.storage_transaction_duration",
+		"evalhub.storage_errors",
+		"evalhub.local_jobs_disk_usage",
+		"evalhub.local_jobs_removed",
+		"evalhub.local_jobs_quota_exceeded",
 	} {
 		if _, ok := names[want]; !ok {
 			t.Errorf("missing metric %q", want)
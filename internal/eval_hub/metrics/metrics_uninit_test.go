@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
@@ -16,7 +17,16 @@ func TestRecordMetricsBeforeInitNoPanic(t *testing.T) {
 	RecordEvaluationJobRuntimeStartFailed(ctx, "kubernetes")
 	RecordEvaluationJobTerminalState(ctx, api.OverallStateRunning, api.OverallStateCompleted)
 	RecordBenchmarkRuntimeError(ctx, "local")
+	RecordProviderHealthCheck(ctx, "lm_evaluation_harness", &api.ProviderHealth{Status: api.ProviderHealthStatusHealthy})
+	RecordAdmissionWebhookCall(ctx, "approved-benchmarks", AdmissionOutcomeDenied)
+	RecordCircuitBreakerTransition(ctx, "mlflow", "open")
+	RecordResultCacheLookup(ctx, "lm_evaluation_harness", true)
+	RecordStorageStatement(ctx, "sqlite", "select", "evaluations", time.Millisecond, false)
+	RecordStorageTransaction(ctx, "sqlite", "update evaluation job", time.Millisecond, true)
 	RecordHTTPServerRequest(ctx, http.MethodGet, "/health", http.StatusOK)
+	RecordLocalJobsDiskUsage(ctx, 1024)
+	RecordLocalJobsRemoved(ctx, 1)
+	RecordLocalJobsQuotaExceeded(ctx, "job")
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/health", nil)
 	if err != nil {
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	providerHealthChecksTotal   metric.Int64Counter
+	providerHealthFailureStreak metric.Int64Gauge
+	providerHealthLastSuccess   metric.Float64Gauge
+)
+
+func initProviderHealthMetrics(meter metric.Meter) error {
+	var err error
+	providerHealthChecksTotal, err = meter.Int64Counter(
+		"evalhub.provider_health_checks",
+		metric.WithDescription("Provider canary evaluations by outcome"),
+	)
+	if err != nil {
+		return err
+	}
+
+	providerHealthFailureStreak, err = meter.Int64Gauge(
+		"evalhub.provider_health_failure_streak",
+		metric.WithDescription("Consecutive failed canary evaluations per provider"),
+	)
+	if err != nil {
+		return err
+	}
+
+	providerHealthLastSuccess, err = meter.Float64Gauge(
+		"evalhub.provider_health_last_success",
+		metric.WithDescription("Unix time of the last successful canary evaluation per provider"),
+		metric.WithUnit("s"),
+	)
+	return err
+}
+
+// RecordProviderHealthCheck records the outcome of a provider canary evaluation together with
+// the resulting failure streak and last success time.
+func RecordProviderHealthCheck(ctx context.Context, providerID string, health *api.ProviderHealth) {
+	if providerHealthChecksTotal == nil || health == nil {
+		return
+	}
+	provider := attribute.String("provider_id", providerID)
+	providerHealthChecksTotal.Add(ctx, 1, metric.WithAttributes(
+		provider,
+		attribute.String("status", string(health.Status)),
+	))
+	providerHealthFailureStreak.Record(ctx, int64(health.FailureStreak), metric.WithAttributes(provider))
+	if health.LastSuccessAt != nil {
+		providerHealthLastSuccess.Record(ctx, float64(health.LastSuccessAt.Unix()), metric.WithAttributes(provider))
+	}
+}
@@ -0,0 +1,34 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var resultCacheLookupsTotal metric.Int64Counter
+
+func initResultCacheMetrics(meter metric.Meter) error {
+	var err error
+	resultCacheLookupsTotal, err = meter.Int64Counter(
+		"evalhub.result_cache_lookups",
+		metric.WithDescription("Benchmark result cache lookups on job creation by hit or miss"),
+	)
+	return err
+}
+
+// RecordResultCacheLookup records a benchmark result cache lookup for a new job.
+func RecordResultCacheLookup(ctx context.Context, providerID string, hit bool) {
+	if resultCacheLookupsTotal == nil {
+		return
+	}
+	outcome := "miss"
+	if hit {
+		outcome = "hit"
+	}
+	resultCacheLookupsTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("provider_id", providerID),
+		attribute.String("outcome", outcome),
+	))
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var statusEventsIgnoredTotal metric.Int64Counter
+
+func initStatusEventMetrics(meter metric.Meter) error {
+	var err error
+	statusEventsIgnoredTotal, err = meter.Int64Counter(
+		"evalhub.status_events_ignored",
+		metric.WithDescription("Benchmark status events ignored as duplicate or out of order"),
+	)
+	return err
+}
+
+// RecordStatusEventIgnored records a status event that was not applied, by reason.
+func RecordStatusEventIgnored(ctx context.Context, providerID string, reason string) {
+	if statusEventsIgnoredTotal == nil {
+		return
+	}
+	statusEventsIgnoredTotal.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("provider_id", providerID),
+		attribute.String("reason", reason),
+	))
+}
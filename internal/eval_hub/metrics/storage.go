@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	storageStatementDuration   metric.Float64Histogram
+	storageTransactionDuration metric.Float64Histogram
+	storageErrorsTotal         metric.Int64Counter
+)
+
+func initStorageMetrics(meter metric.Meter) error {
+	var err error
+	storageStatementDuration, err = meter.Float64Histogram(
+		"evalhub.storage_statement_duration",
+		metric.WithDescription("Duration of the database statements by operation and table"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	storageTransactionDuration, err = meter.Float64Histogram(
+		"evalhub.storage_transaction_duration",
+		metric.WithDescription("Duration of the database transactions by name, retries included"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return err
+	}
+
+	storageErrorsTotal, err = meter.Int64Counter(
+		"evalhub.storage_errors",
+		metric.WithDescription("Failed database statements and transactions"),
+	)
+	return err
+}
+
+// RecordStorageStatement records the duration of a database statement, and counts it as an
+// error when it failed.
+func RecordStorageStatement(ctx context.Context, driver, operation, table string, duration time.Duration, failed bool) {
+	if storageStatementDuration == nil {
+		return
+	}
+	attributes := metric.WithAttributes(
+		attribute.String("driver", driver),
+		attribute.String("operation", operation),
+		attribute.String("table", table),
+	)
+	storageStatementDuration.Record(ctx, duration.Seconds(), attributes)
+	if failed {
+		storageErrorsTotal.Add(ctx, 1, attributes)
+	}
+}
+
+// RecordStorageTransaction records the duration of a database transaction, and counts it as
+// an error when it failed.
+func RecordStorageTransaction(ctx context.Context, driver, name string, duration time.Duration, failed bool) {
+	if storageTransactionDuration == nil {
+		return
+	}
+	storageTransactionDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(
+		attribute.String("driver", driver),
+		attribute.String("transaction", name),
+	))
+	if failed {
+		storageErrorsTotal.Add(ctx, 1, metric.WithAttributes(
+			attribute.String("driver", driver),
+			attribute.String("operation", "transaction"),
+			attribute.String("transaction", name),
+		))
+	}
+}
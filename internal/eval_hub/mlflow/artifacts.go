@@ -3,6 +3,7 @@ package mlflow
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/eval-hub/eval-hub/pkg/mlflowclient"
@@ -64,6 +65,71 @@ func UploadArtifactToExperiment(
 	)
 }
 
+// BuildJobArtifactPath returns the MLflow proxied artifact path of an intermediate artifact
+// of a benchmark of a job. The artifacts of a job are kept under its experiment rather than
+// under a run, since the hub does not know the runs that the adapters create.
+func BuildJobArtifactPath(experimentID, jobID string, benchmarkIndex int, name, artifactLocation string) string {
+	suffix := fmt.Sprintf("%s/eval-hub/jobs/%s/benchmarks/%d/%s", experimentID, jobID, benchmarkIndex, name)
+	prefix := ArtifactLocationPathPrefix(artifactLocation)
+	if prefix == "" {
+		return suffix
+	}
+	return prefix + "/" + suffix
+}
+
+// UploadJobArtifact streams content to the path of an intermediate artifact of a benchmark
+// of a job, see BuildJobArtifactPath, and returns the URL of the artifact.
+func UploadJobArtifact(
+	client *mlflowclient.Client,
+	experimentID string,
+	jobID string,
+	benchmarkIndex int,
+	name string,
+	artifactLocation string,
+	content io.Reader,
+	contentType string,
+) (string, error) {
+	if client == nil {
+		return "", fmt.Errorf("mlflow client is nil")
+	}
+	if strings.TrimSpace(experimentID) == "" {
+		return "", fmt.Errorf("experiment id is required")
+	}
+	if err := client.EnsureWorkspace(); err != nil {
+		return "", err
+	}
+	return client.UploadArtifact(
+		BuildJobArtifactPath(experimentID, jobID, benchmarkIndex, name, artifactLocation),
+		content,
+		contentType,
+	)
+}
+
+// JobGenerationsArtifactName returns the name, under the benchmark of a job, of the raw
+// generations with the name. They are kept apart from the other artifacts of the benchmark.
+func JobGenerationsArtifactName(name string) string {
+	return "generations/" + name
+}
+
+// DownloadJobArtifact returns the content of an intermediate artifact of a benchmark of a
+// job, see BuildJobArtifactPath. The caller closes it.
+func DownloadJobArtifact(
+	client *mlflowclient.Client,
+	experimentID string,
+	jobID string,
+	benchmarkIndex int,
+	name string,
+	artifactLocation string,
+) (io.ReadCloser, error) {
+	if client == nil {
+		return nil, fmt.Errorf("mlflow client is nil")
+	}
+	if strings.TrimSpace(experimentID) == "" {
+		return nil, fmt.Errorf("experiment id is required")
+	}
+	return client.DownloadArtifact(BuildJobArtifactPath(experimentID, jobID, benchmarkIndex, name, artifactLocation))
+}
+
 // CreateEvaluationCardRun creates a new MLflow run for storing evaluation card artifacts.
 func CreateEvaluationCardRun(client *mlflowclient.Client, experimentID, jobID, runName string) (string, error) {
 	if client == nil {
@@ -26,6 +26,22 @@ func TestBuildRunArtifactPath(t *testing.T) {
 	}
 }
 
+func TestBuildJobArtifactPath(t *testing.T) {
+	t.Parallel()
+
+	got := BuildJobArtifactPath("8", "job-1", 2, "predictions.jsonl", "")
+	want := "8/eval-hub/jobs/job-1/benchmarks/2/predictions.jsonl"
+	if got != want {
+		t.Fatalf("path = %q, want %q", got, want)
+	}
+
+	got = BuildJobArtifactPath("8", "job-1", 2, "predictions.jsonl", "/mlflow/artifacts/workspaces/sagar/8")
+	want = "mlflow/artifacts/workspaces/sagar/8/8/eval-hub/jobs/job-1/benchmarks/2/predictions.jsonl"
+	if got != want {
+		t.Fatalf("path with artifact location = %q, want %q", got, want)
+	}
+}
+
 func TestArtifactLocationPathPrefix(t *testing.T) {
 	t.Parallel()
 
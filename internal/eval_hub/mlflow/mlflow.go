@@ -4,13 +4,16 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/eval-hub/eval-hub/internal/eval_hub/circuitbreaker"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
@@ -21,6 +24,12 @@ import (
 
 const workspaceProbeTimeout = 5 * time.Second
 
+// circuitBreakerName names MLflow in the logs and metrics of its circuit breaker.
+const circuitBreakerName = "mlflow"
+
+// proxyDestination names the proxy settings of the MLflow connections in the proxy config.
+const proxyDestination = config.ProxyDestinationMLFlow
+
 func SetupMLFlowClient(config *config.Config, logger *slog.Logger) (*mlflowclient.Client, string, string, error) {
 	mlflowClient, err := NewMLFlowClient(config, logger)
 	if err != nil {
@@ -84,11 +93,17 @@ func NewMLFlowClient(config *config.Config, logger *slog.Logger) (*mlflowclient.
 		config.MLFlow.TLSConfig = tlsConfig
 	}
 
+	if err := config.MLFlow.CircuitBreaker.Validate("mlflow.circuit_breaker"); err != nil {
+		return nil, err
+	}
+	// the breaker fails the requests fast while MLflow is down, see IsUnavailable
+	breaker := circuitbreaker.New(circuitBreakerName, config.MLFlow.CircuitBreaker, logger)
 	httpClient := &http.Client{
 		Timeout: config.MLFlow.HTTPTimeout,
-		Transport: &http.Transport{
+		Transport: breaker.Transport(&http.Transport{
 			TLSClientConfig: config.MLFlow.TLSConfig,
-		},
+			Proxy:           config.Proxy.ProxyFunc(proxyDestination),
+		}),
 	}
 
 	client := mlflowclient.NewClient(url).
@@ -203,7 +218,7 @@ func GetOrCreateExperimentID(mlflowClient *mlflowclient.Client, jobConfig *api.E
 	}
 
 	if err := mlflowClient.EnsureWorkspace(); err != nil {
-		return "", "", serviceerrors.NewServiceError(messages.MLFlowRequestFailed, "Error", err.Error())
+		return "", "", requestFailed(err)
 	}
 
 	tags := injectEvaluationJobTags(jobId, jobConfig)
@@ -214,9 +229,64 @@ func GetOrCreateExperimentID(mlflowClient *mlflowclient.Client, jobConfig *api.E
 	}
 	mlflowExperiment, err := mlflowClient.GetOrCreateExperiment(&req)
 	if err != nil {
-		return "", "", serviceerrors.NewServiceError(messages.MLFlowRequestFailed, "Error", err.Error())
+		return "", "", requestFailed(err)
 	}
 
 	mlflowClient.GetLogger().Info("Resolved experiment", "experiment_name", jobConfig.Experiment.Name, "experiment_id", mlflowExperiment.Experiment.ExperimentID)
 	return mlflowExperiment.Experiment.ExperimentID, mlflowClient.GetExperimentsURL(), nil
 }
+
+// GetRun gets an MLflow run with its metrics. A run that does not exist is reported as
+// ResourceNotFound.
+func GetRun(mlflowClient *mlflowclient.Client, runID string) (*mlflowclient.Run, error) {
+	resp, err := mlflowClient.GetRun(runID)
+	if err != nil {
+		if mlflowclient.IsResourceDoesNotExistError(err) {
+			return nil, serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "MLflow run", "ResourceId", runID)
+		}
+		return nil, requestFailed(err)
+	}
+	return &resp.Run, nil
+}
+
+// UnavailableError is the service error of an MLflow request that failed because MLflow is
+// unavailable, rather than because of the request: its circuit breaker is open, it could not
+// be reached, or it answered with a server error.
+type UnavailableError struct {
+	*serviceerrors.ServiceError
+	cause error
+}
+
+func (e *UnavailableError) Unwrap() []error {
+	return []error{e.ServiceError, e.cause}
+}
+
+// IsUnavailable is true when err is the error of an MLflow request that failed because MLflow
+// is unavailable, which a retry may get past.
+func IsUnavailable(err error) bool {
+	var unavailable *UnavailableError
+	return errors.As(err, &unavailable)
+}
+
+// requestFailed returns the service error of a failed MLflow request, an UnavailableError when
+// MLflow is unavailable.
+func requestFailed(err error) error {
+	serviceErr := serviceerrors.NewServiceError(messages.MLFlowRequestFailed, "Error", err.Error())
+	if isOutage(err) {
+		return &UnavailableError{ServiceError: serviceErr, cause: err}
+	}
+	return serviceErr
+}
+
+func isOutage(err error) bool {
+	if errors.Is(err, circuitbreaker.ErrOpen) {
+		return true
+	}
+	var apiErr *mlflowclient.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= http.StatusInternalServerError || apiErr.StatusCode == http.StatusTooManyRequests
+	}
+	// the client could not reach MLflow, unless the request was cancelled by its caller
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled)
+}
@@ -9,8 +9,11 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/eval-hub/eval-hub/internal/eval_hub/circuitbreaker"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
@@ -316,7 +319,61 @@ func TestGetOrCreateExperimentID(t *testing.T) {
 			Experiment: &api.ExperimentConfig{Name: "demo"},
 		}, "job-1")
 		assertServiceErrorCode(t, err, messages.MLFlowRequestFailed)
+		if !IsUnavailable(err) {
+			t.Fatalf("expected a server error to make MLflow unavailable, got %v", err)
+		}
+	})
+
+	t.Run("client error is not an outage", func(t *testing.T) {
+		t.Parallel()
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"error_code":"PERMISSION_DENIED","message":"denied"}`, http.StatusForbidden)
+		}))
+		t.Cleanup(srv.Close)
+
+		client := mlflowclient.NewClient(srv.URL).WithContext(t.Context()).WithLogger(logger)
+		_, _, err := GetOrCreateExperimentID(client, &api.EvaluationJobConfig{
+			Experiment: &api.ExperimentConfig{Name: "demo"},
+		}, "job-1")
+		assertServiceErrorCode(t, err, messages.MLFlowRequestFailed)
+		if IsUnavailable(err) {
+			t.Fatalf("expected a denied request not to make MLflow unavailable, got %v", err)
+		}
+	})
+}
+
+func TestNewMLFlowClientCircuitBreaker(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, `{"error_code":"INTERNAL_ERROR","message":"down"}`, http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := mlflowServiceConfig(t, srv.URL, func(m *config.MLFlowConfig) {
+		m.CircuitBreaker = &config.CircuitBreakerConfig{FailureThreshold: 2, OpenDuration: time.Hour}
 	})
+	client, err := NewMLFlowClient(cfg, discardTestLogger())
+	if err != nil {
+		t.Fatalf("NewMLFlowClient() err = %v", err)
+	}
+	jobConfig := &api.EvaluationJobConfig{Experiment: &api.ExperimentConfig{Name: "demo"}}
+
+	// the workspace probe and the first lookup fail, which opens the breaker
+	_, _, err = GetOrCreateExperimentID(client.WithContext(t.Context()), jobConfig, "job-1")
+	if !IsUnavailable(err) {
+		t.Fatalf("expected MLflow to be unavailable, got %v", err)
+	}
+	before := calls.Load()
+	_, _, err = GetOrCreateExperimentID(client.WithContext(t.Context()), jobConfig, "job-2")
+	if !IsUnavailable(err) || !errors.Is(err, circuitbreaker.ErrOpen) {
+		t.Fatalf("expected the open breaker to fail the request, got %v", err)
+	}
+	if calls.Load() != before {
+		t.Fatalf("expected no request to MLflow while the breaker is open, got %d more", calls.Load()-before)
+	}
+	assertServiceErrorCode(t, err, messages.MLFlowRequestFailed)
 }
 
 func assertServiceErrorCode(t *testing.T, err error, want *messages.MessageCode) {
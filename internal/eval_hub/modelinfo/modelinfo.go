@@ -0,0 +1,173 @@
+// Package modelinfo reads the metadata of the model that a job evaluates, so that the results
+// of the job tell what was evaluated and not only the URL of its endpoint.
+package modelinfo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// Timeout bounds the requests to the model server, which is not slowed by a job that starts.
+const Timeout = 10 * time.Second
+
+// AnnotationPrefix prefixes the annotations of an InferenceService that describe its model,
+// e.g. eval-hub.github.io/model-quantization.
+const AnnotationPrefix = "eval-hub.github.io/model-"
+
+const maxResponseSize = 1 << 20
+
+// modelsList is the OpenAI-compatible list of the models of a server. max_model_len is an
+// extension of vLLM, context_length of other servers.
+type modelsList struct {
+	Data []listedModel `json:"data"`
+}
+
+type listedModel struct {
+	ID            string `json:"id"`
+	Root          string `json:"root"`
+	MaxModelLen   int    `json:"max_model_len"`
+	ContextLength int    `json:"context_length"`
+	Quantization  string `json:"quantization"`
+}
+
+// find returns the model named name, or the only model of the server, which may serve it
+// under another name.
+func (l *modelsList) find(name string) *listedModel {
+	for i := range l.Data {
+		if l.Data[i].ID == name {
+			return &l.Data[i]
+		}
+	}
+	if len(l.Data) == 1 {
+		return &l.Data[0]
+	}
+	return nil
+}
+
+// Fetch reads the metadata of the model named modelName from the OpenAI-compatible
+// endpoints of the server at modelURL: /v1/models, and /version as served by vLLM. The token
+// is sent as the bearer token when set. It returns an error when the models list cannot be
+// read, the version is optional.
+func Fetch(ctx context.Context, client *http.Client, modelURL string, modelName string, token string) (*api.ModelMetadata, error) {
+	root, models := endpoints(modelURL)
+
+	var list modelsList
+	if err := getJSON(ctx, client, models, token, &list); err != nil {
+		return nil, err
+	}
+	model := list.find(modelName)
+	if model == nil {
+		return nil, fmt.Errorf("the models list of %s has no model %q", models, modelName)
+	}
+	metadata := &api.ModelMetadata{
+		ServedModelID:    model.ID,
+		MaxContextLength: model.MaxModelLen,
+		Quantization:     model.Quantization,
+	}
+	// a root that is a path, e.g. /mnt/models on KServe, does not name the model
+	if model.Root != model.ID && !strings.HasPrefix(model.Root, "/") {
+		metadata.BaseModel = model.Root
+	}
+	if metadata.MaxContextLength == 0 {
+		metadata.MaxContextLength = model.ContextLength
+	}
+
+	var version struct {
+		Version string `json:"version"`
+	}
+	if err := getJSON(ctx, client, root+"/version", token, &version); err == nil {
+		metadata.ServerVersion = version.Version
+	}
+	metadata.CapturedAt = api.DateTimeToString(time.Now())
+	return metadata, nil
+}
+
+// FromAnnotations returns the metadata of the model declared by the annotations of its
+// deployment, nil when there are none.
+func FromAnnotations(annotations map[string]string) *api.ModelMetadata {
+	metadata := &api.ModelMetadata{
+		ServedModelID: strings.TrimSpace(annotations[AnnotationPrefix+"served-model-id"]),
+		BaseModel:     strings.TrimSpace(annotations[AnnotationPrefix+"base-model"]),
+		Quantization:  strings.TrimSpace(annotations[AnnotationPrefix+"quantization"]),
+		ServerVersion: strings.TrimSpace(annotations[AnnotationPrefix+"server-version"]),
+	}
+	if maxContext, err := strconv.Atoi(strings.TrimSpace(annotations[AnnotationPrefix+"max-context-length"])); err == nil && maxContext > 0 {
+		metadata.MaxContextLength = maxContext
+	}
+	if metadata.IsEmpty() {
+		return nil
+	}
+	metadata.CapturedAt = api.DateTimeToString(time.Now())
+	return metadata
+}
+
+// Merge completes the metadata reported by the server with the declared metadata, the server
+// is trusted over the declarations. Either may be nil.
+func Merge(reported, declared *api.ModelMetadata) *api.ModelMetadata {
+	if reported == nil {
+		return declared
+	}
+	if declared == nil {
+		return reported
+	}
+	merged := *reported
+	if merged.ServedModelID == "" {
+		merged.ServedModelID = declared.ServedModelID
+	}
+	if merged.BaseModel == "" {
+		merged.BaseModel = declared.BaseModel
+	}
+	if merged.Quantization == "" {
+		merged.Quantization = declared.Quantization
+	}
+	if merged.MaxContextLength == 0 {
+		merged.MaxContextLength = declared.MaxContextLength
+	}
+	if merged.ServerVersion == "" {
+		merged.ServerVersion = declared.ServerVersion
+	}
+	return &merged
+}
+
+// endpoints returns the root URL of the server and the URL of its models list, for a model
+// URL given with or without its /v1 path.
+func endpoints(modelURL string) (root string, models string) {
+	base := strings.TrimSuffix(strings.TrimSpace(modelURL), "/")
+	root = strings.TrimSuffix(base, "/v1")
+	return root, root + "/v1/models"
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, token string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("GET %s returned %d", url, resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("GET %s did not return JSON: %w", url, err)
+	}
+	return nil
+}
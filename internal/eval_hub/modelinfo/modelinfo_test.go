@@ -0,0 +1,104 @@
+package modelinfo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/models":
+			_, _ = w.Write([]byte(`{"object":"list","data":[
+				{"id":"granite-lora","root":"ibm-granite/granite-3.1-8b-instruct","max_model_len":8192},
+				{"id":"llama","root":"llama","context_length":4096}]}`))
+		case "/version":
+			_, _ = w.Write([]byte(`{"version":"0.8.5"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	for _, modelURL := range []string{server.URL, server.URL + "/v1", server.URL + "/v1/"} {
+		metadata, err := Fetch(context.Background(), server.Client(), modelURL, "granite-lora", "secret")
+		if err != nil {
+			t.Fatalf("Fetch(%s): %v", modelURL, err)
+		}
+		if metadata.ServedModelID != "granite-lora" || metadata.BaseModel != "ibm-granite/granite-3.1-8b-instruct" ||
+			metadata.MaxContextLength != 8192 || metadata.ServerVersion != "0.8.5" || metadata.CapturedAt == "" {
+			t.Errorf("Fetch(%s) = %+v", modelURL, metadata)
+		}
+	}
+
+	metadata, err := Fetch(context.Background(), server.Client(), server.URL, "llama", "secret")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if metadata.BaseModel != "" || metadata.MaxContextLength != 4096 {
+		t.Errorf("expected no base model and the context_length, got %+v", metadata)
+	}
+
+	if _, err := Fetch(context.Background(), server.Client(), server.URL, "mistral", "secret"); err == nil {
+		t.Error("expected an error for a model the server does not serve")
+	}
+	if _, err := Fetch(context.Background(), server.Client(), server.URL, "llama", ""); err == nil {
+		t.Error("expected an error without the token")
+	}
+}
+
+func TestFetchOnlyModel(t *testing.T) {
+	// the only model of a server is the model of the job, whatever its name
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"/mnt/models","root":"/mnt/models","quantization":"awq"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	metadata, err := Fetch(context.Background(), server.Client(), server.URL, "granite", "")
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if metadata.ServedModelID != "/mnt/models" || metadata.BaseModel != "" || metadata.Quantization != "awq" || metadata.ServerVersion != "" {
+		t.Errorf("Fetch = %+v", metadata)
+	}
+}
+
+func TestFromAnnotationsAndMerge(t *testing.T) {
+	if FromAnnotations(map[string]string{"serving.kserve.io/deploymentMode": "RawDeployment"}) != nil {
+		t.Error("expected no metadata without the annotations")
+	}
+	declared := FromAnnotations(map[string]string{
+		AnnotationPrefix + "base-model":         "ibm-granite/granite-3.1-8b-instruct",
+		AnnotationPrefix + "quantization":       " fp8 ",
+		AnnotationPrefix + "max-context-length": "not-a-number",
+		AnnotationPrefix + "server-version":     "0.8.4",
+	})
+	if declared == nil || declared.Quantization != "fp8" || declared.MaxContextLength != 0 {
+		t.Fatalf("FromAnnotations = %+v", declared)
+	}
+
+	reported := &api.ModelMetadata{ServedModelID: "granite", MaxContextLength: 8192, ServerVersion: "0.8.5"}
+	merged := Merge(reported, declared)
+	if merged.ServedModelID != "granite" || merged.BaseModel != "ibm-granite/granite-3.1-8b-instruct" ||
+		merged.Quantization != "fp8" || merged.MaxContextLength != 8192 || merged.ServerVersion != "0.8.5" {
+		t.Errorf("Merge = %+v, the server is trusted over the annotations", merged)
+	}
+	if reported.BaseModel != "" {
+		t.Error("Merge must not change the reported metadata")
+	}
+	if Merge(nil, declared) != declared || Merge(reported, nil) != reported || Merge(nil, nil) != nil {
+		t.Error("Merge of nil metadata")
+	}
+}
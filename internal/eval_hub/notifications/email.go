@@ -0,0 +1,102 @@
+package notifications
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+)
+
+// emailNotifier sends the summaries by email through an SMTP server, using STARTTLS when
+// the server offers it.
+type emailNotifier struct {
+	host     string
+	addr     string
+	username string
+	password string
+	from     string
+	to       []string
+	// tlsConfig is replaced by the tests to trust their server.
+	tlsConfig *tls.Config
+}
+
+func newEmailNotifier(cfg *config.EmailNotifierConfig) *emailNotifier {
+	return &emailNotifier{
+		host:      cfg.Host,
+		addr:      net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.EffectivePort())),
+		username:  cfg.Username,
+		password:  cfg.Password,
+		from:      cfg.From,
+		to:        cfg.To,
+		tlsConfig: &tls.Config{ServerName: cfg.Host, MinVersion: tls.VersionTLS12},
+	}
+}
+
+func (n *emailNotifier) Notify(ctx context.Context, summary *Summary) error {
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+	// net/smtp has no context, the deadline of the connection bounds the whole exchange
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(n.tlsConfig); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+	if n.username != "" {
+		// PlainAuth refuses to send the credentials without TLS, except to localhost
+		if err := client.Auth(smtp.PlainAuth("", n.username, n.password, n.host)); err != nil {
+			return fmt.Errorf("auth: %w", err)
+		}
+	}
+	if err := client.Mail(n.from); err != nil {
+		return err
+	}
+	for _, to := range n.to {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("recipient %s: %w", to, err)
+		}
+	}
+	data, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := data.Write(n.message(summary, time.Now())); err != nil {
+		return err
+	}
+	if err := data.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// message is the RFC 5322 message of the summary.
+func (n *emailNotifier) message(summary *Summary, now time.Time) []byte {
+	var message strings.Builder
+	fmt.Fprintf(&message, "From: %s\r\n", n.from)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(n.to, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", summary.Title()))
+	fmt.Fprintf(&message, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(strings.ReplaceAll(summary.Text(), "\n", "\r\n"))
+	return []byte(message.String())
+}
@@ -0,0 +1,152 @@
+// Package notifications sends a summary of an evaluation job to Slack or by email when the
+// job reaches a terminal state, to the notifiers the job names in notify and to those of its
+// tenant. It is a subscriber of the event bus.
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/events"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// Notifier delivers a summary to its destination.
+type Notifier interface {
+	Notify(ctx context.Context, summary *Summary) error
+}
+
+// Dispatcher sends the summaries of the jobs to their notifiers.
+type Dispatcher struct {
+	logger    *slog.Logger
+	config    *config.NotificationsConfig
+	notifiers map[string]Notifier
+	sending   sync.WaitGroup
+}
+
+// NewDispatcher returns the dispatcher for the configured notifiers, or nil when there are
+// none. The Slack notifiers are called through the notifications proxy of the proxy config.
+func NewDispatcher(logger *slog.Logger, cfg *config.NotificationsConfig, proxyConfig *config.ProxyConfig) (*Dispatcher, error) {
+	if !cfg.IsEnabled() {
+		return nil, nil
+	}
+
+	d := &Dispatcher{
+		logger:    logger,
+		config:    cfg,
+		notifiers: map[string]Notifier{},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxyConfig.ProxyFunc(config.ProxyDestinationNotifications)
+	client := &http.Client{Transport: transport}
+	for _, notifierConfig := range cfg.Notifiers {
+		if notifierConfig.Name == "" {
+			return nil, fmt.Errorf("notifiers require a name")
+		}
+		if _, found := d.notifiers[notifierConfig.Name]; found {
+			return nil, fmt.Errorf("duplicate notifier name %q", notifierConfig.Name)
+		}
+		notifier, err := newNotifier(notifierConfig, client)
+		if err != nil {
+			return nil, fmt.Errorf("notifier %q: %w", notifierConfig.Name, err)
+		}
+		d.notifiers[notifierConfig.Name] = notifier
+	}
+	for tenant, targets := range cfg.Tenants {
+		for _, target := range targets {
+			if cfg.Notifier(target) == nil {
+				return nil, fmt.Errorf("the notify target %q of tenant %q is not a configured notifier or the channel of one", target, tenant)
+			}
+		}
+	}
+	return d, nil
+}
+
+func newNotifier(cfg config.NotifierConfig, client *http.Client) (Notifier, error) {
+	switch cfg.Type {
+	case config.NotifierTypeSlack:
+		if cfg.Slack == nil || cfg.Slack.WebhookURL == "" {
+			return nil, fmt.Errorf("slack notifiers require slack.webhook_url")
+		}
+		return &slackNotifier{webhookURL: cfg.Slack.WebhookURL, client: client}, nil
+	case config.NotifierTypeEmail:
+		if cfg.Email == nil || cfg.Email.Host == "" || cfg.Email.From == "" || len(cfg.Email.To) == 0 {
+			return nil, fmt.Errorf("email notifiers require email.host, email.from and email.to")
+		}
+		return newEmailNotifier(cfg.Email), nil
+	default:
+		return nil, fmt.Errorf("unsupported notifier type %q", cfg.Type)
+	}
+}
+
+// HandleEvent is the events.Handler that sends the summary of a job when it reaches a
+// terminal state, and again when the review of its score is decided. The summaries are sent
+// in the background, by the replica that published the event only.
+func (d *Dispatcher) HandleEvent(event events.Event) {
+	if !event.IsLocal() || event.Job == nil {
+		return
+	}
+	switch event.Type {
+	case events.JobCompleted:
+	case events.JobReviewed:
+		if review := jobReview(event.Job); review == nil || review.State == api.ReviewStatePending {
+			return
+		}
+	default:
+		return
+	}
+
+	targets := d.targets(event.Job)
+	if len(targets) == 0 {
+		return
+	}
+	summary := NewSummary(event.Job, d.config.BaseURL)
+	for _, target := range targets {
+		d.sending.Add(1)
+		go func() {
+			defer d.sending.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), target.EffectiveTimeout())
+			defer cancel()
+			if err := d.notifiers[target.Name].Notify(ctx, summary); err != nil {
+				d.logger.Warn("Failed to send evaluation job notification", "job_id", event.JobID, "notifier", target.Name, "error", err)
+				return
+			}
+			d.logger.Info("Sent evaluation job notification", "job_id", event.JobID, "notifier", target.Name, "state", summary.State)
+		}()
+	}
+}
+
+// Wait waits for the notifications being sent, at shutdown.
+func (d *Dispatcher) Wait() {
+	d.sending.Wait()
+}
+
+// targets returns the notifiers of the job and of its tenant, each once. The targets of the
+// job were checked when it was created, those that are no longer configured are skipped.
+func (d *Dispatcher) targets(job *api.EvaluationJobResource) []*config.NotifierConfig {
+	names := slices.Concat(job.Notify, d.config.Tenants[job.Resource.Tenant.String()])
+	targets := make([]*config.NotifierConfig, 0, len(names))
+	for _, name := range names {
+		target := d.config.Notifier(name)
+		if target == nil {
+			d.logger.Warn("Skipped unknown notify target of evaluation job", "job_id", job.Resource.ID, "target", name)
+			continue
+		}
+		if !slices.ContainsFunc(targets, func(t *config.NotifierConfig) bool { return t.Name == target.Name }) {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+func jobReview(job *api.EvaluationJobResource) *api.Review {
+	if job.Results == nil || job.Results.Test == nil {
+		return nil
+	}
+	return job.Results.Test.Review
+}
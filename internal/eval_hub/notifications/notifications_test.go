@@ -0,0 +1,278 @@
+package notifications
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/events"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// inbox records what the fake Slack webhook and SMTP server receive.
+type inbox struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (i *inbox) add(message string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.messages = append(i.messages, message)
+}
+
+func (i *inbox) all() []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]string(nil), i.messages...)
+}
+
+func newSlackWebhook(t *testing.T) (*httptest.Server, *inbox) {
+	t.Helper()
+	received := &inbox{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		message := slackMessage{}
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		received.add(message.Text)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+// newSMTPServer serves the SMTP commands of net/smtp, without STARTTLS or AUTH.
+func newSMTPServer(t *testing.T) (string, int, *inbox) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { _ = listener.Close() })
+	received := &inbox{}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, received)
+		}
+	}()
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, received
+}
+
+func serveSMTP(conn net.Conn, received *inbox) {
+	defer func() { _ = conn.Close() }()
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		switch command := strings.ToUpper(strings.Fields(line + " x")[0]); command {
+		case "EHLO", "HELO":
+			reply("250 localhost")
+		case "DATA":
+			reply("354 end with .")
+			var data strings.Builder
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if line == ".\r\n" {
+					break
+				}
+				data.WriteString(line)
+			}
+			received.add(data.String())
+			reply("250 queued")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 ok")
+		}
+	}
+}
+
+func completedJob() *api.EvaluationJobResource {
+	review := &api.Review{State: api.ReviewStateApproved}
+	return &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1", Tenant: "team-a"}},
+		Status: &api.EvaluationJobStatus{
+			EvaluationJobState: api.EvaluationJobState{State: api.OverallStateCompleted, Message: &api.MessageInfo{Message: "Evaluation job completed", MessageCode: "completed"}},
+			Benchmarks: []api.BenchmarkStatus{
+				{ID: "arc_easy", ProviderID: "lm_evaluation_harness", Status: api.StateCompleted},
+				{ID: "hellaswag", ProviderID: "lm_evaluation_harness", Status: api.StateCompleted},
+			},
+		},
+		Results: &api.EvaluationJobResults{
+			Test: &api.EvaluationTest{Score: 0.71, Threshold: 0.7, Pass: true, Review: review},
+			Benchmarks: []api.BenchmarkResult{
+				{ID: "arc_easy", ProviderID: "lm_evaluation_harness", Test: &api.BenchmarkTest{PrimaryScore: 0.8, PrimaryScoreMetric: "acc", Pass: true}},
+				{ID: "hellaswag", ProviderID: "lm_evaluation_harness", Test: &api.BenchmarkTest{PrimaryScore: 0.62, PrimaryScoreMetric: "acc_norm", Pass: false}},
+			},
+			MLFlowExperimentURL: "https://mlflow.example.com/#/experiments/8",
+		},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Name:   "nightly <llama>",
+			Notify: []string{"#ml-evals"},
+			EvaluationJobMetadata: api.EvaluationJobMetadata{
+				Links: []api.JobLink{{Type: api.JobLinkTypePullRequest, URL: "https://git.example.com/pr/1"}},
+			},
+		},
+	}
+}
+
+func TestNewSummary(t *testing.T) {
+	summary := NewSummary(completedJob(), "https://eval-hub.example.com")
+	if want := "Evaluation job nightly <llama> (job-1) completed: passed"; summary.Title() != want {
+		t.Errorf("expected the title %q, got %q", want, summary.Title())
+	}
+	text := summary.Text()
+	for _, want := range []string{
+		"Score: 0.71 (threshold 0.7)",
+		"- lm_evaluation_harness/arc_easy: completed, acc 0.8, passed",
+		"- lm_evaluation_harness/hellaswag: completed, acc_norm 0.62, failed",
+		"Job: https://eval-hub.example.com/ui/#/jobs/job-1",
+		"MLflow experiment: https://mlflow.example.com/#/experiments/8",
+		"pull request: https://git.example.com/pr/1",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected the summary to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	pending := completedJob()
+	pending.Results.Test.Review.State = api.ReviewStatePending
+	if got := NewSummary(pending, "").Verdict; got != VerdictReviewPending {
+		t.Errorf("expected the verdict %q, got %q", VerdictReviewPending, got)
+	}
+	if got := NewSummary(&api.EvaluationJobResource{Status: &api.EvaluationJobStatus{}}, "").Verdict; got != "" {
+		t.Errorf("expected no verdict without pass criteria, got %q", got)
+	}
+}
+
+func TestNewDispatcher(t *testing.T) {
+	slack := config.NotifierConfig{Name: "slack", Type: config.NotifierTypeSlack, Slack: &config.SlackNotifierConfig{WebhookURL: "https://hooks.example.com/x"}}
+	for _, tc := range []struct {
+		name   string
+		config *config.NotificationsConfig
+		error  string
+	}{
+		{name: "no notifiers", config: &config.NotificationsConfig{}},
+		{name: "unnamed", config: &config.NotificationsConfig{Notifiers: []config.NotifierConfig{{Type: config.NotifierTypeSlack}}}, error: "require a name"},
+		{name: "duplicate", config: &config.NotificationsConfig{Notifiers: []config.NotifierConfig{slack, slack}}, error: "duplicate notifier name"},
+		{name: "unknown type", config: &config.NotificationsConfig{Notifiers: []config.NotifierConfig{{Name: "pager", Type: "pager"}}}, error: "unsupported notifier type"},
+		{name: "slack without webhook", config: &config.NotificationsConfig{Notifiers: []config.NotifierConfig{{Name: "slack", Type: config.NotifierTypeSlack}}}, error: "slack.webhook_url"},
+		{name: "email without recipients", config: &config.NotificationsConfig{Notifiers: []config.NotifierConfig{{Name: "email", Type: config.NotifierTypeEmail, Email: &config.EmailNotifierConfig{Host: "smtp", From: "a@example.com"}}}}, error: "email.to"},
+		{name: "unknown tenant target", config: &config.NotificationsConfig{Notifiers: []config.NotifierConfig{slack}, Tenants: map[string][]string{"team-a": {"#other"}}}, error: "not a configured notifier"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewDispatcher(logging.FallbackLogger(), tc.config, nil)
+			if tc.error == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.error != "" && (err == nil || !strings.Contains(err.Error(), tc.error)) {
+				t.Fatalf("expected an error with %q, got %v", tc.error, err)
+			}
+		})
+	}
+}
+
+func TestDispatcherHandleEvent(t *testing.T) {
+	webhook, slackMessages := newSlackWebhook(t)
+	host, port, emails := newSMTPServer(t)
+
+	dispatcher, err := NewDispatcher(logging.FallbackLogger(), &config.NotificationsConfig{
+		BaseURL: "https://eval-hub.example.com",
+		Notifiers: []config.NotifierConfig{
+			{Name: "ml-evals", Type: config.NotifierTypeSlack, Slack: &config.SlackNotifierConfig{WebhookURL: webhook.URL, Channel: "#ml-evals"}},
+			{Name: "team-a-email", Type: config.NotifierTypeEmail, Email: &config.EmailNotifierConfig{Host: host, Port: port, From: "eval-hub@example.com", To: []string{"team-a@example.com"}}},
+		},
+		// the Slack notifier of the job is also a target of the tenant, it is sent one summary
+		Tenants: map[string][]string{"team-a": {"team-a-email", "ml-evals"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewDispatcher: %v", err)
+	}
+
+	job := completedJob()
+	local := events.ReplicaID()
+	dispatcher.HandleEvent(events.Event{Type: events.BenchmarkUpdated, JobID: "job-1", Job: job, Origin: local})
+	dispatcher.HandleEvent(events.Event{Type: events.JobCompleted, JobID: "job-1", Job: job, Origin: "other-replica"})
+	dispatcher.HandleEvent(events.Event{Type: events.JobCompleted, JobID: "job-1", Job: job, Origin: local})
+	dispatcher.Wait()
+
+	slackTexts := slackMessages.all()
+	if len(slackTexts) != 1 {
+		t.Fatalf("expected one Slack message, got %d: %v", len(slackTexts), slackTexts)
+	}
+	for _, want := range []string{"*Evaluation job nightly &lt;llama&gt; (job-1) completed: passed*", "<https://eval-hub.example.com/ui/#/jobs/job-1|Job>"} {
+		if !strings.Contains(slackTexts[0], want) {
+			t.Errorf("expected the Slack message to contain %q, got:\n%s", want, slackTexts[0])
+		}
+	}
+
+	mails := emails.all()
+	if len(mails) != 1 {
+		t.Fatalf("expected one email, got %d: %v", len(mails), mails)
+	}
+	for _, want := range []string{"To: team-a@example.com\r\n", "Subject: Evaluation job nightly <llama> (job-1) completed: passed\r\n", "Score: 0.71 (threshold 0.7)\r\n"} {
+		if !strings.Contains(mails[0], want) {
+			t.Errorf("expected the email to contain %q, got:\n%s", want, mails[0])
+		}
+	}
+
+	t.Run("reviews are sent once decided", func(t *testing.T) {
+		pending := completedJob()
+		pending.Resource.Tenant = "team-b"
+		pending.Results.Test.Review.State = api.ReviewStatePending
+		dispatcher.HandleEvent(events.Event{Type: events.JobReviewed, JobID: "job-1", Job: pending, Origin: local})
+		dispatcher.Wait()
+		if got := len(slackMessages.all()); got != 1 {
+			t.Fatalf("expected no message for a pending review, got %d messages", got)
+		}
+
+		decided := completedJob()
+		decided.Resource.Tenant = "team-b"
+		decided.Results.Test.Review.State = api.ReviewStateRejected
+		decided.Results.Test.Pass = false
+		dispatcher.HandleEvent(events.Event{Type: events.JobReviewed, JobID: "job-1", Job: decided, Origin: local})
+		dispatcher.Wait()
+		if texts := slackMessages.all(); len(texts) != 2 || !strings.Contains(texts[1], "completed: failed") {
+			t.Fatalf("expected a message for the rejected review, got %v", texts)
+		}
+		if got := len(emails.all()); got != 1 {
+			t.Errorf("expected no email for a job of another tenant, got %d emails", got)
+		}
+	})
+}
+
+func TestSlackNotifierReportsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+
+	notifier := &slackNotifier{webhookURL: server.URL + "/services/secret", client: server.Client()}
+	err := notifier.Notify(t.Context(), NewSummary(completedJob(), ""))
+	if err == nil || !strings.Contains(err.Error(), strconv.Itoa(http.StatusForbidden)) || strings.Contains(err.Error(), "secret") {
+		t.Fatalf("expected the status of the webhook without its URL, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// slackNotifier posts the summaries to a Slack incoming webhook.
+type slackNotifier struct {
+	webhookURL string
+	client     *http.Client
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// slackEscaper escapes the control characters of Slack message formatting.
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func (n *slackNotifier) Notify(ctx context.Context, summary *Summary) error {
+	body, err := json.Marshal(slackMessage{Text: slackText(summary)})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		// the error would hold the URL of the webhook, which is a credential
+		return fmt.Errorf("invalid slack webhook URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post to slack webhook: %w", unwrapURLError(err))
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("slack webhook returned %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+// slackText formats the summary with Slack mrkdwn.
+func slackText(summary *Summary) string {
+	var text strings.Builder
+	text.WriteString("*" + slackEscaper.Replace(summary.Title()) + "*\n")
+	for _, line := range summary.Lines() {
+		text.WriteString(slackEscaper.Replace(line) + "\n")
+	}
+	links := make([]string, 0, len(summary.Links))
+	for _, link := range summary.Links {
+		links = append(links, fmt.Sprintf("<%s|%s>", link.URL, slackEscaper.Replace(link.Title)))
+	}
+	if len(links) > 0 {
+		text.WriteString(strings.Join(links, " | ") + "\n")
+	}
+	return text.String()
+}
+
+// unwrapURLError drops the URL of the webhook from the errors of the client.
+func unwrapURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
@@ -0,0 +1,171 @@
+package notifications
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/ui"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const (
+	VerdictPassed        = "passed"
+	VerdictFailed        = "failed"
+	VerdictReviewPending = "review pending"
+)
+
+// Summary is what the notifiers report of a job.
+type Summary struct {
+	JobID   string
+	Name    string
+	Tenant  api.Tenant
+	State   api.OverallState
+	Message string
+	// Verdict is the verdict of the pass criteria of the job, empty when it has none.
+	Verdict    string
+	Score      *float32
+	Threshold  *float32
+	Benchmarks []BenchmarkSummary
+	Links      []Link
+}
+
+type BenchmarkSummary struct {
+	ID         string
+	ProviderID string
+	State      api.State
+	Verdict    string
+	Score      *float32
+	Metric     string
+}
+
+type Link struct {
+	Title string
+	URL   string
+}
+
+// NewSummary summarizes the job. The job links to the UI under baseURL, when it is set, to
+// its MLflow experiment and to the links of the job.
+func NewSummary(job *api.EvaluationJobResource, baseURL string) *Summary {
+	summary := &Summary{
+		JobID:  job.Resource.ID,
+		Name:   job.Name,
+		Tenant: job.Resource.Tenant,
+	}
+	if job.Status != nil {
+		summary.State = job.Status.State
+		if job.Status.Message != nil {
+			summary.Message = job.Status.Message.Message
+		}
+		for _, benchmark := range job.Status.Benchmarks {
+			summary.Benchmarks = append(summary.Benchmarks, BenchmarkSummary{
+				ID:         benchmark.ID,
+				ProviderID: benchmark.ProviderID,
+				State:      benchmark.Status,
+			})
+		}
+	}
+
+	if job.Results != nil {
+		if test := job.Results.Test; test != nil {
+			summary.Verdict = verdict(test.Pass)
+			if test.Review != nil && test.Review.State == api.ReviewStatePending {
+				summary.Verdict = VerdictReviewPending
+			}
+			summary.Score, summary.Threshold = &test.Score, &test.Threshold
+		}
+		for _, result := range job.Results.Benchmarks {
+			if result.Test == nil {
+				continue
+			}
+			for i := range summary.Benchmarks {
+				benchmark := &summary.Benchmarks[i]
+				if benchmark.ID == result.ID && benchmark.ProviderID == result.ProviderID {
+					benchmark.Verdict = verdict(result.Test.Pass)
+					benchmark.Score, benchmark.Metric = &result.Test.PrimaryScore, result.Test.PrimaryScoreMetric
+				}
+			}
+		}
+	}
+
+	if baseURL != "" {
+		if jobURL, err := url.JoinPath(baseURL, ui.Path); err == nil {
+			summary.Links = append(summary.Links, Link{Title: "Job", URL: jobURL + "#/jobs/" + url.PathEscape(job.Resource.ID)})
+		}
+	}
+	if job.Results != nil && job.Results.MLFlowExperimentURL != "" {
+		summary.Links = append(summary.Links, Link{Title: "MLflow experiment", URL: job.Results.MLFlowExperimentURL})
+	}
+	for _, link := range job.Links {
+		title := link.Title
+		if title == "" {
+			title = strings.ReplaceAll(string(link.Type), "_", " ")
+		}
+		summary.Links = append(summary.Links, Link{Title: title, URL: link.URL})
+	}
+	return summary
+}
+
+func verdict(pass bool) string {
+	if pass {
+		return VerdictPassed
+	}
+	return VerdictFailed
+}
+
+// Title is the one line summary, e.g. the subject of the email.
+func (s *Summary) Title() string {
+	title := fmt.Sprintf("Evaluation job %s %s", s.displayName(), strings.ReplaceAll(s.State.String(), "_", " "))
+	if s.Verdict != "" {
+		title += ": " + s.Verdict
+	}
+	return title
+}
+
+func (s *Summary) displayName() string {
+	if s.Name == "" {
+		return s.JobID
+	}
+	return fmt.Sprintf("%s (%s)", s.Name, s.JobID)
+}
+
+// Lines are the details of the summary, without the links.
+func (s *Summary) Lines() []string {
+	var lines []string
+	if s.Tenant != "" {
+		lines = append(lines, "Tenant: "+s.Tenant.String())
+	}
+	if s.Score != nil && s.Threshold != nil {
+		lines = append(lines, fmt.Sprintf("Score: %.4g (threshold %.4g)", *s.Score, *s.Threshold))
+	}
+	if s.Message != "" {
+		lines = append(lines, "Message: "+s.Message)
+	}
+	for _, benchmark := range s.Benchmarks {
+		line := fmt.Sprintf("- %s/%s: %s", benchmark.ProviderID, benchmark.ID, benchmark.State)
+		if benchmark.Score != nil {
+			line += fmt.Sprintf(", %s %.4g", benchmark.Metric, *benchmark.Score)
+		}
+		if benchmark.Verdict != "" {
+			line += ", " + benchmark.Verdict
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Text is the plain text summary, e.g. the body of the email.
+func (s *Summary) Text() string {
+	var text strings.Builder
+	text.WriteString(s.Title() + "\n\n")
+	for _, line := range s.Lines() {
+		text.WriteString(line + "\n")
+	}
+	if len(s.Links) > 0 {
+		text.WriteString("\n")
+		for _, link := range s.Links {
+			fmt.Fprintf(&text, "%s: %s\n", link.Title, link.URL)
+		}
+	}
+	return text.String()
+}
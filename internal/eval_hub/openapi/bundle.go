@@ -0,0 +1,342 @@
+// Package openapi bundles the OpenAPI sources under docs/src into the single-file
+// specifications served by the API (docs/openapi*.yaml and docs/openapi*.json).
+//
+// The path files are inlined, and the schema, response and example files become
+// components named after the file, so the bundles only change with the sources.
+// The external bundle leaves out everything marked x-internal.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v4"
+)
+
+// componentKinds are the directories of the sources whose files become components.
+var componentKinds = []string{"schemas", "responses", "examples"}
+
+// Files returns the contents of the bundled specifications of the source root, keyed by
+// their file name under docs.
+func Files(root string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	for _, bundle := range []struct {
+		name     string
+		internal bool
+	}{{"openapi", false}, {"openapi-internal", true}} {
+		document, err := Bundle(root, bundle.internal)
+		if err != nil {
+			return nil, err
+		}
+		// the JSON first: encoding the YAML can change the tags of the nodes
+		asJSON, err := MarshalJSON(document)
+		if err != nil {
+			return nil, err
+		}
+		asYAML, err := MarshalYAML(document)
+		if err != nil {
+			return nil, err
+		}
+		files[bundle.name+".yaml"] = asYAML
+		files[bundle.name+".json"] = asJSON
+	}
+	return files, nil
+}
+
+// Bundle resolves the file references of the specification at root into a single
+// document. Unless internal is set, the operations, tags and other nodes marked
+// x-internal are left out, with the components that only they use.
+func Bundle(root string, internal bool) (*yaml.Node, error) {
+	b := &bundler{
+		internal:   internal,
+		files:      map[string]*yaml.Node{},
+		components: map[string]*yaml.Node{},
+		resolving:  map[string]bool{},
+	}
+	for _, kind := range componentKinds {
+		b.components[kind] = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+	source, err := b.load(root)
+	if err != nil {
+		return nil, err
+	}
+	document, err := b.resolve(source, filepath.Dir(root))
+	if err != nil {
+		return nil, err
+	}
+	if document.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: expected a mapping", root)
+	}
+	if paths := lookup(document, "paths"); paths != nil {
+		// a path whose operations are all internal is left out
+		kept := paths.Content[:0]
+		for i := 0; i < len(paths.Content); i += 2 {
+			if item := paths.Content[i+1]; item.Kind != yaml.MappingNode || len(item.Content) > 0 {
+				kept = append(kept, paths.Content[i], item)
+			}
+		}
+		paths.Content = kept
+	}
+
+	components := lookup(document, "components")
+	if components == nil {
+		components = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		document.Content = append(document.Content, scalar("components"), components)
+	}
+	for _, kind := range componentKinds {
+		if len(b.components[kind].Content) == 0 {
+			continue
+		}
+		if existing := lookup(components, kind); existing != nil {
+			existing.Content = append(existing.Content, b.components[kind].Content...)
+			continue
+		}
+		components.Content = append(components.Content, scalar(kind), b.components[kind])
+	}
+	return document, nil
+}
+
+type bundler struct {
+	internal bool
+	// files are the loaded sources, by path
+	files map[string]*yaml.Node
+	// components are the bundled components, by kind
+	components map[string]*yaml.Node
+	// resolving are the components being resolved, so that recursive schemas end
+	resolving map[string]bool
+}
+
+func (b *bundler) load(path string) (*yaml.Node, error) {
+	if node, ok := b.files[path]; ok {
+		return node, nil
+	}
+	content, err := os.ReadFile(path) // #nosec G304 -- OpenAPI sources of the repository
+	if err != nil {
+		return nil, err
+	}
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal(content, document); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if document.Kind != yaml.DocumentNode || len(document.Content) != 1 {
+		return nil, fmt.Errorf("%s: expected a single document", path)
+	}
+	b.files[path] = document.Content[0]
+	return document.Content[0], nil
+}
+
+// resolve returns a copy of node with its file references resolved, relative to dir.
+func (b *bundler) resolve(node *yaml.Node, dir string) (*yaml.Node, error) {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return b.resolve(node.Alias, dir)
+	case yaml.SequenceNode:
+		out := &yaml.Node{Kind: yaml.SequenceNode, Tag: node.ShortTag()}
+		for _, item := range node.Content {
+			if b.isLeftOut(item, dir) {
+				continue
+			}
+			resolved, err := b.resolve(item, dir)
+			if err != nil {
+				return nil, err
+			}
+			out.Content = append(out.Content, resolved)
+		}
+		return out, nil
+	case yaml.MappingNode:
+		if ref := lookup(node, "$ref"); ref != nil && !strings.HasPrefix(ref.Value, "#") {
+			return b.resolveRef(node, ref.Value, dir)
+		}
+		out := &yaml.Node{Kind: yaml.MappingNode, Tag: node.ShortTag()}
+		for i := 0; i < len(node.Content); i += 2 {
+			if b.isLeftOut(node.Content[i+1], dir) {
+				continue
+			}
+			value, err := b.resolve(node.Content[i+1], dir)
+			if err != nil {
+				return nil, err
+			}
+			out.Content = append(out.Content, scalar(node.Content[i].Value), value)
+		}
+		return out, nil
+	default:
+		return &yaml.Node{Kind: node.Kind, Tag: node.ShortTag(), Value: node.Value}, nil
+	}
+}
+
+// resolveRef inlines the referenced file, or references the component that it becomes.
+// The keys next to $ref are kept.
+func (b *bundler) resolveRef(node *yaml.Node, ref string, dir string) (*yaml.Node, error) {
+	path := filepath.Join(dir, ref)
+	source, err := b.load(path)
+	if err != nil {
+		return nil, err
+	}
+	kind := filepath.Base(filepath.Dir(path))
+	components, isComponent := b.components[kind]
+	if !isComponent {
+		return b.resolve(source, filepath.Dir(path))
+	}
+
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	key := kind + "/" + name
+	if lookup(components, name) == nil && !b.resolving[key] {
+		b.resolving[key] = true
+		resolved, err := b.resolve(source, filepath.Dir(path))
+		if err != nil {
+			return nil, err
+		}
+		components.Content = append(components.Content, scalar(name), resolved)
+	}
+	out := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	out.Content = append(out.Content, scalar("$ref"), scalar("#/components/"+key))
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value == "$ref" {
+			continue
+		}
+		value, err := b.resolve(node.Content[i+1], dir)
+		if err != nil {
+			return nil, err
+		}
+		out.Content = append(out.Content, scalar(node.Content[i].Value), value)
+	}
+	return out, nil
+}
+
+// isLeftOut reports whether node, or the file that it inlines, is marked x-internal and
+// left out of the external bundle. It is checked before resolving node, so that the
+// components used only by internal nodes are left out too.
+func (b *bundler) isLeftOut(node *yaml.Node, dir string) bool {
+	if b.internal || node.Kind != yaml.MappingNode {
+		return false
+	}
+	if ref := lookup(node, "$ref"); ref != nil && !strings.HasPrefix(ref.Value, "#") {
+		path := filepath.Join(dir, ref.Value)
+		if _, isComponent := b.components[filepath.Base(filepath.Dir(path))]; isComponent {
+			return false
+		}
+		source, err := b.load(path)
+		if err != nil {
+			// the error is returned when the reference is resolved
+			return false
+		}
+		node = source
+	}
+	marker := lookup(node, "x-internal")
+	return marker != nil && marker.Value == "true"
+}
+
+// MarshalYAML returns the YAML of a bundled document.
+func MarshalYAML(document *yaml.Node) ([]byte, error) {
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(document); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// MarshalJSON returns the JSON of a bundled document, keeping the order of its keys.
+func MarshalJSON(document *yaml.Node) ([]byte, error) {
+	var compact bytes.Buffer
+	if err := writeJSON(&compact, document); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	out.WriteByte('\n')
+	return out.Bytes(), nil
+}
+
+func writeJSON(out *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		out.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			if err := writeJSON(out, item); err != nil {
+				return err
+			}
+		}
+		out.WriteByte(']')
+	case yaml.MappingNode:
+		out.WriteByte('{')
+		for i := 0; i < len(node.Content); i += 2 {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			writeJSONString(out, node.Content[i].Value)
+			out.WriteByte(':')
+			if err := writeJSON(out, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		out.WriteByte('}')
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!null":
+			out.WriteString("null")
+		case "!!bool":
+			value, err := strconv.ParseBool(node.Value)
+			if err != nil {
+				return fmt.Errorf("invalid boolean %q: %w", node.Value, err)
+			}
+			out.WriteString(strconv.FormatBool(value))
+		case "!!int":
+			value, err := strconv.ParseInt(node.Value, 0, 64)
+			if err != nil {
+				return fmt.Errorf("invalid integer %q: %w", node.Value, err)
+			}
+			out.WriteString(strconv.FormatInt(value, 10))
+		case "!!float":
+			value, err := strconv.ParseFloat(node.Value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid number %q: %w", node.Value, err)
+			}
+			out.WriteString(strconv.FormatFloat(value, 'f', -1, 64))
+		default:
+			writeJSONString(out, node.Value)
+		}
+	default:
+		return fmt.Errorf("unexpected YAML node kind %v", node.Kind)
+	}
+	return nil
+}
+
+func writeJSONString(out *bytes.Buffer, value string) {
+	encoder := json.NewEncoder(out)
+	encoder.SetEscapeHTML(false)
+	// Encode only fails for values that can't be marshalled, never for a string
+	_ = encoder.Encode(value)
+	// drop the newline that Encode adds
+	out.Truncate(out.Len() - 1)
+}
+
+func lookup(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
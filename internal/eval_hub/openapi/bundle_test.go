@@ -0,0 +1,266 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestBundledSpecificationsAreUpToDate fails when the sources under docs/src change without
+// the bundles under docs, that the API serves, being regenerated.
+func TestBundledSpecificationsAreUpToDate(t *testing.T) {
+	docs := filepath.Join("..", "..", "..", "docs")
+	files, err := Files(filepath.Join(docs, "src", "openapi.yaml"))
+	if err != nil {
+		t.Fatalf("Files: %v", err)
+	}
+	for name, expected := range files {
+		actual, err := os.ReadFile(filepath.Join(docs, name))
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", name, err)
+		}
+		if !bytes.Equal(actual, expected) {
+			t.Errorf("docs/%s is not up to date with docs/src, run make generate-openapi", name)
+		}
+	}
+}
+
+func TestBundle(t *testing.T) {
+	root := t.TempDir()
+	write := func(path string, content string) {
+		t.Helper()
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("openapi.yaml", `openapi: 3.1.0
+tags:
+  - name: Jobs
+  - name: Admin
+    x-internal: true
+paths:
+  /jobs:
+    $ref: paths/jobs.yaml
+  /admin:
+    $ref: paths/admin.yaml
+`)
+	write("paths/jobs.yaml", `get:
+  responses:
+    '200':
+      content:
+        application/json:
+          schema:
+            $ref: ../components/schemas/Job.yaml
+            description: The job
+post:
+  x-internal: true
+  requestBody:
+    content:
+      application/json:
+        schema:
+          $ref: ../components/schemas/Event.yaml
+`)
+	write("paths/admin.yaml", `get:
+  x-internal: true
+  responses:
+    '200':
+      description: OK
+`)
+	write("components/schemas/Job.yaml", `type: object
+properties:
+  limit:
+    type: integer
+    default: 50
+  parent:
+    $ref: ./Job.yaml
+`)
+	write("components/schemas/Event.yaml", `type: object
+`)
+
+	bundle := func(internal bool) map[string]any {
+		t.Helper()
+		document, err := Bundle(filepath.Join(root, "openapi.yaml"), internal)
+		if err != nil {
+			t.Fatalf("Bundle: %v", err)
+		}
+		content, err := MarshalJSON(document)
+		if err != nil {
+			t.Fatalf("MarshalJSON: %v", err)
+		}
+		spec := map[string]any{}
+		if err := json.Unmarshal(content, &spec); err != nil {
+			t.Fatalf("the bundle is not JSON: %v", err)
+		}
+		return spec
+	}
+
+	external := bundle(false)
+	paths := external["paths"].(map[string]any)
+	if _, ok := paths["/admin"]; ok {
+		t.Errorf("expected the internal path to be left out, got %v", paths)
+	}
+	if _, ok := paths["/jobs"].(map[string]any)["post"]; ok {
+		t.Errorf("expected the internal operation to be left out, got %v", paths["/jobs"])
+	}
+	if tags := external["tags"].([]any); len(tags) != 1 {
+		t.Errorf("expected the internal tag to be left out, got %v", tags)
+	}
+	schemas := external["components"].(map[string]any)["schemas"].(map[string]any)
+	if _, ok := schemas["Event"]; ok {
+		t.Errorf("expected the schema of the internal operation to be left out, got %v", schemas)
+	}
+	job := schemas["Job"].(map[string]any)
+	if parent := job["properties"].(map[string]any)["parent"].(map[string]any); parent["$ref"] != "#/components/schemas/Job" {
+		t.Errorf("expected the recursive schema to reference itself, got %v", parent)
+	}
+	if limit := job["properties"].(map[string]any)["limit"].(map[string]any); limit["default"] != float64(50) {
+		t.Errorf("expected the default to stay a number, got %#v", limit["default"])
+	}
+	schema := paths["/jobs"].(map[string]any)["get"].(map[string]any)["responses"].(map[string]any)["200"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)["schema"].(map[string]any)
+	if schema["$ref"] != "#/components/schemas/Job" || schema["description"] != "The job" {
+		t.Errorf("expected a reference to the component with its description, got %v", schema)
+	}
+
+	internal := bundle(true)
+	if _, ok := internal["paths"].(map[string]any)["/admin"]; !ok {
+		t.Errorf("expected the internal path in the internal bundle")
+	}
+	if _, ok := internal["components"].(map[string]any)["schemas"].(map[string]any)["Event"]; !ok {
+		t.Errorf("expected the schema of the internal operation in the internal bundle")
+	}
+}
+
+// TestSchemasDocumentTheAPITypes fails when a field is added to a type of pkg/api without
+// being documented in the schema of the same name.
+func TestSchemasDocumentTheAPITypes(t *testing.T) {
+	fields, err := apiTypeFields(filepath.Join("..", "..", "..", "pkg", "api"))
+	if err != nil {
+		t.Fatalf("failed to parse the API types: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join("..", "..", "..", "docs", "openapi-internal.json"))
+	if err != nil {
+		t.Fatalf("failed to read the OpenAPI spec: %v", err)
+	}
+	spec := struct {
+		Components struct {
+			Schemas map[string]map[string]any `json:"schemas"`
+		} `json:"components"`
+	}{}
+	if err := json.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("failed to parse the OpenAPI spec: %v", err)
+	}
+
+	// the health endpoint serves the status and timestamp of handlers.HealthResponse, the
+	// other fields of api.HealthResponse are optional for the clients
+	delete(fields, "HealthResponse")
+	checked := 0
+	for name, schema := range spec.Components.Schemas {
+		typeFields, ok := fields[name]
+		if !ok {
+			continue
+		}
+		checked++
+		properties := map[string]bool{}
+		schemaProperties(spec.Components.Schemas, schema, properties, map[string]bool{})
+		for _, field := range typeFields {
+			if !properties[field] {
+				t.Errorf("the field %s of api.%s is not documented in docs/src/components/schemas/%s.yaml", field, name, name)
+			}
+		}
+	}
+	if checked == 0 {
+		t.Fatal("expected schemas named after the API types")
+	}
+}
+
+// schemaProperties collects the properties of a schema, through its references and
+// compositions.
+func schemaProperties(schemas map[string]map[string]any, schema map[string]any, properties map[string]bool, seen map[string]bool) {
+	if ref, ok := schema["$ref"].(string); ok {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		if !seen[name] {
+			seen[name] = true
+			schemaProperties(schemas, schemas[name], properties, seen)
+		}
+	}
+	if props, ok := schema["properties"].(map[string]any); ok {
+		for property := range props {
+			properties[property] = true
+		}
+	}
+	for _, composition := range []string{"allOf", "oneOf", "anyOf"} {
+		items, _ := schema[composition].([]any)
+		for _, item := range items {
+			if itemSchema, ok := item.(map[string]any); ok {
+				schemaProperties(schemas, itemSchema, properties, seen)
+			}
+		}
+	}
+}
+
+// apiTypeFields returns the JSON fields of the struct types of the package in dir, with
+// the fields of their embedded structs, by type name.
+func apiTypeFields(dir string) (map[string][]string, error) {
+	files := token.NewFileSet()
+	packages, err := parser.ParseDir(files, dir, func(info fs.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+	structs := map[string]*ast.StructType{}
+	for _, pkg := range packages {
+		for _, file := range pkg.Files {
+			ast.Inspect(file, func(node ast.Node) bool {
+				if spec, ok := node.(*ast.TypeSpec); ok && spec.Name.IsExported() {
+					if structType, ok := spec.Type.(*ast.StructType); ok {
+						structs[spec.Name.Name] = structType
+					}
+				}
+				return true
+			})
+		}
+	}
+
+	var collect func(structType *ast.StructType, fields []string) []string
+	collect = func(structType *ast.StructType, fields []string) []string {
+		for _, field := range structType.Fields.List {
+			tag := ""
+			if field.Tag != nil {
+				tag = reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("json")
+			}
+			name, _, _ := strings.Cut(tag, ",")
+			if len(field.Names) == 0 && name == "" {
+				embedded := field.Type
+				if star, ok := embedded.(*ast.StarExpr); ok {
+					embedded = star.X
+				}
+				if ident, ok := embedded.(*ast.Ident); ok && structs[ident.Name] != nil {
+					fields = collect(structs[ident.Name], fields)
+				}
+				continue
+			}
+			if name != "" && name != "-" {
+				fields = append(fields, name)
+			}
+		}
+		return fields
+	}
+	fields := map[string][]string{}
+	for name, structType := range structs {
+		fields[name] = collect(structType, nil)
+	}
+	return fields, nil
+}
@@ -0,0 +1,166 @@
+// Package providercache caches the provider listings, which merge the system providers with
+// the providers of the tenant and carry their benchmarks, in memory.
+package providercache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// maxEntries bounds the listings held, one per tenant, owner and filter; the cache is
+// cleared when it is full.
+const maxEntries = 1024
+
+type entry struct {
+	providers *abstractions.QueryResults[api.ProviderResource]
+	expires   time.Time
+}
+
+// cache is shared by the scoped copies of the storage.
+type cache struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	entries    map[string]entry
+	generation uint64
+	now        func() time.Time
+}
+
+func (c *cache) get(key string) (*abstractions.QueryResults[api.ProviderResource], uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if ok && c.now().After(e.expires) {
+		delete(c.entries, key)
+		ok = false
+	}
+	if !ok {
+		return nil, c.generation
+	}
+	return e.providers, c.generation
+}
+
+// put stores the listing read at generation, unless a provider was written since.
+func (c *cache) put(key string, generation uint64, providers *abstractions.QueryResults[api.ProviderResource]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if len(c.entries) >= maxEntries {
+		clear(c.entries)
+	}
+	c.entries[key] = entry{providers: providers, expires: c.now().Add(c.ttl)}
+}
+
+func (c *cache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	clear(c.entries)
+}
+
+// cachingStorage serves the provider listings from the cache and clears it on every provider
+// write of this replica, whichever code path (providers API, config reload) made it.
+type cachingStorage struct {
+	abstractions.Storage
+	cache  *cache
+	tenant api.Tenant
+	owner  api.User
+}
+
+// NewStorage wraps storage so that its provider listings are cached for ttl.
+func NewStorage(storage abstractions.Storage, ttl time.Duration) abstractions.Storage {
+	return &cachingStorage{
+		Storage: storage,
+		cache:   &cache{ttl: ttl, entries: map[string]entry{}, now: time.Now},
+	}
+}
+
+func (s *cachingStorage) with(storage abstractions.Storage) *cachingStorage {
+	return &cachingStorage{Storage: storage, cache: s.cache, tenant: s.tenant, owner: s.owner}
+}
+
+func (s *cachingStorage) WithLogger(logger *slog.Logger) abstractions.Storage {
+	return s.with(s.Storage.WithLogger(logger))
+}
+
+func (s *cachingStorage) WithContext(ctx context.Context) abstractions.Storage {
+	return s.with(s.Storage.WithContext(ctx))
+}
+
+func (s *cachingStorage) WithTenant(tenant api.Tenant) abstractions.Storage {
+	scoped := s.with(s.Storage.WithTenant(tenant))
+	scoped.tenant = tenant
+	return scoped
+}
+
+func (s *cachingStorage) WithOwner(owner api.User) abstractions.Storage {
+	scoped := s.with(s.Storage.WithOwner(owner))
+	scoped.owner = owner
+	return scoped
+}
+
+// GetProviders returns a copy of the cached listing, so that callers can set the fields of
+// the providers, e.g. to drop their benchmarks, but must not change the benchmarks in place.
+func (s *cachingStorage) GetProviders(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.ProviderResource], error) {
+	key, err := s.key(filter)
+	if err != nil {
+		// the filter can not be keyed, e.g. a value that does not marshal
+		return s.Storage.GetProviders(filter)
+	}
+	cached, generation := s.cache.get(key)
+	if cached == nil {
+		providers, err := s.Storage.GetProviders(filter)
+		if err != nil {
+			return nil, err
+		}
+		s.cache.put(key, generation, providers)
+		cached = providers
+	}
+	return &abstractions.QueryResults[api.ProviderResource]{
+		Items:      append([]api.ProviderResource(nil), cached.Items...),
+		TotalCount: cached.TotalCount,
+		Errors:     cached.Errors,
+	}, nil
+}
+
+func (s *cachingStorage) key(filter *abstractions.QueryFilter) (string, error) {
+	key := struct {
+		Tenant api.Tenant                `json:"tenant"`
+		Owner  api.User                  `json:"owner"`
+		Filter *abstractions.QueryFilter `json:"filter"`
+	}{s.tenant, s.owner, filter}
+	data, err := json.Marshal(key)
+	return string(data), err
+}
+
+func (s *cachingStorage) CreateProvider(provider *api.ProviderResource) error {
+	defer s.cache.invalidate()
+	return s.Storage.CreateProvider(provider)
+}
+
+func (s *cachingStorage) UpdateProvider(id string, providerConfig *api.ProviderConfig) (*api.ProviderResource, error) {
+	defer s.cache.invalidate()
+	return s.Storage.UpdateProvider(id, providerConfig)
+}
+
+func (s *cachingStorage) PatchProvider(id string, patches *api.Patch) (*api.ProviderResource, error) {
+	defer s.cache.invalidate()
+	return s.Storage.PatchProvider(id, patches)
+}
+
+func (s *cachingStorage) DeleteProvider(id string) error {
+	defer s.cache.invalidate()
+	return s.Storage.DeleteProvider(id)
+}
+
+func (s *cachingStorage) LoadSystemResources(systemCollections map[string]api.CollectionResource, systemProviders map[string]api.ProviderResource) error {
+	defer s.cache.invalidate()
+	return s.Storage.LoadSystemResources(systemCollections, systemProviders)
+}
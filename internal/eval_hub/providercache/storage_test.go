@@ -0,0 +1,171 @@
+package providercache
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// countingStorage counts the provider listings read from it, the other methods are not
+// implemented.
+type countingStorage struct {
+	abstractions.Storage
+	reads     int
+	providers []api.ProviderResource
+}
+
+func (s *countingStorage) WithLogger(_ *slog.Logger) abstractions.Storage     { return s }
+func (s *countingStorage) WithContext(_ context.Context) abstractions.Storage { return s }
+func (s *countingStorage) WithTenant(_ api.Tenant) abstractions.Storage       { return s }
+func (s *countingStorage) WithOwner(_ api.User) abstractions.Storage          { return s }
+
+func (s *countingStorage) GetProviders(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.ProviderResource], error) {
+	s.reads++
+	return &abstractions.QueryResults[api.ProviderResource]{
+		Items:      append([]api.ProviderResource(nil), s.providers...),
+		TotalCount: len(s.providers),
+	}, nil
+}
+
+func (s *countingStorage) CreateProvider(provider *api.ProviderResource) error {
+	s.providers = append(s.providers, *provider)
+	return nil
+}
+
+func (s *countingStorage) DeleteProvider(_ string) error { return nil }
+
+func (s *countingStorage) LoadSystemResources(_ map[string]api.CollectionResource, _ map[string]api.ProviderResource) error {
+	return nil
+}
+
+func provider(id string) api.ProviderResource {
+	return api.ProviderResource{Resource: api.Resource{ID: id}}
+}
+
+func TestGetProvidersIsCached(t *testing.T) {
+	inner := &countingStorage{providers: []api.ProviderResource{provider("garak")}}
+	store := NewStorage(inner, time.Minute)
+	filter := &abstractions.QueryFilter{Limit: 10}
+
+	for range 3 {
+		providers, err := store.WithTenant("tenant-a").GetProviders(filter)
+		if err != nil {
+			t.Fatalf("GetProviders: %v", err)
+		}
+		if providers.TotalCount != 1 || providers.Items[0].Resource.ID != "garak" {
+			t.Fatalf("unexpected providers %+v", providers)
+		}
+	}
+	if inner.reads != 1 {
+		t.Errorf("expected one read of the storage, got %d", inner.reads)
+	}
+
+	if _, err := store.WithTenant("tenant-b").GetProviders(filter); err != nil {
+		t.Fatalf("GetProviders: %v", err)
+	}
+	if _, err := store.WithTenant("tenant-a").GetProviders(&abstractions.QueryFilter{Limit: 20}); err != nil {
+		t.Fatalf("GetProviders: %v", err)
+	}
+	if inner.reads != 3 {
+		t.Errorf("expected the tenants and filters to be cached apart, got %d reads", inner.reads)
+	}
+}
+
+func TestGetProvidersReturnsACopy(t *testing.T) {
+	inner := &countingStorage{providers: []api.ProviderResource{provider("garak")}}
+	store := NewStorage(inner, time.Minute)
+
+	first, err := store.GetProviders(nil)
+	if err != nil {
+		t.Fatalf("GetProviders: %v", err)
+	}
+	first.Items[0].Resource.ID = "changed"
+
+	second, err := store.GetProviders(nil)
+	if err != nil {
+		t.Fatalf("GetProviders: %v", err)
+	}
+	if second.Items[0].Resource.ID != "garak" {
+		t.Errorf("the cached listing was changed by a caller: %q", second.Items[0].Resource.ID)
+	}
+}
+
+func TestProviderWritesInvalidateTheCache(t *testing.T) {
+	inner := &countingStorage{providers: []api.ProviderResource{provider("garak")}}
+	store := NewStorage(inner, time.Minute).WithTenant("tenant-a")
+
+	writes := map[string]func() error{
+		"create": func() error {
+			p := provider("lm_eval")
+			return store.CreateProvider(&p)
+		},
+		"delete": func() error { return store.DeleteProvider("lm_eval") },
+		"config reload": func() error {
+			return store.LoadSystemResources(nil, nil)
+		},
+	}
+	for name, write := range writes {
+		t.Run(name, func(t *testing.T) {
+			if _, err := store.GetProviders(nil); err != nil {
+				t.Fatalf("GetProviders: %v", err)
+			}
+			reads := inner.reads
+			if err := write(); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if _, err := store.GetProviders(nil); err != nil {
+				t.Fatalf("GetProviders: %v", err)
+			}
+			if inner.reads != reads+1 {
+				t.Errorf("expected the listing to be read again after the write")
+			}
+		})
+	}
+
+	providers, err := store.GetProviders(nil)
+	if err != nil {
+		t.Fatalf("GetProviders: %v", err)
+	}
+	if providers.TotalCount != len(inner.providers) {
+		t.Errorf("expected the created provider to be listed, got %+v", providers)
+	}
+}
+
+func TestCachedProvidersExpire(t *testing.T) {
+	inner := &countingStorage{providers: []api.ProviderResource{provider("garak")}}
+	store := NewStorage(inner, time.Minute).(*cachingStorage)
+	now := time.Now()
+	store.cache.now = func() time.Time { return now }
+
+	if _, err := store.GetProviders(nil); err != nil {
+		t.Fatalf("GetProviders: %v", err)
+	}
+	now = now.Add(30 * time.Second)
+	if _, err := store.GetProviders(nil); err != nil {
+		t.Fatalf("GetProviders: %v", err)
+	}
+	if inner.reads != 1 {
+		t.Fatalf("expected the listing to be cached within its ttl, got %d reads", inner.reads)
+	}
+	now = now.Add(time.Minute)
+	if _, err := store.GetProviders(nil); err != nil {
+		t.Fatalf("GetProviders: %v", err)
+	}
+	if inner.reads != 2 {
+		t.Errorf("expected the listing to be read again after its ttl, got %d reads", inner.reads)
+	}
+}
+
+func TestStaleListingIsNotCached(t *testing.T) {
+	c := &cache{ttl: time.Minute, entries: map[string]entry{}, now: time.Now}
+	_, generation := c.get("key")
+	c.invalidate()
+	c.put("key", generation, &abstractions.QueryResults[api.ProviderResource]{})
+	if cached, _ := c.get("key"); cached != nil {
+		t.Error("expected a listing read before a write not to be cached")
+	}
+}
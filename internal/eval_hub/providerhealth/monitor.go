@@ -0,0 +1,476 @@
+package providerhealth
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/metrics"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const (
+	// CanaryTag is added to every canary evaluation job so they can be filtered out of job listings.
+	CanaryTag = "provider-health"
+
+	// DefaultPollInterval is how often the monitor looks for due canaries and in-flight results.
+	DefaultPollInterval = 30 * time.Second
+
+	providersPageSize = 100
+)
+
+// Monitor periodically runs a small canary evaluation for every system provider that
+// declares a health_check, and keeps the latest outcome in the storage. Broken adapter images
+// are then reported on GET /providers and in metrics instead of on a user's real job.
+//
+// The canaries only run on the leader replica, which stores the health with its canaries in
+// flight: every replica reports the stored health with RunHealthSync, and a new leader resumes
+// the failure streaks and collects the canaries started by the previous one.
+//
+// Only system providers (loaded from the service configuration) are checked: they are
+// operated by the service owner, whereas tenant providers are the tenant's responsibility.
+type Monitor struct {
+	logger  *slog.Logger
+	storage abstractions.Storage
+	runtime abstractions.Runtime
+	now     func() time.Time
+
+	// canaries is only accessed from Run and RunOnce, which are never called concurrently.
+	canaries map[string]*canary
+	// leading is set while Run runs, when the health in memory is the latest one.
+	leading atomic.Bool
+
+	mu     sync.RWMutex
+	health map[string]api.ProviderHealth
+
+	// pollInterval is changed with SetPollInterval while Run is running, which is
+	// told through pollIntervalChanged.
+	pollInterval        atomic.Int64
+	pollIntervalChanged chan struct{}
+}
+
+type canary struct {
+	nextRunAt time.Time
+	job       *api.EvaluationJobResource
+	startedAt time.Time
+	// saved is the record last stored for the provider.
+	saved *abstractions.ProviderHealthRecord
+}
+
+func NewMonitor(logger *slog.Logger, storage abstractions.Storage, runtime abstractions.Runtime) *Monitor {
+	return &Monitor{
+		logger:   logger,
+		storage:  storage,
+		runtime:  runtime,
+		now:      time.Now,
+		canaries: map[string]*canary{},
+		health:   map[string]api.ProviderHealth{},
+
+		pollIntervalChanged: make(chan struct{}, 1),
+	}
+}
+
+// Run runs the monitor until ctx is cancelled. A poll interval set with SetPollInterval
+// takes precedence over the one given here.
+func (m *Monitor) Run(ctx context.Context, pollInterval time.Duration) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	m.pollInterval.CompareAndSwap(0, int64(pollInterval))
+	m.leading.Store(true)
+	defer m.leading.Store(false)
+	m.resume(ctx)
+	ticker := time.NewTicker(m.PollInterval())
+	defer ticker.Stop()
+	for {
+		m.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-m.pollIntervalChanged:
+			ticker.Reset(m.PollInterval())
+		}
+	}
+}
+
+// RunHealthSync loads the health stored by the leader every poll interval until ctx is
+// cancelled, so that every replica reports it on GET /providers.
+func (m *Monitor) RunHealthSync(ctx context.Context) {
+	timer := time.NewTimer(m.PollInterval())
+	defer timer.Stop()
+	for {
+		m.sync(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			timer.Reset(m.PollInterval())
+		}
+	}
+}
+
+// sync replaces the health in memory with the stored one, unless this replica is the leader
+// and holds the latest health.
+func (m *Monitor) sync(ctx context.Context) {
+	if m.leading.Load() {
+		return
+	}
+	records, err := m.storage.WithLogger(m.logger).WithContext(ctx).GetProviderHealthRecords()
+	if err != nil {
+		m.logger.Warn("Failed to load provider health", "error", err)
+		return
+	}
+	health := make(map[string]api.ProviderHealth, len(records))
+	for _, record := range records {
+		health[record.ProviderID] = record.Health
+	}
+	m.mu.Lock()
+	m.health = health
+	m.mu.Unlock()
+}
+
+// resume restores the health and the canaries stored by the previous leader, so that the
+// failure streaks carry over and the canaries in flight are collected, or cancelled once
+// they exceed their timeout, rather than left running.
+func (m *Monitor) resume(ctx context.Context) {
+	m.canaries = map[string]*canary{}
+	records, err := m.storage.WithLogger(m.logger).WithContext(ctx).GetProviderHealthRecords()
+	if err != nil {
+		m.logger.Warn("Failed to restore provider health", "error", err)
+		return
+	}
+	health := make(map[string]api.ProviderHealth, len(records))
+	for i := range records {
+		record := records[i]
+		c := &canary{nextRunAt: record.NextRunAt, saved: &record}
+		if record.CanaryJobID != "" {
+			// collect gets the job by its ID and tenant
+			c.job = &api.EvaluationJobResource{Resource: api.EvaluationResource{Resource: api.Resource{ID: record.CanaryJobID, Tenant: record.CanaryTenant}}}
+			c.startedAt = record.CanaryStartedAt
+			m.logger.Info("Resuming provider health check", "provider_id", record.ProviderID, "job_id", record.CanaryJobID)
+		}
+		m.canaries[record.ProviderID] = c
+		health[record.ProviderID] = record.Health
+	}
+	m.mu.Lock()
+	m.health = health
+	m.mu.Unlock()
+}
+
+// PollInterval returns how often the monitor looks for due canaries and in-flight results.
+func (m *Monitor) PollInterval() time.Duration {
+	if pollInterval := time.Duration(m.pollInterval.Load()); pollInterval > 0 {
+		return pollInterval
+	}
+	return DefaultPollInterval
+}
+
+// SetPollInterval changes how often the monitor polls, taking effect immediately when
+// it is running.
+func (m *Monitor) SetPollInterval(pollInterval time.Duration) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	m.pollInterval.Store(int64(pollInterval))
+	select {
+	case m.pollIntervalChanged <- struct{}{}:
+	default:
+	}
+}
+
+// ProviderHealth returns the latest health for the provider, or nil when the provider
+// has no health check configured.
+func (m *Monitor) ProviderHealth(providerID string) *api.ProviderHealth {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	health, ok := m.health[providerID]
+	if !ok {
+		return nil
+	}
+	return &health
+}
+
+// RunOnce collects the results of in-flight canaries and starts the ones that are due.
+func (m *Monitor) RunOnce(ctx context.Context) {
+	providers, err := m.systemProviders(ctx)
+	if err != nil {
+		m.logger.Warn("Failed to list providers for health checks", "error", err)
+		return
+	}
+
+	configured := map[string]bool{}
+	for i := range providers {
+		provider := &providers[i]
+		if provider.HealthCheck == nil {
+			continue
+		}
+		id := provider.Resource.ID
+		configured[id] = true
+
+		c, ok := m.canaries[id]
+		if !ok {
+			c = &canary{}
+			m.canaries[id] = c
+			m.updateHealth(ctx, id, false, func(h *api.ProviderHealth) {
+				h.Status = api.ProviderHealthStatusUnknown
+			})
+		}
+
+		switch {
+		case c.job != nil:
+			m.collect(ctx, provider, c)
+		case !m.now().Before(c.nextRunAt):
+			m.launch(ctx, provider, c)
+		}
+		m.save(ctx, id, c)
+	}
+
+	// forget providers whose health check has been removed
+	for id := range m.canaries {
+		if !configured[id] {
+			if err := m.storage.WithLogger(m.logger).WithContext(ctx).DeleteProviderHealth(id); err != nil {
+				m.logger.Warn("Failed to delete provider health", "provider_id", id, "error", err)
+				continue
+			}
+			delete(m.canaries, id)
+			m.mu.Lock()
+			delete(m.health, id)
+			m.mu.Unlock()
+		}
+	}
+}
+
+// save stores the health of the provider with its canary in flight, when they changed since
+// they were last stored.
+func (m *Monitor) save(ctx context.Context, providerID string, c *canary) {
+	record := abstractions.ProviderHealthRecord{
+		ProviderID: providerID,
+		NextRunAt:  c.nextRunAt,
+	}
+	m.mu.RLock()
+	record.Health = m.health[providerID]
+	m.mu.RUnlock()
+	if c.job != nil {
+		record.CanaryJobID = c.job.Resource.ID
+		record.CanaryTenant = c.job.Resource.Tenant
+		record.CanaryStartedAt = c.startedAt
+	}
+	if c.saved != nil && *c.saved == record {
+		return
+	}
+	if err := m.storage.WithLogger(m.logger).WithContext(ctx).PutProviderHealth(&record); err != nil {
+		m.logger.Warn("Failed to store provider health", "provider_id", providerID, "error", err)
+		return
+	}
+	c.saved = &record
+}
+
+func (m *Monitor) systemProviders(ctx context.Context) ([]api.ProviderResource, error) {
+	storage := m.storage.WithLogger(m.logger).WithContext(ctx)
+	var providers []api.ProviderResource
+	for offset := 0; ; offset += providersPageSize {
+		res, err := storage.GetProviders(&abstractions.QueryFilter{
+			Limit:  providersPageSize,
+			Offset: offset,
+			Params: map[string]any{"scope": abstractions.ScopeSystem},
+		})
+		if err != nil {
+			return nil, err
+		}
+		providers = append(providers, res.Items...)
+		if len(res.Items) < providersPageSize || len(providers) >= res.TotalCount {
+			return providers, nil
+		}
+	}
+}
+
+func (m *Monitor) jobStorage(ctx context.Context, tenant api.Tenant) abstractions.Storage {
+	return m.storage.WithLogger(m.logger).WithContext(ctx).WithTenant(tenant).WithOwner(abstractions.OwnerSystem)
+}
+
+func (m *Monitor) launch(ctx context.Context, provider *api.ProviderResource, c *canary) {
+	check := provider.HealthCheck
+	id := provider.Resource.ID
+	c.nextRunAt = m.now().Add(check.EffectiveInterval())
+
+	if !slices.ContainsFunc(provider.Benchmarks, func(b api.BenchmarkResource) bool { return b.ID == check.BenchmarkID }) {
+		m.recordFailure(ctx, id, "", fmt.Sprintf("health check benchmark %s is not offered by the provider", check.BenchmarkID))
+		return
+	}
+
+	jobID := common.GUID()
+	benchmarks := []api.EvaluationBenchmarkConfig{
+		{
+			Ref:        api.Ref{ID: check.BenchmarkID},
+			ProviderID: id,
+			Parameters: check.Parameters,
+		},
+	}
+	job := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{
+			Resource: api.Resource{
+				ID:        jobID,
+				CreatedAt: m.now(),
+				Owner:     abstractions.OwnerSystem,
+				Tenant:    check.Tenant,
+			},
+		},
+		Status: &api.EvaluationJobStatus{
+			EvaluationJobState: api.EvaluationJobState{
+				State: api.OverallStatePending,
+				Message: api.WithMessageOrigin(&api.MessageInfo{
+					Message:     "Provider health check created",
+					MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_CREATED,
+				}, api.MessageOriginServer),
+			},
+		},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Name:       fmt.Sprintf("%s-%s", CanaryTag, id),
+			Tags:       []string{CanaryTag},
+			Model:      check.Model,
+			Benchmarks: benchmarks,
+		},
+	}
+
+	logger := m.logger.With("provider_id", id, "job_id", jobID)
+	storage := m.jobStorage(ctx, check.Tenant)
+	if err := storage.CreateEvaluationJob(job); err != nil {
+		logger.Error("Failed to store provider health check job", "error", err)
+		m.recordFailure(ctx, id, "", err.Error())
+		return
+	}
+
+	// Like user jobs, the runtime gets a context that is not tied to a single poll.
+	jobStorage := &runtimeStorage{storage: m.jobStorage(context.Background(), check.Tenant)}
+	if err := m.runtime.WithLogger(logger).WithContext(context.Background()).RunEvaluationJob(job, benchmarks, jobStorage); err != nil {
+		logger.Warn("Failed to start provider health check job", "error", err)
+		m.markJob(storage, jobID, api.OverallStateFailed, constants.MESSAGE_CODE_EVALUATION_JOB_FAILED, err.Error())
+		m.recordFailure(ctx, id, jobID, err.Error())
+		return
+	}
+
+	logger.Info("Started provider health check")
+	c.job = job
+	c.startedAt = m.now()
+}
+
+func (m *Monitor) collect(ctx context.Context, provider *api.ProviderResource, c *canary) {
+	id := provider.Resource.ID
+	storage := m.jobStorage(ctx, c.job.Resource.Tenant)
+
+	job, err := storage.GetEvaluationJob(c.job.Resource.ID)
+	if err != nil {
+		m.logger.Warn("Failed to get provider health check job", "provider_id", id, "job_id", c.job.Resource.ID, "error", err)
+		m.recordFailure(ctx, id, c.job.Resource.ID, err.Error())
+		c.job = nil
+		return
+	}
+
+	state := api.OverallStatePending
+	if job.Status != nil {
+		state = job.Status.State
+	}
+
+	switch {
+	case state == api.OverallStateCompleted:
+		m.recordSuccess(ctx, id, job.Resource.ID)
+	case state.IsTerminalState():
+		reason := fmt.Sprintf("health check job finished in state %s", state)
+		if job.Status.Message != nil && job.Status.Message.Message != "" {
+			reason = job.Status.Message.Message
+		}
+		m.recordFailure(ctx, id, job.Resource.ID, reason)
+	case m.now().Sub(c.startedAt) > provider.HealthCheck.EffectiveTimeout():
+		reason := fmt.Sprintf("health check job did not finish within %s", provider.HealthCheck.EffectiveTimeout())
+		if err := m.runtime.WithLogger(m.logger).WithContext(ctx).DeleteEvaluationJobResources(job); err != nil {
+			m.logger.Warn("Failed to delete timed out provider health check job", "provider_id", id, "job_id", job.Resource.ID, "error", err)
+		}
+		m.markJob(storage, job.Resource.ID, api.OverallStateCancelled, constants.MESSAGE_CODE_EVALUATION_JOB_CANCELLED, reason)
+		m.recordFailure(ctx, id, job.Resource.ID, reason)
+	default:
+		// still running
+		return
+	}
+	c.job = nil
+}
+
+func (m *Monitor) markJob(storage abstractions.Storage, jobID string, state api.OverallState, code string, reason string) {
+	message := api.WithMessageOrigin(&api.MessageInfo{
+		Message:     reason,
+		MessageCode: code,
+	}, api.MessageOriginServer)
+	if _, err := storage.UpdateEvaluationJobStatus(jobID, state, message); err != nil {
+		m.logger.Error("Failed to update provider health check job status", "job_id", jobID, "error", err)
+	}
+}
+
+func (m *Monitor) recordSuccess(ctx context.Context, providerID string, jobID string) {
+	m.updateHealth(ctx, providerID, true, func(h *api.ProviderHealth) {
+		now := m.now()
+		h.Status = api.ProviderHealthStatusHealthy
+		h.LastCheckedAt = &now
+		h.LastSuccessAt = &now
+		h.FailureStreak = 0
+		h.LastJobID = jobID
+		h.LastError = ""
+	})
+}
+
+func (m *Monitor) recordFailure(ctx context.Context, providerID string, jobID string, reason string) {
+	m.logger.Warn("Provider health check failed", "provider_id", providerID, "job_id", jobID, "reason", reason)
+	m.updateHealth(ctx, providerID, true, func(h *api.ProviderHealth) {
+		now := m.now()
+		h.Status = api.ProviderHealthStatusUnhealthy
+		h.LastCheckedAt = &now
+		h.FailureStreak++
+		h.LastJobID = jobID
+		h.LastError = reason
+	})
+}
+
+func (m *Monitor) updateHealth(ctx context.Context, providerID string, record bool, update func(h *api.ProviderHealth)) {
+	m.mu.Lock()
+	health := m.health[providerID]
+	update(&health)
+	m.health[providerID] = health
+	m.mu.Unlock()
+
+	if record {
+		metrics.RecordProviderHealthCheck(ctx, providerID, &health)
+	}
+}
+
+// runtimeStorage gives the runtime access to the canary job without the request-scoped
+// side effects (result exports, job metrics) applied to user jobs.
+type runtimeStorage struct {
+	storage abstractions.Storage
+}
+
+func (s *runtimeStorage) GetProvider(id string) (*api.ProviderResource, error) {
+	return s.storage.GetProvider(id)
+}
+
+func (s *runtimeStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent) error {
+	_, err := s.storage.UpdateEvaluationJob(id, runStatus)
+	return err
+}
+
+func (s *runtimeStorage) UpdateEvaluationJobModelMetadata(id string, metadata *api.ModelMetadata) error {
+	return s.storage.UpdateEvaluationJobModelMetadata(id, metadata)
+}
+
+func (s *runtimeStorage) UpdateEvaluationJobBenchmarkPlacement(id string, benchmarkIndex int, placement *api.BenchmarkPlacement) error {
+	return s.storage.UpdateEvaluationJobBenchmarkPlacement(id, benchmarkIndex, placement)
+}
+
+func (s *runtimeStorage) PinEvaluationJobAdapterImage(id string, image string, pinned string) (string, error) {
+	return s.storage.PinEvaluationJobAdapterImage(id, image, pinned)
+}
@@ -0,0 +1,276 @@
+package providerhealth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+type fakeRuntime struct {
+	runErr  error
+	started []*api.EvaluationJobResource
+	deleted []string
+}
+
+func (r *fakeRuntime) WithLogger(_ *slog.Logger) abstractions.Runtime { return r }
+
+func (r *fakeRuntime) WithContext(_ context.Context) abstractions.Runtime { return r }
+
+func (r *fakeRuntime) Name() string { return "fake" }
+
+func (r *fakeRuntime) RunEvaluationJob(evaluation *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, _ abstractions.RuntimeStorage) error {
+	if r.runErr != nil {
+		return r.runErr
+	}
+	r.started = append(r.started, evaluation)
+	return nil
+}
+
+func (r *fakeRuntime) RunEvaluationBenchmarks(_ *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, _ []int, _ abstractions.RuntimeStorage) error {
+	return nil
+}
+
+func (r *fakeRuntime) DeleteEvaluationJobResources(evaluation *api.EvaluationJobResource) error {
+	r.deleted = append(r.deleted, evaluation.Resource.ID)
+	return nil
+}
+
+func (r *fakeRuntime) GetEvaluationLogs(_ *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, _ *int, _ api.EvaluationLogOptions) (string, error) {
+	return "", nil
+}
+
+func (r *fakeRuntime) GetEvaluationJobSpec(_ *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, _ int, _ int, _ abstractions.RuntimeStorage) ([]byte, error) {
+	return nil, nil
+}
+
+func newTestMonitor(t *testing.T, runtime *fakeRuntime, healthCheck *api.ProviderHealthCheck) (*Monitor, abstractions.Storage, *time.Time) {
+	t.Helper()
+	logger := logging.FallbackLogger()
+	providers := map[string]api.ProviderResource{
+		"canary_provider": {
+			Resource: api.Resource{ID: "canary_provider", Owner: abstractions.OwnerSystem},
+			ProviderConfig: api.ProviderConfig{
+				Name:        "Canary provider",
+				Benchmarks:  []api.BenchmarkResource{{ID: "smoke", Name: "Smoke"}},
+				HealthCheck: healthCheck,
+			},
+		},
+		"plain_provider": {
+			Resource: api.Resource{ID: "plain_provider", Owner: abstractions.OwnerSystem},
+			ProviderConfig: api.ProviderConfig{
+				Name:       "Plain provider",
+				Benchmarks: []api.BenchmarkResource{{ID: "smoke", Name: "Smoke"}},
+			},
+		},
+	}
+	databaseConfig := map[string]any{
+		"driver": "sqlite",
+		"url":    fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name()),
+	}
+	store, err := storage.NewStorage(&databaseConfig, nil, providers, false, false, logger)
+	if err != nil {
+		t.Fatalf("NewStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMonitor(logger, store, runtime)
+	m.now = func() time.Time { return now }
+	return m, store, &now
+}
+
+func smokeHealthCheck() *api.ProviderHealthCheck {
+	return &api.ProviderHealthCheck{
+		BenchmarkID:     "smoke",
+		Model:           api.ModelRef{URL: "http://model:8000/v1", Name: "tiny"},
+		IntervalSeconds: 600,
+		TimeoutSeconds:  300,
+	}
+}
+
+func finishJob(t *testing.T, store abstractions.Storage, jobID string, state api.OverallState) {
+	t.Helper()
+	_, err := store.WithOwner(abstractions.OwnerSystem).UpdateEvaluationJobStatus(jobID, state, &api.MessageInfo{Message: "finished", MessageCode: "test"})
+	if err != nil {
+		t.Fatalf("UpdateEvaluationJobStatus: %v", err)
+	}
+}
+
+func TestMonitorRunOnce(t *testing.T) {
+	t.Run("reports success after the canary job completes", func(t *testing.T) {
+		runtime := &fakeRuntime{}
+		m, store, _ := newTestMonitor(t, runtime, smokeHealthCheck())
+
+		m.RunOnce(context.Background())
+		if len(runtime.started) != 1 {
+			t.Fatalf("expected one canary job, got %d", len(runtime.started))
+		}
+		if h := m.ProviderHealth("canary_provider"); h == nil || h.Status != api.ProviderHealthStatusUnknown {
+			t.Fatalf("expected unknown health while the canary runs, got %+v", h)
+		}
+		if h := m.ProviderHealth("plain_provider"); h != nil {
+			t.Fatalf("expected no health for provider without health_check, got %+v", h)
+		}
+
+		job := runtime.started[0]
+		if job.Tags[0] != CanaryTag || job.Benchmarks[0].ID != "smoke" {
+			t.Fatalf("unexpected canary job config: %+v", job.EvaluationJobConfig)
+		}
+		finishJob(t, store, job.Resource.ID, api.OverallStateCompleted)
+
+		m.RunOnce(context.Background())
+		h := m.ProviderHealth("canary_provider")
+		if h.Status != api.ProviderHealthStatusHealthy || h.FailureStreak != 0 || h.LastSuccessAt == nil || h.LastJobID != job.Resource.ID {
+			t.Fatalf("unexpected health after success: %+v", h)
+		}
+		if len(runtime.started) != 1 {
+			t.Fatalf("expected no new canary before the interval elapsed, got %d", len(runtime.started))
+		}
+	})
+
+	t.Run("counts consecutive failures", func(t *testing.T) {
+		runtime := &fakeRuntime{runErr: errors.New("image pull failed")}
+		m, _, now := newTestMonitor(t, runtime, smokeHealthCheck())
+
+		m.RunOnce(context.Background())
+		*now = now.Add(11 * time.Minute)
+		m.RunOnce(context.Background())
+
+		h := m.ProviderHealth("canary_provider")
+		if h.Status != api.ProviderHealthStatusUnhealthy || h.FailureStreak != 2 || h.LastError != "image pull failed" {
+			t.Fatalf("unexpected health after failures: %+v", h)
+		}
+		if h.LastSuccessAt != nil {
+			t.Fatalf("expected no last success, got %v", h.LastSuccessAt)
+		}
+	})
+
+	t.Run("fails and cancels a canary that exceeds its timeout", func(t *testing.T) {
+		runtime := &fakeRuntime{}
+		m, store, now := newTestMonitor(t, runtime, smokeHealthCheck())
+
+		m.RunOnce(context.Background())
+		*now = now.Add(6 * time.Minute)
+		m.RunOnce(context.Background())
+
+		h := m.ProviderHealth("canary_provider")
+		if h.Status != api.ProviderHealthStatusUnhealthy || h.FailureStreak != 1 {
+			t.Fatalf("unexpected health after timeout: %+v", h)
+		}
+		jobID := runtime.started[0].Resource.ID
+		if len(runtime.deleted) != 1 || runtime.deleted[0] != jobID {
+			t.Fatalf("expected timed out canary resources to be deleted, got %v", runtime.deleted)
+		}
+		job, err := store.WithOwner(abstractions.OwnerSystem).GetEvaluationJob(jobID)
+		if err != nil {
+			t.Fatalf("GetEvaluationJob: %v", err)
+		}
+		if job.Status.State != api.OverallStateCancelled {
+			t.Fatalf("expected cancelled canary job, got %s", job.Status.State)
+		}
+	})
+
+	t.Run("reports a health check benchmark the provider does not offer", func(t *testing.T) {
+		runtime := &fakeRuntime{}
+		check := smokeHealthCheck()
+		check.BenchmarkID = "missing"
+		m, _, _ := newTestMonitor(t, runtime, check)
+
+		m.RunOnce(context.Background())
+		if len(runtime.started) != 0 {
+			t.Fatalf("expected no canary job, got %d", len(runtime.started))
+		}
+		if h := m.ProviderHealth("canary_provider"); h.Status != api.ProviderHealthStatusUnhealthy {
+			t.Fatalf("unexpected health: %+v", h)
+		}
+	})
+}
+
+func TestMonitorSharedHealth(t *testing.T) {
+	t.Run("a new leader resumes the failure streak and the canary in flight", func(t *testing.T) {
+		runtime := &fakeRuntime{runErr: errors.New("image pull failed")}
+		previous, store, now := newTestMonitor(t, runtime, smokeHealthCheck())
+		previous.RunOnce(context.Background())
+		runtime.runErr = nil
+		*now = now.Add(11 * time.Minute)
+		previous.RunOnce(context.Background())
+		if len(runtime.started) != 1 {
+			t.Fatalf("expected one canary job, got %d", len(runtime.started))
+		}
+
+		// the leader changes while the canary runs
+		m := NewMonitor(logging.FallbackLogger(), store, runtime)
+		m.now = previous.now
+		m.resume(context.Background())
+		if h := m.ProviderHealth("canary_provider"); h == nil || h.FailureStreak != 1 {
+			t.Fatalf("expected the failure streak of the previous leader, got %+v", h)
+		}
+		jobID := runtime.started[0].Resource.ID
+		finishJob(t, store, jobID, api.OverallStateCompleted)
+
+		m.RunOnce(context.Background())
+		h := m.ProviderHealth("canary_provider")
+		if h.Status != api.ProviderHealthStatusHealthy || h.FailureStreak != 0 || h.LastJobID != jobID {
+			t.Fatalf("expected the canary of the previous leader to be collected, got %+v", h)
+		}
+		if len(runtime.started) != 1 {
+			t.Fatalf("expected no new canary, got %d", len(runtime.started))
+		}
+	})
+
+	t.Run("every replica reports the health stored by the leader", func(t *testing.T) {
+		runtime := &fakeRuntime{runErr: errors.New("image pull failed")}
+		leader, store, _ := newTestMonitor(t, runtime, smokeHealthCheck())
+		leader.RunOnce(context.Background())
+
+		follower := NewMonitor(logging.FallbackLogger(), store, runtime)
+		if h := follower.ProviderHealth("canary_provider"); h != nil {
+			t.Fatalf("expected no health before the sync, got %+v", h)
+		}
+		follower.sync(context.Background())
+		h := follower.ProviderHealth("canary_provider")
+		if h == nil || h.Status != api.ProviderHealthStatusUnhealthy || h.FailureStreak != 1 || h.LastError != "image pull failed" {
+			t.Fatalf("expected the health stored by the leader, got %+v", h)
+		}
+		if h := follower.ProviderHealth("plain_provider"); h != nil {
+			t.Fatalf("expected no health for provider without health_check, got %+v", h)
+		}
+	})
+}
+
+func TestMonitorSetPollInterval(t *testing.T) {
+	m, _, _ := newTestMonitor(t, &fakeRuntime{}, nil)
+	if got := m.PollInterval(); got != DefaultPollInterval {
+		t.Fatalf("PollInterval() = %s, want the default %s", got, DefaultPollInterval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		m.Run(ctx, time.Hour)
+	}()
+
+	m.SetPollInterval(time.Minute)
+	if got := m.PollInterval(); got != time.Minute {
+		t.Errorf("PollInterval() = %s, want 1m", got)
+	}
+	cancel()
+	<-done
+
+	// an interval set while running is kept over the one given to Run
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	m.Run(ctx, time.Hour)
+	if got := m.PollInterval(); got != time.Minute {
+		t.Errorf("PollInterval() = %s after Run, want 1m", got)
+	}
+}
@@ -0,0 +1,154 @@
+// Package redaction scrubs the patterns of the redaction policy of a tenant, e.g. emails or
+// phone numbers in the prompts that safety benchmarks capture, from the artifacts and the
+// messages of its jobs before they are stored.
+package redaction
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"mime"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// Counts are the numbers of matches replaced, by rule name.
+type Counts map[string]int
+
+// Total returns the number of matches replaced by all the rules.
+func (c Counts) Total() int {
+	total := 0
+	for _, n := range c {
+		total += n
+	}
+	return total
+}
+
+type rule struct {
+	name        string
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Redactor applies the rules of a redaction policy, in order. A nil Redactor redacts nothing.
+type Redactor struct {
+	rules []rule
+}
+
+// Compile returns the redactor of the policy, nil when the policy is empty. The patterns
+// must be valid and must not match the empty text.
+func Compile(policy *api.RedactionPolicy) (*Redactor, error) {
+	if policy.IsEmpty() {
+		return nil, nil
+	}
+	redactor := &Redactor{}
+	for _, r := range policy.Rules {
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+		if pattern.MatchString("") {
+			return nil, fmt.Errorf("rule %q: the pattern matches the empty text", r.Name)
+		}
+		replacement := r.Replacement
+		if replacement == "" {
+			replacement = "[REDACTED:" + r.Name + "]"
+		}
+		redactor.rules = append(redactor.rules, rule{name: r.Name, pattern: pattern, replacement: replacement})
+	}
+	return redactor, nil
+}
+
+// Redact returns the text with the matches of the rules replaced, and the numbers of
+// matches replaced by rule.
+func (r *Redactor) Redact(text string) (string, Counts) {
+	counts := Counts{}
+	return r.redact(text, counts), counts
+}
+
+func (r *Redactor) redact(text string, counts Counts) string {
+	if r == nil {
+		return text
+	}
+	for _, rule := range r.rules {
+		text = rule.pattern.ReplaceAllStringFunc(text, func(string) string {
+			counts[rule.name]++
+			return rule.replacement
+		})
+	}
+	return text
+}
+
+// RedactMessage redacts the message and the logs of the diagnostics of a message info in
+// place, and returns the numbers of matches replaced.
+func (r *Redactor) RedactMessage(message *api.MessageInfo) Counts {
+	counts := Counts{}
+	if r == nil || message == nil {
+		return counts
+	}
+	message.Message = r.redact(message.Message, counts)
+	if message.Diagnostics != nil {
+		message.Diagnostics.Logs = r.redact(message.Diagnostics.Logs, counts)
+	}
+	return counts
+}
+
+// Reader redacts the content it reads one line at a time, so that artifacts are redacted as
+// they are streamed.
+type Reader struct {
+	redactor *Redactor
+	source   *bufio.Reader
+	pending  []byte
+	err      error
+	// Counts are the numbers of matches replaced so far, by rule name.
+	Counts Counts
+}
+
+// NewReader returns a reader of the redacted content of source.
+func (r *Redactor) NewReader(source io.Reader) *Reader {
+	return &Reader{redactor: r, source: bufio.NewReader(source), Counts: Counts{}}
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		line, err := r.source.ReadString('\n')
+		if line != "" {
+			r.pending = []byte(r.redactor.redact(line, r.Counts))
+		}
+		r.err = err
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// textMediaTypes are the media types, besides text/*, of the artifacts that are redacted.
+var textMediaTypes = []string{
+	"application/json",
+	"application/jsonl",
+	"application/x-ndjson",
+	"application/x-jsonlines",
+	"application/yaml",
+	"application/x-yaml",
+	"application/xml",
+	"application/csv",
+}
+
+// IsText reports whether an artifact of the content type holds text, which can be redacted;
+// binary artifacts, e.g. checkpoints, are stored as they are.
+func IsText(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") || strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") {
+		return true
+	}
+	return slices.Contains(textMediaTypes, mediaType)
+}
@@ -0,0 +1,92 @@
+package redaction
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func testPolicy() *api.RedactionPolicy {
+	return &api.RedactionPolicy{Rules: []api.RedactionRule{
+		{Name: "email", Pattern: `[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`},
+		{Name: "phone", Pattern: `\+?\d{3}[ -]?\d{3}[ -]?\d{4}`, Replacement: "<phone>"},
+	}}
+}
+
+func TestRedact(t *testing.T) {
+	redactor, err := Compile(testPolicy())
+	if err != nil {
+		t.Fatalf("failed to compile the policy: %v", err)
+	}
+
+	text, counts := redactor.Redact("Write to jane.doe@example.com or john@example.org, or call 555 123 4567.")
+	if want := "Write to [REDACTED:email] or [REDACTED:email], or call <phone>."; text != want {
+		t.Fatalf("Redact = %q, want %q", text, want)
+	}
+	if counts["email"] != 2 || counts["phone"] != 1 || counts.Total() != 3 {
+		t.Fatalf("unexpected counts %v", counts)
+	}
+
+	message := &api.MessageInfo{Message: "prompt from jane@example.com failed", Diagnostics: &api.FailureDiagnostics{Logs: "user=bob@example.com"}}
+	if counts := redactor.RedactMessage(message); counts["email"] != 2 {
+		t.Fatalf("unexpected counts %v", counts)
+	}
+	if message.Message != "prompt from [REDACTED:email] failed" || message.Diagnostics.Logs != "user=[REDACTED:email]" {
+		t.Fatalf("unexpected redacted message %+v", message)
+	}
+
+	var none *Redactor
+	if text, counts := none.Redact("jane@example.com"); text != "jane@example.com" || counts.Total() != 0 {
+		t.Fatal("expected a nil redactor to redact nothing")
+	}
+}
+
+func TestCompile(t *testing.T) {
+	if redactor, err := Compile(&api.RedactionPolicy{}); err != nil || redactor != nil {
+		t.Fatalf("expected no redactor for an empty policy, got %v %v", redactor, err)
+	}
+	if _, err := Compile(&api.RedactionPolicy{Rules: []api.RedactionRule{{Name: "bad", Pattern: "("}}}); err == nil || !strings.Contains(err.Error(), `"bad"`) {
+		t.Fatalf("expected an error naming the invalid rule, got %v", err)
+	}
+	if _, err := Compile(&api.RedactionPolicy{Rules: []api.RedactionRule{{Name: "empty", Pattern: "a*"}}}); err == nil {
+		t.Fatal("expected a pattern that matches the empty text to be rejected")
+	}
+}
+
+func TestReader(t *testing.T) {
+	redactor, err := Compile(testPolicy())
+	if err != nil {
+		t.Fatalf("failed to compile the policy: %v", err)
+	}
+	input := `{"prompt": "I am jane@example.com"}` + "\n" + `{"prompt": "call 555-123-4567"}` + "\n" + `{"prompt": "no pii"}`
+	reader := redactor.NewReader(strings.NewReader(input))
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to read: %v", err)
+	}
+	want := `{"prompt": "I am [REDACTED:email]"}` + "\n" + `{"prompt": "call <phone>"}` + "\n" + `{"prompt": "no pii"}`
+	if string(output) != want {
+		t.Fatalf("read %q, want %q", output, want)
+	}
+	if reader.Counts["email"] != 1 || reader.Counts["phone"] != 1 {
+		t.Fatalf("unexpected counts %v", reader.Counts)
+	}
+}
+
+func TestIsText(t *testing.T) {
+	for contentType, want := range map[string]bool{
+		"text/plain; charset=utf-8": true,
+		"application/json":          true,
+		"application/x-ndjson":      true,
+		"application/vnd.api+json":  true,
+		"application/octet-stream":  false,
+		"image/png":                 false,
+		"":                          false,
+	} {
+		if got := IsText(contentType); got != want {
+			t.Errorf("IsText(%q) = %v, want %v", contentType, got, want)
+		}
+	}
+}
@@ -0,0 +1,532 @@
+// Package argo is a runtime that submits the evaluation jobs as Argo Workflows, for clusters
+// standardized on Argo: each benchmark is a task of the workflow of its job, which Argo runs
+// after the benchmarks it depends on, retries and shows in its UI. The adapters run without
+// the sidecar of the kubernetes runtime and post their status events to eval-hub directly.
+package argo
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/k8s"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	inClusterNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	defaultNamespace       = "default"
+)
+
+type ArgoRuntime struct {
+	logger        *slog.Logger
+	ctx           context.Context
+	config        *config.ArgoConfig
+	serviceConfig *config.Config
+	dynamicClient dynamic.Interface
+	clientset     kubernetes.Interface
+}
+
+func NewArgoRuntime(
+	logger *slog.Logger,
+	serviceConfig *config.Config,
+) (abstractions.Runtime, error) {
+	if err := serviceConfig.Argo.Validate(); err != nil {
+		return nil, err
+	}
+	restConfig, err := k8s.LoadKubernetesConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &ArgoRuntime{
+		logger:        logger,
+		config:        serviceConfig.Argo,
+		serviceConfig: serviceConfig,
+		dynamicClient: dynamicClient,
+		clientset:     clientset,
+	}, nil
+}
+
+func (r *ArgoRuntime) WithLogger(logger *slog.Logger) abstractions.Runtime {
+	return &ArgoRuntime{
+		logger:        logger,
+		ctx:           r.ctx,
+		config:        r.config,
+		serviceConfig: r.serviceConfig,
+		dynamicClient: r.dynamicClient,
+		clientset:     r.clientset,
+	}
+}
+
+func (r *ArgoRuntime) WithContext(ctx context.Context) abstractions.Runtime {
+	return &ArgoRuntime{
+		logger:        r.logger,
+		ctx:           ctx,
+		config:        r.config,
+		serviceConfig: r.serviceConfig,
+		dynamicClient: r.dynamicClient,
+		clientset:     r.clientset,
+	}
+}
+
+func (r *ArgoRuntime) Name() string {
+	return api.RuntimeArgo
+}
+
+// RunEvaluationJob submits one workflow with the benchmarks of the job that have not finished.
+// A benchmark that cannot be rendered fails, and the benchmarks that depend on it do not run.
+func (r *ArgoRuntime) RunEvaluationJob(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	storage abstractions.RuntimeStorage,
+) error {
+	if len(benchmarks) == 0 {
+		return serviceerrors.NewServiceError(messages.EvaluationJobEmpty, "EvaluationJobID", evaluation.Resource.ID)
+	}
+	if r.ctx == nil {
+		return fmt.Errorf("argo runtime: nil context — WithContext must be called before RunEvaluationJob")
+	}
+
+	prepared := map[int]*workflowBenchmark{}
+	for i := range benchmarks {
+		if status := benchmarkStatus(evaluation, i); status != nil && api.IsBenchmarkTerminalState(status.Status) {
+			continue
+		}
+		b, err := r.prepareBenchmark(evaluation, benchmarks, i, storage)
+		if err != nil {
+			r.failBenchmark(evaluation.Resource.ID, benchmarks[i], i, err, storage)
+			continue
+		}
+		prepared[i] = b
+	}
+	// a benchmark runs in the workflow when each of its dependencies has completed or runs
+	// in the workflow too
+	for changed := true; changed; {
+		changed = false
+		for i, b := range prepared {
+			b.dependencies = nil
+			for _, dependency := range benchmarks[i].DependsOn {
+				if _, ok := prepared[dependency]; ok {
+					b.dependencies = append(b.dependencies, dependency)
+				} else if status := benchmarkStatus(evaluation, dependency); status == nil || status.Status != api.StateCompleted {
+					delete(prepared, i)
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	if len(prepared) == 0 {
+		return nil
+	}
+	indices := make([]int, 0, len(prepared))
+	for i := range prepared {
+		indices = append(indices, i)
+	}
+	sort.Ints(indices)
+	workflowBenchmarks := make([]workflowBenchmark, len(indices))
+	for n, i := range indices {
+		workflowBenchmarks[n] = *prepared[i]
+	}
+	return r.submitWorkflow(evaluation, workflowBenchmarks, workflowName(evaluation.Resource.ID, ""))
+}
+
+// RunEvaluationBenchmarks submits a workflow for each benchmark at benchmarkIndices, except
+// for the benchmarks that the DAG of the workflow of the job starts itself: the benchmarks
+// of that workflow that depend on others and that, like all the benchmarks they depend on,
+// were not retried.
+func (r *ArgoRuntime) RunEvaluationBenchmarks(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndices []int,
+	storage abstractions.RuntimeStorage,
+) error {
+	if r.ctx == nil {
+		return fmt.Errorf("argo runtime: nil context — WithContext must be called before RunEvaluationBenchmarks")
+	}
+	var jobTasks map[string]bool
+	for _, i := range benchmarkIndices {
+		if i < 0 || i >= len(benchmarks) {
+			continue
+		}
+		if len(benchmarks[i].DependsOn) > 0 && !retried(evaluation, benchmarks, i, map[int]bool{}) {
+			if jobTasks == nil {
+				tasks, err := r.jobWorkflowTasks(evaluation)
+				if err != nil {
+					return err
+				}
+				jobTasks = tasks
+			}
+			if jobTasks[benchmarkTaskName(i)] {
+				continue
+			}
+		}
+		b, err := r.prepareBenchmark(evaluation, benchmarks, i, storage)
+		if err != nil {
+			r.failBenchmark(evaluation.Resource.ID, benchmarks[i], i, err, storage)
+			continue
+		}
+		suffix := fmt.Sprintf("-b%d-a%d", i, benchmarkAttempts(evaluation, i))
+		if err := r.submitWorkflow(evaluation, []workflowBenchmark{*b}, workflowName(evaluation.Resource.ID, suffix)); err != nil {
+			r.failBenchmark(evaluation.Resource.ID, benchmarks[i], i, err, storage)
+		}
+	}
+	return nil
+}
+
+// jobWorkflowTasks returns the names of the tasks of the workflow of the job, none when the
+// benchmarks of the job were submitted separately, e.g. next to benchmarks of other runtimes.
+func (r *ArgoRuntime) jobWorkflowTasks(evaluation *api.EvaluationJobResource) (map[string]bool, error) {
+	name := workflowName(evaluation.Resource.ID, "")
+	wf, err := r.dynamicClient.Resource(workflowGVR).Namespace(r.namespace(evaluation)).Get(r.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return map[string]bool{}, nil
+		}
+		return nil, fmt.Errorf("get argo workflow %s: %w", name, err)
+	}
+	tasks := map[string]bool{}
+	templates, _, _ := unstructured.NestedSlice(wf.Object, "spec", "templates")
+	for _, item := range templates {
+		if t, ok := item.(map[string]any); ok {
+			if name, ok := t["name"].(string); ok {
+				tasks[name] = true
+			}
+		}
+	}
+	return tasks, nil
+}
+
+// retried returns true when the benchmark, or one of the benchmarks it depends on, was run
+// again outside of the workflow of the job.
+func retried(evaluation *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig, index int, seen map[int]bool) bool {
+	if seen[index] || index < 0 || index >= len(benchmarks) {
+		return false
+	}
+	seen[index] = true
+	if benchmarkAttempts(evaluation, index) > 0 {
+		return true
+	}
+	for _, dependency := range benchmarks[index].DependsOn {
+		if retried(evaluation, benchmarks, dependency, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// prepareBenchmark builds the job spec of the benchmark, for an adapter that posts its status
+// events to eval-hub directly.
+func (r *ArgoRuntime) prepareBenchmark(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	storage abstractions.RuntimeStorage,
+) (*workflowBenchmark, error) {
+	bench := benchmarks[benchmarkIndex]
+	provider, err := storage.GetProvider(bench.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	if provider.Runtime == nil || provider.Runtime.K8s == nil {
+		return nil, fmt.Errorf("provider %s has no runtime.k8s configuration for the argo runtime", bench.ProviderID)
+	}
+	if provider.Runtime.K8s.ResultRelay {
+		return nil, fmt.Errorf("provider %s relays its results through the sidecar, which the argo runtime does not run", bench.ProviderID)
+	}
+	spec, err := r.buildJobSpec(evaluation, provider, bench, benchmarkIndex)
+	if err != nil {
+		return nil, err
+	}
+	data, err := shared.MarshalJobSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	return &workflowBenchmark{index: benchmarkIndex, bench: bench, provider: provider, jobSpec: data}, nil
+}
+
+func (r *ArgoRuntime) buildJobSpec(
+	evaluation *api.EvaluationJobResource,
+	provider *api.ProviderResource,
+	bench api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+) (*shared.JobSpec, error) {
+	bench = provider.WithDefaultParameters(bench)
+	callbackURL := strings.TrimSuffix(r.config.EvalHubURL, "/")
+	spec, err := shared.BuildJobSpec(evaluation, bench.ProviderID, &bench, benchmarkIndex, &callbackURL)
+	if err != nil {
+		return nil, fmt.Errorf("build job spec: %w", err)
+	}
+	spec.CallbackToken = callbackauth.Token(r.serviceConfig.CallbackAuth, evaluation.Resource.ID)
+	shared.ApplySampling(spec, evaluation, r.serviceConfig.Sampling)
+	spec.SpecVersion, err = shared.NegotiateJobSpecVersion(provider.JobSpecVersions)
+	if err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+func (r *ArgoRuntime) submitWorkflow(evaluation *api.EvaluationJobResource, benchmarks []workflowBenchmark, name string) error {
+	jobID := evaluation.Resource.ID
+	namespace := r.namespace(evaluation)
+	wf, err := buildWorkflow(evaluation, benchmarks, workflowOptions{
+		name:             name,
+		namespace:        namespace,
+		serviceAccount:   r.config.ServiceAccount,
+		eventsURL:        strings.TrimSuffix(r.config.EvalHubURL, "/") + "/api/v1/evaluations/jobs/" + jobID + "/events",
+		callbackToken:    callbackauth.Token(r.serviceConfig.CallbackAuth, jobID),
+		exitHandlerImage: r.config.EffectiveExitHandlerImage(),
+		ttlSeconds:       int32(r.config.TTL / time.Second),
+		archiveData:      r.config.ArchiveData,
+		jobPolicy:        evaluation.JobPolicy,
+		env:              evaluation.Env,
+	})
+	if err != nil {
+		return err
+	}
+	object, err := wf.toUnstructured()
+	if err != nil {
+		return err
+	}
+	if _, err := r.dynamicClient.Resource(workflowGVR).Namespace(namespace).Create(r.ctx, object, metav1.CreateOptions{}); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			r.logger.Info("argo workflow already submitted", "job_id", jobID, "workflow", name, "namespace", namespace)
+			return nil
+		}
+		return fmt.Errorf("submit argo workflow %s: %w", name, err)
+	}
+	r.logger.Info("argo workflow submitted", "job_id", jobID, "workflow", name, "namespace", namespace, "benchmarks", len(benchmarks))
+	return nil
+}
+
+func (r *ArgoRuntime) failBenchmark(
+	jobID string,
+	bench api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	cause error,
+	storage abstractions.RuntimeStorage,
+) {
+	r.logger.Error("failed to run benchmark on argo", "error", cause, "job_id", jobID, "benchmark_id", bench.ID, "benchmark_index", benchmarkIndex)
+	if storage == nil {
+		return
+	}
+	event := &api.BenchmarkStatusEvent{
+		ProviderID:     bench.ProviderID,
+		ID:             bench.ID,
+		BenchmarkIndex: benchmarkIndex,
+		Status:         api.StateFailed,
+		CompletedAt:    api.DateTimeToString(time.Now()),
+		ErrorMessage: api.WithMessageOrigin(&api.MessageInfo{
+			Message:     cause.Error(),
+			MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_FAILED,
+		}, api.MessageOriginRuntime),
+	}
+	if err := storage.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: event}); err != nil {
+		r.logger.Error("failed to update benchmark status", "error", err, "job_id", jobID, "benchmark_index", benchmarkIndex)
+	}
+}
+
+// DeleteEvaluationJobResources deletes the workflows of the job, which stops their pods.
+func (r *ArgoRuntime) DeleteEvaluationJobResources(evaluation *api.EvaluationJobResource) error {
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	namespace := r.namespace(evaluation)
+	workflows := r.dynamicClient.Resource(workflowGVR).Namespace(namespace)
+	list, err := workflows.List(ctx, metav1.ListOptions{LabelSelector: jobSelector(evaluation.Resource.ID)})
+	if err != nil {
+		return fmt.Errorf("list argo workflows: %w", err)
+	}
+	propagation := metav1.DeletePropagationBackground
+	for _, item := range list.Items {
+		if err := workflows.Delete(ctx, item.GetName(), metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete argo workflow %s: %w", item.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// GetEvaluationLogs returns the logs of the adapter of the latest pod of each benchmark.
+func (r *ArgoRuntime) GetEvaluationLogs(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndex *int,
+	opts api.EvaluationLogOptions,
+) (string, error) {
+	if r.ctx == nil {
+		return "", fmt.Errorf("argo runtime: nil context — WithContext must be called before GetEvaluationLogs")
+	}
+	if len(benchmarks) == 0 {
+		return "", serviceerrors.NewServiceError(messages.EvaluationJobEmpty, "EvaluationJobID", evaluation.Resource.ID)
+	}
+	if benchmarkIndex != nil {
+		if *benchmarkIndex < 0 || *benchmarkIndex >= len(benchmarks) {
+			return "", serviceerrors.NewServiceError(
+				messages.ResourceNotFound,
+				"Type", "benchmark",
+				"ResourceId", fmt.Sprintf("%d", *benchmarkIndex),
+			)
+		}
+		return r.readBenchmarkLogs(evaluation, benchmarks[*benchmarkIndex], *benchmarkIndex, opts, false)
+	}
+	var sections []string
+	for i, bench := range benchmarks {
+		section, err := r.readBenchmarkLogs(evaluation, bench, i, opts, true)
+		if err != nil {
+			return "", err
+		}
+		sections = append(sections, section)
+	}
+	return strings.Join(sections, "\n"), nil
+}
+
+func (r *ArgoRuntime) readBenchmarkLogs(
+	evaluation *api.EvaluationJobResource,
+	bench api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	opts api.EvaluationLogOptions,
+	includeHeader bool,
+) (string, error) {
+	namespace := r.namespace(evaluation)
+	selector := fmt.Sprintf("%s,%s=%d", jobSelector(evaluation.Resource.ID), labelBenchmarkIndexKey, benchmarkIndex)
+	pods, err := r.clientset.CoreV1().Pods(namespace).List(r.ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return "", err
+	}
+	if len(pods.Items) == 0 {
+		if includeHeader {
+			return shared.FormatLogSectionHeader("unknown", mainContainerName, bench.ID), nil
+		}
+		return "", nil
+	}
+	// the latest pod is the last retry of the benchmark
+	sort.Slice(pods.Items, func(i, j int) bool {
+		return pods.Items[i].CreationTimestamp.After(pods.Items[j].CreationTimestamp.Time)
+	})
+	pod := pods.Items[0]
+
+	logOpts := &corev1.PodLogOptions{Container: mainContainerName, Timestamps: opts.Timestamps}
+	if opts.TailLines > 0 {
+		tail := int64(opts.TailLines)
+		logOpts.TailLines = &tail
+	}
+	if opts.SinceSeconds != nil {
+		since := int64(*opts.SinceSeconds)
+		logOpts.SinceSeconds = &since
+	}
+	data, err := r.clientset.CoreV1().Pods(namespace).GetLogs(pod.Name, logOpts).DoRaw(r.ctx)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", err
+	}
+	logs := strings.TrimRight(string(data), "\n")
+	if !includeHeader {
+		return logs, nil
+	}
+	header := shared.FormatLogSectionHeader(pod.Name, mainContainerName, bench.ID)
+	if logs == "" {
+		return header, nil
+	}
+	return header + "\n" + logs, nil
+}
+
+// GetEvaluationJobSpec returns the job spec of the benchmark in its workflow. Benchmarks are
+// not sharded in the argo runtime, so there is only shard 0.
+func (r *ArgoRuntime) GetEvaluationJobSpec(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	shardIndex int,
+	storage abstractions.RuntimeStorage,
+) ([]byte, error) {
+	if benchmarkIndex < 0 || benchmarkIndex >= len(benchmarks) {
+		return nil, serviceerrors.NewServiceError(
+			messages.ResourceNotFound,
+			"Type", "benchmark",
+			"ResourceId", fmt.Sprintf("%d", benchmarkIndex),
+		)
+	}
+	if shardIndex != 0 {
+		return nil, serviceerrors.NewServiceError(
+			messages.ResourceNotFound,
+			"Type", "shard",
+			"ResourceId", fmt.Sprintf("%d", shardIndex),
+		)
+	}
+	bench := benchmarks[benchmarkIndex]
+	provider, err := storage.GetProvider(bench.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := r.buildJobSpec(evaluation, provider, bench, benchmarkIndex)
+	if err != nil {
+		return nil, err
+	}
+	spec.CallbackToken = ""
+	return shared.MarshalJobSpec(spec)
+}
+
+// namespace returns the namespace of the workflows of the job: the configured one, else the
+// namespace of the tenant of the job, like the kubernetes runtime.
+func (r *ArgoRuntime) namespace(evaluation *api.EvaluationJobResource) string {
+	if r.config.Namespace != "" {
+		return r.config.Namespace
+	}
+	if tenant := string(evaluation.Resource.Tenant); tenant != "" {
+		return tenant
+	}
+	if content, err := os.ReadFile(inClusterNamespaceFile); err == nil {
+		if namespace := strings.TrimSpace(string(content)); namespace != "" {
+			return namespace
+		}
+	}
+	return defaultNamespace
+}
+
+func jobSelector(jobID string) string {
+	return labelJobIDKey + "=" + sanitizeLabelValue(jobID)
+}
+
+func benchmarkStatus(evaluation *api.EvaluationJobResource, benchmarkIndex int) *api.BenchmarkStatus {
+	if evaluation == nil || evaluation.Status == nil {
+		return nil
+	}
+	for i, benchmark := range evaluation.Status.Benchmarks {
+		if benchmark.BenchmarkIndex == benchmarkIndex {
+			return &evaluation.Status.Benchmarks[i]
+		}
+	}
+	return nil
+}
+
+func benchmarkAttempts(evaluation *api.EvaluationJobResource, benchmarkIndex int) int {
+	if status := benchmarkStatus(evaluation, benchmarkIndex); status != nil {
+		return len(status.Attempts)
+	}
+	return 0
+}
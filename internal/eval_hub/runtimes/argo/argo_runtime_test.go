@@ -0,0 +1,283 @@
+package argo
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// fakeStorage serves the providers and records the status updates of the runtime.
+type fakeStorage struct {
+	providers map[string]*api.ProviderResource
+	events    []*api.BenchmarkStatusEvent
+}
+
+func (f *fakeStorage) GetProvider(id string) (*api.ProviderResource, error) {
+	if provider, ok := f.providers[id]; ok {
+		return provider, nil
+	}
+	return &api.ProviderResource{Resource: api.Resource{ID: id}}, nil
+}
+
+func (f *fakeStorage) UpdateEvaluationJob(_ string, runStatus *api.StatusEvent) error {
+	f.events = append(f.events, runStatus.BenchmarkStatusEvent)
+	return nil
+}
+
+func (f *fakeStorage) UpdateEvaluationJobModelMetadata(_ string, _ *api.ModelMetadata) error {
+	return nil
+}
+func (f *fakeStorage) UpdateEvaluationJobBenchmarkPlacement(_ string, _ int, _ *api.BenchmarkPlacement) error {
+	return nil
+}
+func (f *fakeStorage) PinEvaluationJobAdapterImage(_ string, _ string, pinned string) (string, error) {
+	return pinned, nil
+}
+
+func newTestRuntime(objects ...k8sruntime.Object) *ArgoRuntime {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		k8sruntime.NewScheme(),
+		map[schema.GroupVersionResource]string{workflowGVR: "WorkflowList"},
+		objects...,
+	)
+	return &ArgoRuntime{
+		logger: slog.New(slog.DiscardHandler),
+		ctx:    context.Background(),
+		config: &config.ArgoConfig{Namespace: "evals", EvalHubURL: "https://evalhub.evals.svc:8443/"},
+		serviceConfig: &config.Config{
+			CallbackAuth: &config.CallbackAuthConfig{Enabled: true, Secret: "s3cr3t"},
+		},
+		dynamicClient: dynamicClient,
+		clientset:     fake.NewClientset(),
+	}
+}
+
+func sampleStorage() *fakeStorage {
+	backoffLimit := int32(2)
+	provider := &api.ProviderResource{Resource: api.Resource{ID: "provider-1"}}
+	provider.Runtime = &api.Runtime{K8s: &api.K8sRuntime{
+		Image:       "quay.io/evalhub/adapter:latest",
+		Entrypoint:  []string{"python", "-m", "adapter"},
+		CPURequest:  "500m",
+		MemoryLimit: "2Gi",
+		Env:         []api.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+		JobPolicy:   &api.JobPolicy{BackoffLimit: &backoffLimit},
+	}}
+	return &fakeStorage{providers: map[string]*api.ProviderResource{"provider-1": provider}}
+}
+
+func sampleEvaluation() (*api.EvaluationJobResource, []api.EvaluationBenchmarkConfig) {
+	evaluation := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1"}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model: api.ModelRef{URL: "http://model.example", Name: "model-1"},
+		},
+	}
+	benchmarks := []api.EvaluationBenchmarkConfig{
+		{Ref: api.Ref{ID: "bench-0"}, ProviderID: "provider-1"},
+		{Ref: api.Ref{ID: "bench-1"}, ProviderID: "provider-1", DependsOn: []int{0}},
+		{Ref: api.Ref{ID: "bench-2"}, ProviderID: "provider-1"},
+	}
+	return evaluation, benchmarks
+}
+
+func getWorkflow(t *testing.T, runtime *ArgoRuntime, name string) *workflow {
+	t.Helper()
+	object, err := runtime.dynamicClient.Resource(workflowGVR).Namespace("evals").Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get workflow %s: %v", name, err)
+	}
+	data, err := json.Marshal(object.Object)
+	if err != nil {
+		t.Fatalf("marshal workflow: %v", err)
+	}
+	wf := &workflow{}
+	if err := json.Unmarshal(data, wf); err != nil {
+		t.Fatalf("unmarshal workflow: %v", err)
+	}
+	return wf
+}
+
+func findTemplate(wf *workflow, name string) *template {
+	for i := range wf.Spec.Templates {
+		if wf.Spec.Templates[i].Name == name {
+			return &wf.Spec.Templates[i]
+		}
+	}
+	return nil
+}
+
+func TestRunEvaluationJobSubmitsTheBenchmarksAsADAG(t *testing.T) {
+	runtime := newTestRuntime()
+	evaluation, benchmarks := sampleEvaluation()
+
+	if err := runtime.RunEvaluationJob(evaluation, benchmarks, sampleStorage()); err != nil {
+		t.Fatalf("RunEvaluationJob: %v", err)
+	}
+	wf := getWorkflow(t, runtime, "evalhub-job-1")
+	if wf.Metadata.Labels[labelJobIDKey] != "job-1" || wf.Spec.OnExit != exitHandlerTemplateName {
+		t.Fatalf("expected the workflow of the job with an exit handler, got %+v", wf.Metadata)
+	}
+	dag := findTemplate(wf, entrypointTemplateName).DAG
+	if len(dag.Tasks) != 3 {
+		t.Fatalf("expected a task per benchmark, got %+v", dag.Tasks)
+	}
+	if deps := dag.Tasks[1].Dependencies; len(deps) != 1 || deps[0] != "benchmark-0" {
+		t.Fatalf("expected benchmark-1 to depend on benchmark-0, got %v", deps)
+	}
+
+	bench := findTemplate(wf, "benchmark-1")
+	if bench.Container.Image != "quay.io/evalhub/adapter:latest" || bench.Container.Resources.Limits.Memory().String() != "2Gi" {
+		t.Fatalf("expected the adapter of the provider, got %+v", bench.Container)
+	}
+	if bench.RetryStrategy == nil || bench.RetryStrategy.Limit != 2 {
+		t.Fatalf("expected the backoff limit of the provider as retry limit, got %+v", bench.RetryStrategy)
+	}
+	spec := map[string]any{}
+	if err := json.Unmarshal([]byte(bench.Inputs.Artifacts[0].Raw.Data), &spec); err != nil {
+		t.Fatalf("unmarshal job spec: %v", err)
+	}
+	if spec["callback_url"] != "https://evalhub.evals.svc:8443" || spec["benchmark_id"] != "bench-1" || spec["callback_token"] == "" {
+		t.Fatalf("expected the job spec to call eval-hub directly, got %v", spec)
+	}
+
+	exit := findTemplate(wf, exitHandlerTemplateName).Script
+	for _, want := range []string{
+		`report benchmark-2 '{"benchmark_status_event":{"provider_id":"provider-1","id":"bench-2","benchmark_index":2,"status":"failed"`,
+		"X-Evalhub-Callback-Token: ${CALLBACK_TOKEN}",
+	} {
+		if !strings.Contains(exit.Source, want) {
+			t.Errorf("expected the exit handler to contain %q, got\n%s", want, exit.Source)
+		}
+	}
+	if exit.Env[1].Value != "https://evalhub.evals.svc:8443/api/v1/evaluations/jobs/job-1/events" {
+		t.Errorf("expected the events URL of the job, got %s", exit.Env[1].Value)
+	}
+}
+
+func TestRunEvaluationJobFailsTheBenchmarksWithoutAKubernetesRuntime(t *testing.T) {
+	runtime := newTestRuntime()
+	evaluation, benchmarks := sampleEvaluation()
+	benchmarks[0].ProviderID = "provider-local"
+	storage := sampleStorage()
+
+	if err := runtime.RunEvaluationJob(evaluation, benchmarks, storage); err != nil {
+		t.Fatalf("RunEvaluationJob: %v", err)
+	}
+	if len(storage.events) != 1 || storage.events[0].BenchmarkIndex != 0 || storage.events[0].Status != api.StateFailed {
+		t.Fatalf("expected benchmark 0 to fail, got %+v", storage.events)
+	}
+	wf := getWorkflow(t, runtime, "evalhub-job-1")
+	// benchmark 1 depends on the failed benchmark and does not run
+	if tasks := findTemplate(wf, entrypointTemplateName).DAG.Tasks; len(tasks) != 1 || tasks[0].Name != "benchmark-2" {
+		t.Fatalf("expected only benchmark-2 to run, got %+v", tasks)
+	}
+}
+
+func TestRunEvaluationBenchmarksLeavesTheDependentsToTheDAG(t *testing.T) {
+	runtime := newTestRuntime()
+	evaluation, benchmarks := sampleEvaluation()
+	storage := sampleStorage()
+	if err := runtime.RunEvaluationJob(evaluation, benchmarks, storage); err != nil {
+		t.Fatalf("RunEvaluationJob: %v", err)
+	}
+
+	// benchmark 1 is ready once benchmark 0 completes, the DAG starts it
+	if err := runtime.RunEvaluationBenchmarks(evaluation, benchmarks, []int{1}, storage); err != nil {
+		t.Fatalf("RunEvaluationBenchmarks: %v", err)
+	}
+	list, err := runtime.dynamicClient.Resource(workflowGVR).Namespace("evals").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("list workflows: %v", err)
+	}
+	if len(list.Items) != 1 {
+		t.Fatalf("expected no other workflow, got %d", len(list.Items))
+	}
+
+	// a retried benchmark runs in a workflow of its own
+	evaluation.Status = &api.EvaluationJobStatus{Benchmarks: []api.BenchmarkStatus{
+		{BenchmarkIndex: 0, Status: api.StatePending, Attempts: []api.BenchmarkAttempt{{}}},
+	}}
+	if err := runtime.RunEvaluationBenchmarks(evaluation, benchmarks, []int{0}, storage); err != nil {
+		t.Fatalf("RunEvaluationBenchmarks: %v", err)
+	}
+	wf := getWorkflow(t, runtime, "evalhub-job-1-b0-a1")
+	if tasks := findTemplate(wf, entrypointTemplateName).DAG.Tasks; len(tasks) != 1 || tasks[0].Name != "benchmark-0" {
+		t.Fatalf("expected the retry of benchmark-0, got %+v", tasks)
+	}
+	// and so do the benchmarks that depend on it
+	if err := runtime.RunEvaluationBenchmarks(evaluation, benchmarks, []int{1}, storage); err != nil {
+		t.Fatalf("RunEvaluationBenchmarks: %v", err)
+	}
+	getWorkflow(t, runtime, "evalhub-job-1-b1-a0")
+}
+
+func TestGetEvaluationJobSpecOmitsTheCallbackToken(t *testing.T) {
+	runtime := newTestRuntime()
+	evaluation, benchmarks := sampleEvaluation()
+
+	data, err := runtime.GetEvaluationJobSpec(evaluation, benchmarks, 1, 0, sampleStorage())
+	if err != nil {
+		t.Fatalf("GetEvaluationJobSpec: %v", err)
+	}
+	spec := map[string]any{}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("unmarshal job spec: %v", err)
+	}
+	if spec["benchmark_id"] != "bench-1" {
+		t.Fatalf("expected the job spec of bench-1, got %v", spec)
+	}
+	if token, ok := spec["callback_token"]; ok {
+		t.Errorf("expected no callback token in the job spec, got %v", token)
+	}
+}
+
+func TestDeleteEvaluationJobResourcesDeletesTheWorkflowsOfTheJob(t *testing.T) {
+	other := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": workflowAPIVersion,
+		"kind":       workflowKind,
+		"metadata": map[string]any{
+			"name":      "evalhub-job-2",
+			"namespace": "evals",
+			"labels":    map[string]any{labelJobIDKey: "job-2"},
+		},
+	}}
+	runtime := newTestRuntime(other)
+	evaluation, benchmarks := sampleEvaluation()
+	if err := runtime.RunEvaluationJob(evaluation, benchmarks, sampleStorage()); err != nil {
+		t.Fatalf("RunEvaluationJob: %v", err)
+	}
+
+	if err := runtime.DeleteEvaluationJobResources(evaluation); err != nil {
+		t.Fatalf("DeleteEvaluationJobResources: %v", err)
+	}
+	list, err := runtime.dynamicClient.Resource(workflowGVR).Namespace("evals").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("list workflows: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].GetName() != "evalhub-job-2" {
+		t.Fatalf("expected only the workflow of the other job to remain, got %d", len(list.Items))
+	}
+}
+
+func TestArgoConfigValidate(t *testing.T) {
+	for _, cfg := range []*config.ArgoConfig{nil, {}, {EvalHubURL: "evalhub:8080"}, {EvalHubURL: "http://evalhub:8080", TTL: -1}} {
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("expected an error for %+v", cfg)
+		}
+	}
+	if err := (&config.ArgoConfig{EvalHubURL: "http://evalhub:8080"}).Validate(); err != nil {
+		t.Errorf("Validate: %v", err)
+	}
+}
@@ -0,0 +1,423 @@
+package argo
+
+// Rendering of the benchmarks of an evaluation job into an Argo Workflow.
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/k8s"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	workflowAPIVersion = "argoproj.io/v1alpha1"
+	workflowKind       = "Workflow"
+	workflowNamePrefix = "evalhub-"
+	maxNameLength      = 63
+
+	labelJobIDKey          = "job_id"
+	labelBenchmarkIndexKey = "benchmark_index"
+	labelAppKey            = "app"
+	labelAppValue          = "evalhub"
+	labelComponentKey      = "component"
+	labelComponentValue    = "evaluation-job"
+	annotationJobIDKey     = "eval-hub.github.io/job_id"
+
+	entrypointTemplateName  = "benchmarks"
+	exitHandlerTemplateName = "report-failures"
+	// mainContainerName is the name that Argo gives to the container of a template.
+	mainContainerName = "main"
+
+	jobSpecArtifactName = "job-spec"
+	jobSpecPath         = "/meta/job.json"
+	dataVolumeName      = "data"
+	dataMountPath       = "/data"
+	envJobSpecPathName  = "EVALHUB_JOB_SPEC_PATH"
+	saTokenPath         = "/var/run/secrets/kubernetes.io/serviceaccount/token" // #nosec G101 -- K8s service account mount path
+)
+
+var workflowGVR = schema.GroupVersionResource{
+	Group:    "argoproj.io",
+	Version:  "v1alpha1",
+	Resource: "workflows",
+}
+
+var nameSanitizer = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// workflow is the subset of the Argo Workflow resource that the runtime submits. It is kept
+// local, rather than importing the Argo API, since the workflow is submitted as unstructured.
+type workflow struct {
+	APIVersion string       `json:"apiVersion"`
+	Kind       string       `json:"kind"`
+	Metadata   objectMeta   `json:"metadata"`
+	Spec       workflowSpec `json:"spec"`
+}
+
+type objectMeta struct {
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type workflowSpec struct {
+	Entrypoint         string          `json:"entrypoint"`
+	OnExit             string          `json:"onExit,omitempty"`
+	ServiceAccountName string          `json:"serviceAccountName,omitempty"`
+	TTLStrategy        *ttlStrategy    `json:"ttlStrategy,omitempty"`
+	Volumes            []corev1.Volume `json:"volumes,omitempty"`
+	Templates          []template      `json:"templates"`
+}
+
+type ttlStrategy struct {
+	SecondsAfterCompletion int32 `json:"secondsAfterCompletion"`
+}
+
+type template struct {
+	Name          string            `json:"name"`
+	Metadata      *objectMeta       `json:"metadata,omitempty"`
+	DAG           *dagTemplate      `json:"dag,omitempty"`
+	Container     *corev1.Container `json:"container,omitempty"`
+	Script        *scriptTemplate   `json:"script,omitempty"`
+	Inputs        *artifacts        `json:"inputs,omitempty"`
+	Outputs       *artifacts        `json:"outputs,omitempty"`
+	RetryStrategy *retryStrategy    `json:"retryStrategy,omitempty"`
+	NodeSelector  map[string]string `json:"nodeSelector,omitempty"`
+}
+
+type dagTemplate struct {
+	Tasks []dagTask `json:"tasks"`
+}
+
+type dagTask struct {
+	Name         string   `json:"name"`
+	Template     string   `json:"template"`
+	Dependencies []string `json:"dependencies,omitempty"`
+}
+
+type scriptTemplate struct {
+	Image   string          `json:"image"`
+	Command []string        `json:"command"`
+	Env     []corev1.EnvVar `json:"env,omitempty"`
+	Source  string          `json:"source"`
+}
+
+type artifacts struct {
+	Artifacts []artifact `json:"artifacts"`
+}
+
+type artifact struct {
+	Name     string       `json:"name"`
+	Path     string       `json:"path"`
+	Raw      *rawArtifact `json:"raw,omitempty"`
+	Optional bool         `json:"optional,omitempty"`
+}
+
+type rawArtifact struct {
+	Data string `json:"data"`
+}
+
+type retryStrategy struct {
+	Limit       int32  `json:"limit"`
+	RetryPolicy string `json:"retryPolicy"`
+}
+
+// workflowBenchmark is a benchmark of the job rendered into the workflow, with its job spec.
+type workflowBenchmark struct {
+	index    int
+	bench    api.EvaluationBenchmarkConfig
+	provider *api.ProviderResource
+	jobSpec  []byte
+	// dependencies are the benchmarks of the workflow that must complete first
+	dependencies []int
+}
+
+// workflowOptions are the settings of the deployment that the workflow is rendered with.
+type workflowOptions struct {
+	name             string
+	namespace        string
+	serviceAccount   string
+	eventsURL        string
+	callbackToken    string
+	exitHandlerImage string
+	ttlSeconds       int32
+	archiveData      bool
+	jobPolicy        *api.JobPolicy
+	env              []api.JobEnvVar
+}
+
+// benchmarkTaskName is the name of the DAG task, and of the template, of a benchmark.
+func benchmarkTaskName(index int) string {
+	return "benchmark-" + strconv.Itoa(index)
+}
+
+// buildWorkflow renders the benchmarks into a workflow with a DAG task per benchmark, which
+// depends on the tasks of the benchmarks listed in its depends_on, and an exit handler that
+// reports the benchmarks whose task failed, since their adapter may not have.
+func buildWorkflow(evaluation *api.EvaluationJobResource, benchmarks []workflowBenchmark, opts workflowOptions) (*workflow, error) {
+	jobID := evaluation.Resource.ID
+	labels := map[string]string{
+		labelJobIDKey:     sanitizeLabelValue(jobID),
+		labelAppKey:       labelAppValue,
+		labelComponentKey: labelComponentValue,
+	}
+	wf := &workflow{
+		APIVersion: workflowAPIVersion,
+		Kind:       workflowKind,
+		Metadata: objectMeta{
+			Name:        opts.name,
+			Namespace:   opts.namespace,
+			Labels:      labels,
+			Annotations: map[string]string{annotationJobIDKey: jobID},
+		},
+		Spec: workflowSpec{
+			Entrypoint:         entrypointTemplateName,
+			OnExit:             exitHandlerTemplateName,
+			ServiceAccountName: opts.serviceAccount,
+			Volumes: []corev1.Volume{{
+				Name:         dataVolumeName,
+				VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+			}},
+		},
+	}
+	if opts.ttlSeconds > 0 {
+		wf.Spec.TTLStrategy = &ttlStrategy{SecondsAfterCompletion: opts.ttlSeconds}
+	}
+
+	dag := &dagTemplate{}
+	templates := []template{{Name: entrypointTemplateName, DAG: dag}}
+	for _, b := range benchmarks {
+		task := dagTask{Name: benchmarkTaskName(b.index), Template: benchmarkTaskName(b.index)}
+		for _, dependency := range b.dependencies {
+			task.Dependencies = append(task.Dependencies, benchmarkTaskName(dependency))
+		}
+		dag.Tasks = append(dag.Tasks, task)
+
+		benchmarkTemplate, err := buildBenchmarkTemplate(jobID, b, opts)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *benchmarkTemplate)
+	}
+	exitHandler, err := buildExitHandlerTemplate(benchmarks, opts)
+	if err != nil {
+		return nil, err
+	}
+	wf.Spec.Templates = append(templates, *exitHandler)
+	return wf, nil
+}
+
+// buildBenchmarkTemplate runs the adapter of the provider of the benchmark, with its job
+// spec as a raw input artifact at the path that the adapters read it from.
+func buildBenchmarkTemplate(jobID string, b workflowBenchmark, opts workflowOptions) (*template, error) {
+	k8sRuntime := b.provider.Runtime.K8s
+	resources, err := buildResources(k8sRuntime)
+	if err != nil {
+		return nil, fmt.Errorf("benchmark %s: %w", b.bench.ID, err)
+	}
+	env := []corev1.EnvVar{{Name: envJobSpecPathName, Value: jobSpecPath}}
+	seen := map[string]bool{envJobSpecPathName: true}
+	// the env of the job wins over the env of the provider
+	for _, item := range k8s.JobEnvVars(opts.env) {
+		if !seen[item.Name] {
+			seen[item.Name] = true
+			env = append(env, item)
+		}
+	}
+	for _, item := range k8sRuntime.Env {
+		if item.Name == "" || seen[item.Name] {
+			continue
+		}
+		seen[item.Name] = true
+		env = append(env, corev1.EnvVar{Name: item.Name, Value: item.Value})
+	}
+	container := &corev1.Container{
+		Image:        k8sRuntime.Image,
+		Command:      k8sRuntime.Entrypoint,
+		Env:          env,
+		Resources:    resources,
+		VolumeMounts: []corev1.VolumeMount{{Name: dataVolumeName, MountPath: dataMountPath}},
+	}
+	if k8sRuntime.ImagePullPolicy == "always" {
+		container.ImagePullPolicy = corev1.PullAlways
+	}
+	t := &template{
+		Name: benchmarkTaskName(b.index),
+		Metadata: &objectMeta{Labels: map[string]string{
+			labelJobIDKey:          sanitizeLabelValue(jobID),
+			labelBenchmarkIndexKey: strconv.Itoa(b.index),
+			labelAppKey:            labelAppValue,
+			labelComponentKey:      labelComponentValue,
+		}},
+		Container: container,
+		Inputs: &artifacts{Artifacts: []artifact{{
+			Name: jobSpecArtifactName,
+			Path: jobSpecPath,
+			Raw:  &rawArtifact{Data: string(b.jobSpec)},
+		}}},
+	}
+	if k8sRuntime.GPU != nil {
+		t.NodeSelector = k8sRuntime.GPU.NodeSelector
+	}
+	if opts.archiveData {
+		t.Outputs = &artifacts{Artifacts: []artifact{{Name: dataVolumeName, Path: dataMountPath, Optional: true}}}
+	}
+	if limit := backoffLimit(k8sRuntime.JobPolicy, opts.jobPolicy); limit > 0 {
+		t.RetryStrategy = &retryStrategy{Limit: limit, RetryPolicy: "Always"}
+	}
+	return t, nil
+}
+
+// backoffLimit is the number of retries of the pod of a benchmark, from the job policy of the
+// job, else of the provider.
+func backoffLimit(providerPolicy, jobPolicy *api.JobPolicy) int32 {
+	if jobPolicy != nil && jobPolicy.BackoffLimit != nil {
+		return *jobPolicy.BackoffLimit
+	}
+	if providerPolicy != nil && providerPolicy.BackoffLimit != nil {
+		return *providerPolicy.BackoffLimit
+	}
+	return 0
+}
+
+func buildResources(k8sRuntime *api.K8sRuntime) (corev1.ResourceRequirements, error) {
+	resources := corev1.ResourceRequirements{}
+	set := func(list *corev1.ResourceList, name corev1.ResourceName, value string) error {
+		if value == "" {
+			return nil
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("parse %s: %w", name, err)
+		}
+		if *list == nil {
+			*list = corev1.ResourceList{}
+		}
+		(*list)[name] = quantity
+		return nil
+	}
+	for _, r := range []struct {
+		list  *corev1.ResourceList
+		name  corev1.ResourceName
+		value string
+	}{
+		{&resources.Requests, corev1.ResourceCPU, k8sRuntime.CPURequest},
+		{&resources.Requests, corev1.ResourceMemory, k8sRuntime.MemoryRequest},
+		{&resources.Limits, corev1.ResourceCPU, k8sRuntime.CPULimit},
+		{&resources.Limits, corev1.ResourceMemory, k8sRuntime.MemoryLimit},
+	} {
+		if err := set(r.list, r.name, r.value); err != nil {
+			return corev1.ResourceRequirements{}, err
+		}
+	}
+	if gpu := k8sRuntime.GPU; gpu != nil && gpu.Count > 0 {
+		name := corev1.ResourceName(gpu.Resource)
+		if name == "" {
+			name = "nvidia.com/gpu"
+		}
+		count := strconv.Itoa(gpu.Count)
+		if err := set(&resources.Requests, name, count); err != nil {
+			return corev1.ResourceRequirements{}, err
+		}
+		if err := set(&resources.Limits, name, count); err != nil {
+			return corev1.ResourceRequirements{}, err
+		}
+	}
+	return resources, nil
+}
+
+// buildExitHandlerTemplate posts a failed status event for each benchmark whose task is in
+// the failed nodes of the workflow, i.e. failed after its retries. The adapter of such a
+// benchmark may have crashed or never started, and would otherwise leave it running.
+func buildExitHandlerTemplate(benchmarks []workflowBenchmark, opts workflowOptions) (*template, error) {
+	var source strings.Builder
+	source.WriteString(`set -u
+echo "Content-Type: application/json" > /tmp/headers
+echo "` + callbackauth.TokenHeader + `: ${CALLBACK_TOKEN}" >> /tmp/headers
+if [ -r ` + saTokenPath + ` ]; then
+  echo "Authorization: Bearer $(cat ` + saTokenPath + `)" >> /tmp/headers
+fi
+report() {
+  case "$FAILURES" in
+    *"\"displayName\":\"$1\""*) ;;
+    *) return 0 ;;
+  esac
+  echo "reporting the failure of $1"
+  curl -sS --fail --retry 5 --retry-all-errors -X POST -H @/tmp/headers --data "$2" "$EVENTS_URL" || echo "failed to report the failure of $1"
+}
+`)
+	for _, b := range benchmarks {
+		event, err := json.Marshal(&api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+			ProviderID:     b.bench.ProviderID,
+			ID:             b.bench.ID,
+			BenchmarkIndex: b.index,
+			Status:         api.StateFailed,
+			ErrorMessage: api.WithMessageOrigin(&api.MessageInfo{
+				Message:     fmt.Sprintf("the Argo workflow %s failed benchmark %s, see the workflow for the reason", opts.name, b.bench.ID),
+				MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_FAILED,
+			}, api.MessageOriginRuntime),
+		}})
+		if err != nil {
+			return nil, fmt.Errorf("marshal status event: %w", err)
+		}
+		fmt.Fprintf(&source, "report %s %s\n", benchmarkTaskName(b.index), shellQuote(string(event)))
+	}
+	return &template{
+		Name: exitHandlerTemplateName,
+		Script: &scriptTemplate{
+			Image:   opts.exitHandlerImage,
+			Command: []string{"sh"},
+			Env: []corev1.EnvVar{
+				{Name: "FAILURES", Value: "{{workflow.failures}}"},
+				{Name: "EVENTS_URL", Value: opts.eventsURL},
+				{Name: "CALLBACK_TOKEN", Value: opts.callbackToken},
+			},
+			Source: source.String(),
+		},
+	}, nil
+}
+
+// toUnstructured returns the workflow as the unstructured object that the dynamic client
+// submits.
+func (wf *workflow) toUnstructured() (*unstructured.Unstructured, error) {
+	data, err := json.Marshal(wf)
+	if err != nil {
+		return nil, fmt.Errorf("marshal workflow: %w", err)
+	}
+	object := map[string]any{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("unmarshal workflow: %w", err)
+	}
+	return &unstructured.Unstructured{Object: object}, nil
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// workflowName returns the name of the workflow of the job, with the suffix of a workflow
+// that runs some of its benchmarks again.
+func workflowName(jobID string, suffix string) string {
+	name := strings.Trim(nameSanitizer.ReplaceAllString(strings.ToLower(jobID), "-"), "-")
+	if limit := maxNameLength - len(workflowNamePrefix) - len(suffix); len(name) > limit {
+		name = strings.TrimRight(name[:limit], "-")
+	}
+	return workflowNamePrefix + name + suffix
+}
+
+func sanitizeLabelValue(value string) string {
+	safe := nameSanitizer.ReplaceAllString(strings.ToLower(value), "-")
+	if len(safe) > maxNameLength {
+		safe = safe[:maxNameLength]
+	}
+	return strings.Trim(safe, "-")
+}
@@ -0,0 +1,80 @@
+package k8s
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+const (
+	inferenceServiceAPIGroup   = "serving.kserve.io"
+	inferenceServiceAPIVersion = "v1beta1"
+	inferenceServiceResource   = "inferenceservices"
+	// inferenceServiceTokenKey is the key of the serving auth token in its secret, as in the
+	// secrets of type kubernetes.io/service-account-token.
+	inferenceServiceTokenKey = "token"
+)
+
+func resolveInferenceServiceNamespace(ref *api.InferenceServiceRef, tenant string) string {
+	if namespace := strings.TrimSpace(ref.Namespace); namespace != "" {
+		return namespace
+	}
+	return resolveNamespace(tenant)
+}
+
+// parseInferenceService returns the cluster-internal URL of a ready InferenceService, and
+// an error when the InferenceService is not ready.
+func parseInferenceService(isvc *unstructured.Unstructured) (string, error) {
+	if isvc == nil {
+		return "", fmt.Errorf("inference service is required")
+	}
+	conditions, _, err := unstructured.NestedSlice(isvc.Object, "status", "conditions")
+	if err != nil {
+		return "", fmt.Errorf("read inference service conditions: %w", err)
+	}
+	ready := false
+	reason := "it has no Ready condition"
+	for _, raw := range conditions {
+		condition, ok := raw.(map[string]any)
+		if !ok || stringFromUnstructured(condition["type"]) != "Ready" {
+			continue
+		}
+		ready = stringFromUnstructured(condition["status"]) == "True"
+		reason = "its Ready condition is " + stringFromUnstructured(condition["status"])
+		if message := strings.TrimSpace(stringFromUnstructured(condition["message"])); message != "" {
+			reason += ": " + message
+		}
+	}
+	if !ready {
+		return "", fmt.Errorf("inference service %s/%s is not ready, %s", isvc.GetNamespace(), isvc.GetName(), reason)
+	}
+
+	// the address is the cluster-internal URL, the url may be the external route
+	for _, path := range [][]string{{"status", "address", "url"}, {"status", "url"}} {
+		url, _, err := unstructured.NestedString(isvc.Object, path...)
+		if err == nil && strings.TrimSpace(url) != "" {
+			return strings.TrimSpace(url), nil
+		}
+	}
+	return "", fmt.Errorf("inference service %s/%s has no URL", isvc.GetNamespace(), isvc.GetName())
+}
+
+// withModelURL returns a copy of the evaluation whose model URL is the URL resolved from its
+// InferenceService, which buildJobConfig hands to the sidecar model proxy. The evaluation is
+// shared by the benchmarks of the job and is not changed.
+func withModelURL(evaluation *api.EvaluationJobResource, url string) *api.EvaluationJobResource {
+	resolved := *evaluation
+	resolved.Model.URL = url
+	return &resolved
+}
+
+// inferenceServiceAuthTokenSecret returns the secret of the serving auth token of the model,
+// empty when the model is not an InferenceService or sends the token of the job.
+func inferenceServiceAuthTokenSecret(model api.ModelRef) string {
+	if model.InferenceService == nil {
+		return ""
+	}
+	return strings.TrimSpace(model.InferenceService.AuthTokenSecret)
+}
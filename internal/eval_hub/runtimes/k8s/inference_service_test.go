@@ -0,0 +1,145 @@
+package k8s
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func testInferenceService(status map[string]any) *unstructured.Unstructured {
+	isvc := &unstructured.Unstructured{Object: map[string]any{"status": status}}
+	isvc.SetNamespace("models")
+	isvc.SetName("granite")
+	return isvc
+}
+
+func readyCondition(status string) map[string]any {
+	return map[string]any{"type": "Ready", "status": status, "message": "Revision is not ready"}
+}
+
+func TestParseInferenceService(t *testing.T) {
+	internalURL := "http://granite-predictor.models.svc.cluster.local"
+	url, err := parseInferenceService(testInferenceService(map[string]any{
+		"url":        "https://granite-models.apps.example.com",
+		"address":    map[string]any{"url": internalURL},
+		"conditions": []any{map[string]any{"type": "PredictorReady", "status": "True"}, readyCondition("True")},
+	}))
+	if err != nil {
+		t.Fatalf("parseInferenceService: %v", err)
+	}
+	if url != internalURL {
+		t.Errorf("url = %q, want the cluster-internal address %q", url, internalURL)
+	}
+
+	url, err = parseInferenceService(testInferenceService(map[string]any{
+		"url":        "http://granite.models.example.com",
+		"conditions": []any{readyCondition("True")},
+	}))
+	if err != nil || url != "http://granite.models.example.com" {
+		t.Errorf("expected the url without an address, got %q, %v", url, err)
+	}
+
+	tests := map[string]struct {
+		status map[string]any
+		want   string
+	}{
+		"not ready": {
+			status: map[string]any{"address": map[string]any{"url": internalURL}, "conditions": []any{readyCondition("False")}},
+			want:   "models/granite is not ready, its Ready condition is False: Revision is not ready",
+		},
+		"no conditions": {
+			status: map[string]any{"address": map[string]any{"url": internalURL}},
+			want:   "it has no Ready condition",
+		},
+		"no url": {
+			status: map[string]any{"conditions": []any{readyCondition("True")}},
+			want:   "has no URL",
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseInferenceService(testInferenceService(tt.status))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestResolveInferenceServiceNamespace(t *testing.T) {
+	if got := resolveInferenceServiceNamespace(&api.InferenceServiceRef{Name: "granite", Namespace: "models"}, "tenant-a"); got != "models" {
+		t.Errorf("namespace = %q, want models", got)
+	}
+	if got := resolveInferenceServiceNamespace(&api.InferenceServiceRef{Name: "granite"}, "tenant-a"); got != "tenant-a" {
+		t.Errorf("namespace = %q, want the namespace of the tenant", got)
+	}
+}
+
+func TestWithModelURLDoesNotChangeTheJob(t *testing.T) {
+	evaluation := &api.EvaluationJobResource{}
+	evaluation.Model = api.ModelRef{Name: "granite", InferenceService: &api.InferenceServiceRef{Name: "granite"}}
+
+	resolved := withModelURL(evaluation, "http://granite-predictor.models.svc.cluster.local")
+	if resolved.Model.URL != "http://granite-predictor.models.svc.cluster.local" {
+		t.Errorf("model url = %q", resolved.Model.URL)
+	}
+	if evaluation.Model.URL != "" {
+		t.Errorf("the job shared by the benchmarks was changed: %q", evaluation.Model.URL)
+	}
+}
+
+func TestBuildJobMountsInferenceServiceTokenInSidecarOnly(t *testing.T) {
+	cfg := &jobConfig{
+		jobID:                "job-isvc-token",
+		resourceGUID:         "guid-isvc-token",
+		namespace:            "default",
+		providerID:           "provider-1",
+		benchmarkID:          "bench-1",
+		adapterImage:         "adapter:latest",
+		defaultEnv:           []api.EnvVar{},
+		modelTargetURL:       "http://granite-predictor.models.svc.cluster.local",
+		modelAuthTokenSecret: "granite-sa-token",
+	}
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob: %v", err)
+	}
+	var foundVolume bool
+	for _, v := range job.Spec.Template.Spec.Volumes {
+		if v.Name == modelAuthTokenVolumeName {
+			foundVolume = v.Secret != nil && v.Secret.SecretName == "granite-sa-token" &&
+				len(v.Secret.Items) == 1 && v.Secret.Items[0].Key == inferenceServiceTokenKey
+		}
+	}
+	if !foundVolume {
+		t.Fatalf("expected the token key of secret granite-sa-token as volume %q", modelAuthTokenVolumeName)
+	}
+	sidecar := findContainer(job.Spec.Template.Spec.InitContainers, sidecarContainerName)
+	if sidecar == nil {
+		t.Fatal("expected sidecar init container")
+	}
+	var sidecarMount bool
+	for _, m := range sidecar.VolumeMounts {
+		if m.Name == modelAuthTokenVolumeName && m.MountPath == modelAuthTokenMountPath && m.ReadOnly {
+			sidecarMount = true
+		}
+	}
+	if !sidecarMount {
+		t.Fatalf("sidecar should mount %q read-only at %q", modelAuthTokenVolumeName, modelAuthTokenMountPath)
+	}
+	for _, m := range job.Spec.Template.Spec.Containers[0].VolumeMounts {
+		if m.Name == modelAuthTokenVolumeName {
+			t.Fatalf("adapter must not mount %q", modelAuthTokenVolumeName)
+		}
+	}
+
+	export, err := sidecarForJobPod(nil, cfg)
+	if err != nil {
+		t.Fatalf("sidecarForJobPod: %v", err)
+	}
+	if export.Model == nil || export.Model.AuthTokenPath != modelAuthTokenMountPath+"/token" {
+		t.Fatalf("sidecar model config = %+v, want the mounted token", export.Model)
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"strings"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
 
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -38,9 +40,12 @@ const (
 	sidecarConfigFileName             = "sidecar_config.json"
 	sidecarConfigMountPath            = "/meta/sidecar_config.json"
 	dataMountPath                     = "/data"
+	checkpointsDir                    = "checkpoints"
 	testDataMountPath                 = "/test_data"
 	serviceCAMountPath                = "/etc/pki/ca-trust/source/anchors"
 	specSuffix                        = "-spec"
+	dataVolumeSuffix                  = "-data"
+	annotationDataVolumeCleanupKey    = "eval-hub.github.io/data_volume_cleanup"
 	envMLFlowTrackingURIName          = "MLFLOW_TRACKING_URI"
 	envMLFlowWorkspaceName            = "MLFLOW_WORKSPACE"
 	mlflowTokenVolumeName             = "mlflow-token"
@@ -50,8 +55,12 @@ const (
 	ociCredentialsMountPath           = "/etc/evalhub/.docker/config.json" // #nosec G101 -- K8s secret mount path
 	ociCredentialsSubPath             = ".dockerconfigjson"                // #nosec G101 -- K8s secret subpath name
 	envOCIAuthConfigPathName          = "OCI_AUTH_CONFIG_PATH"
-	modelAuthVolumeName               = "model-auth" // credentials secret; mounted in sidecar only
+	modelAuthVolumeName               = "model-auth"                          // credentials secret; mounted in sidecar only
+	evalHubClientTLSVolumeName        = "evalhub-client-tls"                  // client certificate for eval-hub mTLS; mounted in sidecar only
+	evalHubClientTLSMountPath         = "/var/run/secrets/evalhub-client-tls" // #nosec G101 -- K8s secret mount path
 	modelAuthMountPath                = "/var/run/secrets/model"
+	modelAuthTokenVolumeName          = "model-auth-token"                  // serving auth token secret; mounted in sidecar only
+	modelAuthTokenMountPath           = "/var/run/secrets/model-auth-token" // #nosec G101 -- K8s secret mount path
 	// Standard Kubernetes SA mount path; used by both the sidecar SA token volume and the
 	// adapter DownwardAPI namespace volume so the SDK finds files at the expected locations.
 	k8sSAMountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
@@ -86,6 +95,7 @@ const (
 	labelProviderIDKey               = "provider_id"
 	labelBenchmarkIDKey              = "benchmark_id"
 	labelBenchmarkIndexKey           = "benchmark_index"
+	labelShardIndexKey               = "shard_index"
 	labelAppValue                    = "evalhub"
 	labelComponentValue              = "evaluation-job"
 	capabilityDropAll                = "ALL"
@@ -148,9 +158,9 @@ func buildConfigMap(cfg *jobConfig) (*corev1.ConfigMap, error) {
 	annotations := jobAnnotations(cfg.jobID, cfg.providerID, cfg.benchmarkID)
 	name := configMapName(cfg.jobID, cfg.resourceGUID)
 
-	specJSON, err := json.MarshalIndent(cfg.jobSpec, "", "  ")
+	specJSON, err := shared.MarshalJobSpec(&cfg.jobSpec)
 	if err != nil {
-		return nil, fmt.Errorf("marshal job spec: %w", err)
+		return nil, err
 	}
 	sidecarJSON := "{}"
 	if cfg.sidecarConfig != nil {
@@ -202,13 +212,17 @@ func buildJob(cfg *jobConfig) (*batchv1.Job, error) {
 	if cfg.adapterImage == "" {
 		return nil, fmt.Errorf("adapter image is required")
 	}
-	labels := jobLabels(cfg)
-	annotations := jobAnnotations(cfg.jobID, cfg.providerID, cfg.benchmarkID)
+	labels := withExtraMetadata(jobLabels(cfg), cfg.podLabels)
+	annotations := withExtraMetadata(jobAnnotations(cfg.jobID, cfg.providerID, cfg.benchmarkID), cfg.podAnnotations)
 	jobName := jobName(cfg.jobID, cfg.resourceGUID)
 	configMap := configMapName(cfg.jobID, cfg.resourceGUID)
 
-	ttl := defaultJobTTLSeconds
-	backoff := defaultJobBackoffLimit
+	ttl := cfg.ttlSecondsAfterFinished
+	backoff := cfg.backoffLimit
+	restartPolicy := cfg.restartPolicy
+	if restartPolicy == "" {
+		restartPolicy = corev1.RestartPolicyNever
+	}
 
 	adapterEnvVars := buildEnvVars(cfg)
 	resources, err := buildResources(cfg)
@@ -293,8 +307,9 @@ func buildJob(cfg *jobConfig) (*batchv1.Job, error) {
 					Annotations: annotations,
 				},
 				Spec: corev1.PodSpec{
-					RestartPolicy:                corev1.RestartPolicyNever,
+					RestartPolicy:                restartPolicy,
 					NodeSelector:                 cfg.nodeSelector,
+					Tolerations:                  cfg.tolerations,
 					InitContainers:               initContainers,
 					Containers:                   containers,
 					Volumes:                      jobVolumes,
@@ -307,20 +322,30 @@ func buildJob(cfg *jobConfig) (*batchv1.Job, error) {
 }
 
 func buildRuntimeContainerVolumesAndMounts(configMap string, cfg *jobConfig) ([]corev1.Volume, []corev1.VolumeMount) {
-	volumes := []corev1.Volume{
-		{
-			Name: jobSpecVolumeName,
-			VolumeSource: corev1.VolumeSource{
-				ConfigMap: &corev1.ConfigMapVolumeSource{
-					LocalObjectReference: corev1.LocalObjectReference{Name: configMap},
-				},
+	jobSpecVolume := corev1.Volume{
+		Name: jobSpecVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: configMap},
 			},
 		},
-		{
-			Name: dataVolumeName,
+	}
+	if cfg.parameterSecretName != "" {
+		// the job spec with the resolved secretRef:// parameters is only mounted in the adapter
+		jobSpecVolume = corev1.Volume{
+			Name: resolvedJobSpecVolumeName,
 			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: cfg.parameterSecretName,
+				},
 			},
+		}
+	}
+	volumes := []corev1.Volume{
+		jobSpecVolume,
+		{
+			Name:         dataVolumeName,
+			VolumeSource: dataVolumeSource(cfg),
 		},
 		{
 			Name: terminationFileVolumeName,
@@ -354,7 +379,7 @@ func buildRuntimeContainerVolumesAndMounts(configMap string, cfg *jobConfig) ([]
 	// Build volume mounts list
 	volumeMounts := []corev1.VolumeMount{
 		{
-			Name:      jobSpecVolumeName,
+			Name:      jobSpecVolume.Name,
 			MountPath: jobSpecMountPath,
 			SubPath:   jobSpecFileName,
 			ReadOnly:  true,
@@ -482,10 +507,8 @@ func buildSidecarContainerVolumesAndMounts(configMap string, cfg *jobConfig) ([]
 			},
 		},
 		{
-			Name: dataVolumeName,
-			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
-			},
+			Name:         dataVolumeName,
+			VolumeSource: dataVolumeSource(cfg),
 		},
 		{
 			Name: terminationFileVolumeName,
@@ -603,6 +626,42 @@ func buildSidecarContainerVolumesAndMounts(configMap string, cfg *jobConfig) ([]
 		})
 	}
 
+	// Mount the serving auth token of the InferenceService in the sidecar, which sends it to the
+	// model instead of the service account token of the job.
+	if cfg.modelAuthTokenSecret != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: modelAuthTokenVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: cfg.modelAuthTokenSecret,
+					Items:      []corev1.KeyToPath{{Key: inferenceServiceTokenKey, Path: inferenceServiceTokenKey}},
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      modelAuthTokenVolumeName,
+			MountPath: modelAuthTokenMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	// Mount the client certificate the sidecar presents to eval-hub when it verifies client certificates.
+	if cfg.evalHubClientCertSecret != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: evalHubClientTLSVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: cfg.evalHubClientCertSecret,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      evalHubClientTLSVolumeName,
+			MountPath: evalHubClientTLSMountPath,
+			ReadOnly:  true,
+		})
+	}
+
 	// Mount OCI credentials on the sidecar so it can proxy calls to the OCI registry.
 	// The volume is already on the pod from the runtime container; we only add the mount here.
 	if cfg.ociCredentialsSecret != "" {
@@ -740,6 +799,48 @@ func hasS3TestData(cfg *jobConfig) bool {
 	return normalizeS3Key(cfg.testDataS3.key) != ""
 }
 
+// dataVolumeSource returns the source of the volume mounted at /data: the data volume claim of
+// the provider runtime, or an emptyDir on the ephemeral storage of the node.
+func dataVolumeSource(cfg *jobConfig) corev1.VolumeSource {
+	if cfg.dataVolume == nil {
+		return corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	}
+	return corev1.VolumeSource{
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: cfg.dataVolume.claimName},
+	}
+}
+
+// buildDataVolumeClaim returns the claim provisioned for the benchmark job, nil when the job
+// mounts an existing claim or an emptyDir at /data.
+func buildDataVolumeClaim(cfg *jobConfig) *corev1.PersistentVolumeClaim {
+	if cfg.dataVolume == nil || !cfg.dataVolume.provision {
+		return nil
+	}
+	annotations := jobAnnotations(cfg.jobID, cfg.providerID, cfg.benchmarkID)
+	annotations[annotationDataVolumeCleanupKey] = api.DataVolumeCleanupDelete
+	if cfg.dataVolume.retain {
+		annotations[annotationDataVolumeCleanupKey] = api.DataVolumeCleanupRetain
+	}
+	claim := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cfg.dataVolume.claimName,
+			Namespace:   cfg.namespace,
+			Labels:      jobLabels(cfg),
+			Annotations: annotations,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{cfg.dataVolume.accessMode},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: cfg.dataVolume.size},
+			},
+		},
+	}
+	if cfg.dataVolume.storageClass != "" {
+		claim.Spec.StorageClassName = &cfg.dataVolume.storageClass
+	}
+	return claim
+}
+
 func hasPVCTestData(cfg *jobConfig) bool {
 	return cfg.testDataPVC.claimName != ""
 }
@@ -821,6 +922,15 @@ func buildEnvVars(cfg *jobConfig) []corev1.EnvVar {
 		seen[envMLFlowCertPathName] = true
 	}
 
+	// Add the environment variables of the job, which win over the ones of the provider
+	for _, item := range JobEnvVars(cfg.jobEnv) {
+		if seen[item.Name] {
+			continue
+		}
+		seen[item.Name] = true
+		env = append(env, item)
+	}
+
 	// Add provider-specific environment variables
 	for _, item := range cfg.defaultEnv {
 		if item.Name == "" || seen[item.Name] {
@@ -906,6 +1016,9 @@ func jobLabels(cfg *jobConfig) map[string]string {
 		labelBenchmarkIDKey:    sanitizeLabelValue(cfg.benchmarkID),
 		labelBenchmarkIndexKey: sanitizeLabelValue(strconv.Itoa(cfg.benchmarkIndex)),
 	}
+	if cfg.jobSpec.Shard != nil {
+		m[labelShardIndexKey] = sanitizeLabelValue(strconv.Itoa(cfg.jobSpec.Shard.Index))
+	}
 	if cfg.evalHubInstanceName != "" && cfg.evalHubCRNamespace != "" {
 		m[labelEvalHubInstanceNameKey] = sanitizeLabelValue(cfg.evalHubInstanceName)
 		m[labelEvalHubInstanceNamespaceKey] = sanitizeLabelValue(cfg.evalHubCRNamespace)
@@ -916,6 +1029,17 @@ func jobLabels(cfg *jobConfig) map[string]string {
 	return m
 }
 
+// withExtraMetadata adds the extra labels or annotations of the provider and the job to the
+// built-in ones, which are kept when a key is repeated.
+func withExtraMetadata(builtIn map[string]string, extra map[string]string) map[string]string {
+	for key, value := range extra {
+		if _, ok := builtIn[key]; !ok {
+			builtIn[key] = value
+		}
+	}
+	return builtIn
+}
+
 func jobAnnotations(jobID, providerID, benchmarkID string) map[string]string {
 	return map[string]string{
 		annotationJobIDKey:       jobID,
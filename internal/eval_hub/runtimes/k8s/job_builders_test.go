@@ -9,6 +9,7 @@ import (
 	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
 	"github.com/eval-hub/eval-hub/pkg/api"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestSidecarPortFromInt(t *testing.T) {
@@ -69,7 +70,7 @@ func TestBuildConfigMap(t *testing.T) {
 		namespace:      "default",
 		providerID:     "provider-1",
 		benchmarkID:    "bench-1",
-		jobSpec:        shared.JobSpec{},
+		jobSpec:        shared.JobSpec{SpecVersion: shared.JobSpecVersion},
 		resourceGUID:   "guid-123",
 	}
 
@@ -111,7 +112,7 @@ func TestBuildConfigMapSidecarConfigJSONContent(t *testing.T) {
 		namespace:      "default",
 		providerID:     "provider-1",
 		benchmarkID:    "bench-1",
-		jobSpec:        shared.JobSpec{},
+		jobSpec:        shared.JobSpec{SpecVersion: shared.JobSpecVersion},
 		resourceGUID:   "guid-123",
 		sidecarConfig: &config.SidecarConfig{
 			Port:    8081,
@@ -178,6 +179,17 @@ func TestJobLabelsEvalHubInstance(t *testing.T) {
 	}
 }
 
+func TestJobLabelsShardIndex(t *testing.T) {
+	labels := jobLabels(&jobConfig{jobID: "j", providerID: "p", benchmarkID: "b", benchmarkIndex: 0, jobSpec: shared.JobSpec{Shard: &shared.JobSpecShard{Index: 3, Count: 4}}})
+	if labels[labelShardIndexKey] != "3" {
+		t.Fatalf("expected shard_index label %q, got %q", "3", labels[labelShardIndexKey])
+	}
+	unsharded := jobLabels(&jobConfig{jobID: "j", providerID: "p", benchmarkID: "b", benchmarkIndex: 0})
+	if _, ok := unsharded[labelShardIndexKey]; ok {
+		t.Fatal("expected no shard_index label for an unsharded benchmark")
+	}
+}
+
 func TestJobLabelsKueueQueueName(t *testing.T) {
 	labels := jobLabels(&jobConfig{jobID: "j", providerID: "p", benchmarkID: "b", benchmarkIndex: 0, queueKind: "kueue", queueName: "my-queue"})
 	if labels[labelKueueQueueNameKey] != "my-queue" {
@@ -279,6 +291,47 @@ func TestBuildJobAdapterEvalHubModeEnv(t *testing.T) {
 	}
 }
 
+func TestBuildJobAdapterJobEnv(t *testing.T) {
+	cfg := &jobConfig{
+		jobID:          "job-env",
+		resourceGUID:   "guid-env",
+		benchmarkIndex: 0,
+		namespace:      "default",
+		providerID:     "provider-1",
+		benchmarkID:    "bench-1",
+		adapterImage:   "adapter:latest",
+		defaultEnv:     []api.EnvVar{{Name: "HF_ENDPOINT", Value: "https://huggingface.co"}, {Name: "LOG_LEVEL", Value: "info"}},
+		jobEnv: []api.JobEnvVar{
+			{Name: "HF_ENDPOINT", Value: "https://mirror.example"},
+			{Name: "HF_TOKEN", Value: "secretRef://hf-credentials/token"},
+			{Name: envEvalHubModeName, Value: "local"},
+		},
+	}
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob: %v", err)
+	}
+	env := map[string]corev1.EnvVar{}
+	for _, e := range job.Spec.Template.Spec.Containers[0].Env {
+		if _, ok := env[e.Name]; ok {
+			t.Fatalf("adapter env %q set twice", e.Name)
+		}
+		env[e.Name] = e
+	}
+	if got := env["HF_ENDPOINT"].Value; got != "https://mirror.example" {
+		t.Errorf("HF_ENDPOINT = %q, want the value of the job", got)
+	}
+	if ref := env["HF_TOKEN"].ValueFrom; ref == nil || ref.SecretKeyRef.Name != "hf-credentials" || ref.SecretKeyRef.Key != "token" {
+		t.Errorf("HF_TOKEN = %+v, want the token key of the hf-credentials secret", env["HF_TOKEN"])
+	}
+	if got := env[envEvalHubModeName].Value; got != "k8s" {
+		t.Errorf("EVALHUB_MODE = %q, want the value set by eval-hub", got)
+	}
+	if got := env["LOG_LEVEL"].Value; got != "info" {
+		t.Errorf("LOG_LEVEL = %q, want the value of the provider", got)
+	}
+}
+
 func TestBuildJobSecurityContext(t *testing.T) {
 	cfg := &jobConfig{
 		jobID:          "job-123",
@@ -366,6 +419,66 @@ func TestBuildJobAnnotations(t *testing.T) {
 	}
 }
 
+func TestBuildJobPodMetadata(t *testing.T) {
+	cfg := &jobConfig{
+		jobID:          "job-123",
+		resourceGUID:   "guid-123",
+		namespace:      "default",
+		providerID:     "provider-1",
+		benchmarkID:    "bench-1",
+		adapterImage:   "adapter:latest",
+		defaultEnv:     []api.EnvVar{},
+		podLabels:      map[string]string{"cost-center": "ml-platform", labelJobIDKey: "other-job"},
+		podAnnotations: map[string]string{"sidecar.istio.io/inject": "false"},
+	}
+
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+
+	for name, meta := range map[string]metav1.ObjectMeta{"job": job.ObjectMeta, "pod template": job.Spec.Template.ObjectMeta} {
+		if meta.Labels["cost-center"] != "ml-platform" {
+			t.Errorf("expected %s label cost-center, got %v", name, meta.Labels)
+		}
+		if meta.Labels[labelJobIDKey] != sanitizeLabelValue(cfg.jobID) {
+			t.Errorf("expected the built-in %s label job_id to be kept, got %q", name, meta.Labels[labelJobIDKey])
+		}
+		if meta.Annotations["sidecar.istio.io/inject"] != "false" {
+			t.Errorf("expected %s annotation sidecar.istio.io/inject, got %v", name, meta.Annotations)
+		}
+		if meta.Annotations[annotationJobIDKey] != cfg.jobID {
+			t.Errorf("expected %s annotation job_id %q, got %q", name, cfg.jobID, meta.Annotations[annotationJobIDKey])
+		}
+	}
+}
+
+func TestBuildJobPolicy(t *testing.T) {
+	cfg := &jobConfig{
+		jobID:                   "job-123",
+		resourceGUID:            "guid-123",
+		namespace:               "default",
+		providerID:              "provider-1",
+		benchmarkID:             "bench-1",
+		adapterImage:            "adapter:latest",
+		defaultEnv:              []api.EnvVar{},
+		ttlSecondsAfterFinished: 86400,
+		backoffLimit:            2,
+		restartPolicy:           corev1.RestartPolicyOnFailure,
+	}
+
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob returned error: %v", err)
+	}
+	if *job.Spec.TTLSecondsAfterFinished != 86400 || *job.Spec.BackoffLimit != 2 {
+		t.Errorf("expected the ttl and backoff limit of the job policy, got %d %d", *job.Spec.TTLSecondsAfterFinished, *job.Spec.BackoffLimit)
+	}
+	if job.Spec.Template.Spec.RestartPolicy != corev1.RestartPolicyOnFailure {
+		t.Errorf("expected restart policy OnFailure, got %s", job.Spec.Template.Spec.RestartPolicy)
+	}
+}
+
 func TestBuildJobWithOCICredentials(t *testing.T) {
 	cfg := &jobConfig{
 		jobID:                "job-oci",
@@ -491,6 +604,51 @@ func TestBuildJobTerminationFileVolume(t *testing.T) {
 	}
 }
 
+func TestBuildJobMountsEvalHubClientCertificateInSidecarOnly(t *testing.T) {
+	cfg := &jobConfig{
+		jobID:                   "job-client-tls",
+		resourceGUID:            "guid-client-tls",
+		benchmarkIndex:          0,
+		namespace:               "default",
+		providerID:              "provider-1",
+		benchmarkID:             "bench-1",
+		adapterImage:            "adapter:latest",
+		defaultEnv:              []api.EnvVar{},
+		evalHubClientCertSecret: "sidecar-client-tls",
+	}
+	job, err := buildJob(cfg)
+	if err != nil {
+		t.Fatalf("buildJob: %v", err)
+	}
+	var foundVolume bool
+	for _, v := range job.Spec.Template.Spec.Volumes {
+		if v.Name == evalHubClientTLSVolumeName {
+			foundVolume = v.Secret != nil && v.Secret.SecretName == "sidecar-client-tls"
+		}
+	}
+	if !foundVolume {
+		t.Fatalf("expected secret volume %q for secret sidecar-client-tls", evalHubClientTLSVolumeName)
+	}
+	sidecar := findContainer(job.Spec.Template.Spec.InitContainers, sidecarContainerName)
+	if sidecar == nil {
+		t.Fatal("expected sidecar init container")
+	}
+	var sidecarMount bool
+	for _, m := range sidecar.VolumeMounts {
+		if m.Name == evalHubClientTLSVolumeName && m.MountPath == evalHubClientTLSMountPath && m.ReadOnly {
+			sidecarMount = true
+		}
+	}
+	if !sidecarMount {
+		t.Fatalf("sidecar should mount %q read-only at %q", evalHubClientTLSVolumeName, evalHubClientTLSMountPath)
+	}
+	for _, m := range job.Spec.Template.Spec.Containers[0].VolumeMounts {
+		if m.Name == evalHubClientTLSVolumeName {
+			t.Fatalf("adapter must not mount %q", evalHubClientTLSVolumeName)
+		}
+	}
+}
+
 func TestBuildJobSidecarDoesNotUseEvalhubConfigVolume(t *testing.T) {
 	cfg := &jobConfig{
 		jobID:          "job-sidecar-vol",
@@ -3,9 +3,12 @@ package k8s
 // Contains the configuration logic that prepares the data needed by the builders
 import (
 	"fmt"
+	"maps"
 	"os"
+	"path"
 	"strings"
 
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
 	"github.com/eval-hub/eval-hub/pkg/api"
@@ -46,13 +49,18 @@ type jobConfig struct {
 	sidecarImage        string
 	entrypoint          []string
 	defaultEnv          []api.EnvVar
+	jobEnv              []api.JobEnvVar // env of the job config, over the env of the provider
 	cpuRequest          string
 	memoryRequest       string
 	cpuLimit            string
 	memoryLimit         string
-	gpuResource         string            // Kubernetes extended resource name (e.g. "nvidia.com/gpu")
-	gpuCount            int               // number of GPU units to request (0 = CPU-only)
-	nodeSelector        map[string]string // pod nodeSelector from GPU config; nil when queue is set
+	gpuResource         string              // Kubernetes extended resource name (e.g. "nvidia.com/gpu")
+	gpuCount            int                 // number of GPU units to request (0 = CPU-only)
+	nodeSelector        map[string]string   // pod nodeSelector from GPU and spot config; nil when queue is set
+	tolerations         []corev1.Toleration // tolerations of the spot node pool; nil on the regular nodes
+	checkpoint          bool                // the adapter checkpoints on the data volume, see completeJobSpec
+	attempts            int                 // earlier runs of the benchmark, a retry when more than 0
+	resultRelay         bool                // the sidecar relays the status events of the results file of the adapter
 	jobSpec             shared.JobSpec
 	serviceAccountName  string
 	serviceCAConfigMap  string
@@ -64,19 +72,43 @@ type jobConfig struct {
 	mlflowTrackingURI          string
 	mlflowWorkspace            string
 	ociCredentialsSecret       string
+	evalHubClientCertSecret    string // kubernetes.io/tls secret with the sidecar's client certificate for eval-hub
 	modelAuthSecretRef         string // user's real credentials secret mounted only in sidecar
 	modelInternalRefSecretName string // ephemeral internalModelRef secret mounted in adapter; empty when credential injection is not active
+	parameterSecretName        string // secret with the job spec of the adapter when its parameters reference secrets; empty otherwise
 	modelTargetURL             string // real model URL forwarded by the sidecar model proxy; always set for all jobs
+	modelAuthTokenSecret       string // secret with the serving auth token of an InferenceService; mounted only in sidecar
 	sidecarResources           corev1.ResourceRequirements
 	testDataS3                 s3TestDataConfig
 	testDataPVC                pvcTestDataConfig
 	testDataInitImage          string
+	dataVolume                 *dataVolumeConfig // persistent volume claim mounted at /data; nil for an emptyDir
+	podLabels                  map[string]string // extra labels of the Job and Pod template from the provider and the job
+	podAnnotations             map[string]string // extra annotations of the Job and Pod template from the provider and the job
+	ttlSecondsAfterFinished    int32             // retention of the finished Job
+	backoffLimit               int32             // retries of the pod of the Job
+	restartPolicy              corev1.RestartPolicy
 	sidecarConfig              *config.SidecarConfig
 	// queueKind and queueName come from evaluation.Queue when set (API layer normalizes empty kind to kueue).
 	queueKind string
 	queueName string
 }
 
+// completeJobSpec sets the shard of the job spec and points its model URL at the sidecar
+// model proxy, which gives the spec handed to the adapter.
+func completeJobSpec(cfg *jobConfig, shard *shared.JobSpecShard) error {
+	cfg.jobSpec.Shard = shard
+	if cfg.checkpoint {
+		applyCheckpoint(cfg, shard)
+	}
+	rewrittenModelURL, err := rewriteModelURLForSidecar(cfg.sidecarBaseURL, cfg.modelTargetURL)
+	if err != nil {
+		return fmt.Errorf("rewriting model URL for sidecar: %w", err)
+	}
+	cfg.jobSpec.Model.URL = rewrittenModelURL
+	return nil
+}
+
 type s3TestDataConfig struct {
 	bucket    string
 	key       string
@@ -88,6 +120,154 @@ type pvcTestDataConfig struct {
 	subPath   string
 }
 
+// applyCheckpoint points the checkpoint of the job spec at a path of the data volume that only
+// this benchmark, or shard of it, writes. A provisioned claim is named after the benchmark
+// rather than the run, so that the run after an interruption mounts the claim of the earlier
+// run and finds its checkpoint.
+func applyCheckpoint(cfg *jobConfig, shard *shared.JobSpecShard) {
+	name := fmt.Sprintf("benchmark-%d", cfg.benchmarkIndex)
+	if shard != nil {
+		name += fmt.Sprintf("-shard-%d", shard.Index)
+	}
+	cfg.jobSpec.Checkpoint = &shared.JobSpecCheckpoint{
+		Path:   path.Join(dataMountPath, checkpointsDir, cfg.jobID, name),
+		Resume: cfg.attempts > 0,
+	}
+	if cfg.dataVolume.provision {
+		cfg.dataVolume.claimName = buildK8sName(cfg.jobID, name, dataVolumeSuffix)
+	}
+}
+
+// benchmarkAttempts returns the number of earlier runs of the benchmark of the job.
+func benchmarkAttempts(evaluation *api.EvaluationJobResource, benchmarkIndex int) int {
+	if evaluation.Status == nil {
+		return 0
+	}
+	for _, benchmark := range evaluation.Status.Benchmarks {
+		if benchmark.BenchmarkIndex == benchmarkIndex {
+			return len(benchmark.Attempts)
+		}
+	}
+	return 0
+}
+
+// resolveTolerations returns the pod tolerations of the spot node pool.
+func resolveTolerations(tolerations []api.Toleration) []corev1.Toleration {
+	if len(tolerations) == 0 {
+		return nil
+	}
+	out := make([]corev1.Toleration, 0, len(tolerations))
+	for _, toleration := range tolerations {
+		out = append(out, corev1.Toleration{
+			Key:      toleration.Key,
+			Operator: corev1.TolerationOperator(toleration.Operator),
+			Value:    toleration.Value,
+			Effect:   corev1.TaintEffect(toleration.Effect),
+		})
+	}
+	return out
+}
+
+// dataVolumeConfig is the persistent volume claim mounted at /data, either an existing claim
+// or a claim provisioned for the benchmark job.
+type dataVolumeConfig struct {
+	claimName    string
+	provision    bool
+	size         resource.Quantity
+	storageClass string
+	accessMode   corev1.PersistentVolumeAccessMode
+	retain       bool
+}
+
+// resolveDataVolume returns the data volume of the provider runtime for the benchmark job,
+// nil when /data is an emptyDir.
+func resolveDataVolume(dataVolume *api.DataVolumeConfig, jobID, resourceGUID string) (*dataVolumeConfig, error) {
+	if dataVolume == nil {
+		return nil, nil
+	}
+	if !dataVolume.IsProvisioned() {
+		return &dataVolumeConfig{claimName: strings.TrimSpace(dataVolume.ClaimName)}, nil
+	}
+	size, err := resource.ParseQuantity(strings.TrimSpace(dataVolume.Size))
+	if err != nil {
+		return nil, fmt.Errorf("invalid data volume size %q: %w", dataVolume.Size, err)
+	}
+	return &dataVolumeConfig{
+		claimName:    buildK8sName(jobID, resourceGUID, dataVolumeSuffix),
+		provision:    true,
+		size:         size,
+		storageClass: strings.TrimSpace(dataVolume.StorageClass),
+		accessMode:   resolveDataVolumeAccessMode(dataVolume.AccessMode),
+		retain:       dataVolume.RetainsClaim(),
+	}, nil
+}
+
+// resolveDataVolumeAccessMode maps the validated data volume access_mode to a
+// corev1.PersistentVolumeAccessMode. Empty string defaults to ReadWriteOnce.
+func resolveDataVolumeAccessMode(accessMode string) corev1.PersistentVolumeAccessMode {
+	switch accessMode {
+	case "read_write_many":
+		return corev1.ReadWriteMany
+	case "read_write_once_pod":
+		return corev1.ReadWriteOncePod
+	default:
+		return corev1.ReadWriteOnce
+	}
+}
+
+// resolvePodMetadata merges the pod metadata of the job over the pod metadata of the provider
+// runtime, and drops the keys of the protected domains that skipped validation, e.g. in
+// provider configs loaded from files.
+func resolvePodMetadata(provider, job *api.PodMetadata) (labels, annotations map[string]string) {
+	for _, podMetadata := range []*api.PodMetadata{provider, job} {
+		if podMetadata == nil {
+			continue
+		}
+		labels = mergeUnprotected(labels, podMetadata.Labels)
+		annotations = mergeUnprotected(annotations, podMetadata.Annotations)
+	}
+	return labels, annotations
+}
+
+// resolveJobPolicy returns the retention, the retries and the restart policy of the Job, from
+// the job policy of the job over the job policy of the provider runtime over the defaults.
+func resolveJobPolicy(provider, job *api.JobPolicy) (ttlSecondsAfterFinished, backoffLimit int32, restartPolicy corev1.RestartPolicy) {
+	ttlSecondsAfterFinished = defaultJobTTLSeconds
+	backoffLimit = defaultJobBackoffLimit
+	restartPolicy = corev1.RestartPolicyNever
+	for _, policy := range []*api.JobPolicy{provider, job} {
+		if policy == nil {
+			continue
+		}
+		if policy.TTLSecondsAfterFinished != nil {
+			ttlSecondsAfterFinished = *policy.TTLSecondsAfterFinished
+		}
+		if policy.BackoffLimit != nil {
+			backoffLimit = *policy.BackoffLimit
+		}
+		switch policy.RestartPolicy {
+		case api.JobRestartPolicyNever:
+			restartPolicy = corev1.RestartPolicyNever
+		case api.JobRestartPolicyOnFailure:
+			restartPolicy = corev1.RestartPolicyOnFailure
+		}
+	}
+	return ttlSecondsAfterFinished, backoffLimit, restartPolicy
+}
+
+func mergeUnprotected(dst, src map[string]string) map[string]string {
+	for key, value := range src {
+		if api.IsProtectedPodMetadataKey(key) {
+			continue
+		}
+		if dst == nil {
+			dst = map[string]string{}
+		}
+		dst[key] = value
+	}
+	return dst
+}
+
 func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.ProviderResource, benchmarkConfig *api.EvaluationBenchmarkConfig, benchmarkIndex int, serviceConfig *config.Config, hardwareProfile *hardwareProfileResources) (*jobConfig, error) {
 	runtime := provider.Runtime
 	if runtime == nil || runtime.K8s == nil {
@@ -123,10 +303,23 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 	}
 
 	namespace := resolveNamespace(string(evaluation.Resource.Tenant))
-	spec, err := shared.BuildJobSpec(evaluation, provider.Resource.ID, benchmarkConfig, benchmarkIndex, &sidecarBaseURL)
+	benchmarkWithDefaults := provider.WithDefaultParameters(*benchmarkConfig)
+	spec, err := shared.BuildJobSpec(evaluation, provider.Resource.ID, &benchmarkWithDefaults, benchmarkIndex, &sidecarBaseURL)
 	if err != nil {
 		return nil, err
 	}
+	if serviceConfig != nil {
+		spec.CallbackToken = callbackauth.Token(serviceConfig.CallbackAuth, evaluation.Resource.ID)
+		shared.ApplySampling(spec, evaluation, serviceConfig.Sampling)
+	}
+	spec.SpecVersion, err = shared.NegotiateJobSpecVersion(provider.JobSpecVersions)
+	if err != nil {
+		return nil, err
+	}
+	if runtime.K8s.ResultRelay {
+		spec.CallbackURL = nil
+		spec.ResultsFile = shared.JobSpecResultsFile
+	}
 
 	// Get EvalHub instance name from environment (set by operator in deployment)
 	evalHubInstanceName := strings.TrimSpace(os.Getenv(evalHubInstanceNameEnv))
@@ -134,12 +327,16 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 	// Get MLFlow configuration from environment (set by operator in deployment)
 	mlflowTrackingURI := strings.TrimSpace(os.Getenv(mlflowTrackingURIEnv))
 	// Job pod must send X-MLFLOW-WORKSPACE = tenant namespace so MLflow's kubernetes-auth
-	// checks RBAC in the correct namespace. Always use the job's namespace; the
+	// checks RBAC in the correct namespace. Use the job's namespace, or the workspace that
+	// the server set on its experiment when the tenant is mapped to MLflow workspaces; the
 	// MLFLOW_WORKSPACE env var on EvalHub identifies EvalHub's own namespace,
 	// not the tenant's, so it must not be forwarded to job pods.
 	mlflowWorkspace := ""
 	if mlflowTrackingURI != "" {
 		mlflowWorkspace = namespace
+		if evaluation.Experiment != nil && evaluation.Experiment.Workspace != "" {
+			mlflowWorkspace = evaluation.Experiment.Workspace
+		}
 	}
 
 	// Build ServiceAccount name and ConfigMap name if instance name is set.
@@ -171,6 +368,11 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 		ociCredentialsSecret = evaluation.Exports.OCI.K8s.Connection
 	}
 
+	var evalHubClientCertSecret string
+	if serviceConfig != nil && serviceConfig.Sidecar != nil && serviceConfig.Sidecar.EvalHub != nil {
+		evalHubClientCertSecret = strings.TrimSpace(serviceConfig.Sidecar.EvalHub.ClientCertSecret)
+	}
+
 	modelAuthSecretRef := ""
 	if evaluation.Model.Auth != nil {
 		modelAuthSecretRef = strings.TrimSpace(evaluation.Model.Auth.SecretRef)
@@ -219,10 +421,32 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 		nodeSelector = resolveNodeSelector(runtime.K8s.GPU)
 	}
 
+	// A spot job targets the spot node pool of the provider; like the GPU node selector, its
+	// node selector is left to the ResourceFlavor of Kueue when a queue is specified.
+	spot := evaluation.Spot && runtime.K8s.Spot != nil
+	var tolerations []corev1.Toleration
+	if spot {
+		if queueName == "" && len(runtime.K8s.Spot.NodeSelector) > 0 {
+			if nodeSelector == nil {
+				nodeSelector = make(map[string]string, len(runtime.K8s.Spot.NodeSelector))
+			}
+			maps.Copy(nodeSelector, runtime.K8s.Spot.NodeSelector)
+		}
+		tolerations = resolveTolerations(runtime.K8s.Spot.Tolerations)
+	}
+
 	adapterPullPolicy := resolveImagePullPolicy(runtime.K8s.ImagePullPolicy)
 
 	resourceGUID := uuid.NewString()
 
+	dataVolume, err := resolveDataVolume(runtime.K8s.DataVolume, evaluation.Resource.ID, resourceGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	podLabels, podAnnotations := resolvePodMetadata(runtime.K8s.PodMetadata, evaluation.PodMetadata)
+	ttlSecondsAfterFinished, backoffLimit, restartPolicy := resolveJobPolicy(runtime.K8s.JobPolicy, evaluation.JobPolicy)
+
 	out := &jobConfig{
 		jobID:                      evaluation.Resource.ID,
 		resourceGUID:               resourceGUID,
@@ -235,6 +459,7 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 		sidecarImage:               sidecarImage,
 		entrypoint:                 runtime.K8s.Entrypoint,
 		defaultEnv:                 runtime.K8s.Env,
+		jobEnv:                     evaluation.Env,
 		cpuRequest:                 cpuRequest,
 		memoryRequest:              memoryRequest,
 		cpuLimit:                   cpuLimit,
@@ -242,6 +467,10 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 		gpuResource:                gpuResource,
 		gpuCount:                   gpuCount,
 		nodeSelector:               nodeSelector,
+		tolerations:                tolerations,
+		checkpoint:                 spot && runtime.K8s.Checkpoints(),
+		attempts:                   benchmarkAttempts(evaluation, benchmarkIndex),
+		resultRelay:                runtime.K8s.ResultRelay,
 		jobSpec:                    *spec,
 		serviceAccountName:         serviceAccountName,
 		serviceCAConfigMap:         serviceCAConfigMap,
@@ -250,9 +479,11 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 		mlflowTrackingURI:          mlflowTrackingURI,
 		mlflowWorkspace:            mlflowWorkspace,
 		ociCredentialsSecret:       ociCredentialsSecret,
+		evalHubClientCertSecret:    evalHubClientCertSecret,
 		modelAuthSecretRef:         modelAuthSecretRef,
 		modelInternalRefSecretName: modelInternalRefSecretName,
 		modelTargetURL:             modelTargetURL,
+		modelAuthTokenSecret:       inferenceServiceAuthTokenSecret(evaluation.Model),
 		sidecarResources:           sidecarResources,
 		sidecarBaseURL:             sidecarBaseURL,
 		evalHubURL:                 evalHubURL,
@@ -267,6 +498,13 @@ func buildJobConfig(evaluation *api.EvaluationJobResource, provider *api.Provide
 			claimName: testDataPVCClaimName,
 			subPath:   testDataPVCSubPath,
 		},
+		dataVolume:     dataVolume,
+		podLabels:      podLabels,
+		podAnnotations: podAnnotations,
+
+		ttlSecondsAfterFinished: ttlSecondsAfterFinished,
+		backoffLimit:            backoffLimit,
+		restartPolicy:           restartPolicy,
 	}
 	applyHardwareProfileResources(out, hardwareProfile)
 	return out, nil
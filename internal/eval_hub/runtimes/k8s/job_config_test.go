@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
 	"github.com/eval-hub/eval-hub/pkg/api"
@@ -181,6 +182,55 @@ func TestBuildJobConfigUsesValidSidecarPort(t *testing.T) {
 	}
 }
 
+func TestBuildJobConfigSetsCallbackToken(t *testing.T) {
+	evaluation := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{
+			Resource: api.Resource{ID: "job-123"},
+		},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model: api.ModelRef{
+				URL:  "http://model",
+				Name: "model",
+			},
+			Benchmarks: []api.EvaluationBenchmarkConfig{
+				{Ref: api.Ref{ID: "bench-1"}},
+			},
+		},
+	}
+	provider := &api.ProviderResource{
+		Resource: api.Resource{ID: "provider-1"},
+		ProviderConfig: api.ProviderConfig{
+			Runtime: &api.Runtime{
+				K8s: &api.K8sRuntime{
+					Image: "adapter:latest",
+				},
+			},
+		},
+	}
+	serviceConfig := &config.Config{
+		CallbackAuth: &config.CallbackAuthConfig{Enabled: true, Secret: "test-secret"},
+	}
+
+	cfg, err := buildJobConfig(evaluation, provider, &evaluation.Benchmarks[0], 0, serviceConfig, nil)
+	if err != nil {
+		t.Fatalf("buildJobConfig() = %v, want nil error", err)
+	}
+	if cfg.jobSpec.CallbackToken == "" {
+		t.Fatal("jobSpec.CallbackToken is empty, want a token")
+	}
+	if !callbackauth.Verify(serviceConfig.CallbackAuth, "job-123", cfg.jobSpec.CallbackToken) {
+		t.Fatalf("jobSpec.CallbackToken = %q, want a token for job-123", cfg.jobSpec.CallbackToken)
+	}
+
+	cfg, err = buildJobConfig(evaluation, provider, &evaluation.Benchmarks[0], 0, nil, nil)
+	if err != nil {
+		t.Fatalf("buildJobConfig() = %v, want nil error", err)
+	}
+	if cfg.jobSpec.CallbackToken != "" {
+		t.Fatalf("jobSpec.CallbackToken = %q, want empty without callback_auth", cfg.jobSpec.CallbackToken)
+	}
+}
+
 func TestBuildJobConfigModelAuthSecretRefPresent(t *testing.T) {
 	evaluation := &api.EvaluationJobResource{
 		Resource: api.EvaluationResource{
@@ -354,6 +404,55 @@ func TestBuildJobConfigAllowsNumExamplesOnly(t *testing.T) {
 	}
 }
 
+func TestBuildJobConfigMergesProviderDefaultParameters(t *testing.T) {
+	evaluation := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{
+			Resource: api.Resource{ID: "job-789"},
+		},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model: api.ModelRef{
+				URL:  "http://model",
+				Name: "model",
+			},
+			Benchmarks: []api.EvaluationBenchmarkConfig{
+				{
+					Ref:        api.Ref{ID: "bench-1"},
+					Parameters: map[string]any{"batch_size": 32},
+				},
+			},
+		},
+	}
+	provider := &api.ProviderResource{
+		Resource: api.Resource{ID: "provider-1"},
+		ProviderConfig: api.ProviderConfig{
+			Benchmarks: []api.BenchmarkResource{
+				{ID: "bench-1", Parameters: map[string]any{"batch_size": 8, "num_fewshot": 5, "num_examples": 100}},
+			},
+			Runtime: &api.Runtime{
+				K8s: &api.K8sRuntime{
+					Image: "adapter:latest",
+				},
+			},
+		},
+	}
+
+	cfg, err := buildJobConfig(evaluation, provider, &evaluation.Benchmarks[0], 0, nil, nil)
+	if err != nil {
+		t.Fatalf("buildJobConfig returned error: %v", err)
+	}
+
+	spec := cfg.jobSpec
+	if spec.Parameters["batch_size"] != 32 || spec.Parameters["num_fewshot"] != 5 {
+		t.Fatalf("expected the job parameters merged over the provider defaults, got %v", spec.Parameters)
+	}
+	if spec.NumExamples == nil || *spec.NumExamples != 100 {
+		t.Fatalf("expected num_examples from the provider defaults, got %v", spec.NumExamples)
+	}
+	if len(evaluation.Benchmarks[0].Parameters) != 1 {
+		t.Fatalf("expected the job benchmark to be left unchanged, got %v", evaluation.Benchmarks[0].Parameters)
+	}
+}
+
 func TestBuildJobConfigMissingRuntime(t *testing.T) {
 	evaluation := &api.EvaluationJobResource{
 		Resource: api.EvaluationResource{
@@ -1158,3 +1257,46 @@ func TestResolveImagePullPolicy(t *testing.T) {
 		}
 	}
 }
+
+func TestResolvePodMetadata(t *testing.T) {
+	provider := &api.PodMetadata{
+		Labels:      map[string]string{"cost-center": "ml-platform", "team": "eval"},
+		Annotations: map[string]string{"sidecar.istio.io/inject": "true", "eval-hub.github.io/job_id": "spoofed"},
+	}
+	job := &api.PodMetadata{
+		Labels: map[string]string{"team": "research", "kueue.x-k8s.io/queue-name": "other"},
+	}
+
+	labels, annotations := resolvePodMetadata(provider, job)
+	if len(labels) != 2 || labels["cost-center"] != "ml-platform" || labels["team"] != "research" {
+		t.Fatalf("expected the job labels to override the provider labels, got %v", labels)
+	}
+	if len(annotations) != 1 || annotations["sidecar.istio.io/inject"] != "true" {
+		t.Fatalf("expected the protected annotations to be dropped, got %v", annotations)
+	}
+
+	labels, annotations = resolvePodMetadata(nil, nil)
+	if labels != nil || annotations != nil {
+		t.Fatalf("expected no pod metadata, got %v %v", labels, annotations)
+	}
+}
+
+func TestResolveJobPolicy(t *testing.T) {
+	ttl, backoff, restartPolicy := resolveJobPolicy(nil, nil)
+	if ttl != defaultJobTTLSeconds || backoff != defaultJobBackoffLimit || restartPolicy != corev1.RestartPolicyNever {
+		t.Fatalf("expected the defaults, got %d %d %s", ttl, backoff, restartPolicy)
+	}
+
+	day, two, none := int32(86400), int32(2), int32(0)
+	provider := &api.JobPolicy{TTLSecondsAfterFinished: &day, BackoffLimit: &two, RestartPolicy: api.JobRestartPolicyOnFailure}
+	ttl, backoff, restartPolicy = resolveJobPolicy(provider, nil)
+	if ttl != day || backoff != two || restartPolicy != corev1.RestartPolicyOnFailure {
+		t.Fatalf("expected the job policy of the provider, got %d %d %s", ttl, backoff, restartPolicy)
+	}
+
+	// the job overrides the fields it sets, a zero included
+	ttl, backoff, restartPolicy = resolveJobPolicy(provider, &api.JobPolicy{BackoffLimit: &none, RestartPolicy: api.JobRestartPolicyNever})
+	if ttl != day || backoff != 0 || restartPolicy != corev1.RestartPolicyNever {
+		t.Fatalf("expected the job policy of the job over the provider, got %d %d %s", ttl, backoff, restartPolicy)
+	}
+}
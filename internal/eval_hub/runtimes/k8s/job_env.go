@@ -0,0 +1,35 @@
+package k8s
+
+import (
+	"github.com/eval-hub/eval-hub/pkg/api"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// JobEnvVars returns the env of a job as the env of a container: a secretRef://name/key value
+// is read by Kubernetes from the key of the secret in the namespace of the pod, so that the
+// value is never in the Job. The argo and lmevaljob runtimes set the env of a job the same way.
+func JobEnvVars(env []api.JobEnvVar) []corev1.EnvVar {
+	var out []corev1.EnvVar
+	for _, item := range env {
+		if item.Name == "" {
+			continue
+		}
+		ref, isRef, err := item.SecretRef()
+		switch {
+		case err != nil:
+			// rejected when the job was created, skipped rather than passed on as a literal
+			continue
+		case isRef:
+			out = append(out, corev1.EnvVar{
+				Name: item.Name,
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ref.Name},
+					Key:                  ref.Key,
+				}},
+			})
+		default:
+			out = append(out, corev1.EnvVar{Name: item.Name, Value: item.Value})
+		}
+	}
+	return out
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 
+	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,7 +33,15 @@ var hardwareProfileGVR = schema.GroupVersionResource{
 	Resource: hardwareProfileResource,
 }
 
-func loadKubernetesConfig() (*rest.Config, error) {
+var inferenceServiceGVR = schema.GroupVersionResource{
+	Group:    inferenceServiceAPIGroup,
+	Version:  inferenceServiceAPIVersion,
+	Resource: inferenceServiceResource,
+}
+
+// LoadKubernetesConfig returns the in-cluster config of the service, or the config of the
+// default kubeconfig when it runs outside a cluster.
+func LoadKubernetesConfig() (*rest.Config, error) {
 	config, err := rest.InClusterConfig()
 	if err != nil {
 		loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
@@ -50,10 +60,14 @@ func loadKubernetesConfig() (*rest.Config, error) {
 // NewKubernetesHelper builds a Kubernetes client (in-cluster config, then default kubeconfig)
 // and returns a KubernetesHelper.
 func NewKubernetesHelper() (*KubernetesHelper, error) {
-	config, err := loadKubernetesConfig()
+	config, err := LoadKubernetesConfig()
 	if err != nil {
 		return nil, err
 	}
+	return newKubernetesHelperForConfig(config)
+}
+
+func newKubernetesHelperForConfig(config *rest.Config) (*KubernetesHelper, error) {
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
@@ -68,6 +82,19 @@ func NewKubernetesHelper() (*KubernetesHelper, error) {
 	}, nil
 }
 
+// NewKubernetesHelperForKubeconfig builds a Kubernetes client for the cluster of a kubeconfig
+// file, with the context of the file when kubeContext is empty.
+func NewKubernetesHelperForKubeconfig(kubeconfig, kubeContext string) (*KubernetesHelper, error) {
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
+		&clientcmd.ConfigOverrides{CurrentContext: kubeContext},
+	).ClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	return newKubernetesHelperForConfig(config)
+}
+
 // NewKubernetesHelperWithClientset returns a helper backed by the given clientset.
 // It is intended for unit tests that need deterministic Kubernetes API behavior.
 func NewKubernetesHelperWithClientset(clientset kubernetes.Interface) *KubernetesHelper {
@@ -96,6 +123,17 @@ func (h *KubernetesHelper) DeleteHardwareProfile(ctx context.Context, namespace,
 	return h.dynamicClient.Resource(hardwareProfileGVR).Namespace(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
+// GetInferenceService fetches a KServe InferenceService by name in the given namespace.
+func (h *KubernetesHelper) GetInferenceService(ctx context.Context, namespace, name string) (*unstructured.Unstructured, error) {
+	if namespace == "" || name == "" {
+		return nil, fmt.Errorf("namespace and name are required")
+	}
+	if h.dynamicClient == nil {
+		return nil, fmt.Errorf("dynamic kubernetes client is not configured")
+	}
+	return h.dynamicClient.Resource(inferenceServiceGVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
 // CreateConfigMap creates a ConfigMap in the given namespace.
 // name is the ConfigMap name; data is the key-value map for ConfigMap.Data.
 // opts may be nil; use it to set labels and annotations.
@@ -150,6 +188,37 @@ func (h *KubernetesHelper) DeleteConfigMap(ctx context.Context, namespace, name
 	return h.clientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 }
 
+// ServerVersion returns the Kubernetes version of the API server, which tells that the
+// cluster is reachable with the credentials of the helper.
+func (h *KubernetesHelper) ServerVersion() (string, error) {
+	version, err := h.clientset.Discovery().ServerVersion()
+	if err != nil {
+		return "", err
+	}
+	return version.GitVersion, nil
+}
+
+// CanI asks the API server whether the credentials of the helper allow the access, with a
+// SelfSubjectAccessReview; the reason of the authorizer is returned with a denial.
+func (h *KubernetesHelper) CanI(ctx context.Context, attributes authorizationv1.ResourceAttributes) (bool, string, error) {
+	review, err := h.clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: &attributes},
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return false, "", err
+	}
+	return review.Status.Allowed, review.Status.Reason, nil
+}
+
+// ListJobsInAllNamespaces lists the Jobs of all namespaces that match the label selector.
+func (h *KubernetesHelper) ListJobsInAllNamespaces(ctx context.Context, labelSelector string) ([]batchv1.Job, error) {
+	list, err := h.clientset.BatchV1().Jobs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
 // ListJobs returns Jobs matching the label selector.
 func (h *KubernetesHelper) ListJobs(ctx context.Context, namespace, labelSelector string) ([]batchv1.Job, error) {
 	if namespace == "" {
@@ -239,6 +308,48 @@ func (h *KubernetesHelper) SetSecretOwner(ctx context.Context, namespace, name s
 	return err
 }
 
+// CreatePersistentVolumeClaim creates a PersistentVolumeClaim in its namespace.
+func (h *KubernetesHelper) CreatePersistentVolumeClaim(ctx context.Context, claim *corev1.PersistentVolumeClaim) (*corev1.PersistentVolumeClaim, error) {
+	if claim == nil || claim.Namespace == "" || claim.Name == "" {
+		return nil, fmt.Errorf("persistentvolumeclaim, namespace, and name are required")
+	}
+	return h.clientset.CoreV1().PersistentVolumeClaims(claim.Namespace).Create(ctx, claim, metav1.CreateOptions{})
+}
+
+// DeletePersistentVolumeClaim deletes a PersistentVolumeClaim in the given namespace.
+func (h *KubernetesHelper) DeletePersistentVolumeClaim(ctx context.Context, namespace, name string) error {
+	if namespace == "" || name == "" {
+		return fmt.Errorf("namespace and name are required")
+	}
+	return h.clientset.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// ListPersistentVolumeClaims returns PersistentVolumeClaims matching the label selector.
+func (h *KubernetesHelper) ListPersistentVolumeClaims(ctx context.Context, namespace, labelSelector string) ([]corev1.PersistentVolumeClaim, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	list, err := h.clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// SetPersistentVolumeClaimOwner sets a single owner reference on the PersistentVolumeClaim.
+func (h *KubernetesHelper) SetPersistentVolumeClaimOwner(ctx context.Context, namespace, name string, owner metav1.OwnerReference) error {
+	if namespace == "" || name == "" {
+		return fmt.Errorf("namespace and name are required")
+	}
+	claim, err := h.clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	claim.OwnerReferences = []metav1.OwnerReference{owner}
+	_, err = h.clientset.CoreV1().PersistentVolumeClaims(namespace).Update(ctx, claim, metav1.UpdateOptions{})
+	return err
+}
+
 // ListPods returns Pods matching the label selector.
 func (h *KubernetesHelper) ListPods(ctx context.Context, namespace, labelSelector string) ([]corev1.Pod, error) {
 	if namespace == "" {
@@ -251,6 +362,63 @@ func (h *KubernetesHelper) ListPods(ctx context.Context, namespace, labelSelecto
 	return list.Items, nil
 }
 
+// ListNodes returns the nodes of the cluster.
+func (h *KubernetesHelper) ListNodes(ctx context.Context) ([]corev1.Node, error) {
+	list, err := h.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// ListScheduledPods returns the pods of all the namespaces that are bound to a node and have
+// not terminated, the pods whose requests take up the resources of the nodes.
+func (h *KubernetesHelper) ListScheduledPods(ctx context.Context) ([]corev1.Pod, error) {
+	list, err := h.clientset.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName!=,status.phase!=Succeeded,status.phase!=Failed",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// CreateDaemonSet creates a DaemonSet in its namespace.
+func (h *KubernetesHelper) CreateDaemonSet(ctx context.Context, daemonSet *appsv1.DaemonSet) (*appsv1.DaemonSet, error) {
+	if daemonSet == nil || daemonSet.Namespace == "" || daemonSet.Name == "" {
+		return nil, fmt.Errorf("daemonset, namespace, and name are required")
+	}
+	return h.clientset.AppsV1().DaemonSets(daemonSet.Namespace).Create(ctx, daemonSet, metav1.CreateOptions{})
+}
+
+// UpdateDaemonSet updates a DaemonSet in its namespace.
+func (h *KubernetesHelper) UpdateDaemonSet(ctx context.Context, daemonSet *appsv1.DaemonSet) (*appsv1.DaemonSet, error) {
+	if daemonSet == nil || daemonSet.Namespace == "" || daemonSet.Name == "" {
+		return nil, fmt.Errorf("daemonset, namespace, and name are required")
+	}
+	return h.clientset.AppsV1().DaemonSets(daemonSet.Namespace).Update(ctx, daemonSet, metav1.UpdateOptions{})
+}
+
+// DeleteDaemonSet deletes a DaemonSet in the given namespace.
+func (h *KubernetesHelper) DeleteDaemonSet(ctx context.Context, namespace, name string) error {
+	if namespace == "" || name == "" {
+		return fmt.Errorf("namespace and name are required")
+	}
+	return h.clientset.AppsV1().DaemonSets(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+}
+
+// ListDaemonSets returns DaemonSets matching the label selector.
+func (h *KubernetesHelper) ListDaemonSets(ctx context.Context, namespace, labelSelector string) ([]appsv1.DaemonSet, error) {
+	if namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	list, err := h.clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
 // GetPodLogs returns plain-text logs for a pod container.
 func (h *KubernetesHelper) GetPodLogs(ctx context.Context, namespace, podName string, opts *corev1.PodLogOptions) (string, error) {
 	if namespace == "" || podName == "" {
@@ -14,6 +14,7 @@ import (
 	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/metrics"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
 	"github.com/eval-hub/eval-hub/pkg/api"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -25,6 +26,12 @@ type K8sRuntime struct {
 	serviceConfig *config.Config
 	helper        *KubernetesHelper
 	ctx           context.Context
+	// remotes are the helpers of the registered remote clusters by name, and cluster is the
+	// name of the remote cluster that helper reaches, empty for the cluster of the service.
+	remotes map[string]*KubernetesHelper
+	cluster string
+	// images pins the adapter images to digests, nil when the images run by tag.
+	images *imagePolicy
 }
 
 // NewK8sRuntime creates a Kubernetes runtime.
@@ -33,7 +40,15 @@ func NewK8sRuntime(logger *slog.Logger, serviceConfig *config.Config) (abstracti
 	if err != nil {
 		return nil, err
 	}
-	return &K8sRuntime{logger: logger, serviceConfig: serviceConfig, helper: helper}, nil
+	remotes, err := newRemoteClusterHelpers(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+	images, err := newImagePolicy(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &K8sRuntime{logger: logger, serviceConfig: serviceConfig, helper: helper, remotes: remotes, images: images}, nil
 }
 
 func (r *K8sRuntime) WithLogger(logger *slog.Logger) abstractions.Runtime {
@@ -42,6 +57,9 @@ func (r *K8sRuntime) WithLogger(logger *slog.Logger) abstractions.Runtime {
 		serviceConfig: r.serviceConfig,
 		helper:        r.helper,
 		ctx:           r.ctx,
+		remotes:       r.remotes,
+		cluster:       r.cluster,
+		images:        r.images,
 	}
 }
 
@@ -51,6 +69,9 @@ func (r *K8sRuntime) WithContext(ctx context.Context) abstractions.Runtime {
 		serviceConfig: r.serviceConfig,
 		helper:        r.helper,
 		ctx:           ctx,
+		remotes:       r.remotes,
+		cluster:       r.cluster,
+		images:        r.images,
 	}
 }
 
@@ -63,10 +84,59 @@ func (r *K8sRuntime) RunEvaluationJob(
 		return serviceerrors.NewServiceError(messages.EvaluationJobEmpty, "EvaluationJobID", evaluation.Resource.ID)
 	}
 
-	go func() {
-		for idx, bench := range benchmarks {
+	var benchmarkIndices []int
+	for idx := range benchmarks {
+		if shared.IsBenchmarkReady(evaluation, benchmarks, idx) {
+			benchmarkIndices = append(benchmarkIndices, idx)
+		}
+	}
+	go r.createBenchmarks(evaluation, benchmarks, benchmarkIndices, storage)
+	go r.captureModelMetadata(evaluation, storage)
+	return nil
+}
+
+func (r *K8sRuntime) RunEvaluationBenchmarks(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndices []int,
+	storage abstractions.RuntimeStorage,
+) error {
+	go r.createBenchmarks(evaluation, benchmarks, benchmarkIndices, storage)
+	return nil
+}
+
+// createBenchmarks creates the Kubernetes resources of the benchmarks at benchmarkIndices.
+// Creation failures are reported as failed benchmarks.
+func (r *K8sRuntime) createBenchmarks(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndices []int,
+	storage abstractions.RuntimeStorage,
+) {
+	if r.images != nil {
+		evaluation = withAdapterImages(evaluation)
+	}
+	for _, idx := range benchmarkIndices {
+		if idx < 0 || idx >= len(benchmarks) {
+			continue
+		}
+		bench := benchmarks[idx]
+		// a sharded benchmark runs as one Kubernetes Job per shard
+		shards := []*shared.JobSpecShard{nil}
+		if count := shared.ShardCount(&bench); count > 1 {
+			shards = make([]*shared.JobSpecShard, count)
+			for i := range shards {
+				shards[i] = &shared.JobSpecShard{Index: i, Count: count}
+			}
+		}
+		target, targetErr := r.onCluster(r.clusterFor(evaluation, bench.ProviderID))
+		for _, shard := range shards {
 			benchCtx := context.Background()
-			if err := r.createBenchmarkResources(benchCtx, r.logger, evaluation, &bench, idx, storage); err != nil {
+			err := targetErr
+			if err == nil {
+				err = target.createBenchmarkResources(benchCtx, r.logger, evaluation, &bench, idx, shard, storage)
+			}
+			if err != nil {
 				metrics.RecordBenchmarkRuntimeError(benchCtx, r.Name())
 				r.logger.Error(
 					"kubernetes job creation failed",
@@ -77,6 +147,9 @@ func (r *K8sRuntime) RunEvaluationJob(
 
 				if storage != nil {
 					runStatus := buildBenchmarkFailureStatus(&bench, idx, err)
+					if shard != nil {
+						runStatus.BenchmarkStatusEvent.ShardIndex = &shard.Index
+					}
 					if updateErr := storage.UpdateEvaluationJob(evaluation.Resource.ID, runStatus); updateErr != nil {
 						r.logger.Error(
 							"failed to update benchmark status",
@@ -88,8 +161,7 @@ func (r *K8sRuntime) RunEvaluationJob(
 				}
 			}
 		}
-	}()
-	return nil
+	}
 }
 
 // jobForegroundDeleteOptions deletes Job-owned Pods before removing the Job so stuck Init
@@ -99,7 +171,20 @@ func jobForegroundDeleteOptions() metav1.DeleteOptions {
 	return metav1.DeleteOptions{PropagationPolicy: &propagationPolicy}
 }
 
+// DeleteEvaluationJobResources deletes the resources of the job on the cluster of the service
+// and on the remote clusters, as the benchmarks of the job may have run on any of them.
 func (r *K8sRuntime) DeleteEvaluationJobResources(evaluation *api.EvaluationJobResource) error {
+	var deleteErr error
+	for _, target := range r.clusterRuntimes() {
+		if err := target.deleteClusterResources(evaluation); err != nil {
+			deleteErr = errors.Join(deleteErr, target.clusterError(err))
+		}
+	}
+	return deleteErr
+}
+
+// deleteClusterResources deletes the resources of the job on the cluster of the runtime.
+func (r *K8sRuntime) deleteClusterResources(evaluation *api.EvaluationJobResource) error {
 	namespace := resolveNamespace(string(evaluation.Resource.Tenant))
 	deleteOptions := jobForegroundDeleteOptions()
 
@@ -108,6 +193,7 @@ func (r *K8sRuntime) DeleteEvaluationJobResources(evaluation *api.EvaluationJobR
 		"job_id", evaluation.Resource.ID,
 		"benchmark_count", len(evaluation.Benchmarks),
 		"namespace", namespace,
+		"cluster", r.clusterName(),
 	)
 
 	labelSelector := fmt.Sprintf("%s=%s", labelJobIDKey, sanitizeLabelValue(evaluation.Resource.ID))
@@ -162,6 +248,32 @@ func (r *K8sRuntime) DeleteEvaluationJobResources(evaluation *api.EvaluationJobR
 			deleteErr = errors.Join(deleteErr, err)
 		}
 	}
+	// Delete the data volume claims provisioned for the job unless their cleanup policy
+	// retains them; claims that the job mounted but did not provision carry no job label.
+	claims, err := r.helper.ListPersistentVolumeClaims(r.ctx, namespace, labelSelector)
+	if err != nil {
+		deleteErr = errors.Join(deleteErr, err)
+	}
+	for _, claim := range claims {
+		if claim.Annotations[annotationDataVolumeCleanupKey] == api.DataVolumeCleanupRetain {
+			r.logger.Info(
+				"retaining evaluation runtime data volume claim",
+				"job_id", evaluation.Resource.ID,
+				"claim_name", claim.Name,
+				"namespace", namespace,
+			)
+			continue
+		}
+		r.logger.Info(
+			"deleting evaluation runtime data volume claim",
+			"job_id", evaluation.Resource.ID,
+			"claim_name", claim.Name,
+			"namespace", namespace,
+		)
+		if err := r.helper.DeletePersistentVolumeClaim(r.ctx, namespace, claim.Name); err != nil && !apierrors.IsNotFound(err) {
+			deleteErr = errors.Join(deleteErr, err)
+		}
+	}
 	return deleteErr
 }
 
@@ -170,6 +282,7 @@ func (r *K8sRuntime) createBenchmarkResources(ctx context.Context,
 	evaluation *api.EvaluationJobResource,
 	benchmark *api.EvaluationBenchmarkConfig,
 	benchmarkIndex int,
+	shard *shared.JobSpecShard,
 	storage abstractions.RuntimeStorage,
 ) error {
 	benchmarkID := benchmark.ID
@@ -196,6 +309,20 @@ func (r *K8sRuntime) createBenchmarkResources(ctx context.Context,
 			hardwareProfile = parsed
 		}
 	}
+	if ref := evaluation.Model.InferenceService; ref != nil {
+		isvcNamespace := resolveInferenceServiceNamespace(ref, string(evaluation.Resource.Tenant))
+		isvc, err := r.helper.GetInferenceService(ctx, isvcNamespace, ref.Name)
+		if err != nil {
+			return fmt.Errorf("job %s benchmark %s: fetch inference service %q in namespace %q: %w",
+				evaluation.Resource.ID, benchmarkID, ref.Name, isvcNamespace, err)
+		}
+		modelURL, err := parseInferenceService(isvc)
+		if err != nil {
+			return fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmarkID, err)
+		}
+		logger.Info("resolved model inference service", "job_id", evaluation.Resource.ID, "benchmark_id", benchmarkID, "inference_service", ref.Name, "namespace", isvcNamespace, "url", modelURL)
+		evaluation = withModelURL(evaluation, modelURL)
+	}
 	jobConfig, err := buildJobConfig(evaluation, provider, benchmark, benchmarkIndex, r.serviceConfig, hardwareProfile)
 	if err != nil {
 		logger.Error("kubernetes job config error", "benchmark_id", benchmarkID, "error", err)
@@ -205,6 +332,18 @@ func (r *K8sRuntime) createBenchmarkResources(ctx context.Context,
 		return fmt.Errorf("service config is required")
 	}
 	jobConfig.testDataInitImage = r.serviceConfig.Service.EvalInitImage
+	if r.images != nil {
+		pinned, err := r.pinAdapterImage(ctx, evaluation, storage, jobConfig.adapterImage)
+		if err != nil {
+			logger.Error("kubernetes adapter image policy error", "benchmark_id", benchmarkID, "image", jobConfig.adapterImage, "error", err)
+			return fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmarkID, err)
+		}
+		jobConfig.adapterImage = pinned
+	}
+	// the in-cluster URL of the service does not resolve on a remote cluster
+	if remote := r.serviceConfig.Clusters.Cluster(r.cluster); remote != nil {
+		jobConfig.evalHubURL = remote.EvalHubURL
+	}
 	logger.Info(
 		"kubernetes job config",
 		"job_id", evaluation.Resource.ID,
@@ -212,6 +351,7 @@ func (r *K8sRuntime) createBenchmarkResources(ctx context.Context,
 		"service_account", jobConfig.serviceAccountName,
 		"service_ca_configmap", jobConfig.serviceCAConfigMap,
 		"eval_hub_url", jobConfig.evalHubURL,
+		"cluster", r.clusterName(),
 	)
 	// The sidecar model proxy is always active for all jobs. When model.auth is set,
 	// the secret is inspected to determine if credential injection (ref-token resolution)
@@ -224,11 +364,9 @@ func (r *K8sRuntime) createBenchmarkResources(ctx context.Context,
 	// Redirect the adapter to the sidecar, preserving the full path from the user's model URL.
 	// The sidecar Rewrite function swaps only scheme+host from its configured target, so
 	// whatever path the adapter sends is forwarded verbatim to the real upstream model host.
-	rewrittenModelURL, err := rewriteModelURLForSidecar(jobConfig.sidecarBaseURL, jobConfig.modelTargetURL)
-	if err != nil {
-		return fmt.Errorf("job %s benchmark %s: rewriting model URL for sidecar: %w", evaluation.Resource.ID, benchmarkID, err)
+	if err := completeJobSpec(jobConfig, shard); err != nil {
+		return fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmarkID, err)
 	}
-	jobConfig.jobSpec.Model.URL = rewrittenModelURL
 
 	var secretInfo modelSecretInfo
 	if jobConfig.modelAuthSecretRef != "" {
@@ -255,11 +393,30 @@ func (r *K8sRuntime) createBenchmarkResources(ctx context.Context,
 		logger.Error("kubernetes configmap build error", "benchmark_id", benchmarkID, "error", err)
 		return fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmarkID, err)
 	}
+	// The secretRef:// parameters are resolved into a secret that only the adapter mounts,
+	// so that their values are neither stored with the job nor written to the ConfigMap.
+	parameterSecret, err := buildParameterSecret(ctx, jobConfig, r.helper)
+	if err != nil {
+		logger.Error("kubernetes parameter secret build error", "benchmark_id", benchmarkID, "error", err)
+		return fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmarkID, err)
+	}
+	if parameterSecret != nil {
+		jobConfig.parameterSecretName = parameterSecret.Name
+	}
 	job, err := buildJob(jobConfig)
 	if err != nil {
 		logger.Error("kubernetes job build error", "benchmark_id", benchmarkID, "error", err)
 		return fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmarkID, err)
 	}
+	// a benchmark that is likely to stay pending is still dispatched, its status says why
+	capacityHint := r.capacityHint(ctx, logger, job)
+	if capacityHint != "" {
+		if job.Annotations == nil {
+			job.Annotations = map[string]string{}
+		}
+		job.Annotations[annotationCapacityHintKey] = capacityHint
+		reportCapacityHint(logger, evaluation, benchmark, benchmarkIndex, shard, capacityHint, storage)
+	}
 	hasServiceCAVolume := false
 	for _, volume := range job.Spec.Template.Spec.Volumes {
 		if volume.Name == serviceCAVolumeName {
@@ -307,20 +464,68 @@ func (r *K8sRuntime) createBenchmarkResources(ctx context.Context,
 		}
 	}
 
+	// Create the parameter secret before the Job so the Pod can mount it.
+	if parameterSecret != nil {
+		if _, err := r.helper.CreateSecret(ctx, jobConfig.namespace, parameterSecret); err != nil {
+			logger.Error("kubernetes parameter secret create error", "namespace", jobConfig.namespace, "name", parameterSecret.Name, "error", err)
+			cleanupModelRefSecret()
+			return fmt.Errorf("job %s benchmark %s: parameter secret: %w", evaluation.Resource.ID, benchmarkID, err)
+		}
+		logger.Info("kubernetes parameter secret created", "namespace", jobConfig.namespace, "name", parameterSecret.Name)
+	}
+	cleanupSecrets := func() {
+		cleanupModelRefSecret()
+		if parameterSecret == nil {
+			return
+		}
+		if cleanupErr := r.helper.DeleteSecret(ctx, jobConfig.namespace, parameterSecret.Name, metav1.DeleteOptions{}); cleanupErr != nil && !apierrors.IsNotFound(cleanupErr) {
+			logger.Error("failed to delete parameter secret after error", "error", cleanupErr)
+		}
+	}
+
+	// Provision the data volume claim before the Job so the Pod can mount it.
+	dataVolumeClaim := buildDataVolumeClaim(jobConfig)
+	if dataVolumeClaim != nil {
+		_, err := r.helper.CreatePersistentVolumeClaim(ctx, dataVolumeClaim)
+		switch {
+		case err != nil && jobConfig.checkpoint && apierrors.IsAlreadyExists(err):
+			// the claim of an earlier run of the benchmark holds its checkpoint; it is not
+			// deleted when this run fails to start
+			logger.Info("kubernetes data volume claim reused", "namespace", dataVolumeClaim.Namespace, "name", dataVolumeClaim.Name)
+			dataVolumeClaim = nil
+		case err != nil:
+			logger.Error("kubernetes data volume claim create error", "namespace", dataVolumeClaim.Namespace, "name", dataVolumeClaim.Name, "error", err)
+			cleanupSecrets()
+			return fmt.Errorf("job %s benchmark %s: data volume claim: %w", evaluation.Resource.ID, benchmarkID, err)
+		default:
+			logger.Info("kubernetes data volume claim created", "namespace", dataVolumeClaim.Namespace, "name", dataVolumeClaim.Name, "size", jobConfig.dataVolume.size.String())
+		}
+	}
+	cleanupDataVolumeClaim := func() {
+		if dataVolumeClaim == nil {
+			return
+		}
+		if cleanupErr := r.helper.DeletePersistentVolumeClaim(ctx, dataVolumeClaim.Namespace, dataVolumeClaim.Name); cleanupErr != nil && !apierrors.IsNotFound(cleanupErr) {
+			logger.Error("failed to delete data volume claim after error", "error", cleanupErr)
+		}
+	}
+
 	_, err = r.helper.CreateConfigMap(ctx, configMap.Namespace, configMap.Name, configMap.Data, &CreateConfigMapOptions{
 		Labels:      configMap.Labels,
 		Annotations: configMap.Annotations,
 	})
 	if err != nil {
 		logger.Error("kubernetes configmap create error", "namespace", configMap.Namespace, "name", configMap.Name, "error", err)
-		cleanupModelRefSecret()
+		cleanupSecrets()
+		cleanupDataVolumeClaim()
 		return fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, benchmarkID, err)
 	}
 
 	createdJob, err := r.helper.CreateJob(ctx, job)
 	if err != nil {
 		logger.Error("kubernetes job create error", "namespace", job.Namespace, "name", job.Name, "error", err)
-		cleanupModelRefSecret()
+		cleanupSecrets()
+		cleanupDataVolumeClaim()
 		cleanupErr := r.helper.DeleteConfigMap(ctx, configMap.Namespace, configMap.Name)
 		if cleanupErr != nil && !apierrors.IsNotFound(cleanupErr) {
 			if logger != nil {
@@ -347,7 +552,8 @@ func (r *K8sRuntime) createBenchmarkResources(ctx context.Context,
 				logger.Error("failed to delete orphaned job", "namespace", createdJob.Namespace, "name", createdJob.Name, "error", delErr)
 				return delErr
 			}
-			cleanupModelRefSecret()
+			cleanupSecrets()
+			cleanupDataVolumeClaim()
 			return nil
 		}
 		logger.Error("failed to set configmap owner reference", "namespace", configMap.Namespace, "name", configMap.Name, "error", err)
@@ -360,6 +566,22 @@ func (r *K8sRuntime) createBenchmarkResources(ctx context.Context,
 			cleanupModelRefSecret()
 		}
 	}
+	// The parameter secret is mounted by the Pod, so it is kept when its owner can not be set;
+	// it carries the job label and is deleted with the resources of the job.
+	if parameterSecret != nil {
+		if err := r.helper.SetSecretOwner(ctx, jobConfig.namespace, parameterSecret.Name, ownerRef); err != nil {
+			logger.Error("failed to set parameter secret owner reference", "namespace", jobConfig.namespace, "name", parameterSecret.Name, "error", err)
+		}
+	}
+	// Point a data volume claim that is not retained at the Job so Kubernetes GC deletes it
+	// together with the Job, e.g. when the Job's TTL expires. A claim that holds checkpoints
+	// outlives the Job of a run for the next run; it is deleted with the resources of the job.
+	if dataVolumeClaim != nil && !jobConfig.dataVolume.retain && !jobConfig.checkpoint {
+		if err := r.helper.SetPersistentVolumeClaimOwner(ctx, dataVolumeClaim.Namespace, dataVolumeClaim.Name, ownerRef); err != nil {
+			logger.Error("failed to set data volume claim owner reference", "namespace", dataVolumeClaim.Namespace, "name", dataVolumeClaim.Name, "error", err)
+		}
+	}
+	r.recordJobPlacement(logger, evaluation, benchmarkIndex, shard, createdJob, storage)
 	return nil
 }
 
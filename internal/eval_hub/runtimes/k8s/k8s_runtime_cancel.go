@@ -0,0 +1,56 @@
+package k8s
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// CancelEvaluationBenchmarks deletes the Kubernetes Jobs of the benchmarks of the job at
+// benchmarkIndices on all the clusters, and keeps the Jobs of its other benchmarks. Their
+// ConfigMaps and Secrets are garbage collected with the Jobs that own them.
+func (r *K8sRuntime) CancelEvaluationBenchmarks(evaluation *api.EvaluationJobResource, benchmarkIndices []int) error {
+	var deleteErr error
+	for _, target := range r.clusterRuntimes() {
+		if err := target.deleteClusterBenchmarkJobs(evaluation, benchmarkIndices); err != nil {
+			deleteErr = errors.Join(deleteErr, target.clusterError(err))
+		}
+	}
+	return deleteErr
+}
+
+// deleteClusterBenchmarkJobs deletes the Jobs of the benchmarks of the job at
+// benchmarkIndices on the cluster of the runtime.
+func (r *K8sRuntime) deleteClusterBenchmarkJobs(evaluation *api.EvaluationJobResource, benchmarkIndices []int) error {
+	namespace := resolveNamespace(string(evaluation.Resource.Tenant))
+	deleteOptions := jobForegroundDeleteOptions()
+	var deleteErr error
+	for _, benchmarkIndex := range benchmarkIndices {
+		labelSelector := fmt.Sprintf("%s=%s,%s=%s",
+			labelJobIDKey, sanitizeLabelValue(evaluation.Resource.ID),
+			labelBenchmarkIndexKey, sanitizeLabelValue(strconv.Itoa(benchmarkIndex)),
+		)
+		jobs, err := r.helper.ListJobs(r.ctx, namespace, labelSelector)
+		if err != nil {
+			deleteErr = errors.Join(deleteErr, err)
+			continue
+		}
+		for _, job := range jobs {
+			r.logger.Info(
+				"deleting evaluation runtime job of a cancelled benchmark",
+				"job_id", evaluation.Resource.ID,
+				"benchmark_index", benchmarkIndex,
+				"job_name", job.Name,
+				"namespace", namespace,
+				"cluster", r.clusterName(),
+			)
+			if err := r.helper.DeleteJob(r.ctx, namespace, job.Name, deleteOptions); err != nil && !apierrors.IsNotFound(err) {
+				deleteErr = errors.Join(deleteErr, err)
+			}
+		}
+	}
+	return deleteErr
+}
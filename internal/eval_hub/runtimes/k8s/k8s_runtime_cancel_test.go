@@ -0,0 +1,51 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCancelEvaluationBenchmarks(t *testing.T) {
+	evaluation := sampleEvaluation("provider-1")
+	namespace := "default"
+	benchmarkJob := func(name, index string) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+				Labels: map[string]string{
+					labelJobIDKey:          sanitizeLabelValue(evaluation.Resource.ID),
+					labelBenchmarkIndexKey: index,
+				},
+			},
+		}
+	}
+	clientset := fake.NewClientset(
+		benchmarkJob("eval-job-0", "0"),
+		benchmarkJob("eval-job-1", "1"),
+		benchmarkJob("eval-job-2", "2"),
+	)
+	runtime := &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{clientset: clientset},
+		ctx:    context.Background(),
+	}
+
+	if err := runtime.CancelEvaluationBenchmarks(evaluation, []int{1, 2}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	jobs, err := clientset.BatchV1().Jobs(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list jobs: %v", err)
+	}
+	if len(jobs.Items) != 1 || jobs.Items[0].Name != "eval-job-0" {
+		t.Fatalf("expected only the job of the benchmark that was not cancelled to be kept, got %v", jobs.Items)
+	}
+}
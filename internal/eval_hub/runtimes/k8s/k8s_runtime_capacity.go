@@ -0,0 +1,256 @@
+package k8s
+
+// Capacity check of the nodes of the cluster before the Job of a benchmark is created.
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// annotationCapacityHintKey annotates the Job of a benchmark that no node has the free
+// resources for when it is created, with the reason.
+const annotationCapacityHintKey = "eval-hub.github.io/capacity_hint"
+
+// capacityHint returns why the pod of the Job is likely to stay pending, empty when a node
+// has the resources it requests free or when the check is not enabled. The check is a hint:
+// the Job is created whatever it returns, and it returns empty when the cluster can not be
+// read. The Jobs queued by Kueue are not checked, Kueue admits them when they fit.
+func (r *K8sRuntime) capacityHint(ctx context.Context, logger *slog.Logger, job *batchv1.Job) string {
+	if r.serviceConfig == nil || !r.serviceConfig.CapacityCheck.IsEnabled() {
+		return ""
+	}
+	if job.Labels[labelKueueQueueNameKey] != "" {
+		return ""
+	}
+	nodes, err := r.helper.ListNodes(ctx)
+	if err != nil {
+		logger.Warn("capacity check skipped, the nodes can not be listed", "error", err)
+		return ""
+	}
+	pods, err := r.helper.ListScheduledPods(ctx)
+	if err != nil {
+		logger.Warn("capacity check skipped, the pods can not be listed", "error", err)
+		return ""
+	}
+	return checkCapacity(&job.Spec.Template.Spec, nodes, pods, r.serviceConfig.CapacityCheck.Autoscaling)
+}
+
+// checkCapacity returns why a pod with the spec is likely to stay pending on the nodes, given
+// the pods that run on them, empty when one of them can run it now.
+func checkCapacity(spec *corev1.PodSpec, nodes []corev1.Node, pods []corev1.Pod, autoscaling bool) string {
+	requests := podRequests(spec)
+	used := map[string]corev1.ResourceList{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Spec.NodeName == "" || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if used[pod.Spec.NodeName] == nil {
+			used[pod.Spec.NodeName] = corev1.ResourceList{}
+		}
+		addResources(used[pod.Spec.NodeName], podRequests(&pod.Spec))
+	}
+
+	candidates, large := 0, 0
+	for i := range nodes {
+		node := &nodes[i]
+		if !nodeAccepts(node, spec) {
+			continue
+		}
+		candidates++
+		if !fits(requests, node.Status.Allocatable, nil) {
+			continue
+		}
+		large++
+		if fits(requests, node.Status.Allocatable, used[node.Name]) {
+			return ""
+		}
+	}
+
+	requested := formatResources(requests)
+	if requested == "" {
+		requested = "a pod slot"
+	}
+	wait := "until resources are released"
+	if autoscaling {
+		wait = "until the cluster autoscaler adds a node"
+	}
+	switch {
+	case candidates == 0 && len(spec.NodeSelector) > 0:
+		return fmt.Sprintf("No ready node matches the node selector %s of the benchmark, the pod is likely to stay pending %s", formatLabels(spec.NodeSelector), nodeWait(autoscaling))
+	case candidates == 0:
+		return fmt.Sprintf("No ready node can run the benchmark, the pod is likely to stay pending %s", nodeWait(autoscaling))
+	case large == 0:
+		return fmt.Sprintf("None of the %d nodes that can run the benchmark has %s allocatable, the pod is likely to stay pending %s", candidates, requested, nodeWait(autoscaling))
+	default:
+		return fmt.Sprintf("None of the %d nodes that can run the benchmark has %s free, the pod is likely to stay pending %s", candidates, requested, wait)
+	}
+}
+
+func nodeWait(autoscaling bool) string {
+	if autoscaling {
+		return "until the cluster autoscaler adds a node"
+	}
+	return "until such a node joins the cluster"
+}
+
+// reportCapacityHint reports the benchmark pending with the capacity hint as its warning, so
+// that the status of the job explains why it does not start.
+func reportCapacityHint(logger *slog.Logger, evaluation *api.EvaluationJobResource, benchmark *api.EvaluationBenchmarkConfig, benchmarkIndex int, shard *shared.JobSpecShard, hint string, storage abstractions.RuntimeStorage) {
+	logger.Warn("benchmark is likely to stay pending", "job_id", evaluation.Resource.ID, "benchmark_id", benchmark.ID, "reason", hint)
+	if storage == nil {
+		return
+	}
+	event := &api.BenchmarkStatusEvent{
+		ProviderID:     benchmark.ProviderID,
+		ID:             benchmark.ID,
+		BenchmarkIndex: benchmarkIndex,
+		Status:         api.StatePending,
+		WarningMessage: api.WithMessageOrigin(&api.MessageInfo{
+			Message:     hint,
+			MessageCode: constants.MESSAGE_CODE_INSUFFICIENT_CAPACITY,
+		}, api.MessageOriginRuntime),
+	}
+	if shard != nil {
+		event.ShardIndex = &shard.Index
+	}
+	if err := storage.UpdateEvaluationJob(evaluation.Resource.ID, &api.StatusEvent{BenchmarkStatusEvent: event}); err != nil {
+		logger.Error("failed to report the capacity hint of the benchmark", "error", err, "job_id", evaluation.Resource.ID, "benchmark_id", benchmark.ID)
+	}
+}
+
+// podRequests returns the resources that the scheduler reserves for a pod: the requests of
+// its containers and sidecars, or of its largest init container when that is more.
+func podRequests(spec *corev1.PodSpec) corev1.ResourceList {
+	requests := corev1.ResourceList{corev1.ResourcePods: resource.MustParse("1")}
+	for _, container := range spec.Containers {
+		addResources(requests, container.Resources.Requests)
+	}
+	sidecars := corev1.ResourceList{}
+	var initRequests []corev1.ResourceList
+	for _, container := range spec.InitContainers {
+		if container.RestartPolicy != nil && *container.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			addResources(sidecars, container.Resources.Requests)
+			continue
+		}
+		// the init containers run one at a time, each next to the sidecars started before it
+		running := sidecars.DeepCopy()
+		addResources(running, container.Resources.Requests)
+		initRequests = append(initRequests, running)
+	}
+	addResources(requests, sidecars)
+	for _, running := range initRequests {
+		for name, quantity := range running {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity
+			}
+		}
+	}
+	return requests
+}
+
+func addResources(total corev1.ResourceList, add corev1.ResourceList) {
+	for name, quantity := range add {
+		current := total[name]
+		current.Add(quantity)
+		total[name] = current
+	}
+}
+
+// fits returns true when the allocatable resources, less the used ones, cover the requests.
+func fits(requests, allocatable, used corev1.ResourceList) bool {
+	for name, request := range requests {
+		if request.IsZero() {
+			continue
+		}
+		free := allocatable[name].DeepCopy()
+		if quantity, ok := used[name]; ok {
+			free.Sub(quantity)
+		}
+		if free.Cmp(request) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// nodeAccepts returns true when the pod can be scheduled on the node: the node is ready and
+// schedulable, matches the node selector of the pod and the pod tolerates its taints.
+func nodeAccepts(node *corev1.Node, spec *corev1.PodSpec) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+	ready := false
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			ready = condition.Status == corev1.ConditionTrue
+		}
+	}
+	if !ready {
+		return false
+	}
+	for key, value := range spec.NodeSelector {
+		if node.Labels[key] != value {
+			return false
+		}
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		if !slices.ContainsFunc(spec.Tolerations, func(toleration corev1.Toleration) bool {
+			return tolerates(&toleration, &taint)
+		}) {
+			return false
+		}
+	}
+	return true
+}
+
+func tolerates(toleration *corev1.Toleration, taint *corev1.Taint) bool {
+	if toleration.Effect != "" && toleration.Effect != taint.Effect {
+		return false
+	}
+	if toleration.Key != "" && toleration.Key != taint.Key {
+		return false
+	}
+	switch toleration.Operator {
+	case corev1.TolerationOpExists:
+		return true
+	case "", corev1.TolerationOpEqual:
+		return toleration.Key != "" && toleration.Value == taint.Value
+	default:
+		return false
+	}
+}
+
+func formatResources(resources corev1.ResourceList) string {
+	var parts []string
+	for _, name := range slices.Sorted(maps.Keys(resources)) {
+		quantity := resources[name]
+		if name == corev1.ResourcePods || quantity.IsZero() {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s %s", name, quantity.String()))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatLabels(labels map[string]string) string {
+	var parts []string
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		parts = append(parts, key+"="+labels[key])
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,207 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func capacityNode(name string, labels map[string]string, cpu, memory, gpus string, taints ...corev1.Taint) corev1.Node {
+	allocatable := corev1.ResourceList{
+		corev1.ResourceCPU:    resource.MustParse(cpu),
+		corev1.ResourceMemory: resource.MustParse(memory),
+		corev1.ResourcePods:   resource.MustParse("110"),
+	}
+	if gpus != "" {
+		allocatable["nvidia.com/gpu"] = resource.MustParse(gpus)
+	}
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Spec:       corev1.NodeSpec{Taints: taints},
+		Status: corev1.NodeStatus{
+			Allocatable: allocatable,
+			Conditions:  []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func capacityPod(node string, cpu, memory string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-on-" + node, Namespace: "other"},
+		Spec: corev1.PodSpec{
+			NodeName: node,
+			Containers: []corev1.Container{{Name: "main", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+			}}}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func TestCheckCapacity(t *testing.T) {
+	gpuSelector := map[string]string{"nvidia.com/gpu.present": "true"}
+	gpuTaint := corev1.Taint{Key: "nvidia.com/gpu", Effect: corev1.TaintEffectNoSchedule}
+	spec := func(selector map[string]string, tolerations []corev1.Toleration, gpus string) *corev1.PodSpec {
+		requests := corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("2"),
+			corev1.ResourceMemory: resource.MustParse("8Gi"),
+		}
+		if gpus != "" {
+			requests["nvidia.com/gpu"] = resource.MustParse(gpus)
+		}
+		return &corev1.PodSpec{
+			NodeSelector: selector,
+			Tolerations:  tolerations,
+			Containers:   []corev1.Container{{Name: adapterContainerName, Resources: corev1.ResourceRequirements{Requests: requests}}},
+		}
+	}
+	tolerateGPU := []corev1.Toleration{{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists}}
+
+	for _, tc := range []struct {
+		name        string
+		spec        *corev1.PodSpec
+		nodes       []corev1.Node
+		pods        []corev1.Pod
+		autoscaling bool
+		want        string
+	}{
+		{
+			name:  "a node has the resources free",
+			spec:  spec(nil, nil, ""),
+			nodes: []corev1.Node{capacityNode("small", nil, "1", "4Gi", ""), capacityNode("large", nil, "8", "32Gi", "")},
+			pods:  []corev1.Pod{capacityPod("large", "4", "16Gi")},
+		},
+		{
+			name:  "the nodes are busy",
+			spec:  spec(nil, nil, ""),
+			nodes: []corev1.Node{capacityNode("large", nil, "8", "32Gi", "")},
+			pods:  []corev1.Pod{capacityPod("large", "7", "16Gi")},
+			want:  "None of the 1 nodes that can run the benchmark has cpu 2, memory 8Gi free, the pod is likely to stay pending until resources are released",
+		},
+		{
+			name:        "the nodes are busy in an autoscaled cluster",
+			spec:        spec(nil, nil, ""),
+			nodes:       []corev1.Node{capacityNode("large", nil, "8", "32Gi", "")},
+			pods:        []corev1.Pod{capacityPod("large", "7", "16Gi")},
+			autoscaling: true,
+			want:        "until the cluster autoscaler adds a node",
+		},
+		{
+			name:  "no node is large enough",
+			spec:  spec(gpuSelector, tolerateGPU, "4"),
+			nodes: []corev1.Node{capacityNode("gpu", gpuSelector, "16", "64Gi", "2", gpuTaint)},
+			want:  "None of the 1 nodes that can run the benchmark has cpu 2, memory 8Gi, nvidia.com/gpu 4 allocatable, the pod is likely to stay pending until such a node joins the cluster",
+		},
+		{
+			name:  "no node matches the node selector",
+			spec:  spec(gpuSelector, tolerateGPU, "1"),
+			nodes: []corev1.Node{capacityNode("cpu", nil, "16", "64Gi", "")},
+			want:  "No ready node matches the node selector nvidia.com/gpu.present=true of the benchmark",
+		},
+		{
+			name:  "the taints of the nodes are not tolerated",
+			spec:  spec(gpuSelector, nil, "1"),
+			nodes: []corev1.Node{capacityNode("gpu", gpuSelector, "16", "64Gi", "2", gpuTaint)},
+			want:  "No ready node matches the node selector",
+		},
+		{
+			name:  "the tainted nodes are tolerated",
+			spec:  spec(gpuSelector, tolerateGPU, "1"),
+			nodes: []corev1.Node{capacityNode("gpu", gpuSelector, "16", "64Gi", "2", gpuTaint)},
+			pods:  []corev1.Pod{capacityPod("gpu", "4", "16Gi")},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := checkCapacity(tc.spec, tc.nodes, tc.pods, tc.autoscaling)
+			if tc.want == "" && got != "" {
+				t.Fatalf("expected the pod to fit, got %q", got)
+			}
+			if !strings.Contains(got, tc.want) {
+				t.Fatalf("expected a hint containing %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestPodRequests(t *testing.T) {
+	always := corev1.ContainerRestartPolicyAlways
+	requests := func(cpu string) corev1.ResourceRequirements {
+		return corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}}
+	}
+	spec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{Name: "sidecar", RestartPolicy: &always, Resources: requests("500m")},
+			{Name: "init", Resources: requests("4")},
+		},
+		Containers: []corev1.Container{{Name: "adapter", Resources: requests("1")}, {Name: "proxy", Resources: requests("250m")}},
+	}
+	// the init container with the sidecar started before it takes more than the containers
+	got := podRequests(spec)
+	if cpu := got[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("4500m")) != 0 {
+		t.Errorf("expected 4500m of cpu, got %s", cpu.String())
+	}
+	spec.InitContainers = spec.InitContainers[:1]
+	got = podRequests(spec)
+	if cpu := got[corev1.ResourceCPU]; cpu.Cmp(resource.MustParse("1750m")) != 0 {
+		t.Errorf("expected 1750m of cpu, got %s", cpu.String())
+	}
+}
+
+func TestCreateBenchmarkResourcesReportsCapacityHint(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+
+	node := capacityNode("tiny", nil, "100m", "128Mi", "")
+	clientset := fake.NewClientset(&node)
+	runtime := &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{clientset: clientset},
+		serviceConfig: &config.Config{
+			Service:       &config.ServiceConfig{EvalInitImage: "eval-init-image"},
+			CapacityCheck: &config.CapacityCheckConfig{Enabled: true},
+		},
+	}
+
+	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
+	if err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	jobs := listJobsByJobID(t, clientset, evaluation.Resource.ID)
+	if len(jobs) != 1 {
+		t.Fatalf("expected the job to be created, got %d jobs", len(jobs))
+	}
+	hint := jobs[0].Annotations[annotationCapacityHintKey]
+	if !strings.Contains(hint, "allocatable") {
+		t.Errorf("expected the job to be annotated with the capacity hint, got %q", hint)
+	}
+	if storage.runStatus == nil || storage.runStatus.BenchmarkStatusEvent == nil {
+		t.Fatalf("expected the capacity hint to be reported")
+	}
+	event := storage.runStatus.BenchmarkStatusEvent
+	if event.Status != api.StatePending || event.WarningMessage == nil || event.WarningMessage.MessageCode != constants.MESSAGE_CODE_INSUFFICIENT_CAPACITY || event.WarningMessage.Message != hint {
+		t.Errorf("expected a pending benchmark with the capacity hint, got %+v", event)
+	}
+
+	// without the check, nothing is reported
+	runtime.serviceConfig.CapacityCheck = nil
+	storage = &fakeStorage{providerConfigs: sampleProviders(providerID)}
+	evaluation.Resource.ID = "job-2"
+	if err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if storage.called {
+		t.Errorf("expected no status to be reported without the capacity check, got %+v", storage.runStatus)
+	}
+}
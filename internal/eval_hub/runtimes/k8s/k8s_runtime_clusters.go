@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	batchv1 "k8s.io/api/batch/v1"
+)
+
+// newRemoteClusterHelpers builds the clients of the remote clusters registered in the config.
+func newRemoteClusterHelpers(serviceConfig *config.Config) (map[string]*KubernetesHelper, error) {
+	if serviceConfig == nil || serviceConfig.Clusters == nil {
+		return nil, nil
+	}
+	if err := serviceConfig.Clusters.Validate(); err != nil {
+		return nil, err
+	}
+	remotes := map[string]*KubernetesHelper{}
+	for _, cluster := range serviceConfig.Clusters.Remote {
+		helper, err := NewKubernetesHelperForKubeconfig(cluster.Kubeconfig, cluster.Context)
+		if err != nil {
+			return nil, fmt.Errorf("cluster %s: %w", cluster.Name, err)
+		}
+		remotes[cluster.Name] = helper
+	}
+	return remotes, nil
+}
+
+// clusterFor returns the name of the cluster that the benchmarks of the provider of the job
+// run on, empty for the cluster of the service.
+func (r *K8sRuntime) clusterFor(evaluation *api.EvaluationJobResource, providerID string) string {
+	if r.serviceConfig == nil {
+		return ""
+	}
+	return r.serviceConfig.Clusters.ClusterFor(evaluation.Cluster, providerID, string(evaluation.Resource.Tenant))
+}
+
+// onCluster returns the runtime that creates and reads the resources on the named cluster,
+// the runtime itself for the cluster of the service.
+func (r *K8sRuntime) onCluster(name string) (*K8sRuntime, error) {
+	if name == "" {
+		return r, nil
+	}
+	helper, ok := r.remotes[name]
+	if !ok {
+		return nil, fmt.Errorf("cluster %q is not registered", name)
+	}
+	remote := *r
+	remote.helper = helper
+	remote.cluster = name
+	return &remote, nil
+}
+
+// clusterRuntimes returns the runtimes of the cluster of the service and of the remote
+// clusters, in the order of the config.
+func (r *K8sRuntime) clusterRuntimes() []*K8sRuntime {
+	runtimes := []*K8sRuntime{r}
+	if r.serviceConfig == nil {
+		return runtimes
+	}
+	for _, name := range r.serviceConfig.Clusters.Names() {
+		if remote, err := r.onCluster(name); err == nil {
+			runtimes = append(runtimes, remote)
+		}
+	}
+	return runtimes
+}
+
+// clusterName returns the name of the cluster of the runtime.
+func (r *K8sRuntime) clusterName() string {
+	if r.cluster == "" {
+		return config.LocalClusterName
+	}
+	return r.cluster
+}
+
+func (r *K8sRuntime) clusterError(err error) error {
+	return fmt.Errorf("cluster %s: %w", r.clusterName(), err)
+}
+
+// ClusterStatus reports, for the cluster of the service and each remote cluster, whether the
+// service reaches its API server and how many Kubernetes Jobs of evaluation jobs are active.
+func (r *K8sRuntime) ClusterStatus(ctx context.Context) ([]api.ClusterStatus, error) {
+	var statuses []api.ClusterStatus
+	for _, target := range r.clusterRuntimes() {
+		status := api.ClusterStatus{Name: target.clusterName()}
+		version, err := target.helper.ServerVersion()
+		if err != nil {
+			status.Error = err.Error()
+			statuses = append(statuses, status)
+			continue
+		}
+		status.Reachable = true
+		status.Version = version
+		jobs, err := target.helper.ListJobsInAllNamespaces(ctx, labelJobIDKey)
+		if err != nil {
+			status.Error = err.Error()
+		}
+		for i := range jobs {
+			if jobCondition(&jobs[i], batchv1.JobComplete) == nil && jobCondition(&jobs[i], batchv1.JobFailed) == nil {
+				status.ActiveJobs++
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
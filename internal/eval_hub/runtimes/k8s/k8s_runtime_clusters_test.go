@@ -0,0 +1,109 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func clustersRuntime(local, remote *fake.Clientset, providerID string) *K8sRuntime {
+	return &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{clientset: local},
+		ctx:    context.Background(),
+		serviceConfig: &config.Config{
+			Service: &config.ServiceConfig{},
+			Clusters: &config.ClustersConfig{
+				Remote: []config.ClusterConfig{{
+					Name:       "gpu-east",
+					Kubeconfig: "/etc/evalhub/clusters/gpu-east/kubeconfig",
+					EvalHubURL: "https://eval-hub.example.com",
+				}},
+				Providers: map[string]string{providerID: "gpu-east"},
+			},
+		},
+		remotes: map[string]*KubernetesHelper{"gpu-east": {clientset: remote}},
+	}
+}
+
+func TestCreateBenchmarksDispatchesToTheClusterOfTheProvider(t *testing.T) {
+	providerID := "provider-1"
+	local := fake.NewClientset()
+	remote := fake.NewClientset()
+	runtime := clustersRuntime(local, remote, providerID)
+	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
+
+	evaluation := sampleEvaluation(providerID)
+	runtime.createBenchmarks(evaluation, evaluation.Benchmarks, []int{0}, storage)
+	if jobs := listJobsByJobID(t, local, evaluation.Resource.ID); len(jobs) != 0 {
+		t.Fatalf("expected no job on the cluster of the service, got %d", len(jobs))
+	}
+	if jobs := listJobsByJobID(t, remote, evaluation.Resource.ID); len(jobs) != 1 {
+		t.Fatalf("expected 1 job on the remote cluster, got %d", len(jobs))
+	}
+	configMaps := listConfigMapsByJobID(t, remote, evaluation.Resource.ID)
+	if len(configMaps) != 1 || !strings.Contains(configMaps[0].Data[sidecarConfigFileName], "https://eval-hub.example.com") {
+		t.Fatalf("expected the sidecar to report to the eval_hub_url of the cluster, got %+v", configMaps)
+	}
+
+	// the job field wins over the cluster of the provider
+	evaluation.Resource.ID = "job-2"
+	evaluation.Cluster = config.LocalClusterName
+	runtime.createBenchmarks(evaluation, evaluation.Benchmarks, []int{0}, storage)
+	if jobs := listJobsByJobID(t, local, "job-2"); len(jobs) != 1 {
+		t.Fatalf("expected 1 job on the cluster of the service, got %d", len(jobs))
+	}
+
+	// the resources are deleted on every cluster
+	for _, jobID := range []string{"job-1", "job-2"} {
+		evaluation.Resource.ID = jobID
+		if err := runtime.DeleteEvaluationJobResources(evaluation); err != nil {
+			t.Fatalf("delete resources of %s: %v", jobID, err)
+		}
+	}
+	for _, clientset := range []*fake.Clientset{local, remote} {
+		for _, jobID := range []string{"job-1", "job-2"} {
+			if jobs := listJobsByJobID(t, clientset, jobID); len(jobs) != 0 {
+				t.Errorf("expected the jobs of %s to be deleted, got %d", jobID, len(jobs))
+			}
+		}
+	}
+}
+
+func TestClusterStatusCountsActiveJobs(t *testing.T) {
+	providerID := "provider-1"
+	job := func(name string, conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant-a", Labels: map[string]string{labelJobIDKey: "job-1"}},
+			Status:     batchv1.JobStatus{Conditions: conditions},
+		}
+	}
+	remote := fake.NewClientset(
+		job("running"),
+		job("complete", batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}),
+		job("failed", batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}),
+	)
+	runtime := clustersRuntime(fake.NewClientset(), remote, providerID)
+
+	statuses, err := runtime.ClusterStatus(context.Background())
+	if err != nil {
+		t.Fatalf("ClusterStatus: %v", err)
+	}
+	if len(statuses) != 2 {
+		t.Fatalf("expected the local and the remote cluster, got %+v", statuses)
+	}
+	if statuses[0].Name != config.LocalClusterName || !statuses[0].Reachable || statuses[0].ActiveJobs != 0 {
+		t.Errorf("unexpected status of the local cluster %+v", statuses[0])
+	}
+	if statuses[1].Name != "gpu-east" || !statuses[1].Reachable || statuses[1].ActiveJobs != 1 {
+		t.Errorf("unexpected status of the remote cluster %+v", statuses[1])
+	}
+}
@@ -0,0 +1,187 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// failureLogTailLines are the lines of the adapter logs kept with a failure.
+	failureLogTailLines = 20
+
+	podReasonEvicted         = "Evicted"
+	containerReasonOOMKilled = "OOMKilled"
+)
+
+// DiagnoseBenchmarkFailures returns the benchmarks of the job whose latest Kubernetes Job
+// failed, classified from the state of the pod of the Job: a container killed for running
+// out of memory, an evicted pod, a Job stopped at its deadline, or a container that exited
+// with an error. The last lines of the adapter logs are kept with the failure. The Jobs are
+// looked up on the cluster of the service and on the remote clusters.
+func (r *K8sRuntime) DiagnoseBenchmarkFailures(ctx context.Context, evaluation *api.EvaluationJobResource) ([]abstractions.BenchmarkFailure, error) {
+	var failures []abstractions.BenchmarkFailure
+	var diagnoseErr error
+	for _, target := range r.clusterRuntimes() {
+		clusterFailures, err := target.diagnoseClusterFailures(ctx, evaluation)
+		if err != nil {
+			diagnoseErr = errors.Join(diagnoseErr, target.clusterError(err))
+			continue
+		}
+		failures = append(failures, clusterFailures...)
+	}
+	return failures, diagnoseErr
+}
+
+func (r *K8sRuntime) diagnoseClusterFailures(ctx context.Context, evaluation *api.EvaluationJobResource) ([]abstractions.BenchmarkFailure, error) {
+	namespace := resolveNamespace(string(evaluation.Resource.Tenant))
+	labelSelector := fmt.Sprintf("%s=%s", labelJobIDKey, sanitizeLabelValue(evaluation.Resource.ID))
+	jobs, err := r.helper.ListJobs(ctx, namespace, labelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	// a retried benchmark has a Job for each run, only the latest one counts
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreationTimestamp.After(jobs[j].CreationTimestamp.Time)
+	})
+	seen := map[string]bool{}
+	var failures []abstractions.BenchmarkFailure
+	for i := range jobs {
+		job := &jobs[i]
+		index, err := strconv.Atoi(job.Labels[labelBenchmarkIndexKey])
+		if err != nil {
+			continue
+		}
+		workload := job.Labels[labelBenchmarkIndexKey] + "/" + job.Labels[labelShardIndexKey]
+		if seen[workload] {
+			continue
+		}
+		seen[workload] = true
+		failed := jobCondition(job, batchv1.JobFailed)
+		if failed == nil {
+			continue
+		}
+
+		failure := abstractions.BenchmarkFailure{BenchmarkIndex: index, CreatedAt: job.CreationTimestamp.Time}
+		if shard, err := strconv.Atoi(job.Labels[labelShardIndexKey]); err == nil {
+			failure.ShardIndex = &shard
+		}
+		pod, err := r.latestJobPod(ctx, namespace, job.Name)
+		if err != nil {
+			return nil, err
+		}
+		failure.ErrorMessage = classifyJobFailure(failed, pod)
+		if pod != nil {
+			failure.ErrorMessage.Diagnostics.Logs = r.adapterLogTail(ctx, namespace, pod.Name)
+		}
+		failures = append(failures, failure)
+	}
+	return failures, nil
+}
+
+func jobCondition(job *batchv1.Job, conditionType batchv1.JobConditionType) *batchv1.JobCondition {
+	for i := range job.Status.Conditions {
+		condition := &job.Status.Conditions[i]
+		if condition.Type == conditionType && condition.Status == corev1.ConditionTrue {
+			return condition
+		}
+	}
+	return nil
+}
+
+// classifyJobFailure returns the error message of a failed Job, from its Failed condition and
+// the state of its latest pod, which is nil when it is gone.
+func classifyJobFailure(failed *batchv1.JobCondition, pod *corev1.Pod) *api.MessageInfo {
+	diagnostics := &api.FailureDiagnostics{Reason: failed.Reason}
+	message := &api.MessageInfo{
+		Message:      "The benchmark workload failed",
+		MessageCode:  constants.MESSAGE_CODE_BENCHMARK_WORKLOAD_FAILED,
+		FailureClass: api.FailureClassUnknown,
+		Diagnostics:  diagnostics,
+	}
+	if failed.Message != "" {
+		message.Message += ": " + failed.Message
+	}
+	if failed.Reason == batchv1.JobReasonDeadlineExceeded {
+		message.FailureClass = api.FailureClassDeadlineExceeded
+		message.Message = "The benchmark workload was stopped at its active deadline — raise the deadline or reduce the examples"
+	}
+	if pod == nil {
+		return api.WithMessageOrigin(message, api.MessageOriginRuntime)
+	}
+
+	diagnostics.Pod = pod.Name
+	if pod.Status.Reason == podReasonEvicted {
+		diagnostics.Reason = pod.Status.Reason
+		message.FailureClass = api.FailureClassEvicted
+		message.MessageCode = constants.MESSAGE_CODE_POD_EVICTED
+		message.Message = "The benchmark pod was evicted — " + strings.TrimSuffix(pod.Status.Message, ".")
+		return api.WithMessageOrigin(message, api.MessageOriginRuntime)
+	}
+	status := failedContainer(pod)
+	if status == nil {
+		return api.WithMessageOrigin(message, api.MessageOriginRuntime)
+	}
+	terminated := status.State.Terminated
+	exitCode := terminated.ExitCode
+	diagnostics.Container = status.Name
+	diagnostics.ExitCode = &exitCode
+	diagnostics.Reason = terminated.Reason
+	if terminated.Reason == containerReasonOOMKilled {
+		message.FailureClass = api.FailureClassOOMKilled
+		message.MessageCode = constants.MESSAGE_CODE_OOM_KILLED
+		message.Message = fmt.Sprintf("The %s container was OOMKilled — raise the memory limit of the benchmark", status.Name)
+		return api.WithMessageOrigin(message, api.MessageOriginRuntime)
+	}
+	message.FailureClass = api.FailureClassError
+	message.Message = fmt.Sprintf("The %s container exited with code %d", status.Name, exitCode)
+	if terminated.Reason != "" {
+		message.Message += " (" + terminated.Reason + ")"
+	}
+	return api.WithMessageOrigin(message, api.MessageOriginRuntime)
+}
+
+// failedContainer returns the status of the container of the pod that was OOMKilled or
+// exited with an error, the adapter before the other containers, nil when there is none.
+func failedContainer(pod *corev1.Pod) *corev1.ContainerStatus {
+	var failed *corev1.ContainerStatus
+	for i := range pod.Status.ContainerStatuses {
+		status := &pod.Status.ContainerStatuses[i]
+		terminated := status.State.Terminated
+		if terminated == nil || (terminated.ExitCode == 0 && terminated.Reason != containerReasonOOMKilled) {
+			continue
+		}
+		if status.Name == adapterContainerName {
+			return status
+		}
+		if failed == nil {
+			failed = status
+		}
+	}
+	return failed
+}
+
+// adapterLogTail returns the last lines of the logs of the adapter container, empty when
+// they cannot be read.
+func (r *K8sRuntime) adapterLogTail(ctx context.Context, namespace, podName string) string {
+	tail := int64(failureLogTailLines)
+	logs, err := r.helper.GetPodLogs(ctx, namespace, podName, &corev1.PodLogOptions{
+		Container: adapterContainerName,
+		TailLines: &tail,
+	})
+	if err != nil {
+		r.logger.Debug("failed to read the logs of a failed benchmark pod", "pod", podName, "namespace", namespace, "error", err)
+		return ""
+	}
+	return strings.TrimRight(logs, "\n")
+}
@@ -0,0 +1,110 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDiagnoseBenchmarkFailures(t *testing.T) {
+	evaluation := sampleEvaluation("provider-1")
+	namespace := "default"
+	created := time.Now().Add(-time.Hour)
+	failedCondition := batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}
+	benchmarkJob := func(name, index string, created time.Time, conditions ...batchv1.JobCondition) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         namespace,
+				CreationTimestamp: metav1.NewTime(created),
+				Labels: map[string]string{
+					labelJobIDKey:          sanitizeLabelValue(evaluation.Resource.ID),
+					labelBenchmarkIndexKey: index,
+				},
+			},
+			Status: batchv1.JobStatus{Conditions: conditions},
+		}
+	}
+	jobPod := func(name, jobName string, status corev1.PodStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: map[string]string{"job-name": jobName}},
+			Status:     status,
+		}
+	}
+
+	clientset := fake.NewClientset(
+		// an adapter that ran out of memory
+		benchmarkJob("oom", "0", created, failedCondition),
+		jobPod("oom-pod", "oom", corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+			{Name: "sidecar", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 2, Reason: "Error"}}},
+			{Name: adapterContainerName, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}}},
+		}}),
+		// a failed run of a benchmark that was retried and is running again
+		benchmarkJob("retried-1", "1", created, failedCondition),
+		benchmarkJob("retried-2", "1", created.Add(time.Minute)),
+		// an evicted pod
+		benchmarkJob("evicted", "2", created, failedCondition),
+		jobPod("evicted-pod", "evicted", corev1.PodStatus{Reason: "Evicted", Message: "The node was low on resource: memory."}),
+	)
+	runtime := &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{clientset: clientset},
+	}
+
+	failures, err := runtime.DiagnoseBenchmarkFailures(context.Background(), evaluation)
+	if err != nil {
+		t.Fatalf("DiagnoseBenchmarkFailures: %v", err)
+	}
+	if len(failures) != 2 {
+		t.Fatalf("expected the failures of benchmarks 0 and 2, got %+v", failures)
+	}
+	for _, failure := range failures {
+		message := failure.ErrorMessage
+		switch failure.BenchmarkIndex {
+		case 0:
+			if message.FailureClass != api.FailureClassOOMKilled || message.MessageCode != constants.MESSAGE_CODE_OOM_KILLED || !strings.Contains(message.Message, "raise the memory limit") {
+				t.Errorf("expected an OOMKilled failure, got %+v", message)
+			}
+			diagnostics := message.Diagnostics
+			if diagnostics.Container != adapterContainerName || diagnostics.ExitCode == nil || *diagnostics.ExitCode != 137 || diagnostics.Logs != "fake logs" {
+				t.Errorf("expected the diagnostics of the adapter container, got %+v", diagnostics)
+			}
+		case 2:
+			if message.FailureClass != api.FailureClassEvicted || message.MessageCode != constants.MESSAGE_CODE_POD_EVICTED || !strings.Contains(message.Message, "low on resource") {
+				t.Errorf("expected an eviction, got %+v", message)
+			}
+		default:
+			t.Errorf("unexpected failure of benchmark %d", failure.BenchmarkIndex)
+		}
+	}
+}
+
+func TestClassifyJobFailure(t *testing.T) {
+	deadline := &batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: batchv1.JobReasonDeadlineExceeded}
+	if message := classifyJobFailure(deadline, nil); message.FailureClass != api.FailureClassDeadlineExceeded {
+		t.Errorf("expected a deadline failure, got %+v", message)
+	}
+
+	failed := &batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded"}
+	pod := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+		{Name: adapterContainerName, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}}},
+	}}}
+	message := classifyJobFailure(failed, pod)
+	if message.FailureClass != api.FailureClassError || message.Message != "The adapter container exited with code 1 (Error)" || message.MessageOrigin != api.MessageOriginRuntime {
+		t.Errorf("expected a container error, got %+v", message)
+	}
+
+	if message := classifyJobFailure(failed, &corev1.Pod{}); message.FailureClass != api.FailureClassUnknown || message.Diagnostics.Reason != "BackoffLimitExceeded" {
+		t.Errorf("expected an unknown failure with the reason of the Job, got %+v", message)
+	}
+}
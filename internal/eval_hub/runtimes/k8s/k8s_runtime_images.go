@@ -0,0 +1,143 @@
+package k8s
+
+// Digest pinning and signature verification of provider adapter images.
+import (
+	"context"
+	"crypto"
+	"fmt"
+	"maps"
+	"net/http"
+	"os"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"github.com/eval-hub/eval-hub/pkg/ociclient"
+)
+
+// imagePolicy resolves the adapter images to digests in their registries and verifies their
+// signatures, see config.ImagePolicyConfig.
+type imagePolicy struct {
+	config       *config.ImagePolicyConfig
+	keys         []crypto.PublicKey
+	dockerConfig []byte
+	httpClient   *http.Client
+}
+
+// newImagePolicy loads the public keys and registry credentials of the image policy, nil when
+// the images are not pinned.
+func newImagePolicy(serviceConfig *config.Config) (*imagePolicy, error) {
+	if serviceConfig == nil || !serviceConfig.ImagePolicy.IsEnabled() {
+		return nil, nil
+	}
+	cfg := serviceConfig.ImagePolicy
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	policy := &imagePolicy{
+		config: cfg,
+		httpClient: &http.Client{
+			Timeout:   cfg.EffectiveTimeout(),
+			Transport: &http.Transport{Proxy: serviceConfig.Proxy.ProxyFunc(config.ProxyDestinationOCI)},
+		},
+	}
+	for _, path := range cfg.PublicKeys {
+		data, err := os.ReadFile(path) // #nosec G304 -- key path from service configuration
+		if err != nil {
+			return nil, fmt.Errorf("image_policy: read public key: %w", err)
+		}
+		key, err := ociclient.ParsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("image_policy: public key %s: %w", path, err)
+		}
+		policy.keys = append(policy.keys, key)
+	}
+	if cfg.DockerConfig != "" {
+		data, err := os.ReadFile(cfg.DockerConfig) // #nosec G304 -- path from service configuration
+		if err != nil {
+			return nil, fmt.Errorf("image_policy: read docker config: %w", err)
+		}
+		policy.dockerConfig = data
+	}
+	return policy, nil
+}
+
+// pin returns the reference by digest of the image, after checking its signature when the
+// policy verifies signatures. An image referenced by digest keeps its digest.
+func (p *imagePolicy) pin(ctx context.Context, image string) (string, error) {
+	ref, err := ociclient.ParseImageReference(image)
+	if err != nil {
+		return "", err
+	}
+	creds := ociclient.Credentials{}
+	if p.dockerConfig != nil {
+		// registries without credentials are read anonymously
+		if registryCreds, err := ociclient.ParseDockerConfigJSON(p.dockerConfig, ref.Registry); err == nil {
+			creds = registryCreds
+		}
+	}
+	host := ref.APIRegistry()
+	if p.config.IsInsecureRegistry(ref.Registry) {
+		host = "http://" + host
+	}
+	client, err := ociclient.NewPullClient(host, ref.Repository, creds, p.httpClient)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.config.EffectiveTimeout())
+	defer cancel()
+	digest := ref.Digest
+	if digest == "" {
+		digest, err = client.ResolveDigest(ctx, ref.Tag)
+		if err != nil {
+			return "", fmt.Errorf("resolve digest of %s: %w", image, err)
+		}
+	}
+	if p.config.VerifiesSignatures() {
+		if err := client.VerifySignature(ctx, digest, p.keys); err != nil {
+			return "", fmt.Errorf("verify signature of %s: %w", image, err)
+		}
+	}
+	return ref.Pinned(digest), nil
+}
+
+// withAdapterImages returns a copy of the job with its own record of the pinned adapter
+// images, which the benchmarks created in one pass fill in turn.
+func withAdapterImages(evaluation *api.EvaluationJobResource) *api.EvaluationJobResource {
+	copied := *evaluation
+	status := api.EvaluationJobStatus{}
+	if evaluation.Status != nil {
+		status = *evaluation.Status
+	}
+	status.AdapterImages = maps.Clone(status.AdapterImages)
+	if status.AdapterImages == nil {
+		status.AdapterImages = map[string]string{}
+	}
+	copied.Status = &status
+	return &copied
+}
+
+// pinAdapterImage returns the adapter image of the job pinned to its digest: the image that
+// the job recorded for an earlier benchmark or run, else the image pinned now, which the job
+// records so that its other benchmarks and their retries run the same image even when the
+// tag moves.
+func (r *K8sRuntime) pinAdapterImage(ctx context.Context, evaluation *api.EvaluationJobResource, storage abstractions.RuntimeStorage, image string) (string, error) {
+	if evaluation.Status != nil {
+		if pinned, ok := evaluation.Status.AdapterImages[image]; ok {
+			return pinned, nil
+		}
+	}
+	pinned, err := r.images.pin(ctx, image)
+	if err != nil {
+		return "", err
+	}
+	// another benchmark of the job may have pinned the image meanwhile
+	pinned, err = storage.PinEvaluationJobAdapterImage(evaluation.Resource.ID, image, pinned)
+	if err != nil {
+		return "", fmt.Errorf("record the pinned adapter image %s: %w", image, err)
+	}
+	if evaluation.Status != nil && evaluation.Status.AdapterImages != nil {
+		evaluation.Status.AdapterImages[image] = pinned
+	}
+	return pinned, nil
+}
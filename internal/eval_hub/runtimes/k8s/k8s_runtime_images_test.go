@@ -0,0 +1,185 @@
+package k8s
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const adapterDigest = "sha256:4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+
+// unsignedRegistry serves the digest of the latest tag of evalhub/adapter and no signatures.
+func unsignedRegistry(t *testing.T) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/evalhub/adapter/manifests/latest" {
+			w.Header().Set("Docker-Content-Digest", adapterDigest)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	return strings.TrimPrefix(srv.URL, "http://")
+}
+
+func imagePolicyRuntime(clientset *fake.Clientset, policy *config.ImagePolicyConfig) *K8sRuntime {
+	serviceConfig := &config.Config{Service: &config.ServiceConfig{}, ImagePolicy: policy}
+	return &K8sRuntime{
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:        &KubernetesHelper{clientset: clientset},
+		ctx:           context.Background(),
+		serviceConfig: serviceConfig,
+		images:        &imagePolicy{config: policy, httpClient: http.DefaultClient},
+	}
+}
+
+func TestCreateBenchmarksPinsTheAdapterImage(t *testing.T) {
+	registry := unsignedRegistry(t)
+	providerID := "provider-1"
+	providers := sampleProviders(providerID)
+	providers[providerID].Runtime.K8s.Image = registry + "/evalhub/adapter:latest"
+	clientset := fake.NewClientset()
+	runtime := imagePolicyRuntime(clientset, &config.ImagePolicyConfig{PinDigests: true, InsecureRegistries: []string{registry}})
+
+	evaluation := sampleEvaluation(providerID)
+	runtime.createBenchmarks(evaluation, evaluation.Benchmarks, []int{0}, &fakeStorage{providerConfigs: providers})
+	jobs := listJobsByJobID(t, clientset, evaluation.Resource.ID)
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+	image := jobs[0].Spec.Template.Spec.Containers[0].Image
+	if image != registry+"/evalhub/adapter@"+adapterDigest {
+		t.Fatalf("expected the adapter image to be pinned to its digest, got %s", image)
+	}
+}
+
+func TestCreateBenchmarksRunsTheAdapterImagePinnedFirst(t *testing.T) {
+	// the latest tag moves on every request
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v2/evalhub/adapter/manifests/latest" {
+			requests++
+			w.Header().Set("Docker-Content-Digest", fmt.Sprintf("sha256:%064x", requests))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	registry := strings.TrimPrefix(srv.URL, "http://")
+	image := registry + "/evalhub/adapter:latest"
+	pinned := registry + "/evalhub/adapter@" + fmt.Sprintf("sha256:%064x", 1)
+	providerID := "provider-1"
+	providers := sampleProviders(providerID)
+	providers[providerID].Runtime.K8s.Image = image
+	policy := &config.ImagePolicyConfig{PinDigests: true, InsecureRegistries: []string{registry}}
+	storage := &fakeStorage{providerConfigs: providers}
+
+	clientset := fake.NewClientset()
+	evaluation := sampleEvaluation(providerID)
+	second := evaluation.Benchmarks[0]
+	second.ID = "bench-2"
+	evaluation.Benchmarks = append(evaluation.Benchmarks, second)
+	imagePolicyRuntime(clientset, policy).createBenchmarks(evaluation, evaluation.Benchmarks, []int{0, 1}, storage)
+	jobs := listJobsByJobID(t, clientset, evaluation.Resource.ID)
+	if len(jobs) != 2 {
+		t.Fatalf("expected 2 jobs, got %d", len(jobs))
+	}
+	for _, job := range jobs {
+		if got := job.Spec.Template.Spec.Containers[0].Image; got != pinned {
+			t.Errorf("expected every benchmark to run %s, got %s", pinned, got)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("expected the tag to be resolved once for the job, got %d requests", requests)
+	}
+	if storage.adapterImages[image] != pinned {
+		t.Fatalf("expected the job to record the pinned image, got %v", storage.adapterImages)
+	}
+
+	// a retry reads the job from storage with the image that it recorded
+	clientset = fake.NewClientset()
+	retry := sampleEvaluation(providerID)
+	retry.Status = &api.EvaluationJobStatus{AdapterImages: map[string]string{image: pinned}}
+	imagePolicyRuntime(clientset, policy).createBenchmarks(retry, retry.Benchmarks, []int{0}, storage)
+	jobs = listJobsByJobID(t, clientset, retry.Resource.ID)
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+	if got := jobs[0].Spec.Template.Spec.Containers[0].Image; got != pinned {
+		t.Errorf("expected the retry to run %s, got %s", pinned, got)
+	}
+	if requests != 1 {
+		t.Errorf("expected the retry not to resolve the tag again, got %d requests", requests)
+	}
+}
+
+func TestCreateBenchmarksFailsOnAnUnsignedAdapterImage(t *testing.T) {
+	registry := unsignedRegistry(t)
+	providerID := "provider-1"
+	providers := sampleProviders(providerID)
+	providers[providerID].Runtime.K8s.Image = registry + "/evalhub/adapter:latest"
+	clientset := fake.NewClientset()
+	runtime := imagePolicyRuntime(clientset, &config.ImagePolicyConfig{PublicKeys: []string{"cosign.pub"}, InsecureRegistries: []string{registry}})
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	runtime.images.keys = append(runtime.images.keys, &key.PublicKey)
+
+	evaluation := sampleEvaluation(providerID)
+	storage := &fakeStorage{providerConfigs: providers}
+	runtime.createBenchmarks(evaluation, evaluation.Benchmarks, []int{0}, storage)
+	if jobs := listJobsByJobID(t, clientset, evaluation.Resource.ID); len(jobs) != 0 {
+		t.Fatalf("expected no job for an unsigned image, got %d", len(jobs))
+	}
+	if storage.runStatus == nil || storage.runStatus.BenchmarkStatusEvent.Status != api.StateFailed {
+		t.Fatalf("expected the benchmark to fail, got %+v", storage.runStatus)
+	}
+	if message := storage.runStatus.BenchmarkStatusEvent.ErrorMessage.Message; !strings.Contains(message, "no signature found") {
+		t.Fatalf("expected the failure to name the missing signature, got %q", message)
+	}
+}
+
+func TestNewImagePolicyLoadsThePublicKeys(t *testing.T) {
+	if policy, err := newImagePolicy(&config.Config{}); policy != nil || err != nil {
+		t.Fatalf("expected no policy without image_policy, got %v, %v", policy, err)
+	}
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "cosign.pub")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	policy, err := newImagePolicy(&config.Config{ImagePolicy: &config.ImagePolicyConfig{PublicKeys: []string{path}}})
+	if err != nil {
+		t.Fatalf("newImagePolicy: %v", err)
+	}
+	if len(policy.keys) != 1 {
+		t.Fatalf("expected 1 public key, got %d", len(policy.keys))
+	}
+	if _, err := newImagePolicy(&config.Config{ImagePolicy: &config.ImagePolicyConfig{PublicKeys: []string{filepath.Join(t.TempDir(), "missing.pub")}}}); err == nil {
+		t.Fatal("expected an error for a missing public key")
+	}
+}
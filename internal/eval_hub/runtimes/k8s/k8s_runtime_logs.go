@@ -1,6 +1,7 @@
 package k8s
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strconv"
@@ -34,12 +35,20 @@ func (r *K8sRuntime) GetEvaluationLogs(
 				"ResourceId", fmt.Sprintf("%d", *benchmarkIndex),
 			)
 		}
-		return r.readBenchmarkLogs(evaluation, benchmarks[*benchmarkIndex], *benchmarkIndex, opts, false)
+		target, err := r.onCluster(r.clusterFor(evaluation, benchmarks[*benchmarkIndex].ProviderID))
+		if err != nil {
+			return "", err
+		}
+		return target.readBenchmarkLogs(evaluation, benchmarks[*benchmarkIndex], *benchmarkIndex, opts, false)
 	}
 
 	var sections []string
 	for i, bench := range benchmarks {
-		section, err := r.readBenchmarkLogs(evaluation, bench, i, opts, true)
+		target, err := r.onCluster(r.clusterFor(evaluation, bench.ProviderID))
+		if err != nil {
+			return "", err
+		}
+		section, err := target.readBenchmarkLogs(evaluation, bench, i, opts, true)
 		if err != nil {
 			return "", err
 		}
@@ -75,7 +84,7 @@ func (r *K8sRuntime) readBenchmarkLogs(
 	}
 
 	job := jobs[0]
-	pod, err := r.latestJobPod(namespace, job.Name)
+	pod, err := r.latestJobPod(r.ctx, namespace, job.Name)
 	if err != nil {
 		return "", err
 	}
@@ -118,8 +127,8 @@ func (r *K8sRuntime) readBenchmarkLogs(
 	return header + "\n" + logs, nil
 }
 
-func (r *K8sRuntime) latestJobPod(namespace, jobName string) (*corev1.Pod, error) {
-	pods, err := r.helper.ListPods(r.ctx, namespace, fmt.Sprintf("job-name=%s", jobName))
+func (r *K8sRuntime) latestJobPod(ctx context.Context, namespace, jobName string) (*corev1.Pod, error) {
+	pods, err := r.helper.ListPods(ctx, namespace, fmt.Sprintf("job-name=%s", jobName))
 	if err != nil {
 		return nil, err
 	}
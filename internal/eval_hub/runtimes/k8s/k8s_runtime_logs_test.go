@@ -262,7 +262,7 @@ func TestLatestJobPodSelectsNewestPod(t *testing.T) {
 		ctx:    context.Background(),
 	}
 
-	pod, err := runtime.latestJobPod(namespace, jobName)
+	pod, err := runtime.latestJobPod(context.Background(), namespace, jobName)
 	if err != nil {
 		t.Fatalf("latestJobPod: %v", err)
 	}
@@ -0,0 +1,101 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+	authorizationv1 "k8s.io/api/authorization/v1"
+)
+
+// requiredPermission is a verb on a resource that the runtime uses, in the namespace of the
+// service unless it is cluster-wide.
+type requiredPermission struct {
+	verbs       []string
+	group       string
+	resource    string
+	subresource string
+	clusterWide bool
+	neededFor   string
+}
+
+// requiredPermissions returns the permissions that the runtime needs with the service config:
+// the ones of the Jobs of the benchmarks and of their resources, and the ones of the
+// capacity check and of the image warm-up when they are enabled.
+func (r *K8sRuntime) requiredPermissions() []requiredPermission {
+	permissions := []requiredPermission{
+		{verbs: []string{"create", "list", "delete"}, group: "batch", resource: "jobs", neededFor: "running the benchmarks"},
+		{verbs: []string{"create", "get", "list", "update", "delete"}, resource: "configmaps", neededFor: "the job specs of the benchmarks"},
+		{verbs: []string{"create", "get", "list", "update", "delete"}, resource: "secrets", neededFor: "the model credentials and the secret parameters of the benchmarks"},
+		{verbs: []string{"create", "get", "list", "update", "delete"}, resource: "persistentvolumeclaims", neededFor: "the data volumes of the benchmarks"},
+		{verbs: []string{"list"}, resource: "pods", neededFor: "the logs and the failure diagnostics of the benchmarks"},
+		{verbs: []string{"get"}, resource: "pods", subresource: "log", neededFor: "the logs and the failure diagnostics of the benchmarks"},
+	}
+	if r.serviceConfig != nil && r.serviceConfig.CapacityCheck.IsEnabled() {
+		permissions = append(permissions,
+			requiredPermission{verbs: []string{"list"}, resource: "nodes", clusterWide: true, neededFor: "capacity_check"},
+			requiredPermission{verbs: []string{"list"}, resource: "pods", clusterWide: true, neededFor: "capacity_check"},
+		)
+	}
+	if r.warmupConfig().IsEnabled() {
+		permissions = append(permissions,
+			requiredPermission{verbs: []string{"create", "list", "update", "delete"}, group: "apps", resource: "daemonsets", neededFor: "image_warmup"},
+		)
+	}
+	return permissions
+}
+
+// CheckPermissions checks with SelfSubjectAccessReviews that the service account of the
+// service may use every resource that the runtime needs, on the cluster of the service and on
+// the remote clusters, so that missing RBAC is reported when the service starts rather than
+// as Forbidden errors of the benchmarks. The namespace-scoped permissions are checked in the
+// namespace of the service; the jobs of a tenant run in the namespace of the tenant.
+func (r *K8sRuntime) CheckPermissions(ctx context.Context) (*api.PermissionCheck, error) {
+	check := &api.PermissionCheck{CheckedAt: time.Now().UTC()}
+	namespace := resolveNamespace("")
+	for _, target := range r.clusterRuntimes() {
+		missing, err := target.missingPermissions(ctx, namespace)
+		if err != nil {
+			check.Errors = append(check.Errors, target.clusterError(err).Error())
+			continue
+		}
+		check.Missing = append(check.Missing, missing...)
+	}
+	return check, nil
+}
+
+func (r *K8sRuntime) missingPermissions(ctx context.Context, namespace string) ([]api.MissingPermission, error) {
+	var missing []api.MissingPermission
+	for _, permission := range r.requiredPermissions() {
+		attributes := authorizationv1.ResourceAttributes{
+			Group:       permission.group,
+			Resource:    permission.resource,
+			Subresource: permission.subresource,
+		}
+		if !permission.clusterWide {
+			attributes.Namespace = namespace
+		}
+		for _, verb := range permission.verbs {
+			attributes.Verb = verb
+			allowed, reason, err := r.helper.CanI(ctx, attributes)
+			if err != nil {
+				return nil, fmt.Errorf("check %s %s: %w", verb, permission.resource, err)
+			}
+			if allowed {
+				continue
+			}
+			missing = append(missing, api.MissingPermission{
+				Cluster:     r.clusterName(),
+				Namespace:   attributes.Namespace,
+				Verb:        verb,
+				Group:       permission.group,
+				Resource:    permission.resource,
+				Subresource: permission.subresource,
+				NeededFor:   permission.neededFor,
+				Reason:      reason,
+			})
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,64 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// denyingClientset answers the access reviews, denying the ones that deny returns true for.
+func denyingClientset(deny func(attributes *authorizationv1.ResourceAttributes) bool) *fake.Clientset {
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, k8sruntime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = !deny(review.Spec.ResourceAttributes)
+		if !review.Status.Allowed {
+			review.Status.Reason = "no RBAC policy matched"
+		}
+		return true, review, nil
+	})
+	return clientset
+}
+
+func TestCheckPermissionsReportsMissingPermissions(t *testing.T) {
+	clientset := denyingClientset(func(attributes *authorizationv1.ResourceAttributes) bool {
+		return attributes.Subresource == "log" || attributes.Resource == "nodes"
+	})
+	runtime := &K8sRuntime{
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper:        &KubernetesHelper{clientset: clientset},
+		serviceConfig: &config.Config{CapacityCheck: &config.CapacityCheckConfig{Enabled: true}},
+	}
+
+	check, err := runtime.CheckPermissions(context.Background())
+	if err != nil {
+		t.Fatalf("CheckPermissions: %v", err)
+	}
+	if len(check.Missing) != 2 || len(check.Errors) != 0 {
+		t.Fatalf("expected 2 missing permissions, got %+v", check)
+	}
+	logs, nodes := check.Missing[0], check.Missing[1]
+	if logs.Cluster != config.LocalClusterName || logs.Verb != "get" || logs.Resource != "pods" || logs.Subresource != "log" || logs.Namespace == "" {
+		t.Errorf("unexpected missing permission %+v", logs)
+	}
+	if nodes.Verb != "list" || nodes.Resource != "nodes" || nodes.Namespace != "" || nodes.NeededFor != "capacity_check" || nodes.Reason != "no RBAC policy matched" {
+		t.Errorf("unexpected missing permission %+v", nodes)
+	}
+
+	// the permissions of the disabled features are not checked
+	runtime.serviceConfig = &config.Config{}
+	check, err = runtime.CheckPermissions(context.Background())
+	if err != nil {
+		t.Fatalf("CheckPermissions: %v", err)
+	}
+	if len(check.Missing) != 1 {
+		t.Errorf("expected 1 missing permission, got %+v", check.Missing)
+	}
+}
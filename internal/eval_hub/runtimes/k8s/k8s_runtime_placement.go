@@ -0,0 +1,111 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// recordJobPlacement stores where the Job of a benchmark was created. The shards of a sharded
+// benchmark have a Job each, so only their cluster and namespace are recorded.
+func (r *K8sRuntime) recordJobPlacement(logger *slog.Logger, evaluation *api.EvaluationJobResource, benchmarkIndex int, shard *shared.JobSpecShard, job *batchv1.Job, storage abstractions.RuntimeStorage) {
+	if storage == nil || job == nil {
+		return
+	}
+	placement := &api.BenchmarkPlacement{
+		Runtime:   r.Name(),
+		Cluster:   r.cluster,
+		Namespace: job.Namespace,
+	}
+	if shard == nil {
+		placement.JobName = job.Name
+	}
+	if err := storage.UpdateEvaluationJobBenchmarkPlacement(evaluation.Resource.ID, benchmarkIndex, placement); err != nil {
+		logger.Error("failed to record the placement of the benchmark", "error", err, "job_id", evaluation.Resource.ID, "benchmark_index", benchmarkIndex)
+	}
+}
+
+// BenchmarkPlacements returns the placement of the latest Job of each benchmark of the job
+// that is not sharded, with the pod of the Job, its node and the times its pod started and
+// its adapter finished. The Jobs are looked up on the cluster of the service and on the
+// remote clusters.
+func (r *K8sRuntime) BenchmarkPlacements(ctx context.Context, evaluation *api.EvaluationJobResource) (map[int]*api.BenchmarkPlacement, error) {
+	placements := map[int]*api.BenchmarkPlacement{}
+	var placementErr error
+	for _, target := range r.clusterRuntimes() {
+		if err := target.readClusterPlacements(ctx, evaluation, placements); err != nil {
+			placementErr = errors.Join(placementErr, target.clusterError(err))
+		}
+	}
+	return placements, placementErr
+}
+
+func (r *K8sRuntime) readClusterPlacements(ctx context.Context, evaluation *api.EvaluationJobResource, placements map[int]*api.BenchmarkPlacement) error {
+	namespace := resolveNamespace(string(evaluation.Resource.Tenant))
+	labelSelector := fmt.Sprintf("%s=%s", labelJobIDKey, sanitizeLabelValue(evaluation.Resource.ID))
+	jobs, err := r.helper.ListJobs(ctx, namespace, labelSelector)
+	if err != nil {
+		return err
+	}
+
+	// a retried benchmark has a Job for each run, only the latest one counts
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].CreationTimestamp.After(jobs[j].CreationTimestamp.Time)
+	})
+	for i := range jobs {
+		job := &jobs[i]
+		if job.Labels[labelShardIndexKey] != "" {
+			continue
+		}
+		index, err := strconv.Atoi(job.Labels[labelBenchmarkIndexKey])
+		if err != nil {
+			continue
+		}
+		if _, seen := placements[index]; seen {
+			continue
+		}
+		placement := &api.BenchmarkPlacement{
+			Runtime:   r.Name(),
+			Cluster:   r.cluster,
+			Namespace: job.Namespace,
+			JobName:   job.Name,
+		}
+		pod, err := r.latestJobPod(ctx, namespace, job.Name)
+		if err != nil {
+			return err
+		}
+		if pod != nil {
+			placement.PodName = pod.Name
+			placement.NodeName = pod.Spec.NodeName
+			if pod.Status.StartTime != nil {
+				placement.StartedAt = api.DateTimeToString(pod.Status.StartTime.Time)
+			}
+			if finished := adapterFinishedAt(pod); finished != nil {
+				placement.FinishedAt = api.DateTimeToString(*finished)
+			}
+		}
+		placements[index] = placement
+	}
+	return nil
+}
+
+// adapterFinishedAt returns when the adapter container of the pod terminated, nil while it
+// runs.
+func adapterFinishedAt(pod *corev1.Pod) *time.Time {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == adapterContainerName && status.State.Terminated != nil {
+			return &status.State.Terminated.FinishedAt.Time
+		}
+	}
+	return nil
+}
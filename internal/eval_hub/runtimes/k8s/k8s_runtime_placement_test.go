@@ -0,0 +1,82 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestBenchmarkPlacements(t *testing.T) {
+	evaluation := sampleEvaluation("provider-1")
+	namespace := "default"
+	created := time.Now().Add(-time.Hour).Truncate(time.Second)
+	benchmarkJob := func(name, index, shard string, created time.Time) *batchv1.Job {
+		labels := map[string]string{
+			labelJobIDKey:          sanitizeLabelValue(evaluation.Resource.ID),
+			labelBenchmarkIndexKey: index,
+		}
+		if shard != "" {
+			labels[labelShardIndexKey] = shard
+		}
+		return &batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			CreationTimestamp: metav1.NewTime(created),
+			Labels:            labels,
+		}}
+	}
+	started := metav1.NewTime(created.Add(time.Minute))
+	finished := metav1.NewTime(created.Add(10 * time.Minute))
+
+	clientset := fake.NewClientset(
+		// a benchmark that ran to its end
+		benchmarkJob("done", "0", "", created),
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "done-pod", Namespace: namespace, Labels: map[string]string{"job-name": "done"}},
+			Spec:       corev1.PodSpec{NodeName: "gpu-node-1"},
+			Status: corev1.PodStatus{StartTime: &started, ContainerStatuses: []corev1.ContainerStatus{
+				{Name: adapterContainerName, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{FinishedAt: finished}}},
+			}},
+		},
+		// a retried benchmark whose latest Job has no pod yet
+		benchmarkJob("retried-1", "1", "", created),
+		benchmarkJob("retried-2", "1", "", created.Add(time.Minute)),
+		// the shards of a sharded benchmark
+		benchmarkJob("sharded-0", "2", "0", created),
+	)
+	runtime := &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{clientset: clientset},
+	}
+
+	placements, err := runtime.BenchmarkPlacements(context.Background(), evaluation)
+	if err != nil {
+		t.Fatalf("BenchmarkPlacements: %v", err)
+	}
+	if len(placements) != 2 {
+		t.Fatalf("expected the placements of benchmarks 0 and 1, got %+v", placements)
+	}
+	want := api.BenchmarkPlacement{
+		Runtime:    "kubernetes",
+		Namespace:  namespace,
+		JobName:    "done",
+		PodName:    "done-pod",
+		NodeName:   "gpu-node-1",
+		StartedAt:  api.DateTimeToString(started.Time),
+		FinishedAt: api.DateTimeToString(finished.Time),
+	}
+	if *placements[0] != want {
+		t.Errorf("placement of benchmark 0 = %+v, want %+v", *placements[0], want)
+	}
+	if placement := placements[1]; placement.JobName != "retried-2" || placement.PodName != "" {
+		t.Errorf("expected the latest Job of the retried benchmark without a pod, got %+v", placement)
+	}
+}
@@ -0,0 +1,58 @@
+package k8s
+
+import (
+	"fmt"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// GetEvaluationJobSpec returns the job.json that the ConfigMap of the benchmark (shard)
+// holds, built the same way as when its Kubernetes Job is created.
+func (r *K8sRuntime) GetEvaluationJobSpec(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	shardIndex int,
+	storage abstractions.RuntimeStorage,
+) ([]byte, error) {
+	if benchmarkIndex < 0 || benchmarkIndex >= len(benchmarks) {
+		return nil, serviceerrors.NewServiceError(
+			messages.ResourceNotFound,
+			"Type", "benchmark",
+			"ResourceId", fmt.Sprintf("%d", benchmarkIndex),
+		)
+	}
+	bench := benchmarks[benchmarkIndex]
+	count := shared.ShardCount(&bench)
+	if shardIndex < 0 || shardIndex >= count {
+		return nil, serviceerrors.NewServiceError(
+			messages.ResourceNotFound,
+			"Type", "shard",
+			"ResourceId", fmt.Sprintf("%d", shardIndex),
+		)
+	}
+	var shard *shared.JobSpecShard
+	if count > 1 {
+		shard = &shared.JobSpecShard{Index: shardIndex, Count: count}
+	}
+
+	provider, err := storage.GetProvider(bench.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	// the hardware profile only changes the resources of the pod, not the job spec
+	jobConfig, err := buildJobConfig(evaluation, provider, &bench, benchmarkIndex, r.serviceConfig, nil)
+	if err != nil {
+		return nil, fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, bench.ID, err)
+	}
+	if err := completeJobSpec(jobConfig, shard); err != nil {
+		return nil, fmt.Errorf("job %s benchmark %s: %w", evaluation.Resource.ID, bench.ID, err)
+	}
+	jobConfig.jobSpec.CallbackToken = ""
+
+	return shared.MarshalJobSpec(&jobConfig.jobSpec)
+}
@@ -0,0 +1,51 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetEvaluationJobSpecMatchesConfigMap(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	evaluation.Benchmarks[0].Shards = 3
+
+	clientset := fake.NewClientset()
+	runtime := &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{clientset: clientset},
+		serviceConfig: &config.Config{
+			Service: &config.ServiceConfig{
+				EvalInitImage: "eval-init-image",
+			},
+		},
+	}
+	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
+
+	shard := &shared.JobSpecShard{Index: 1, Count: 3}
+	if err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, shard, storage); err != nil {
+		t.Fatalf("createBenchmarkResources() error: %v", err)
+	}
+	configMaps := listConfigMapsByJobID(t, clientset, evaluation.Resource.ID)
+	if len(configMaps) != 1 {
+		t.Fatalf("expected 1 configmap, got %d", len(configMaps))
+	}
+
+	spec, err := runtime.GetEvaluationJobSpec(evaluation, evaluation.Benchmarks, 0, 1, storage)
+	if err != nil {
+		t.Fatalf("GetEvaluationJobSpec() error: %v", err)
+	}
+	if got, want := string(spec), configMaps[0].Data[jobSpecFileName]; got != want {
+		t.Fatalf("job spec differs from the ConfigMap\ngot:  %s\nwant: %s", got, want)
+	}
+
+	if _, err := runtime.GetEvaluationJobSpec(evaluation, evaluation.Benchmarks, 0, 3, storage); err == nil {
+		t.Fatal("expected an error for a shard out of range")
+	}
+}
@@ -248,12 +248,12 @@ func TestCreateBenchmarkResourcesDuplicateBenchmarkIDDoesNotCollide(t *testing.T
 		_ = runtime.DeleteEvaluationJobResources(evaluation)
 	})
 
-	if err := runtime.createBenchmarkResources(context.Background(), logger, evaluation, &evaluation.Benchmarks[0], 0, storage); err != nil {
+	if err := runtime.createBenchmarkResources(context.Background(), logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage); err != nil {
 		t.Logf("first createBenchmarkResources error: %v", err)
 		t.Fatalf("unexpected error creating first benchmark resources: %v", err)
 	}
 
-	if err := runtime.createBenchmarkResources(context.Background(), logger, evaluation, &evaluation.Benchmarks[1], 1, storage); err != nil {
+	if err := runtime.createBenchmarkResources(context.Background(), logger, evaluation, &evaluation.Benchmarks[1], 1, nil, storage); err != nil {
 		t.Fatalf("unexpected error creating second benchmark resources: %v", err)
 	}
 
@@ -319,7 +319,7 @@ func TestCreateBenchmarkResourcesSetsAnnotationsIntegration(t *testing.T) {
 		_ = runtime.DeleteEvaluationJobResources(evaluation)
 	})
 
-	if err := runtime.createBenchmarkResources(context.Background(), logger, evaluation, &evaluation.Benchmarks[0], 0, storage); err != nil {
+	if err := runtime.createBenchmarkResources(context.Background(), logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage); err != nil {
 		t.Fatalf("unexpected error creating benchmark resources: %v", err)
 	}
 
@@ -416,7 +416,7 @@ func TestCreateBenchmarkResourcesAddsModelAuthVolumeAndEnvIntegration(t *testing
 		_ = runtime.DeleteEvaluationJobResources(evaluation)
 	})
 
-	if err := runtime.createBenchmarkResources(context.Background(), logger, evaluation, &evaluation.Benchmarks[0], 0, storage); err != nil {
+	if err := runtime.createBenchmarkResources(context.Background(), logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage); err != nil {
 		t.Fatalf("unexpected error creating benchmark resources: %v", err)
 	}
 
@@ -517,7 +517,7 @@ func TestCreateBenchmarkResourcesAddsInitContainerForS3TestDataIntegration(t *te
 		_ = runtime.DeleteEvaluationJobResources(evaluation)
 	})
 
-	if err := runtime.createBenchmarkResources(context.Background(), logger, evaluation, &evaluation.Benchmarks[0], 0, storage); err != nil {
+	if err := runtime.createBenchmarkResources(context.Background(), logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage); err != nil {
 		t.Fatalf("unexpected error creating benchmark resources: %v", err)
 	}
 
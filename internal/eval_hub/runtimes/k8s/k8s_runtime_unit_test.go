@@ -2,6 +2,7 @@ package k8s
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"log/slog"
@@ -11,7 +12,9 @@ import (
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
 	"github.com/eval-hub/eval-hub/pkg/api"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -35,9 +38,10 @@ type fakeStorage struct {
 	owner             api.User
 	providerConfigs   map[string]api.ProviderResource
 	collectionConfigs map[string]api.CollectionResource
+	adapterImages     map[string]string
 }
 
-// UpdateEvaluationJob implements [abstractions.Storage].
+// UpdateEvaluationJob implements [abstractions.RuntimeStorage].
 func (f *fakeStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent) error {
 	f.called = true
 	f.runStatus = runStatus
@@ -63,9 +67,15 @@ func (f *fakeStorage) GetEvaluationJobs(_ *abstractions.QueryFilter) (*abstracti
 func (f *fakeStorage) DeleteEvaluationJob(_ string) error {
 	return nil
 }
-func (f *fakeStorage) UpdateEvaluationJobStatus(_ string, _ api.OverallState, _ *api.MessageInfo) error {
+func (f *fakeStorage) PatchEvaluationJob(_ string, _ *api.Patch) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) UpdateEvaluationJobOwner(_ string, _ api.User) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) UpdateEvaluationJobStatus(_ string, _ api.OverallState, _ *api.MessageInfo) (api.OverallState, error) {
 	f.called = true
-	return nil
+	return "", nil
 }
 func (f *fakeStorage) CreateCollection(_ *api.CollectionResource) error {
 	return nil
@@ -110,11 +120,125 @@ func (f *fakeStorage) PatchProvider(_ string, _ *api.Patch) (*api.ProviderResour
 	return nil, nil
 }
 func (f *fakeStorage) Close() error { return nil }
+func (f *fakeStorage) CreateBaseline(_ *api.BaselineResource) error {
+	return nil
+}
+func (f *fakeStorage) GetBaseline(_ string) (*api.BaselineResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) GetBaselines(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.BaselineResource], error) {
+	return nil, nil
+}
+func (f *fakeStorage) DeleteBaseline(_ string) error { return nil }
+func (f *fakeStorage) GetEvaluationJobFindings(_ string, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.FindingResource], error) {
+	return &abstractions.QueryResults[api.FindingResource]{}, nil
+}
+func (f *fakeStorage) GetEvaluationMetricsHistory(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.MetricPoint], error) {
+	return &abstractions.QueryResults[api.MetricPoint]{}, nil
+}
+func (f *fakeStorage) GetEvaluationMetricNames(_ *abstractions.QueryFilter) ([]string, error) {
+	return nil, nil
+}
+func (f *fakeStorage) PutEvaluationJobArtifact(_ string, _ *api.ArtifactResource) error { return nil }
+func (f *fakeStorage) GetEvaluationJobArtifacts(_ string, _ int, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.ArtifactResource], error) {
+	return &abstractions.QueryResults[api.ArtifactResource]{}, nil
+}
+func (f *fakeStorage) PutEvaluationJobGeneration(_ string, _ *api.ArtifactResource) error {
+	return nil
+}
+func (f *fakeStorage) GetEvaluationJobGeneration(_ string, _ int, _ string) (*api.ArtifactResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) GetEvaluationJobGenerations(_ string, _ int, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.ArtifactResource], error) {
+	return &abstractions.QueryResults[api.ArtifactResource]{}, nil
+}
+func (f *fakeStorage) ReviewEvaluationJob(_ string, _ api.User, _ *api.ReviewDecision) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) UpdateEvaluationJobModelMetadata(_ string, _ *api.ModelMetadata) error {
+	return nil
+}
+func (f *fakeStorage) UpdateEvaluationJobBenchmarkPlacement(_ string, _ int, _ *api.BenchmarkPlacement) error {
+	return nil
+}
+func (f *fakeStorage) PinEvaluationJobAdapterImage(_ string, image string, pinned string) (string, error) {
+	if recorded, ok := f.adapterImages[image]; ok {
+		return recorded, nil
+	}
+	if f.adapterImages == nil {
+		f.adapterImages = map[string]string{}
+	}
+	f.adapterImages[image] = pinned
+	return pinned, nil
+}
+func (f *fakeStorage) LinkEvaluationJobExperiment(_ string, _ string, _ string) error {
+	return nil
+}
+func (f *fakeStorage) AddStatusDeadLetter(_ *api.StatusDeadLetter) error {
+	return nil
+}
+func (f *fakeStorage) GetStatusDeadLetter(_ string) (*api.StatusDeadLetter, error) {
+	return nil, nil
+}
+func (f *fakeStorage) GetStatusDeadLetters(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.StatusDeadLetter], error) {
+	return &abstractions.QueryResults[api.StatusDeadLetter]{}, nil
+}
+func (f *fakeStorage) DeleteStatusDeadLetter(_ string) error {
+	return nil
+}
+func (f *fakeStorage) GetEvaluationJobsWithoutExperiment(_ int) ([]api.Resource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) AddEvaluationJobBenchmarkMetrics(_ string, _ map[int]map[string]any) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) PutTagPolicy(_ *api.TagPolicyResource) error {
+	return nil
+}
+func (f *fakeStorage) GetTagPolicy() (*api.TagPolicyResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) PutRedactionPolicy(_ *api.RedactionPolicyResource) error {
+	return nil
+}
+func (f *fakeStorage) GetRedactionPolicy() (*api.RedactionPolicyResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) AddEvaluationJobRedaction(_ string, _ *api.RedactionRecord) error {
+	return nil
+}
+func (f *fakeStorage) GetEvaluationJobRedactions(_ string, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.RedactionRecord], error) {
+	return &abstractions.QueryResults[api.RedactionRecord]{}, nil
+}
+func (f *fakeStorage) CreateEvaluationView(_ *api.EvaluationViewResource) error {
+	return nil
+}
+func (f *fakeStorage) GetEvaluationView(_ string) (*api.EvaluationViewResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) GetEvaluationViews(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.EvaluationViewResource], error) {
+	return nil, nil
+}
+func (f *fakeStorage) UpdateEvaluationView(_ string, _ *api.EvaluationViewConfig) (*api.EvaluationViewResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) DeleteEvaluationView(_ string) error {
+	return nil
+}
+func (f *fakeStorage) PutCachedBenchmarkResult(_ *abstractions.CachedBenchmarkResult) error {
+	return nil
+}
+func (f *fakeStorage) GetCachedBenchmarkResult(_ string, _ time.Time) (*abstractions.CachedBenchmarkResult, error) {
+	return nil, nil
+}
+func (f *fakeStorage) GetBenchmarkDurations(_ []abstractions.BenchmarkDurationKey) (map[abstractions.BenchmarkDurationKey]time.Duration, error) {
+	return nil, nil
+}
 func (f *fakeStorage) LoadSystemResources(_ map[string]api.CollectionResource, _ map[string]api.ProviderResource) error {
 	return nil
 }
 
-func (f *fakeStorage) WithLogger(logger *slog.Logger) abstractions.Storage {
+func (f *fakeStorage) WithLogger(logger *slog.Logger) *fakeStorage {
 	return &fakeStorage{
 		logger:            logger,
 		ctx:               f.ctx,
@@ -127,7 +251,7 @@ func (f *fakeStorage) WithLogger(logger *slog.Logger) abstractions.Storage {
 	}
 }
 
-func (f *fakeStorage) WithContext(ctx context.Context) abstractions.Storage {
+func (f *fakeStorage) WithContext(ctx context.Context) *fakeStorage {
 	return &fakeStorage{
 		logger:            f.logger,
 		ctx:               ctx,
@@ -140,7 +264,7 @@ func (f *fakeStorage) WithContext(ctx context.Context) abstractions.Storage {
 	}
 }
 
-func (f *fakeStorage) WithTenant(tenant api.Tenant) abstractions.Storage {
+func (f *fakeStorage) WithTenant(tenant api.Tenant) *fakeStorage {
 	return &fakeStorage{
 		logger:            f.logger,
 		ctx:               f.ctx,
@@ -153,7 +277,7 @@ func (f *fakeStorage) WithTenant(tenant api.Tenant) abstractions.Storage {
 	}
 }
 
-func (f *fakeStorage) WithOwner(owner api.User) abstractions.Storage {
+func (f *fakeStorage) WithOwner(owner api.User) *fakeStorage {
 	return &fakeStorage{
 		logger:            f.logger,
 		ctx:               f.ctx,
@@ -189,7 +313,7 @@ func TestCreateBenchmarkResourcesSetsConfigMapOwner(t *testing.T) {
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -234,7 +358,7 @@ func TestCreateBenchmarkResourcesSetsAnnotations(t *testing.T) {
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -430,7 +554,7 @@ func TestCreateBenchmarkResourcesAddsInitContainerForS3TestData(t *testing.T) {
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -524,7 +648,7 @@ func TestCreateBenchmarkResourcesMountsPVCTestData(t *testing.T) {
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -597,7 +721,7 @@ func TestCreateBenchmarkResourcesMountsPVCTestDataNoSubPath(t *testing.T) {
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -620,6 +744,297 @@ func TestCreateBenchmarkResourcesMountsPVCTestDataNoSubPath(t *testing.T) {
 	t.Fatalf("expected adapter container to mount %s", testDataMountPath)
 }
 
+func TestCreateBenchmarkResourcesProvisionsDataVolume(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	providers := sampleProviders(providerID)
+	providers[providerID].Runtime.K8s.DataVolume = &api.DataVolumeConfig{
+		Size:         "200Gi",
+		StorageClass: "gp3",
+	}
+
+	clientset := fake.NewClientset()
+	runtime := &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{clientset: clientset},
+		serviceConfig: &config.Config{
+			Service: &config.ServiceConfig{},
+		},
+	}
+
+	storage := &fakeStorage{providerConfigs: providers}
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	jobs := listJobsByJobID(t, clientset, evaluation.Resource.ID)
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+	job := jobs[0]
+
+	claims, err := clientset.CoreV1().PersistentVolumeClaims(job.Namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list claims: %v", err)
+	}
+	if len(claims.Items) != 1 {
+		t.Fatalf("expected 1 data volume claim, got %d", len(claims.Items))
+	}
+	claim := claims.Items[0]
+	if size := claim.Spec.Resources.Requests[corev1.ResourceStorage]; size.String() != "200Gi" {
+		t.Fatalf("expected storage request 200Gi, got %s", size.String())
+	}
+	if claim.Spec.StorageClassName == nil || *claim.Spec.StorageClassName != "gp3" {
+		t.Fatalf("expected storage class gp3, got %v", claim.Spec.StorageClassName)
+	}
+	if len(claim.Spec.AccessModes) != 1 || claim.Spec.AccessModes[0] != corev1.ReadWriteOnce {
+		t.Fatalf("expected ReadWriteOnce access mode, got %v", claim.Spec.AccessModes)
+	}
+	if claim.Labels[labelJobIDKey] != sanitizeLabelValue(evaluation.Resource.ID) {
+		t.Fatalf("expected claim to carry the job label, got %v", claim.Labels)
+	}
+	if len(claim.OwnerReferences) != 1 || claim.OwnerReferences[0].Kind != "Job" || claim.OwnerReferences[0].Name != job.Name {
+		t.Fatalf("expected claim to be owned by job %s, got %+v", job.Name, claim.OwnerReferences)
+	}
+
+	var foundDataVolume bool
+	for _, volume := range job.Spec.Template.Spec.Volumes {
+		if volume.Name != dataVolumeName {
+			continue
+		}
+		if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ClaimName != claim.Name {
+			t.Fatalf("expected data volume backed by claim %s, got %+v", claim.Name, volume.VolumeSource)
+		}
+		foundDataVolume = true
+	}
+	if !foundDataVolume {
+		t.Fatalf("expected volume %q in pod spec", dataVolumeName)
+	}
+}
+
+func TestCreateBenchmarkResourcesMountsExistingDataVolume(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	providers := sampleProviders(providerID)
+	providers[providerID].Runtime.K8s.DataVolume = &api.DataVolumeConfig{ClaimName: "shared-scratch"}
+
+	clientset := fake.NewClientset()
+	runtime := &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{clientset: clientset},
+		serviceConfig: &config.Config{
+			Service: &config.ServiceConfig{},
+		},
+	}
+
+	storage := &fakeStorage{providerConfigs: providers}
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	jobs := listJobsByJobID(t, clientset, evaluation.Resource.ID)
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+	claims, err := clientset.CoreV1().PersistentVolumeClaims(jobs[0].Namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list claims: %v", err)
+	}
+	if len(claims.Items) != 0 {
+		t.Fatalf("expected no claim to be provisioned for an existing claim, got %d", len(claims.Items))
+	}
+	for _, volume := range jobs[0].Spec.Template.Spec.Volumes {
+		if volume.Name == dataVolumeName {
+			if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ClaimName != "shared-scratch" {
+				t.Fatalf("expected data volume backed by claim shared-scratch, got %+v", volume.VolumeSource)
+			}
+			return
+		}
+	}
+	t.Fatalf("expected volume %q in pod spec", dataVolumeName)
+}
+
+func TestCreateBenchmarkResourcesRunsOnSpotNodePool(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	evaluation.Spot = true
+	providers := sampleProviders(providerID)
+	providers[providerID].Runtime.K8s.DataVolume = &api.DataVolumeConfig{Size: "50Gi"}
+	providers[providerID].Runtime.K8s.Spot = &api.SpotConfig{
+		NodeSelector: map[string]string{"cloud.google.com/gke-spot": "true"},
+		Tolerations:  []api.Toleration{{Key: "cloud.google.com/gke-spot", Operator: "Equal", Value: "true", Effect: "NoSchedule"}},
+		Checkpoint:   true,
+	}
+
+	clientset := fake.NewClientset()
+	runtime := &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{clientset: clientset},
+		serviceConfig: &config.Config{
+			Service: &config.ServiceConfig{},
+		},
+	}
+	run := func() (*batchv1.Job, *shared.JobSpecCheckpoint) {
+		t.Helper()
+		storage := &fakeStorage{providerConfigs: providers}
+		if err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		jobs := listJobsByJobID(t, clientset, evaluation.Resource.ID)
+		configMaps := listConfigMapsByJobID(t, clientset, evaluation.Resource.ID)
+		if len(jobs) != 1 || len(configMaps) != 1 {
+			t.Fatalf("expected 1 job and 1 configmap, got %d and %d", len(jobs), len(configMaps))
+		}
+		spec := shared.JobSpec{}
+		if err := json.Unmarshal([]byte(configMaps[0].Data[jobSpecFileName]), &spec); err != nil {
+			t.Fatalf("decode job spec: %v", err)
+		}
+		// the Job of the run goes, e.g. when its node is reclaimed and its TTL expires
+		ctx := context.Background()
+		if err := clientset.BatchV1().Jobs(jobs[0].Namespace).Delete(ctx, jobs[0].Name, metav1.DeleteOptions{}); err != nil {
+			t.Fatalf("delete job: %v", err)
+		}
+		if err := clientset.CoreV1().ConfigMaps(configMaps[0].Namespace).Delete(ctx, configMaps[0].Name, metav1.DeleteOptions{}); err != nil {
+			t.Fatalf("delete configmap: %v", err)
+		}
+		return &jobs[0], spec.Checkpoint
+	}
+
+	job, checkpoint := run()
+	podSpec := job.Spec.Template.Spec
+	if podSpec.NodeSelector["cloud.google.com/gke-spot"] != "true" {
+		t.Errorf("expected the spot node selector, got %v", podSpec.NodeSelector)
+	}
+	if len(podSpec.Tolerations) != 1 || podSpec.Tolerations[0].Key != "cloud.google.com/gke-spot" || podSpec.Tolerations[0].Effect != corev1.TaintEffectNoSchedule {
+		t.Errorf("expected the spot toleration, got %+v", podSpec.Tolerations)
+	}
+	if checkpoint == nil || checkpoint.Path != "/data/checkpoints/job-1/benchmark-0" || checkpoint.Resume {
+		t.Fatalf("expected a checkpoint without resume, got %+v", checkpoint)
+	}
+	claims, err := clientset.CoreV1().PersistentVolumeClaims(job.Namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list claims: %v", err)
+	}
+	if len(claims.Items) != 1 || len(claims.Items[0].OwnerReferences) != 0 {
+		t.Fatalf("expected 1 data volume claim that outlives the job, got %+v", claims.Items)
+	}
+	claimName := claims.Items[0].Name
+
+	// the retry after the interruption mounts the same claim and resumes
+	evaluation.Status = &api.EvaluationJobStatus{Benchmarks: []api.BenchmarkStatus{{
+		ProviderID: providerID,
+		ID:         "bench-1",
+		Status:     api.StatePending,
+		Attempts:   []api.BenchmarkAttempt{{Attempt: 1, ErrorMessage: &api.MessageInfo{MessageCode: constants.MESSAGE_CODE_POD_EVICTED}}},
+	}}}
+	job, checkpoint = run()
+	if checkpoint == nil || checkpoint.Path != "/data/checkpoints/job-1/benchmark-0" || !checkpoint.Resume {
+		t.Fatalf("expected the checkpoint to be resumed, got %+v", checkpoint)
+	}
+	for _, volume := range job.Spec.Template.Spec.Volumes {
+		if volume.Name == dataVolumeName && (volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ClaimName != claimName) {
+			t.Errorf("expected the retry to mount claim %s, got %+v", claimName, volume.VolumeSource)
+		}
+	}
+
+	// a job that does not ask for spot runs on the regular nodes
+	evaluation.Spot = false
+	evaluation.Status = nil
+	job, checkpoint = run()
+	if len(job.Spec.Template.Spec.NodeSelector) != 0 || len(job.Spec.Template.Spec.Tolerations) != 0 || checkpoint != nil {
+		t.Errorf("expected no spot scheduling nor checkpoint, got %v, %+v and %+v", job.Spec.Template.Spec.NodeSelector, job.Spec.Template.Spec.Tolerations, checkpoint)
+	}
+}
+
+func TestCreateBenchmarkResourcesResolvesSecretRefParameters(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	evaluation.Benchmarks[0].Parameters["judge"] = map[string]any{"api_key": "secretRef://judge-credentials/api-key"}
+
+	clientset := fake.NewClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "judge-credentials", Namespace: getTestNamespace(t)},
+		Data:       map[string][]byte{"api-key": []byte("sk-judge")},
+	})
+	runtime := &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{clientset: clientset},
+		serviceConfig: &config.Config{
+			Service: &config.ServiceConfig{},
+		},
+	}
+
+	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	configMaps := listConfigMapsByJobID(t, clientset, evaluation.Resource.ID)
+	if len(configMaps) != 1 {
+		t.Fatalf("expected 1 configmap, got %d", len(configMaps))
+	}
+	if spec := configMaps[0].Data[jobSpecFileName]; strings.Contains(spec, "sk-judge") || !strings.Contains(spec, "secretRef://judge-credentials/api-key") {
+		t.Fatalf("expected the configmap to keep the secret reference, got %s", spec)
+	}
+
+	jobs := listJobsByJobID(t, clientset, evaluation.Resource.ID)
+	if len(jobs) != 1 {
+		t.Fatalf("expected 1 job, got %d", len(jobs))
+	}
+	secrets, err := clientset.CoreV1().Secrets(getTestNamespace(t)).List(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", labelJobIDKey, sanitizeLabelValue(evaluation.Resource.ID)),
+	})
+	if err != nil {
+		t.Fatalf("failed to list secrets: %v", err)
+	}
+	if len(secrets.Items) != 1 || !strings.HasSuffix(secrets.Items[0].Name, parameterSecretSuffix) {
+		t.Fatalf("expected 1 parameter secret, got %+v", secrets.Items)
+	}
+	secret, secretName := secrets.Items[0], secrets.Items[0].Name
+	if !strings.Contains(string(secret.Data[jobSpecFileName]), `"api_key": "sk-judge"`) {
+		t.Fatalf("expected the resolved job spec in the parameter secret, got %s", secret.Data[jobSpecFileName])
+	}
+	if len(secret.OwnerReferences) != 1 || secret.OwnerReferences[0].Name != jobs[0].Name {
+		t.Fatalf("expected the parameter secret to be owned by the job, got %+v", secret.OwnerReferences)
+	}
+
+	adapterMount := jobs[0].Spec.Template.Spec.Containers[0].VolumeMounts[0]
+	if adapterMount.Name != resolvedJobSpecVolumeName || adapterMount.MountPath != jobSpecMountPath {
+		t.Fatalf("expected the adapter to mount the resolved job spec, got %+v", adapterMount)
+	}
+	for _, volume := range jobs[0].Spec.Template.Spec.Volumes {
+		if volume.Name == resolvedJobSpecVolumeName && (volume.Secret == nil || volume.Secret.SecretName != secretName) {
+			t.Fatalf("expected the resolved job spec volume to be backed by %s, got %+v", secretName, volume.VolumeSource)
+		}
+	}
+}
+
+func TestCreateBenchmarkResourcesFailsOnMissingParameterSecret(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	evaluation.Benchmarks[0].Parameters["api_key"] = "secretRef://missing/api-key"
+
+	clientset := fake.NewClientset()
+	runtime := &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{clientset: clientset},
+		serviceConfig: &config.Config{
+			Service: &config.ServiceConfig{},
+		},
+	}
+
+	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
+	if err == nil || !strings.Contains(err.Error(), `"missing"`) {
+		t.Fatalf("expected an error naming the missing secret, got %v", err)
+	}
+	if configMaps := listConfigMapsByJobID(t, clientset, evaluation.Resource.ID); len(configMaps) != 0 {
+		t.Fatalf("expected no configmap to be created, got %d", len(configMaps))
+	}
+}
+
 func TestCreateBenchmarkResourcesDeletesConfigMapOnJobFailure(t *testing.T) {
 	providerID := "provider-1"
 	evaluation := sampleEvaluation(providerID)
@@ -640,7 +1055,7 @@ func TestCreateBenchmarkResourcesDeletesConfigMapOnJobFailure(t *testing.T) {
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 	if err == nil {
 		t.Fatalf("expected error, got nil")
 	}
@@ -689,7 +1104,7 @@ func TestCreateBenchmarkResourcesAppliesHardwareProfile(t *testing.T) {
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 	if err != nil {
 		t.Fatalf("createBenchmarkResources returned error: %v", err)
 	}
@@ -741,7 +1156,7 @@ func TestCreateBenchmarkResourcesHardwareProfileUsesExplicitNamespace(t *testing
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	if err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage); err != nil {
+	if err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage); err != nil {
 		t.Fatalf("createBenchmarkResources returned error: %v", err)
 	}
 }
@@ -766,7 +1181,7 @@ func TestCreateBenchmarkResourcesHardwareProfileNotFound(t *testing.T) {
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 	if err == nil {
 		t.Fatal("expected error when hardware profile is missing")
 	}
@@ -806,7 +1221,7 @@ func TestCreateBenchmarkResourcesInvalidHardwareProfileSpec(t *testing.T) {
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 	if err == nil {
 		t.Fatal("expected error when hardware profile spec is invalid")
 	}
@@ -835,7 +1250,7 @@ func TestCreateBenchmarkResourcesIgnoresEmptyHardwareProfileName(t *testing.T) {
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	if err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage); err != nil {
+	if err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage); err != nil {
 		t.Fatalf("createBenchmarkResources returned error: %v", err)
 	}
 }
@@ -872,7 +1287,7 @@ func TestRunEvaluationJobMarksBenchmarkFailedOnCreateError(t *testing.T) {
 
 	statusCh := make(chan *api.StatusEvent, 1)
 	storage := &fakeStorage{logger: logger, ctx: context.Background(), runStatusChan: statusCh, providerConfigs: sampleProviders(providerID)}
-	var store abstractions.Storage = storage
+	var store abstractions.RuntimeStorage = storage
 
 	benchmarks, err := handlers.GetJobBenchmarks(evaluation, nil)
 	if err != nil {
@@ -937,7 +1352,7 @@ func TestRunEvaluationJobHandlesUpdateFailure(t *testing.T) {
 		updateErr:       fmt.Errorf("update failed"),
 		providerConfigs: sampleProviders(providerID),
 	}
-	var store abstractions.Storage = storage
+	var store abstractions.RuntimeStorage = storage
 
 	benchmarks, err := handlers.GetJobBenchmarks(evaluation, nil)
 	if err != nil {
@@ -1011,7 +1426,7 @@ func TestCreateBenchmarkResourcesDeletesOrphanedJobWhenConfigMapDeletedMidCreati
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -1046,7 +1461,7 @@ func TestCreateBenchmarkResourcesReturnsErrorWhenOrphanedJobDeletionFails(t *tes
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 	if err == nil {
 		t.Fatal("expected error when orphaned job deletion fails, got nil")
 	}
@@ -1124,6 +1539,61 @@ func TestDeleteEvaluationJobResourcesIgnoresMissingRefSecret(t *testing.T) {
 	}
 }
 
+func TestDeleteEvaluationJobResourcesHonoursDataVolumeCleanup(t *testing.T) {
+	evaluation := sampleEvaluation("provider-1")
+	namespace := "default"
+	labels := map[string]string{labelJobIDKey: sanitizeLabelValue(evaluation.Resource.ID)}
+
+	clientset := fake.NewClientset(
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "eval-data-delete",
+				Namespace:   namespace,
+				Labels:      labels,
+				Annotations: map[string]string{annotationDataVolumeCleanupKey: api.DataVolumeCleanupDelete},
+			},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "eval-data-retain",
+				Namespace:   namespace,
+				Labels:      labels,
+				Annotations: map[string]string{annotationDataVolumeCleanupKey: api.DataVolumeCleanupRetain},
+			},
+		},
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shared-scratch",
+				Namespace: namespace,
+			},
+		},
+	)
+	runtime := &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{clientset: clientset},
+		ctx:    context.Background(),
+	}
+
+	if err := runtime.DeleteEvaluationJobResources(evaluation); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	claims, err := clientset.CoreV1().PersistentVolumeClaims(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list claims: %v", err)
+	}
+	remaining := map[string]bool{}
+	for _, claim := range claims.Items {
+		remaining[claim.Name] = true
+	}
+	if remaining["eval-data-delete"] {
+		t.Fatal("expected the claim with cleanup delete to be deleted")
+	}
+	if !remaining["eval-data-retain"] || !remaining["shared-scratch"] {
+		t.Fatalf("expected the retained and the unlabelled claims to be kept, got %v", remaining)
+	}
+}
+
 // TestCreateBenchmarkResourcesDeletesRefSecretWhenConfigMapDeletedMidCreation verifies that
 // when the ConfigMap disappears between Job creation and owner-ref setup (race with hard_delete),
 // the ephemeral internalModelRef secret is cleaned up together with the orphaned Job.
@@ -1153,7 +1623,7 @@ func TestCreateBenchmarkResourcesDeletesRefSecretWhenConfigMapDeletedMidCreation
 	}
 
 	storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+	err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -1486,7 +1956,7 @@ func TestModelAuthCombinations(t *testing.T) {
 			}
 
 			storage := &fakeStorage{providerConfigs: sampleProviders(providerID)}
-			err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, storage)
+			err := runtime.createBenchmarkResources(context.Background(), runtime.logger, evaluation, &evaluation.Benchmarks[0], 0, nil, storage)
 			if tc.wantErr {
 				if err == nil {
 					t.Fatalf("expected an error, got nil")
@@ -0,0 +1,289 @@
+package k8s
+
+// Pre-pull of provider adapter images with a DaemonSet per provider.
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	warmupComponentValue     = "image-warmup"
+	warmupNamePrefix         = "evalhub-image-warmup-"
+	warmupPullContainerName  = "pull"
+	warmupPauseContainerName = "pause"
+	warmupCPURequest         = "10m"
+	warmupMemoryRequest      = "16Mi"
+	warmupCPULimit           = "100m"
+	warmupMemoryLimit        = "64Mi"
+	annotationWarmupSpecKey  = "eval-hub.github.io/image_warmup_spec"
+	annotationWarmupImageKey = "eval-hub.github.io/image"
+)
+
+// imagePullFailureReasons are the waiting reasons of a container whose image cannot be pulled.
+var imagePullFailureReasons = []string{"ErrImagePull", "ImagePullBackOff", "InvalidImageName", "ErrImageNeverPull"}
+
+func (r *K8sRuntime) warmupConfig() *config.ImageWarmupConfig {
+	if r.serviceConfig == nil {
+		return nil
+	}
+	return r.serviceConfig.ImageWarmup
+}
+
+func (r *K8sRuntime) warmupNamespace() string {
+	namespace := ""
+	if cfg := r.warmupConfig(); cfg != nil {
+		namespace = cfg.Namespace
+	}
+	return resolveNamespace(namespace)
+}
+
+func warmupSelector() string {
+	return fmt.Sprintf("%s=%s,%s=%s", labelAppKey, labelAppValue, labelComponentKey, warmupComponentValue)
+}
+
+// WarmUpImages creates a DaemonSet per provider that pulls its image on the selected nodes,
+// updates the DaemonSets whose image or node selection changed and deletes the DaemonSets
+// of the providers that are no longer pre-pulled.
+func (r *K8sRuntime) WarmUpImages(ctx context.Context, images map[string]string) error {
+	namespace := r.warmupNamespace()
+	existing, err := r.helper.ListDaemonSets(ctx, namespace, warmupSelector())
+	if err != nil {
+		return fmt.Errorf("list image warm-up daemonsets: %w", err)
+	}
+	current := map[string]*appsv1.DaemonSet{}
+	for i := range existing {
+		current[existing[i].Name] = &existing[i]
+	}
+
+	var errs []error
+	wanted := map[string]bool{}
+	for _, providerID := range slices.Sorted(maps.Keys(images)) {
+		daemonSet, err := buildWarmupDaemonSet(r.warmupConfig(), namespace, providerID, images[providerID])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		wanted[daemonSet.Name] = true
+		logger := r.logger.With("provider_id", providerID, "image", images[providerID], "daemonset", daemonSet.Name)
+
+		found, ok := current[daemonSet.Name]
+		switch {
+		case !ok:
+			if _, err := r.helper.CreateDaemonSet(ctx, daemonSet); err != nil {
+				errs = append(errs, fmt.Errorf("create image warm-up daemonset %s: %w", daemonSet.Name, err))
+				continue
+			}
+			logger.Info("Started image warm-up")
+		case found.Annotations[annotationWarmupSpecKey] != daemonSet.Annotations[annotationWarmupSpecKey]:
+			found.Labels = daemonSet.Labels
+			found.Annotations = daemonSet.Annotations
+			found.Spec.Template = daemonSet.Spec.Template
+			if _, err := r.helper.UpdateDaemonSet(ctx, found); err != nil {
+				errs = append(errs, fmt.Errorf("update image warm-up daemonset %s: %w", daemonSet.Name, err))
+				continue
+			}
+			logger.Info("Updated image warm-up")
+		}
+	}
+
+	for name := range current {
+		if wanted[name] {
+			continue
+		}
+		if err := r.helper.DeleteDaemonSet(ctx, namespace, name); err != nil {
+			errs = append(errs, fmt.Errorf("delete image warm-up daemonset %s: %w", name, err))
+			continue
+		}
+		r.logger.Info("Stopped image warm-up", "daemonset", name)
+	}
+	return errors.Join(errs...)
+}
+
+// ImageWarmupStatus returns the pull status of the images of the warm-up DaemonSets. An
+// image is pulled on a node once the pull container of the node's pod has been created.
+func (r *K8sRuntime) ImageWarmupStatus(ctx context.Context) (map[string]api.ProviderImageWarmup, error) {
+	namespace := r.warmupNamespace()
+	daemonSets, err := r.helper.ListDaemonSets(ctx, namespace, warmupSelector())
+	if err != nil {
+		return nil, fmt.Errorf("list image warm-up daemonsets: %w", err)
+	}
+	pods, err := r.helper.ListPods(ctx, namespace, warmupSelector())
+	if err != nil {
+		return nil, fmt.Errorf("list image warm-up pods: %w", err)
+	}
+
+	status := map[string]api.ProviderImageWarmup{}
+	for i := range daemonSets {
+		daemonSet := &daemonSets[i]
+		providerID := daemonSet.Annotations[annotationProviderIDKey]
+		if providerID == "" {
+			continue
+		}
+		status[providerID] = warmupStatus(daemonSet, pods)
+	}
+	return status, nil
+}
+
+func warmupStatus(daemonSet *appsv1.DaemonSet, pods []corev1.Pod) api.ProviderImageWarmup {
+	warmup := api.ProviderImageWarmup{
+		Image: daemonSet.Annotations[annotationWarmupImageKey],
+		Nodes: int(daemonSet.Status.DesiredNumberScheduled),
+	}
+	var failures []string
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Labels[labelProviderIDKey] != daemonSet.Spec.Selector.MatchLabels[labelProviderIDKey] {
+			continue
+		}
+		pulled, failure := podImagePulled(pod)
+		switch {
+		case pulled:
+			warmup.Pulled++
+		case failure != "":
+			warmup.Failed++
+			if !slices.Contains(failures, failure) {
+				failures = append(failures, failure)
+			}
+		}
+	}
+
+	switch {
+	case warmup.Failed > 0:
+		warmup.State = api.ImageWarmupStateFailed
+		warmup.Message = fmt.Sprintf("failed to pull the image on %d node(s): %s", warmup.Failed, strings.Join(failures, "; "))
+	case warmup.Nodes == 0:
+		warmup.State = api.ImageWarmupStatePending
+		warmup.Message = "no node matches the warm-up node selector"
+	case warmup.Pulled >= warmup.Nodes:
+		warmup.State = api.ImageWarmupStateReady
+	case warmup.Pulled == 0:
+		warmup.State = api.ImageWarmupStatePending
+	default:
+		warmup.State = api.ImageWarmupStatePulling
+	}
+	return warmup
+}
+
+// podImagePulled returns true when the image of the pull container of the pod is on its
+// node, and the reason of the failure when the image cannot be pulled.
+func podImagePulled(pod *corev1.Pod) (bool, string) {
+	for _, status := range pod.Status.InitContainerStatuses {
+		if status.Name != warmupPullContainerName {
+			continue
+		}
+		if status.ImageID != "" || status.State.Running != nil || status.State.Terminated != nil {
+			return true, ""
+		}
+		if waiting := status.State.Waiting; waiting != nil && slices.Contains(imagePullFailureReasons, waiting.Reason) {
+			if waiting.Message != "" {
+				return false, waiting.Message
+			}
+			return false, waiting.Reason
+		}
+	}
+	return false, ""
+}
+
+// buildWarmupDaemonSet returns the DaemonSet that pulls the image of the provider on the
+// selected nodes: the image is pulled by an init container that exits at once, after which
+// the pod idles in a pause container so that it holds no more than a few megabytes.
+func buildWarmupDaemonSet(cfg *config.ImageWarmupConfig, namespace, providerID, image string) (*appsv1.DaemonSet, error) {
+	name := sanitizeDNS1123Label(warmupNamePrefix + providerID)
+	if len(name) > maxK8sNameLength {
+		name = strings.TrimRight(name[:maxK8sNameLength], "-")
+	}
+	labels := map[string]string{
+		labelAppKey:        labelAppValue,
+		labelComponentKey:  warmupComponentValue,
+		labelProviderIDKey: sanitizeLabelValue(providerID),
+	}
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(warmupCPURequest),
+			corev1.ResourceMemory: resource.MustParse(warmupMemoryRequest),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse(warmupCPULimit),
+			corev1.ResourceMemory: resource.MustParse(warmupMemoryLimit),
+		},
+	}
+	podSpec := corev1.PodSpec{
+		InitContainers: []corev1.Container{
+			{
+				Name:            warmupPullContainerName,
+				Image:           image,
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Command:         []string{"sh", "-c", "exit 0"},
+				Resources:       resources,
+				SecurityContext: defaultSecurityContext(),
+			},
+		},
+		Containers: []corev1.Container{
+			{
+				Name:            warmupPauseContainerName,
+				Image:           cfg.EffectivePauseImage(),
+				ImagePullPolicy: corev1.PullIfNotPresent,
+				Resources:       resources,
+				SecurityContext: defaultSecurityContext(),
+			},
+		},
+		AutomountServiceAccountToken: boolPtr(false),
+	}
+	if cfg != nil {
+		podSpec.NodeSelector = cfg.NodeSelector
+		for _, toleration := range cfg.Tolerations {
+			podSpec.Tolerations = append(podSpec.Tolerations, corev1.Toleration{
+				Key:      toleration.Key,
+				Operator: corev1.TolerationOperator(toleration.Operator),
+				Value:    toleration.Value,
+				Effect:   corev1.TaintEffect(toleration.Effect),
+			})
+		}
+	}
+
+	// the hash of the pod spec tells when a DaemonSet has to be updated, the server adds
+	// defaults to the spec that it returns
+	spec, err := json.Marshal(podSpec)
+	if err != nil {
+		return nil, fmt.Errorf("marshal image warm-up pod spec for provider %s: %w", providerID, err)
+	}
+	hash := sha256.Sum256(spec)
+	annotations := map[string]string{
+		annotationProviderIDKey:  providerID,
+		annotationWarmupImageKey: image,
+		annotationWarmupSpecKey:  hex.EncodeToString(hash[:]),
+	}
+
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels:      labels,
+					Annotations: map[string]string{annotationProviderIDKey: providerID},
+				},
+				Spec: podSpec,
+			},
+		},
+	}, nil
+}
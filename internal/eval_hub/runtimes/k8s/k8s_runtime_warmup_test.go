@@ -0,0 +1,146 @@
+package k8s
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newWarmupRuntime(clientset *fake.Clientset, cfg *config.ImageWarmupConfig) *K8sRuntime {
+	return &K8sRuntime{
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		serviceConfig: &config.Config{ImageWarmup: cfg},
+		helper:        &KubernetesHelper{clientset: clientset},
+		ctx:           context.Background(),
+	}
+}
+
+func TestWarmUpImages(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewClientset()
+	cfg := &config.ImageWarmupConfig{
+		Enabled:      true,
+		Namespace:    "evalhub",
+		NodeSelector: map[string]string{"nvidia.com/gpu.present": "true"},
+		Tolerations:  []config.Toleration{{Key: "nvidia.com/gpu", Operator: "Exists", Effect: "NoSchedule"}},
+	}
+	runtime := newWarmupRuntime(clientset, cfg)
+
+	if err := runtime.WarmUpImages(ctx, map[string]string{"lm_eval": "quay.io/eval-hub/lm-eval:1.0", "garak": "quay.io/eval-hub/garak:1.0"}); err != nil {
+		t.Fatalf("WarmUpImages: %v", err)
+	}
+	daemonSets, err := clientset.AppsV1().DaemonSets("evalhub").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(daemonSets.Items) != 2 {
+		t.Fatalf("expected 2 daemonsets, got %d", len(daemonSets.Items))
+	}
+	daemonSet, err := clientset.AppsV1().DaemonSets("evalhub").Get(ctx, "evalhub-image-warmup-lm-eval", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the daemonset of lm_eval: %v", err)
+	}
+	pod := daemonSet.Spec.Template.Spec
+	if len(pod.InitContainers) != 1 || pod.InitContainers[0].Image != "quay.io/eval-hub/lm-eval:1.0" {
+		t.Errorf("expected the provider image in the pull container, got %+v", pod.InitContainers)
+	}
+	if len(pod.Containers) != 1 || pod.Containers[0].Image != config.DefaultImageWarmupPauseImage {
+		t.Errorf("expected the pause image in the main container, got %+v", pod.Containers)
+	}
+	if pod.NodeSelector["nvidia.com/gpu.present"] != "true" {
+		t.Errorf("expected the node selector, got %v", pod.NodeSelector)
+	}
+	if len(pod.Tolerations) != 1 || pod.Tolerations[0].Operator != corev1.TolerationOpExists || pod.Tolerations[0].Effect != corev1.TaintEffectNoSchedule {
+		t.Errorf("expected the toleration, got %+v", pod.Tolerations)
+	}
+
+	// a new image updates the daemonset, a provider that is no longer warmed up is removed
+	if err := runtime.WarmUpImages(ctx, map[string]string{"lm_eval": "quay.io/eval-hub/lm-eval:2.0"}); err != nil {
+		t.Fatalf("WarmUpImages: %v", err)
+	}
+	daemonSets, err = clientset.AppsV1().DaemonSets("evalhub").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(daemonSets.Items) != 1 || daemonSets.Items[0].Spec.Template.Spec.InitContainers[0].Image != "quay.io/eval-hub/lm-eval:2.0" {
+		t.Fatalf("expected the updated daemonset of lm_eval only, got %+v", daemonSets.Items)
+	}
+}
+
+func TestImageWarmupStatus(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewClientset()
+	runtime := newWarmupRuntime(clientset, &config.ImageWarmupConfig{Enabled: true, Namespace: "evalhub"})
+	images := map[string]string{"lm_eval": "quay.io/eval-hub/lm-eval:1.0", "garak": "quay.io/eval-hub/garak:1.0", "ragas": "quay.io/eval-hub/ragas:1.0"}
+	if err := runtime.WarmUpImages(ctx, images); err != nil {
+		t.Fatalf("WarmUpImages: %v", err)
+	}
+
+	setNodes := func(providerID string, nodes int32) {
+		t.Helper()
+		name := sanitizeDNS1123Label(warmupNamePrefix + providerID)
+		daemonSet, err := clientset.AppsV1().DaemonSets("evalhub").Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		daemonSet.Status.DesiredNumberScheduled = nodes
+		if _, err := clientset.AppsV1().DaemonSets("evalhub").UpdateStatus(ctx, daemonSet, metav1.UpdateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	addPod := func(name string, providerID string, status corev1.ContainerStatus) {
+		t.Helper()
+		status.Name = warmupPullContainerName
+		_, err := clientset.CoreV1().Pods("evalhub").Create(ctx, &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "evalhub",
+				Labels: map[string]string{
+					labelAppKey:        labelAppValue,
+					labelComponentKey:  warmupComponentValue,
+					labelProviderIDKey: sanitizeLabelValue(providerID),
+				},
+			},
+			Status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{status}},
+		}, metav1.CreateOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	setNodes("lm_eval", 2)
+	addPod("lm-eval-1", "lm_eval", corev1.ContainerStatus{ImageID: "sha256:abc", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}})
+	addPod("lm-eval-2", "lm_eval", corev1.ContainerStatus{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}}})
+	setNodes("garak", 1)
+	addPod("garak-1", "garak", corev1.ContainerStatus{State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "manifest unknown"}}})
+
+	status, err := runtime.ImageWarmupStatus(ctx)
+	if err != nil {
+		t.Fatalf("ImageWarmupStatus: %v", err)
+	}
+	if got := status["lm_eval"]; got.State != api.ImageWarmupStatePulling || got.Nodes != 2 || got.Pulled != 1 || got.Image != images["lm_eval"] {
+		t.Errorf("expected lm_eval to be pulling on 1 of 2 nodes, got %+v", got)
+	}
+	if got := status["garak"]; got.State != api.ImageWarmupStateFailed || got.Failed != 1 || got.Message == "" {
+		t.Errorf("expected garak to have failed, got %+v", got)
+	}
+	if got := status["ragas"]; got.State != api.ImageWarmupStatePending || got.Nodes != 0 {
+		t.Errorf("expected ragas to be pending without nodes, got %+v", got)
+	}
+
+	addPod("lm-eval-3", "lm_eval", corev1.ContainerStatus{ImageID: "sha256:abc"})
+	status, err = runtime.ImageWarmupStatus(ctx)
+	if err != nil {
+		t.Fatalf("ImageWarmupStatus: %v", err)
+	}
+	if got := status["lm_eval"]; got.State != api.ImageWarmupStateReady {
+		t.Errorf("expected lm_eval to be ready, got %+v", got)
+	}
+}
@@ -0,0 +1,77 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/modelinfo"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// captureModelMetadata stores the metadata of the InferenceService model of the job in its
+// results: the metadata declared by the annotations of the InferenceService, completed by what
+// its server reports. A model without metadata does not fail the job.
+func (r *K8sRuntime) captureModelMetadata(evaluation *api.EvaluationJobResource, storage abstractions.RuntimeStorage) {
+	ref := evaluation.Model.InferenceService
+	if ref == nil || storage == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), modelinfo.Timeout)
+	defer cancel()
+	logger := r.logger.With("job_id", evaluation.Resource.ID, "inference_service", ref.Name)
+
+	namespace := resolveInferenceServiceNamespace(ref, string(evaluation.Resource.Tenant))
+	isvc, err := r.helper.GetInferenceService(ctx, namespace, ref.Name)
+	if err != nil {
+		logger.Info("model metadata not captured", "reason", err.Error())
+		return
+	}
+	declared := modelinfo.FromAnnotations(isvc.GetAnnotations())
+
+	var reported *api.ModelMetadata
+	if modelURL, err := parseInferenceService(isvc); err != nil {
+		logger.Info("model metadata not read from the inference service", "reason", err.Error())
+	} else if token, err := r.inferenceServiceToken(ctx, resolveNamespace(string(evaluation.Resource.Tenant)), inferenceServiceAuthTokenSecret(evaluation.Model)); err != nil {
+		logger.Info("model metadata not read from the inference service", "reason", err.Error())
+	} else {
+		reported, err = modelinfo.Fetch(ctx, r.modelClient(), modelURL, evaluation.Model.Name, token)
+		if err != nil {
+			logger.Info("model metadata not read from the inference service", "reason", err.Error())
+		}
+	}
+
+	metadata := modelinfo.Merge(reported, declared)
+	if metadata == nil {
+		return
+	}
+	if err := storage.UpdateEvaluationJobModelMetadata(evaluation.Resource.ID, metadata); err != nil {
+		logger.Warn("failed to store the model metadata", "error", err)
+	}
+}
+
+// inferenceServiceToken returns the serving auth token of the secret in the namespace of the
+// job, empty without a secret.
+func (r *K8sRuntime) inferenceServiceToken(ctx context.Context, namespace, secretName string) (string, error) {
+	if secretName == "" {
+		return "", nil
+	}
+	secret, err := r.helper.GetSecret(ctx, namespace, secretName)
+	if err != nil {
+		return "", fmt.Errorf("read auth token secret %q: %w", secretName, err)
+	}
+	return strings.TrimSpace(string(secret.Data[inferenceServiceTokenKey])), nil
+}
+
+func (r *K8sRuntime) modelClient() *http.Client {
+	var proxyConfig *config.ProxyConfig
+	if r.serviceConfig != nil {
+		proxyConfig = r.serviceConfig.Proxy
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxyConfig.ProxyFunc(config.ProxyDestinationModel)
+	return &http.Client{Transport: transport, Timeout: modelinfo.Timeout}
+}
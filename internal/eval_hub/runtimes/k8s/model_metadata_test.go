@@ -0,0 +1,83 @@
+package k8s
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/modelinfo"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// modelMetadataStorage records the model metadata stored by the runtime.
+type modelMetadataStorage struct {
+	*fakeStorage
+	metadata *api.ModelMetadata
+}
+
+func (s *modelMetadataStorage) UpdateEvaluationJobModelMetadata(_ string, metadata *api.ModelMetadata) error {
+	s.metadata = metadata
+	return nil
+}
+
+func TestCaptureModelMetadataOfInferenceService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer isvc-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Path != "/v1/models" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[{"id":"granite","root":"/mnt/models","max_model_len":8192}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	isvc := testInferenceService(map[string]any{
+		"address":    map[string]any{"url": server.URL},
+		"conditions": []any{readyCondition("True")},
+	})
+	isvc.SetAPIVersion(inferenceServiceAPIGroup + "/" + inferenceServiceAPIVersion)
+	isvc.SetKind("InferenceService")
+	isvc.SetAnnotations(map[string]string{
+		modelinfo.AnnotationPrefix + "base-model":   "ibm-granite/granite-3.1-8b-instruct",
+		modelinfo.AnnotationPrefix + "quantization": "fp8",
+	})
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "granite-sa-token", Namespace: "tenant-a"},
+		Data:       map[string][]byte{inferenceServiceTokenKey: []byte("isvc-token\n")},
+	}
+	runtime := &K8sRuntime{
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+		helper: &KubernetesHelper{
+			clientset:     fake.NewClientset(secret),
+			dynamicClient: dynamicfake.NewSimpleDynamicClient(k8sruntime.NewScheme(), isvc),
+		},
+	}
+
+	evaluation := &api.EvaluationJobResource{}
+	evaluation.Resource.ID = "job-isvc"
+	evaluation.Resource.Tenant = "tenant-a"
+	evaluation.Model = api.ModelRef{Name: "granite", InferenceService: &api.InferenceServiceRef{
+		Name: "granite", Namespace: "models", AuthTokenSecret: "granite-sa-token",
+	}}
+	storage := &modelMetadataStorage{fakeStorage: &fakeStorage{}}
+	runtime.captureModelMetadata(evaluation, storage)
+
+	got := storage.metadata
+	if got == nil {
+		t.Fatal("expected the model metadata to be stored")
+	}
+	// the root of the server is a path, the base model is the one of the annotations
+	if got.ServedModelID != "granite" || got.MaxContextLength != 8192 || got.Quantization != "fp8" || got.BaseModel != "ibm-granite/granite-3.1-8b-instruct" {
+		t.Errorf("model metadata = %+v", got)
+	}
+}
@@ -0,0 +1,67 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// parameterSecretSuffix is the suffix of the secret holding the resolved job spec.
+	parameterSecretSuffix = "-params"
+	// resolvedJobSpecVolumeName is the volume of the adapter's job spec when the parameters
+	// reference secrets; the sidecar keeps mounting the job spec from the ConfigMap.
+	resolvedJobSpecVolumeName = "job-spec-resolved"
+)
+
+// buildParameterSecret returns the secret holding the job spec of the benchmark with the
+// secretRef:// parameters resolved from the secrets in the job namespace, or nil when the
+// parameters reference no secret. The ConfigMap keeps the references so that the values
+// are only readable by the adapter of the job.
+func buildParameterSecret(ctx context.Context, cfg *jobConfig, helper *KubernetesHelper) (*corev1.Secret, error) {
+	secrets := map[string]*corev1.Secret{}
+	parameters, err := api.ResolveSecretRefs(cfg.jobSpec.Parameters, func(ref api.SecretRef) (string, error) {
+		secret, ok := secrets[ref.Name]
+		if !ok {
+			var err error
+			secret, err = helper.GetSecret(ctx, cfg.namespace, ref.Name)
+			if err != nil {
+				return "", fmt.Errorf("get parameter secret %q: %w", ref.Name, err)
+			}
+			secrets[ref.Name] = secret
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return "", fmt.Errorf("parameter secret %q has no key %q", ref.Name, ref.Key)
+		}
+		return string(value), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resolve parameters: %w", err)
+	}
+	if len(secrets) == 0 {
+		return nil, nil
+	}
+
+	spec := cfg.jobSpec
+	spec.Parameters = parameters
+	specJSON, err := shared.MarshalJobSpec(&spec)
+	if err != nil {
+		return nil, err
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        buildK8sName(cfg.jobID, cfg.resourceGUID, parameterSecretSuffix),
+			Namespace:   cfg.namespace,
+			Labels:      jobLabels(cfg),
+			Annotations: jobAnnotations(cfg.jobID, cfg.providerID, cfg.benchmarkID),
+		},
+		Data: map[string][]byte{
+			jobSpecFileName: specJSON,
+		},
+	}, nil
+}
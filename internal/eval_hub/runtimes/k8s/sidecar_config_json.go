@@ -2,7 +2,9 @@ package k8s
 
 import (
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
 	"github.com/eval-hub/eval-hub/internal/otel"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // sidecarForJobPod builds sidecar_config.json for the job ConfigMap from server
@@ -33,6 +35,13 @@ func sidecarForJobPod(cfg *config.Config, jc *jobConfig) (*config.SidecarConfig,
 				export.EvalHub.InsecureSkipVerify = false
 			}
 		}
+		if jc.evalHubClientCertSecret != "" {
+			if export.EvalHub == nil {
+				export.EvalHub = &config.EvalHubClientConfig{}
+			}
+			export.EvalHub.ClientCertPath = evalHubClientTLSMountPath + "/" + corev1.TLSCertKey
+			export.EvalHub.ClientKeyPath = evalHubClientTLSMountPath + "/" + corev1.TLSPrivateKeyKey
+		}
 		if jc.mlflowTrackingURI != "" {
 			if export.MLFlow == nil {
 				export.MLFlow = &config.SidecarMLFlowConfig{}
@@ -59,13 +68,22 @@ func sidecarForJobPod(cfg *config.Config, jc *jobConfig) (*config.SidecarConfig,
 			if jc.modelAuthSecretRef != "" {
 				mc.AuthSecretMountPath = modelAuthMountPath
 			}
+			if jc.modelAuthTokenSecret != "" {
+				mc.AuthTokenPath = modelAuthTokenMountPath + "/" + inferenceServiceTokenKey
+			}
 			export.Model = mc
 		}
+		if jc.resultRelay {
+			export.ResultRelay = &config.SidecarResultRelayConfig{Path: shared.JobSpecResultsFile, JobID: jc.jobID}
+		}
 	}
 
 	if otelCfg := otelConfigForJobPod(cfg); otelCfg != nil {
 		export.OTEL = otelCfg
 	}
+	if proxyCfg := proxyConfigForJobPod(cfg); proxyCfg != nil {
+		export.Proxy = proxyCfg
+	}
 
 	return export, nil
 }
@@ -82,6 +100,31 @@ func otelConfigForJobPod(cfg *config.Config) *config.OTELConfig {
 	return &out
 }
 
+// proxyConfigForJobPod resolves the proxy settings of the destinations that the sidecar
+// connects to, including the proxy environment variables of eval-hub that the job pod does
+// not have. Returns nil when they are all reached directly.
+func proxyConfigForJobPod(cfg *config.Config) *config.ProxyConfig {
+	var proxyCfg *config.ProxyConfig
+	if cfg != nil {
+		proxyCfg = cfg.Proxy
+	}
+	out := &config.ProxyConfig{ProxySettings: config.ProxySettings{Direct: true}}
+	for _, destination := range []string{config.ProxyDestinationMLFlow, config.ProxyDestinationOCI, config.ProxyDestinationModel} {
+		settings := proxyCfg.Settings(destination)
+		if settings.IsDirect() {
+			continue
+		}
+		if out.Destinations == nil {
+			out.Destinations = map[string]config.ProxySettings{}
+		}
+		out.Destinations[destination] = settings
+	}
+	if out.Destinations == nil {
+		return nil
+	}
+	return out
+}
+
 func cloneSidecarConfig(sc *config.SidecarConfig) *config.SidecarConfig {
 	if sc == nil {
 		return nil
@@ -2,9 +2,11 @@ package k8s
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
 	"github.com/eval-hub/eval-hub/internal/otel"
 )
 
@@ -39,6 +41,31 @@ func TestOtelConfigForJobPod(t *testing.T) {
 	})
 }
 
+func TestSidecarForJobPodEvalHubClientCertificate(t *testing.T) {
+	cfg := &config.Config{
+		Sidecar: &config.SidecarConfig{
+			EvalHub: &config.EvalHubClientConfig{ClientCertSecret: "sidecar-client-tls"},
+		},
+	}
+	jc := &jobConfig{evalHubURL: "https://eval-hub:8443", evalHubClientCertSecret: "sidecar-client-tls"}
+
+	export, err := sidecarForJobPod(cfg, jc)
+	if err != nil {
+		t.Fatalf("sidecarForJobPod: %v", err)
+	}
+	if export.EvalHub.ClientCertPath != evalHubClientTLSMountPath+"/tls.crt" || export.EvalHub.ClientKeyPath != evalHubClientTLSMountPath+"/tls.key" {
+		t.Fatalf("client certificate = %q, %q, want the files of the mounted secret", export.EvalHub.ClientCertPath, export.EvalHub.ClientKeyPath)
+	}
+
+	data, err := json.Marshal(export)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if strings.Contains(string(data), "sidecar-client-tls") {
+		t.Fatalf("sidecar config must not carry the secret name: %s", data)
+	}
+}
+
 func TestSidecarForJobPodIncludesOTEL(t *testing.T) {
 	cfg := &config.Config{
 		OTEL: &config.OTELConfig{
@@ -70,3 +97,84 @@ func TestSidecarForJobPodIncludesOTEL(t *testing.T) {
 		t.Fatalf("invalid JSON: %s", data)
 	}
 }
+
+func TestSidecarForJobPodProxy(t *testing.T) {
+	for _, name := range []string{"HTTP_PROXY", "http_proxy", "HTTPS_PROXY", "https_proxy", "NO_PROXY", "no_proxy"} {
+		t.Setenv(name, "")
+	}
+	jc := &jobConfig{evalHubURL: "http://eval-hub:8080"}
+
+	export, err := sidecarForJobPod(&config.Config{Sidecar: &config.SidecarConfig{}}, jc)
+	if err != nil {
+		t.Fatalf("sidecarForJobPod: %v", err)
+	}
+	if export.Proxy != nil {
+		t.Fatalf("expected no proxy without proxy settings, got %+v", export.Proxy)
+	}
+
+	// the proxy environment of eval-hub is resolved, the job pod does not have it
+	t.Setenv("HTTPS_PROXY", "http://egress:3128")
+	cfg := &config.Config{
+		Sidecar: &config.SidecarConfig{},
+		Proxy: &config.ProxyConfig{
+			ProxySettings: config.ProxySettings{NoProxy: ".svc"},
+			Destinations:  map[string]config.ProxySettings{config.ProxyDestinationMLFlow: {Direct: true}},
+		},
+	}
+	export, err = sidecarForJobPod(cfg, jc)
+	if err != nil {
+		t.Fatalf("sidecarForJobPod: %v", err)
+	}
+	if export.Proxy == nil {
+		t.Fatal("expected proxy settings in sidecar export")
+	}
+	if _, found := export.Proxy.Destinations[config.ProxyDestinationMLFlow]; found {
+		t.Errorf("mlflow: expected no proxy, got %+v", export.Proxy.Destinations)
+	}
+	for _, destination := range []string{config.ProxyDestinationOCI, config.ProxyDestinationModel} {
+		settings := export.Proxy.Destinations[destination]
+		if settings.HTTPSProxy != "http://egress:3128" || settings.NoProxy != ".svc" {
+			t.Errorf("%s: got %+v, want the resolved proxy settings", destination, settings)
+		}
+	}
+
+	// the sidecar uses the exported settings without the environment of eval-hub
+	t.Setenv("HTTPS_PROXY", "")
+	if settings := export.Proxy.Settings(config.ProxyDestinationMLFlow); !settings.IsDirect() {
+		t.Errorf("mlflow: got %+v, want a direct connection", settings)
+	}
+	if settings := export.Proxy.Settings(config.ProxyDestinationOCI); settings.HTTPSProxy != "http://egress:3128" {
+		t.Errorf("oci: got %+v, want the exported proxy", settings)
+	}
+}
+
+func TestSidecarForJobPodResultRelay(t *testing.T) {
+	evaluation := sampleEvaluation("provider-1")
+	provider := sampleProviders("provider-1")["provider-1"]
+	provider.Runtime.K8s.ResultRelay = true
+	cfg := &config.Config{Sidecar: &config.SidecarConfig{EvalHub: &config.EvalHubClientConfig{BaseURL: "https://eval-hub:8443"}}}
+
+	jc, err := buildJobConfig(evaluation, &provider, &evaluation.Benchmarks[0], 0, cfg, nil)
+	if err != nil {
+		t.Fatalf("buildJobConfig: %v", err)
+	}
+	if jc.jobSpec.CallbackURL != nil || jc.jobSpec.ResultsFile != shared.JobSpecResultsFile {
+		t.Fatalf("expected the job spec to name the results file instead of the callback URL, got %v %q", jc.jobSpec.CallbackURL, jc.jobSpec.ResultsFile)
+	}
+	export, err := sidecarForJobPod(cfg, jc)
+	if err != nil {
+		t.Fatalf("sidecarForJobPod: %v", err)
+	}
+	if export.ResultRelay == nil || export.ResultRelay.Path != shared.JobSpecResultsFile || export.ResultRelay.JobID != evaluation.Resource.ID {
+		t.Fatalf("expected the sidecar to relay the results file of the job, got %+v", export.ResultRelay)
+	}
+
+	provider.Runtime.K8s.ResultRelay = false
+	jc, err = buildJobConfig(evaluation, &provider, &evaluation.Benchmarks[0], 0, cfg, nil)
+	if err != nil {
+		t.Fatalf("buildJobConfig: %v", err)
+	}
+	if export, _ := sidecarForJobPod(cfg, jc); export.ResultRelay != nil || jc.jobSpec.CallbackURL == nil {
+		t.Fatalf("expected no result relay without result_relay, got %+v", export.ResultRelay)
+	}
+}
@@ -0,0 +1,510 @@
+// Package lmevaljob is a runtime for the providers managed by the TrustyAI operator: each
+// benchmark runs as an LMEvalJob custom resource, which the operator runs, instead of an adapter
+// Job. The runtime watches the status of the resources and reports it as the status of their
+// benchmarks.
+package lmevaljob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/k8s"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	inClusterNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	defaultNamespace       = "default"
+	// rewatchDelay is how long a watch that could not be established waits before trying again.
+	rewatchDelay = 5 * time.Second
+)
+
+// watches holds the watches of the running jobs, shared by the scoped copies of the runtime.
+type watches struct {
+	mu   sync.Mutex
+	jobs map[string]*watchedJob
+}
+
+// watchedJob is a job with watched benchmarks, whose context is cancelled when the job is.
+type watchedJob struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running int
+}
+
+type LMEvalJobRuntime struct {
+	logger        *slog.Logger
+	ctx           context.Context
+	config        *config.LMEvalJobConfig
+	dynamicClient dynamic.Interface
+	clientset     kubernetes.Interface
+	watches       *watches
+}
+
+func NewLMEvalJobRuntime(
+	logger *slog.Logger,
+	serviceConfig *config.Config,
+) (abstractions.Runtime, error) {
+	var lmEvalJobConfig *config.LMEvalJobConfig
+	if serviceConfig != nil {
+		lmEvalJobConfig = serviceConfig.LMEvalJob
+	}
+	if err := lmEvalJobConfig.Validate(); err != nil {
+		return nil, err
+	}
+	restConfig, err := k8s.LoadKubernetesConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &LMEvalJobRuntime{
+		logger:        logger,
+		config:        lmEvalJobConfig,
+		dynamicClient: dynamicClient,
+		clientset:     clientset,
+		watches:       &watches{jobs: make(map[string]*watchedJob)},
+	}, nil
+}
+
+func (r *LMEvalJobRuntime) WithLogger(logger *slog.Logger) abstractions.Runtime {
+	return &LMEvalJobRuntime{
+		logger:        logger,
+		ctx:           r.ctx,
+		config:        r.config,
+		dynamicClient: r.dynamicClient,
+		clientset:     r.clientset,
+		watches:       r.watches,
+	}
+}
+
+func (r *LMEvalJobRuntime) WithContext(ctx context.Context) abstractions.Runtime {
+	return &LMEvalJobRuntime{
+		logger:        r.logger,
+		ctx:           ctx,
+		config:        r.config,
+		dynamicClient: r.dynamicClient,
+		clientset:     r.clientset,
+		watches:       r.watches,
+	}
+}
+
+func (r *LMEvalJobRuntime) Name() string {
+	return api.RuntimeLMEvalJob
+}
+
+func (r *LMEvalJobRuntime) RunEvaluationJob(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	storage abstractions.RuntimeStorage,
+) error {
+	if len(benchmarks) == 0 {
+		return serviceerrors.NewServiceError(messages.EvaluationJobEmpty, "EvaluationJobID", evaluation.Resource.ID)
+	}
+	var benchmarkIndices []int
+	for i := range benchmarks {
+		if shared.IsBenchmarkReady(evaluation, benchmarks, i) {
+			benchmarkIndices = append(benchmarkIndices, i)
+		}
+	}
+	return r.RunEvaluationBenchmarks(evaluation, benchmarks, benchmarkIndices, storage)
+}
+
+// RunEvaluationBenchmarks creates the LMEvalJob of each benchmark at benchmarkIndices and
+// watches its status. The LMEvalJob of an earlier run of a retried benchmark is replaced.
+func (r *LMEvalJobRuntime) RunEvaluationBenchmarks(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndices []int,
+	storage abstractions.RuntimeStorage,
+) error {
+	if r.ctx == nil {
+		return fmt.Errorf("lmevaljob runtime: nil context — WithContext must be called before RunEvaluationBenchmarks")
+	}
+	jobID := evaluation.Resource.ID
+	for _, i := range benchmarkIndices {
+		if i < 0 || i >= len(benchmarks) {
+			continue
+		}
+		bench := benchmarks[i]
+		job, err := r.buildBenchmarkResource(evaluation, bench, i, storage)
+		if err == nil {
+			err = r.createResource(job)
+		}
+		if err != nil {
+			r.failBenchmark(jobID, bench, i, err, storage)
+			continue
+		}
+		r.logger.Info("LMEvalJob created", "job_id", jobID, "benchmark_id", bench.ID, "benchmark_index", i, "name", job.Metadata.Name, "namespace", job.Metadata.Namespace)
+		watchCtx := r.watches.start(jobID)
+		go func() {
+			defer r.watches.finish(jobID)
+			r.watchBenchmark(watchCtx, job.Metadata.Namespace, job.Metadata.Name, jobID, bench, i, storage)
+		}()
+	}
+	return nil
+}
+
+func (r *LMEvalJobRuntime) buildBenchmarkResource(
+	evaluation *api.EvaluationJobResource,
+	bench api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	storage abstractions.RuntimeStorage,
+) (*lmEvalJob, error) {
+	provider, err := storage.GetProvider(bench.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	if provider.Runtime == nil || provider.Runtime.LMEvalJob == nil {
+		return nil, fmt.Errorf("provider %s is not managed by the TrustyAI operator, it has no runtime.lmevaljob configuration", bench.ProviderID)
+	}
+	return buildLMEvalJob(evaluation, provider, bench, benchmarkIndex, resourceOptions{
+		namespace:       r.namespace(evaluation),
+		completionsPath: r.config.EffectiveCompletionsPath(),
+	})
+}
+
+// createResource creates the LMEvalJob, after deleting the one of an earlier run of the
+// benchmark, which the operator does not run again.
+func (r *LMEvalJobRuntime) createResource(job *lmEvalJob) error {
+	object, err := job.toUnstructured()
+	if err != nil {
+		return err
+	}
+	resources := r.dynamicClient.Resource(lmEvalJobGVR).Namespace(job.Metadata.Namespace)
+	if err := resources.Delete(r.ctx, job.Metadata.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("delete the LMEvalJob of the earlier run: %w", err)
+	}
+	if _, err := resources.Create(r.ctx, object, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("create LMEvalJob %s: %w", job.Metadata.Name, err)
+	}
+	return nil
+}
+
+// watchBenchmark reports the status of the LMEvalJob of the benchmark each time it changes,
+// until it is terminal or the job is cancelled. A watch that ends is established again, and
+// starts with the current status of the resource.
+func (r *LMEvalJobRuntime) watchBenchmark(
+	ctx context.Context,
+	namespace string,
+	name string,
+	jobID string,
+	bench api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	storage abstractions.RuntimeStorage,
+) {
+	resources := r.dynamicClient.Resource(lmEvalJobGVR).Namespace(namespace)
+	var reported *api.BenchmarkStatusEvent
+	for ctx.Err() == nil {
+		watcher, err := resources.Watch(ctx, metav1.ListOptions{FieldSelector: "metadata.name=" + name})
+		if err != nil {
+			r.logger.Warn("failed to watch LMEvalJob, retrying", "error", err, "job_id", jobID, "name", name)
+			select {
+			case <-ctx.Done():
+			case <-time.After(rewatchDelay):
+			}
+			continue
+		}
+		done := r.handleEvents(ctx, watcher, name, jobID, bench, benchmarkIndex, storage, &reported)
+		watcher.Stop()
+		if done {
+			return
+		}
+	}
+}
+
+// handleEvents reports the status changes of the watch and returns true once the benchmark is
+// terminal or the job is cancelled.
+func (r *LMEvalJobRuntime) handleEvents(
+	ctx context.Context,
+	watcher watch.Interface,
+	name string,
+	jobID string,
+	bench api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	storage abstractions.RuntimeStorage,
+	reported **api.BenchmarkStatusEvent,
+) bool {
+	for {
+		select {
+		case <-ctx.Done():
+			return true
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return false
+			}
+			object, isObject := event.Object.(*unstructured.Unstructured)
+			if !isObject || object.GetName() != name {
+				continue
+			}
+			if event.Type == watch.Deleted {
+				r.failBenchmark(jobID, bench, benchmarkIndex, fmt.Errorf("the LMEvalJob %s was deleted before it completed", name), storage)
+				return true
+			}
+			status := statusEvent(object, bench, benchmarkIndex)
+			if status == nil || (*reported != nil && (*reported).Status == status.Status && (*reported).Phase == status.Phase) {
+				continue
+			}
+			r.updateBenchmark(jobID, storage, status)
+			*reported = status
+			if api.IsBenchmarkTerminalState(status.Status) {
+				return true
+			}
+		}
+	}
+}
+
+func (r *LMEvalJobRuntime) failBenchmark(
+	jobID string,
+	bench api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	cause error,
+	storage abstractions.RuntimeStorage,
+) {
+	r.logger.Error("LMEvalJob benchmark failed", "error", cause, "job_id", jobID, "benchmark_id", bench.ID, "benchmark_index", benchmarkIndex)
+	r.updateBenchmark(jobID, storage, &api.BenchmarkStatusEvent{
+		ProviderID:     bench.ProviderID,
+		ID:             bench.ID,
+		BenchmarkIndex: benchmarkIndex,
+		Status:         api.StateFailed,
+		CompletedAt:    api.DateTimeToString(time.Now()),
+		ErrorMessage: api.WithMessageOrigin(&api.MessageInfo{
+			Message:     cause.Error(),
+			MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_FAILED,
+		}, api.MessageOriginRuntime),
+	})
+}
+
+func (r *LMEvalJobRuntime) updateBenchmark(jobID string, storage abstractions.RuntimeStorage, event *api.BenchmarkStatusEvent) {
+	if storage == nil {
+		return
+	}
+	if err := storage.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: event}); err != nil {
+		r.logger.Error(
+			"failed to update benchmark status",
+			"error", err,
+			"job_id", jobID,
+			"benchmark_id", event.ID,
+			"benchmark_index", event.BenchmarkIndex,
+			"provider_id", event.ProviderID,
+			"status", event.Status,
+		)
+	}
+}
+
+// DeleteEvaluationJobResources stops watching the benchmarks of the job and deletes their
+// LMEvalJobs, which the operator then stops.
+func (r *LMEvalJobRuntime) DeleteEvaluationJobResources(evaluation *api.EvaluationJobResource) error {
+	r.watches.cancel(evaluation.Resource.ID)
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	resources := r.dynamicClient.Resource(lmEvalJobGVR).Namespace(r.namespace(evaluation))
+	list, err := resources.List(ctx, metav1.ListOptions{LabelSelector: jobSelector(evaluation.Resource.ID)})
+	if err != nil {
+		return fmt.Errorf("list LMEvalJobs: %w", err)
+	}
+	for _, item := range list.Items {
+		if err := resources.Delete(ctx, item.GetName(), metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("delete LMEvalJob %s: %w", item.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// GetEvaluationLogs returns the logs of lm-eval in the pod of the LMEvalJob of each benchmark.
+func (r *LMEvalJobRuntime) GetEvaluationLogs(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndex *int,
+	opts api.EvaluationLogOptions,
+) (string, error) {
+	if r.ctx == nil {
+		return "", fmt.Errorf("lmevaljob runtime: nil context — WithContext must be called before GetEvaluationLogs")
+	}
+	if len(benchmarks) == 0 {
+		return "", serviceerrors.NewServiceError(messages.EvaluationJobEmpty, "EvaluationJobID", evaluation.Resource.ID)
+	}
+	if benchmarkIndex != nil {
+		if *benchmarkIndex < 0 || *benchmarkIndex >= len(benchmarks) {
+			return "", serviceerrors.NewServiceError(
+				messages.ResourceNotFound,
+				"Type", "benchmark",
+				"ResourceId", fmt.Sprintf("%d", *benchmarkIndex),
+			)
+		}
+		return r.readBenchmarkLogs(evaluation, benchmarks[*benchmarkIndex], *benchmarkIndex, opts, false)
+	}
+	var sections []string
+	for i, bench := range benchmarks {
+		section, err := r.readBenchmarkLogs(evaluation, bench, i, opts, true)
+		if err != nil {
+			return "", err
+		}
+		sections = append(sections, section)
+	}
+	return strings.Join(sections, "\n"), nil
+}
+
+func (r *LMEvalJobRuntime) readBenchmarkLogs(
+	evaluation *api.EvaluationJobResource,
+	bench api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	opts api.EvaluationLogOptions,
+	includeHeader bool,
+) (string, error) {
+	namespace := r.namespace(evaluation)
+	object, err := r.dynamicClient.Resource(lmEvalJobGVR).Namespace(namespace).Get(r.ctx, resourceName(evaluation.Resource.ID, benchmarkIndex), metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", err
+	}
+	podName := ""
+	if object != nil {
+		podName, _, _ = unstructured.NestedString(object.Object, "status", "podName")
+	}
+	if podName == "" {
+		if includeHeader {
+			return shared.FormatLogSectionHeader("unknown", mainContainerName, bench.ID), nil
+		}
+		return "", nil
+	}
+
+	logOpts := &corev1.PodLogOptions{Container: mainContainerName, Timestamps: opts.Timestamps}
+	if opts.TailLines > 0 {
+		tail := int64(opts.TailLines)
+		logOpts.TailLines = &tail
+	}
+	if opts.SinceSeconds != nil {
+		since := int64(*opts.SinceSeconds)
+		logOpts.SinceSeconds = &since
+	}
+	data, err := r.clientset.CoreV1().Pods(namespace).GetLogs(podName, logOpts).DoRaw(r.ctx)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return "", err
+	}
+	logs := strings.TrimRight(string(data), "\n")
+	if !includeHeader {
+		return logs, nil
+	}
+	header := shared.FormatLogSectionHeader(podName, mainContainerName, bench.ID)
+	if logs == "" {
+		return header, nil
+	}
+	return header + "\n" + logs, nil
+}
+
+// GetEvaluationJobSpec returns the LMEvalJob of the benchmark, which takes the place of the job
+// spec of an adapter. Benchmarks are not sharded in the lmevaljob runtime, so there is only
+// shard 0.
+func (r *LMEvalJobRuntime) GetEvaluationJobSpec(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	shardIndex int,
+	storage abstractions.RuntimeStorage,
+) ([]byte, error) {
+	if benchmarkIndex < 0 || benchmarkIndex >= len(benchmarks) {
+		return nil, serviceerrors.NewServiceError(
+			messages.ResourceNotFound,
+			"Type", "benchmark",
+			"ResourceId", fmt.Sprintf("%d", benchmarkIndex),
+		)
+	}
+	if shardIndex != 0 {
+		return nil, serviceerrors.NewServiceError(
+			messages.ResourceNotFound,
+			"Type", "shard",
+			"ResourceId", fmt.Sprintf("%d", shardIndex),
+		)
+	}
+	job, err := r.buildBenchmarkResource(evaluation, benchmarks[benchmarkIndex], benchmarkIndex, storage)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(job, "", "  ")
+}
+
+// namespace returns the namespace of the LMEvalJobs of the job: the configured one, else the
+// namespace of the tenant of the job, like the kubernetes runtime.
+func (r *LMEvalJobRuntime) namespace(evaluation *api.EvaluationJobResource) string {
+	if r.config != nil && r.config.Namespace != "" {
+		return r.config.Namespace
+	}
+	if tenant := string(evaluation.Resource.Tenant); tenant != "" {
+		return tenant
+	}
+	if content, err := os.ReadFile(inClusterNamespaceFile); err == nil {
+		if namespace := strings.TrimSpace(string(content)); namespace != "" {
+			return namespace
+		}
+	}
+	return defaultNamespace
+}
+
+func jobSelector(jobID string) string {
+	return labelJobIDKey + "=" + sanitizeLabelValue(jobID)
+}
+
+// start registers a watched benchmark of the job and returns the context of the job.
+func (w *watches) start(jobID string) context.Context {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	job, ok := w.jobs[jobID]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		job = &watchedJob{ctx: ctx, cancel: cancel}
+		w.jobs[jobID] = job
+	}
+	job.running++
+	return job.ctx
+}
+
+// finish forgets the job once its last watched benchmark is terminal.
+func (w *watches) finish(jobID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	job, ok := w.jobs[jobID]
+	if !ok {
+		return
+	}
+	job.running--
+	if job.running <= 0 {
+		job.cancel()
+		delete(w.jobs, jobID)
+	}
+}
+
+func (w *watches) cancel(jobID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if job, ok := w.jobs[jobID]; ok {
+		job.cancel()
+		delete(w.jobs, jobID)
+	}
+}
@@ -0,0 +1,283 @@
+package lmevaljob
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// fakeStorage serves the providers and records the status updates of the runtime.
+type fakeStorage struct {
+	providers map[string]*api.ProviderResource
+	events    []*api.BenchmarkStatusEvent
+}
+
+func (f *fakeStorage) GetProvider(id string) (*api.ProviderResource, error) {
+	if provider, ok := f.providers[id]; ok {
+		return provider, nil
+	}
+	return &api.ProviderResource{Resource: api.Resource{ID: id}}, nil
+}
+
+func (f *fakeStorage) UpdateEvaluationJob(_ string, runStatus *api.StatusEvent) error {
+	f.events = append(f.events, runStatus.BenchmarkStatusEvent)
+	return nil
+}
+
+func (f *fakeStorage) UpdateEvaluationJobModelMetadata(_ string, _ *api.ModelMetadata) error {
+	return nil
+}
+func (f *fakeStorage) UpdateEvaluationJobBenchmarkPlacement(_ string, _ int, _ *api.BenchmarkPlacement) error {
+	return nil
+}
+func (f *fakeStorage) PinEvaluationJobAdapterImage(_ string, _ string, pinned string) (string, error) {
+	return pinned, nil
+}
+
+func newTestRuntime(objects ...k8sruntime.Object) *LMEvalJobRuntime {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(
+		k8sruntime.NewScheme(),
+		map[schema.GroupVersionResource]string{lmEvalJobGVR: "LMEvalJobList"},
+		objects...,
+	)
+	return &LMEvalJobRuntime{
+		logger:        slog.New(slog.DiscardHandler),
+		ctx:           context.Background(),
+		config:        &config.LMEvalJobConfig{Namespace: "evals"},
+		dynamicClient: dynamicClient,
+		clientset:     fake.NewClientset(),
+		watches:       &watches{jobs: make(map[string]*watchedJob)},
+	}
+}
+
+func sampleStorage() *fakeStorage {
+	provider := &api.ProviderResource{Resource: api.Resource{ID: "lm_evaluation_harness"}}
+	provider.Runtime = &api.Runtime{LMEvalJob: &api.LMEvalJobRuntime{
+		ModelArgs:   []api.EnvVar{{Name: "tokenizer", Value: "org/model-1"}, {Name: "base_url", Value: "ignored"}},
+		AllowOnline: true,
+		Env:         []api.EnvVar{{Name: "HF_HUB_OFFLINE", Value: "0"}},
+	}}
+	return &fakeStorage{providers: map[string]*api.ProviderResource{"lm_evaluation_harness": provider}}
+}
+
+func sampleEvaluation() (*api.EvaluationJobResource, []api.EvaluationBenchmarkConfig) {
+	evaluation := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "Job_1"}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model: api.ModelRef{
+				URL:  "http://model.example/",
+				Name: "model-1",
+				Auth: &api.ModelAuth{SecretRef: "model-auth"},
+			},
+		},
+	}
+	benchmarks := []api.EvaluationBenchmarkConfig{
+		{
+			Ref:        api.Ref{ID: "arc_easy"},
+			ProviderID: "lm_evaluation_harness",
+			Parameters: map[string]any{"num_examples": 10, "num_fewshot": float64(5), "batch_size": "8"},
+		},
+		{Ref: api.Ref{ID: "hellaswag"}, ProviderID: "lm_evaluation_harness"},
+	}
+	return evaluation, benchmarks
+}
+
+func lmEvalJobObject(name string, status map[string]any) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": lmEvalJobAPIVersion,
+		"kind":       lmEvalJobKind,
+		"metadata":   map[string]any{"name": name, "namespace": "evals"},
+	}}
+	if status != nil {
+		object.Object["status"] = status
+	}
+	return object
+}
+
+func TestBuildLMEvalJobRendersTheBenchmark(t *testing.T) {
+	evaluation, benchmarks := sampleEvaluation()
+	provider := sampleStorage().providers["lm_evaluation_harness"]
+
+	job, err := buildLMEvalJob(evaluation, provider, benchmarks[0], 0, resourceOptions{
+		namespace:       "evals",
+		completionsPath: config.DefaultLMEvalJobCompletionsPath,
+	})
+	if err != nil {
+		t.Fatalf("buildLMEvalJob: %v", err)
+	}
+	if job.Metadata.Name != "evalhub-job-1-0" || job.Metadata.Labels[labelJobIDKey] != "job-1" {
+		t.Fatalf("unexpected metadata %+v", job.Metadata)
+	}
+	spec := job.Spec
+	if spec.Model != defaultModelType || len(spec.TaskList.TaskNames) != 1 || spec.TaskList.TaskNames[0] != "arc_easy" {
+		t.Fatalf("expected the benchmark as the task of a local-completions model, got %+v", spec)
+	}
+	wantArgs := []argument{
+		{Name: "model", Value: "model-1"},
+		{Name: "base_url", Value: "http://model.example/v1/completions"},
+		{Name: "tokenizer", Value: "org/model-1"},
+	}
+	if len(spec.ModelArgs) != len(wantArgs) {
+		t.Fatalf("expected model args %v, got %v", wantArgs, spec.ModelArgs)
+	}
+	for i, want := range wantArgs {
+		if spec.ModelArgs[i] != want {
+			t.Errorf("expected model arg %v, got %v", want, spec.ModelArgs[i])
+		}
+	}
+	if spec.Limit != "10" || spec.NumFewShot == nil || *spec.NumFewShot != 5 || spec.BatchSize != "8" || !spec.AllowOnline {
+		t.Fatalf("expected the parameters of the benchmark, got %+v", spec)
+	}
+	env := spec.Pod.Container.Env
+	if len(env) != 2 || env[0].Name != envModelAPIKeyName || env[0].ValueFrom.SecretKeyRef.Name != "model-auth" {
+		t.Fatalf("expected the API key of the model from its auth secret, got %+v", env)
+	}
+
+	evaluation.Model.URL = ""
+	if _, err := buildLMEvalJob(evaluation, provider, benchmarks[0], 0, resourceOptions{}); err == nil {
+		t.Fatalf("expected an error for a model without a url")
+	}
+}
+
+func TestStatusEventMapsTheStateOfTheLMEvalJob(t *testing.T) {
+	bench := api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}
+	results := `{"results":{"arc_easy":{"alias":"arc_easy","acc,none":0.75,"acc_stderr,none":0.01,"exact_match,strict-match":0.5}}}`
+
+	cases := []struct {
+		name   string
+		status map[string]any
+		want   api.State
+		phase  api.JobPhase
+	}{
+		{name: "new", status: map[string]any{"state": "New"}},
+		{name: "scheduled", status: map[string]any{"state": stateScheduled}, want: api.StateRunning, phase: api.JobPhaseInitializing},
+		{name: "running", status: map[string]any{"state": stateRunning}, want: api.StateRunning, phase: api.JobPhaseRunningEvaluation},
+		{name: "succeeded", status: map[string]any{"state": stateComplete, "reason": reasonSucceeded, "results": results}, want: api.StateCompleted, phase: api.JobPhaseCompleted},
+		{name: "failed", status: map[string]any{"state": stateComplete, "reason": "Failed", "message": "lm-eval exited with 1"}, want: api.StateFailed},
+		{name: "cancelled", status: map[string]any{"state": stateCancelled, "reason": "Cancelled"}, want: api.StateFailed},
+		{name: "succeeded without results", status: map[string]any{"state": stateComplete, "reason": reasonSucceeded}, want: api.StateFailed},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := statusEvent(lmEvalJobObject("evalhub-job-1-0", tc.status), bench, 0)
+			if tc.want == "" {
+				if event != nil {
+					t.Fatalf("expected no event, got %+v", event)
+				}
+				return
+			}
+			if event == nil || event.Status != tc.want || event.Phase != tc.phase {
+				t.Fatalf("expected %s/%s, got %+v", tc.want, tc.phase, event)
+			}
+			if tc.want == api.StateFailed && (event.ErrorMessage == nil || event.ErrorMessage.Message == "") {
+				t.Fatalf("expected an error message, got %+v", event)
+			}
+			if tc.want == api.StateCompleted {
+				if event.Metrics["acc"] != 0.75 || event.Metrics["exact_match_strict-match"] != 0.5 {
+					t.Fatalf("expected the metrics of the task, got %v", event.Metrics)
+				}
+				if _, ok := event.Metrics["alias"]; ok {
+					t.Fatalf("expected only numeric metrics, got %v", event.Metrics)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleEventsReportsEachChangeUntilTheLMEvalJobIsTerminal(t *testing.T) {
+	runtime := newTestRuntime()
+	bench := api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}
+	storage := sampleStorage()
+	watcher := watch.NewFakeWithChanSize(6, false)
+	watcher.Add(lmEvalJobObject("evalhub-job-1-0", map[string]any{"state": stateScheduled}))
+	watcher.Modify(lmEvalJobObject("evalhub-job-1-0", map[string]any{"state": stateRunning}))
+	watcher.Modify(lmEvalJobObject("evalhub-job-1-1", map[string]any{"state": stateComplete, "reason": "Failed"}))
+	watcher.Modify(lmEvalJobObject("evalhub-job-1-0", map[string]any{"state": stateRunning, "message": "progress"}))
+	watcher.Modify(lmEvalJobObject("evalhub-job-1-0", map[string]any{"state": stateComplete, "reason": "Failed"}))
+
+	var reported *api.BenchmarkStatusEvent
+	done := runtime.handleEvents(context.Background(), watcher, "evalhub-job-1-0", "job-1", bench, 0, storage, &reported)
+	if !done {
+		t.Fatalf("expected the watch to end once the LMEvalJob is terminal")
+	}
+	want := []api.State{api.StateRunning, api.StateRunning, api.StateFailed}
+	if len(storage.events) != len(want) {
+		t.Fatalf("expected %d events, got %+v", len(want), storage.events)
+	}
+	for i, state := range want {
+		if storage.events[i].Status != state {
+			t.Errorf("expected event %d to be %s, got %s", i, state, storage.events[i].Status)
+		}
+	}
+
+	storage.events = nil
+	reported = nil
+	watcher = watch.NewFakeWithChanSize(1, false)
+	watcher.Delete(lmEvalJobObject("evalhub-job-1-0", nil))
+	if !runtime.handleEvents(context.Background(), watcher, "evalhub-job-1-0", "job-1", bench, 0, storage, &reported) {
+		t.Fatalf("expected the watch to end once the LMEvalJob is deleted")
+	}
+	if len(storage.events) != 1 || storage.events[0].Status != api.StateFailed {
+		t.Fatalf("expected the deleted LMEvalJob to fail the benchmark, got %+v", storage.events)
+	}
+}
+
+func TestRunAndDeleteEvaluationJob(t *testing.T) {
+	// an LMEvalJob of an earlier run of benchmark 0 is replaced
+	runtime := newTestRuntime(lmEvalJobObject("evalhub-job-1-0", map[string]any{"state": stateComplete, "reason": "Failed"}))
+	evaluation, benchmarks := sampleEvaluation()
+	evaluation.Resource.ID = "job-1"
+	benchmarks = append(benchmarks, api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness", DependsOn: []int{0}})
+	storage := sampleStorage()
+
+	if err := runtime.RunEvaluationJob(evaluation, benchmarks, storage); err != nil {
+		t.Fatalf("RunEvaluationJob: %v", err)
+	}
+	resources := runtime.dynamicClient.Resource(lmEvalJobGVR).Namespace("evals")
+	list, err := resources.List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("list LMEvalJobs: %v", err)
+	}
+	// benchmark 2 waits for benchmark 0
+	if len(list.Items) != 2 {
+		t.Fatalf("expected an LMEvalJob per ready benchmark, got %d", len(list.Items))
+	}
+	object, err := resources.Get(context.Background(), "evalhub-job-1-0", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get LMEvalJob: %v", err)
+	}
+	if _, found := object.Object["status"]; found {
+		t.Fatalf("expected the LMEvalJob of the earlier run to be replaced, got %v", object.Object)
+	}
+
+	if err := runtime.DeleteEvaluationJobResources(evaluation); err != nil {
+		t.Fatalf("DeleteEvaluationJobResources: %v", err)
+	}
+	list, err = resources.List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("list LMEvalJobs: %v", err)
+	}
+	if len(list.Items) != 0 {
+		t.Fatalf("expected the LMEvalJobs of the job to be deleted, got %d", len(list.Items))
+	}
+}
+
+func TestLMEvalJobConfigValidate(t *testing.T) {
+	var nilConfig *config.LMEvalJobConfig
+	if err := nilConfig.Validate(); err != nil {
+		t.Fatalf("expected no error without configuration, got %v", err)
+	}
+	if err := (&config.LMEvalJobConfig{CompletionsPath: "v1/chat/completions"}).Validate(); err == nil {
+		t.Fatalf("expected an error for a relative completions path")
+	}
+}
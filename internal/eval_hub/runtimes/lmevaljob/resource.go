@@ -0,0 +1,334 @@
+package lmevaljob
+
+// Rendering of a benchmark into an LMEvalJob custom resource, and of the status of the resource
+// into a benchmark status event.
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/k8s"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+const (
+	lmEvalJobAPIVersion = "trustyai.opendatahub.io/v1alpha1"
+	lmEvalJobKind       = "LMEvalJob"
+	defaultModelType    = "local-completions"
+	resourceNamePrefix  = "evalhub-"
+	maxNameLength       = 63
+
+	labelJobIDKey          = "job_id"
+	labelBenchmarkIndexKey = "benchmark_index"
+	labelAppKey            = "app"
+	labelAppValue          = "evalhub"
+	labelComponentKey      = "component"
+	labelComponentValue    = "evaluation-job"
+	annotationJobIDKey     = "eval-hub.github.io/job_id"
+
+	// mainContainerName is the container of the pod of an LMEvalJob that runs lm-eval.
+	mainContainerName = "main"
+	// modelAPIKeySecretKey is the key of the model auth secret with the API key of the model.
+	modelAPIKeySecretKey = "api-key"
+	envModelAPIKeyName   = "OPENAI_API_KEY"
+)
+
+// The states and reasons of the status of an LMEvalJob.
+const (
+	stateScheduled  = "Scheduled"
+	stateRunning    = "Running"
+	stateComplete   = "Complete"
+	stateCancelled  = "Cancelled"
+	reasonSucceeded = "Succeeded"
+)
+
+var lmEvalJobGVR = schema.GroupVersionResource{
+	Group:    "trustyai.opendatahub.io",
+	Version:  "v1alpha1",
+	Resource: "lmevaljobs",
+}
+
+var nameSanitizer = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// lmEvalJob is the subset of the LMEvalJob resource that the runtime creates. It is kept local,
+// rather than importing the API of the operator, since the resource is created as unstructured.
+type lmEvalJob struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Metadata   objectMeta    `json:"metadata"`
+	Spec       lmEvalJobSpec `json:"spec"`
+}
+
+type objectMeta struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type lmEvalJobSpec struct {
+	Model              string     `json:"model"`
+	ModelArgs          []argument `json:"modelArgs,omitempty"`
+	TaskList           taskList   `json:"taskList"`
+	Limit              string     `json:"limit,omitempty"`
+	NumFewShot         *int       `json:"numFewShot,omitempty"`
+	BatchSize          string     `json:"batchSize,omitempty"`
+	AllowOnline        bool       `json:"allowOnline,omitempty"`
+	AllowCodeExecution bool       `json:"allowCodeExecution,omitempty"`
+	Pod                *podSpec   `json:"pod,omitempty"`
+}
+
+type argument struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type taskList struct {
+	TaskNames []string `json:"taskNames"`
+}
+
+type podSpec struct {
+	Container containerSpec `json:"container"`
+}
+
+type containerSpec struct {
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// resourceOptions are the settings of the deployment that the resource is rendered with.
+type resourceOptions struct {
+	namespace       string
+	completionsPath string
+}
+
+// buildLMEvalJob renders the benchmark into an LMEvalJob that runs the benchmark as an lm-eval
+// task against the model of the job.
+func buildLMEvalJob(
+	evaluation *api.EvaluationJobResource,
+	provider *api.ProviderResource,
+	bench api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	opts resourceOptions,
+) (*lmEvalJob, error) {
+	runtime := provider.Runtime.LMEvalJob
+	if evaluation.Model.URL == "" {
+		return nil, fmt.Errorf("the lmevaljob runtime needs the url of the model, it does not resolve inference services")
+	}
+	bench = provider.WithDefaultParameters(bench)
+	parameters := shared.CopyParams(bench.Parameters)
+
+	modelType := runtime.Model
+	if modelType == "" {
+		modelType = defaultModelType
+	}
+	baseURL := strings.TrimSuffix(evaluation.Model.URL, "/")
+	if !strings.HasSuffix(baseURL, opts.completionsPath) {
+		baseURL += opts.completionsPath
+	}
+	modelArgs := []argument{{Name: "model", Value: evaluation.Model.Name}, {Name: "base_url", Value: baseURL}}
+	for _, arg := range runtime.ModelArgs {
+		if arg.Name == "" || arg.Name == "model" || arg.Name == "base_url" {
+			continue
+		}
+		modelArgs = append(modelArgs, argument{Name: arg.Name, Value: arg.Value})
+	}
+
+	jobID := evaluation.Resource.ID
+	job := &lmEvalJob{
+		APIVersion: lmEvalJobAPIVersion,
+		Kind:       lmEvalJobKind,
+		Metadata: objectMeta{
+			Name:      resourceName(jobID, benchmarkIndex),
+			Namespace: opts.namespace,
+			Labels: map[string]string{
+				labelJobIDKey:          sanitizeLabelValue(jobID),
+				labelBenchmarkIndexKey: strconv.Itoa(benchmarkIndex),
+				labelAppKey:            labelAppValue,
+				labelComponentKey:      labelComponentValue,
+			},
+			Annotations: map[string]string{annotationJobIDKey: jobID},
+		},
+		Spec: lmEvalJobSpec{
+			Model:              modelType,
+			ModelArgs:          modelArgs,
+			TaskList:           taskList{TaskNames: []string{bench.ID}},
+			AllowOnline:        runtime.AllowOnline,
+			AllowCodeExecution: runtime.AllowCodeExecution,
+		},
+	}
+	if numExamples := shared.NumExamplesFromParameters(parameters); numExamples != nil {
+		job.Spec.Limit = strconv.Itoa(*numExamples)
+	}
+	if numFewShot, ok := intParameter(parameters, "num_fewshot"); ok {
+		job.Spec.NumFewShot = &numFewShot
+	}
+	if batchSize, ok := intParameter(parameters, "batch_size"); ok {
+		job.Spec.BatchSize = strconv.Itoa(batchSize)
+	}
+
+	// the API key of the model wins over the env of the job, which wins over the env of the
+	// provider
+	var env []corev1.EnvVar
+	seen := map[string]bool{}
+	if evaluation.Model.Auth != nil && evaluation.Model.Auth.SecretRef != "" {
+		optional := true
+		env = append(env, corev1.EnvVar{
+			Name: envModelAPIKeyName,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: evaluation.Model.Auth.SecretRef},
+				Key:                  modelAPIKeySecretKey,
+				Optional:             &optional,
+			}},
+		})
+		seen[envModelAPIKeyName] = true
+	}
+	for _, item := range k8s.JobEnvVars(evaluation.Env) {
+		if !seen[item.Name] {
+			seen[item.Name] = true
+			env = append(env, item)
+		}
+	}
+	for _, item := range runtime.Env {
+		if item.Name != "" && !seen[item.Name] {
+			seen[item.Name] = true
+			env = append(env, corev1.EnvVar{Name: item.Name, Value: item.Value})
+		}
+	}
+	if len(env) > 0 {
+		job.Spec.Pod = &podSpec{Container: containerSpec{Env: env}}
+	}
+	return job, nil
+}
+
+func intParameter(parameters map[string]any, name string) (int, bool) {
+	switch value := parameters[name].(type) {
+	case int:
+		return value, true
+	case int64:
+		return int(value), true
+	case float64:
+		if value == math.Trunc(value) {
+			return int(value), true
+		}
+	case string:
+		if n, err := strconv.Atoi(value); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// toUnstructured returns the resource as the unstructured object that the dynamic client
+// creates.
+func (j *lmEvalJob) toUnstructured() (*unstructured.Unstructured, error) {
+	data, err := json.Marshal(j)
+	if err != nil {
+		return nil, fmt.Errorf("marshal LMEvalJob: %w", err)
+	}
+	object := map[string]any{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("unmarshal LMEvalJob: %w", err)
+	}
+	return &unstructured.Unstructured{Object: object}, nil
+}
+
+// statusEvent maps the status of the LMEvalJob of a benchmark to a status event of the
+// benchmark, nil while the resource waits for the operator.
+func statusEvent(object *unstructured.Unstructured, bench api.EvaluationBenchmarkConfig, benchmarkIndex int) *api.BenchmarkStatusEvent {
+	state, _, _ := unstructured.NestedString(object.Object, "status", "state")
+	reason, _, _ := unstructured.NestedString(object.Object, "status", "reason")
+	message, _, _ := unstructured.NestedString(object.Object, "status", "message")
+	event := &api.BenchmarkStatusEvent{
+		ProviderID:     bench.ProviderID,
+		ID:             bench.ID,
+		BenchmarkIndex: benchmarkIndex,
+	}
+	switch state {
+	case stateScheduled:
+		event.Status = api.StateRunning
+		event.Phase = api.JobPhaseInitializing
+	case stateRunning:
+		event.Status = api.StateRunning
+		event.Phase = api.JobPhaseRunningEvaluation
+	case stateComplete, stateCancelled:
+		if state == stateComplete && reason == reasonSucceeded {
+			results, _, _ := unstructured.NestedString(object.Object, "status", "results")
+			metrics, err := parseResults(results, bench.ID)
+			if err == nil {
+				event.Status = api.StateCompleted
+				event.Phase = api.JobPhaseCompleted
+				event.Metrics = metrics
+				return event
+			}
+			message = err.Error()
+		}
+		if message == "" {
+			message = fmt.Sprintf("the LMEvalJob %s ended in state %s with reason %s", object.GetName(), state, reason)
+		}
+		event.Status = api.StateFailed
+		event.ErrorMessage = api.WithMessageOrigin(&api.MessageInfo{
+			Message:     message,
+			MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_FAILED,
+		}, api.MessageOriginRuntime)
+	default:
+		return nil
+	}
+	return event
+}
+
+// parseResults returns the metrics of the task in the lm-eval results of an LMEvalJob. The
+// metrics of lm-eval are named <metric>,<filter>; the filter is dropped when it is none.
+func parseResults(results string, task string) (map[string]any, error) {
+	if results == "" {
+		return nil, fmt.Errorf("the LMEvalJob completed without results")
+	}
+	var output struct {
+		Results map[string]map[string]any `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(results), &output); err != nil {
+		return nil, fmt.Errorf("parse the results of the LMEvalJob: %w", err)
+	}
+	taskResults, ok := output.Results[task]
+	if !ok {
+		return nil, fmt.Errorf("the results of the LMEvalJob have no task %s", task)
+	}
+	metrics := map[string]any{}
+	for key, value := range taskResults {
+		number, ok := value.(float64)
+		if !ok {
+			continue
+		}
+		name, filter, found := strings.Cut(key, ",")
+		if found && filter != "none" {
+			name += "_" + filter
+		}
+		metrics[name] = number
+	}
+	return metrics, nil
+}
+
+// resourceName returns the name of the LMEvalJob of a benchmark of the job.
+func resourceName(jobID string, benchmarkIndex int) string {
+	suffix := "-" + strconv.Itoa(benchmarkIndex)
+	name := strings.Trim(nameSanitizer.ReplaceAllString(strings.ToLower(jobID), "-"), "-")
+	if limit := maxNameLength - len(resourceNamePrefix) - len(suffix); len(name) > limit {
+		name = strings.TrimRight(name[:limit], "-")
+	}
+	return resourceNamePrefix + name + suffix
+}
+
+func sanitizeLabelValue(value string) string {
+	safe := nameSanitizer.ReplaceAllString(strings.ToLower(value), "-")
+	if len(safe) > maxNameLength {
+		safe = safe[:maxNameLength]
+	}
+	return strings.Trim(safe, "-")
+}
@@ -0,0 +1,50 @@
+//go:build linux
+
+package local
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+)
+
+// cpuMaxPeriod is the cpu.max period, in microseconds.
+const cpuMaxPeriod = 100000
+
+// joinCgroup creates the cgroup v2 dir with the memory and CPU limits and starts the
+// command in it. The returned function removes the cgroup once the process finished.
+func joinCgroup(cmd *exec.Cmd, dir string, limits config.LocalSandboxLimits) (func(), error) {
+	if err := os.Mkdir(dir, 0o755); err != nil && !os.IsExist(err) {
+		return nil, err
+	}
+	remove := func() { _ = os.Remove(dir) }
+	controls := map[string]string{}
+	if limits.MemoryMB > 0 {
+		controls["memory.max"] = strconv.Itoa(limits.MemoryMB * 1024 * 1024)
+	}
+	if limits.CPUs > 0 {
+		controls["cpu.max"] = fmt.Sprintf("%d %d", int(limits.CPUs*cpuMaxPeriod), cpuMaxPeriod)
+	}
+	for control, value := range controls {
+		if err := os.WriteFile(filepath.Join(dir, control), []byte(value), 0o600); err != nil {
+			remove()
+			return nil, fmt.Errorf("set %s: %w", control, err)
+		}
+	}
+	cgroup, err := os.Open(dir) // #nosec G304 -- the cgroup is under the configured cgroup_parent
+	if err != nil {
+		remove()
+		return nil, err
+	}
+	// the process starts in the cgroup, before it can fork anything outside of it
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(cgroup.Fd())
+	return func() {
+		_ = cgroup.Close()
+		remove()
+	}, nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package local
+
+import (
+	"errors"
+	"os/exec"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+)
+
+// joinCgroup is only supported on Linux.
+func joinCgroup(cmd *exec.Cmd, dir string, limits config.LocalSandboxLimits) (func(), error) {
+	return nil, errors.New("local_sandbox.cgroup_parent is only supported on Linux")
+}
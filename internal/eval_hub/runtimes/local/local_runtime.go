@@ -2,15 +2,17 @@ package local
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
@@ -20,37 +22,39 @@ import (
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
-const localJobsBaseDir = "/tmp/evalhub-jobs"
-
 // jobTracker manages subprocess tracking per job for cancellation.
 type jobTracker interface {
 	registerJob(jobID string)
-	addPID(jobID string, pid int)
+	addPID(jobID string, benchmarkIndex int, pid int)
 	cancelJob(jobID string)
+	cancelBenchmarks(jobID string, benchmarkIndices []int)
 	isCancelled(jobID string) bool
 }
 
 // pidTracker tracks running subprocess PIDs per job so they can be killed on cancel.
 type pidTracker struct {
 	mu        sync.Mutex
-	pids      map[string][]int // jobID -> list of PIDs
-	cancelled map[string]bool  // jobs cancelled before all PIDs arrived
+	pids      map[string]map[int][]int // jobID -> benchmark index -> list of PIDs
+	cancelled map[string]bool          // jobs cancelled before all PIDs arrived
 }
 
 func (jr *pidTracker) registerJob(jobID string) {
 	jr.mu.Lock()
 	defer jr.mu.Unlock()
-	jr.pids[jobID] = nil
+	jr.pids[jobID] = make(map[int][]int)
 }
 
-func (jr *pidTracker) addPID(jobID string, pid int) {
+func (jr *pidTracker) addPID(jobID string, benchmarkIndex int, pid int) {
 	jr.mu.Lock()
 	defer jr.mu.Unlock()
 	if jr.cancelled[jobID] {
 		_ = killProcessGroup(pid)
 		return
 	}
-	jr.pids[jobID] = append(jr.pids[jobID], pid)
+	if jr.pids[jobID] == nil {
+		jr.pids[jobID] = make(map[int][]int)
+	}
+	jr.pids[jobID][benchmarkIndex] = append(jr.pids[jobID][benchmarkIndex], pid)
 }
 
 // cancelJob sends SIGKILL to the process group of every tracked PID for the
@@ -60,15 +64,30 @@ func (jr *pidTracker) addPID(jobID string, pid int) {
 func (jr *pidTracker) cancelJob(jobID string) {
 	jr.mu.Lock()
 	defer jr.mu.Unlock()
-	if pids, ok := jr.pids[jobID]; ok {
-		for _, pid := range pids {
-			_ = killProcessGroup(pid)
+	if benchmarks, ok := jr.pids[jobID]; ok {
+		for _, pids := range benchmarks {
+			for _, pid := range pids {
+				_ = killProcessGroup(pid)
+			}
 		}
 		delete(jr.pids, jobID)
 	}
 	jr.cancelled[jobID] = true
 }
 
+// cancelBenchmarks sends SIGKILL to the process group of every tracked PID of the benchmarks
+// of the job at benchmarkIndices, and keeps the processes of its other benchmarks.
+func (jr *pidTracker) cancelBenchmarks(jobID string, benchmarkIndices []int) {
+	jr.mu.Lock()
+	defer jr.mu.Unlock()
+	for _, benchmarkIndex := range benchmarkIndices {
+		for _, pid := range jr.pids[jobID][benchmarkIndex] {
+			_ = killProcessGroup(pid)
+		}
+		delete(jr.pids[jobID], benchmarkIndex)
+	}
+}
+
 func (jr *pidTracker) isCancelled(jobID string) bool {
 	jr.mu.Lock()
 	defer jr.mu.Unlock()
@@ -76,26 +95,71 @@ func (jr *pidTracker) isCancelled(jobID string) bool {
 }
 
 type LocalRuntime struct {
-	logger      *slog.Logger
-	ctx         context.Context
-	tracker     jobTracker
-	callbackURL *string
+	logger       *slog.Logger
+	ctx          context.Context
+	tracker      jobTracker
+	callbackURL  *string
+	callbackAuth *config.CallbackAuthConfig
+	// secretsDir is where the secretRef:// parameters are read from
+	secretsDir string
+	sampling   *config.SamplingConfig
+	sandbox    *config.LocalSandboxConfig
+	jobs       *config.LocalJobsConfig
+	containers *config.LocalContainersConfig
 }
 
 func NewLocalRuntime(
 	logger *slog.Logger,
 	serviceConfig *config.Config,
 ) (abstractions.Runtime, error) {
+	var sandbox *config.LocalSandboxConfig
+	var jobs *config.LocalJobsConfig
+	var containers *config.LocalContainersConfig
+	if serviceConfig != nil {
+		sandbox = serviceConfig.LocalSandbox
+		jobs = serviceConfig.LocalJobs
+		containers = serviceConfig.LocalContainers
+	}
+	if err := sandbox.Validate(); err != nil {
+		return nil, err
+	}
 	return &LocalRuntime{
-		logger:      logger,
-		callbackURL: buildCallbackURL(serviceConfig),
+		logger:       logger,
+		callbackURL:  buildCallbackURL(serviceConfig),
+		callbackAuth: callbackAuthConfig(serviceConfig),
+		secretsDir:   parameterSecretsDir(serviceConfig),
+		sampling:     samplingConfig(serviceConfig),
+		sandbox:      sandbox,
+		jobs:         jobs,
+		containers:   containers,
 		tracker: &pidTracker{
-			pids:      make(map[string][]int),
+			pids:      make(map[string]map[int][]int),
 			cancelled: make(map[string]bool),
 		},
 	}, nil
 }
 
+func callbackAuthConfig(serviceConfig *config.Config) *config.CallbackAuthConfig {
+	if serviceConfig == nil {
+		return nil
+	}
+	return serviceConfig.CallbackAuth
+}
+
+func samplingConfig(serviceConfig *config.Config) *config.SamplingConfig {
+	if serviceConfig == nil {
+		return nil
+	}
+	return serviceConfig.Sampling
+}
+
+func parameterSecretsDir(serviceConfig *config.Config) string {
+	if serviceConfig == nil {
+		return ""
+	}
+	return serviceConfig.ParameterSecrets.SecretsDir()
+}
+
 func buildCallbackURL(serviceConfig *config.Config) *string {
 	if serviceConfig == nil || serviceConfig.Service == nil || serviceConfig.Service.Port <= 0 {
 		return nil
@@ -114,19 +178,31 @@ func buildCallbackURL(serviceConfig *config.Config) *string {
 
 func (r *LocalRuntime) WithLogger(logger *slog.Logger) abstractions.Runtime {
 	return &LocalRuntime{
-		logger:      logger,
-		ctx:         r.ctx,
-		tracker:     r.tracker,
-		callbackURL: r.callbackURL,
+		logger:       logger,
+		ctx:          r.ctx,
+		tracker:      r.tracker,
+		callbackURL:  r.callbackURL,
+		callbackAuth: r.callbackAuth,
+		secretsDir:   r.secretsDir,
+		sampling:     r.sampling,
+		sandbox:      r.sandbox,
+		jobs:         r.jobs,
+		containers:   r.containers,
 	}
 }
 
 func (r *LocalRuntime) WithContext(ctx context.Context) abstractions.Runtime {
 	return &LocalRuntime{
-		logger:      r.logger,
-		ctx:         ctx,
-		tracker:     r.tracker,
-		callbackURL: r.callbackURL,
+		logger:       r.logger,
+		ctx:          ctx,
+		tracker:      r.tracker,
+		callbackURL:  r.callbackURL,
+		callbackAuth: r.callbackAuth,
+		secretsDir:   r.secretsDir,
+		sampling:     r.sampling,
+		sandbox:      r.sandbox,
+		jobs:         r.jobs,
+		containers:   r.containers,
 	}
 }
 
@@ -143,14 +219,56 @@ func (r *LocalRuntime) RunEvaluationJob(
 	if len(benchmarks) == 0 {
 		return serviceerrors.NewServiceError(messages.EvaluationJobEmpty, "EvaluationJobID", evaluation.Resource.ID)
 	}
+	if evaluation.Model.InferenceService != nil {
+		return serviceerrors.NewServiceError(messages.InferenceServiceNotSupported, "Name", evaluation.Model.InferenceService.Name)
+	}
+
+	r.tracker.registerJob(evaluation.Resource.ID)
+
+	var benchmarkIndices []int
+	for i := range benchmarks {
+		if shared.IsBenchmarkReady(evaluation, benchmarks, i) {
+			benchmarkIndices = append(benchmarkIndices, i)
+		}
+	}
+	r.startBenchmarks(evaluation, benchmarks, benchmarkIndices, storage)
 
+	return nil
+}
+
+func (r *LocalRuntime) RunEvaluationBenchmarks(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndices []int,
+	storage abstractions.RuntimeStorage,
+) error {
+	if r.ctx == nil {
+		r.logger.Error("RunEvaluationBenchmarks called with nil context; WithContext must be called before RunEvaluationBenchmarks")
+		return fmt.Errorf("local runtime: nil context — WithContext must be called before RunEvaluationBenchmarks")
+	}
+
+	// the job was registered when it was started, registering it again would drop its PIDs
+	r.startBenchmarks(evaluation, benchmarks, benchmarkIndices, storage)
+
+	return nil
+}
+
+// startBenchmarks launches the benchmarks at benchmarkIndices, each in its own goroutine.
+func (r *LocalRuntime) startBenchmarks(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndices []int,
+	storage abstractions.RuntimeStorage,
+) {
 	// Capture job ID before launching goroutine to avoid a data race
 	// on the shared evaluation pointer.
 	jobID := evaluation.Resource.ID
 
-	r.tracker.registerJob(jobID)
-
-	for i, bench := range benchmarks {
+	for _, i := range benchmarkIndices {
+		if i < 0 || i >= len(benchmarks) {
+			continue
+		}
+		bench := benchmarks[i]
 		go func() {
 			if err := r.runBenchmark(jobID, bench, i, evaluation, r.callbackURL, storage); err != nil {
 				metrics.RecordBenchmarkRuntimeError(r.ctx, r.Name())
@@ -166,8 +284,81 @@ func (r *LocalRuntime) RunEvaluationJob(
 			}
 		}()
 	}
+}
 
-	return nil
+// localProvider returns the provider of a benchmark, which must have a local command.
+func localProvider(providerID string, storage abstractions.RuntimeStorage) (*api.ProviderResource, error) {
+	provider, err := storage.GetProvider(providerID)
+	if err != nil {
+		return nil, err
+	}
+	if provider.Runtime == nil || provider.Runtime.Local == nil || provider.Runtime.Local.Command == "" {
+		return nil, serviceerrors.NewServiceError(messages.LocalRuntimeNotEnabled, "ProviderID", providerID)
+	}
+	return provider, nil
+}
+
+// buildJobSpec builds the job spec written to job.json for a benchmark.
+func (r *LocalRuntime) buildJobSpec(
+	evaluation *api.EvaluationJobResource,
+	provider *api.ProviderResource,
+	bench api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	callbackURL *string,
+) (*shared.JobSpec, error) {
+	bench = provider.WithDefaultParameters(bench)
+	spec, err := shared.BuildJobSpec(evaluation, bench.ProviderID, &bench, benchmarkIndex, callbackURL)
+	if err != nil {
+		return nil, fmt.Errorf("build job spec: %w", err)
+	}
+	spec.CallbackToken = callbackauth.Token(r.callbackAuth, evaluation.Resource.ID)
+	shared.ApplySampling(spec, evaluation, r.sampling)
+	spec.SpecVersion, err = shared.NegotiateJobSpecVersion(provider.JobSpecVersions)
+	if err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// resolveSecretRefs replaces the secretRef://name/key parameters with the content of the
+// file name/key in the parameter secrets directory.
+func (r *LocalRuntime) resolveSecretRefs(parameters map[string]any) (map[string]any, error) {
+	return api.ResolveSecretRefs(parameters, r.readSecret)
+}
+
+// readSecret returns the content of the file name/key in the parameter secrets directory.
+func (r *LocalRuntime) readSecret(ref api.SecretRef) (string, error) {
+	if r.secretsDir == "" {
+		return "", fmt.Errorf("%s can not be resolved, parameter_secrets.dir is not configured", ref)
+	}
+	value, err := os.ReadFile(filepath.Join(r.secretsDir, filepath.Base(ref.Name), filepath.Base(ref.Key)))
+	if err != nil {
+		return "", fmt.Errorf("read parameter secret %s: %w", ref, err)
+	}
+	return strings.TrimRight(string(value), "\r\n"), nil
+}
+
+// jobEnv returns the env of the job as NAME=value, with the secretRef://name/key values read
+// from the parameter secrets directory.
+func (r *LocalRuntime) jobEnv(evaluation *api.EvaluationJobResource) ([]string, error) {
+	var env []string
+	for _, item := range evaluation.Env {
+		if item.Name == "" {
+			continue
+		}
+		value := item.Value
+		ref, isRef, err := item.SecretRef()
+		if err != nil {
+			return nil, fmt.Errorf("env %s: %w", item.Name, err)
+		}
+		if isRef {
+			if value, err = r.readSecret(ref); err != nil {
+				return nil, fmt.Errorf("env %s: %w", item.Name, err)
+			}
+		}
+		env = append(env, item.Name+"="+value)
+	}
+	return env, nil
 }
 
 // runBenchmark launches a single benchmark process. It writes the job spec,
@@ -182,34 +373,41 @@ func (r *LocalRuntime) runBenchmark(
 	callbackURL *string,
 	storage abstractions.RuntimeStorage,
 ) error {
-	provider, err := storage.GetProvider(bench.ProviderID)
+	provider, err := localProvider(bench.ProviderID, storage)
 	if err != nil {
 		return err
 	}
-	if provider.Runtime == nil || provider.Runtime.Local == nil || provider.Runtime.Local.Command == "" {
-		return serviceerrors.NewServiceError(messages.LocalRuntimeNotEnabled, "ProviderID", bench.ProviderID)
-	}
 
 	if r.tracker.isCancelled(jobID) {
 		return nil
 	}
+	if err := r.sandbox.CheckCommand(bench.ProviderID, provider.Runtime.Local.Command); err != nil {
+		return err
+	}
+	if err := r.checkTotalQuota(); err != nil {
+		return err
+	}
 
-	// Build job spec JSON using shared logic
-	spec, err := shared.BuildJobSpec(evaluation, bench.ProviderID, &bench, benchmarkIndex, callbackURL)
+	spec, err := r.buildJobSpec(evaluation, provider, bench, benchmarkIndex, callbackURL)
 	if err != nil {
-		return fmt.Errorf("build job spec: %w", err)
+		return err
+	}
+	// only the job.json of the process gets the values of the secretRef:// parameters
+	spec.Parameters, err = r.resolveSecretRefs(spec.Parameters)
+	if err != nil {
+		return fmt.Errorf("resolve parameters: %w", err)
 	}
 
-	// Create output directory: /tmp/evalhub-jobs/<job_id>/<benchmark_index>/<provider_id>/<benchmark_id>/
-	jobDir := filepath.Join(localJobsBaseDir, jobID, fmt.Sprintf("%d", benchmarkIndex), bench.ProviderID, bench.ID)
+	// Create output directory: <jobs dir>/<job_id>/<benchmark_index>/<provider_id>/<benchmark_id>/
+	jobDir := r.benchmarkDir(jobID, benchmarkIndex, bench)
 	metaDir := filepath.Join(jobDir, "meta")
 	if err := os.MkdirAll(metaDir, 0o750); err != nil {
 		return fmt.Errorf("create meta directory: %w", err)
 	}
 
-	specJSON, err := json.MarshalIndent(spec, "", "  ")
+	specJSON, err := shared.MarshalJobSpec(spec)
 	if err != nil {
-		return fmt.Errorf("marshal job spec: %w", err)
+		return err
 	}
 
 	// Write job.json
@@ -234,7 +432,14 @@ func (r *LocalRuntime) runBenchmark(
 
 	// Build command using shell interpretation
 	command := provider.Runtime.Local.Command
-	cmd := exec.Command("sh", "-c", command) // #nosec G204 -- local runtime executes provider-defined commands by design
+	script := sandboxCommand(r.sandbox, command)
+	cmd := exec.Command("sh", "-c", script) // #nosec G204 -- local runtime executes provider-defined commands by design
+	if provider.Runtime.Local.Image != "" {
+		cmd, err = r.containerCommand(provider.Runtime.Local, evaluation.Env, jobID, benchmarkIndex, jobDir, script)
+		if err != nil {
+			return err
+		}
+	}
 	// Setpgid places the child in its own process group (PGID = child PID).
 	// This is critical for two reasons:
 	//   1. cancelJob calls Kill(-PID, SIGKILL) which targets the entire process
@@ -246,14 +451,24 @@ func (r *LocalRuntime) runBenchmark(
 	setSysProcAttr(cmd)
 
 	// Set environment variables
-	cmd.Env = append(os.Environ(),
-		fmt.Sprintf("EVALHUB_JOB_SPEC_PATH=%s", absJobSpecPath),
-	)
+	cmd.Env = os.Environ()
+	releaseSandbox, err := sandboxProcess(r.sandbox, cmd, r.jobs.JobsDir(), jobDir, fmt.Sprintf("%s-%d", jobID, benchmarkIndex))
+	if err != nil {
+		return fmt.Errorf("sandbox local process: %w", err)
+	}
+	defer releaseSandbox()
+	cmd.Env = append(cmd.Env, fmt.Sprintf("EVALHUB_JOB_SPEC_PATH=%s", absJobSpecPath))
 	for _, envVar := range provider.Runtime.Local.Env {
 		if envVar.Name != "" {
 			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", envVar.Name, envVar.Value))
 		}
 	}
+	// the env of the job is appended last, so that it wins over the env of the provider
+	jobEnv, err := r.jobEnv(evaluation)
+	if err != nil {
+		return err
+	}
+	cmd.Env = append(cmd.Env, jobEnv...)
 
 	// Capture stdout/stderr to log file
 	logFilePath := filepath.Join(jobDir, "jobrun.log")
@@ -280,7 +495,8 @@ func (r *LocalRuntime) runBenchmark(
 	}
 
 	pid := cmd.Process.Pid
-	r.tracker.addPID(jobID, pid)
+	r.tracker.addPID(jobID, benchmarkIndex, pid)
+	stopQuotaWatch := r.watchJobQuota(jobID, pid)
 
 	// Close the log file — the child process has its own fd copy.
 	_ = logFile.Close()
@@ -294,6 +510,16 @@ func (r *LocalRuntime) runBenchmark(
 		"pid", pid,
 		"command", command,
 	)
+	logPath, err := filepath.Abs(logFilePath)
+	if err != nil {
+		logPath = logFilePath
+	}
+	r.recordPlacement(jobID, benchmarkIndex, &api.BenchmarkPlacement{
+		Runtime:   r.Name(),
+		PID:       pid,
+		LogPath:   logPath,
+		StartedAt: api.DateTimeToString(time.Now()),
+	}, storage)
 
 	// Reap the child process to prevent zombies. Each benchmark runs in its
 	// own goroutine, so this blocks only this benchmark's goroutine.
@@ -307,15 +533,28 @@ func (r *LocalRuntime) runBenchmark(
 	// in the same way. Until a common cross-platform approach is found for Linux,
 	// macOS, and Windows, cmd.Wait() serves as the portable solution.
 	_ = cmd.Wait()
+	quotaErr := stopQuotaWatch()
 
 	// If the job was cancelled while this goroutine was running, the directory
 	// may have been recreated after DeleteEvaluationJobResources already
 	// cleaned it up. Remove it now to prevent orphaned directories.
 	if r.tracker.isCancelled(jobID) {
-		_ = os.RemoveAll(filepath.Join(localJobsBaseDir, jobID))
+		_ = os.RemoveAll(r.jobDir(jobID))
+		return nil
 	}
+	r.recordPlacement(jobID, benchmarkIndex, &api.BenchmarkPlacement{
+		PID:        pid,
+		FinishedAt: api.DateTimeToString(time.Now()),
+	}, storage)
 
-	return nil
+	return quotaErr
+}
+
+// recordPlacement stores where the process of a benchmark runs, or when it exited.
+func (r *LocalRuntime) recordPlacement(jobID string, benchmarkIndex int, placement *api.BenchmarkPlacement, storage abstractions.RuntimeStorage) {
+	if err := storage.UpdateEvaluationJobBenchmarkPlacement(jobID, benchmarkIndex, placement); err != nil {
+		r.logger.Warn("failed to record the placement of the benchmark", "job_id", jobID, "benchmark_index", benchmarkIndex, "error", err)
+	}
 }
 
 // failBenchmark updates storage to mark a benchmark as failed.
@@ -355,7 +594,8 @@ func (r *LocalRuntime) failBenchmark(
 
 func (r *LocalRuntime) DeleteEvaluationJobResources(evaluation *api.EvaluationJobResource) error {
 	r.tracker.cancelJob(evaluation.Resource.ID)
-	jobDir := filepath.Join(localJobsBaseDir, evaluation.Resource.ID)
+	r.removeContainers(evaluation.Resource.ID)
+	jobDir := r.jobDir(evaluation.Resource.ID)
 	if err := os.RemoveAll(jobDir); err != nil {
 		r.logger.Error(
 			"failed to remove local runtime job directory",
@@ -373,6 +613,29 @@ func (r *LocalRuntime) DeleteEvaluationJobResources(evaluation *api.EvaluationJo
 	return nil
 }
 
+// CancelEvaluationBenchmarks kills the processes, and removes the containers, of the
+// benchmarks of the job at benchmarkIndices. The directories of the benchmarks are kept.
+func (r *LocalRuntime) CancelEvaluationBenchmarks(evaluation *api.EvaluationJobResource, benchmarkIndices []int) error {
+	r.tracker.cancelBenchmarks(evaluation.Resource.ID, benchmarkIndices)
+	r.removeBenchmarkContainers(evaluation.Resource.ID, benchmarkIndices)
+	r.logger.Info(
+		"cancelled local runtime benchmarks",
+		"job_id", evaluation.Resource.ID,
+		"benchmark_indices", benchmarkIndices,
+	)
+	return nil
+}
+
+// jobDir is the directory of the files of a job.
+func (r *LocalRuntime) jobDir(jobID string) string {
+	return filepath.Join(r.jobs.JobsDir(), jobID)
+}
+
+// benchmarkDir is the directory of the job spec, logs and outputs of a benchmark of a job.
+func (r *LocalRuntime) benchmarkDir(jobID string, benchmarkIndex int, bench api.EvaluationBenchmarkConfig) string {
+	return filepath.Join(r.jobDir(jobID), fmt.Sprintf("%d", benchmarkIndex), bench.ProviderID, bench.ID)
+}
+
 func (r *LocalRuntime) Name() string {
 	return "local"
 }
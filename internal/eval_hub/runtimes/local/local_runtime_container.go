@@ -0,0 +1,94 @@
+package local
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const (
+	// containerJobDir is where the directory of the benchmark is mounted in the container.
+	containerJobDir = "/eval-hub/job"
+	// containerJobIDLabel labels the containers with the job that they run a benchmark of.
+	containerJobIDLabel = "eval-hub.job-id"
+)
+
+// containerCommand returns the command that runs the script of a benchmark in a container of
+// the image of the provider, with the directory of the benchmark mounted as its working
+// directory. The provider and job env is passed through from the environment of the command.
+func (r *LocalRuntime) containerCommand(local *api.LocalRuntime, jobEnv []api.JobEnvVar, jobID string, benchmarkIndex int, benchmarkDir, script string) (*exec.Cmd, error) {
+	absDir, err := filepath.Abs(benchmarkDir)
+	if err != nil {
+		return nil, fmt.Errorf("resolve benchmark directory: %w", err)
+	}
+	args := []string{
+		"run", "--rm",
+		"--name", containerName(jobID, benchmarkIndex),
+		"--label", containerJobIDLabel + "=" + jobID,
+		"--network", r.containers.EffectiveNetwork(),
+		"--volume", absDir + ":" + containerJobDir,
+		"--workdir", containerJobDir,
+		"--env", "EVALHUB_JOB_SPEC_PATH=" + containerJobDir + "/meta/job.json",
+	}
+	for _, envVar := range local.Env {
+		if envVar.Name != "" {
+			args = append(args, "--env", envVar.Name)
+		}
+	}
+	for _, envVar := range jobEnv {
+		if envVar.Name != "" {
+			args = append(args, "--env", envVar.Name)
+		}
+	}
+	if r.containers != nil {
+		args = append(args, r.containers.Args...)
+	}
+	args = append(args, "--entrypoint", "sh", local.Image, "-c", script)
+	return exec.Command(r.containers.EffectiveEngine(), args...), nil // #nosec G204 -- local runtime executes provider-defined commands by design
+}
+
+// removeContainers force-removes the containers of a job: killing the engine CLI does not
+// stop the container that it started.
+func (r *LocalRuntime) removeContainers(jobID string) {
+	engine := r.containers.EffectiveEngine()
+	if _, err := exec.LookPath(engine); err != nil {
+		return
+	}
+	output, err := exec.Command(engine, "ps", "--all", "--quiet", "--filter", "label="+containerJobIDLabel+"="+jobID).Output() // #nosec G204 -- the engine is configured by the operator
+	if err != nil {
+		r.logger.Warn("failed to list local runtime containers", "job_id", jobID, "engine", engine, "error", err)
+		return
+	}
+	ids := strings.Fields(string(output))
+	if len(ids) == 0 {
+		return
+	}
+	if err := exec.Command(engine, append([]string{"rm", "--force"}, ids...)...).Run(); err != nil { // #nosec G204 -- the engine is configured by the operator
+		r.logger.Warn("failed to remove local runtime containers", "job_id", jobID, "engine", engine, "error", err)
+		return
+	}
+	r.logger.Info("removed local runtime containers", "job_id", jobID, "count", len(ids))
+}
+
+// removeBenchmarkContainers force-removes the containers of the benchmarks of a job at
+// benchmarkIndices, if they run in containers.
+func (r *LocalRuntime) removeBenchmarkContainers(jobID string, benchmarkIndices []int) {
+	engine := r.containers.EffectiveEngine()
+	if _, err := exec.LookPath(engine); err != nil || len(benchmarkIndices) == 0 {
+		return
+	}
+	names := make([]string, 0, len(benchmarkIndices))
+	for _, benchmarkIndex := range benchmarkIndices {
+		names = append(names, containerName(jobID, benchmarkIndex))
+	}
+	// the benchmarks that do not run in a container have no container to remove
+	_ = exec.Command(engine, append([]string{"rm", "--force"}, names...)...).Run() // #nosec G204 -- the engine is configured by the operator
+}
+
+// containerName is the name of the container of the benchmark of a job.
+func containerName(jobID string, benchmarkIndex int) string {
+	return fmt.Sprintf("evalhub-%s-%d", jobID, benchmarkIndex)
+}
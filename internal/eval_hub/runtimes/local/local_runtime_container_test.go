@@ -0,0 +1,102 @@
+package local
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+)
+
+// fakeEngine writes a container CLI that records the arguments of each of its commands, with
+// the TEST_VAR of its environment, in <dir>/<command>.args.
+func fakeEngine(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	engine := filepath.Join(dir, "fake-engine")
+	script := fmt.Sprintf(`#!/bin/sh
+out=%q/"$1".args
+{ printf '%%s\n' "$@"; echo "TEST_VAR=$TEST_VAR"; } > "$out.tmp" && mv "$out.tmp" "$out"
+if [ "$1" = ps ]; then echo container-1; fi
+`, dir)
+	if err := os.WriteFile(engine, []byte(script), 0o700); err != nil { // #nosec G306 -- the fake engine must be executable
+		t.Fatal(err)
+	}
+	return engine, dir
+}
+
+func TestRunEvaluationJobInContainer(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	jobsDir := t.TempDir()
+	engine, engineDir := fakeEngine(t)
+
+	rt := &LocalRuntime{
+		logger:     discardLogger(),
+		ctx:        testContext(t),
+		tracker:    newTracker(),
+		jobs:       &config.LocalJobsConfig{Dir: jobsDir},
+		containers: &config.LocalContainersConfig{Engine: engine, Args: []string{"--gpus=all"}},
+	}
+	providers := sampleLocalProviders(providerID, "python -m adapter")
+	providers[providerID].Runtime.Local.Image = "quay.io/eval-hub/adapter:latest"
+	benchmarks, err := handlers.GetJobBenchmarks(evaluation, nil)
+	if err != nil {
+		t.Fatalf("RunEvaluationJob failed to resolve benchmarks: %v", err)
+	}
+	if err := rt.RunEvaluationJob(evaluation, benchmarks, &fakeStorage{providerConfigs: providers}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	argsFile := filepath.Join(engineDir, "run.args")
+	waitForFile(t, argsFile, 5*time.Second)
+	output, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	benchmarkDir, _ := filepath.Abs(filepath.Join(jobsDir, "job-1", "0", providerID, "bench-1"))
+	want := strings.Join([]string{
+		"run", "--rm",
+		"--name", "evalhub-job-1-0",
+		"--label", "eval-hub.job-id=job-1",
+		"--network", "host",
+		"--volume", benchmarkDir + ":/eval-hub/job",
+		"--workdir", "/eval-hub/job",
+		"--env", "EVALHUB_JOB_SPEC_PATH=/eval-hub/job/meta/job.json",
+		"--env", "TEST_VAR",
+		"--gpus=all",
+		"--entrypoint", "sh", "quay.io/eval-hub/adapter:latest", "-c", "python -m adapter",
+		// the provider env is passed to the container through the environment of the CLI
+		"TEST_VAR=test_value",
+	}, "\n") + "\n"
+	if string(output) != want {
+		t.Fatalf("expected the container to run with\n%s\ngot\n%s", want, output)
+	}
+}
+
+func TestDeleteEvaluationJobResourcesRemovesContainers(t *testing.T) {
+	engine, engineDir := fakeEngine(t)
+	rt := &LocalRuntime{
+		logger:     discardLogger(),
+		tracker:    newTracker(),
+		jobs:       &config.LocalJobsConfig{Dir: t.TempDir()},
+		containers: &config.LocalContainersConfig{Engine: engine},
+	}
+
+	if err := rt.DeleteEvaluationJobResources(sampleEvaluation("provider-1")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	ps, err := os.ReadFile(filepath.Join(engineDir, "ps.args"))
+	if err != nil || !strings.Contains(string(ps), "label=eval-hub.job-id=job-1") {
+		t.Fatalf("expected the containers of the job to be listed, got %q (%v)", ps, err)
+	}
+	rm, err := os.ReadFile(filepath.Join(engineDir, "rm.args"))
+	if err != nil || !strings.HasPrefix(string(rm), "rm\n--force\ncontainer-1\n") {
+		t.Fatalf("expected the containers of the job to be removed, got %q (%v)", rm, err)
+	}
+}
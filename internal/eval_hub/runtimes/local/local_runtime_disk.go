@@ -0,0 +1,166 @@
+package local
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/metrics"
+)
+
+const megabyte = 1024 * 1024
+
+// jobQuotaInterval is how often the directory of a job is measured while its benchmarks run.
+var jobQuotaInterval = 5 * time.Second
+
+// checkTotalQuota returns an error when the directory of the jobs exceeds its quota.
+func (r *LocalRuntime) checkTotalQuota() error {
+	if r.jobs == nil || r.jobs.MaxTotalSizeMB <= 0 {
+		return nil
+	}
+	size, err := dirSize(r.jobs.JobsDir())
+	if err != nil {
+		return fmt.Errorf("measure the local jobs directory: %w", err)
+	}
+	if size >= int64(r.jobs.MaxTotalSizeMB)*megabyte {
+		metrics.RecordLocalJobsQuotaExceeded(r.ctx, "total")
+		return fmt.Errorf("the local jobs directory uses %d MB, local_jobs.max_total_size_mb is %d MB", size/megabyte, r.jobs.MaxTotalSizeMB)
+	}
+	return nil
+}
+
+// watchJobQuota kills the process group of pid when the directory of the job grows past its
+// quota. The returned function stops the watch and returns the error of an exceeded quota.
+func (r *LocalRuntime) watchJobQuota(jobID string, pid int) func() error {
+	if r.jobs == nil || r.jobs.MaxJobSizeMB <= 0 {
+		return func() error { return nil }
+	}
+	limit := int64(r.jobs.MaxJobSizeMB) * megabyte
+	var exceeded atomic.Int64
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(jobQuotaInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			size, err := dirSize(r.jobDir(jobID))
+			if err != nil || size <= limit {
+				continue
+			}
+			exceeded.Store(size)
+			metrics.RecordLocalJobsQuotaExceeded(r.ctx, "job")
+			r.logger.Warn("local runtime job exceeded its disk quota", "job_id", jobID, "pid", pid, "size_mb", size/megabyte, "max_job_size_mb", r.jobs.MaxJobSizeMB)
+			_ = killProcessGroup(pid)
+			r.removeContainers(jobID)
+			return
+		}
+	}()
+	return func() error {
+		close(done)
+		<-stopped
+		if size := exceeded.Load(); size > 0 {
+			return fmt.Errorf("the job used %d MB, local_jobs.max_job_size_mb is %d MB", size/megabyte, r.jobs.MaxJobSizeMB)
+		}
+		return nil
+	}
+}
+
+// CleanupJobFiles removes the directories of the jobs that were not written to for longer than
+// local_jobs.retention, and reports the disk usage of the jobs directory.
+func (r *LocalRuntime) CleanupJobFiles(ctx context.Context) error {
+	jobsDir := r.jobs.JobsDir()
+	entries, err := os.ReadDir(jobsDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		metrics.RecordLocalJobsDiskUsage(ctx, 0)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read the local jobs directory: %w", err)
+	}
+	if r.jobs != nil && r.jobs.Retention > 0 {
+		cutoff := time.Now().Add(-r.jobs.Retention)
+		removed := 0
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			jobDir := filepath.Join(jobsDir, entry.Name())
+			modified, err := latestModTime(jobDir)
+			if err != nil || modified.After(cutoff) {
+				continue
+			}
+			if err := os.RemoveAll(jobDir); err != nil {
+				r.logger.Warn("failed to remove expired local runtime job directory", "job_id", entry.Name(), "error", err)
+				continue
+			}
+			removed++
+		}
+		if removed > 0 {
+			r.logger.Info("removed expired local runtime job directories", "count", removed, "retention", r.jobs.Retention)
+			metrics.RecordLocalJobsRemoved(ctx, removed)
+		}
+	}
+	size, err := dirSize(jobsDir)
+	if err != nil {
+		return fmt.Errorf("measure the local jobs directory: %w", err)
+	}
+	metrics.RecordLocalJobsDiskUsage(ctx, size)
+	return nil
+}
+
+// dirSize returns the size of the regular files under dir, files removed while it is walked
+// are skipped.
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// latestModTime returns when a file under dir, or dir itself, was last modified.
+func latestModTime(dir string) (time.Time, error) {
+	var latest time.Time
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+		return nil
+	})
+	return latest, err
+}
@@ -0,0 +1,152 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestRunEvaluationJobConfiguredJobsDir(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	jobsDir := t.TempDir()
+
+	rt := &LocalRuntime{
+		logger:  discardLogger(),
+		ctx:     testContext(t),
+		tracker: newTracker(),
+		jobs:    &config.LocalJobsConfig{Dir: jobsDir},
+	}
+	benchmarks, err := handlers.GetJobBenchmarks(evaluation, nil)
+	if err != nil {
+		t.Fatalf("RunEvaluationJob failed to resolve benchmarks: %v", err)
+	}
+	if err := rt.RunEvaluationJob(evaluation, benchmarks, &fakeStorage{providerConfigs: sampleLocalProviders(providerID, "true")}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	waitForFile(t, filepath.Join(jobsDir, "job-1", "0", providerID, "bench-1", "meta", "job.json"), 5*time.Second)
+}
+
+func TestRunEvaluationJobTotalQuotaExceeded(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	jobsDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(jobsDir, "old.bin"), make([]byte, megabyte), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	statusCh := make(chan *api.StatusEvent, 1)
+
+	rt := &LocalRuntime{
+		logger:  discardLogger(),
+		ctx:     testContext(t),
+		tracker: newTracker(),
+		jobs:    &config.LocalJobsConfig{Dir: jobsDir, MaxTotalSizeMB: 1},
+	}
+	benchmarks, err := handlers.GetJobBenchmarks(evaluation, nil)
+	if err != nil {
+		t.Fatalf("RunEvaluationJob failed to resolve benchmarks: %v", err)
+	}
+	storage := &fakeStorage{runStatusChan: statusCh, providerConfigs: sampleLocalProviders(providerID, "true")}
+	if err := rt.RunEvaluationJob(evaluation, benchmarks, storage); err != nil {
+		t.Fatalf("expected no synchronous error, got %v", err)
+	}
+
+	select {
+	case runStatus := <-statusCh:
+		if runStatus.BenchmarkStatusEvent.Status != api.StateFailed || !strings.Contains(runStatus.BenchmarkStatusEvent.ErrorMessage.Message, "max_total_size_mb") {
+			t.Fatalf("expected the benchmark to fail on the total quota, got %+v", runStatus.BenchmarkStatusEvent)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for failed benchmark status update")
+	}
+	if _, err := os.Stat(filepath.Join(jobsDir, "job-1")); !os.IsNotExist(err) {
+		t.Fatalf("expected no job directory, got %v", err)
+	}
+}
+
+func TestRunEvaluationJobJobQuotaExceeded(t *testing.T) {
+	interval := jobQuotaInterval
+	jobQuotaInterval = 10 * time.Millisecond
+	t.Cleanup(func() { jobQuotaInterval = interval })
+
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	statusCh := make(chan *api.StatusEvent, 1)
+
+	rt := &LocalRuntime{
+		logger:  discardLogger(),
+		ctx:     testContext(t),
+		tracker: newTracker(),
+		jobs:    &config.LocalJobsConfig{Dir: t.TempDir(), MaxJobSizeMB: 1},
+	}
+	benchmarks, err := handlers.GetJobBenchmarks(evaluation, nil)
+	if err != nil {
+		t.Fatalf("RunEvaluationJob failed to resolve benchmarks: %v", err)
+	}
+	command := "head -c 2097152 /dev/zero > output.bin; sleep 30"
+	storage := &fakeStorage{runStatusChan: statusCh, providerConfigs: sampleLocalProviders(providerID, "cd \"$(dirname \"$EVALHUB_JOB_SPEC_PATH\")\" && "+command)}
+	if err := rt.RunEvaluationJob(evaluation, benchmarks, storage); err != nil {
+		t.Fatalf("expected no synchronous error, got %v", err)
+	}
+
+	select {
+	case runStatus := <-statusCh:
+		if runStatus.BenchmarkStatusEvent.Status != api.StateFailed || !strings.Contains(runStatus.BenchmarkStatusEvent.ErrorMessage.Message, "max_job_size_mb") {
+			t.Fatalf("expected the benchmark to fail on the job quota, got %+v", runStatus.BenchmarkStatusEvent)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the benchmark to be killed")
+	}
+}
+
+func TestCleanupJobFiles(t *testing.T) {
+	jobsDir := t.TempDir()
+	expired := filepath.Join(jobsDir, "job-expired", "0", "provider-1", "bench-1")
+	recent := filepath.Join(jobsDir, "job-recent", "0", "provider-1", "bench-1")
+	for _, dir := range []string{expired, recent} {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, "jobrun.log"), []byte("log"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := filepath.Walk(filepath.Join(jobsDir, "job-expired"), func(path string, _ os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	rt := &LocalRuntime{
+		logger:  discardLogger(),
+		tracker: newTracker(),
+		jobs:    &config.LocalJobsConfig{Dir: jobsDir, Retention: time.Hour},
+	}
+	if err := rt.CleanupJobFiles(testContext(t)); err != nil {
+		t.Fatalf("CleanupJobFiles: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(jobsDir, "job-expired")); !os.IsNotExist(err) {
+		t.Errorf("expected the expired job directory to be removed, got %v", err)
+	}
+	if _, err := os.Stat(recent); err != nil {
+		t.Errorf("expected the recent job directory to be kept, got %v", err)
+	}
+
+	// a missing jobs directory is nothing to clean up
+	rt.jobs.Dir = filepath.Join(jobsDir, "missing")
+	if err := rt.CleanupJobFiles(testContext(t)); err != nil {
+		t.Fatalf("CleanupJobFiles of a missing directory: %v", err)
+	}
+}
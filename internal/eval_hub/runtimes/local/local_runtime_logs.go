@@ -57,8 +57,7 @@ func (r *LocalRuntime) readBenchmarkLogs(
 	opts api.EvaluationLogOptions,
 	includeHeader bool,
 ) (string, error) {
-	jobDir := filepath.Join(localJobsBaseDir, jobID, fmt.Sprintf("%d", benchmarkIndex), bench.ProviderID, bench.ID)
-	logFilePath := filepath.Join(jobDir, "jobrun.log")
+	logFilePath := filepath.Join(r.benchmarkDir(jobID, benchmarkIndex, bench), "jobrun.log")
 	lines, err := shared.TailFileLines(logFilePath, opts.TailLines)
 	if err != nil {
 		return "", fmt.Errorf("read local benchmark logs: %w", err)
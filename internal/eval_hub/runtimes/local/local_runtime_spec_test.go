@@ -0,0 +1,69 @@
+package local
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestGetEvaluationJobSpec(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	providers := sampleLocalProviders(providerID, "true")
+	provider := providers[providerID]
+	provider.Benchmarks = append(provider.Benchmarks, api.BenchmarkResource{
+		ID:         "bench-1",
+		Parameters: map[string]any{"foo": "default", "batch_size": 8},
+	})
+	providers[providerID] = provider
+
+	callbackURL := "http://localhost:8080"
+	rt := &LocalRuntime{
+		logger:       discardLogger(),
+		ctx:          testContext(t),
+		tracker:      newTracker(),
+		callbackURL:  &callbackURL,
+		callbackAuth: &config.CallbackAuthConfig{Enabled: true, Secret: "secret"},
+	}
+	storage := &fakeStorage{providerConfigs: providers}
+
+	data, err := rt.GetEvaluationJobSpec(evaluation, evaluation.Benchmarks, 0, 0, storage)
+	if err != nil {
+		t.Fatalf("GetEvaluationJobSpec() error: %v", err)
+	}
+	var spec shared.JobSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatalf("expected valid JSON, got %v", err)
+	}
+	if spec.JobID != "job-1" || spec.BenchmarkID != "bench-1" || spec.ExperimentName != "exp-1" {
+		t.Fatalf("unexpected job spec %s", data)
+	}
+	if spec.CallbackURL == nil || *spec.CallbackURL != callbackURL {
+		t.Fatalf("expected callback_url %q, got %v", callbackURL, spec.CallbackURL)
+	}
+	if spec.CallbackToken != "" {
+		t.Fatal("expected the callback token to be left out")
+	}
+	if spec.Parameters["foo"] != "bar" || spec.Parameters["batch_size"] != float64(8) {
+		t.Fatalf("expected the parameters merged over the provider defaults, got %v", spec.Parameters)
+	}
+	if spec.NumExamples == nil || *spec.NumExamples != 5 {
+		t.Fatalf("expected num_examples 5, got %v", spec.NumExamples)
+	}
+
+	for name, indices := range map[string][2]int{"benchmark": {1, 0}, "shard": {0, 1}} {
+		t.Run("unknown "+name, func(t *testing.T) {
+			_, err := rt.GetEvaluationJobSpec(evaluation, evaluation.Benchmarks, indices[0], indices[1], storage)
+			var serviceErr *serviceerrors.ServiceError
+			if !errors.As(err, &serviceErr) || serviceErr.MessageCode().GetStatusCode() != http.StatusNotFound {
+				t.Fatalf("expected a not found error, got %v", err)
+			}
+		})
+	}
+}
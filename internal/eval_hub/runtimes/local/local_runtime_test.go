@@ -19,7 +19,7 @@ import (
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
-// fakeStorage implements [abstractions.Storage] for testing.
+// fakeStorage implements [abstractions.RuntimeStorage] for testing.
 type fakeStorage struct {
 	logger            *slog.Logger
 	called            bool
@@ -52,9 +52,15 @@ func (f *fakeStorage) GetEvaluationJobs(_ *abstractions.QueryFilter) (*abstracti
 	return nil, nil
 }
 func (f *fakeStorage) DeleteEvaluationJob(_ string) error { return nil }
-func (f *fakeStorage) UpdateEvaluationJobStatus(_ string, _ api.OverallState, _ *api.MessageInfo) error {
+func (f *fakeStorage) PatchEvaluationJob(_ string, _ *api.Patch) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) UpdateEvaluationJobOwner(_ string, _ api.User) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) UpdateEvaluationJobStatus(_ string, _ api.OverallState, _ *api.MessageInfo) (api.OverallState, error) {
 	f.called = true
-	return nil
+	return "", nil
 }
 func (f *fakeStorage) CreateCollection(_ *api.CollectionResource) error { return nil }
 func (f *fakeStorage) GetCollection(id string) (*api.CollectionResource, error) {
@@ -73,6 +79,113 @@ func (f *fakeStorage) UpdateCollection(_ string, _ *api.CollectionConfig) (*api.
 	return nil, nil
 }
 func (f *fakeStorage) DeleteCollection(_ string) error { return nil }
+func (f *fakeStorage) CreateBaseline(_ *api.BaselineResource) error {
+	return nil
+}
+func (f *fakeStorage) GetBaseline(_ string) (*api.BaselineResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) GetBaselines(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.BaselineResource], error) {
+	return nil, nil
+}
+func (f *fakeStorage) DeleteBaseline(_ string) error { return nil }
+func (f *fakeStorage) GetEvaluationJobFindings(_ string, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.FindingResource], error) {
+	return &abstractions.QueryResults[api.FindingResource]{}, nil
+}
+func (f *fakeStorage) GetEvaluationMetricsHistory(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.MetricPoint], error) {
+	return &abstractions.QueryResults[api.MetricPoint]{}, nil
+}
+func (f *fakeStorage) GetEvaluationMetricNames(_ *abstractions.QueryFilter) ([]string, error) {
+	return nil, nil
+}
+func (f *fakeStorage) PutEvaluationJobArtifact(_ string, _ *api.ArtifactResource) error { return nil }
+func (f *fakeStorage) GetEvaluationJobArtifacts(_ string, _ int, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.ArtifactResource], error) {
+	return &abstractions.QueryResults[api.ArtifactResource]{}, nil
+}
+func (f *fakeStorage) PutEvaluationJobGeneration(_ string, _ *api.ArtifactResource) error {
+	return nil
+}
+func (f *fakeStorage) GetEvaluationJobGeneration(_ string, _ int, _ string) (*api.ArtifactResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) GetEvaluationJobGenerations(_ string, _ int, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.ArtifactResource], error) {
+	return &abstractions.QueryResults[api.ArtifactResource]{}, nil
+}
+func (f *fakeStorage) ReviewEvaluationJob(_ string, _ api.User, _ *api.ReviewDecision) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) UpdateEvaluationJobModelMetadata(_ string, _ *api.ModelMetadata) error {
+	return nil
+}
+func (f *fakeStorage) UpdateEvaluationJobBenchmarkPlacement(_ string, _ int, _ *api.BenchmarkPlacement) error {
+	return nil
+}
+func (f *fakeStorage) PinEvaluationJobAdapterImage(_ string, _ string, pinned string) (string, error) {
+	return pinned, nil
+}
+func (f *fakeStorage) LinkEvaluationJobExperiment(_ string, _ string, _ string) error {
+	return nil
+}
+func (f *fakeStorage) AddStatusDeadLetter(_ *api.StatusDeadLetter) error {
+	return nil
+}
+func (f *fakeStorage) GetStatusDeadLetter(_ string) (*api.StatusDeadLetter, error) {
+	return nil, nil
+}
+func (f *fakeStorage) GetStatusDeadLetters(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.StatusDeadLetter], error) {
+	return &abstractions.QueryResults[api.StatusDeadLetter]{}, nil
+}
+func (f *fakeStorage) DeleteStatusDeadLetter(_ string) error {
+	return nil
+}
+func (f *fakeStorage) GetEvaluationJobsWithoutExperiment(_ int) ([]api.Resource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) AddEvaluationJobBenchmarkMetrics(_ string, _ map[int]map[string]any) (*api.EvaluationJobResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) PutTagPolicy(_ *api.TagPolicyResource) error {
+	return nil
+}
+func (f *fakeStorage) GetTagPolicy() (*api.TagPolicyResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) PutRedactionPolicy(_ *api.RedactionPolicyResource) error {
+	return nil
+}
+func (f *fakeStorage) GetRedactionPolicy() (*api.RedactionPolicyResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) AddEvaluationJobRedaction(_ string, _ *api.RedactionRecord) error {
+	return nil
+}
+func (f *fakeStorage) GetEvaluationJobRedactions(_ string, _ *abstractions.QueryFilter) (*abstractions.QueryResults[api.RedactionRecord], error) {
+	return &abstractions.QueryResults[api.RedactionRecord]{}, nil
+}
+func (f *fakeStorage) CreateEvaluationView(_ *api.EvaluationViewResource) error {
+	return nil
+}
+func (f *fakeStorage) GetEvaluationView(_ string) (*api.EvaluationViewResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) GetEvaluationViews(_ *abstractions.QueryFilter) (*abstractions.QueryResults[api.EvaluationViewResource], error) {
+	return nil, nil
+}
+func (f *fakeStorage) UpdateEvaluationView(_ string, _ *api.EvaluationViewConfig) (*api.EvaluationViewResource, error) {
+	return nil, nil
+}
+func (f *fakeStorage) DeleteEvaluationView(_ string) error {
+	return nil
+}
+func (f *fakeStorage) PutCachedBenchmarkResult(_ *abstractions.CachedBenchmarkResult) error {
+	return nil
+}
+func (f *fakeStorage) GetCachedBenchmarkResult(_ string, _ time.Time) (*abstractions.CachedBenchmarkResult, error) {
+	return nil, nil
+}
+func (f *fakeStorage) GetBenchmarkDurations(_ []abstractions.BenchmarkDurationKey) (map[abstractions.BenchmarkDurationKey]time.Duration, error) {
+	return nil, nil
+}
 func (f *fakeStorage) LoadSystemResources(_ map[string]api.CollectionResource, _ map[string]api.ProviderResource) error {
 	return nil
 }
@@ -96,7 +209,7 @@ func (f *fakeStorage) PatchProvider(_ string, _ *api.Patch) (*api.ProviderResour
 	return nil, nil
 }
 
-func (f *fakeStorage) WithLogger(logger *slog.Logger) abstractions.Storage {
+func (f *fakeStorage) WithLogger(logger *slog.Logger) *fakeStorage {
 	return &fakeStorage{
 		logger:        logger,
 		ctx:           f.ctx,
@@ -105,11 +218,11 @@ func (f *fakeStorage) WithLogger(logger *slog.Logger) abstractions.Storage {
 	}
 }
 
-func (f *fakeStorage) WithTenant(_ api.Tenant) abstractions.Storage {
+func (f *fakeStorage) WithTenant(_ api.Tenant) *fakeStorage {
 	return f
 }
 
-func (f *fakeStorage) WithContext(ctx context.Context) abstractions.Storage {
+func (f *fakeStorage) WithContext(ctx context.Context) *fakeStorage {
 	return &fakeStorage{
 		logger:        f.logger,
 		ctx:           ctx,
@@ -118,7 +231,7 @@ func (f *fakeStorage) WithContext(ctx context.Context) abstractions.Storage {
 	}
 }
 
-func (f *fakeStorage) WithOwner(owner api.User) abstractions.Storage {
+func (f *fakeStorage) WithOwner(owner api.User) *fakeStorage {
 	return f
 }
 
@@ -127,7 +240,7 @@ func discardLogger() *slog.Logger {
 }
 
 func newTracker() jobTracker {
-	return &pidTracker{pids: make(map[string][]int), cancelled: make(map[string]bool)}
+	return &pidTracker{pids: make(map[string]map[int][]int), cancelled: make(map[string]bool)}
 }
 
 // testContext returns a context with a 10-second deadline tied to t.Cleanup.
@@ -185,13 +298,13 @@ func sampleLocalProviders(providerID, command string) map[string]api.ProviderRes
 }
 
 func localJobDir(jobID string, benchmarkIndex int, providerID, benchmarkID string) string {
-	return filepath.Join(localJobsBaseDir, jobID, fmt.Sprintf("%d", benchmarkIndex), providerID, benchmarkID)
+	return filepath.Join(config.DefaultLocalJobsDir, jobID, fmt.Sprintf("%d", benchmarkIndex), providerID, benchmarkID)
 }
 
 func cleanupDir(t *testing.T, jobID string) {
 	t.Helper()
 	t.Cleanup(func() {
-		_ = os.RemoveAll(filepath.Join(localJobsBaseDir, jobID))
+		_ = os.RemoveAll(filepath.Join(config.DefaultLocalJobsDir, jobID))
 	})
 }
 
@@ -321,6 +434,34 @@ func TestRunEvaluationJobWritesJobSpec(t *testing.T) {
 	}
 }
 
+func TestResolveSecretRefs(t *testing.T) {
+	secretsDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(secretsDir, "judge-credentials"), 0o700); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(secretsDir, "judge-credentials", "api-key"), []byte("sk-judge\n"), 0o600); err != nil {
+		t.Fatalf("write secret: %v", err)
+	}
+	parameters := map[string]any{"judge": map[string]any{"api_key": "secretRef://judge-credentials/api-key"}, "limit": 5}
+
+	rt := &LocalRuntime{logger: discardLogger(), secretsDir: secretsDir}
+	resolved, err := rt.resolveSecretRefs(parameters)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if resolved["judge"].(map[string]any)["api_key"] != "sk-judge" || resolved["limit"] != 5 {
+		t.Fatalf("expected the secret value in the parameters, got %v", resolved)
+	}
+	if parameters["judge"].(map[string]any)["api_key"] != "secretRef://judge-credentials/api-key" {
+		t.Fatalf("expected the parameters of the job to keep the reference, got %v", parameters)
+	}
+
+	rt.secretsDir = ""
+	if _, err := rt.resolveSecretRefs(parameters); err == nil || !strings.Contains(err.Error(), "parameter_secrets.dir") {
+		t.Fatalf("expected an error without a secrets directory, got %v", err)
+	}
+}
+
 func TestRunEvaluationJobPassesEnvVar(t *testing.T) {
 	providerID := "provider-1"
 	evaluation := sampleEvaluation(providerID)
@@ -402,6 +543,28 @@ func TestRunEvaluationJobNoBenchmarks(t *testing.T) {
 	}
 }
 
+func TestRunEvaluationJobInferenceServiceNotSupported(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	evaluation.Model = api.ModelRef{Name: "granite", InferenceService: &api.InferenceServiceRef{Name: "granite"}}
+
+	rt := &LocalRuntime{
+		logger:  discardLogger(),
+		ctx:     context.Background(),
+		tracker: newTracker(),
+	}
+
+	storage := &fakeStorage{providerConfigs: sampleLocalProviders(providerID, "true")}
+
+	err := rt.RunEvaluationJob(evaluation, evaluation.Benchmarks, storage)
+	if err == nil {
+		t.Fatal("expected error, got nil")
+	}
+	if !strings.Contains(err.Error(), "InferenceService 'granite'") {
+		t.Fatalf("expected error to name the InferenceService, got %q", err.Error())
+	}
+}
+
 func TestRunEvaluationJobProviderNotFound(t *testing.T) {
 	providerID := "provider-1"
 	evaluation := sampleEvaluation(providerID)
@@ -410,7 +573,7 @@ func TestRunEvaluationJobProviderNotFound(t *testing.T) {
 	logger := discardLogger()
 	statusCh := make(chan *api.StatusEvent, 1)
 	storage := &fakeStorage{logger: logger, ctx: tctx, runStatusChan: statusCh}
-	var store abstractions.Storage = storage
+	var store abstractions.RuntimeStorage = storage
 
 	// Use empty providers map so provider is not found
 	rt := &LocalRuntime{
@@ -558,7 +721,7 @@ func TestRunEvaluationJobProcessFailureNoCallback(t *testing.T) {
 	}
 
 	storage := &fakeStorage{logger: logger, ctx: tctx, runStatusChan: statusCh, providerConfigs: providers}
-	var store abstractions.Storage = storage
+	var store abstractions.RuntimeStorage = storage
 
 	benchmarks, err := handlers.GetJobBenchmarks(evaluation, nil)
 	if err != nil {
@@ -603,7 +766,7 @@ func TestRunEvaluationJobCancelledNoFailure(t *testing.T) {
 	}
 
 	storage := &fakeStorage{logger: logger, ctx: tctx, runStatusChan: statusCh, providerConfigs: providers}
-	var store abstractions.Storage = storage
+	var store abstractions.RuntimeStorage = storage
 
 	benchmarks, err := handlers.GetJobBenchmarks(evaluation, nil)
 	if err != nil {
@@ -632,6 +795,49 @@ func TestRunEvaluationJobCancelledNoFailure(t *testing.T) {
 	}
 }
 
+func TestCancelEvaluationBenchmarksKeepsTheJob(t *testing.T) {
+	providerID := "provider-1"
+	evaluation := sampleEvaluation(providerID)
+	cleanupDir(t, "job-1")
+
+	dirName := localJobDir("job-1", 0, providerID, "bench-1")
+	startedPath := filepath.Join(dirName, "started")
+	finishedPath := filepath.Join(dirName, "finished")
+	providers := sampleLocalProviders(providerID, fmt.Sprintf("touch %s && sleep 1 && touch %s", startedPath, finishedPath))
+
+	tctx := testContext(t)
+	logger := discardLogger()
+	rt := &LocalRuntime{
+		logger:  logger,
+		ctx:     tctx,
+		tracker: newTracker(),
+	}
+	storage := &fakeStorage{logger: logger, ctx: tctx, runStatusChan: make(chan *api.StatusEvent, 1), providerConfigs: providers}
+
+	benchmarks, err := handlers.GetJobBenchmarks(evaluation, nil)
+	if err != nil {
+		t.Fatalf("RunEvaluationJob failed to resolve benchmarks: %v", err)
+	}
+	if err := rt.RunEvaluationJob(evaluation, benchmarks, storage); err != nil {
+		t.Fatalf("expected no synchronous error, got %v", err)
+	}
+	waitForFile(t, startedPath, 5*time.Second)
+
+	if err := rt.CancelEvaluationBenchmarks(evaluation, []int{0}); err != nil {
+		t.Fatalf("expected no error on cancel, got %v", err)
+	}
+	time.Sleep(1500 * time.Millisecond)
+	if _, err := os.Stat(finishedPath); !os.IsNotExist(err) {
+		t.Fatalf("expected the process of the benchmark to be killed before it finished")
+	}
+	if _, err := os.Stat(startedPath); err != nil {
+		t.Fatalf("expected the directory of the benchmark to be kept, got %v", err)
+	}
+	if rt.tracker.isCancelled("job-1") {
+		t.Fatalf("expected the job not to be cancelled")
+	}
+}
+
 func TestRunEvaluationJobMultipleBenchmarks(t *testing.T) {
 	providerID := "provider-1"
 	evaluation := sampleEvaluation(providerID)
@@ -731,7 +937,7 @@ func TestRunEvaluationJobMultipleBenchmarksPartialFailure(t *testing.T) {
 	logger := discardLogger()
 	statusCh := make(chan *api.StatusEvent, 2)
 	storage := &fakeStorage{logger: logger, ctx: tctx, runStatusChan: statusCh, providerConfigs: providers}
-	var store abstractions.Storage = storage
+	var store abstractions.RuntimeStorage = storage
 
 	rt := &LocalRuntime{
 		logger:  logger,
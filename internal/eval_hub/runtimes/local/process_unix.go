@@ -17,3 +17,9 @@ func killProcessGroup(pid int) error {
 func setSysProcAttr(cmd *exec.Cmd) {
 	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 }
+
+// setCredential runs the command as the sandbox user.
+func setCredential(cmd *exec.Cmd, runAs sandboxUser) error {
+	cmd.SysProcAttr.Credential = &syscall.Credential{Uid: runAs.uid, Gid: runAs.gid}
+	return nil
+}
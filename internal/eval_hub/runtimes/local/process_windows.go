@@ -3,6 +3,7 @@
 package local
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"strconv"
@@ -29,3 +30,8 @@ func killProcessGroup(pid int) error {
 
 // setSysProcAttr is a no-op on Windows (no Setpgid equivalent).
 func setSysProcAttr(cmd *exec.Cmd) {}
+
+// setCredential is not supported on Windows.
+func setCredential(cmd *exec.Cmd, runAs sandboxUser) error {
+	return errors.New("local_sandbox.user is not supported on Windows")
+}
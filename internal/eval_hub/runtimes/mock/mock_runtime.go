@@ -0,0 +1,353 @@
+// Package mock is a runtime that simulates the benchmarks instead of running their adapters:
+// each benchmark reports running, then completes with random metrics or fails, after a
+// random duration. It lets operators load test the storage, webhooks and dashboards of a
+// deployment without consuming GPU time.
+package mock
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const mockLogContainerName = "mock"
+
+// simulator holds the random source and the running jobs, shared by the scoped copies of the
+// runtime.
+type simulator struct {
+	mu     sync.Mutex
+	random *rand.Rand
+	jobs   map[string]*simulatedJob
+}
+
+// simulatedJob is a job with running benchmarks, whose context is cancelled when the job is.
+type simulatedJob struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running int
+}
+
+type MockRuntime struct {
+	logger *slog.Logger
+	ctx    context.Context
+	config *config.MockRuntimeConfig
+	sim    *simulator
+}
+
+func NewMockRuntime(
+	logger *slog.Logger,
+	serviceConfig *config.Config,
+) (abstractions.Runtime, error) {
+	var mockConfig *config.MockRuntimeConfig
+	if serviceConfig != nil {
+		mockConfig = serviceConfig.MockRuntime
+	}
+	if err := mockConfig.Validate(); err != nil {
+		return nil, err
+	}
+	seed := rand.Uint64()
+	if mockConfig != nil && mockConfig.Seed != 0 {
+		seed = mockConfig.Seed
+	}
+	return &MockRuntime{
+		logger: logger,
+		config: mockConfig,
+		sim: &simulator{
+			random: rand.New(rand.NewPCG(seed, seed)), // #nosec G404 -- simulated results, not security sensitive
+			jobs:   make(map[string]*simulatedJob),
+		},
+	}, nil
+}
+
+func (r *MockRuntime) WithLogger(logger *slog.Logger) abstractions.Runtime {
+	return &MockRuntime{
+		logger: logger,
+		ctx:    r.ctx,
+		config: r.config,
+		sim:    r.sim,
+	}
+}
+
+func (r *MockRuntime) WithContext(ctx context.Context) abstractions.Runtime {
+	return &MockRuntime{
+		logger: r.logger,
+		ctx:    ctx,
+		config: r.config,
+		sim:    r.sim,
+	}
+}
+
+func (r *MockRuntime) Name() string {
+	return api.RuntimeMock
+}
+
+func (r *MockRuntime) RunEvaluationJob(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	storage abstractions.RuntimeStorage,
+) error {
+	if len(benchmarks) == 0 {
+		return serviceerrors.NewServiceError(messages.EvaluationJobEmpty, "EvaluationJobID", evaluation.Resource.ID)
+	}
+
+	var benchmarkIndices []int
+	for i := range benchmarks {
+		if shared.IsBenchmarkReady(evaluation, benchmarks, i) {
+			benchmarkIndices = append(benchmarkIndices, i)
+		}
+	}
+	r.startBenchmarks(evaluation, benchmarks, benchmarkIndices, storage)
+
+	return nil
+}
+
+func (r *MockRuntime) RunEvaluationBenchmarks(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndices []int,
+	storage abstractions.RuntimeStorage,
+) error {
+	r.startBenchmarks(evaluation, benchmarks, benchmarkIndices, storage)
+	return nil
+}
+
+// startBenchmarks simulates the benchmarks at benchmarkIndices, each in its own goroutine.
+func (r *MockRuntime) startBenchmarks(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndices []int,
+	storage abstractions.RuntimeStorage,
+) {
+	jobID := evaluation.Resource.ID
+	for _, i := range benchmarkIndices {
+		if i < 0 || i >= len(benchmarks) {
+			continue
+		}
+		bench := benchmarks[i]
+		jobCtx := r.sim.start(jobID)
+		go func() {
+			defer r.sim.finish(jobID)
+			r.runBenchmark(jobCtx, jobID, bench, i, storage)
+		}()
+	}
+}
+
+// runBenchmark reports the benchmark running, waits for its simulated duration and reports
+// its result. Nothing more is reported once the job is cancelled.
+func (r *MockRuntime) runBenchmark(
+	ctx context.Context,
+	jobID string,
+	bench api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	storage abstractions.RuntimeStorage,
+) {
+	duration, failed, metrics := r.sim.outcome(r.config)
+	startedAt := time.Now()
+	r.logger.Info(
+		"mock runtime benchmark started",
+		"job_id", jobID,
+		"benchmark_id", bench.ID,
+		"benchmark_index", benchmarkIndex,
+		"provider_id", bench.ProviderID,
+		"duration", duration,
+		"failed", failed,
+	)
+	r.updateBenchmark(jobID, storage, &api.BenchmarkStatusEvent{
+		ProviderID:     bench.ProviderID,
+		ID:             bench.ID,
+		BenchmarkIndex: benchmarkIndex,
+		Status:         api.StateRunning,
+		Phase:          api.JobPhaseRunningEvaluation,
+		StartedAt:      api.DateTimeToString(startedAt),
+	})
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return
+	case <-timer.C:
+	}
+
+	event := &api.BenchmarkStatusEvent{
+		ProviderID:     bench.ProviderID,
+		ID:             bench.ID,
+		BenchmarkIndex: benchmarkIndex,
+		Status:         api.StateCompleted,
+		Phase:          api.JobPhaseCompleted,
+		StartedAt:      api.DateTimeToString(startedAt),
+		CompletedAt:    api.DateTimeToString(time.Now()),
+	}
+	if failed {
+		event.Status = api.StateFailed
+		event.ErrorMessage = api.WithMessageOrigin(&api.MessageInfo{
+			Message:     fmt.Sprintf("the mock runtime failed benchmark %s after %s", bench.ID, duration),
+			MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_FAILED,
+		}, api.MessageOriginRuntime)
+	} else {
+		event.Metrics = metrics
+	}
+	r.updateBenchmark(jobID, storage, event)
+}
+
+func (r *MockRuntime) updateBenchmark(jobID string, storage abstractions.RuntimeStorage, event *api.BenchmarkStatusEvent) {
+	if storage == nil {
+		return
+	}
+	if err := storage.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: event}); err != nil {
+		r.logger.Error(
+			"failed to update benchmark status",
+			"error", err,
+			"job_id", jobID,
+			"benchmark_id", event.ID,
+			"benchmark_index", event.BenchmarkIndex,
+			"provider_id", event.ProviderID,
+			"status", event.Status,
+		)
+	}
+}
+
+// DeleteEvaluationJobResources stops the simulation of the running benchmarks of the job.
+func (r *MockRuntime) DeleteEvaluationJobResources(evaluation *api.EvaluationJobResource) error {
+	r.sim.cancel(evaluation.Resource.ID)
+	return nil
+}
+
+// GetEvaluationLogs returns a header per benchmark, simulated benchmarks write no logs.
+func (r *MockRuntime) GetEvaluationLogs(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndex *int,
+	opts api.EvaluationLogOptions,
+) (string, error) {
+	if len(benchmarks) == 0 {
+		return "", serviceerrors.NewServiceError(messages.EvaluationJobEmpty, "EvaluationJobID", evaluation.Resource.ID)
+	}
+	if benchmarkIndex != nil {
+		if *benchmarkIndex < 0 || *benchmarkIndex >= len(benchmarks) {
+			return "", serviceerrors.NewServiceError(
+				messages.ResourceNotFound,
+				"Type", "benchmark",
+				"ResourceId", fmt.Sprintf("%d", *benchmarkIndex),
+			)
+		}
+		return "", nil
+	}
+	sections := make([]string, len(benchmarks))
+	for i, bench := range benchmarks {
+		sections[i] = shared.FormatLogSectionHeader(fmt.Sprintf("%s-%d", evaluation.Resource.ID, i), mockLogContainerName, bench.ID)
+	}
+	return strings.Join(sections, "\n"), nil
+}
+
+// GetEvaluationJobSpec returns the job spec that an adapter of the benchmark would get.
+// Benchmarks are not sharded in the mock runtime, so there is only shard 0.
+func (r *MockRuntime) GetEvaluationJobSpec(
+	evaluation *api.EvaluationJobResource,
+	benchmarks []api.EvaluationBenchmarkConfig,
+	benchmarkIndex int,
+	shardIndex int,
+	storage abstractions.RuntimeStorage,
+) ([]byte, error) {
+	if benchmarkIndex < 0 || benchmarkIndex >= len(benchmarks) {
+		return nil, serviceerrors.NewServiceError(
+			messages.ResourceNotFound,
+			"Type", "benchmark",
+			"ResourceId", fmt.Sprintf("%d", benchmarkIndex),
+		)
+	}
+	if shardIndex != 0 {
+		return nil, serviceerrors.NewServiceError(
+			messages.ResourceNotFound,
+			"Type", "shard",
+			"ResourceId", fmt.Sprintf("%d", shardIndex),
+		)
+	}
+	bench := benchmarks[benchmarkIndex]
+	provider, err := storage.GetProvider(bench.ProviderID)
+	if err != nil {
+		return nil, err
+	}
+	bench = provider.WithDefaultParameters(bench)
+	spec, err := shared.BuildJobSpec(evaluation, bench.ProviderID, &bench, benchmarkIndex, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build job spec: %w", err)
+	}
+	return shared.MarshalJobSpec(spec)
+}
+
+// start registers a running benchmark of the job and returns the context of the job.
+func (s *simulator) start(jobID string) context.Context {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[jobID]
+	if !ok {
+		ctx, cancel := context.WithCancel(context.Background())
+		job = &simulatedJob{ctx: ctx, cancel: cancel}
+		s.jobs[jobID] = job
+	}
+	job.running++
+	return job.ctx
+}
+
+// finish forgets the job once its last running benchmark has finished.
+func (s *simulator) finish(jobID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	job, ok := s.jobs[jobID]
+	if !ok {
+		return
+	}
+	job.running--
+	if job.running <= 0 {
+		job.cancel()
+		delete(s.jobs, jobID)
+	}
+}
+
+func (s *simulator) cancel(jobID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if job, ok := s.jobs[jobID]; ok {
+		job.cancel()
+		delete(s.jobs, jobID)
+	}
+}
+
+// outcome draws the duration of a benchmark, whether it fails, and its metrics.
+func (s *simulator) outcome(mockConfig *config.MockRuntimeConfig) (time.Duration, bool, map[string]any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	minDuration, maxDuration := mockConfig.DurationRange()
+	duration := minDuration
+	if maxDuration > minDuration {
+		duration += time.Duration(s.random.Int64N(int64(maxDuration - minDuration + 1)))
+	}
+	failed := mockConfig != nil && s.random.Float64() < mockConfig.FailureRate
+	metrics := map[string]any{}
+	configured := mockConfig.EffectiveMetrics()
+	// drawn in the order of their names, so that a seed gives the same metrics
+	for _, name := range slices.Sorted(maps.Keys(configured)) {
+		metric := configured[name]
+		value := metric.Mean + s.random.NormFloat64()*metric.StdDev
+		if metric.Max > metric.Min {
+			value = min(max(value, metric.Min), metric.Max)
+		}
+		metrics[name] = value
+	}
+	return duration, failed, metrics
+}
@@ -0,0 +1,171 @@
+package mock
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// fakeStorage records the status updates of the runtime.
+type fakeStorage struct {
+	events chan *api.BenchmarkStatusEvent
+}
+
+func newFakeStorage() *fakeStorage {
+	return &fakeStorage{events: make(chan *api.BenchmarkStatusEvent, 16)}
+}
+
+func (f *fakeStorage) GetProvider(id string) (*api.ProviderResource, error) {
+	return &api.ProviderResource{Resource: api.Resource{ID: id}}, nil
+}
+
+func (f *fakeStorage) UpdateEvaluationJob(_ string, runStatus *api.StatusEvent) error {
+	f.events <- runStatus.BenchmarkStatusEvent
+	return nil
+}
+
+func (f *fakeStorage) UpdateEvaluationJobModelMetadata(_ string, _ *api.ModelMetadata) error {
+	return nil
+}
+func (f *fakeStorage) UpdateEvaluationJobBenchmarkPlacement(_ string, _ int, _ *api.BenchmarkPlacement) error {
+	return nil
+}
+func (f *fakeStorage) PinEvaluationJobAdapterImage(_ string, _ string, pinned string) (string, error) {
+	return pinned, nil
+}
+
+func (f *fakeStorage) next(t *testing.T) *api.BenchmarkStatusEvent {
+	t.Helper()
+	select {
+	case event := <-f.events:
+		return event
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a status event")
+		return nil
+	}
+}
+
+func newTestRuntime(t *testing.T, mockConfig *config.MockRuntimeConfig) *MockRuntime {
+	t.Helper()
+	runtime, err := NewMockRuntime(slog.New(slog.DiscardHandler), &config.Config{MockRuntime: mockConfig})
+	if err != nil {
+		t.Fatalf("NewMockRuntime: %v", err)
+	}
+	return runtime.WithContext(context.Background()).(*MockRuntime)
+}
+
+func sampleEvaluation() (*api.EvaluationJobResource, []api.EvaluationBenchmarkConfig) {
+	evaluation := &api.EvaluationJobResource{Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1"}}}
+	benchmarks := []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "bench-1"}, ProviderID: "provider-1"}}
+	return evaluation, benchmarks
+}
+
+func TestMockRuntimeCompletesWithMetrics(t *testing.T) {
+	runtime := newTestRuntime(t, &config.MockRuntimeConfig{
+		MinDuration: time.Millisecond,
+		MaxDuration: 10 * time.Millisecond,
+		Metrics:     map[string]config.MockMetricConfig{"accuracy": {Mean: 0.8, StdDev: 0.5, Min: 0, Max: 1}},
+	})
+	storage := newFakeStorage()
+	evaluation, benchmarks := sampleEvaluation()
+
+	if err := runtime.RunEvaluationJob(evaluation, benchmarks, storage); err != nil {
+		t.Fatalf("RunEvaluationJob: %v", err)
+	}
+
+	running := storage.next(t)
+	if running.Status != api.StateRunning || running.ID != "bench-1" || running.ProviderID != "provider-1" {
+		t.Fatalf("expected bench-1 to run, got %+v", running)
+	}
+	completed := storage.next(t)
+	if completed.Status != api.StateCompleted {
+		t.Fatalf("expected bench-1 to complete, got %+v", completed)
+	}
+	accuracy, ok := completed.Metrics["accuracy"].(float64)
+	if !ok || accuracy < 0 || accuracy > 1 {
+		t.Errorf("expected an accuracy clamped to [0, 1], got %v", completed.Metrics)
+	}
+}
+
+func TestMockRuntimeFailsAtTheFailureRate(t *testing.T) {
+	runtime := newTestRuntime(t, &config.MockRuntimeConfig{MinDuration: time.Millisecond, MaxDuration: time.Millisecond, FailureRate: 1})
+	storage := newFakeStorage()
+	evaluation, benchmarks := sampleEvaluation()
+
+	if err := runtime.RunEvaluationJob(evaluation, benchmarks, storage); err != nil {
+		t.Fatalf("RunEvaluationJob: %v", err)
+	}
+
+	storage.next(t)
+	failed := storage.next(t)
+	if failed.Status != api.StateFailed || failed.ErrorMessage == nil || failed.ErrorMessage.MessageOrigin != api.MessageOriginRuntime {
+		t.Fatalf("expected bench-1 to fail with a runtime message, got %+v", failed)
+	}
+}
+
+func TestMockRuntimeCancelStopsTheSimulation(t *testing.T) {
+	runtime := newTestRuntime(t, &config.MockRuntimeConfig{MinDuration: time.Hour, MaxDuration: time.Hour})
+	storage := newFakeStorage()
+	evaluation, benchmarks := sampleEvaluation()
+
+	if err := runtime.RunEvaluationJob(evaluation, benchmarks, storage); err != nil {
+		t.Fatalf("RunEvaluationJob: %v", err)
+	}
+	storage.next(t)
+	if err := runtime.DeleteEvaluationJobResources(evaluation); err != nil {
+		t.Fatalf("DeleteEvaluationJobResources: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		runtime.sim.mu.Lock()
+		jobs := len(runtime.sim.jobs)
+		runtime.sim.mu.Unlock()
+		if jobs == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the cancelled job to be forgotten")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	select {
+	case event := <-storage.events:
+		t.Fatalf("expected no event after the job was cancelled, got %+v", event)
+	default:
+	}
+}
+
+func TestMockRuntimeSeedIsReproducible(t *testing.T) {
+	mockConfig := &config.MockRuntimeConfig{
+		FailureRate: 0.5,
+		Seed:        42,
+		Metrics: map[string]config.MockMetricConfig{
+			"accuracy": {Mean: 0.5, StdDev: 0.2},
+			"f1":       {Mean: 0.5, StdDev: 0.2},
+		},
+	}
+	first := newTestRuntime(t, mockConfig)
+	second := newTestRuntime(t, mockConfig)
+
+	for range 5 {
+		firstDuration, firstFailed, firstMetrics := first.sim.outcome(mockConfig)
+		secondDuration, secondFailed, secondMetrics := second.sim.outcome(mockConfig)
+		if firstDuration != secondDuration || firstFailed != secondFailed ||
+			firstMetrics["accuracy"] != secondMetrics["accuracy"] || firstMetrics["f1"] != secondMetrics["f1"] {
+			t.Fatalf("expected the same outcomes with the same seed, got %v %t %v and %v %t %v",
+				firstDuration, firstFailed, firstMetrics, secondDuration, secondFailed, secondMetrics)
+		}
+	}
+}
+
+func TestNewMockRuntimeRejectsInvalidConfig(t *testing.T) {
+	_, err := NewMockRuntime(slog.New(slog.DiscardHandler), &config.Config{MockRuntime: &config.MockRuntimeConfig{FailureRate: 2}})
+	if err == nil {
+		t.Fatal("expected a failure rate above 1 to be rejected")
+	}
+}
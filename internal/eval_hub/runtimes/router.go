@@ -0,0 +1,338 @@
+package runtimes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// providerGetter looks up the providers of the benchmarks of a job.
+type providerGetter interface {
+	GetProvider(id string) (*api.ProviderResource, error)
+}
+
+// routerRuntime runs each benchmark on the runtime that its provider declares, so that a
+// deployment can run providers that only have a k8s block next to providers that only have
+// a local command. A job that selects a runtime runs all its benchmarks on it. A benchmark
+// whose provider declares several runtimes runs on the first of them that is enabled,
+// starting with the default runtime.
+type routerRuntime struct {
+	logger  *slog.Logger
+	ctx     context.Context
+	storage abstractions.Storage
+	// enabled are the names of the runtimes, the default runtime first
+	enabled  []string
+	runtimes map[string]abstractions.Runtime
+}
+
+func (r *routerRuntime) WithLogger(logger *slog.Logger) abstractions.Runtime {
+	scoped := r.with(func(runtime abstractions.Runtime) abstractions.Runtime { return runtime.WithLogger(logger) })
+	scoped.logger = logger
+	return scoped
+}
+
+func (r *routerRuntime) WithContext(ctx context.Context) abstractions.Runtime {
+	scoped := r.with(func(runtime abstractions.Runtime) abstractions.Runtime { return runtime.WithContext(ctx) })
+	scoped.ctx = ctx
+	return scoped
+}
+
+func (r *routerRuntime) with(scope func(abstractions.Runtime) abstractions.Runtime) *routerRuntime {
+	runtimes := make(map[string]abstractions.Runtime, len(r.runtimes))
+	for name, runtime := range r.runtimes {
+		runtimes[name] = scope(runtime)
+	}
+	return &routerRuntime{logger: r.logger, ctx: r.ctx, storage: r.storage, enabled: r.enabled, runtimes: runtimes}
+}
+
+// Name returns the name of the default runtime.
+func (r *routerRuntime) Name() string {
+	return r.enabled[0]
+}
+
+// benchmarkRuntimes returns the name of the runtime of each benchmark of a job.
+func (r *routerRuntime) benchmarkRuntimes(evaluation *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig, providers providerGetter) ([]string, error) {
+	names := make([]string, len(benchmarks))
+	if evaluation.Runtime != "" {
+		// a job can select a runtime that has been disabled since it was created
+		if _, ok := r.runtimes[evaluation.Runtime]; !ok {
+			return nil, serviceerrors.NewServiceError(messages.RuntimeNotEnabled, "Runtime", evaluation.Runtime, "EnabledRuntimes", strings.Join(r.enabled, ", "))
+		}
+		for i := range names {
+			names[i] = evaluation.Runtime
+		}
+		return names, nil
+	}
+	for i, benchmark := range benchmarks {
+		names[i] = r.providerRuntime(benchmark.ProviderID, providers)
+	}
+	return names, nil
+}
+
+// providerRuntime returns the first enabled runtime that the provider declares. The default
+// runtime is returned when the provider can not be read or declares none of them, so that
+// the benchmark fails as it would in a deployment with that runtime only.
+func (r *routerRuntime) providerRuntime(providerID string, providers providerGetter) string {
+	if providers == nil {
+		return r.enabled[0]
+	}
+	provider, err := providers.GetProvider(providerID)
+	if err != nil {
+		r.logger.Debug("Failed to read the provider of a benchmark, using the default runtime", "provider_id", providerID, "error", err)
+		return r.enabled[0]
+	}
+	for _, name := range r.enabled {
+		if provider.SupportsRuntime(name) {
+			return name
+		}
+	}
+	return r.enabled[0]
+}
+
+// tenantProviders returns the providers visible to the tenant of a job.
+func (r *routerRuntime) tenantProviders(evaluation *api.EvaluationJobResource) providerGetter {
+	if r.storage == nil {
+		return nil
+	}
+	storage := r.storage.WithLogger(r.logger).WithTenant(evaluation.Resource.Tenant)
+	if r.ctx != nil {
+		storage = storage.WithContext(r.ctx)
+	}
+	return storage
+}
+
+// groupIndices groups the benchmark indices by the name of their runtime, in the order of
+// the enabled runtimes.
+func (r *routerRuntime) groupIndices(names []string, indices []int) ([]string, map[string][]int) {
+	groups := map[string][]int{}
+	for _, idx := range indices {
+		if idx < 0 || idx >= len(names) {
+			continue
+		}
+		groups[names[idx]] = append(groups[names[idx]], idx)
+	}
+	var order []string
+	for _, name := range r.enabled {
+		if len(groups[name]) > 0 {
+			order = append(order, name)
+		}
+	}
+	return order, groups
+}
+
+// RunEvaluationJob starts a job whose benchmarks all run on one runtime as that runtime does.
+// The benchmarks of a job that spans runtimes are started by each runtime as benchmarks of a
+// running job, so the steps that a runtime only takes for a whole job, such as reading the
+// metadata of the model deployment, are skipped.
+func (r *routerRuntime) RunEvaluationJob(evaluation *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig, storage abstractions.RuntimeStorage) error {
+	if len(benchmarks) == 0 {
+		return serviceerrors.NewServiceError(messages.EvaluationJobEmpty, "EvaluationJobID", evaluation.Resource.ID)
+	}
+	names, err := r.benchmarkRuntimes(evaluation, benchmarks, storage)
+	if err != nil {
+		return err
+	}
+	all := make([]int, len(benchmarks))
+	for i := range all {
+		all[i] = i
+	}
+	order, groups := r.groupIndices(names, all)
+	if len(order) == 1 {
+		return r.runtimes[order[0]].RunEvaluationJob(evaluation, benchmarks, storage)
+	}
+
+	r.logger.Info("Running the benchmarks of the job on several runtimes", "job_id", evaluation.Resource.ID, "runtimes", order)
+	var runErr error
+	for _, name := range order {
+		var ready []int
+		for _, idx := range groups[name] {
+			if shared.IsBenchmarkReady(evaluation, benchmarks, idx) {
+				ready = append(ready, idx)
+			}
+		}
+		if len(ready) == 0 {
+			continue
+		}
+		if err := r.runtimes[name].RunEvaluationBenchmarks(evaluation, benchmarks, ready, storage); err != nil {
+			runErr = errors.Join(runErr, fmt.Errorf("%s runtime: %w", name, err))
+		}
+	}
+	return runErr
+}
+
+func (r *routerRuntime) RunEvaluationBenchmarks(evaluation *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig, benchmarkIndices []int, storage abstractions.RuntimeStorage) error {
+	names, err := r.benchmarkRuntimes(evaluation, benchmarks, storage)
+	if err != nil {
+		return err
+	}
+	order, groups := r.groupIndices(names, benchmarkIndices)
+	var runErr error
+	for _, name := range order {
+		if err := r.runtimes[name].RunEvaluationBenchmarks(evaluation, benchmarks, groups[name], storage); err != nil {
+			runErr = errors.Join(runErr, fmt.Errorf("%s runtime: %w", name, err))
+		}
+	}
+	return runErr
+}
+
+// DeleteEvaluationJobResources deletes the resources of the job on every runtime; a runtime
+// that ran none of its benchmarks has none to delete.
+func (r *routerRuntime) DeleteEvaluationJobResources(evaluation *api.EvaluationJobResource) error {
+	var deleteErr error
+	for _, name := range r.enabled {
+		if err := r.runtimes[name].DeleteEvaluationJobResources(evaluation); err != nil {
+			deleteErr = errors.Join(deleteErr, fmt.Errorf("%s runtime: %w", name, err))
+		}
+	}
+	return deleteErr
+}
+
+// CancelEvaluationBenchmarks stops the benchmarks at benchmarkIndices on every runtime; a
+// runtime that cannot stop some of the benchmarks of a job deletes all its resources.
+func (r *routerRuntime) CancelEvaluationBenchmarks(evaluation *api.EvaluationJobResource, benchmarkIndices []int) error {
+	var cancelErr error
+	for _, name := range r.enabled {
+		var err error
+		if canceller, ok := r.runtimes[name].(abstractions.BenchmarkCanceller); ok {
+			err = canceller.CancelEvaluationBenchmarks(evaluation, benchmarkIndices)
+		} else {
+			err = r.runtimes[name].DeleteEvaluationJobResources(evaluation)
+		}
+		if err != nil {
+			cancelErr = errors.Join(cancelErr, fmt.Errorf("%s runtime: %w", name, err))
+		}
+	}
+	return cancelErr
+}
+
+// GetEvaluationLogs reads the logs of each benchmark from its runtime. The section headers of
+// a job that spans runtimes name the runtime of each benchmark.
+func (r *routerRuntime) GetEvaluationLogs(evaluation *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig, benchmarkIndex *int, opts api.EvaluationLogOptions) (string, error) {
+	names, err := r.benchmarkRuntimes(evaluation, benchmarks, r.tenantProviders(evaluation))
+	if err != nil {
+		return "", err
+	}
+	if benchmarkIndex != nil {
+		if *benchmarkIndex < 0 || *benchmarkIndex >= len(benchmarks) {
+			// the default runtime reports the index as not found
+			return r.runtimes[r.enabled[0]].GetEvaluationLogs(evaluation, benchmarks, benchmarkIndex, opts)
+		}
+		return r.runtimes[names[*benchmarkIndex]].GetEvaluationLogs(evaluation, benchmarks, benchmarkIndex, opts)
+	}
+	all := make([]int, len(benchmarks))
+	for i := range all {
+		all[i] = i
+	}
+	order, _ := r.groupIndices(names, all)
+	if len(order) <= 1 {
+		name := r.enabled[0]
+		if len(order) == 1 {
+			name = order[0]
+		}
+		return r.runtimes[name].GetEvaluationLogs(evaluation, benchmarks, nil, opts)
+	}
+
+	sections := make([]string, 0, len(benchmarks))
+	for i, benchmark := range benchmarks {
+		index := i
+		logs, err := r.runtimes[names[i]].GetEvaluationLogs(evaluation, benchmarks, &index, opts)
+		if err != nil {
+			return "", err
+		}
+		section := shared.FormatLogSectionHeader(fmt.Sprintf("%s-%d", evaluation.Resource.ID, i), names[i], benchmark.ID)
+		if logs != "" {
+			section += "\n" + logs
+		}
+		sections = append(sections, section)
+	}
+	return strings.Join(sections, "\n"), nil
+}
+
+func (r *routerRuntime) GetEvaluationJobSpec(evaluation *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig, benchmarkIndex int, shardIndex int, storage abstractions.RuntimeStorage) ([]byte, error) {
+	names, err := r.benchmarkRuntimes(evaluation, benchmarks, storage)
+	if err != nil {
+		return nil, err
+	}
+	name := r.enabled[0]
+	if benchmarkIndex >= 0 && benchmarkIndex < len(names) {
+		name = names[benchmarkIndex]
+	}
+	return r.runtimes[name].GetEvaluationJobSpec(evaluation, benchmarks, benchmarkIndex, shardIndex, storage)
+}
+
+// WarmUpImages pre-pulls the images with the enabled runtime that can, i.e. kubernetes.
+func (r *routerRuntime) WarmUpImages(ctx context.Context, images map[string]string) error {
+	warmer, err := r.imageWarmer()
+	if err != nil {
+		return err
+	}
+	return warmer.WarmUpImages(ctx, images)
+}
+
+func (r *routerRuntime) ImageWarmupStatus(ctx context.Context) (map[string]api.ProviderImageWarmup, error) {
+	warmer, err := r.imageWarmer()
+	if err != nil {
+		return nil, err
+	}
+	return warmer.ImageWarmupStatus(ctx)
+}
+
+func (r *routerRuntime) imageWarmer() (abstractions.ImageWarmer, error) {
+	if warmer, ok := r.runtimes[api.RuntimeKubernetes].(abstractions.ImageWarmer); ok {
+		return warmer, nil
+	}
+	return nil, fmt.Errorf("none of the runtimes %s can pre-pull images", strings.Join(r.enabled, ", "))
+}
+
+// DiagnoseBenchmarkFailures diagnoses the failed workloads with the enabled runtime that can,
+// i.e. kubernetes; there are none when it is not enabled.
+func (r *routerRuntime) DiagnoseBenchmarkFailures(ctx context.Context, evaluation *api.EvaluationJobResource) ([]abstractions.BenchmarkFailure, error) {
+	if diagnoser, ok := r.runtimes[api.RuntimeKubernetes].(abstractions.FailureDiagnoser); ok {
+		return diagnoser.DiagnoseBenchmarkFailures(ctx, evaluation)
+	}
+	return nil, nil
+}
+
+// BenchmarkPlacements reads the placements of the benchmarks with the enabled runtime that
+// can, i.e. kubernetes; there are none when it is not enabled.
+func (r *routerRuntime) BenchmarkPlacements(ctx context.Context, evaluation *api.EvaluationJobResource) (map[int]*api.BenchmarkPlacement, error) {
+	if reporter, ok := r.runtimes[api.RuntimeKubernetes].(abstractions.PlacementReporter); ok {
+		return reporter.BenchmarkPlacements(ctx, evaluation)
+	}
+	return nil, nil
+}
+
+// ClusterStatus reports the clusters of the enabled runtime that dispatches to clusters, i.e.
+// kubernetes; there are none when it is not enabled.
+func (r *routerRuntime) ClusterStatus(ctx context.Context) ([]api.ClusterStatus, error) {
+	if reporter, ok := r.runtimes[api.RuntimeKubernetes].(abstractions.ClusterReporter); ok {
+		return reporter.ClusterStatus(ctx)
+	}
+	return nil, nil
+}
+
+// CheckPermissions checks the permissions of the enabled runtime that needs some, i.e.
+// kubernetes; there is nothing to check when it is not enabled.
+func (r *routerRuntime) CheckPermissions(ctx context.Context) (*api.PermissionCheck, error) {
+	if checker, ok := r.runtimes[api.RuntimeKubernetes].(abstractions.PermissionChecker); ok {
+		return checker.CheckPermissions(ctx)
+	}
+	return nil, nil
+}
+
+// CleanupJobFiles cleans up the job files of the local runtime, the only runtime that keeps
+// them on the disk of the server; there are none when it is not enabled.
+func (r *routerRuntime) CleanupJobFiles(ctx context.Context) error {
+	if cleaner, ok := r.runtimes[api.RuntimeLocal].(abstractions.JobFilesCleaner); ok {
+		return cleaner.CleanupJobFiles(ctx)
+	}
+	return nil
+}
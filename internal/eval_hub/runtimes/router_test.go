@@ -0,0 +1,204 @@
+package runtimes
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// recordingRuntime records the calls it gets.
+type recordingRuntime struct {
+	abstractions.Runtime
+	name  string
+	calls *[]string
+}
+
+func (r *recordingRuntime) WithLogger(_ *slog.Logger) abstractions.Runtime     { return r }
+func (r *recordingRuntime) WithContext(_ context.Context) abstractions.Runtime { return r }
+func (r *recordingRuntime) Name() string                                       { return r.name }
+
+func (r *recordingRuntime) RunEvaluationJob(evaluation *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, _ abstractions.RuntimeStorage) error {
+	*r.calls = append(*r.calls, fmt.Sprintf("%s:run:%s", r.name, evaluation.Resource.ID))
+	return nil
+}
+
+func (r *recordingRuntime) RunEvaluationBenchmarks(evaluation *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, benchmarkIndices []int, _ abstractions.RuntimeStorage) error {
+	*r.calls = append(*r.calls, fmt.Sprintf("%s:run:%s%v", r.name, evaluation.Resource.ID, benchmarkIndices))
+	return nil
+}
+
+func (r *recordingRuntime) DeleteEvaluationJobResources(evaluation *api.EvaluationJobResource) error {
+	*r.calls = append(*r.calls, fmt.Sprintf("%s:delete:%s", r.name, evaluation.Resource.ID))
+	return nil
+}
+
+func (r *recordingRuntime) GetEvaluationLogs(_ *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, benchmarkIndex *int, _ api.EvaluationLogOptions) (string, error) {
+	return fmt.Sprintf("%s logs of %d", r.name, *benchmarkIndex), nil
+}
+
+// cancellingRuntime is a recording runtime that can stop some of the benchmarks of a job.
+type cancellingRuntime struct {
+	recordingRuntime
+}
+
+func (r *cancellingRuntime) CancelEvaluationBenchmarks(evaluation *api.EvaluationJobResource, benchmarkIndices []int) error {
+	*r.calls = append(*r.calls, fmt.Sprintf("%s:cancel:%s%v", r.name, evaluation.Resource.ID, benchmarkIndices))
+	return nil
+}
+
+// routerProviders is the runtime storage of the providers of the benchmarks.
+type routerProviders map[string]api.ProviderResource
+
+func (p routerProviders) UpdateEvaluationJob(_ string, _ *api.StatusEvent) error { return nil }
+func (p routerProviders) UpdateEvaluationJobModelMetadata(_ string, _ *api.ModelMetadata) error {
+	return nil
+}
+func (p routerProviders) UpdateEvaluationJobBenchmarkPlacement(_ string, _ int, _ *api.BenchmarkPlacement) error {
+	return nil
+}
+func (p routerProviders) PinEvaluationJobAdapterImage(_ string, _ string, pinned string) (string, error) {
+	return pinned, nil
+}
+
+func (p routerProviders) GetProvider(id string) (*api.ProviderResource, error) {
+	provider, ok := p[id]
+	if !ok {
+		return nil, fmt.Errorf("provider %s not found", id)
+	}
+	return &provider, nil
+}
+
+func TestRouterRuntime(t *testing.T) {
+	providers := routerProviders{
+		"k8s-only":   {ProviderConfig: api.ProviderConfig{Runtime: &api.Runtime{K8s: &api.K8sRuntime{Image: "quay.io/adapter"}}}},
+		"local-only": {ProviderConfig: api.ProviderConfig{Runtime: &api.Runtime{Local: &api.LocalRuntime{Command: "python adapter.py"}}}},
+		"both": {ProviderConfig: api.ProviderConfig{Runtime: &api.Runtime{
+			K8s:   &api.K8sRuntime{Image: "quay.io/adapter"},
+			Local: &api.LocalRuntime{Command: "python adapter.py"},
+		}}},
+	}
+	var calls []string
+	router := &routerRuntime{
+		logger:  slog.New(slog.DiscardHandler),
+		enabled: []string{api.RuntimeKubernetes, api.RuntimeLocal},
+		runtimes: map[string]abstractions.Runtime{
+			api.RuntimeKubernetes: &recordingRuntime{name: api.RuntimeKubernetes, calls: &calls},
+			api.RuntimeLocal:      &recordingRuntime{name: api.RuntimeLocal, calls: &calls},
+		},
+	}
+	runtime := router.WithLogger(slog.New(slog.DiscardHandler)).WithContext(context.Background())
+	if runtime.Name() != api.RuntimeKubernetes {
+		t.Errorf("expected the name of the default runtime, got %q", runtime.Name())
+	}
+	job := func(id string, selected string) *api.EvaluationJobResource {
+		job := &api.EvaluationJobResource{Resource: api.EvaluationResource{Resource: api.Resource{ID: id}}}
+		job.Runtime = selected
+		return job
+	}
+	benchmarks := func(providerIDs ...string) []api.EvaluationBenchmarkConfig {
+		var benchmarks []api.EvaluationBenchmarkConfig
+		for i, providerID := range providerIDs {
+			benchmarks = append(benchmarks, api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: fmt.Sprintf("bench-%d", i)}, ProviderID: providerID})
+		}
+		return benchmarks
+	}
+
+	tests := map[string]struct {
+		job        *api.EvaluationJobResource
+		benchmarks []api.EvaluationBenchmarkConfig
+		want       []string
+	}{
+		"one runtime":               {job: job("job-1", ""), benchmarks: benchmarks("local-only", "local-only"), want: []string{"local:run:job-1"}},
+		"the default runtime first": {job: job("job-2", ""), benchmarks: benchmarks("both"), want: []string{"kubernetes:run:job-2"}},
+		"several runtimes":          {job: job("job-3", ""), benchmarks: benchmarks("local-only", "k8s-only", "both"), want: []string{"kubernetes:run:job-3[1 2]", "local:run:job-3[0]"}},
+		"selected by the job":       {job: job("job-4", api.RuntimeLocal), benchmarks: benchmarks("both", "local-only"), want: []string{"local:run:job-4"}},
+		"unknown provider":          {job: job("job-5", ""), benchmarks: benchmarks("missing"), want: []string{"kubernetes:run:job-5"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			calls = nil
+			if err := runtime.RunEvaluationJob(tt.job, tt.benchmarks, providers); err != nil {
+				t.Fatalf("RunEvaluationJob: %v", err)
+			}
+			if strings.Join(calls, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("expected %v, got %v", tt.want, calls)
+			}
+		})
+	}
+
+	t.Run("disabled runtime", func(t *testing.T) {
+		err := runtime.RunEvaluationJob(job("job-6", api.RuntimeKFP), benchmarks("both"), providers)
+		if err == nil || !strings.Contains(err.Error(), "kubernetes, local") {
+			t.Errorf("expected an error listing the enabled runtimes, got %v", err)
+		}
+	})
+
+	t.Run("dependent benchmarks", func(t *testing.T) {
+		calls = nil
+		if err := runtime.RunEvaluationBenchmarks(job("job-7", ""), benchmarks("local-only", "k8s-only", "local-only"), []int{1, 2}, providers); err != nil {
+			t.Fatalf("RunEvaluationBenchmarks: %v", err)
+		}
+		if strings.Join(calls, " ") != "kubernetes:run:job-7[1] local:run:job-7[2]" {
+			t.Errorf("expected each benchmark on its runtime, got %v", calls)
+		}
+	})
+
+	t.Run("deletion", func(t *testing.T) {
+		calls = nil
+		if err := runtime.DeleteEvaluationJobResources(job("job-8", "")); err != nil {
+			t.Fatalf("DeleteEvaluationJobResources: %v", err)
+		}
+		if strings.Join(calls, " ") != "kubernetes:delete:job-8 local:delete:job-8" {
+			t.Errorf("expected the resources to be deleted on every runtime, got %v", calls)
+		}
+	})
+
+	t.Run("logs", func(t *testing.T) {
+		index := 1
+		logs, err := runtime.GetEvaluationLogs(job("job-9", api.RuntimeLocal), benchmarks("both", "local-only"), &index, api.EvaluationLogOptions{})
+		if err != nil || logs != "local logs of 1" {
+			t.Errorf("expected the logs of the benchmark from its runtime, got %q, %v", logs, err)
+		}
+	})
+}
+
+func TestNewRuntimeReplacesTheDefaultRuntime(t *testing.T) {
+	serviceConfig := &config.Config{Service: &config.ServiceConfig{LocalMode: true, Runtime: api.RuntimeMock}}
+	runtime, err := NewRuntime(slog.New(slog.DiscardHandler), serviceConfig, nil)
+	if err != nil {
+		t.Fatalf("NewRuntime: %v", err)
+	}
+	if runtime.Name() != api.RuntimeMock {
+		t.Errorf("expected the mock runtime, got %s", runtime.Name())
+	}
+
+	serviceConfig.Service.Runtime = "unknown"
+	if _, err := NewRuntime(slog.New(slog.DiscardHandler), serviceConfig, nil); err == nil || !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("expected an unknown runtime to be rejected, got %v", err)
+	}
+}
+
+func TestRouterRuntimeCancelsBenchmarks(t *testing.T) {
+	var calls []string
+	router := &routerRuntime{
+		logger:  slog.New(slog.DiscardHandler),
+		enabled: []string{api.RuntimeKubernetes, api.RuntimeLocal},
+		runtimes: map[string]abstractions.Runtime{
+			api.RuntimeKubernetes: &cancellingRuntime{recordingRuntime{name: api.RuntimeKubernetes, calls: &calls}},
+			api.RuntimeLocal:      &recordingRuntime{name: api.RuntimeLocal, calls: &calls},
+		},
+	}
+	job := &api.EvaluationJobResource{Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1"}}}
+	if err := router.CancelEvaluationBenchmarks(job, []int{1, 2}); err != nil {
+		t.Fatalf("CancelEvaluationBenchmarks: %v", err)
+	}
+	if strings.Join(calls, " ") != "kubernetes:cancel:job-1[1 2] local:delete:job-1" {
+		t.Errorf("expected the benchmarks to be cancelled, or the job deleted where they cannot be, got %v", calls)
+	}
+}
@@ -1,20 +1,58 @@
 package runtimes
 
 import (
+	"fmt"
 	"log/slog"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/argo"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/k8s"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/lmevaljob"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/local"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/mock"
+	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
+// NewRuntime creates the runtimes enabled in the service configuration. With a single runtime
+// it is returned as is; otherwise a router runs each benchmark on the runtime selected by its
+// job or declared by its provider, which it reads from storage.
 func NewRuntime(
 	logger *slog.Logger,
 	serviceConfig *config.Config,
+	storage abstractions.Storage,
 ) (abstractions.Runtime, error) {
-	if serviceConfig.Service.LocalMode {
+	enabled := serviceConfig.Service.EnabledRuntimes()
+	if err := serviceConfig.ImagePolicy.ValidateRuntimes(enabled); err != nil {
+		return nil, err
+	}
+	created := make(map[string]abstractions.Runtime, len(enabled))
+	for _, name := range enabled {
+		runtime, err := newRuntime(logger, serviceConfig, name)
+		if err != nil {
+			return nil, err
+		}
+		created[name] = runtime
+	}
+	if len(created) == 1 {
+		return created[enabled[0]], nil
+	}
+	return &routerRuntime{logger: logger, storage: storage, enabled: enabled, runtimes: created}, nil
+}
+
+func newRuntime(logger *slog.Logger, serviceConfig *config.Config, name string) (abstractions.Runtime, error) {
+	switch name {
+	case api.RuntimeLocal:
 		return local.NewLocalRuntime(logger, serviceConfig)
+	case api.RuntimeKubernetes:
+		return k8s.NewK8sRuntime(logger, serviceConfig)
+	case api.RuntimeArgo:
+		return argo.NewArgoRuntime(logger, serviceConfig)
+	case api.RuntimeLMEvalJob:
+		return lmevaljob.NewLMEvalJobRuntime(logger, serviceConfig)
+	case api.RuntimeMock:
+		return mock.NewMockRuntime(logger, serviceConfig)
+	default:
+		return nil, fmt.Errorf("service.runtimes: the runtime %q is not supported, the supported runtimes are %s, %s, %s, %s and %s", name, api.RuntimeLocal, api.RuntimeKubernetes, api.RuntimeArgo, api.RuntimeLMEvalJob, api.RuntimeMock)
 	}
-	return k8s.NewK8sRuntime(logger, serviceConfig)
 }
@@ -2,23 +2,75 @@ package shared
 
 import (
 	"fmt"
+	"slices"
 
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
 
 // JobSpec is the JSON structure written to job.json for benchmark adapters to consume.
 type JobSpec struct {
-	JobID          string              `json:"id"`
-	ProviderID     string              `json:"provider_id"`
-	BenchmarkID    string              `json:"benchmark_id"`
-	BenchmarkIndex int                 `json:"benchmark_index"`
-	Model          api.ModelRef        `json:"model"`
-	NumExamples    *int                `json:"num_examples,omitempty"`
-	Parameters     map[string]any      `json:"parameters"`
-	ExperimentName string              `json:"experiment_name,omitempty"`
-	Tags           []api.ExperimentTag `json:"tags,omitempty"`
-	CallbackURL    *string             `json:"callback_url"`
-	Exports        *JobSpecExports     `json:"exports,omitempty"`
+	// SpecVersion is the version of the job spec that the adapter reads, see MarshalJobSpec.
+	SpecVersion    int                     `json:"spec_version"`
+	JobID          string                  `json:"id"`
+	ProviderID     string                  `json:"provider_id"`
+	BenchmarkID    string                  `json:"benchmark_id"`
+	BenchmarkIndex int                     `json:"benchmark_index"`
+	Model          api.ModelRef            `json:"model"`
+	NumExamples    *int                    `json:"num_examples,omitempty"`
+	Parameters     map[string]any          `json:"parameters"`
+	Conversation   *api.ConversationConfig `json:"conversation,omitempty"`
+	RAG            *api.RAGConfig          `json:"rag,omitempty"`
+	ExperimentName string                  `json:"experiment_name,omitempty"`
+	Tags           []api.ExperimentTag     `json:"tags,omitempty"`
+	CallbackURL    *string                 `json:"callback_url"`
+	CallbackToken  string                  `json:"callback_token,omitempty"` // sent in the callbackauth.TokenHeader header of status events
+	Exports        *JobSpecExports         `json:"exports,omitempty"`
+	Shard          *JobSpecShard           `json:"shard,omitempty"`
+	Dependencies   []JobSpecDependency     `json:"dependencies,omitempty"`
+	Checkpoint     *JobSpecCheckpoint      `json:"checkpoint,omitempty"`
+	// ResultsFile is set instead of CallbackURL when the results of the adapter are relayed,
+	// see JobSpecResultsFile.
+	ResultsFile string `json:"results_file,omitempty"`
+}
+
+// JobSpecResultsFile is the results file of an adapter whose provider sets result_relay. The
+// adapter appends each status event to it as a line of JSON, the body it would otherwise
+// post to the events endpoint of the job, and the sidecar relays the complete lines, those
+// ending with a newline, in order.
+const JobSpecResultsFile = "/data/status-events.jsonl"
+
+// JobSpecCheckpoint tells the adapter of a benchmark that runs on spot nodes where to
+// checkpoint its progress. The path is on the data volume, which outlives the pod. Resume is
+// set when the benchmark runs again after an interruption: the adapter then resumes from the
+// checkpoint it finds in the path, if any, instead of starting over.
+type JobSpecCheckpoint struct {
+	Path   string `json:"path"`
+	Resume bool   `json:"resume"`
+}
+
+// JobSpecDependency is a benchmark of the job that completed before this benchmark was
+// started, as listed in its depends_on, with the artifacts it reported.
+type JobSpecDependency struct {
+	BenchmarkIndex int            `json:"benchmark_index"`
+	BenchmarkID    string         `json:"benchmark_id"`
+	ProviderID     string         `json:"provider_id"`
+	Artifacts      map[string]any `json:"artifacts,omitempty"`
+}
+
+// JobSpecShard tells the adapter of a sharded benchmark which part of the benchmark to run.
+// The adapter reports its status events with shard_index set to Index.
+type JobSpecShard struct {
+	Index int `json:"index"`
+	Count int `json:"count"`
+}
+
+// ShardCount returns the number of shards a benchmark runs as, at least 1.
+func ShardCount(benchmarkConfig *api.EvaluationBenchmarkConfig) int {
+	if benchmarkConfig == nil || benchmarkConfig.Shards < 1 {
+		return 1
+	}
+	return benchmarkConfig.Shards
 }
 
 // JobSpecExports is the subset of EvaluationExports serialized into the job spec (excludes k8s connection config).
@@ -47,6 +99,7 @@ func BuildJobSpec(
 	delete(benchmarkParams, "num_examples")
 
 	spec := JobSpec{
+		SpecVersion:    JobSpecVersion,
 		JobID:          evaluation.Resource.ID,
 		ProviderID:     providerID,
 		BenchmarkID:    benchmarkConfig.ID,
@@ -54,6 +107,8 @@ func BuildJobSpec(
 		Model:          evaluation.Model,
 		NumExamples:    numExamples,
 		Parameters:     benchmarkParams,
+		Conversation:   benchmarkConfig.Conversation,
+		RAG:            evaluation.RAG,
 		CallbackURL:    callbackURL,
 	}
 	if evaluation.Experiment != nil {
@@ -67,10 +122,41 @@ func BuildJobSpec(
 			},
 		}
 	}
+	spec.Dependencies = jobSpecDependencies(evaluation, benchmarkConfig.DependsOn)
 
 	return &spec, nil
 }
 
+// ApplySampling caps the num_examples of the job spec by the sampling config of the tenant
+// of the job, downscaling it to the smoke sample size when the job is tagged smoke_test.
+func ApplySampling(spec *JobSpec, evaluation *api.EvaluationJobResource, sampling *config.SamplingConfig) {
+	smokeTest := slices.Contains(evaluation.Tags, config.SmokeTestTag)
+	spec.NumExamples = sampling.NumExamples(string(evaluation.Resource.Tenant), smokeTest, spec.NumExamples)
+}
+
+// jobSpecDependencies returns the results of the benchmarks at dependsOn, in that order.
+func jobSpecDependencies(evaluation *api.EvaluationJobResource, dependsOn []int) []JobSpecDependency {
+	if len(dependsOn) == 0 || evaluation.Results == nil {
+		return nil
+	}
+	var dependencies []JobSpecDependency
+	for _, index := range dependsOn {
+		for _, result := range evaluation.Results.Benchmarks {
+			if result.BenchmarkIndex != index {
+				continue
+			}
+			dependencies = append(dependencies, JobSpecDependency{
+				BenchmarkIndex: index,
+				BenchmarkID:    result.ID,
+				ProviderID:     result.ProviderID,
+				Artifacts:      result.Artifacts,
+			})
+			break
+		}
+	}
+	return dependencies
+}
+
 // CopyParams creates a shallow copy of a parameters map.
 func CopyParams(source map[string]any) map[string]any {
 	if len(source) == 0 {
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
@@ -210,6 +211,56 @@ func TestBuildJobSpecJSONNilExperiment(t *testing.T) {
 	}
 }
 
+func TestBuildJobSpecConversation(t *testing.T) {
+	eval := baseEvaluation()
+	eval.Benchmarks[0].Conversation = &api.ConversationConfig{SystemPrompt: "Be brief.", MultiTurn: true, MaxTurns: 4}
+
+	spec, err := shared.BuildJobSpec(eval, "provider-1", &eval.Benchmarks[0], 0, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	data, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if !strings.Contains(string(data), `"conversation":{"system_prompt":"Be brief.","multi_turn":true,"max_turns":4}`) {
+		t.Fatalf("expected the conversation in the job spec, got %s", data)
+	}
+
+	spec, err = shared.BuildJobSpec(eval, "provider-2", &eval.Benchmarks[1], 1, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	data, _ = json.Marshal(spec)
+	if strings.Contains(string(data), `"conversation"`) {
+		t.Fatalf("expected no conversation in the job spec, got %s", data)
+	}
+}
+
+func TestBuildJobSpecRAG(t *testing.T) {
+	eval := baseEvaluation()
+	eval.RAG = &api.RAGConfig{
+		Retrieval:      api.RetrievalConfig{URL: "http://retriever.example", Corpus: "product-docs", TopK: 5},
+		EmbeddingModel: &api.EndpointModelRef{URL: "http://embeddings.example", Name: "bge-m3"},
+		Judge:          &api.JudgeConfig{Model: api.EndpointModelRef{URL: "http://judge.example", Name: "llama-3-70b"}, Metrics: []string{"faithfulness"}},
+	}
+
+	for i := range eval.Benchmarks {
+		spec, err := shared.BuildJobSpec(eval, eval.Benchmarks[i].ProviderID, &eval.Benchmarks[i], i, nil)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		data, err := json.Marshal(spec)
+		if err != nil {
+			t.Fatalf("marshal: %v", err)
+		}
+		want := `"rag":{"retrieval":{"url":"http://retriever.example","corpus":"product-docs","top_k":5},"embedding_model":{"url":"http://embeddings.example","name":"bge-m3"},"judge":{"model":{"url":"http://judge.example","name":"llama-3-70b"},"metrics":["faithfulness"]}}`
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("expected the rag config of the job in the spec of benchmark %d, got %s", i, data)
+		}
+	}
+}
+
 func TestBuildJobSpecJSONNoNumExamples(t *testing.T) {
 	eval := baseEvaluation()
 	// Use bench-2 which has no num_examples
@@ -249,6 +300,50 @@ func TestBuildJobSpecJSONDoesNotMutateOriginalParams(t *testing.T) {
 	}
 }
 
+func TestBuildJobSpecDependencies(t *testing.T) {
+	eval := baseEvaluation()
+	eval.Benchmarks[1].DependsOn = []int{0}
+	eval.Results = &api.EvaluationJobResults{Benchmarks: []api.BenchmarkResult{
+		{ID: "bench-1", ProviderID: "provider-1", BenchmarkIndex: 0, Artifacts: map[string]any{"dataset": "s3://bucket/generated"}},
+	}}
+
+	spec, err := shared.BuildJobSpec(eval, "provider-2", &eval.Benchmarks[1], 1, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(spec.Dependencies) != 1 {
+		t.Fatalf("expected one dependency, got %d", len(spec.Dependencies))
+	}
+	dependency := spec.Dependencies[0]
+	if dependency.BenchmarkIndex != 0 || dependency.BenchmarkID != "bench-1" || dependency.Artifacts["dataset"] != "s3://bucket/generated" {
+		t.Fatalf("unexpected dependency %+v", dependency)
+	}
+
+	spec, err = shared.BuildJobSpec(eval, "provider-1", &eval.Benchmarks[0], 0, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if spec.Dependencies != nil {
+		t.Fatalf("expected no dependencies, got %+v", spec.Dependencies)
+	}
+}
+
+func TestIsBenchmarkReady(t *testing.T) {
+	eval := baseEvaluation()
+	eval.Benchmarks[1].DependsOn = []int{0}
+
+	if !shared.IsBenchmarkReady(eval, eval.Benchmarks, 0) || shared.IsBenchmarkReady(eval, eval.Benchmarks, 1) {
+		t.Fatal("expected only the benchmark without dependencies to be ready")
+	}
+
+	eval.Status = &api.EvaluationJobStatus{Benchmarks: []api.BenchmarkStatus{
+		{ID: "bench-1", BenchmarkIndex: 0, Status: api.StateCompleted},
+	}}
+	if shared.IsBenchmarkReady(eval, eval.Benchmarks, 0) || !shared.IsBenchmarkReady(eval, eval.Benchmarks, 1) {
+		t.Fatal("expected only the benchmark whose dependency completed to be ready")
+	}
+}
+
 func TestJobSpecSerialization(t *testing.T) {
 	// Create a minimal evaluation job for testing
 	callbackURL := "http://localhost:8080/callback"
@@ -308,3 +403,44 @@ func TestJobSpecSerialization(t *testing.T) {
 		t.Fatal("❌ FAILURE: benchmark_index field is MISSING from serialized JSON")
 	}
 }
+
+func TestApplySampling(t *testing.T) {
+	sampling := &config.SamplingConfig{
+		SamplingLimits:       config.SamplingLimits{MaxNumExamples: 100},
+		SmokeTestNumExamples: 3,
+		Tenants:              map[string]config.SamplingLimits{"tenant-a": {MaxNumExamples: 4}},
+	}
+	numExamples := func(eval *api.EvaluationJobResource, benchmarkIndex int) *int {
+		spec, err := shared.BuildJobSpec(eval, eval.Benchmarks[benchmarkIndex].ProviderID, &eval.Benchmarks[benchmarkIndex], benchmarkIndex, nil)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		shared.ApplySampling(spec, eval, sampling)
+		return spec.NumExamples
+	}
+
+	eval := baseEvaluation()
+	if got := numExamples(eval, 0); got == nil || *got != 5 {
+		t.Errorf("expected num_examples under the cap to be kept, got %v", got)
+	}
+	if got := numExamples(eval, 1); got == nil || *got != 100 {
+		t.Errorf("expected a benchmark without num_examples to be capped at 100, got %v", got)
+	}
+
+	eval.Resource.Tenant = "tenant-a"
+	if got := numExamples(eval, 0); got == nil || *got != 4 {
+		t.Errorf("expected num_examples to be capped by the tenant at 4, got %v", got)
+	}
+
+	eval.Tags = []string{config.SmokeTestTag}
+	if got := numExamples(eval, 1); got == nil || *got != 3 {
+		t.Errorf("expected a smoke test to run 3 examples, got %v", got)
+	}
+
+	eval = baseEvaluation()
+	spec, _ := shared.BuildJobSpec(eval, "provider-2", &eval.Benchmarks[1], 1, nil)
+	shared.ApplySampling(spec, eval, nil)
+	if spec.NumExamples != nil {
+		t.Errorf("expected no cap without a sampling config, got %d", *spec.NumExamples)
+	}
+}
@@ -0,0 +1,139 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+const (
+	// JobSpecVersion1 is the job spec of the first releases, without spec_version,
+	// callback_token, conversation, rag, shard, dependencies and checkpoint.
+	JobSpecVersion1 = 1
+	// JobSpecVersion is the version of JobSpec.
+	JobSpecVersion = 2
+)
+
+// JobSpecVersions are the versions of the job spec that the server can write, oldest first.
+var JobSpecVersions = []int{JobSpecVersion1, JobSpecVersion}
+
+// jobSpecV1 is the job.json of version 1.
+type jobSpecV1 struct {
+	JobID          string              `json:"id"`
+	ProviderID     string              `json:"provider_id"`
+	BenchmarkID    string              `json:"benchmark_id"`
+	BenchmarkIndex int                 `json:"benchmark_index"`
+	Model          api.ModelRef        `json:"model"`
+	NumExamples    *int                `json:"num_examples,omitempty"`
+	Parameters     map[string]any      `json:"parameters"`
+	ExperimentName string              `json:"experiment_name,omitempty"`
+	Tags           []api.ExperimentTag `json:"tags,omitempty"`
+	CallbackURL    *string             `json:"callback_url"`
+	Exports        *JobSpecExports     `json:"exports,omitempty"`
+}
+
+// NegotiateJobSpecVersion returns the latest version of the job spec that both the server and
+// the adapter of a provider read, given the versions that the provider declares; an adapter
+// that declares none reads the current version.
+func NegotiateJobSpecVersion(supported []int) (int, error) {
+	if len(supported) == 0 {
+		return JobSpecVersion, nil
+	}
+	for _, version := range slices.Backward(JobSpecVersions) {
+		if slices.Contains(supported, version) {
+			return version, nil
+		}
+	}
+	return 0, fmt.Errorf("the adapter reads the job spec versions %s, the server writes the versions %s", formatVersions(supported), formatVersions(JobSpecVersions))
+}
+
+// CheckJobSpecVersion returns an error when the job spec of a benchmark of the job needs
+// fields that the version can not express, e.g. shard for a sharded benchmark. callbackAuth
+// tells whether the spec carries a callback token.
+func CheckJobSpecVersion(evaluation *api.EvaluationJobConfig, benchmarkConfig *api.EvaluationBenchmarkConfig, callbackAuth bool, version int) error {
+	// a spec with the fields set that the benchmark will get
+	spec := &JobSpec{
+		SpecVersion:  version,
+		Conversation: benchmarkConfig.Conversation,
+		RAG:          evaluation.RAG,
+	}
+	if callbackAuth {
+		spec.CallbackToken = "token"
+	}
+	if count := ShardCount(benchmarkConfig); count > 1 {
+		spec.Shard = &JobSpecShard{Count: count}
+	}
+	if len(benchmarkConfig.DependsOn) > 0 {
+		spec.Dependencies = make([]JobSpecDependency, len(benchmarkConfig.DependsOn))
+	}
+	_, err := convertJobSpec(spec)
+	return err
+}
+
+// MarshalJobSpec returns the job.json of the spec in its SpecVersion.
+func MarshalJobSpec(spec *JobSpec) ([]byte, error) {
+	converted, err := convertJobSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	specJSON, err := json.MarshalIndent(converted, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal job spec: %w", err)
+	}
+	return specJSON, nil
+}
+
+// convertJobSpec returns the spec in its SpecVersion, an error when the version can not
+// express one of its fields.
+func convertJobSpec(spec *JobSpec) (any, error) {
+	switch spec.SpecVersion {
+	case JobSpecVersion:
+		return spec, nil
+	case JobSpecVersion1:
+		var unsupported []string
+		for _, field := range []struct {
+			name string
+			set  bool
+		}{
+			{"callback_token", spec.CallbackToken != ""},
+			{"conversation", spec.Conversation != nil},
+			{"rag", spec.RAG != nil},
+			{"shard", spec.Shard != nil},
+			{"dependencies", len(spec.Dependencies) > 0},
+			{"checkpoint", spec.Checkpoint != nil},
+		} {
+			if field.set {
+				unsupported = append(unsupported, field.name)
+			}
+		}
+		if len(unsupported) > 0 {
+			return nil, fmt.Errorf("job spec version %d has no %s", spec.SpecVersion, strings.Join(unsupported, ", "))
+		}
+		return &jobSpecV1{
+			JobID:          spec.JobID,
+			ProviderID:     spec.ProviderID,
+			BenchmarkID:    spec.BenchmarkID,
+			BenchmarkIndex: spec.BenchmarkIndex,
+			Model:          spec.Model,
+			NumExamples:    spec.NumExamples,
+			Parameters:     spec.Parameters,
+			ExperimentName: spec.ExperimentName,
+			Tags:           spec.Tags,
+			CallbackURL:    spec.CallbackURL,
+			Exports:        spec.Exports,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown job spec version %d", spec.SpecVersion)
+	}
+}
+
+func formatVersions(versions []int) string {
+	formatted := make([]string, len(versions))
+	for i, version := range versions {
+		formatted[i] = fmt.Sprintf("%d", version)
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,75 @@
+package shared
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestNegotiateJobSpecVersion(t *testing.T) {
+	for _, tc := range []struct {
+		supported []int
+		want      int
+	}{
+		{nil, JobSpecVersion},
+		{[]int{JobSpecVersion1}, JobSpecVersion1},
+		{[]int{JobSpecVersion1, JobSpecVersion, 99}, JobSpecVersion},
+	} {
+		got, err := NegotiateJobSpecVersion(tc.supported)
+		if err != nil || got != tc.want {
+			t.Errorf("NegotiateJobSpecVersion(%v) = %d, %v, want %d", tc.supported, got, err, tc.want)
+		}
+	}
+	if _, err := NegotiateJobSpecVersion([]int{99}); err == nil {
+		t.Error("expected an error for an adapter of an unknown version")
+	}
+}
+
+func TestMarshalJobSpecVersion1(t *testing.T) {
+	callbackURL := "http://localhost:8080"
+	spec := &JobSpec{
+		SpecVersion: JobSpecVersion1,
+		JobID:       "job-1",
+		ProviderID:  "provider-1",
+		BenchmarkID: "bench-1",
+		Parameters:  map[string]any{"foo": "bar"},
+		CallbackURL: &callbackURL,
+	}
+	specJSON, err := MarshalJobSpec(spec)
+	if err != nil {
+		t.Fatalf("MarshalJobSpec: %v", err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(specJSON, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fields["spec_version"]; ok {
+		t.Errorf("expected no spec_version in version 1, got %s", specJSON)
+	}
+	if fields["provider_id"] != "provider-1" || fields["callback_url"] != callbackURL {
+		t.Errorf("expected the fields of version 1, got %s", specJSON)
+	}
+
+	spec.Shard = &JobSpecShard{Index: 0, Count: 2}
+	spec.CallbackToken = "token"
+	if _, err := MarshalJobSpec(spec); err == nil || !strings.Contains(err.Error(), "callback_token, shard") {
+		t.Errorf("expected an error naming the fields that version 1 does not have, got %v", err)
+	}
+}
+
+func TestCheckJobSpecVersion(t *testing.T) {
+	evaluation := &api.EvaluationJobConfig{}
+	plain := &api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: "bench-1"}}
+	if err := CheckJobSpecVersion(evaluation, plain, false, JobSpecVersion1); err != nil {
+		t.Errorf("expected a plain benchmark to run on version 1, got %v", err)
+	}
+	if err := CheckJobSpecVersion(evaluation, plain, true, JobSpecVersion1); err == nil {
+		t.Error("expected an error for a callback token on version 1")
+	}
+	dependent := &api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: "bench-2"}, DependsOn: []int{0}}
+	if err := CheckJobSpecVersion(evaluation, dependent, true, JobSpecVersion); err != nil {
+		t.Errorf("expected the current version to have every field, got %v", err)
+	}
+}
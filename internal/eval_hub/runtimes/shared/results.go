@@ -0,0 +1,34 @@
+package shared
+
+import "github.com/eval-hub/eval-hub/pkg/api"
+
+// IsBenchmarkReady reports whether the benchmark at benchmarkIndex can be started when the
+// job is started: it has not finished yet, e.g. because its result was reused from the result
+// cache, and the benchmarks it depends on, if any, have all completed. Benchmarks that wait
+// for others are started by the server once their dependencies complete.
+func IsBenchmarkReady(evaluation *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig, benchmarkIndex int) bool {
+	if status := benchmarkStatus(evaluation, benchmarkIndex); status != nil && api.IsBenchmarkTerminalState(status.Status) {
+		return false
+	}
+	if benchmarkIndex < 0 || benchmarkIndex >= len(benchmarks) {
+		return false
+	}
+	for _, dependency := range benchmarks[benchmarkIndex].DependsOn {
+		if status := benchmarkStatus(evaluation, dependency); status == nil || status.Status != api.StateCompleted {
+			return false
+		}
+	}
+	return true
+}
+
+func benchmarkStatus(evaluation *api.EvaluationJobResource, benchmarkIndex int) *api.BenchmarkStatus {
+	if evaluation == nil || evaluation.Status == nil {
+		return nil
+	}
+	for i, benchmark := range evaluation.Status.Benchmarks {
+		if benchmark.BenchmarkIndex == benchmarkIndex {
+			return &evaluation.Status.Benchmarks[i]
+		}
+	}
+	return nil
+}
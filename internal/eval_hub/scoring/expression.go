@@ -0,0 +1,318 @@
+// Package scoring computes the primary score of a benchmark from its metrics: a single
+// metric, the weighted average of several metrics, or an arithmetic expression of metrics
+// such as 0.5*acc + 0.5*f1.
+//
+// Expressions support numbers, metric names, + - * /, parentheses and the functions min and
+// max, e.g. min(toxicity_probe, jailbreak_probe). Metric names that are not identifiers, e.g.
+// rouge-l, are quoted with single quotes: 'rouge-l'.
+package scoring
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrMissingMetric is returned when a metric of a primary score is not reported.
+var ErrMissingMetric = errors.New("metric not reported")
+
+// MetricValue returns the value of the metric with the given name, or false when it is not
+// reported.
+type MetricValue func(name string) (float64, bool)
+
+// Expression is a parsed arithmetic expression of metrics.
+type Expression struct {
+	source  string
+	root    node
+	metrics []string
+}
+
+// Parse parses an expression of metrics.
+func Parse(source string) (*Expression, error) {
+	p := &parser{source: source}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	if len(p.tokens) == 0 {
+		return nil, fmt.Errorf("the expression is empty")
+	}
+	root, err := p.expression()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %q at position %d", p.tokens[p.pos].text, p.tokens[p.pos].offset)
+	}
+	return &Expression{source: source, root: root, metrics: p.metrics}, nil
+}
+
+// Metrics returns the metrics that the expression refers to, in the order they first appear.
+func (e *Expression) Metrics() []string {
+	return slices.Clone(e.metrics)
+}
+
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Evaluate returns the value of the expression. It fails with ErrMissingMetric when a metric
+// is not reported, and when the result is not a finite number, e.g. after a division by 0.
+func (e *Expression) Evaluate(value MetricValue) (float64, error) {
+	result, err := e.root.eval(value)
+	if err != nil {
+		return 0, err
+	}
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, fmt.Errorf("the expression %q is not a finite number", e.source)
+	}
+	return result, nil
+}
+
+type node interface {
+	eval(value MetricValue) (float64, error)
+}
+
+type number float64
+
+func (n number) eval(MetricValue) (float64, error) {
+	return float64(n), nil
+}
+
+type metric string
+
+func (m metric) eval(value MetricValue) (float64, error) {
+	v, ok := value(string(m))
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrMissingMetric, string(m))
+	}
+	return v, nil
+}
+
+type negation struct {
+	operand node
+}
+
+func (n negation) eval(value MetricValue) (float64, error) {
+	v, err := n.operand.eval(value)
+	return -v, err
+}
+
+type binary struct {
+	operator    byte
+	left, right node
+}
+
+func (b binary) eval(value MetricValue) (float64, error) {
+	left, err := b.left.eval(value)
+	if err != nil {
+		return 0, err
+	}
+	right, err := b.right.eval(value)
+	if err != nil {
+		return 0, err
+	}
+	switch b.operator {
+	case '+':
+		return left + right, nil
+	case '-':
+		return left - right, nil
+	case '*':
+		return left * right, nil
+	default:
+		return left / right, nil
+	}
+}
+
+type call struct {
+	function  string
+	arguments []node
+}
+
+func (c call) eval(value MetricValue) (float64, error) {
+	var result float64
+	for i, argument := range c.arguments {
+		v, err := argument.eval(value)
+		if err != nil {
+			return 0, err
+		}
+		if i == 0 || (c.function == "min" && v < result) || (c.function == "max" && v > result) {
+			result = v
+		}
+	}
+	return result, nil
+}
+
+type tokenKind int
+
+const (
+	tokenNumber tokenKind = iota
+	tokenName
+	tokenOperator
+)
+
+type token struct {
+	kind   tokenKind
+	text   string
+	offset int
+}
+
+type parser struct {
+	source  string
+	tokens  []token
+	pos     int
+	metrics []string
+}
+
+func (p *parser) tokenize() error {
+	for i := 0; i < len(p.source); {
+		c := rune(p.source[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case strings.ContainsRune("+-*/(),", c):
+			p.tokens = append(p.tokens, token{kind: tokenOperator, text: string(c), offset: i})
+			i++
+		case c == '\'':
+			end := strings.IndexByte(p.source[i+1:], '\'')
+			if end <= 0 {
+				return fmt.Errorf("unterminated or empty metric name at position %d", i)
+			}
+			p.tokens = append(p.tokens, token{kind: tokenName, text: p.source[i+1 : i+1+end], offset: i})
+			i += end + 2
+		case unicode.IsDigit(c) || c == '.':
+			start := i
+			for i < len(p.source) && (unicode.IsDigit(rune(p.source[i])) || p.source[i] == '.') {
+				i++
+			}
+			p.tokens = append(p.tokens, token{kind: tokenNumber, text: p.source[start:i], offset: start})
+		case unicode.IsLetter(c) || c == '_':
+			start := i
+			for i < len(p.source) && (unicode.IsLetter(rune(p.source[i])) || unicode.IsDigit(rune(p.source[i])) || p.source[i] == '_' || p.source[i] == '.') {
+				i++
+			}
+			p.tokens = append(p.tokens, token{kind: tokenName, text: p.source[start:i], offset: start})
+		default:
+			return fmt.Errorf("unexpected %q at position %d", c, i)
+		}
+	}
+	return nil
+}
+
+func (p *parser) peek(text string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenOperator && p.tokens[p.pos].text == text
+}
+
+func (p *parser) expect(text string) error {
+	if !p.peek(text) {
+		return p.unexpected("expected " + text)
+	}
+	p.pos++
+	return nil
+}
+
+func (p *parser) unexpected(expected string) error {
+	if p.pos >= len(p.tokens) {
+		return fmt.Errorf("unexpected end of the expression, %s", expected)
+	}
+	return fmt.Errorf("unexpected %q at position %d, %s", p.tokens[p.pos].text, p.tokens[p.pos].offset, expected)
+}
+
+// expression = term { ("+" | "-") term }
+func (p *parser) expression() (node, error) {
+	left, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("+") || p.peek("-") {
+		operator := p.tokens[p.pos].text[0]
+		p.pos++
+		right, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{operator: operator, left: left, right: right}
+	}
+	return left, nil
+}
+
+// term = unary { ("*" | "/") unary }
+func (p *parser) term() (node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek("*") || p.peek("/") {
+		operator := p.tokens[p.pos].text[0]
+		p.pos++
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = binary{operator: operator, left: left, right: right}
+	}
+	return left, nil
+}
+
+// unary = "-" unary | primary
+func (p *parser) unary() (node, error) {
+	if p.peek("-") {
+		p.pos++
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return negation{operand: operand}, nil
+	}
+	return p.primary()
+}
+
+// primary = number | metric | ("min" | "max") "(" expression { "," expression } ")" | "(" expression ")"
+func (p *parser) primary() (node, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, p.unexpected("expected a number, a metric or (")
+	}
+	t := p.tokens[p.pos]
+	switch {
+	case t.kind == tokenNumber:
+		p.pos++
+		value, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.text, t.offset)
+		}
+		return number(value), nil
+	case t.kind == tokenName && (t.text == "min" || t.text == "max") && p.pos+1 < len(p.tokens) && p.tokens[p.pos+1].text == "(":
+		p.pos += 2
+		c := call{function: t.text}
+		for {
+			argument, err := p.expression()
+			if err != nil {
+				return nil, err
+			}
+			c.arguments = append(c.arguments, argument)
+			if !p.peek(",") {
+				break
+			}
+			p.pos++
+		}
+		return c, p.expect(")")
+	case t.kind == tokenName:
+		p.pos++
+		if !slices.Contains(p.metrics, t.text) {
+			p.metrics = append(p.metrics, t.text)
+		}
+		return metric(t.text), nil
+	case p.peek("("):
+		p.pos++
+		inner, err := p.expression()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	default:
+		return nil, p.unexpected("expected a number, a metric or (")
+	}
+}
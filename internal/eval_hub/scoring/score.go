@@ -0,0 +1,59 @@
+package scoring
+
+import (
+	"fmt"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// Metrics returns the metrics that the primary score refers to.
+func Metrics(primaryScore *api.PrimaryScore) ([]string, error) {
+	switch {
+	case !primaryScore.IsSet():
+		return nil, nil
+	case primaryScore.Metric != "":
+		return []string{primaryScore.Metric}, nil
+	case primaryScore.Expression != "":
+		expression, err := Parse(primaryScore.Expression)
+		if err != nil {
+			return nil, err
+		}
+		return expression.Metrics(), nil
+	}
+	metrics := make([]string, 0, len(primaryScore.Metrics))
+	for _, metric := range primaryScore.Metrics {
+		metrics = append(metrics, metric.Metric)
+	}
+	return metrics, nil
+}
+
+// Evaluate returns the value of the primary score from the values of its metrics. It fails
+// with ErrMissingMetric when one of them is not reported.
+func Evaluate(primaryScore *api.PrimaryScore, value MetricValue) (float64, error) {
+	switch {
+	case !primaryScore.IsSet():
+		return 0, fmt.Errorf("the primary score is not set")
+	case primaryScore.Metric != "":
+		return metric(primaryScore.Metric).eval(value)
+	case primaryScore.Expression != "":
+		expression, err := Parse(primaryScore.Expression)
+		if err != nil {
+			return 0, err
+		}
+		return expression.Evaluate(value)
+	}
+	var sum, sumOfWeights float64
+	for _, weighted := range primaryScore.Metrics {
+		v, err := metric(weighted.Metric).eval(value)
+		if err != nil {
+			return 0, err
+		}
+		weight := float64(weighted.Weight)
+		if weight == 0 {
+			weight = 1
+		}
+		sum += weight * v
+		sumOfWeights += weight
+	}
+	return sum / sumOfWeights, nil
+}
@@ -0,0 +1,99 @@
+package scoring
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func values(metrics map[string]float64) MetricValue {
+	return func(name string) (float64, bool) {
+		value, ok := metrics[name]
+		return value, ok
+	}
+}
+
+func TestExpression(t *testing.T) {
+	metrics := values(map[string]float64{"acc": 0.8, "f1": 0.4, "rouge-l": 0.5, "safety.toxicity": 0.1})
+	tests := map[string]float64{
+		"0.5*acc + 0.5*f1":            0.6,
+		"acc - f1 - 0.1":              0.3,
+		"acc / (f1 + 0.4)":            1,
+		"-acc + 1":                    0.2,
+		"2 * -f1":                     -0.8,
+		"'rouge-l' * 2":               1,
+		"1 - safety.toxicity":         0.9,
+		"min(acc, f1, 'rouge-l')":     0.4,
+		"max(acc, f1) - min(acc, f1)": 0.4,
+		"  ( ( acc ) )  ":             0.8,
+		"0.25 * (acc + f1 + 2 * 0.5)": 0.55,
+	}
+	for source, want := range tests {
+		expression, err := Parse(source)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", source, err)
+			continue
+		}
+		got, err := expression.Evaluate(metrics)
+		if err != nil || math.Abs(got-want) > 1e-9 {
+			t.Errorf("%q = %v, %v, want %v", source, got, err, want)
+		}
+	}
+
+	expression, err := Parse("acc + max(f1, acc) * 'rouge-l'")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := expression.Metrics(); !slices.Equal(got, []string{"acc", "f1", "rouge-l"}) {
+		t.Errorf("Metrics() = %v", got)
+	}
+	if _, err := expression.Evaluate(values(map[string]float64{"acc": 1})); !errors.Is(err, ErrMissingMetric) {
+		t.Errorf("expected ErrMissingMetric, got %v", err)
+	}
+	expression, err = Parse("acc / 0")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if _, err := expression.Evaluate(metrics); err == nil {
+		t.Error("expected a division by 0 to fail")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, source := range []string{"", "  ", "acc +", "(acc", "acc)", "acc f1", "min()", "max(acc,", "1.2.3", "''", "'acc", "acc % 2", "* acc"} {
+		if _, err := Parse(source); err == nil {
+			t.Errorf("Parse(%q): expected an error", source)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	metrics := values(map[string]float64{"acc": 0.8, "f1": 0.4})
+	tests := []struct {
+		primaryScore api.PrimaryScore
+		want         float64
+		names        []string
+	}{
+		{api.PrimaryScore{Metric: "acc"}, 0.8, []string{"acc"}},
+		{api.PrimaryScore{Metrics: []api.WeightedMetric{{Metric: "acc", Weight: 3}, {Metric: "f1"}}}, 0.7, []string{"acc", "f1"}},
+		{api.PrimaryScore{Expression: "(acc + f1) / 2"}, 0.6, []string{"acc", "f1"}},
+	}
+	for _, test := range tests {
+		got, err := Evaluate(&test.primaryScore, metrics)
+		if err != nil || math.Abs(got-test.want) > 1e-9 {
+			t.Errorf("Evaluate(%s) = %v, %v, want %v", test.primaryScore.Name(), got, err, test.want)
+		}
+		if names, err := Metrics(&test.primaryScore); err != nil || !slices.Equal(names, test.names) {
+			t.Errorf("Metrics(%s) = %v, %v, want %v", test.primaryScore.Name(), names, err, test.names)
+		}
+	}
+	if _, err := Evaluate(&api.PrimaryScore{Metrics: []api.WeightedMetric{{Metric: "acc"}, {Metric: "recall"}}}, metrics); !errors.Is(err, ErrMissingMetric) {
+		t.Errorf("expected ErrMissingMetric, got %v", err)
+	}
+	if _, err := Evaluate(nil, metrics); err == nil {
+		t.Error("expected an error without a primary score")
+	}
+}
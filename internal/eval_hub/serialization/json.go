@@ -43,6 +43,10 @@ func formatValidationError(errs validator.ValidationErrors) string {
 		if param := e.Param(); param != "" {
 			return fmt.Sprintf("test_data_ref: %s", param)
 		}
+	case "pod_metadata":
+		return fmt.Sprintf("pod_metadata.%s: %s", e.Field(), e.Param())
+	case "spot_checkpoint":
+		return fmt.Sprintf("spot: %s", e.Param())
 	}
 	return errs.Error()
 }
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/google/uuid"
+)
+
+// BodyLoggingMiddleware logs the request and response bodies of the requests matched by
+// cfg, with the request ID so that they can be correlated with the other logs of the
+// request. Bodies are captured as the handler reads and writes them, up to the configured
+// size: larger bodies, and bodies that are not JSON, are logged by their size only, since
+// their secrets cannot be redacted reliably. Event streams are not captured.
+func BodyLoggingMiddleware(next http.Handler, cfg *config.BodyLoggingConfig, logger *slog.Logger) http.Handler {
+	if !cfg.IsEnabled() {
+		return next
+	}
+
+	maxBytes := cfg.EffectiveMaxBytes()
+	redactedFields := cfg.EffectiveRedactedFields()
+	logger.Info("Enabled request and response body logging", "routes", cfg.Routes, "tenants", cfg.Tenants)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant := r.Header.Get(TENANT_HEADER)
+		if !cfg.Matches(r.URL.Path, tenant) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// the handler takes the request ID from the header, so set it when the client did not
+		requestID := r.Header.Get(TRANSACTION_ID_HEADER)
+		if requestID == "" {
+			requestID = uuid.New().String()
+			r.Header.Set(TRANSACTION_ID_HEADER, requestID)
+		}
+
+		requestBody := &bodyCapture{maxBytes: maxBytes}
+		if r.Body != nil && r.Body != http.NoBody {
+			r.Body = &capturingReadCloser{ReadCloser: r.Body, capture: requestBody}
+		}
+		rw := &bodyCaptureWriter{ResponseWriter: w, statusCode: http.StatusOK, capture: bodyCapture{maxBytes: maxBytes}}
+		next.ServeHTTP(rw, r)
+
+		logger.Info("Request and response bodies",
+			constants.LOG_REQUEST_ID, requestID,
+			constants.LOG_METHOD, r.Method,
+			constants.LOG_URI, r.URL.RequestURI(),
+			constants.LOG_TENANT, tenant,
+			constants.LOG_RESP_CODE, rw.statusCode,
+			"request_body", requestBody.format(r.Header.Get("Content-Type"), redactedFields),
+			"response_body", rw.capture.format(rw.Header().Get("Content-Type"), redactedFields),
+		)
+	})
+}
+
+// bodyCapture keeps the first maxBytes of a body and counts the rest.
+type bodyCapture struct {
+	maxBytes int
+	buf      bytes.Buffer
+	size     int
+	skipped  string // why the body is not captured, e.g. for event streams
+}
+
+func (c *bodyCapture) write(p []byte) {
+	c.size += len(p)
+	if c.skipped != "" {
+		return
+	}
+	if room := c.maxBytes - c.buf.Len(); room > 0 {
+		c.buf.Write(p[:min(len(p), room)])
+	}
+}
+
+// format returns the body as it is logged: JSON with the redacted fields replaced, or a
+// description of the body when it cannot be logged safely.
+func (c *bodyCapture) format(contentType string, redactedFields []string) string {
+	switch {
+	case c.size == 0:
+		return ""
+	case c.skipped != "":
+		return fmt.Sprintf("[%d bytes of %s, not captured]", c.size, c.skipped)
+	case c.size > c.maxBytes:
+		return fmt.Sprintf("[%d bytes, larger than %d]", c.size, c.maxBytes)
+	}
+	if redacted, ok := redactBody(c.buf.Bytes(), redactedFields); ok {
+		return redacted
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "" {
+		mediaType = "unknown content type"
+	}
+	return fmt.Sprintf("[%d bytes of %s]", c.size, mediaType)
+}
+
+// redactBody redacts a JSON body with config.RedactBody, at any depth and in the
+// operations of JSON patches. It returns false for bodies that are not JSON.
+func redactBody(body []byte, redactedFields []string) (string, bool) {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return "", false
+	}
+	data, err := json.Marshal(config.RedactBody(v, redactedFields))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// capturingReadCloser captures the request body as the handler reads it, so that bodies
+// are neither read twice nor past the limits the handler applies.
+type capturingReadCloser struct {
+	io.ReadCloser
+	capture *bodyCapture
+}
+
+func (r *capturingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.capture.write(p[:n])
+	return n, err
+}
+
+// bodyCaptureWriter captures the status code and the body of the response.
+type bodyCaptureWriter struct {
+	http.ResponseWriter
+	statusCode    int
+	headerWritten bool
+	capture       bodyCapture
+}
+
+func (rw *bodyCaptureWriter) WriteHeader(code int) {
+	if !rw.headerWritten && code >= 200 {
+		rw.headerWritten = true
+		rw.statusCode = code
+		if mediaType, _, _ := mime.ParseMediaType(rw.Header().Get("Content-Type")); mediaType == "text/event-stream" {
+			rw.capture.skipped = mediaType
+		}
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *bodyCaptureWriter) Write(p []byte) (int, error) {
+	if !rw.headerWritten {
+		rw.WriteHeader(http.StatusOK)
+	}
+	rw.capture.write(p)
+	return rw.ResponseWriter.Write(p)
+}
+
+// Flush keeps streamed responses flowing through the capture.
+func (rw *bodyCaptureWriter) Flush() {
+	_ = http.NewResponseController(rw.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer (deadlines).
+func (rw *bodyCaptureWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,177 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+)
+
+// bodyLogRecords returns the body log records written to buf.
+func bodyLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var records []map[string]any
+	for line := range strings.SplitSeq(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("decode log line %q: %v", line, err)
+		}
+		if record["msg"] == "Request and response bodies" {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+func TestBodyLoggingMiddleware(t *testing.T) {
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(TRANSACTION_ID_HEADER, r.Header.Get(TRANSACTION_ID_HEADER))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	})
+	cfg := &config.BodyLoggingConfig{
+		Enabled:        true,
+		Routes:         []string{"/api/v1/evaluations/jobs"},
+		Tenants:        []string{"team-a"},
+		MaxBytes:       256,
+		RedactedFields: []string{"custom.api_token"},
+	}
+
+	serve := func(t *testing.T, path string, tenant string, body string) (*httptest.ResponseRecorder, []map[string]any) {
+		t.Helper()
+		var logs bytes.Buffer
+		handler := BodyLoggingMiddleware(echo, cfg, slog.New(slog.NewJSONHandler(&logs, nil)))
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(TENANT_HEADER, tenant)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w, bodyLogRecords(t, &logs)
+	}
+
+	t.Run("logs redacted bodies with the request ID", func(t *testing.T) {
+		body := `{"name":"job","model":{"url":"http://m","auth":{"secret_ref":"s"}},"custom":{"api_token":"abc"}}`
+		w, records := serve(t, "/api/v1/evaluations/jobs", "team-a", body)
+		if w.Body.String() != body {
+			t.Fatalf("the response must not be changed, got %s", w.Body.String())
+		}
+		if len(records) != 1 {
+			t.Fatalf("expected one body log record, got %d", len(records))
+		}
+		record := records[0]
+		if record["request_id"] == "" || record["request_id"] != w.Header().Get(TRANSACTION_ID_HEADER) {
+			t.Errorf("request_id %v does not match the one the handler saw %q", record["request_id"], w.Header().Get(TRANSACTION_ID_HEADER))
+		}
+		if record["code"] != float64(http.StatusCreated) {
+			t.Errorf("code: got %v", record["code"])
+		}
+		for _, key := range []string{"request_body", "response_body"} {
+			logged, _ := record[key].(string)
+			if strings.Contains(logged, "abc") || strings.Contains(logged, "secret_ref") {
+				t.Errorf("%s was not redacted: %s", key, logged)
+			}
+			if !strings.Contains(logged, `"name":"job"`) {
+				t.Errorf("%s is missing the other fields: %s", key, logged)
+			}
+		}
+	})
+
+	t.Run("redacts the objects of JSON arrays", func(t *testing.T) {
+		_, records := serve(t, "/api/v1/evaluations/jobs/j1", "team-a", `[{"op":"replace","path":"/x","token":"abc"}]`)
+		if len(records) != 1 {
+			t.Fatalf("expected one body log record, got %d", len(records))
+		}
+		if logged, _ := records[0]["request_body"].(string); strings.Contains(logged, "abc") || !strings.Contains(logged, `"op":"replace"`) {
+			t.Errorf("request_body: %s", logged)
+		}
+	})
+
+	t.Run("redacts nested secrets and the values of JSON patch paths", func(t *testing.T) {
+		body := `{"name":"job","benchmarks":[{"id":"b1","parameters":{"auth":{"api_key":"nested-key"}}}],` +
+			`"outer":{"custom":{"api_token":"nested-token"}}}`
+		_, records := serve(t, "/api/v1/evaluations/jobs", "team-a", body)
+		logged, _ := records[0]["request_body"].(string)
+		if strings.Contains(logged, "nested-key") || strings.Contains(logged, "nested-token") {
+			t.Errorf("nested secrets were not redacted: %s", logged)
+		}
+		if !strings.Contains(logged, `"id":"b1"`) {
+			t.Errorf("request_body is missing the other fields: %s", logged)
+		}
+
+		patch := `[{"op":"replace","path":"/model/auth","value":{"secret_ref":"patched-secret"}},` +
+			`{"op":"add","path":"/custom","value":{"api_token":"patched-token"}},` +
+			`{"op":"replace","path":"/name","value":"renamed"}]`
+		_, records = serve(t, "/api/v1/evaluations/jobs/j1", "team-a", patch)
+		logged, _ = records[0]["request_body"].(string)
+		if strings.Contains(logged, "patched-secret") || strings.Contains(logged, "patched-token") {
+			t.Errorf("the values of sensitive patch paths were not redacted: %s", logged)
+		}
+		if !strings.Contains(logged, `"value":"renamed"`) {
+			t.Errorf("the values of other patch paths must be kept: %s", logged)
+		}
+	})
+
+	t.Run("logs large and non-JSON bodies by size only", func(t *testing.T) {
+		_, records := serve(t, "/api/v1/evaluations/jobs", "team-a", `{"token":"`+strings.Repeat("x", 300)+`"}`)
+		if logged, _ := records[0]["request_body"].(string); logged != "[312 bytes, larger than 256]" {
+			t.Errorf("large body: got %q", logged)
+		}
+		_, records = serve(t, "/api/v1/evaluations/jobs", "team-a", "token=abc")
+		if logged, _ := records[0]["request_body"].(string); logged != "[9 bytes of application/json]" {
+			t.Errorf("non-JSON body: got %q", logged)
+		}
+	})
+
+	t.Run("skips other routes and tenants", func(t *testing.T) {
+		if _, records := serve(t, "/api/v1/evaluations/providers", "team-a", "{}"); len(records) != 0 {
+			t.Errorf("route not configured: got %d records", len(records))
+		}
+		if _, records := serve(t, "/api/v1/evaluations/jobs", "team-b", "{}"); len(records) != 0 {
+			t.Errorf("tenant not configured: got %d records", len(records))
+		}
+	})
+}
+
+func TestBodyLoggingMiddleware_DoesNotCaptureEventStreams(t *testing.T) {
+	var logs bytes.Buffer
+	stream := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("data: {\"token\":\"abc\"}\n\n"))
+		http.NewResponseController(w).Flush()
+	})
+	handler := BodyLoggingMiddleware(stream, &config.BodyLoggingConfig{Enabled: true}, slog.New(slog.NewJSONHandler(&logs, nil)))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/evaluations/jobs/j1/watch", nil))
+	if !w.Flushed {
+		t.Error("the event stream was not flushed")
+	}
+	records := bodyLogRecords(t, &logs)
+	if len(records) != 1 {
+		t.Fatalf("expected one body log record, got %d", len(records))
+	}
+	if logged := records[0]["response_body"]; logged != "[23 bytes of text/event-stream, not captured]" {
+		t.Errorf("response_body: got %q", logged)
+	}
+}
+
+func TestBodyLoggingMiddleware_Disabled(t *testing.T) {
+	next := http.NewServeMux()
+	if handler := BodyLoggingMiddleware(next, nil, slog.Default()); handler != next {
+		t.Fatal("expected the handler itself when body logging is off")
+	}
+	if handler := BodyLoggingMiddleware(next, &config.BodyLoggingConfig{}, slog.Default()); handler != next {
+		t.Fatal("expected the handler itself when body logging is not enabled")
+	}
+}
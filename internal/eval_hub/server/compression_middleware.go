@@ -0,0 +1,255 @@
+package server
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+var (
+	gzipWriters = sync.Pool{New: func() any {
+		return gzip.NewWriter(io.Discard)
+	}}
+	flateWriters = sync.Pool{New: func() any {
+		w, _ := flate.NewWriter(io.Discard, flate.DefaultCompression)
+		return w
+	}}
+)
+
+// CompressionMiddleware compresses responses with gzip or deflate, as negotiated with the
+// Accept-Encoding request header. Only textual content types are compressed, and only
+// responses of at least minBytes: smaller ones are not worth the CPU. Responses that are
+// already encoded, partial content and server-sent events are passed through unchanged.
+func CompressionMiddleware(next http.Handler, minBytes int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressResponseWriter{
+			ResponseWriter: w,
+			encoding:       encoding,
+			minBytes:       minBytes,
+			statusCode:     http.StatusOK,
+		}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding returns the encoding to compress with for the Accept-Encoding header
+// value, preferring gzip, or "" when the client accepts neither gzip nor deflate. An
+// encoding listed with q=0 is refused even when "*" is accepted.
+func negotiateEncoding(acceptEncoding string) string {
+	// accepted holds the encodings that the header lists, whether they are accepted or refused
+	accepted := map[string]bool{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.EqualFold(strings.TrimSpace(key), "q") {
+				if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		accepted[name] = q > 0
+	}
+	for _, encoding := range []string{encodingGzip, encodingDeflate} {
+		if accepted[encoding] {
+			return encoding
+		}
+	}
+	if accepted["*"] {
+		// "*" stands for the encodings that the header does not list
+		for _, encoding := range []string{encodingGzip, encodingDeflate} {
+			if _, listed := accepted[encoding]; !listed {
+				return encoding
+			}
+		}
+	}
+	return ""
+}
+
+// isCompressible returns true for the content types worth compressing.
+func isCompressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	switch {
+	case mediaType == "text/event-stream":
+		return false
+	case strings.HasPrefix(mediaType, "text/"):
+		return true
+	case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"),
+		mediaType == "application/yaml", mediaType == "application/x-yaml",
+		mediaType == "application/javascript", mediaType == "image/svg+xml":
+		return true
+	default:
+		return false
+	}
+}
+
+// compressResponseWriter holds the response back until it has seen minBytes of it, then
+// decides whether to compress it. Flushing decides early so that streamed responses are
+// not delayed.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding   string
+	minBytes   int
+	statusCode int
+
+	headerWritten bool // WriteHeader was called by the handler
+	decided       bool // the headers were sent, compressing or not
+	compressor    io.WriteCloser
+	buf           bytes.Buffer
+}
+
+func (cw *compressResponseWriter) WriteHeader(code int) {
+	if cw.headerWritten || cw.decided {
+		return
+	}
+	// informational responses are sent as they are and do not end the headers
+	if code >= 100 && code < 200 {
+		cw.ResponseWriter.WriteHeader(code)
+		return
+	}
+	cw.headerWritten = true
+	cw.statusCode = code
+	if !cw.eligible() {
+		cw.decide(false)
+	}
+}
+
+func (cw *compressResponseWriter) Write(p []byte) (int, error) {
+	if !cw.headerWritten {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if cw.decided {
+		if cw.compressor != nil {
+			return cw.compressor.Write(p)
+		}
+		return cw.ResponseWriter.Write(p)
+	}
+	cw.buf.Write(p)
+	if cw.buf.Len() >= cw.minBytes {
+		if err := cw.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush sends what was written so far, compressing it when it is large enough.
+func (cw *compressResponseWriter) Flush() {
+	if !cw.headerWritten {
+		cw.WriteHeader(http.StatusOK)
+	}
+	if !cw.decided {
+		_ = cw.decide(cw.buf.Len() >= cw.minBytes && cw.eligible())
+	}
+	if f, ok := cw.compressor.(interface{ Flush() error }); ok {
+		_ = f.Flush()
+	}
+	_ = http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer (deadlines).
+func (cw *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// eligible returns true when the response may be compressed, whatever its size.
+func (cw *compressResponseWriter) eligible() bool {
+	header := cw.Header()
+	switch {
+	case cw.statusCode == http.StatusNoContent, cw.statusCode == http.StatusNotModified,
+		cw.statusCode == http.StatusPartialContent:
+		return false
+	case header.Get("Content-Encoding") != "", header.Get("Content-Range") != "":
+		return false
+	default:
+		return isCompressible(header.Get("Content-Type"))
+	}
+}
+
+// decide sends the headers, compressing the rest of the response when compress is true,
+// and writes out what was held back.
+func (cw *compressResponseWriter) decide(compress bool) error {
+	cw.decided = true
+	header := cw.Header()
+	if compress {
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+		header.Add("Vary", "Accept-Encoding")
+		cw.compressor = cw.newCompressor()
+	} else if cw.eligible() {
+		// the same resource may be compressed when it grows
+		header.Add("Vary", "Accept-Encoding")
+	}
+	cw.ResponseWriter.WriteHeader(cw.statusCode)
+
+	if cw.buf.Len() == 0 {
+		return nil
+	}
+	var err error
+	if cw.compressor != nil {
+		_, err = cw.compressor.Write(cw.buf.Bytes())
+	} else {
+		_, err = cw.ResponseWriter.Write(cw.buf.Bytes())
+	}
+	cw.buf.Reset()
+	return err
+}
+
+func (cw *compressResponseWriter) newCompressor() io.WriteCloser {
+	if cw.encoding == encodingDeflate {
+		w := flateWriters.Get().(*flate.Writer)
+		w.Reset(cw.ResponseWriter)
+		return w
+	}
+	w := gzipWriters.Get().(*gzip.Writer)
+	w.Reset(cw.ResponseWriter)
+	return w
+}
+
+// close completes the response once the handler returned.
+func (cw *compressResponseWriter) close() {
+	if !cw.decided {
+		if !cw.headerWritten {
+			// nothing was written, let net/http send its default response
+			return
+		}
+		_ = cw.decide(false)
+	}
+	if cw.compressor == nil {
+		return
+	}
+	_ = cw.compressor.Close()
+	switch w := cw.compressor.(type) {
+	case *gzip.Writer:
+		gzipWriters.Put(w)
+	case *flate.Writer:
+		flateWriters.Put(w)
+	}
+	cw.compressor = nil
+}
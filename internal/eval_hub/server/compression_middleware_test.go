@@ -0,0 +1,189 @@
+package server
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", "gzip"},
+		{"deflate", "deflate"},
+		{"deflate, gzip", "gzip"},
+		{"GZIP;q=0.5, br", "gzip"},
+		{"gzip;q=0, deflate", "deflate"},
+		{"gzip;q=0", ""},
+		{"*", "gzip"},
+		{"br, *;q=0", ""},
+		{"gzip;q=0, *", "deflate"},
+		{"gzip;q=0, deflate;q=0, *", ""},
+	}
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.acceptEncoding); got != tt.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.acceptEncoding, got, tt.want)
+		}
+	}
+}
+
+func serveCompressed(t *testing.T, handler http.HandlerFunc, acceptEncoding string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/evaluations/providers", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	CompressionMiddleware(handler, 1024).ServeHTTP(rec, req)
+	return rec
+}
+
+func jsonHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		// written in pieces, as json.Encoder may
+		for len(body) > 0 {
+			n := min(len(body), 100)
+			_, _ = io.WriteString(w, body[:n])
+			body = body[n:]
+		}
+	}
+}
+
+func TestCompressionMiddleware_CompressesLargeResponses(t *testing.T) {
+	body := `{"items":[` + strings.Repeat(`{"id":"benchmark","provider_id":"lm_evaluation_harness"},`, 100) + `{}]}`
+
+	for _, tt := range []struct {
+		encoding   string
+		decompress func(io.Reader) (io.Reader, error)
+	}{
+		{encoding: "gzip", decompress: func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		{encoding: "deflate", decompress: func(r io.Reader) (io.Reader, error) { return flate.NewReader(r), nil }},
+	} {
+		t.Run(tt.encoding, func(t *testing.T) {
+			rec := serveCompressed(t, jsonHandler(body), tt.encoding)
+
+			if got := rec.Header().Get("Content-Encoding"); got != tt.encoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.encoding)
+			}
+			if got := rec.Header().Get("Vary"); got != "Accept-Encoding" {
+				t.Errorf("Vary = %q, want Accept-Encoding", got)
+			}
+			if rec.Body.Len() >= len(body) {
+				t.Errorf("compressed body is %d bytes, not smaller than %d", rec.Body.Len(), len(body))
+			}
+			reader, err := tt.decompress(rec.Body)
+			if err != nil {
+				t.Fatalf("decompress: %v", err)
+			}
+			decompressed, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("decompress: %v", err)
+			}
+			if string(decompressed) != body {
+				t.Fatalf("decompressed body differs from the response")
+			}
+		})
+	}
+}
+
+func TestCompressionMiddleware_PassesThrough(t *testing.T) {
+	large := strings.Repeat("x", 4096)
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		handler        http.HandlerFunc
+		wantStatus     int
+		wantBody       string
+	}{
+		{
+			name:           "client does not accept compression",
+			acceptEncoding: "",
+			handler:        jsonHandler(large),
+			wantStatus:     http.StatusOK,
+			wantBody:       large,
+		},
+		{
+			name:           "small response",
+			acceptEncoding: "gzip",
+			handler:        jsonHandler(`{"status":"healthy"}`),
+			wantStatus:     http.StatusOK,
+			wantBody:       `{"status":"healthy"}`,
+		},
+		{
+			name:           "binary content",
+			acceptEncoding: "gzip",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "image/png")
+				_, _ = io.WriteString(w, large)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   large,
+		},
+		{
+			name:           "already encoded",
+			acceptEncoding: "gzip",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/plain")
+				w.Header().Set("Content-Encoding", "br")
+				_, _ = io.WriteString(w, large)
+			},
+			wantStatus: http.StatusOK,
+			wantBody:   large,
+		},
+		{
+			name:           "no content",
+			acceptEncoding: "gzip",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			wantStatus: http.StatusNoContent,
+			wantBody:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveCompressed(t, tt.handler, tt.acceptEncoding)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Content-Encoding"); got == "gzip" {
+				t.Fatalf("response was compressed")
+			}
+			if rec.Body.String() != tt.wantBody {
+				t.Fatalf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestCompressionMiddleware_DoesNotHoldBackEventStreams(t *testing.T) {
+	flushed := make(chan string, 1)
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = io.WriteString(w, "event: update\ndata: {}\n\n")
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("Flush() error: %v", err)
+		}
+		flushed <- w.(*compressResponseWriter).ResponseWriter.(*httptest.ResponseRecorder).Body.String()
+	}
+
+	rec := serveCompressed(t, handler, "gzip")
+	if got := <-flushed; got != "event: update\ndata: {}\n\n" {
+		t.Fatalf("body before the handler returned = %q, want the event", got)
+	}
+	if got := rec.Header().Get("Content-Encoding"); got != "" {
+		t.Fatalf("Content-Encoding = %q, want none for an event stream", got)
+	}
+}
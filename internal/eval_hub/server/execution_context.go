@@ -1,10 +1,13 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
@@ -20,6 +23,9 @@ const (
 	TRANSACTION_ID_HEADER = "X-Global-Transaction-Id"
 	USER_HEADER           = "X-User"
 	TENANT_HEADER         = "X-Tenant"
+	GROUPS_HEADER         = "X-Groups"
+	GROUPS_SEPARATOR      = "|"
+	LANGUAGE_HEADER       = "Accept-Language"
 )
 
 // newExecutionContext creates a new ExecutionContext with default values. This function
@@ -27,7 +33,8 @@ const (
 // request-scoped context.
 //
 // Identity headers: in cluster mode kube-rbac-proxy sets X-Tenant and X-User (required).
-// Local mode (--local) does not require these headers.
+// Local mode (--local) does not require these headers. X-Groups, when set, holds the
+// groups of the user separated by "|".
 //
 // This enables automatic request ID tracking (from X-Global-Transaction-Id header or
 // auto-generated UUID) and structured logging with consistent request metadata.
@@ -56,12 +63,29 @@ func (s *Server) newExecutionContext(r *http.Request) *executioncontext.Executio
 	// Use r.Context() so OTEL trace context (and the HTTP span from otelhttp) propagates
 	// to handlers and downstream calls (storage, runtime, mlflow). Using context.Background()
 	// would break parent-span linkage and create orphan traces.
-	return executioncontext.NewExecutionContext(
+	ctx := executioncontext.NewExecutionContext(
 		r.Context(),
 		requestID,
 		enhancedLogger,
 		api.User(user),
 		api.Tenant(tenant))
+	ctx.Groups = parseGroups(r.Header.Get(GROUPS_HEADER))
+	ctx.Messages = s.messageCatalogs.Negotiate(r.Header.Get(LANGUAGE_HEADER))
+	if trace := executioncontext.TraceFrom(r.Context()); trace != nil {
+		ctx.Trace = trace
+		ctx.Timeout = trace.Timeout()
+	}
+	return ctx
+}
+
+func parseGroups(header string) []string {
+	var groups []string
+	for group := range strings.SplitSeq(header, GROUPS_SEPARATOR) {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+	return groups
 }
 
 // Abstract request objects to not depend on the underlying HTTP framework.
@@ -117,6 +141,25 @@ func (r *ReqWrapper) BodyAsBytes() ([]byte, error) {
 	return bodyBytes, nil
 }
 
+func (r *ReqWrapper) Body() io.Reader {
+	return &limitedBodyReader{body: r.Request.Body}
+}
+
+// limitedBodyReader reports a body larger than the limit of http.MaxBytesReader as a service
+// error, as BodyAsBytes does.
+type limitedBodyReader struct {
+	body io.Reader
+}
+
+func (b *limitedBodyReader) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return n, serviceerrors.NewServiceError(messages.RequestBodyTooLarge, "Limit", maxErr.Limit)
+	}
+	return n, err
+}
+
 func (r *ReqWrapper) SetHeader(key string, value string) {
 	r.Request.Header.Set(key, value)
 }
@@ -172,17 +215,53 @@ func (r RespWrapper) SetStatusCode(code int) {
 	r.Response.WriteHeader(code)
 }
 
+func (r RespWrapper) Flush() error {
+	return http.NewResponseController(r.Response).Flush()
+}
+
+func (r RespWrapper) SetWriteDeadline(deadline time.Time) error {
+	return http.NewResponseController(r.Response).SetWriteDeadline(deadline)
+}
+
 func (r RespWrapper) errorWithMessageCode(requestId string, messageCode *messages.MessageCode, messageParams ...any) {
+	// a failure of a request that ran out of time is reported as such, with what it did
+	if messageCode.GetStatusCode() >= constants.HTTPCodeInternalServerError && r.deadlineExceeded() {
+		messageCode = messages.RequestTimeout
+		messageParams = []any{
+			"Timeout", r.ctx.Timeout.String(),
+			"Elapsed", time.Since(r.ctx.StartedAt).Round(time.Millisecond).String(),
+			"Diagnostics", r.ctx.Trace.String(),
+		}
+	}
+	// the log keeps the English message, the client gets it in its negotiated locale
 	msg := messages.GetErrorMessage(messageCode, messageParams...)
+	localizedMsg := msg
+	if r.ctx != nil && r.ctx.Messages != nil {
+		var locale string
+		localizedMsg, locale = r.ctx.Messages.ErrorMessage(messageCode, messageParams...)
+		r.SetHeader("Content-Language", locale)
+	}
 
 	r.DeleteHeader("Content-Length")
 
 	r.SetHeader("X-Content-Type-Options", "nosniff")
-	r.WriteJSON(api.Error{Message: msg, MessageCode: messageCode.GetCode(), Trace: requestId}, messageCode.GetStatusCode())
+	r.WriteJSON(api.Error{
+		Code:        messageCode.GetErrorCode(),
+		MessageCode: messageCode.GetCode(),
+		Message:     localizedMsg,
+		DocsURL:     messageCode.GetDocsURL(),
+		Retriable:   messageCode.IsRetriable(),
+		Trace:       requestId,
+	}, messageCode.GetStatusCode())
 
 	logging.LogRequestFailed(r.ctx, messageCode.GetStatusCode(), msg, 2)
 }
 
+// deadlineExceeded reports whether the request has exceeded its deadline.
+func (r RespWrapper) deadlineExceeded() bool {
+	return r.ctx != nil && r.ctx.Timeout > 0 && r.ctx.Ctx != nil && errors.Is(r.ctx.Ctx.Err(), context.DeadlineExceeded)
+}
+
 func (r RespWrapper) ErrorWithMessageCode(requestId string, messageCode *messages.MessageCode, messageParams ...any) {
 	r.errorWithMessageCode(requestId, messageCode, messageParams...)
 }
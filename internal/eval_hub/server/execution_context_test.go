@@ -57,3 +57,18 @@ func TestRequestWrapper_BodyExceedsMaxBytes(t *testing.T) {
 		t.Errorf("expected status 413, got %d", se.MessageCode().GetStatusCode())
 	}
 }
+
+func TestParseGroups(t *testing.T) {
+	for _, tc := range []struct {
+		header string
+		want   []string
+	}{
+		{"", nil},
+		{"ml-team", []string{"ml-team"}},
+		{"ml-team| system:authenticated ||", []string{"ml-team", "system:authenticated"}},
+	} {
+		if got := server.ParseGroups(tc.header); !slices.Equal(got, tc.want) {
+			t.Errorf("ParseGroups(%q) = %v, want %v", tc.header, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package server
+
+import "github.com/eval-hub/eval-hub/internal/eval_hub/config"
+
+// ServiceConfig exposes the service config so that tests can change it before SetupRoutes.
+func (s *Server) ServiceConfig() *config.Config {
+	return s.serviceConfig
+}
+
+// ParseGroups exposes parseGroups to the tests.
+var ParseGroups = parseGroups
+
+// Routes exposes the patterns registered by SetupRoutes to the tests.
+func (s *Server) Routes() []string {
+	return s.routes
+}
@@ -0,0 +1,41 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+)
+
+// requestTimeoutGrace is how long past its deadline the response of a request may still be
+// written, so that the 504 of a request that exceeded its deadline reaches the client.
+const requestTimeoutGrace = 5 * time.Second
+
+// RequestTimeoutMiddleware sets the deadline of the requests to the route of the pattern, as
+// configured for their method, on their context. The storage and runtime calls of the
+// handlers get it through the execution context. When the deadline is past the write
+// timeout of the server, the write deadline of the response is extended to it.
+func RequestTimeoutMiddleware(next http.Handler, pattern string, timeouts *config.RequestTimeoutsConfig, writeTimeout time.Duration, logger *slog.Logger) http.Handler {
+	// patterns such as "GET /ui/" are configured without their method
+	if _, path, found := strings.Cut(pattern, " "); found {
+		pattern = path
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		timeout := timeouts.Timeout(r.Method, pattern)
+		if timeout <= 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx, cancel := executioncontext.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		if timeout+requestTimeoutGrace > writeTimeout {
+			if err := http.NewResponseController(w).SetWriteDeadline(time.Now().Add(timeout + requestTimeoutGrace)); err != nil {
+				logger.Debug("Failed to extend the write deadline of a request", "pattern", pattern, "timeout", timeout.String(), "error", err)
+			}
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -3,11 +3,15 @@ package server
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
@@ -17,7 +21,9 @@ import (
 	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/http_wrappers"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/ui"
 	"github.com/eval-hub/eval-hub/internal/platform"
+	"github.com/eval-hub/eval-hub/pkg/api"
 	"github.com/eval-hub/eval-hub/pkg/mlflowclient"
 	"github.com/go-playground/validator/v10"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -36,6 +42,21 @@ type Server struct {
 	runtime         abstractions.Runtime
 	mlflowClient    *mlflowclient.Client
 	resultsExporter evalcards.ResultsExporter
+	providerHealth  abstractions.ProviderHealthReporter
+	imageWarmup     abstractions.ImageWarmupReporter
+	jobWatcher      abstractions.JobWatcher
+	jobAdmission    abstractions.JobAdmission
+	messageCatalogs messages.Catalogs
+	handlers        *handlers.Handlers
+
+	providerHealthScheduler abstractions.ProviderHealthScheduler
+	permissionCheck         *api.PermissionCheck
+	// routes are the patterns registered by setupRoutes, documented in the OpenAPI spec
+	routes []string
+	// reconcileInterval is the interval of RunFailureDiagnostics, changed on the admin API
+	// while it runs, which is told through reconcileIntervalChanged.
+	reconcileInterval        atomic.Int64
+	reconcileIntervalChanged chan struct{}
 }
 
 func (s *Server) isOTELEnabled() bool {
@@ -98,9 +119,54 @@ func NewServer(logger *slog.Logger,
 		runtime:         runtime,
 		mlflowClient:    mlflowClient,
 		resultsExporter: resultsExporter,
+
+		reconcileIntervalChanged: make(chan struct{}, 1),
 	}, nil
 }
 
+// SetProviderHealth sets the provider health reported on the providers API. Call before Start.
+func (s *Server) SetProviderHealth(providerHealth abstractions.ProviderHealthReporter) {
+	s.providerHealth = providerHealth
+}
+
+// SetImageWarmup sets the image pre-pull status reported on the providers API. Call before Start.
+func (s *Server) SetImageWarmup(imageWarmup abstractions.ImageWarmupReporter) {
+	s.imageWarmup = imageWarmup
+}
+
+// SetProviderHealthScheduler sets the provider health checks tuned on the admin API. Call before Start.
+func (s *Server) SetProviderHealthScheduler(providerHealthScheduler abstractions.ProviderHealthScheduler) {
+	s.providerHealthScheduler = providerHealthScheduler
+}
+
+// SetJobWatcher sets the source of job updates for the watch API. Call before Start.
+func (s *Server) SetJobWatcher(jobWatcher abstractions.JobWatcher) {
+	s.jobWatcher = jobWatcher
+}
+
+// SetJobAdmission sets the admission webhooks that review evaluation jobs on creation. Call before Start.
+func (s *Server) SetJobAdmission(jobAdmission abstractions.JobAdmission) {
+	s.jobAdmission = jobAdmission
+}
+
+// SetMessageCatalogs sets the catalogs used to localize messages per Accept-Language. Call before Start.
+func (s *Server) SetMessageCatalogs(catalogs messages.Catalogs) {
+	s.messageCatalogs = catalogs
+}
+
+// BaseURL returns the URL clients use to reach the API server.
+func (s *Server) BaseURL() string {
+	scheme := "http"
+	if s.serviceConfig.Service.TLSEnabled() {
+		scheme = "https"
+	}
+	host := s.serviceConfig.Service.Host
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	return fmt.Sprintf("%s://%s", scheme, net.JoinHostPort(host, strconv.Itoa(s.port)))
+}
+
 func (s *Server) GetPort() int {
 	return s.port
 }
@@ -199,11 +265,17 @@ func spanNameFormatter(operation string, r *http.Request) string {
 }
 
 func (s *Server) handle(router *http.ServeMux, pattern string, handler http.Handler) {
+	handler = RequestTimeoutMiddleware(handler, pattern, s.serviceConfig.Service.RequestTimeouts, s.serviceConfig.Service.EffectiveWriteTimeout(), s.logger)
 	if s.isOTELEnabled() {
 		handler = otelhttp.NewHandler(handler, pattern, otelhttp.WithSpanNameFormatter(spanNameFormatter))
 		s.logger.Info("Enabled OTEL handler", "pattern", pattern)
 	}
 	router.Handle(pattern, handler)
+	s.registered(pattern)
+}
+
+func (s *Server) registered(pattern string) {
+	s.routes = append(s.routes, pattern)
 	s.logger.Info("Registered API", "pattern", pattern)
 }
 
@@ -221,6 +293,18 @@ func (s *Server) setupHealthRoutes(h *handlers.Handlers, router *http.ServeMux)
 			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
 		}
 	})
+	// /readyz is unauthenticated too; it reports the maintenance mode of the replica.
+	s.handleFunc(router, "/readyz", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		switch req.Method() {
+		case http.MethodGet:
+			h.HandleReadiness(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
 }
 
 func (s *Server) setupEvaluationJobsRoutes(h *handlers.Handlers, router *http.ServeMux) {
@@ -258,6 +342,21 @@ func (s *Server) setupEvaluationJobLogsRoutes(h *handlers.Handlers, router *http
 		}
 	})
 
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/benchmarks/{%s}/spec", constants.PATH_PARAMETER_JOB_ID, constants.PATH_PARAMETER_BENCHMARK_INDEX), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetEvaluationBenchmarkJobSpec(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+
 	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/logs", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
@@ -291,8 +390,12 @@ func (s *Server) setupEvaluationJobEventsRoutes(h *handlers.Handlers, router *ht
 	})
 }
 
-func (s *Server) setupEvaluationJobRoutes(h *handlers.Handlers, router *http.ServeMux) {
-	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
+func (s *Server) setupEvaluationJobWatchRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	pattern := fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/watch", constants.PATH_PARAMETER_JOB_ID)
+	// Registered without the otelhttp wrapper: its response writer hides the connection,
+	// so the stream could not lift the server write timeout, and a span that lasts as
+	// long as the stream is of little use.
+	router.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
 		req := s.newRequestWrapper(w, r)
@@ -301,17 +404,19 @@ func (s *Server) setupEvaluationJobRoutes(h *handlers.Handlers, router *http.Ser
 		}
 		switch r.Method {
 		case http.MethodGet:
-			h.HandleGetEvaluation(ctx, req, resp)
-		case http.MethodDelete:
-			h.HandleCancelEvaluation(ctx, req, resp)
+			h.HandleWatchEvaluation(ctx, req, resp)
 		default:
 			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
 		}
 	})
+	s.registered(pattern)
 }
 
-func (s *Server) setupCollectionsRoutes(h *handlers.Handlers, router *http.ServeMux) {
-	s.handleFunc(router, "/api/v1/evaluations/collections", func(w http.ResponseWriter, r *http.Request) {
+func (s *Server) setupEvaluationInlineRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	pattern := "/api/v1/evaluations/jobs:evaluate"
+	// Registered without the otelhttp wrapper, as the watch stream: the answer waits for the
+	// job to finish, past the server write timeout.
+	router.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
 		req := s.newRequestWrapper(w, r)
@@ -320,17 +425,16 @@ func (s *Server) setupCollectionsRoutes(h *handlers.Handlers, router *http.Serve
 		}
 		switch r.Method {
 		case http.MethodPost:
-			h.HandleCreateCollection(ctx, req, resp)
-		case http.MethodGet:
-			h.HandleListCollections(ctx, req, resp)
+			h.HandleEvaluateInline(ctx, req, resp)
 		default:
 			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
 		}
 	})
+	s.registered(pattern)
 }
 
-func (s *Server) setupCollectionRoutes(h *handlers.Handlers, router *http.ServeMux) {
-	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/collections/{%s}", constants.PATH_PARAMETER_COLLECTION_ID), func(w http.ResponseWriter, r *http.Request) {
+func (s *Server) setupEvaluationJobArtifactsRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/benchmarks/{%s}/artifacts", constants.PATH_PARAMETER_JOB_ID, constants.PATH_PARAMETER_BENCHMARK_INDEX), func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
 		req := s.newRequestWrapper(w, r)
@@ -339,21 +443,41 @@ func (s *Server) setupCollectionRoutes(h *handlers.Handlers, router *http.ServeM
 		}
 		switch r.Method {
 		case http.MethodGet:
-			h.HandleGetCollection(ctx, req, resp)
+			h.HandleListEvaluationBenchmarkArtifacts(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+
+	pattern := fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/benchmarks/{%s}/artifacts/{%s}", constants.PATH_PARAMETER_JOB_ID, constants.PATH_PARAMETER_BENCHMARK_INDEX, constants.PATH_PARAMETER_ARTIFACT_NAME)
+	// Registered without the otelhttp wrapper, as the watch API: its response writer hides
+	// the connection, so the upload could not lift the server read timeout.
+	router.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		// artifacts are streamed to the artifact store, they are bounded by their own limit
+		req := NewRequestWrapper(w, r, s.serviceConfig.Artifacts.EffectiveMaxSizeBytes())
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
 		case http.MethodPut:
-			h.HandleUpdateCollection(ctx, req, resp)
-		case http.MethodPatch:
-			h.HandlePatchCollection(ctx, req, resp)
-		case http.MethodDelete:
-			h.HandleDeleteCollection(ctx, req, resp)
+			// a large artifact takes longer to upload than the server read and write timeouts,
+			// the write timeout runs from the end of the request headers
+			rc := http.NewResponseController(w)
+			if err := errors.Join(rc.SetReadDeadline(time.Time{}), rc.SetWriteDeadline(time.Time{})); err != nil {
+				ctx.Logger.Warn("Failed to lift the deadlines of the artifact upload", "error", err)
+			}
+			h.HandleUploadEvaluationBenchmarkArtifact(ctx, req, resp)
 		default:
 			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
 		}
 	})
+	s.registered(pattern)
 }
 
-func (s *Server) setupProvidersRoutes(h *handlers.Handlers, router *http.ServeMux) {
-	s.handleFunc(router, "/api/v1/evaluations/providers", func(w http.ResponseWriter, r *http.Request) {
+func (s *Server) setupEvaluationJobGenerationsRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/benchmarks/{%s}/generations", constants.PATH_PARAMETER_JOB_ID, constants.PATH_PARAMETER_BENCHMARK_INDEX), func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
 		req := s.newRequestWrapper(w, r)
@@ -362,17 +486,43 @@ func (s *Server) setupProvidersRoutes(h *handlers.Handlers, router *http.ServeMu
 		}
 		switch r.Method {
 		case http.MethodGet:
-			h.HandleListProviders(ctx, req, resp)
-		case http.MethodPost:
-			h.HandleCreateProvider(ctx, req, resp)
+			h.HandleListEvaluationBenchmarkGenerations(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+
+	pattern := fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/benchmarks/{%s}/generations/{%s}", constants.PATH_PARAMETER_JOB_ID, constants.PATH_PARAMETER_BENCHMARK_INDEX, constants.PATH_PARAMETER_ARTIFACT_NAME)
+	// Registered without the otelhttp wrapper, as the artifacts: the uploads and downloads
+	// lift the server timeouts.
+	router.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		// generations are streamed to the artifact store, they are bounded as the artifacts
+		req := NewRequestWrapper(w, r, s.serviceConfig.Artifacts.EffectiveMaxSizeBytes())
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPut, http.MethodGet:
+			rc := http.NewResponseController(w)
+			if err := errors.Join(rc.SetReadDeadline(time.Time{}), rc.SetWriteDeadline(time.Time{})); err != nil {
+				ctx.Logger.Warn("Failed to lift the deadlines of the generations transfer", "error", err)
+			}
+			if r.Method == http.MethodPut {
+				h.HandleUploadEvaluationBenchmarkGenerations(ctx, req, resp)
+			} else {
+				h.HandleDownloadEvaluationBenchmarkGenerations(ctx, req, resp)
+			}
 		default:
 			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
 		}
 	})
+	s.registered(pattern)
 }
 
-func (s *Server) setupProviderRoutes(h *handlers.Handlers, router *http.ServeMux) {
-	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/providers/{%s}", constants.PATH_PARAMETER_PROVIDER_ID), func(w http.ResponseWriter, r *http.Request) {
+func (s *Server) setupEvaluationJobRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
 		req := s.newRequestWrapper(w, r)
@@ -381,88 +531,724 @@ func (s *Server) setupProviderRoutes(h *handlers.Handlers, router *http.ServeMux
 		}
 		switch r.Method {
 		case http.MethodGet:
-			h.HandleGetProvider(ctx, req, resp)
-		case http.MethodPut:
-			h.HandleUpdateProvider(ctx, req, resp)
+			h.HandleGetEvaluation(ctx, req, resp)
 		case http.MethodPatch:
-			h.HandlePatchProvider(ctx, req, resp)
+			h.HandlePatchEvaluation(ctx, req, resp)
 		case http.MethodDelete:
-			h.HandleDeleteProvider(ctx, req, resp)
+			h.HandleCancelEvaluation(ctx, req, resp)
 		default:
 			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
 		}
 	})
 }
 
-func (s *Server) setupOpenAPIRoutes(h *handlers.Handlers, router *http.ServeMux) {
-	s.handleFunc(router, "/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+func (s *Server) setupEvaluationJobAccessRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/owner", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			h.HandleUpdateEvaluationOwner(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/sharing", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPut:
+			h.HandleUpdateEvaluationSharing(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+func (s *Server) setupEvaluationSweepRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/sweeps/{%s}", constants.PATH_PARAMETER_SWEEP_ID), func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
 		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
 		switch r.Method {
 		case http.MethodGet:
-			h.HandleOpenAPI(ctx, req, resp)
+			h.HandleGetEvaluationSweep(ctx, req, resp)
 		default:
 			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
 		}
 	})
 }
 
-func (s *Server) setupDocsRoutes(h *handlers.Handlers, router *http.ServeMux) {
-	s.handleFunc(router, "/docs", func(w http.ResponseWriter, r *http.Request) {
+func (s *Server) setupEvaluationJobFindingsRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/findings", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
 		ctx := s.newExecutionContext(r)
 		resp := NewRespWrapper(w, ctx)
 		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
 		switch r.Method {
 		case http.MethodGet:
-			h.HandleDocs(ctx, req, resp)
+			h.HandleListEvaluationFindings(ctx, req, resp)
 		default:
 			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
 		}
+	})
+}
 
+func (s *Server) setupEvaluationJobMLFlowRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/mlflow/sync", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			h.HandleSyncEvaluationMLFlowMetrics(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
 	})
 }
 
-func (s *Server) canContinueRequest(ctx *executioncontext.ExecutionContext, resp RespWrapper) bool {
-	if !s.serviceConfig.RequiresIdentityHeaders() {
-		return true
-	}
-	if ctx.Tenant == "" {
-		resp.ErrorWithMessageCode(ctx.RequestID, messages.MissingTenantHeader, "Header", TENANT_HEADER)
-		return false
-	}
-	if ctx.User == "" {
-		resp.ErrorWithMessageCode(ctx.RequestID, messages.MissingUserHeader, "Header", USER_HEADER)
-		return false
+func (s *Server) setupEvaluationMetricsRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, "/api/v1/evaluations/metrics/history", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleListMetricsHistory(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	// the Grafana JSON datasource tests the datasource with a GET of its URL, with or
+	// without a trailing slash
+	for _, pattern := range []string{"/api/v1/evaluations/metrics/grafana", "/api/v1/evaluations/metrics/grafana/{$}"} {
+		s.handleFunc(router, pattern, func(w http.ResponseWriter, r *http.Request) {
+			ctx := s.newExecutionContext(r)
+			resp := NewRespWrapper(w, ctx)
+			req := s.newRequestWrapper(w, r)
+			if !s.canContinueRequest(ctx, resp) {
+				return
+			}
+			switch r.Method {
+			case http.MethodGet:
+				h.HandleGrafanaTest(ctx, req, resp)
+			default:
+				resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+			}
+		})
 	}
-	return true
+	s.handleFunc(router, "/api/v1/evaluations/metrics/grafana/search", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			h.HandleGrafanaSearch(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, "/api/v1/evaluations/metrics/grafana/query", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			h.HandleGrafanaQuery(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
 }
 
-func (s *Server) setupRoutes() (http.Handler, error) {
-	router := http.NewServeMux()
-	h := handlers.New(s.storage, s.validate, s.runtime, s.mlflowClient, s.serviceConfig, s.resultsExporter)
-
-	// Health
-	s.setupHealthRoutes(h, router)
-
-	// Evaluation jobs endpoints
-	s.setupEvaluationJobsRoutes(h, router)
-	s.setupEvaluationJobLogsRoutes(h, router)
-	s.setupEvaluationJobEventsRoutes(h, router)
-	s.setupEvaluationJobRoutes(h, router)
-
-	// Collections endpoints
-	s.setupCollectionsRoutes(h, router)
-	s.setupCollectionRoutes(h, router)
+func (s *Server) setupEvaluationReviewRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, "/api/v1/evaluations/reviews", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleListReviews(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/review", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			h.HandleReviewEvaluationJob(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
 
-	// Providers endpoints
-	s.setupProvidersRoutes(h, router)
-	s.setupProviderRoutes(h, router)
+func (s *Server) setupEvaluationJobComparisonRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/comparison", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetEvaluationComparison(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
 
-	// OpenAPI documentation endpoints
+func (s *Server) setupBaselinesRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, "/api/v1/evaluations/baselines", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			h.HandleCreateBaseline(ctx, req, resp)
+		case http.MethodGet:
+			h.HandleListBaselines(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+func (s *Server) setupBaselineRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/baselines/{%s}", constants.PATH_PARAMETER_BASELINE_NAME), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetBaseline(ctx, req, resp)
+		case http.MethodDelete:
+			h.HandleDeleteBaseline(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+func (s *Server) setupCollectionsRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, "/api/v1/evaluations/collections", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			h.HandleCreateCollection(ctx, req, resp)
+		case http.MethodGet:
+			h.HandleListCollections(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+func (s *Server) setupCollectionRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/collections/{%s}", constants.PATH_PARAMETER_COLLECTION_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetCollection(ctx, req, resp)
+		case http.MethodPut:
+			h.HandleUpdateCollection(ctx, req, resp)
+		case http.MethodPatch:
+			h.HandlePatchCollection(ctx, req, resp)
+		case http.MethodDelete:
+			h.HandleDeleteCollection(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+func (s *Server) setupEvaluationViewsRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, "/api/v1/evaluations/views", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			h.HandleCreateEvaluationView(ctx, req, resp)
+		case http.MethodGet:
+			h.HandleListEvaluationViews(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+func (s *Server) setupEvaluationViewRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/views/{%s}", constants.PATH_PARAMETER_VIEW_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetEvaluationView(ctx, req, resp)
+		case http.MethodPut:
+			h.HandleUpdateEvaluationView(ctx, req, resp)
+		case http.MethodDelete:
+			h.HandleDeleteEvaluationView(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+func (s *Server) setupTagPolicyRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, "/api/v1/evaluations/tag-policy", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetTagPolicy(ctx, req, resp)
+		case http.MethodPut:
+			h.HandlePutTagPolicy(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+func (s *Server) setupRedactionRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, "/api/v1/evaluations/redaction-policy", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetRedactionPolicy(ctx, req, resp)
+		case http.MethodPut:
+			h.HandlePutRedactionPolicy(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/jobs/{%s}/redactions", constants.PATH_PARAMETER_JOB_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleListEvaluationRedactions(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+func (s *Server) setupProvidersRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, "/api/v1/evaluations/providers", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleListProviders(ctx, req, resp)
+		case http.MethodPost:
+			h.HandleCreateProvider(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+func (s *Server) setupProviderRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/providers/{%s}", constants.PATH_PARAMETER_PROVIDER_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetProvider(ctx, req, resp)
+		case http.MethodPut:
+			h.HandleUpdateProvider(ctx, req, resp)
+		case http.MethodPatch:
+			h.HandlePatchProvider(ctx, req, resp)
+		case http.MethodDelete:
+			h.HandleDeleteProvider(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/providers/{%s}/benchmarks", constants.PATH_PARAMETER_PROVIDER_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleListProviderBenchmarks(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/providers/{%s}/export", constants.PATH_PARAMETER_PROVIDER_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleExportProvider(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, fmt.Sprintf("/api/v1/evaluations/providers/{%s}/diff/{%s}", constants.PATH_PARAMETER_PROVIDER_ID, constants.PATH_PARAMETER_OTHER_PROVIDER_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleDiffProviders(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, "/api/v1/evaluations/providers/import", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			h.HandleImportProvider(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+func (s *Server) setupOpenAPIRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, "/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleOpenAPI(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, "/api/v1/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleOpenAPIJSON(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+func (s *Server) setupDocsRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, "/docs", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleDocs(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+
+	})
+}
+
+// setupAdminRoutes serves the live-tunable settings of this replica. The settings are not
+// scoped to a tenant, so the API is only served when enabled and operators must restrict
+// access to it in the proxy in front of eval-hub.
+func (s *Server) setupAdminRoutes(h *handlers.Handlers, router *http.ServeMux) {
+	s.handleFunc(router, "/api/v1/admin/config", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetAdminConfig(ctx, req, resp)
+		case http.MethodPatch:
+			h.HandlePatchAdminConfig(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, "/api/v1/admin/maintenance", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetMaintenance(ctx, req, resp)
+		case http.MethodPut:
+			h.HandleSetMaintenance(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, "/api/v1/admin/clusters", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleGetAdminClusters(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, "/api/v1/admin/dead-letters", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleListDeadLetters(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, fmt.Sprintf("/api/v1/admin/dead-letters/{%s}", constants.PATH_PARAMETER_DEAD_LETTER_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodDelete:
+			h.HandleDeleteDeadLetter(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, fmt.Sprintf("/api/v1/admin/dead-letters/{%s}/requeue", constants.PATH_PARAMETER_DEAD_LETTER_ID), func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			h.HandleRequeueDeadLetter(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, "/api/v1/admin/export", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			h.HandleExportArchive(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+	s.handleFunc(router, "/api/v1/admin/import", func(w http.ResponseWriter, r *http.Request) {
+		ctx := s.newExecutionContext(r)
+		resp := NewRespWrapper(w, ctx)
+		req := s.newRequestWrapper(w, r)
+		if !s.canContinueRequest(ctx, resp) {
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			h.HandleImportArchive(ctx, req, resp)
+		default:
+			resp.ErrorWithMessageCode(ctx.RequestID, messages.MethodNotAllowed, "Method", req.Method(), "Api", req.URI())
+		}
+	})
+}
+
+// setupUIRoutes serves the embedded results UI. The assets are static and public; the
+// API calls made by the UI go through the regular endpoints and their identity checks.
+func (s *Server) setupUIRoutes(router *http.ServeMux) {
+	s.handle(router, "GET "+ui.Path, ui.Handler())
+	router.Handle("GET "+strings.TrimSuffix(ui.Path, "/"), http.RedirectHandler(ui.Path, http.StatusMovedPermanently))
+}
+
+func (s *Server) canContinueRequest(ctx *executioncontext.ExecutionContext, resp RespWrapper) bool {
+	if !s.serviceConfig.RequiresIdentityHeaders() {
+		return true
+	}
+	if ctx.Tenant == "" {
+		resp.ErrorWithMessageCode(ctx.RequestID, messages.MissingTenantHeader, "Header", TENANT_HEADER)
+		return false
+	}
+	if ctx.User == "" {
+		resp.ErrorWithMessageCode(ctx.RequestID, messages.MissingUserHeader, "Header", USER_HEADER)
+		return false
+	}
+	return true
+}
+
+func (s *Server) setupRoutes() (http.Handler, error) {
+	router := http.NewServeMux()
+	h := handlers.New(s.storage, s.validate, s.runtime, s.mlflowClient, s.serviceConfig, s.resultsExporter).WithProviderHealth(s.providerHealth).WithImageWarmup(s.imageWarmup).WithJobWatcher(s.jobWatcher).WithJobAdmission(s.jobAdmission).WithProviderHealthScheduler(s.providerHealthScheduler).WithReconcileScheduler(s).WithPermissionCheck(s.permissionCheck)
+	s.handlers = h
+
+	// Health
+	s.setupHealthRoutes(h, router)
+
+	// Evaluation jobs endpoints
+	s.setupEvaluationJobsRoutes(h, router)
+	s.setupEvaluationJobLogsRoutes(h, router)
+	s.setupEvaluationJobEventsRoutes(h, router)
+	s.setupEvaluationJobWatchRoutes(h, router)
+	s.setupEvaluationInlineRoutes(h, router)
+	s.setupEvaluationJobArtifactsRoutes(h, router)
+	s.setupEvaluationJobGenerationsRoutes(h, router)
+	s.setupEvaluationJobRoutes(h, router)
+	s.setupEvaluationJobAccessRoutes(h, router)
+	s.setupEvaluationSweepRoutes(h, router)
+	s.setupEvaluationJobComparisonRoutes(h, router)
+	s.setupEvaluationJobFindingsRoutes(h, router)
+	s.setupEvaluationJobMLFlowRoutes(h, router)
+	s.setupEvaluationMetricsRoutes(h, router)
+	s.setupEvaluationReviewRoutes(h, router)
+
+	// Baselines endpoints
+	s.setupBaselinesRoutes(h, router)
+	s.setupBaselineRoutes(h, router)
+
+	// Evaluation views endpoints
+	s.setupEvaluationViewsRoutes(h, router)
+	s.setupEvaluationViewRoutes(h, router)
+
+	// Collections endpoints
+	s.setupCollectionsRoutes(h, router)
+	s.setupCollectionRoutes(h, router)
+
+	// Tag policy endpoints
+	s.setupTagPolicyRoutes(h, router)
+
+	// Redaction policy and audit endpoints
+	s.setupRedactionRoutes(h, router)
+
+	// Providers endpoints
+	s.setupProvidersRoutes(h, router)
+	s.setupProviderRoutes(h, router)
+
+	// OpenAPI specification and (optional) Swagger UI
 	s.setupOpenAPIRoutes(h, router)
 
-	s.setupDocsRoutes(h, router)
+	if !s.serviceConfig.Service.DisableSwaggerUI {
+		s.setupDocsRoutes(h, router)
+	}
+
+	// Results UI
+	s.setupUIRoutes(router)
+
+	if s.serviceConfig.Service.EnableAdminAPI {
+		s.setupAdminRoutes(h, router)
+	}
 
 	// Prometheus metrics endpoint: in cluster mode, /metrics is served by the
 	// dedicated MetricsServer on a separate port. In local mode, also serve it
@@ -470,6 +1256,7 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 	prometheusEnabled := s.serviceConfig.IsPrometheusEnabled()
 	if prometheusEnabled && s.serviceConfig.Service.LocalMode {
 		router.Handle("/metrics", promhttp.Handler())
+		s.routes = append(s.routes, "/metrics")
 		s.logger.Info("Registered API (local mode)", "pattern", "/metrics")
 	}
 
@@ -479,6 +1266,13 @@ func (s *Server) setupRoutes() (http.Handler, error) {
 		handler = CorsMiddleware(handler, s.serviceConfig)
 	}
 
+	// inside the compression so that the bodies are logged as the handlers see them
+	handler = BodyLoggingMiddleware(handler, s.serviceConfig.BodyLogging, s.logger)
+
+	if !s.serviceConfig.Service.DisableCompression {
+		handler = CompressionMiddleware(handler, s.serviceConfig.Service.EffectiveCompressionMinBytes())
+	}
+
 	handler = HTTPMetricsMiddleware(handler, s.serviceConfig.IsOTELMetricsEnabled(), s.logger)
 
 	return handler, nil
@@ -525,13 +1319,18 @@ func (s *Server) Start() error {
 	}
 
 	tlsEnabled := s.serviceConfig.Service.TLSEnabled()
-	s.logger.Info("API Server starting", "addr", addr, "tls", tlsEnabled)
+	if tlsEnabled {
+		reloader, err := newTLSReloader(s.serviceConfig.Service, s.logger)
+		if err != nil {
+			return err
+		}
+		s.httpServer.TLSConfig = reloader.serverTLSConfig()
+	}
+	s.logger.Info("API Server starting", "addr", addr, "tls", tlsEnabled, "tls_client_auth", s.serviceConfig.Service.TLSClientAuth)
 
 	if tlsEnabled {
-		err = s.httpServer.ListenAndServeTLS(
-			s.serviceConfig.Service.TLSCertFile,
-			s.serviceConfig.Service.TLSKeyFile,
-		)
+		// the certificates come from the TLS config, which reloads them when they are rotated
+		err = s.httpServer.ListenAndServeTLS("", "")
 	} else {
 		err = s.httpServer.ListenAndServe()
 	}
@@ -543,6 +1342,157 @@ func (s *Server) Start() error {
 	return err
 }
 
+// StopModelWaits fails the evaluation jobs that still wait for their model endpoint, at
+// shutdown, before the storage is closed.
+func (s *Server) StopModelWaits() {
+	if s.handlers != nil {
+		s.handlers.StopModelWaits()
+	}
+}
+
+// RunFailureDiagnostics reports the benchmark workloads of the unfinished evaluation jobs that
+// the runtime finds failed, and refreshes where their benchmarks run, until ctx is cancelled.
+// The checks start once the server has started.
+func (s *Server) RunFailureDiagnostics(ctx context.Context) {
+	ticker := time.NewTicker(s.ReconcileInterval())
+	defer ticker.Stop()
+	for {
+		if s.handlers != nil {
+			s.handlers.DiagnoseBenchmarkFailures(ctx, s.logger)
+			s.handlers.RefreshBenchmarkPlacements(ctx, s.logger)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.reconcileIntervalChanged:
+			ticker.Reset(s.ReconcileInterval())
+		}
+	}
+}
+
+// ReconcileInterval returns how often RunFailureDiagnostics checks the workloads of the
+// unfinished jobs.
+func (s *Server) ReconcileInterval() time.Duration {
+	if reconcileInterval := time.Duration(s.reconcileInterval.Load()); reconcileInterval > 0 {
+		return reconcileInterval
+	}
+	return handlers.FailureDiagnosticsInterval
+}
+
+// SetReconcileInterval changes how often RunFailureDiagnostics checks the workloads of the
+// unfinished jobs, taking effect immediately when it is running.
+func (s *Server) SetReconcileInterval(reconcileInterval time.Duration) {
+	if reconcileInterval <= 0 {
+		reconcileInterval = handlers.FailureDiagnosticsInterval
+	}
+	s.reconcileInterval.Store(int64(reconcileInterval))
+	select {
+	case s.reconcileIntervalChanged <- struct{}{}:
+	default:
+	}
+}
+
+// RunAdminConfigSync applies to this replica the admin configuration changed on any replica,
+// until ctx is cancelled.
+func (s *Server) RunAdminConfigSync(ctx context.Context) {
+	ticker := time.NewTicker(handlers.AdminConfigSyncInterval)
+	defer ticker.Stop()
+	for {
+		if s.handlers != nil {
+			s.handlers.SyncAdminConfig(ctx, s.logger)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunExperimentLinking retries the links of the evaluation jobs to their MLflow experiment
+// that failed when the jobs were created, until ctx is cancelled.
+func (s *Server) RunExperimentLinking(ctx context.Context) {
+	if s.handlers == nil {
+		return
+	}
+	ticker := time.NewTicker(s.handlers.ExperimentLinkRetryInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		s.handlers.LinkPendingExperiments(ctx, s.logger)
+	}
+}
+
+// RunJobFilesCleanup removes the expired job files that the runtime keeps on the disk of this
+// replica, and reports their disk usage, until ctx is cancelled.
+func (s *Server) RunJobFilesCleanup(ctx context.Context) {
+	cleaner, ok := s.runtime.(abstractions.JobFilesCleaner)
+	if !ok {
+		return
+	}
+	ticker := time.NewTicker(s.serviceConfig.LocalJobs.EffectiveCleanupInterval())
+	defer ticker.Stop()
+	for {
+		if err := cleaner.CleanupJobFiles(ctx); err != nil {
+			s.logger.Warn("Failed to clean up the local job files", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckRuntimePermissions checks that the runtime may do everything that running the jobs
+// needs and logs each missing permission with what fails without it; the result is reported
+// on /readyz. Call before Start.
+func (s *Server) CheckRuntimePermissions(ctx context.Context) {
+	checker, ok := s.runtime.(abstractions.PermissionChecker)
+	if !ok {
+		return
+	}
+	check, err := checker.CheckPermissions(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to check the permissions of the runtime", "runtime", s.runtime.Name(), "error", err)
+		return
+	}
+	if check == nil {
+		return
+	}
+	s.permissionCheck = check
+	for _, reason := range check.Errors {
+		s.logger.Warn("Failed to check the permissions of the runtime", "runtime", s.runtime.Name(), "error", reason)
+	}
+	for _, missing := range check.Missing {
+		resource := missing.Resource
+		if missing.Subresource != "" {
+			resource += "/" + missing.Subresource
+		}
+		if missing.Group != "" {
+			resource += "." + missing.Group
+		}
+		s.logger.Error("Missing Kubernetes permission, grant it to the service account of the service",
+			"cluster", missing.Cluster,
+			"namespace", missing.Namespace,
+			"verb", missing.Verb,
+			"resource", resource,
+			"needed_for", missing.NeededFor,
+			"reason", missing.Reason,
+		)
+	}
+	if len(check.Missing) > 0 {
+		s.logger.Error("The runtime lacks Kubernetes permissions, the benchmarks that need them will fail", "runtime", s.runtime.Name(), "missing", len(check.Missing))
+	} else if len(check.Errors) == 0 {
+		s.logger.Info("The runtime has the Kubernetes permissions it needs", "runtime", s.runtime.Name())
+	}
+}
+
 func (s *Server) Shutdown(ctx context.Context) error {
 	s.logger.Info("Shutting down API server gracefully...")
 	return s.httpServer.Shutdown(ctx)
@@ -0,0 +1,100 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/logging"
+)
+
+type stubProviderHealthScheduler struct {
+	pollInterval time.Duration
+}
+
+func (s *stubProviderHealthScheduler) PollInterval() time.Duration {
+	return s.pollInterval
+}
+
+func (s *stubProviderHealthScheduler) SetPollInterval(pollInterval time.Duration) {
+	s.pollInterval = pollInterval
+}
+
+func TestAdminConfigRoutes(t *testing.T) {
+	t.Run("not served unless enabled", func(t *testing.T) {
+		srv, err := createServer(t, 8080)
+		if err != nil {
+			t.Fatalf("createServer: %v", err)
+		}
+		handler, err := srv.SetupRoutes()
+		if err != nil {
+			t.Fatalf("SetupRoutes: %v", err)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/config", nil))
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("got status %d want 404", w.Code)
+		}
+	})
+
+	t.Run("served when enabled", func(t *testing.T) {
+		previousLevel := logging.Level()
+		t.Cleanup(func() { _ = logging.SetLevel(previousLevel) })
+
+		srv, err := createServerWithLocalMode(t, 8080, false)
+		if err != nil {
+			t.Fatalf("createServer: %v", err)
+		}
+		srv.ServiceConfig().Service.EnableAdminAPI = true
+		scheduler := &stubProviderHealthScheduler{pollInterval: 30 * time.Second}
+		srv.SetProviderHealthScheduler(scheduler)
+		handler, err := srv.SetupRoutes()
+		if err != nil {
+			t.Fatalf("SetupRoutes: %v", err)
+		}
+
+		// identity headers are required in cluster mode
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/admin/config", nil))
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("without identity headers: got status %d want 400", w.Code)
+		}
+
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/admin/config",
+			strings.NewReader(`[{"op": "replace", "path": "/provider_health_poll_interval", "value": "5m"}]`))
+		req.Header.Set("X-Tenant", "ops")
+		req.Header.Set("X-User", "admin")
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("PATCH: got status %d body %s", w.Code, w.Body.String())
+		}
+		if scheduler.pollInterval != 5*time.Minute {
+			t.Errorf("poll interval: got %s want 5m", scheduler.pollInterval)
+		}
+
+		req = httptest.NewRequest(http.MethodPatch, "/api/v1/admin/config",
+			strings.NewReader(`[{"op": "replace", "path": "/reconcile_interval", "value": "2m"}]`))
+		req.Header.Set("X-Tenant", "ops")
+		req.Header.Set("X-User", "admin")
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Fatalf("PATCH: got status %d body %s", w.Code, w.Body.String())
+		}
+		if srv.ReconcileInterval() != 2*time.Minute {
+			t.Errorf("reconcile interval: got %s want 2m", srv.ReconcileInterval())
+		}
+
+		req = httptest.NewRequest(http.MethodPost, "/api/v1/admin/config", strings.NewReader("{}"))
+		req.Header.Set("X-Tenant", "ops")
+		req.Header.Set("X-User", "admin")
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("POST: got status %d want 405", w.Code)
+		}
+	})
+}
@@ -121,4 +121,13 @@ func assertMessageCode(t *testing.T, w *httptest.ResponseRecorder, want string)
 	if got != want {
 		t.Fatalf("message_code: got %q want %q body %s", got, want, w.Body.String())
 	}
+	if code, _ := body["code"].(string); code != "EVAL_"+strings.ToUpper(want) {
+		t.Fatalf("code: got %q want %q body %s", code, "EVAL_"+strings.ToUpper(want), w.Body.String())
+	}
+	if docsURL, _ := body["docs_url"].(string); !strings.HasSuffix(docsURL, "#eval_"+want) {
+		t.Fatalf("docs_url: got %q want anchor %q", docsURL, "#eval_"+want)
+	}
+	if _, ok := body["retriable"].(bool); !ok {
+		t.Fatalf("retriable: missing from body %s", w.Body.String())
+	}
 }
@@ -0,0 +1,66 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+)
+
+func TestErrorMessagesFollowAcceptLanguage(t *testing.T) {
+	srv, err := createServer(t, 8080)
+	if err != nil {
+		t.Fatalf("createServer: %v", err)
+	}
+	catalog, err := messages.NewCatalog("de", map[string]string{
+		"method_not_allowed": "Die HTTP-Methode {{.Method}} ist für die API {{.Api}} nicht erlaubt.",
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewCatalog: %v", err)
+	}
+	srv.SetMessageCatalogs(messages.Catalogs{"de": catalog})
+	handler, err := srv.SetupRoutes()
+	if err != nil {
+		t.Fatalf("SetupRoutes: %v", err)
+	}
+
+	tests := []struct {
+		name            string
+		acceptLanguage  string
+		wantMessage     string
+		wantContentLang string
+	}{
+		{"German", "de-AT,de;q=0.9", "Die HTTP-Methode POST ist für die API /api/v1/health nicht erlaubt.", "de"},
+		{"English preferred", "en-US,de;q=0.5", "The HTTP method POST is not allowed for the API /api/v1/health.", ""},
+		{"no header", "", "The HTTP method POST is not allowed for the API /api/v1/health.", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/health", strings.NewReader(""))
+			if tt.acceptLanguage != "" {
+				req.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+			if w.Code != http.StatusMethodNotAllowed {
+				t.Fatalf("got status %d want 405", w.Code)
+			}
+			var body map[string]any
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if body["message"] != tt.wantMessage {
+				t.Errorf("message: got %q want %q", body["message"], tt.wantMessage)
+			}
+			if body["code"] != "EVAL_METHOD_NOT_ALLOWED" {
+				t.Errorf("code: got %q, the error code must not be localized", body["code"])
+			}
+			if got := w.Header().Get("Content-Language"); got != tt.wantContentLang {
+				t.Errorf("Content-Language: got %q want %q", got, tt.wantContentLang)
+			}
+		})
+	}
+}
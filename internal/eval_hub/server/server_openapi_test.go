@@ -0,0 +1,76 @@
+package server_test
+
+import (
+	"encoding/json"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/ui"
+)
+
+var pathParameter = regexp.MustCompile(`\{[^}]*\}`)
+
+// documentedPath returns the path of a route pattern without its method and with anonymous
+// parameters, so that it can be compared to the paths of the OpenAPI spec.
+func documentedPath(pattern string) string {
+	if _, path, found := strings.Cut(pattern, " "); found {
+		pattern = path
+	}
+	pattern = strings.TrimSuffix(pattern, "/{$}")
+	return pathParameter.ReplaceAllString(pattern, "{}")
+}
+
+// TestRoutesAreDocumented fails when a route is added without its OpenAPI source, or a
+// documented path is no longer served.
+func TestRoutesAreDocumented(t *testing.T) {
+	srv, err := createServer(t, 8080)
+	if err != nil {
+		t.Fatalf("createServer: %v", err)
+	}
+	srv.ServiceConfig().Service.EnableAdminAPI = true
+	srv.ServiceConfig().Service.DisableSwaggerUI = false
+	if _, err := srv.SetupRoutes(); err != nil {
+		t.Fatalf("SetupRoutes: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join("..", "..", "..", "docs", "openapi-internal.json"))
+	if err != nil {
+		t.Fatalf("failed to read the OpenAPI spec: %v", err)
+	}
+	spec := struct {
+		Paths map[string]any `json:"paths"`
+	}{}
+	if err := json.Unmarshal(content, &spec); err != nil {
+		t.Fatalf("failed to parse the OpenAPI spec: %v", err)
+	}
+	documented := map[string]bool{}
+	for path := range spec.Paths {
+		documented[documentedPath(path)] = true
+	}
+
+	if len(srv.Routes()) == 0 {
+		t.Fatal("expected the routes registered by SetupRoutes")
+	}
+	served := map[string]bool{}
+	for _, pattern := range srv.Routes() {
+		path := documentedPath(pattern)
+		if strings.HasPrefix(path, ui.Path) {
+			// the results UI is not part of the API
+			continue
+		}
+		served[path] = true
+		if !documented[path] {
+			t.Errorf("the route %s is not documented in docs/src/openapi.yaml", pattern)
+		}
+	}
+	for _, path := range slices.Sorted(maps.Keys(spec.Paths)) {
+		if !served[documentedPath(path)] {
+			t.Errorf("the documented path %s is not served", path)
+		}
+	}
+}
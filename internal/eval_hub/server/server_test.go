@@ -84,6 +84,15 @@ func (r *stubRuntime) RunEvaluationJob(
 	return nil
 }
 
+func (r *stubRuntime) RunEvaluationBenchmarks(
+	_ *api.EvaluationJobResource,
+	_ []api.EvaluationBenchmarkConfig,
+	_ []int,
+	_ abstractions.RuntimeStorage,
+) error {
+	return nil
+}
+
 func (r *stubRuntime) DeleteEvaluationJobResources(_ *api.EvaluationJobResource) error {
 	return nil
 }
@@ -97,6 +106,16 @@ func (r *stubRuntime) GetEvaluationLogs(
 	return "", nil
 }
 
+func (r *stubRuntime) GetEvaluationJobSpec(
+	_ *api.EvaluationJobResource,
+	_ []api.EvaluationBenchmarkConfig,
+	_ int,
+	_ int,
+	_ abstractions.RuntimeStorage,
+) ([]byte, error) {
+	return nil, nil
+}
+
 func TestNewServer(t *testing.T) {
 	t.Run("creates server with default port", func(t *testing.T) {
 		_ = os.Unsetenv("PORT")
@@ -156,13 +175,21 @@ func TestServerSetupRoutes(t *testing.T) {
 	}{
 		{http.MethodGet, "/api/v1/health", http.StatusOK, ""},
 		{http.MethodGet, "/openapi.yaml", http.StatusOK, ""},
+		{http.MethodGet, "/api/v1/openapi.json", http.StatusOK, ""},
+		{http.MethodPost, "/api/v1/openapi.json", http.StatusMethodNotAllowed, ""},
 		{http.MethodGet, "/docs", http.StatusOK, ""},
+		{http.MethodGet, "/ui", http.StatusMovedPermanently, ""},
+		{http.MethodGet, "/ui/", http.StatusOK, ""},
+		{http.MethodGet, "/ui/app.js", http.StatusOK, ""},
+		{http.MethodPost, "/ui/", http.StatusMethodNotAllowed, ""},
 		// Evaluation endpoints
 		{http.MethodPost, "/api/v1/evaluations/jobs", http.StatusAccepted, `{"name": "test-evaluation-job", "model": {"url": "http://test.com", "name": "test"}, "benchmarks": [{"id": "arc_easy", "provider_id": "lm_evaluation_harness"}]}`},
 		{http.MethodGet, "/api/v1/evaluations/jobs", http.StatusOK, ""},
 		{http.MethodGet, "/api/v1/evaluations/jobs/test-id", http.StatusNotFound, ""},
 		{http.MethodPost, "/api/v1/evaluations/jobs/test-id/logs", http.StatusMethodNotAllowed, ""},
+		{http.MethodPost, "/api/v1/evaluations/jobs/test-id/watch", http.StatusMethodNotAllowed, ""},
 		{http.MethodPost, "/api/v1/evaluations/jobs/test-id/benchmarks/0/logs", http.StatusMethodNotAllowed, ""},
+		{http.MethodPost, "/api/v1/evaluations/jobs/test-id/benchmarks/0/spec", http.StatusMethodNotAllowed, ""},
 		// Collections
 		{http.MethodPost, "/api/v1/evaluations/collections", http.StatusCreated, `{"name": "test-benchmarks-collection", "description": "Collection of benchmarks for FVT", "category": "test", "benchmarks": [{"id": "arc_easy", "provider_id": "lm_evaluation_harness"}]}`},
 		{http.MethodGet, "/api/v1/evaluations/collections", http.StatusOK, ""},
@@ -0,0 +1,53 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestRequestTimeouts(t *testing.T) {
+	srv, err := createServer(t, 8080)
+	if err != nil {
+		t.Fatalf("createServer: %v", err)
+	}
+	srv.ServiceConfig().Service.RequestTimeouts = &config.RequestTimeoutsConfig{
+		Routes: map[string]time.Duration{"GET /api/v1/evaluations/jobs": time.Nanosecond},
+	}
+	handler, err := srv.SetupRoutes()
+	if err != nil {
+		t.Fatalf("SetupRoutes: %v", err)
+	}
+
+	t.Run("a request past its deadline gets 504", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/evaluations/jobs", nil))
+		if w.Code != http.StatusGatewayTimeout {
+			t.Fatalf("got status %d want 504, body %s", w.Code, w.Body.String())
+		}
+		var body api.Error
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode error: %v", err)
+		}
+		if body.Code != "EVAL_REQUEST_TIMEOUT" || !body.Retriable {
+			t.Errorf("got error %+v, want a retriable EVAL_REQUEST_TIMEOUT", body)
+		}
+		if !strings.Contains(body.Message, "deadline of 1ns") || !strings.Contains(body.Message, "Completed steps: ") {
+			t.Errorf("expected the deadline and the completed steps in the message, got %q", body.Message)
+		}
+	})
+
+	t.Run("the other routes keep their deadline", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/evaluations/providers", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("got status %d want 200, body %s", w.Code, w.Body.String())
+		}
+	})
+}
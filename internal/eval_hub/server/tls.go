@@ -0,0 +1,147 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+)
+
+// tlsReloader hands out the TLS configuration of the server for each connection. The
+// certificate, key and client CA files are checked for changes at most once per interval
+// and reloaded when they changed, so that rotated certificates (e.g. renewed by
+// cert-manager into a mounted secret) are served without a restart. When a reload fails
+// the previous configuration is kept.
+type tlsReloader struct {
+	service  *config.ServiceConfig
+	interval time.Duration
+	logger   *slog.Logger
+
+	mu        sync.Mutex
+	config    *tls.Config
+	modTimes  []time.Time
+	checkedAt time.Time
+}
+
+func newTLSReloader(service *config.ServiceConfig, logger *slog.Logger) (*tlsReloader, error) {
+	r := &tlsReloader{
+		service:  service,
+		interval: service.EffectiveTLSReloadInterval(),
+		logger:   logger,
+	}
+	modTimes, err := r.fileModTimes()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	r.config = tlsConfig
+	r.modTimes = modTimes
+	r.checkedAt = time.Now()
+	return r, nil
+}
+
+// serverTLSConfig returns the TLS configuration to set on the http.Server.
+func (r *tlsReloader) serverTLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		MaxVersion:         tls.VersionTLS13,
+		GetConfigForClient: r.getConfigForClient,
+	}
+}
+
+func (r *tlsReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if time.Since(r.checkedAt) >= r.interval {
+		r.checkedAt = time.Now()
+		r.reloadIfChanged()
+	}
+	return r.config, nil
+}
+
+func (r *tlsReloader) reloadIfChanged() {
+	modTimes, err := r.fileModTimes()
+	if err != nil {
+		r.logger.Warn("Failed to check the TLS files for changes, keeping the loaded certificates", "error", err)
+		return
+	}
+	changed := false
+	for i := range modTimes {
+		if !modTimes[i].Equal(r.modTimes[i]) {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return
+	}
+	tlsConfig, err := r.load()
+	if err != nil {
+		r.logger.Warn("Failed to reload the TLS files, keeping the loaded certificates", "error", err)
+		return
+	}
+	r.config = tlsConfig
+	r.modTimes = modTimes
+	r.logger.Info("Reloaded the TLS certificates", "cert_file", r.service.TLSCertFile, "client_ca_file", r.service.TLSClientCAFile)
+}
+
+func (r *tlsReloader) files() []string {
+	files := []string{r.service.TLSCertFile, r.service.TLSKeyFile}
+	if r.service.ClientCertificatesEnabled() {
+		files = append(files, r.service.TLSClientCAFile)
+	}
+	return files
+}
+
+func (r *tlsReloader) fileModTimes() ([]time.Time, error) {
+	files := r.files()
+	modTimes := make([]time.Time, len(files))
+	for i, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("TLS file %s: %w", file, err)
+		}
+		modTimes[i] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+func (r *tlsReloader) load() (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(r.service.TLSCertFile, r.service.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the TLS certificate %s: %w", r.service.TLSCertFile, err)
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		MaxVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{certificate},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+	if !r.service.ClientCertificatesEnabled() {
+		return tlsConfig, nil
+	}
+
+	caCert, err := os.ReadFile(r.service.TLSClientCAFile) // #nosec G304 -- CA bundle path from service configuration
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the TLS client CA certificate at %s: %w", r.service.TLSClientCAFile, err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse the TLS client CA certificate at %s: file contains no valid PEM certificates", r.service.TLSClientCAFile)
+	}
+	tlsConfig.ClientCAs = clientCAs
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	if r.service.TLSClientAuth == config.TLSClientAuthRequire {
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
@@ -0,0 +1,249 @@
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+)
+
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCertificate(t *testing.T, commonName string, serial int64, parent *testCertificate, isCA bool) *testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:              []string{"localhost"},
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+	}
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCertificate{
+		cert: cert,
+		key:  key,
+		pem:  pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func (c *testCertificate) keyPEM(t *testing.T) []byte {
+	t.Helper()
+	der, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+}
+
+func (c *testCertificate) tlsCertificate(t *testing.T) tls.Certificate {
+	t.Helper()
+	certificate, err := tls.X509KeyPair(c.pem, c.keyPEM(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return certificate
+}
+
+func writeTestFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// startTLSTestServer starts a test server using the TLS configuration of the reloader.
+func startTLSTestServer(t *testing.T, service *config.ServiceConfig) *httptest.Server {
+	t.Helper()
+	reloader, err := newTLSReloader(service, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatalf("newTLSReloader() error: %v", err)
+	}
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	ts.TLS = reloader.serverTLSConfig()
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func tlsTestClient(ca *testCertificate, certificates ...tls.Certificate) *http.Client {
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	return &http.Client{Transport: &http.Transport{
+		TLSClientConfig: &tls.Config{
+			RootCAs:    roots,
+			ServerName: "localhost",
+			MinVersion: tls.VersionTLS12,
+			// send the certificate even when the server does not list its issuer as acceptable
+			GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				if len(certificates) == 0 {
+					return &tls.Certificate{}, nil
+				}
+				return &certificates[0], nil
+			},
+		},
+		DisableKeepAlives: true,
+	}}
+}
+
+func TestTLSReloaderClientCertificates(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "test-ca", 1, nil, true)
+	serverCert := newTestCertificate(t, "eval-hub", 2, ca, false)
+	clientCert := newTestCertificate(t, "adapter", 3, ca, false)
+	otherCA := newTestCertificate(t, "other-ca", 4, nil, true)
+	untrustedCert := newTestCertificate(t, "untrusted", 5, otherCA, false)
+
+	now := time.Now()
+	service := &config.ServiceConfig{
+		TLSCertFile:     filepath.Join(dir, "tls.crt"),
+		TLSKeyFile:      filepath.Join(dir, "tls.key"),
+		TLSClientCAFile: filepath.Join(dir, "ca.crt"),
+	}
+	writeTestFile(t, service.TLSCertFile, serverCert.pem, now)
+	writeTestFile(t, service.TLSKeyFile, serverCert.keyPEM(t), now)
+	writeTestFile(t, service.TLSClientCAFile, ca.pem, now)
+
+	tests := []struct {
+		name        string
+		clientAuth  string
+		certificate *testCertificate
+		wantOK      bool
+	}{
+		{name: "no client auth without certificate", clientAuth: "", wantOK: true},
+		{name: "optional without certificate", clientAuth: config.TLSClientAuthOptional, wantOK: true},
+		{name: "optional with untrusted certificate", clientAuth: config.TLSClientAuthOptional, certificate: untrustedCert, wantOK: false},
+		{name: "require without certificate", clientAuth: config.TLSClientAuthRequire, wantOK: false},
+		{name: "require with certificate", clientAuth: config.TLSClientAuthRequire, certificate: clientCert, wantOK: true},
+		{name: "require with untrusted certificate", clientAuth: config.TLSClientAuthRequire, certificate: untrustedCert, wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			serviceConfig := *service
+			serviceConfig.TLSClientAuth = tt.clientAuth
+			ts := startTLSTestServer(t, &serviceConfig)
+
+			var certificates []tls.Certificate
+			if tt.certificate != nil {
+				certificates = append(certificates, tt.certificate.tlsCertificate(t))
+			}
+			resp, err := tlsTestClient(ca, certificates...).Get(ts.URL)
+			if resp != nil {
+				_ = resp.Body.Close()
+			}
+			if tt.wantOK && err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if !tt.wantOK && err == nil {
+				t.Fatalf("request succeeded with status %d, want a TLS error", resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestTLSReloaderReloadsRotatedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "test-ca", 1, nil, true)
+	first := newTestCertificate(t, "eval-hub", 2, ca, false)
+	rotated := newTestCertificate(t, "eval-hub", 3, ca, false)
+
+	now := time.Now()
+	service := &config.ServiceConfig{
+		TLSCertFile:       filepath.Join(dir, "tls.crt"),
+		TLSKeyFile:        filepath.Join(dir, "tls.key"),
+		TLSReloadInterval: time.Nanosecond,
+	}
+	writeTestFile(t, service.TLSCertFile, first.pem, now)
+	writeTestFile(t, service.TLSKeyFile, first.keyPEM(t), now)
+	ts := startTLSTestServer(t, service)
+	client := tlsTestClient(ca)
+
+	servedSerial := func() int64 {
+		t.Helper()
+		resp, err := client.Get(ts.URL)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		_ = resp.Body.Close()
+		return resp.TLS.PeerCertificates[0].SerialNumber.Int64()
+	}
+
+	if serial := servedSerial(); serial != 2 {
+		t.Fatalf("served certificate serial = %d, want 2", serial)
+	}
+
+	// a key that does not match the certificate is not loaded
+	writeTestFile(t, service.TLSCertFile, rotated.pem, now.Add(time.Minute))
+	if serial := servedSerial(); serial != 2 {
+		t.Fatalf("served certificate serial = %d after a partial rotation, want 2", serial)
+	}
+
+	writeTestFile(t, service.TLSKeyFile, rotated.keyPEM(t), now.Add(time.Minute))
+	if serial := servedSerial(); serial != 3 {
+		t.Fatalf("served certificate serial = %d after the rotation, want 3", serial)
+	}
+}
+
+func TestNewTLSReloaderRejectsInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCertificate(t, "test-ca", 1, nil, true)
+	serverCert := newTestCertificate(t, "eval-hub", 2, ca, false)
+	now := time.Now()
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	badCAFile := filepath.Join(dir, "ca.crt")
+	writeTestFile(t, certFile, serverCert.pem, now)
+	writeTestFile(t, keyFile, serverCert.keyPEM(t), now)
+	writeTestFile(t, badCAFile, []byte("not a certificate"), now)
+
+	for name, service := range map[string]*config.ServiceConfig{
+		"missing certificate": {TLSCertFile: filepath.Join(dir, "missing.crt"), TLSKeyFile: keyFile},
+		"invalid client CA":   {TLSCertFile: certFile, TLSKeyFile: keyFile, TLSClientCAFile: badCAFile, TLSClientAuth: config.TLSClientAuthRequire},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := newTLSReloader(service, slog.New(slog.DiscardHandler)); err == nil {
+				t.Fatal("newTLSReloader() = nil error, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package sql
+
+import (
+	"database/sql"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The adapter image pinned first is kept: the benchmarks of a job may be created
+// concurrently, and each of them and each retry runs the image that the job recorded.
+
+func (s *sqlStorage) PinEvaluationJobAdapterImage(id string, image string, pinned string) (string, error) {
+	recorded := pinned
+	err := s.withTransaction("pin evaluation job adapter image", id, func(txn *sql.Tx) error {
+		job, stored, err := s.getEvaluationJobTransactionalForUpdate(txn, id)
+		if err != nil {
+			return err
+		}
+		if job.Status == nil {
+			job.Status = &api.EvaluationJobStatus{
+				EvaluationJobState: api.EvaluationJobState{
+					State: api.OverallStatePending,
+				},
+			}
+		}
+		if existing, ok := job.Status.AdapterImages[image]; ok {
+			recorded = existing
+			return nil
+		}
+		if job.Status.AdapterImages == nil {
+			job.Status.AdapterImages = map[string]string{}
+		}
+		job.Status.AdapterImages[image] = pinned
+		return s.updateEvaluationJobTxn(txn, id, job.Status.State, job, stored)
+	})
+	if err != nil {
+		return "", err
+	}
+	return recorded, nil
+}
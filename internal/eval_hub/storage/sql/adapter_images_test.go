@@ -0,0 +1,55 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestPinEvaluationJobAdapterImage(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-adapter-images")
+	store = store.WithTenant(tenant)
+
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, Owner: "alice", CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePending}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Name:       "adapter-images",
+			Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+
+	const image = "quay.io/evalhub/adapter:latest"
+	first := "quay.io/evalhub/adapter@sha256:1111"
+	if recorded, err := store.PinEvaluationJobAdapterImage(jobID, image, first); err != nil || recorded != first {
+		t.Fatalf("PinEvaluationJobAdapterImage() = %q, %v, want %q", recorded, err, first)
+	}
+	// the tag moved before a later benchmark of the job resolved it
+	if recorded, err := store.PinEvaluationJobAdapterImage(jobID, image, "quay.io/evalhub/adapter@sha256:2222"); err != nil || recorded != first {
+		t.Fatalf("PinEvaluationJobAdapterImage() = %q, %v, want the image pinned first %q", recorded, err, first)
+	}
+	job, err := store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if job.Status == nil || job.Status.AdapterImages[image] != first {
+		t.Fatalf("expected the status to record the pinned image, got %+v", job.Status)
+	}
+	if job.Status.State != api.OverallStatePending {
+		t.Errorf("expected the job to stay pending, got %s", job.Status.State)
+	}
+
+	if _, err := store.PinEvaluationJobAdapterImage("missing", image, first); err == nil {
+		t.Error("expected an error for an unknown job")
+	}
+}
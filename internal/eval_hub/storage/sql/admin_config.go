@@ -0,0 +1,49 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+//#######################################################################
+// Admin configuration operations
+//#######################################################################
+
+func (s *sqlStorage) PutAdminConfig(config *api.AdminConfig) error {
+	entity, err := json.Marshal(config)
+	if err != nil {
+		return serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	statement, args := s.statementsFactory.CreateAdminConfigPutStatement(config.UpdatedAt, string(entity))
+	if _, err := s.exec(nil, statement, args...); err != nil {
+		s.logger.Error("Failed to store admin configuration", "error", err)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "admin configuration", "ResourceId", "service", "Error", err.Error())
+	}
+	return nil
+}
+
+func (s *sqlStorage) GetAdminConfig() (*api.AdminConfig, error) {
+	statement, args := s.statementsFactory.CreateAdminConfigGetStatement()
+
+	var config api.AdminConfig
+	var entity string
+	err := s.queryRow(nil, statement, args...).Scan(&config.UpdatedAt, &entity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		s.logger.Error("Failed to get admin configuration", "error", err)
+		return nil, serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "admin configuration", "ResourceId", "service", "Error", err.Error())
+	}
+	updatedAt := config.UpdatedAt
+	if err := json.Unmarshal([]byte(entity), &config); err != nil {
+		s.logger.Error("Failed to unmarshal admin configuration", "error", err)
+		return nil, serviceerrors.NewServiceError(messages.JSONUnmarshalFailed, "Type", "admin configuration", "Error", err.Error())
+	}
+	config.UpdatedAt = updatedAt
+	return &config, nil
+}
@@ -0,0 +1,43 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestAdminConfig(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	config, err := store.GetAdminConfig()
+	if err != nil || config != nil {
+		t.Fatalf("expected no admin configuration, got %+v, %v", config, err)
+	}
+
+	put := func(tenant api.Tenant, config api.AdminConfig) {
+		t.Helper()
+		config.UpdatedAt = time.Now()
+		config.UpdatedBy = "alice"
+		if err := store.WithTenant(tenant).PutAdminConfig(&config); err != nil {
+			t.Fatalf("PutAdminConfig: %v", err)
+		}
+	}
+	put("ops", api.AdminConfig{LogLevel: "debug"})
+	// the configuration is replaced, whatever the tenant of the request
+	put("other", api.AdminConfig{LogLevel: "warn", ProviderHealthPollInterval: "1m0s", ReconcileInterval: "2m0s"})
+
+	config, err = store.WithTenant("ops").GetAdminConfig()
+	if err != nil {
+		t.Fatalf("GetAdminConfig: %v", err)
+	}
+	if config.LogLevel != "warn" || config.ProviderHealthPollInterval != "1m0s" || config.ReconcileInterval != "2m0s" {
+		t.Errorf("expected the second admin configuration, got %+v", config)
+	}
+	if config.UpdatedBy != "alice" || config.UpdatedAt.IsZero() {
+		t.Errorf("expected who changed the configuration and when, got %+v", config)
+	}
+}
@@ -0,0 +1,79 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	se "github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The intermediate artifacts of a benchmark are stored in the artifact store, the
+// evaluation_artifacts table only holds their metadata, one row per artifact name, which is
+// written as soon as an artifact is uploaded so that it can be listed while the job runs.
+
+// PutEvaluationJobArtifact stores the metadata of an artifact of a benchmark of the job,
+// replacing the metadata of a previous upload of the artifact.
+func (s *sqlStorage) PutEvaluationJobArtifact(id string, artifact *api.ArtifactResource) error {
+	return s.withTransaction("put evaluation job artifact", id, func(txn *sql.Tx) error {
+		// the job is read in the scope of the storage, the artifacts are not scoped to a tenant
+		if _, err := s.scanEvaluationJobTransactional(txn, id, false); err != nil {
+			return err
+		}
+		entity, err := json.Marshal(artifact)
+		if err != nil {
+			return se.WithRollback(se.NewServiceError(messages.InternalServerError, "Error", err.Error()))
+		}
+		upsertQuery, args := s.statementsFactory.CreateEvaluationArtifactUpsertStatement(id, artifact.BenchmarkIndex, artifact.Name, artifact.UploadedAt, string(entity))
+		if _, err := s.exec(txn, upsertQuery, args...); err != nil {
+			s.logger.Error("Failed to write the artifact of evaluation job", "error", err, "id", id, "benchmark_index", artifact.BenchmarkIndex, "name", artifact.Name)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job artifacts", "ResourceId", id, "Error", err.Error()))
+		}
+		return nil
+	})
+}
+
+// GetEvaluationJobArtifacts returns the artifacts of a benchmark of the job, by name.
+func (s *sqlStorage) GetEvaluationJobArtifacts(id string, benchmarkIndex int, filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.ArtifactResource], error) {
+	if _, err := s.scanEvaluationJobTransactional(nil, id, false); err != nil {
+		return nil, err
+	}
+
+	var total int
+	countQuery, args := s.statementsFactory.CreateEvaluationArtifactsCountStatement(id, benchmarkIndex)
+	if err := s.queryRow(nil, countQuery, args...).Scan(&total); err != nil {
+		s.logger.Error("Failed to count the artifacts of evaluation job", "error", err, "id", id)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job artifacts", "ResourceId", id, "Error", err.Error())
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = math.MaxInt32
+	}
+	listQuery, args := s.statementsFactory.CreateEvaluationArtifactsListStatement(id, benchmarkIndex, limit, filter.Offset)
+	rows, err := s.query(nil, listQuery, args...)
+	if err != nil {
+		s.logger.Error("Failed to list the artifacts of evaluation job", "error", err, "id", id)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job artifacts", "ResourceId", id, "Error", err.Error())
+	}
+	defer func() { _ = rows.Close() }()
+	items := make([]api.ArtifactResource, 0)
+	for rows.Next() {
+		var entity string
+		if err := rows.Scan(&entity); err != nil {
+			return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job artifacts", "ResourceId", id, "Error", err.Error())
+		}
+		var item api.ArtifactResource
+		if err := json.Unmarshal([]byte(entity), &item); err != nil {
+			return nil, se.NewServiceError(messages.JSONUnmarshalFailed, "Type", "evaluation job artifact", "Error", err.Error())
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job artifacts", "ResourceId", id, "Error", err.Error())
+	}
+	return &abstractions.QueryResults[api.ArtifactResource]{Items: items, TotalCount: total}, nil
+}
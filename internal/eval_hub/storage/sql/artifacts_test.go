@@ -0,0 +1,87 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestEvaluationJobArtifacts(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-artifacts")
+	store = store.WithTenant(tenant)
+
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model: api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{
+				{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"},
+				{Ref: api.Ref{ID: "hellaswag"}, ProviderID: "lm_evaluation_harness"},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+
+	put := func(index int, name string, size int64) {
+		t.Helper()
+		if err := store.PutEvaluationJobArtifact(jobID, &api.ArtifactResource{
+			JobID:          jobID,
+			BenchmarkIndex: index,
+			Name:           name,
+			ContentType:    "application/jsonl",
+			Size:           size,
+			URI:            "mlflow-artifacts:/1/eval-hub/jobs/" + jobID + "/" + name,
+			UploadedAt:     time.Now().UTC(),
+		}); err != nil {
+			t.Fatalf("PutEvaluationJobArtifact(%s): %v", name, err)
+		}
+	}
+	put(0, "predictions.jsonl", 10)
+	put(0, "checkpoint.json", 5)
+	// a new upload replaces the artifact
+	put(0, "predictions.jsonl", 20)
+	put(1, "predictions.jsonl", 30)
+
+	list := func(index int, limit int) *abstractions.QueryResults[api.ArtifactResource] {
+		t.Helper()
+		artifacts, err := store.GetEvaluationJobArtifacts(jobID, index, &abstractions.QueryFilter{Limit: limit})
+		if err != nil {
+			t.Fatalf("GetEvaluationJobArtifacts(%d): %v", index, err)
+		}
+		return artifacts
+	}
+	first := list(0, 0)
+	if first.TotalCount != 2 || len(first.Items) != 2 {
+		t.Fatalf("expected 2 artifacts of the first benchmark, got %+v", first)
+	}
+	if first.Items[0].Name != "checkpoint.json" || first.Items[1].Name != "predictions.jsonl" || first.Items[1].Size != 20 {
+		t.Errorf("expected the artifacts by name with the last upload, got %+v", first.Items)
+	}
+	if page := list(0, 1); page.TotalCount != 2 || len(page.Items) != 1 {
+		t.Errorf("expected one of 2 artifacts, got %+v", page)
+	}
+	if second := list(1, 0); second.TotalCount != 1 || second.Items[0].Size != 30 {
+		t.Errorf("expected the artifact of the second benchmark, got %+v", second)
+	}
+
+	if err := store.WithTenant("other-tenant").PutEvaluationJobArtifact(jobID, &api.ArtifactResource{Name: "other.json"}); err == nil {
+		t.Errorf("expected the job of another tenant not to be found")
+	}
+
+	if err := store.DeleteEvaluationJob(jobID); err != nil {
+		t.Fatalf("DeleteEvaluationJob: %v", err)
+	}
+	if _, err := store.GetEvaluationJobArtifacts(jobID, 0, &abstractions.QueryFilter{}); err == nil {
+		t.Errorf("expected the artifacts of a deleted job not to be found")
+	}
+}
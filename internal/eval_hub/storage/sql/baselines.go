@@ -0,0 +1,114 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// BaselineEntity is the stored form of a baseline, the resource is kept in the columns
+type BaselineEntity struct {
+	api.BaselineConfig
+	Model      api.ModelRef            `json:"model"`
+	Score      float32                 `json:"score"`
+	Benchmarks []api.BaselineBenchmark `json:"benchmarks,omitempty"`
+}
+
+//#######################################################################
+// Baseline operations
+//#######################################################################
+
+func (s *sqlStorage) CreateBaseline(baseline *api.BaselineResource) error {
+	return s.withTransaction("create baseline", baseline.Resource.ID, func(txn *sql.Tx) error {
+		existing, err := s.getBaselineTransactional(txn, baseline.Resource.ID)
+		if existing != nil {
+			return serviceerrors.NewServiceError(messages.BaselineAlreadyExists, "Name", baseline.Resource.ID)
+		}
+		var se *serviceerrors.ServiceError
+		if !errors.As(err, &se) || se.MessageCode() != messages.ResourceNotFound {
+			return err
+		}
+
+		entity, err := json.Marshal(BaselineEntity{
+			BaselineConfig: baseline.BaselineConfig,
+			Model:          baseline.Model,
+			Score:          baseline.Score,
+			Benchmarks:     baseline.Benchmarks,
+		})
+		if err != nil {
+			return serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+		}
+		statement, args := s.statementsFactory.CreateBaselineAddEntityStatement(baseline, string(entity))
+		if _, err := s.exec(txn, statement, args...); err != nil {
+			s.logger.Error("Failed to store baseline", "error", err, "name", baseline.Resource.ID)
+			return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "baseline", "ResourceId", baseline.Resource.ID, "Error", err.Error())
+		}
+
+		s.logger.Info("Stored baseline", "name", baseline.Resource.ID, "job_id", baseline.JobID)
+		return nil
+	})
+}
+
+func (s *sqlStorage) GetBaseline(name string) (*api.BaselineResource, error) {
+	return s.getBaselineTransactional(nil, name)
+}
+
+func (s *sqlStorage) getBaselineTransactional(txn *sql.Tx, name string) (*api.BaselineResource, error) {
+	query := shared.EntityQuery{Resource: api.Resource{ID: name, Tenant: s.tenant}}
+	selectQuery, selectArgs, queryArgs := s.statementsFactory.CreateBaselineGetEntityStatement(&query)
+
+	err := s.queryRow(txn, selectQuery, selectArgs...).Scan(queryArgs...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "baseline", "ResourceId", name)
+		}
+		s.logger.Error("Failed to get baseline", "error", err, "name", name)
+		return nil, serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "baseline", "ResourceId", name, "Error", err.Error())
+	}
+
+	if !s.isVisibleResource(&query.Resource) {
+		return nil, serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "baseline", "ResourceId", name)
+	}
+
+	var entity BaselineEntity
+	if err := json.Unmarshal([]byte(query.EntityJSON), &entity); err != nil {
+		s.logger.Error("Failed to unmarshal baseline", "error", err, "name", name)
+		return nil, serviceerrors.NewServiceError(messages.JSONUnmarshalFailed, "Type", "baseline", "Error", err.Error())
+	}
+	return constructBaselineResource(&query.Resource, &entity), nil
+}
+
+func constructBaselineResource(resource *api.Resource, entity *BaselineEntity) *api.BaselineResource {
+	return &api.BaselineResource{
+		Resource:       *resource,
+		BaselineConfig: entity.BaselineConfig,
+		Model:          entity.Model,
+		Score:          entity.Score,
+		Benchmarks:     entity.Benchmarks,
+	}
+}
+
+func (s *sqlStorage) GetBaselines(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.BaselineResource], error) {
+	return listEntities[api.BaselineResource](s, nil, shared.TABLE_BASELINES, filter)
+}
+
+func (s *sqlStorage) DeleteBaseline(name string) error {
+	return s.withTransaction("delete baseline", name, func(txn *sql.Tx) error {
+		if _, err := s.getBaselineTransactional(txn, name); err != nil {
+			return err
+		}
+		deleteQuery, args := s.statementsFactory.CreateDeleteEntityStatement(s.tenant, shared.TABLE_BASELINES, name)
+		if _, err := s.exec(txn, deleteQuery, args...); err != nil {
+			s.logger.Error("Failed to delete baseline", "error", err, "name", name)
+			return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "baseline", "ResourceId", name, "Error", err.Error())
+		}
+		s.logger.Debug("Deleted baseline", "name", name)
+		return nil
+	})
+}
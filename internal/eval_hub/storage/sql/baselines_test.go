@@ -0,0 +1,185 @@
+package sql_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestBaselines(t *testing.T) {
+	logger := logging.FallbackLogger()
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           getDBInMemoryURL("eval_hub_baselines"),
+		"database_name": "eval_hub_baselines",
+	}
+	store, err := storage.NewStorage(&databaseConfig, nil, nil, false, false, logger)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	teamA := store.WithTenant("team-a")
+	baseline := &api.BaselineResource{
+		Resource:       api.Resource{ID: "prod-v3", Tenant: "team-a", Owner: "alice", CreatedAt: time.Now()},
+		BaselineConfig: api.BaselineConfig{Name: "prod-v3", JobID: "job-1"},
+		Model:          api.ModelRef{Name: "granite", URL: "http://granite:8000"},
+		Score:          0.7,
+		Benchmarks: []api.BaselineBenchmark{
+			{ID: "mmlu", ProviderID: "lm_evaluation_harness", PrimaryScoreMetric: "acc", PrimaryScore: 0.6},
+		},
+	}
+	if err := teamA.CreateBaseline(baseline); err != nil {
+		t.Fatalf("CreateBaseline: %v", err)
+	}
+
+	hasMessage := func(err error, message *messages.MessageCode) bool {
+		var se *serviceerrors.ServiceError
+		return errors.As(err, &se) && se.MessageCode() == message
+	}
+
+	t.Run("a baseline is returned by name", func(t *testing.T) {
+		got, err := teamA.GetBaseline("prod-v3")
+		if err != nil {
+			t.Fatalf("GetBaseline: %v", err)
+		}
+		if got.JobID != "job-1" || got.Model.Name != "granite" || got.Score != 0.7 || got.GetBenchmark("lm_evaluation_harness", "mmlu") == nil {
+			t.Fatalf("unexpected baseline %+v", got)
+		}
+	})
+
+	t.Run("a name can only be registered once", func(t *testing.T) {
+		if err := teamA.CreateBaseline(baseline); !hasMessage(err, messages.BaselineAlreadyExists) {
+			t.Fatalf("expected baseline_already_exists, got %v", err)
+		}
+	})
+
+	t.Run("baselines are scoped to the tenant", func(t *testing.T) {
+		teamB := store.WithTenant("team-b")
+		if _, err := teamB.GetBaseline("prod-v3"); !hasMessage(err, messages.ResourceNotFound) {
+			t.Fatalf("expected resource_not_found for another tenant, got %v", err)
+		}
+		other := *baseline
+		other.Resource.Tenant = "team-b"
+		if err := teamB.CreateBaseline(&other); err != nil {
+			t.Fatalf("expected the name to be free in another tenant, got %v", err)
+		}
+	})
+
+	t.Run("baselines are listed", func(t *testing.T) {
+		res, err := teamA.GetBaselines(&abstractions.QueryFilter{Limit: 10})
+		if err != nil {
+			t.Fatalf("GetBaselines: %v", err)
+		}
+		if res.TotalCount != 1 || len(res.Items) != 1 || res.Items[0].Name != "prod-v3" {
+			t.Fatalf("unexpected baselines %+v", res)
+		}
+	})
+
+	t.Run("a deleted baseline is not found", func(t *testing.T) {
+		if err := teamA.DeleteBaseline("prod-v3"); err != nil {
+			t.Fatalf("DeleteBaseline: %v", err)
+		}
+		if _, err := teamA.GetBaseline("prod-v3"); !hasMessage(err, messages.ResourceNotFound) {
+			t.Fatalf("expected resource_not_found, got %v", err)
+		}
+		if err := teamA.DeleteBaseline("prod-v3"); !hasMessage(err, messages.ResourceNotFound) {
+			t.Fatalf("expected resource_not_found, got %v", err)
+		}
+	})
+}
+
+func TestUpdateEvaluationJob_MustNotRegress(t *testing.T) {
+	store, err := getTestStorage(t, "sqlite", "eval_hub_must_not_regress")
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-baseline")
+	if err := store.WithTenant(tenant).CreateBaseline(&api.BaselineResource{
+		Resource:       api.Resource{ID: "prod-v3", Tenant: tenant, CreatedAt: time.Now()},
+		BaselineConfig: api.BaselineConfig{Name: "prod-v3", JobID: "job-1"},
+		Score:          0.8,
+		Benchmarks: []api.BaselineBenchmark{
+			{ID: "arc_easy", ProviderID: "lm_evaluation_harness", PrimaryScoreMetric: "acc", PrimaryScore: 0.8},
+		},
+	}); err != nil {
+		t.Fatalf("CreateBaseline: %v", err)
+	}
+
+	threshold := float32(0.5)
+	run := func(t *testing.T, passCriteria *api.PassCriteria, acc float64) *api.EvaluationJobResults {
+		t.Helper()
+		jobID := common.GUID()
+		job := &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{
+				Resource: api.Resource{ID: jobID, Tenant: tenant, CreatedAt: time.Now()},
+			},
+			Status: &api.EvaluationJobStatus{
+				EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning},
+			},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Model:        api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+				PassCriteria: passCriteria,
+				Benchmarks: []api.EvaluationBenchmarkConfig{{
+					Ref:          api.Ref{ID: "arc_easy"},
+					ProviderID:   "lm_evaluation_harness",
+					PrimaryScore: &api.PrimaryScore{Metric: "acc"},
+					PassCriteria: passCriteria,
+				}},
+			},
+		}
+		if err := store.CreateEvaluationJob(job); err != nil {
+			t.Fatalf("Failed to create job: %v", err)
+		}
+		if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+				ProviderID: "lm_evaluation_harness",
+				ID:         "arc_easy",
+				Status:     api.StateCompleted,
+				Metrics:    map[string]any{"acc": acc},
+			},
+		}); err != nil {
+			t.Fatalf("Failed to complete job: %v", err)
+		}
+		completed, err := store.GetEvaluationJob(jobID)
+		if err != nil {
+			t.Fatalf("Failed to get job: %v", err)
+		}
+		if completed.Results == nil || completed.Results.Test == nil || len(completed.Results.Benchmarks) != 1 || completed.Results.Benchmarks[0].Test == nil {
+			t.Fatalf("expected job and benchmark test results, got %+v", completed.Results)
+		}
+		return completed.Results
+	}
+
+	t.Run("a score below the baseline fails", func(t *testing.T) {
+		results := run(t, &api.PassCriteria{Threshold: &threshold, MustNotRegress: "prod-v3"}, 0.7)
+		if results.Test.Pass || results.Test.Threshold != 0.8 || results.Test.Baseline != "prod-v3" || results.Test.BaselineScore == nil {
+			t.Fatalf("expected the job to fail against the baseline, got %+v", results.Test)
+		}
+		if results.Benchmarks[0].Test.Pass || results.Benchmarks[0].Test.BaselinePrimaryScore == nil {
+			t.Fatalf("expected the benchmark to fail against the baseline, got %+v", results.Benchmarks[0].Test)
+		}
+	})
+
+	t.Run("a score matching the baseline passes", func(t *testing.T) {
+		results := run(t, &api.PassCriteria{MustNotRegress: "prod-v3"}, 0.9)
+		if !results.Test.Pass || !results.Benchmarks[0].Test.Pass {
+			t.Fatalf("expected the job to pass, got %+v and %+v", results.Test, results.Benchmarks[0].Test)
+		}
+	})
+
+	t.Run("a missing baseline fails", func(t *testing.T) {
+		results := run(t, &api.PassCriteria{MustNotRegress: "prod-v4"}, 0.9)
+		if results.Test.Pass || results.Test.BaselineScore != nil || results.Benchmarks[0].Test.Pass {
+			t.Fatalf("expected the job to fail without its baseline, got %+v", results.Test)
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	se "github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+//#######################################################################
+// Status dead letter operations
+//#######################################################################
+
+// The status_dead_letters table holds the status events that were rejected, one row per
+// request, for the admin API. The dead letters are not scoped to the tenant of the storage,
+// they carry their own; they are deleted when they are requeued or discarded, or with their
+// job.
+
+func (s *sqlStorage) AddStatusDeadLetter(letter *api.StatusDeadLetter) error {
+	entity, err := json.Marshal(letter)
+	if err != nil {
+		return se.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	statement, args := s.statementsFactory.CreateStatusDeadLetterInsertStatement(letter.ID, letter.Tenant, letter.JobID, letter.ReceivedAt, string(entity))
+	if _, err := s.exec(nil, statement, args...); err != nil {
+		s.logger.Error("Failed to store status dead letter", "error", err, "id", letter.ID, "job_id", letter.JobID)
+		return se.NewServiceError(messages.DatabaseOperationFailed, "Type", "status dead letter", "ResourceId", letter.ID, "Error", err.Error())
+	}
+	return nil
+}
+
+func (s *sqlStorage) GetStatusDeadLetter(id string) (*api.StatusDeadLetter, error) {
+	statement, args := s.statementsFactory.CreateStatusDeadLetterGetStatement(id)
+	var entity string
+	if err := s.queryRow(nil, statement, args...).Scan(&entity); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, se.NewServiceError(messages.ResourceNotFound, "Type", "status dead letter", "ResourceId", id)
+		}
+		s.logger.Error("Failed to get status dead letter", "error", err, "id", id)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "status dead letter", "ResourceId", id, "Error", err.Error())
+	}
+	var letter api.StatusDeadLetter
+	if err := json.Unmarshal([]byte(entity), &letter); err != nil {
+		return nil, se.NewServiceError(messages.JSONUnmarshalFailed, "Type", "status dead letter", "Error", err.Error())
+	}
+	return &letter, nil
+}
+
+func (s *sqlStorage) GetStatusDeadLetters(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.StatusDeadLetter], error) {
+	tenant, _ := filter.Params["tenant"].(string)
+	jobID, _ := filter.Params["job_id"].(string)
+
+	var total int
+	countQuery, args := s.statementsFactory.CreateStatusDeadLettersCountStatement(api.Tenant(tenant), jobID)
+	if err := s.queryRow(nil, countQuery, args...).Scan(&total); err != nil {
+		s.logger.Error("Failed to count status dead letters", "error", err)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "status dead letters", "ResourceId", jobID, "Error", err.Error())
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = math.MaxInt32
+	}
+	listQuery, args := s.statementsFactory.CreateStatusDeadLettersListStatement(api.Tenant(tenant), jobID, limit, filter.Offset)
+	rows, err := s.query(nil, listQuery, args...)
+	if err != nil {
+		s.logger.Error("Failed to list status dead letters", "error", err)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "status dead letters", "ResourceId", jobID, "Error", err.Error())
+	}
+	defer func() { _ = rows.Close() }()
+	items := make([]api.StatusDeadLetter, 0)
+	for rows.Next() {
+		var entity string
+		if err := rows.Scan(&entity); err != nil {
+			return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "status dead letters", "ResourceId", jobID, "Error", err.Error())
+		}
+		var item api.StatusDeadLetter
+		if err := json.Unmarshal([]byte(entity), &item); err != nil {
+			return nil, se.NewServiceError(messages.JSONUnmarshalFailed, "Type", "status dead letter", "Error", err.Error())
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "status dead letters", "ResourceId", jobID, "Error", err.Error())
+	}
+	return &abstractions.QueryResults[api.StatusDeadLetter]{Items: items, TotalCount: total}, nil
+}
+
+func (s *sqlStorage) DeleteStatusDeadLetter(id string) error {
+	statement, args := s.statementsFactory.CreateStatusDeadLetterDeleteStatement(id)
+	result, err := s.exec(nil, statement, args...)
+	if err != nil {
+		s.logger.Error("Failed to delete status dead letter", "error", err, "id", id)
+		return se.NewServiceError(messages.DatabaseOperationFailed, "Type", "status dead letter", "ResourceId", id, "Error", err.Error())
+	}
+	if deleted, err := result.RowsAffected(); err == nil && deleted == 0 {
+		return se.NewServiceError(messages.ResourceNotFound, "Type", "status dead letter", "ResourceId", id)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package sql_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestStatusDeadLetters(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	jobA, jobB := common.GUID(), common.GUID()
+	received := time.Now().UTC().Truncate(time.Second)
+	letters := []*api.StatusDeadLetter{
+		{ID: common.GUID(), Tenant: "tenant-a", Owner: "alice", JobID: jobA, ReceivedAt: received.Add(-2 * time.Minute), ErrorCode: "job_can_not_be_updated", Reason: "cancelled", Body: `{"a":1}`},
+		{ID: common.GUID(), Tenant: "tenant-a", Owner: "alice", JobID: jobA, ReceivedAt: received.Add(-time.Minute), ErrorCode: "invalid_benchmark", Reason: "unknown benchmark", Body: `{"a":2}`},
+		{ID: common.GUID(), Tenant: "tenant-b", Owner: "bob", JobID: jobB, ReceivedAt: received, ErrorCode: "invalid_benchmark", Reason: "unknown benchmark", Body: `{"b":1}`},
+	}
+	for _, letter := range letters {
+		if err := store.AddStatusDeadLetter(letter); err != nil {
+			t.Fatalf("AddStatusDeadLetter: %v", err)
+		}
+	}
+
+	list := func(params map[string]any, limit int) *abstractions.QueryResults[api.StatusDeadLetter] {
+		t.Helper()
+		res, err := store.GetStatusDeadLetters(&abstractions.QueryFilter{Limit: limit, Params: params})
+		if err != nil {
+			t.Fatalf("GetStatusDeadLetters: %v", err)
+		}
+		return res
+	}
+
+	// the dead letters of all tenants, the newest first
+	res := list(map[string]any{}, 2)
+	if res.TotalCount < 3 || len(res.Items) != 2 {
+		t.Fatalf("expected a page of 2 of at least 3 dead letters, got %d of %d", len(res.Items), res.TotalCount)
+	}
+	res = list(map[string]any{"job_id": jobA}, 10)
+	if res.TotalCount != 2 || len(res.Items) != 2 || res.Items[0].ID != letters[1].ID || res.Items[1].ID != letters[0].ID {
+		t.Errorf("expected the dead letters of the job newest first, got %+v", res.Items)
+	}
+	res = list(map[string]any{"tenant": "tenant-b"}, 10)
+	if res.TotalCount != 1 || res.Items[0] != *letters[2] {
+		t.Errorf("expected the dead letter of tenant-b, got %+v", res.Items)
+	}
+	if res = list(map[string]any{"tenant": "tenant-b", "job_id": jobA}, 10); res.TotalCount != 0 || len(res.Items) != 0 {
+		t.Errorf("expected no dead letter, got %+v", res.Items)
+	}
+
+	got, err := store.GetStatusDeadLetter(letters[0].ID)
+	if err != nil {
+		t.Fatalf("GetStatusDeadLetter: %v", err)
+	}
+	if !got.ReceivedAt.Equal(letters[0].ReceivedAt) {
+		t.Errorf("received at: got %s want %s", got.ReceivedAt, letters[0].ReceivedAt)
+	}
+	got.ReceivedAt = letters[0].ReceivedAt
+	if *got != *letters[0] {
+		t.Errorf("got %+v want %+v", got, letters[0])
+	}
+
+	if err := store.DeleteStatusDeadLetter(letters[0].ID); err != nil {
+		t.Fatalf("DeleteStatusDeadLetter: %v", err)
+	}
+	for _, fn := range []func() error{
+		func() error { _, err := store.GetStatusDeadLetter(letters[0].ID); return err },
+		func() error { return store.DeleteStatusDeadLetter(letters[0].ID) },
+	} {
+		var se *serviceerrors.ServiceError
+		if err := fn(); err == nil || !errors.As(err, &se) || se.MessageCode() != messages.ResourceNotFound {
+			t.Errorf("expected not found for a deleted dead letter, got %v", err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package sql
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// updateBenchmarkDependencies follows the status of a benchmark that was just stored through
+// to the benchmarks that depend on it. When it completed, the benchmarks whose dependencies
+// have now all completed are marked pending and their indices returned, to be started by the
+// caller; a benchmark is returned once only, as it has a status from then on. When it failed
+// or was cancelled, the benchmarks that depend on it, directly or not, are cancelled as they
+// can no longer run.
+func updateBenchmarkDependencies(job *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig, benchmarkIndex int) []int {
+	current := benchmarkStatusAt(job, benchmarkIndex)
+	if current == nil || !api.IsBenchmarkTerminalState(current.Status) {
+		return nil
+	}
+
+	if current.Status == api.StateCompleted {
+		var ready []int
+		for index, benchmark := range benchmarks {
+			if !slices.Contains(benchmark.DependsOn, benchmarkIndex) || benchmarkStatusAt(job, index) != nil {
+				continue
+			}
+			completed := true
+			for _, dependency := range benchmark.DependsOn {
+				if status := benchmarkStatusAt(job, dependency); status == nil || status.Status != api.StateCompleted {
+					completed = false
+					break
+				}
+			}
+			if !completed {
+				continue
+			}
+			job.Status.Benchmarks = append(job.Status.Benchmarks, api.BenchmarkStatus{
+				ProviderID:     benchmark.ProviderID,
+				ID:             benchmark.ID,
+				BenchmarkIndex: index,
+				Status:         api.StatePending,
+			})
+			ready = append(ready, index)
+		}
+		return ready
+	}
+
+	// cancel the benchmarks that can no longer run, and the benchmarks waiting for those
+	now := api.DateTimeToString(time.Now())
+	blocked := []int{benchmarkIndex}
+	for len(blocked) > 0 {
+		dependency := blocked[0]
+		blocked = blocked[1:]
+		for index, benchmark := range benchmarks {
+			if !slices.Contains(benchmark.DependsOn, dependency) || benchmarkStatusAt(job, index) != nil {
+				continue
+			}
+			job.Status.Benchmarks = append(job.Status.Benchmarks, api.BenchmarkStatus{
+				ProviderID:     benchmark.ProviderID,
+				ID:             benchmark.ID,
+				BenchmarkIndex: index,
+				Status:         api.StateCancelled,
+				ErrorMessage: api.WithMessageOrigin(&api.MessageInfo{
+					Message:     fmt.Sprintf("Benchmark %s was not run because benchmark %s at index %d did not complete", benchmark.ID, benchmarks[dependency].ID, dependency),
+					MessageCode: constants.MESSAGE_CODE_BENCHMARK_DEPENDENCY_FAILED,
+				}, api.MessageOriginServer),
+				CompletedAt: now,
+			})
+			blocked = append(blocked, index)
+		}
+	}
+	return nil
+}
+
+func benchmarkStatusAt(job *api.EvaluationJobResource, benchmarkIndex int) *api.BenchmarkStatus {
+	if job.Status == nil {
+		return nil
+	}
+	for i, benchmark := range job.Status.Benchmarks {
+		if benchmark.BenchmarkIndex == benchmarkIndex {
+			return &job.Status.Benchmarks[i]
+		}
+	}
+	return nil
+}
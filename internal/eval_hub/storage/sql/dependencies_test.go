@@ -0,0 +1,117 @@
+package sql_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestUpdateEvaluationJob_BenchmarkDependencies(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	// generate -> judge -> report
+	createJob := func(t *testing.T) string {
+		t.Helper()
+		now := time.Now()
+		jobID := common.GUID()
+		job := &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{
+				Resource: api.Resource{ID: jobID, Tenant: api.Tenant("tenant-dependencies"), CreatedAt: now, UpdatedAt: now},
+			},
+			Status: &api.EvaluationJobStatus{
+				EvaluationJobState: api.EvaluationJobState{
+					State:   api.OverallStatePending,
+					Message: &api.MessageInfo{Message: "Job is pending", MessageCode: "JOB_PENDING"},
+				},
+			},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Model: api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+				Benchmarks: []api.EvaluationBenchmarkConfig{
+					{Ref: api.Ref{ID: "generate"}, ProviderID: "garak"},
+					{Ref: api.Ref{ID: "judge"}, ProviderID: "garak", DependsOn: []int{0}},
+					{Ref: api.Ref{ID: "report"}, ProviderID: "garak", DependsOn: []int{1}},
+				},
+			},
+		}
+		if err := store.CreateEvaluationJob(job); err != nil {
+			t.Fatalf("Failed to create job: %v", err)
+		}
+		return jobID
+	}
+	updateBenchmark := func(t *testing.T, jobID string, index int, id string, status api.State) *abstractions.EvaluationJobUpdate {
+		t.Helper()
+		event := &api.StatusEvent{
+			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+				ProviderID:     "garak",
+				ID:             id,
+				BenchmarkIndex: index,
+				Status:         status,
+				Artifacts:      map[string]any{"dataset": "s3://bucket/" + id},
+			},
+		}
+		update, err := store.UpdateEvaluationJob(jobID, event)
+		if err != nil {
+			t.Fatalf("Failed to update benchmark %s: %v", id, err)
+		}
+		return update
+	}
+	statusOf := func(t *testing.T, jobID string, index int) *api.BenchmarkStatus {
+		t.Helper()
+		job, err := store.GetEvaluationJob(jobID)
+		if err != nil {
+			t.Fatalf("Failed to get job: %v", err)
+		}
+		for i, benchmark := range job.Status.Benchmarks {
+			if benchmark.BenchmarkIndex == index {
+				return &job.Status.Benchmarks[i]
+			}
+		}
+		return nil
+	}
+
+	t.Run("a benchmark is ready once its dependencies complete", func(t *testing.T) {
+		jobID := createJob(t)
+		if update := updateBenchmark(t, jobID, 0, "generate", api.StateRunning); len(update.ReadyBenchmarks) != 0 {
+			t.Fatalf("Expected no benchmark to be ready while generate runs, got %v", update.ReadyBenchmarks)
+		}
+		update := updateBenchmark(t, jobID, 0, "generate", api.StateCompleted)
+		if !slices.Equal(update.ReadyBenchmarks, []int{1}) {
+			t.Fatalf("Expected judge to be ready, got %v", update.ReadyBenchmarks)
+		}
+		if status := statusOf(t, jobID, 1); status == nil || status.Status != api.StatePending {
+			t.Fatalf("Expected judge to be pending, got %+v", status)
+		}
+		if status := statusOf(t, jobID, 2); status != nil {
+			t.Fatalf("Expected report to wait for judge, got %+v", status)
+		}
+
+		// a repeated event does not start the benchmark again
+		if update := updateBenchmark(t, jobID, 0, "generate", api.StateCompleted); len(update.ReadyBenchmarks) != 0 {
+			t.Fatalf("Expected judge to be ready only once, got %v", update.ReadyBenchmarks)
+		}
+	})
+
+	t.Run("the dependents of a failed benchmark are cancelled", func(t *testing.T) {
+		jobID := createJob(t)
+		if update := updateBenchmark(t, jobID, 0, "generate", api.StateFailed); len(update.ReadyBenchmarks) != 0 {
+			t.Fatalf("Expected no benchmark to be ready, got %v", update.ReadyBenchmarks)
+		}
+		for index := 1; index <= 2; index++ {
+			status := statusOf(t, jobID, index)
+			if status == nil || status.Status != api.StateCancelled || status.ErrorMessage == nil {
+				t.Fatalf("Expected benchmark %d to be cancelled, got %+v", index, status)
+			}
+		}
+		job, _ := store.GetEvaluationJob(jobID)
+		if job.Status.State != api.OverallStatePartiallyFailed {
+			t.Fatalf("Expected the job to finish, got %s", job.Status.State)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package sql
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/eta"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	se "github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+//#######################################################################
+// Benchmark duration operations
+//#######################################################################
+
+// recordBenchmarkDuration adds the duration of the completed run of the benchmark of the
+// event to the durations of the benchmark in the tenant of the job. A run without a start
+// time, e.g. a cached result, has no duration.
+func (s *sqlStorage) recordBenchmarkDuration(txn *sql.Tx, job *api.EvaluationJobResource, benchmark *api.EvaluationBenchmarkConfig, event *api.BenchmarkStatusEvent) error {
+	startedAt, err := api.DateTimeFromString(event.StartedAt)
+	if err != nil || event.CachedFrom != "" {
+		return nil
+	}
+	completedAt := time.Now()
+	if event.CompletedAt != "" {
+		if parsed, err := api.DateTimeFromString(event.CompletedAt); err == nil {
+			completedAt = parsed
+		}
+	}
+	seconds := completedAt.Sub(startedAt).Seconds()
+	if seconds < 0 {
+		return nil
+	}
+	key := eta.Key(benchmark)
+	statement, args := s.statementsFactory.CreateBenchmarkDurationRecordStatement(job.Resource.Tenant, key.ProviderID, key.BenchmarkID, key.ExamplesBucket, seconds)
+	if _, err := s.exec(txn, statement, args...); err != nil {
+		s.logger.Error("Failed to record the duration of the benchmark", "error", err, "id", job.Resource.ID, "benchmark_index", event.BenchmarkIndex)
+		return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "benchmark duration", "ResourceId", job.Resource.ID, "Error", err.Error()))
+	}
+	return nil
+}
+
+func (s *sqlStorage) GetBenchmarkDurations(keys []abstractions.BenchmarkDurationKey) (map[abstractions.BenchmarkDurationKey]time.Duration, error) {
+	durations := map[abstractions.BenchmarkDurationKey]time.Duration{}
+	for _, key := range keys {
+		if _, ok := durations[key]; ok {
+			continue
+		}
+		statement, args := s.statementsFactory.CreateBenchmarkDurationGetStatement(s.tenant, key.ProviderID, key.BenchmarkID, key.ExamplesBucket)
+		var runs int
+		var totalSeconds float64
+		if err := s.queryRow(nil, statement, args...).Scan(&runs, &totalSeconds); err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+			s.logger.Error("Failed to get the duration of the benchmark", "error", err, "provider_id", key.ProviderID, "benchmark_id", key.BenchmarkID)
+			return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "benchmark duration", "ResourceId", key.BenchmarkID, "Error", err.Error())
+		}
+		if runs > 0 {
+			durations[key] = time.Duration(totalSeconds / float64(runs) * float64(time.Second))
+		}
+	}
+	return durations, nil
+}
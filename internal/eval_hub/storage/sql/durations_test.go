@@ -0,0 +1,78 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestUpdateEvaluationJob_RecordsBenchmarkDurations(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-durations")
+	store = store.WithTenant(tenant)
+	if err := store.CreateProvider(&api.ProviderResource{
+		Resource: api.Resource{ID: "lm_evaluation_harness", Tenant: tenant, CreatedAt: time.Now()},
+		ProviderConfig: api.ProviderConfig{
+			Name:       "LM Evaluation Harness",
+			Benchmarks: []api.BenchmarkResource{{ID: "mmlu"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateProvider: %v", err)
+	}
+
+	startedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	run := func(duration time.Duration, redeliver bool) {
+		t.Helper()
+		jobID := common.GUID()
+		if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, CreatedAt: time.Now()}},
+			Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Model: api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+				Benchmarks: []api.EvaluationBenchmarkConfig{
+					{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness", Parameters: map[string]any{"num_examples": 100}},
+				},
+			},
+		}); err != nil {
+			t.Fatalf("CreateEvaluationJob: %v", err)
+		}
+		completed := &api.BenchmarkStatusEvent{
+			ProviderID:  "lm_evaluation_harness",
+			ID:          "mmlu",
+			Status:      api.StateCompleted,
+			StartedAt:   api.DateTimeToString(startedAt),
+			CompletedAt: api.DateTimeToString(startedAt.Add(duration)),
+			Metrics:     map[string]any{"acc": 0.5},
+		}
+		if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: completed}); err != nil {
+			t.Fatalf("UpdateEvaluationJob: %v", err)
+		}
+		if redeliver {
+			// the job is finished, the redelivered event is rejected and not recorded
+			_, _ = store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: completed})
+		}
+	}
+	run(10*time.Minute, true)
+	run(20*time.Minute, false)
+
+	key := abstractions.BenchmarkDurationKey{ProviderID: "lm_evaluation_harness", BenchmarkID: "mmlu", ExamplesBucket: 100}
+	unknown := abstractions.BenchmarkDurationKey{ProviderID: "lm_evaluation_harness", BenchmarkID: "mmlu"}
+	durations, err := store.GetBenchmarkDurations([]abstractions.BenchmarkDurationKey{key, unknown})
+	if err != nil {
+		t.Fatalf("GetBenchmarkDurations: %v", err)
+	}
+	if len(durations) != 1 || durations[key] != 15*time.Minute {
+		t.Errorf("expected the mean duration of the 2 runs, got %v", durations)
+	}
+
+	other, err := store.WithTenant("tenant-other").GetBenchmarkDurations([]abstractions.BenchmarkDurationKey{key})
+	if err != nil || len(other) != 0 {
+		t.Errorf("expected no duration for another tenant, got %v, %v", other, err)
+	}
+}
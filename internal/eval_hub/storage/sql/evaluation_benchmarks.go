@@ -0,0 +1,269 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	se "github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The status and the result of each benchmark of a job are stored in a row of the
+// evaluation_benchmarks table of their own, rather than in the entity of the job, so that a
+// status event writes the row of its benchmark only and the overall state of the job is
+// counted in SQL. The entity of a job written by an older version still embeds the statuses
+// and results of its benchmarks: they are read as they are, and moved to the table the next
+// time the job is written.
+
+// benchmarkRow is the status and, once the benchmark finished, the result of a benchmark of
+// a job, as they are stored.
+type benchmarkRow struct {
+	entity string
+	result string
+}
+
+// storedBenchmarks are the rows of the benchmarks of a job by benchmark index.
+type storedBenchmarks map[int]benchmarkRow
+
+func (s *sqlStorage) readBenchmarkRows(txn *sql.Tx, jobIDs []string, benchmarkIndex *int) (map[string]storedBenchmarks, error) {
+	stored := make(map[string]storedBenchmarks, len(jobIDs))
+	if len(jobIDs) == 0 {
+		return stored, nil
+	}
+	selectQuery, args := s.statementsFactory.CreateEvaluationBenchmarksGetStatement(jobIDs, benchmarkIndex)
+	rows, err := s.query(txn, selectQuery, args...)
+	if err != nil {
+		s.logger.Error("Failed to read the benchmarks of evaluation jobs", "error", err, "ids", jobIDs)
+		return nil, se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job benchmarks", "ResourceId", jobIDs[0], "Error", err.Error()))
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var jobID string
+		var index int
+		var entity string
+		var result sql.NullString
+		if err := rows.Scan(&jobID, &index, &entity, &result); err != nil {
+			s.logger.Error("Failed to scan the benchmark of an evaluation job", "error", err)
+			return nil, se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job benchmarks", "ResourceId", jobID, "Error", err.Error()))
+		}
+		if stored[jobID] == nil {
+			stored[jobID] = storedBenchmarks{}
+		}
+		stored[jobID][index] = benchmarkRow{entity: entity, result: result.String}
+	}
+	if err := rows.Err(); err != nil {
+		s.logger.Error("Error iterating the benchmarks of evaluation jobs", "error", err)
+		return nil, se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job benchmarks", "ResourceId", jobIDs[0], "Error", err.Error()))
+	}
+	return stored, nil
+}
+
+// readJobBenchmarks reads the rows of the benchmarks of the job, or of the benchmark at
+// benchmarkIndex only, into the job and returns them.
+func (s *sqlStorage) readJobBenchmarks(txn *sql.Tx, job *api.EvaluationJobResource, benchmarkIndex *int) (storedBenchmarks, error) {
+	stored, err := s.readBenchmarkRows(txn, []string{job.Resource.ID}, benchmarkIndex)
+	if err != nil {
+		return nil, err
+	}
+	rows := stored[job.Resource.ID]
+	if rows == nil {
+		rows = storedBenchmarks{}
+	}
+	if err := mergeBenchmarkRows(job, rows); err != nil {
+		return nil, se.WithRollback(err)
+	}
+	return rows, nil
+}
+
+// readListedJobBenchmarks reads the rows of the benchmarks of the jobs of a page into them.
+func (s *sqlStorage) readListedJobBenchmarks(txn *sql.Tx, jobs []api.EvaluationJobResource) error {
+	ids := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		ids = append(ids, job.Resource.ID)
+	}
+	stored, err := s.readBenchmarkRows(txn, ids, nil)
+	if err != nil {
+		return err
+	}
+	for i := range jobs {
+		if err := mergeBenchmarkRows(&jobs[i], stored[jobs[i].Resource.ID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeBenchmarkRows sets the statuses and results of the rows on the job, replacing the
+// ones with the same benchmark index.
+func mergeBenchmarkRows(job *api.EvaluationJobResource, rows storedBenchmarks) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	if job.Status == nil {
+		job.Status = &api.EvaluationJobStatus{}
+	}
+	for _, index := range slices.Sorted(maps.Keys(rows)) {
+		row := rows[index]
+		var status api.BenchmarkStatus
+		if err := json.Unmarshal([]byte(row.entity), &status); err != nil {
+			return se.NewServiceError(messages.JSONUnmarshalFailed, "Type", "evaluation job benchmark", "Error", err.Error())
+		}
+		if status.Status != "" {
+			i := slices.IndexFunc(job.Status.Benchmarks, func(b api.BenchmarkStatus) bool { return b.BenchmarkIndex == index })
+			if i < 0 {
+				job.Status.Benchmarks = append(job.Status.Benchmarks, status)
+			} else {
+				job.Status.Benchmarks[i] = status
+			}
+		}
+		if row.result == "" {
+			continue
+		}
+		var result api.BenchmarkResult
+		if err := json.Unmarshal([]byte(row.result), &result); err != nil {
+			return se.NewServiceError(messages.JSONUnmarshalFailed, "Type", "evaluation job benchmark", "Error", err.Error())
+		}
+		if job.Results == nil {
+			job.Results = &api.EvaluationJobResults{}
+		}
+		i := slices.IndexFunc(job.Results.Benchmarks, func(b api.BenchmarkResult) bool { return b.BenchmarkIndex == index })
+		if i < 0 {
+			job.Results.Benchmarks = append(job.Results.Benchmarks, result)
+		} else {
+			job.Results.Benchmarks[i] = result
+		}
+	}
+	rollupFindings(job)
+	return nil
+}
+
+// benchmarkRowsOf returns the rows of the statuses and results of the benchmarks of the job.
+func benchmarkRowsOf(job *api.EvaluationJobResource) (storedBenchmarks, map[int]api.State, error) {
+	rows := storedBenchmarks{}
+	states := map[int]api.State{}
+	if job.Status != nil {
+		for _, status := range job.Status.Benchmarks {
+			entity, err := json.Marshal(status)
+			if err != nil {
+				return nil, nil, se.NewServiceError(messages.InternalServerError, "Error", err.Error())
+			}
+			rows[status.BenchmarkIndex] = benchmarkRow{entity: string(entity)}
+			states[status.BenchmarkIndex] = status.Status
+		}
+	}
+	if job.Results != nil {
+		for _, result := range job.Results.Benchmarks {
+			resultJSON, err := json.Marshal(result)
+			if err != nil {
+				return nil, nil, se.NewServiceError(messages.InternalServerError, "Error", err.Error())
+			}
+			row := rows[result.BenchmarkIndex]
+			if row.entity == "" {
+				// a result without a status, the status is written empty and not read back
+				row.entity = "{}"
+			}
+			row.result = string(resultJSON)
+			rows[result.BenchmarkIndex] = row
+		}
+	}
+	return rows, states, nil
+}
+
+// writeChangedBenchmarks writes the rows of the benchmarks of the job that differ from the
+// stored ones, and records them as stored.
+func (s *sqlStorage) writeChangedBenchmarks(txn *sql.Tx, id string, job *api.EvaluationJobResource, stored storedBenchmarks) error {
+	rows, states, err := benchmarkRowsOf(job)
+	if err != nil {
+		return se.WithRollback(err)
+	}
+	for _, index := range slices.Sorted(maps.Keys(rows)) {
+		row := rows[index]
+		if current, ok := stored[index]; ok && jsonEqual(current.entity, row.entity) && jsonEqual(current.result, row.result) {
+			continue
+		}
+		var result *string
+		if row.result != "" {
+			result = &row.result
+		}
+		upsertQuery, args := s.statementsFactory.CreateEvaluationBenchmarkUpsertStatement(id, index, states[index], row.entity, result)
+		if _, err := s.exec(txn, upsertQuery, args...); err != nil {
+			s.logger.Error("Failed to write the benchmark of evaluation job", "error", err, "id", id, "benchmark_index", index)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job benchmark", "ResourceId", id, "Error", err.Error()))
+		}
+		if stored != nil {
+			stored[index] = row
+		}
+	}
+	return nil
+}
+
+// jsonEqual compares two JSON documents, the ones read from a JSONB column are formatted
+// by the database.
+func jsonEqual(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if a == "" || b == "" {
+		return false
+	}
+	var va, vb any
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// countBenchmarkStates returns the number of benchmarks of the job in each state.
+func (s *sqlStorage) countBenchmarkStates(txn *sql.Tx, id string) (map[api.State]int, error) {
+	countQuery, args := s.statementsFactory.CreateEvaluationBenchmarkStatesStatement(id)
+	rows, err := s.query(txn, countQuery, args...)
+	if err != nil {
+		s.logger.Error("Failed to count the benchmark states of evaluation job", "error", err, "id", id)
+		return nil, se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job benchmarks", "ResourceId", id, "Error", err.Error()))
+	}
+	defer func() { _ = rows.Close() }()
+	states := map[api.State]int{}
+	for rows.Next() {
+		var state api.State
+		var count int
+		if err := rows.Scan(&state, &count); err != nil {
+			return nil, se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job benchmarks", "ResourceId", id, "Error", err.Error()))
+		}
+		states[state] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job benchmarks", "ResourceId", id, "Error", err.Error()))
+	}
+	return states, nil
+}
+
+// hasEmbeddedBenchmarks reports whether the entity of the job, as read, still holds the
+// statuses or results of its benchmarks.
+func hasEmbeddedBenchmarks(job *api.EvaluationJobResource) bool {
+	return (job.Status != nil && len(job.Status.Benchmarks) > 0) || (job.Results != nil && len(job.Results.Benchmarks) > 0)
+}
+
+// entityWithoutBenchmarks returns the entity of the job without the statuses and results of
+// its benchmarks, which are stored in their own rows.
+func entityWithoutBenchmarks(job *api.EvaluationJobResource) *EvaluationJobEntity {
+	entity := &EvaluationJobEntity{Config: &job.EvaluationJobConfig}
+	if job.Status != nil {
+		status := *job.Status
+		status.Benchmarks = nil
+		entity.Status = &status
+	}
+	if job.Results != nil {
+		results := *job.Results
+		results.Benchmarks = nil
+		// rolled up from the results of the benchmarks when they are read
+		results.Findings = nil
+		if results.Test != nil || results.MLFlowExperimentURL != "" || results.Model != nil {
+			entity.Results = &results
+		}
+	}
+	return entity
+}
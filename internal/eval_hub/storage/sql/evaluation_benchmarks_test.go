@@ -0,0 +1,167 @@
+package sql_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func createBenchmarkRowsTestJob(t *testing.T, store abstractions.Storage) string {
+	t.Helper()
+	now := time.Now()
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{
+			Resource: api.Resource{ID: jobID, Tenant: api.Tenant(getTenant("team-a")), CreatedAt: now, UpdatedAt: now},
+		},
+		Status: &api.EvaluationJobStatus{
+			EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePending},
+		},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model: api.ModelRef{URL: "http://test.com", Name: "test"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{
+				{Ref: api.Ref{ID: "b1"}, ProviderID: "prov1"},
+				{Ref: api.Ref{ID: "b2"}, ProviderID: "prov1"},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+	return jobID
+}
+
+func updateBenchmarkRowsTestJob(t *testing.T, store abstractions.Storage, jobID string, id string, index int, status api.State) {
+	t.Helper()
+	event := &api.BenchmarkStatusEvent{ID: id, ProviderID: "prov1", BenchmarkIndex: index, Status: status}
+	if status == api.StateCompleted {
+		event.Metrics = map[string]any{"acc": 0.9}
+	}
+	if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: event}); err != nil {
+		t.Fatalf("UpdateEvaluationJob(%s, %s): %v", id, status, err)
+	}
+}
+
+func storedEvaluationEntity(t *testing.T, store abstractions.Storage, jobID string) sql.EvaluationJobEntity {
+	t.Helper()
+	entityJSON, err := sql.QueryString(store, "SELECT entity FROM evaluations WHERE id = ?", jobID)
+	if err != nil {
+		t.Fatalf("read the entity of the job: %v", err)
+	}
+	var entity sql.EvaluationJobEntity
+	if err := json.Unmarshal([]byte(entityJSON), &entity); err != nil {
+		t.Fatalf("decode the entity of the job: %v", err)
+	}
+	return entity
+}
+
+func benchmarkRowCount(t *testing.T, store abstractions.Storage, jobID string) int {
+	t.Helper()
+	count, err := sql.QueryInt(store, "SELECT COUNT(*) FROM evaluation_benchmarks WHERE job_id = ?", jobID)
+	if err != nil {
+		t.Fatalf("count the benchmark rows of the job: %v", err)
+	}
+	return count
+}
+
+func TestUpdateEvaluationJob_StoresBenchmarksInTheirRows(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	jobID := createBenchmarkRowsTestJob(t, store)
+
+	updateBenchmarkRowsTestJob(t, store, jobID, "b1", 0, api.StateRunning)
+	updateBenchmarkRowsTestJob(t, store, jobID, "b1", 0, api.StateCompleted)
+	updateBenchmarkRowsTestJob(t, store, jobID, "b2", 1, api.StateRunning)
+
+	if entity := storedEvaluationEntity(t, store, jobID); len(entity.Status.Benchmarks) != 0 || entity.Results != nil {
+		t.Fatalf("expected the entity of the job not to hold the benchmarks, got %+v %+v", entity.Status, entity.Results)
+	}
+	if count := benchmarkRowCount(t, store, jobID); count != 2 {
+		t.Fatalf("expected a row for each benchmark, got %d", count)
+	}
+
+	job, err := store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if job.Status.State != api.OverallStateRunning || len(job.Status.Benchmarks) != 2 || job.Status.Benchmarks[0].Status != api.StateCompleted || job.Status.Benchmarks[1].Status != api.StateRunning {
+		t.Fatalf("unexpected job status %+v", job.Status)
+	}
+	if job.Results == nil || len(job.Results.Benchmarks) != 1 || job.Results.Benchmarks[0].Metrics["acc"] != 0.9 {
+		t.Fatalf("unexpected job results %+v", job.Results)
+	}
+	jobs, err := store.GetEvaluationJobs(&abstractions.QueryFilter{Limit: 10, Params: map[string]any{}})
+	if err != nil {
+		t.Fatalf("GetEvaluationJobs: %v", err)
+	}
+	if len(jobs.Items) != 1 || len(jobs.Items[0].Status.Benchmarks) != 2 || len(jobs.Items[0].Results.Benchmarks) != 1 {
+		t.Fatalf("expected the listed job to have its benchmarks, got %+v", jobs.Items)
+	}
+
+	updateBenchmarkRowsTestJob(t, store, jobID, "b2", 1, api.StateCompleted)
+	job, err = store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if job.Status.State != api.OverallStateCompleted || len(job.Results.Benchmarks) != 2 {
+		t.Fatalf("expected the job to complete with the results of both benchmarks, got %+v %+v", job.Status, job.Results)
+	}
+
+	if err := store.DeleteEvaluationJob(jobID); err != nil {
+		t.Fatalf("DeleteEvaluationJob: %v", err)
+	}
+	if count := benchmarkRowCount(t, store, jobID); count != 0 {
+		t.Fatalf("expected the benchmark rows to be deleted with the job, got %d", count)
+	}
+}
+
+func TestUpdateEvaluationJob_EmbeddedBenchmarks(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	jobID := createBenchmarkRowsTestJob(t, store)
+
+	// the entity of the job as an older version wrote it, with the benchmarks in it
+	entity := storedEvaluationEntity(t, store, jobID)
+	entity.Status.State = api.OverallStateRunning
+	entity.Status.Benchmarks = []api.BenchmarkStatus{{ID: "b1", ProviderID: "prov1", BenchmarkIndex: 0, Status: api.StateCompleted}}
+	entity.Results = &api.EvaluationJobResults{Benchmarks: []api.BenchmarkResult{{ID: "b1", ProviderID: "prov1", BenchmarkIndex: 0, Metrics: map[string]any{"acc": 0.8}}}}
+	entityJSON, err := json.Marshal(entity)
+	if err != nil {
+		t.Fatalf("encode the entity: %v", err)
+	}
+	if err := sql.ExecSQL(store, "UPDATE evaluations SET entity = ?, status = ? WHERE id = ?", string(entityJSON), api.OverallStateRunning, jobID); err != nil {
+		t.Fatalf("write the entity: %v", err)
+	}
+
+	job, err := store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if len(job.Status.Benchmarks) != 1 || job.Status.Benchmarks[0].Status != api.StateCompleted || len(job.Results.Benchmarks) != 1 {
+		t.Fatalf("expected the embedded benchmarks to be read, got %+v %+v", job.Status, job.Results)
+	}
+
+	updateBenchmarkRowsTestJob(t, store, jobID, "b2", 1, api.StateCompleted)
+
+	job, err = store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if job.Status.State != api.OverallStateCompleted || len(job.Results.Benchmarks) != 2 || job.Results.Benchmarks[0].Metrics["acc"] != 0.8 {
+		t.Fatalf("expected the job to complete with the embedded result, got %+v %+v", job.Status, job.Results)
+	}
+	if entity := storedEvaluationEntity(t, store, jobID); len(entity.Status.Benchmarks) != 0 || entity.Results != nil {
+		t.Fatalf("expected the embedded benchmarks to move to their rows, got %+v %+v", entity.Status, entity.Results)
+	}
+	if count := benchmarkRowCount(t, store, jobID); count != 2 {
+		t.Fatalf("expected a row for each benchmark, got %d", count)
+	}
+}
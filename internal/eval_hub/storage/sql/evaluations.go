@@ -3,13 +3,18 @@ package sql
 import (
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/jobstate"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/scoring"
 	se "github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql/shared"
 	"github.com/eval-hub/eval-hub/pkg/api"
@@ -35,18 +40,17 @@ func (s *sqlStorage) CreateEvaluationJob(evaluation *api.EvaluationJobResource)
 		if err != nil {
 			return se.WithRollback(err)
 		}
+		// the benchmarks of a job that is created with them, e.g. when it is imported
+		if err := s.writeChangedBenchmarks(txn, evaluation.Resource.ID, evaluation, nil); err != nil {
+			return err
+		}
 		s.logger.Info("Created evaluation job", "id", evaluation.Resource.ID, "addEntityStatement", addEntityStatement)
 		return nil
 	})
 }
 
 func (s *sqlStorage) createEvaluationJobEntity(evaluation *api.EvaluationJobResource) ([]byte, error) {
-	evaluationEntity := &EvaluationJobEntity{
-		Config:  &evaluation.EvaluationJobConfig,
-		Status:  evaluation.Status,
-		Results: evaluation.Results,
-	}
-	evaluationJSON, err := json.Marshal(evaluationEntity)
+	evaluationJSON, err := json.Marshal(entityWithoutBenchmarks(evaluation))
 	if err != nil {
 		return nil, se.NewServiceError(messages.InternalServerError, "Error", err.Error())
 	}
@@ -58,11 +62,28 @@ func (s *sqlStorage) GetEvaluationJob(id string) (*api.EvaluationJobResource, er
 }
 
 func (s *sqlStorage) getEvaluationJobTransactional(txn *sql.Tx, id string) (*api.EvaluationJobResource, error) {
-	return s.scanEvaluationJobTransactional(txn, id, false)
+	job, err := s.scanEvaluationJobTransactional(txn, id, false)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.readJobBenchmarks(txn, job, nil); err != nil {
+		return nil, err
+	}
+	return job, nil
 }
 
-func (s *sqlStorage) getEvaluationJobTransactionalForUpdate(txn *sql.Tx, id string) (*api.EvaluationJobResource, error) {
-	return s.scanEvaluationJobTransactional(txn, id, true)
+// getEvaluationJobTransactionalForUpdate locks the job and returns it with the rows of its
+// benchmarks as they are stored, to write back the ones that change.
+func (s *sqlStorage) getEvaluationJobTransactionalForUpdate(txn *sql.Tx, id string) (*api.EvaluationJobResource, storedBenchmarks, error) {
+	job, err := s.scanEvaluationJobTransactional(txn, id, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	stored, err := s.readJobBenchmarks(txn, job, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return job, stored, nil
 }
 
 func (s *sqlStorage) scanEvaluationJobTransactional(txn *sql.Tx, id string, forUpdate bool) (*api.EvaluationJobResource, error) {
@@ -105,45 +126,127 @@ func (s *sqlStorage) GetEvaluationJobs(filter *abstractions.QueryFilter) (*abstr
 }
 
 func (s *sqlStorage) DeleteEvaluationJob(id string) error {
-	// Build the DELETE query
-	deleteQuery, args := s.statementsFactory.CreateDeleteEntityStatement(s.tenant, shared.TABLE_EVALUATIONS, id)
+	return s.withTransaction("delete evaluation job", id, func(txn *sql.Tx) error {
+		// Build the DELETE query
+		deleteQuery, args := s.statementsFactory.CreateDeleteEntityStatement(s.tenant, shared.TABLE_EVALUATIONS, id)
 
-	// Execute the DELETE query
-	result, err := s.exec(nil, deleteQuery, args...)
-	if err != nil {
-		if err == sql.ErrNoRows {
+		// Execute the DELETE query
+		result, err := s.exec(txn, deleteQuery, args...)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				s.logger.Debug("Evaluation job not found", "id", id)
+				return se.NewServiceError(messages.ResourceNotFound, "Type", "evaluation job", "ResourceId", id)
+			}
+			s.logger.Error("Failed to delete evaluation job", "error", err, "id", id)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error()))
+		}
+		if rows, err := result.RowsAffected(); ((err == nil) && (rows == 0)) || (err == sql.ErrNoRows) {
 			s.logger.Debug("Evaluation job not found", "id", id)
 			return se.NewServiceError(messages.ResourceNotFound, "Type", "evaluation job", "ResourceId", id)
 		}
-		s.logger.Error("Failed to delete evaluation job", "error", err, "id", id)
-		return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error()))
-	}
-	if rows, err := result.RowsAffected(); ((err == nil) && (rows == 0)) || (err == sql.ErrNoRows) {
-		s.logger.Debug("Evaluation job not found", "id", id)
-		return se.NewServiceError(messages.ResourceNotFound, "Type", "evaluation job", "ResourceId", id)
-	}
 
-	s.logger.Info("Deleted evaluation job", "id", id)
+		deleteBenchmarksQuery, args := s.statementsFactory.CreateEvaluationBenchmarksDeleteStatement(id)
+		if _, err := s.exec(txn, deleteBenchmarksQuery, args...); err != nil {
+			s.logger.Error("Failed to delete the benchmarks of evaluation job", "error", err, "id", id)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error()))
+		}
 
-	return nil
+		deleteFindingsQuery, args := s.statementsFactory.CreateEvaluationFindingsDeleteStatement(id, nil, shared.NoShard)
+		if _, err := s.exec(txn, deleteFindingsQuery, args...); err != nil {
+			s.logger.Error("Failed to delete the findings of evaluation job", "error", err, "id", id)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error()))
+		}
+
+		deleteMetricsQuery, args := s.statementsFactory.CreateEvaluationMetricsDeleteStatement(id, nil)
+		if _, err := s.exec(txn, deleteMetricsQuery, args...); err != nil {
+			s.logger.Error("Failed to delete the metrics of evaluation job", "error", err, "id", id)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error()))
+		}
+
+		// the artifacts themselves are kept in the artifact store with the experiment
+		deleteArtifactsQuery, args := s.statementsFactory.CreateEvaluationArtifactsDeleteStatement(id)
+		if _, err := s.exec(txn, deleteArtifactsQuery, args...); err != nil {
+			s.logger.Error("Failed to delete the artifacts of evaluation job", "error", err, "id", id)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error()))
+		}
+
+		// the encrypted generations are kept in the artifact store with the experiment
+		deleteGenerationsQuery, args := s.statementsFactory.CreateEvaluationGenerationsDeleteStatement(id)
+		if _, err := s.exec(txn, deleteGenerationsQuery, args...); err != nil {
+			s.logger.Error("Failed to delete the generations of evaluation job", "error", err, "id", id)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error()))
+		}
+
+		deleteRedactionsQuery, args := s.statementsFactory.CreateEvaluationRedactionsDeleteStatement(id)
+		if _, err := s.exec(txn, deleteRedactionsQuery, args...); err != nil {
+			s.logger.Error("Failed to delete the redactions of evaluation job", "error", err, "id", id)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error()))
+		}
+
+		deleteDeadLettersQuery, args := s.statementsFactory.CreateStatusDeadLettersDeleteStatement(id)
+		if _, err := s.exec(txn, deleteDeadLettersQuery, args...); err != nil {
+			s.logger.Error("Failed to delete the status dead letters of evaluation job", "error", err, "id", id)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error()))
+		}
+
+		s.logger.Info("Deleted evaluation job", "id", id)
+
+		return nil
+	})
 }
 
-func (s *sqlStorage) checkEvaluationJobState(evaluationJobID string, evaluationJobState api.OverallState, state api.OverallState) (bool, error) {
-	// check if the state is unchanged
-	if state == evaluationJobState {
-		// if the state is the same as the current state then we don't need to update the status
-		// we don't treat this as an error for now, we just return 204
-		return true, nil
-	}
+func (s *sqlStorage) PatchEvaluationJob(id string, patches *api.Patch) (*api.EvaluationJobResource, error) {
+	var updated *api.EvaluationJobResource
 
-	// check if the job is in a final state
-	switch evaluationJobState {
-	case api.OverallStateCancelled, api.OverallStateCompleted, api.OverallStateFailed, api.OverallStatePartiallyFailed:
-		// the job is already in a final state, so we can't update the status
-		return false, se.NewServiceError(messages.JobCanNotBeUpdated, "Id", evaluationJobID, "NewStatus", state, "Status", evaluationJobState)
-	}
+	err := s.withTransaction("patch evaluation job", id, func(txn *sql.Tx) error {
+		evaluationJob, stored, err := s.getEvaluationJobTransactionalForUpdate(txn, id)
+		if err != nil {
+			return err
+		}
+		configJSON, err := json.Marshal(evaluationJob.EvaluationJobConfig)
+		if err != nil {
+			return se.WithRollback(se.NewServiceError(messages.InternalServerError, "Error", err.Error()))
+		}
+		patchedJSON, err := applyPatches(string(configJSON), patches)
+		if err != nil {
+			return se.WithRollback(se.NewServiceError(messages.InvalidJSONRequest, "Error", err.Error()))
+		}
+		var config api.EvaluationJobConfig
+		if err := json.Unmarshal(patchedJSON, &config); err != nil {
+			return se.WithRollback(se.NewServiceError(messages.InvalidJSONRequest, "Error", err.Error()))
+		}
+		evaluationJob.EvaluationJobConfig = config
+		if err := s.updateEvaluationJobTxn(txn, id, evaluationJob.Status.State, evaluationJob, stored); err != nil {
+			return err
+		}
+		updated, err = s.getEvaluationJobTransactional(txn, id)
+		return err
+	})
 
-	return false, nil
+	return updated, err
+}
+
+func (s *sqlStorage) UpdateEvaluationJobOwner(id string, owner api.User) (*api.EvaluationJobResource, error) {
+	var updated *api.EvaluationJobResource
+
+	err := s.withTransaction("update evaluation job owner", id, func(txn *sql.Tx) error {
+		// lock the job so that the owner is not changed under a concurrent update
+		if _, err := s.scanEvaluationJobTransactional(txn, id, true); err != nil {
+			return err
+		}
+		updateQuery, args := s.statementsFactory.CreateUpdateEntityOwnerStatement(s.tenant, shared.TABLE_EVALUATIONS, id, owner)
+		if _, err := s.exec(txn, updateQuery, args...); err != nil {
+			s.logger.Error("Failed to update evaluation job owner", "error", err, "id", id)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error()))
+		}
+		s.logger.Info("Updated evaluation job owner", "id", id, "owner", owner)
+
+		var err error
+		updated, err = s.getEvaluationJobTransactional(txn, id)
+		return err
+	})
+
+	return updated, err
 }
 
 func messageInfosEquivalent(a, b *api.MessageInfo) bool {
@@ -156,19 +259,21 @@ func messageInfosEquivalent(a, b *api.MessageInfo) bool {
 	return a.Message == b.Message && a.MessageCode == b.MessageCode && a.MessageOrigin == b.MessageOrigin
 }
 
-func (s *sqlStorage) UpdateEvaluationJobStatus(id string, state api.OverallState, message *api.MessageInfo) error {
+func (s *sqlStorage) UpdateEvaluationJobStatus(id string, state api.OverallState, message *api.MessageInfo) (api.OverallState, error) {
 	api.WithMessageOrigin(message, api.MessageOriginServer)
 	// we have to get the evaluation job and update the status so we need a transaction
 	s.logger.Debug("Updating evaluation job status", "id", id, "state", state, "message", message)
+	var previousState api.OverallState
 	err := s.withTransaction("update evaluation job status", id, func(txn *sql.Tx) error {
 		// get the evaluation job
-		evaluationJob, err := s.getEvaluationJobTransactionalForUpdate(txn, id)
+		evaluationJob, stored, err := s.getEvaluationJobTransactionalForUpdate(txn, id)
 		if err != nil {
 			return err
 		}
 
 		// check the state
-		sameState, err := s.checkEvaluationJobState(evaluationJob.Resource.ID, evaluationJob.Status.State, state)
+		previousState = evaluationJob.Status.State
+		sameState, err := jobstate.Check(evaluationJob.Resource.ID, previousState, state)
 		if err != nil {
 			return err
 		}
@@ -176,19 +281,8 @@ func (s *sqlStorage) UpdateEvaluationJobStatus(id string, state api.OverallState
 			if message == nil || messageInfosEquivalent(evaluationJob.Status.Message, message) {
 				return nil
 			}
-			benchmarks := evaluationJob.Status.Benchmarks
-			entity := EvaluationJobEntity{
-				Config: &evaluationJob.EvaluationJobConfig,
-				Status: &api.EvaluationJobStatus{
-					EvaluationJobState: api.EvaluationJobState{
-						State:   evaluationJob.Status.State,
-						Message: message,
-					},
-					Benchmarks: benchmarks,
-				},
-				Results: evaluationJob.Results,
-			}
-			return s.updateEvaluationJobTxn(txn, id, evaluationJob.Status.State, &entity)
+			evaluationJob.Status.Message = message
+			return s.updateEvaluationJobTxn(txn, id, evaluationJob.Status.State, evaluationJob, stored)
 		}
 
 		benchmarks := evaluationJob.Status.Benchmarks
@@ -203,25 +297,29 @@ func (s *sqlStorage) UpdateEvaluationJobStatus(id string, state api.OverallState
 			}
 		}
 
-		entity := EvaluationJobEntity{
-			Config: &evaluationJob.EvaluationJobConfig,
-			Status: &api.EvaluationJobStatus{
-				EvaluationJobState: api.EvaluationJobState{
-					State:   state,
-					Message: message,
-				},
-				Benchmarks: benchmarks,
-			},
-			Results: evaluationJob.Results,
-		}
+		evaluationJob.Status.State = state
+		evaluationJob.Status.Message = message
 
-		return s.updateEvaluationJobTxn(txn, id, state, &entity)
+		return s.updateEvaluationJobTxn(txn, id, state, evaluationJob, stored)
 	})
-	return err
+	if err != nil {
+		return "", err
+	}
+	jobstate.Transitioned(s.ctx, id, previousState, state)
+	return previousState, nil
 }
 
-func (s *sqlStorage) updateEvaluationJobTxn(txn *sql.Tx, id string, status api.OverallState, evaluationJob *EvaluationJobEntity) error {
-	entityJSON, err := json.Marshal(evaluationJob)
+// updateEvaluationJobTxn writes the job: the rows of its benchmarks that differ from the
+// stored ones, and its entity.
+func (s *sqlStorage) updateEvaluationJobTxn(txn *sql.Tx, id string, status api.OverallState, evaluationJob *api.EvaluationJobResource, stored storedBenchmarks) error {
+	if err := s.writeChangedBenchmarks(txn, id, evaluationJob, stored); err != nil {
+		return err
+	}
+	return s.writeEvaluationJobEntity(txn, id, status, evaluationJob)
+}
+
+func (s *sqlStorage) writeEvaluationJobEntity(txn *sql.Tx, id string, status api.OverallState, evaluationJob *api.EvaluationJobResource) error {
+	entityJSON, err := json.Marshal(entityWithoutBenchmarks(evaluationJob))
 	if err != nil {
 		// we should never get here
 		return se.WithRollback(se.NewServiceError(messages.InternalServerError, "Error", err.Error()))
@@ -239,6 +337,19 @@ func (s *sqlStorage) updateEvaluationJobTxn(txn *sql.Tx, id string, status api.O
 	return nil
 }
 
+// writeEvaluationJobStatus writes the overall state of the job only, when its entity is unchanged.
+func (s *sqlStorage) writeEvaluationJobStatus(txn *sql.Tx, id string, status api.OverallState) error {
+	updateQuery, args := s.statementsFactory.CreateUpdateEvaluationStatusStatement(s.tenant, id, status)
+	if _, err := s.exec(txn, updateQuery, args...); err != nil {
+		s.logger.Error("Failed to update evaluation job status", "error", err, "id", id, "status", status)
+		return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error()))
+	}
+
+	s.logger.Info("Updated evaluation job status", "id", id, "status", status)
+
+	return nil
+}
+
 // validateBenchmarkExists checks that the event's benchmark is valid for the job (in job.Benchmarks or in the job's collection).
 func (s *sqlStorage) validateBenchmarkExists(job *api.EvaluationJobResource, runStatus *api.StatusEvent, collection *api.CollectionResource) error {
 	event := runStatus.BenchmarkStatusEvent
@@ -273,18 +384,13 @@ func (s *sqlStorage) getOverallJobStatus(txn *sql.Tx, job *api.EvaluationJobReso
 	}
 
 	// group all benchmarks by state
-	benchmarkStates := make(map[api.State]int)
-	failureMessage := ""
-	for _, benchmark := range job.Status.Benchmarks {
-		benchmarkStates[benchmark.Status]++
-		if benchmark.Status == api.StateFailed && benchmark.ErrorMessage != nil {
-			failureMessage += "Benchmark " + benchmark.ID + " failed with message: " + benchmark.ErrorMessage.Message + "\n"
-		}
+	benchmarkStates, err := s.countBenchmarkStates(txn, job.Resource.ID)
+	if err != nil {
+		return api.OverallStatePending, nil, err
 	}
 
 	// determine the overall job status (use resolved benchmark count for collection-only jobs)
 	var collection *api.CollectionResource
-	var err error
 	if job.Collection != nil && job.Collection.ID != "" {
 		collection, err = s.getCollectionTransactional(txn, job.Collection.ID)
 		if err != nil {
@@ -305,25 +411,27 @@ func (s *sqlStorage) getOverallJobStatus(txn *sql.Tx, job *api.EvaluationJobReso
 	total = len(benchmarks)
 	completed, failed, running, cancelled := benchmarkStates[api.StateCompleted], benchmarkStates[api.StateFailed], benchmarkStates[api.StateRunning], benchmarkStates[api.StateCancelled]
 
-	var overallState api.OverallState
-	var stateMessage string
-	switch {
-	case completed == total:
-		overallState, stateMessage = api.OverallStateCompleted, "Evaluation job is completed"
-	case failed == total:
-		overallState, stateMessage = api.OverallStateFailed, "Evaluation job is failed. \n"+failureMessage
-	case completed+failed == total:
-		overallState, stateMessage = api.OverallStatePartiallyFailed, "Some of the benchmarks failed. \n"+failureMessage
-	case cancelled == total:
-		overallState, stateMessage = api.OverallStateCancelled, "Evaluation job is cancelled"
-	case completed+failed+cancelled == total:
-		overallState, stateMessage = api.OverallStatePartiallyFailed, "Some of the benchmarks failed or cancelled. \n"+failureMessage
-	case running > 0, completed > 0, failed > 0, cancelled > 0: // if at least one benchmark has reported a state then the job is running
-		overallState, stateMessage = api.OverallStateRunning, "Evaluation job is running"
-	default:
-		overallState, stateMessage = api.OverallStatePending, "Evaluation job is pending"
+	failureMessage := ""
+	if completed+failed+cancelled == total || (job.Aggregation().FailsFast() && failed > 0) {
+		// the job is finished, the statuses of all its benchmarks are read for the failures and the results
+		if _, err := s.readJobBenchmarks(txn, job, nil); err != nil {
+			return api.OverallStatePending, nil, err
+		}
+		for _, benchmark := range job.Status.Benchmarks {
+			if benchmark.Status == api.StateFailed && benchmark.ErrorMessage != nil {
+				failureMessage += "Benchmark " + benchmark.ID + " failed with message: " + benchmark.ErrorMessage.Message + "\n"
+			}
+		}
 	}
 
+	overallState, stateMessage := jobstate.DeriveWith(jobstate.BenchmarkCounts{
+		Total:     total,
+		Running:   running,
+		Completed: completed,
+		Failed:    failed,
+		Cancelled: cancelled,
+	}, job.Aggregation(), failureMessage)
+
 	s.logger.Debug("Overall job state", "state", overallState, "completed", completed, "failed", failed, "running", running, "cancelled", cancelled, "total", total)
 
 	return overallState, api.WithMessageOrigin(&api.MessageInfo{
@@ -383,20 +491,37 @@ func (s *sqlStorage) updateBenchmarkResults(job *api.EvaluationJobResource, runS
 }
 
 // UpdateEvaluationJobWithRunStatus runs in a transaction: fetches the job, merges RunStatusInternal into the entity, and persists.
-func (s *sqlStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent) error {
-	return s.withTransaction("update evaluation job", id, func(txn *sql.Tx) error {
+func (s *sqlStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent) (*abstractions.EvaluationJobUpdate, error) {
+	var ready []int
+	var retries []api.BenchmarkRetry
+	var ignored string
+	var previousState, overallState api.OverallState
+	err := s.withTransaction("update evaluation job", id, func(txn *sql.Tx) error {
+		ready = nil
+		retries = nil
+		ignored = ""
 		s.logger.Info("Updating evaluation job", "id", id, "status", runStatus.BenchmarkStatusEvent.Status, "runStatus", runStatus)
 
-		job, err := s.getEvaluationJobTransactionalForUpdate(txn, id)
+		// The job is locked until the transaction ends, with SELECT ... FOR UPDATE on Postgres
+		// and by the write lock that SQLite transactions take when they begin, so that the
+		// events that the adapters of the benchmarks send at the same time are merged one
+		// after the other rather than overwriting each other's status.
+		job, err := s.scanEvaluationJobTransactional(txn, id, true)
 		if err != nil {
 			return err
 		}
 		// Test hook: no-op unless a test installs a callback (see test_hooks.go).
 		invokeEvaluationJobUpdateAfterLockedReadHook(id, runStatus.BenchmarkStatusEvent.ID)
 
-		// Guard: reject benchmark updates if job is already in a terminal state.
-		// We pass OverallStateRunning as the target to leverage checkEvaluationJobState's terminal-state check.
-		if _, err := s.checkEvaluationJobState(job.Resource.ID, job.Status.State, api.OverallStateRunning); err != nil {
+		// Guard: reject benchmark updates if job is already in a terminal state, from which
+		// a job can not move to running.
+		if _, err := jobstate.Check(job.Resource.ID, job.Status.State, api.OverallStateRunning); err != nil {
+			if s.retriedStatusEvent(txn, job, runStatus.BenchmarkStatusEvent) {
+				previousState, overallState = job.Status.State, job.Status.State
+				ignored = api.StatusEventDuplicate
+				s.logIgnoredStatusEvent(id, ignored, findBenchmarkStatus(job, runStatus.BenchmarkStatusEvent), runStatus.BenchmarkStatusEvent)
+				return nil
+			}
 			return err
 		}
 
@@ -411,6 +536,80 @@ func (s *sqlStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent)
 		if err != nil {
 			return err
 		}
+		benchmarks, err := handlers.GetJobBenchmarks(job, collection)
+		if err != nil {
+			return err
+		}
+
+		// Only the row of the event's benchmark is read, unless the benchmarks depend on each
+		// other or the entity still holds the benchmarks, as written by an older version.
+		embedded := hasEmbeddedBenchmarks(job)
+		var benchmarkIndex *int
+		if !embedded && !slices.ContainsFunc(benchmarks, func(b api.EvaluationBenchmarkConfig) bool { return len(b.DependsOn) > 0 }) {
+			benchmarkIndex = &runStatus.BenchmarkStatusEvent.BenchmarkIndex
+		}
+		stored, err := s.readJobBenchmarks(txn, job, benchmarkIndex)
+		if err != nil {
+			return err
+		}
+		previousState = job.Status.State
+		previousMessage := job.Status.Message
+
+		// the events that are retried or arrive out of order leave the job as it is
+		if current := findBenchmarkStatus(job, runStatus.BenchmarkStatusEvent); current != nil {
+			if ignored = staleStatusEvent(current, runStatus.BenchmarkStatusEvent); ignored != "" {
+				s.logIgnoredStatusEvent(id, ignored, current, runStatus.BenchmarkStatusEvent)
+				overallState = previousState
+				return nil
+			}
+		}
+
+		// the findings are reported with the completed status of the benchmark, or of each shard
+		if runStatus.BenchmarkStatusEvent.Status == api.StateCompleted {
+			if err := s.writeFindings(txn, id, runStatus.BenchmarkStatusEvent); err != nil {
+				return err
+			}
+		}
+
+		// the shards of a sharded benchmark are folded into one status for the benchmark
+		event, shards, err := s.mergeShardEvent(job, runStatus.BenchmarkStatusEvent, collection)
+		if err != nil {
+			return err
+		}
+
+		// a benchmark that failed with a transient error is marked pending to be retried
+		var attempts []api.BenchmarkAttempt
+		var placement *api.BenchmarkPlacement
+		eventID, sequence := event.EventID, event.Sequence
+		previous := findBenchmarkStatus(job, event)
+		if previous != nil {
+			attempts = previous.Attempts
+			placement = previous.Placement
+			if sequence == 0 && eventSource(eventID) == eventSource(previous.EventID) {
+				sequence = previous.Sequence
+			}
+		}
+		if event.BenchmarkIndex < len(benchmarks) {
+			var retry *api.BenchmarkRetry
+			event, attempts, retry = retryBenchmark(&benchmarks[event.BenchmarkIndex], previous, event, attempts)
+			if retry != nil {
+				s.logger.Info("Retrying benchmark after a transient failure", "id", id, "benchmark_index", retry.BenchmarkIndex, "attempts", len(attempts), "backoff", retry.Backoff)
+				// the retry runs every shard again, its adapters number their events anew
+				shards = nil
+				sequence = 0
+				retries = append(retries, *retry)
+			}
+		}
+		runStatus = &api.StatusEvent{BenchmarkStatusEvent: event}
+
+		// the duration of a run is recorded once, when the benchmark first completes
+		if event.Status == api.StateCompleted && event.BenchmarkIndex < len(benchmarks) {
+			if previous == nil || previous.Status != api.StateCompleted {
+				if err := s.recordBenchmarkDuration(txn, job, &benchmarks[event.BenchmarkIndex], event); err != nil {
+					return err
+				}
+			}
+		}
 
 		// first we store the benchmark status
 		benchmark := api.BenchmarkStatus{
@@ -423,13 +622,24 @@ func (s *sqlStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent)
 			StartedAt:      runStatus.BenchmarkStatusEvent.StartedAt,
 			CompletedAt:    runStatus.BenchmarkStatusEvent.CompletedAt,
 			BenchmarkIndex: runStatus.BenchmarkStatusEvent.BenchmarkIndex,
+			Shards:         shards,
+			Attempts:       attempts,
+			Placement:      placement,
+			EventID:        eventID,
+			Sequence:       sequence,
 		}
 		s.updateBenchmarkStatus(job, runStatus, &benchmark)
 
+		ready = updateBenchmarkDependencies(job, benchmarks, runStatus.BenchmarkStatusEvent.BenchmarkIndex)
+
 		outcome := s.computeBenchmarkTestResult(txn, job, runStatus.BenchmarkStatusEvent, collection)
 
 		// if the run status is terminal, we need to update the results
 		if api.IsBenchmarkTerminalState(runStatus.BenchmarkStatusEvent.Status) {
+			findings, err := s.summarizeFindings(txn, id, runStatus.BenchmarkStatusEvent.BenchmarkIndex)
+			if err != nil {
+				return err
+			}
 			result := api.BenchmarkResult{
 				ID:             runStatus.BenchmarkStatusEvent.ID,
 				ProviderID:     runStatus.BenchmarkStatusEvent.ProviderID,
@@ -440,43 +650,80 @@ func (s *sqlStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent)
 				LogsPath:       runStatus.BenchmarkStatusEvent.LogsPath,
 				BenchmarkIndex: runStatus.BenchmarkStatusEvent.BenchmarkIndex,
 				Test:           outcome,
+				MLFlowRunURL:   runStatus.BenchmarkStatusEvent.MLFlowRunURL,
+				Cached:         runStatus.BenchmarkStatusEvent.CachedFrom != "",
+				CachedFrom:     runStatus.BenchmarkStatusEvent.CachedFrom,
+
+				ProcessedMetrics: runStatus.BenchmarkStatusEvent.ProcessedMetrics,
+				Findings:         findings,
 			}
-			err := s.updateBenchmarkResults(job, runStatus, &result)
-			if err != nil {
+			if err := s.updateBenchmarkResults(job, runStatus, &result); err != nil {
 				return err
 			}
+			if runStatus.BenchmarkStatusEvent.Status == api.StateCompleted {
+				if err := s.writeMetrics(txn, job, &result); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := s.writeChangedBenchmarks(txn, id, job, stored); err != nil {
+			return err
 		}
 
 		// get the overall job status
-		overallState, message, err := s.getOverallJobStatus(txn, job)
+		var message *api.MessageInfo
+		overallState, message, err = s.getOverallJobStatus(txn, job)
 		if err != nil {
 			return err
 		}
+		if overallState != previousState && !jobstate.CanTransition(previousState, overallState) {
+			// the benchmarks keep their status, the job stays in its state
+			s.logger.Warn("Ignoring an invalid transition of the evaluation job", "id", id, "from", previousState, "to", overallState)
+			overallState, message = previousState, previousMessage
+		}
 		job.Status.State = overallState
 		job.Status.Message = message
 
-		s.logger.Info("Calculated overall job status", "id", id, "overall_state", overallState, "status", runStatus.BenchmarkStatusEvent.Status)
-
-		// compute the job test result only if the job is completed
-		if overallState == api.OverallStateCompleted {
-			s.computeJobTestResult(job, collection)
+		// a job that fails fast does not wait for the benchmarks that did not finish
+		if overallState != previousState && overallState == api.OverallStateFailed && job.Aggregation().FailsFast() {
+			if cancelUnfinishedBenchmarks(job, benchmarks, runStatus.BenchmarkStatusEvent) {
+				if err := s.writeChangedBenchmarks(txn, id, job, stored); err != nil {
+					return err
+				}
+			}
 		}
 
-		entity := EvaluationJobEntity{
-			Config:  &job.EvaluationJobConfig,
-			Status:  job.Status,
-			Results: job.Results,
-		}
+		s.logger.Info("Calculated overall job status", "id", id, "overall_state", overallState, "status", runStatus.BenchmarkStatusEvent.Status)
 
-		if err := s.updateEvaluationJobTxn(txn, id, overallState, &entity); err != nil {
-			return err
+		// compute the job test result only if the job is completed, or finished with failures
+		// that may be of optional benchmarks only
+		if overallState == api.OverallStateCompleted || (overallState == api.OverallStatePartiallyFailed && hasOptionalBenchmarks(benchmarks)) {
+			s.computeJobTestResult(txn, job, collection)
 		}
 
-		return nil
+		// the entity is rewritten only when it changes, or to move the benchmarks it holds to their rows
+		if !embedded && overallState == previousState && messageInfosEquivalent(previousMessage, message) {
+			return s.writeEvaluationJobStatus(txn, id, overallState)
+		}
+		return s.writeEvaluationJobEntity(txn, id, overallState, job)
 	})
+	if err != nil {
+		return nil, err
+	}
+	if ignored == "" {
+		jobstate.Transitioned(s.ctx, id, previousState, overallState)
+	}
+	return &abstractions.EvaluationJobUpdate{
+		PreviousState:   previousState,
+		State:           overallState,
+		ReadyBenchmarks: ready,
+		RetryBenchmarks: retries,
+		Ignored:         ignored,
+	}, nil
 }
 
-func (s *sqlStorage) computeJobTestResult(job *api.EvaluationJobResource, collection *api.CollectionResource) {
+func (s *sqlStorage) computeJobTestResult(txn *sql.Tx, job *api.EvaluationJobResource, collection *api.CollectionResource) {
 	if job.Results == nil || job.Results.Benchmarks == nil || len(job.Results.Benchmarks) == 0 {
 		return
 	}
@@ -509,7 +756,8 @@ func (s *sqlStorage) computeJobTestResult(job *api.EvaluationJobResource, collec
 			benchmarkWeight = 1
 		}
 		weightedScore := benchmarkWeight * benchmark.Test.PrimaryScore
-		if primaryScore := resolvedJobBenchmarks[benchmark.BenchmarkIndex].PrimaryScore; primaryScore != nil && primaryScore.LowerIsBetter {
+		// the test results of benchmarks that completed before the direction was recorded lack it
+		if primaryScore := resolvedJobBenchmarks[benchmark.BenchmarkIndex].PrimaryScore; benchmark.Test.LowerIsBetter || (primaryScore != nil && primaryScore.LowerIsBetter) {
 			weightedScore = benchmarkWeight * (1 - benchmark.Test.PrimaryScore)
 		}
 		sumOfWeightedScores += weightedScore
@@ -525,14 +773,92 @@ func (s *sqlStorage) computeJobTestResult(job *api.EvaluationJobResource, collec
 
 	threshold := getPassCriteriaThreshold(job, collection)
 	jobTest := &api.EvaluationTest{
-		Score:     weightedAvgJobScore,
-		Threshold: threshold,
-		Pass:      weightedAvgJobScore >= threshold,
+		Score: weightedAvgJobScore,
+	}
+	pass := true
+	if baselineName := getPassCriteriaBaseline(job, collection); baselineName != "" {
+		jobTest.Baseline = baselineName
+		if baseline := s.getJobBaseline(txn, job, baselineName); baseline != nil {
+			baselineScore := baseline.Score
+			jobTest.BaselineScore = &baselineScore
+			threshold = max(threshold, baselineScore)
+		} else {
+			// the regression can not be ruled out without the baseline
+			pass = false
+		}
+	}
+	jobTest.Threshold = threshold
+	jobTest.Pass = pass && weightedAvgJobScore >= threshold
+	jobTest.FailedRequired = failedRequiredBenchmarks(job, resolvedJobBenchmarks)
+	if len(jobTest.FailedRequired) > 0 {
+		// a failed required benchmark fails the job whatever its score, there is nothing to review
+		jobTest.Pass = false
+	} else {
+		requestReview(jobTest, getPassCriteriaReviewBand(job, collection))
 	}
 
 	job.Results.Test = jobTest
 }
 
+func hasOptionalBenchmarks(benchmarks []api.EvaluationBenchmarkConfig) bool {
+	for i := range benchmarks {
+		if !benchmarks[i].IsRequired() {
+			return true
+		}
+	}
+	return false
+}
+
+// cancelUnfinishedBenchmarks cancels the benchmarks of a job that fails fast that did not
+// finish when the failed benchmark of the event failed the job, and reports whether there
+// were any. The statuses of all the benchmarks of the job must have been read.
+func cancelUnfinishedBenchmarks(job *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig, failed *api.BenchmarkStatusEvent) bool {
+	message := api.WithMessageOrigin(&api.MessageInfo{
+		Message:     fmt.Sprintf("Benchmark cancelled because benchmark %s at index %d failed and the job fails fast", failed.ID, failed.BenchmarkIndex),
+		MessageCode: constants.MESSAGE_CODE_BENCHMARK_FAIL_FAST,
+	}, api.MessageOriginServer)
+	now := api.DateTimeToString(time.Now())
+	cancelled := false
+	for index, benchmark := range benchmarks {
+		status := benchmarkStatusAt(job, index)
+		if status == nil {
+			job.Status.Benchmarks = append(job.Status.Benchmarks, api.BenchmarkStatus{
+				ProviderID:     benchmark.ProviderID,
+				ID:             benchmark.ID,
+				BenchmarkIndex: index,
+			})
+			status = &job.Status.Benchmarks[len(job.Status.Benchmarks)-1]
+		}
+		if api.IsBenchmarkTerminalState(status.Status) {
+			continue
+		}
+		status.Status = api.StateCancelled
+		status.ErrorMessage = message
+		status.CompletedAt = now
+		cancelled = true
+	}
+	return cancelled
+}
+
+// failedRequiredBenchmarks returns the IDs of the required benchmarks of the job that failed
+// or were cancelled.
+func failedRequiredBenchmarks(job *api.EvaluationJobResource, benchmarks []api.EvaluationBenchmarkConfig) []string {
+	if job.Status == nil {
+		return nil
+	}
+	var failed []string
+	for _, benchmark := range job.Status.Benchmarks {
+		if benchmark.Status != api.StateFailed && benchmark.Status != api.StateCancelled {
+			continue
+		}
+		if benchmark.BenchmarkIndex >= 0 && benchmark.BenchmarkIndex < len(benchmarks) && !benchmarks[benchmark.BenchmarkIndex].IsRequired() {
+			continue
+		}
+		failed = append(failed, benchmark.ID)
+	}
+	return failed
+}
+
 func getPassCriteriaThreshold(job *api.EvaluationJobResource, collection *api.CollectionResource) float32 {
 	if job.PassCriteria != nil && job.PassCriteria.Threshold != nil {
 		return *job.PassCriteria.Threshold
@@ -540,10 +866,36 @@ func getPassCriteriaThreshold(job *api.EvaluationJobResource, collection *api.Co
 	if collection != nil && collection.PassCriteria != nil && collection.PassCriteria.Threshold != nil {
 		return *collection.PassCriteria.Threshold
 	}
+	if getPassCriteriaBaseline(job, collection) != "" {
+		// the baseline score alone is the threshold
+		return 0
+	}
 	// this is the hard-coded default pass criteria threshold
 	return 0.5
 }
 
+func getPassCriteriaBaseline(job *api.EvaluationJobResource, collection *api.CollectionResource) string {
+	if job.PassCriteria != nil && job.PassCriteria.MustNotRegress != "" {
+		return job.PassCriteria.MustNotRegress
+	}
+	if collection != nil && collection.PassCriteria != nil {
+		return collection.PassCriteria.MustNotRegress
+	}
+	return ""
+}
+
+// getJobBaseline returns the baseline of the pass criteria of a job from the tenant of the
+// job, or nil when it does not exist, e.g. it was deleted after the job was created.
+func (s *sqlStorage) getJobBaseline(txn *sql.Tx, job *api.EvaluationJobResource, name string) *api.BaselineResource {
+	scoped := s.WithTenant(job.Resource.Tenant).(*sqlStorage)
+	baseline, err := scoped.getBaselineTransactional(txn, name)
+	if err != nil {
+		s.logger.Warn("Failed to get the baseline of the pass criteria", "error", err, "job_id", job.Resource.ID, "baseline", name)
+		return nil
+	}
+	return baseline
+}
+
 func (s *sqlStorage) computeBenchmarkTestResult(txn *sql.Tx, job *api.EvaluationJobResource, benchmarkStatusEvent *api.BenchmarkStatusEvent, collection *api.CollectionResource) *api.BenchmarkTest {
 	// job could have benchmarks array or it could have collection. If it has collection, we need to get the benchmarks from the collection
 	benchmarks, err := handlers.GetJobBenchmarks(job, collection)
@@ -560,47 +912,83 @@ func (s *sqlStorage) computeBenchmarkTestResult(txn *sql.Tx, job *api.Evaluation
 		}
 		primaryScore := benchmark.PrimaryScore
 		var providerBench *api.BenchmarkResource
-		// if the primary score is not defined, we need to get the primary score from the provider
-		if (primaryScore == nil || primaryScore.Metric == "") && benchmark.ProviderID != "" {
+		// the provider declares the metrics that the primary score can refer to and, if the
+		// primary score is not defined, the primary score of the benchmark
+		var providerConfig *api.ProviderConfig
+		if benchmark.ProviderID != "" {
 			provider, err := s.getUserProviderTransactional(txn, benchmark.ProviderID)
 			if err == nil && provider != nil {
-				for i := range provider.Benchmarks {
-					if provider.Benchmarks[i].ID == benchmark.ID {
-						providerBench = &provider.Benchmarks[i]
-						break
-					}
-				}
-			}
-			if providerBench != nil && providerBench.PrimaryScore != nil && providerBench.PrimaryScore.Metric != "" {
-				primaryScore = providerBench.PrimaryScore
+				providerConfig = &provider.ProviderConfig
+				providerBench = provider.GetBenchmark(benchmark.ID)
 			}
 		}
-		if primaryScore != nil && primaryScore.Metric != "" {
-			primaryMetric := primaryScore.Metric
-			if primaryMetricValue, ok := benchmarkStatusEvent.Metrics[primaryMetric]; ok {
-				primaryMetricValueFloat, err := castAnyToFloat32(primaryMetricValue)
+		if !primaryScore.IsSet() && providerBench != nil && providerBench.PrimaryScore.IsSet() {
+			primaryScore = providerBench.PrimaryScore
+		}
+		if primaryScore.IsSet() {
+			primaryMetric := primaryScore.Name()
+			lowerIsBetter := primaryScore.LowerIsBetter
+			if providerConfig != nil && providerConfig.GetMetric(primaryScore.Metric).LowerIsBetter() {
+				lowerIsBetter = true
+			}
+			value, err := scoring.Evaluate(primaryScore, func(name string) (float64, bool) {
+				metricValue, ok := providerConfig.MetricValue(benchmarkStatusEvent.Metrics, name)
+				if !ok {
+					return 0, false
+				}
+				metricValueFloat, err := castAnyToFloat32(metricValue)
 				if err != nil {
-					s.logger.Error("Failed to cast primary metric value to float32", "error", err, "primary_metric", primaryMetric, "primary_metric_value", primaryMetricValue)
-					return nil
+					s.logger.Error("Failed to cast metric value of the primary score to float32", "error", err, "metric", name, "metric_value", metricValue)
+					return 0, false
 				}
-				var threshold float32
-				if benchmark.PassCriteria != nil && benchmark.PassCriteria.Threshold != nil {
-					threshold = *benchmark.PassCriteria.Threshold
-				} else if providerBench != nil && providerBench.PassCriteria != nil && providerBench.PassCriteria.Threshold != nil {
-					threshold = *providerBench.PassCriteria.Threshold
-				} else {
-					return nil
+				return float64(metricValueFloat), true
+			})
+			if err != nil && !errors.Is(err, scoring.ErrMissingMetric) {
+				s.logger.Error("Failed to compute the primary score", "error", err, "primary_metric", primaryMetric)
+			}
+			if err == nil {
+				primaryMetricValueFloat := float32(value)
+				passCriteria := benchmark.PassCriteria
+				if passCriteria == nil && providerBench != nil {
+					passCriteria = providerBench.PassCriteria
 				}
-				pass := primaryMetricValueFloat >= threshold
-				if primaryScore.LowerIsBetter {
-					pass = primaryMetricValueFloat <= threshold
+				if passCriteria == nil {
+					return nil
 				}
-				return &api.BenchmarkTest{
+				test := &api.BenchmarkTest{
 					PrimaryScore:       primaryMetricValueFloat,
 					PrimaryScoreMetric: primaryMetric,
-					Threshold:          threshold,
-					Pass:               pass,
+					LowerIsBetter:      lowerIsBetter,
+				}
+				threshold := passCriteria.Threshold
+				pass := true
+				if passCriteria.MustNotRegress != "" {
+					test.Baseline = passCriteria.MustNotRegress
+					var baselineBenchmark *api.BaselineBenchmark
+					if baseline := s.getJobBaseline(txn, job, passCriteria.MustNotRegress); baseline != nil {
+						baselineBenchmark = baseline.GetBenchmark(benchmark.ProviderID, benchmark.ID)
+					}
+					if baselineBenchmark == nil {
+						// the regression can not be ruled out without the baseline of the benchmark
+						pass = false
+					} else {
+						baselineScore := baselineBenchmark.PrimaryScore
+						test.BaselinePrimaryScore = &baselineScore
+						if threshold == nil || (lowerIsBetter && baselineScore < *threshold) || (!lowerIsBetter && baselineScore > *threshold) {
+							threshold = &baselineScore
+						}
+					}
+				}
+				if threshold != nil {
+					test.Threshold = *threshold
+					if lowerIsBetter {
+						pass = pass && primaryMetricValueFloat <= *threshold
+					} else {
+						pass = pass && primaryMetricValueFloat >= *threshold
+					}
 				}
+				test.Pass = pass
+				return test
 			}
 		}
 	}
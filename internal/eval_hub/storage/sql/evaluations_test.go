@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"maps"
+	"math"
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -27,6 +29,18 @@ func TestGetEvaluationJobs_TenantFilter(t *testing.T) {
 	testGetEvaluationJobs_TenantFilter(t, drivers[0], getDBName())
 }
 
+func TestGetEvaluationJobs_SweepFilter(t *testing.T) {
+	testGetEvaluationJobs_SweepFilter(t, drivers[0], getDBName())
+}
+
+func TestGetEvaluationJobs_AnnotationFilter(t *testing.T) {
+	testGetEvaluationJobs_AnnotationFilter(t, drivers[0], getDBName())
+}
+
+func TestGetEvaluationJobs_VisibilityFilter(t *testing.T) {
+	testGetEvaluationJobs_VisibilityFilter(t, drivers[0], getDBName())
+}
+
 func TestUpdateEvaluationJob_PreservesProviderID(t *testing.T) {
 	testUpdateEvaluationJob_PreservesProviderID(t, drivers[0], getDBName())
 }
@@ -62,12 +76,16 @@ func TestGetEvaluationJobs_Postgres(t *testing.T) {
 	})
 
 	testGetEvaluationJobs_TenantFilter(t, drivers[1], databaseName)
+	testGetEvaluationJobs_SweepFilter(t, drivers[1], databaseName)
+	testGetEvaluationJobs_AnnotationFilter(t, drivers[1], databaseName)
+	testGetEvaluationJobs_VisibilityFilter(t, drivers[1], databaseName)
 	testUpdateEvaluationJob_PreservesProviderID(t, drivers[1], databaseName)
 	testUpdateEvaluationJob_PersistsPhase(t, drivers[1], databaseName)
 	testUpdateEvaluationJob_PersistsAdditionalInfo(t, drivers[1], databaseName)
 	testEvaluationsStorage(t, drivers[1], databaseName)
 	testUpdateBenchmarkStatus_RejectsTerminalDowngrade(t, drivers[1], databaseName)
 	testUpdateEvaluationJob_ConcurrentBenchmarkCompletions(t, drivers[1], databaseName)
+	testUpdateEvaluationJob_ParallelCallbacks(t, drivers[1], databaseName)
 }
 
 func TestUpdateBenchmarkStatus_RejectsTerminalDowngrade(t *testing.T) {
@@ -78,6 +96,13 @@ func TestUpdateEvaluationJob_ConcurrentBenchmarkCompletions(t *testing.T) {
 	testUpdateEvaluationJob_ConcurrentBenchmarkCompletions(t, drivers[0], getDBName())
 }
 
+// TestUpdateEvaluationJob_ParallelCallbacks sends the events of all the benchmarks of a job
+// at once, as the adapters of a job do, and checks that none of them is lost in the
+// read-modify-write of the job.
+func TestUpdateEvaluationJob_ParallelCallbacks(t *testing.T) {
+	testUpdateEvaluationJob_ParallelCallbacks(t, drivers[0], getDBName())
+}
+
 func testUpdateBenchmarkStatus_RejectsTerminalDowngrade(t *testing.T, driver string, databaseName string) {
 	store, err := getTestStorage(t, driver, databaseName)
 	if err != nil {
@@ -111,7 +136,7 @@ func testUpdateBenchmarkStatus_RejectsTerminalDowngrade(t *testing.T, driver str
 		t.Fatalf("CreateEvaluationJob: %v", err)
 	}
 
-	if err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+	if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
 		BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
 			ID: "b1", ProviderID: "prov1", BenchmarkIndex: 0,
 			Status: api.StateCompleted, CompletedAt: api.DateTimeToString(now),
@@ -120,7 +145,7 @@ func testUpdateBenchmarkStatus_RejectsTerminalDowngrade(t *testing.T, driver str
 		t.Fatalf("UpdateEvaluationJob completed: %v", err)
 	}
 
-	if err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+	if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
 		BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
 			ID: "b1", ProviderID: "prov1", BenchmarkIndex: 0,
 			Status: api.StateRunning, Phase: api.JobPhasePersistingArtifacts,
@@ -182,12 +207,13 @@ func testUpdateEvaluationJob_ConcurrentBenchmarkCompletions(t *testing.T, driver
 	}
 
 	completeBenchmark := func(id string, index int) error {
-		return store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+		_, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
 			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
 				ID: id, ProviderID: "garak", BenchmarkIndex: index,
 				Status: api.StateCompleted, CompletedAt: api.DateTimeToString(now),
 			},
 		})
+		return err
 	}
 
 	if err := completeBenchmark("truthfulqa_mc1", 1); err != nil {
@@ -275,6 +301,286 @@ func testUpdateEvaluationJob_ConcurrentBenchmarkCompletions(t *testing.T, driver
 	}
 }
 
+func testUpdateEvaluationJob_ParallelCallbacks(t *testing.T, driver string, databaseName string) {
+	store, err := getTestStorage(t, driver, databaseName)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	const benchmarks = 8
+	jobID := common.GUID()
+	config := api.EvaluationJobConfig{Model: api.ModelRef{URL: "http://test.com", Name: "test"}}
+	for i := range benchmarks {
+		config.Benchmarks = append(config.Benchmarks, api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: fmt.Sprintf("b%d", i)}, ProviderID: "prov"})
+	}
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource:            api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: api.Tenant(getTenant("team-a"))}},
+		Status:              &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: config,
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*benchmarks)
+	for i := range benchmarks {
+		wg.Go(func() {
+			event := &api.BenchmarkStatusEvent{
+				ID: fmt.Sprintf("b%d", i), ProviderID: "prov", BenchmarkIndex: i,
+				Status: api.StateRunning, StartedAt: api.DateTimeToString(time.Now()),
+			}
+			_, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: event})
+			errs <- err
+			event.Status, event.CompletedAt = api.StateCompleted, api.DateTimeToString(time.Now())
+			event.Metrics = map[string]any{"acc": float64(i) / 10}
+			_, err = store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: event})
+			errs <- err
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("UpdateEvaluationJob: %v", err)
+		}
+	}
+
+	final, err := store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if final.Status.State != api.OverallStateCompleted {
+		t.Fatalf("overall state = %s, want completed", final.Status.State)
+	}
+	if len(final.Status.Benchmarks) != benchmarks {
+		t.Fatalf("expected %d benchmark statuses, got %d", benchmarks, len(final.Status.Benchmarks))
+	}
+	for _, benchmark := range final.Status.Benchmarks {
+		if benchmark.Status != api.StateCompleted {
+			t.Errorf("benchmark %s status = %s, want completed", benchmark.ID, benchmark.Status)
+		}
+	}
+	if final.Results == nil || len(final.Results.Benchmarks) != benchmarks {
+		t.Fatalf("expected the results of %d benchmarks, got %+v", benchmarks, final.Results)
+	}
+	for _, result := range final.Results.Benchmarks {
+		if want := float64(result.BenchmarkIndex) / 10; result.Metrics["acc"] != want {
+			t.Errorf("benchmark %s acc = %v, want %v", result.ID, result.Metrics["acc"], want)
+		}
+	}
+}
+
+// testGetEvaluationJobs_SweepFilter verifies that the sweep_id filter returns the child
+// jobs of a sweep only.
+func testGetEvaluationJobs_SweepFilter(t *testing.T, driver string, databaseName string) {
+	store, err := getTestStorage(t, driver, databaseName)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	tenant := api.Tenant(getTenant("team-sweep"))
+	store = store.WithTenant(tenant)
+
+	sweepID := common.GUID()
+	makeJob := func(sweepRun *api.SweepRun) *api.EvaluationJobResource {
+		return &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{
+				Resource: api.Resource{ID: common.GUID(), Tenant: tenant},
+			},
+			Status: &api.EvaluationJobStatus{
+				EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePending},
+			},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Model:      api.ModelRef{URL: "http://model", Name: "m"},
+				Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "b"}, ProviderID: "p"}},
+				SweepRun:   sweepRun,
+			},
+		}
+	}
+	for i := range 2 {
+		run := &api.SweepRun{SweepID: sweepID, Strategy: api.SweepStrategyGrid, Index: i, Count: 2, Parameters: map[string]any{"model.temperature": i}}
+		if err := store.CreateEvaluationJob(makeJob(run)); err != nil {
+			t.Fatalf("create sweep job %d: %v", i, err)
+		}
+	}
+	if err := store.CreateEvaluationJob(makeJob(&api.SweepRun{SweepID: common.GUID()})); err != nil {
+		t.Fatalf("create job of another sweep: %v", err)
+	}
+	if err := store.CreateEvaluationJob(makeJob(nil)); err != nil {
+		t.Fatalf("create job without a sweep: %v", err)
+	}
+
+	res, err := store.GetEvaluationJobs(&abstractions.QueryFilter{Limit: 50, Params: map[string]any{"sweep_id": sweepID}})
+	if err != nil {
+		t.Fatalf("GetEvaluationJobs: %v", err)
+	}
+	if len(res.Items) != 2 || res.TotalCount != 2 {
+		t.Fatalf("expected the 2 jobs of the sweep, got %d of %d", len(res.Items), res.TotalCount)
+	}
+	for _, job := range res.Items {
+		if job.SweepRun == nil || job.SweepRun.SweepID != sweepID {
+			t.Fatalf("unexpected sweep run %+v", job.SweepRun)
+		}
+	}
+}
+
+// testGetEvaluationJobs_VisibilityFilter verifies that the jobs owned by or shared with a
+// user are listed for the user, and that a job handed over moves to its new owner.
+func testGetEvaluationJobs_VisibilityFilter(t *testing.T, driver string, databaseName string) {
+	store, err := getTestStorage(t, driver, databaseName)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	tenant := api.Tenant(getTenant("team-visibility"))
+	store = store.WithTenant(tenant)
+
+	makeJob := func(owner api.User, sharing *api.JobSharing) *api.EvaluationJobResource {
+		return &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{
+				Resource: api.Resource{ID: common.GUID(), Tenant: tenant, Owner: owner},
+			},
+			Status: &api.EvaluationJobStatus{
+				EvaluationJobState: api.EvaluationJobState{State: api.OverallStateCompleted},
+			},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Model:      api.ModelRef{URL: "http://model", Name: "m"},
+				Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "b"}, ProviderID: "p"}},
+				SharedWith: sharing,
+			},
+		}
+	}
+	aliceJob := makeJob("alice", nil)
+	sharedWithBob := makeJob("alice", &api.JobSharing{Users: []api.User{"bob"}})
+	sharedWithML := makeJob("carol", &api.JobSharing{Groups: []string{"ml-team"}})
+	for _, job := range []*api.EvaluationJobResource{aliceJob, sharedWithBob, sharedWithML} {
+		if err := store.CreateEvaluationJob(job); err != nil {
+			t.Fatalf("create job: %v", err)
+		}
+	}
+
+	listVisible := func(t *testing.T, visibility abstractions.JobVisibility) []string {
+		t.Helper()
+		res, err := store.GetEvaluationJobs(&abstractions.QueryFilter{Limit: 50, Params: map[string]any{"visible_to": visibility}})
+		if err != nil {
+			t.Fatalf("GetEvaluationJobs(%v): %v", visibility, err)
+		}
+		got := make([]string, 0, len(res.Items))
+		for _, job := range res.Items {
+			got = append(got, job.Resource.ID)
+		}
+		if res.TotalCount != len(got) {
+			t.Errorf("GetEvaluationJobs(%v) total count = %d, want %d", visibility, res.TotalCount, len(got))
+		}
+		slices.Sort(got)
+		return got
+	}
+	sorted := func(ids ...string) []string {
+		slices.Sort(ids)
+		return ids
+	}
+
+	for _, tc := range []struct {
+		visibility abstractions.JobVisibility
+		want       []string
+	}{
+		{abstractions.JobVisibility{User: "alice"}, sorted(aliceJob.Resource.ID, sharedWithBob.Resource.ID)},
+		{abstractions.JobVisibility{User: "bob"}, sorted(sharedWithBob.Resource.ID)},
+		{abstractions.JobVisibility{User: "bob", Groups: []string{"dev", "ml-team"}}, sorted(sharedWithBob.Resource.ID, sharedWithML.Resource.ID)},
+		{abstractions.JobVisibility{User: "dave", Groups: []string{"dev"}}, []string{}},
+	} {
+		if got := listVisible(t, tc.visibility); !slices.Equal(got, tc.want) {
+			t.Errorf("GetEvaluationJobs(%v) = %v, want %v", tc.visibility, got, tc.want)
+		}
+	}
+
+	handedOver, err := store.UpdateEvaluationJobOwner(aliceJob.Resource.ID, "dave")
+	if err != nil {
+		t.Fatalf("UpdateEvaluationJobOwner: %v", err)
+	}
+	if handedOver.Resource.Owner != "dave" || handedOver.Status.State != api.OverallStateCompleted {
+		t.Fatalf("unexpected job after the hand over %+v", handedOver.Resource)
+	}
+	if got := listVisible(t, abstractions.JobVisibility{User: "dave"}); !slices.Equal(got, []string{aliceJob.Resource.ID}) {
+		t.Errorf("expected the job to be visible to its new owner, got %v", got)
+	}
+	if _, err := store.UpdateEvaluationJobOwner(common.GUID(), "dave"); err == nil {
+		t.Errorf("expected an unknown job not to be handed over")
+	}
+}
+
+// testGetEvaluationJobs_AnnotationFilter verifies that annotations and links patched after
+// the job was created are stored and matched by the annotation and link filters.
+func testGetEvaluationJobs_AnnotationFilter(t *testing.T, driver string, databaseName string) {
+	store, err := getTestStorage(t, driver, databaseName)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	tenant := api.Tenant(getTenant("team-annotations"))
+	store = store.WithTenant(tenant)
+
+	makeJob := func(annotations map[string]string) *api.EvaluationJobResource {
+		return &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{
+				Resource: api.Resource{ID: common.GUID(), Tenant: tenant},
+			},
+			Status: &api.EvaluationJobStatus{
+				EvaluationJobState: api.EvaluationJobState{State: api.OverallStateCompleted},
+			},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Model:                 api.ModelRef{URL: "http://model", Name: "m"},
+				Benchmarks:            []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "b"}, ProviderID: "p"}},
+				EvaluationJobMetadata: api.EvaluationJobMetadata{Annotations: annotations},
+			},
+		}
+	}
+	prodJob := makeJob(map[string]string{"env": "prod", "team": "ml"})
+	stagingJob := makeJob(map[string]string{"env": "staging"})
+	for _, job := range []*api.EvaluationJobResource{prodJob, stagingJob, makeJob(nil)} {
+		if err := store.CreateEvaluationJob(job); err != nil {
+			t.Fatalf("create job: %v", err)
+		}
+	}
+
+	pullRequest := "https://github.com/org/model/pull/42"
+	patched, err := store.PatchEvaluationJob(stagingJob.Resource.ID, &api.Patch{
+		{Op: api.PatchOpAdd, Path: "/annotations/team", Value: "ml"},
+		{Op: api.PatchOpAdd, Path: "/links", Value: []any{map[string]any{"type": "pull_request", "url": pullRequest}}},
+	})
+	if err != nil {
+		t.Fatalf("PatchEvaluationJob: %v", err)
+	}
+	if patched.Annotations["team"] != "ml" || len(patched.Links) != 1 || patched.Links[0].Type != api.JobLinkTypePullRequest {
+		t.Fatalf("unexpected patched job annotations %v and links %v", patched.Annotations, patched.Links)
+	}
+	if patched.Status.State != api.OverallStateCompleted {
+		t.Fatalf("state = %s, want the patch to keep completed", patched.Status.State)
+	}
+
+	for _, tc := range []struct {
+		filter map[string]any
+		want   []string
+	}{
+		{map[string]any{"annotation": "team"}, []string{prodJob.Resource.ID, stagingJob.Resource.ID}},
+		{map[string]any{"annotation": "env:prod"}, []string{prodJob.Resource.ID}},
+		{map[string]any{"annotation": "env:staging,team:ml"}, []string{stagingJob.Resource.ID}},
+		{map[string]any{"annotation": "env:prod|env:staging"}, []string{prodJob.Resource.ID, stagingJob.Resource.ID}},
+		{map[string]any{"link": pullRequest}, []string{stagingJob.Resource.ID}},
+	} {
+		res, err := store.GetEvaluationJobs(&abstractions.QueryFilter{Limit: 50, Params: tc.filter})
+		if err != nil {
+			t.Fatalf("GetEvaluationJobs(%v): %v", tc.filter, err)
+		}
+		var got []string
+		for _, job := range res.Items {
+			got = append(got, job.Resource.ID)
+		}
+		slices.Sort(got)
+		slices.Sort(tc.want)
+		if !slices.Equal(got, tc.want) || res.TotalCount != len(tc.want) {
+			t.Errorf("GetEvaluationJobs(%v) = %v of %d, want %v", tc.filter, got, res.TotalCount, tc.want)
+		}
+	}
+}
+
 func testGetEvaluationJobs_TenantFilter(t *testing.T, driver string, databaseName string) {
 	store, err := getTestStorage(t, driver, databaseName)
 	if err != nil {
@@ -432,7 +738,7 @@ func testUpdateEvaluationJob_PreservesProviderID(t *testing.T, driver string, da
 		},
 	}
 
-	err = store.UpdateEvaluationJob(job.Resource.ID, statusUpdate)
+	_, err = store.UpdateEvaluationJob(job.Resource.ID, statusUpdate)
 	if err != nil {
 		t.Fatalf("Failed to update job: %v", err)
 	}
@@ -460,7 +766,7 @@ func testUpdateEvaluationJob_PreservesProviderID(t *testing.T, driver string, da
 		},
 	}
 
-	err = store.UpdateEvaluationJob(job.Resource.ID, completionUpdate)
+	_, err = store.UpdateEvaluationJob(job.Resource.ID, completionUpdate)
 	if err != nil {
 		t.Fatalf("Failed to update job with results: %v", err)
 	}
@@ -537,7 +843,7 @@ func testUpdateEvaluationJob_PersistsPhase(t *testing.T, driver string, database
 		},
 	}
 
-	if err := store.UpdateEvaluationJob(jobID, statusUpdate); err != nil {
+	if _, err := store.UpdateEvaluationJob(jobID, statusUpdate); err != nil {
 		t.Fatalf("Failed to update job with phase: %v", err)
 	}
 
@@ -564,7 +870,7 @@ func testUpdateEvaluationJob_PersistsPhase(t *testing.T, driver string, database
 		},
 	}
 
-	if err := store.UpdateEvaluationJob(jobID, completionUpdate); err != nil {
+	if _, err := store.UpdateEvaluationJob(jobID, completionUpdate); err != nil {
 		t.Fatalf("Failed to update job with completed phase: %v", err)
 	}
 
@@ -629,7 +935,7 @@ func testUpdateEvaluationJob_PersistsAdditionalInfo(t *testing.T, driver string,
 		},
 	}
 
-	if err := store.UpdateEvaluationJob(jobID, runningUpdate); err != nil {
+	if _, err := store.UpdateEvaluationJob(jobID, runningUpdate); err != nil {
 		t.Fatalf("Failed to update job with running status: %v", err)
 	}
 
@@ -651,7 +957,7 @@ func testUpdateEvaluationJob_PersistsAdditionalInfo(t *testing.T, driver string,
 		},
 	}
 
-	if err := store.UpdateEvaluationJob(jobID, completionUpdate); err != nil {
+	if _, err := store.UpdateEvaluationJob(jobID, completionUpdate); err != nil {
 		t.Fatalf("Failed to update job with completed status: %v", err)
 	}
 
@@ -855,7 +1161,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 		if err != nil {
 			t.Fatalf("Failed to validate status: %v", err)
 		}
-		err = store.UpdateEvaluationJob(evaluationId, status)
+		_, err = store.UpdateEvaluationJob(evaluationId, status)
 		if err != nil {
 			t.Fatalf("Failed to update evaluation job: %v", err)
 		}
@@ -937,7 +1243,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 			},
 		}
 		status.BenchmarkStatusEvent.StampRuntimeMessageOrigins()
-		if err := store.UpdateEvaluationJob(jobID, status); err != nil {
+		if _, err := store.UpdateEvaluationJob(jobID, status); err != nil {
 			t.Fatalf("UpdateEvaluationJob: %v", err)
 		}
 
@@ -952,7 +1258,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 			t.Fatalf("persisted error message = %q, want full detail %q", got.Status.Benchmarks[0].ErrorMessage.Message, originalMessage)
 		}
 		if got.Status.Message == nil || !strings.Contains(got.Status.Message.Message, originalMessage) {
-			t.Fatalf("overall message = %q, want it to contain full benchmark error %q", got.Status.Message, originalMessage)
+			t.Fatalf("overall message = %q, want it to contain full benchmark error %q", got.Status.Message.Message, originalMessage)
 		}
 	})
 
@@ -1000,7 +1306,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 			},
 		}
 		status.BenchmarkStatusEvent.StampRuntimeMessageOrigins()
-		if err := store.UpdateEvaluationJob(jobID, status); err != nil {
+		if _, err := store.UpdateEvaluationJob(jobID, status); err != nil {
 			t.Fatalf("UpdateEvaluationJob: %v", err)
 		}
 
@@ -1064,7 +1370,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 		}
 		// Stamp defaults missing origins only; an explicit server origin must be kept.
 		status.BenchmarkStatusEvent.StampRuntimeMessageOrigins()
-		if err := store.UpdateEvaluationJob(jobID, status); err != nil {
+		if _, err := store.UpdateEvaluationJob(jobID, status); err != nil {
 			t.Fatalf("UpdateEvaluationJob: %v", err)
 		}
 
@@ -1124,7 +1430,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 			},
 		}
 		status.BenchmarkStatusEvent.StampRuntimeMessageOrigins()
-		if err := store.UpdateEvaluationJob(jobID, status); err != nil {
+		if _, err := store.UpdateEvaluationJob(jobID, status); err != nil {
 			t.Fatalf("UpdateEvaluationJob: %v", err)
 		}
 
@@ -1175,9 +1481,13 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 		}
 
 		msg := &api.MessageInfo{Message: "cancelled by server", MessageCode: "CANCELLED"}
-		if err := store.UpdateEvaluationJobStatus(jobID, api.OverallStateCancelled, msg); err != nil {
+		previousState, err := store.UpdateEvaluationJobStatus(jobID, api.OverallStateCancelled, msg)
+		if err != nil {
 			t.Fatalf("UpdateEvaluationJobStatus: %v", err)
 		}
+		if previousState != api.OverallStateRunning {
+			t.Fatalf("expected the running state before the update, got %q", previousState)
+		}
 
 		got, err := store.GetEvaluationJob(jobID)
 		if err != nil {
@@ -1220,7 +1530,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 			t.Fatalf("CreateEvaluationJob: %v", err)
 		}
 		msg := &api.MessageInfo{Message: "now pending", MessageCode: "test"}
-		err := store.UpdateEvaluationJobStatus(jobID, api.OverallStatePending, msg)
+		_, err := store.UpdateEvaluationJobStatus(jobID, api.OverallStatePending, msg)
 		if err != nil {
 			t.Fatalf("UpdateEvaluationJobStatus running->pending: %v", err)
 		}
@@ -1266,7 +1576,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 			Message:     "Evaluation job created but no runtime configured",
 			MessageCode: "updated",
 		}
-		if err := store.UpdateEvaluationJobStatus(jobID, api.OverallStatePending, newMsg); err != nil {
+		if _, err := store.UpdateEvaluationJobStatus(jobID, api.OverallStatePending, newMsg); err != nil {
 			t.Fatalf("UpdateEvaluationJobStatus same-state message: %v", err)
 		}
 		got, err := store.GetEvaluationJob(jobID)
@@ -1313,7 +1623,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 		}
 
 		updatedMsg := &api.MessageInfo{Message: "unchanged", MessageCode: "K"}
-		if err := store.UpdateEvaluationJobStatus(jobID, api.OverallStatePending, updatedMsg); err != nil {
+		if _, err := store.UpdateEvaluationJobStatus(jobID, api.OverallStatePending, updatedMsg); err != nil {
 			t.Fatalf("UpdateEvaluationJobStatus: %v", err)
 		}
 		got, err := store.GetEvaluationJob(jobID)
@@ -1354,7 +1664,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 		if err := store.CreateEvaluationJob(j); err != nil {
 			t.Fatalf("CreateEvaluationJob: %v", err)
 		}
-		if err := store.UpdateEvaluationJobStatus(jobID, api.OverallStatePending, nil); err != nil {
+		if _, err := store.UpdateEvaluationJobStatus(jobID, api.OverallStatePending, nil); err != nil {
 			t.Fatalf("UpdateEvaluationJobStatus: %v", err)
 		}
 		got, err := store.GetEvaluationJob(jobID)
@@ -1412,7 +1722,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 			// Drive job to terminal state
 			switch terminalState {
 			case api.OverallStateCompleted:
-				if err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+				if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
 					BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
 						ID: "b1", ProviderID: "p1", BenchmarkIndex: 0,
 						Status: api.StateCompleted,
@@ -1421,7 +1731,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 					t.Fatalf("setup for %s: %v", terminalState, err)
 				}
 			case api.OverallStateFailed:
-				if err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+				if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
 					BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
 						ID: "b1", ProviderID: "p1", BenchmarkIndex: 0,
 						Status:       api.StateFailed,
@@ -1431,11 +1741,11 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 					t.Fatalf("setup for %s: %v", terminalState, err)
 				}
 			case api.OverallStateCancelled:
-				if err := store.UpdateEvaluationJobStatus(jobID, api.OverallStateCancelled, &api.MessageInfo{Message: "cancelled", MessageCode: "X"}); err != nil {
+				if _, err := store.UpdateEvaluationJobStatus(jobID, api.OverallStateCancelled, &api.MessageInfo{Message: "cancelled", MessageCode: "X"}); err != nil {
 					t.Fatalf("setup for %s: %v", terminalState, err)
 				}
 			case api.OverallStatePartiallyFailed:
-				if err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+				if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
 					BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
 						ID: "b1", ProviderID: "p1", BenchmarkIndex: 0,
 						Status: api.StateCompleted,
@@ -1443,7 +1753,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 				}); err != nil {
 					t.Fatalf("setup for %s (b1): %v", terminalState, err)
 				}
-				if err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+				if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
 					BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
 						ID: "b2", ProviderID: "p1", BenchmarkIndex: 1,
 						Status:       api.StateFailed,
@@ -1460,7 +1770,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 			if got.Status.State != terminalState {
 				t.Fatalf("job %s: expected state %s, got %s", jobID, terminalState, got.Status.State)
 			}
-			err := store.UpdateEvaluationJobStatus(jobID, api.OverallStatePending, &api.MessageInfo{Message: "try", MessageCode: "X"})
+			_, err := store.UpdateEvaluationJobStatus(jobID, api.OverallStatePending, &api.MessageInfo{Message: "try", MessageCode: "X"})
 			if err == nil {
 				t.Errorf("UpdateEvaluationJobStatus from %s should return error", terminalState)
 			}
@@ -1505,7 +1815,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 		}
 		// (1) pending->running: verify State and Message updated
 		msg := &api.MessageInfo{Message: "job running", MessageCode: "RUNNING"}
-		if err := store.UpdateEvaluationJobStatus(jobID, api.OverallStateRunning, msg); err != nil {
+		if _, err := store.UpdateEvaluationJobStatus(jobID, api.OverallStateRunning, msg); err != nil {
 			t.Fatalf("UpdateEvaluationJobStatus pending->running: %v", err)
 		}
 		updated, err := store.GetEvaluationJob(jobID)
@@ -1519,7 +1829,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 			t.Errorf("Message should be updated, got %v", updated.Status.Message)
 		}
 		// (2) running->cancelled: verify Benchmarks and Results preserved
-		if err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+		if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
 			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
 				ID: "bx", ProviderID: "garak", BenchmarkIndex: 0,
 				Status:  api.StateCompleted,
@@ -1556,7 +1866,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 		if err := store.CreateEvaluationJob(job2); err != nil {
 			t.Fatalf("CreateEvaluationJob job2: %v", err)
 		}
-		if err := store.UpdateEvaluationJob(jobID2, &api.StatusEvent{
+		if _, err := store.UpdateEvaluationJob(jobID2, &api.StatusEvent{
 			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
 				ID: "bx", ProviderID: "garak", BenchmarkIndex: 0,
 				Status: api.StateRunning,
@@ -1564,7 +1874,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 		}); err != nil {
 			t.Fatalf("UpdateEvaluationJob job2 running: %v", err)
 		}
-		if err := store.UpdateEvaluationJobStatus(jobID2, api.OverallStateCancelled, &api.MessageInfo{Message: "cancelled", MessageCode: "C"}); err != nil {
+		if _, err := store.UpdateEvaluationJobStatus(jobID2, api.OverallStateCancelled, &api.MessageInfo{Message: "cancelled", MessageCode: "C"}); err != nil {
 			t.Fatalf("UpdateEvaluationJobStatus running->cancelled: %v", err)
 		}
 		final, err := store.GetEvaluationJob(jobID2)
@@ -1619,7 +1929,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 			t.Fatalf("CreateEvaluationJob: %v", err)
 		}
 		// Set benchmark 0 to running, benchmark 1 to completed, benchmark 2 to pending
-		if err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+		if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
 			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
 				ID: "b1", ProviderID: "prov1", BenchmarkIndex: 0,
 				Status: api.StateRunning,
@@ -1627,7 +1937,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 		}); err != nil {
 			t.Fatalf("UpdateEvaluationJob b1 running: %v", err)
 		}
-		if err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+		if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
 			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
 				ID: "b2", ProviderID: "prov2", BenchmarkIndex: 1,
 				Status:  api.StateCompleted,
@@ -1636,7 +1946,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 		}); err != nil {
 			t.Fatalf("UpdateEvaluationJob b2 completed: %v", err)
 		}
-		if err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+		if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
 			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
 				ID: "b3", ProviderID: "prov3", BenchmarkIndex: 2,
 				Status: api.StatePending,
@@ -1649,7 +1959,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 			Message:     "Evaluation job cancelled",
 			MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_CANCELLED,
 		}
-		if err := store.UpdateEvaluationJobStatus(jobID, api.OverallStateCancelled, cancelMsg); err != nil {
+		if _, err := store.UpdateEvaluationJobStatus(jobID, api.OverallStateCancelled, cancelMsg); err != nil {
 			t.Fatalf("UpdateEvaluationJobStatus running->cancelled: %v", err)
 		}
 		final, err := store.GetEvaluationJob(jobID)
@@ -1683,7 +1993,7 @@ func testEvaluationsStorage(t *testing.T, driver string, databaseName string) {
 	})
 
 	t.Run("DeleteEvaluationJob deletes the evaluation job", func(t *testing.T) {
-		err := store.UpdateEvaluationJobStatus(evaluationId, api.OverallStateCancelled, &api.MessageInfo{
+		_, err := store.UpdateEvaluationJobStatus(evaluationId, api.OverallStateCancelled, &api.MessageInfo{
 			Message:     "Evaluation job cancelled",
 			MessageCode: constants.MESSAGE_CODE_EVALUATION_JOB_CANCELLED,
 		})
@@ -1804,3 +2114,307 @@ func testGetEvaluationJobs_PassCriteria(jobThreshold *float32, collectionThresho
 	}
 	return nil
 }
+
+func TestUpdateEvaluationJob_PrimaryScoreOfDeclaredMetric(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-metrics")
+	store = store.WithTenant(tenant)
+	threshold := float32(10)
+	if err := store.CreateProvider(&api.ProviderResource{
+		Resource: api.Resource{ID: "metrics-provider", Tenant: tenant, CreatedAt: time.Now()},
+		ProviderConfig: api.ProviderConfig{
+			Name:    "Metrics Provider",
+			Metrics: []api.MetricDefinition{{Name: "perplexity", Direction: api.MetricDirectionLowerIsBetter, Aliases: []string{"ppl"}}},
+			Benchmarks: []api.BenchmarkResource{{
+				ID:           "wikitext",
+				PrimaryScore: &api.PrimaryScore{Metric: "perplexity"},
+				PassCriteria: &api.PassCriteria{Threshold: &threshold},
+			}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateProvider: %v", err)
+	}
+
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "wikitext"}, ProviderID: "metrics-provider"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+	// the runtime reports the primary score under an alias of the metric
+	if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+		ProviderID: "metrics-provider",
+		ID:         "wikitext",
+		Status:     api.StateCompleted,
+		Metrics:    map[string]any{"ppl": 4.0},
+	}}); err != nil {
+		t.Fatalf("UpdateEvaluationJob: %v", err)
+	}
+
+	job, err := store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	test := job.Results.Benchmarks[0].Test
+	if test == nil || test.PrimaryScore != 4 || !test.LowerIsBetter || !test.Pass {
+		t.Fatalf("expected the alias to pass below the threshold of a lower is better metric, got %+v", test)
+	}
+}
+
+func TestUpdateEvaluationJob_CompositePrimaryScore(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-composite")
+	store = store.WithTenant(tenant)
+	threshold := float32(0.7)
+	if err := store.CreateProvider(&api.ProviderResource{
+		Resource: api.Resource{ID: "composite-provider", Tenant: tenant, CreatedAt: time.Now()},
+		ProviderConfig: api.ProviderConfig{
+			Name: "Composite Provider",
+			Benchmarks: []api.BenchmarkResource{
+				{ID: "weighted", PrimaryScore: &api.PrimaryScore{Metrics: []api.WeightedMetric{{Metric: "acc", Weight: 3}, {Metric: "f1"}}}},
+				{ID: "expression", PrimaryScore: &api.PrimaryScore{Expression: "0.5*acc + 0.5*f1"}},
+				{ID: "missing", PrimaryScore: &api.PrimaryScore{Expression: "acc * recall"}},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("CreateProvider: %v", err)
+	}
+
+	benchmarkIDs := []string{"weighted", "expression", "missing"}
+	benchmarks := make([]api.EvaluationBenchmarkConfig, 0, len(benchmarkIDs))
+	for _, id := range benchmarkIDs {
+		benchmarks = append(benchmarks, api.EvaluationBenchmarkConfig{
+			Ref:          api.Ref{ID: id},
+			ProviderID:   "composite-provider",
+			PassCriteria: &api.PassCriteria{Threshold: &threshold},
+		})
+	}
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+			Benchmarks: benchmarks,
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+	for index, id := range benchmarkIDs {
+		if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+			ProviderID:     "composite-provider",
+			ID:             id,
+			BenchmarkIndex: index,
+			Status:         api.StateCompleted,
+			Metrics:        map[string]any{"acc": 0.8, "f1": 0.4},
+		}}); err != nil {
+			t.Fatalf("UpdateEvaluationJob(%s): %v", id, err)
+		}
+	}
+
+	job, err := store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	tests := map[string]*api.BenchmarkTest{}
+	for _, result := range job.Results.Benchmarks {
+		tests[result.ID] = result.Test
+	}
+	if test := tests["weighted"]; test == nil || math.Abs(float64(test.PrimaryScore)-0.7) > 1e-6 || test.PrimaryScoreMetric != "weighted_mean(acc=3, f1=1)" {
+		t.Errorf("expected the weighted average of the metrics, got %+v", test)
+	}
+	if test := tests["expression"]; test == nil || math.Abs(float64(test.PrimaryScore)-0.6) > 1e-6 || test.Pass || test.PrimaryScoreMetric != "0.5*acc + 0.5*f1" {
+		t.Errorf("expected the expression to fail below the threshold, got %+v", test)
+	}
+	if test := tests["missing"]; test != nil {
+		t.Errorf("expected no test result when a metric of the expression is not reported, got %+v", test)
+	}
+}
+
+func TestUpdateEvaluationJob_OptionalBenchmarks(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-optional")
+	store = store.WithTenant(tenant)
+	threshold := float32(0.5)
+	if err := store.CreateProvider(&api.ProviderResource{
+		Resource: api.Resource{ID: "optional-provider", Tenant: tenant, CreatedAt: time.Now()},
+		ProviderConfig: api.ProviderConfig{
+			Name: "Optional Provider",
+			Benchmarks: []api.BenchmarkResource{
+				{ID: "core", PrimaryScore: &api.PrimaryScore{Metric: "acc"}, PassCriteria: &api.PassCriteria{Threshold: &threshold}},
+				{ID: "extra", PrimaryScore: &api.PrimaryScore{Metric: "acc"}, PassCriteria: &api.PassCriteria{Threshold: &threshold}},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("CreateProvider: %v", err)
+	}
+	optional := false
+
+	// runJob fails the benchmark at the failed index and completes the others
+	runJob := func(failed int) *api.EvaluationJobResource {
+		t.Helper()
+		jobID := common.GUID()
+		if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, CreatedAt: time.Now()}},
+			Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Model: api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+				Benchmarks: []api.EvaluationBenchmarkConfig{
+					{Ref: api.Ref{ID: "core"}, ProviderID: "optional-provider"},
+					{Ref: api.Ref{ID: "core"}, ProviderID: "optional-provider"},
+					{Ref: api.Ref{ID: "extra"}, ProviderID: "optional-provider", Required: &optional},
+				},
+			},
+		}); err != nil {
+			t.Fatalf("CreateEvaluationJob: %v", err)
+		}
+		for index, id := range []string{"core", "core", "extra"} {
+			event := &api.BenchmarkStatusEvent{
+				ProviderID:     "optional-provider",
+				ID:             id,
+				BenchmarkIndex: index,
+				Status:         api.StateCompleted,
+				Metrics:        map[string]any{"acc": 0.9},
+			}
+			if index == failed {
+				event.Status = api.StateFailed
+				event.Metrics = nil
+				event.ErrorMessage = &api.MessageInfo{Message: "adapter crashed", MessageCode: "adapter_error"}
+			}
+			if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: event}); err != nil {
+				t.Fatalf("UpdateEvaluationJob(%d): %v", index, err)
+			}
+		}
+		job, err := store.GetEvaluationJob(jobID)
+		if err != nil {
+			t.Fatalf("GetEvaluationJob: %v", err)
+		}
+		if job.Status.State != api.OverallStatePartiallyFailed {
+			t.Fatalf("expected the job to be partially failed, got %s", job.Status.State)
+		}
+		return job
+	}
+
+	job := runJob(2)
+	if test := job.Results.Test; test == nil || !test.Pass || math.Abs(float64(test.Score)-0.9) > 1e-6 || len(test.FailedRequired) != 0 {
+		t.Errorf("expected the job to pass on its required benchmarks when an optional one fails, got %+v", test)
+	}
+
+	job = runJob(1)
+	if test := job.Results.Test; test == nil || test.Pass || len(test.FailedRequired) != 1 || test.FailedRequired[0] != "core" {
+		t.Errorf("expected a failed required benchmark to fail the job, got %+v", test)
+	}
+}
+
+func TestUpdateEvaluationJob_RetryTransientFailures(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-retry")
+	store = store.WithTenant(tenant)
+	if err := store.CreateProvider(&api.ProviderResource{
+		Resource: api.Resource{ID: "retry-provider", Tenant: tenant, CreatedAt: time.Now()},
+		ProviderConfig: api.ProviderConfig{
+			Name:       "Retry Provider",
+			Benchmarks: []api.BenchmarkResource{{ID: "mmlu"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateProvider: %v", err)
+	}
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model: api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{
+				{Ref: api.Ref{ID: "mmlu"}, ProviderID: "retry-provider", Retry: &api.RetryPolicy{MaxRetries: 1, BackoffSeconds: 30}},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+	fail := func(code string) *abstractions.EvaluationJobUpdate {
+		t.Helper()
+		update, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+			ProviderID:   "retry-provider",
+			ID:           "mmlu",
+			Status:       api.StateFailed,
+			StartedAt:    api.DateTimeToString(time.Now().Add(-time.Minute)),
+			ErrorMessage: &api.MessageInfo{Message: "model returned 503", MessageCode: code},
+		}})
+		if err != nil {
+			t.Fatalf("UpdateEvaluationJob: %v", err)
+		}
+		return update
+	}
+
+	update := fail(constants.MESSAGE_CODE_MODEL_UNAVAILABLE)
+	if len(update.RetryBenchmarks) != 1 || update.RetryBenchmarks[0].BenchmarkIndex != 0 || update.RetryBenchmarks[0].Backoff != 30*time.Second {
+		t.Fatalf("expected the benchmark to be retried after 30s, got %+v", update.RetryBenchmarks)
+	}
+	job, err := store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	benchmark := job.Status.Benchmarks[0]
+	if benchmark.Status != api.StatePending || len(benchmark.Attempts) != 1 || benchmark.Attempts[0].ErrorMessage.MessageCode != constants.MESSAGE_CODE_MODEL_UNAVAILABLE {
+		t.Fatalf("expected the benchmark to be pending with one failed attempt, got %+v", benchmark)
+	}
+	if job.Status.State == api.OverallStateFailed {
+		t.Fatalf("expected the job not to fail while its benchmark is retried")
+	}
+
+	// the retries are used up
+	update = fail(constants.MESSAGE_CODE_MODEL_UNAVAILABLE)
+	if len(update.RetryBenchmarks) != 0 {
+		t.Fatalf("expected no more retries, got %+v", update.RetryBenchmarks)
+	}
+	job, err = store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if benchmark := job.Status.Benchmarks[0]; benchmark.Status != api.StateFailed || len(benchmark.Attempts) != 1 {
+		t.Fatalf("expected the benchmark to fail keeping its attempts, got %+v", benchmark)
+	}
+	if job.Status.State != api.OverallStateFailed {
+		t.Fatalf("expected the job to fail, got %s", job.Status.State)
+	}
+}
+
+func TestRetryBenchmark(t *testing.T) {
+	benchmark := &api.EvaluationBenchmarkConfig{Retry: &api.RetryPolicy{MaxRetries: 3, BackoffSeconds: 10, RetryOn: []string{"rate_limited"}}}
+	failed := func(code string) *api.BenchmarkStatusEvent {
+		return &api.BenchmarkStatusEvent{ID: "mmlu", Status: api.StateFailed, ErrorMessage: &api.MessageInfo{Message: "failed", MessageCode: code}}
+	}
+
+	if _, _, retry := sql.RetryBenchmark(benchmark, nil, failed(constants.MESSAGE_CODE_OOM_KILLED), nil); retry != nil {
+		t.Errorf("expected a failure not listed in retry_on not to be retried")
+	}
+	attempts := []api.BenchmarkAttempt{{Attempt: 1}}
+	event, attempts, retry := sql.RetryBenchmark(benchmark, nil, failed("rate_limited"), attempts)
+	if retry == nil || retry.Backoff != 20*time.Second || event.Status != api.StatePending || len(attempts) != 2 || attempts[1].Attempt != 2 {
+		t.Errorf("expected the second retry after a doubled backoff, got %+v %+v %+v", retry, event, attempts)
+	}
+	if _, _, retry := sql.RetryBenchmark(benchmark, nil, failed("rate_limited"), make([]api.BenchmarkAttempt, 3)); retry != nil {
+		t.Errorf("expected no retry once max_retries is reached")
+	}
+	if _, _, retry := sql.RetryBenchmark(&api.EvaluationBenchmarkConfig{}, nil, failed(constants.MESSAGE_CODE_OOM_KILLED), nil); retry != nil {
+		t.Errorf("expected a benchmark without a retry policy not to be retried")
+	}
+}
@@ -0,0 +1,82 @@
+package sql
+
+import (
+	"database/sql"
+	"slices"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// staleStatusEvent returns why the event is not to be applied to the benchmark, empty when it
+// is. The event of a shard is compared to the last event applied to the shard, others to the
+// last event applied to the benchmark. An event with the ID or the sequence of that event is
+// a duplicate, one with a lower sequence, or one that would reopen a finished benchmark or
+// shard, is out of order. Sequences are only compared between events of the same source, see
+// eventSource, since the sequence of a new run of the adapter, e.g. in a restarted pod,
+// starts over.
+func staleStatusEvent(current *api.BenchmarkStatus, event *api.BenchmarkStatusEvent) string {
+	if current == nil {
+		return ""
+	}
+	status, eventID, sequence := current.Status, current.EventID, current.Sequence
+	if event.ShardIndex != nil && len(current.Shards) > 0 {
+		i := slices.IndexFunc(current.Shards, func(shard api.BenchmarkShardStatus) bool {
+			return shard.ShardIndex == *event.ShardIndex
+		})
+		if i < 0 {
+			return ""
+		}
+		shard := current.Shards[i]
+		status, eventID, sequence = shard.Status, shard.EventID, shard.Sequence
+	}
+	sameSource := eventSource(event.EventID) == eventSource(eventID)
+	switch {
+	case event.EventID != "" && event.EventID == eventID:
+		return api.StatusEventDuplicate
+	case sameSource && event.Sequence > 0 && event.Sequence == sequence:
+		return api.StatusEventDuplicate
+	case sameSource && event.Sequence > 0 && event.Sequence < sequence:
+		return api.StatusEventOutOfOrder
+	case api.IsBenchmarkTerminalState(status) && !api.IsBenchmarkTerminalState(event.Status):
+		return api.StatusEventOutOfOrder
+	}
+	return ""
+}
+
+// eventSource returns the source of an event ID of the form <source>:<number>, e.g. the pod of
+// the result relay of the sidecar, and an empty source for other IDs.
+func eventSource(eventID string) string {
+	if i := strings.LastIndex(eventID, ":"); i >= 0 {
+		return eventID[:i]
+	}
+	return ""
+}
+
+// retriedStatusEvent tells whether an event rejected for a job that already finished is the
+// last event of its benchmark posted again, e.g. the completed event that finished the job.
+func (s *sqlStorage) retriedStatusEvent(txn *sql.Tx, job *api.EvaluationJobResource, event *api.BenchmarkStatusEvent) bool {
+	if _, err := s.readJobBenchmarks(txn, job, &event.BenchmarkIndex); err != nil {
+		return false
+	}
+	return staleStatusEvent(findBenchmarkStatus(job, event), event) == api.StatusEventDuplicate
+}
+
+func (s *sqlStorage) logIgnoredStatusEvent(id string, reason string, current *api.BenchmarkStatus, event *api.BenchmarkStatusEvent) {
+	args := []any{
+		"id", id,
+		"reason", reason,
+		"benchmark_id", event.ID,
+		"benchmark_index", event.BenchmarkIndex,
+		"status", event.Status,
+		"event_id", event.EventID,
+		"sequence", event.Sequence,
+	}
+	if event.ShardIndex != nil {
+		args = append(args, "shard_index", *event.ShardIndex)
+	}
+	if current != nil {
+		args = append(args, "benchmark_status", current.Status, "last_event_id", current.EventID, "last_sequence", current.Sequence)
+	}
+	s.logger.Warn("Ignoring a status event of evaluation job", args...)
+}
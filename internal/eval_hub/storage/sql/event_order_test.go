@@ -0,0 +1,125 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestUpdateEvaluationJobIgnoresStaleEvents(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-event-order")
+	store = store.WithTenant(tenant)
+
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, Owner: "alice", CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePending}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Name:  "event-order",
+			Model: api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{
+				{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness"},
+				{Ref: api.Ref{ID: "hellaswag"}, ProviderID: "lm_evaluation_harness"},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+	sendFor := func(index int, status api.State, eventID string, sequence int64, metrics map[string]any) *abstractions.EvaluationJobUpdate {
+		t.Helper()
+		event := &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+			ProviderID:     "lm_evaluation_harness",
+			ID:             []string{"mmlu", "hellaswag"}[index],
+			BenchmarkIndex: index,
+			Status:         status,
+			EventID:        eventID,
+			Sequence:       sequence,
+			Metrics:        metrics,
+		}}
+		update, err := store.UpdateEvaluationJob(jobID, event)
+		if err != nil {
+			t.Fatalf("UpdateEvaluationJob(%s, %d): %v", status, sequence, err)
+		}
+		return update
+	}
+	send := func(status api.State, eventID string, sequence int64, metrics map[string]any) *abstractions.EvaluationJobUpdate {
+		t.Helper()
+		return sendFor(0, status, eventID, sequence, metrics)
+	}
+
+	if update := send(api.StateRunning, "e1", 1, nil); update.Ignored != "" {
+		t.Fatalf("expected the first event to be applied, got %q", update.Ignored)
+	}
+	if update := send(api.StateRunning, "e1", 1, nil); update.Ignored != api.StatusEventDuplicate {
+		t.Errorf("expected a retried event to be a duplicate, got %q", update.Ignored)
+	}
+	if update := send(api.StateRunning, "e2", 2, nil); update.Ignored != "" {
+		t.Errorf("expected the next event to be applied, got %q", update.Ignored)
+	}
+	if update := send(api.StateRunning, "e0", 0, nil); update.Ignored != "" {
+		t.Errorf("expected an event without a sequence to be applied, got %q", update.Ignored)
+	}
+	// the result relay of a restarted pod numbers its events from the start of a new file
+	if update := send(api.StateRunning, "pod-a:120", 121, nil); update.Ignored != "" {
+		t.Errorf("expected the event of the relay to be applied, got %q", update.Ignored)
+	}
+	if update := send(api.StateRunning, "pod-a:40", 41, nil); update.Ignored != api.StatusEventOutOfOrder {
+		t.Errorf("expected an earlier event of the same pod to be out of order, got %q", update.Ignored)
+	}
+	if update := send(api.StateRunning, "pod-b:0", 1, nil); update.Ignored != "" {
+		t.Errorf("expected the first event of a restarted pod to be applied, got %q", update.Ignored)
+	}
+	if update := send(api.StateRunning, "e3", 3, nil); update.Ignored != "" {
+		t.Errorf("expected an event of the adapter after the relay to be applied, got %q", update.Ignored)
+	}
+	if update := send(api.StateCompleted, "e4", 4, map[string]any{"accuracy": 0.8}); update.Ignored != "" {
+		t.Fatalf("expected the completed event to be applied, got %q", update.Ignored)
+	}
+
+	// a running event that arrives after the completed one
+	if update := send(api.StateRunning, "e3", 3, nil); update.Ignored != api.StatusEventOutOfOrder {
+		t.Errorf("expected a late event to be out of order, got %q", update.Ignored)
+	}
+	if update := send(api.StateRunning, "", 0, nil); update.Ignored != api.StatusEventOutOfOrder {
+		t.Errorf("expected an event that reopens the benchmark to be out of order, got %q", update.Ignored)
+	}
+	if update := send(api.StateCompleted, "e4", 4, map[string]any{"accuracy": 0.1}); update.Ignored != api.StatusEventDuplicate {
+		t.Errorf("expected the retried completed event to be a duplicate, got %q", update.Ignored)
+	}
+
+	// the completed event that finished the job posted again
+	if update := sendFor(1, api.StateCompleted, "h1", 1, nil); update.Ignored != "" {
+		t.Fatalf("expected the completed event to be applied, got %q", update.Ignored)
+	}
+	if update := sendFor(1, api.StateCompleted, "h1", 1, nil); update.Ignored != api.StatusEventDuplicate {
+		t.Errorf("expected the retried completed event of a finished job to be a duplicate, got %q", update.Ignored)
+	}
+
+	job, err := store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if job.Status.State != api.OverallStateCompleted || len(job.Status.Benchmarks) != 2 {
+		t.Fatalf("expected the job to be completed, got %+v", job.Status)
+	}
+	if benchmark := job.Status.Benchmarks[0]; benchmark.Status != api.StateCompleted || benchmark.EventID != "e4" || benchmark.Sequence != 4 {
+		t.Errorf("expected the completed event to be the last one applied, got %+v", benchmark)
+	}
+	if job.Results == nil || len(job.Results.Benchmarks) != 2 || job.Results.Benchmarks[0].Metrics["accuracy"] != 0.8 {
+		t.Errorf("expected the results of the first completed event, got %+v", job.Results)
+	}
+
+	// other events of a finished job are still rejected
+	if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+		ProviderID: "lm_evaluation_harness", ID: "mmlu", Status: api.StateCompleted, EventID: "e5", Sequence: 5,
+	}}); err == nil {
+		t.Error("expected a new event of a finished job to be rejected")
+	}
+}
@@ -0,0 +1,61 @@
+package sql
+
+import (
+	"database/sql"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	se "github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The MLflow experiment of a job is resolved in the background, once the job is stored, and
+// linked to the job when it is: the experiment id is in its own column, the URL in the
+// results of the entity. The jobs that have an experiment name and no experiment id are the
+// ones still waiting for their experiment.
+
+func (s *sqlStorage) LinkEvaluationJobExperiment(id string, experimentID string, experimentURL string) error {
+	return s.withTransaction("link evaluation job experiment", id, func(txn *sql.Tx) error {
+		job, stored, err := s.getEvaluationJobTransactionalForUpdate(txn, id)
+		if err != nil {
+			return err
+		}
+		if job.Results == nil {
+			job.Results = &api.EvaluationJobResults{}
+		}
+		job.Results.MLFlowExperimentURL = experimentURL
+		if err := s.updateEvaluationJobTxn(txn, id, job.Status.State, job, stored); err != nil {
+			return err
+		}
+		updateQuery, args := s.statementsFactory.CreateUpdateEvaluationExperimentStatement(s.tenant, id, experimentID)
+		if _, err := s.exec(txn, updateQuery, args...); err != nil {
+			s.logger.Error("Failed to link evaluation job experiment", "error", err, "id", id, "experiment_id", experimentID)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job", "ResourceId", id, "Error", err.Error()))
+		}
+		s.logger.Info("Linked evaluation job experiment", "id", id, "experiment_id", experimentID)
+		return nil
+	})
+}
+
+// GetEvaluationJobsWithoutExperiment returns the jobs of every tenant that have an experiment
+// name but no experiment id, the newest first, with their id, tenant and creation time.
+func (s *sqlStorage) GetEvaluationJobsWithoutExperiment(limit int) ([]api.Resource, error) {
+	listQuery, args := s.statementsFactory.CreateEvaluationsWithoutExperimentStatement(limit)
+	rows, err := s.query(nil, listQuery, args...)
+	if err != nil {
+		s.logger.Error("Failed to list the evaluation jobs without experiment", "error", err)
+		return nil, se.NewServiceError(messages.QueryFailed, "Type", "evaluation job", "Error", err.Error())
+	}
+	defer func() { _ = rows.Close() }()
+	jobs := make([]api.Resource, 0)
+	for rows.Next() {
+		var job api.Resource
+		if err := rows.Scan(&job.ID, &job.Tenant, &job.CreatedAt); err != nil {
+			return nil, se.NewServiceError(messages.QueryFailed, "Type", "evaluation job", "Error", err.Error())
+		}
+		jobs = append(jobs, job)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, se.NewServiceError(messages.QueryFailed, "Type", "evaluation job", "Error", err.Error())
+	}
+	return jobs, nil
+}
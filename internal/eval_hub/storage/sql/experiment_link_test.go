@@ -0,0 +1,79 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestLinkEvaluationJobExperiment(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-experiment-link")
+	store = store.WithTenant(tenant)
+
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, Owner: "alice", CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Name:       "experiment-link",
+			Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness"}},
+			Experiment: &api.ExperimentConfig{Name: "demo"},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+
+	pending, err := store.GetEvaluationJobsWithoutExperiment(100)
+	if err != nil {
+		t.Fatalf("GetEvaluationJobsWithoutExperiment: %v", err)
+	}
+	if !containsJob(pending, jobID) {
+		t.Fatalf("expected job %s to be without experiment, got %+v", jobID, pending)
+	}
+
+	if err := store.LinkEvaluationJobExperiment(jobID, "exp-1", "http://mlflow:5000/#/experiments"); err != nil {
+		t.Fatalf("LinkEvaluationJobExperiment: %v", err)
+	}
+
+	job, err := store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if job.Resource.MLFlowExperimentID != "exp-1" {
+		t.Errorf("experiment id = %q, want exp-1", job.Resource.MLFlowExperimentID)
+	}
+	if job.Results == nil || job.Results.MLFlowExperimentURL != "http://mlflow:5000/#/experiments" {
+		t.Errorf("expected the experiment URL in the results, got %+v", job.Results)
+	}
+	if job.Status.State != api.OverallStateRunning {
+		t.Errorf("state = %s, the link must not change the state", job.Status.State)
+	}
+
+	pending, err = store.GetEvaluationJobsWithoutExperiment(100)
+	if err != nil {
+		t.Fatalf("GetEvaluationJobsWithoutExperiment: %v", err)
+	}
+	if containsJob(pending, jobID) {
+		t.Errorf("expected the linked job %s not to be without experiment", jobID)
+	}
+
+	if err := store.LinkEvaluationJobExperiment("missing", "exp-1", ""); err == nil {
+		t.Error("expected an error for a missing job")
+	}
+}
+
+func containsJob(jobs []api.Resource, id string) bool {
+	for _, job := range jobs {
+		if job.ID == id {
+			return true
+		}
+	}
+	return false
+}
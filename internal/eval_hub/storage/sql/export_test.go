@@ -1,6 +1,58 @@
 package sql
 
+import (
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+)
+
 var ApplyPatches = applyPatches
 var GetPassCriteriaThreshold = getPassCriteriaThreshold
 var GetIsolationLevel = getIsolationLevel
 var SetEvaluationJobUpdateAfterLockedReadHook = setEvaluationJobUpdateAfterLockedReadHook
+var StatementLabels = statementLabels
+var RetryBenchmark = retryBenchmark
+
+func PreparedStatementCount(s abstractions.Storage) int {
+	return s.(*sqlStorage).statements.size()
+}
+
+// ExecSQL runs a statement on the database of the storage, to set up rows as an older
+// version wrote them.
+func ExecSQL(s abstractions.Storage, query string, args ...any) error {
+	_, err := s.(*sqlStorage).pool.Exec(query, args...)
+	return err
+}
+
+// QueryInt returns the integer that a query of the database of the storage selects.
+func QueryInt(s abstractions.Storage, query string, args ...any) (int, error) {
+	var value int
+	err := s.(*sqlStorage).pool.QueryRow(query, args...).Scan(&value)
+	return value, err
+}
+
+// QueryString returns the text that a query of the database of the storage selects.
+func QueryString(s abstractions.Storage, query string, args ...any) (string, error) {
+	var value string
+	err := s.(*sqlStorage).pool.QueryRow(query, args...).Scan(&value)
+	return value, err
+}
+
+// WithTimeouts returns the storage with other query and transaction timeouts, the ones of
+// the configuration also apply to the creation of the schema.
+func WithTimeouts(s abstractions.Storage, queryTimeout, transactionTimeout time.Duration) abstractions.Storage {
+	storage := *s.(*sqlStorage)
+	config := *storage.sqlConfig
+	config.QueryTimeout, config.TransactionTimeout = &queryTimeout, &transactionTimeout
+	storage.sqlConfig = &config
+	return &storage
+}
+
+// WithSlowQueryThreshold returns the storage with another slow query threshold.
+func WithSlowQueryThreshold(s abstractions.Storage, threshold time.Duration) abstractions.Storage {
+	storage := *s.(*sqlStorage)
+	config := *storage.sqlConfig
+	config.SlowQueryThreshold = &threshold
+	storage.sqlConfig = &config
+	return &storage
+}
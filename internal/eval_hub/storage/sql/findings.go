@@ -0,0 +1,133 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	se "github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The safety findings of a benchmark are stored in rows of the evaluation_findings table,
+// one per finding, so that they can be filtered by severity, probe and detector rather than
+// being read with the result of the benchmark. The result of the benchmark, and the results
+// of the job, only hold the numbers of findings by severity.
+
+// writeFindings replaces the findings of the benchmark of the event, or of its shard when the
+// benchmark is sharded, with the findings of the event.
+func (s *sqlStorage) writeFindings(txn *sql.Tx, id string, event *api.BenchmarkStatusEvent) error {
+	shardIndex := shared.NoShard
+	if event.ShardIndex != nil {
+		shardIndex = *event.ShardIndex
+	}
+	deleteQuery, args := s.statementsFactory.CreateEvaluationFindingsDeleteStatement(id, &event.BenchmarkIndex, shardIndex)
+	if _, err := s.exec(txn, deleteQuery, args...); err != nil {
+		s.logger.Error("Failed to delete the findings of evaluation job", "error", err, "id", id, "benchmark_index", event.BenchmarkIndex)
+		return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job findings", "ResourceId", id, "Error", err.Error()))
+	}
+	for i := range event.Findings {
+		finding := &event.Findings[i]
+		entity, err := json.Marshal(finding)
+		if err != nil {
+			return se.WithRollback(se.NewServiceError(messages.InternalServerError, "Error", err.Error()))
+		}
+		insertQuery, args := s.statementsFactory.CreateEvaluationFindingInsertStatement(id, event.BenchmarkIndex, shardIndex, i, event.ProviderID, event.ID, finding, string(entity))
+		if _, err := s.exec(txn, insertQuery, args...); err != nil {
+			s.logger.Error("Failed to write the finding of evaluation job", "error", err, "id", id, "benchmark_index", event.BenchmarkIndex, "finding_index", i)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job findings", "ResourceId", id, "Error", err.Error()))
+		}
+	}
+	return nil
+}
+
+// summarizeFindings returns the numbers of findings of the benchmark by severity, with the
+// ones of all its shards, or nil when it has none.
+func (s *sqlStorage) summarizeFindings(txn *sql.Tx, id string, benchmarkIndex int) (*api.FindingsSummary, error) {
+	countQuery, args := s.statementsFactory.CreateEvaluationFindingsSeverityCountStatement(id, benchmarkIndex)
+	rows, err := s.query(txn, countQuery, args...)
+	if err != nil {
+		s.logger.Error("Failed to count the findings of evaluation job", "error", err, "id", id, "benchmark_index", benchmarkIndex)
+		return nil, se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job findings", "ResourceId", id, "Error", err.Error()))
+	}
+	defer func() { _ = rows.Close() }()
+	counts := map[api.Severity]int{}
+	for rows.Next() {
+		var severity api.Severity
+		var count int
+		if err := rows.Scan(&severity, &count); err != nil {
+			return nil, se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job findings", "ResourceId", id, "Error", err.Error()))
+		}
+		counts[severity] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job findings", "ResourceId", id, "Error", err.Error()))
+	}
+	return api.NewFindingsSummary(counts), nil
+}
+
+// rollupFindings sets the numbers of findings of the job by severity from the ones of its
+// benchmarks.
+func rollupFindings(job *api.EvaluationJobResource) {
+	if job.Results == nil {
+		return
+	}
+	var summary *api.FindingsSummary
+	for _, result := range job.Results.Benchmarks {
+		if result.Findings != nil {
+			summary = summary.Add(result.Findings)
+		}
+	}
+	job.Results.Findings = summary
+}
+
+// GetEvaluationJobFindings returns the findings of the job that match the filter, the most
+// severe first. The filter params are the ones of shared.FindingsWhere.
+func (s *sqlStorage) GetEvaluationJobFindings(id string, filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.FindingResource], error) {
+	// the job is read in the scope of the storage, the findings are not scoped to a tenant
+	if _, err := s.scanEvaluationJobTransactional(nil, id, false); err != nil {
+		return nil, err
+	}
+
+	var total int
+	countQuery, args := s.statementsFactory.CreateEvaluationFindingsCountStatement(id, filter.Params)
+	if err := s.queryRow(nil, countQuery, args...).Scan(&total); err != nil {
+		s.logger.Error("Failed to count the findings of evaluation job", "error", err, "id", id)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job findings", "ResourceId", id, "Error", err.Error())
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = math.MaxInt32
+	}
+	listQuery, args := s.statementsFactory.CreateEvaluationFindingsListStatement(id, filter.Params, limit, filter.Offset)
+	rows, err := s.query(nil, listQuery, args...)
+	if err != nil {
+		s.logger.Error("Failed to list the findings of evaluation job", "error", err, "id", id)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job findings", "ResourceId", id, "Error", err.Error())
+	}
+	defer func() { _ = rows.Close() }()
+	items := make([]api.FindingResource, 0)
+	for rows.Next() {
+		item := api.FindingResource{JobID: id}
+		var shardIndex int
+		var entity string
+		if err := rows.Scan(&item.ProviderID, &item.BenchmarkID, &item.BenchmarkIndex, &shardIndex, &entity); err != nil {
+			return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job findings", "ResourceId", id, "Error", err.Error())
+		}
+		if err := json.Unmarshal([]byte(entity), &item.Finding); err != nil {
+			return nil, se.NewServiceError(messages.JSONUnmarshalFailed, "Type", "evaluation job finding", "Error", err.Error())
+		}
+		if shardIndex != shared.NoShard {
+			item.ShardIndex = &shardIndex
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job findings", "ResourceId", id, "Error", err.Error())
+	}
+	return &abstractions.QueryResults[api.FindingResource]{Items: items, TotalCount: total}, nil
+}
@@ -0,0 +1,123 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestUpdateEvaluationJob_Findings(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-findings")
+	store = store.WithTenant(tenant)
+	if err := store.CreateProvider(&api.ProviderResource{
+		Resource: api.Resource{ID: "garak", Tenant: tenant, CreatedAt: time.Now()},
+		ProviderConfig: api.ProviderConfig{
+			Name:       "Garak",
+			Benchmarks: []api.BenchmarkResource{{ID: "dan"}, {ID: "encoding"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateProvider: %v", err)
+	}
+
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model: api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{
+				{Ref: api.Ref{ID: "dan"}, ProviderID: "garak"},
+				{Ref: api.Ref{ID: "encoding"}, ProviderID: "garak"},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+
+	complete := func(id string, index int, findings ...api.Finding) {
+		t.Helper()
+		if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+			ProviderID:     "garak",
+			ID:             id,
+			BenchmarkIndex: index,
+			Status:         api.StateCompleted,
+			Metrics:        map[string]any{"attack_success_rate": 0.1},
+			Findings:       findings,
+		}}); err != nil {
+			t.Fatalf("UpdateEvaluationJob(%s): %v", id, err)
+		}
+	}
+	complete("dan", 0, api.Finding{Probe: "dan.Dan_11_0", Severity: api.SeverityInfo})
+	// a redelivered event replaces the findings of the benchmark
+	complete("dan", 0,
+		api.Finding{Probe: "dan.Dan_11_0", Detector: "dan.DAN", Severity: api.SeverityCritical},
+		api.Finding{Probe: "dan.AutoDAN", Detector: "mitigation.MitigationBypass", Severity: api.SeverityLow},
+	)
+	complete("encoding", 1,
+		api.Finding{Probe: "encoding.InjectBase64", Detector: "encoding.DecodeMatch", Severity: api.SeverityHigh},
+		api.Finding{Probe: "encoding.InjectHex", Detector: "encoding.DecodeMatch", Severity: api.SeverityMedium},
+	)
+
+	job, err := store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	for _, result := range job.Results.Benchmarks {
+		if result.Findings == nil || result.Findings.Total != 2 {
+			t.Errorf("expected 2 findings for benchmark %s, got %+v", result.ID, result.Findings)
+		}
+	}
+	if summary := job.Results.Findings; summary == nil || summary.Total != 4 || summary.HighestSeverity != api.SeverityCritical || summary.BySeverity[api.SeverityInfo] != 0 {
+		t.Errorf("expected the findings of both benchmarks in the job results, got %+v", summary)
+	}
+
+	list := func(params map[string]any, limit int) *abstractions.QueryResults[api.FindingResource] {
+		t.Helper()
+		findings, err := store.GetEvaluationJobFindings(jobID, &abstractions.QueryFilter{Limit: limit, Params: params})
+		if err != nil {
+			t.Fatalf("GetEvaluationJobFindings(%v): %v", params, err)
+		}
+		return findings
+	}
+	all := list(map[string]any{}, 0)
+	if all.TotalCount != 4 || len(all.Items) != 4 {
+		t.Fatalf("expected 4 findings, got %+v", all)
+	}
+	for i, severity := range []api.Severity{api.SeverityCritical, api.SeverityHigh, api.SeverityMedium, api.SeverityLow} {
+		if all.Items[i].Severity != severity {
+			t.Errorf("expected finding %d to be %s, got %s", i, severity, all.Items[i].Severity)
+		}
+	}
+	if first := all.Items[0]; first.BenchmarkID != "dan" || first.ProviderID != "garak" || first.Detector != "dan.DAN" || first.ShardIndex != nil {
+		t.Errorf("unexpected finding %+v", first)
+	}
+	if page := list(map[string]any{}, 1); page.TotalCount != 4 || len(page.Items) != 1 {
+		t.Errorf("expected one of 4 findings, got %+v", page)
+	}
+	if high := list(map[string]any{"min_severity": api.SeverityHigh.Rank()}, 0); high.TotalCount != 2 {
+		t.Errorf("expected 2 findings of high severity or more, got %+v", high)
+	}
+	if low := list(map[string]any{"severity": []api.Severity{api.SeverityLow, api.SeverityMedium}}, 0); low.TotalCount != 2 {
+		t.Errorf("expected 2 low or medium findings, got %+v", low)
+	}
+	if encoding := list(map[string]any{"benchmark_index": 1, "detector": "encoding.DecodeMatch"}, 0); encoding.TotalCount != 2 {
+		t.Errorf("expected 2 findings of the encoding benchmark, got %+v", encoding)
+	}
+	if probe := list(map[string]any{"probe": "encoding.InjectHex"}, 0); probe.TotalCount != 1 || probe.Items[0].Severity != api.SeverityMedium {
+		t.Errorf("expected the finding of the probe, got %+v", probe)
+	}
+
+	if err := store.DeleteEvaluationJob(jobID); err != nil {
+		t.Fatalf("DeleteEvaluationJob: %v", err)
+	}
+	if _, err := store.GetEvaluationJobFindings(jobID, &abstractions.QueryFilter{}); err == nil {
+		t.Errorf("expected the findings of a deleted job not to be found")
+	}
+}
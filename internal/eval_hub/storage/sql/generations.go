@@ -0,0 +1,102 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	se "github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The raw generations of a benchmark are stored encrypted in the artifact store, the
+// evaluation_generations table holds their metadata like evaluation_artifacts does for the
+// other artifacts. They are kept apart so that listing the artifacts of a job does not
+// reveal them to the users who can not read them.
+
+// PutEvaluationJobGeneration stores the metadata of the generations of a benchmark of the
+// job, replacing the metadata of a previous upload with the same name.
+func (s *sqlStorage) PutEvaluationJobGeneration(id string, generation *api.ArtifactResource) error {
+	return s.withTransaction("put evaluation job generation", id, func(txn *sql.Tx) error {
+		if _, err := s.scanEvaluationJobTransactional(txn, id, false); err != nil {
+			return err
+		}
+		entity, err := json.Marshal(generation)
+		if err != nil {
+			return se.WithRollback(se.NewServiceError(messages.InternalServerError, "Error", err.Error()))
+		}
+		upsertQuery, args := s.statementsFactory.CreateEvaluationGenerationUpsertStatement(id, generation.BenchmarkIndex, generation.Name, generation.UploadedAt, string(entity))
+		if _, err := s.exec(txn, upsertQuery, args...); err != nil {
+			s.logger.Error("Failed to write the generations of evaluation job", "error", err, "id", id, "benchmark_index", generation.BenchmarkIndex, "name", generation.Name)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job generations", "ResourceId", id, "Error", err.Error()))
+		}
+		return nil
+	})
+}
+
+// GetEvaluationJobGeneration returns the metadata of the generations of a benchmark of the
+// job with the name.
+func (s *sqlStorage) GetEvaluationJobGeneration(id string, benchmarkIndex int, name string) (*api.ArtifactResource, error) {
+	if _, err := s.scanEvaluationJobTransactional(nil, id, false); err != nil {
+		return nil, err
+	}
+
+	var entity string
+	getQuery, args := s.statementsFactory.CreateEvaluationGenerationGetStatement(id, benchmarkIndex, name)
+	if err := s.queryRow(nil, getQuery, args...).Scan(&entity); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, se.NewServiceError(messages.ResourceNotFound, "Type", "evaluation job generations", "ResourceId", name)
+		}
+		s.logger.Error("Failed to read the generations of evaluation job", "error", err, "id", id, "name", name)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job generations", "ResourceId", id, "Error", err.Error())
+	}
+	var generation api.ArtifactResource
+	if err := json.Unmarshal([]byte(entity), &generation); err != nil {
+		return nil, se.NewServiceError(messages.JSONUnmarshalFailed, "Type", "evaluation job generations", "Error", err.Error())
+	}
+	return &generation, nil
+}
+
+// GetEvaluationJobGenerations returns the generations of a benchmark of the job, by name.
+func (s *sqlStorage) GetEvaluationJobGenerations(id string, benchmarkIndex int, filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.ArtifactResource], error) {
+	if _, err := s.scanEvaluationJobTransactional(nil, id, false); err != nil {
+		return nil, err
+	}
+
+	var total int
+	countQuery, args := s.statementsFactory.CreateEvaluationGenerationsCountStatement(id, benchmarkIndex)
+	if err := s.queryRow(nil, countQuery, args...).Scan(&total); err != nil {
+		s.logger.Error("Failed to count the generations of evaluation job", "error", err, "id", id)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job generations", "ResourceId", id, "Error", err.Error())
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = math.MaxInt32
+	}
+	listQuery, args := s.statementsFactory.CreateEvaluationGenerationsListStatement(id, benchmarkIndex, limit, filter.Offset)
+	rows, err := s.query(nil, listQuery, args...)
+	if err != nil {
+		s.logger.Error("Failed to list the generations of evaluation job", "error", err, "id", id)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job generations", "ResourceId", id, "Error", err.Error())
+	}
+	defer func() { _ = rows.Close() }()
+	items := make([]api.ArtifactResource, 0)
+	for rows.Next() {
+		var entity string
+		if err := rows.Scan(&entity); err != nil {
+			return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job generations", "ResourceId", id, "Error", err.Error())
+		}
+		var item api.ArtifactResource
+		if err := json.Unmarshal([]byte(entity), &item); err != nil {
+			return nil, se.NewServiceError(messages.JSONUnmarshalFailed, "Type", "evaluation job generations", "Error", err.Error())
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job generations", "ResourceId", id, "Error", err.Error())
+	}
+	return &abstractions.QueryResults[api.ArtifactResource]{Items: items, TotalCount: total}, nil
+}
@@ -0,0 +1,82 @@
+package sql_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestEvaluationJobGenerations(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-generations")
+	store = store.WithTenant(tenant)
+
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+
+	put := func(name string, size int64) {
+		t.Helper()
+		if err := store.PutEvaluationJobGeneration(jobID, &api.ArtifactResource{
+			JobID:       jobID,
+			Name:        name,
+			ContentType: "application/jsonl",
+			Size:        size,
+			UploadedAt:  time.Now().UTC(),
+		}); err != nil {
+			t.Fatalf("PutEvaluationJobGeneration(%s): %v", name, err)
+		}
+	}
+	put("samples.jsonl", 10)
+	put("retries.jsonl", 5)
+	// a new upload replaces the generations
+	put("samples.jsonl", 20)
+
+	generations, err := store.GetEvaluationJobGenerations(jobID, 0, &abstractions.QueryFilter{})
+	if err != nil {
+		t.Fatalf("GetEvaluationJobGenerations: %v", err)
+	}
+	if generations.TotalCount != 2 || generations.Items[0].Name != "retries.jsonl" || generations.Items[1].Size != 20 {
+		t.Errorf("expected the generations by name with the last upload, got %+v", generations)
+	}
+	// the generations are kept apart from the artifacts
+	if artifacts, err := store.GetEvaluationJobArtifacts(jobID, 0, &abstractions.QueryFilter{}); err != nil || artifacts.TotalCount != 0 {
+		t.Errorf("expected no artifacts, got %+v, %v", artifacts, err)
+	}
+
+	generation, err := store.GetEvaluationJobGeneration(jobID, 0, "samples.jsonl")
+	if err != nil || generation.Size != 20 {
+		t.Errorf("expected the last upload of the generations, got %+v, %v", generation, err)
+	}
+	var se *serviceerrors.ServiceError
+	if _, err := store.GetEvaluationJobGeneration(jobID, 0, "missing.jsonl"); !errors.As(err, &se) || se.MessageCode() != messages.ResourceNotFound {
+		t.Errorf("expected missing generations not to be found, got %v", err)
+	}
+	if _, err := store.WithTenant("other-tenant").GetEvaluationJobGeneration(jobID, 0, "samples.jsonl"); err == nil {
+		t.Errorf("expected the job of another tenant not to be found")
+	}
+
+	if err := store.DeleteEvaluationJob(jobID); err != nil {
+		t.Fatalf("DeleteEvaluationJob: %v", err)
+	}
+	if _, err := store.GetEvaluationJobGenerations(jobID, 0, &abstractions.QueryFilter{}); err == nil {
+		t.Errorf("expected the generations of a deleted job not to be found")
+	}
+}
@@ -0,0 +1,82 @@
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/executioncontext"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/metrics"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql/shared"
+)
+
+// statementTable matches the table that a statement reads or writes.
+var statementTable = regexp.MustCompile(`(?i)\b(?:FROM|INTO|UPDATE|TABLE(?:\s+IF\s+NOT\s+EXISTS)?)\s+"?(\w+)`)
+
+// statementLabels returns the operation (select, insert, ...) and the table of a statement,
+// which label its metrics; the statement itself would make too many series.
+func statementLabels(query string) (string, string) {
+	operation, table := "", ""
+	if fields := strings.Fields(query); len(fields) > 0 {
+		operation = strings.ToLower(fields[0])
+	}
+	if match := statementTable.FindStringSubmatch(query); match != nil {
+		table = strings.ToLower(match[1])
+	}
+	return operation, table
+}
+
+func (s *sqlStorage) driver() string {
+	if s.sqlConfig == nil {
+		return ""
+	}
+	return s.sqlConfig.GetDriverName()
+}
+
+func (s *sqlStorage) slowQueryThreshold() time.Duration {
+	if s.sqlConfig == nil {
+		return shared.DefaultSlowQueryThreshold
+	}
+	return s.sqlConfig.GetSlowQueryThreshold()
+}
+
+// observeStatement records the duration of a statement that started at started, and logs it
+// when it is slow. A query that finds no row did not fail.
+func (s *sqlStorage) observeStatement(query string, args []any, started time.Time, err error) {
+	duration := time.Since(started)
+	operation, table := statementLabels(query)
+	failed := err != nil && !errors.Is(err, sql.ErrNoRows)
+	metrics.RecordStorageStatement(s.ctx, s.driver(), operation, table, duration, failed)
+	executioncontext.RecordStep(s.ctx, "storage "+operation+" "+table, duration, failed)
+	if threshold := s.slowQueryThreshold(); threshold > 0 && duration >= threshold {
+		s.logger.Warn("Slow database statement", "duration", duration.String(), "operation", operation, "table", table, "query", s.safeArg(query), "args", s.safeArgs(args))
+	}
+}
+
+// observeTransaction records the duration of a transaction that started at started, and logs
+// it when it is slow.
+func (s *sqlStorage) observeTransaction(name string, resourceID string, started time.Time, err error) {
+	duration := time.Since(started)
+	metrics.RecordStorageTransaction(s.ctx, s.driver(), name, duration, isDatabaseFailure(err))
+	executioncontext.RecordStep(s.ctx, "storage transaction "+name, duration, isDatabaseFailure(err))
+	if threshold := s.slowQueryThreshold(); threshold > 0 && duration >= threshold {
+		s.logger.Warn("Slow database transaction", "duration", duration.String(), "name", name, "resource_id", resourceID)
+	}
+}
+
+// isDatabaseFailure reports whether the error of a transaction comes from the database,
+// rather than from the checks of the operation, e.g. a job that can no longer be updated.
+func isDatabaseFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var serviceError abstractions.ServiceError
+	if errors.As(err, &serviceError) {
+		return serviceError.MessageCode() == messages.DatabaseOperationFailed || serviceError.MessageCode() == messages.QueryFailed
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package sql_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestStatementLabels(t *testing.T) {
+	tests := []struct {
+		query     string
+		operation string
+		table     string
+	}{
+		{"SELECT id, entity FROM evaluations WHERE id = ?", "select", "evaluations"},
+		{"INSERT INTO evaluation_benchmarks (job_id) VALUES ($1)", "insert", "evaluation_benchmarks"},
+		{"UPDATE evaluations SET status = ? WHERE id = ?", "update", "evaluations"},
+		{"DELETE FROM collections WHERE id = ?", "delete", "collections"},
+		{"CREATE TABLE IF NOT EXISTS providers (id TEXT)", "create", "providers"},
+		{"  select count(*) from \"Evaluations\"", "select", "evaluations"},
+		{"BEGIN", "begin", ""},
+	}
+	for _, tt := range tests {
+		operation, table := sql.StatementLabels(tt.query)
+		if operation != tt.operation || table != tt.table {
+			t.Errorf("StatementLabels(%q) = %q, %q, want %q, %q", tt.query, operation, table, tt.operation, tt.table)
+		}
+	}
+}
+
+func TestSlowQueryLogging(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	jobID := createBenchmarkRowsTestJob(t, sql.WithSlowQueryThreshold(store, 0).WithLogger(logger))
+	if strings.Contains(buf.String(), "Slow database") {
+		t.Fatalf("expected no slow query log with the threshold disabled, got %s", buf.String())
+	}
+
+	slow := sql.WithSlowQueryThreshold(store, 1).WithLogger(logger)
+	if _, err := slow.GetEvaluationJob(jobID); err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	updateBenchmarkRowsTestJob(t, slow, jobID, "b1", 0, api.StateRunning)
+	logs := buf.String()
+	if !strings.Contains(logs, `"msg":"Slow database statement"`) || !strings.Contains(logs, `"table":"evaluations"`) {
+		t.Fatalf("expected the statements to be logged as slow, got %s", logs)
+	}
+	if !strings.Contains(logs, `"msg":"Slow database transaction"`) {
+		t.Fatalf("expected the transaction to be logged as slow, got %s", logs)
+	}
+}
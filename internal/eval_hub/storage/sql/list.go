@@ -23,11 +23,15 @@ func getTypeFromTableName(tableName string) string {
 		return "providers"
 	case shared.TABLE_COLLECTIONS:
 		return "collections"
+	case shared.TABLE_BASELINES:
+		return "baselines"
+	case shared.TABLE_VIEWS:
+		return "evaluation views"
 	}
 	return "unknown"
 }
 
-func listEntities[T api.EvaluationJobResource | api.ProviderResource | api.CollectionResource](s *sqlStorage, txn *sql.Tx, tableName string, filter *abstractions.QueryFilter) (*abstractions.QueryResults[T], error) {
+func listEntities[T api.EvaluationJobResource | api.ProviderResource | api.CollectionResource | api.BaselineResource | api.EvaluationViewResource](s *sqlStorage, txn *sql.Tx, tableName string, filter *abstractions.QueryFilter) (*abstractions.QueryResults[T], error) {
 	filter = filter.ExtractQueryParams()
 	params := filter.Params
 	limit := filter.Limit
@@ -74,7 +78,7 @@ func listEntities[T api.EvaluationJobResource | api.ProviderResource | api.Colle
 	// Process rows (use make so empty result serializes to [] not null)
 	items := make([]T, 0)
 	for rows.Next() {
-		resource, err := scanResource[T](s, rows, tableName)
+		resource, err := scanResource[T](s, rows.Rows, tableName)
 		if err != nil {
 			return nil, err
 		}
@@ -90,6 +94,13 @@ func listEntities[T api.EvaluationJobResource | api.ProviderResource | api.Colle
 		s.logger.Error(fmt.Sprintf("Error iterating %s rows", typeName), "error", err)
 		return nil, serviceerrors.NewServiceError(messages.QueryFailed, "Type", typeName, "Error", err.Error())
 	}
+	_ = rows.Close()
+
+	if jobs, ok := any(items).([]api.EvaluationJobResource); ok {
+		if err := s.readListedJobBenchmarks(txn, jobs); err != nil {
+			return nil, err
+		}
+	}
 
 	return &abstractions.QueryResults[T]{
 		Items:      items,
@@ -97,7 +108,7 @@ func listEntities[T api.EvaluationJobResource | api.ProviderResource | api.Colle
 	}, nil
 }
 
-func scanResource[T api.EvaluationJobResource | api.ProviderResource | api.CollectionResource](s *sqlStorage, rows *sql.Rows, tableName string) (*T, error) {
+func scanResource[T api.EvaluationJobResource | api.ProviderResource | api.CollectionResource | api.BaselineResource | api.EvaluationViewResource](s *sqlStorage, rows *sql.Rows, tableName string) (*T, error) {
 	query := shared.EntityQuery{}
 	err := s.statementsFactory.ScanRowForEntity(s.tenant, tableName, rows, &query)
 	if err != nil {
@@ -136,6 +147,20 @@ func scanResource[T api.EvaluationJobResource | api.ProviderResource | api.Colle
 			t := any(*resource).(T)
 			return &t, nil
 		}
+	case shared.TABLE_BASELINES:
+		storedEntity := BaselineEntity{}
+		err = json.Unmarshal([]byte(query.EntityJSON), &storedEntity)
+		if err == nil {
+			t := any(*constructBaselineResource(&query.Resource, &storedEntity)).(T)
+			return &t, nil
+		}
+	case shared.TABLE_VIEWS:
+		storedEntity := api.EvaluationViewConfig{}
+		err = json.Unmarshal([]byte(query.EntityJSON), &storedEntity)
+		if err == nil {
+			t := any(api.EvaluationViewResource{Resource: query.Resource, EvaluationViewConfig: storedEntity}).(T)
+			return &t, nil
+		}
 	default:
 		err = serviceerrors.NewServiceError(messages.InternalServerError, "Error", fmt.Sprintf("Unknown table name: %s", tableName))
 	}
@@ -0,0 +1,115 @@
+package sql
+
+import (
+	"database/sql"
+	"math"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	se "github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The numeric metrics of a completed benchmark are also stored in rows of the
+// evaluation_metrics table, one per metric, with the model of the job, so that the history
+// of a metric of a model across jobs is read from the table rather than from the entities
+// of all the jobs.
+
+// writeMetrics replaces the metrics of the benchmark of the result with its numeric metrics,
+// the processed metrics when the benchmark has some.
+func (s *sqlStorage) writeMetrics(txn *sql.Tx, job *api.EvaluationJobResource, result *api.BenchmarkResult) error {
+	id := job.Resource.ID
+	deleteQuery, args := s.statementsFactory.CreateEvaluationMetricsDeleteStatement(id, &result.BenchmarkIndex)
+	if _, err := s.exec(txn, deleteQuery, args...); err != nil {
+		s.logger.Error("Failed to delete the metrics of evaluation job", "error", err, "id", id, "benchmark_index", result.BenchmarkIndex)
+		return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job metrics", "ResourceId", id, "Error", err.Error()))
+	}
+	metrics := result.ProcessedMetrics
+	if len(metrics) == 0 {
+		metrics = result.Metrics
+	}
+	recordedAt := time.Now().UTC()
+	for name, value := range metrics {
+		number, ok := toFloat(value)
+		if !ok || math.IsNaN(number) || math.IsInf(number, 0) {
+			continue
+		}
+		point := &api.MetricPoint{
+			ModelName:   job.Model.Name,
+			ProviderID:  result.ProviderID,
+			BenchmarkID: result.ID,
+			Metric:      name,
+			Value:       number,
+			RecordedAt:  recordedAt,
+		}
+		insertQuery, args := s.statementsFactory.CreateEvaluationMetricInsertStatement(id, result.BenchmarkIndex, point)
+		if _, err := s.exec(txn, insertQuery, args...); err != nil {
+			s.logger.Error("Failed to write the metric of evaluation job", "error", err, "id", id, "benchmark_index", result.BenchmarkIndex, "metric", name)
+			return se.WithRollback(se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job metrics", "ResourceId", id, "Error", err.Error()))
+		}
+	}
+	return nil
+}
+
+// GetEvaluationMetricsHistory returns the metrics of the completed benchmarks of the jobs of
+// the tenant that match the filter, the oldest first. The filter params are the ones of
+// shared.MetricsHistoryWhere.
+func (s *sqlStorage) GetEvaluationMetricsHistory(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.MetricPoint], error) {
+	var total int
+	countQuery, args := s.statementsFactory.CreateEvaluationMetricsHistoryCountStatement(s.tenant, filter.Params)
+	if err := s.queryRow(nil, countQuery, args...).Scan(&total); err != nil {
+		s.logger.Error("Failed to count the metrics of evaluation jobs", "error", err)
+		return nil, se.NewServiceError(messages.QueryFailed, "Type", "evaluation job metrics", "Error", err.Error())
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = math.MaxInt32
+	}
+	listQuery, args := s.statementsFactory.CreateEvaluationMetricsHistoryListStatement(s.tenant, filter.Params, limit, filter.Offset)
+	rows, err := s.query(nil, listQuery, args...)
+	if err != nil {
+		s.logger.Error("Failed to list the metrics of evaluation jobs", "error", err)
+		return nil, se.NewServiceError(messages.QueryFailed, "Type", "evaluation job metrics", "Error", err.Error())
+	}
+	defer func() { _ = rows.Close() }()
+	items := make([]api.MetricPoint, 0)
+	for rows.Next() {
+		var item api.MetricPoint
+		if err := rows.Scan(&item.JobID, &item.BenchmarkIndex, &item.Metric, &item.ModelName, &item.ProviderID, &item.BenchmarkID, &item.Value, &item.RecordedAt); err != nil {
+			return nil, se.NewServiceError(messages.QueryFailed, "Type", "evaluation job metrics", "Error", err.Error())
+		}
+		item.RecordedAt = item.RecordedAt.UTC()
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, se.NewServiceError(messages.QueryFailed, "Type", "evaluation job metrics", "Error", err.Error())
+	}
+	return &abstractions.QueryResults[api.MetricPoint]{Items: items, TotalCount: total}, nil
+}
+
+// GetEvaluationMetricNames returns the names of the metrics of the completed benchmarks of the
+// jobs of the tenant that match the filter, sorted. The filter params are the ones of
+// shared.MetricsHistoryWhere.
+func (s *sqlStorage) GetEvaluationMetricNames(filter *abstractions.QueryFilter) ([]string, error) {
+	namesQuery, args := s.statementsFactory.CreateEvaluationMetricNamesStatement(s.tenant, filter.Params)
+	rows, err := s.query(nil, namesQuery, args...)
+	if err != nil {
+		s.logger.Error("Failed to list the metric names of evaluation jobs", "error", err)
+		return nil, se.NewServiceError(messages.QueryFailed, "Type", "evaluation job metrics", "Error", err.Error())
+	}
+	defer func() { _ = rows.Close() }()
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, se.NewServiceError(messages.QueryFailed, "Type", "evaluation job metrics", "Error", err.Error())
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, se.NewServiceError(messages.QueryFailed, "Type", "evaluation job metrics", "Error", err.Error())
+	}
+	return names, nil
+}
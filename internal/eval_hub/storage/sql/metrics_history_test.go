@@ -0,0 +1,123 @@
+package sql_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestGetEvaluationMetricsHistory(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-metrics-history")
+	store = store.WithTenant(tenant)
+	if err := store.CreateProvider(&api.ProviderResource{
+		Resource: api.Resource{ID: "lm_evaluation_harness", Tenant: tenant, CreatedAt: time.Now()},
+		ProviderConfig: api.ProviderConfig{
+			Name:       "LM Evaluation Harness",
+			Benchmarks: []api.BenchmarkResource{{ID: "mmlu"}, {ID: "arc_easy"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateProvider: %v", err)
+	}
+
+	createJob := func(owner api.User, model string) string {
+		t.Helper()
+		jobID := common.GUID()
+		if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, Owner: owner, CreatedAt: time.Now()}},
+			Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Model: api.ModelRef{URL: "http://" + model + ":8000", Name: model},
+				Benchmarks: []api.EvaluationBenchmarkConfig{
+					{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness"},
+					{Ref: api.Ref{ID: "arc_easy"}, ProviderID: "lm_evaluation_harness"},
+				},
+			},
+		}); err != nil {
+			t.Fatalf("CreateEvaluationJob: %v", err)
+		}
+		return jobID
+	}
+	update := func(jobID string, id string, index int, status api.State, metrics map[string]any) {
+		t.Helper()
+		if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+			ProviderID:     "lm_evaluation_harness",
+			ID:             id,
+			BenchmarkIndex: index,
+			Status:         status,
+			Metrics:        metrics,
+		}}); err != nil {
+			t.Fatalf("UpdateEvaluationJob(%s): %v", id, err)
+		}
+	}
+
+	release1 := createJob("alice", "granite")
+	update(release1, "mmlu", 0, api.StateCompleted, map[string]any{"acc": 0.6, "acc_stderr": 0.01, "samples": "all"})
+	// a failed benchmark has no metrics in the history
+	update(release1, "arc_easy", 1, api.StateFailed, map[string]any{"acc": 0.1})
+	release2 := createJob("bob", "granite")
+	update(release2, "mmlu", 0, api.StateCompleted, map[string]any{"acc": 0.5})
+	// a redelivered event replaces the metrics of the benchmark
+	update(release2, "mmlu", 0, api.StateCompleted, map[string]any{"acc": 0.7})
+	update(release2, "arc_easy", 1, api.StateCompleted, map[string]any{"acc": 0.8})
+	other := createJob("alice", "llama")
+	update(other, "mmlu", 0, api.StateCompleted, map[string]any{"acc": 0.4})
+
+	list := func(params map[string]any, limit int) *abstractions.QueryResults[api.MetricPoint] {
+		t.Helper()
+		history, err := store.GetEvaluationMetricsHistory(&abstractions.QueryFilter{Limit: limit, Params: params})
+		if err != nil {
+			t.Fatalf("GetEvaluationMetricsHistory(%v): %v", params, err)
+		}
+		return history
+	}
+	if all := list(map[string]any{}, 0); all.TotalCount != 5 || len(all.Items) != 5 {
+		t.Fatalf("expected the 5 numeric metrics of the completed benchmarks, got %+v", all)
+	}
+	if page := list(map[string]any{}, 2); page.TotalCount != 5 || len(page.Items) != 2 {
+		t.Errorf("expected 2 of 5 metrics, got %+v", page)
+	}
+
+	mmlu := list(map[string]any{"model": "granite", "benchmark": "mmlu", "metric": "acc"}, 0)
+	if mmlu.TotalCount != 2 || len(mmlu.Items) != 2 {
+		t.Fatalf("expected the mmlu accuracy of both releases, got %+v", mmlu)
+	}
+	first, second := mmlu.Items[0], mmlu.Items[1]
+	if first.JobID != release1 || first.Value != 0.6 || first.ModelName != "granite" || first.ProviderID != "lm_evaluation_harness" || first.BenchmarkIndex != 0 {
+		t.Errorf("unexpected first point %+v", first)
+	}
+	if second.JobID != release2 || second.Value != 0.7 || second.RecordedAt.Before(first.RecordedAt) {
+		t.Errorf("expected the point of the second release last, got %+v", second)
+	}
+
+	if visible := list(map[string]any{"model": "granite", "visible_to": abstractions.JobVisibility{User: "alice"}}, 0); visible.TotalCount != 2 {
+		t.Errorf("expected the 2 metrics of the job of alice, got %+v", visible)
+	}
+	if recent := list(map[string]any{"recorded_after": second.RecordedAt, "recorded_before": time.Now().Add(time.Minute)}, 0); recent.TotalCount != 3 {
+		t.Errorf("expected the 3 metrics recorded since the second release, got %+v", recent)
+	}
+	if later := list(map[string]any{"recorded_after": time.Now().Add(time.Minute)}, 0); later.TotalCount != 0 {
+		t.Errorf("expected no metric recorded in the future, got %+v", later)
+	}
+	names, err := store.GetEvaluationMetricNames(&abstractions.QueryFilter{Params: map[string]any{"model": "granite"}})
+	if err != nil || !slices.Equal(names, []string{"acc", "acc_stderr"}) {
+		t.Errorf("expected the metric names of granite, got %v, %v", names, err)
+	}
+	if history, err := store.WithTenant("tenant-other").GetEvaluationMetricsHistory(&abstractions.QueryFilter{}); err != nil || history.TotalCount != 0 {
+		t.Errorf("expected no metrics for another tenant, got %+v, %v", history, err)
+	}
+
+	if err := store.DeleteEvaluationJob(release1); err != nil {
+		t.Fatalf("DeleteEvaluationJob: %v", err)
+	}
+	if all := list(map[string]any{}, 0); all.TotalCount != 3 {
+		t.Errorf("expected the metrics of the deleted job to be removed, got %+v", all)
+	}
+}
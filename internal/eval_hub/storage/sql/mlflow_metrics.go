@@ -0,0 +1,76 @@
+package sql
+
+import (
+	"database/sql"
+	"maps"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// AddEvaluationJobBenchmarkMetrics adds the metrics that the results of the benchmarks do not
+// have yet, the metrics that the results have are kept as the runtime reported them. The
+// metrics history of a completed benchmark is rewritten with the added metrics.
+func (s *sqlStorage) AddEvaluationJobBenchmarkMetrics(id string, metrics map[int]map[string]any) (*api.EvaluationJobResource, error) {
+	var updated *api.EvaluationJobResource
+
+	err := s.withTransaction("add evaluation job benchmark metrics", id, func(txn *sql.Tx) error {
+		job, stored, err := s.getEvaluationJobTransactionalForUpdate(txn, id)
+		if err != nil {
+			return err
+		}
+		added := 0
+		if job.Results != nil {
+			for i := range job.Results.Benchmarks {
+				result := &job.Results.Benchmarks[i]
+				missing := metrics[result.BenchmarkIndex]
+				if len(missing) == 0 {
+					continue
+				}
+				merged := maps.Clone(result.Metrics)
+				if merged == nil {
+					merged = map[string]any{}
+				}
+				count := 0
+				for name, value := range missing {
+					if _, ok := merged[name]; !ok {
+						merged[name] = value
+						count++
+					}
+				}
+				if count == 0 {
+					continue
+				}
+				result.Metrics = merged
+				added += count
+				if benchmarkState(job, result.BenchmarkIndex) == api.StateCompleted {
+					if err := s.writeMetrics(txn, job, result); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if added > 0 {
+			if err := s.updateEvaluationJobTxn(txn, id, job.Status.State, job, stored); err != nil {
+				return err
+			}
+			s.logger.Info("Added evaluation job benchmark metrics", "id", id, "metrics", added)
+		}
+
+		updated, err = s.getEvaluationJobTransactional(txn, id)
+		return err
+	})
+
+	return updated, err
+}
+
+func benchmarkState(job *api.EvaluationJobResource, benchmarkIndex int) api.State {
+	if job.Status == nil {
+		return ""
+	}
+	for _, benchmark := range job.Status.Benchmarks {
+		if benchmark.BenchmarkIndex == benchmarkIndex {
+			return benchmark.Status
+		}
+	}
+	return ""
+}
@@ -0,0 +1,74 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestAddEvaluationJobBenchmarkMetrics(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-mlflow-metrics")
+	store = store.WithTenant(tenant)
+	if err := store.CreateProvider(&api.ProviderResource{
+		Resource: api.Resource{ID: "lm_evaluation_harness", Tenant: tenant, CreatedAt: time.Now()},
+		ProviderConfig: api.ProviderConfig{
+			Name:       "LM Evaluation Harness",
+			Benchmarks: []api.BenchmarkResource{{ID: "mmlu"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateProvider: %v", err)
+	}
+
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, Owner: "alice", CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model:      api.ModelRef{URL: "http://granite:8000", Name: "granite"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+	if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+		ProviderID:   "lm_evaluation_harness",
+		ID:           "mmlu",
+		Status:       api.StateCompleted,
+		Metrics:      map[string]any{"acc": 0.6},
+		MLFlowRunID:  "run-1",
+		MLFlowRunURL: "http://mlflow:5000/#/experiments/exp-1/runs/run-1",
+	}}); err != nil {
+		t.Fatalf("UpdateEvaluationJob: %v", err)
+	}
+
+	// the metric the hub has is kept, the one only logged to MLflow is added
+	job, err := store.AddEvaluationJobBenchmarkMetrics(jobID, map[int]map[string]any{0: {"acc": 0.1, "f1": 0.7}})
+	if err != nil {
+		t.Fatalf("AddEvaluationJobBenchmarkMetrics: %v", err)
+	}
+	if job.Status.State != api.OverallStateCompleted {
+		t.Errorf("state = %s, adding metrics must not change the state", job.Status.State)
+	}
+	result := job.Results.Benchmarks[0]
+	if result.Metrics["acc"] != 0.6 || result.Metrics["f1"] != 0.7 {
+		t.Errorf("expected acc 0.6 and f1 0.7, got %v", result.Metrics)
+	}
+	if result.MLFlowRunURL != "http://mlflow:5000/#/experiments/exp-1/runs/run-1" {
+		t.Errorf("expected the run URL in the result, got %q", result.MLFlowRunURL)
+	}
+
+	history, err := store.GetEvaluationMetricsHistory(&abstractions.QueryFilter{Params: map[string]any{"metric": "f1"}})
+	if err != nil {
+		t.Fatalf("GetEvaluationMetricsHistory: %v", err)
+	}
+	if history.TotalCount != 1 || history.Items[0].Value != 0.7 {
+		t.Errorf("expected the added metric in the history, got %+v", history)
+	}
+}
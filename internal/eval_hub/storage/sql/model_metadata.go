@@ -0,0 +1,25 @@
+package sql
+
+import (
+	"database/sql"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The metadata of the model is held by the results of the job, in its entity, next to the
+// test result, whatever the state of the job: it is captured in the background when the job
+// starts and may be stored after the job ended.
+
+func (s *sqlStorage) UpdateEvaluationJobModelMetadata(id string, metadata *api.ModelMetadata) error {
+	return s.withTransaction("update evaluation job model metadata", id, func(txn *sql.Tx) error {
+		job, stored, err := s.getEvaluationJobTransactionalForUpdate(txn, id)
+		if err != nil {
+			return err
+		}
+		if job.Results == nil {
+			job.Results = &api.EvaluationJobResults{}
+		}
+		job.Results.Model = metadata
+		return s.updateEvaluationJobTxn(txn, id, job.Status.State, job, stored)
+	})
+}
@@ -0,0 +1,64 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestUpdateEvaluationJobModelMetadata(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-model-metadata")
+	store = store.WithTenant(tenant)
+
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, Owner: "alice", CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Name:       "model-metadata",
+			Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+
+	metadata := &api.ModelMetadata{
+		ServedModelID:    "test-model",
+		BaseModel:        "ibm-granite/granite-3.1-8b-instruct",
+		Quantization:     "fp8",
+		MaxContextLength: 8192,
+		ServerVersion:    "0.8.5",
+		CapturedAt:       api.DateTimeToString(time.Now()),
+	}
+	if err := store.UpdateEvaluationJobModelMetadata(jobID, metadata); err != nil {
+		t.Fatalf("UpdateEvaluationJobModelMetadata: %v", err)
+	}
+
+	job, err := store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if job.Results == nil || job.Results.Model == nil {
+		t.Fatalf("expected the model metadata in the results, got %+v", job.Results)
+	}
+	if *job.Results.Model != *metadata {
+		t.Errorf("model metadata = %+v, want %+v", *job.Results.Model, *metadata)
+	}
+	if job.Status.State != api.OverallStateRunning {
+		t.Errorf("state = %s, the metadata must not change the state", job.Status.State)
+	}
+	if len(job.Benchmarks) != 1 {
+		t.Errorf("expected the benchmarks of the job to be kept, got %d", len(job.Benchmarks))
+	}
+
+	if err := store.UpdateEvaluationJobModelMetadata("missing", metadata); err == nil {
+		t.Error("expected an error for a missing job")
+	}
+}
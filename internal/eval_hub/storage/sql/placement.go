@@ -0,0 +1,59 @@
+package sql
+
+import (
+	"database/sql"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The placement of a benchmark is held by its status, whatever the state of the job: the
+// runtime reports it when it creates the workload of the benchmark and while it runs, and
+// the finish time of the workload may only be read after the job ended.
+
+func (s *sqlStorage) UpdateEvaluationJobBenchmarkPlacement(id string, benchmarkIndex int, placement *api.BenchmarkPlacement) error {
+	return s.withTransaction("update evaluation job benchmark placement", id, func(txn *sql.Tx) error {
+		job, stored, err := s.getEvaluationJobTransactionalForUpdate(txn, id)
+		if err != nil {
+			return err
+		}
+		if job.Status == nil {
+			job.Status = &api.EvaluationJobStatus{
+				EvaluationJobState: api.EvaluationJobState{
+					State: api.OverallStatePending,
+				},
+			}
+		}
+		for i := range job.Status.Benchmarks {
+			if job.Status.Benchmarks[i].BenchmarkIndex == benchmarkIndex {
+				job.Status.Benchmarks[i].Placement = job.Status.Benchmarks[i].Placement.Merge(placement)
+				return s.updateEvaluationJobTxn(txn, id, job.Status.State, job, stored)
+			}
+		}
+
+		// the workload of the benchmark was created before the adapter reported its status
+		var collection *api.CollectionResource
+		if job.Collection != nil && job.Collection.ID != "" {
+			collection, err = s.getCollectionTransactional(txn, job.Collection.ID)
+			if err != nil {
+				return err
+			}
+		}
+		benchmarks, err := handlers.GetJobBenchmarks(job, collection)
+		if err != nil {
+			return err
+		}
+		if benchmarkIndex < 0 || benchmarkIndex >= len(benchmarks) {
+			s.logger.Warn("Ignoring the placement of an unknown benchmark", "id", id, "benchmark_index", benchmarkIndex)
+			return nil
+		}
+		job.Status.Benchmarks = append(job.Status.Benchmarks, api.BenchmarkStatus{
+			ProviderID:     benchmarks[benchmarkIndex].ProviderID,
+			ID:             benchmarks[benchmarkIndex].ID,
+			BenchmarkIndex: benchmarkIndex,
+			Status:         api.StatePending,
+			Placement:      (*api.BenchmarkPlacement)(nil).Merge(placement),
+		})
+		return s.updateEvaluationJobTxn(txn, id, job.Status.State, job, stored)
+	})
+}
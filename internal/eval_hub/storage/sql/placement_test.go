@@ -0,0 +1,87 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestUpdateEvaluationJobBenchmarkPlacement(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-placement")
+	store = store.WithTenant(tenant)
+
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, Owner: "alice", CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePending}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Name:       "placement",
+			Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+	placementOf := func() *api.BenchmarkStatus {
+		t.Helper()
+		job, err := store.GetEvaluationJob(jobID)
+		if err != nil {
+			t.Fatalf("GetEvaluationJob: %v", err)
+		}
+		if job.Status == nil || len(job.Status.Benchmarks) != 1 {
+			t.Fatalf("expected the status of the benchmark, got %+v", job.Status)
+		}
+		return &job.Status.Benchmarks[0]
+	}
+
+	// the Job of the benchmark is created before its adapter reports anything
+	if err := store.UpdateEvaluationJobBenchmarkPlacement(jobID, 0, &api.BenchmarkPlacement{Runtime: "kubernetes", Namespace: "team-a", JobName: "eval-job-1"}); err != nil {
+		t.Fatalf("UpdateEvaluationJobBenchmarkPlacement: %v", err)
+	}
+	status := placementOf()
+	if status.Status != api.StatePending || status.ID != "mmlu" || status.ProviderID != "lm_evaluation_harness" {
+		t.Errorf("expected a pending status of the benchmark, got %+v", status)
+	}
+	if status.Placement == nil || status.Placement.JobName != "eval-job-1" {
+		t.Fatalf("expected the placement of the Job, got %+v", status.Placement)
+	}
+
+	// the status events of the adapter keep the placement
+	if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+		ProviderID: "lm_evaluation_harness",
+		ID:         "mmlu",
+		Status:     api.StateRunning,
+	}}); err != nil {
+		t.Fatalf("UpdateEvaluationJob: %v", err)
+	}
+	started := api.DateTimeToString(time.Now())
+	if err := store.UpdateEvaluationJobBenchmarkPlacement(jobID, 0, &api.BenchmarkPlacement{JobName: "eval-job-1", PodName: "eval-job-1-abcde", NodeName: "gpu-node-1", StartedAt: started}); err != nil {
+		t.Fatalf("UpdateEvaluationJobBenchmarkPlacement: %v", err)
+	}
+	status = placementOf()
+	want := api.BenchmarkPlacement{Runtime: "kubernetes", Namespace: "team-a", JobName: "eval-job-1", PodName: "eval-job-1-abcde", NodeName: "gpu-node-1", StartedAt: started}
+	if status.Status != api.StateRunning || status.Placement == nil || *status.Placement != want {
+		t.Errorf("expected the placement %+v of the running benchmark, got %+v", want, status)
+	}
+
+	// the placement of another Job, e.g. of a retry, replaces the earlier one
+	if err := store.UpdateEvaluationJobBenchmarkPlacement(jobID, 0, &api.BenchmarkPlacement{Runtime: "kubernetes", Namespace: "team-a", JobName: "eval-job-2"}); err != nil {
+		t.Fatalf("UpdateEvaluationJobBenchmarkPlacement: %v", err)
+	}
+	if placement := placementOf().Placement; placement == nil || placement.JobName != "eval-job-2" || placement.PodName != "" {
+		t.Errorf("expected the placement of the new Job alone, got %+v", placement)
+	}
+
+	if err := store.UpdateEvaluationJobBenchmarkPlacement(jobID, 3, &api.BenchmarkPlacement{JobName: "unknown"}); err != nil {
+		t.Errorf("expected the placement of an unknown benchmark to be ignored, got %v", err)
+	}
+	if err := store.UpdateEvaluationJobBenchmarkPlacement("missing", 0, &api.BenchmarkPlacement{JobName: "eval-job-1"}); err == nil {
+		t.Error("expected an error for a missing job")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql/shared"
@@ -18,6 +19,87 @@ const (
 
 	INSERT_PROVIDER_STATEMENT = `INSERT INTO providers (id, tenant_id, owner, entity) VALUES ($1, $2, $3, $4) RETURNING id;`
 
+	INSERT_BASELINE_STATEMENT        = `INSERT INTO baselines (id, tenant_id, owner, entity) VALUES ($1, $2, $3, $4) RETURNING id;`
+	INSERT_EVALUATION_VIEW_STATEMENT = `INSERT INTO evaluation_views (id, tenant_id, owner, entity) VALUES ($1, $2, $3, $4) RETURNING id;`
+
+	UPSERT_RESULT_CACHE_STATEMENT = `INSERT INTO benchmark_result_cache (cache_key, tenant_id, job_id, completed_at, entity) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (tenant_id, cache_key) DO UPDATE SET job_id = EXCLUDED.job_id, completed_at = EXCLUDED.completed_at, entity = EXCLUDED.entity;`
+
+	SELECT_RESULT_CACHE_STATEMENT = `SELECT job_id, completed_at, entity FROM benchmark_result_cache WHERE tenant_id = $1 AND cache_key = $2;`
+
+	UPSERT_TAG_POLICY_STATEMENT = `INSERT INTO tag_policies (tenant_id, updated_at, entity) VALUES ($1, $2, $3) ON CONFLICT (tenant_id) DO UPDATE SET updated_at = EXCLUDED.updated_at, entity = EXCLUDED.entity;`
+
+	SELECT_TAG_POLICY_STATEMENT = `SELECT updated_at, entity FROM tag_policies WHERE tenant_id = $1;`
+
+	UPSERT_REDACTION_POLICY_STATEMENT = `INSERT INTO redaction_policies (tenant_id, updated_at, entity) VALUES ($1, $2, $3) ON CONFLICT (tenant_id) DO UPDATE SET updated_at = EXCLUDED.updated_at, entity = EXCLUDED.entity;`
+
+	SELECT_REDACTION_POLICY_STATEMENT = `SELECT updated_at, entity FROM redaction_policies WHERE tenant_id = $1;`
+
+	UPSERT_ADMIN_CONFIG_STATEMENT = `INSERT INTO admin_config (id, updated_at, entity) VALUES ('service', $1, $2) ON CONFLICT (id) DO UPDATE SET updated_at = EXCLUDED.updated_at, entity = EXCLUDED.entity;`
+
+	SELECT_ADMIN_CONFIG_STATEMENT = `SELECT updated_at, entity FROM admin_config WHERE id = 'service';`
+
+	UPSERT_PROVIDER_HEALTH_STATEMENT = `INSERT INTO provider_health (provider_id, updated_at, entity) VALUES ($1, $2, $3) ON CONFLICT (provider_id) DO UPDATE SET updated_at = EXCLUDED.updated_at, entity = EXCLUDED.entity;`
+
+	SELECT_PROVIDER_HEALTH_STATEMENT = `SELECT entity FROM provider_health ORDER BY provider_id;`
+
+	DELETE_PROVIDER_HEALTH_STATEMENT = `DELETE FROM provider_health WHERE provider_id = $1;`
+
+	INSERT_EVALUATION_REDACTION_STATEMENT = `INSERT INTO evaluation_redactions (job_id, benchmark_index, target, redacted_at, entity) VALUES ($1, $2, $3, $4, $5);`
+
+	SELECT_EVALUATION_REDACTIONS_COUNT_STATEMENT = `SELECT COUNT(*) FROM evaluation_redactions WHERE job_id = $1;`
+
+	SELECT_EVALUATION_REDACTIONS_STATEMENT = `SELECT entity FROM evaluation_redactions WHERE job_id = $1 ORDER BY redacted_at, benchmark_index LIMIT $2 OFFSET $3;`
+
+	DELETE_EVALUATION_REDACTIONS_STATEMENT = `DELETE FROM evaluation_redactions WHERE job_id = $1;`
+
+	INSERT_STATUS_DEAD_LETTER_STATEMENT = `INSERT INTO status_dead_letters (id, tenant_id, job_id, received_at, entity) VALUES ($1, $2, $3, $4, $5);`
+
+	SELECT_STATUS_DEAD_LETTER_STATEMENT = `SELECT entity FROM status_dead_letters WHERE id = $1;`
+
+	SELECT_STATUS_DEAD_LETTERS_COUNT_STATEMENT = `SELECT COUNT(*) FROM status_dead_letters WHERE ($1::text = '' OR tenant_id = $1) AND ($2::text = '' OR job_id = $2);`
+
+	SELECT_STATUS_DEAD_LETTERS_STATEMENT = `SELECT entity FROM status_dead_letters WHERE ($1::text = '' OR tenant_id = $1) AND ($2::text = '' OR job_id = $2) ORDER BY received_at DESC, id LIMIT $3 OFFSET $4;`
+
+	DELETE_STATUS_DEAD_LETTER_STATEMENT = `DELETE FROM status_dead_letters WHERE id = $1;`
+
+	DELETE_STATUS_DEAD_LETTERS_STATEMENT = `DELETE FROM status_dead_letters WHERE job_id = $1;`
+
+	UPSERT_BENCHMARK_DURATION_STATEMENT = `INSERT INTO benchmark_durations (tenant_id, provider_id, benchmark_id, examples_bucket, runs, total_seconds) VALUES ($1, $2, $3, $4, 1, $5) ON CONFLICT (tenant_id, provider_id, benchmark_id, examples_bucket) DO UPDATE SET runs = benchmark_durations.runs + 1, total_seconds = benchmark_durations.total_seconds + EXCLUDED.total_seconds, updated_at = CURRENT_TIMESTAMP;`
+
+	SELECT_BENCHMARK_DURATION_STATEMENT = `SELECT runs, total_seconds FROM benchmark_durations WHERE tenant_id = $1 AND provider_id = $2 AND benchmark_id = $3 AND examples_bucket = $4;`
+
+	UPSERT_EVALUATION_BENCHMARK_STATEMENT = `INSERT INTO evaluation_benchmarks (job_id, benchmark_index, status, entity, result) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (job_id, benchmark_index) DO UPDATE SET status = EXCLUDED.status, entity = EXCLUDED.entity, result = EXCLUDED.result, updated_at = CURRENT_TIMESTAMP;`
+
+	SELECT_EVALUATION_BENCHMARK_STATES_STATEMENT = `SELECT status, COUNT(*) FROM evaluation_benchmarks WHERE job_id = $1 GROUP BY status;`
+
+	DELETE_EVALUATION_BENCHMARKS_STATEMENT = `DELETE FROM evaluation_benchmarks WHERE job_id = $1;`
+
+	INSERT_EVALUATION_FINDING_STATEMENT = `INSERT INTO evaluation_findings (job_id, benchmark_index, shard_index, finding_index, provider_id, benchmark_id, severity, severity_rank, probe, detector, entity) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);`
+
+	SELECT_EVALUATION_FINDINGS_SEVERITY_COUNT_STATEMENT = `SELECT severity, COUNT(*) FROM evaluation_findings WHERE job_id = $1 AND benchmark_index = $2 GROUP BY severity;`
+
+	UPSERT_EVALUATION_ARTIFACT_STATEMENT = `INSERT INTO evaluation_artifacts (job_id, benchmark_index, name, uploaded_at, entity) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (job_id, benchmark_index, name) DO UPDATE SET uploaded_at = EXCLUDED.uploaded_at, entity = EXCLUDED.entity;`
+
+	SELECT_EVALUATION_ARTIFACTS_COUNT_STATEMENT = `SELECT COUNT(*) FROM evaluation_artifacts WHERE job_id = $1 AND benchmark_index = $2;`
+
+	SELECT_EVALUATION_ARTIFACTS_STATEMENT = `SELECT entity FROM evaluation_artifacts WHERE job_id = $1 AND benchmark_index = $2 ORDER BY name LIMIT $3 OFFSET $4;`
+
+	DELETE_EVALUATION_ARTIFACTS_STATEMENT = `DELETE FROM evaluation_artifacts WHERE job_id = $1;`
+
+	UPSERT_EVALUATION_GENERATION_STATEMENT = `INSERT INTO evaluation_generations (job_id, benchmark_index, name, uploaded_at, entity) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (job_id, benchmark_index, name) DO UPDATE SET uploaded_at = EXCLUDED.uploaded_at, entity = EXCLUDED.entity;`
+
+	SELECT_EVALUATION_GENERATION_STATEMENT = `SELECT entity FROM evaluation_generations WHERE job_id = $1 AND benchmark_index = $2 AND name = $3;`
+
+	SELECT_EVALUATION_GENERATIONS_COUNT_STATEMENT = `SELECT COUNT(*) FROM evaluation_generations WHERE job_id = $1 AND benchmark_index = $2;`
+
+	SELECT_EVALUATION_GENERATIONS_STATEMENT = `SELECT entity FROM evaluation_generations WHERE job_id = $1 AND benchmark_index = $2 ORDER BY name LIMIT $3 OFFSET $4;`
+
+	DELETE_EVALUATION_GENERATIONS_STATEMENT = `DELETE FROM evaluation_generations WHERE job_id = $1;`
+
+	INSERT_EVALUATION_METRIC_STATEMENT = `INSERT INTO evaluation_metrics (job_id, benchmark_index, metric, model_name, provider_id, benchmark_id, value, recorded_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8);`
+
+	DELETE_EVALUATION_METRICS_STATEMENT = `DELETE FROM evaluation_metrics WHERE job_id = $1;`
+
 	TABLES_SCHEMA = `
 CREATE TABLE IF NOT EXISTS evaluations (
     id VARCHAR(36) NOT NULL,
@@ -31,6 +113,64 @@ CREATE TABLE IF NOT EXISTS evaluations (
     PRIMARY KEY (id)
 );
 
+CREATE TABLE IF NOT EXISTS evaluation_benchmarks (
+    job_id VARCHAR(36) NOT NULL,
+    benchmark_index INTEGER NOT NULL,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    status VARCHAR(50) NOT NULL,
+    entity JSONB NOT NULL,
+    result JSONB,
+    PRIMARY KEY (job_id, benchmark_index)
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_findings (
+    job_id VARCHAR(36) NOT NULL,
+    benchmark_index INTEGER NOT NULL,
+    shard_index INTEGER NOT NULL,
+    finding_index INTEGER NOT NULL,
+    provider_id VARCHAR(255) NOT NULL,
+    benchmark_id VARCHAR(255) NOT NULL,
+    severity VARCHAR(50) NOT NULL,
+    severity_rank INTEGER NOT NULL,
+    probe VARCHAR(255) NOT NULL,
+    detector VARCHAR(255) NOT NULL,
+    entity JSONB NOT NULL,
+    PRIMARY KEY (job_id, benchmark_index, shard_index, finding_index)
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_artifacts (
+    job_id VARCHAR(36) NOT NULL,
+    benchmark_index INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    uploaded_at TIMESTAMP NOT NULL,
+    entity JSONB NOT NULL,
+    PRIMARY KEY (job_id, benchmark_index, name)
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_generations (
+    job_id VARCHAR(36) NOT NULL,
+    benchmark_index INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    uploaded_at TIMESTAMP NOT NULL,
+    entity JSONB NOT NULL,
+    PRIMARY KEY (job_id, benchmark_index, name)
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_metrics (
+    job_id VARCHAR(36) NOT NULL,
+    benchmark_index INTEGER NOT NULL,
+    metric VARCHAR(255) NOT NULL,
+    model_name VARCHAR(255) NOT NULL,
+    provider_id VARCHAR(255) NOT NULL,
+    benchmark_id VARCHAR(255) NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    recorded_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (job_id, benchmark_index, metric)
+);
+
+CREATE INDEX IF NOT EXISTS idx_evaluation_metrics_history
+ON evaluation_metrics (model_name, benchmark_id, metric, recorded_at);
+
 CREATE TABLE IF NOT EXISTS collections (
     id VARCHAR(36) NOT NULL,
 	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -50,6 +190,97 @@ CREATE TABLE IF NOT EXISTS providers (
     entity JSONB NOT NULL,
     PRIMARY KEY (id)
 );
+
+CREATE TABLE IF NOT EXISTS baselines (
+    id VARCHAR(63) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    tenant_id VARCHAR(255) NOT NULL,
+    owner VARCHAR(255) NOT NULL,
+    entity JSONB NOT NULL,
+    PRIMARY KEY (tenant_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_views (
+    id VARCHAR(36) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    tenant_id VARCHAR(255) NOT NULL,
+    owner VARCHAR(255) NOT NULL,
+    entity JSONB NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS benchmark_result_cache (
+    cache_key VARCHAR(64) NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL,
+    job_id VARCHAR(36) NOT NULL,
+    completed_at TIMESTAMP NOT NULL,
+    entity JSONB NOT NULL,
+    PRIMARY KEY (tenant_id, cache_key)
+);
+
+CREATE TABLE IF NOT EXISTS tag_policies (
+    tenant_id VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    entity JSONB NOT NULL,
+    PRIMARY KEY (tenant_id)
+);
+
+CREATE TABLE IF NOT EXISTS redaction_policies (
+    tenant_id VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    entity JSONB NOT NULL,
+    PRIMARY KEY (tenant_id)
+);
+
+CREATE TABLE IF NOT EXISTS admin_config (
+    id VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    entity JSONB NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS provider_health (
+    provider_id VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    entity JSONB NOT NULL,
+    PRIMARY KEY (provider_id)
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_redactions (
+    job_id VARCHAR(36) NOT NULL,
+    benchmark_index INTEGER NOT NULL,
+    target VARCHAR(64) NOT NULL,
+    redacted_at TIMESTAMP NOT NULL,
+    entity JSONB NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_evaluation_redactions_job
+ON evaluation_redactions (job_id, redacted_at);
+
+CREATE TABLE IF NOT EXISTS status_dead_letters (
+    id VARCHAR(36) NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL,
+    job_id VARCHAR(36) NOT NULL,
+    received_at TIMESTAMP NOT NULL,
+    entity JSONB NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_status_dead_letters_received
+ON status_dead_letters (received_at);
+
+CREATE TABLE IF NOT EXISTS benchmark_durations (
+    tenant_id VARCHAR(255) NOT NULL,
+    provider_id VARCHAR(255) NOT NULL,
+    benchmark_id VARCHAR(255) NOT NULL,
+    examples_bucket INTEGER NOT NULL,
+    runs INTEGER NOT NULL,
+    total_seconds DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, provider_id, benchmark_id, examples_bucket)
+);
 `
 )
 
@@ -86,16 +317,152 @@ func (s *postgresStatementsFactory) CreateEvaluationGetEntityForUpdateStatement(
 	return strings.TrimSuffix(stmt, ";") + " FOR UPDATE;", args, scanArgs
 }
 
+func (s *postgresStatementsFactory) CreateEvaluationBenchmarksGetStatement(jobIDs []string, benchmarkIndex *int) (string, []any) {
+	args := make([]any, 0, len(jobIDs)+1)
+	placeholders := make([]string, 0, len(jobIDs))
+	for i, jobID := range jobIDs {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
+		args = append(args, jobID)
+	}
+	where := fmt.Sprintf("job_id IN (%s)", strings.Join(placeholders, ", "))
+	if benchmarkIndex != nil {
+		where += fmt.Sprintf(" AND benchmark_index = $%d", len(args)+1)
+		args = append(args, *benchmarkIndex)
+	}
+	return fmt.Sprintf(`SELECT job_id, benchmark_index, entity, result FROM evaluation_benchmarks WHERE %s ORDER BY job_id, benchmark_index;`, where), args
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationBenchmarkUpsertStatement(jobID string, benchmarkIndex int, status api.State, entity string, result *string) (string, []any) {
+	return UPSERT_EVALUATION_BENCHMARK_STATEMENT, []any{jobID, benchmarkIndex, status, entity, result}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationBenchmarkStatesStatement(jobID string) (string, []any) {
+	return SELECT_EVALUATION_BENCHMARK_STATES_STATEMENT, []any{jobID}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationBenchmarksDeleteStatement(jobID string) (string, []any) {
+	return DELETE_EVALUATION_BENCHMARKS_STATEMENT, []any{jobID}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationFindingsDeleteStatement(jobID string, benchmarkIndex *int, shardIndex int) (string, []any) {
+	if benchmarkIndex == nil {
+		return `DELETE FROM evaluation_findings WHERE job_id = $1;`, []any{jobID}
+	}
+	return `DELETE FROM evaluation_findings WHERE job_id = $1 AND benchmark_index = $2 AND shard_index = $3;`, []any{jobID, *benchmarkIndex, shardIndex}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationFindingInsertStatement(jobID string, benchmarkIndex int, shardIndex int, findingIndex int, providerID string, benchmarkID string, finding *api.Finding, entity string) (string, []any) {
+	return INSERT_EVALUATION_FINDING_STATEMENT, []any{jobID, benchmarkIndex, shardIndex, findingIndex, providerID, benchmarkID, finding.Severity, finding.Severity.Rank(), finding.Probe, finding.Detector, entity}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationFindingsSeverityCountStatement(jobID string, benchmarkIndex int) (string, []any) {
+	return SELECT_EVALUATION_FINDINGS_SEVERITY_COUNT_STATEMENT, []any{jobID, benchmarkIndex}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationFindingsCountStatement(jobID string, filter map[string]any) (string, []any) {
+	where, args := shared.FindingsWhere(jobID, filter, func(n int) string { return fmt.Sprintf("$%d", n) })
+	return fmt.Sprintf(`SELECT COUNT(*) FROM evaluation_findings WHERE %s;`, where), args
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationFindingsListStatement(jobID string, filter map[string]any, limit, offset int) (string, []any) {
+	where, args := shared.FindingsWhere(jobID, filter, func(n int) string { return fmt.Sprintf("$%d", n) })
+	args = append(args, limit, offset)
+	return fmt.Sprintf(`SELECT provider_id, benchmark_id, benchmark_index, shard_index, entity FROM evaluation_findings WHERE %s ORDER BY severity_rank DESC, benchmark_index, shard_index, finding_index LIMIT $%d OFFSET $%d;`, where, len(args)-1, len(args)), args
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationArtifactUpsertStatement(jobID string, benchmarkIndex int, name string, uploadedAt time.Time, entity string) (string, []any) {
+	return UPSERT_EVALUATION_ARTIFACT_STATEMENT, []any{jobID, benchmarkIndex, name, uploadedAt.UTC(), entity}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationArtifactsCountStatement(jobID string, benchmarkIndex int) (string, []any) {
+	return SELECT_EVALUATION_ARTIFACTS_COUNT_STATEMENT, []any{jobID, benchmarkIndex}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationArtifactsListStatement(jobID string, benchmarkIndex int, limit, offset int) (string, []any) {
+	return SELECT_EVALUATION_ARTIFACTS_STATEMENT, []any{jobID, benchmarkIndex, limit, offset}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationArtifactsDeleteStatement(jobID string) (string, []any) {
+	return DELETE_EVALUATION_ARTIFACTS_STATEMENT, []any{jobID}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationGenerationUpsertStatement(jobID string, benchmarkIndex int, name string, uploadedAt time.Time, entity string) (string, []any) {
+	return UPSERT_EVALUATION_GENERATION_STATEMENT, []any{jobID, benchmarkIndex, name, uploadedAt.UTC(), entity}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationGenerationGetStatement(jobID string, benchmarkIndex int, name string) (string, []any) {
+	return SELECT_EVALUATION_GENERATION_STATEMENT, []any{jobID, benchmarkIndex, name}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationGenerationsCountStatement(jobID string, benchmarkIndex int) (string, []any) {
+	return SELECT_EVALUATION_GENERATIONS_COUNT_STATEMENT, []any{jobID, benchmarkIndex}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationGenerationsListStatement(jobID string, benchmarkIndex int, limit, offset int) (string, []any) {
+	return SELECT_EVALUATION_GENERATIONS_STATEMENT, []any{jobID, benchmarkIndex, limit, offset}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationGenerationsDeleteStatement(jobID string) (string, []any) {
+	return DELETE_EVALUATION_GENERATIONS_STATEMENT, []any{jobID}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationMetricsDeleteStatement(jobID string, benchmarkIndex *int) (string, []any) {
+	if benchmarkIndex == nil {
+		return DELETE_EVALUATION_METRICS_STATEMENT, []any{jobID}
+	}
+	return `DELETE FROM evaluation_metrics WHERE job_id = $1 AND benchmark_index = $2;`, []any{jobID, *benchmarkIndex}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationMetricInsertStatement(jobID string, benchmarkIndex int, point *api.MetricPoint) (string, []any) {
+	return INSERT_EVALUATION_METRIC_STATEMENT, []any{jobID, benchmarkIndex, point.Metric, point.ModelName, point.ProviderID, point.BenchmarkID, point.Value, point.RecordedAt.UTC()}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationMetricsHistoryCountStatement(tenant api.Tenant, filter map[string]any) (string, []any) {
+	where, args := shared.MetricsHistoryWhere(s, tenant, filter, func(n int) string { return fmt.Sprintf("$%d", n) })
+	return fmt.Sprintf(`SELECT COUNT(*) FROM evaluation_metrics m, evaluations e WHERE %s;`, where), args
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationMetricsHistoryListStatement(tenant api.Tenant, filter map[string]any, limit, offset int) (string, []any) {
+	where, args := shared.MetricsHistoryWhere(s, tenant, filter, func(n int) string { return fmt.Sprintf("$%d", n) })
+	args = append(args, limit, offset)
+	return fmt.Sprintf(`SELECT m.job_id, m.benchmark_index, m.metric, m.model_name, m.provider_id, m.benchmark_id, m.value, m.recorded_at FROM evaluation_metrics m, evaluations e WHERE %s ORDER BY m.recorded_at, m.job_id, m.benchmark_index, m.metric LIMIT $%d OFFSET $%d;`, where, len(args)-1, len(args)), args
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationMetricNamesStatement(tenant api.Tenant, filter map[string]any) (string, []any) {
+	where, args := shared.MetricsHistoryWhere(s, tenant, filter, func(n int) string { return fmt.Sprintf("$%d", n) })
+	return fmt.Sprintf(`SELECT DISTINCT m.metric FROM evaluation_metrics m, evaluations e WHERE %s ORDER BY m.metric;`, where), args
+}
+
+func (s *postgresStatementsFactory) CreateUpdateEvaluationStatusStatement(tenant api.Tenant, id string, status api.OverallState) (string, []any) {
+	if !tenant.IsEmpty() {
+		return `UPDATE evaluations SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2 AND tenant_id = $3;`, []any{status, id, tenant.String()}
+	}
+	return `UPDATE evaluations SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2;`, []any{status, id}
+}
+
+func (s *postgresStatementsFactory) CreateUpdateEvaluationExperimentStatement(tenant api.Tenant, id string, experimentID string) (string, []any) {
+	if !tenant.IsEmpty() {
+		return `UPDATE evaluations SET experiment_id = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2 AND tenant_id = $3;`, []any{experimentID, id, tenant.String()}
+	}
+	return `UPDATE evaluations SET experiment_id = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2;`, []any{experimentID, id}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationsWithoutExperimentStatement(limit int) (string, []any) {
+	return `SELECT id, tenant_id, created_at FROM evaluations WHERE experiment_id = '' AND COALESCE(entity->'config'->'experiment'->>'name', '') <> '' ORDER BY created_at DESC LIMIT $1;`, []any{limit}
+}
+
 // allowedFilterColumns returns the set of column/param names allowed in filter for each table.
 func (s *postgresStatementsFactory) GetAllowedFilterColumns(tableName string) []string {
 	allColumns := []string{"owner", "name", "tags"}
 	switch tableName {
 	case shared.TABLE_EVALUATIONS:
-		return append(allColumns, "status", "experiment_id")
+		return append(allColumns, "status", "experiment_id", "sweep_id", "annotation", "link", "visible_to", "review", "model", "created_after", "created_before")
 	case shared.TABLE_PROVIDERS:
 		return allColumns // "benchmarks" and "scope" are not allowed filters for providers from the database
 	case shared.TABLE_COLLECTIONS:
 		return append(allColumns, "category") // "scope" is not allowed filter for collections from the database
+	case shared.TABLE_BASELINES, shared.TABLE_VIEWS:
+		return []string{"owner", "name"}
 	default:
 		return nil
 	}
@@ -130,6 +497,46 @@ func (s *postgresStatementsFactory) CreateEntityFilterCondition(key string, valu
 			tagsPath = "entity->'config'->'tags'"
 		}
 		return fmt.Sprintf("jsonb_typeof(%s) = 'array' AND EXISTS (SELECT 1 FROM jsonb_array_elements_text(%s) AS tag WHERE tag = $%d)", tagsPath, tagsPath, index), []any{tagStr}
+	case "sweep_id":
+		return fmt.Sprintf("entity->'config'->'sweep_run'->>'sweep_id' = $%d", index), []any{value}
+	case "review":
+		return fmt.Sprintf("entity->'results'->'test'->'review'->>'state' = $%d", index), []any{value}
+	case "annotation":
+		annotationKey, annotationValue, hasValue := shared.ParseAnnotationFilter(value)
+		condition := fmt.Sprintf("jsonb_typeof(entity->'config'->'annotations') = 'object' AND EXISTS (SELECT 1 FROM jsonb_each_text(entity->'config'->'annotations') AS annotation WHERE annotation.key = $%d", index)
+		if hasValue {
+			return condition + fmt.Sprintf(" AND annotation.value = $%d)", index+1), []any{annotationKey, annotationValue}
+		}
+		return condition + ")", []any{annotationKey}
+	case "link":
+		return fmt.Sprintf("jsonb_typeof(entity->'config'->'links') = 'array' AND EXISTS (SELECT 1 FROM jsonb_array_elements(entity->'config'->'links') AS link WHERE link->>'url' = $%d)", index), []any{value}
+	case "model":
+		return fmt.Sprintf("entity->'config'->'model'->>'name' = $%d", index), []any{value}
+	case "created_after", "created_before":
+		createdAt, _ := value.(time.Time)
+		operator := ">="
+		if key == "created_before" {
+			operator = "<"
+		}
+		return fmt.Sprintf("created_at %s $%d", operator, index), []any{createdAt.UTC()}
+	case "visible_to":
+		visibility, _ := value.(abstractions.JobVisibility)
+		var sb strings.Builder
+		args := []any{visibility.User, visibility.User}
+		fmt.Fprintf(&sb, "(owner = $%d OR (jsonb_typeof(entity->'config'->'shared_with'->'users') = 'array' AND EXISTS (SELECT 1 FROM jsonb_array_elements_text(entity->'config'->'shared_with'->'users') AS shared_user WHERE shared_user = $%d))", index, index+1)
+		if len(visibility.Groups) > 0 {
+			sb.WriteString(" OR (jsonb_typeof(entity->'config'->'shared_with'->'groups') = 'array' AND EXISTS (SELECT 1 FROM jsonb_array_elements_text(entity->'config'->'shared_with'->'groups') AS shared_group WHERE shared_group IN (")
+			for i, group := range visibility.Groups {
+				if i > 0 {
+					sb.WriteString(", ")
+				}
+				fmt.Fprintf(&sb, "$%d", index+len(args))
+				args = append(args, group)
+			}
+			sb.WriteString(")))")
+		}
+		sb.WriteString(")")
+		return sb.String(), args
 	case "ORDER BY":
 		return "ORDER BY " + value.(string), []any{}
 	case "LIMIT", "OFFSET":
@@ -196,6 +603,13 @@ func (s *postgresStatementsFactory) CreateUpdateEntityStatement(tenant api.Tenan
 	}
 }
 
+func (s *postgresStatementsFactory) CreateUpdateEntityOwnerStatement(tenant api.Tenant, tableName, id string, owner api.User) (string, []any) {
+	if !tenant.IsEmpty() {
+		return fmt.Sprintf(`UPDATE %s SET owner = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2 AND tenant_id = $3;`, tableName), []any{owner, id, tenant.String()}
+	}
+	return fmt.Sprintf(`UPDATE %s SET owner = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2;`, tableName), []any{owner, id}
+}
+
 func (s *postgresStatementsFactory) CreateProviderAddEntityStatement(provider *api.ProviderResource, entity string) (string, []any) {
 	return INSERT_PROVIDER_STATEMENT, []any{provider.Resource.ID, provider.Resource.Tenant, provider.Resource.Owner, entity}
 }
@@ -233,3 +647,113 @@ func (s *postgresStatementsFactory) CreateCollectionGetEntityStatement(query *sh
 	where, whereArgs := s.getWhereStatement(query.Resource.Tenant, query.Resource.ID, 1)
 	return fmt.Sprintf(`SELECT id, created_at, updated_at, tenant_id, owner, entity FROM collections WHERE %s;`, where), whereArgs, []any{&query.Resource.ID, &query.Resource.CreatedAt, &query.Resource.UpdatedAt, &query.Resource.Tenant, &query.Resource.Owner, &query.EntityJSON}
 }
+
+func (s *postgresStatementsFactory) CreateBaselineAddEntityStatement(baseline *api.BaselineResource, entity string) (string, []any) {
+	return INSERT_BASELINE_STATEMENT, []any{baseline.Resource.ID, baseline.Resource.Tenant, baseline.Resource.Owner, entity}
+}
+
+func (s *postgresStatementsFactory) CreateBaselineGetEntityStatement(query *shared.EntityQuery) (string, []any, []any) {
+	where, whereArgs := s.getWhereStatement(query.Resource.Tenant, query.Resource.ID, 1)
+	return fmt.Sprintf(`SELECT id, created_at, updated_at, tenant_id, owner, entity FROM baselines WHERE %s;`, where), whereArgs, []any{&query.Resource.ID, &query.Resource.CreatedAt, &query.Resource.UpdatedAt, &query.Resource.Tenant, &query.Resource.Owner, &query.EntityJSON}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationViewAddEntityStatement(view *api.EvaluationViewResource, entity string) (string, []any) {
+	return INSERT_EVALUATION_VIEW_STATEMENT, []any{view.Resource.ID, view.Resource.Tenant, view.Resource.Owner, entity}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationViewGetEntityStatement(query *shared.EntityQuery) (string, []any, []any) {
+	where, whereArgs := s.getWhereStatement(query.Resource.Tenant, query.Resource.ID, 1)
+	return fmt.Sprintf(`SELECT id, created_at, updated_at, tenant_id, owner, entity FROM evaluation_views WHERE %s;`, where), whereArgs, []any{&query.Resource.ID, &query.Resource.CreatedAt, &query.Resource.UpdatedAt, &query.Resource.Tenant, &query.Resource.Owner, &query.EntityJSON}
+}
+
+func (s *postgresStatementsFactory) CreateResultCachePutStatement(tenant api.Tenant, key string, jobID string, completedAt time.Time, entity string) (string, []any) {
+	return UPSERT_RESULT_CACHE_STATEMENT, []any{key, tenant.String(), jobID, completedAt.UTC(), entity}
+}
+
+func (s *postgresStatementsFactory) CreateResultCacheGetStatement(tenant api.Tenant, key string) (string, []any) {
+	return SELECT_RESULT_CACHE_STATEMENT, []any{tenant.String(), key}
+}
+
+func (s *postgresStatementsFactory) CreateTagPolicyPutStatement(tenant api.Tenant, updatedAt time.Time, entity string) (string, []any) {
+	return UPSERT_TAG_POLICY_STATEMENT, []any{tenant.String(), updatedAt.UTC(), entity}
+}
+
+func (s *postgresStatementsFactory) CreateTagPolicyGetStatement(tenant api.Tenant) (string, []any) {
+	return SELECT_TAG_POLICY_STATEMENT, []any{tenant.String()}
+}
+
+func (s *postgresStatementsFactory) CreateRedactionPolicyPutStatement(tenant api.Tenant, updatedAt time.Time, entity string) (string, []any) {
+	return UPSERT_REDACTION_POLICY_STATEMENT, []any{tenant.String(), updatedAt.UTC(), entity}
+}
+
+func (s *postgresStatementsFactory) CreateRedactionPolicyGetStatement(tenant api.Tenant) (string, []any) {
+	return SELECT_REDACTION_POLICY_STATEMENT, []any{tenant.String()}
+}
+
+func (s *postgresStatementsFactory) CreateAdminConfigPutStatement(updatedAt time.Time, entity string) (string, []any) {
+	return UPSERT_ADMIN_CONFIG_STATEMENT, []any{updatedAt.UTC(), entity}
+}
+
+func (s *postgresStatementsFactory) CreateAdminConfigGetStatement() (string, []any) {
+	return SELECT_ADMIN_CONFIG_STATEMENT, nil
+}
+
+func (s *postgresStatementsFactory) CreateProviderHealthPutStatement(providerID string, updatedAt time.Time, entity string) (string, []any) {
+	return UPSERT_PROVIDER_HEALTH_STATEMENT, []any{providerID, updatedAt.UTC(), entity}
+}
+
+func (s *postgresStatementsFactory) CreateProviderHealthListStatement() (string, []any) {
+	return SELECT_PROVIDER_HEALTH_STATEMENT, nil
+}
+
+func (s *postgresStatementsFactory) CreateProviderHealthDeleteStatement(providerID string) (string, []any) {
+	return DELETE_PROVIDER_HEALTH_STATEMENT, []any{providerID}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationRedactionInsertStatement(jobID string, benchmarkIndex int, target string, redactedAt time.Time, entity string) (string, []any) {
+	return INSERT_EVALUATION_REDACTION_STATEMENT, []any{jobID, benchmarkIndex, target, redactedAt.UTC(), entity}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationRedactionsCountStatement(jobID string) (string, []any) {
+	return SELECT_EVALUATION_REDACTIONS_COUNT_STATEMENT, []any{jobID}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationRedactionsListStatement(jobID string, limit, offset int) (string, []any) {
+	return SELECT_EVALUATION_REDACTIONS_STATEMENT, []any{jobID, limit, offset}
+}
+
+func (s *postgresStatementsFactory) CreateEvaluationRedactionsDeleteStatement(jobID string) (string, []any) {
+	return DELETE_EVALUATION_REDACTIONS_STATEMENT, []any{jobID}
+}
+
+func (s *postgresStatementsFactory) CreateStatusDeadLetterInsertStatement(id string, tenant api.Tenant, jobID string, receivedAt time.Time, entity string) (string, []any) {
+	return INSERT_STATUS_DEAD_LETTER_STATEMENT, []any{id, tenant.String(), jobID, receivedAt.UTC(), entity}
+}
+
+func (s *postgresStatementsFactory) CreateStatusDeadLetterGetStatement(id string) (string, []any) {
+	return SELECT_STATUS_DEAD_LETTER_STATEMENT, []any{id}
+}
+
+func (s *postgresStatementsFactory) CreateStatusDeadLettersCountStatement(tenant api.Tenant, jobID string) (string, []any) {
+	return SELECT_STATUS_DEAD_LETTERS_COUNT_STATEMENT, []any{tenant.String(), jobID}
+}
+
+func (s *postgresStatementsFactory) CreateStatusDeadLettersListStatement(tenant api.Tenant, jobID string, limit, offset int) (string, []any) {
+	return SELECT_STATUS_DEAD_LETTERS_STATEMENT, []any{tenant.String(), jobID, limit, offset}
+}
+
+func (s *postgresStatementsFactory) CreateStatusDeadLetterDeleteStatement(id string) (string, []any) {
+	return DELETE_STATUS_DEAD_LETTER_STATEMENT, []any{id}
+}
+
+func (s *postgresStatementsFactory) CreateStatusDeadLettersDeleteStatement(jobID string) (string, []any) {
+	return DELETE_STATUS_DEAD_LETTERS_STATEMENT, []any{jobID}
+}
+
+func (s *postgresStatementsFactory) CreateBenchmarkDurationRecordStatement(tenant api.Tenant, providerID string, benchmarkID string, examplesBucket int, seconds float64) (string, []any) {
+	return UPSERT_BENCHMARK_DURATION_STATEMENT, []any{tenant.String(), providerID, benchmarkID, examplesBucket, seconds}
+}
+
+func (s *postgresStatementsFactory) CreateBenchmarkDurationGetStatement(tenant api.Tenant, providerID string, benchmarkID string, examplesBucket int) (string, []any) {
+	return SELECT_BENCHMARK_DURATION_STATEMENT, []any{tenant.String(), providerID, benchmarkID, examplesBucket}
+}
@@ -0,0 +1,62 @@
+package sql
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+)
+
+//#######################################################################
+// Provider health operations
+//#######################################################################
+
+func (s *sqlStorage) PutProviderHealth(record *abstractions.ProviderHealthRecord) error {
+	entity, err := json.Marshal(record)
+	if err != nil {
+		return serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	statement, args := s.statementsFactory.CreateProviderHealthPutStatement(record.ProviderID, time.Now(), string(entity))
+	if _, err := s.exec(nil, statement, args...); err != nil {
+		s.logger.Error("Failed to store provider health", "error", err, "provider_id", record.ProviderID)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "provider health", "ResourceId", record.ProviderID, "Error", err.Error())
+	}
+	return nil
+}
+
+func (s *sqlStorage) GetProviderHealthRecords() ([]abstractions.ProviderHealthRecord, error) {
+	statement, args := s.statementsFactory.CreateProviderHealthListStatement()
+	rows, err := s.query(nil, statement, args...)
+	if err != nil {
+		s.logger.Error("Failed to list provider health", "error", err)
+		return nil, serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "provider health", "ResourceId", "", "Error", err.Error())
+	}
+	defer func() { _ = rows.Close() }()
+	records := make([]abstractions.ProviderHealthRecord, 0)
+	for rows.Next() {
+		var entity string
+		if err := rows.Scan(&entity); err != nil {
+			return nil, serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "provider health", "ResourceId", "", "Error", err.Error())
+		}
+		var record abstractions.ProviderHealthRecord
+		if err := json.Unmarshal([]byte(entity), &record); err != nil {
+			return nil, serviceerrors.NewServiceError(messages.JSONUnmarshalFailed, "Type", "provider health", "Error", err.Error())
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "provider health", "ResourceId", "", "Error", err.Error())
+	}
+	return records, nil
+}
+
+func (s *sqlStorage) DeleteProviderHealth(providerID string) error {
+	statement, args := s.statementsFactory.CreateProviderHealthDeleteStatement(providerID)
+	if _, err := s.exec(nil, statement, args...); err != nil {
+		s.logger.Error("Failed to delete provider health", "error", err, "provider_id", providerID)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "provider health", "ResourceId", providerID, "Error", err.Error())
+	}
+	return nil
+}
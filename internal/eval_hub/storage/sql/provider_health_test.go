@@ -0,0 +1,60 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestProviderHealth(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	records, err := store.GetProviderHealthRecords()
+	if err != nil || len(records) != 0 {
+		t.Fatalf("expected no provider health, got %+v, %v", records, err)
+	}
+
+	startedAt := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	put := func(record abstractions.ProviderHealthRecord) {
+		t.Helper()
+		if err := store.WithTenant("ops").PutProviderHealth(&record); err != nil {
+			t.Fatalf("PutProviderHealth: %v", err)
+		}
+	}
+	put(abstractions.ProviderHealthRecord{ProviderID: "garak", Health: api.ProviderHealth{Status: api.ProviderHealthStatusUnknown}})
+	put(abstractions.ProviderHealthRecord{ProviderID: "lm_evaluation_harness", Health: api.ProviderHealth{Status: api.ProviderHealthStatusUnknown}})
+	// the health of a provider is replaced, whatever the tenant of the request
+	put(abstractions.ProviderHealthRecord{
+		ProviderID:      "garak",
+		Health:          api.ProviderHealth{Status: api.ProviderHealthStatusUnhealthy, FailureStreak: 2, LastError: "image pull failed"},
+		NextRunAt:       startedAt.Add(10 * time.Minute),
+		CanaryJobID:     "job-1",
+		CanaryTenant:    "canaries",
+		CanaryStartedAt: startedAt,
+	})
+
+	records, err = store.WithTenant("other").GetProviderHealthRecords()
+	if err != nil {
+		t.Fatalf("GetProviderHealthRecords: %v", err)
+	}
+	if len(records) != 2 || records[0].ProviderID != "garak" || records[1].ProviderID != "lm_evaluation_harness" {
+		t.Fatalf("expected the health of both providers, got %+v", records)
+	}
+	garak := records[0]
+	if garak.Health.Status != api.ProviderHealthStatusUnhealthy || garak.Health.FailureStreak != 2 || garak.CanaryJobID != "job-1" || garak.CanaryTenant != "canaries" || !garak.CanaryStartedAt.Equal(startedAt) {
+		t.Errorf("expected the second health of garak, got %+v", garak)
+	}
+
+	if err := store.DeleteProviderHealth("garak"); err != nil {
+		t.Fatalf("DeleteProviderHealth: %v", err)
+	}
+	records, err = store.GetProviderHealthRecords()
+	if err != nil || len(records) != 1 || records[0].ProviderID != "lm_evaluation_harness" {
+		t.Errorf("expected the health of garak to be deleted, got %+v, %v", records, err)
+	}
+}
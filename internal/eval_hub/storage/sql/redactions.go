@@ -0,0 +1,116 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"math"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	se "github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+//#######################################################################
+// Redaction policy operations
+//#######################################################################
+
+func (s *sqlStorage) PutRedactionPolicy(policy *api.RedactionPolicyResource) error {
+	entity, err := json.Marshal(policy)
+	if err != nil {
+		return se.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	statement, args := s.statementsFactory.CreateRedactionPolicyPutStatement(s.tenant, policy.UpdatedAt, string(entity))
+	if _, err := s.exec(nil, statement, args...); err != nil {
+		s.logger.Error("Failed to store redaction policy", "error", err)
+		return se.NewServiceError(messages.DatabaseOperationFailed, "Type", "redaction policy", "ResourceId", s.tenant.String(), "Error", err.Error())
+	}
+	return nil
+}
+
+func (s *sqlStorage) GetRedactionPolicy() (*api.RedactionPolicyResource, error) {
+	statement, args := s.statementsFactory.CreateRedactionPolicyGetStatement(s.tenant)
+
+	var policy api.RedactionPolicyResource
+	var entity string
+	err := s.queryRow(nil, statement, args...).Scan(&policy.UpdatedAt, &entity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		s.logger.Error("Failed to get redaction policy", "error", err)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "redaction policy", "ResourceId", s.tenant.String(), "Error", err.Error())
+	}
+	updatedAt := policy.UpdatedAt
+	if err := json.Unmarshal([]byte(entity), &policy); err != nil {
+		s.logger.Error("Failed to unmarshal redaction policy", "error", err)
+		return nil, se.NewServiceError(messages.JSONUnmarshalFailed, "Type", "redaction policy", "Error", err.Error())
+	}
+	policy.Tenant = s.tenant
+	policy.UpdatedAt = updatedAt
+	return &policy, nil
+}
+
+//#######################################################################
+// Evaluation redaction operations
+//#######################################################################
+
+// The evaluation_redactions table is the audit of the redactions applied to the artifacts and
+// messages of the benchmarks of a job, one row per redacted artifact upload or status event.
+
+// AddEvaluationJobRedaction records the redactions applied to an artifact or a message of a
+// benchmark of the job.
+func (s *sqlStorage) AddEvaluationJobRedaction(id string, record *api.RedactionRecord) error {
+	entity, err := json.Marshal(record)
+	if err != nil {
+		return se.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	statement, args := s.statementsFactory.CreateEvaluationRedactionInsertStatement(id, record.BenchmarkIndex, string(record.Target), record.RedactedAt, string(entity))
+	if _, err := s.exec(nil, statement, args...); err != nil {
+		s.logger.Error("Failed to record the redactions of evaluation job", "error", err, "id", id, "benchmark_index", record.BenchmarkIndex)
+		return se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job redactions", "ResourceId", id, "Error", err.Error())
+	}
+	return nil
+}
+
+// GetEvaluationJobRedactions returns the redactions applied to the job, oldest first.
+func (s *sqlStorage) GetEvaluationJobRedactions(id string, filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.RedactionRecord], error) {
+	if _, err := s.scanEvaluationJobTransactional(nil, id, false); err != nil {
+		return nil, err
+	}
+
+	var total int
+	countQuery, args := s.statementsFactory.CreateEvaluationRedactionsCountStatement(id)
+	if err := s.queryRow(nil, countQuery, args...).Scan(&total); err != nil {
+		s.logger.Error("Failed to count the redactions of evaluation job", "error", err, "id", id)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job redactions", "ResourceId", id, "Error", err.Error())
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = math.MaxInt32
+	}
+	listQuery, args := s.statementsFactory.CreateEvaluationRedactionsListStatement(id, limit, filter.Offset)
+	rows, err := s.query(nil, listQuery, args...)
+	if err != nil {
+		s.logger.Error("Failed to list the redactions of evaluation job", "error", err, "id", id)
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job redactions", "ResourceId", id, "Error", err.Error())
+	}
+	defer func() { _ = rows.Close() }()
+	items := make([]api.RedactionRecord, 0)
+	for rows.Next() {
+		var entity string
+		if err := rows.Scan(&entity); err != nil {
+			return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job redactions", "ResourceId", id, "Error", err.Error())
+		}
+		var item api.RedactionRecord
+		if err := json.Unmarshal([]byte(entity), &item); err != nil {
+			return nil, se.NewServiceError(messages.JSONUnmarshalFailed, "Type", "evaluation job redaction", "Error", err.Error())
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, se.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation job redactions", "ResourceId", id, "Error", err.Error())
+	}
+	return &abstractions.QueryResults[api.RedactionRecord]{Items: items, TotalCount: total}, nil
+}
@@ -0,0 +1,95 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestRedactionPolicy(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := store.WithTenant("tenant-redaction-policy")
+
+	if policy, err := tenant.GetRedactionPolicy(); err != nil || policy != nil {
+		t.Fatalf("expected no redaction policy, got %+v, %v", policy, err)
+	}
+
+	put := func(rules ...api.RedactionRule) {
+		t.Helper()
+		if err := tenant.PutRedactionPolicy(&api.RedactionPolicyResource{UpdatedAt: time.Now(), UpdatedBy: "alice", RedactionPolicy: api.RedactionPolicy{Rules: rules}}); err != nil {
+			t.Fatalf("PutRedactionPolicy: %v", err)
+		}
+	}
+	put(api.RedactionRule{Name: "email", Pattern: `\S+@\S+`})
+	// the policy of the tenant is replaced
+	put(api.RedactionRule{Name: "email", Pattern: `\S+@\S+`}, api.RedactionRule{Name: "ssn", Pattern: `\d{3}-\d{2}-\d{4}`, Replacement: "***"})
+
+	policy, err := tenant.GetRedactionPolicy()
+	if err != nil {
+		t.Fatalf("GetRedactionPolicy: %v", err)
+	}
+	if policy.Tenant != "tenant-redaction-policy" || policy.UpdatedBy != "alice" || len(policy.Rules) != 2 || policy.Rules[1].Replacement != "***" {
+		t.Errorf("unexpected redaction policy %+v", policy)
+	}
+	if other, err := store.WithTenant("tenant-redaction-policy-other").GetRedactionPolicy(); err != nil || other != nil {
+		t.Errorf("expected no redaction policy in another tenant, got %+v, %v", other, err)
+	}
+}
+
+func TestEvaluationJobRedactions(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-redactions")
+	store = store.WithTenant(tenant)
+
+	jobID := common.GUID()
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, CreatedAt: time.Now()}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model:      api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "toxigen"}, ProviderID: "lm_evaluation_harness"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+
+	redactedAt := time.Now().UTC()
+	for i, record := range []api.RedactionRecord{
+		{Target: api.RedactionTargetArtifact, Artifact: "samples.jsonl", Redactions: map[string]int{"email": 3}},
+		{Target: api.RedactionTargetErrorMessage, Redactions: map[string]int{"email": 1}},
+	} {
+		record.JobID = jobID
+		record.RedactedAt = redactedAt.Add(time.Duration(i) * time.Second)
+		if err := store.AddEvaluationJobRedaction(jobID, &record); err != nil {
+			t.Fatalf("AddEvaluationJobRedaction: %v", err)
+		}
+	}
+
+	redactions, err := store.GetEvaluationJobRedactions(jobID, &abstractions.QueryFilter{})
+	if err != nil {
+		t.Fatalf("GetEvaluationJobRedactions: %v", err)
+	}
+	if redactions.TotalCount != 2 || len(redactions.Items) != 2 || redactions.Items[0].Artifact != "samples.jsonl" || redactions.Items[0].Redactions["email"] != 3 || redactions.Items[1].Target != api.RedactionTargetErrorMessage {
+		t.Fatalf("unexpected redactions %+v", redactions)
+	}
+	if page, err := store.GetEvaluationJobRedactions(jobID, &abstractions.QueryFilter{Limit: 1, Offset: 1}); err != nil || page.TotalCount != 2 || len(page.Items) != 1 || page.Items[0].Target != api.RedactionTargetErrorMessage {
+		t.Fatalf("unexpected page %+v, %v", page, err)
+	}
+
+	// the redactions are deleted with the job
+	if err := store.DeleteEvaluationJob(jobID); err != nil {
+		t.Fatalf("DeleteEvaluationJob: %v", err)
+	}
+	if _, err := store.GetEvaluationJobRedactions(jobID, &abstractions.QueryFilter{}); err == nil {
+		t.Fatal("expected the redactions of a deleted job not to be found")
+	}
+}
@@ -0,0 +1,56 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+//#######################################################################
+// Result cache operations
+//#######################################################################
+
+func (s *sqlStorage) PutCachedBenchmarkResult(entry *abstractions.CachedBenchmarkResult) error {
+	entity, err := json.Marshal(entry.Result)
+	if err != nil {
+		return serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	statement, args := s.statementsFactory.CreateResultCachePutStatement(s.tenant, entry.Key, entry.JobID, entry.CompletedAt, string(entity))
+	if _, err := s.exec(nil, statement, args...); err != nil {
+		s.logger.Error("Failed to store cached benchmark result", "error", err, "key", entry.Key, "job_id", entry.JobID)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "cached benchmark result", "ResourceId", entry.Key, "Error", err.Error())
+	}
+	return nil
+}
+
+func (s *sqlStorage) GetCachedBenchmarkResult(key string, since time.Time) (*abstractions.CachedBenchmarkResult, error) {
+	statement, args := s.statementsFactory.CreateResultCacheGetStatement(s.tenant, key)
+
+	entry := abstractions.CachedBenchmarkResult{Key: key}
+	var entity string
+	err := s.queryRow(nil, statement, args...).Scan(&entry.JobID, &entry.CompletedAt, &entity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		s.logger.Error("Failed to get cached benchmark result", "error", err, "key", key)
+		return nil, serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "cached benchmark result", "ResourceId", key, "Error", err.Error())
+	}
+	// the TTL is checked here rather than in the query as SQLite stores timestamps as text
+	if entry.CompletedAt.Before(since) {
+		return nil, nil
+	}
+
+	var result api.BenchmarkResult
+	if err := json.Unmarshal([]byte(entity), &result); err != nil {
+		s.logger.Error("Failed to unmarshal cached benchmark result", "error", err, "key", key)
+		return nil, serviceerrors.NewServiceError(messages.JSONUnmarshalFailed, "Type", "cached benchmark result", "Error", err.Error())
+	}
+	entry.Result = result
+	return &entry, nil
+}
@@ -0,0 +1,69 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage"
+	"github.com/eval-hub/eval-hub/internal/logging"
+)
+
+func TestResultCache(t *testing.T) {
+	logger := logging.FallbackLogger()
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           getDBInMemoryURL("eval_hub_result_cache"),
+		"database_name": "eval_hub_result_cache",
+	}
+	store, err := storage.NewStorage(&databaseConfig, nil, nil, false, false, logger)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	teamA := store.WithTenant("team-a")
+	completedAt := time.Now().Add(-time.Hour)
+	put := func(jobID string, completedAt time.Time) {
+		t.Helper()
+		entry := &abstractions.CachedBenchmarkResult{Key: "key-1", JobID: jobID, CompletedAt: completedAt}
+		entry.Result.ID = "mmlu"
+		entry.Result.Metrics = map[string]any{"accuracy": 0.5}
+		if err := teamA.PutCachedBenchmarkResult(entry); err != nil {
+			t.Fatalf("PutCachedBenchmarkResult: %v", err)
+		}
+	}
+	put("job-1", completedAt)
+
+	t.Run("a result within the TTL is returned", func(t *testing.T) {
+		entry, err := teamA.GetCachedBenchmarkResult("key-1", completedAt.Add(-time.Minute))
+		if err != nil {
+			t.Fatalf("GetCachedBenchmarkResult: %v", err)
+		}
+		if entry == nil || entry.JobID != "job-1" || entry.Result.Metrics["accuracy"] != 0.5 {
+			t.Fatalf("unexpected cache entry %+v", entry)
+		}
+	})
+
+	t.Run("an expired result is not returned", func(t *testing.T) {
+		entry, err := teamA.GetCachedBenchmarkResult("key-1", completedAt.Add(time.Minute))
+		if err != nil || entry != nil {
+			t.Fatalf("expected no entry, got %+v, %v", entry, err)
+		}
+	})
+
+	t.Run("results are scoped to the tenant", func(t *testing.T) {
+		entry, err := store.WithTenant("team-b").GetCachedBenchmarkResult("key-1", completedAt.Add(-time.Minute))
+		if err != nil || entry != nil {
+			t.Fatalf("expected no entry for another tenant, got %+v, %v", entry, err)
+		}
+	})
+
+	t.Run("a newer result replaces the entry", func(t *testing.T) {
+		put("job-2", time.Now())
+		entry, err := teamA.GetCachedBenchmarkResult("key-1", completedAt.Add(time.Minute))
+		if err != nil || entry == nil || entry.JobID != "job-2" {
+			t.Fatalf("expected the entry of job-2, got %+v, %v", entry, err)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package sql
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// retryBenchmark turns the failure of a benchmark into a retry when the retry policy of the
+// benchmark covers the failure and has retries left. The failed run is added to the attempts
+// of the benchmark and the returned event marks the benchmark pending again, with the backoff
+// before the caller starts it on new runtime resources. The event is returned unchanged, with
+// a nil retry, when the benchmark is not retried.
+func retryBenchmark(
+	benchmark *api.EvaluationBenchmarkConfig,
+	previous *api.BenchmarkStatus,
+	event *api.BenchmarkStatusEvent,
+	attempts []api.BenchmarkAttempt,
+) (*api.BenchmarkStatusEvent, []api.BenchmarkAttempt, *api.BenchmarkRetry) {
+	policy := benchmark.Retry
+	if policy == nil || event.Status != api.StateFailed || len(attempts) >= policy.MaxRetries {
+		return event, attempts, nil
+	}
+	// a failure reported again for a benchmark that already failed is not retried
+	if previous != nil && api.IsBenchmarkTerminalState(previous.Status) {
+		return event, attempts, nil
+	}
+	retryOn := policy.RetryOn
+	if len(retryOn) == 0 {
+		retryOn = constants.TransientMessageCodes
+	}
+	if event.ErrorMessage == nil || !slices.Contains(retryOn, event.ErrorMessage.MessageCode) {
+		return event, attempts, nil
+	}
+
+	failedAt := event.CompletedAt
+	if failedAt == "" {
+		failedAt = api.DateTimeToString(time.Now())
+	}
+	attempts = append(slices.Clone(attempts), api.BenchmarkAttempt{
+		Attempt:      len(attempts) + 1,
+		ErrorMessage: event.ErrorMessage,
+		StartedAt:    event.StartedAt,
+		FailedAt:     failedAt,
+	})
+	backoff := time.Duration(policy.BackoffSeconds) * time.Second << (len(attempts) - 1)
+	retry := &api.BenchmarkStatusEvent{
+		ProviderID:     event.ProviderID,
+		ID:             event.ID,
+		BenchmarkIndex: event.BenchmarkIndex,
+		Status:         api.StatePending,
+		WarningMessage: api.WithMessageOrigin(&api.MessageInfo{
+			Message:     fmt.Sprintf("Retry %d of %d in %s after: %s", len(attempts), policy.MaxRetries, backoff, event.ErrorMessage.Message),
+			MessageCode: constants.MESSAGE_CODE_BENCHMARK_RETRYING,
+		}, api.MessageOriginServer),
+	}
+	return retry, attempts, &api.BenchmarkRetry{BenchmarkIndex: event.BenchmarkIndex, Backoff: backoff}
+}
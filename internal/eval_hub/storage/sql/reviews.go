@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	se "github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// The review of a job is held by the test result of the job, in its entity, so that the jobs
+// with a pending review are listed with the "review" filter of the evaluations.
+
+// requestReview requests a review of the job test result when its score is within the review
+// band of its threshold. The job does not pass until the review is approved.
+func requestReview(jobTest *api.EvaluationTest, band float32) {
+	if band <= 0 || jobTest.Score < jobTest.Threshold-band || jobTest.Score > jobTest.Threshold+band {
+		return
+	}
+	now := api.DateTimeToString(time.Now())
+	jobTest.Review = &api.Review{
+		State:       api.ReviewStatePending,
+		Band:        band,
+		ScorePass:   jobTest.Pass,
+		RequestedAt: now,
+		History:     []api.ReviewEvent{{State: api.ReviewStatePending, Time: now}},
+	}
+	jobTest.Pass = false
+}
+
+func getPassCriteriaReviewBand(job *api.EvaluationJobResource, collection *api.CollectionResource) float32 {
+	if job.PassCriteria != nil && job.PassCriteria.ReviewBand != nil {
+		return *job.PassCriteria.ReviewBand
+	}
+	if collection != nil && collection.PassCriteria != nil && collection.PassCriteria.ReviewBand != nil {
+		return *collection.PassCriteria.ReviewBand
+	}
+	return 0
+}
+
+func (s *sqlStorage) ReviewEvaluationJob(id string, reviewer api.User, decision *api.ReviewDecision) (*api.EvaluationJobResource, error) {
+	var updated *api.EvaluationJobResource
+
+	err := s.withTransaction("review evaluation job", id, func(txn *sql.Tx) error {
+		job, stored, err := s.getEvaluationJobTransactionalForUpdate(txn, id)
+		if err != nil {
+			return err
+		}
+		var review *api.Review
+		if job.Results != nil && job.Results.Test != nil {
+			review = job.Results.Test.Review
+		}
+		if review == nil || review.State != api.ReviewStatePending {
+			state := "not requested"
+			if review != nil {
+				state = string(review.State)
+			}
+			return se.WithRollback(se.NewServiceError(messages.ReviewNotPending, "EvaluationJobID", id, "State", state))
+		}
+
+		now := api.DateTimeToString(time.Now())
+		review.State = decision.Decision
+		review.Reviewer = reviewer
+		review.Comment = decision.Comment
+		review.ReviewedAt = now
+		review.History = append(review.History, api.ReviewEvent{State: decision.Decision, User: reviewer, Comment: decision.Comment, Time: now})
+		job.Results.Test.Pass = decision.Decision == api.ReviewStateApproved
+
+		if err := s.updateEvaluationJobTxn(txn, id, job.Status.State, job, stored); err != nil {
+			return err
+		}
+		s.logger.Info("Reviewed evaluation job", "id", id, "reviewer", reviewer, "decision", decision.Decision)
+
+		updated, err = s.getEvaluationJobTransactional(txn, id)
+		return err
+	})
+
+	return updated, err
+}
@@ -0,0 +1,136 @@
+package sql_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestReviewEvaluationJob(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-reviews")
+	store = store.WithTenant(tenant)
+	if err := store.CreateProvider(&api.ProviderResource{
+		Resource: api.Resource{ID: "review-provider", Tenant: tenant, CreatedAt: time.Now()},
+		ProviderConfig: api.ProviderConfig{
+			Name:       "Review Provider",
+			Benchmarks: []api.BenchmarkResource{{ID: "mmlu", PrimaryScore: &api.PrimaryScore{Metric: "acc"}}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateProvider: %v", err)
+	}
+
+	benchmarkThreshold := float32(0.5)
+	threshold := float32(0.7)
+	band := float32(0.05)
+	// completeJob runs a job whose score is acc against the job threshold of 0.7
+	completeJob := func(acc float64) string {
+		t.Helper()
+		jobID := common.GUID()
+		if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, Owner: "alice", CreatedAt: time.Now()}},
+			Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Name:         "review",
+				Model:        api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+				PassCriteria: &api.PassCriteria{Threshold: &threshold, ReviewBand: &band},
+				Benchmarks: []api.EvaluationBenchmarkConfig{{
+					Ref:          api.Ref{ID: "mmlu"},
+					ProviderID:   "review-provider",
+					PassCriteria: &api.PassCriteria{Threshold: &benchmarkThreshold},
+				}},
+			},
+		}); err != nil {
+			t.Fatalf("CreateEvaluationJob: %v", err)
+		}
+		if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+			ProviderID: "review-provider",
+			ID:         "mmlu",
+			Status:     api.StateCompleted,
+			Metrics:    map[string]any{"acc": acc},
+		}}); err != nil {
+			t.Fatalf("UpdateEvaluationJob: %v", err)
+		}
+		return jobID
+	}
+	jobTest := func(jobID string) *api.EvaluationTest {
+		t.Helper()
+		job, err := store.GetEvaluationJob(jobID)
+		if err != nil {
+			t.Fatalf("GetEvaluationJob: %v", err)
+		}
+		if job.Results == nil || job.Results.Test == nil {
+			t.Fatalf("expected a test result for job %s", jobID)
+		}
+		return job.Results.Test
+	}
+	hasMessage := func(err error, message *messages.MessageCode) bool {
+		var se *serviceerrors.ServiceError
+		return errors.As(err, &se) && se.MessageCode() == message
+	}
+
+	borderline := completeJob(0.72)
+	passed := completeJob(0.9)
+	rejected := completeJob(0.68)
+
+	test := jobTest(borderline)
+	if test.Pass || test.Review == nil || test.Review.State != api.ReviewStatePending || !test.Review.ScorePass || test.Review.Band != band {
+		t.Fatalf("expected a pending review of a passing score, got %+v (review %+v)", test, test.Review)
+	}
+	if test := jobTest(passed); !test.Pass || test.Review != nil {
+		t.Errorf("expected a score outside the band to pass without review, got %+v", test)
+	}
+
+	pending, err := store.GetEvaluationJobs(&abstractions.QueryFilter{Limit: 10, Params: map[string]any{"review": string(api.ReviewStatePending)}})
+	if err != nil {
+		t.Fatalf("GetEvaluationJobs: %v", err)
+	}
+	if pending.TotalCount != 2 {
+		t.Errorf("expected the 2 borderline jobs to be pending review, got %d", pending.TotalCount)
+	}
+
+	job, err := store.ReviewEvaluationJob(borderline, "carol", &api.ReviewDecision{Decision: api.ReviewStateApproved})
+	if err != nil {
+		t.Fatalf("ReviewEvaluationJob: %v", err)
+	}
+	review := job.Results.Test.Review
+	if !job.Results.Test.Pass || review.State != api.ReviewStateApproved || review.Reviewer != "carol" || review.ReviewedAt == "" {
+		t.Errorf("expected the approved job to pass, got %+v (review %+v)", job.Results.Test, review)
+	}
+	if len(review.History) != 2 || review.History[0].State != api.ReviewStatePending || review.History[1].User != "carol" {
+		t.Errorf("expected the request and the approval in the history, got %+v", review.History)
+	}
+	if len(job.Results.Benchmarks) != 1 {
+		t.Errorf("expected the benchmark results to be kept, got %+v", job.Results.Benchmarks)
+	}
+
+	if _, err := store.ReviewEvaluationJob(borderline, "dave", &api.ReviewDecision{Decision: api.ReviewStateRejected, Comment: "again"}); !hasMessage(err, messages.ReviewNotPending) {
+		t.Errorf("expected a decided review not to be decided again, got %v", err)
+	}
+	if _, err := store.ReviewEvaluationJob(passed, "carol", &api.ReviewDecision{Decision: api.ReviewStateApproved}); !hasMessage(err, messages.ReviewNotPending) {
+		t.Errorf("expected a job without review not to be reviewed, got %v", err)
+	}
+
+	if _, err := store.ReviewEvaluationJob(rejected, "carol", &api.ReviewDecision{Decision: api.ReviewStateRejected, Comment: "regressed on the held-out set"}); err != nil {
+		t.Fatalf("ReviewEvaluationJob: %v", err)
+	}
+	if test := jobTest(rejected); test.Pass || test.Review.State != api.ReviewStateRejected || test.Review.Comment != "regressed on the held-out set" {
+		t.Errorf("expected the rejected job to fail, got %+v (review %+v)", test, test.Review)
+	}
+	pending, err = store.GetEvaluationJobs(&abstractions.QueryFilter{Limit: 10, Params: map[string]any{"review": string(api.ReviewStatePending)}})
+	if err != nil {
+		t.Fatalf("GetEvaluationJobs: %v", err)
+	}
+	if pending.TotalCount != 0 {
+		t.Errorf("expected no pending review, got %d", pending.TotalCount)
+	}
+}
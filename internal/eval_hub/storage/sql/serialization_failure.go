@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 )
 
 const serializationFailureMaxAttempts = 5
@@ -25,9 +27,16 @@ func isSerializationFailure(err error) bool {
 	if errors.As(err, &pgErr) && pgErr.Code == "40001" {
 		return true
 	}
+	// SQLite reports a writer that outlasted the busy timeout, e.g. of another process,
+	// with SQLITE_BUSY (or an extended code of it); the transaction can be run again
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code()&0xff == sqlite3.SQLITE_BUSY {
+		return true
+	}
 	msg := err.Error()
 	return strings.Contains(msg, "SQLSTATE 40001") ||
-		strings.Contains(msg, "could not serialize access due to read/write dependencies among transactions")
+		strings.Contains(msg, "could not serialize access due to read/write dependencies among transactions") ||
+		strings.Contains(msg, "(SQLITE_BUSY)")
 }
 
 func serializationFailureBackoff(attempt int) time.Duration {
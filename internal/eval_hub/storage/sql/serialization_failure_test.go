@@ -36,6 +36,16 @@ func TestIsSerializationFailure(t *testing.T) {
 			),
 			want: true,
 		},
+		{
+			name: "service error with sqlite busy",
+			err: serviceerrors.NewServiceError(
+				messages.DatabaseOperationFailed,
+				"Type", "begin transaction update evaluation job",
+				"ResourceId", "job-1",
+				"Error", "database is locked (5) (SQLITE_BUSY)",
+			),
+			want: true,
+		},
 		{
 			name: "service error unrelated",
 			err: serviceerrors.NewServiceError(
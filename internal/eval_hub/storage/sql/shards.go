@@ -0,0 +1,249 @@
+package sql
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	se "github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// shardWeightKey is the additional_info entry in which a shard reports how many examples
+// it evaluated. Numeric metrics are averaged over the shards weighted by it, or with
+// equal weights when a shard does not report it.
+const shardWeightKey = "num_examples"
+
+// mergeShardEvent records the status event of one shard of a sharded benchmark and returns
+// the event for the benchmark as a whole: failed as soon as one shard fails, completed with
+// the merged metrics once all the shards completed, and running otherwise. Events without
+// a shard index, or for benchmarks that are not sharded, are returned as they are.
+func (s *sqlStorage) mergeShardEvent(job *api.EvaluationJobResource, event *api.BenchmarkStatusEvent, collection *api.CollectionResource) (*api.BenchmarkStatusEvent, []api.BenchmarkShardStatus, error) {
+	if event.ShardIndex == nil {
+		return event, nil, nil
+	}
+	benchmarks, err := handlers.GetJobBenchmarks(job, collection)
+	if err != nil {
+		return nil, nil, err
+	}
+	shardCount := 1
+	if event.BenchmarkIndex >= 0 && event.BenchmarkIndex < len(benchmarks) {
+		shardCount = max(benchmarks[event.BenchmarkIndex].Shards, 1)
+	}
+	if shardCount == 1 {
+		return event, nil, nil
+	}
+	shardIndex := *event.ShardIndex
+	if shardIndex < 0 || shardIndex >= shardCount {
+		return nil, nil, se.NewServiceError(messages.InvalidShardIndex, "ShardIndex", shardIndex, "BenchmarkID", event.ID, "Shards", shardCount)
+	}
+
+	var shards []api.BenchmarkShardStatus
+	if current := findBenchmarkStatus(job, event); current != nil {
+		shards = slices.Clone(current.Shards)
+	}
+	shard := api.BenchmarkShardStatus{
+		ShardIndex:     shardIndex,
+		Status:         event.Status,
+		Phase:          event.Phase,
+		Metrics:        event.Metrics,
+		AdditionalInfo: event.AdditionalInfo,
+		Artifacts:      event.Artifacts,
+		ErrorMessage:   event.ErrorMessage,
+		MLFlowRunID:    event.MLFlowRunID,
+		LogsPath:       event.LogsPath,
+		StartedAt:      event.StartedAt,
+		CompletedAt:    event.CompletedAt,
+		MLFlowRunURL:   event.MLFlowRunURL,
+
+		ProcessedMetrics: event.ProcessedMetrics,
+		EventID:          event.EventID,
+		Sequence:         event.Sequence,
+	}
+	i := slices.IndexFunc(shards, func(existing api.BenchmarkShardStatus) bool {
+		return existing.ShardIndex == shardIndex
+	})
+	switch {
+	case i < 0:
+		shards = append(shards, shard)
+		slices.SortFunc(shards, func(a, b api.BenchmarkShardStatus) int { return a.ShardIndex - b.ShardIndex })
+	case api.IsBenchmarkTerminalState(shards[i].Status) && !api.IsBenchmarkTerminalState(shard.Status):
+		// a late progress update must not reopen a finished shard
+	default:
+		if shard.Sequence == 0 && eventSource(shard.EventID) == eventSource(shards[i].EventID) {
+			shard.Sequence = shards[i].Sequence
+		}
+		shards[i] = shard
+	}
+
+	merged := *event
+	merged.ShardIndex = nil
+	merged.Metrics, merged.AdditionalInfo, merged.Artifacts, merged.ProcessedMetrics = nil, nil, nil, nil
+	merged.MLFlowRunID, merged.MLFlowRunURL, merged.LogsPath = "", "", ""
+	// the events of the shards are ordered by shard, not for the benchmark
+	merged.EventID, merged.Sequence = "", 0
+	merged.StartedAt = earliestShardStart(shards)
+
+	completed := 0
+	for _, shard := range shards {
+		switch shard.Status {
+		case api.StateFailed, api.StateCancelled:
+			merged.Status = shard.Status
+			merged.ErrorMessage = shardErrorMessage(shard)
+			if shard.CompletedAt != "" {
+				merged.CompletedAt = shard.CompletedAt
+			}
+			return &merged, shards, nil
+		case api.StateCompleted:
+			completed++
+		}
+	}
+	if completed < shardCount {
+		merged.Status = api.StateRunning
+		merged.CompletedAt = ""
+		merged.ErrorMessage = nil
+		return &merged, shards, nil
+	}
+
+	merged.Status = api.StateCompleted
+	merged.Phase = api.JobPhaseCompleted
+	merged.Metrics = mergeShardMetrics(shards, func(shard api.BenchmarkShardStatus) map[string]any { return shard.Metrics })
+	merged.ProcessedMetrics = mergeShardMetrics(shards, func(shard api.BenchmarkShardStatus) map[string]any { return shard.ProcessedMetrics })
+	merged.AdditionalInfo = map[string]any{}
+	merged.Artifacts = map[string]any{}
+	for _, shard := range shards {
+		key := fmt.Sprintf("shard_%d", shard.ShardIndex)
+		if len(shard.AdditionalInfo) > 0 {
+			merged.AdditionalInfo[key] = shard.AdditionalInfo
+		}
+		if len(shard.Artifacts) > 0 {
+			merged.Artifacts[key] = shard.Artifacts
+		}
+	}
+	// the MLflow run and logs of the first shard stand for the benchmark
+	merged.MLFlowRunID = shards[0].MLFlowRunID
+	merged.MLFlowRunURL = shards[0].MLFlowRunURL
+	merged.LogsPath = shards[0].LogsPath
+	return &merged, shards, nil
+}
+
+func findBenchmarkStatus(job *api.EvaluationJobResource, event *api.BenchmarkStatusEvent) *api.BenchmarkStatus {
+	if job.Status == nil {
+		return nil
+	}
+	for i, benchmark := range job.Status.Benchmarks {
+		if benchmark.ID == event.ID && benchmark.ProviderID == event.ProviderID && benchmark.BenchmarkIndex == event.BenchmarkIndex {
+			return &job.Status.Benchmarks[i]
+		}
+	}
+	return nil
+}
+
+func earliestShardStart(shards []api.BenchmarkShardStatus) api.DateTime {
+	var earliest api.DateTime
+	for _, shard := range shards {
+		if shard.StartedAt == "" {
+			continue
+		}
+		started, err := api.DateTimeFromString(shard.StartedAt)
+		if err != nil {
+			continue
+		}
+		if current, err := api.DateTimeFromString(earliest); earliest == "" || err != nil || started.Before(current) {
+			earliest = shard.StartedAt
+		}
+	}
+	return earliest
+}
+
+func shardErrorMessage(shard api.BenchmarkShardStatus) *api.MessageInfo {
+	message := api.MessageInfo{Message: fmt.Sprintf("Shard %d %s", shard.ShardIndex, shard.Status)}
+	if shard.ErrorMessage != nil {
+		message = *shard.ErrorMessage
+		message.Message = fmt.Sprintf("Shard %d: %s", shard.ShardIndex, shard.ErrorMessage.Message)
+	}
+	return &message
+}
+
+// mergeShardMetrics averages the numeric metrics of the shards, weighted by the number of
+// examples each shard evaluated. Nested metric objects are merged the same way, and
+// non-numeric values are taken from the first shard that reports them.
+func mergeShardMetrics(shards []api.BenchmarkShardStatus, metricsOf func(api.BenchmarkShardStatus) map[string]any) map[string]any {
+	metrics := make([]map[string]any, 0, len(shards))
+	weights := make([]float64, 0, len(shards))
+	for _, shard := range shards {
+		weight := 1.0
+		if value, ok := toFloat(shard.AdditionalInfo[shardWeightKey]); ok && value > 0 {
+			weight = value
+		}
+		metrics = append(metrics, metricsOf(shard))
+		weights = append(weights, weight)
+	}
+	return mergeWeighted(metrics, weights)
+}
+
+func mergeWeighted(values []map[string]any, weights []float64) map[string]any {
+	keys := map[string]bool{}
+	for _, value := range values {
+		for key := range value {
+			keys[key] = true
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	merged := make(map[string]any, len(keys))
+	for key := range keys {
+		var sum, totalWeight float64
+		var nested []map[string]any
+		var nestedWeights []float64
+		var first any
+		numeric := true
+		for i, value := range values {
+			v, ok := value[key]
+			if !ok {
+				continue
+			}
+			if first == nil {
+				first = v
+			}
+			if object, ok := v.(map[string]any); ok {
+				nested = append(nested, object)
+				nestedWeights = append(nestedWeights, weights[i])
+			}
+			number, ok := toFloat(v)
+			if !ok {
+				numeric = false
+				continue
+			}
+			sum += number * weights[i]
+			totalWeight += weights[i]
+		}
+		switch {
+		case numeric && totalWeight > 0:
+			merged[key] = sum / totalWeight
+		case len(nested) > 0:
+			merged[key] = mergeWeighted(nested, nestedWeights)
+		default:
+			merged[key] = first
+		}
+	}
+	return merged
+}
+
+func toFloat(value any) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case float32:
+		return float64(number), true
+	case int:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,132 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestUpdateEvaluationJob_MergesShards(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+
+	createJob := func(t *testing.T) string {
+		t.Helper()
+		now := time.Now()
+		jobID := common.GUID()
+		job := &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{
+				Resource: api.Resource{ID: jobID, Tenant: api.Tenant("tenant-shards"), CreatedAt: now, UpdatedAt: now},
+			},
+			Status: &api.EvaluationJobStatus{
+				EvaluationJobState: api.EvaluationJobState{
+					State:   api.OverallStatePending,
+					Message: &api.MessageInfo{Message: "Job is pending", MessageCode: "JOB_PENDING"},
+				},
+			},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Model: api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+				Benchmarks: []api.EvaluationBenchmarkConfig{
+					{Ref: api.Ref{ID: "mmlu"}, ProviderID: "lm_evaluation_harness", Shards: 2},
+				},
+			},
+		}
+		if err := store.CreateEvaluationJob(job); err != nil {
+			t.Fatalf("Failed to create job: %v", err)
+		}
+		return jobID
+	}
+	update := func(t *testing.T, jobID string, shardIndex int, status api.State, metrics map[string]any, additionalInfo map[string]any) error {
+		t.Helper()
+		_, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+			BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+				ProviderID:     "lm_evaluation_harness",
+				ID:             "mmlu",
+				ShardIndex:     &shardIndex,
+				Status:         status,
+				Metrics:        metrics,
+				AdditionalInfo: additionalInfo,
+				ErrorMessage:   map[bool]*api.MessageInfo{true: {Message: "out of memory", MessageCode: "OOM"}}[status == api.StateFailed],
+			},
+		})
+		return err
+	}
+
+	t.Run("the benchmark completes with the merged metrics of all shards", func(t *testing.T) {
+		jobID := createJob(t)
+		if err := update(t, jobID, 0, api.StateCompleted, map[string]any{"acc": 0.5, "model": "m"}, map[string]any{"num_examples": 100}); err != nil {
+			t.Fatalf("Failed to update shard 0: %v", err)
+		}
+		job, _ := store.GetEvaluationJob(jobID)
+		if job.Status.Benchmarks[0].Status != api.StateRunning || job.Status.State != api.OverallStateRunning {
+			t.Fatalf("Expected the benchmark to run until all shards complete, got %s / %s", job.Status.Benchmarks[0].Status, job.Status.State)
+		}
+
+		if err := update(t, jobID, 1, api.StateCompleted, map[string]any{"acc": 0.8, "model": "m"}, map[string]any{"num_examples": 300}); err != nil {
+			t.Fatalf("Failed to update shard 1: %v", err)
+		}
+		job, _ = store.GetEvaluationJob(jobID)
+		if job.Status.State != api.OverallStateCompleted || len(job.Status.Benchmarks[0].Shards) != 2 {
+			t.Fatalf("Expected a completed job with 2 shards, got %s with %d shards", job.Status.State, len(job.Status.Benchmarks[0].Shards))
+		}
+		if len(job.Results.Benchmarks) != 1 {
+			t.Fatalf("Expected one merged result, got %d", len(job.Results.Benchmarks))
+		}
+		result := job.Results.Benchmarks[0]
+		if acc, _ := result.Metrics["acc"].(float64); acc < 0.7249 || acc > 0.7251 {
+			t.Errorf("Expected the example-weighted accuracy 0.725, got %v", result.Metrics["acc"])
+		}
+		if result.Metrics["model"] != "m" {
+			t.Errorf("Expected non-numeric metrics to be kept, got %v", result.Metrics["model"])
+		}
+		if _, ok := result.AdditionalInfo["shard_1"]; !ok {
+			t.Errorf("Expected the additional info of each shard, got %v", result.AdditionalInfo)
+		}
+	})
+
+	t.Run("the processed metrics of the shards are merged like their metrics", func(t *testing.T) {
+		jobID := createJob(t)
+		for shardIndex, acc := range []float64{50, 80} {
+			_, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{
+				BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+					ProviderID:       "lm_evaluation_harness",
+					ID:               "mmlu",
+					ShardIndex:       &shardIndex,
+					Status:           api.StateCompleted,
+					Metrics:          map[string]any{"acc": acc / 100},
+					ProcessedMetrics: map[string]any{"acc_percent": acc},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Failed to update shard %d: %v", shardIndex, err)
+			}
+		}
+		job, _ := store.GetEvaluationJob(jobID)
+		if processed := job.Results.Benchmarks[0].ProcessedMetrics; processed["acc_percent"] != 65.0 {
+			t.Fatalf("Expected the merged processed metrics, got %v", processed)
+		}
+	})
+
+	t.Run("a failed shard fails the benchmark", func(t *testing.T) {
+		jobID := createJob(t)
+		if err := update(t, jobID, 1, api.StateFailed, nil, nil); err != nil {
+			t.Fatalf("Failed to update shard 1: %v", err)
+		}
+		job, _ := store.GetEvaluationJob(jobID)
+		benchmark := job.Status.Benchmarks[0]
+		if benchmark.Status != api.StateFailed || benchmark.ErrorMessage == nil || benchmark.ErrorMessage.Message != "Shard 1: out of memory" {
+			t.Fatalf("Expected the benchmark to fail with the shard's error, got %+v", benchmark)
+		}
+	})
+
+	t.Run("an unknown shard is rejected", func(t *testing.T) {
+		jobID := createJob(t)
+		if err := update(t, jobID, 2, api.StateRunning, nil, nil); err == nil {
+			t.Fatalf("Expected an error for a shard index out of range")
+		}
+	})
+}
@@ -4,4 +4,6 @@ const (
 	TABLE_EVALUATIONS = "evaluations"
 	TABLE_COLLECTIONS = "collections"
 	TABLE_PROVIDERS   = "providers"
+	TABLE_BASELINES   = "baselines"
+	TABLE_VIEWS       = "evaluation_views"
 )
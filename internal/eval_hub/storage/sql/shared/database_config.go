@@ -19,10 +19,44 @@ type SQLDatabaseConfig struct {
 	MaxIdleConns    *int           `mapstructure:"max_idle_conns,omitempty"`
 	MaxOpenConns    *int           `mapstructure:"max_open_conns,omitempty"`
 	Fallback        bool           `mapstructure:"fallback,omitempty"`
+	// QueryTimeout bounds each statement and TransactionTimeout each transaction, within
+	// the deadline of the request, 0 disables them.
+	QueryTimeout       *time.Duration `mapstructure:"query_timeout,omitempty"`
+	TransactionTimeout *time.Duration `mapstructure:"transaction_timeout,omitempty"`
+	// SlowQueryThreshold is the duration from which statements and transactions are logged
+	// as slow, 0 disables the log.
+	SlowQueryThreshold *time.Duration `mapstructure:"slow_query_threshold,omitempty"`
 
 	// Other map[string]any `mapstructure:",remain"`
 }
 
+const (
+	DefaultQueryTimeout       = 30 * time.Second
+	DefaultTransactionTimeout = 60 * time.Second
+	DefaultSlowQueryThreshold = 1 * time.Second
+)
+
+func (s *SQLDatabaseConfig) GetQueryTimeout() time.Duration {
+	if s.QueryTimeout == nil {
+		return DefaultQueryTimeout
+	}
+	return *s.QueryTimeout
+}
+
+func (s *SQLDatabaseConfig) GetTransactionTimeout() time.Duration {
+	if s.TransactionTimeout == nil {
+		return DefaultTransactionTimeout
+	}
+	return *s.TransactionTimeout
+}
+
+func (s *SQLDatabaseConfig) GetSlowQueryThreshold() time.Duration {
+	if s.SlowQueryThreshold == nil {
+		return DefaultSlowQueryThreshold
+	}
+	return *s.SlowQueryThreshold
+}
+
 func (s *SQLDatabaseConfig) GetDriverName() string {
 	return s.Driver
 }
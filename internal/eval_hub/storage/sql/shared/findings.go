@@ -0,0 +1,43 @@
+package shared
+
+import (
+	"strings"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// NoShard is the shard index of the findings of a benchmark that is not sharded.
+const NoShard = -1
+
+// FindingsWhere returns the WHERE conditions and args of the findings of the job that match
+// the filter, placeholder returns the placeholder of the nth arg. The filter keys are
+// benchmark_index (int), severity ([]api.Severity, any of them), min_severity (the rank of the
+// lowest severity), probe and detector.
+func FindingsWhere(jobID string, filter map[string]any, placeholder func(n int) string) (string, []any) {
+	var args []any
+	next := func(value any) string {
+		args = append(args, value)
+		return placeholder(len(args))
+	}
+	conditions := []string{"job_id = " + next(jobID)}
+	if benchmarkIndex, ok := filter["benchmark_index"].(int); ok {
+		conditions = append(conditions, "benchmark_index = "+next(benchmarkIndex))
+	}
+	if severities, ok := filter["severity"].([]api.Severity); ok && len(severities) > 0 {
+		in := make([]string, 0, len(severities))
+		for _, severity := range severities {
+			in = append(in, next(string(severity)))
+		}
+		conditions = append(conditions, "severity IN ("+strings.Join(in, ", ")+")")
+	}
+	if minSeverity, ok := filter["min_severity"].(int); ok && minSeverity > 0 {
+		conditions = append(conditions, "severity_rank >= "+next(minSeverity))
+	}
+	if probe, ok := filter["probe"].(string); ok && probe != "" {
+		conditions = append(conditions, "probe = "+next(probe))
+	}
+	if detector, ok := filter["detector"].(string); ok && detector != "" {
+		conditions = append(conditions, "detector = "+next(detector))
+	}
+	return strings.Join(conditions, " AND "), args
+}
@@ -28,8 +28,12 @@ func getString(value any) string {
 
 // GetValues parses a filter value into individual values and returns the operator.
 // Supports "," for AND (all must match) and "|" for OR (any must match) in any value.
+// Values that are not strings, like the "visible_to" filter, are a single value.
 func GetValues(key string, values any) ([]any, string) {
-	s := getString(values)
+	s, ok := values.(string)
+	if !ok {
+		return []any{values}, "AND"
+	}
 	if strings.Contains(s, ",") {
 		parts := strings.Split(s, ",")
 		results := make([]any, 0, len(parts))
@@ -49,6 +53,12 @@ func GetValues(key string, values any) ([]any, string) {
 	return []any{values}, "AND"
 }
 
+// ParseAnnotationFilter splits an annotation filter value: "key:value" matches the jobs
+// with the annotation set to value, "key" the jobs with the annotation set at all.
+func ParseAnnotationFilter(value any) (key string, annotationValue string, hasValue bool) {
+	return strings.Cut(getString(value), ":")
+}
+
 // CreateFilterStatement builds a WHERE clause and args from the filter.
 // It validates each key against the table's allowlist, sorts keys deterministically,
 // and returns both the clause and args in matching order. Returns an error if any
@@ -0,0 +1,48 @@
+package shared
+
+import (
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// MetricsHistoryWhere returns the WHERE conditions and args of the metrics of the jobs of the
+// tenant that match the filter, placeholder returns the placeholder of the nth arg. The
+// conditions join the metrics table, aliased m, with the evaluations table, aliased e. The filter
+// keys are model, benchmark, provider_id, metric, recorded_after and recorded_before
+// (time.Time, inclusive) and visible_to (the jobs the user can see).
+func MetricsHistoryWhere(s SQLStatementsFactory, tenant api.Tenant, filter map[string]any, placeholder func(n int) string) (string, []any) {
+	var args []any
+	next := func(value any) string {
+		args = append(args, value)
+		return placeholder(len(args))
+	}
+	conditions := []string{"e.id = m.job_id"}
+	if !tenant.IsEmpty() {
+		conditions = append(conditions, "e.tenant_id = "+next(tenant.String()))
+	}
+	for _, column := range [][2]string{
+		{"model", "m.model_name"},
+		{"benchmark", "m.benchmark_id"},
+		{"provider_id", "m.provider_id"},
+		{"metric", "m.metric"},
+	} {
+		if value, ok := filter[column[0]].(string); ok && value != "" {
+			conditions = append(conditions, column[1]+" = "+next(value))
+		}
+	}
+	// the times are compared in UTC, as they are stored, which SQLite compares as text
+	if after, ok := filter["recorded_after"].(time.Time); ok {
+		conditions = append(conditions, "m.recorded_at >= "+next(after.UTC()))
+	}
+	if before, ok := filter["recorded_before"].(time.Time); ok {
+		conditions = append(conditions, "m.recorded_at <= "+next(before.UTC()))
+	}
+	if visibility, ok := filter["visible_to"]; ok {
+		condition, conditionArgs := s.CreateEntityFilterCondition("visible_to", visibility, len(args)+1, TABLE_EVALUATIONS)
+		conditions = append(conditions, condition)
+		args = append(args, conditionArgs...)
+	}
+	return strings.Join(conditions, " AND "), args
+}
@@ -3,6 +3,7 @@ package shared
 import (
 	"database/sql"
 	"log/slog"
+	"time"
 
 	"github.com/eval-hub/eval-hub/pkg/api"
 )
@@ -20,6 +21,46 @@ type SQLStatementsFactory interface {
 	CreateEvaluationGetEntityStatement(query *EntityQuery) (string, []any, []any)
 	CreateEvaluationGetEntityForUpdateStatement(query *EntityQuery) (string, []any, []any)
 
+	// evaluation benchmark operations, the statuses and results of the benchmarks of the jobs
+	CreateEvaluationBenchmarksGetStatement(jobIDs []string, benchmarkIndex *int) (string, []any)
+	CreateEvaluationBenchmarkUpsertStatement(jobID string, benchmarkIndex int, status api.State, entity string, result *string) (string, []any)
+	CreateEvaluationBenchmarkStatesStatement(jobID string) (string, []any)
+	CreateEvaluationBenchmarksDeleteStatement(jobID string) (string, []any)
+	CreateUpdateEvaluationStatusStatement(tenant api.Tenant, id string, status api.OverallState) (string, []any)
+	CreateUpdateEvaluationExperimentStatement(tenant api.Tenant, id string, experimentID string) (string, []any)
+	CreateEvaluationsWithoutExperimentStatement(limit int) (string, []any)
+
+	// evaluation finding operations, the safety findings of the benchmarks of the jobs. The
+	// shard index of the findings of a benchmark that is not sharded is NoShard.
+	CreateEvaluationFindingsDeleteStatement(jobID string, benchmarkIndex *int, shardIndex int) (string, []any)
+	CreateEvaluationFindingInsertStatement(jobID string, benchmarkIndex int, shardIndex int, findingIndex int, providerID string, benchmarkID string, finding *api.Finding, entity string) (string, []any)
+	CreateEvaluationFindingsSeverityCountStatement(jobID string, benchmarkIndex int) (string, []any)
+	CreateEvaluationFindingsCountStatement(jobID string, filter map[string]any) (string, []any)
+	CreateEvaluationFindingsListStatement(jobID string, filter map[string]any, limit, offset int) (string, []any)
+
+	// evaluation artifact operations, the intermediate artifacts that the adapters uploaded
+	// for the benchmarks of the jobs
+	CreateEvaluationArtifactUpsertStatement(jobID string, benchmarkIndex int, name string, uploadedAt time.Time, entity string) (string, []any)
+	CreateEvaluationArtifactsCountStatement(jobID string, benchmarkIndex int) (string, []any)
+	CreateEvaluationArtifactsListStatement(jobID string, benchmarkIndex int, limit, offset int) (string, []any)
+	CreateEvaluationArtifactsDeleteStatement(jobID string) (string, []any)
+
+	// evaluation generation operations, the encrypted raw generations that the adapters uploaded
+	// for the benchmarks of a job
+	CreateEvaluationGenerationUpsertStatement(jobID string, benchmarkIndex int, name string, uploadedAt time.Time, entity string) (string, []any)
+	CreateEvaluationGenerationGetStatement(jobID string, benchmarkIndex int, name string) (string, []any)
+	CreateEvaluationGenerationsCountStatement(jobID string, benchmarkIndex int) (string, []any)
+	CreateEvaluationGenerationsListStatement(jobID string, benchmarkIndex int, limit, offset int) (string, []any)
+	CreateEvaluationGenerationsDeleteStatement(jobID string) (string, []any)
+
+	// evaluation metric operations, the metrics of the completed benchmarks of the jobs kept
+	// in their own table for the history of the metrics of a model across jobs
+	CreateEvaluationMetricsDeleteStatement(jobID string, benchmarkIndex *int) (string, []any)
+	CreateEvaluationMetricInsertStatement(jobID string, benchmarkIndex int, point *api.MetricPoint) (string, []any)
+	CreateEvaluationMetricsHistoryCountStatement(tenant api.Tenant, filter map[string]any) (string, []any)
+	CreateEvaluationMetricsHistoryListStatement(tenant api.Tenant, filter map[string]any, limit, offset int) (string, []any)
+	CreateEvaluationMetricNamesStatement(tenant api.Tenant, filter map[string]any) (string, []any)
+
 	// collections operations
 	CreateCollectionAddEntityStatement(collection *api.CollectionResource, entity string) (string, []any)
 	CreateCollectionGetEntityStatement(query *EntityQuery) (string, []any, []any)
@@ -28,10 +69,61 @@ type SQLStatementsFactory interface {
 	CreateProviderAddEntityStatement(provider *api.ProviderResource, entity string) (string, []any)
 	CreateProviderGetEntityStatement(query *EntityQuery) (string, []any, []any)
 
+	// baselines operations
+	CreateBaselineAddEntityStatement(baseline *api.BaselineResource, entity string) (string, []any)
+	CreateBaselineGetEntityStatement(query *EntityQuery) (string, []any, []any)
+
+	// evaluation views operations
+	CreateEvaluationViewAddEntityStatement(view *api.EvaluationViewResource, entity string) (string, []any)
+	CreateEvaluationViewGetEntityStatement(query *EntityQuery) (string, []any, []any)
+
+	// result cache operations
+	CreateResultCachePutStatement(tenant api.Tenant, key string, jobID string, completedAt time.Time, entity string) (string, []any)
+	CreateResultCacheGetStatement(tenant api.Tenant, key string) (string, []any)
+
+	// tag policy operations, a tenant has at most one tag policy
+	CreateTagPolicyPutStatement(tenant api.Tenant, updatedAt time.Time, entity string) (string, []any)
+	CreateTagPolicyGetStatement(tenant api.Tenant) (string, []any)
+
+	// redaction policy operations, a tenant has at most one redaction policy
+	CreateRedactionPolicyPutStatement(tenant api.Tenant, updatedAt time.Time, entity string) (string, []any)
+	CreateRedactionPolicyGetStatement(tenant api.Tenant) (string, []any)
+
+	// admin configuration operations, the live settings shared by all the replicas
+	CreateAdminConfigPutStatement(updatedAt time.Time, entity string) (string, []any)
+	CreateAdminConfigGetStatement() (string, []any)
+
+	// provider health operations, the canary outcomes shared by all the replicas
+	CreateProviderHealthPutStatement(providerID string, updatedAt time.Time, entity string) (string, []any)
+	CreateProviderHealthListStatement() (string, []any)
+	CreateProviderHealthDeleteStatement(providerID string) (string, []any)
+
+	// evaluation redaction operations, the audit of the redactions applied to the artifacts and
+	// messages of the benchmarks of a job
+	CreateEvaluationRedactionInsertStatement(jobID string, benchmarkIndex int, target string, redactedAt time.Time, entity string) (string, []any)
+	CreateEvaluationRedactionsCountStatement(jobID string) (string, []any)
+	CreateEvaluationRedactionsListStatement(jobID string, limit, offset int) (string, []any)
+	CreateEvaluationRedactionsDeleteStatement(jobID string) (string, []any)
+
+	// status dead letter operations, the status events that were rejected; an empty tenant or
+	// job ID matches all of them
+	CreateStatusDeadLetterInsertStatement(id string, tenant api.Tenant, jobID string, receivedAt time.Time, entity string) (string, []any)
+	CreateStatusDeadLetterGetStatement(id string) (string, []any)
+	CreateStatusDeadLettersCountStatement(tenant api.Tenant, jobID string) (string, []any)
+	CreateStatusDeadLettersListStatement(tenant api.Tenant, jobID string, limit, offset int) (string, []any)
+	CreateStatusDeadLetterDeleteStatement(id string) (string, []any)
+	CreateStatusDeadLettersDeleteStatement(jobID string) (string, []any)
+
+	// benchmark duration operations, the number and total duration of the completed runs of
+	// the benchmarks of the tenants
+	CreateBenchmarkDurationRecordStatement(tenant api.Tenant, providerID string, benchmarkID string, examplesBucket int, seconds float64) (string, []any)
+	CreateBenchmarkDurationGetStatement(tenant api.Tenant, providerID string, benchmarkID string, examplesBucket int) (string, []any)
+
 	// common operations
 	CreateCountEntitiesStatement(tenant api.Tenant, tableName string, filter map[string]any) (string, []any)
 	CreateListEntitiesStatement(tenant api.Tenant, tableName string, limit, offset int, filter map[string]any) (string, []any)
 	ScanRowForEntity(tenant api.Tenant, ableName string, rows *sql.Rows, query *EntityQuery) error
 	CreateDeleteEntityStatement(tenant api.Tenant, tableName string, id string) (string, []any)
 	CreateUpdateEntityStatement(tenant api.Tenant, tableName, id string, entityJSON string, status *api.OverallState) (string, []any)
+	CreateUpdateEntityOwnerStatement(tenant api.Tenant, tableName, id string, owner api.User) (string, []any)
 }
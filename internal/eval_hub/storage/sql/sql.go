@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -46,6 +47,10 @@ type sqlStorage struct {
 	owner             api.User
 	maxArgLength      int
 	isolationLevel    sql.IsolationLevel
+	// writeQueue serializes the writes of the replica when the database has a single
+	// writer (SQLite), nil otherwise
+	writeQueue chan struct{}
+	statements *statementCache
 }
 
 func NewStorage(
@@ -56,26 +61,9 @@ func NewStorage(
 	otelMetricsEnabled bool,
 	logger *slog.Logger,
 ) (abstractions.Storage, error) {
-	var sqlConfig shared.SQLDatabaseConfig
-	decoder, merr := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
-		DecodeHook: mapstructure.StringToTimeDurationHookFunc(),
-		Result:     &sqlConfig,
-	})
-	if merr != nil {
-		return nil, merr
-	}
-	if merr = decoder.Decode(config); merr != nil {
-		return nil, merr
-	}
-
-	// check that the driver is supported
-	switch sqlConfig.Driver {
-	case SQLITE_DRIVER:
-		break
-	case POSTGRES_DRIVER:
-		break
-	default:
-		return nil, fmt.Errorf("unsupported driver: %s", (sqlConfig.Driver))
+	sqlConfig, err := decodeConfig(config)
+	if err != nil {
+		return nil, err
 	}
 
 	logger = logger.With("driver", sqlConfig.GetDriverName())
@@ -86,8 +74,11 @@ func NewStorage(
 
 	logger.Info("Creating SQL storage")
 
+	if sqlConfig.Driver == SQLITE_DRIVER {
+		sqlConfig.URL = sqlite.ConnectionURL(sqlConfig.URL)
+	}
+
 	var pool *sql.DB
-	var err error
 	useOTELOSQL := otelStorageScansEnabled || otelMetricsEnabled
 	if useOTELOSQL {
 		var attrs []attribute.KeyValue
@@ -134,24 +125,24 @@ func NewStorage(
 	var statementsFactory shared.SQLStatementsFactory
 	switch sqlConfig.Driver {
 	case SQLITE_DRIVER:
-		statementsFactory, err = sqlite.Setup(logger, pool, &sqlConfig)
+		statementsFactory, err = sqlite.Setup(logger, pool, sqlConfig)
 		if err != nil {
 			return nil, err
 		}
 	case POSTGRES_DRIVER:
-		statementsFactory, err = postgres.Setup(logger, pool, &sqlConfig)
+		statementsFactory, err = postgres.Setup(logger, pool, sqlConfig)
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	isolationLevel, err := getIsolationLevel(os.Getenv("DEBUG_SQL_ISOLATION_LEVEL"), &sqlConfig, logger)
+	isolationLevel, err := getIsolationLevel(os.Getenv("DEBUG_SQL_ISOLATION_LEVEL"), sqlConfig, logger)
 	if err != nil {
 		return nil, err
 	}
 
 	s := &sqlStorage{
-		sqlConfig:         &sqlConfig,
+		sqlConfig:         sqlConfig,
 		statementsFactory: statementsFactory,
 		pool:              pool,
 		logger:            logger,
@@ -159,6 +150,9 @@ func NewStorage(
 		maxArgLength:      512,
 		isolationLevel:    isolationLevel,
 	}
+	if sqlConfig.Driver == SQLITE_DRIVER {
+		s.writeQueue = make(chan struct{}, 1)
+	}
 
 	// ping the database to verify the DSN provided by the user is valid and the server is accessible
 	logger.Info("Pinging SQL storage")
@@ -173,6 +167,10 @@ func NewStorage(
 		return nil, err
 	}
 
+	// prepare the statements of the hot paths once the tables exist
+	s.statements = newStatementCache(s.ctx, logger, pool, hotPathStatements(statementsFactory))
+	logger.Info("Prepared SQL statements", "count", s.statements.size())
+
 	// load any system resources
 	if err := s.LoadSystemResources(systemCollections, systemProviders); err != nil {
 		return nil, err
@@ -220,6 +218,53 @@ func getIsolationLevel(isolationLevel string, config *shared.SQLDatabaseConfig,
 
 // Ping the database to verify DSN provided by the user is valid and the
 // server accessible. If the ping fails exit the program with an error.
+// decodeConfig decodes the database section of the service config and checks its driver.
+func decodeConfig(config map[string]any) (*shared.SQLDatabaseConfig, error) {
+	var sqlConfig shared.SQLDatabaseConfig
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		DecodeHook: mapstructure.StringToTimeDurationHookFunc(),
+		Result:     &sqlConfig,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err = decoder.Decode(config); err != nil {
+		return nil, err
+	}
+
+	// check that the driver is supported
+	switch sqlConfig.Driver {
+	case SQLITE_DRIVER:
+		break
+	case POSTGRES_DRIVER:
+		break
+	default:
+		return nil, fmt.Errorf("unsupported driver: %s", (sqlConfig.Driver))
+	}
+	return &sqlConfig, nil
+}
+
+// CheckConnection checks that the database of the config is reachable within the timeout. It
+// only opens and pings a connection, the schemas and the system resources are left untouched.
+func CheckConnection(config map[string]any, timeout time.Duration) error {
+	sqlConfig, err := decodeConfig(config)
+	if err != nil {
+		return err
+	}
+	if sqlConfig.Driver == SQLITE_DRIVER {
+		sqlConfig.URL = sqlite.ConnectionURL(sqlConfig.URL)
+	}
+	pool, err := sql.Open(sqlConfig.Driver, sqlConfig.URL)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = pool.Close() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return pool.PingContext(ctx)
+}
+
 func (s *sqlStorage) Ping(timeout time.Duration) error {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
@@ -248,33 +293,129 @@ func (s *sqlStorage) safeArgs(args []any) []any {
 	return newArgs
 }
 
+// statementContext returns the context of a statement: the context of the request with
+// the query timeout, which the caller releases once the statement is done.
+func (s *sqlStorage) statementContext() (context.Context, context.CancelFunc) {
+	if s.sqlConfig == nil || s.sqlConfig.GetQueryTimeout() <= 0 {
+		return context.WithCancel(s.ctx)
+	}
+	return context.WithTimeout(s.ctx, s.sqlConfig.GetQueryTimeout())
+}
+
+// timedRows are the rows of a query, which is observed and whose timeout is released when
+// they are closed.
+type timedRows struct {
+	*sql.Rows
+	done func(error)
+}
+
+func (r *timedRows) Close() error {
+	rowsErr := r.Rows.Err()
+	err := r.Rows.Close()
+	r.done(errors.Join(rowsErr, err))
+	return err
+}
+
+// timedRow is the row of a query, which is observed and whose timeout is released when it is
+// scanned.
+type timedRow struct {
+	*sql.Row
+	done func(error)
+}
+
+func (r *timedRow) Scan(dest ...any) error {
+	err := r.Row.Scan(dest...)
+	r.done(err)
+	return err
+}
+
+// statementDone returns the function that ends a statement started now: it releases the
+// context of the statement and observes it.
+func (s *sqlStorage) statementDone(cancel context.CancelFunc, query string, args []any) func(error) {
+	started := time.Now()
+	return func(err error) {
+		cancel()
+		s.observeStatement(query, args, started, err)
+	}
+}
+
 func (s *sqlStorage) exec(txn *sql.Tx, query string, args ...any) (sql.Result, error) {
 	s.logger.Debug("Executing exec", "transaction", txn != nil, "query", query, "args", s.safeArgs(args))
 
-	if txn != nil {
-		return txn.ExecContext(s.ctx, query, args...)
-	} else {
-		return s.pool.ExecContext(s.ctx, query, args...)
+	ctx, cancel := s.statementContext()
+	stmt := s.statements.get(ctx, txn, query)
+	if txn == nil {
+		done, err := s.waitForWriteTurn()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		defer done()
+	}
+	statementDone := s.statementDone(cancel, query, args)
+	var result sql.Result
+	var err error
+	switch {
+	case stmt != nil:
+		result, err = stmt.ExecContext(ctx, args...)
+	case txn != nil:
+		result, err = txn.ExecContext(ctx, query, args...)
+	default:
+		result, err = s.pool.ExecContext(ctx, query, args...)
 	}
+	statementDone(err)
+	return result, err
 }
 
-func (s *sqlStorage) query(txn *sql.Tx, query string, args ...any) (*sql.Rows, error) {
+// waitForWriteTurn waits until the writes queued before this one are done when the
+// writes are serialized, and returns the function that lets the next write in. The
+// writes of a replica then wait in line rather than failing with SQLITE_BUSY once the
+// busy timeout expires.
+func (s *sqlStorage) waitForWriteTurn() (func(), error) {
+	if s.writeQueue == nil {
+		return func() {}, nil
+	}
+	select {
+	case s.writeQueue <- struct{}{}:
+		return func() { <-s.writeQueue }, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func (s *sqlStorage) query(txn *sql.Tx, query string, args ...any) (*timedRows, error) {
 	s.logger.Debug("Executing query", "transaction", txn != nil, "query", query, "args", s.safeArgs(args))
 
-	if txn != nil {
-		return txn.QueryContext(s.ctx, query, args...)
+	ctx, cancel := s.statementContext()
+	done := s.statementDone(cancel, query, args)
+	var rows *sql.Rows
+	var err error
+	if stmt := s.statements.get(ctx, txn, query); stmt != nil {
+		rows, err = stmt.QueryContext(ctx, args...)
+	} else if txn != nil {
+		rows, err = txn.QueryContext(ctx, query, args...)
 	} else {
-		return s.pool.QueryContext(s.ctx, query, args...)
+		rows, err = s.pool.QueryContext(ctx, query, args...)
 	}
+	if err != nil {
+		done(err)
+		return nil, err
+	}
+	return &timedRows{Rows: rows, done: done}, nil
 }
 
-func (s *sqlStorage) queryRow(txn *sql.Tx, query string, args ...any) *sql.Row {
+func (s *sqlStorage) queryRow(txn *sql.Tx, query string, args ...any) *timedRow {
 	s.logger.Debug("Executing row query", "transaction", txn != nil, "query", query, "args", s.safeArgs(args))
 
+	ctx, cancel := s.statementContext()
+	done := s.statementDone(cancel, query, args)
+	if stmt := s.statements.get(ctx, txn, query); stmt != nil {
+		return &timedRow{Row: stmt.QueryRowContext(ctx, args...), done: done}
+	}
 	if txn != nil {
-		return txn.QueryRowContext(s.ctx, query, args...)
+		return &timedRow{Row: txn.QueryRowContext(ctx, query, args...), done: done}
 	} else {
-		return s.pool.QueryRowContext(s.ctx, query, args...)
+		return &timedRow{Row: s.pool.QueryRowContext(ctx, query, args...), done: done}
 	}
 }
 
@@ -345,7 +486,7 @@ func applyPatches(resource string, patches *api.Patch) ([]byte, error) {
 }
 
 func (s *sqlStorage) Close() error {
-	return s.pool.Close()
+	return errors.Join(s.statements.Close(), s.pool.Close())
 }
 
 func (s *sqlStorage) WithLogger(logger *slog.Logger) abstractions.Storage {
@@ -359,6 +500,8 @@ func (s *sqlStorage) WithLogger(logger *slog.Logger) abstractions.Storage {
 		owner:             s.owner,
 		maxArgLength:      s.maxArgLength,
 		isolationLevel:    s.isolationLevel,
+		writeQueue:        s.writeQueue,
+		statements:        s.statements,
 	}
 }
 
@@ -373,6 +516,8 @@ func (s *sqlStorage) WithContext(ctx context.Context) abstractions.Storage {
 		owner:             s.owner,
 		maxArgLength:      s.maxArgLength,
 		isolationLevel:    s.isolationLevel,
+		writeQueue:        s.writeQueue,
+		statements:        s.statements,
 	}
 }
 
@@ -387,6 +532,8 @@ func (s *sqlStorage) WithTenant(tenant api.Tenant) abstractions.Storage {
 		owner:             s.owner,
 		maxArgLength:      s.maxArgLength,
 		isolationLevel:    s.isolationLevel,
+		writeQueue:        s.writeQueue,
+		statements:        s.statements,
 	}
 }
 
@@ -401,5 +548,7 @@ func (s *sqlStorage) WithOwner(owner api.User) abstractions.Storage {
 		owner:             owner,
 		maxArgLength:      s.maxArgLength,
 		isolationLevel:    s.isolationLevel,
+		writeQueue:        s.writeQueue,
+		statements:        s.statements,
 	}
 }
@@ -3,13 +3,19 @@ package sql_test
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/storage"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql/shared"
 	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/sdk/metric"
 )
@@ -65,6 +71,142 @@ func TestNewStorageOTELMetrics(t *testing.T) {
 	_ = s.Close()
 }
 
+// TestNewStorageSQLiteFileConcurrentWrites sends concurrent status updates to an on-disk
+// database through two storages, as two processes sharing the file would, and checks that
+// none of them is lost to SQLITE_BUSY.
+func TestNewStorageSQLiteFileConcurrentWrites(t *testing.T) {
+	logger := logging.FallbackLogger()
+	url := "file:" + filepath.ToSlash(filepath.Join(t.TempDir(), "evalhub.db"))
+
+	var stores []abstractions.Storage
+	for range 2 {
+		config := map[string]any{"driver": "sqlite", "url": url}
+		s, err := storage.NewStorage(&config, nil, nil, false, false, logger)
+		if err != nil {
+			t.Fatalf("NewStorage on a file database: %v", err)
+		}
+		t.Cleanup(func() { _ = s.Close() })
+		stores = append(stores, s.WithTenant(api.Tenant("team-a")))
+	}
+
+	const benchmarks = 20
+	jobID := common.GUID()
+	config := api.EvaluationJobConfig{Model: api.ModelRef{URL: "http://test.com", Name: "test"}}
+	for i := range benchmarks {
+		config.Benchmarks = append(config.Benchmarks, api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: fmt.Sprintf("b%d", i)}, ProviderID: "prov"})
+	}
+	if err := stores[0].CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource:            api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: api.Tenant("team-a")}},
+		Status:              &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: config,
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, benchmarks)
+	for i := range benchmarks {
+		wg.Go(func() {
+			_, err := stores[i%len(stores)].UpdateEvaluationJob(jobID, &api.StatusEvent{
+				BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+					ID: fmt.Sprintf("b%d", i), ProviderID: "prov", BenchmarkIndex: i,
+					Status: api.StateRunning, StartedAt: api.DateTimeToString(time.Now()),
+				},
+			})
+			errs <- err
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("UpdateEvaluationJob: %v", err)
+		}
+	}
+
+	job, err := stores[1].GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if len(job.Status.Benchmarks) != benchmarks {
+		t.Fatalf("expected %d benchmark statuses, got %d", benchmarks, len(job.Status.Benchmarks))
+	}
+}
+
+func TestNewStorageTimeouts(t *testing.T) {
+	logger := logging.FallbackLogger()
+	newJob := func() *api.EvaluationJobResource {
+		return &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: common.GUID()}},
+			Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePending}},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Model:      api.ModelRef{URL: "http://test.com", Name: "test"},
+				Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "b1"}, ProviderID: "prov1"}},
+			},
+		}
+	}
+
+	t.Run("the storage stops when the request is cancelled", func(t *testing.T) {
+		s, err := getTestStorage(t, drivers[0], getDBName())
+		if err != nil {
+			t.Fatalf("failed to create storage: %v", err)
+		}
+		defer func() { _ = s.Close() }()
+		job := newJob()
+		if err := s.CreateEvaluationJob(job); err != nil {
+			t.Fatalf("CreateEvaluationJob: %v", err)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		cancelled := s.WithContext(ctx)
+		if _, err := cancelled.GetEvaluationJob(job.Resource.ID); err == nil {
+			t.Fatal("expected the read of a cancelled request to fail")
+		}
+		if err := cancelled.CreateEvaluationJob(newJob()); err == nil {
+			t.Fatal("expected the transaction of a cancelled request to fail")
+		}
+		if _, err := s.GetEvaluationJob(job.Resource.ID); err != nil {
+			t.Fatalf("expected the storage to serve the other requests, got %v", err)
+		}
+	})
+
+	t.Run("the statements and transactions time out", func(t *testing.T) {
+		s, err := getTestStorage(t, drivers[0], getDBName())
+		if err != nil {
+			t.Fatalf("failed to create storage: %v", err)
+		}
+		defer func() { _ = s.Close() }()
+		job := newJob()
+		if err := s.CreateEvaluationJob(job); err != nil {
+			t.Fatalf("CreateEvaluationJob: %v", err)
+		}
+		if _, err := sql.WithTimeouts(s, time.Nanosecond, 0).GetEvaluationJob(job.Resource.ID); err == nil {
+			t.Error("expected the query to time out")
+		}
+		if err := sql.WithTimeouts(s, 0, time.Nanosecond).CreateEvaluationJob(newJob()); err == nil {
+			t.Error("expected the transaction to time out")
+		}
+	})
+
+	t.Run("a zero timeout disables it", func(t *testing.T) {
+		config := map[string]any{
+			"driver":              "sqlite",
+			"url":                 getDBInMemoryURL(getDBName()),
+			"query_timeout":       "0s",
+			"transaction_timeout": "0s",
+		}
+		s, err := storage.NewStorage(&config, nil, nil, false, false, logger)
+		if err != nil {
+			t.Fatalf("NewStorage: %v", err)
+		}
+		defer func() { _ = s.Close() }()
+		if err := s.CreateEvaluationJob(newJob()); err != nil {
+			t.Fatalf("CreateEvaluationJob: %v", err)
+		}
+	})
+}
+
 func TestSQLStorage(t *testing.T) {
 	t.Run("Check database name is extracted correctly", func(t *testing.T) {
 		data := [][]string{
@@ -1,31 +1,87 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"strings"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql/shared"
 )
 
+const (
+	// busyTimeoutPragma makes a connection wait up to 5s for the lock of another
+	// connection or process instead of failing with SQLITE_BUSY.
+	busyTimeoutPragma = "busy_timeout(5000)"
+	walPragma         = "journal_mode(WAL)"
+
+	// defaultMaxOpenConns is the size of the pool of a file database when max_open_conns
+	// is not set: with WAL the readers do not wait for the writer.
+	defaultMaxOpenConns = 4
+)
+
+// ConnectionURL returns the URL to open the database with, so that the driver configures
+// every connection of the pool when it opens it: a busy timeout, WAL mode for file
+// databases, and transactions that take the write lock when they begin, which the busy
+// timeout then covers, rather than when they first write, which fails straight away
+// with SQLITE_BUSY if another connection writes. Settings already in the URL are kept.
+func ConnectionURL(databaseURL string) string {
+	base, query, _ := strings.Cut(databaseURL, "?")
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		// leave it to the driver to report the malformed URL
+		return databaseURL
+	}
+	pragmas := strings.ToLower(strings.Join(params["_pragma"], ","))
+	var extra []string
+	if !strings.Contains(pragmas, "busy_timeout") {
+		extra = append(extra, "_pragma="+url.QueryEscape(busyTimeoutPragma))
+	}
+	if !isMemoryDatabase(databaseURL) && !strings.Contains(pragmas, "journal_mode") {
+		extra = append(extra, "_pragma="+url.QueryEscape(walPragma))
+	}
+	if !params.Has("_txlock") {
+		extra = append(extra, "_txlock=immediate")
+	}
+	if len(extra) == 0 {
+		return databaseURL
+	}
+	if query != "" {
+		extra = append([]string{query}, extra...)
+	}
+	return base + "?" + strings.Join(extra, "&")
+}
+
 func Setup(logger *slog.Logger, pool *sql.DB, config *shared.SQLDatabaseConfig) (shared.SQLStatementsFactory, error) {
-	// SQLite only supports one writer at a time; a single connection
-	// serializes all access and eliminates lock contention.
-	pool.SetMaxOpenConns(1)
-	if _, err := pool.Exec("PRAGMA busy_timeout = 5000"); err != nil {
-		return nil, fmt.Errorf("failed to set busy_timeout: %w", err)
-	}
-	// Enable WAL mode for file-based databases (in-memory databases don't
-	// support WAL and always return journal_mode="memory").
-	if !strings.Contains(config.URL, "mode=memory") {
-		var mode string
-		if err := pool.QueryRow("PRAGMA journal_mode = WAL").Scan(&mode); err != nil {
-			return nil, fmt.Errorf("failed to set journal_mode: %w", err)
-		}
-		if mode != "wal" {
-			return nil, fmt.Errorf("failed to enable WAL mode: database returned journal_mode=%q", mode)
-		}
+	if isMemoryDatabase(config.URL) {
+		// The connections to a shared in-memory database lock whole tables and fail
+		// with SQLITE_LOCKED without waiting for the busy timeout, so a single
+		// connection serializes all access.
+		pool.SetMaxOpenConns(1)
+		return NewStatementsFactory(logger), nil
+	}
+	if config.MaxOpenConns == nil {
+		pool.SetMaxOpenConns(defaultMaxOpenConns)
+	}
+	// in-memory databases don't support WAL and always return journal_mode="memory"
+	ctx := context.Background()
+	if timeout := config.GetQueryTimeout(); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	var mode string
+	if err := pool.QueryRowContext(ctx, "PRAGMA journal_mode").Scan(&mode); err != nil {
+		return nil, fmt.Errorf("failed to read journal_mode: %w", err)
+	}
+	if !strings.EqualFold(mode, "wal") {
+		logger.Warn("SQLite database is not in WAL mode, readers wait for the writer", "journal_mode", mode)
 	}
 	return NewStatementsFactory(logger), nil
 }
+
+func isMemoryDatabase(databaseURL string) bool {
+	return strings.Contains(databaseURL, "mode=memory") || strings.Contains(databaseURL, ":memory:")
+}
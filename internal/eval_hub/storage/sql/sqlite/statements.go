@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql/shared"
@@ -18,6 +19,87 @@ const (
 
 	INSERT_PROVIDER_STATEMENT = `INSERT INTO providers (id, tenant_id, owner, entity) VALUES (?, ?, ?, ?);`
 
+	INSERT_BASELINE_STATEMENT        = `INSERT INTO baselines (id, tenant_id, owner, entity) VALUES (?, ?, ?, ?);`
+	INSERT_EVALUATION_VIEW_STATEMENT = `INSERT INTO evaluation_views (id, tenant_id, owner, entity) VALUES (?, ?, ?, ?);`
+
+	UPSERT_RESULT_CACHE_STATEMENT = `INSERT INTO benchmark_result_cache (cache_key, tenant_id, job_id, completed_at, entity) VALUES (?, ?, ?, ?, ?) ON CONFLICT (tenant_id, cache_key) DO UPDATE SET job_id = EXCLUDED.job_id, completed_at = EXCLUDED.completed_at, entity = EXCLUDED.entity;`
+
+	SELECT_RESULT_CACHE_STATEMENT = `SELECT job_id, completed_at, entity FROM benchmark_result_cache WHERE tenant_id = ? AND cache_key = ?;`
+
+	UPSERT_TAG_POLICY_STATEMENT = `INSERT INTO tag_policies (tenant_id, updated_at, entity) VALUES (?, ?, ?) ON CONFLICT (tenant_id) DO UPDATE SET updated_at = EXCLUDED.updated_at, entity = EXCLUDED.entity;`
+
+	SELECT_TAG_POLICY_STATEMENT = `SELECT updated_at, entity FROM tag_policies WHERE tenant_id = ?;`
+
+	UPSERT_REDACTION_POLICY_STATEMENT = `INSERT INTO redaction_policies (tenant_id, updated_at, entity) VALUES (?, ?, ?) ON CONFLICT (tenant_id) DO UPDATE SET updated_at = EXCLUDED.updated_at, entity = EXCLUDED.entity;`
+
+	SELECT_REDACTION_POLICY_STATEMENT = `SELECT updated_at, entity FROM redaction_policies WHERE tenant_id = ?;`
+
+	UPSERT_ADMIN_CONFIG_STATEMENT = `INSERT INTO admin_config (id, updated_at, entity) VALUES ('service', ?, ?) ON CONFLICT (id) DO UPDATE SET updated_at = EXCLUDED.updated_at, entity = EXCLUDED.entity;`
+
+	SELECT_ADMIN_CONFIG_STATEMENT = `SELECT updated_at, entity FROM admin_config WHERE id = 'service';`
+
+	UPSERT_PROVIDER_HEALTH_STATEMENT = `INSERT INTO provider_health (provider_id, updated_at, entity) VALUES (?, ?, ?) ON CONFLICT (provider_id) DO UPDATE SET updated_at = EXCLUDED.updated_at, entity = EXCLUDED.entity;`
+
+	SELECT_PROVIDER_HEALTH_STATEMENT = `SELECT entity FROM provider_health ORDER BY provider_id;`
+
+	DELETE_PROVIDER_HEALTH_STATEMENT = `DELETE FROM provider_health WHERE provider_id = ?;`
+
+	INSERT_EVALUATION_REDACTION_STATEMENT = `INSERT INTO evaluation_redactions (job_id, benchmark_index, target, redacted_at, entity) VALUES (?, ?, ?, ?, ?);`
+
+	SELECT_EVALUATION_REDACTIONS_COUNT_STATEMENT = `SELECT COUNT(*) FROM evaluation_redactions WHERE job_id = ?;`
+
+	SELECT_EVALUATION_REDACTIONS_STATEMENT = `SELECT entity FROM evaluation_redactions WHERE job_id = ? ORDER BY redacted_at, benchmark_index LIMIT ? OFFSET ?;`
+
+	DELETE_EVALUATION_REDACTIONS_STATEMENT = `DELETE FROM evaluation_redactions WHERE job_id = ?;`
+
+	INSERT_STATUS_DEAD_LETTER_STATEMENT = `INSERT INTO status_dead_letters (id, tenant_id, job_id, received_at, entity) VALUES (?, ?, ?, ?, ?);`
+
+	SELECT_STATUS_DEAD_LETTER_STATEMENT = `SELECT entity FROM status_dead_letters WHERE id = ?;`
+
+	SELECT_STATUS_DEAD_LETTERS_COUNT_STATEMENT = `SELECT COUNT(*) FROM status_dead_letters WHERE (? = '' OR tenant_id = ?) AND (? = '' OR job_id = ?);`
+
+	SELECT_STATUS_DEAD_LETTERS_STATEMENT = `SELECT entity FROM status_dead_letters WHERE (? = '' OR tenant_id = ?) AND (? = '' OR job_id = ?) ORDER BY received_at DESC, id LIMIT ? OFFSET ?;`
+
+	DELETE_STATUS_DEAD_LETTER_STATEMENT = `DELETE FROM status_dead_letters WHERE id = ?;`
+
+	DELETE_STATUS_DEAD_LETTERS_STATEMENT = `DELETE FROM status_dead_letters WHERE job_id = ?;`
+
+	UPSERT_BENCHMARK_DURATION_STATEMENT = `INSERT INTO benchmark_durations (tenant_id, provider_id, benchmark_id, examples_bucket, runs, total_seconds) VALUES (?, ?, ?, ?, 1, ?) ON CONFLICT (tenant_id, provider_id, benchmark_id, examples_bucket) DO UPDATE SET runs = benchmark_durations.runs + 1, total_seconds = benchmark_durations.total_seconds + EXCLUDED.total_seconds, updated_at = CURRENT_TIMESTAMP;`
+
+	SELECT_BENCHMARK_DURATION_STATEMENT = `SELECT runs, total_seconds FROM benchmark_durations WHERE tenant_id = ? AND provider_id = ? AND benchmark_id = ? AND examples_bucket = ?;`
+
+	UPSERT_EVALUATION_BENCHMARK_STATEMENT = `INSERT INTO evaluation_benchmarks (job_id, benchmark_index, status, entity, result) VALUES (?, ?, ?, ?, ?) ON CONFLICT (job_id, benchmark_index) DO UPDATE SET status = EXCLUDED.status, entity = EXCLUDED.entity, result = EXCLUDED.result, updated_at = CURRENT_TIMESTAMP;`
+
+	SELECT_EVALUATION_BENCHMARK_STATES_STATEMENT = `SELECT status, COUNT(*) FROM evaluation_benchmarks WHERE job_id = ? GROUP BY status;`
+
+	DELETE_EVALUATION_BENCHMARKS_STATEMENT = `DELETE FROM evaluation_benchmarks WHERE job_id = ?;`
+
+	INSERT_EVALUATION_FINDING_STATEMENT = `INSERT INTO evaluation_findings (job_id, benchmark_index, shard_index, finding_index, provider_id, benchmark_id, severity, severity_rank, probe, detector, entity) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+
+	SELECT_EVALUATION_FINDINGS_SEVERITY_COUNT_STATEMENT = `SELECT severity, COUNT(*) FROM evaluation_findings WHERE job_id = ? AND benchmark_index = ? GROUP BY severity;`
+
+	UPSERT_EVALUATION_ARTIFACT_STATEMENT = `INSERT INTO evaluation_artifacts (job_id, benchmark_index, name, uploaded_at, entity) VALUES (?, ?, ?, ?, ?) ON CONFLICT (job_id, benchmark_index, name) DO UPDATE SET uploaded_at = EXCLUDED.uploaded_at, entity = EXCLUDED.entity;`
+
+	SELECT_EVALUATION_ARTIFACTS_COUNT_STATEMENT = `SELECT COUNT(*) FROM evaluation_artifacts WHERE job_id = ? AND benchmark_index = ?;`
+
+	SELECT_EVALUATION_ARTIFACTS_STATEMENT = `SELECT entity FROM evaluation_artifacts WHERE job_id = ? AND benchmark_index = ? ORDER BY name LIMIT ? OFFSET ?;`
+
+	DELETE_EVALUATION_ARTIFACTS_STATEMENT = `DELETE FROM evaluation_artifacts WHERE job_id = ?;`
+
+	UPSERT_EVALUATION_GENERATION_STATEMENT = `INSERT INTO evaluation_generations (job_id, benchmark_index, name, uploaded_at, entity) VALUES (?, ?, ?, ?, ?) ON CONFLICT (job_id, benchmark_index, name) DO UPDATE SET uploaded_at = EXCLUDED.uploaded_at, entity = EXCLUDED.entity;`
+
+	SELECT_EVALUATION_GENERATION_STATEMENT = `SELECT entity FROM evaluation_generations WHERE job_id = ? AND benchmark_index = ? AND name = ?;`
+
+	SELECT_EVALUATION_GENERATIONS_COUNT_STATEMENT = `SELECT COUNT(*) FROM evaluation_generations WHERE job_id = ? AND benchmark_index = ?;`
+
+	SELECT_EVALUATION_GENERATIONS_STATEMENT = `SELECT entity FROM evaluation_generations WHERE job_id = ? AND benchmark_index = ? ORDER BY name LIMIT ? OFFSET ?;`
+
+	DELETE_EVALUATION_GENERATIONS_STATEMENT = `DELETE FROM evaluation_generations WHERE job_id = ?;`
+
+	INSERT_EVALUATION_METRIC_STATEMENT = `INSERT INTO evaluation_metrics (job_id, benchmark_index, metric, model_name, provider_id, benchmark_id, value, recorded_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+
+	DELETE_EVALUATION_METRICS_STATEMENT = `DELETE FROM evaluation_metrics WHERE job_id = ?;`
+
 	TABLES_SCHEMA = `
 CREATE TABLE IF NOT EXISTS evaluations (
     id VARCHAR(36) NOT NULL,
@@ -31,6 +113,64 @@ CREATE TABLE IF NOT EXISTS evaluations (
     PRIMARY KEY (id)
 );
 
+CREATE TABLE IF NOT EXISTS evaluation_benchmarks (
+    job_id VARCHAR(36) NOT NULL,
+    benchmark_index INTEGER NOT NULL,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    status VARCHAR(50) NOT NULL,
+    entity TEXT NOT NULL,
+    result TEXT,
+    PRIMARY KEY (job_id, benchmark_index)
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_findings (
+    job_id VARCHAR(36) NOT NULL,
+    benchmark_index INTEGER NOT NULL,
+    shard_index INTEGER NOT NULL,
+    finding_index INTEGER NOT NULL,
+    provider_id VARCHAR(255) NOT NULL,
+    benchmark_id VARCHAR(255) NOT NULL,
+    severity VARCHAR(50) NOT NULL,
+    severity_rank INTEGER NOT NULL,
+    probe VARCHAR(255) NOT NULL,
+    detector VARCHAR(255) NOT NULL,
+    entity TEXT NOT NULL,
+    PRIMARY KEY (job_id, benchmark_index, shard_index, finding_index)
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_artifacts (
+    job_id VARCHAR(36) NOT NULL,
+    benchmark_index INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    uploaded_at TIMESTAMP NOT NULL,
+    entity TEXT NOT NULL,
+    PRIMARY KEY (job_id, benchmark_index, name)
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_generations (
+    job_id VARCHAR(36) NOT NULL,
+    benchmark_index INTEGER NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    uploaded_at TIMESTAMP NOT NULL,
+    entity TEXT NOT NULL,
+    PRIMARY KEY (job_id, benchmark_index, name)
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_metrics (
+    job_id VARCHAR(36) NOT NULL,
+    benchmark_index INTEGER NOT NULL,
+    metric VARCHAR(255) NOT NULL,
+    model_name VARCHAR(255) NOT NULL,
+    provider_id VARCHAR(255) NOT NULL,
+    benchmark_id VARCHAR(255) NOT NULL,
+    value REAL NOT NULL,
+    recorded_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (job_id, benchmark_index, metric)
+);
+
+CREATE INDEX IF NOT EXISTS idx_evaluation_metrics_history
+ON evaluation_metrics (model_name, benchmark_id, metric, recorded_at);
+
 CREATE TABLE IF NOT EXISTS collections (
     id VARCHAR(36) NOT NULL,
 	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
@@ -51,6 +191,97 @@ CREATE TABLE IF NOT EXISTS providers (
     PRIMARY KEY (id)
 );
 
+CREATE TABLE IF NOT EXISTS baselines (
+    id VARCHAR(63) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    tenant_id VARCHAR(255) NOT NULL,
+    owner VARCHAR(255) NOT NULL,
+    entity TEXT NOT NULL,
+    PRIMARY KEY (tenant_id, id)
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_views (
+    id VARCHAR(36) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    tenant_id VARCHAR(255) NOT NULL,
+    owner VARCHAR(255) NOT NULL,
+    entity TEXT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS benchmark_result_cache (
+    cache_key VARCHAR(64) NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL,
+    job_id VARCHAR(36) NOT NULL,
+    completed_at TIMESTAMP NOT NULL,
+    entity TEXT NOT NULL,
+    PRIMARY KEY (tenant_id, cache_key)
+);
+
+CREATE TABLE IF NOT EXISTS tag_policies (
+    tenant_id VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    entity TEXT NOT NULL,
+    PRIMARY KEY (tenant_id)
+);
+
+CREATE TABLE IF NOT EXISTS redaction_policies (
+    tenant_id VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    entity TEXT NOT NULL,
+    PRIMARY KEY (tenant_id)
+);
+
+CREATE TABLE IF NOT EXISTS admin_config (
+    id VARCHAR(36) NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    entity TEXT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS provider_health (
+    provider_id VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    entity TEXT NOT NULL,
+    PRIMARY KEY (provider_id)
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_redactions (
+    job_id VARCHAR(36) NOT NULL,
+    benchmark_index INTEGER NOT NULL,
+    target VARCHAR(64) NOT NULL,
+    redacted_at TIMESTAMP NOT NULL,
+    entity TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_evaluation_redactions_job
+ON evaluation_redactions (job_id, redacted_at);
+
+CREATE TABLE IF NOT EXISTS status_dead_letters (
+    id VARCHAR(36) NOT NULL,
+    tenant_id VARCHAR(255) NOT NULL,
+    job_id VARCHAR(36) NOT NULL,
+    received_at TIMESTAMP NOT NULL,
+    entity TEXT NOT NULL,
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_status_dead_letters_received
+ON status_dead_letters (received_at);
+
+CREATE TABLE IF NOT EXISTS benchmark_durations (
+    tenant_id VARCHAR(255) NOT NULL,
+    provider_id VARCHAR(255) NOT NULL,
+    benchmark_id VARCHAR(255) NOT NULL,
+    examples_bucket INTEGER NOT NULL,
+    runs INTEGER NOT NULL,
+    total_seconds REAL NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, provider_id, benchmark_id, examples_bucket)
+);
+
 CREATE INDEX IF NOT EXISTS idx_eval_entity
 ON evaluations (id);
 
@@ -83,11 +314,13 @@ func (s *sqliteStatementsFactory) GetAllowedFilterColumns(tableName string) []st
 	allColumns := []string{"owner", "name", "tags"}
 	switch tableName {
 	case shared.TABLE_EVALUATIONS:
-		return append(allColumns, "status", "experiment_id")
+		return append(allColumns, "status", "experiment_id", "sweep_id", "annotation", "link", "visible_to", "review", "model", "created_after", "created_before")
 	case shared.TABLE_PROVIDERS:
 		return allColumns // "benchmarks" and "scope" are not allowed filters for providers from the database
 	case shared.TABLE_COLLECTIONS:
 		return append(allColumns, "category") // "scope" is not allowed filter for collections from the database
+	case shared.TABLE_BASELINES, shared.TABLE_VIEWS:
+		return []string{"owner", "name"}
 	default:
 		return nil
 	}
@@ -103,10 +336,142 @@ func (s *sqliteStatementsFactory) CreateEvaluationGetEntityStatement(query *shar
 }
 
 func (s *sqliteStatementsFactory) CreateEvaluationGetEntityForUpdateStatement(query *shared.EntityQuery) (string, []any, []any) {
-	// SQLite serializes writers via SetMaxOpenConns(1); FOR UPDATE is unsupported.
+	// SQLite transactions take the write lock when they begin (_txlock=immediate); FOR UPDATE is unsupported.
 	return s.CreateEvaluationGetEntityStatement(query)
 }
 
+func (s *sqliteStatementsFactory) CreateEvaluationBenchmarksGetStatement(jobIDs []string, benchmarkIndex *int) (string, []any) {
+	args := make([]any, 0, len(jobIDs)+1)
+	for _, jobID := range jobIDs {
+		args = append(args, jobID)
+	}
+	where := fmt.Sprintf("job_id IN (%s)", strings.TrimSuffix(strings.Repeat("?, ", len(jobIDs)), ", "))
+	if benchmarkIndex != nil {
+		where += " AND benchmark_index = ?"
+		args = append(args, *benchmarkIndex)
+	}
+	return fmt.Sprintf(`SELECT job_id, benchmark_index, entity, result FROM evaluation_benchmarks WHERE %s ORDER BY job_id, benchmark_index;`, where), args
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationBenchmarkUpsertStatement(jobID string, benchmarkIndex int, status api.State, entity string, result *string) (string, []any) {
+	return UPSERT_EVALUATION_BENCHMARK_STATEMENT, []any{jobID, benchmarkIndex, status, entity, result}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationBenchmarkStatesStatement(jobID string) (string, []any) {
+	return SELECT_EVALUATION_BENCHMARK_STATES_STATEMENT, []any{jobID}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationBenchmarksDeleteStatement(jobID string) (string, []any) {
+	return DELETE_EVALUATION_BENCHMARKS_STATEMENT, []any{jobID}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationFindingsDeleteStatement(jobID string, benchmarkIndex *int, shardIndex int) (string, []any) {
+	if benchmarkIndex == nil {
+		return `DELETE FROM evaluation_findings WHERE job_id = ?;`, []any{jobID}
+	}
+	return `DELETE FROM evaluation_findings WHERE job_id = ? AND benchmark_index = ? AND shard_index = ?;`, []any{jobID, *benchmarkIndex, shardIndex}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationFindingInsertStatement(jobID string, benchmarkIndex int, shardIndex int, findingIndex int, providerID string, benchmarkID string, finding *api.Finding, entity string) (string, []any) {
+	return INSERT_EVALUATION_FINDING_STATEMENT, []any{jobID, benchmarkIndex, shardIndex, findingIndex, providerID, benchmarkID, finding.Severity, finding.Severity.Rank(), finding.Probe, finding.Detector, entity}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationFindingsSeverityCountStatement(jobID string, benchmarkIndex int) (string, []any) {
+	return SELECT_EVALUATION_FINDINGS_SEVERITY_COUNT_STATEMENT, []any{jobID, benchmarkIndex}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationFindingsCountStatement(jobID string, filter map[string]any) (string, []any) {
+	where, args := shared.FindingsWhere(jobID, filter, func(int) string { return "?" })
+	return fmt.Sprintf(`SELECT COUNT(*) FROM evaluation_findings WHERE %s;`, where), args
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationFindingsListStatement(jobID string, filter map[string]any, limit, offset int) (string, []any) {
+	where, args := shared.FindingsWhere(jobID, filter, func(int) string { return "?" })
+	args = append(args, limit, offset)
+	return fmt.Sprintf(`SELECT provider_id, benchmark_id, benchmark_index, shard_index, entity FROM evaluation_findings WHERE %s ORDER BY severity_rank DESC, benchmark_index, shard_index, finding_index LIMIT ? OFFSET ?;`, where), args
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationArtifactUpsertStatement(jobID string, benchmarkIndex int, name string, uploadedAt time.Time, entity string) (string, []any) {
+	return UPSERT_EVALUATION_ARTIFACT_STATEMENT, []any{jobID, benchmarkIndex, name, uploadedAt.UTC(), entity}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationArtifactsCountStatement(jobID string, benchmarkIndex int) (string, []any) {
+	return SELECT_EVALUATION_ARTIFACTS_COUNT_STATEMENT, []any{jobID, benchmarkIndex}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationArtifactsListStatement(jobID string, benchmarkIndex int, limit, offset int) (string, []any) {
+	return SELECT_EVALUATION_ARTIFACTS_STATEMENT, []any{jobID, benchmarkIndex, limit, offset}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationArtifactsDeleteStatement(jobID string) (string, []any) {
+	return DELETE_EVALUATION_ARTIFACTS_STATEMENT, []any{jobID}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationGenerationUpsertStatement(jobID string, benchmarkIndex int, name string, uploadedAt time.Time, entity string) (string, []any) {
+	return UPSERT_EVALUATION_GENERATION_STATEMENT, []any{jobID, benchmarkIndex, name, uploadedAt.UTC(), entity}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationGenerationGetStatement(jobID string, benchmarkIndex int, name string) (string, []any) {
+	return SELECT_EVALUATION_GENERATION_STATEMENT, []any{jobID, benchmarkIndex, name}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationGenerationsCountStatement(jobID string, benchmarkIndex int) (string, []any) {
+	return SELECT_EVALUATION_GENERATIONS_COUNT_STATEMENT, []any{jobID, benchmarkIndex}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationGenerationsListStatement(jobID string, benchmarkIndex int, limit, offset int) (string, []any) {
+	return SELECT_EVALUATION_GENERATIONS_STATEMENT, []any{jobID, benchmarkIndex, limit, offset}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationGenerationsDeleteStatement(jobID string) (string, []any) {
+	return DELETE_EVALUATION_GENERATIONS_STATEMENT, []any{jobID}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationMetricsDeleteStatement(jobID string, benchmarkIndex *int) (string, []any) {
+	if benchmarkIndex == nil {
+		return DELETE_EVALUATION_METRICS_STATEMENT, []any{jobID}
+	}
+	return `DELETE FROM evaluation_metrics WHERE job_id = ? AND benchmark_index = ?;`, []any{jobID, *benchmarkIndex}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationMetricInsertStatement(jobID string, benchmarkIndex int, point *api.MetricPoint) (string, []any) {
+	return INSERT_EVALUATION_METRIC_STATEMENT, []any{jobID, benchmarkIndex, point.Metric, point.ModelName, point.ProviderID, point.BenchmarkID, point.Value, point.RecordedAt.UTC()}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationMetricsHistoryCountStatement(tenant api.Tenant, filter map[string]any) (string, []any) {
+	where, args := shared.MetricsHistoryWhere(s, tenant, filter, func(int) string { return "?" })
+	return fmt.Sprintf(`SELECT COUNT(*) FROM evaluation_metrics m, evaluations e WHERE %s;`, where), args
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationMetricsHistoryListStatement(tenant api.Tenant, filter map[string]any, limit, offset int) (string, []any) {
+	where, args := shared.MetricsHistoryWhere(s, tenant, filter, func(int) string { return "?" })
+	args = append(args, limit, offset)
+	return fmt.Sprintf(`SELECT m.job_id, m.benchmark_index, m.metric, m.model_name, m.provider_id, m.benchmark_id, m.value, m.recorded_at FROM evaluation_metrics m, evaluations e WHERE %s ORDER BY m.recorded_at, m.job_id, m.benchmark_index, m.metric LIMIT ? OFFSET ?;`, where), args
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationMetricNamesStatement(tenant api.Tenant, filter map[string]any) (string, []any) {
+	where, args := shared.MetricsHistoryWhere(s, tenant, filter, func(int) string { return "?" })
+	return fmt.Sprintf(`SELECT DISTINCT m.metric FROM evaluation_metrics m, evaluations e WHERE %s ORDER BY m.metric;`, where), args
+}
+
+func (s *sqliteStatementsFactory) CreateUpdateEvaluationStatusStatement(tenant api.Tenant, id string, status api.OverallState) (string, []any) {
+	if !tenant.IsEmpty() {
+		return `UPDATE evaluations SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND tenant_id = ?;`, []any{status, id, tenant.String()}
+	}
+	return `UPDATE evaluations SET status = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?;`, []any{status, id}
+}
+
+func (s *sqliteStatementsFactory) CreateUpdateEvaluationExperimentStatement(tenant api.Tenant, id string, experimentID string) (string, []any) {
+	if !tenant.IsEmpty() {
+		return `UPDATE evaluations SET experiment_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND tenant_id = ?;`, []any{experimentID, id, tenant.String()}
+	}
+	return `UPDATE evaluations SET experiment_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?;`, []any{experimentID, id}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationsWithoutExperimentStatement(limit int) (string, []any) {
+	return `SELECT id, tenant_id, created_at FROM evaluations WHERE experiment_id = '' AND COALESCE(json_extract(entity, '$.config.experiment.name'), '') <> '' ORDER BY created_at DESC LIMIT ?;`, []any{limit}
+}
+
 // entityFilterCondition returns the SQL condition and args for a filter key.
 func (s *sqliteStatementsFactory) CreateEntityFilterCondition(key string, value any, index int, tableName string) (condition string, args []any) {
 	switch key {
@@ -134,6 +499,47 @@ func (s *sqliteStatementsFactory) CreateEntityFilterCondition(key string, value
 			tagsPath = "$.config.tags"
 		}
 		return fmt.Sprintf("json_type(json_extract(entity, '%s')) = 'array' AND EXISTS (SELECT 1 FROM json_each(json_extract(entity, '%s')) WHERE value = ?)", tagsPath, tagsPath), []any{tagStr}
+	case "sweep_id":
+		return "json_extract(entity, '$.config.sweep_run.sweep_id') = ?", []any{value}
+	case "review":
+		return "json_extract(entity, '$.results.test.review.state') = ?", []any{value}
+	case "annotation":
+		annotationKey, annotationValue, hasValue := shared.ParseAnnotationFilter(value)
+		condition := "json_type(json_extract(entity, '$.config.annotations')) = 'object' AND EXISTS (SELECT 1 FROM json_each(json_extract(entity, '$.config.annotations')) WHERE key = ?"
+		if hasValue {
+			return condition + " AND value = ?)", []any{annotationKey, annotationValue}
+		}
+		return condition + ")", []any{annotationKey}
+	case "link":
+		return "json_type(json_extract(entity, '$.config.links')) = 'array' AND EXISTS (SELECT 1 FROM json_each(json_extract(entity, '$.config.links')) WHERE json_extract(value, '$.url') = ?)", []any{value}
+	case "model":
+		return "json_extract(entity, '$.config.model.name') = ?", []any{value}
+	case "created_after", "created_before":
+		// created_at is set by CURRENT_TIMESTAMP, which SQLite stores as UTC text
+		createdAt, _ := value.(time.Time)
+		operator := ">="
+		if key == "created_before" {
+			operator = "<"
+		}
+		return "created_at " + operator + " ?", []any{createdAt.UTC().Format(time.DateTime)}
+	case "visible_to":
+		visibility, _ := value.(abstractions.JobVisibility)
+		var sb strings.Builder
+		args := []any{visibility.User, visibility.User}
+		sb.WriteString("(owner = ? OR (json_type(json_extract(entity, '$.config.shared_with.users')) = 'array' AND EXISTS (SELECT 1 FROM json_each(json_extract(entity, '$.config.shared_with.users')) WHERE value = ?))")
+		if len(visibility.Groups) > 0 {
+			sb.WriteString(" OR (json_type(json_extract(entity, '$.config.shared_with.groups')) = 'array' AND EXISTS (SELECT 1 FROM json_each(json_extract(entity, '$.config.shared_with.groups')) WHERE value IN (")
+			for i, group := range visibility.Groups {
+				if i > 0 {
+					sb.WriteString(", ")
+				}
+				sb.WriteString("?")
+				args = append(args, group)
+			}
+			sb.WriteString(")))")
+		}
+		sb.WriteString(")")
+		return sb.String(), args
 	case "ORDER BY":
 		return "ORDER BY " + value.(string), []any{}
 	case "LIMIT", "OFFSET":
@@ -201,6 +607,13 @@ func (s *sqliteStatementsFactory) CreateUpdateEntityStatement(tenant api.Tenant,
 	}
 }
 
+func (s *sqliteStatementsFactory) CreateUpdateEntityOwnerStatement(tenant api.Tenant, tableName, id string, owner api.User) (string, []any) {
+	if !tenant.IsEmpty() {
+		return fmt.Sprintf(`UPDATE %s SET owner = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND tenant_id = ?;`, tableName), []any{owner, id, tenant.String()}
+	}
+	return fmt.Sprintf(`UPDATE %s SET owner = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?;`, tableName), []any{owner, id}
+}
+
 func (s *sqliteStatementsFactory) CreateProviderAddEntityStatement(provider *api.ProviderResource, entity string) (string, []any) {
 	return INSERT_PROVIDER_STATEMENT, []any{provider.Resource.ID, provider.Resource.Tenant, provider.Resource.Owner, entity}
 }
@@ -237,3 +650,113 @@ func (s *sqliteStatementsFactory) CreateCollectionGetEntityStatement(query *shar
 	where, whereArgs := s.getWhereStatement(query.Resource.Tenant, query.Resource.ID)
 	return fmt.Sprintf(`SELECT id, created_at, updated_at, tenant_id, owner, entity FROM collections WHERE %s;`, where), whereArgs, []any{&query.Resource.ID, &query.Resource.CreatedAt, &query.Resource.UpdatedAt, &query.Resource.Tenant, &query.Resource.Owner, &query.EntityJSON}
 }
+
+func (s *sqliteStatementsFactory) CreateBaselineAddEntityStatement(baseline *api.BaselineResource, entity string) (string, []any) {
+	return INSERT_BASELINE_STATEMENT, []any{baseline.Resource.ID, baseline.Resource.Tenant, baseline.Resource.Owner, entity}
+}
+
+func (s *sqliteStatementsFactory) CreateBaselineGetEntityStatement(query *shared.EntityQuery) (string, []any, []any) {
+	where, whereArgs := s.getWhereStatement(query.Resource.Tenant, query.Resource.ID)
+	return fmt.Sprintf(`SELECT id, created_at, updated_at, tenant_id, owner, entity FROM baselines WHERE %s;`, where), whereArgs, []any{&query.Resource.ID, &query.Resource.CreatedAt, &query.Resource.UpdatedAt, &query.Resource.Tenant, &query.Resource.Owner, &query.EntityJSON}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationViewAddEntityStatement(view *api.EvaluationViewResource, entity string) (string, []any) {
+	return INSERT_EVALUATION_VIEW_STATEMENT, []any{view.Resource.ID, view.Resource.Tenant, view.Resource.Owner, entity}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationViewGetEntityStatement(query *shared.EntityQuery) (string, []any, []any) {
+	where, whereArgs := s.getWhereStatement(query.Resource.Tenant, query.Resource.ID)
+	return fmt.Sprintf(`SELECT id, created_at, updated_at, tenant_id, owner, entity FROM evaluation_views WHERE %s;`, where), whereArgs, []any{&query.Resource.ID, &query.Resource.CreatedAt, &query.Resource.UpdatedAt, &query.Resource.Tenant, &query.Resource.Owner, &query.EntityJSON}
+}
+
+func (s *sqliteStatementsFactory) CreateResultCachePutStatement(tenant api.Tenant, key string, jobID string, completedAt time.Time, entity string) (string, []any) {
+	return UPSERT_RESULT_CACHE_STATEMENT, []any{key, tenant.String(), jobID, completedAt.UTC(), entity}
+}
+
+func (s *sqliteStatementsFactory) CreateResultCacheGetStatement(tenant api.Tenant, key string) (string, []any) {
+	return SELECT_RESULT_CACHE_STATEMENT, []any{tenant.String(), key}
+}
+
+func (s *sqliteStatementsFactory) CreateTagPolicyPutStatement(tenant api.Tenant, updatedAt time.Time, entity string) (string, []any) {
+	return UPSERT_TAG_POLICY_STATEMENT, []any{tenant.String(), updatedAt.UTC(), entity}
+}
+
+func (s *sqliteStatementsFactory) CreateTagPolicyGetStatement(tenant api.Tenant) (string, []any) {
+	return SELECT_TAG_POLICY_STATEMENT, []any{tenant.String()}
+}
+
+func (s *sqliteStatementsFactory) CreateRedactionPolicyPutStatement(tenant api.Tenant, updatedAt time.Time, entity string) (string, []any) {
+	return UPSERT_REDACTION_POLICY_STATEMENT, []any{tenant.String(), updatedAt.UTC(), entity}
+}
+
+func (s *sqliteStatementsFactory) CreateRedactionPolicyGetStatement(tenant api.Tenant) (string, []any) {
+	return SELECT_REDACTION_POLICY_STATEMENT, []any{tenant.String()}
+}
+
+func (s *sqliteStatementsFactory) CreateAdminConfigPutStatement(updatedAt time.Time, entity string) (string, []any) {
+	return UPSERT_ADMIN_CONFIG_STATEMENT, []any{updatedAt.UTC(), entity}
+}
+
+func (s *sqliteStatementsFactory) CreateAdminConfigGetStatement() (string, []any) {
+	return SELECT_ADMIN_CONFIG_STATEMENT, nil
+}
+
+func (s *sqliteStatementsFactory) CreateProviderHealthPutStatement(providerID string, updatedAt time.Time, entity string) (string, []any) {
+	return UPSERT_PROVIDER_HEALTH_STATEMENT, []any{providerID, updatedAt.UTC(), entity}
+}
+
+func (s *sqliteStatementsFactory) CreateProviderHealthListStatement() (string, []any) {
+	return SELECT_PROVIDER_HEALTH_STATEMENT, nil
+}
+
+func (s *sqliteStatementsFactory) CreateProviderHealthDeleteStatement(providerID string) (string, []any) {
+	return DELETE_PROVIDER_HEALTH_STATEMENT, []any{providerID}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationRedactionInsertStatement(jobID string, benchmarkIndex int, target string, redactedAt time.Time, entity string) (string, []any) {
+	return INSERT_EVALUATION_REDACTION_STATEMENT, []any{jobID, benchmarkIndex, target, redactedAt.UTC(), entity}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationRedactionsCountStatement(jobID string) (string, []any) {
+	return SELECT_EVALUATION_REDACTIONS_COUNT_STATEMENT, []any{jobID}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationRedactionsListStatement(jobID string, limit, offset int) (string, []any) {
+	return SELECT_EVALUATION_REDACTIONS_STATEMENT, []any{jobID, limit, offset}
+}
+
+func (s *sqliteStatementsFactory) CreateEvaluationRedactionsDeleteStatement(jobID string) (string, []any) {
+	return DELETE_EVALUATION_REDACTIONS_STATEMENT, []any{jobID}
+}
+
+func (s *sqliteStatementsFactory) CreateStatusDeadLetterInsertStatement(id string, tenant api.Tenant, jobID string, receivedAt time.Time, entity string) (string, []any) {
+	return INSERT_STATUS_DEAD_LETTER_STATEMENT, []any{id, tenant.String(), jobID, receivedAt.UTC(), entity}
+}
+
+func (s *sqliteStatementsFactory) CreateStatusDeadLetterGetStatement(id string) (string, []any) {
+	return SELECT_STATUS_DEAD_LETTER_STATEMENT, []any{id}
+}
+
+func (s *sqliteStatementsFactory) CreateStatusDeadLettersCountStatement(tenant api.Tenant, jobID string) (string, []any) {
+	return SELECT_STATUS_DEAD_LETTERS_COUNT_STATEMENT, []any{tenant.String(), tenant.String(), jobID, jobID}
+}
+
+func (s *sqliteStatementsFactory) CreateStatusDeadLettersListStatement(tenant api.Tenant, jobID string, limit, offset int) (string, []any) {
+	return SELECT_STATUS_DEAD_LETTERS_STATEMENT, []any{tenant.String(), tenant.String(), jobID, jobID, limit, offset}
+}
+
+func (s *sqliteStatementsFactory) CreateStatusDeadLetterDeleteStatement(id string) (string, []any) {
+	return DELETE_STATUS_DEAD_LETTER_STATEMENT, []any{id}
+}
+
+func (s *sqliteStatementsFactory) CreateStatusDeadLettersDeleteStatement(jobID string) (string, []any) {
+	return DELETE_STATUS_DEAD_LETTERS_STATEMENT, []any{jobID}
+}
+
+func (s *sqliteStatementsFactory) CreateBenchmarkDurationRecordStatement(tenant api.Tenant, providerID string, benchmarkID string, examplesBucket int, seconds float64) (string, []any) {
+	return UPSERT_BENCHMARK_DURATION_STATEMENT, []any{tenant.String(), providerID, benchmarkID, examplesBucket, seconds}
+}
+
+func (s *sqliteStatementsFactory) CreateBenchmarkDurationGetStatement(tenant api.Tenant, providerID string, benchmarkID string, examplesBucket int) (string, []any) {
+	return SELECT_BENCHMARK_DURATION_STATEMENT, []any{tenant.String(), providerID, benchmarkID, examplesBucket}
+}
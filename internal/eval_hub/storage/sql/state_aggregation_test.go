@@ -0,0 +1,106 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/constants"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestUpdateEvaluationJobStateAggregation(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := api.Tenant("tenant-state-aggregation")
+	store = store.WithTenant(tenant)
+	ids := []string{"mmlu", "arc", "hellaswag"}
+
+	createJob := func(aggregation *api.StateAggregation, failFast bool) string {
+		t.Helper()
+		jobID := common.GUID()
+		benchmarks := make([]api.EvaluationBenchmarkConfig, 0, len(ids))
+		for _, id := range ids {
+			benchmarks = append(benchmarks, api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: id}, ProviderID: "lm_evaluation_harness"})
+		}
+		if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant, Owner: "alice", CreatedAt: time.Now()}},
+			Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePending}},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Name:             "state-aggregation",
+				Model:            api.ModelRef{URL: "http://test-model:8000", Name: "test-model"},
+				Benchmarks:       benchmarks,
+				StateAggregation: aggregation,
+				FailFast:         failFast,
+			},
+		}); err != nil {
+			t.Fatalf("CreateEvaluationJob: %v", err)
+		}
+		return jobID
+	}
+	send := func(store abstractions.Storage, jobID string, index int, status api.State) {
+		t.Helper()
+		if _, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+			ProviderID:     "lm_evaluation_harness",
+			ID:             ids[index],
+			BenchmarkIndex: index,
+			Status:         status,
+		}}); err != nil {
+			t.Fatalf("UpdateEvaluationJob(%d, %s): %v", index, status, err)
+		}
+	}
+
+	for name, createFailFastJob := range map[string]func() string{
+		"fail fast":        func() string { return createJob(&api.StateAggregation{Policy: api.AggregationFailFast}, false) },
+		"fail fast option": func() string { return createJob(nil, true) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			jobID := createFailFastJob()
+			send(store, jobID, 0, api.StateRunning)
+			send(store, jobID, 1, api.StateFailed)
+
+			job, err := store.GetEvaluationJob(jobID)
+			if err != nil {
+				t.Fatalf("GetEvaluationJob: %v", err)
+			}
+			if job.Status.State != api.OverallStateFailed {
+				t.Fatalf("expected the job to fail on the first failure, got %s", job.Status.State)
+			}
+			if len(job.Status.Benchmarks) != 3 {
+				t.Fatalf("expected the status of every benchmark, got %+v", job.Status.Benchmarks)
+			}
+			for _, benchmark := range job.Status.Benchmarks {
+				if benchmark.BenchmarkIndex == 1 {
+					continue
+				}
+				if benchmark.Status != api.StateCancelled || benchmark.ErrorMessage == nil || benchmark.ErrorMessage.MessageCode != constants.MESSAGE_CODE_BENCHMARK_FAIL_FAST {
+					t.Errorf("expected benchmark %d to be cancelled, got %+v", benchmark.BenchmarkIndex, benchmark)
+				}
+			}
+		})
+	}
+
+	t.Run("quorum", func(t *testing.T) {
+		met := createJob(&api.StateAggregation{Policy: api.AggregationQuorum, Quorum: 2}, false)
+		send(store, met, 0, api.StateCompleted)
+		send(store, met, 1, api.StateFailed)
+		send(store, met, 2, api.StateCompleted)
+		missed := createJob(&api.StateAggregation{Policy: api.AggregationQuorum, Quorum: 2}, false)
+		send(store, missed, 0, api.StateCompleted)
+		send(store, missed, 1, api.StateFailed)
+		send(store, missed, 2, api.StateFailed)
+
+		for jobID, want := range map[string]api.OverallState{met: api.OverallStateCompleted, missed: api.OverallStateFailed} {
+			job, err := store.GetEvaluationJob(jobID)
+			if err != nil {
+				t.Fatalf("GetEvaluationJob: %v", err)
+			}
+			if job.Status.State != want {
+				t.Errorf("expected state %s, got %s: %v", want, job.Status.State, job.Status.Message)
+			}
+		}
+	})
+}
@@ -0,0 +1,104 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// statementCache holds the statements of the hot paths of the job callbacks, prepared
+// once when the storage is created so that the driver does not parse them again on every
+// call. A statement is identified by its SQL: the statements factory builds the same SQL
+// for every call of a statement, and the statements that are not in the cache run
+// unprepared.
+//
+// A statement stays valid across the connections of the pool: database/sql prepares it
+// again on a connection the first time it runs there, and a transaction prepares it on
+// its own connection. The statements are all prepared up front, rather than on first use,
+// because preparing with the pool takes a connection of its own, which a call inside a
+// transaction would wait for while the other connections are busy, and forever with the
+// single connection of an in-memory SQLite database.
+type statementCache struct {
+	statements map[string]*sql.Stmt
+}
+
+func newStatementCache(ctx context.Context, logger *slog.Logger, pool *sql.DB, queries []string) *statementCache {
+	c := &statementCache{statements: make(map[string]*sql.Stmt, len(queries))}
+	for _, query := range queries {
+		if _, ok := c.statements[query]; ok {
+			continue
+		}
+		stmt, err := pool.PrepareContext(ctx, query)
+		if err != nil {
+			// the statement still works unprepared
+			logger.Warn("Failed to prepare statement", "query", query, "error", err.Error())
+			continue
+		}
+		c.statements[query] = stmt
+	}
+	return c
+}
+
+// hotPathStatements returns the SQL of the statements that every job callback runs:
+// reading the job (for update in the transaction of a status event), updating it, and
+// inserting it, for requests with and without a tenant, and reading, writing and
+// counting the rows of the benchmarks of a job.
+func hotPathStatements(factory shared.SQLStatementsFactory) []string {
+	insert, _ := factory.CreateEvaluationAddEntityStatement(&api.EvaluationJobResource{Status: &api.EvaluationJobStatus{}}, "{}")
+	index := 0
+	getBenchmark, _ := factory.CreateEvaluationBenchmarksGetStatement([]string{"id"}, &index)
+	getBenchmarks, _ := factory.CreateEvaluationBenchmarksGetStatement([]string{"id"}, nil)
+	upsertBenchmark, _ := factory.CreateEvaluationBenchmarkUpsertStatement("id", 0, api.StatePending, "{}", nil)
+	countStates, _ := factory.CreateEvaluationBenchmarkStatesStatement("id")
+	queries := []string{insert, getBenchmark, getBenchmarks, upsertBenchmark, countStates}
+	status := api.OverallStatePending
+	for _, tenant := range []api.Tenant{"", "tenant"} {
+		query := shared.EntityQuery{Resource: api.Resource{ID: "id", Tenant: tenant}}
+		get, _, _ := factory.CreateEvaluationGetEntityStatement(&query)
+		getForUpdate, _, _ := factory.CreateEvaluationGetEntityForUpdateStatement(&query)
+		update, _ := factory.CreateUpdateEntityStatement(tenant, shared.TABLE_EVALUATIONS, "id", "{}", &status)
+		updateStatus, _ := factory.CreateUpdateEvaluationStatusStatement(tenant, "id", status)
+		queries = append(queries, get, getForUpdate, update, updateStatus)
+	}
+	return queries
+}
+
+// get returns the prepared statement of the query, bound to the transaction when there is
+// one, or nil when the query is not cached.
+func (c *statementCache) get(ctx context.Context, txn *sql.Tx, query string) *sql.Stmt {
+	if c == nil {
+		return nil
+	}
+	stmt, ok := c.statements[query]
+	if !ok {
+		return nil
+	}
+	if txn != nil {
+		// closed with the transaction, the statement stays prepared on the connection
+		return txn.StmtContext(ctx, stmt)
+	}
+	return stmt
+}
+
+func (c *statementCache) size() int {
+	if c == nil {
+		return 0
+	}
+	return len(c.statements)
+}
+
+// Close closes the prepared statements, before the pool is closed.
+func (c *statementCache) Close() error {
+	if c == nil {
+		return nil
+	}
+	var errs []error
+	for _, stmt := range c.statements {
+		errs = append(errs, stmt.Close())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,116 @@
+package sql_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestStatementCache(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+
+	// insert, get and update, and update of the status with and without a tenant, and the
+	// benchmark rows' get (one and all), upsert and count by state; SQLite has no FOR UPDATE
+	if count := sql.PreparedStatementCount(store); count != 11 {
+		t.Fatalf("expected 11 prepared statements, got %d", count)
+	}
+
+	// the prepared statements run in and out of transactions, with and without a tenant
+	for _, tenant := range []api.Tenant{"", api.Tenant(getTenant("team-a"))} {
+		scoped := store.WithTenant(tenant)
+		jobID := common.GUID()
+		if err := scoped.CreateEvaluationJob(&api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: jobID, Tenant: tenant}},
+			Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePending}},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Model:      api.ModelRef{URL: "http://test.com", Name: "test"},
+				Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "b1"}, ProviderID: "prov1"}},
+			},
+		}); err != nil {
+			t.Fatalf("CreateEvaluationJob(tenant %q): %v", tenant, err)
+		}
+		if _, err := scoped.UpdateEvaluationJobStatus(jobID, api.OverallStateRunning, nil); err != nil {
+			t.Fatalf("UpdateEvaluationJobStatus(tenant %q): %v", tenant, err)
+		}
+		job, err := scoped.GetEvaluationJob(jobID)
+		if err != nil {
+			t.Fatalf("GetEvaluationJob(tenant %q): %v", tenant, err)
+		}
+		if job.Status.State != api.OverallStateRunning {
+			t.Fatalf("expected the job of tenant %q to be running, got %s", tenant, job.Status.State)
+		}
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+}
+
+// TestStatementCachePooledConnections runs the prepared statements concurrently on the
+// pool of a file database, so that they run on several of its connections.
+func TestStatementCachePooledConnections(t *testing.T) {
+	config := map[string]any{"driver": "sqlite", "url": "file:" + filepath.ToSlash(filepath.Join(t.TempDir(), "evalhub.db"))}
+	store, err := storage.NewStorage(&config, nil, nil, false, false, logging.FallbackLogger())
+	if err != nil {
+		t.Fatalf("NewStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	if sql.PreparedStatementCount(store) == 0 {
+		t.Fatal("expected prepared statements")
+	}
+
+	const benchmarks = 8
+	jobID := common.GUID()
+	jobConfig := api.EvaluationJobConfig{Model: api.ModelRef{URL: "http://test.com", Name: "test"}}
+	for i := range benchmarks {
+		jobConfig.Benchmarks = append(jobConfig.Benchmarks, api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: fmt.Sprintf("b%d", i)}, ProviderID: "prov"})
+	}
+	if err := store.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource:            api.EvaluationResource{Resource: api.Resource{ID: jobID}},
+		Status:              &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStateRunning}},
+		EvaluationJobConfig: jobConfig,
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+
+	// the reads run outside of transactions, on the connections that the writes leave free
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*benchmarks)
+	for i := range benchmarks {
+		wg.Go(func() {
+			_, err := store.UpdateEvaluationJob(jobID, &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+				ID: fmt.Sprintf("b%d", i), ProviderID: "prov", BenchmarkIndex: i, Status: api.StateRunning,
+			}})
+			errs <- err
+		})
+		wg.Go(func() {
+			_, err := store.GetEvaluationJob(jobID)
+			errs <- err
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("expected the prepared statements to run on every connection: %v", err)
+		}
+	}
+
+	job, err := store.GetEvaluationJob(jobID)
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if len(job.Status.Benchmarks) != benchmarks {
+		t.Fatalf("expected %d benchmark statuses, got %d", benchmarks, len(job.Status.Benchmarks))
+	}
+}
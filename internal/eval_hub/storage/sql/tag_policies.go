@@ -0,0 +1,50 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+//#######################################################################
+// Tag policy operations
+//#######################################################################
+
+func (s *sqlStorage) PutTagPolicy(policy *api.TagPolicyResource) error {
+	entity, err := json.Marshal(policy)
+	if err != nil {
+		return serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	statement, args := s.statementsFactory.CreateTagPolicyPutStatement(s.tenant, policy.UpdatedAt, string(entity))
+	if _, err := s.exec(nil, statement, args...); err != nil {
+		s.logger.Error("Failed to store tag policy", "error", err)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "tag policy", "ResourceId", s.tenant.String(), "Error", err.Error())
+	}
+	return nil
+}
+
+func (s *sqlStorage) GetTagPolicy() (*api.TagPolicyResource, error) {
+	statement, args := s.statementsFactory.CreateTagPolicyGetStatement(s.tenant)
+
+	var policy api.TagPolicyResource
+	var entity string
+	err := s.queryRow(nil, statement, args...).Scan(&policy.UpdatedAt, &entity)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		s.logger.Error("Failed to get tag policy", "error", err)
+		return nil, serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "tag policy", "ResourceId", s.tenant.String(), "Error", err.Error())
+	}
+	updatedAt := policy.UpdatedAt
+	if err := json.Unmarshal([]byte(entity), &policy); err != nil {
+		s.logger.Error("Failed to unmarshal tag policy", "error", err)
+		return nil, serviceerrors.NewServiceError(messages.JSONUnmarshalFailed, "Type", "tag policy", "Error", err.Error())
+	}
+	policy.Tenant = s.tenant
+	policy.UpdatedAt = updatedAt
+	return &policy, nil
+}
@@ -0,0 +1,50 @@
+package sql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestTagPolicy(t *testing.T) {
+	store, err := getTestStorage(t, drivers[0], getDBName())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	tenant := store.WithTenant("tenant-tag-policy")
+
+	policy, err := tenant.GetTagPolicy()
+	if err != nil || policy != nil {
+		t.Fatalf("expected no tag policy, got %+v, %v", policy, err)
+	}
+
+	put := func(policy api.TagPolicy) {
+		t.Helper()
+		if err := tenant.PutTagPolicy(&api.TagPolicyResource{UpdatedAt: time.Now(), UpdatedBy: "alice", TagPolicy: policy}); err != nil {
+			t.Fatalf("PutTagPolicy: %v", err)
+		}
+	}
+	put(api.TagPolicy{Keys: []api.TagPolicyKey{{Key: "env", Values: []string{"production"}}}})
+	// the policy of the tenant is replaced
+	put(api.TagPolicy{
+		Keys:     []api.TagPolicyKey{{Key: "env", Values: []string{"production", "staging"}}, {Key: "cost-center"}},
+		Required: []string{"cost-center"},
+	})
+
+	policy, err = tenant.GetTagPolicy()
+	if err != nil {
+		t.Fatalf("GetTagPolicy: %v", err)
+	}
+	if policy.Tenant != "tenant-tag-policy" || policy.UpdatedBy != "alice" || policy.UpdatedAt.IsZero() {
+		t.Errorf("unexpected tag policy resource %+v", policy)
+	}
+	if len(policy.Keys) != 2 || len(policy.Keys[0].Values) != 2 || len(policy.Required) != 1 {
+		t.Errorf("expected the second tag policy, got %+v", policy.TagPolicy)
+	}
+
+	// the policy is scoped to its tenant
+	if other, err := store.WithTenant("tenant-tag-policy-other").GetTagPolicy(); err != nil || other != nil {
+		t.Errorf("expected no tag policy in another tenant, got %+v, %v", other, err)
+	}
+}
@@ -1,8 +1,10 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
@@ -17,7 +19,9 @@ type TransactionFunction func(*sql.Tx) error
 
 // withTransaction runs fn inside a transaction, retrying the full transaction on
 // serialization failure via retryOnSerializationFailure. See TransactionFunction.
-func (s *sqlStorage) withTransaction(name string, resourceID string, fn TransactionFunction) error {
+func (s *sqlStorage) withTransaction(name string, resourceID string, fn TransactionFunction) (err error) {
+	started := time.Now()
+	defer func() { s.observeTransaction(name, resourceID, started, err) }()
 	return retryOnSerializationFailure(serializationFailureMaxAttempts, func() error {
 		err := s.runTransaction(name, resourceID, fn)
 		if err != nil && isSerializationFailure(err) {
@@ -33,11 +37,30 @@ func (s *sqlStorage) withTransaction(name string, resourceID string, fn Transact
 	})
 }
 
-// runTransaction begins a transaction, runs fn, then commits or rolls back.
+// transactionContext returns the context of a transaction, bounded by the transaction timeout.
+func (s *sqlStorage) transactionContext() (context.Context, context.CancelFunc) {
+	if s.sqlConfig == nil || s.sqlConfig.GetTransactionTimeout() <= 0 {
+		return context.WithCancel(s.ctx)
+	}
+	return context.WithTimeout(s.ctx, s.sqlConfig.GetTransactionTimeout())
+}
+
+// runTransaction begins a transaction, runs fn, then commits or rolls back. The
+// transactions of a single-writer database wait for their turn, see waitForWriteTurn.
+// The transaction is rolled back when the request is cancelled or the transaction
+// timeout expires.
 // Serialization-failure retries are applied by withTransaction, which re-invokes
 // runTransaction (and thus fn) until success or retryOnSerializationFailure exhausts attempts.
 func (s *sqlStorage) runTransaction(name string, resourceID string, fn TransactionFunction) error {
-	txn, err := s.pool.BeginTx(s.ctx, &sql.TxOptions{Isolation: s.isolationLevel})
+	done, err := s.waitForWriteTurn()
+	if err != nil {
+		s.logger.Error("Failed to wait for the turn to write", "name", fmt.Sprintf("begin transaction %s", name), "resource_id", resourceID, "error", err.Error())
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", fmt.Sprintf("begin transaction %s", name), "ResourceId", resourceID, "Error", err.Error())
+	}
+	defer done()
+	ctx, cancel := s.transactionContext()
+	defer cancel()
+	txn, err := s.pool.BeginTx(ctx, &sql.TxOptions{Isolation: s.isolationLevel})
 	if err != nil {
 		s.logger.Error("Failed to begin transaction", "name", fmt.Sprintf("begin transaction %s", name), "resource_id", resourceID, "isolation_level", s.isolationLevel.String(), "error", err.Error())
 		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", fmt.Sprintf("begin transaction %s", name), "ResourceId", resourceID, "Error", err.Error())
@@ -58,6 +81,10 @@ func (s *sqlStorage) runTransaction(name string, resourceID string, fn Transacti
 	if commit {
 		if txnErr := txn.Commit(); txnErr != nil {
 			_ = txn.Rollback()
+			if ctx.Err() != nil {
+				// the transaction was rolled back when its context ended
+				txnErr = ctx.Err()
+			}
 			s.logger.Error("Failed to commit transaction", "name", fmt.Sprintf("commit transaction %s", name), "resource_id", resourceID, "isolation_level", s.isolationLevel.String(), "error", txnErr.Error())
 			return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", fmt.Sprintf("commit transaction %s", name), "ResourceId", resourceID, "Error", txnErr.Error())
 		}
@@ -0,0 +1,101 @@
+package sql
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage/sql/shared"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+//#######################################################################
+// Evaluation view operations
+//#######################################################################
+
+func (s *sqlStorage) CreateEvaluationView(view *api.EvaluationViewResource) error {
+	entity, err := json.Marshal(view.EvaluationViewConfig)
+	if err != nil {
+		return serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+	}
+	statement, args := s.statementsFactory.CreateEvaluationViewAddEntityStatement(view, string(entity))
+	if _, err := s.exec(nil, statement, args...); err != nil {
+		s.logger.Error("Failed to store evaluation view", "error", err, "id", view.Resource.ID)
+		return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation view", "ResourceId", view.Resource.ID, "Error", err.Error())
+	}
+	s.logger.Info("Stored evaluation view", "id", view.Resource.ID, "name", view.Name)
+	return nil
+}
+
+func (s *sqlStorage) GetEvaluationView(id string) (*api.EvaluationViewResource, error) {
+	return s.getEvaluationViewTransactional(nil, id)
+}
+
+func (s *sqlStorage) getEvaluationViewTransactional(txn *sql.Tx, id string) (*api.EvaluationViewResource, error) {
+	query := shared.EntityQuery{Resource: api.Resource{ID: id, Tenant: s.tenant}}
+	selectQuery, selectArgs, queryArgs := s.statementsFactory.CreateEvaluationViewGetEntityStatement(&query)
+
+	err := s.queryRow(txn, selectQuery, selectArgs...).Scan(queryArgs...)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "evaluation view", "ResourceId", id)
+		}
+		s.logger.Error("Failed to get evaluation view", "error", err, "id", id)
+		return nil, serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation view", "ResourceId", id, "Error", err.Error())
+	}
+
+	if !s.isVisibleResource(&query.Resource) {
+		return nil, serviceerrors.NewServiceError(messages.ResourceNotFound, "Type", "evaluation view", "ResourceId", id)
+	}
+
+	var config api.EvaluationViewConfig
+	if err := json.Unmarshal([]byte(query.EntityJSON), &config); err != nil {
+		s.logger.Error("Failed to unmarshal evaluation view", "error", err, "id", id)
+		return nil, serviceerrors.NewServiceError(messages.JSONUnmarshalFailed, "Type", "evaluation view", "Error", err.Error())
+	}
+	return &api.EvaluationViewResource{Resource: query.Resource, EvaluationViewConfig: config}, nil
+}
+
+func (s *sqlStorage) GetEvaluationViews(filter *abstractions.QueryFilter) (*abstractions.QueryResults[api.EvaluationViewResource], error) {
+	return listEntities[api.EvaluationViewResource](s, nil, shared.TABLE_VIEWS, filter)
+}
+
+func (s *sqlStorage) UpdateEvaluationView(id string, config *api.EvaluationViewConfig) (*api.EvaluationViewResource, error) {
+	var updated *api.EvaluationViewResource
+
+	err := s.withTransaction("update evaluation view", id, func(txn *sql.Tx) error {
+		if _, err := s.getEvaluationViewTransactional(txn, id); err != nil {
+			return err
+		}
+		entity, err := json.Marshal(config)
+		if err != nil {
+			return serviceerrors.NewServiceError(messages.InternalServerError, "Error", err.Error())
+		}
+		statement, args := s.statementsFactory.CreateUpdateEntityStatement(s.tenant, shared.TABLE_VIEWS, id, string(entity), nil)
+		if _, err := s.exec(txn, statement, args...); err != nil {
+			s.logger.Error("Failed to update evaluation view", "error", err, "id", id)
+			return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation view", "ResourceId", id, "Error", err.Error())
+		}
+		updated, err = s.getEvaluationViewTransactional(txn, id)
+		return err
+	})
+
+	return updated, err
+}
+
+func (s *sqlStorage) DeleteEvaluationView(id string) error {
+	return s.withTransaction("delete evaluation view", id, func(txn *sql.Tx) error {
+		if _, err := s.getEvaluationViewTransactional(txn, id); err != nil {
+			return err
+		}
+		deleteQuery, args := s.statementsFactory.CreateDeleteEntityStatement(s.tenant, shared.TABLE_VIEWS, id)
+		if _, err := s.exec(txn, deleteQuery, args...); err != nil {
+			s.logger.Error("Failed to delete evaluation view", "error", err, "id", id)
+			return serviceerrors.NewServiceError(messages.DatabaseOperationFailed, "Type", "evaluation view", "ResourceId", id, "Error", err.Error())
+		}
+		s.logger.Debug("Deleted evaluation view", "id", id)
+		return nil
+	})
+}
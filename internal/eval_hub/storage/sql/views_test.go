@@ -0,0 +1,146 @@
+package sql_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/common"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestEvaluationViews(t *testing.T) {
+	logger := logging.FallbackLogger()
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           getDBInMemoryURL("eval_hub_views"),
+		"database_name": "eval_hub_views",
+	}
+	store, err := storage.NewStorage(&databaseConfig, nil, nil, false, false, logger)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	teamA := store.WithTenant("team-a")
+	after := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	view := &api.EvaluationViewResource{
+		Resource: api.Resource{ID: common.GUID(), Tenant: "team-a", Owner: "alice", CreatedAt: time.Now()},
+		EvaluationViewConfig: api.EvaluationViewConfig{
+			Name:   "failed granite jobs",
+			Filter: api.EvaluationViewFilter{Status: "failed", Model: "granite", Tags: "nightly", CreatedAfter: &after},
+		},
+	}
+	if err := teamA.CreateEvaluationView(view); err != nil {
+		t.Fatalf("CreateEvaluationView: %v", err)
+	}
+
+	hasMessage := func(err error, message *messages.MessageCode) bool {
+		var se *serviceerrors.ServiceError
+		return errors.As(err, &se) && se.MessageCode() == message
+	}
+
+	t.Run("a view is returned by ID", func(t *testing.T) {
+		got, err := teamA.GetEvaluationView(view.Resource.ID)
+		if err != nil {
+			t.Fatalf("GetEvaluationView: %v", err)
+		}
+		if got.Name != view.Name || got.Filter.Model != "granite" || got.Filter.CreatedAfter == nil || !got.Filter.CreatedAfter.Equal(after) || got.Resource.Owner != "alice" {
+			t.Fatalf("unexpected view %+v", got)
+		}
+	})
+
+	t.Run("views are scoped to the tenant", func(t *testing.T) {
+		if _, err := store.WithTenant("team-b").GetEvaluationView(view.Resource.ID); !hasMessage(err, messages.ResourceNotFound) {
+			t.Fatalf("expected resource_not_found for another tenant, got %v", err)
+		}
+	})
+
+	t.Run("views are listed and filtered by name", func(t *testing.T) {
+		res, err := teamA.GetEvaluationViews(&abstractions.QueryFilter{Limit: 10, Params: map[string]any{"name": "failed granite jobs"}})
+		if err != nil {
+			t.Fatalf("GetEvaluationViews: %v", err)
+		}
+		if res.TotalCount != 1 || len(res.Items) != 1 || res.Items[0].Resource.ID != view.Resource.ID {
+			t.Fatalf("expected the view to be listed, got %+v", res)
+		}
+	})
+
+	t.Run("a view is updated", func(t *testing.T) {
+		updated, err := teamA.UpdateEvaluationView(view.Resource.ID, &api.EvaluationViewConfig{Name: "running jobs", Filter: api.EvaluationViewFilter{Status: "running"}})
+		if err != nil {
+			t.Fatalf("UpdateEvaluationView: %v", err)
+		}
+		if updated.Name != "running jobs" || updated.Filter.Model != "" || updated.Resource.Owner != "alice" {
+			t.Fatalf("unexpected updated view %+v", updated)
+		}
+	})
+
+	t.Run("a view is deleted", func(t *testing.T) {
+		if err := teamA.DeleteEvaluationView(view.Resource.ID); err != nil {
+			t.Fatalf("DeleteEvaluationView: %v", err)
+		}
+		if _, err := teamA.GetEvaluationView(view.Resource.ID); !hasMessage(err, messages.ResourceNotFound) {
+			t.Fatalf("expected the deleted view to be gone, got %v", err)
+		}
+		if err := teamA.DeleteEvaluationView(view.Resource.ID); !hasMessage(err, messages.ResourceNotFound) {
+			t.Fatalf("expected resource_not_found deleting it again, got %v", err)
+		}
+	})
+}
+
+func TestGetEvaluationJobsByModelAndCreationTime(t *testing.T) {
+	logger := logging.FallbackLogger()
+	databaseConfig := map[string]any{
+		"driver":        "sqlite",
+		"url":           getDBInMemoryURL("eval_hub_views_jobs"),
+		"database_name": "eval_hub_views_jobs",
+	}
+	store, err := storage.NewStorage(&databaseConfig, nil, nil, false, false, logger)
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	store = store.WithTenant("team-a")
+
+	for _, model := range []string{"granite", "llama"} {
+		job := &api.EvaluationJobResource{
+			Resource: api.EvaluationResource{Resource: api.Resource{ID: common.GUID(), Tenant: "team-a"}},
+			Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePending}},
+			EvaluationJobConfig: api.EvaluationJobConfig{
+				Model:      api.ModelRef{URL: "http://model", Name: model},
+				Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "b"}, ProviderID: "p"}},
+			},
+		}
+		if err := store.CreateEvaluationJob(job); err != nil {
+			t.Fatalf("create job: %v", err)
+		}
+	}
+
+	hourAgo := time.Now().Add(-time.Hour)
+	inAnHour := time.Now().Add(time.Hour)
+	for _, tc := range []struct {
+		filter map[string]any
+		want   int
+	}{
+		{map[string]any{"model": "granite"}, 1},
+		{map[string]any{"model": "granite|llama"}, 2},
+		{map[string]any{"created_after": hourAgo}, 2},
+		{map[string]any{"created_after": inAnHour}, 0},
+		{map[string]any{"created_before": inAnHour, "model": "llama"}, 1},
+		{map[string]any{"created_before": hourAgo}, 0},
+	} {
+		res, err := store.GetEvaluationJobs(&abstractions.QueryFilter{Limit: 50, Params: tc.filter})
+		if err != nil {
+			t.Fatalf("GetEvaluationJobs(%v): %v", tc.filter, err)
+		}
+		if len(res.Items) != tc.want || res.TotalCount != tc.want {
+			t.Errorf("GetEvaluationJobs(%v) = %d jobs of %d, want %d", tc.filter, len(res.Items), res.TotalCount, tc.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"log/slog"
+	"time"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
@@ -25,3 +26,12 @@ func NewStorage(
 	}
 	return sql.NewStorage(*databaseConfig, systemCollections, systemProviders, otelStorageScansEnabled, otelMetricsEnabled, logger)
 }
+
+// CheckConnection checks that the database of the configuration is reachable, without
+// creating the schemas or loading the system resources.
+func CheckConnection(databaseConfig *map[string]any, timeout time.Duration) error {
+	if databaseConfig == nil {
+		return serviceerrors.NewServiceError(messages.ConfigurationFailed, "Error", "database configuration")
+	}
+	return sql.CheckConnection(*databaseConfig, timeout)
+}
@@ -0,0 +1,275 @@
+// Eval Hub results UI. Everything shown here comes from the public JSON APIs.
+"use strict";
+
+const API = "../api/v1/evaluations";
+const PAGE_SIZE = 20;
+
+const state = {
+  offset: 0,
+  total: 0,
+};
+
+function $(id) {
+  return document.getElementById(id);
+}
+
+function el(tag, attrs, ...children) {
+  const node = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs || {})) {
+    if (key === "class") {
+      node.className = value;
+    } else {
+      node.setAttribute(key, value);
+    }
+  }
+  for (const child of children) {
+    if (child === null || child === undefined) {
+      continue;
+    }
+    node.append(child instanceof Node ? child : String(child));
+  }
+  return node;
+}
+
+function headers() {
+  const h = { Accept: "application/json" };
+  const tenant = localStorage.getItem("evalhub.tenant");
+  const user = localStorage.getItem("evalhub.user");
+  if (tenant) {
+    h["X-Tenant"] = tenant;
+  }
+  if (user) {
+    h["X-User"] = user;
+  }
+  return h;
+}
+
+async function request(path, asText) {
+  const resp = await fetch(API + path, { headers: headers() });
+  if (!resp.ok) {
+    let message = resp.status + " " + resp.statusText;
+    try {
+      const body = await resp.json();
+      if (body.message) {
+        message = body.message;
+      }
+    } catch (e) {
+      // not a JSON error body
+    }
+    throw new Error(message);
+  }
+  return asText ? resp.text() : resp.json();
+}
+
+function showError(err) {
+  const node = $("error");
+  node.textContent = err ? err.message : "";
+  node.hidden = !err;
+}
+
+function stateBadge(value) {
+  return el("span", { class: "state state-" + (value || "unknown") }, value || "unknown");
+}
+
+function formatTime(value) {
+  if (!value) {
+    return "";
+  }
+  const date = new Date(value);
+  return isNaN(date) ? value : date.toLocaleString();
+}
+
+function formatValue(value) {
+  if (typeof value === "number") {
+    return Number.isInteger(value) ? String(value) : value.toFixed(4);
+  }
+  if (typeof value === "object" && value !== null) {
+    return JSON.stringify(value);
+  }
+  return String(value);
+}
+
+function isURL(value) {
+  return typeof value === "string" && /^https?:\/\//.test(value);
+}
+
+// Jobs list
+
+async function loadJobs() {
+  const params = new URLSearchParams({ limit: PAGE_SIZE, offset: state.offset });
+  for (const key of ["status", "name", "tags"]) {
+    const value = $(key).value.trim();
+    if (value) {
+      params.set(key, value);
+    }
+  }
+  const page = await request("/jobs?" + params.toString());
+  state.total = page.total_count || 0;
+
+  const body = $("jobs").querySelector("tbody");
+  body.replaceChildren();
+  for (const job of page.items || []) {
+    const id = job.resource.id;
+    const benchmarks = (job.benchmarks || []).map((b) => b.id).join(", ") ||
+      (job.collection ? "collection " + job.collection.id : "");
+    body.append(el("tr", {},
+      el("td", {}, el("a", { href: "#/jobs/" + encodeURIComponent(id) }, job.name || id)),
+      el("td", {}, stateBadge(job.status && job.status.state)),
+      el("td", {}, benchmarks),
+      el("td", {}, job.model ? job.model.name : ""),
+      el("td", {}, formatTime(job.resource.created_at)),
+    ));
+  }
+  if (!body.children.length) {
+    body.append(el("tr", {}, el("td", { colspan: "5" }, "No evaluation jobs")));
+  }
+
+  const last = Math.min(state.offset + PAGE_SIZE, state.total);
+  $("page-info").textContent = state.total ? (state.offset + 1) + "-" + last + " of " + state.total : "";
+  $("prev").disabled = state.offset === 0;
+  $("next").disabled = last >= state.total;
+}
+
+// Job detail
+
+function timeline(job) {
+  const list = el("ol", { class: "timeline" });
+  list.append(el("li", {}, formatTime(job.resource.created_at), " job created"));
+  for (const b of (job.status && job.status.benchmarks) || []) {
+    const label = b.provider_id + "/" + b.id + " [" + b.benchmark_index + "]";
+    if (b.started_at) {
+      list.append(el("li", {}, formatTime(b.started_at), " " + label + " started"));
+    }
+    const message = b.error_message ? " - " + b.error_message.message : "";
+    list.append(el("li", {},
+      b.completed_at ? formatTime(b.completed_at) + " " : "",
+      label + " ", stateBadge(b.status), b.phase ? " " + b.phase : "", message));
+  }
+  if (job.status && job.status.message) {
+    list.append(el("li", {},
+      formatTime(job.resource.updated_at), " ", stateBadge(job.status.state), " " + job.status.message.message));
+  }
+  return list;
+}
+
+function metricsTable(results) {
+  const rows = [];
+  for (const b of results.benchmarks || []) {
+    for (const [metric, value] of Object.entries(b.metrics || {})) {
+      rows.push(el("tr", {},
+        el("td", {}, b.provider_id + "/" + b.id),
+        el("td", {}, metric),
+        el("td", {}, formatValue(value))));
+    }
+  }
+  if (!rows.length) {
+    return el("p", {}, "No metrics reported yet.");
+  }
+  return el("table", {},
+    el("thead", {}, el("tr", {}, el("th", {}, "Benchmark"), el("th", {}, "Metric"), el("th", {}, "Value"))),
+    el("tbody", {}, ...rows));
+}
+
+function artifactsList(job) {
+  const list = el("ul");
+  const results = job.results || {};
+  if (results.mlflow_experiment_url) {
+    list.append(el("li", {}, el("a", { href: results.mlflow_experiment_url, target: "_blank", rel: "noopener" }, "MLflow experiment")));
+  }
+  for (const b of results.benchmarks || []) {
+    for (const [name, value] of Object.entries(b.artifacts || {})) {
+      const label = b.id + ": " + name;
+      list.append(el("li", {}, isURL(value)
+        ? el("a", { href: value, target: "_blank", rel: "noopener" }, label)
+        : label + " = " + formatValue(value)));
+    }
+    if (b.logs_path) {
+      list.append(el("li", {}, b.id + ": logs at " + b.logs_path));
+    }
+  }
+  return list.children.length ? list : el("p", {}, "No artifacts reported.");
+}
+
+function logsSection(job) {
+  const id = encodeURIComponent(job.resource.id);
+  const output = el("pre", { hidden: "" });
+  const select = el("select", {}, el("option", { value: "" }, "all benchmarks"));
+  (job.benchmarks || []).forEach((b, i) => select.append(el("option", { value: String(i) }, b.id + " [" + i + "]")));
+  const button = el("button", { type: "button" }, "Show logs");
+  button.addEventListener("click", async () => {
+    const index = select.value;
+    const path = index === "" ? "/jobs/" + id + "/logs" : "/jobs/" + id + "/benchmarks/" + index + "/logs";
+    output.hidden = false;
+    output.textContent = "Loading...";
+    try {
+      output.textContent = await request(path, true) || "(no logs)";
+    } catch (err) {
+      output.textContent = err.message;
+    }
+  });
+  return el("div", {}, el("form", {}, select, button), output);
+}
+
+async function loadJob(id) {
+  const job = await request("/jobs/" + encodeURIComponent(id));
+  const view = $("job-view");
+  view.replaceChildren(
+    el("p", {}, el("a", { href: "#" }, "All jobs")),
+    el("h2", {}, job.name || id, " ", stateBadge(job.status && job.status.state)),
+    el("p", {}, "ID " + id + (job.model ? " | model " + job.model.name + " (" + job.model.url + ")" : "")),
+    el("h3", {}, "Timeline"),
+    timeline(job),
+    el("h3", {}, "Metrics"),
+    metricsTable(job.results || {}),
+    el("h3", {}, "Artifacts"),
+    artifactsList(job),
+    el("h3", {}, "Logs"),
+    logsSection(job),
+  );
+}
+
+// Routing
+
+async function route() {
+  const match = location.hash.match(/^#\/jobs\/(.+)$/);
+  $("jobs-view").hidden = !!match;
+  $("job-view").hidden = !match;
+  showError(null);
+  try {
+    if (match) {
+      await loadJob(decodeURIComponent(match[1]));
+    } else {
+      await loadJobs();
+    }
+  } catch (err) {
+    showError(err);
+  }
+}
+
+function init() {
+  $("tenant").value = localStorage.getItem("evalhub.tenant") || "";
+  $("user").value = localStorage.getItem("evalhub.user") || "";
+  $("settings").addEventListener("submit", (e) => {
+    e.preventDefault();
+    localStorage.setItem("evalhub.tenant", $("tenant").value.trim());
+    localStorage.setItem("evalhub.user", $("user").value.trim());
+    route();
+  });
+  $("filters").addEventListener("submit", (e) => {
+    e.preventDefault();
+    state.offset = 0;
+    route();
+  });
+  $("prev").addEventListener("click", () => {
+    state.offset = Math.max(0, state.offset - PAGE_SIZE);
+    route();
+  });
+  $("next").addEventListener("click", () => {
+    state.offset += PAGE_SIZE;
+    route();
+  });
+  window.addEventListener("hashchange", route);
+  route();
+}
+
+init();
@@ -0,0 +1,26 @@
+// Package ui embeds the static results UI served under /ui. The UI is plain HTML and
+// JavaScript that only uses the public JSON APIs, so it needs no build step and no
+// separate frontend deployment.
+package ui
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// Path is the URL prefix the UI is served under.
+const Path = "/ui/"
+
+//go:embed static
+var static embed.FS
+
+// Handler serves the embedded UI assets. It expects the request path to include Path.
+func Handler() http.Handler {
+	assets, err := fs.Sub(static, "static")
+	if err != nil {
+		// the embedded directory is fixed at build time
+		panic(err)
+	}
+	return http.StripPrefix(Path, http.FileServerFS(assets))
+}
@@ -0,0 +1,117 @@
+package validation
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// ScoreBounds are the bounds of a score. A nil bound is unbounded or not known.
+type ScoreBounds struct {
+	Min *float64
+	Max *float64
+}
+
+// Contains reports whether the value is within the bounds.
+func (b ScoreBounds) Contains(value float64) bool {
+	return (b.Min == nil || value >= *b.Min) && (b.Max == nil || value <= *b.Max)
+}
+
+// String returns the bounds as an interval, e.g. [0, 1].
+func (b ScoreBounds) String() string {
+	bound := func(value *float64, unbounded string) string {
+		if value == nil {
+			return unbounded
+		}
+		return strconv.FormatFloat(*value, 'g', -1, 64)
+	}
+	return "[" + bound(b.Min, "-inf") + ", " + bound(b.Max, "+inf") + "]"
+}
+
+// widen returns the bounds that contain both bounds.
+func (b ScoreBounds) widen(other ScoreBounds) ScoreBounds {
+	pick := func(a, b *float64, pick func(float64, float64) float64) *float64 {
+		if a == nil || b == nil {
+			return nil
+		}
+		value := pick(*a, *b)
+		return &value
+	}
+	return ScoreBounds{Min: pick(b.Min, other.Min, math.Min), Max: pick(b.Max, other.Max, math.Max)}
+}
+
+// inverted returns the bounds of 1 - score, which is how a score where lower is better
+// counts towards the score of a job.
+func (b ScoreBounds) inverted() ScoreBounds {
+	invert := func(value *float64) *float64 {
+		if value == nil {
+			return nil
+		}
+		inverted := 1 - *value
+		return &inverted
+	}
+	return ScoreBounds{Min: invert(b.Max), Max: invert(b.Min)}
+}
+
+// PrimaryScoreBounds returns the bounds of the primary score of a benchmark from the metrics
+// its provider declares: the range of its metric, or for a weighted average the lowest
+// minimum and the highest maximum of its metrics. The bounds of an expression are not known.
+func PrimaryScoreBounds(provider *api.ProviderConfig, primaryScore *api.PrimaryScore) ScoreBounds {
+	switch {
+	case !primaryScore.IsSet() || primaryScore.Expression != "":
+		return ScoreBounds{}
+	case primaryScore.Metric != "":
+		if metric := provider.GetMetric(primaryScore.Metric); metric != nil {
+			return ScoreBounds{Min: metric.Min, Max: metric.Max}
+		}
+		return ScoreBounds{}
+	}
+	var bounds ScoreBounds
+	for i, weighted := range primaryScore.Metrics {
+		metric := provider.GetMetric(weighted.Metric)
+		if metric == nil {
+			return ScoreBounds{}
+		}
+		if i == 0 {
+			bounds = ScoreBounds{Min: metric.Min, Max: metric.Max}
+			continue
+		}
+		bounds = bounds.widen(ScoreBounds{Min: metric.Min, Max: metric.Max})
+	}
+	return bounds
+}
+
+// JobScoreBounds returns the bounds of the score of a job, the weighted average of the
+// primary scores of its benchmarks, given the bounds of each primary score and whether lower
+// is better for it.
+func JobScoreBounds(benchmarks []ScoreBounds, lowerIsBetter []bool) ScoreBounds {
+	var bounds ScoreBounds
+	for i, benchmark := range benchmarks {
+		if i < len(lowerIsBetter) && lowerIsBetter[i] {
+			benchmark = benchmark.inverted()
+		}
+		if i == 0 {
+			bounds = benchmark
+			continue
+		}
+		bounds = bounds.widen(benchmark)
+	}
+	return bounds
+}
+
+// ValidateJobThreshold returns an error if the threshold of the pass criteria of a job, or
+// of its collection, is outside the bounds of the score of the job, so that the job could
+// never pass, or never fail, its pass criteria.
+func ValidateJobThreshold(passCriteria *api.PassCriteria, bounds ScoreBounds) error {
+	if passCriteria == nil || passCriteria.Threshold == nil || bounds.Contains(float64(*passCriteria.Threshold)) {
+		return nil
+	}
+	return serviceerrors.NewServiceError(
+		messages.RequestValidationFailed,
+		"Error", fmt.Sprintf("the pass criteria threshold %v is outside the range %s of the job score", *passCriteria.Threshold, bounds),
+	)
+}
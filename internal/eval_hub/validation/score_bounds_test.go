@@ -0,0 +1,69 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestPrimaryScoreBounds(t *testing.T) {
+	t.Parallel()
+	zero, one, ten := 0.0, 1.0, 10.0
+	provider := &api.ProviderConfig{Metrics: []api.MetricDefinition{
+		{Name: "accuracy", Min: &zero, Max: &one, Aliases: []string{"acc"}},
+		{Name: "bleu", Min: &zero, Max: &ten},
+		{Name: "loss", Min: &zero},
+	}}
+
+	tests := map[string]struct {
+		primaryScore *api.PrimaryScore
+		want         string
+	}{
+		"none":             {primaryScore: nil, want: "[-inf, +inf]"},
+		"metric":           {primaryScore: &api.PrimaryScore{Metric: "acc"}, want: "[0, 1]"},
+		"undeclared":       {primaryScore: &api.PrimaryScore{Metric: "f1"}, want: "[-inf, +inf]"},
+		"weighted":         {primaryScore: &api.PrimaryScore{Metrics: []api.WeightedMetric{{Metric: "acc"}, {Metric: "bleu", Weight: 2}}}, want: "[0, 10]"},
+		"weighted unbound": {primaryScore: &api.PrimaryScore{Metrics: []api.WeightedMetric{{Metric: "acc"}, {Metric: "loss"}}}, want: "[0, +inf]"},
+		"expression":       {primaryScore: &api.PrimaryScore{Expression: "acc * 0.5"}, want: "[-inf, +inf]"},
+	}
+	for name, tt := range tests {
+		if got := PrimaryScoreBounds(provider, tt.primaryScore).String(); got != tt.want {
+			t.Errorf("%s: expected %s, got %s", name, tt.want, got)
+		}
+	}
+}
+
+func TestValidateJobThreshold(t *testing.T) {
+	t.Parallel()
+	zero, one, hundred := 0.0, 1.0, 100.0
+	accuracy := ScoreBounds{Min: &zero, Max: &one}
+	perplexity := ScoreBounds{Min: &zero, Max: &hundred}
+	threshold := func(value float32) *api.PassCriteria { return &api.PassCriteria{Threshold: &value} }
+
+	if got := JobScoreBounds([]ScoreBounds{accuracy, perplexity}, []bool{false, true}).String(); got != "[-99, 1]" {
+		t.Errorf("expected the inverted bounds of a lower is better score, got %s", got)
+	}
+	if got := JobScoreBounds([]ScoreBounds{accuracy, {}}, nil).String(); got != "[-inf, +inf]" {
+		t.Errorf("expected the score of a job with an unbounded benchmark to be unbounded, got %s", got)
+	}
+
+	bounds := JobScoreBounds([]ScoreBounds{accuracy, accuracy}, nil)
+	for name, passCriteria := range map[string]*api.PassCriteria{
+		"none":            nil,
+		"baseline only":   {MustNotRegress: "prod"},
+		"in range":        threshold(0.8),
+		"the upper bound": threshold(1),
+	} {
+		if err := ValidateJobThreshold(passCriteria, bounds); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+	err := ValidateJobThreshold(threshold(80), bounds)
+	var se *serviceerrors.ServiceError
+	if !errors.As(err, &se) || se.MessageCode() != messages.RequestValidationFailed {
+		t.Errorf("expected a RequestValidationFailed service error, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+	validator "github.com/go-playground/validator/v10"
+)
+
+// validateTagPolicy checks that the keys of a tag policy are unique and that its required keys
+// are allowed.
+func validateTagPolicy(sl validator.StructLevel) {
+	policy := sl.Current().Interface().(api.TagPolicy)
+	keys := make(map[string]bool, len(policy.Keys))
+	for i, key := range policy.Keys {
+		if keys[key.Key] {
+			sl.ReportError(policy.Keys[i].Key, fmt.Sprintf("keys[%d].key", i), "Key", "unique", key.Key)
+		}
+		keys[key.Key] = true
+	}
+	if len(policy.Keys) == 0 {
+		return
+	}
+	for i, required := range policy.Required {
+		if !keys[required] {
+			sl.ReportError(policy.Required[i], fmt.Sprintf("required[%d]", i), "Required", "oneof", required)
+		}
+	}
+}
+
+// ValidateTags returns an error when the tags of a job or collection do not follow the tag
+// policy of its tenant: a tag with a key that is not allowed, or with a value that is not
+// allowed for its key, or a required key without tag. Every violation is reported, with the
+// allowed values, so that the tags can be fixed at once.
+func ValidateTags(policy *api.TagPolicy, resourceType string, tags []string) error {
+	if policy.IsEmpty() {
+		return nil
+	}
+	var problems []string
+	tagged := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		key, value := api.SplitTag(tag)
+		tagged[key] = true
+		if len(policy.Keys) == 0 {
+			continue
+		}
+		allowed := policy.Key(key)
+		switch {
+		case allowed == nil:
+			problems = append(problems, fmt.Sprintf("the tag key %q is not allowed", key))
+		case len(allowed.Values) > 0 && !slices.Contains(allowed.Values, value):
+			problems = append(problems, fmt.Sprintf("the tag %q must have one of the values %s of the key %q", tag, strings.Join(allowed.Values, ", "), key))
+		}
+	}
+	for _, required := range policy.Required {
+		if !tagged[required] {
+			problems = append(problems, fmt.Sprintf("the tag key %q is required", required))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return serviceerrors.NewServiceError(messages.TagPolicyViolation, "Type", resourceType, "Reason", strings.Join(problems, "; "))
+}
@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestTagPolicyValidation(t *testing.T) {
+	validate := newTestValidator(t)
+
+	tests := map[string]struct {
+		policy api.TagPolicy
+		valid  bool
+	}{
+		"empty":              {policy: api.TagPolicy{}, valid: true},
+		"keys":               {policy: api.TagPolicy{Keys: []api.TagPolicyKey{{Key: "cost-center"}, {Key: "env", Values: []string{"dev", "production"}}}, Required: []string{"cost-center"}}, valid: true},
+		"required only":      {policy: api.TagPolicy{Required: []string{"cost-center"}}, valid: true},
+		"duplicate key":      {policy: api.TagPolicy{Keys: []api.TagPolicyKey{{Key: "env"}, {Key: "env"}}}},
+		"required not a key": {policy: api.TagPolicy{Keys: []api.TagPolicyKey{{Key: "env"}}, Required: []string{"cost-center"}}},
+		"key with separator": {policy: api.TagPolicy{Keys: []api.TagPolicyKey{{Key: "env:prod"}}}},
+		"empty key":          {policy: api.TagPolicy{Keys: []api.TagPolicyKey{{}}}},
+	}
+	for name, tt := range tests {
+		err := validate.Struct(tt.policy)
+		if tt.valid && err != nil {
+			t.Errorf("%s: expected a valid policy, got %v", name, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("%s: expected an invalid policy", name)
+		}
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	t.Parallel()
+	policy := &api.TagPolicy{
+		Keys: []api.TagPolicyKey{
+			{Key: "cost-center"},
+			{Key: "env", Values: []string{"dev", "production"}},
+			{Key: "smoke_test"},
+		},
+		Required: []string{"cost-center"},
+	}
+
+	for name, tags := range map[string][]string{
+		"required and allowed": {"cost-center:ml-platform", "env:production"},
+		"key without value":    {"cost-center", "smoke_test"},
+	} {
+		if err := ValidateTags(policy, "evaluation job", tags); err != nil {
+			t.Errorf("%s: expected the tags to be allowed, got %v", name, err)
+		}
+	}
+	if err := ValidateTags(nil, "evaluation job", []string{"anything"}); err != nil {
+		t.Errorf("expected any tag without policy, got %v", err)
+	}
+	if err := ValidateTags(&api.TagPolicy{Required: []string{"cost-center"}}, "collection", []string{"anything", "cost-center:a"}); err != nil {
+		t.Errorf("expected any key when the policy only has required keys, got %v", err)
+	}
+
+	err := ValidateTags(policy, "evaluation job", []string{"team:nlp", "env:prod"})
+	var se *serviceerrors.ServiceError
+	if !errors.As(err, &se) || se.MessageCode() != messages.TagPolicyViolation {
+		t.Fatalf("expected a TagPolicyViolation service error, got %v", err)
+	}
+	for _, want := range []string{`"team" is not allowed`, `"env:prod" must have one of the values dev, production`, `"cost-center" is required`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected the violations to include %s, got %v", want, err)
+		}
+	}
+}
@@ -2,21 +2,27 @@ package validation
 
 import (
 	"fmt"
+	"maps"
 	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/scoring"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/serviceerrors"
 	"github.com/eval-hub/eval-hub/pkg/api"
 	validator "github.com/go-playground/validator/v10"
 	"github.com/go-playground/validator/v10/non-standard/validators"
+	k8svalidation "k8s.io/apimachinery/pkg/util/validation"
 )
 
 var (
 	tagAliases = map[string]string{
 		// this is the definition for tag name validation
 		"tagname": "max=128,min=1,excludesall=0x2C0x7C",
+		// annotation keys can not hold the separators of the annotation list filter
+		"annotationkey": "max=253,min=1,excludesall=0x2C0x7C:",
 		// this is the definition for id validation for a uuid - system resources are not uuid's
 		"resource_id": "required,min=1,max=36",
 	}
@@ -58,8 +64,22 @@ func registerCustomValidators(instance *validator.Validate) error {
 	}
 	// Benchmarks min=1 only when Collection is not set (required_without handles presence; this enforces length)
 	instance.RegisterStructValidation(evaluationJobConfigBenchmarksMin, api.EvaluationJobConfig{})
+	// The metrics of a provider are unique and its benchmarks refer to them.
+	instance.RegisterStructValidation(validateProviderMetrics, api.ProviderConfig{})
 	// Exactly one of s3 or pvc must be set in TestDataRef.
 	instance.RegisterStructValidation(validateTestDataRefMutualExclusion, api.TestDataRef{})
+	// The expression of a primary score parses.
+	instance.RegisterStructValidation(validatePrimaryScoreExpression, api.PrimaryScore{})
+	// The labels and annotations of the pod metadata are valid and not reserved.
+	instance.RegisterStructValidation(validatePodMetadata, api.PodMetadata{})
+	// The checkpoints of the spot node pool are written to the data volume.
+	instance.RegisterStructValidation(validateSpotCheckpoint, api.K8sRuntime{})
+	// A corpus indexed by the adapter needs the embedding model of the retrieval.
+	instance.RegisterStructValidation(validateRAGConfig, api.RAGConfig{})
+	// The chat settings of a benchmark are set by its conversation, not by its parameters.
+	instance.RegisterStructValidation(validateBenchmarkConversation, api.EvaluationBenchmarkConfig{}, api.CollectionBenchmarkConfig{})
+	// The keys of a tag policy are unique and its required keys are allowed.
+	instance.RegisterStructValidation(validateTagPolicy, api.TagPolicy{})
 	return nil
 }
 
@@ -81,7 +101,16 @@ func ValidateCollectionOverrides(overrides []api.EvaluationBenchmarkConfig, coll
 		providerIDs[b.ProviderID] = struct{}{}
 		pairs[benchmarkKey{b.ProviderID, b.ID}] = struct{}{}
 	}
-	for _, override := range overrides {
+	for i, override := range overrides {
+		if len(override.DependsOn) > 0 {
+			return serviceerrors.NewServiceError(
+				messages.InvalidBenchmarkDependency,
+				"BenchmarkID", override.ID,
+				"BenchmarkIndex", i,
+				"DependsOn", override.DependsOn[0],
+				"Reason", "dependencies are not supported for collection benchmarks",
+			)
+		}
 		if _, ok := providerIDs[override.ProviderID]; !ok {
 			return serviceerrors.NewServiceError(
 				messages.ResourceDoesNotExist,
@@ -102,6 +131,138 @@ func ValidateCollectionOverrides(overrides []api.EvaluationBenchmarkConfig, coll
 	return nil
 }
 
+// ValidateBenchmarkDependencies returns an error if a benchmark of a job depends on a
+// benchmark index that does not exist, on itself, or on a benchmark that depends on it,
+// directly or not.
+func ValidateBenchmarkDependencies(benchmarks []api.EvaluationBenchmarkConfig) error {
+	invalid := func(index, dependency int, reason string) error {
+		return serviceerrors.NewServiceError(
+			messages.InvalidBenchmarkDependency,
+			"BenchmarkID", benchmarks[index].ID,
+			"BenchmarkIndex", index,
+			"DependsOn", dependency,
+			"Reason", reason,
+		)
+	}
+	for i, benchmark := range benchmarks {
+		for _, dependency := range benchmark.DependsOn {
+			switch {
+			case dependency < 0 || dependency >= len(benchmarks):
+				return invalid(i, dependency, "the job has no benchmark at that index")
+			case dependency == i:
+				return invalid(i, dependency, "a benchmark cannot depend on itself")
+			}
+		}
+	}
+
+	// depth-first search for a cycle, marking the benchmarks being visited and visited
+	const visiting, visited = 1, 2
+	marks := make([]int, len(benchmarks))
+	var visit func(index int) error
+	visit = func(index int) error {
+		marks[index] = visiting
+		for _, dependency := range benchmarks[index].DependsOn {
+			switch marks[dependency] {
+			case visiting:
+				return invalid(index, dependency, "the dependencies form a cycle")
+			case 0:
+				if err := visit(dependency); err != nil {
+					return err
+				}
+			}
+		}
+		marks[index] = visited
+		return nil
+	}
+	for i := range benchmarks {
+		if marks[i] == 0 {
+			if err := visit(i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateSweep returns an error if the sweep of a job repeats a parameter, sets benchmark
+// parameters on a job that uses a collection, or has more configurations than a sweep may
+// run. The sweep must have passed the struct validation.
+func ValidateSweep(evaluation *api.EvaluationJobConfig) error {
+	sweep := evaluation.Sweep
+	if sweep == nil {
+		return nil
+	}
+	invalid := func(reason string, args ...any) error {
+		return serviceerrors.NewServiceError(messages.InvalidSweep, "Reason", fmt.Sprintf(reason, args...))
+	}
+	keys := make(map[string]bool, len(sweep.Parameters))
+	for _, parameter := range sweep.Parameters {
+		if keys[parameter.Key()] {
+			return invalid("the parameter %s is repeated", parameter.Key())
+		}
+		keys[parameter.Key()] = true
+		if parameter.Target == api.SweepTargetBenchmark && evaluation.Collection != nil {
+			return invalid("the benchmark parameter %s cannot be set on the benchmarks of a collection", parameter.Name)
+		}
+	}
+	size := sweep.GridSize(api.MaxSweepJobs)
+	switch sweep.Strategy {
+	case api.SweepStrategyGrid:
+		if size > api.MaxSweepJobs {
+			return invalid("the grid has more than %d configurations", api.MaxSweepJobs)
+		}
+	case api.SweepStrategyRandom:
+		if sweep.Samples > api.MaxSweepJobs {
+			return invalid("samples must not be more than %d", api.MaxSweepJobs)
+		}
+		if sweep.Samples > size {
+			return invalid("samples must not be more than the %d configurations of the parameters", size)
+		}
+	}
+	return nil
+}
+
+// ValidateWaitForModel returns an error if a job that waits for a model served by an
+// InferenceService does not give the URL to poll, which is only known to the runtime.
+func ValidateWaitForModel(evaluation *api.EvaluationJobConfig) error {
+	if evaluation.WaitForModel == nil || evaluation.Model.InferenceService == nil || evaluation.WaitForModel.URL != "" {
+		return nil
+	}
+	return serviceerrors.NewServiceError(messages.RequestValidationFailed, "Error", "wait_for_model.url is required when the model is an inference_service")
+}
+
+// ValidateStateAggregation returns an error if the quorum of a job is not set for the quorum
+// policy, is set for another policy, or is larger than the number of its benchmarks, or if
+// the job fails fast with another policy.
+func ValidateStateAggregation(evaluation *api.EvaluationJobConfig, benchmarks []api.EvaluationBenchmarkConfig) error {
+	aggregation := evaluation.StateAggregation
+	if aggregation == nil {
+		return nil
+	}
+	switch {
+	case evaluation.FailFast && aggregation.Policy != api.AggregationFailFast:
+		return serviceerrors.NewServiceError(messages.RequestValidationFailed, "Error", fmt.Sprintf("fail_fast is not allowed with the %s state_aggregation policy", aggregation.Policy))
+	case aggregation.Policy == api.AggregationQuorum && aggregation.Quorum == 0:
+		return serviceerrors.NewServiceError(messages.RequestValidationFailed, "Error", "state_aggregation.quorum is required for the quorum policy")
+	case aggregation.Policy != api.AggregationQuorum && aggregation.Quorum != 0:
+		return serviceerrors.NewServiceError(messages.RequestValidationFailed, "Error", "state_aggregation.quorum is only allowed for the quorum policy")
+	case aggregation.Quorum > len(benchmarks):
+		return serviceerrors.NewServiceError(messages.RequestValidationFailed, "Error", fmt.Sprintf("state_aggregation.quorum %d is larger than the %d benchmarks of the job", aggregation.Quorum, len(benchmarks)))
+	}
+	return nil
+}
+
+// ValidateSecretRefs returns an error if a parameter of a benchmark is a malformed secret
+// reference, so that the job fails when it is created rather than when it is started.
+func ValidateSecretRefs(benchmarks []api.EvaluationBenchmarkConfig) error {
+	for _, benchmark := range benchmarks {
+		if _, err := api.FindSecretRefs(benchmark.Parameters); err != nil {
+			return serviceerrors.NewServiceError(messages.RequestValidationFailed, "Error", fmt.Sprintf("benchmark %s parameter %v", benchmark.ID, err))
+		}
+	}
+	return nil
+}
+
 // validateTestDataRefMutualExclusion ensures exactly one of s3 or pvc is set.
 func validateTestDataRefMutualExclusion(sl validator.StructLevel) {
 	ref, ok := sl.Current().Interface().(api.TestDataRef)
@@ -116,6 +277,173 @@ func validateTestDataRefMutualExclusion(sl validator.StructLevel) {
 	}
 }
 
+// validateRAGConfig ensures that the embedding model is set when the adapter retrieves from a
+// corpus that it indexes itself, without a retrieval service.
+func validateRAGConfig(sl validator.StructLevel) {
+	rag, ok := sl.Current().Interface().(api.RAGConfig)
+	if !ok {
+		return
+	}
+	if rag.Retrieval.URL == "" && rag.EmbeddingModel == nil {
+		sl.ReportError(rag.EmbeddingModel, "embedding_model", "embedding_model", "rag_embedding_model", "the embedding model is required to index a corpus without a retrieval url")
+	}
+}
+
+// validateBenchmarkConversation ensures that a benchmark with a conversation does not set the
+// same chat settings in its parameters, which adapters would read differently.
+func validateBenchmarkConversation(sl validator.StructLevel) {
+	var conversation *api.ConversationConfig
+	var parameters map[string]any
+	switch benchmark := sl.Current().Interface().(type) {
+	case api.EvaluationBenchmarkConfig:
+		conversation, parameters = benchmark.Conversation, benchmark.Parameters
+	case api.CollectionBenchmarkConfig:
+		conversation, parameters = benchmark.Conversation, benchmark.Parameters
+	}
+	if conversation == nil {
+		return
+	}
+	for _, name := range api.ConversationParameters {
+		if _, ok := parameters[name]; ok {
+			sl.ReportError(parameters, "parameters", "parameters", "conversation_parameter",
+				fmt.Sprintf("the parameter '%s' is set by the conversation of the benchmark", name))
+			return
+		}
+	}
+}
+
+// validatePrimaryScoreExpression ensures that the expression of a composite primary score
+// parses.
+func validatePrimaryScoreExpression(sl validator.StructLevel) {
+	primaryScore, ok := sl.Current().Interface().(api.PrimaryScore)
+	if !ok || primaryScore.Expression == "" {
+		return
+	}
+	if _, err := scoring.Parse(primaryScore.Expression); err != nil {
+		sl.ReportError(primaryScore.Expression, "expression", "Expression", "primary_score_expression", err.Error())
+	}
+}
+
+// validatePodMetadata ensures that the keys of the pod metadata are valid Kubernetes label and
+// annotation keys outside the protected domains, and that its label values are valid.
+func validatePodMetadata(sl validator.StructLevel) {
+	podMetadata, ok := sl.Current().Interface().(api.PodMetadata)
+	if !ok {
+		return
+	}
+	invalid := func(field any, name string, reason string) {
+		sl.ReportError(field, name, name, "pod_metadata", reason)
+	}
+	for _, key := range slices.Sorted(maps.Keys(podMetadata.Labels)) {
+		if problems := k8svalidation.IsQualifiedName(key); len(problems) > 0 {
+			invalid(podMetadata.Labels, "labels", fmt.Sprintf("invalid label key '%s': %s", key, problems[0]))
+			return
+		}
+		if api.IsProtectedPodMetadataKey(key) {
+			invalid(podMetadata.Labels, "labels", fmt.Sprintf("the label key '%s' is reserved", key))
+			return
+		}
+		if problems := k8svalidation.IsValidLabelValue(podMetadata.Labels[key]); len(problems) > 0 {
+			invalid(podMetadata.Labels, "labels", fmt.Sprintf("invalid value of the label '%s': %s", key, problems[0]))
+			return
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(podMetadata.Annotations)) {
+		if problems := k8svalidation.IsQualifiedName(strings.ToLower(key)); len(problems) > 0 {
+			invalid(podMetadata.Annotations, "annotations", fmt.Sprintf("invalid annotation key '%s': %s", key, problems[0]))
+			return
+		}
+		if api.IsProtectedPodMetadataKey(key) {
+			invalid(podMetadata.Annotations, "annotations", fmt.Sprintf("the annotation key '%s' is reserved", key))
+			return
+		}
+	}
+}
+
+// validateSpotCheckpoint ensures that a provider runtime whose spot node pool checkpoints has a
+// data volume to write the checkpoints to, as the ephemeral storage goes with the node.
+func validateSpotCheckpoint(sl validator.StructLevel) {
+	runtime, ok := sl.Current().Interface().(api.K8sRuntime)
+	if !ok || runtime.Spot == nil || !runtime.Spot.Checkpoint {
+		return
+	}
+	if runtime.DataVolume == nil {
+		sl.ReportError(runtime.Spot, "spot", "Spot", "spot_checkpoint", "checkpoint needs a data_volume")
+	}
+}
+
+// validateProviderMetrics ensures that the names and aliases of the metrics of a provider are
+// unique, that their ranges are not empty, and that the primary scores of its benchmarks
+// refer to its metrics.
+func validateProviderMetrics(sl validator.StructLevel) {
+	provider, ok := sl.Current().Interface().(api.ProviderConfig)
+	if !ok || len(provider.Metrics) == 0 {
+		return
+	}
+	names := map[string]bool{}
+	for _, metric := range provider.Metrics {
+		for _, name := range metric.Names() {
+			if names[name] {
+				sl.ReportError(provider.Metrics, "metrics", "Metrics", "metric_unique", name)
+				return
+			}
+			names[name] = true
+		}
+		if metric.Min != nil && metric.Max != nil && *metric.Min > *metric.Max {
+			sl.ReportError(provider.Metrics, "metrics", "Metrics", "metric_range", metric.Name)
+			return
+		}
+	}
+	for _, benchmark := range provider.Benchmarks {
+		if reason := metricReferenceProblem(&provider, benchmark.PrimaryScore, benchmark.PassCriteria); reason != "" {
+			sl.ReportError(provider.Benchmarks, "benchmarks", "Benchmarks", "metric_reference", benchmark.ID)
+			return
+		}
+	}
+}
+
+// ValidateMetricReference returns an error if the primary score of a benchmark refers to
+// metrics that its provider does not declare, or the threshold of its pass criteria is outside
+// the range of the primary score. The benchmarks of providers that declare no metrics are not
+// checked.
+func ValidateMetricReference(provider *api.ProviderResource, benchmarkID string, primaryScore *api.PrimaryScore, passCriteria *api.PassCriteria) error {
+	if reason := metricReferenceProblem(&provider.ProviderConfig, primaryScore, passCriteria); reason != "" {
+		return serviceerrors.NewServiceError(
+			messages.InvalidMetricReference,
+			"BenchmarkID", benchmarkID,
+			"ProviderID", provider.Resource.ID,
+			"Reason", reason,
+		)
+	}
+	return nil
+}
+
+func metricReferenceProblem(provider *api.ProviderConfig, primaryScore *api.PrimaryScore, passCriteria *api.PassCriteria) string {
+	if len(provider.Metrics) == 0 || !primaryScore.IsSet() {
+		return ""
+	}
+	names, err := scoring.Metrics(primaryScore)
+	if err != nil {
+		return fmt.Sprintf("the expression of the primary score is invalid: %s", err.Error())
+	}
+	for _, name := range names {
+		if provider.GetMetric(name) == nil {
+			return fmt.Sprintf("the provider does not declare the metric '%s'", name)
+		}
+	}
+	if passCriteria == nil || passCriteria.Threshold == nil {
+		return ""
+	}
+	// the range of an expression is not known
+	if bounds := PrimaryScoreBounds(provider, primaryScore); !bounds.Contains(float64(*passCriteria.Threshold)) {
+		if metric := provider.GetMetric(primaryScore.Metric); metric != nil {
+			return fmt.Sprintf("the threshold %v is outside the range of the metric '%s'", *passCriteria.Threshold, metric.Name)
+		}
+		return fmt.Sprintf("the threshold %v is outside the range %s of the primary score", *passCriteria.Threshold, bounds)
+	}
+	return ""
+}
+
 // evaluationJobConfigBenchmarksMin ensures Benchmarks has at least one element when Collection is not present
 // and no benchmarks are provided when Collection is set.
 func evaluationJobConfigBenchmarksMin(sl validator.StructLevel) {
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/messages"
@@ -333,6 +334,40 @@ func TestEvaluationJobConfig_ExperimentOmittedOk(t *testing.T) {
 	}
 }
 
+func TestK8sRuntimeJobPolicy(t *testing.T) {
+	validate := newTestValidator(t)
+	providerWith := func(policy *api.JobPolicy) api.ProviderConfig {
+		return api.ProviderConfig{
+			Name: "test-provider",
+			Runtime: &api.Runtime{
+				K8s: &api.K8sRuntime{
+					Image:      "quay.io/example/adapter:latest",
+					Entrypoint: []string{"/bin/true"},
+					JobPolicy:  policy,
+				},
+			},
+			Benchmarks: []api.BenchmarkResource{{ID: "bench-1", Name: "Bench 1"}},
+		}
+	}
+	day, zero, negative, many := int32(86400), int32(0), int32(-1), int32(100)
+
+	if err := validate.Struct(providerWith(&api.JobPolicy{TTLSecondsAfterFinished: &day, BackoffLimit: &zero, RestartPolicy: api.JobRestartPolicyOnFailure})); err != nil {
+		t.Fatalf("expected a valid job policy, got %v", err)
+	}
+	invalid := map[string]*api.JobPolicy{
+		"ttl_seconds_after_finished": {TTLSecondsAfterFinished: &negative},
+		"backoff_limit":              {BackoffLimit: &many},
+		"restart_policy":             {RestartPolicy: "Always"},
+	}
+	for field, policy := range invalid {
+		err := validate.Struct(providerWith(policy))
+		valErr, ok := err.(validator.ValidationErrors)
+		if !ok || len(valErr) == 0 || valErr[0].Field() != field {
+			t.Errorf("expected a validation error on %s, got %v", field, err)
+		}
+	}
+}
+
 func TestK8sRuntimeImagePullPolicy_InvalidValueRejected(t *testing.T) {
 	validate := newTestValidator(t)
 	cfg := api.ProviderConfig{
@@ -379,6 +414,61 @@ func TestK8sRuntimeImagePullPolicy_ValidValuesAccepted(t *testing.T) {
 	}
 }
 
+func TestPodMetadata(t *testing.T) {
+	validate := newTestValidator(t)
+	valid := []api.PodMetadata{
+		{},
+		{Labels: map[string]string{"cost-center": "ml-platform", "example.com/team": "eval"}},
+		{Annotations: map[string]string{"sidecar.istio.io/inject": "false", "Cost": "any value, with spaces"}},
+	}
+	for _, podMetadata := range valid {
+		if err := validate.Struct(podMetadata); err != nil {
+			t.Errorf("%+v: expected no error, got: %v", podMetadata, err)
+		}
+	}
+	invalid := []api.PodMetadata{
+		{Labels: map[string]string{"bad key": "value"}},
+		{Labels: map[string]string{"team": "not a label value"}},
+		{Labels: map[string]string{"kueue.x-k8s.io/queue-name": "other"}},
+		{Labels: map[string]string{"app.kubernetes.io/name": "other"}},
+		{Annotations: map[string]string{"eval-hub.github.io/job_id": "spoofed"}},
+		{Annotations: map[string]string{"batch.kubernetes.io/job-tracking": ""}},
+	}
+	for _, podMetadata := range invalid {
+		err := validate.Struct(podMetadata)
+		valErr, ok := err.(validator.ValidationErrors)
+		if !ok || len(valErr) == 0 || valErr[0].Tag() != "pod_metadata" {
+			t.Errorf("%+v: expected a pod_metadata error, got: %v", podMetadata, err)
+		}
+	}
+}
+
+func TestSpotCheckpoint(t *testing.T) {
+	validate := newTestValidator(t)
+	spot := &api.SpotConfig{
+		NodeSelector: map[string]string{"cloud.google.com/gke-spot": "true"},
+		Tolerations:  []api.Toleration{{Key: "cloud.google.com/gke-spot", Operator: "Equal", Value: "true", Effect: "NoSchedule"}},
+		Checkpoint:   true,
+	}
+	if err := validate.Struct(api.K8sRuntime{Image: "adapter", Spot: spot, DataVolume: &api.DataVolumeConfig{Size: "50Gi"}}); err != nil {
+		t.Errorf("expected no error, got: %v", err)
+	}
+	err := validate.Struct(api.K8sRuntime{Image: "adapter", Spot: spot})
+	valErr, ok := err.(validator.ValidationErrors)
+	if !ok || len(valErr) == 0 || valErr[0].Tag() != "spot_checkpoint" {
+		t.Errorf("expected a spot_checkpoint error without a data volume, got: %v", err)
+	}
+	withoutCheckpoint := *spot
+	withoutCheckpoint.Checkpoint = false
+	if err := validate.Struct(api.K8sRuntime{Image: "adapter", Spot: &withoutCheckpoint}); err != nil {
+		t.Errorf("expected no error without checkpoint, got: %v", err)
+	}
+	withoutCheckpoint.Tolerations = []api.Toleration{{Key: "spot", Operator: "Matches"}}
+	if err := validate.Struct(api.K8sRuntime{Image: "adapter", Spot: &withoutCheckpoint}); err == nil {
+		t.Errorf("expected an error for an unknown toleration operator")
+	}
+}
+
 func TestValidateCollectionOverrides_InvalidProviderID(t *testing.T) {
 	t.Parallel()
 	overrides := []api.EvaluationBenchmarkConfig{
@@ -435,6 +525,207 @@ func TestValidateCollectionOverrides_EmptyOverrides(t *testing.T) {
 	}
 }
 
+func TestValidateCollectionOverrides_DependenciesRejected(t *testing.T) {
+	t.Parallel()
+	overrides := []api.EvaluationBenchmarkConfig{
+		{Ref: api.Ref{ID: "b1"}, ProviderID: "p1", DependsOn: []int{1}},
+	}
+	collectionBenchmarks := []api.CollectionBenchmarkConfig{
+		{Ref: api.Ref{ID: "b1"}, ProviderID: "p1"},
+		{Ref: api.Ref{ID: "b2"}, ProviderID: "p1"},
+	}
+	err := ValidateCollectionOverrides(overrides, collectionBenchmarks)
+	var se *serviceerrors.ServiceError
+	if !errors.As(err, &se) || se.MessageCode() != messages.InvalidBenchmarkDependency {
+		t.Fatalf("err = %v, want InvalidBenchmarkDependency service error", err)
+	}
+}
+
+func TestValidateBenchmarkDependencies(t *testing.T) {
+	t.Parallel()
+	benchmarks := func(dependsOn ...[]int) []api.EvaluationBenchmarkConfig {
+		result := make([]api.EvaluationBenchmarkConfig, len(dependsOn))
+		for i := range dependsOn {
+			result[i] = api.EvaluationBenchmarkConfig{Ref: api.Ref{ID: "b"}, ProviderID: "p", DependsOn: dependsOn[i]}
+		}
+		return result
+	}
+
+	valid := map[string][]api.EvaluationBenchmarkConfig{
+		"no dependencies": benchmarks(nil, nil),
+		"pipeline":        benchmarks(nil, []int{0}, []int{1}),
+		"fan in":          benchmarks(nil, nil, []int{0, 1}),
+		"forward":         benchmarks([]int{1}, nil),
+	}
+	for name, benchmarks := range valid {
+		if err := ValidateBenchmarkDependencies(benchmarks); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+
+	invalid := map[string][]api.EvaluationBenchmarkConfig{
+		"out of range": benchmarks(nil, []int{2}),
+		"self":         benchmarks([]int{0}),
+		"cycle":        benchmarks([]int{2}, []int{0}, []int{1}),
+	}
+	for name, benchmarks := range invalid {
+		err := ValidateBenchmarkDependencies(benchmarks)
+		var se *serviceerrors.ServiceError
+		if !errors.As(err, &se) || se.MessageCode() != messages.InvalidBenchmarkDependency {
+			t.Errorf("%s: err = %v, want InvalidBenchmarkDependency service error", name, err)
+		}
+	}
+}
+
+func TestValidateSweep(t *testing.T) {
+	t.Parallel()
+	values := func(n int) []any {
+		result := make([]any, n)
+		for i := range result {
+			result[i] = i
+		}
+		return result
+	}
+	job := func(sweep api.SweepConfig) *api.EvaluationJobConfig {
+		return &api.EvaluationJobConfig{Sweep: &sweep}
+	}
+
+	valid := map[string]*api.EvaluationJobConfig{
+		"no sweep": {},
+		"grid": job(api.SweepConfig{Strategy: api.SweepStrategyGrid, Parameters: []api.SweepParameter{
+			{Target: api.SweepTargetModel, Name: "temperature", Values: values(10)},
+			{Target: api.SweepTargetBenchmark, Name: "prompt_template", Values: values(10)},
+		}}),
+		"random over a large grid": job(api.SweepConfig{Strategy: api.SweepStrategyRandom, Samples: 20, Parameters: []api.SweepParameter{
+			{Target: api.SweepTargetModel, Name: "temperature", Values: values(100)},
+			{Target: api.SweepTargetModel, Name: "top_p", Values: values(100)},
+		}}),
+	}
+	for name, evaluation := range valid {
+		if err := ValidateSweep(evaluation); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+
+	withCollection := job(api.SweepConfig{Strategy: api.SweepStrategyGrid, Parameters: []api.SweepParameter{
+		{Target: api.SweepTargetBenchmark, Name: "prompt_template", Values: values(2)},
+	}})
+	withCollection.Collection = &api.CollectionRef{ID: "collection"}
+	invalid := map[string]*api.EvaluationJobConfig{
+		"grid too large": job(api.SweepConfig{Strategy: api.SweepStrategyGrid, Parameters: []api.SweepParameter{
+			{Target: api.SweepTargetModel, Name: "temperature", Values: values(11)},
+			{Target: api.SweepTargetModel, Name: "top_p", Values: values(10)},
+		}}),
+		"repeated parameter": job(api.SweepConfig{Strategy: api.SweepStrategyGrid, Parameters: []api.SweepParameter{
+			{Target: api.SweepTargetModel, Name: "temperature", Values: values(2)},
+			{Target: api.SweepTargetModel, Name: "temperature", Values: values(2)},
+		}}),
+		"more samples than configurations": job(api.SweepConfig{Strategy: api.SweepStrategyRandom, Samples: 5, Parameters: []api.SweepParameter{
+			{Target: api.SweepTargetModel, Name: "temperature", Values: values(4)},
+		}}),
+		"too many samples": job(api.SweepConfig{Strategy: api.SweepStrategyRandom, Samples: 101, Parameters: []api.SweepParameter{
+			{Target: api.SweepTargetModel, Name: "temperature", Values: values(100)},
+			{Target: api.SweepTargetModel, Name: "top_p", Values: values(100)},
+		}}),
+		"benchmark parameter on a collection": withCollection,
+	}
+	for name, evaluation := range invalid {
+		err := ValidateSweep(evaluation)
+		var se *serviceerrors.ServiceError
+		if !errors.As(err, &se) || se.MessageCode() != messages.InvalidSweep {
+			t.Errorf("%s: err = %v, want InvalidSweep service error", name, err)
+		}
+	}
+}
+
+func TestModelRef_URLOrInferenceService(t *testing.T) {
+	validate := newTestValidator(t)
+	job := func(model api.ModelRef) api.EvaluationJobConfig {
+		return api.EvaluationJobConfig{
+			Name:  "test-job",
+			Model: model,
+			Benchmarks: []api.EvaluationBenchmarkConfig{
+				{Ref: api.Ref{ID: "b1"}, ProviderID: "provider-1"},
+			},
+		}
+	}
+	valid := map[string]api.ModelRef{
+		"url":               {URL: "http://test.com", Name: "model"},
+		"inference service": {Name: "model", InferenceService: &api.InferenceServiceRef{Name: "granite", Namespace: "models", AuthTokenSecret: "granite-sa.token"}},
+	}
+	for name, model := range valid {
+		if err := validate.Struct(job(model)); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+	invalid := map[string]api.ModelRef{
+		"neither":                        {Name: "model"},
+		"both":                           {URL: "http://test.com", Name: "model", InferenceService: &api.InferenceServiceRef{Name: "granite"}},
+		"inference service without name": {Name: "model", InferenceService: &api.InferenceServiceRef{}},
+		"invalid inference service":      {Name: "model", InferenceService: &api.InferenceServiceRef{Name: "Granite_3"}},
+		"invalid inference service ns":   {Name: "model", InferenceService: &api.InferenceServiceRef{Name: "granite", Namespace: "my_models"}},
+	}
+	for name, model := range invalid {
+		if err := validate.Struct(job(model)); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+}
+
+func TestValidateWaitForModel(t *testing.T) {
+	isvc := api.ModelRef{Name: "model", InferenceService: &api.InferenceServiceRef{Name: "granite"}}
+	valid := map[string]*api.EvaluationJobConfig{
+		"no wait":                           {Model: isvc},
+		"url model":                         {Model: api.ModelRef{URL: "http://test.com", Name: "model"}, WaitForModel: &api.WaitForModel{}},
+		"inference service with a wait url": {Model: isvc, WaitForModel: &api.WaitForModel{URL: "http://granite-predictor.models.svc"}},
+	}
+	for name, evaluation := range valid {
+		if err := ValidateWaitForModel(evaluation); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+	err := ValidateWaitForModel(&api.EvaluationJobConfig{Model: isvc, WaitForModel: &api.WaitForModel{ReadinessPath: "/health"}})
+	var se *serviceerrors.ServiceError
+	if !errors.As(err, &se) || se.MessageCode() != messages.RequestValidationFailed {
+		t.Errorf("err = %v, want RequestValidationFailed service error", err)
+	}
+}
+
+func TestValidateStateAggregation(t *testing.T) {
+	benchmarks := []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "mmlu"}}, {Ref: api.Ref{ID: "arc"}}, {Ref: api.Ref{ID: "hellaswag"}}}
+	valid := map[string]*api.StateAggregation{
+		"default":     nil,
+		"best effort": {Policy: api.AggregationBestEffort},
+		"fail fast":   {Policy: api.AggregationFailFast},
+		"quorum":      {Policy: api.AggregationQuorum, Quorum: 3},
+	}
+	for name, aggregation := range valid {
+		if err := ValidateStateAggregation(&api.EvaluationJobConfig{StateAggregation: aggregation}, benchmarks); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+	invalid := map[string]*api.StateAggregation{
+		"quorum without a quorum":     {Policy: api.AggregationQuorum},
+		"quorum of another policy":    {Policy: api.AggregationFailFast, Quorum: 1},
+		"quorum above the benchmarks": {Policy: api.AggregationQuorum, Quorum: 4},
+	}
+	for name, aggregation := range invalid {
+		err := ValidateStateAggregation(&api.EvaluationJobConfig{StateAggregation: aggregation}, benchmarks)
+		var se *serviceerrors.ServiceError
+		if !errors.As(err, &se) || se.MessageCode() != messages.RequestValidationFailed {
+			t.Errorf("%s: err = %v, want RequestValidationFailed service error", name, err)
+		}
+	}
+	if err := ValidateStateAggregation(&api.EvaluationJobConfig{FailFast: true, StateAggregation: valid["fail fast"]}, benchmarks); err != nil {
+		t.Errorf("fail_fast with the fail fast policy: expected no error, got %v", err)
+	}
+	err := ValidateStateAggregation(&api.EvaluationJobConfig{FailFast: true, StateAggregation: valid["quorum"]}, benchmarks)
+	var se *serviceerrors.ServiceError
+	if !errors.As(err, &se) || se.MessageCode() != messages.RequestValidationFailed {
+		t.Errorf("fail_fast with the quorum policy: err = %v, want RequestValidationFailed service error", err)
+	}
+}
+
 func TestTestDataRef_BothS3AndPVCRejected(t *testing.T) {
 	validate := newTestValidator(t)
 	ref := api.TestDataRef{
@@ -497,3 +788,178 @@ func TestPVCTestDataRef_ValidClaimNameAccepted(t *testing.T) {
 		}
 	}
 }
+
+func TestProviderMetrics(t *testing.T) {
+	validate := newTestValidator(t)
+	zero, one, two := 0.0, 1.0, 2.0
+	threshold := float32(0.5)
+	provider := func(metrics []api.MetricDefinition, primaryScore string) api.ProviderConfig {
+		return api.ProviderConfig{
+			Name:    "p",
+			Metrics: metrics,
+			Benchmarks: []api.BenchmarkResource{{
+				ID:           "b",
+				PrimaryScore: &api.PrimaryScore{Metric: primaryScore},
+				PassCriteria: &api.PassCriteria{Threshold: &threshold},
+			}},
+		}
+	}
+	accuracy := api.MetricDefinition{Name: "accuracy", Min: &zero, Max: &one, Aliases: []string{"acc"}}
+
+	valid := map[string]api.ProviderConfig{
+		"no metrics":       provider(nil, "anything"),
+		"by name":          provider([]api.MetricDefinition{accuracy}, "accuracy"),
+		"by alias":         provider([]api.MetricDefinition{accuracy}, "acc"),
+		"lower is better":  provider([]api.MetricDefinition{{Name: "loss", Direction: api.MetricDirectionLowerIsBetter}}, "loss"),
+		"open range above": provider([]api.MetricDefinition{{Name: "accuracy", Min: &zero}}, "accuracy"),
+	}
+	for name, config := range valid {
+		if err := validate.Struct(config); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+
+	invalid := map[string]api.ProviderConfig{
+		"undeclared metric":   provider([]api.MetricDefinition{accuracy}, "f1"),
+		"duplicate alias":     provider([]api.MetricDefinition{accuracy, {Name: "exact_match", Aliases: []string{"acc"}}}, "accuracy"),
+		"empty range":         provider([]api.MetricDefinition{{Name: "accuracy", Min: &two, Max: &one}}, "accuracy"),
+		"threshold out":       provider([]api.MetricDefinition{{Name: "accuracy", Min: &one, Max: &two}}, "accuracy"),
+		"unknown direction":   provider([]api.MetricDefinition{{Name: "accuracy", Direction: "up"}}, "accuracy"),
+		"metric without name": provider([]api.MetricDefinition{{Aliases: []string{"acc"}}}, "acc"),
+	}
+	for name, config := range invalid {
+		if err := validate.Struct(config); err == nil {
+			t.Errorf("%s: expected a validation error", name)
+		}
+	}
+}
+
+func TestValidateMetricReference(t *testing.T) {
+	t.Parallel()
+	one := 1.0
+	provider := &api.ProviderResource{
+		Resource:       api.Resource{ID: "p"},
+		ProviderConfig: api.ProviderConfig{Metrics: []api.MetricDefinition{{Name: "accuracy", Max: &one, Aliases: []string{"acc"}}}},
+	}
+	inRange, outOfRange := float32(0.9), float32(90)
+
+	if err := ValidateMetricReference(provider, "b", &api.PrimaryScore{Metric: "acc"}, &api.PassCriteria{Threshold: &inRange}); err != nil {
+		t.Errorf("expected an alias within range to be valid, got %v", err)
+	}
+	// the threshold of a composite primary score is not checked against the range of a metric
+	if err := ValidateMetricReference(provider, "b", &api.PrimaryScore{Expression: "100 * acc"}, &api.PassCriteria{Threshold: &outOfRange}); err != nil {
+		t.Errorf("expected an expression of declared metrics to be valid, got %v", err)
+	}
+	if err := ValidateMetricReference(provider, "b", nil, nil); err != nil {
+		t.Errorf("expected a benchmark without primary score to be valid, got %v", err)
+	}
+	for name, err := range map[string]error{
+		"undeclared":   ValidateMetricReference(provider, "b", &api.PrimaryScore{Metric: "f1"}, nil),
+		"out of range": ValidateMetricReference(provider, "b", &api.PrimaryScore{Metric: "accuracy"}, &api.PassCriteria{Threshold: &outOfRange}),
+		"weighted":     ValidateMetricReference(provider, "b", &api.PrimaryScore{Metrics: []api.WeightedMetric{{Metric: "acc"}, {Metric: "f1"}}}, nil),
+		"expression":   ValidateMetricReference(provider, "b", &api.PrimaryScore{Expression: "0.5*accuracy + 0.5*f1"}, nil),
+	} {
+		var se *serviceerrors.ServiceError
+		if !errors.As(err, &se) || se.MessageCode() != messages.InvalidMetricReference {
+			t.Errorf("%s: err = %v, want InvalidMetricReference service error", name, err)
+		}
+	}
+}
+
+func TestPrimaryScore(t *testing.T) {
+	validate := newTestValidator(t)
+	valid := map[string]api.PrimaryScore{
+		"metric":     {Metric: "acc"},
+		"metrics":    {Metrics: []api.WeightedMetric{{Metric: "acc", Weight: 0.5}, {Metric: "f1"}}},
+		"expression": {Expression: "0.5*acc + 0.5*(1 - 'word-error-rate')", LowerIsBetter: true},
+	}
+	for name, primaryScore := range valid {
+		if err := validate.Struct(primaryScore); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+	invalid := map[string]api.PrimaryScore{
+		"none":               {LowerIsBetter: true},
+		"metric and metrics": {Metric: "acc", Metrics: []api.WeightedMetric{{Metric: "f1"}}},
+		"metrics and expr":   {Metrics: []api.WeightedMetric{{Metric: "f1"}}, Expression: "acc"},
+		"negative weight":    {Metrics: []api.WeightedMetric{{Metric: "f1", Weight: -1}}},
+		"unparsable":         {Expression: "0.5*acc +"},
+	}
+	for name, primaryScore := range invalid {
+		if err := validate.Struct(primaryScore); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestBenchmarkConversation(t *testing.T) {
+	validate := newTestValidator(t)
+	benchmark := func(conversation *api.ConversationConfig, parameters map[string]any) api.EvaluationBenchmarkConfig {
+		return api.EvaluationBenchmarkConfig{
+			Ref:          api.Ref{ID: "mt_bench"},
+			ProviderID:   "lm_evaluation_harness",
+			Conversation: conversation,
+			Parameters:   parameters,
+		}
+	}
+	valid := map[string]api.EvaluationBenchmarkConfig{
+		"no conversation":      benchmark(nil, map[string]any{"system_instruction": "Be brief."}),
+		"system prompt":        benchmark(&api.ConversationConfig{SystemPrompt: "Be brief.", ChatTemplate: "chatml"}, map[string]any{"num_fewshot": 5}),
+		"multi-turn":           benchmark(&api.ConversationConfig{MultiTurn: true, MaxTurns: 8, FewshotAsMultiturn: true}, nil),
+		"multi-turn, no limit": benchmark(&api.ConversationConfig{MultiTurn: true}, nil),
+	}
+	for name, b := range valid {
+		if err := validate.Struct(b); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+	invalid := map[string]api.EvaluationBenchmarkConfig{
+		"max turns without multi-turn": benchmark(&api.ConversationConfig{MaxTurns: 8}, nil),
+		"too many turns":               benchmark(&api.ConversationConfig{MultiTurn: true, MaxTurns: 101}, nil),
+		"parameter set twice":          benchmark(&api.ConversationConfig{SystemPrompt: "Be brief."}, map[string]any{"apply_chat_template": true}),
+	}
+	for name, b := range invalid {
+		if err := validate.Struct(b); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	collectionBenchmark := api.CollectionBenchmarkConfig{
+		Ref:          api.Ref{ID: "mt_bench"},
+		ProviderID:   "lm_evaluation_harness",
+		Conversation: &api.ConversationConfig{ChatTemplate: "chatml"},
+		Parameters:   map[string]any{"chat_template": "llama-3"},
+	}
+	if err := validate.Struct(collectionBenchmark); err == nil || !strings.Contains(err.Error(), "conversation_parameter") {
+		t.Errorf("expected the chat_template parameter of a collection benchmark to be rejected, got %v", err)
+	}
+}
+
+func TestRAGConfig(t *testing.T) {
+	validate := newTestValidator(t)
+	embeddings := &api.EndpointModelRef{URL: "http://embeddings:8080", Name: "bge-m3"}
+	judge := &api.JudgeConfig{Model: api.EndpointModelRef{URL: "http://judge:8000", Name: "llama-3-70b"}, Metrics: []string{"faithfulness", "answer_relevancy"}}
+	valid := map[string]api.RAGConfig{
+		"retrieval service": {Retrieval: api.RetrievalConfig{URL: "http://retriever:8000", Corpus: "product-docs", TopK: 5}, Judge: judge},
+		"indexed corpus":    {Retrieval: api.RetrievalConfig{Corpus: "s3://docs/product/"}, EmbeddingModel: embeddings},
+	}
+	for name, rag := range valid {
+		if err := validate.Struct(rag); err != nil {
+			t.Errorf("%s: expected no error, got %v", name, err)
+		}
+	}
+	invalid := map[string]api.RAGConfig{
+		"no source":                   {EmbeddingModel: embeddings},
+		"corpus without embeddings":   {Retrieval: api.RetrievalConfig{Corpus: "s3://docs/product/"}},
+		"invalid url":                 {Retrieval: api.RetrievalConfig{URL: "retriever:8000"}},
+		"top k":                       {Retrieval: api.RetrievalConfig{URL: "http://retriever:8000", TopK: 1001}},
+		"judge without model":         {Retrieval: api.RetrievalConfig{URL: "http://retriever:8000"}, Judge: &api.JudgeConfig{Metrics: []string{"faithfulness"}}},
+		"embedding model without url": {Retrieval: api.RetrievalConfig{URL: "http://retriever:8000"}, EmbeddingModel: &api.EndpointModelRef{Name: "bge-m3"}},
+		"empty judge metric":          {Retrieval: api.RetrievalConfig{URL: "http://retriever:8000"}, Judge: &api.JudgeConfig{Model: judge.Model, Metrics: []string{""}}},
+	}
+	for name, rag := range invalid {
+		if err := validate.Struct(rag); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
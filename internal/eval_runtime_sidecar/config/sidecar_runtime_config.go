@@ -58,5 +58,9 @@ func LoadSidecarRuntimeConfig(sidecarJSONPath, version, build, buildDate string)
 		cfg.OTEL = sc.OTEL
 	}
 
+	if sc.Proxy != nil {
+		cfg.Proxy = sc.Proxy
+	}
+
 	return cfg, nil
 }
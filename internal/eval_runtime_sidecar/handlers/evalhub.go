@@ -5,8 +5,10 @@ import (
 	"log/slog"
 	"net/http/httputil"
 	"net/url"
+	"os"
 	"strings"
 
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
 	"github.com/eval-hub/eval-hub/internal/eval_runtime_sidecar/proxy"
 )
@@ -33,5 +35,27 @@ func newEvalhubProxy(config *config.Config, logger *slog.Logger) (*httputil.Reve
 		return nil, fmt.Errorf("invalid eval_hub.base_url: %w", err)
 	}
 	evalHubProxy := proxy.NewReverseProxy(evalHubTarget, evalHubHTTPClient, logger, nil)
+	if callbackToken := loadCallbackToken(logger); callbackToken != "" {
+		rewrite := evalHubProxy.Rewrite
+		evalHubProxy.Rewrite = func(pr *httputil.ProxyRequest) {
+			rewrite(pr)
+			pr.Out.Header.Set(callbackauth.TokenHeader, callbackToken)
+		}
+	}
 	return evalHubProxy, nil
 }
+
+// loadCallbackToken returns the callback token of the job spec, sent with every request to eval-hub
+// so that it accepts the status events of the job. Empty when eval-hub does not require one.
+func loadCallbackToken(logger *slog.Logger) string {
+	jobSpecPath := os.Getenv("JOB_SPEC_PATH")
+	if jobSpecPath == "" {
+		jobSpecPath = JobSpecPathDefault
+	}
+	token, err := proxy.GetCallbackTokenFromJobSpec(jobSpecPath)
+	if err != nil {
+		logger.Debug("callback token not set: could not read job spec", "path", jobSpecPath, "error", err)
+		return ""
+	}
+	return token
+}
@@ -4,9 +4,12 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/eval-hub/eval-hub/internal/eval_hub/callbackauth"
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
 )
 
@@ -210,6 +213,42 @@ func TestHandlers_HandleProxyCall(t *testing.T) {
 	})
 }
 
+func TestNewEvalhubProxy_CallbackToken(t *testing.T) {
+	var gotToken string
+	evalHub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get(callbackauth.TokenHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer evalHub.Close()
+
+	jobSpecPath := filepath.Join(t.TempDir(), "job.json")
+	if err := os.WriteFile(jobSpecPath, []byte(`{"callback_token":"job-token"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("JOB_SPEC_PATH", jobSpecPath)
+
+	cfg := &config.Config{
+		Sidecar: &config.SidecarConfig{
+			EvalHub: &config.EvalHubClientConfig{BaseURL: evalHub.URL, InsecureSkipVerify: true},
+		},
+	}
+	evalHubProxy, err := newEvalhubProxy(cfg, slog.Default())
+	if err != nil {
+		t.Fatalf("newEvalhubProxy() error: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/evaluations/jobs/job-1/events", strings.NewReader("{}"))
+	req.Header.Set(callbackauth.TokenHeader, "forged")
+	rw := httptest.NewRecorder()
+	evalHubProxy.ServeHTTP(rw, req)
+	if rw.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", rw.Code)
+	}
+	if gotToken != "job-token" {
+		t.Errorf("callback token = %q, want the token of the job spec", gotToken)
+	}
+}
+
 func TestOciRouteMatch(t *testing.T) {
 	h := &Handlers{ociRepository: "org/repo"}
 	tests := []struct {
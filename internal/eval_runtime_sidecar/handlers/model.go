@@ -39,8 +39,13 @@ func newModelProxy(config *config.Config, logger *slog.Logger) (*httputil.Revers
 	}
 
 	secretMountPath := strings.TrimSpace(mc.AuthSecretMountPath)
+	// the serving auth token of an InferenceService replaces the service account token
+	tokenPath := ServiceAccountTokenPathDefault
+	if authTokenPath := strings.TrimSpace(mc.AuthTokenPath); authTokenPath != "" {
+		tokenPath = authTokenPath
+	}
 
-	rp := proxy.NewModelReverseProxy(target, modelHTTPClient, logger, secretMountPath, ServiceAccountTokenPathDefault)
+	rp := proxy.NewModelReverseProxy(target, modelHTTPClient, logger, secretMountPath, tokenPath)
 	logger.Info("Model proxy enabled", "url", targetURL)
 	return rp, nil
 }
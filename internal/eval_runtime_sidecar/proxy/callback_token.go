@@ -0,0 +1,24 @@
+package proxy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/runtimes/shared"
+)
+
+// GetCallbackTokenFromJobSpec reads the job spec at path and returns its callback token.
+// Returns an empty token when eval-hub does not require callback tokens.
+func GetCallbackTokenFromJobSpec(path string) (string, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- job spec path from sidecar configuration
+	if err != nil {
+		return "", fmt.Errorf("read job spec: %w", err)
+	}
+	var spec shared.JobSpec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return "", fmt.Errorf("parse job spec: %w", err)
+	}
+	return strings.TrimSpace(spec.CallbackToken), nil
+}
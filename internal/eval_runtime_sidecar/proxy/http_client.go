@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"time"
 
@@ -17,10 +18,10 @@ const DefaultCACertPath = "/etc/pki/ca-trust/source/anchors/service-ca.crt"
 const DefaultInsecureSkipVerify = false
 const DefaultHTTPTimeout = 30 * time.Second
 
-// NewHTTPClient creates an HTTP client with the given timeout and TLS config.
-// If isOTELEnabled is true, the transport is wrapped with OTEL instrumentation.
-func newHTTPClient(timeout time.Duration, tlsConfig *tls.Config, isOTELEnabled bool, logger *slog.Logger, transportLabel string) *http.Client {
-	transport := &http.Transport{}
+// NewHTTPClient creates an HTTP client with the given timeout, TLS config and proxy (nil
+// connects directly). If isOTELEnabled is true, the transport is wrapped with OTEL instrumentation.
+func newHTTPClient(timeout time.Duration, tlsConfig *tls.Config, proxy func(*http.Request) (*url.URL, error), isOTELEnabled bool, logger *slog.Logger, transportLabel string) *http.Client {
+	transport := &http.Transport{Proxy: proxy}
 	if tlsConfig != nil {
 		transport.TLSClientConfig = tlsConfig
 	}
@@ -98,9 +99,17 @@ func NewEvalHubHTTPClient(config *config.Config, isOTELEnabled bool, logger *slo
 		if err != nil {
 			return nil, err
 		}
+		if cfg.ClientCertPath != "" || cfg.ClientKeyPath != "" {
+			clientCert, err := tls.LoadX509KeyPair(cfg.ClientCertPath, cfg.ClientKeyPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load EvalHub client certificate at %s: %w", cfg.ClientCertPath, err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{clientCert}
+			logger.Info("Loaded client certificate", "label", "EvalHub", "path", cfg.ClientCertPath)
+		}
 	}
 
-	client := newHTTPClient(timeout, tlsConfig, isOTELEnabled, logger, "EvalHub")
+	client := newHTTPClient(timeout, tlsConfig, nil, isOTELEnabled, logger, "EvalHub")
 	return client, nil
 }
 
@@ -124,7 +133,7 @@ func NewMLFlowHTTPClient(serviceConfig *config.Config, isOTELEnabled bool, logge
 		return nil, err
 	}
 
-	client := newHTTPClient(timeout, tlsConfig, isOTELEnabled, logger, "MLflow")
+	client := newHTTPClient(timeout, tlsConfig, serviceConfig.Proxy.ProxyFunc(config.ProxyDestinationMLFlow), isOTELEnabled, logger, "MLflow")
 	return client, nil
 }
 
@@ -159,7 +168,7 @@ func NewModelHTTPClient(serviceConfig *config.Config, isOTELEnabled bool, logger
 	if err != nil {
 		return nil, err
 	}
-	client := newHTTPClient(timeout, tlsConfig, isOTELEnabled, logger, "Model")
+	client := newHTTPClient(timeout, tlsConfig, serviceConfig.Proxy.ProxyFunc(config.ProxyDestinationModel), isOTELEnabled, logger, "Model")
 	return client, nil
 }
 
@@ -185,6 +194,6 @@ func NewOCIHTTPClient(serviceConfig *config.Config, isOTELEnabled bool, logger *
 	if err != nil {
 		return nil, err
 	}
-	client := newHTTPClient(timeout, tlsConfig, isOTELEnabled, logger, "OCI")
+	client := newHTTPClient(timeout, tlsConfig, serviceConfig.Proxy.ProxyFunc(config.ProxyDestinationOCI), isOTELEnabled, logger, "OCI")
 	return client, nil
 }
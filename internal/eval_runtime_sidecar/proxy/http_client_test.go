@@ -50,6 +50,21 @@ func TestNewEvalHubHTTPClient(t *testing.T) {
 			t.Error("expected non-zero timeout")
 		}
 	})
+
+	t.Run("returns error when client certificate cannot be loaded", func(t *testing.T) {
+		cfg := &config.Config{
+			Sidecar: &config.SidecarConfig{
+				EvalHub: &config.EvalHubClientConfig{
+					InsecureSkipVerify: true,
+					ClientCertPath:     "/nonexistent/tls.crt",
+					ClientKeyPath:      "/nonexistent/tls.key",
+				},
+			},
+		}
+		if _, err := NewEvalHubHTTPClient(cfg, false, logger); err == nil {
+			t.Fatal("expected error for a missing client certificate")
+		}
+	})
 }
 
 func TestNewMLFlowHTTPClient(t *testing.T) {
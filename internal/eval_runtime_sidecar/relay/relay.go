@@ -0,0 +1,202 @@
+package relay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+)
+
+const (
+	defaultPollInterval = 2 * time.Second
+	offsetFileSuffix    = ".offset"
+)
+
+// Relay posts the status events that an adapter appends to its results file to the events
+// endpoint of the job, for adapters that cannot make HTTP calls. The events are posted to the
+// sidecar itself, so that they reach eval-hub through its eval-hub proxy and its credentials.
+// The offset of the events relayed so far is kept next to the results file, so that a
+// restarted sidecar does not post them again. Each event is posted with an event ID and a
+// sequence from its position in the file, so that eval-hub ignores an event posted again
+// after a failure that it had applied.
+type Relay struct {
+	logger     *slog.Logger
+	path       string
+	source     string
+	eventsURL  string
+	interval   time.Duration
+	httpClient *http.Client
+	offset     int64
+}
+
+// New creates the relay of the results file of the config to the sidecar at sidecarURL.
+func New(logger *slog.Logger, cfg *config.SidecarResultRelayConfig, sidecarURL string, httpClient *http.Client) (*Relay, error) {
+	if cfg == nil || strings.TrimSpace(cfg.Path) == "" {
+		return nil, fmt.Errorf("result relay path is required")
+	}
+	if strings.TrimSpace(cfg.JobID) == "" {
+		return nil, fmt.Errorf("result relay job id is required")
+	}
+	interval := cfg.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	// the host name is the pod of the benchmark, unique to each pod of its Job: eval-hub only
+	// compares the sequences of events of the same pod, as the offsets of another pod start over
+	source, err := os.Hostname()
+	if err != nil {
+		source = cfg.JobID
+	}
+	r := &Relay{
+		logger:     logger.With("results_file", cfg.Path),
+		path:       cfg.Path,
+		source:     source,
+		eventsURL:  strings.TrimSuffix(sidecarURL, "/") + "/api/v1/evaluations/jobs/" + cfg.JobID + "/events",
+		interval:   interval,
+		httpClient: httpClient,
+	}
+	r.offset = r.readOffset()
+	return r, nil
+}
+
+// Run relays the new status events every poll interval until the context is done, and once
+// more then, so that the events the adapter wrote before it exited are not lost.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), r.interval*5)
+			if err := r.Flush(flushCtx); err != nil {
+				r.logger.Error("failed to relay the last status events", "error", err)
+			}
+			cancel()
+			return
+		case <-ticker.C:
+			if err := r.Flush(ctx); err != nil {
+				r.logger.Warn("failed to relay status events, retrying", "error", err)
+			}
+		}
+	}
+}
+
+// Flush posts the complete lines written since the last flush, in order. It stops at the
+// first event that eval-hub could not take, which is posted again on the next flush; an
+// event that eval-hub rejects as invalid is logged and skipped.
+func (r *Relay) Flush(ctx context.Context) error {
+	file, err := os.Open(r.path) // #nosec G304 -- results file path from sidecar configuration
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("open results file: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+	if _, err := file.Seek(r.offset, io.SeekStart); err != nil {
+		return fmt.Errorf("seek results file: %w", err)
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("read results file: %w", err)
+	}
+	for {
+		end := bytes.IndexByte(data, '\n')
+		if end < 0 {
+			return nil
+		}
+		line := bytes.TrimSpace(data[:end])
+		if len(line) > 0 {
+			if err := r.post(ctx, line); err != nil {
+				return err
+			}
+		}
+		data = data[end+1:]
+		r.offset += int64(end + 1)
+		r.writeOffset()
+	}
+}
+
+// post sends one status event, an error means that it should be posted again.
+func (r *Relay) post(ctx context.Context, event []byte) error {
+	if !json.Valid(event) {
+		r.logger.Error("skipping a line of the results file that is not JSON", "offset", r.offset)
+		return nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.eventsURL, bytes.NewReader(r.stamp(event)))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("post status event: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	switch {
+	case resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("post status event: status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	default:
+		r.logger.Error("eval-hub rejected a status event of the results file", "status", resp.StatusCode, "response", strings.TrimSpace(string(body)), "offset", r.offset)
+		return nil
+	}
+}
+
+// stamp sets the event ID and the sequence of the status event of a line from the offset of
+// the line, unless the adapter set them.
+func (r *Relay) stamp(event []byte) []byte {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(event, &envelope); err != nil {
+		return event
+	}
+	var status map[string]json.RawMessage
+	if err := json.Unmarshal(envelope["benchmark_status_event"], &status); err != nil || status == nil {
+		return event
+	}
+	if _, ok := status["event_id"]; !ok {
+		status["event_id"], _ = json.Marshal(r.source + ":" + strconv.FormatInt(r.offset, 10))
+	}
+	if _, ok := status["sequence"]; !ok {
+		status["sequence"], _ = json.Marshal(r.offset + 1)
+	}
+	stamped, err := json.Marshal(status)
+	if err != nil {
+		return event
+	}
+	envelope["benchmark_status_event"] = stamped
+	out, err := json.Marshal(envelope)
+	if err != nil {
+		return event
+	}
+	return out
+}
+
+func (r *Relay) readOffset() int64 {
+	data, err := os.ReadFile(r.path + offsetFileSuffix) // #nosec G304 -- next to the results file
+	if err != nil {
+		return 0
+	}
+	offset, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || offset < 0 {
+		return 0
+	}
+	return offset
+}
+
+func (r *Relay) writeOffset() {
+	if err := os.WriteFile(r.path+offsetFileSuffix, []byte(strconv.FormatInt(r.offset, 10)), 0o600); err != nil {
+		r.logger.Warn("failed to record the offset of the results file", "error", err)
+	}
+}
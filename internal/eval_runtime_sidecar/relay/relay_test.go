@@ -0,0 +1,166 @@
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// eventsServer records the status events posted to it and answers them with the next of
+// statuses, 202 once they run out.
+type eventsServer struct {
+	mu       sync.Mutex
+	paths    []string
+	events   []string
+	statuses []int
+}
+
+func (s *eventsServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	body, _ := io.ReadAll(r.Body)
+	status := http.StatusAccepted
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	if status == http.StatusAccepted {
+		s.paths = append(s.paths, r.URL.Path)
+		s.events = append(s.events, string(body))
+	}
+	w.WriteHeader(status)
+}
+
+func newRelay(t *testing.T, path string, server *eventsServer) *Relay {
+	t.Helper()
+	srv := httptest.NewServer(server)
+	t.Cleanup(srv.Close)
+	relay, err := New(slog.New(slog.NewTextHandler(io.Discard, nil)), &config.SidecarResultRelayConfig{Path: path, JobID: "job-1"}, srv.URL, srv.Client())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return relay
+}
+
+func appendLine(t *testing.T, path, line string) {
+	t.Helper()
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("open results file: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+	if _, err := file.WriteString(line); err != nil {
+		t.Fatalf("write results file: %v", err)
+	}
+}
+
+func TestFlushRelaysTheCompleteLinesInOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status-events.jsonl")
+	server := &eventsServer{}
+	relay := newRelay(t, path, server)
+
+	// nothing to relay before the adapter writes the file
+	if err := relay.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush without a results file: %v", err)
+	}
+	appendLine(t, path, `{"benchmark_status_event":{"status":"running"}}`+"\n")
+	appendLine(t, path, `{"benchmark_status_event":{"status":"comp`)
+	if err := relay.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if len(server.events) != 1 || server.paths[0] != "/api/v1/evaluations/jobs/job-1/events" {
+		t.Fatalf("expected the complete line to be posted to the events of the job, got %v %v", server.paths, server.events)
+	}
+
+	appendLine(t, path, `leted"}}`+"\n")
+	if err := relay.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if len(server.events) != 2 || postedEvent(t, server.events[1]).Status != "completed" {
+		t.Fatalf("expected the line to be posted once complete, got %v", server.events)
+	}
+
+	// a restarted sidecar starts from the recorded offset
+	restarted := newRelay(t, path, server)
+	if err := restarted.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if len(server.events) != 2 {
+		t.Fatalf("expected no event to be posted again, got %v", server.events)
+	}
+}
+
+func TestFlushRetriesTheEventsThatEvalHubCouldNotTake(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status-events.jsonl")
+	server := &eventsServer{statuses: []int{http.StatusServiceUnavailable, http.StatusBadRequest}}
+	relay := newRelay(t, path, server)
+	appendLine(t, path, `{"event":1}`+"\nnot json\n"+`{"event":2}`+"\n")
+
+	if err := relay.Flush(context.Background()); err == nil {
+		t.Fatal("expected an error while eval-hub is unavailable")
+	}
+	if len(server.events) != 0 {
+		t.Fatalf("expected no event to be taken, got %v", server.events)
+	}
+	// the first event is rejected as invalid and skipped, the line that is not JSON is skipped
+	if err := relay.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if len(server.events) != 1 || server.events[0] != `{"event":2}` {
+		t.Fatalf("expected the second event to be relayed, got %v", server.events)
+	}
+}
+
+func postedEvent(t *testing.T, body string) api.BenchmarkStatusEvent {
+	t.Helper()
+	var event api.StatusEvent
+	if err := json.Unmarshal([]byte(body), &event); err != nil || event.BenchmarkStatusEvent == nil {
+		t.Fatalf("expected a status event, got %s", body)
+	}
+	return *event.BenchmarkStatusEvent
+}
+
+func TestFlushStampsTheEventsWithTheirPosition(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "status-events.jsonl")
+	server := &eventsServer{statuses: []int{http.StatusAccepted, http.StatusServiceUnavailable}}
+	relay := newRelay(t, path, server)
+	running := `{"benchmark_status_event":{"status":"running"}}` + "\n"
+	appendLine(t, path, running+`{"benchmark_status_event":{"status":"completed","event_id":"adapter-2","sequence":7}}`+"\n")
+
+	if err := relay.Flush(context.Background()); err == nil {
+		t.Fatal("expected an error while eval-hub is unavailable")
+	}
+	// the event that was not taken is posted again with the same ID
+	if err := relay.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+	if len(server.events) != 2 {
+		t.Fatalf("expected two events, got %v", server.events)
+	}
+	first := postedEvent(t, server.events[0])
+	if first.EventID == "" || first.Sequence != 1 {
+		t.Errorf("expected the first event to be stamped from its offset, got %+v", first)
+	}
+	// the keys set by the adapter are kept
+	if second := postedEvent(t, server.events[1]); second.EventID != "adapter-2" || second.Sequence != 7 {
+		t.Errorf("expected the keys of the adapter, got %+v", second)
+	}
+}
+
+func TestNewValidatesTheConfig(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	for _, cfg := range []*config.SidecarResultRelayConfig{nil, {JobID: "job-1"}, {Path: "/data/status-events.jsonl"}} {
+		if _, err := New(logger, cfg, "http://localhost:8080", http.DefaultClient); err == nil {
+			t.Errorf("expected an error for %+v", cfg)
+		}
+	}
+}
@@ -12,14 +12,22 @@ import (
 
 	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
 	handlers "github.com/eval-hub/eval-hub/internal/eval_runtime_sidecar/handlers"
+	"github.com/eval-hub/eval-hub/internal/eval_runtime_sidecar/relay"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
+// resultRelayTimeout bounds a status event posted by the result relay.
+const resultRelayTimeout = 30 * time.Second
+
 type SidecarServer struct {
 	httpServer *http.Server
 	port       int
 	logger     *slog.Logger
 	config     *config.Config
+	// stopRelay stops the relay of the results file of the adapter, relayDone is closed
+	// once it has relayed the last events; both are nil without a result relay.
+	stopRelay context.CancelFunc
+	relayDone chan struct{}
 }
 
 // NewSidecarServer creates a new sidecar HTTP server with the given logger and config.
@@ -118,6 +126,10 @@ func (s *SidecarServer) Start() error {
 		},
 	}
 
+	if err := s.startResultRelay(); err != nil {
+		return err
+	}
+
 	s.logger.Info("Sidecar server starting", "port", s.port)
 	err = s.httpServer.ListenAndServe()
 
@@ -128,8 +140,37 @@ func (s *SidecarServer) Start() error {
 	return err
 }
 
+// startResultRelay starts relaying the status events of the results file of the adapter to
+// the sidecar itself when the provider of the job sets result_relay.
+func (s *SidecarServer) startResultRelay() error {
+	if s.config.Sidecar == nil || s.config.Sidecar.ResultRelay == nil {
+		return nil
+	}
+	resultRelay, err := relay.New(s.logger, s.config.Sidecar.ResultRelay, fmt.Sprintf("http://localhost:%d", s.port), &http.Client{Timeout: resultRelayTimeout})
+	if err != nil {
+		return fmt.Errorf("failed to create result relay: %w", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopRelay = cancel
+	s.relayDone = make(chan struct{})
+	go func() {
+		defer close(s.relayDone)
+		resultRelay.Run(ctx)
+	}()
+	s.logger.Info("Relaying the status events of the results file", "path", s.config.Sidecar.ResultRelay.Path)
+	return nil
+}
+
 func (s *SidecarServer) Shutdown(ctx context.Context) error {
 	s.logger.Info("Shutting down sidecar server gracefully...")
+	// the last events of the adapter are relayed while the proxy still serves
+	if s.stopRelay != nil {
+		s.stopRelay()
+		select {
+		case <-s.relayDone:
+		case <-ctx.Done():
+		}
+	}
 	return s.httpServer.Shutdown(ctx)
 }
 
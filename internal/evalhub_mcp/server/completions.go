@@ -16,6 +16,7 @@ const defaultCacheTTL = 30 * time.Second
 
 var statusValues = []string{
 	string(api.OverallStatePending),
+	string(api.OverallStateWaitingForModel),
 	string(api.OverallStateRunning),
 	string(api.OverallStateCompleted),
 	string(api.OverallStateFailed),
@@ -185,10 +185,10 @@ func TestCompleteStatus(t *testing.T) {
 	ctx, cs := connectWithCompletions(t, testDataSource())
 
 	result := complete(t, ctx, cs, "evalhub://jobs{?status}", "status", "")
-	if len(result.Completion.Values) != 6 {
-		t.Fatalf("expected 6 status values, got %d: %v", len(result.Completion.Values), result.Completion.Values)
+	if len(result.Completion.Values) != 7 {
+		t.Fatalf("expected 7 status values, got %d: %v", len(result.Completion.Values), result.Completion.Values)
 	}
-	want := []string{"pending", "running", "completed", "failed", "cancelled", "partially_failed"}
+	want := []string{"pending", "waiting_for_model", "running", "completed", "failed", "cancelled", "partially_failed"}
 	for _, w := range want {
 		found := false
 		for _, v := range result.Completion.Values {
@@ -94,7 +94,7 @@ func registerResources(srv *mcp.Server, ds EvalHubDiscovery, logger *slog.Logger
 
 	srv.AddResourceTemplate(&mcp.ResourceTemplate{
 		Name:        "jobs-by-status",
-		Description: "Filter evaluation jobs by status (pending, running, completed, failed, cancelled, partially_failed)",
+		Description: "Filter evaluation jobs by status (pending, waiting_for_model, running, completed, failed, cancelled, partially_failed)",
 		MIMEType:    "application/json",
 		URITemplate: "evalhub://jobs{?status}",
 	}, jobsHandler)
@@ -303,7 +303,7 @@ func extractStatus(rawURI string) (api.OverallState, bool, error) {
 	}
 	state, err := api.GetOverallState(s)
 	if err != nil {
-		return "", true, fmt.Errorf("invalid job status %q: valid values are pending, running, completed, failed, cancelled, partially_failed", s)
+		return "", true, fmt.Errorf("invalid job status %q: valid values are pending, waiting_for_model, running, completed, failed, cancelled, partially_failed", s)
 	}
 	return state, true, nil
 }
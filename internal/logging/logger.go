@@ -22,6 +22,10 @@ const (
 	envLogLevel = "LOG_LEVEL"
 )
 
+// level is the level of the loggers created by NewLogger. It is shared so that it can be
+// changed while the service runs, see SetLevel.
+var level = zap.NewAtomicLevel()
+
 // ShutdownFunc is a function that shuts down the logger
 // the return is an error if the logger could not be shut down
 type ShutdownFunc func() error
@@ -36,9 +40,10 @@ type ShutdownFunc func() error
 func NewLogger() (*slog.Logger, ShutdownFunc, error) {
 	logConfig := zap.NewProductionConfig()
 	logConfig.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-	if level := parseLogLevel(os.Getenv(envLogLevel)); level != nil {
-		logConfig.Level = zap.NewAtomicLevelAt(*level)
+	if l := parseLogLevel(os.Getenv(envLogLevel)); l != nil {
+		level.SetLevel(*l)
 	}
+	logConfig.Level = level
 	zapLog, err := logConfig.Build()
 	if err != nil {
 		return nil, nil, err
@@ -48,6 +53,28 @@ func NewLogger() (*slog.Logger, ShutdownFunc, error) {
 	return slog.New(zapslog.NewHandler(zapLog.Core(), zapslog.WithCaller(true))), f, nil
 }
 
+// Level returns the current level of the loggers created by NewLogger: debug, info, warn or error.
+func Level() string {
+	return level.Level().String()
+}
+
+// SetLevel changes the level of the loggers created by NewLogger, without a restart.
+// The level is one of debug, info, warn or error.
+func SetLevel(s string) error {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "info":
+		level.SetLevel(zapcore.InfoLevel)
+		return nil
+	default:
+		l := parseLogLevel(s)
+		if l == nil {
+			return fmt.Errorf("invalid log level %q", s)
+		}
+		level.SetLevel(*l)
+		return nil
+	}
+}
+
 func FallbackLogger() *slog.Logger {
 	return slog.New(slog.NewJSONHandler(os.Stdout, nil))
 }
@@ -162,3 +162,23 @@ func TestLogRequestSuccess_additionalArgsInOutput(t *testing.T) {
 		t.Fatalf("expected duration field in log output: %v", payload)
 	}
 }
+
+func TestSetLevel(t *testing.T) {
+	previous := Level()
+	t.Cleanup(func() { _ = SetLevel(previous) })
+
+	for _, l := range []string{"debug", "warn", "error", "INFO"} {
+		if err := SetLevel(l); err != nil {
+			t.Fatalf("SetLevel(%q): %v", l, err)
+		}
+		if got := Level(); got != strings.ToLower(l) {
+			t.Errorf("Level() = %q after SetLevel(%q)", got, l)
+		}
+	}
+	if err := SetLevel("verbose"); err == nil {
+		t.Error("expected an error for an invalid level")
+	}
+	if got := Level(); got != "info" {
+		t.Errorf("an invalid level must not change the level, got %q", got)
+	}
+}
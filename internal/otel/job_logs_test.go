@@ -126,6 +126,9 @@ func (r *stubLogsRuntime) Name() string { return "stub" }
 func (r *stubLogsRuntime) RunEvaluationJob(_ *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, _ abstractions.RuntimeStorage) error {
 	return nil
 }
+func (r *stubLogsRuntime) RunEvaluationBenchmarks(_ *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, _ []int, _ abstractions.RuntimeStorage) error {
+	return nil
+}
 func (r *stubLogsRuntime) DeleteEvaluationJobResources(_ *api.EvaluationJobResource) error {
 	return nil
 }
@@ -133,3 +136,6 @@ func (r *stubLogsRuntime) GetEvaluationLogs(_ *api.EvaluationJobResource, _ []ap
 	close(r.exported)
 	return r.logs, nil
 }
+func (r *stubLogsRuntime) GetEvaluationJobSpec(_ *api.EvaluationJobResource, _ []api.EvaluationBenchmarkConfig, _ int, _ int, _ abstractions.RuntimeStorage) ([]byte, error) {
+	return nil, nil
+}
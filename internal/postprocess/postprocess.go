@@ -0,0 +1,132 @@
+// Package postprocess runs the configured post-processors on the metrics of a benchmark when
+// it completes, e.g. to rename or normalize metrics, convert their units or derive metrics
+// such as a harmonic mean. The metrics reported under an alias of a metric that the provider
+// declares are first renamed to the name of the metric. The processed metrics are stored
+// next to the metrics that the runtime reported, which are kept as they are.
+//
+// Post-processors are registered by type with Register, the built-in ones in processors.go,
+// and configured in order under post_processing.processors.
+package postprocess
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// Processor post-processes the metrics of a benchmark. It returns the processed metrics and
+// must not change the ones it is given.
+type Processor interface {
+	Process(metrics map[string]any) (map[string]any, error)
+}
+
+// Factory creates a post-processor from the options of its configuration.
+type Factory func(options map[string]any) (Processor, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{}
+)
+
+// Register makes a post-processor type available to the configuration. It panics when the
+// type is registered twice, like database/sql.Register.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if factory == nil {
+		panic("postprocess: Register factory is nil")
+	}
+	if _, ok := registry[name]; ok {
+		panic("postprocess: Register called twice for " + name)
+	}
+	registry[name] = factory
+}
+
+// Types returns the registered post-processor types, sorted.
+func Types() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	return slices.Sorted(maps.Keys(registry))
+}
+
+type step struct {
+	config.PostProcessorConfig
+	processor Processor
+}
+
+func (s *step) appliesTo(providerID string, tenant api.Tenant) bool {
+	if len(s.Providers) > 0 && !slices.Contains(s.Providers, providerID) {
+		return false
+	}
+	return len(s.Tenants) == 0 || slices.Contains(s.Tenants, string(tenant))
+}
+
+// Chain is the configured post-processors, in order.
+type Chain struct {
+	steps  []*step
+	logger *slog.Logger
+}
+
+// NewChain returns the chain of the configured post-processors, or nil when there are none.
+func NewChain(logger *slog.Logger, cfg *config.PostProcessingConfig) (*Chain, error) {
+	if !cfg.IsEnabled() {
+		return nil, nil
+	}
+	c := &Chain{logger: logger}
+	for i, processorConfig := range cfg.Processors {
+		registryMu.RLock()
+		factory, ok := registry[processorConfig.Type]
+		registryMu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("post_processing.processors[%d]: unknown type %q, expected one of %v", i, processorConfig.Type, Types())
+		}
+		processor, err := factory(processorConfig.Options)
+		if err != nil {
+			return nil, fmt.Errorf("post_processing.processors[%d] (%s): %w", i, processorConfig.Type, err)
+		}
+		c.steps = append(c.steps, &step{PostProcessorConfig: processorConfig, processor: processor})
+	}
+	return c, nil
+}
+
+// AppliesTo reports whether a post-processor of the chain runs for the benchmarks of the
+// provider, for some tenant.
+func (c *Chain) AppliesTo(providerID string) bool {
+	if c == nil {
+		return false
+	}
+	return slices.ContainsFunc(c.steps, func(s *step) bool {
+		return len(s.Providers) == 0 || slices.Contains(s.Providers, providerID)
+	})
+}
+
+// Process runs the post-processors of the provider and the tenant on the metrics, and returns
+// the processed metrics, or nil when none of them ran. A post-processor that fails is
+// skipped, so that the result of the benchmark is still stored.
+func (c *Chain) Process(providerID string, tenant api.Tenant, metrics map[string]any) map[string]any {
+	if c == nil || len(metrics) == 0 {
+		return nil
+	}
+	var processed map[string]any
+	for _, s := range c.steps {
+		if !s.appliesTo(providerID, tenant) {
+			continue
+		}
+		input := processed
+		if input == nil {
+			input = metrics
+		}
+		output, err := s.processor.Process(input)
+		if err != nil {
+			c.logger.Warn("Results post-processor failed, skipping it", "type", s.Type, "provider_id", providerID, "tenant", tenant, "error", err.Error())
+			continue
+		}
+		processed = output
+	}
+	return processed
+}
@@ -0,0 +1,79 @@
+package postprocess
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/logging"
+)
+
+func TestNewChain(t *testing.T) {
+	logger := logging.FallbackLogger()
+
+	t.Run("no post-processors", func(t *testing.T) {
+		chain, err := NewChain(logger, &config.PostProcessingConfig{})
+		if err != nil || chain != nil {
+			t.Fatalf("expected no chain, got %v, %v", chain, err)
+		}
+		if processed := chain.Process("prov", "", map[string]any{"acc": 0.5}); processed != nil {
+			t.Fatalf("expected a nil chain not to process metrics, got %v", processed)
+		}
+	})
+
+	tests := []struct {
+		name      string
+		processor config.PostProcessorConfig
+		err       string
+	}{
+		{"unknown type", config.PostProcessorConfig{Type: "unknown"}, `unknown type "unknown"`},
+		{"rename without metrics", config.PostProcessorConfig{Type: "rename"}, "options.metrics"},
+		{"scale without factor", config.PostProcessorConfig{Type: "scale", Options: map[string]any{"metrics": []any{"acc"}}}, "options.factor"},
+		{"unknown option", config.PostProcessorConfig{Type: "harmonic_mean", Options: map[string]any{"metrics": []any{"p", "r"}, "name": "f1", "weights": 1}}, "weights"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewChain(logger, &config.PostProcessingConfig{Processors: []config.PostProcessorConfig{tt.processor}})
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected an error about %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestChainProcess(t *testing.T) {
+	chain, err := NewChain(logging.FallbackLogger(), &config.PostProcessingConfig{Processors: []config.PostProcessorConfig{
+		{Type: "rename", Providers: []string{"lm_evaluation_harness"}, Options: map[string]any{"metrics": map[string]any{"prec": "precision"}}},
+		{Type: "scale", Tenants: []string{"team-a"}, Options: map[string]any{"metrics": []any{"precision", "recall"}, "factor": "100"}},
+		{Type: "harmonic_mean", Options: map[string]any{"metrics": []any{"precision", "recall"}, "name": "f1"}},
+	}})
+	if err != nil {
+		t.Fatalf("NewChain: %v", err)
+	}
+	if !chain.AppliesTo("other") {
+		t.Fatal("expected the post-processors without providers to apply to every provider")
+	}
+
+	metrics := map[string]any{"prec": 0.5, "recall": 1.0, "samples": 10}
+	processed := chain.Process("lm_evaluation_harness", "team-a", metrics)
+	if processed["precision"] != 50.0 || processed["recall"] != 100.0 || processed["samples"] != 10 {
+		t.Fatalf("expected the metrics to be renamed and scaled, got %v", processed)
+	}
+	if f1, ok := processed["f1"].(float64); !ok || f1 < 66.66 || f1 > 66.67 {
+		t.Fatalf("expected the harmonic mean of the scaled metrics, got %v", processed["f1"])
+	}
+	if _, ok := metrics["precision"]; ok || metrics["recall"] != 1.0 {
+		t.Fatalf("expected the reported metrics to be left as they are, got %v", metrics)
+	}
+
+	// neither the rename of the provider nor the scale of the tenant apply
+	processed = chain.Process("other", "team-b", map[string]any{"precision": 0.5, "recall": 1.0})
+	if f1, ok := processed["f1"].(float64); !ok || f1 < 0.666 || f1 > 0.667 {
+		t.Fatalf("expected only the harmonic mean to apply, got %v", processed)
+	}
+
+	processed = chain.Process("other", "team-b", map[string]any{"precision": 0.5})
+	if _, ok := processed["f1"]; ok {
+		t.Fatalf("expected no harmonic mean without all of its metrics, got %v", processed)
+	}
+}
@@ -0,0 +1,129 @@
+package postprocess
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/go-viper/mapstructure/v2"
+)
+
+func init() {
+	Register("rename", newRename)
+	Register("scale", newScale)
+	Register("harmonic_mean", newHarmonicMean)
+}
+
+func decodeOptions(options map[string]any, result any) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused:      true,
+		WeaklyTypedInput: true,
+		Result:           result,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(options)
+}
+
+// rename renames metrics, e.g. to normalize the names that providers report for the same
+// metric. A metric that is not reported is left out.
+type rename struct {
+	Metrics map[string]string `mapstructure:"metrics"`
+}
+
+func newRename(options map[string]any) (Processor, error) {
+	p := &rename{}
+	if err := decodeOptions(options, p); err != nil {
+		return nil, err
+	}
+	if len(p.Metrics) == 0 {
+		return nil, fmt.Errorf("options.metrics must map the metrics to their new names")
+	}
+	return p, nil
+}
+
+func (p *rename) Process(metrics map[string]any) (map[string]any, error) {
+	processed := make(map[string]any, len(metrics))
+	for name, value := range metrics {
+		if newName, ok := p.Metrics[name]; ok {
+			name = newName
+		}
+		processed[name] = value
+	}
+	return processed, nil
+}
+
+// scale multiplies numeric metrics by a factor, e.g. 100 to report fractions as percentages.
+type scale struct {
+	Metrics []string `mapstructure:"metrics"`
+	Factor  float64  `mapstructure:"factor"`
+}
+
+func newScale(options map[string]any) (Processor, error) {
+	p := &scale{}
+	if err := decodeOptions(options, p); err != nil {
+		return nil, err
+	}
+	if len(p.Metrics) == 0 || p.Factor == 0 {
+		return nil, fmt.Errorf("options.metrics and options.factor are required")
+	}
+	return p, nil
+}
+
+func (p *scale) Process(metrics map[string]any) (map[string]any, error) {
+	processed := maps.Clone(metrics)
+	for _, name := range p.Metrics {
+		if value, ok := toFloat(metrics[name]); ok {
+			processed[name] = value * p.Factor
+		}
+	}
+	return processed, nil
+}
+
+// harmonicMean adds the harmonic mean of numeric metrics, e.g. an F1 score from the
+// precision and the recall. It is left out when one of the metrics is not reported or is not
+// positive.
+type harmonicMean struct {
+	Metrics []string `mapstructure:"metrics"`
+	Name    string   `mapstructure:"name"`
+}
+
+func newHarmonicMean(options map[string]any) (Processor, error) {
+	p := &harmonicMean{}
+	if err := decodeOptions(options, p); err != nil {
+		return nil, err
+	}
+	if len(p.Metrics) < 2 || p.Name == "" {
+		return nil, fmt.Errorf("options.metrics must list at least two metrics and options.name is required")
+	}
+	return p, nil
+}
+
+func (p *harmonicMean) Process(metrics map[string]any) (map[string]any, error) {
+	processed := maps.Clone(metrics)
+	var sum float64
+	for _, name := range p.Metrics {
+		value, ok := toFloat(metrics[name])
+		if !ok || value <= 0 {
+			return processed, nil
+		}
+		sum += 1 / value
+	}
+	processed[p.Name] = float64(len(p.Metrics)) / sum
+	return processed, nil
+}
+
+func toFloat(value any) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case float32:
+		return float64(number), true
+	case int:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	default:
+		return 0, false
+	}
+}
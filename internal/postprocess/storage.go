@@ -0,0 +1,95 @@
+package postprocess
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/abstractions"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+// processingStorage normalizes and post-processes the metrics of the benchmarks that
+// complete, whichever code path (jobs API, runtime, result cache) reports them, before they
+// are stored.
+type processingStorage struct {
+	abstractions.Storage
+	chain  *Chain
+	logger *slog.Logger
+}
+
+// NewStorage wraps storage so that the metrics of completed benchmarks are normalized to the
+// metrics that their provider declares and post-processed by chain, which may be nil.
+func NewStorage(storage abstractions.Storage, chain *Chain, logger *slog.Logger) abstractions.Storage {
+	return &processingStorage{Storage: storage, chain: chain, logger: logger}
+}
+
+func (s *processingStorage) with(storage abstractions.Storage) *processingStorage {
+	return &processingStorage{Storage: storage, chain: s.chain, logger: s.logger}
+}
+
+func (s *processingStorage) WithLogger(logger *slog.Logger) abstractions.Storage {
+	scoped := s.with(s.Storage.WithLogger(logger))
+	scoped.logger = logger
+	return scoped
+}
+
+func (s *processingStorage) WithContext(ctx context.Context) abstractions.Storage {
+	return s.with(s.Storage.WithContext(ctx))
+}
+
+func (s *processingStorage) WithTenant(tenant api.Tenant) abstractions.Storage {
+	return s.with(s.Storage.WithTenant(tenant))
+}
+
+func (s *processingStorage) WithOwner(owner api.User) abstractions.Storage {
+	return s.with(s.Storage.WithOwner(owner))
+}
+
+func (s *processingStorage) UpdateEvaluationJob(id string, runStatus *api.StatusEvent) (*abstractions.EvaluationJobUpdate, error) {
+	event := runStatus.BenchmarkStatusEvent
+	if event != nil && event.Status == api.StateCompleted && len(event.Metrics) > 0 {
+		if metrics := s.process(id, event); metrics != nil {
+			processed := *event
+			processed.ProcessedMetrics = metrics
+			runStatus = &api.StatusEvent{BenchmarkStatusEvent: &processed}
+		}
+	}
+	return s.Storage.UpdateEvaluationJob(id, runStatus)
+}
+
+// process returns the normalized and post-processed metrics of the event, or nil when they
+// are the reported ones.
+func (s *processingStorage) process(id string, event *api.BenchmarkStatusEvent) map[string]any {
+	var normalized map[string]any
+	if provider, err := s.Storage.GetProvider(event.ProviderID); err != nil {
+		s.logger.Warn("Failed to read the provider for metrics normalization", "provider_id", event.ProviderID, "error", err)
+	} else if provider != nil {
+		normalized = provider.NormalizeMetrics(event.Metrics)
+	}
+	if !s.chain.AppliesTo(event.ProviderID) {
+		return normalized
+	}
+	metrics := normalized
+	if metrics == nil {
+		metrics = event.Metrics
+	}
+	if processed := s.chain.Process(event.ProviderID, s.tenantOf(id), metrics); processed != nil {
+		return processed
+	}
+	return normalized
+}
+
+// tenantOf returns the tenant of the job, which is only read when a post-processor is
+// restricted to some tenants: the status events of the runtimes are not scoped to a tenant.
+func (s *processingStorage) tenantOf(id string) api.Tenant {
+	if !slices.ContainsFunc(s.chain.steps, func(s *step) bool { return len(s.Tenants) > 0 }) {
+		return ""
+	}
+	job, err := s.Storage.GetEvaluationJob(id)
+	if err != nil {
+		s.logger.Warn("Failed to read the evaluation job for results post-processing", "id", id, "error", err)
+		return ""
+	}
+	return job.Resource.Tenant
+}
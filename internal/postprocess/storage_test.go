@@ -0,0 +1,120 @@
+package postprocess
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/eval-hub/eval-hub/internal/eval_hub/config"
+	"github.com/eval-hub/eval-hub/internal/eval_hub/storage"
+	"github.com/eval-hub/eval-hub/internal/logging"
+	"github.com/eval-hub/eval-hub/pkg/api"
+)
+
+func TestStoragePostProcessesCompletedBenchmarks(t *testing.T) {
+	logger := logging.FallbackLogger()
+	databaseConfig := map[string]any{
+		"driver": "sqlite",
+		"url":    fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name()),
+	}
+	store, err := storage.NewStorage(&databaseConfig, nil, nil, false, false, logger)
+	if err != nil {
+		t.Fatalf("NewStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+
+	chain, err := NewChain(logger, &config.PostProcessingConfig{Processors: []config.PostProcessorConfig{
+		{Type: "scale", Tenants: []string{"tenant-a"}, Options: map[string]any{"metrics": []any{"acc"}, "factor": 100}},
+	}})
+	if err != nil {
+		t.Fatalf("NewChain: %v", err)
+	}
+	scoped := NewStorage(store, chain, logger).WithTenant("tenant-a")
+
+	job := &api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1", CreatedAt: time.Now(), Tenant: "tenant-a"}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePending}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model: api.ModelRef{URL: "http://test.com", Name: "test"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{
+				{Ref: api.Ref{ID: "b1"}, ProviderID: "prov1"},
+				{Ref: api.Ref{ID: "b2"}, ProviderID: "prov1"},
+			},
+		},
+	}
+	if err := scoped.CreateEvaluationJob(job); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+	for _, event := range []*api.BenchmarkStatusEvent{
+		{ID: "b1", ProviderID: "prov1", BenchmarkIndex: 0, Status: api.StateCompleted, Metrics: map[string]any{"acc": 0.5}},
+		{ID: "b2", ProviderID: "prov1", BenchmarkIndex: 1, Status: api.StateFailed, Metrics: map[string]any{"acc": 0.1}},
+	} {
+		if _, err := scoped.UpdateEvaluationJob("job-1", &api.StatusEvent{BenchmarkStatusEvent: event}); err != nil {
+			t.Fatalf("UpdateEvaluationJob: %v", err)
+		}
+	}
+
+	stored, err := scoped.GetEvaluationJob("job-1")
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	if stored.Results == nil || len(stored.Results.Benchmarks) != 2 {
+		t.Fatalf("expected the results of both benchmarks, got %+v", stored.Results)
+	}
+	completed, failed := stored.Results.Benchmarks[0], stored.Results.Benchmarks[1]
+	if completed.Metrics["acc"] != 0.5 || completed.ProcessedMetrics["acc"] != 50.0 {
+		t.Fatalf("expected the processed metrics next to the reported ones, got %v and %v", completed.Metrics, completed.ProcessedMetrics)
+	}
+	if failed.ProcessedMetrics != nil {
+		t.Fatalf("expected the metrics of a failed benchmark not to be processed, got %v", failed.ProcessedMetrics)
+	}
+}
+
+func TestStorageNormalizesDeclaredMetrics(t *testing.T) {
+	logger := logging.FallbackLogger()
+	databaseConfig := map[string]any{
+		"driver": "sqlite",
+		"url":    fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name()),
+	}
+	store, err := storage.NewStorage(&databaseConfig, nil, nil, false, false, logger)
+	if err != nil {
+		t.Fatalf("NewStorage: %v", err)
+	}
+	t.Cleanup(func() { _ = store.Close() })
+	scoped := NewStorage(store, nil, logger).WithTenant("tenant-a")
+
+	if err := scoped.CreateProvider(&api.ProviderResource{
+		Resource: api.Resource{ID: "prov1", Tenant: "tenant-a", CreatedAt: time.Now()},
+		ProviderConfig: api.ProviderConfig{
+			Name:       "Provider",
+			Metrics:    []api.MetricDefinition{{Name: "accuracy", Aliases: []string{"acc"}}},
+			Benchmarks: []api.BenchmarkResource{{ID: "b1"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateProvider: %v", err)
+	}
+	if err := scoped.CreateEvaluationJob(&api.EvaluationJobResource{
+		Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1", CreatedAt: time.Now(), Tenant: "tenant-a"}},
+		Status:   &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: api.OverallStatePending}},
+		EvaluationJobConfig: api.EvaluationJobConfig{
+			Model:      api.ModelRef{URL: "http://test.com", Name: "test"},
+			Benchmarks: []api.EvaluationBenchmarkConfig{{Ref: api.Ref{ID: "b1"}, ProviderID: "prov1"}},
+		},
+	}); err != nil {
+		t.Fatalf("CreateEvaluationJob: %v", err)
+	}
+	if _, err := scoped.UpdateEvaluationJob("job-1", &api.StatusEvent{BenchmarkStatusEvent: &api.BenchmarkStatusEvent{
+		ID: "b1", ProviderID: "prov1", Status: api.StateCompleted, Metrics: map[string]any{"acc": 0.5},
+	}}); err != nil {
+		t.Fatalf("UpdateEvaluationJob: %v", err)
+	}
+
+	stored, err := scoped.GetEvaluationJob("job-1")
+	if err != nil {
+		t.Fatalf("GetEvaluationJob: %v", err)
+	}
+	result := stored.Results.Benchmarks[0]
+	if result.Metrics["acc"] != 0.5 || result.ProcessedMetrics["accuracy"] != 0.5 || len(result.ProcessedMetrics) != 1 {
+		t.Fatalf("expected the metric normalized to its declared name, got %v and %v", result.Metrics, result.ProcessedMetrics)
+	}
+}
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// AdminConfig holds the settings of the service that operators can change while it runs,
+// with GET and PATCH /api/v1/admin/config. The settings are stored and applied by every
+// replica, the intervals of the background checks taking effect on the leader.
+type AdminConfig struct {
+	// LogLevel is the level of the service logs: debug, info, warn or error.
+	LogLevel string `json:"log_level" validate:"required,oneof=debug info warn error"`
+	// ProviderHealthPollInterval is how often the provider health checks look for due
+	// canaries and in-flight results, as a Go duration (e.g. 30s). Empty when the provider
+	// health checks are not running.
+	ProviderHealthPollInterval string `json:"provider_health_poll_interval,omitempty"`
+	// ReconcileInterval is how often the workloads of the unfinished jobs are reconciled
+	// with the jobs, to fail the benchmarks whose workload failed unreported and refresh
+	// where they run, as a Go duration (e.g. 30s).
+	ReconcileInterval string `json:"reconcile_interval,omitempty"`
+	// UpdatedAt and UpdatedBy are when and by whom the settings were last changed.
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+	UpdatedBy User      `json:"updated_by,omitempty"`
+}
+
+// MaintenanceMode is the maintenance state of a replica, with GET and PUT
+// /api/v1/admin/maintenance. In maintenance mode new evaluation jobs are rejected, while the
+// jobs that were already submitted keep running.
+type MaintenanceMode struct {
+	// Enabled is true while the replica is in maintenance mode.
+	Enabled bool `json:"enabled"`
+	// Message tells the users why new jobs are rejected, it is returned in the errors.
+	Message string `json:"message,omitempty" validate:"omitempty,max=1024"`
+	// RetryAfterSeconds is the Retry-After of the rejected requests; a default is used when
+	// it is not set.
+	RetryAfterSeconds int `json:"retry_after_seconds,omitempty" validate:"min=0,max=86400"`
+	// Since is when the maintenance mode was enabled.
+	Since *time.Time `json:"since,omitempty"`
+	// EnabledBy is the user who enabled the maintenance mode.
+	EnabledBy User `json:"enabled_by,omitempty"`
+}
+
+// ArchiveFormat and ArchiveVersion identify the tenant archives of GET /api/v1/admin/export.
+const (
+	ArchiveFormat  = "eval-hub-archive"
+	ArchiveVersion = 1
+)
+
+// ArchiveKind is the kind of the resource of an archive record.
+type ArchiveKind string
+
+const (
+	ArchiveKindManifest      ArchiveKind = "manifest"
+	ArchiveKindProvider      ArchiveKind = "provider"
+	ArchiveKindCollection    ArchiveKind = "collection"
+	ArchiveKindBaseline      ArchiveKind = "baseline"
+	ArchiveKindEvaluationJob ArchiveKind = "evaluation_job"
+)
+
+// ArchiveRecord is a line of a tenant archive. The first record of an archive is its
+// manifest, the next ones the resources in the order they are imported in: providers,
+// collections, baselines and evaluation jobs.
+type ArchiveRecord struct {
+	Kind     ArchiveKind     `json:"kind"`
+	Resource json.RawMessage `json:"resource"`
+}
+
+// ArchiveManifest describes a tenant archive.
+type ArchiveManifest struct {
+	Format     string              `json:"format"`
+	Version    int                 `json:"version"`
+	Tenant     Tenant              `json:"tenant,omitempty"`
+	ExportedAt time.Time           `json:"exported_at"`
+	ExportedBy User                `json:"exported_by,omitempty"`
+	Counts     map[ArchiveKind]int `json:"counts"`
+}
+
+// ArchiveImportResult reports what POST /api/v1/admin/import did with the resources of an
+// archive. Resources whose ID already exists are skipped.
+type ArchiveImportResult struct {
+	Imported map[ArchiveKind]int `json:"imported"`
+	Skipped  map[ArchiveKind]int `json:"skipped"`
+	Errors   []string            `json:"errors,omitempty"`
+}
+
+// ClusterStatus is the status of a Kubernetes cluster that evaluation jobs run on, with GET
+// /api/v1/admin/clusters: the cluster of the service, named local, and the remote clusters.
+type ClusterStatus struct {
+	// Name is the name of the cluster.
+	Name string `json:"name"`
+	// Reachable is true when the service reaches the API server of the cluster.
+	Reachable bool `json:"reachable"`
+	// Version is the Kubernetes version of the API server of the cluster.
+	Version string `json:"version,omitempty"`
+	// ActiveJobs is the number of Kubernetes Jobs of evaluation jobs that have not finished.
+	ActiveJobs int `json:"active_jobs"`
+	// Error tells why the cluster is not reachable or its Jobs could not be listed.
+	Error string `json:"error,omitempty"`
+}
+
+// ClusterStatusList is the response of GET /api/v1/admin/clusters.
+type ClusterStatusList struct {
+	Clusters []ClusterStatus `json:"clusters"`
+}
+
+// StatusDeadLetter is a status event of a job that the service rejected, e.g. for a job that
+// had already finished or for a benchmark that the job does not have, kept for the operators
+// to analyse and requeue with /api/v1/admin/dead-letters.
+type StatusDeadLetter struct {
+	ID     string `json:"id"`
+	Tenant Tenant `json:"tenant"`
+	// Owner is the user of the request that sent the event.
+	Owner      User      `json:"owner,omitempty"`
+	JobID      string    `json:"job_id"`
+	ReceivedAt time.Time `json:"received_at"`
+	// ErrorCode and Reason are the error that the event was rejected with.
+	ErrorCode string `json:"error_code"`
+	Reason    string `json:"reason"`
+	// Body is the body of the request as received, with the redaction policy of the tenant
+	// applied.
+	Body string `json:"body"`
+}
+
+// StatusDeadLetterList is the response of GET /api/v1/admin/dead-letters, the newest first.
+type StatusDeadLetterList struct {
+	Page
+	Items []StatusDeadLetter `json:"items"`
+}
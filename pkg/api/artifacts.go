@@ -0,0 +1,23 @@
+package api
+
+import "time"
+
+// ArtifactResource is an intermediate artifact that the adapter of a benchmark uploaded to
+// the artifact store while the benchmark runs, e.g. a checkpoint of its predictions.
+type ArtifactResource struct {
+	JobID          string `json:"job_id"`
+	BenchmarkIndex int    `json:"benchmark_index"`
+	Name           string `json:"name"`
+	ContentType    string `json:"content_type"`
+	Size           int64  `json:"size"`
+	// Digest is the sha256 digest of the content, as sha256:<hex>.
+	Digest string `json:"digest"`
+	// URI is the location of the artifact in the artifact store.
+	URI        string    `json:"uri"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+type ArtifactResourceList struct {
+	Page
+	Items []ArtifactResource `json:"items"`
+}
@@ -0,0 +1,139 @@
+package api
+
+// BaselineConfig represents request to register a completed evaluation job as a named baseline
+type BaselineConfig struct {
+	// Name is the name the baseline is referenced by, e.g. in pass_criteria.must_not_regress.
+	Name        string `json:"name" validate:"required,rfc1123_dns_label"`
+	Description string `json:"description,omitempty" validate:"omitempty,max=1024"`
+	JobID       string `json:"job_id" validate:"required"`
+}
+
+// BaselineBenchmark is the primary score of a benchmark of the baseline job
+type BaselineBenchmark struct {
+	ID                 string  `json:"id"`
+	ProviderID         string  `json:"provider_id"`
+	PrimaryScoreMetric string  `json:"primary_score_metric"`
+	PrimaryScore       float32 `json:"primary_score"`
+}
+
+// BaselineResource represents a named baseline. The model and scores are copied from the
+// job when the baseline is registered, so the baseline outlives the job.
+type BaselineResource struct {
+	Resource Resource `json:"resource"`
+	BaselineConfig
+	Model ModelRef `json:"model"`
+	// Score is the weighted score of the job, see EvaluationTest.
+	Score      float32             `json:"score"`
+	Benchmarks []BaselineBenchmark `json:"benchmarks,omitempty"`
+}
+
+// NewBaselineResource returns the baseline of a completed job with a test result.
+func NewBaselineResource(resource Resource, config BaselineConfig, job *EvaluationJobResource) *BaselineResource {
+	baseline := &BaselineResource{
+		Resource:       resource,
+		BaselineConfig: config,
+		Model:          job.Model,
+		Score:          job.Results.Test.Score,
+	}
+	baseline.Model.Auth = nil
+	for _, result := range job.Results.Benchmarks {
+		if result.Test == nil {
+			continue
+		}
+		baseline.Benchmarks = append(baseline.Benchmarks, BaselineBenchmark{
+			ID:                 result.ID,
+			ProviderID:         result.ProviderID,
+			PrimaryScoreMetric: result.Test.PrimaryScoreMetric,
+			PrimaryScore:       result.Test.PrimaryScore,
+		})
+	}
+	return baseline
+}
+
+// GetBenchmark returns the baseline of the benchmark, or nil when the baseline job did not
+// report a primary score for it.
+func (b *BaselineResource) GetBenchmark(providerID string, id string) *BaselineBenchmark {
+	for i := range b.Benchmarks {
+		if b.Benchmarks[i].ProviderID == providerID && b.Benchmarks[i].ID == id {
+			return &b.Benchmarks[i]
+		}
+	}
+	return nil
+}
+
+// BaselineResourceList represents list of baseline resources with pagination
+type BaselineResourceList struct {
+	Page
+	Items []BaselineResource `json:"items"`
+}
+
+// BenchmarkComparison compares the primary score of a benchmark of a job with its baseline.
+// Delta is the primary score minus the baseline primary score, and the benchmark regressed
+// when it is negative, or positive for a primary score that is better when lower.
+type BenchmarkComparison struct {
+	ID                   string   `json:"id"`
+	ProviderID           string   `json:"provider_id"`
+	PrimaryScoreMetric   string   `json:"primary_score_metric,omitempty"`
+	PrimaryScore         *float32 `json:"primary_score,omitempty"`
+	BaselinePrimaryScore *float32 `json:"baseline_primary_score,omitempty"`
+	Delta                *float32 `json:"delta,omitempty"`
+	LowerIsBetter        bool     `json:"lower_is_better,omitempty"`
+	Regressed            bool     `json:"regressed"`
+}
+
+// BaselineComparison compares the results of a job with a named baseline. Delta is the
+// score minus the baseline score, and the job regressed when it is negative.
+type BaselineComparison struct {
+	JobID         string                `json:"job_id"`
+	Baseline      string                `json:"baseline"`
+	BaselineJobID string                `json:"baseline_job_id"`
+	Score         *float32              `json:"score,omitempty"`
+	BaselineScore float32               `json:"baseline_score"`
+	Delta         *float32              `json:"delta,omitempty"`
+	Regressed     bool                  `json:"regressed"`
+	Benchmarks    []BenchmarkComparison `json:"benchmarks,omitempty"`
+}
+
+// NewBaselineComparison compares the job with the baseline. The benchmarks of the job are
+// matched with the baseline benchmarks by provider and ID.
+func NewBaselineComparison(job *EvaluationJobResource, baseline *BaselineResource) *BaselineComparison {
+	comparison := &BaselineComparison{
+		JobID:         job.Resource.ID,
+		Baseline:      baseline.Name,
+		BaselineJobID: baseline.JobID,
+		BaselineScore: baseline.Score,
+	}
+	if job.Results == nil {
+		return comparison
+	}
+	if job.Results.Test != nil {
+		score := job.Results.Test.Score
+		delta := score - baseline.Score
+		comparison.Score = &score
+		comparison.Delta = &delta
+		comparison.Regressed = delta < 0
+	}
+	for _, result := range job.Results.Benchmarks {
+		benchmark := BenchmarkComparison{
+			ID:         result.ID,
+			ProviderID: result.ProviderID,
+		}
+		if result.Test != nil {
+			score := result.Test.PrimaryScore
+			benchmark.PrimaryScoreMetric = result.Test.PrimaryScoreMetric
+			benchmark.PrimaryScore = &score
+			benchmark.LowerIsBetter = result.Test.LowerIsBetter
+		}
+		if base := baseline.GetBenchmark(result.ProviderID, result.ID); base != nil {
+			baseScore := base.PrimaryScore
+			benchmark.BaselinePrimaryScore = &baseScore
+			if benchmark.PrimaryScore != nil {
+				delta := *benchmark.PrimaryScore - baseScore
+				benchmark.Delta = &delta
+				benchmark.Regressed = (delta < 0 && !benchmark.LowerIsBetter) || (delta > 0 && benchmark.LowerIsBetter)
+			}
+		}
+		comparison.Benchmarks = append(comparison.Benchmarks, benchmark)
+	}
+	return comparison
+}
@@ -3,13 +3,16 @@ package api
 // CollectionBenchmarkConfig describes a benchmark entry in a collection. The url field is set by the server on read when known.
 type CollectionBenchmarkConfig struct {
 	Ref          `mapstructure:",squash"`
-	ProviderID   string         `mapstructure:"provider_id" json:"provider_id" validate:"required"`
-	URL          string         `mapstructure:"url,omitempty" json:"url,omitempty"`
-	Weight       float32        `mapstructure:"weight" json:"weight,omitempty" validate:"omitempty,min=0"`
-	PrimaryScore *PrimaryScore  `mapstructure:"primary_score" json:"primary_score,omitempty"`
-	PassCriteria *PassCriteria  `mapstructure:"pass_criteria" json:"pass_criteria,omitempty"`
-	Parameters   map[string]any `mapstructure:"parameters" json:"parameters,omitempty"`
-	TestDataRef  *TestDataRef   `mapstructure:"test_data_ref" json:"test_data_ref,omitempty"`
+	ProviderID   string              `mapstructure:"provider_id" json:"provider_id" validate:"required"`
+	URL          string              `mapstructure:"url,omitempty" json:"url,omitempty"`
+	Weight       float32             `mapstructure:"weight" json:"weight,omitempty" validate:"omitempty,min=0"`
+	PrimaryScore *PrimaryScore       `mapstructure:"primary_score" json:"primary_score,omitempty"`
+	PassCriteria *PassCriteria       `mapstructure:"pass_criteria" json:"pass_criteria,omitempty"`
+	Required     *bool               `mapstructure:"required" json:"required,omitempty"`
+	Retry        *RetryPolicy        `mapstructure:"retry" json:"retry,omitempty"`
+	Parameters   map[string]any      `mapstructure:"parameters" json:"parameters,omitempty"`
+	TestDataRef  *TestDataRef        `mapstructure:"test_data_ref" json:"test_data_ref,omitempty"`
+	Conversation *ConversationConfig `mapstructure:"conversation" json:"conversation,omitempty"`
 }
 
 // ToEvaluationBenchmark returns the benchmark spec for evaluation jobs and runtime (strips collection-only url).
@@ -20,8 +23,11 @@ func (b CollectionBenchmarkConfig) ToEvaluationBenchmark() EvaluationBenchmarkCo
 		Weight:       b.Weight,
 		PrimaryScore: b.PrimaryScore,
 		PassCriteria: b.PassCriteria,
+		Required:     b.Required,
+		Retry:        b.Retry,
 		Parameters:   b.Parameters,
 		TestDataRef:  b.TestDataRef,
+		Conversation: b.Conversation,
 	}
 }
 
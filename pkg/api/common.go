@@ -47,9 +47,15 @@ type HRef struct {
 
 // Error represents an error response
 type Error struct {
+	// Code is the stable error code to branch on, e.g. EVAL_RESOURCE_NOT_FOUND.
+	Code        string `json:"code"`
 	MessageCode string `json:"message_code"`
 	Message     string `json:"message"`
-	Trace       string `json:"trace"`
+	// DocsURL links to the description of the error code.
+	DocsURL string `json:"docs_url,omitempty"`
+	// Retriable is true when the request may succeed if it is retried unchanged.
+	Retriable bool   `json:"retriable"`
+	Trace     string `json:"trace"`
 }
 
 // PatchOperation represents a single patch operation
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -45,6 +46,9 @@ const (
 	OverallStateFailed          OverallState = OverallState(StateFailed)
 	OverallStateCancelled       OverallState = OverallState(StateCancelled)
 	OverallStatePartiallyFailed OverallState = "partially_failed"
+	// OverallStateWaitingForModel is the state of a job with wait_for_model until its model
+	// endpoint reports ready and its benchmarks are launched.
+	OverallStateWaitingForModel OverallState = "waiting_for_model"
 )
 
 func (o OverallState) String() string {
@@ -59,6 +63,8 @@ func GetOverallState(s string) (OverallState, error) {
 	switch s {
 	case string(OverallStatePending):
 		return OverallStatePending, nil
+	case string(OverallStateWaitingForModel):
+		return OverallStateWaitingForModel, nil
 	case string(OverallStateRunning):
 		return OverallStateRunning, nil
 	case string(OverallStateCompleted):
@@ -76,17 +82,30 @@ func GetOverallState(s string) (OverallState, error) {
 
 // ModelRef represents model specification for evaluation requests
 type ModelRef struct {
-	URL        string         `json:"url" validate:"required"`
-	Name       string         `json:"name" validate:"required"`
-	Auth       *ModelAuth     `json:"auth,omitempty"`
-	Parameters map[string]any `json:"parameters,omitempty"`
-	CardURL    string         `json:"card_url,omitempty"`
+	URL  string `json:"url" validate:"required_without=InferenceService,excluded_with=InferenceService"`
+	Name string `json:"name" validate:"required"`
+	// InferenceService is resolved to the URL of the model by the Kubernetes runtime.
+	InferenceService *InferenceServiceRef `json:"inference_service,omitempty"`
+	Auth             *ModelAuth           `json:"auth,omitempty"`
+	Parameters       map[string]any       `json:"parameters,omitempty"`
+	CardURL          string               `json:"card_url,omitempty"`
 }
 
 type ModelAuth struct {
 	SecretRef string `json:"secret_ref" validate:"required"`
 }
 
+// InferenceServiceRef references a KServe InferenceService that serves the model. The
+// namespace defaults to the namespace of the tenant.
+type InferenceServiceRef struct {
+	Name      string `json:"name" validate:"required,rfc1123_dns_label"`
+	Namespace string `json:"namespace,omitempty" validate:"omitempty,rfc1123_dns_label"`
+	// AuthTokenSecret is a secret whose token key is sent as the bearer token of the model
+	// requests, e.g. the token of the service account allowed to query an InferenceService
+	// with authentication enabled. When empty the service account token of the job is sent.
+	AuthTokenSecret string `json:"auth_token_secret,omitempty"`
+}
+
 // MessageOrigin represents the origin of a status or error message.
 type MessageOrigin string
 
@@ -102,6 +121,36 @@ type MessageInfo struct {
 	Message       string        `json:"message" validate:"required"`
 	MessageCode   string        `json:"message_code" validate:"required"`
 	MessageOrigin MessageOrigin `json:"message_origin,omitempty"`
+	// FailureClass and Diagnostics are set on the error message of a benchmark whose
+	// workload the runtime found failed, from the state of its pod.
+	FailureClass FailureClass        `json:"failure_class,omitempty"`
+	Diagnostics  *FailureDiagnostics `json:"diagnostics,omitempty"`
+}
+
+// FailureClass is why the workload of a benchmark failed.
+type FailureClass string
+
+const (
+	// FailureClassOOMKilled is a container killed for using more memory than its limit.
+	FailureClassOOMKilled FailureClass = "oom_killed"
+	// FailureClassEvicted is a pod evicted from its node, e.g. under resource pressure.
+	FailureClassEvicted FailureClass = "evicted"
+	// FailureClassDeadlineExceeded is a workload stopped at its active deadline.
+	FailureClassDeadlineExceeded FailureClass = "deadline_exceeded"
+	// FailureClassError is a container that exited with a non-zero exit code.
+	FailureClassError FailureClass = "error"
+	// FailureClassUnknown is a workload that failed for a reason that is not known.
+	FailureClassUnknown FailureClass = "unknown"
+)
+
+// FailureDiagnostics are the details of the pod of a failed benchmark workload.
+type FailureDiagnostics struct {
+	Pod       string `json:"pod,omitempty"`
+	Container string `json:"container,omitempty"`
+	ExitCode  *int32 `json:"exit_code,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	// Logs are the last lines of the logs of the container.
+	Logs string `json:"logs,omitempty"`
 }
 
 // WithMessageOrigin sets origin on message and returns it (nil-safe).
@@ -338,14 +387,63 @@ func pathSegmentsEqual(a, b []string) bool {
 	return true
 }
 
+// PrimaryScore is the score of a benchmark that its pass criteria apply to: a single metric,
+// the weighted average of several metrics, or an arithmetic expression of metrics such as
+// 0.5*acc + 0.5*f1. Exactly one of Metric, Metrics and Expression is set.
 type PrimaryScore struct {
-	Metric        string `mapstructure:"metric" json:"metric" validate:"required"`
-	LowerIsBetter bool   `mapstructure:"lower_is_better" json:"lower_is_better,omitempty" validate:"omitempty,boolean"`
+	Metric        string           `mapstructure:"metric" json:"metric,omitempty" validate:"required_without_all=Metrics Expression,excluded_with=Metrics Expression"`
+	Metrics       []WeightedMetric `mapstructure:"metrics" json:"metrics,omitempty" validate:"omitempty,excluded_with=Expression,dive"`
+	Expression    string           `mapstructure:"expression" json:"expression,omitempty" validate:"omitempty,max=1024"`
+	LowerIsBetter bool             `mapstructure:"lower_is_better" json:"lower_is_better,omitempty" validate:"omitempty,boolean"`
+}
+
+// WeightedMetric is a metric of a composite primary score. A weight of 0 counts as 1.
+type WeightedMetric struct {
+	Metric string  `mapstructure:"metric" json:"metric" validate:"required"`
+	Weight float32 `mapstructure:"weight" json:"weight,omitempty" validate:"omitempty,min=0"`
+}
+
+// IsSet reports whether the primary score defines a metric, several metrics or an expression.
+func (p *PrimaryScore) IsSet() bool {
+	return p != nil && (p.Metric != "" || len(p.Metrics) > 0 || p.Expression != "")
+}
+
+// IsComposite reports whether the primary score combines several metrics.
+func (p *PrimaryScore) IsComposite() bool {
+	return p.IsSet() && p.Metric == ""
+}
+
+// Name returns the label of the primary score: the metric, the expression, or the weighted
+// metrics, e.g. weighted_mean(acc=0.5, f1=0.5).
+func (p *PrimaryScore) Name() string {
+	switch {
+	case p == nil:
+		return ""
+	case p.Metric != "":
+		return p.Metric
+	case p.Expression != "":
+		return p.Expression
+	}
+	terms := make([]string, 0, len(p.Metrics))
+	for _, metric := range p.Metrics {
+		weight := metric.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		terms = append(terms, metric.Metric+"="+strconv.FormatFloat(float64(weight), 'g', -1, 32))
+	}
+	return "weighted_mean(" + strings.Join(terms, ", ") + ")"
 }
 
 type PassCriteria struct {
 	// The *float32 is a hack to avoid validation failure when threshold=0
-	Threshold *float32 `mapstructure:"threshold" json:"threshold" validate:"required"`
+	Threshold *float32 `mapstructure:"threshold" json:"threshold,omitempty" validate:"required_without=MustNotRegress"`
+	// MustNotRegress is the name of a baseline whose score must be matched, in addition to
+	// the threshold when one is set.
+	MustNotRegress string `mapstructure:"must_not_regress" json:"must_not_regress,omitempty" validate:"omitempty,rfc1123_dns_label"`
+	// ReviewBand requires a human review of the job when its score is within the band of the
+	// threshold, either side of it. It applies to the pass criteria of a job or a collection.
+	ReviewBand *float32 `mapstructure:"review_band" json:"review_band,omitempty" validate:"omitempty,gt=0,lte=1"`
 }
 
 // S3TestDataRef represents S3 source for test data.
@@ -379,6 +477,29 @@ type BenchmarkHardwareConfig struct {
 	HardwareProfileRef HardwareProfileRef `mapstructure:"hardware_profile_ref" json:"hardware_profile_ref,omitempty"`
 }
 
+// ConversationConfig holds the chat settings of a benchmark that evaluates the model as a chat
+// model. It is handed to the adapter in the conversation field of the job spec, so that every
+// provider reads the same settings.
+type ConversationConfig struct {
+	// SystemPrompt is sent as the system message of every conversation.
+	SystemPrompt string `mapstructure:"system_prompt" json:"system_prompt,omitempty" validate:"omitempty,max=32768"`
+	// ChatTemplate names the chat template of the model that the prompts are rendered with,
+	// the default template of the model when empty.
+	ChatTemplate string `mapstructure:"chat_template" json:"chat_template,omitempty" validate:"omitempty,max=256"`
+	// MultiTurn evaluates the model on conversations of several turns, each turn answered by
+	// the model before the next one is sent.
+	MultiTurn bool `mapstructure:"multi_turn" json:"multi_turn,omitempty"`
+	// MaxTurns bounds the turns of a multi-turn conversation.
+	MaxTurns int `mapstructure:"max_turns" json:"max_turns,omitempty" validate:"omitempty,min=1,max=100,excluded_without=MultiTurn"`
+	// FewshotAsMultiturn sends the few-shot examples as turns of the conversation rather than
+	// in the first message.
+	FewshotAsMultiturn bool `mapstructure:"fewshot_as_multiturn" json:"fewshot_as_multiturn,omitempty"`
+}
+
+// ConversationParameters are the benchmark parameters that set what a ConversationConfig
+// sets. A benchmark with a conversation must not set them.
+var ConversationParameters = []string{"system_prompt", "system_instruction", "chat_template", "apply_chat_template", "multi_turn", "max_turns", "fewshot_as_multiturn"}
+
 // EvaluationBenchmarkConfig represents a benchmark reference in an evaluation job request or persisted job config.
 type EvaluationBenchmarkConfig struct {
 	Ref            `mapstructure:",squash"`
@@ -389,6 +510,35 @@ type EvaluationBenchmarkConfig struct {
 	HardwareConfig *BenchmarkHardwareConfig `mapstructure:"hardware_config" json:"hardware_config,omitempty"`
 	Parameters     map[string]any           `mapstructure:"parameters" json:"parameters,omitempty"`
 	TestDataRef    *TestDataRef             `mapstructure:"test_data_ref" json:"test_data_ref,omitempty"`
+	Conversation   *ConversationConfig      `mapstructure:"conversation" json:"conversation,omitempty"`
+	// Shards splits the benchmark across this many pods on the Kubernetes runtime; the
+	// metrics of the shards are merged into one benchmark result.
+	Shards int `mapstructure:"shards" json:"shards,omitempty" validate:"omitempty,min=1,max=100"`
+	// DependsOn lists the indices of the benchmarks of the job that must complete before this
+	// benchmark is started. The artifacts they report are passed on in its job spec.
+	DependsOn []int `mapstructure:"depends_on" json:"depends_on,omitempty" validate:"omitempty,dive,min=0"`
+	// Required is set to false for an optional benchmark: when it fails the job test is still
+	// computed from the benchmarks that completed. Benchmarks are required by default.
+	Required *bool `mapstructure:"required" json:"required,omitempty"`
+	// Retry retries the benchmark on new runtime resources when it fails with a transient error.
+	Retry *RetryPolicy `mapstructure:"retry" json:"retry,omitempty"`
+}
+
+// RetryPolicy is how a benchmark that fails with a transient error, e.g. an unavailable model
+// or a pod killed for running out of memory, is retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of times the benchmark is retried after its first run.
+	MaxRetries int `mapstructure:"max_retries" json:"max_retries" validate:"min=1,max=10"`
+	// BackoffSeconds is the wait before the first retry, doubled for each retry after it.
+	BackoffSeconds int `mapstructure:"backoff_seconds" json:"backoff_seconds,omitempty" validate:"omitempty,min=0,max=3600"`
+	// RetryOn lists the message codes of the failures that are retried; the failures known
+	// to be transient are retried when it is empty.
+	RetryOn []string `mapstructure:"retry_on" json:"retry_on,omitempty" validate:"omitempty,max=20,dive,required"`
+}
+
+// IsRequired reports whether the job fails its test when the benchmark fails.
+func (b *EvaluationBenchmarkConfig) IsRequired() bool {
+	return b.Required == nil || *b.Required
 }
 
 // ExperimentTag represents a tag on an experiment
@@ -402,6 +552,10 @@ type ExperimentConfig struct {
 	Name             string          `json:"name,omitempty" validate:"notblank"`
 	Tags             []ExperimentTag `json:"tags,omitempty" validate:"omitempty,max=20,dive"`
 	ArtifactLocation string          `json:"artifact_location,omitempty"`
+	// Workspace is the MLflow workspace of the experiment and the runs of the job, among the
+	// workspaces of its tenant. Without it the job writes to the default workspace of the
+	// tenant, which the server sets here.
+	Workspace string `json:"workspace,omitempty" validate:"omitempty,max=63"`
 }
 
 // for marshalling and unmarshalling
@@ -426,6 +580,106 @@ type BenchmarkStatus struct {
 	WarningMessage *MessageInfo `json:"warning_message,omitempty"`
 	StartedAt      DateTime     `json:"started_at,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
 	CompletedAt    DateTime     `json:"completed_at,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	// Shards is the progress of each shard of a sharded benchmark.
+	Shards []BenchmarkShardStatus `json:"shards,omitempty"`
+	// Attempts are the earlier runs of a benchmark that was retried, oldest first.
+	Attempts []BenchmarkAttempt `json:"attempts,omitempty"`
+	// Placement is where the runtime runs the latest run of the benchmark, to find its
+	// workload, logs and artifacts.
+	Placement *BenchmarkPlacement `json:"placement,omitempty"`
+
+	// EventID and Sequence are of the last event applied to the benchmark, to ignore the
+	// events that are retried or arrive out of order. Sequence is reset when it is retried.
+	EventID  string `json:"event_id,omitempty"`
+	Sequence int64  `json:"sequence,omitempty"`
+}
+
+// BenchmarkPlacement is where a runtime runs a benchmark, as reported by the runtime. The
+// fields that do not apply to the runtime are empty.
+type BenchmarkPlacement struct {
+	Runtime string `json:"runtime,omitempty"`
+	// Cluster is the remote cluster of a benchmark of the kubernetes runtime, empty on the
+	// cluster of the service.
+	Cluster   string `json:"cluster,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// JobName is the Kubernetes Job of the benchmark.
+	JobName  string `json:"job_name,omitempty"`
+	PodName  string `json:"pod_name,omitempty"`
+	NodeName string `json:"node_name,omitempty"`
+	// StartedAt and FinishedAt are when the pod, or the process, of the benchmark started
+	// and finished, as seen by the cluster or the host.
+	StartedAt  DateTime `json:"started_at,omitempty"`
+	FinishedAt DateTime `json:"finished_at,omitempty"`
+	// PID is the process of a benchmark of the local runtime, LogPath its log file.
+	PID     int    `json:"pid,omitempty"`
+	LogPath string `json:"log_path,omitempty"`
+}
+
+// Merge returns the placement with the fields set in update replacing its own. A placement
+// of another workload, e.g. of the retry of the benchmark, replaces it entirely.
+func (p *BenchmarkPlacement) Merge(update *BenchmarkPlacement) *BenchmarkPlacement {
+	if update == nil {
+		return p
+	}
+	if p == nil || (update.JobName != "" && update.JobName != p.JobName) || (update.PID != 0 && update.PID != p.PID) {
+		merged := *update
+		return &merged
+	}
+	merged := *p
+	for _, field := range []struct {
+		target *string
+		value  string
+	}{
+		{&merged.Runtime, update.Runtime},
+		{&merged.Cluster, update.Cluster},
+		{&merged.Namespace, update.Namespace},
+		{&merged.PodName, update.PodName},
+		{&merged.NodeName, update.NodeName},
+		{&merged.LogPath, update.LogPath},
+	} {
+		if field.value != "" {
+			*field.target = field.value
+		}
+	}
+	if update.StartedAt != "" {
+		merged.StartedAt = update.StartedAt
+	}
+	if update.FinishedAt != "" {
+		merged.FinishedAt = update.FinishedAt
+	}
+	return &merged
+}
+
+// BenchmarkAttempt is a run of a benchmark that failed and was retried.
+type BenchmarkAttempt struct {
+	Attempt      int          `json:"attempt"`
+	ErrorMessage *MessageInfo `json:"error_message,omitempty"`
+	StartedAt    DateTime     `json:"started_at,omitempty"`
+	FailedAt     DateTime     `json:"failed_at,omitempty"`
+}
+
+// BenchmarkShardStatus is the status and metrics reported by one shard of a benchmark.
+type BenchmarkShardStatus struct {
+	ShardIndex     int            `json:"shard_index"`
+	Status         State          `json:"status,omitempty"`
+	Phase          JobPhase       `json:"phase,omitempty"`
+	Metrics        map[string]any `json:"metrics,omitempty"`
+	AdditionalInfo map[string]any `json:"additional_info,omitempty"`
+	Artifacts      map[string]any `json:"artifacts,omitempty"`
+	ErrorMessage   *MessageInfo   `json:"error_message,omitempty"`
+	MLFlowRunID    string         `json:"mlflow_run_id,omitempty"`
+	LogsPath       string         `json:"logs_path,omitempty"`
+	StartedAt      DateTime       `json:"started_at,omitempty"`
+	CompletedAt    DateTime       `json:"completed_at,omitempty"`
+	// MLFlowRunURL is the URL of the MLflow run of the shard in the MLflow UI.
+	MLFlowRunURL string `json:"mlflow_run_url,omitempty"`
+	// ProcessedMetrics are the metrics of the shard after the configured results
+	// post-processors, merged like the metrics when the benchmark completes.
+	ProcessedMetrics map[string]any `json:"processed_metrics,omitempty"`
+
+	// EventID and Sequence are of the last event applied to the shard.
+	EventID  string `json:"event_id,omitempty"`
+	Sequence int64  `json:"sequence,omitempty"`
 }
 
 // BenchmarkStatusEvent is used when the job runtime needs to update the status of a benchmark
@@ -444,10 +698,33 @@ type BenchmarkStatusEvent struct {
 	CompletedAt    DateTime       `json:"completed_at,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
 	MLFlowRunID    string         `json:"mlflow_run_id,omitempty"`
 	LogsPath       string         `json:"logs_path,omitempty"`
+	// ShardIndex is set by the adapters of a sharded benchmark, from the shard in their job spec.
+	ShardIndex *int `json:"shard_index,omitempty" validate:"omitempty,min=0"`
+	// Findings are the safety findings of the benchmark, reported with its terminal status.
+	Findings []Finding `json:"findings,omitempty" validate:"omitempty,max=10000,dive"`
+	// EventID is an idempotency key of the event: an event with the ID of the last event
+	// applied to the benchmark, or to the shard, is a retry and is ignored.
+	EventID string `json:"event_id,omitempty" validate:"omitempty,max=256"`
+	// Sequence numbers the events of the adapter of a benchmark, or of a shard, increasing in
+	// the order they were sent. An event with a sequence that is not above the last one applied
+	// is a retry or arrived out of order, and is ignored. Sequences are only compared between
+	// events whose EventID has the same source, the part before its last colon, so that a new
+	// run of the adapter, e.g. in a restarted pod, can number its events from the start.
+	Sequence int64 `json:"sequence,omitempty" validate:"omitempty,min=0"`
+	// CachedFrom is the job whose result is reused for this benchmark. It is set by
+	// the server only, never decoded from a runtime status update.
+	CachedFrom string `json:"-"`
+	// ProcessedMetrics are the metrics after the configured results post-processors. They
+	// are set by the server only, never decoded from a runtime status update.
+	ProcessedMetrics map[string]any `json:"-"`
+	// MLFlowRunURL is the URL of the MLflow run in the MLflow UI, once the server verified
+	// that the run exists. It is set by the server only, never decoded from a runtime status
+	// update.
+	MLFlowRunURL string `json:"-"`
 }
 
 type EvaluationJobState struct {
-	State   OverallState `json:"state" validate:"required,oneof=pending running completed failed cancelled partially_failed"`
+	State   OverallState `json:"state" validate:"required,oneof=pending waiting_for_model running completed failed cancelled partially_failed"`
 	Message *MessageInfo `json:"message" validate:"required"`
 }
 
@@ -455,6 +732,21 @@ type StatusEvent struct {
 	BenchmarkStatusEvent *BenchmarkStatusEvent `json:"benchmark_status_event" validate:"required"`
 }
 
+const (
+	// StatusEventDuplicate is an event that was already applied, e.g. posted again by an
+	// adapter that did not get the response.
+	StatusEventDuplicate = "duplicate"
+	// StatusEventOutOfOrder is an event older than the last one applied, e.g. a running
+	// event that arrives after the completed one.
+	StatusEventOutOfOrder = "out_of_order"
+)
+
+// BenchmarkRetry is a benchmark to start again after a transient failure.
+type BenchmarkRetry struct {
+	BenchmarkIndex int
+	Backoff        time.Duration
+}
+
 type BenchmarkResult struct {
 	ID             string         `json:"id"`
 	ProviderID     string         `json:"provider_id"`
@@ -466,6 +758,20 @@ type BenchmarkResult struct {
 	MLFlowRunID    string         `json:"mlflow_run_id,omitempty"`
 	LogsPath       string         `json:"logs_path,omitempty"`
 	Test           *BenchmarkTest `json:"test,omitempty"`
+	// MLFlowRunURL is the URL of the MLflow run of the benchmark in the MLflow UI, in the
+	// MLflow workspace of the tenant.
+	MLFlowRunURL string `json:"mlflow_run_url,omitempty"`
+	// Cached is true when the result was reused from an earlier job instead of being
+	// re-run; CachedFrom is the ID of that job.
+	Cached     bool   `json:"cached,omitempty"`
+	CachedFrom string `json:"cached_from,omitempty"`
+	// ProcessedMetrics are the metrics after the results post-processors configured for the
+	// provider or tenant of the job, e.g. renamed or derived metrics; Metrics stay as the
+	// runtime reported them.
+	ProcessedMetrics map[string]any `json:"processed_metrics,omitempty"`
+	// Findings counts the safety findings of the benchmark by severity, the findings are
+	// listed with GET /api/v1/evaluations/jobs/{id}/findings.
+	Findings *FindingsSummary `json:"findings,omitempty"`
 }
 
 // EvaluationJobResults represents results section for EvaluationJobResource
@@ -473,6 +779,30 @@ type EvaluationJobResults struct {
 	Test                *EvaluationTest   `json:"test,omitempty"`
 	Benchmarks          []BenchmarkResult `json:"benchmarks,omitempty" validate:"omitempty,dive"`
 	MLFlowExperimentURL string            `json:"mlflow_experiment_url,omitempty"`
+	// Findings counts the safety findings of all the benchmarks by severity.
+	Findings *FindingsSummary `json:"findings,omitempty"`
+	// Model is the metadata of the model that was evaluated, as reported by its server when
+	// the job started.
+	Model *ModelMetadata `json:"model,omitempty"`
+}
+
+// ModelMetadata describes the model that a job evaluated, beyond the URL of its endpoint:
+// what the OpenAI-compatible endpoints of the model server report, completed by the
+// eval-hub.github.io/model-* annotations of its InferenceService.
+type ModelMetadata struct {
+	// ServedModelID is the id of the model in the models list of the server.
+	ServedModelID string `json:"served_model_id,omitempty"`
+	// BaseModel is the model that the served model was loaded from, e.g. a Hugging Face id.
+	BaseModel        string   `json:"base_model,omitempty"`
+	Quantization     string   `json:"quantization,omitempty"`
+	MaxContextLength int      `json:"max_context_length,omitempty"`
+	ServerVersion    string   `json:"server_version,omitempty"`
+	CapturedAt       DateTime `json:"captured_at"`
+}
+
+// IsEmpty returns true when no metadata of the model is known.
+func (m *ModelMetadata) IsEmpty() bool {
+	return m == nil || (m.ServedModelID == "" && m.BaseModel == "" && m.Quantization == "" && m.MaxContextLength == 0 && m.ServerVersion == "")
 }
 
 // OCICoordinates represents OCI artifact coordinates for persistence
@@ -516,6 +846,94 @@ type QueueConfig struct {
 	Name string `json:"name" validate:"required,rfc1123_dns_label"`
 }
 
+const (
+	DefaultWaitForModelTimeout      = 30 * time.Minute
+	DefaultWaitForModelPollInterval = 10 * time.Second
+)
+
+// WaitForModel defers launching the benchmarks of a job until its model endpoint is ready,
+// e.g. for a job triggered right after the model is deployed. The job is waiting_for_model
+// meanwhile, and fails when the endpoint is not ready within the timeout.
+type WaitForModel struct {
+	// URL is the base URL of the model endpoint, the URL of the model when empty.
+	URL string `json:"url,omitempty" validate:"omitempty,http_url,max=2048"`
+	// ReadinessPath is appended to the URL, e.g. /health. The endpoint is ready when a GET
+	// of the readiness URL answers with a 2xx status.
+	ReadinessPath       string `json:"readiness_path,omitempty" validate:"omitempty,startswith=/,max=2048"`
+	TimeoutSeconds      int    `json:"timeout_seconds,omitempty" validate:"omitempty,min=1,max=86400"`
+	PollIntervalSeconds int    `json:"poll_interval_seconds,omitempty" validate:"omitempty,min=1,max=3600"`
+}
+
+// ReadinessURL returns the URL polled for the readiness of the model at modelURL.
+func (w *WaitForModel) ReadinessURL(modelURL string) string {
+	base := w.URL
+	if base == "" {
+		base = modelURL
+	}
+	if w.ReadinessPath == "" {
+		return base
+	}
+	return strings.TrimSuffix(base, "/") + w.ReadinessPath
+}
+
+// EffectiveTimeout returns the configured timeout or DefaultWaitForModelTimeout.
+func (w *WaitForModel) EffectiveTimeout() time.Duration {
+	if w.TimeoutSeconds <= 0 {
+		return DefaultWaitForModelTimeout
+	}
+	return time.Duration(w.TimeoutSeconds) * time.Second
+}
+
+// EffectivePollInterval returns the configured poll interval or DefaultWaitForModelPollInterval.
+func (w *WaitForModel) EffectivePollInterval() time.Duration {
+	if w.PollIntervalSeconds <= 0 {
+		return DefaultWaitForModelPollInterval
+	}
+	return time.Duration(w.PollIntervalSeconds) * time.Second
+}
+
+// JobLinkType is the kind of resource a job link points to
+type JobLinkType string
+
+const (
+	JobLinkTypeTicket      JobLinkType = "ticket"
+	JobLinkTypePullRequest JobLinkType = "pull_request"
+	JobLinkTypeModelCard   JobLinkType = "model_card"
+	JobLinkTypeIncident    JobLinkType = "incident"
+	JobLinkTypeOther       JobLinkType = "other"
+)
+
+// JobLink links a job to the change, ticket or incident that triggered it.
+type JobLink struct {
+	Type  JobLinkType `json:"type" validate:"required,oneof=ticket pull_request model_card incident other"`
+	URL   string      `json:"url" validate:"required,http_url,max=2048"`
+	Title string      `json:"title,omitempty" validate:"omitempty,max=256"`
+}
+
+// EvaluationJobMetadata holds the fields of a job config that can be patched after the
+// job is created, to trace the job back to what triggered it.
+type EvaluationJobMetadata struct {
+	Annotations map[string]string `json:"annotations,omitempty" validate:"omitempty,max=64,dive,keys,annotationkey,endkeys,max=4096"`
+	Links       []JobLink         `json:"links,omitempty" validate:"omitempty,max=32,dive"`
+}
+
+// JobSharing lists the users and groups of the tenant that can read a job besides its
+// owner, when jobs are scoped to their owner.
+type JobSharing struct {
+	Users  []User   `json:"users,omitempty" validate:"omitempty,max=64,dive,required,max=255"`
+	Groups []string `json:"groups,omitempty" validate:"omitempty,max=64,dive,required,max=255"`
+}
+
+// IsEmpty returns true when the job is not shared with anyone.
+func (s *JobSharing) IsEmpty() bool {
+	return s == nil || (len(s.Users) == 0 && len(s.Groups) == 0)
+}
+
+// JobOwnership is the request to hand a job over to another user of the tenant.
+type JobOwnership struct {
+	Owner User `json:"owner" validate:"required,max=255"`
+}
+
 // EvaluationJobConfig represents evaluation job request schema
 type EvaluationJobConfig struct {
 	Name         string                      `json:"name" validate:"required"`
@@ -529,8 +947,113 @@ type EvaluationJobConfig struct {
 	Custom       *map[string]any             `json:"custom,omitempty"`
 	Exports      *EvaluationExports          `json:"exports,omitempty"`
 	Queue        *QueueConfig                `json:"queue,omitempty"`
+	WaitForModel *WaitForModel               `json:"wait_for_model,omitempty"`
+	// RAG configures the retrieval, embedding model and judge of RAG benchmarks.
+	RAG *RAGConfig `json:"rag,omitempty"`
+	// PodMetadata adds labels and annotations to the Kubernetes Job and Pod template of the
+	// benchmarks of the job, over the pod metadata of their providers.
+	PodMetadata *PodMetadata `json:"pod_metadata,omitempty"`
+	// JobPolicy overrides the fields it sets of the job policy of the providers of the
+	// benchmarks of the job, e.g. a longer retention of the finished Kubernetes Jobs.
+	JobPolicy *JobPolicy `json:"job_policy,omitempty"`
+	// Env are environment variables of the adapters of the benchmarks of the job, e.g. HF_TOKEN
+	// or a dataset mirror, over the env of their providers. The names, and the secrets of
+	// the secretRef:// values, must be in the job env allowlist of the tenant.
+	Env []JobEnvVar `json:"env,omitempty" validate:"omitempty,max=64,dive"`
+	// Spot runs the benchmarks of the job on the spot node pools of their providers, which
+	// are cheaper but can be reclaimed; the benchmarks of providers without one run on the
+	// regular nodes. Set a retry policy on the benchmarks to run them again when interrupted.
+	Spot bool `json:"spot,omitempty"`
+	EvaluationJobMetadata
+	// SharedWith are the users and groups that can read the job besides its owner.
+	SharedWith *JobSharing `json:"shared_with,omitempty"`
+	// Notify are the notifiers, or Slack channels of notifiers, that are sent a summary of
+	// the job when it reaches a terminal state, e.g. ["#ml-evals"].
+	Notify []string `json:"notify,omitempty" validate:"omitempty,max=16,dive,required,max=255"`
+	// ReuseCachedResults reuses the completed result of an identical model, benchmark
+	// and parameters evaluation, if one is within the result cache TTL.
+	ReuseCachedResults bool `json:"reuse_cached_results,omitempty"`
+	// Sweep creates one child job per configuration of the sweep parameters instead of
+	// this job.
+	Sweep *SweepConfig `json:"sweep,omitempty"`
+	// SweepRun is set by the server on the child jobs of a sweep.
+	SweepRun *SweepRun `json:"sweep_run,omitempty"`
+	// Runtime selects the runtime that runs all the benchmarks of the job among the runtimes
+	// enabled in the deployment. Without it, each benchmark runs on the enabled runtime that
+	// its provider declares.
+	Runtime string `json:"runtime,omitempty" validate:"omitempty,oneof=local kubernetes kfp argo lmevaljob mock"`
+	// Cluster selects the Kubernetes cluster that the benchmarks of the job run on among the
+	// remote clusters registered in the deployment, or local for the cluster of the service.
+	// Without it, the benchmarks run on the cluster of their provider or of the tenant.
+	Cluster string `json:"cluster,omitempty" validate:"omitempty,max=63"`
+	// StateAggregation is how the states of the benchmarks of the job roll up into the state
+	// of the job, best_effort without it.
+	StateAggregation *StateAggregation `json:"state_aggregation,omitempty"`
+	// FailFast cancels the benchmarks that did not finish, and stops their workloads, as soon
+	// as a benchmark fails. It is the fail_fast state aggregation policy.
+	FailFast bool `json:"fail_fast,omitempty"`
+}
+
+// Aggregation returns the state aggregation of the job, the fail_fast policy when the job
+// only sets FailFast.
+func (c *EvaluationJobConfig) Aggregation() *StateAggregation {
+	if c.StateAggregation == nil && c.FailFast {
+		return &StateAggregation{Policy: AggregationFailFast}
+	}
+	return c.StateAggregation
+}
+
+// The policies of the aggregation of the states of the benchmarks of a job.
+const (
+	// AggregationBestEffort waits for all the benchmarks: the job completes when they all
+	// completed, fails when they all failed, and is partially failed otherwise.
+	AggregationBestEffort = "best_effort"
+	// AggregationFailFast fails the job as soon as a benchmark fails, and cancels the
+	// benchmarks that did not finish.
+	AggregationFailFast = "fail_fast"
+	// AggregationQuorum waits for all the benchmarks: the job completes when at least
+	// Quorum of them completed, and fails otherwise.
+	AggregationQuorum = "quorum"
+)
+
+// StateAggregation is how the states of the benchmarks of a job roll up into the state of
+// the job, e.g. for a CI gate that fails on the first failure or that tolerates some.
+type StateAggregation struct {
+	Policy string `json:"policy" validate:"required,oneof=best_effort fail_fast quorum"`
+	// Quorum is the number of benchmarks that must complete for a job of the quorum policy
+	// to complete.
+	Quorum int `json:"quorum,omitempty" validate:"omitempty,min=1"`
+}
+
+// FailsFast reports whether the job fails on the first failed benchmark.
+func (a *StateAggregation) FailsFast() bool {
+	return a != nil && a.Policy == AggregationFailFast
+}
+
+// JobEnvVar is an environment variable of the adapters of a job. A value of the form
+// secretRef://name/key is read from the key of a secret when the benchmark starts, like a
+// parameter, and only the reference is stored with the job.
+type JobEnvVar struct {
+	Name  string `json:"name" validate:"required,max=255"`
+	Value string `json:"value" validate:"max=4096"`
+}
+
+// SecretRef returns the secret that the value of the variable references. It returns false
+// when the value is a literal, and an error when it is a malformed reference.
+func (e JobEnvVar) SecretRef() (SecretRef, bool, error) {
+	return ParseSecretRef(e.Value)
 }
 
+// The runtimes that a job can select.
+const (
+	RuntimeLocal      = "local"
+	RuntimeKubernetes = "kubernetes"
+	RuntimeKFP        = "kfp"
+	RuntimeArgo       = "argo"
+	RuntimeLMEvalJob  = "lmevaljob"
+	RuntimeMock       = "mock"
+)
+
 type EvaluationResource struct {
 	Resource
 	MLFlowExperimentID string `json:"mlflow_experiment_id,omitempty"`
@@ -539,6 +1062,17 @@ type EvaluationResource struct {
 type EvaluationJobStatus struct {
 	EvaluationJobState
 	Benchmarks []BenchmarkStatus `json:"benchmarks,omitempty"`
+	// AllowedNextStates are the states the job can move to from its state, none when the
+	// job is finished. They are set on the job returned by GET and not stored.
+	AllowedNextStates []OverallState `json:"allowed_next_states,omitempty"`
+	// EstimatedCompletionAt is when a job that is not finished is estimated to complete,
+	// from the durations of the previous runs of its benchmarks. It is set on the job
+	// returned by GET when all its benchmarks that have not finished have run before.
+	EstimatedCompletionAt DateTime `json:"estimated_completion_at,omitempty"`
+	// AdapterImages are the adapter images of the job pinned to their digest, by the image
+	// reference of the provider, when the service pins the adapter images. Every benchmark
+	// of the job, and every retry, runs the image that was pinned first.
+	AdapterImages map[string]string `json:"adapter_images,omitempty"`
 }
 
 // EvaluationJobResource represents evaluation job resource response
@@ -549,6 +1083,15 @@ type EvaluationJobResource struct {
 	EvaluationJobConfig
 }
 
+// MLFlowWorkspace returns the MLflow workspace that the job writes to: the workspace of its
+// experiment, else the workspace named after its tenant.
+func (j *EvaluationJobResource) MLFlowWorkspace() string {
+	if j.Experiment != nil && j.Experiment.Workspace != "" {
+		return j.Experiment.Workspace
+	}
+	return j.Resource.Tenant.String()
+}
+
 // EvaluationJobResourceList represents list of evaluation job resources with pagination
 type EvaluationJobResourceList struct {
 	Page
@@ -560,6 +1103,16 @@ type EvaluationTest struct {
 	Score     float32 `json:"score"`
 	Threshold float32 `json:"threshold"`
 	Pass      bool    `json:"pass"`
+	// Baseline is the baseline the score must not regress from, see PassCriteria; the
+	// threshold includes its score. BaselineScore is unset when the baseline was not found.
+	Baseline      string   `json:"baseline,omitempty"`
+	BaselineScore *float32 `json:"baseline_score,omitempty"`
+	// Review is set when the score is within the review band of the threshold, Pass is then
+	// the decision of the reviewer, and false until the review is decided.
+	Review *Review `json:"review,omitempty"`
+	// FailedRequired lists the required benchmarks that failed or were cancelled; the job
+	// does not pass, whatever its score.
+	FailedRequired []string `json:"failed_required,omitempty"`
 }
 
 type BenchmarkTest struct {
@@ -567,4 +1120,10 @@ type BenchmarkTest struct {
 	PrimaryScoreMetric string  `json:"primary_score_metric"`
 	Threshold          float32 `json:"threshold"`
 	Pass               bool    `json:"pass"`
+	// Baseline is the baseline the primary score must not regress from, see EvaluationTest.
+	Baseline             string   `json:"baseline,omitempty"`
+	BaselinePrimaryScore *float32 `json:"baseline_primary_score,omitempty"`
+	// LowerIsBetter is the direction of the primary score, from the primary score of the
+	// benchmark or the metric that its provider declares.
+	LowerIsBetter bool `json:"lower_is_better,omitempty"`
 }
@@ -0,0 +1,108 @@
+package api
+
+import "slices"
+
+// Severity is the severity of a safety finding.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// Severities are the severities from the lowest to the highest.
+var Severities = []Severity{SeverityInfo, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+// Rank returns the rank of the severity, from 1 for info to 5 for critical, or 0 when the
+// severity is unknown.
+func (s Severity) Rank() int {
+	return slices.Index(Severities, s) + 1
+}
+
+// FindingExample is a prompt that produced a finding and the response of the model.
+type FindingExample struct {
+	Prompt   string `json:"prompt" validate:"max=16384"`
+	Response string `json:"response,omitempty" validate:"omitempty,max=16384"`
+}
+
+// Finding is a failure that a safety benchmark found, e.g. a garak probe whose attempts a
+// detector flagged.
+type Finding struct {
+	Probe       string   `json:"probe" validate:"required,max=256"`
+	Detector    string   `json:"detector,omitempty" validate:"omitempty,max=256"`
+	Severity    Severity `json:"severity" validate:"required,oneof=info low medium high critical"`
+	Description string   `json:"description,omitempty" validate:"omitempty,max=4096"`
+	// FailureRate is the fraction of the attempts of the probe that the detector flagged.
+	FailureRate *float64         `json:"failure_rate,omitempty" validate:"omitempty,min=0,max=1"`
+	Examples    []FindingExample `json:"examples,omitempty" validate:"omitempty,max=20,dive"`
+}
+
+// FindingResource is a finding of a benchmark of an evaluation job.
+type FindingResource struct {
+	JobID          string `json:"job_id"`
+	ProviderID     string `json:"provider_id"`
+	BenchmarkID    string `json:"benchmark_id"`
+	BenchmarkIndex int    `json:"benchmark_index"`
+	ShardIndex     *int   `json:"shard_index,omitempty"`
+	Finding
+}
+
+type FindingResourceList struct {
+	Page
+	Items []FindingResource `json:"items"`
+}
+
+// FindingsSummary counts the findings of a benchmark, or of all the benchmarks of a job, by
+// severity.
+type FindingsSummary struct {
+	Total           int              `json:"total"`
+	BySeverity      map[Severity]int `json:"by_severity,omitempty"`
+	HighestSeverity Severity         `json:"highest_severity,omitempty"`
+}
+
+// NewFindingsSummary returns the summary of the numbers of findings by severity, or nil when
+// there are none.
+func NewFindingsSummary(counts map[Severity]int) *FindingsSummary {
+	summary := &FindingsSummary{}
+	for severity, count := range counts {
+		summary.add(severity, count)
+	}
+	if summary.Total == 0 {
+		return nil
+	}
+	return summary
+}
+
+// Add returns the summary of the findings of both summaries, either of which may be nil.
+func (s *FindingsSummary) Add(other *FindingsSummary) *FindingsSummary {
+	if s == nil && other == nil {
+		return nil
+	}
+	sum := &FindingsSummary{}
+	for _, summary := range []*FindingsSummary{s, other} {
+		if summary == nil {
+			continue
+		}
+		for severity, count := range summary.BySeverity {
+			sum.add(severity, count)
+		}
+	}
+	return sum
+}
+
+func (s *FindingsSummary) add(severity Severity, count int) {
+	if count <= 0 {
+		return
+	}
+	if s.BySeverity == nil {
+		s.BySeverity = map[Severity]int{}
+	}
+	s.BySeverity[severity] += count
+	s.Total += count
+	if severity.Rank() > s.HighestSeverity.Rank() {
+		s.HighestSeverity = severity
+	}
+}
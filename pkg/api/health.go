@@ -11,3 +11,29 @@ type HealthResponse struct {
 	Uptime            time.Duration             `json:"uptime"`
 	ActiveEvaluations int                       `json:"active_evaluations,omitempty"`
 }
+
+// PermissionCheck is the result of the check of the Kubernetes permissions that the runtime
+// needs, made when the service starts and reported on /readyz.
+type PermissionCheck struct {
+	CheckedAt time.Time `json:"checked_at"`
+	// Missing are the permissions that the service account of the service lacks.
+	Missing []MissingPermission `json:"missing,omitempty"`
+	// Errors tell why the permissions could not be checked on a cluster.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// MissingPermission is a verb on a Kubernetes resource that the runtime needs and may not use.
+type MissingPermission struct {
+	// Cluster is the name of the cluster, local for the cluster of the service.
+	Cluster string `json:"cluster"`
+	// Namespace is empty for the resources of the cluster, e.g. nodes, or of all namespaces.
+	Namespace   string `json:"namespace,omitempty"`
+	Verb        string `json:"verb"`
+	Group       string `json:"group,omitempty"`
+	Resource    string `json:"resource"`
+	Subresource string `json:"subresource,omitempty"`
+	// NeededFor tells what fails without the permission.
+	NeededFor string `json:"needed_for"`
+	// Reason is the reason of the authorizer, when it gives one.
+	Reason string `json:"reason,omitempty"`
+}
@@ -0,0 +1,63 @@
+package api
+
+import "time"
+
+// MetricPoint is a metric of a completed benchmark of an evaluation job, one point of the
+// history of the metric of the model across jobs.
+type MetricPoint struct {
+	JobID          string    `json:"job_id"`
+	ModelName      string    `json:"model_name"`
+	ProviderID     string    `json:"provider_id"`
+	BenchmarkID    string    `json:"benchmark_id"`
+	BenchmarkIndex int       `json:"benchmark_index"`
+	Metric         string    `json:"metric"`
+	Value          float64   `json:"value"`
+	RecordedAt     time.Time `json:"recorded_at"`
+}
+
+type MetricPointList struct {
+	Page
+	Items []MetricPoint `json:"items"`
+}
+
+// The Grafana types are the requests and responses of the JSON datasource API, so that
+// Grafana dashboards can chart the history of the metrics with the JSON datasource plugin.
+
+// GrafanaSearchRequest lists the metrics that match the target, all when it is empty.
+type GrafanaSearchRequest struct {
+	Target string `json:"target,omitempty"`
+}
+
+// GrafanaQueryRequest queries the history of the metrics of the targets in the time range.
+type GrafanaQueryRequest struct {
+	Range   GrafanaRange    `json:"range"`
+	Targets []GrafanaTarget `json:"targets" validate:"dive"`
+}
+
+type GrafanaRange struct {
+	From time.Time `json:"from" validate:"required"`
+	To   time.Time `json:"to" validate:"required,gtefield=From"`
+}
+
+// GrafanaTarget is a metric of a query, optionally of a model, benchmark and provider given
+// in the payload of the target.
+type GrafanaTarget struct {
+	RefID   string                `json:"refId,omitempty"`
+	Target  string                `json:"target" validate:"required"`
+	Hide    bool                  `json:"hide,omitempty"`
+	Payload *GrafanaTargetPayload `json:"payload,omitempty"`
+}
+
+type GrafanaTargetPayload struct {
+	Model      string `json:"model,omitempty"`
+	Benchmark  string `json:"benchmark,omitempty"`
+	ProviderID string `json:"provider_id,omitempty"`
+}
+
+// GrafanaTimeSeries is a series of the values of a metric of a model and benchmark, each
+// datapoint being the value and the time in milliseconds since the epoch.
+type GrafanaTimeSeries struct {
+	Target     string       `json:"target"`
+	RefID      string       `json:"refId,omitempty"`
+	Datapoints [][2]float64 `json:"datapoints"`
+}
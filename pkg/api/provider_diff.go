@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// ProviderDiff lists the benchmarks of GET /api/v1/evaluations/providers/{id}/diff/{other_id}:
+// the ones only the other provider has, the ones only the provider has and the ones both
+// have with different definitions, e.g. between the system provider of a framework and the
+// import of its new version.
+type ProviderDiff struct {
+	From      string              `json:"from"`
+	To        string              `json:"to"`
+	Added     []BenchmarkResource `json:"added"`
+	Removed   []BenchmarkResource `json:"removed"`
+	Changed   []BenchmarkChange   `json:"changed"`
+	Unchanged int                 `json:"unchanged"`
+}
+
+// BenchmarkChange is a benchmark both providers have, with the fields that differ.
+type BenchmarkChange struct {
+	ID     string                 `json:"id"`
+	Fields []BenchmarkFieldChange `json:"fields"`
+}
+
+// BenchmarkFieldChange is a field of a benchmark that differs between two providers.
+// The parameters are compared one by one, as parameters.<name>; a field one of the
+// providers does not set has no value on its side.
+type BenchmarkFieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from,omitempty"`
+	To    any    `json:"to,omitempty"`
+}
+
+// benchmarkParametersField is the field of the benchmarks whose entries are compared one by one.
+const benchmarkParametersField = "parameters"
+
+// DiffProviders compares the benchmarks of two providers by id. The benchmarks are listed
+// in the order of the provider they come from.
+func DiffProviders(from, to *ProviderResource) *ProviderDiff {
+	diff := &ProviderDiff{
+		From:    from.Resource.ID,
+		To:      to.Resource.ID,
+		Added:   []BenchmarkResource{},
+		Removed: []BenchmarkResource{},
+		Changed: []BenchmarkChange{},
+	}
+
+	fromBenchmarks := make(map[string]BenchmarkResource, len(from.Benchmarks))
+	for _, benchmark := range from.Benchmarks {
+		fromBenchmarks[benchmark.ID] = benchmark
+	}
+	toBenchmarks := make(map[string]BenchmarkResource, len(to.Benchmarks))
+	for _, benchmark := range to.Benchmarks {
+		toBenchmarks[benchmark.ID] = benchmark
+	}
+
+	for _, benchmark := range from.Benchmarks {
+		other, ok := toBenchmarks[benchmark.ID]
+		if !ok {
+			diff.Removed = append(diff.Removed, benchmark)
+			continue
+		}
+		if fields := diffBenchmarks(benchmark, other); len(fields) > 0 {
+			diff.Changed = append(diff.Changed, BenchmarkChange{ID: benchmark.ID, Fields: fields})
+		} else {
+			diff.Unchanged++
+		}
+	}
+	for _, benchmark := range to.Benchmarks {
+		if _, ok := fromBenchmarks[benchmark.ID]; !ok {
+			diff.Added = append(diff.Added, benchmark)
+		}
+	}
+	return diff
+}
+
+// diffBenchmarks returns the fields that differ between two definitions of a benchmark,
+// sorted by name. The benchmarks are compared in their JSON form, so that parameters
+// decoded from JSON and from YAML compare alike.
+func diffBenchmarks(from, to BenchmarkResource) []BenchmarkFieldChange {
+	fromFields := benchmarkFields(from)
+	toFields := benchmarkFields(to)
+
+	fields := []BenchmarkFieldChange{}
+	for _, name := range unionKeys(fromFields, toFields) {
+		if name == benchmarkParametersField {
+			fromParameters, _ := fromFields[name].(map[string]any)
+			toParameters, _ := toFields[name].(map[string]any)
+			for _, parameter := range unionKeys(fromParameters, toParameters) {
+				if !reflect.DeepEqual(fromParameters[parameter], toParameters[parameter]) {
+					fields = append(fields, BenchmarkFieldChange{
+						Field: benchmarkParametersField + "." + parameter,
+						From:  fromParameters[parameter],
+						To:    toParameters[parameter],
+					})
+				}
+			}
+			continue
+		}
+		if !reflect.DeepEqual(fromFields[name], toFields[name]) {
+			fields = append(fields, BenchmarkFieldChange{Field: name, From: fromFields[name], To: toFields[name]})
+		}
+	}
+	slices.SortFunc(fields, func(a, b BenchmarkFieldChange) int {
+		return strings.Compare(a.Field, b.Field)
+	})
+	return fields
+}
+
+func benchmarkFields(benchmark BenchmarkResource) map[string]any {
+	fields := map[string]any{}
+	if data, err := json.Marshal(benchmark); err == nil {
+		_ = json.Unmarshal(data, &fields)
+	}
+	return fields
+}
+
+func unionKeys(a, b map[string]any) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	for key := range b {
+		if _, ok := a[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
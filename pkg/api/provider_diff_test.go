@@ -0,0 +1,50 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffProviders(t *testing.T) {
+	from := &ProviderResource{
+		Resource: Resource{ID: "lm_evaluation_harness"},
+		ProviderConfig: ProviderConfig{Benchmarks: []BenchmarkResource{
+			{ID: "arc_easy", Name: "ARC Easy", Category: "reasoning", Parameters: map[string]any{"num_fewshot": 0, "limit": 100}},
+			{ID: "mmlu", Name: "MMLU", Category: "knowledge"},
+			{ID: "gsm8k", Name: "GSM8K", Category: "math", Metrics: []string{"exact_match"}},
+		}},
+	}
+	to := &ProviderResource{
+		Resource: Resource{ID: "lm_evaluation_harness-v2"},
+		ProviderConfig: ProviderConfig{Benchmarks: []BenchmarkResource{
+			{ID: "arc_easy", Name: "ARC Easy", Category: "commonsense", Parameters: map[string]any{"num_fewshot": 5, "seed": 1234}},
+			{ID: "gsm8k", Name: "GSM8K", Category: "math", Metrics: []string{"exact_match"}},
+			{ID: "ifeval", Name: "IFEval", Category: "instruction_following"},
+		}},
+	}
+
+	diff := DiffProviders(from, to)
+
+	if diff.From != "lm_evaluation_harness" || diff.To != "lm_evaluation_harness-v2" || diff.Unchanged != 1 {
+		t.Fatalf("unexpected diff %+v", diff)
+	}
+	if len(diff.Added) != 1 || diff.Added[0].ID != "ifeval" {
+		t.Fatalf("expected ifeval to be added, got %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != "mmlu" {
+		t.Fatalf("expected mmlu to be removed, got %+v", diff.Removed)
+	}
+	want := []BenchmarkChange{{ID: "arc_easy", Fields: []BenchmarkFieldChange{
+		{Field: "category", From: "reasoning", To: "commonsense"},
+		{Field: "parameters.limit", From: float64(100)},
+		{Field: "parameters.num_fewshot", From: float64(0), To: float64(5)},
+		{Field: "parameters.seed", To: float64(1234)},
+	}}}
+	if !reflect.DeepEqual(diff.Changed, want) {
+		t.Fatalf("Changed = %+v, want %+v", diff.Changed, want)
+	}
+
+	if same := DiffProviders(from, from); len(same.Added) != 0 || len(same.Removed) != 0 || len(same.Changed) != 0 || same.Unchanged != 3 {
+		t.Fatalf("expected no difference between a provider and itself, got %+v", same)
+	}
+}
@@ -1,5 +1,12 @@
 package api
 
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
 // AgentMetadata contains structured metadata for AI agent consumption at the provider level.
 type AgentMetadata struct {
 	Evaluates            []string `mapstructure:"evaluates" yaml:"evaluates" json:"evaluates,omitempty"`
@@ -17,6 +24,51 @@ type ScoreRange struct {
 	Meaning string `mapstructure:"meaning" yaml:"meaning" json:"meaning" validate:"required"`
 }
 
+type MetricDirection string
+
+const (
+	MetricDirectionHigherIsBetter MetricDirection = "higher_is_better"
+	MetricDirectionLowerIsBetter  MetricDirection = "lower_is_better"
+)
+
+// MetricDefinition declares a metric that the benchmarks of a provider report. Aliases are
+// the other names the metric is reported under, e.g. acc and exact_match for accuracy: the
+// metrics of the results are normalized to the name, and primary scores and pass criteria
+// may refer to the metric by any of them.
+//
+// Example YAML for provider configs:
+//
+//	metrics:
+//	  - name: accuracy
+//	    direction: higher_is_better
+//	    min: 0
+//	    max: 1
+//	    aliases: [acc, exact_match]
+type MetricDefinition struct {
+	Name        string          `mapstructure:"name" yaml:"name" json:"name" validate:"required"`
+	Description string          `mapstructure:"description" yaml:"description" json:"description,omitempty" validate:"omitempty,max=1024"`
+	Direction   MetricDirection `mapstructure:"direction" yaml:"direction" json:"direction,omitempty" validate:"omitempty,oneof=higher_is_better lower_is_better"`
+	// Min and Max bound the values of the metric, when set.
+	Min     *float64 `mapstructure:"min" yaml:"min" json:"min,omitempty"`
+	Max     *float64 `mapstructure:"max" yaml:"max" json:"max,omitempty"`
+	Aliases []string `mapstructure:"aliases" yaml:"aliases" json:"aliases,omitempty"`
+}
+
+// LowerIsBetter reports whether lower values of the metric are better.
+func (m *MetricDefinition) LowerIsBetter() bool {
+	return m != nil && m.Direction == MetricDirectionLowerIsBetter
+}
+
+// InRange reports whether the value is within the bounds of the metric.
+func (m *MetricDefinition) InRange(value float64) bool {
+	return (m.Min == nil || value >= *m.Min) && (m.Max == nil || value <= *m.Max)
+}
+
+// Names returns the name and the aliases of the metric.
+func (m *MetricDefinition) Names() []string {
+	return append([]string{m.Name}, m.Aliases...)
+}
+
 // BenchmarkAgentMetadata contains agent metadata at the individual benchmark level.
 type BenchmarkAgentMetadata struct {
 	ResultInterpretation string       `mapstructure:"result_interpretation" yaml:"result_interpretation" json:"result_interpretation,omitempty"`
@@ -36,26 +88,251 @@ type BenchmarkResource struct {
 	PrimaryScore *PrimaryScore           `mapstructure:"primary_score" yaml:"primary_score" json:"primary_score,omitempty"`
 	PassCriteria *PassCriteria           `mapstructure:"pass_criteria" yaml:"pass_criteria" json:"pass_criteria,omitempty" validate:"omitempty"`
 	Agent        *BenchmarkAgentMetadata `mapstructure:"agent" yaml:"agent" json:"agent,omitempty"`
+	// Parameters are the default parameters of the benchmark, merged under the parameters
+	// given for it in evaluation jobs and collections.
+	Parameters map[string]any `mapstructure:"parameters" yaml:"parameters" json:"parameters,omitempty"`
 }
 
 type ProviderConfig struct {
-	Name        string              `mapstructure:"name" yaml:"name" json:"name"`
-	Description string              `mapstructure:"description" yaml:"description" json:"description,omitempty" validate:"omitempty,max=1024,min=1"`
-	Title       string              `mapstructure:"title" yaml:"title" json:"title"`
-	Tags        []string            `mapstructure:"tags" yaml:"tags" json:"tags,omitempty" validate:"omitempty,dive,tagname"`
-	Benchmarks  []BenchmarkResource `mapstructure:"benchmarks" yaml:"benchmarks" json:"benchmarks" validate:"dive"`
-	Runtime     *Runtime            `mapstructure:"runtime" yaml:"runtime" json:"runtime,omitempty"`
-	Agent       *AgentMetadata      `mapstructure:"agent" yaml:"agent" json:"agent,omitempty"`
+	Name        string               `mapstructure:"name" yaml:"name" json:"name"`
+	Description string               `mapstructure:"description" yaml:"description" json:"description,omitempty" validate:"omitempty,max=1024,min=1"`
+	Title       string               `mapstructure:"title" yaml:"title" json:"title"`
+	Tags        []string             `mapstructure:"tags" yaml:"tags" json:"tags,omitempty" validate:"omitempty,dive,tagname"`
+	Benchmarks  []BenchmarkResource  `mapstructure:"benchmarks" yaml:"benchmarks" json:"benchmarks" validate:"dive"`
+	Runtime     *Runtime             `mapstructure:"runtime" yaml:"runtime" json:"runtime,omitempty"`
+	Agent       *AgentMetadata       `mapstructure:"agent" yaml:"agent" json:"agent,omitempty"`
+	HealthCheck *ProviderHealthCheck `mapstructure:"health_check" yaml:"health_check" json:"health_check,omitempty" validate:"omitempty"`
+	// Metrics declares the metrics that the benchmarks of the provider report.
+	Metrics []MetricDefinition `mapstructure:"metrics" yaml:"metrics" json:"metrics,omitempty" validate:"omitempty,dive"`
+	// JobSpecVersions are the versions of the job spec that the adapter of the provider reads,
+	// the adapter gets the latest one that the server writes. Empty is the current version.
+	JobSpecVersions []int `mapstructure:"job_spec_versions" yaml:"job_spec_versions,omitempty" json:"job_spec_versions,omitempty" validate:"omitempty,dive,min=1"`
+}
+
+// SupportsRuntime returns true when the provider has the configuration that the runtime
+// needs to start its adapter: runtime.k8s for kubernetes and argo, a runtime.local command
+// for local, runtime.lmevaljob for lmevaljob.
+// The mock runtime starts no adapter and supports every provider.
+func (p *ProviderConfig) SupportsRuntime(runtime string) bool {
+	if runtime == RuntimeMock {
+		return true
+	}
+	if p.Runtime == nil {
+		return false
+	}
+	switch runtime {
+	case RuntimeKubernetes, RuntimeArgo:
+		return p.Runtime.K8s != nil
+	case RuntimeLocal:
+		return p.Runtime.Local != nil && p.Runtime.Local.Command != ""
+	case RuntimeLMEvalJob:
+		return p.Runtime.LMEvalJob != nil
+	default:
+		return false
+	}
+}
+
+// GetBenchmark returns the benchmark with the given ID, or nil when the provider has none.
+func (p *ProviderConfig) GetBenchmark(id string) *BenchmarkResource {
+	for i := range p.Benchmarks {
+		if p.Benchmarks[i].ID == id {
+			return &p.Benchmarks[i]
+		}
+	}
+	return nil
+}
+
+// GetMetric returns the metric that the provider declares with the given name or alias, or
+// nil when it declares none.
+func (p *ProviderConfig) GetMetric(name string) *MetricDefinition {
+	for i := range p.Metrics {
+		if p.Metrics[i].Name == name || slices.Contains(p.Metrics[i].Aliases, name) {
+			return &p.Metrics[i]
+		}
+	}
+	return nil
+}
+
+// MetricValue returns the value of the metric with the given name or alias in the metrics,
+// reported under any name of the metric that the provider declares for it.
+func (p *ProviderConfig) MetricValue(metrics map[string]any, name string) (any, bool) {
+	if value, ok := metrics[name]; ok {
+		return value, true
+	}
+	if p == nil {
+		return nil, false
+	}
+	if metric := p.GetMetric(name); metric != nil {
+		for _, other := range metric.Names() {
+			if value, ok := metrics[other]; ok {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// NormalizeMetrics returns the metrics with the ones reported under an alias renamed to the
+// name of their metric, or nil when none of them is reported under an alias. A metric that
+// is also reported under its name keeps that value, and one reported under several aliases
+// the value of the first alias in sorted order.
+func (p *ProviderConfig) NormalizeMetrics(metrics map[string]any) map[string]any {
+	if p == nil || len(p.Metrics) == 0 {
+		return nil
+	}
+	var normalized map[string]any
+	for _, name := range slices.Sorted(maps.Keys(metrics)) {
+		metric := p.GetMetric(name)
+		if metric == nil || metric.Name == name {
+			continue
+		}
+		if normalized == nil {
+			normalized = maps.Clone(metrics)
+		}
+		delete(normalized, name)
+		if _, ok := normalized[metric.Name]; !ok {
+			normalized[metric.Name] = metrics[name]
+		}
+	}
+	return normalized
+}
+
+// WithDefaultParameters returns the benchmark with the default parameters that the provider
+// defines for it merged under its own parameters.
+func (p *ProviderConfig) WithDefaultParameters(benchmark EvaluationBenchmarkConfig) EvaluationBenchmarkConfig {
+	resource := p.GetBenchmark(benchmark.ID)
+	if resource == nil || len(resource.Parameters) == 0 {
+		return benchmark
+	}
+	benchmark.Parameters = MergeParameters(resource.Parameters, benchmark.Parameters)
+	return benchmark
+}
+
+// MergeParameters returns a new map with parameters merged over defaults. Values in
+// parameters win, except that nested maps present in both are merged the same way.
+func MergeParameters(defaults, parameters map[string]any) map[string]any {
+	merged := make(map[string]any, len(defaults)+len(parameters))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range parameters {
+		defaultMap, defaultIsMap := merged[key].(map[string]any)
+		valueMap, valueIsMap := value.(map[string]any)
+		if defaultIsMap && valueIsMap {
+			merged[key] = MergeParameters(defaultMap, valueMap)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
 }
 
 type ProviderResource struct {
 	Resource Resource `json:"resource"`
 	ProviderConfig
+	// Health is the outcome of the most recent canary evaluations. It is computed by the
+	// service at read time and never persisted with the provider.
+	Health *ProviderHealth `json:"health,omitempty"`
+	// ImageWarmup is the pre-pull status of the adapter image on the nodes that run
+	// evaluation jobs, when image warm-up is enabled. It is computed by the service at read
+	// time and never persisted with the provider.
+	ImageWarmup *ProviderImageWarmup `json:"image_warmup,omitempty"`
+}
+
+// ProviderHealthCheck configures a canary evaluation that the service runs periodically
+// against a provider so that broken adapter images are detected before users hit them.
+//
+// Example YAML for provider configs:
+//
+//	health_check:
+//	  benchmark_id: arc_easy
+//	  model:
+//	    url: http://vllm.models.svc:8000/v1
+//	    name: tiny-llm
+//	  parameters:
+//	    limit: 5
+//	  interval_seconds: 900
+//	  timeout_seconds: 600
+//	  tenant: evalhub-canary      # namespace the canary job runs in (k8s runtime)
+type ProviderHealthCheck struct {
+	BenchmarkID     string         `mapstructure:"benchmark_id" yaml:"benchmark_id" json:"benchmark_id" validate:"required"`
+	Model           ModelRef       `mapstructure:"model" yaml:"model" json:"model"`
+	Parameters      map[string]any `mapstructure:"parameters" yaml:"parameters" json:"parameters,omitempty"`
+	IntervalSeconds int            `mapstructure:"interval_seconds" yaml:"interval_seconds" json:"interval_seconds,omitempty" validate:"omitempty,min=60"`
+	TimeoutSeconds  int            `mapstructure:"timeout_seconds" yaml:"timeout_seconds" json:"timeout_seconds,omitempty" validate:"omitempty,min=1"`
+	Tenant          Tenant         `mapstructure:"tenant" yaml:"tenant" json:"tenant,omitempty"`
+}
+
+const (
+	DefaultProviderHealthCheckInterval = 15 * time.Minute
+	DefaultProviderHealthCheckTimeout  = 30 * time.Minute
+)
+
+// EffectiveInterval returns the configured interval or DefaultProviderHealthCheckInterval.
+func (h *ProviderHealthCheck) EffectiveInterval() time.Duration {
+	if h == nil || h.IntervalSeconds <= 0 {
+		return DefaultProviderHealthCheckInterval
+	}
+	return time.Duration(h.IntervalSeconds) * time.Second
+}
+
+// EffectiveTimeout returns the configured timeout or DefaultProviderHealthCheckTimeout.
+func (h *ProviderHealthCheck) EffectiveTimeout() time.Duration {
+	if h == nil || h.TimeoutSeconds <= 0 {
+		return DefaultProviderHealthCheckTimeout
+	}
+	return time.Duration(h.TimeoutSeconds) * time.Second
+}
+
+type ProviderHealthStatus string
+
+const (
+	ProviderHealthStatusUnknown   ProviderHealthStatus = "unknown"
+	ProviderHealthStatusHealthy   ProviderHealthStatus = "healthy"
+	ProviderHealthStatusUnhealthy ProviderHealthStatus = "unhealthy"
+)
+
+// ProviderHealth reports the outcome of the canary evaluations run for a provider.
+type ProviderHealth struct {
+	Status        ProviderHealthStatus `json:"status"`
+	LastCheckedAt *time.Time           `json:"last_checked_at,omitempty"`
+	LastSuccessAt *time.Time           `json:"last_success_at,omitempty"`
+	FailureStreak int                  `json:"failure_streak"`
+	LastJobID     string               `json:"last_job_id,omitempty"`
+	LastError     string               `json:"last_error,omitempty"`
+}
+
+type ImageWarmupState string
+
+const (
+	// ImageWarmupStatePending is the state of an image that is not pulled on any node yet,
+	// e.g. when no node matches the warm-up node selector.
+	ImageWarmupStatePending ImageWarmupState = "pending"
+	ImageWarmupStatePulling ImageWarmupState = "pulling"
+	ImageWarmupStateReady   ImageWarmupState = "ready"
+	ImageWarmupStateFailed  ImageWarmupState = "failed"
+)
+
+// ProviderImageWarmup reports the pre-pull of the adapter image of a provider on the nodes
+// selected for warm-up.
+type ProviderImageWarmup struct {
+	Image string           `json:"image"`
+	State ImageWarmupState `json:"state"`
+	// Nodes is the number of nodes the image is pulled on, Pulled and Failed the number of
+	// those nodes where the pull completed and failed.
+	Nodes     int       `json:"nodes"`
+	Pulled    int       `json:"pulled"`
+	Failed    int       `json:"failed,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Runtime struct {
 	K8s   *K8sRuntime   `mapstructure:"k8s" yaml:"k8s" json:"k8s,omitempty"`
 	Local *LocalRuntime `mapstructure:"local" yaml:"local" json:"local,omitempty"`
+	// LMEvalJob marks the provider as managed by the TrustyAI operator: its benchmarks run as
+	// LMEvalJob custom resources of the lmevaljob runtime instead of adapter Jobs.
+	LMEvalJob *LMEvalJobRuntime `mapstructure:"lmevaljob" yaml:"lmevaljob,omitempty" json:"lmevaljob,omitempty"`
 }
 
 // GPUConfig declares the GPU resources required by an adapter.
@@ -113,11 +390,202 @@ type K8sRuntime struct {
 	// API values: if_not_present (default when omitted) or always. Mapped to Kubernetes
 	// PullIfNotPresent / PullAlways on the adapter container only; sidecar/init are fixed.
 	ImagePullPolicy string `mapstructure:"image_pull_policy" yaml:"image_pull_policy,omitempty" json:"image_pull_policy,omitempty" validate:"omitempty,oneof=if_not_present always"`
+	// DataVolume mounts a persistent volume claim at /data instead of an emptyDir. Omit to
+	// keep the working data of the job on the ephemeral storage of the node.
+	DataVolume *DataVolumeConfig `mapstructure:"data_volume" yaml:"data_volume,omitempty" json:"data_volume,omitempty"`
+	// PodMetadata adds labels and annotations to the Job and Pod template of the benchmark
+	// jobs of the provider. The pod metadata of a job overrides the keys it repeats.
+	PodMetadata *PodMetadata `mapstructure:"pod_metadata" yaml:"pod_metadata,omitempty" json:"pod_metadata,omitempty"`
+	// Spot is the spot or preemptible node pool that the benchmark jobs of the provider run on
+	// when their evaluation job asks for it. Omit when the provider has no such node pool.
+	Spot *SpotConfig `mapstructure:"spot" yaml:"spot,omitempty" json:"spot,omitempty"`
+	// JobPolicy sets how long the finished benchmark jobs of the provider are kept and whether
+	// Kubernetes retries their pods. The job policy of a job overrides the fields it sets.
+	JobPolicy *JobPolicy `mapstructure:"job_policy" yaml:"job_policy,omitempty" json:"job_policy,omitempty"`
+	// ResultRelay is set for adapters that cannot make HTTP calls, not even to the sidecar.
+	// The adapter then appends its status events to the results file named in its job spec,
+	// on the data volume, and the sidecar relays them to eval-hub.
+	ResultRelay bool `mapstructure:"result_relay" yaml:"result_relay,omitempty" json:"result_relay,omitempty"`
+}
+
+// SpotConfig selects the spot or preemptible node pool of the cluster, whose nodes can be
+// reclaimed at any time, for the benchmark jobs of evaluation jobs that set spot. A benchmark
+// interrupted by the reclaim fails with pod_evicted and is run again by its retry policy.
+// With checkpoint, the job spec of the adapter carries a checkpoint path on the data volume
+// and a resume flag, so that the run after an interruption resumes from the last checkpoint
+// of the adapter instead of restarting from scratch.
+//
+// Example YAML for provider configs:
+//
+//	runtime:
+//	  k8s:
+//	    spot:
+//	      node_selector:
+//	        cloud.google.com/gke-spot: "true"
+//	      tolerations:
+//	        - key: cloud.google.com/gke-spot
+//	          operator: Equal
+//	          value: "true"
+//	          effect: NoSchedule
+//	      checkpoint: true               # needs a data_volume
+type SpotConfig struct {
+	NodeSelector map[string]string `mapstructure:"node_selector" yaml:"node_selector,omitempty" json:"node_selector,omitempty"`
+	Tolerations  []Toleration      `mapstructure:"tolerations" yaml:"tolerations,omitempty" json:"tolerations,omitempty" validate:"omitempty,dive"`
+	Checkpoint   bool              `mapstructure:"checkpoint" yaml:"checkpoint,omitempty" json:"checkpoint,omitempty"`
+}
+
+// Toleration lets the pods of the benchmark jobs run on nodes with a matching taint.
+type Toleration struct {
+	Key      string `mapstructure:"key" yaml:"key,omitempty" json:"key,omitempty"`
+	Operator string `mapstructure:"operator" yaml:"operator,omitempty" json:"operator,omitempty" validate:"omitempty,oneof=Exists Equal"`
+	Value    string `mapstructure:"value" yaml:"value,omitempty" json:"value,omitempty"`
+	Effect   string `mapstructure:"effect" yaml:"effect,omitempty" json:"effect,omitempty" validate:"omitempty,oneof=NoSchedule PreferNoSchedule NoExecute"`
+}
+
+// Checkpoints returns true when the benchmark jobs that run on the spot node pool checkpoint
+// their progress on the data volume.
+func (r *K8sRuntime) Checkpoints() bool {
+	return r != nil && r.Spot != nil && r.Spot.Checkpoint && r.DataVolume != nil
+}
+
+const (
+	DataVolumeCleanupDelete = "delete"
+	DataVolumeCleanupRetain = "retain"
+)
+
+// DataVolumeConfig mounts a persistent volume claim at /data in the adapter and sidecar
+// containers, for evaluations whose working datasets do not fit in the ephemeral storage of
+// a node. Either an existing claim is mounted, shared by the jobs of the provider, or a claim
+// is provisioned for each benchmark job.
+//
+// Example YAML for provider configs:
+//
+//	runtime:
+//	  k8s:
+//	    data_volume:
+//	      size: 200Gi                   # provision a claim per benchmark job
+//	      storage_class: gp3-csi        # optional; the default storage class when omitted
+//	      access_mode: read_write_once  # optional; read_write_once (default), read_write_many or read_write_once_pod
+//	      cleanup: delete               # optional; delete (default) or retain the claim when the job is deleted
+//
+// or, to mount an existing claim of the job namespace, which is never deleted:
+//
+//	data_volume:
+//	  claim_name: eval-datasets
+type DataVolumeConfig struct {
+	ClaimName    string `mapstructure:"claim_name" yaml:"claim_name,omitempty" json:"claim_name,omitempty" validate:"omitempty,rfc1123_dns_label,excluded_with=Size"`
+	Size         string `mapstructure:"size" yaml:"size,omitempty" json:"size,omitempty" validate:"required_without=ClaimName"`
+	StorageClass string `mapstructure:"storage_class" yaml:"storage_class,omitempty" json:"storage_class,omitempty"`
+	AccessMode   string `mapstructure:"access_mode" yaml:"access_mode,omitempty" json:"access_mode,omitempty" validate:"omitempty,oneof=read_write_once read_write_many read_write_once_pod"`
+	Cleanup      string `mapstructure:"cleanup" yaml:"cleanup,omitempty" json:"cleanup,omitempty" validate:"omitempty,oneof=delete retain"`
+}
+
+// IsProvisioned returns true when a claim is provisioned for each benchmark job.
+func (c *DataVolumeConfig) IsProvisioned() bool {
+	return c != nil && c.ClaimName == ""
+}
+
+// RetainsClaim returns true when a provisioned claim is kept after the job is deleted.
+func (c *DataVolumeConfig) RetainsClaim() bool {
+	return c != nil && c.Cleanup == DataVolumeCleanupRetain
+}
+
+// ProtectedPodMetadataDomains are the label and annotation key prefixes that are reserved
+// for eval-hub, Kueue and Kubernetes, with their subdomains; pod metadata cannot set them.
+var ProtectedPodMetadataDomains = []string{"eval-hub.github.io", "kueue.x-k8s.io", "kubernetes.io", "k8s.io"}
+
+// PodMetadata are extra labels and annotations of the Kubernetes Job and Pod template of the
+// benchmark jobs, e.g. cost-allocation labels or the sidecar.istio.io/inject annotation of a
+// service mesh. They are merged with the labels and annotations that eval-hub sets, which
+// take precedence.
+//
+// Example YAML for provider configs:
+//
+//	runtime:
+//	  k8s:
+//	    pod_metadata:
+//	      labels:
+//	        cost-center: ml-platform
+//	      annotations:
+//	        sidecar.istio.io/inject: "false"
+type PodMetadata struct {
+	Labels      map[string]string `mapstructure:"labels" yaml:"labels,omitempty" json:"labels,omitempty"`
+	Annotations map[string]string `mapstructure:"annotations" yaml:"annotations,omitempty" json:"annotations,omitempty"`
+}
+
+// The restart policies of the pods of the benchmark jobs.
+const (
+	JobRestartPolicyNever     = "never"
+	JobRestartPolicyOnFailure = "on_failure"
+)
+
+// JobPolicy sets the retention and the retries of the Kubernetes Jobs of the benchmarks. By
+// default a finished Job is deleted after an hour and its pod is never retried, as a failed
+// benchmark is retried by its retry policy instead. Frameworks whose logs are scraped after
+// the Job finishes need a longer retention; retries by Kubernetes only suit adapters that
+// crash before they report any status.
+//
+// Example YAML for provider configs:
+//
+//	runtime:
+//	  k8s:
+//	    job_policy:
+//	      ttl_seconds_after_finished: 86400  # keep the finished Jobs a day
+//	      backoff_limit: 2                   # retry a failed pod twice
+//	      restart_policy: on_failure         # restart the containers in place, or never
+type JobPolicy struct {
+	TTLSecondsAfterFinished *int32 `mapstructure:"ttl_seconds_after_finished" yaml:"ttl_seconds_after_finished,omitempty" json:"ttl_seconds_after_finished,omitempty" validate:"omitempty,min=0,max=2592000"`
+	BackoffLimit            *int32 `mapstructure:"backoff_limit" yaml:"backoff_limit,omitempty" json:"backoff_limit,omitempty" validate:"omitempty,min=0,max=10"`
+	RestartPolicy           string `mapstructure:"restart_policy" yaml:"restart_policy,omitempty" json:"restart_policy,omitempty" validate:"omitempty,oneof=never on_failure"`
+}
+
+// IsProtectedPodMetadataKey returns true when the prefix of the label or annotation key is
+// one of the ProtectedPodMetadataDomains or a subdomain of one.
+func IsProtectedPodMetadataKey(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+	prefix = strings.ToLower(prefix)
+	for _, domain := range ProtectedPodMetadataDomains {
+		if prefix == domain || strings.HasSuffix(prefix, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// LMEvalJobRuntime is how the lmevaljob runtime fills in the LMEvalJob custom resources of the
+// benchmarks of an operator-managed provider. The task of a resource is the benchmark, its
+// model the model of the job.
+//
+// Example YAML for provider configs:
+//
+//	runtime:
+//	  lmevaljob:
+//	    model: local-completions
+//	    model_args:
+//	      - name: tokenizer
+//	        value: TinyLlama/TinyLlama-1.1B-Chat-v1.0
+//	    allow_online: true
+type LMEvalJobRuntime struct {
+	// Model is the lm-eval model type, local-completions when omitted.
+	Model string `mapstructure:"model" yaml:"model,omitempty" json:"model,omitempty"`
+	// ModelArgs are added to the model and base_url arguments set from the model of the job.
+	ModelArgs []EnvVar `mapstructure:"model_args" yaml:"model_args,omitempty" json:"model_args,omitempty"`
+	// AllowOnline lets the job download datasets and tokenizers.
+	AllowOnline bool `mapstructure:"allow_online" yaml:"allow_online,omitempty" json:"allow_online,omitempty"`
+	// AllowCodeExecution lets the tasks that run generated code do so.
+	AllowCodeExecution bool     `mapstructure:"allow_code_execution" yaml:"allow_code_execution,omitempty" json:"allow_code_execution,omitempty"`
+	Env                []EnvVar `mapstructure:"env" yaml:"env,omitempty" json:"env,omitempty"`
 }
 
 type LocalRuntime struct {
 	Command string   `mapstructure:"command" yaml:"command" json:"command,omitempty"`
 	Env     []EnvVar `mapstructure:"env" yaml:"env" json:"env,omitempty"`
+	// Image runs the command in a container of the image, with podman or docker, instead of
+	// on the host. The directory of the benchmark is bind-mounted in the container.
+	Image string `mapstructure:"image" yaml:"image,omitempty" json:"image,omitempty"`
 }
 
 // ProviderResourceList represents response for listing providers
@@ -125,3 +593,27 @@ type ProviderResourceList struct {
 	Page
 	Items []ProviderResource `json:"items"`
 }
+
+// BenchmarkResourceList is a page of the benchmarks of a provider.
+type BenchmarkResourceList struct {
+	Page
+	Items []BenchmarkResource `json:"items"`
+}
+
+// ProviderBundleFormat and ProviderBundleVersion identify the provider bundles of
+// GET /api/v1/evaluations/providers/{id}/export.
+const (
+	ProviderBundleFormat  = "eval-hub-provider"
+	ProviderBundleVersion = 1
+)
+
+// ProviderBundle is a provider exported to be imported in another eval-hub instance: its
+// config with the definitions of its benchmarks, without the tenant and owner it had in the
+// instance it was exported from.
+type ProviderBundle struct {
+	Format     string         `json:"format"`
+	Version    int            `json:"version"`
+	ID         string         `json:"id"`
+	ExportedAt time.Time      `json:"exported_at"`
+	Provider   ProviderConfig `json:"provider"`
+}
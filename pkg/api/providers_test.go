@@ -0,0 +1,53 @@
+package api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProviderConfigMetrics(t *testing.T) {
+	provider := &ProviderConfig{Metrics: []MetricDefinition{
+		{Name: "accuracy", Aliases: []string{"exact_match", "acc"}},
+		{Name: "perplexity", Direction: MetricDirectionLowerIsBetter, Aliases: []string{"ppl"}},
+	}}
+
+	if metric := provider.GetMetric("acc"); metric == nil || metric.Name != "accuracy" || metric.LowerIsBetter() {
+		t.Fatalf("expected the alias to resolve to accuracy, got %+v", metric)
+	}
+	if !provider.GetMetric("ppl").LowerIsBetter() || provider.GetMetric("f1") != nil {
+		t.Fatal("unexpected metric lookups")
+	}
+
+	if value, ok := provider.MetricValue(map[string]any{"acc": 0.5}, "accuracy"); !ok || value != 0.5 {
+		t.Fatalf("expected the value reported under an alias, got %v %v", value, ok)
+	}
+	if _, ok := (*ProviderConfig)(nil).MetricValue(map[string]any{"acc": 0.5}, "accuracy"); ok {
+		t.Fatal("expected no value without a provider to resolve the alias")
+	}
+
+	normalized := provider.NormalizeMetrics(map[string]any{"exact_match": 0.4, "acc": 0.5, "ppl": 3.0, "other": 1})
+	if want := map[string]any{"accuracy": 0.5, "perplexity": 3.0, "other": 1}; !reflect.DeepEqual(normalized, want) {
+		t.Fatalf("NormalizeMetrics = %v, want %v", normalized, want)
+	}
+	if normalized := provider.NormalizeMetrics(map[string]any{"accuracy": 0.6, "acc": 0.5}); !reflect.DeepEqual(normalized, map[string]any{"accuracy": 0.6}) {
+		t.Fatalf("expected the metric reported under its name to win, got %v", normalized)
+	}
+	if normalized := provider.NormalizeMetrics(map[string]any{"accuracy": 0.6}); normalized != nil {
+		t.Fatalf("expected nil when no metric is reported under an alias, got %v", normalized)
+	}
+}
+
+func TestNewBaselineComparisonDirection(t *testing.T) {
+	job := &EvaluationJobResource{Results: &EvaluationJobResults{Benchmarks: []BenchmarkResult{
+		{ID: "acc", ProviderID: "p", Test: &BenchmarkTest{PrimaryScore: 0.7}},
+		{ID: "ppl", ProviderID: "p", Test: &BenchmarkTest{PrimaryScore: 3, LowerIsBetter: true}},
+	}}}
+	baseline := &BaselineResource{Benchmarks: []BaselineBenchmark{
+		{ID: "acc", ProviderID: "p", PrimaryScore: 0.8},
+		{ID: "ppl", ProviderID: "p", PrimaryScore: 4},
+	}}
+	comparison := NewBaselineComparison(job, baseline)
+	if !comparison.Benchmarks[0].Regressed || comparison.Benchmarks[1].Regressed || !comparison.Benchmarks[1].LowerIsBetter {
+		t.Fatalf("unexpected comparison %+v", comparison.Benchmarks)
+	}
+}
@@ -0,0 +1,40 @@
+package api
+
+// RAGConfig configures the evaluation of a retrieval-augmented generation pipeline: where the
+// context of the questions is retrieved from, the embedding model of the retrieval and the
+// judge that scores the answers. It is handed to the adapters of the benchmarks in the rag
+// field of their job spec, for RAGAS-style providers.
+type RAGConfig struct {
+	Retrieval RetrievalConfig `json:"retrieval" validate:"required"`
+	// EmbeddingModel embeds the questions and, without a retrieval URL, the corpus.
+	EmbeddingModel *EndpointModelRef `json:"embedding_model,omitempty"`
+	Judge          *JudgeConfig      `json:"judge,omitempty"`
+}
+
+// RetrievalConfig is the source of the retrieved context: a retrieval service, or a corpus
+// that the adapter indexes with the embedding model.
+type RetrievalConfig struct {
+	// URL is the endpoint of the retrieval service.
+	URL string `json:"url,omitempty" validate:"required_without=Corpus,omitempty,http_url,max=2048"`
+	// Corpus is the collection of the retrieval service that is queried, or without a URL the
+	// URI of the documents, e.g. s3://bucket/docs/.
+	Corpus string `json:"corpus,omitempty" validate:"omitempty,max=2048"`
+	// TopK is the number of documents retrieved per question.
+	TopK int `json:"top_k,omitempty" validate:"omitempty,min=1,max=1000"`
+}
+
+// EndpointModelRef is a model served at an OpenAI-compatible endpoint that the adapter calls
+// directly, without the model proxy of the job.
+type EndpointModelRef struct {
+	URL        string         `json:"url" validate:"required,http_url,max=2048"`
+	Name       string         `json:"name" validate:"required,max=256"`
+	Parameters map[string]any `json:"parameters,omitempty"`
+}
+
+// JudgeConfig is the model that scores the answers of a RAG pipeline, e.g. for faithfulness,
+// and the metrics it scores.
+type JudgeConfig struct {
+	Model EndpointModelRef `json:"model" validate:"required"`
+	// Metrics are the metrics of the provider scored by the judge, all of them when empty.
+	Metrics []string `json:"metrics,omitempty" validate:"omitempty,max=32,dive,required,max=128"`
+}
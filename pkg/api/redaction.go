@@ -0,0 +1,63 @@
+package api
+
+import "time"
+
+// RedactionPolicy is the redaction of a tenant: the patterns of personal data scrubbed from
+// the text artifacts that adapters upload and from the error and warning messages of the
+// benchmarks of its jobs, before they are stored. What is stored is redacted, so the exports
+// of the jobs are too.
+type RedactionPolicy struct {
+	Rules []RedactionRule `json:"rules,omitempty" validate:"omitempty,max=100,dive"`
+}
+
+// RedactionRule is a pattern to redact.
+type RedactionRule struct {
+	// Name identifies the rule in the audit of the redactions, e.g. email.
+	Name string `json:"name" validate:"required,max=64"`
+	// Pattern is a regular expression, in the RE2 syntax, of the text to redact. It is matched
+	// against one line of an artifact at a time.
+	Pattern string `json:"pattern" validate:"required,max=1024"`
+	// Replacement replaces the matches, [REDACTED:<name>] by default.
+	Replacement string `json:"replacement,omitempty" validate:"omitempty,max=256"`
+}
+
+// RedactionPolicyResource is the redaction policy of a tenant.
+type RedactionPolicyResource struct {
+	Tenant    Tenant    `json:"tenant,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+	UpdatedBy User      `json:"updated_by,omitempty"`
+	RedactionPolicy
+}
+
+// IsEmpty returns true when the policy redacts nothing.
+func (p *RedactionPolicy) IsEmpty() bool {
+	return p == nil || len(p.Rules) == 0
+}
+
+// RedactionTarget is what a redaction was applied to.
+type RedactionTarget string
+
+const (
+	RedactionTargetArtifact       RedactionTarget = "artifact"
+	RedactionTargetErrorMessage   RedactionTarget = "error_message"
+	RedactionTargetWarningMessage RedactionTarget = "warning_message"
+)
+
+// RedactionRecord is the audit of the redactions applied to an artifact or a message of a
+// benchmark of a job: how many matches of each rule were replaced. The redacted text is
+// not kept.
+type RedactionRecord struct {
+	JobID          string          `json:"job_id"`
+	BenchmarkIndex int             `json:"benchmark_index"`
+	Target         RedactionTarget `json:"target"`
+	// Artifact is the name of the redacted artifact.
+	Artifact string `json:"artifact,omitempty"`
+	// Redactions are the numbers of matches replaced, by rule name.
+	Redactions map[string]int `json:"redactions"`
+	RedactedAt time.Time      `json:"redacted_at"`
+}
+
+type RedactionRecordList struct {
+	Page
+	Items []RedactionRecord `json:"items"`
+}
@@ -0,0 +1,75 @@
+package api
+
+// ReviewState is the state of the human review of the result of a job.
+type ReviewState string
+
+const (
+	ReviewStatePending  ReviewState = "pending"
+	ReviewStateApproved ReviewState = "approved"
+	ReviewStateRejected ReviewState = "rejected"
+)
+
+// Review is the sign-off that a job needs when its score is within the review band of the
+// threshold of its pass criteria. The job does not pass until a reviewer approves it.
+type Review struct {
+	State ReviewState `json:"state"`
+	// Band is the review band of the pass criteria that the score fell within.
+	Band float32 `json:"band"`
+	// ScorePass is the pass of the score against the threshold, before the review.
+	ScorePass   bool     `json:"score_pass"`
+	RequestedAt DateTime `json:"requested_at"`
+	Reviewer    User     `json:"reviewer,omitempty"`
+	Comment     string   `json:"comment,omitempty"`
+	ReviewedAt  DateTime `json:"reviewed_at,omitempty"`
+	// History is the audit trail of the review, from the request to the decision.
+	History []ReviewEvent `json:"history,omitempty"`
+}
+
+// ReviewEvent is an entry of the audit trail of a review. The request of the review is
+// made by the server and has no user.
+type ReviewEvent struct {
+	State   ReviewState `json:"state"`
+	User    User        `json:"user,omitempty"`
+	Comment string      `json:"comment,omitempty"`
+	Time    DateTime    `json:"time"`
+}
+
+// ReviewDecision is the request of a reviewer to approve or reject a job. A rejection must
+// say why.
+type ReviewDecision struct {
+	Decision ReviewState `json:"decision" validate:"required,oneof=approved rejected"`
+	Comment  string      `json:"comment,omitempty" validate:"required_if=Decision rejected,max=4096"`
+}
+
+// ReviewResource is the review of a job, with what a reviewer needs to decide it.
+type ReviewResource struct {
+	JobID     string   `json:"job_id"`
+	Name      string   `json:"name"`
+	Owner     User     `json:"owner,omitempty"`
+	Model     ModelRef `json:"model"`
+	Score     float32  `json:"score"`
+	Threshold float32  `json:"threshold"`
+	Review
+}
+
+type ReviewResourceList struct {
+	Page
+	Items []ReviewResource `json:"items"`
+}
+
+// NewReviewResource returns the review of the job, or nil when its result is not reviewed.
+func NewReviewResource(job *EvaluationJobResource) *ReviewResource {
+	if job.Results == nil || job.Results.Test == nil || job.Results.Test.Review == nil {
+		return nil
+	}
+	test := job.Results.Test
+	return &ReviewResource{
+		JobID:     job.Resource.ID,
+		Name:      job.Name,
+		Owner:     job.Resource.Owner,
+		Model:     job.Model,
+		Score:     test.Score,
+		Threshold: test.Threshold,
+		Review:    *test.Review,
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// SecretRefPrefix marks a benchmark parameter whose value is read from a secret when the
+// job spec of the benchmark is built, e.g. "secretRef://openai-credentials/api-key". Only
+// the reference is stored with the job; the runtime gives the value to the adapter.
+const SecretRefPrefix = "secretRef://"
+
+// SecretRef is a reference to the key of a secret.
+type SecretRef struct {
+	Name string
+	Key  string
+}
+
+func (r SecretRef) String() string {
+	return SecretRefPrefix + r.Name + "/" + r.Key
+}
+
+// ParseSecretRef parses a parameter value. It returns false when the value is not a secret
+// reference, and an error when it is one without both a secret name and a key.
+func ParseSecretRef(value any) (SecretRef, bool, error) {
+	text, ok := value.(string)
+	if !ok || !strings.HasPrefix(text, SecretRefPrefix) {
+		return SecretRef{}, false, nil
+	}
+	name, key, found := strings.Cut(strings.TrimPrefix(text, SecretRefPrefix), "/")
+	if !found || name == "" || key == "" || strings.Contains(key, "/") {
+		return SecretRef{}, true, fmt.Errorf("%q is not a valid secret reference, expected %sname/key", text, SecretRefPrefix)
+	}
+	return SecretRef{Name: name, Key: key}, true, nil
+}
+
+// FindSecretRefs returns the secret references in the parameters, including the ones in
+// nested objects and lists.
+func FindSecretRefs(parameters map[string]any) ([]SecretRef, error) {
+	var refs []SecretRef
+	_, err := ResolveSecretRefs(parameters, func(ref SecretRef) (string, error) {
+		refs = append(refs, ref)
+		return "", nil
+	})
+	return refs, err
+}
+
+// ResolveSecretRefs returns a copy of the parameters with each secret reference replaced by
+// the value that resolve returns for it. The parameters are returned as they are when they
+// contain no reference.
+func ResolveSecretRefs(parameters map[string]any, resolve func(SecretRef) (string, error)) (map[string]any, error) {
+	resolved, err := resolveSecretRefs("", parameters, resolve)
+	if err != nil {
+		return nil, err
+	}
+	if resolved == nil {
+		return parameters, nil
+	}
+	return resolved.(map[string]any), nil
+}
+
+// resolveSecretRefs returns nil when the value contains no secret reference. Errors are
+// prefixed with the path of the parameter, e.g. "judge.api_key".
+func resolveSecretRefs(path string, value any, resolve func(SecretRef) (string, error)) (any, error) {
+	switch typed := value.(type) {
+	case map[string]any:
+		var copied map[string]any
+		for key, item := range typed {
+			itemPath := key
+			if path != "" {
+				itemPath = path + "." + key
+			}
+			resolved, err := resolveSecretRefs(itemPath, item, resolve)
+			if err != nil {
+				return nil, err
+			}
+			if resolved == nil {
+				continue
+			}
+			if copied == nil {
+				copied = maps.Clone(typed)
+			}
+			copied[key] = resolved
+		}
+		if copied == nil {
+			return nil, nil
+		}
+		return copied, nil
+	case []any:
+		var copied []any
+		for i, item := range typed {
+			resolved, err := resolveSecretRefs(fmt.Sprintf("%s[%d]", path, i), item, resolve)
+			if err != nil {
+				return nil, err
+			}
+			if resolved == nil {
+				continue
+			}
+			if copied == nil {
+				copied = append([]any(nil), typed...)
+			}
+			copied[i] = resolved
+		}
+		if copied == nil {
+			return nil, nil
+		}
+		return copied, nil
+	default:
+		ref, ok, err := ParseSecretRef(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if !ok {
+			return nil, nil
+		}
+		secret, err := resolve(ref)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return secret, nil
+	}
+}
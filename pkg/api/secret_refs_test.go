@@ -0,0 +1,67 @@
+package api
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestParseSecretRef(t *testing.T) {
+	tests := map[string]struct {
+		value   any
+		want    SecretRef
+		isRef   bool
+		wantErr bool
+	}{
+		"reference":     {value: "secretRef://openai/api-key", want: SecretRef{Name: "openai", Key: "api-key"}, isRef: true},
+		"plain string":  {value: "gpt-4o"},
+		"not a string":  {value: 5},
+		"no key":        {value: "secretRef://openai", isRef: true, wantErr: true},
+		"empty name":    {value: "secretRef:///api-key", isRef: true, wantErr: true},
+		"nested key":    {value: "secretRef://openai/keys/api-key", isRef: true, wantErr: true},
+		"trailing path": {value: "secretRef://openai/", isRef: true, wantErr: true},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			ref, isRef, err := ParseSecretRef(tt.value)
+			if isRef != tt.isRef || (err != nil) != tt.wantErr || ref != tt.want {
+				t.Errorf("ParseSecretRef(%v) = %v, %v, %v", tt.value, ref, isRef, err)
+			}
+		})
+	}
+}
+
+func TestResolveSecretRefs(t *testing.T) {
+	parameters := map[string]any{
+		"judge":   map[string]any{"api_key": "secretRef://judge/api-key", "model": "gpt-4o"},
+		"headers": []any{"secretRef://search/token", "accept: json"},
+		"limit":   10,
+	}
+	resolved, err := ResolveSecretRefs(parameters, func(ref SecretRef) (string, error) {
+		return "value of " + ref.String(), nil
+	})
+	if err != nil {
+		t.Fatalf("ResolveSecretRefs: %v", err)
+	}
+	if got := resolved["judge"].(map[string]any)["api_key"]; got != "value of secretRef://judge/api-key" {
+		t.Errorf("expected the nested reference to be resolved, got %v", got)
+	}
+	if got := resolved["headers"].([]any); got[0] != "value of secretRef://search/token" || got[1] != "accept: json" {
+		t.Errorf("expected the reference in the list to be resolved, got %v", got)
+	}
+	if parameters["judge"].(map[string]any)["api_key"] != "secretRef://judge/api-key" || parameters["headers"].([]any)[0] != "secretRef://search/token" {
+		t.Errorf("expected the parameters to be left unchanged, got %v", parameters)
+	}
+
+	_, err = ResolveSecretRefs(parameters, func(ref SecretRef) (string, error) {
+		return "", fmt.Errorf("secret %s not found", ref.Name)
+	})
+	if err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected the error of the resolver, got %v", err)
+	}
+
+	refs, err := FindSecretRefs(map[string]any{"api_key": "secretRef://judge"})
+	if err == nil || len(refs) != 0 {
+		t.Errorf("expected an error for a malformed reference, got %v, %v", refs, err)
+	}
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+)
+
+// MaxSweepJobs is the largest number of child jobs that a sweep may create.
+const MaxSweepJobs = 100
+
+// maxEnumeratedSweepGrid is the largest grid that a random sweep samples from by
+// enumerating its combinations; larger grids are sampled one parameter at a time.
+const maxEnumeratedSweepGrid = 1 << 16
+
+// SweepStrategy is how the configurations of a sweep are chosen from its parameter values
+type SweepStrategy string
+
+const (
+	// SweepStrategyGrid runs every combination of the parameter values.
+	SweepStrategyGrid SweepStrategy = "grid"
+	// SweepStrategyRandom runs a random sample of the combinations.
+	SweepStrategyRandom SweepStrategy = "random"
+)
+
+// SweepTarget is where the values of a sweep parameter are set in the child jobs
+type SweepTarget string
+
+const (
+	// SweepTargetModel sets the value in the model parameters, e.g. temperature.
+	SweepTargetModel SweepTarget = "model"
+	// SweepTargetBenchmark sets the value in the parameters of every benchmark of the job,
+	// e.g. a prompt template.
+	SweepTargetBenchmark SweepTarget = "benchmark"
+)
+
+// SweepParameter is a parameter of a sweep with the values it takes
+type SweepParameter struct {
+	Target SweepTarget `json:"target" validate:"required,oneof=model benchmark"`
+	Name   string      `json:"name" validate:"required"`
+	Values []any       `json:"values" validate:"required,min=1,max=100"`
+}
+
+// Key returns the key of the parameter in the parameters of a sweep run, e.g. model.temperature.
+func (p SweepParameter) Key() string {
+	return string(p.Target) + "." + p.Name
+}
+
+// SweepConfig expands an evaluation job into one child job per configuration of the
+// parameters: every combination of their values for a grid sweep, Samples distinct
+// combinations drawn at random for a random one.
+type SweepConfig struct {
+	Strategy   SweepStrategy    `json:"strategy" validate:"required,oneof=grid random"`
+	Parameters []SweepParameter `json:"parameters" validate:"required,min=1,max=10,dive"`
+	// Samples is the number of configurations of a random sweep.
+	Samples int `json:"samples,omitempty" validate:"required_if=Strategy random,omitempty,min=1"`
+	// Seed makes the configurations of a random sweep reproducible.
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+// GridSize returns the number of combinations of the parameter values, or limit+1 when
+// there are more than limit.
+func (c *SweepConfig) GridSize(limit int) int {
+	size := 1
+	for _, parameter := range c.Parameters {
+		size *= len(parameter.Values)
+		if size > limit {
+			return limit + 1
+		}
+	}
+	return size
+}
+
+// Configurations returns the values of the parameters, in the order of the parameters,
+// for each child job of the sweep. The sweep must have been validated.
+func (c *SweepConfig) Configurations() [][]any {
+	size := c.GridSize(maxEnumeratedSweepGrid)
+	if c.Strategy != SweepStrategyRandom {
+		configurations := make([][]any, 0, size)
+		for i := range size {
+			configurations = append(configurations, c.configuration(i))
+		}
+		return configurations
+	}
+
+	seed := rand.Uint64()
+	if c.Seed != nil {
+		seed = uint64(*c.Seed)
+	}
+	rng := rand.New(rand.NewPCG(seed, 0))
+	count := min(c.Samples, size)
+	configurations := make([][]any, 0, count)
+	if size <= maxEnumeratedSweepGrid {
+		for _, i := range rng.Perm(size)[:count] {
+			configurations = append(configurations, c.configuration(i))
+		}
+		return configurations
+	}
+	// the grid is too large to enumerate, and large enough for repeated draws to be rare
+	seen := make(map[string]bool, count)
+	for len(configurations) < count {
+		picks := make([]int, len(c.Parameters))
+		for j, parameter := range c.Parameters {
+			picks[j] = rng.IntN(len(parameter.Values))
+		}
+		if key := fmt.Sprint(picks); !seen[key] {
+			seen[key] = true
+			configurations = append(configurations, c.values(picks))
+		}
+	}
+	return configurations
+}
+
+// configuration returns the i-th combination of the grid; the last parameter varies fastest.
+func (c *SweepConfig) configuration(i int) []any {
+	picks := make([]int, len(c.Parameters))
+	for j := len(c.Parameters) - 1; j >= 0; j-- {
+		n := len(c.Parameters[j].Values)
+		picks[j] = i % n
+		i /= n
+	}
+	return c.values(picks)
+}
+
+func (c *SweepConfig) values(picks []int) []any {
+	values := make([]any, len(picks))
+	for j, pick := range picks {
+		values[j] = c.Parameters[j].Values[pick]
+	}
+	return values
+}
+
+// SweepRun links a child job to its sweep. It is set by the server.
+type SweepRun struct {
+	SweepID  string        `json:"sweep_id"`
+	Strategy SweepStrategy `json:"strategy"`
+	// Index is the position of the job in the sweep and Count the number of jobs of the sweep.
+	Index int `json:"index"`
+	Count int `json:"count"`
+	// Parameters are the values of the sweep parameters for this job, keyed by target and
+	// name, e.g. model.temperature.
+	Parameters map[string]any `json:"parameters"`
+}
+
+// EvaluationSweepJob is a child job of a sweep
+type EvaluationSweepJob struct {
+	JobID      string         `json:"job_id"`
+	Parameters map[string]any `json:"parameters"`
+	State      OverallState   `json:"state"`
+	// Score is the weighted score of the completed job, see EvaluationTest.
+	Score *float32 `json:"score,omitempty"`
+}
+
+// EvaluationSweepResource reports the child jobs of a sweep and the best configuration
+// found so far, the completed job with the highest score. A job only has a score when its
+// benchmarks have a primary score and pass criteria, so Best is nil until one has.
+type EvaluationSweepResource struct {
+	ID       string               `json:"id"`
+	Strategy SweepStrategy        `json:"strategy"`
+	State    OverallState         `json:"state"`
+	Jobs     []EvaluationSweepJob `json:"jobs"`
+	Best     *EvaluationSweepJob  `json:"best,omitempty"`
+}
+
+// NewEvaluationSweepResource builds the sweep resource from its child jobs, which must
+// all belong to the sweep. The jobs are reported in the order of the sweep.
+func NewEvaluationSweepResource(id string, jobs []EvaluationJobResource) *EvaluationSweepResource {
+	jobs = slices.Clone(jobs)
+	slices.SortStableFunc(jobs, func(a, b EvaluationJobResource) int {
+		return sweepIndex(a) - sweepIndex(b)
+	})
+	sweep := &EvaluationSweepResource{
+		ID:   id,
+		Jobs: make([]EvaluationSweepJob, len(jobs)),
+	}
+	states := make(map[OverallState]int)
+	for i, job := range jobs {
+		sweepJob := EvaluationSweepJob{
+			JobID: job.Resource.ID,
+		}
+		if job.SweepRun != nil {
+			sweep.Strategy = job.SweepRun.Strategy
+			sweepJob.Parameters = maps.Clone(job.SweepRun.Parameters)
+		}
+		if job.Status != nil {
+			sweepJob.State = job.Status.State
+		}
+		if sweepJob.State == OverallStateCompleted && job.Results != nil && job.Results.Test != nil {
+			score := job.Results.Test.Score
+			sweepJob.Score = &score
+			if sweep.Best == nil || score > *sweep.Best.Score {
+				best := sweepJob
+				sweep.Best = &best
+			}
+		}
+		states[sweepJob.State]++
+		sweep.Jobs[i] = sweepJob
+	}
+	sweep.State = sweepState(states, len(jobs))
+	return sweep
+}
+
+func sweepIndex(job EvaluationJobResource) int {
+	if job.SweepRun == nil {
+		return 0
+	}
+	return job.SweepRun.Index
+}
+
+// sweepState is the state of a sweep from the number of its jobs in each state: running
+// until all jobs end, then the state they share or partially_failed.
+func sweepState(states map[OverallState]int, count int) OverallState {
+	switch {
+	case states[OverallStatePending]+states[OverallStateWaitingForModel] == count:
+		return OverallStatePending
+	case states[OverallStateCompleted] == count:
+		return OverallStateCompleted
+	case states[OverallStateFailed] == count:
+		return OverallStateFailed
+	case states[OverallStateCancelled] == count:
+		return OverallStateCancelled
+	}
+	for state := range states {
+		if !state.IsTerminalState() {
+			return OverallStateRunning
+		}
+	}
+	return OverallStatePartiallyFailed
+}
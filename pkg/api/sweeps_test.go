@@ -0,0 +1,115 @@
+package api
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestSweepConfigConfigurations(t *testing.T) {
+	parameters := []SweepParameter{
+		{Target: SweepTargetModel, Name: "temperature", Values: []any{0.0, 0.7}},
+		{Target: SweepTargetBenchmark, Name: "prompt_template", Values: []any{"plain", "cot", "few_shot"}},
+	}
+
+	t.Run("grid", func(t *testing.T) {
+		sweep := SweepConfig{Strategy: SweepStrategyGrid, Parameters: parameters}
+		got := fmt.Sprint(sweep.Configurations())
+		want := "[[0 plain] [0 cot] [0 few_shot] [0.7 plain] [0.7 cot] [0.7 few_shot]]"
+		if got != want {
+			t.Fatalf("got %s, want %s", got, want)
+		}
+	})
+
+	t.Run("random", func(t *testing.T) {
+		seed := int64(42)
+		sweep := SweepConfig{Strategy: SweepStrategyRandom, Samples: 4, Seed: &seed, Parameters: parameters}
+		first := sweep.Configurations()
+		if len(first) != 4 {
+			t.Fatalf("expected 4 configurations, got %v", first)
+		}
+		keys := make([]string, len(first))
+		for i, configuration := range first {
+			keys[i] = fmt.Sprint(configuration)
+		}
+		slices.Sort(keys)
+		if len(slices.Compact(keys)) != 4 {
+			t.Errorf("expected distinct configurations, got %v", first)
+		}
+		if again := sweep.Configurations(); fmt.Sprint(again) != fmt.Sprint(first) {
+			t.Errorf("expected the seed to draw the same configurations, got %v and %v", first, again)
+		}
+	})
+
+	t.Run("random over a grid too large to enumerate", func(t *testing.T) {
+		values := make([]any, 100)
+		for i := range values {
+			values[i] = i
+		}
+		sweep := SweepConfig{Strategy: SweepStrategyRandom, Samples: 50, Parameters: []SweepParameter{
+			{Target: SweepTargetModel, Name: "a", Values: values},
+			{Target: SweepTargetModel, Name: "b", Values: values},
+			{Target: SweepTargetModel, Name: "c", Values: values},
+		}}
+		if got := sweep.Configurations(); len(got) != 50 {
+			t.Fatalf("expected 50 configurations, got %d", len(got))
+		}
+	})
+}
+
+func TestNewEvaluationSweepResource(t *testing.T) {
+	job := func(id string, index int, state OverallState, score *float32) EvaluationJobResource {
+		job := EvaluationJobResource{
+			Resource: EvaluationResource{Resource: Resource{ID: id}},
+			Status:   &EvaluationJobStatus{EvaluationJobState: EvaluationJobState{State: state}},
+			EvaluationJobConfig: EvaluationJobConfig{SweepRun: &SweepRun{
+				SweepID: "sweep", Strategy: SweepStrategyGrid, Index: index, Count: 3,
+				Parameters: map[string]any{"model.temperature": index},
+			}},
+		}
+		if score != nil {
+			job.Results = &EvaluationJobResults{Test: &EvaluationTest{Score: *score}}
+		}
+		return job
+	}
+	score := func(v float32) *float32 { return &v }
+
+	// the storage lists the newest jobs first
+	sweep := NewEvaluationSweepResource("sweep", []EvaluationJobResource{
+		job("job-2", 2, OverallStateFailed, nil),
+		job("job-1", 1, OverallStateCompleted, score(0.9)),
+		job("job-0", 0, OverallStateCompleted, score(0.4)),
+	})
+	if sweep.Strategy != SweepStrategyGrid || sweep.State != OverallStatePartiallyFailed {
+		t.Errorf("unexpected strategy %q or state %q", sweep.Strategy, sweep.State)
+	}
+	if len(sweep.Jobs) != 3 || sweep.Jobs[0].JobID != "job-0" || sweep.Jobs[2].JobID != "job-2" {
+		t.Fatalf("expected the jobs in the order of the sweep, got %+v", sweep.Jobs)
+	}
+	if sweep.Jobs[2].Score != nil {
+		t.Errorf("expected no score for a failed job")
+	}
+	if sweep.Best == nil || sweep.Best.JobID != "job-1" || *sweep.Best.Score != 0.9 {
+		t.Errorf("unexpected best job %+v", sweep.Best)
+	}
+
+	states := []struct {
+		states []OverallState
+		want   OverallState
+	}{
+		{[]OverallState{OverallStatePending, OverallStatePending}, OverallStatePending},
+		{[]OverallState{OverallStatePending, OverallStateCompleted}, OverallStateRunning},
+		{[]OverallState{OverallStateCompleted, OverallStateCompleted}, OverallStateCompleted},
+		{[]OverallState{OverallStateCancelled, OverallStateCancelled}, OverallStateCancelled},
+		{[]OverallState{OverallStateCompleted, OverallStateCancelled}, OverallStatePartiallyFailed},
+	}
+	for _, tt := range states {
+		jobs := make([]EvaluationJobResource, len(tt.states))
+		for i, state := range tt.states {
+			jobs[i] = job(fmt.Sprint(i), i, state, nil)
+		}
+		if got := NewEvaluationSweepResource("sweep", jobs).State; got != tt.want {
+			t.Errorf("states %v: got %q, want %q", tt.states, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package api
+
+import (
+	"strings"
+	"time"
+)
+
+// TagSeparator separates the key and the value of a tag, e.g. env:production. A tag without
+// separator is a key without value, e.g. smoke_test.
+const TagSeparator = ":"
+
+// TagPolicy is the taxonomy of the tags of the evaluation jobs and collections of a tenant,
+// enforced when they are created, so that filters on tags stay reliable.
+type TagPolicy struct {
+	// Keys are the allowed tag keys. When set, the tags with another key are rejected.
+	Keys []TagPolicyKey `json:"keys,omitempty" validate:"omitempty,max=500,dive"`
+	// Required are the keys that every job and collection must be tagged with, e.g. cost-center.
+	Required []string `json:"required,omitempty" validate:"omitempty,max=50,dive,tagname,excludes=:"`
+}
+
+// TagPolicyKey is an allowed tag key, with its allowed values.
+type TagPolicyKey struct {
+	Key string `json:"key" validate:"required,tagname,excludes=:"`
+	// Values are the allowed values of the key. When set, a tag with the key must have one of
+	// them, e.g. env:production but not env:prod; otherwise the key takes any value, or none.
+	Values      []string `json:"values,omitempty" validate:"omitempty,max=500,dive,tagname"`
+	Description string   `json:"description,omitempty" validate:"omitempty,max=1024"`
+}
+
+// TagPolicyResource is the tag policy of a tenant.
+type TagPolicyResource struct {
+	Tenant    Tenant    `json:"tenant,omitempty"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+	UpdatedBy User      `json:"updated_by,omitempty"`
+	TagPolicy
+}
+
+// IsEmpty returns true when the policy allows any tag.
+func (p *TagPolicy) IsEmpty() bool {
+	return p == nil || (len(p.Keys) == 0 && len(p.Required) == 0)
+}
+
+// Key returns the allowed key of the policy, nil when it is not listed.
+func (p *TagPolicy) Key(key string) *TagPolicyKey {
+	if p == nil {
+		return nil
+	}
+	for i := range p.Keys {
+		if p.Keys[i].Key == key {
+			return &p.Keys[i]
+		}
+	}
+	return nil
+}
+
+// SplitTag returns the key and the value of a tag, the value is empty for a tag without
+// separator.
+func SplitTag(tag string) (key string, value string) {
+	key, value, _ = strings.Cut(tag, TagSeparator)
+	return key, value
+}
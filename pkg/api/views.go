@@ -0,0 +1,64 @@
+package api
+
+import "time"
+
+// EvaluationViewFilter is a saved filter of the evaluation jobs list. Each field is applied as
+// the query parameter of GET /api/v1/evaluations/jobs with the same name, e.g. a status of
+// running|pending lists the running and the pending jobs.
+type EvaluationViewFilter struct {
+	Status       string `json:"status,omitempty" validate:"omitempty,max=256"`
+	Name         string `json:"name,omitempty" validate:"omitempty,max=255"`
+	Owner        string `json:"owner,omitempty" validate:"omitempty,max=255"`
+	Tags         string `json:"tags,omitempty" validate:"omitempty,max=1024"`
+	Model        string `json:"model,omitempty" validate:"omitempty,max=255"`
+	ExperimentID string `json:"experiment_id,omitempty" validate:"omitempty,max=255"`
+	Annotation   string `json:"annotation,omitempty" validate:"omitempty,max=1024"`
+	// CreatedAfter and CreatedBefore bound the creation time of the jobs.
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+}
+
+// EvaluationViewConfig is a named filter of the evaluation jobs list, shared by the users of a
+// tenant, so that dashboards and CLIs list the jobs by view ID rather than query strings.
+type EvaluationViewConfig struct {
+	Name        string               `json:"name" validate:"required,max=255"`
+	Description string               `json:"description,omitempty" validate:"omitempty,max=1024"`
+	Filter      EvaluationViewFilter `json:"filter"`
+}
+
+// EvaluationViewResource represents a saved view of the evaluation jobs list.
+type EvaluationViewResource struct {
+	Resource Resource `json:"resource"`
+	EvaluationViewConfig
+}
+
+// EvaluationViewResourceList represents list of evaluation view resources with pagination
+type EvaluationViewResourceList struct {
+	Page
+	Items []EvaluationViewResource `json:"items"`
+}
+
+// QueryParams returns the query parameters of the jobs list that the filter sets.
+func (f *EvaluationViewFilter) QueryParams() map[string]any {
+	params := map[string]any{}
+	for name, value := range map[string]string{
+		"status":        f.Status,
+		"name":          f.Name,
+		"owner":         f.Owner,
+		"tags":          f.Tags,
+		"model":         f.Model,
+		"experiment_id": f.ExperimentID,
+		"annotation":    f.Annotation,
+	} {
+		if value != "" {
+			params[name] = value
+		}
+	}
+	if f.CreatedAfter != nil {
+		params["created_after"] = *f.CreatedAfter
+	}
+	if f.CreatedBefore != nil {
+		params["created_before"] = *f.CreatedBefore
+	}
+	return params
+}
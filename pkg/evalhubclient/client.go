@@ -1,6 +1,7 @@
 package evalhubclient
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"crypto/tls"
@@ -31,14 +32,27 @@ const (
 	// DefaultListPageLimit is the page size used for eval-hub list APIs when the caller
 	// does not set an explicit limit. evalhub-mcp applies this (configurable via list_page_limit).
 	DefaultListPageLimit = 200
+
+	// DefaultWaitPollInterval is the interval WaitForJob uses between status polls
+	// when the caller passes a non-positive interval.
+	DefaultWaitPollInterval = 5 * time.Second
+
+	// watchEventStatus is the name of the server-sent events that carry the job.
+	watchEventStatus = "status"
+	// maxWatchEventBytes caps a job read from the watch stream.
+	maxWatchEventBytes = 16 << 20
 )
 
 // APIError represents a typed error returned by the eval-hub API.
 type APIError struct {
 	StatusCode int
 	Code       string
-	Message    string
-	Trace      string
+	// ErrorCode is the stable error code of the response, e.g. EVAL_RESOURCE_NOT_FOUND.
+	ErrorCode string
+	Message   string
+	DocsURL   string
+	Retriable bool
+	Trace     string
 }
 
 func (e *APIError) Error() string {
@@ -396,7 +410,10 @@ func (c *Client) parseAPIError(statusCode int, body []byte) *APIError {
 		var errResp api.Error
 		if err := json.Unmarshal(body, &errResp); err == nil {
 			apiErr.Code = errResp.MessageCode
+			apiErr.ErrorCode = errResp.Code
 			apiErr.Message = errResp.Message
+			apiErr.DocsURL = errResp.DocsURL
+			apiErr.Retriable = errResp.Retriable
 			apiErr.Trace = errResp.Trace
 		}
 	}
@@ -611,7 +628,8 @@ func (c *Client) ListJobsByStatus(status api.OverallState, opts ...ListOption) (
 	return c.ListJobs(opts...)
 }
 
-// CreateJob submits a new evaluation job and returns the created resource.
+// CreateJob submits a new evaluation job and returns the created resource. Use CreateSweep
+// for a job with a sweep block.
 func (c *Client) CreateJob(config api.EvaluationJobConfig) (*api.EvaluationJobResource, error) {
 	body, _, err := c.doRequest(http.MethodPost, apiBasePath+"/jobs", config, nil)
 	if err != nil {
@@ -620,12 +638,284 @@ func (c *Client) CreateJob(config api.EvaluationJobConfig) (*api.EvaluationJobRe
 	return decode[api.EvaluationJobResource](body)
 }
 
+// PatchJob applies JSON Patch operations to the annotations and links of a job, e.g.
+// to link it to a ticket once it has run.
+func (c *Client) PatchJob(id string, patches api.Patch) (*api.EvaluationJobResource, error) {
+	body, _, err := c.doRequest(http.MethodPatch, apiBasePath+"/jobs/"+url.PathEscape(id), patches, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decode[api.EvaluationJobResource](body)
+}
+
+// TransferJob hands a job over to another user of the tenant.
+func (c *Client) TransferJob(id string, owner api.User) (*api.EvaluationJobResource, error) {
+	body, _, err := c.doRequest(http.MethodPut, apiBasePath+"/jobs/"+url.PathEscape(id)+"/owner", api.JobOwnership{Owner: owner}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decode[api.EvaluationJobResource](body)
+}
+
+// ShareJob replaces the users and groups a job is shared with, who can read it when jobs
+// are scoped to their owner.
+func (c *Client) ShareJob(id string, sharing api.JobSharing) (*api.EvaluationJobResource, error) {
+	body, _, err := c.doRequest(http.MethodPut, apiBasePath+"/jobs/"+url.PathEscape(id)+"/sharing", sharing, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decode[api.EvaluationJobResource](body)
+}
+
+// CreateSweep submits an evaluation job with a sweep block and returns the sweep, whose
+// child jobs have been created.
+func (c *Client) CreateSweep(config api.EvaluationJobConfig) (*api.EvaluationSweepResource, error) {
+	if config.Sweep == nil {
+		return nil, fmt.Errorf("the job has no sweep")
+	}
+	body, _, err := c.doRequest(http.MethodPost, apiBasePath+"/jobs", config, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decode[api.EvaluationSweepResource](body)
+}
+
+// GetSweep returns the sweep with the given ID, with the state and score of its child jobs.
+func (c *Client) GetSweep(id string) (*api.EvaluationSweepResource, error) {
+	body, _, err := c.doRequest(http.MethodGet, apiBasePath+"/sweeps/"+url.PathEscape(id), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decode[api.EvaluationSweepResource](body)
+}
+
 // CancelJob cancels the evaluation job with the given ID.
 func (c *Client) CancelJob(id string) error {
 	_, _, err := c.doRequest(http.MethodDelete, apiBasePath+"/jobs/"+url.PathEscape(id), nil, nil)
 	return err
 }
 
+// DeleteJob cancels the evaluation job with the given ID and removes it from storage.
+func (c *Client) DeleteJob(id string) error {
+	params := url.Values{}
+	params.Set("hard_delete", "true")
+	_, _, err := c.doRequest(http.MethodDelete, apiBasePath+"/jobs/"+url.PathEscape(id), nil, params)
+	return err
+}
+
+// WaitForJob waits until the evaluation job reaches a terminal state and returns the final
+// resource. It watches the job with GET /api/v1/evaluations/jobs/{id}/watch, and polls
+// GetJob every pollInterval instead when the server does not stream the job or the stream
+// ends before the job finished. The wait is bounded by the client context (see
+// WithContext); when the context ends first, the last observed job is returned together
+// with the context error. A non-positive pollInterval uses DefaultWaitPollInterval.
+func (c *Client) WaitForJob(id string, pollInterval time.Duration) (*api.EvaluationJobResource, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultWaitPollInterval
+	}
+	ctx := c.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	last, err := c.watchJob(ctx, id)
+	if last != nil && last.Status != nil && last.Status.State.IsTerminalState() {
+		return last, nil
+	}
+	if ctx.Err() != nil {
+		return last, ctx.Err()
+	}
+	c.effectiveLogger().Debug("Polling evaluation job", "job_id", id, "state", jobState(last), "error", err)
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := c.GetJob(id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, ctx.Err()
+			}
+			return nil, err
+		}
+		last = job
+		if job.Status != nil && job.Status.State.IsTerminalState() {
+			return job, nil
+		}
+		c.effectiveLogger().Debug("Waiting for evaluation job", "job_id", id, "state", jobState(job))
+
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchJob reads the server-sent events of the job until it reaches a terminal state and
+// returns the last job received. The stream is bounded by the context rather than the
+// timeout of the HTTP client, as the server keeps it open while the job runs.
+func (c *Client) watchJob(ctx context.Context, id string) (*api.EvaluationJobResource, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+apiBasePath+"/jobs/"+url.PathEscape(id)+"/watch", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	c.setHeaders(req)
+	req.Header.Set("Accept", "text/event-stream")
+
+	streamClient := *c.httpClient
+	streamClient.Timeout = 0
+	resp, err := streamClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return nil, fmt.Errorf("the server does not stream the job: HTTP %d, %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	var (
+		last  *api.EvaluationJobResource
+		event string
+		data  []byte
+	)
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxWatchEventBytes)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// a blank line ends the event
+			if len(data) > 0 && (event == "" || event == watchEventStatus) {
+				job, err := decode[api.EvaluationJobResource](data)
+				if err != nil {
+					return last, err
+				}
+				last = job
+				if job.Status != nil && job.Status.State.IsTerminalState() {
+					return job, nil
+				}
+				c.effectiveLogger().Debug("Waiting for evaluation job", "job_id", id, "state", jobState(job))
+			}
+			event, data = "", nil
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data != nil {
+				data = append(data, '\n')
+			}
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " ")...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return last, err
+	}
+	return last, io.ErrUnexpectedEOF
+}
+
+func jobState(job *api.EvaluationJobResource) api.OverallState {
+	if job == nil || job.Status == nil {
+		return ""
+	}
+	return job.Status.State
+}
+
+// ─── Baselines ────────────────────────────────────────────────────────────────
+
+// ListBaselines returns the named baselines of the tenant. Use WithLimit/WithOffset for pagination.
+func (c *Client) ListBaselines(opts ...ListOption) (*api.BaselineResourceList, error) {
+	body, _, err := c.doRequest(http.MethodGet, apiBasePath+"/baselines", nil, applyListOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	list, err := decode[api.BaselineResourceList](body)
+	if err != nil {
+		return nil, err
+	}
+	c.logTruncatedListPage(apiBasePath+"/baselines", list.Page)
+	return list, nil
+}
+
+// CreateBaseline registers a completed evaluation job as a named baseline.
+func (c *Client) CreateBaseline(config api.BaselineConfig) (*api.BaselineResource, error) {
+	body, _, err := c.doRequest(http.MethodPost, apiBasePath+"/baselines", config, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decode[api.BaselineResource](body)
+}
+
+// GetBaseline returns the baseline with the given name.
+func (c *Client) GetBaseline(name string) (*api.BaselineResource, error) {
+	body, _, err := c.doRequest(http.MethodGet, apiBasePath+"/baselines/"+url.PathEscape(name), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decode[api.BaselineResource](body)
+}
+
+// DeleteBaseline deletes the baseline with the given name. The job it refers to is kept.
+func (c *Client) DeleteBaseline(name string) error {
+	_, _, err := c.doRequest(http.MethodDelete, apiBasePath+"/baselines/"+url.PathEscape(name), nil, nil)
+	return err
+}
+
+// CompareJob compares the results of the evaluation job with the given ID to a named baseline.
+func (c *Client) CompareJob(id string, baseline string) (*api.BaselineComparison, error) {
+	params := url.Values{}
+	params.Set("baseline", baseline)
+	body, _, err := c.doRequest(http.MethodGet, apiBasePath+"/jobs/"+url.PathEscape(id)+"/comparison", nil, params)
+	if err != nil {
+		return nil, err
+	}
+	return decode[api.BaselineComparison](body)
+}
+
+// ListJobFindings returns the safety findings of the benchmarks of the evaluation job with the
+// given ID, the most severe first. Use WithSeverity/WithMinSeverity to filter them and
+// WithLimit/WithOffset for pagination.
+func (c *Client) ListJobFindings(id string, opts ...ListOption) (*api.FindingResourceList, error) {
+	path := apiBasePath + "/jobs/" + url.PathEscape(id) + "/findings"
+	body, _, err := c.doRequest(http.MethodGet, path, nil, applyListOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	list, err := decode[api.FindingResourceList](body)
+	if err != nil {
+		return nil, err
+	}
+	c.logTruncatedListPage(path, list.Page)
+	return list, nil
+}
+
+// ─── Reviews ──────────────────────────────────────────────────────────────────
+
+// ListReviews returns the reviews of the evaluation jobs whose scores were within the review
+// band of their threshold, the pending ones unless WithReviewState says otherwise. Use
+// WithLimit/WithOffset for pagination.
+func (c *Client) ListReviews(opts ...ListOption) (*api.ReviewResourceList, error) {
+	body, _, err := c.doRequest(http.MethodGet, apiBasePath+"/reviews", nil, applyListOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	list, err := decode[api.ReviewResourceList](body)
+	if err != nil {
+		return nil, err
+	}
+	c.logTruncatedListPage(apiBasePath+"/reviews", list.Page)
+	return list, nil
+}
+
+// ReviewJob approves or rejects the pending review of the evaluation job with the given ID
+// and returns the job, whose test result passes only when it is approved.
+func (c *Client) ReviewJob(id string, decision api.ReviewDecision) (*api.EvaluationJobResource, error) {
+	body, _, err := c.doRequest(http.MethodPost, apiBasePath+"/jobs/"+url.PathEscape(id)+"/review", decision, nil)
+	if err != nil {
+		return nil, err
+	}
+	return decode[api.EvaluationJobResource](body)
+}
+
 // ─── List options ─────────────────────────────────────────────────────────────
 
 // ListOption configures query parameters for list endpoints.
@@ -641,6 +931,54 @@ func WithOffset(n int) ListOption {
 	return func(v url.Values) { v.Set("offset", fmt.Sprintf("%d", n)) }
 }
 
+// WithName filters list results by name.
+func WithName(name string) ListOption {
+	return func(v url.Values) { v.Set("name", name) }
+}
+
+// WithTags filters list results to resources carrying all of the given tags.
+func WithTags(tags ...string) ListOption {
+	return func(v url.Values) { v.Set("tags", strings.Join(tags, ",")) }
+}
+
+// WithOwner filters list results by owner.
+func WithOwner(owner string) ListOption {
+	return func(v url.Values) { v.Set("owner", owner) }
+}
+
+// WithAnnotation filters jobs to those with the annotation set to value, or set at all
+// when value is empty.
+func WithAnnotation(key, value string) ListOption {
+	if value == "" {
+		return func(v url.Values) { v.Set("annotation", key) }
+	}
+	return func(v url.Values) { v.Set("annotation", key+":"+value) }
+}
+
+// WithLink filters jobs to those linking to the given URL.
+func WithLink(linkURL string) ListOption {
+	return func(v url.Values) { v.Set("link", linkURL) }
+}
+
+// WithSeverity filters findings to those of any of the given severities.
+func WithSeverity(severities ...api.Severity) ListOption {
+	values := make([]string, 0, len(severities))
+	for _, severity := range severities {
+		values = append(values, string(severity))
+	}
+	return func(v url.Values) { v.Set("severity", strings.Join(values, ",")) }
+}
+
+// WithMinSeverity filters findings to those of the given severity or higher.
+func WithMinSeverity(severity api.Severity) ListOption {
+	return func(v url.Values) { v.Set("min_severity", string(severity)) }
+}
+
+// WithReviewState filters reviews by state.
+func WithReviewState(state api.ReviewState) ListOption {
+	return func(v url.Values) { v.Set("state", string(state)) }
+}
+
 // withRawParam is an unexported option for setting an arbitrary query parameter.
 func withRawParam(key, value string) ListOption {
 	return func(v url.Values) { v.Set(key, value) }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
@@ -254,6 +255,32 @@ func TestErrorInternalServerError(t *testing.T) {
 	}
 }
 
+func TestErrorCodeDocsURLAndRetriable(t *testing.T) {
+	body, _ := json.Marshal(api.Error{
+		Code:        "EVAL_DATABASE_OPERATION_FAILED",
+		MessageCode: "database_operation_failed",
+		Message:     "database unavailable",
+		DocsURL:     "https://example.com/errors.md#eval_database_operation_failed",
+		Retriable:   true,
+	})
+	srv, _ := newCapturingServer(t, http.StatusInternalServerError, body)
+
+	_, err := newTestClient(srv).WithMaxRetries(0).GetHealth()
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("want *APIError, got %T: %v", err, err)
+	}
+	if apiErr.ErrorCode != "EVAL_DATABASE_OPERATION_FAILED" {
+		t.Errorf("ErrorCode = %q, want EVAL_DATABASE_OPERATION_FAILED", apiErr.ErrorCode)
+	}
+	if apiErr.DocsURL != "https://example.com/errors.md#eval_database_operation_failed" {
+		t.Errorf("DocsURL = %q", apiErr.DocsURL)
+	}
+	if !apiErr.Retriable {
+		t.Errorf("Retriable = false, want true")
+	}
+}
+
 func TestErrorMessageFallback(t *testing.T) {
 	// Response body is not valid JSON — the client must fall back to the HTTP status text.
 	srv, _ := newCapturingServer(t, http.StatusBadRequest, []byte("not json"))
@@ -689,6 +716,208 @@ func TestCreateJob(t *testing.T) {
 	}
 }
 
+func TestCreateSweep(t *testing.T) {
+	cfg := api.EvaluationJobConfig{
+		Name:  "prompt-sweep",
+		Model: api.ModelRef{URL: "http://llm:8000", Name: "llama3"},
+		Sweep: &api.SweepConfig{Strategy: api.SweepStrategyGrid, Parameters: []api.SweepParameter{
+			{Target: api.SweepTargetModel, Name: "temperature", Values: []any{0.0, 0.7}},
+		}},
+	}
+	want := api.EvaluationSweepResource{ID: "sweep-1", Strategy: api.SweepStrategyGrid, State: api.OverallStatePending}
+	srv, capture := newCapturingServer(t, http.StatusAccepted, mustMarshal(t, want))
+
+	got, err := newTestClient(srv).CreateSweep(cfg)
+	if err != nil {
+		t.Fatalf("CreateSweep: %v", err)
+	}
+	if capture.method != http.MethodPost || capture.path != "/api/v1/evaluations/jobs" {
+		t.Errorf("request = %s %s, want POST /api/v1/evaluations/jobs", capture.method, capture.path)
+	}
+	if got.ID != "sweep-1" {
+		t.Errorf("ID = %q, want sweep-1", got.ID)
+	}
+
+	cfg.Sweep = nil
+	if _, err := newTestClient(srv).CreateSweep(cfg); err == nil {
+		t.Errorf("expected an error for a job without a sweep")
+	}
+}
+
+func TestGetSweep(t *testing.T) {
+	want := api.EvaluationSweepResource{ID: "sweep-1", State: api.OverallStateRunning}
+	srv, capture := newCapturingServer(t, http.StatusOK, mustMarshal(t, want))
+
+	got, err := newTestClient(srv).GetSweep("sweep-1")
+	if err != nil {
+		t.Fatalf("GetSweep: %v", err)
+	}
+	if capture.path != "/api/v1/evaluations/sweeps/sweep-1" {
+		t.Errorf("path = %s, want /api/v1/evaluations/sweeps/sweep-1", capture.path)
+	}
+	if got.State != api.OverallStateRunning {
+		t.Errorf("State = %q, want running", got.State)
+	}
+}
+
+func TestCreateBaseline(t *testing.T) {
+	want := api.BaselineResource{BaselineConfig: api.BaselineConfig{Name: "prod-v3", JobID: "job-1"}, Score: 0.8}
+	srv, capture := newCapturingServer(t, http.StatusCreated, mustMarshal(t, want))
+
+	got, err := newTestClient(srv).CreateBaseline(api.BaselineConfig{Name: "prod-v3", JobID: "job-1"})
+	if err != nil {
+		t.Fatalf("CreateBaseline: %v", err)
+	}
+	if capture.method != http.MethodPost {
+		t.Errorf("method = %s, want POST", capture.method)
+	}
+	if capture.path != "/api/v1/evaluations/baselines" {
+		t.Errorf("path = %s, want /api/v1/evaluations/baselines", capture.path)
+	}
+	if got.Score != 0.8 {
+		t.Errorf("Score = %v, want 0.8", got.Score)
+	}
+}
+
+func TestCompareJob(t *testing.T) {
+	want := api.BaselineComparison{JobID: "job-1", Baseline: "prod-v3", Regressed: true}
+	srv, capture := newCapturingServer(t, http.StatusOK, mustMarshal(t, want))
+
+	got, err := newTestClient(srv).CompareJob("job-1", "prod-v3")
+	if err != nil {
+		t.Fatalf("CompareJob: %v", err)
+	}
+	if capture.path != "/api/v1/evaluations/jobs/job-1/comparison" {
+		t.Errorf("path = %s, want /api/v1/evaluations/jobs/job-1/comparison", capture.path)
+	}
+	if capture.query != "baseline=prod-v3" {
+		t.Errorf("query = %q, want baseline=prod-v3", capture.query)
+	}
+	if !got.Regressed {
+		t.Errorf("Regressed = false, want true")
+	}
+}
+
+func TestListJobFindings(t *testing.T) {
+	want := api.FindingResourceList{Items: []api.FindingResource{{JobID: "job-1", Finding: api.Finding{Probe: "dan.Dan_11_0", Severity: api.SeverityHigh}}}}
+	srv, capture := newCapturingServer(t, http.StatusOK, mustMarshal(t, want))
+
+	got, err := newTestClient(srv).ListJobFindings("job-1", WithSeverity(api.SeverityHigh, api.SeverityCritical))
+	if err != nil {
+		t.Fatalf("ListJobFindings: %v", err)
+	}
+	if capture.path != "/api/v1/evaluations/jobs/job-1/findings" {
+		t.Errorf("path = %s, want /api/v1/evaluations/jobs/job-1/findings", capture.path)
+	}
+	if capture.query != "severity=high%2Ccritical" {
+		t.Errorf("query = %q, want severity=high%%2Ccritical", capture.query)
+	}
+	if len(got.Items) != 1 || got.Items[0].Probe != "dan.Dan_11_0" {
+		t.Errorf("Items = %+v, want the finding", got.Items)
+	}
+}
+
+func TestReviewJob(t *testing.T) {
+	want := api.EvaluationJobResource{Results: &api.EvaluationJobResults{Test: &api.EvaluationTest{
+		Pass:   true,
+		Review: &api.Review{State: api.ReviewStateApproved, Reviewer: "carol"},
+	}}}
+	srv, capture := newCapturingServer(t, http.StatusOK, mustMarshal(t, want))
+
+	got, err := newTestClient(srv).ReviewJob("job-1", api.ReviewDecision{Decision: api.ReviewStateApproved, Comment: "within noise"})
+	if err != nil {
+		t.Fatalf("ReviewJob: %v", err)
+	}
+	if capture.method != http.MethodPost {
+		t.Errorf("method = %s, want POST", capture.method)
+	}
+	if capture.path != "/api/v1/evaluations/jobs/job-1/review" {
+		t.Errorf("path = %s, want /api/v1/evaluations/jobs/job-1/review", capture.path)
+	}
+	if !got.Results.Test.Pass || got.Results.Test.Review.Reviewer != "carol" {
+		t.Errorf("Test = %+v, want the approved review", got.Results.Test)
+	}
+}
+
+func TestListReviews(t *testing.T) {
+	want := api.ReviewResourceList{Items: []api.ReviewResource{{JobID: "job-1", Review: api.Review{State: api.ReviewStateRejected}}}}
+	srv, capture := newCapturingServer(t, http.StatusOK, mustMarshal(t, want))
+
+	got, err := newTestClient(srv).ListReviews(WithReviewState(api.ReviewStateRejected))
+	if err != nil {
+		t.Fatalf("ListReviews: %v", err)
+	}
+	if capture.path != "/api/v1/evaluations/reviews" || capture.query != "state=rejected" {
+		t.Errorf("request = %s?%s, want /api/v1/evaluations/reviews?state=rejected", capture.path, capture.query)
+	}
+	if len(got.Items) != 1 || got.Items[0].JobID != "job-1" {
+		t.Errorf("Items = %+v, want the review", got.Items)
+	}
+}
+
+func TestPatchJob(t *testing.T) {
+	want := api.EvaluationJobResource{EvaluationJobConfig: api.EvaluationJobConfig{
+		EvaluationJobMetadata: api.EvaluationJobMetadata{Annotations: map[string]string{"ticket": "INC-42"}},
+	}}
+	srv, capture := newCapturingServer(t, http.StatusOK, mustMarshal(t, want))
+
+	got, err := newTestClient(srv).PatchJob("job-1", api.Patch{{Op: api.PatchOpAdd, Path: "/annotations/ticket", Value: "INC-42"}})
+	if err != nil {
+		t.Fatalf("PatchJob: %v", err)
+	}
+	if capture.method != http.MethodPatch {
+		t.Errorf("method = %s, want PATCH", capture.method)
+	}
+	if capture.path != "/api/v1/evaluations/jobs/job-1" {
+		t.Errorf("path = %s, want /api/v1/evaluations/jobs/job-1", capture.path)
+	}
+	if got.Annotations["ticket"] != "INC-42" {
+		t.Errorf("Annotations = %v, want the ticket annotation", got.Annotations)
+	}
+}
+
+func TestTransferJob(t *testing.T) {
+	want := api.EvaluationJobResource{Resource: api.EvaluationResource{Resource: api.Resource{ID: "job-1", Owner: "bob"}}}
+	srv, capture := newCapturingServer(t, http.StatusOK, mustMarshal(t, want))
+
+	got, err := newTestClient(srv).TransferJob("job-1", "bob")
+	if err != nil {
+		t.Fatalf("TransferJob: %v", err)
+	}
+	if capture.method != http.MethodPut {
+		t.Errorf("method = %s, want PUT", capture.method)
+	}
+	if capture.path != "/api/v1/evaluations/jobs/job-1/owner" {
+		t.Errorf("path = %s, want /api/v1/evaluations/jobs/job-1/owner", capture.path)
+	}
+	if string(capture.body) != `{"owner":"bob"}` {
+		t.Errorf("body = %s, want the new owner", capture.body)
+	}
+	if got.Resource.Owner != "bob" {
+		t.Errorf("Owner = %s, want bob", got.Resource.Owner)
+	}
+}
+
+func TestShareJob(t *testing.T) {
+	sharing := api.JobSharing{Users: []api.User{"bob"}, Groups: []string{"ml-team"}}
+	want := api.EvaluationJobResource{EvaluationJobConfig: api.EvaluationJobConfig{SharedWith: &sharing}}
+	srv, capture := newCapturingServer(t, http.StatusOK, mustMarshal(t, want))
+
+	got, err := newTestClient(srv).ShareJob("job-1", sharing)
+	if err != nil {
+		t.Fatalf("ShareJob: %v", err)
+	}
+	if capture.method != http.MethodPut {
+		t.Errorf("method = %s, want PUT", capture.method)
+	}
+	if capture.path != "/api/v1/evaluations/jobs/job-1/sharing" {
+		t.Errorf("path = %s, want /api/v1/evaluations/jobs/job-1/sharing", capture.path)
+	}
+	if got.SharedWith == nil || len(got.SharedWith.Groups) != 1 {
+		t.Errorf("SharedWith = %+v, want the sharing", got.SharedWith)
+	}
+}
+
 func TestCancelJob(t *testing.T) {
 	srv, capture := newCapturingServer(t, http.StatusNoContent, nil)
 
@@ -704,6 +933,150 @@ func TestCancelJob(t *testing.T) {
 	}
 }
 
+func TestDeleteJob(t *testing.T) {
+	srv, capture := newCapturingServer(t, http.StatusNoContent, nil)
+
+	if err := newTestClient(srv).DeleteJob("job-xyz"); err != nil {
+		t.Fatalf("DeleteJob: %v", err)
+	}
+	if capture.method != http.MethodDelete {
+		t.Errorf("method = %s, want DELETE", capture.method)
+	}
+	if capture.query != "hard_delete=true" {
+		t.Errorf("query = %q, want hard_delete=true", capture.query)
+	}
+}
+
+func TestListJobsFilterParams(t *testing.T) {
+	srv, capture := newCapturingServer(t, http.StatusOK, mustMarshal(t, api.EvaluationJobResourceList{}))
+
+	_, err := newTestClient(srv).ListJobs(WithName("nightly"), WithTags("a", "b"), WithOwner("alice"), WithAnnotation("team", "ml"))
+	if err != nil {
+		t.Fatalf("ListJobs: %v", err)
+	}
+	for _, want := range []string{"name=nightly", "tags=a%2Cb", "owner=alice", "annotation=team%3Aml"} {
+		if !strings.Contains(capture.query, want) {
+			t.Errorf("query %q missing %s", capture.query, want)
+		}
+	}
+}
+
+func jobInState(state api.OverallState) api.EvaluationJobResource {
+	return api.EvaluationJobResource{
+		Status: &api.EvaluationJobStatus{EvaluationJobState: api.EvaluationJobState{State: state}},
+	}
+}
+
+func TestWaitForJob(t *testing.T) {
+	states := []api.OverallState{api.OverallStatePending, api.OverallStateRunning, api.OverallStateCompleted}
+	calls := 0
+	// a server without the watch API is polled
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/watch") {
+			http.NotFound(w, r)
+			return
+		}
+		state := states[min(calls, len(states)-1)]
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mustMarshal(t, jobInState(state))) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+
+	got, err := newTestClient(srv).WaitForJob("job-1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForJob: %v", err)
+	}
+	if got.Status.State != api.OverallStateCompleted {
+		t.Errorf("state = %s, want completed", got.Status.State)
+	}
+	if calls != 3 {
+		t.Errorf("polls = %d, want 3", calls)
+	}
+}
+
+func TestWaitForJobWatchesTheJob(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/evaluations/jobs/job-1/watch" {
+			polls++
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Accept"); got != "text/event-stream" {
+			t.Errorf("Accept = %q, want text/event-stream", got)
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "id: 1\nevent: status\ndata: %s\n\n", mustMarshal(t, jobInState(api.OverallStateRunning)))
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprintf(w, "id: 2\nevent: status\ndata: %s\n\n", mustMarshal(t, jobInState(api.OverallStateCompleted)))
+	}))
+	t.Cleanup(srv.Close)
+
+	got, err := newTestClient(srv).WaitForJob("job-1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForJob: %v", err)
+	}
+	if got.Status.State != api.OverallStateCompleted {
+		t.Errorf("state = %s, want completed", got.Status.State)
+	}
+	if polls != 0 {
+		t.Errorf("polls = %d, want none", polls)
+	}
+}
+
+func TestWaitForJobPollsWhenTheWatchStreamEnds(t *testing.T) {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/watch") {
+			// the stream is cut while the job runs
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprintf(w, "id: 1\nevent: status\ndata: %s\n\n", mustMarshal(t, jobInState(api.OverallStateRunning)))
+			return
+		}
+		polls++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(mustMarshal(t, jobInState(api.OverallStateFailed))) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+
+	got, err := newTestClient(srv).WaitForJob("job-1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("WaitForJob: %v", err)
+	}
+	if got.Status.State != api.OverallStateFailed {
+		t.Errorf("state = %s, want failed", got.Status.State)
+	}
+	if polls != 1 {
+		t.Errorf("polls = %d, want 1", polls)
+	}
+}
+
+func TestWaitForJobContextDeadline(t *testing.T) {
+	srv, _ := newCapturingServer(t, http.StatusOK, mustMarshal(t, jobInState(api.OverallStateRunning)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	got, err := newTestClient(srv).WithContext(ctx).WaitForJob("job-1", 5*time.Millisecond)
+	if err == nil {
+		t.Fatal("expected context error")
+	}
+	if got == nil || got.Status.State != api.OverallStateRunning {
+		t.Errorf("expected last observed running job, got %+v", got)
+	}
+}
+
+func TestWaitForJobAPIError(t *testing.T) {
+	srv, _ := newCapturingServer(t, http.StatusNotFound, mustMarshal(t, api.Error{Message: "not found"}))
+
+	_, err := newTestClient(srv).WaitForJob("missing", time.Millisecond)
+	apiErr, ok := err.(*APIError)
+	if !ok || !apiErr.IsNotFound() {
+		t.Fatalf("expected not found APIError, got %v", err)
+	}
+}
+
 // ─── Content-Type / Accept header conditionals ───────────────────────────────
 
 func TestContentTypeSetWhenBodyPresent(t *testing.T) {
@@ -0,0 +1,20 @@
+// Package evalhubclient is the typed Go client for the eval-hub REST API. Requests and
+// responses use the structs from pkg/api, so Go services do not need to hand-roll HTTP
+// calls against the endpoints.
+//
+//	client := evalhubclient.NewClient("https://evalhub:8080").
+//	    WithToken(token).
+//	    WithTenant("my-namespace")
+//
+//	job, err := client.CreateJob(api.EvaluationJobConfig{...})
+//	if err != nil {
+//	    return err
+//	}
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+//	defer cancel()
+//	job, err = client.WithContext(ctx).WaitForJob(job.Resource.ID, 10*time.Second)
+//
+// WaitForJob follows the job on the watch stream of the API, GET
+// /api/v1/evaluations/jobs/{id}/watch, and falls back to polling GetJob when the server does
+// not stream the job or the stream breaks.
+package evalhubclient
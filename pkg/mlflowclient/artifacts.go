@@ -89,6 +89,54 @@ func (c *Client) UploadArtifact(artifactPath string, content io.Reader, contentT
 	return artifactURL, nil
 }
 
+// DownloadArtifact returns the content of an artifact of the MLflow proxied artifact store,
+// which the caller must close. artifactPath is the full artifact path, as for UploadArtifact.
+func (c *Client) DownloadArtifact(artifactPath string) (io.ReadCloser, error) {
+	if c == nil {
+		return nil, fmt.Errorf("mlflow client is nil")
+	}
+	artifactPath = strings.TrimPrefix(strings.TrimSpace(artifactPath), "/")
+	endpoint, err := buildArtifactUploadEndpoint(artifactPath)
+	if err != nil {
+		return nil, err
+	}
+	if c.ctx == nil {
+		return nil, fmt.Errorf("context is nil for MLFlow request")
+	}
+
+	req, err := http.NewRequestWithContext(c.ctx, http.MethodGet, c.baseURL+endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create download request: %w", err)
+	}
+	c.applyAuthHeader(req)
+	if c.workspacesEnabled && c.workspace != "" {
+		req.Header.Set("X-MLFLOW-WORKSPACE", c.workspace)
+	}
+
+	c.logger.Info("MLFlow artifact download started", "endpoint", endpoint)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer func() { _ = resp.Body.Close() }()
+		respBody, _ := io.ReadAll(resp.Body)
+		mlflowError := MLFlowError{}
+		if err := json.Unmarshal(respBody, &mlflowError); err == nil && mlflowError.ErrorCode != "" {
+			return nil, &APIError{
+				StatusCode:   resp.StatusCode,
+				ResponseBody: string(respBody),
+				MLFlowError:  &mlflowError,
+			}
+		}
+		return nil, &APIError{
+			StatusCode:   resp.StatusCode,
+			ResponseBody: string(respBody),
+		}
+	}
+	return resp.Body, nil
+}
+
 func readerContentLength(r io.Reader) int64 {
 	switch br := r.(type) {
 	case *bytes.Reader:
@@ -50,6 +50,39 @@ func TestUploadArtifact(t *testing.T) {
 	}
 }
 
+func TestDownloadArtifact(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Fatalf("method = %s, want GET", r.Method)
+		}
+		if strings.HasSuffix(r.URL.Path, "/missing.jsonl") {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code":"RESOURCE_DOES_NOT_EXIST","message":"not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte("content"))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(srv.URL).WithContext(t.Context())
+	body, err := client.DownloadArtifact("1/eval-hub/jobs/job-1/benchmarks/0/generations.jsonl")
+	if err != nil {
+		t.Fatalf("DownloadArtifact() err = %v", err)
+	}
+	defer func() { _ = body.Close() }()
+	if content, _ := io.ReadAll(body); string(content) != "content" {
+		t.Fatalf("content = %q", content)
+	}
+
+	_, err = client.DownloadArtifact("1/eval-hub/jobs/job-1/benchmarks/0/missing.jsonl")
+	apiErr, ok := err.(*APIError)
+	if !ok || apiErr.StatusCode != http.StatusNotFound || apiErr.MLFlowError == nil {
+		t.Fatalf("expected a not found API error, got %v", err)
+	}
+}
+
 func TestBuildArtifactUploadEndpoint(t *testing.T) {
 	t.Parallel()
 
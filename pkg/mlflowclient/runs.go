@@ -3,11 +3,13 @@ package mlflowclient
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 )
 
 const (
 	endpointRunsCreate = apiBasePath + "/runs/create"
+	endpointRunsGet    = apiBasePath + "/runs/get"
 )
 
 // RunTag is a key-value tag on an MLflow run.
@@ -23,9 +25,24 @@ type RunInfo struct {
 	RunName      string `json:"run_name,omitempty"`
 }
 
+// RunMetric is the latest value of a metric logged to an MLflow run.
+type RunMetric struct {
+	Key       string  `json:"key"`
+	Value     float64 `json:"value"`
+	Timestamp int64   `json:"timestamp,omitempty"`
+	Step      int64   `json:"step,omitempty"`
+}
+
+// RunData contains the metrics and tags of a run returned by the MLflow API.
+type RunData struct {
+	Metrics []RunMetric `json:"metrics,omitempty"`
+	Tags    []RunTag    `json:"tags,omitempty"`
+}
+
 // Run is an MLflow run returned by the REST API.
 type Run struct {
 	Info RunInfo `json:"info"`
+	Data RunData `json:"data"`
 }
 
 // CreateRunRequest is the request body for runs/create.
@@ -41,6 +58,16 @@ type CreateRunResponse struct {
 	Run Run `json:"run"`
 }
 
+// GetRunRequest is the request body for runs/get.
+type GetRunRequest struct {
+	RunID string `json:"run_id"`
+}
+
+// GetRunResponse is the response body from runs/get.
+type GetRunResponse struct {
+	Run Run `json:"run"`
+}
+
 // CreateRun creates a new run in an experiment.
 func (c *Client) CreateRun(req *CreateRunRequest) (*CreateRunResponse, error) {
 	if req == nil {
@@ -55,3 +82,25 @@ func (c *Client) CreateRun(req *CreateRunRequest) (*CreateRunResponse, error) {
 	}
 	return unmarshalResponse[CreateRunResponse](respBody)
 }
+
+// GetRun gets a run, with its metrics, by ID.
+func (c *Client) GetRun(runID string) (*GetRunResponse, error) {
+	req := GetRunRequest{
+		RunID: runID,
+	}
+	respBody, err := c.doRequest(http.MethodGet, endpointRunsGet, req)
+	if err != nil {
+		return nil, err
+	}
+	return unmarshalResponse[GetRunResponse](respBody)
+}
+
+// GetRunURL returns the URL of a run in the MLflow UI, in the workspace of the client when
+// the server supports workspaces.
+func (c *Client) GetRunURL(experimentID string, runID string) string {
+	runURL := c.baseURL + "/#/experiments/" + url.PathEscape(experimentID) + "/runs/" + url.PathEscape(runID)
+	if c.workspace != "" {
+		runURL += "?workspace=" + url.QueryEscape(c.workspace)
+	}
+	return runURL
+}
@@ -51,3 +51,56 @@ func TestCreateRunNilRequest(t *testing.T) {
 		t.Fatal("expected error for nil request")
 	}
 }
+
+func TestGetRun(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || !strings.HasSuffix(r.URL.Path, "/runs/get") {
+			http.NotFound(w, r)
+			return
+		}
+		var req GetRunRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if req.RunID != "run-123" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error_code": "RESOURCE_DOES_NOT_EXIST", "message": "Run not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"run": {"info": {"run_id": "run-123", "experiment_id": "exp-1"}, "data": {"metrics": [{"key": "accuracy", "value": 0.81, "step": 2}]}}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(srv.URL).WithContext(t.Context())
+	resp, err := client.GetRun("run-123")
+	if err != nil {
+		t.Fatalf("GetRun() err = %v", err)
+	}
+	if resp.Run.Info.ExperimentID != "exp-1" || len(resp.Run.Data.Metrics) != 1 || resp.Run.Data.Metrics[0].Value != 0.81 {
+		t.Fatalf("unexpected run %+v", resp.Run)
+	}
+
+	if _, err := client.GetRun("missing"); !IsResourceDoesNotExistError(err) {
+		t.Fatalf("expected a resource does not exist error, got %v", err)
+	}
+}
+
+func TestGetRunURL(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient("https://mlflow.example.com/")
+	if got, want := client.GetRunURL("exp-1", "run-123"), "https://mlflow.example.com/#/experiments/exp-1/runs/run-123"; got != want {
+		t.Fatalf("GetRunURL() = %q, want %q", got, want)
+	}
+
+	// the workspace is ignored unless the server supports workspaces
+	if got, want := client.WithWorkspace("team a").GetRunURL("exp-1", "run-123"), "https://mlflow.example.com/#/experiments/exp-1/runs/run-123"; got != want {
+		t.Fatalf("GetRunURL() = %q, want %q", got, want)
+	}
+	workspaced := client.WithWorkspacesSupport(true).WithWorkspace("team a")
+	if got, want := workspaced.GetRunURL("exp-1", "run-123"), "https://mlflow.example.com/#/experiments/exp-1/runs/run-123?workspace=team+a"; got != want {
+		t.Fatalf("GetRunURL() = %q, want %q", got, want)
+	}
+}
@@ -22,6 +22,9 @@ type authenticator struct {
 	repository string
 	httpClient *http.Client
 	token      string
+	// actions are the repository actions of the token scope, pull,push unless the client
+	// only reads from the registry.
+	actions string
 }
 
 // newAuthenticator builds registry auth state for a single repository using dockerconfigjson
@@ -34,6 +37,7 @@ func newAuthenticator(registry, repository string, creds Credentials, httpClient
 		registry:   NormalizeRegistryHost(registry),
 		repository: strings.TrimSpace(repository),
 		httpClient: httpClient,
+		actions:    "pull,push",
 	}
 }
 
@@ -98,7 +102,7 @@ func (a *authenticator) initiateChallenge(ctx context.Context) (string, error) {
 		if strings.Contains(nextURL, "?") {
 			sep = "&"
 		}
-		nextURL += sep + "scope=repository:" + repository + ":pull,push"
+		nextURL += sep + "scope=repository:" + repository + ":" + a.actions
 	}
 	return nextURL, nil
 }
@@ -113,7 +117,10 @@ func (a *authenticator) createNewToken(ctx context.Context, nextURL string) erro
 	if err != nil {
 		return err
 	}
-	req.SetBasicAuth(a.username, a.password)
+	// public repositories issue anonymous pull tokens
+	if a.username != "" {
+		req.SetBasicAuth(a.username, a.password)
+	}
 
 	resp, err := a.httpClient.Do(req)
 	if err != nil {
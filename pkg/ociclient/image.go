@@ -0,0 +1,292 @@
+package ociclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// MediaTypeImageIndex is the OCI image index media type of multi-platform images.
+	MediaTypeImageIndex = "application/vnd.oci.image.index.v1+json"
+	// MediaTypeDockerManifest and MediaTypeDockerManifestList are the Docker schema 2 media
+	// types that registries still serve for images built with docker.
+	MediaTypeDockerManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeDockerManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+
+	// AnnotationCosignSignature is the annotation of the layers of a cosign signature manifest
+	// that holds the base64 signature of the layer payload.
+	AnnotationCosignSignature = "dev.cosignproject.cosign/signature"
+
+	dockerHubRegistry    = "docker.io"
+	dockerHubAPIRegistry = "registry-1.docker.io"
+	maxManifestSize      = 4 * 1024 * 1024
+	maxSignaturePayload  = 1024 * 1024
+)
+
+// ErrNoSignature is returned by VerifySignature when the registry holds no cosign signature
+// of the image.
+var ErrNoSignature = errors.New("no signature found")
+
+// ImageReference is a container image reference split into its registry, repository and tag
+// or digest.
+type ImageReference struct {
+	// Name is the reference without its tag or digest, as written, e.g. quay.io/org/adapter.
+	Name       string
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseImageReference parses an image reference the way the container runtime does: the first
+// path component is the registry when it looks like a host, Docker Hub otherwise, and the tag
+// defaults to latest.
+func ParseImageReference(image string) (ImageReference, error) {
+	image = strings.TrimSpace(image)
+	if image == "" {
+		return ImageReference{}, fmt.Errorf("image reference is empty")
+	}
+	ref := ImageReference{}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+		if !strings.HasPrefix(ref.Digest, "sha256:") {
+			return ImageReference{}, fmt.Errorf("image reference %q: unsupported digest", image)
+		}
+	}
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	}
+	if name == "" {
+		return ImageReference{}, fmt.Errorf("image reference %q: missing repository", image)
+	}
+	ref.Name = name
+	registry, repository, found := strings.Cut(name, "/")
+	if !found || !(strings.ContainsAny(registry, ".:") || registry == "localhost") {
+		registry, repository = dockerHubRegistry, name
+	}
+	if registry == dockerHubRegistry && !strings.Contains(repository, "/") {
+		repository = "library/" + repository
+	}
+	ref.Registry = registry
+	ref.Repository = repository
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+	return ref, nil
+}
+
+// APIRegistry returns the host that serves the Distribution API of the registry of the image.
+func (r ImageReference) APIRegistry() string {
+	if r.Registry == dockerHubRegistry {
+		return dockerHubAPIRegistry
+	}
+	return r.Registry
+}
+
+// Pinned returns the reference of the image by digest.
+func (r ImageReference) Pinned(digest string) string {
+	return r.Name + "@" + digest
+}
+
+// NewPullClient creates a client that only reads from the registry, which requests tokens
+// scoped to pull so that read-only credentials and anonymous access work.
+func NewPullClient(registryHost, repository string, creds Credentials, httpClient *http.Client) (*Client, error) {
+	client, err := NewClient(registryHost, repository, creds, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	client.auth.actions = "pull"
+	return client, nil
+}
+
+// ResolveDigest returns the digest of the manifest the tag points at. The digest of an image
+// index is returned for multi-platform images, so that every node pulls its own platform.
+func (c *Client) ResolveDigest(ctx context.Context, tag string) (string, error) {
+	resp, err := c.getManifest(ctx, http.MethodHead, tag)
+	if err != nil {
+		return "", err
+	}
+	_ = resp.Body.Close()
+	if digest := resp.Header.Get("Docker-Content-Digest"); strings.HasPrefix(digest, "sha256:") {
+		return digest, nil
+	}
+	// not every registry sets the digest header on HEAD
+	body, err := c.readManifest(ctx, tag)
+	if err != nil {
+		return "", err
+	}
+	return blobDigest(body), nil
+}
+
+// cosignPayload is the simple signing payload that cosign signs.
+type cosignPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// VerifySignature checks that the image with the digest carries a cosign signature made by
+// one of the keys. Signatures are read from the sha256-<hex>.sig tag of the repository,
+// where cosign stores them, and a signature only counts when its payload names the digest.
+func (c *Client) VerifySignature(ctx context.Context, digest string, keys []crypto.PublicKey) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("no public keys to verify the signature against")
+	}
+	body, err := c.readManifest(ctx, strings.Replace(digest, ":", "-", 1)+".sig")
+	if err != nil {
+		var statusErr *manifestStatusError
+		if errors.As(err, &statusErr) && statusErr.status == http.StatusNotFound {
+			return ErrNoSignature
+		}
+		return fmt.Errorf("read signature manifest: %w", err)
+	}
+	var signatures manifest
+	if err := json.Unmarshal(body, &signatures); err != nil {
+		return fmt.Errorf("parse signature manifest: %w", err)
+	}
+	found := false
+	for _, layer := range signatures.Layers {
+		encoded, ok := layer.Annotations[AnnotationCosignSignature]
+		if !ok {
+			continue
+		}
+		found = true
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		payload, err := c.readBlob(ctx, layer.Digest)
+		if err != nil {
+			return fmt.Errorf("read signature payload: %w", err)
+		}
+		var signed cosignPayload
+		if err := json.Unmarshal(payload, &signed); err != nil || signed.Critical.Image.DockerManifestDigest != digest {
+			continue
+		}
+		for _, key := range keys {
+			if verifySignature(key, payload, signature) {
+				return nil
+			}
+		}
+	}
+	if !found {
+		return ErrNoSignature
+	}
+	return fmt.Errorf("no signature of %s matches the public keys", digest)
+}
+
+// ParsePublicKey parses a PEM encoded PKIX public key, as written by cosign generate-key-pair.
+func ParsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+	switch key.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// verifySignature verifies a signature of the payload made with the SHA-256 digest of the
+// payload, except for ed25519 keys which sign the payload itself.
+func verifySignature(key crypto.PublicKey, payload, signature []byte) bool {
+	hash := sha256.Sum256(payload)
+	switch typed := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(typed, hash[:], signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(typed, crypto.SHA256, hash[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(typed, payload, signature)
+	default:
+		return false
+	}
+}
+
+// manifestStatusError is returned when the registry answers a manifest request with an
+// unexpected status.
+type manifestStatusError struct {
+	reference string
+	status    int
+}
+
+func (e *manifestStatusError) Error() string {
+	return fmt.Sprintf("manifest %s: registry returned status %d", e.reference, e.status)
+}
+
+// getManifest requests the manifest of the reference, accepting image manifests and indexes.
+func (c *Client) getManifest(ctx context.Context, method, reference string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.registryURL("/v2/"+c.repository+"/manifests/"+reference), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join([]string{
+		MediaTypeImageManifest, MediaTypeImageIndex, MediaTypeDockerManifest, MediaTypeDockerManifestList,
+	}, ", "))
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, &manifestStatusError{reference: reference, status: resp.StatusCode}
+	}
+	return resp, nil
+}
+
+func (c *Client) readManifest(ctx context.Context, reference string) ([]byte, error) {
+	resp, err := c.getManifest(ctx, http.MethodGet, reference)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	return io.ReadAll(io.LimitReader(resp.Body, maxManifestSize))
+}
+
+// readBlob downloads a small blob and checks it against its digest.
+func (c *Client) readBlob(ctx context.Context, digest string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.registryURL("/v2/"+c.repository+"/blobs/"+digest), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("blob %s: registry returned status %d", digest, resp.StatusCode)
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxSignaturePayload))
+	if err != nil {
+		return nil, err
+	}
+	if blobDigest(content) != digest {
+		return nil, fmt.Errorf("blob %s: digest mismatch", digest)
+	}
+	return content, nil
+}
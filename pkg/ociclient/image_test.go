@@ -0,0 +1,156 @@
+package ociclient
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseImageReference(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		image string
+		want  ImageReference
+	}{
+		{"quay.io/org/adapter:1.2", ImageReference{Name: "quay.io/org/adapter", Registry: "quay.io", Repository: "org/adapter", Tag: "1.2"}},
+		{"localhost:5000/adapter", ImageReference{Name: "localhost:5000/adapter", Registry: "localhost:5000", Repository: "adapter", Tag: "latest"}},
+		{"python:3.12", ImageReference{Name: "python", Registry: "docker.io", Repository: "library/python", Tag: "3.12"}},
+		{"org/adapter", ImageReference{Name: "org/adapter", Registry: "docker.io", Repository: "org/adapter", Tag: "latest"}},
+		{"quay.io/org/adapter:1.2@sha256:abc", ImageReference{Name: "quay.io/org/adapter", Registry: "quay.io", Repository: "org/adapter", Tag: "1.2", Digest: "sha256:abc"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseImageReference(tt.image)
+		if err != nil {
+			t.Fatalf("ParseImageReference(%q): %v", tt.image, err)
+		}
+		if got != tt.want {
+			t.Errorf("ParseImageReference(%q) = %+v, want %+v", tt.image, got, tt.want)
+		}
+	}
+	if ref, _ := ParseImageReference("python"); ref.APIRegistry() != "registry-1.docker.io" || ref.Pinned("sha256:abc") != "python@sha256:abc" {
+		t.Errorf("unexpected registry %q or pinned reference %q", ref.APIRegistry(), ref.Pinned("sha256:abc"))
+	}
+	for _, image := range []string{"", "quay.io/org/adapter@md5:abc", ":1.2"} {
+		if _, err := ParseImageReference(image); err == nil {
+			t.Errorf("expected an error for %q", image)
+		}
+	}
+}
+
+// signedRegistry serves an image manifest under the tag 1.0 and, when key is set, a cosign
+// signature of it made with key.
+func signedRegistry(t *testing.T, key *ecdsa.PrivateKey) (*httptest.Server, string) {
+	t.Helper()
+	imageManifest := []byte(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json"}`)
+	digest := blobDigest(imageManifest)
+
+	var payload, signatureManifest []byte
+	if key != nil {
+		signed := cosignPayload{}
+		signed.Critical.Image.DockerManifestDigest = digest
+		payload, _ = json.Marshal(signed)
+		hash := sha256.Sum256(payload)
+		signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		signatureManifest, _ = json.Marshal(manifest{
+			SchemaVersion: 2,
+			MediaType:     MediaTypeImageManifest,
+			Layers: []descriptor{{
+				MediaType:   "application/vnd.dev.cosign.simplesigning.v1+json",
+				Digest:      blobDigest(payload),
+				Size:        int64(len(payload)),
+				Annotations: map[string]string{AnnotationCosignSignature: base64.StdEncoding.EncodeToString(signature)},
+			}},
+		})
+	}
+	signatureTag := "sha256-" + digest[len("sha256:"):] + ".sig"
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/org/adapter/manifests/1.0":
+			w.Header().Set("Docker-Content-Digest", digest)
+			_, _ = w.Write(imageManifest)
+		case "/v2/org/adapter/manifests/" + signatureTag:
+			if signatureManifest == nil {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(signatureManifest)
+		case "/v2/org/adapter/blobs/" + blobDigest(payload):
+			_, _ = w.Write(payload)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, digest
+}
+
+func TestResolveDigestAndVerifySignature(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	srv, digest := signedRegistry(t, key)
+	client, err := NewPullClient(srv.URL, "org/adapter", Credentials{}, srv.Client())
+	if err != nil {
+		t.Fatalf("NewPullClient: %v", err)
+	}
+	resolved, err := client.ResolveDigest(context.Background(), "1.0")
+	if err != nil || resolved != digest {
+		t.Fatalf("ResolveDigest = %q, %v, want %q", resolved, err, digest)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	publicKey, err := ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("ParsePublicKey: %v", err)
+	}
+	if err := client.VerifySignature(context.Background(), digest, []crypto.PublicKey{publicKey}); err != nil {
+		t.Fatalf("VerifySignature: %v", err)
+	}
+
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err := client.VerifySignature(context.Background(), digest, []crypto.PublicKey{&other.PublicKey}); err == nil {
+		t.Fatal("expected the signature not to match another key")
+	}
+	if err := client.VerifySignature(context.Background(), "sha256:0000", []crypto.PublicKey{publicKey}); !errors.Is(err, ErrNoSignature) {
+		t.Fatalf("expected ErrNoSignature for an unsigned digest, got %v", err)
+	}
+}
+
+func TestVerifySignatureOfUnsignedImage(t *testing.T) {
+	t.Parallel()
+
+	srv, digest := signedRegistry(t, nil)
+	client, err := NewPullClient(srv.URL, "org/adapter", Credentials{}, srv.Client())
+	if err != nil {
+		t.Fatalf("NewPullClient: %v", err)
+	}
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err := client.VerifySignature(context.Background(), digest, []crypto.PublicKey{&key.PublicKey}); !errors.Is(err, ErrNoSignature) {
+		t.Fatalf("expected ErrNoSignature, got %v", err)
+	}
+	if _, err := ParsePublicKey([]byte("not a key")); err == nil {
+		t.Fatal("expected an error for a value that is not PEM")
+	}
+}
@@ -23,7 +23,8 @@ fi
 
 # This assumes that the service has already been built
 # Always run in local mode (CORS enabled)
-${EXE} --local > "${LOGFILE}" 2>&1 &
+# Use a fresh in-memory database rather than the on-disk local mode default
+DB_URL="${DB_URL:-file::eval_hub:?mode=memory&cache=shared}" ${EXE} --local > "${LOGFILE}" 2>&1 &
 
 SERVICE_PID=$!
 
@@ -384,7 +384,7 @@ func (a *apiFeature) startLocalServer(port int) error {
 	}
 	logger.Info("Storage created.")
 
-	runtime, err := runtimes.NewRuntime(logger, serviceConfig)
+	runtime, err := runtimes.NewRuntime(logger, serviceConfig, storage)
 	if err != nil {
 		return logError(fmt.Errorf("failed to create runtime: %w", err))
 	}